@@ -9,8 +9,6 @@ import (
 	"time"
 
 	"github.com/rebelopsio/gohan/internal/container"
-	httpinfra "github.com/rebelopsio/gohan/internal/infrastructure/http"
-	"github.com/rebelopsio/gohan/internal/infrastructure/http/handlers"
 )
 
 func main() {
@@ -24,25 +22,11 @@ func main() {
 	log.Println("Starting Gohan Installation Server...")
 	log.Printf("Configuration: Host=%s Port=%d", c.Config.API.Host, c.Config.API.Port)
 
-	// Create handlers using pre-wired use cases from container
-	installationHandler := handlers.NewInstallationHandler(
-		c.StartInstallationUseCase,
-		c.ExecuteInstallationUseCase,
-		c.GetStatusUseCase,
-		c.ListInstallationsUseCase,
-		c.CancelInstallationUseCase,
-	)
-
-	// Create HTTP server
-	serverConfig := httpinfra.Config{
-		Host:         c.Config.API.Host,
-		Port:         c.Config.API.Port,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+	server, err := c.APIServer()
+	if err != nil {
+		log.Fatalf("Invalid API server settings: %v", err)
 	}
 
-	server := httpinfra.NewServer(serverConfig, installationHandler, false)
-
 	// Start server in a goroutine
 	serverErrors := make(chan error, 1)
 	go func() {
@@ -108,7 +108,7 @@ Feature: Complete Hyprland Installation
 
   Scenario: Installation health check
     Given installation completed successfully
-    When I run "gohan health-check"
+    When I run "gohan doctor"
     Then all installed packages should be verified as healthy
     And all configuration files should exist
     And Hyprland should be launchable
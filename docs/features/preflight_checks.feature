@@ -52,6 +52,23 @@ Feature: Pre-Installation Environment Validation
     And I should see how much space is currently available
     And I should be advised on how to free up space
 
+  Scenario: Disk space is checked on each partition the installation writes to
+    Given I am running a supported Debian version
+    And /var is a separate partition with less free space than packages need
+    And my root partition has plenty of free space
+    When I run the preflight checks
+    Then the disk space check should fail for /var
+    And I should see the free and required space for each mount point
+
+  Scenario: Installation is blocked on a read-only root filesystem
+    Given I am running a supported Debian version
+    And my root filesystem is mounted read-only
+    When I start the installation
+    Then the installation should be blocked before any changes are made
+    And I should see which package directories are read-only
+    And I should be offered to remount them read-write
+    And I should be told how to deploy only my configurations instead
+
   Scenario: Installation is blocked without internet access
     Given I am running a supported Debian version
     And I do not have internet connectivity
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"github.com/rebelopsio/gohan/internal/domain/backup"
+	backupInfra "github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 )
 
 // CreateBackupRequest contains parameters for creating a backup
@@ -24,6 +25,19 @@ type CreateBackupResponse struct {
 	BackupPath  string // Full path to the backup directory
 }
 
+// DefaultPaths returns the configuration directories backed up when no
+// paths are given
+func DefaultPaths(homeDir string) []string {
+	configDir := filepath.Join(homeDir, ".config")
+	return []string{
+		filepath.Join(configDir, "hypr"),
+		filepath.Join(configDir, "waybar"),
+		filepath.Join(configDir, "kitty"),
+		filepath.Join(configDir, "rofi"),
+		filepath.Join(configDir, "mako"),
+	}
+}
+
 // CreateBackupUseCase handles creating configuration backups
 type CreateBackupUseCase struct {
 	repository backup.Repository
@@ -60,8 +74,8 @@ func (uc *CreateBackupUseCase) Execute(ctx context.Context, req CreateBackupRequ
 
 	// Back up each file
 	for _, filePath := range req.FilePaths {
-		// Skip if file doesn't exist
-		if _, err := os.Stat(filePath); err != nil {
+		// Skip if file doesn't exist; a dangling symlink still counts
+		if _, err := os.Lstat(filePath); err != nil {
 			continue // Skip missing files
 		}
 
@@ -101,7 +115,8 @@ func (uc *CreateBackupUseCase) validateRequest(req CreateBackupRequest) error {
 }
 
 func (uc *CreateBackupUseCase) backupPath(ctx context.Context, b *backup.Backup, sourcePath, backupDir string) error {
-	info, err := os.Stat(sourcePath)
+	// A symlinked directory is backed up as the link itself
+	info, err := os.Lstat(sourcePath)
 	if err != nil {
 		return err
 	}
@@ -114,28 +129,16 @@ func (uc *CreateBackupUseCase) backupPath(ctx context.Context, b *backup.Backup,
 }
 
 func (uc *CreateBackupUseCase) backupFile(ctx context.Context, b *backup.Backup, sourcePath, backupDir string) error {
-	info, err := os.Stat(sourcePath)
-	if err != nil {
-		return err
-	}
-
 	// Determine backup file path
 	fileName := filepath.Base(sourcePath)
 	backupFilePath := filepath.Join(backupDir, fileName)
 
-	// Copy file
-	if err := copyFile(sourcePath, backupFilePath); err != nil {
+	// Copy file, keeping symlinks, mtime and extended attributes
+	backupFile, err := backupInfra.CaptureBackupFile(sourcePath, backupFilePath)
+	if err != nil {
 		return err
 	}
 
-	// Add to backup
-	backupFile := backup.BackupFile{
-		OriginalPath: sourcePath,
-		BackupPath:   backupFilePath,
-		Permissions:  info.Mode().Perm(),
-		SizeBytes:    info.Size(),
-	}
-
 	return b.AddFile(backupFile)
 }
 
@@ -160,39 +163,14 @@ func (uc *CreateBackupUseCase) backupDirectory(ctx context.Context, b *backup.Ba
 		// Determine backup path
 		backupFilePath := filepath.Join(backupDir, filepath.Base(sourcePath), relPath)
 
-		// Ensure directory exists
-		if err := os.MkdirAll(filepath.Dir(backupFilePath), 0755); err != nil {
-			return nil
-		}
-
-		// Copy file
-		if err := copyFile(path, backupFilePath); err != nil {
+		// Copy file; Walk does not follow symlinks, so links (dangling
+		// ones included) are stored as links
+		backupFile, err := backupInfra.CaptureBackupFile(path, backupFilePath)
+		if err != nil {
 			return nil
 		}
 
-		// Add to backup
-		backupFile := backup.BackupFile{
-			OriginalPath: path,
-			BackupPath:   backupFilePath,
-			Permissions:  info.Mode().Perm(),
-			SizeBytes:    info.Size(),
-		}
-
 		b.AddFile(backupFile)
 		return nil
 	})
 }
-
-func copyFile(src, dst string) error {
-	input, err := os.ReadFile(src)
-	if err != nil {
-		return err
-	}
-
-	info, err := os.Stat(src)
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(dst, input, info.Mode().Perm())
-}
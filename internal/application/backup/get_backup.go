@@ -0,0 +1,65 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/domain/backup"
+)
+
+// BackupFileSummary describes one file stored in a backup
+type BackupFileSummary struct {
+	Path       string // Where the file is restored to
+	SizeBytes  int64
+	LinkTarget string // Symlink target; empty for regular files
+}
+
+// BackupDetail contains a backup's metadata and the files it holds
+type BackupDetail struct {
+	BackupSummary
+	SizeBytes int64 // Total size in bytes
+	Files     []BackupFileSummary
+}
+
+// GetBackupUseCase handles looking up a single backup
+type GetBackupUseCase struct {
+	repository backup.Repository
+}
+
+// NewGetBackupUseCase creates a new use case instance
+func NewGetBackupUseCase(repository backup.Repository) *GetBackupUseCase {
+	return &GetBackupUseCase{
+		repository: repository,
+	}
+}
+
+// Execute returns the metadata and file list of the backup with the given ID
+func (uc *GetBackupUseCase) Execute(ctx context.Context, backupID string) (*BackupDetail, error) {
+	b, err := uc.repository.FindByID(ctx, backupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find backup: %w", err)
+	}
+
+	files := make([]BackupFileSummary, 0, b.FileCount())
+	for _, file := range b.Files() {
+		files = append(files, BackupFileSummary{
+			Path:       file.OriginalPath,
+			SizeBytes:  file.SizeBytes,
+			LinkTarget: file.LinkTarget,
+		})
+	}
+
+	return &BackupDetail{
+		BackupSummary: BackupSummary{
+			ID:          b.ID(),
+			Description: b.Description(),
+			CreatedAt:   b.CreatedAt().Format("2006-01-02 15:04:05"),
+			Age:         formatDuration(b.Age()),
+			FileCount:   b.FileCount(),
+			TotalSize:   formatBytes(b.TotalSize()),
+			Status:      string(b.Status()),
+		},
+		SizeBytes: b.TotalSize(),
+		Files:     files,
+	}, nil
+}
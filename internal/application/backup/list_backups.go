@@ -19,10 +19,18 @@ type BackupSummary struct {
 	Status      string // Backup status
 }
 
-// ListBackupsResponse contains the list of backups
+// ListBackupsRequest contains pagination parameters for listing backups
+type ListBackupsRequest struct {
+	Offset int // Number of newest backups to skip
+	Limit  int // Maximum backups to return (0 = all)
+}
+
+// ListBackupsResponse contains one page of backups
 type ListBackupsResponse struct {
 	Backups []*BackupSummary
-	Total   int
+	Total   int // Number of backups in the store, across all pages
+	Offset  int
+	HasMore bool // More backups follow this page
 }
 
 // ListBackupsUseCase handles listing available backups
@@ -37,31 +45,40 @@ func NewListBackupsUseCase(repository backup.Repository) *ListBackupsUseCase {
 	}
 }
 
-// Execute retrieves all backups
-func (uc *ListBackupsUseCase) Execute(ctx context.Context) (*ListBackupsResponse, error) {
-	// Get all backups
-	backups, err := uc.repository.FindAll(ctx)
+// Execute retrieves one page of backups without loading their file lists
+func (uc *ListBackupsUseCase) Execute(ctx context.Context, req ListBackupsRequest) (*ListBackupsResponse, error) {
+	if req.Offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative")
+	}
+
+	backups, total, err := uc.repository.FindSummaries(ctx, req.Offset, req.Limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list backups: %w", err)
 	}
 
-	// Convert to summaries
+	// Convert to summaries; listed backups were loaded from disk, so they
+	// are complete
 	summaries := make([]*BackupSummary, 0, len(backups))
 	for _, b := range backups {
 		summaries = append(summaries, &BackupSummary{
-			ID:          b.ID(),
-			Description: b.Description(),
-			CreatedAt:   b.CreatedAt().Format("2006-01-02 15:04:05"),
+			ID:          b.ID,
+			Description: b.Description,
+			CreatedAt:   b.CreatedAt.Format("2006-01-02 15:04:05"),
 			Age:         formatDuration(b.Age()),
-			FileCount:   b.FileCount(),
-			TotalSize:   formatBytes(b.TotalSize()),
-			Status:      string(b.Status()),
+			FileCount:   b.FileCount,
+			TotalSize:   formatBytes(b.SizeBytes),
+			Status:      string(backup.StatusComplete),
 		})
 	}
 
 	return &ListBackupsResponse{
 		Backups: summaries,
-		Total:   len(summaries),
+		Total:   total,
+		Offset:  req.Offset,
+		HasMore: req.Offset+len(summaries) < total,
 	}, nil
 }
 
@@ -3,10 +3,9 @@ package backup
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/rebelopsio/gohan/internal/domain/backup"
+	backupInfra "github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 )
 
 // RestoreBackupRequest contains parameters for restoring a backup
@@ -78,24 +77,9 @@ func (uc *RestoreBackupUseCase) Execute(ctx context.Context, req RestoreBackupRe
 }
 
 func (uc *RestoreBackupUseCase) restoreFile(file backup.BackupFile) error {
-	// Read backup file
-	content, err := os.ReadFile(file.BackupPath)
-	if err != nil {
-		return fmt.Errorf("failed to read backup file: %w", err)
-	}
-
-	// Ensure target directory exists
-	targetDir := filepath.Dir(file.OriginalPath)
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return fmt.Errorf("failed to create target directory: %w", err)
-	}
-
-	// Write to original location
-	if err := os.WriteFile(file.OriginalPath, content, file.Permissions); err != nil {
-		return fmt.Errorf("failed to restore file: %w", err)
-	}
-
-	return nil
+	// Symlinks are recreated as links; regular files get their mode, mtime
+	// and extended attributes back
+	return backupInfra.RestoreBackupFile(file)
 }
 
 func contains(slice []string, item string) bool {
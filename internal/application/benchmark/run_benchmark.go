@@ -0,0 +1,201 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+)
+
+// Default sampling parameters
+const (
+	DefaultDuration       = 10 * time.Second
+	DefaultLatencySamples = 50
+)
+
+// RunBenchmarkRequest contains parameters for a benchmark run
+type RunBenchmarkRequest struct {
+	Duration       time.Duration // How long to sample frame timing and GPU load
+	LatencySamples int           // Number of synthetic input events
+	SetBaseline    bool          // Save this run as the new baseline
+}
+
+// RunBenchmarkResponse contains benchmark results
+type RunBenchmarkResponse struct {
+	ReportID      string
+	ReportPath    string
+	Environment   EnvironmentDTO
+	Metrics       []MetricDTO
+	Notes         []string
+	HasBaseline   bool
+	BaselineSaved bool
+	BaselineDate  time.Time
+	Comparisons   []ComparisonDTO
+	Regressions   int
+	DurationMs    int64
+}
+
+// EnvironmentDTO describes the benchmarked system
+type EnvironmentDTO struct {
+	HyprlandVersion string
+	KernelVersion   string
+	GPU             string
+	GPUDriver       string
+	Monitor         string
+}
+
+// MetricDTO represents a measured metric
+type MetricDTO struct {
+	Name  string
+	Value float64
+	Unit  string
+}
+
+// ComparisonDTO represents a metric compared against the baseline
+type ComparisonDTO struct {
+	Name          string
+	Unit          string
+	Baseline      float64
+	Current       float64
+	PercentChange float64
+	Improved      bool
+	Regressed     bool
+}
+
+// Samplers aggregates the benchmark measurement sources
+type Samplers struct {
+	FrameTiming  benchmark.FrameTimingSampler
+	InputLatency benchmark.InputLatencyProbe
+	GPU          benchmark.GPUSampler
+	Environment  benchmark.EnvironmentDetector
+}
+
+// RunBenchmarkUseCase measures compositor performance and compares it
+// against the stored baseline
+type RunBenchmarkUseCase struct {
+	samplers Samplers
+	store    benchmark.ReportStore
+}
+
+// NewRunBenchmarkUseCase creates a new use case instance
+func NewRunBenchmarkUseCase(samplers Samplers, store benchmark.ReportStore) *RunBenchmarkUseCase {
+	return &RunBenchmarkUseCase{
+		samplers: samplers,
+		store:    store,
+	}
+}
+
+// Execute runs the benchmark
+// The first run, or a run with SetBaseline, becomes the baseline later runs
+// are compared against
+func (uc *RunBenchmarkUseCase) Execute(ctx context.Context, req RunBenchmarkRequest) (*RunBenchmarkResponse, error) {
+	start := time.Now()
+
+	if !uc.samplers.Environment.InHyprlandSession() {
+		return nil, benchmark.ErrNotInHyprlandSession
+	}
+
+	if req.Duration <= 0 {
+		req.Duration = DefaultDuration
+	}
+	if req.LatencySamples <= 0 {
+		req.LatencySamples = DefaultLatencySamples
+	}
+
+	var notes []string
+	environment, err := uc.samplers.Environment.DetectEnvironment(ctx)
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("environment detection incomplete: %v", err))
+	}
+
+	// A missing measurement is noted rather than failing the whole run
+	var metrics []benchmark.Metric
+	if uc.samplers.FrameTiming != nil {
+		m, err := uc.samplers.FrameTiming.SampleFrameTiming(ctx, req.Duration)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("frame timing unavailable: %v", err))
+		}
+		metrics = append(metrics, m...)
+	}
+	if uc.samplers.InputLatency != nil {
+		m, err := uc.samplers.InputLatency.MeasureInputLatency(ctx, req.LatencySamples)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("input latency unavailable: %v", err))
+		}
+		metrics = append(metrics, m...)
+	}
+	if uc.samplers.GPU != nil {
+		m, err := uc.samplers.GPU.SampleGPUUtilization(ctx, req.Duration)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("GPU utilization unavailable: %v", err))
+		}
+		metrics = append(metrics, m...)
+	}
+
+	report, err := benchmark.NewReport(environment, metrics, notes)
+	if err != nil {
+		return nil, fmt.Errorf("benchmark produced no measurements: %w (%v)", err, notes)
+	}
+
+	path, err := uc.store.Save(ctx, report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save benchmark report: %w", err)
+	}
+
+	response := &RunBenchmarkResponse{
+		ReportID:   report.ID(),
+		ReportPath: path,
+		Environment: EnvironmentDTO{
+			HyprlandVersion: environment.HyprlandVersion,
+			KernelVersion:   environment.KernelVersion,
+			GPU:             environment.GPU,
+			GPUDriver:       environment.GPUDriver,
+			Monitor:         environment.Monitor,
+		},
+		Metrics:     make([]MetricDTO, 0, len(metrics)),
+		Notes:       report.Notes(),
+		Comparisons: []ComparisonDTO{},
+	}
+	for _, m := range report.Metrics() {
+		response.Metrics = append(response.Metrics, MetricDTO{Name: m.Name().String(), Value: m.Value(), Unit: m.Unit()})
+	}
+
+	baseline, err := uc.store.LoadBaseline(ctx)
+	switch {
+	case errors.Is(err, benchmark.ErrBaselineNotFound):
+		baseline = nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to load baseline: %w", err)
+	}
+
+	if baseline != nil && !req.SetBaseline {
+		response.HasBaseline = true
+		response.BaselineDate = baseline.CreatedAt()
+		for _, c := range report.Compare(baseline) {
+			response.Comparisons = append(response.Comparisons, ComparisonDTO{
+				Name:          c.Name.String(),
+				Unit:          c.Unit,
+				Baseline:      c.Baseline,
+				Current:       c.Current,
+				PercentChange: c.PercentChange,
+				Improved:      c.Improved(),
+				Regressed:     c.Regressed,
+			})
+			if c.Regressed {
+				response.Regressions++
+			}
+		}
+	}
+
+	if baseline == nil || req.SetBaseline {
+		if err := uc.store.SaveBaseline(ctx, report); err != nil {
+			return nil, fmt.Errorf("failed to save baseline: %w", err)
+		}
+		response.BaselineSaved = true
+	}
+
+	response.DurationMs = time.Since(start).Milliseconds()
+	return response, nil
+}
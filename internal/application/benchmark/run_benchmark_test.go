@@ -0,0 +1,145 @@
+package benchmark_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	benchmarkApp "github.com/rebelopsio/gohan/internal/application/benchmark"
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSampler struct {
+	latency float64
+	err     error
+}
+
+func (f *fakeSampler) SampleFrameTiming(ctx context.Context, duration time.Duration) ([]benchmark.Metric, error) {
+	m, err := benchmark.NewMetric(benchmark.MetricRefreshRate, 60, "Hz")
+	return []benchmark.Metric{m}, err
+}
+
+func (f *fakeSampler) MeasureInputLatency(ctx context.Context, samples int) ([]benchmark.Metric, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	m, err := benchmark.NewMetric(benchmark.MetricInputLatencyAvg, f.latency, "ms")
+	return []benchmark.Metric{m}, err
+}
+
+type fakeEnvironment struct {
+	inSession bool
+}
+
+func (f *fakeEnvironment) InHyprlandSession() bool {
+	return f.inSession
+}
+
+func (f *fakeEnvironment) DetectEnvironment(ctx context.Context) (benchmark.Environment, error) {
+	return benchmark.Environment{HyprlandVersion: "0.45.0"}, nil
+}
+
+type memoryStore struct {
+	saved    int
+	baseline *benchmark.Report
+}
+
+func (s *memoryStore) Save(ctx context.Context, report *benchmark.Report) (string, error) {
+	s.saved++
+	return "/tmp/" + report.ID() + ".json", nil
+}
+
+func (s *memoryStore) SaveBaseline(ctx context.Context, report *benchmark.Report) error {
+	s.baseline = report
+	return nil
+}
+
+func (s *memoryStore) LoadBaseline(ctx context.Context) (*benchmark.Report, error) {
+	if s.baseline == nil {
+		return nil, benchmark.ErrBaselineNotFound
+	}
+	return s.baseline, nil
+}
+
+func newUseCase(sampler *fakeSampler, env *fakeEnvironment, store *memoryStore) *benchmarkApp.RunBenchmarkUseCase {
+	return benchmarkApp.NewRunBenchmarkUseCase(benchmarkApp.Samplers{
+		FrameTiming:  sampler,
+		InputLatency: sampler,
+		Environment:  env,
+	}, store)
+}
+
+func TestRunBenchmarkUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	req := benchmarkApp.RunBenchmarkRequest{Duration: time.Millisecond, LatencySamples: 1}
+
+	t.Run("requires a Hyprland session", func(t *testing.T) {
+		store := &memoryStore{}
+		uc := newUseCase(&fakeSampler{latency: 5}, &fakeEnvironment{}, store)
+
+		_, err := uc.Execute(ctx, req)
+		assert.ErrorIs(t, err, benchmark.ErrNotInHyprlandSession)
+		assert.Zero(t, store.saved)
+	})
+
+	t.Run("first run becomes the baseline", func(t *testing.T) {
+		store := &memoryStore{}
+		uc := newUseCase(&fakeSampler{latency: 5}, &fakeEnvironment{inSession: true}, store)
+
+		resp, err := uc.Execute(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, resp.BaselineSaved)
+		assert.False(t, resp.HasBaseline)
+		assert.Len(t, resp.Metrics, 2)
+		assert.Equal(t, "0.45.0", resp.Environment.HyprlandVersion)
+		assert.Equal(t, 1, store.saved)
+		require.NotNil(t, store.baseline)
+		assert.Equal(t, resp.ReportID, store.baseline.ID())
+	})
+
+	t.Run("later runs are compared against the baseline", func(t *testing.T) {
+		store := &memoryStore{}
+		sampler := &fakeSampler{latency: 5}
+		uc := newUseCase(sampler, &fakeEnvironment{inSession: true}, store)
+
+		first, err := uc.Execute(ctx, req)
+		require.NoError(t, err)
+
+		sampler.latency = 10
+		resp, err := uc.Execute(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, resp.HasBaseline)
+		assert.False(t, resp.BaselineSaved)
+		assert.Equal(t, 1, resp.Regressions)
+		assert.Len(t, resp.Comparisons, 2)
+		assert.Equal(t, first.ReportID, store.baseline.ID())
+	})
+
+	t.Run("baseline flag replaces the baseline", func(t *testing.T) {
+		store := &memoryStore{}
+		uc := newUseCase(&fakeSampler{latency: 5}, &fakeEnvironment{inSession: true}, store)
+
+		_, err := uc.Execute(ctx, req)
+		require.NoError(t, err)
+
+		resp, err := uc.Execute(ctx, benchmarkApp.RunBenchmarkRequest{SetBaseline: true, Duration: time.Millisecond, LatencySamples: 1})
+		require.NoError(t, err)
+		assert.True(t, resp.BaselineSaved)
+		assert.Empty(t, resp.Comparisons)
+		assert.Equal(t, resp.ReportID, store.baseline.ID())
+	})
+
+	t.Run("failed measurement is noted", func(t *testing.T) {
+		store := &memoryStore{}
+		uc := newUseCase(&fakeSampler{err: errors.New("cursor did not move")}, &fakeEnvironment{inSession: true}, store)
+
+		resp, err := uc.Execute(ctx, req)
+		require.NoError(t, err)
+		assert.Len(t, resp.Metrics, 1)
+		require.Len(t, resp.Notes, 1)
+		assert.Contains(t, resp.Notes[0], "cursor did not move")
+	})
+}
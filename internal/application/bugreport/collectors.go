@@ -0,0 +1,235 @@
+package bugreport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+	historyServices "github.com/rebelopsio/gohan/internal/application/history/services"
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+)
+
+// DefaultHistoryRecords is how many recent installation records are included
+const DefaultHistoryRecords = 10
+
+// HistoryCollector includes recent installation records
+type HistoryCollector struct {
+	service *historyServices.HistoryQueryService
+	limit   int
+}
+
+// NewHistoryCollector creates a collector for the most recent records
+func NewHistoryCollector(service *historyServices.HistoryQueryService, limit int) *HistoryCollector {
+	if limit <= 0 {
+		limit = DefaultHistoryRecords
+	}
+	return &HistoryCollector{service: service, limit: limit}
+}
+
+// Name returns the source name
+func (c *HistoryCollector) Name() string {
+	return "history"
+}
+
+// Collect formats the recent installation records
+func (c *HistoryCollector) Collect(ctx context.Context) ([]bugreport.Artifact, error) {
+	records, err := c.service.ListRecent(ctx, c.limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no installation records")
+	}
+
+	var sb strings.Builder
+	for i, record := range records {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "Record:    %s\n", record.ID().String())
+		fmt.Fprintf(&sb, "Session:   %s\n", record.SessionID())
+		fmt.Fprintf(&sb, "Package:   %s %s\n", record.PackageName(), record.TargetVersion())
+		fmt.Fprintf(&sb, "Outcome:   %s\n", record.Outcome().String())
+		fmt.Fprintf(&sb, "Installed: %s (%s)\n", record.InstalledAt().Format(time.RFC3339), record.Duration())
+
+		sysCtx := record.SystemContext()
+		fmt.Fprintf(&sb, "System:    %s, kernel %s, gohan %s, arch %s, gpu %s\n",
+			sysCtx.OSVersion(), sysCtx.KernelVersion(), sysCtx.GohanVersion(),
+			sysCtx.Architecture(), sysCtx.GPUVendor())
+
+		for _, pkg := range record.Metadata().InstalledPackages() {
+			fmt.Fprintf(&sb, "  package: %s %s\n", pkg.Name(), pkg.Version())
+		}
+		for _, w := range record.Warnings() {
+			fmt.Fprintf(&sb, "  warning: %s\n", w)
+		}
+		for _, c := range record.PreflightChecks() {
+			fmt.Fprintf(&sb, "  preflight: %s\n", c)
+		}
+		if record.HasFailureDetails() {
+			fd := record.FailureDetails()
+			fmt.Fprintf(&sb, "  failure: phase=%s code=%s reason=%s\n", fd.Phase(), fd.ErrorCode(), fd.Reason())
+		}
+	}
+
+	artifact, err := bugreport.NewArtifact("gohan/history.txt", []byte(sb.String()))
+	if err != nil {
+		return nil, err
+	}
+	return []bugreport.Artifact{artifact}, nil
+}
+
+// PreflightCollector includes the results of a fresh preflight run
+type PreflightCollector struct {
+	useCase *preflightApp.RunPreflightUseCase
+}
+
+// NewPreflightCollector creates a collector running the preflight checks
+func NewPreflightCollector(useCase *preflightApp.RunPreflightUseCase) *PreflightCollector {
+	return &PreflightCollector{useCase: useCase}
+}
+
+// Name returns the source name
+func (c *PreflightCollector) Name() string {
+	return "preflight"
+}
+
+// Collect runs the preflight checks and formats the results
+func (c *PreflightCollector) Collect(ctx context.Context) ([]bugreport.Artifact, error) {
+	homeDir, _ := os.UserHomeDir()
+	resp, err := c.useCase.Execute(ctx, preflightApp.RunPreflightRequest{HomeDir: homeDir})
+	if err != nil {
+		return nil, fmt.Errorf("preflight checks failed: %w", err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Overall: %s\n", resp.OverallMessage)
+	fmt.Fprintf(&sb, "Checks:  %d passed, %d warnings, %d failed of %d\n",
+		resp.PassedChecks, resp.WarningChecks, resp.FailedChecks, resp.TotalChecks)
+	if resp.RecommendLiteMode {
+		sb.WriteString("Lite rendering recommended\n")
+	}
+	sb.WriteString("\n")
+
+	for _, result := range resp.Results {
+		status := "PASS"
+		if !result.Passed {
+			status = "WARN"
+			if result.Blocking {
+				status = "FAIL"
+			}
+		}
+		fmt.Fprintf(&sb, "[%s] %s: %s\n", status, result.Name, result.Message)
+		if result.Detected != "" {
+			fmt.Fprintf(&sb, "       detected: %s\n", result.Detected)
+		}
+		if !result.Passed {
+			sb.WriteString(result.Guidance.Render("       "))
+		}
+	}
+
+	artifact, err := bugreport.NewArtifact("gohan/preflight.txt", []byte(sb.String()))
+	if err != nil {
+		return nil, err
+	}
+	return []bugreport.Artifact{artifact}, nil
+}
+
+// ConfigStatusCollector includes how the deployed configuration files
+// differ from their templates
+type ConfigStatusCollector struct {
+	useCase *configApp.ConfigStatusUseCase
+}
+
+// NewConfigStatusCollector creates a collector comparing deployed files
+// with their templates
+func NewConfigStatusCollector(useCase *configApp.ConfigStatusUseCase) *ConfigStatusCollector {
+	return &ConfigStatusCollector{useCase: useCase}
+}
+
+// Name returns the source name
+func (c *ConfigStatusCollector) Name() string {
+	return "deployed configs"
+}
+
+// Collect lists each file with its status and hashes
+func (c *ConfigStatusCollector) Collect(ctx context.Context) ([]bugreport.Artifact, error) {
+	resp, err := c.useCase.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Files) == 0 {
+		return nil, fmt.Errorf("no configurations deployed")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Clean: %d, modified: %d, outdated: %d, missing: %d, unmanaged: %d\n\n",
+		resp.Clean, resp.Modified, resp.Outdated, resp.Missing, resp.Unmanaged)
+	for _, file := range resp.Files {
+		fmt.Fprintf(&sb, "%-10s %s\n", file.Status, file.TargetPath)
+		fmt.Fprintf(&sb, "           on disk %s, expected %s\n", orNone(file.CurrentHash), orNone(file.RenderedHash))
+		if file.Error != "" {
+			fmt.Fprintf(&sb, "           template error: %s\n", file.Error)
+		}
+	}
+
+	artifact, err := bugreport.NewArtifact("gohan/config-status.txt", []byte(sb.String()))
+	if err != nil {
+		return nil, err
+	}
+	return []bugreport.Artifact{artifact}, nil
+}
+
+// TemplateUpdatesCollector includes the template updates gohan config
+// upgrade would apply
+type TemplateUpdatesCollector struct {
+	useCase *configApp.UpgradeTemplatesUseCase
+}
+
+// NewTemplateUpdatesCollector creates a collector listing pending template
+// updates; it only runs the upgrade as a dry run
+func NewTemplateUpdatesCollector(useCase *configApp.UpgradeTemplatesUseCase) *TemplateUpdatesCollector {
+	return &TemplateUpdatesCollector{useCase: useCase}
+}
+
+// Name returns the source name
+func (c *TemplateUpdatesCollector) Name() string {
+	return "template updates"
+}
+
+// Collect lists the deployed files whose template changed
+func (c *TemplateUpdatesCollector) Collect(ctx context.Context) ([]bugreport.Artifact, error) {
+	resp, err := c.useCase.Execute(ctx, configApp.UpgradeTemplatesRequest{DryRun: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	if len(resp.Files) == 0 {
+		sb.WriteString("None pending\n")
+	}
+	for _, file := range resp.Files {
+		fmt.Fprintf(&sb, "%-9s %s (%s)\n", file.Status, file.TargetPath, file.Action)
+		if file.Error != "" {
+			fmt.Fprintf(&sb, "          error: %s\n", file.Error)
+		}
+	}
+
+	artifact, err := bugreport.NewArtifact("gohan/template-updates.txt", []byte(sb.String()))
+	if err != nil {
+		return nil, err
+	}
+	return []bugreport.Artifact{artifact}, nil
+}
+
+// orNone shows an empty hash as "none"
+func orNone(hash string) string {
+	if hash == "" {
+		return "none"
+	}
+	return hash
+}
@@ -0,0 +1,105 @@
+package bugreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+)
+
+// CreateBugReportRequest contains parameters for building a bug report
+type CreateBugReportRequest struct {
+	OutputPath string // Archive path; defaults to gohan-bugreport-<timestamp>.tar.gz
+}
+
+// CreateBugReportResponse describes the written bug report
+type CreateBugReportResponse struct {
+	Path       string
+	Files      []FileDTO
+	Skipped    []SkippedDTO
+	Redactions int
+	DurationMs int64
+}
+
+// FileDTO represents a file in the bug report
+type FileDTO struct {
+	Name string
+	Size int
+}
+
+// SkippedDTO represents a source that was not collected
+type SkippedDTO struct {
+	Source string
+	Reason string
+}
+
+// CreateBugReportUseCase gathers diagnostics into a redacted archive
+type CreateBugReportUseCase struct {
+	collectors []bugreport.Collector
+	redactor   *bugreport.Redactor
+	writer     bugreport.BundleWriter
+}
+
+// NewCreateBugReportUseCase creates a new use case instance
+func NewCreateBugReportUseCase(
+	collectors []bugreport.Collector,
+	redactor *bugreport.Redactor,
+	writer bugreport.BundleWriter,
+) *CreateBugReportUseCase {
+	return &CreateBugReportUseCase{
+		collectors: collectors,
+		redactor:   redactor,
+		writer:     writer,
+	}
+}
+
+// Execute runs every collector, redacts the results and writes the archive
+// A failing collector is recorded in the manifest instead of aborting
+func (uc *CreateBugReportUseCase) Execute(ctx context.Context, req CreateBugReportRequest) (*CreateBugReportResponse, error) {
+	start := time.Now()
+	bundle := bugreport.NewBundle(start)
+
+	for _, collector := range uc.collectors {
+		artifacts, err := collector.Collect(ctx)
+		if err != nil {
+			// Error messages often carry file paths, so they are redacted too
+			reason, _ := uc.redactor.Redact(err.Error())
+			bundle.Skip(collector.Name(), reason)
+			continue
+		}
+
+		for _, artifact := range artifacts {
+			if err := bundle.Add(artifact, uc.redactor); err != nil {
+				return nil, fmt.Errorf("failed to add %s output: %w", collector.Name(), err)
+			}
+		}
+	}
+
+	if bundle.IsEmpty() {
+		return nil, bugreport.ErrEmptyBundle
+	}
+
+	outputPath := req.OutputPath
+	if outputPath == "" {
+		outputPath = bugreport.DefaultFileName(start)
+	}
+
+	if err := uc.writer.Write(ctx, bundle, outputPath); err != nil {
+		return nil, fmt.Errorf("failed to write bug report: %w", err)
+	}
+
+	response := &CreateBugReportResponse{
+		Path:       outputPath,
+		Redactions: bundle.Redactions(),
+	}
+	for _, a := range bundle.Artifacts() {
+		response.Files = append(response.Files, FileDTO{Name: a.Name(), Size: a.Size()})
+	}
+	for _, s := range bundle.Skipped() {
+		response.Skipped = append(response.Skipped, SkippedDTO{Source: s.Source, Reason: s.Reason})
+	}
+
+	response.DurationMs = time.Since(start).Milliseconds()
+	return response, nil
+}
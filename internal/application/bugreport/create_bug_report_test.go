@@ -0,0 +1,98 @@
+package bugreport_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	bugreportApp "github.com/rebelopsio/gohan/internal/application/bugreport"
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCollector struct {
+	name    string
+	content map[string]string
+	err     error
+}
+
+func (c *stubCollector) Name() string {
+	return c.name
+}
+
+func (c *stubCollector) Collect(ctx context.Context) ([]bugreport.Artifact, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	var artifacts []bugreport.Artifact
+	for name, content := range c.content {
+		a, err := bugreport.NewArtifact(name, []byte(content))
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts, nil
+}
+
+type recordingWriter struct {
+	bundle *bugreport.Bundle
+	path   string
+}
+
+func (w *recordingWriter) Write(ctx context.Context, bundle *bugreport.Bundle, path string) error {
+	w.bundle = bundle
+	w.path = path
+	return nil
+}
+
+func TestCreateBugReportUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	redactor := bugreport.NewRedactor(bugreport.Identity{Username: "alice", HomeDir: "/home/alice"})
+
+	t.Run("redacts collected artifacts", func(t *testing.T) {
+		writer := &recordingWriter{}
+		uc := bugreportApp.NewCreateBugReportUseCase([]bugreport.Collector{
+			&stubCollector{name: "logs", content: map[string]string{"hyprland/hyprland.log": "loaded /home/alice/.config/hypr/hyprland.conf"}},
+		}, redactor, writer)
+
+		resp, err := uc.Execute(ctx, bugreportApp.CreateBugReportRequest{OutputPath: "/tmp/report.tar.gz"})
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/report.tar.gz", resp.Path)
+		assert.Equal(t, "/tmp/report.tar.gz", writer.path)
+		assert.Equal(t, 1, resp.Redactions)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "hyprland/hyprland.log", resp.Files[0].Name)
+
+		content := string(writer.bundle.Artifacts()[0].Content())
+		assert.Equal(t, "loaded ~/.config/hypr/hyprland.conf", content)
+	})
+
+	t.Run("records failing collectors with redacted reasons", func(t *testing.T) {
+		writer := &recordingWriter{}
+		uc := bugreportApp.NewCreateBugReportUseCase([]bugreport.Collector{
+			&stubCollector{name: "versions", content: map[string]string{"system/versions.txt": "gohan: dev"}},
+			&stubCollector{name: "crashes", err: errors.New("open /home/alice/.cache/hyprland: permission denied")},
+		}, redactor, writer)
+
+		resp, err := uc.Execute(ctx, bugreportApp.CreateBugReportRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Skipped, 1)
+		assert.Equal(t, "crashes", resp.Skipped[0].Source)
+		assert.NotContains(t, resp.Skipped[0].Reason, "alice")
+		assert.True(t, strings.HasPrefix(resp.Path, "gohan-bugreport-"))
+	})
+
+	t.Run("fails when nothing was collected", func(t *testing.T) {
+		writer := &recordingWriter{}
+		uc := bugreportApp.NewCreateBugReportUseCase([]bugreport.Collector{
+			&stubCollector{name: "gpu", err: errors.New("lspci not installed")},
+		}, redactor, writer)
+
+		_, err := uc.Execute(ctx, bugreportApp.CreateBugReportRequest{})
+		assert.ErrorIs(t, err, bugreport.ErrEmptyBundle)
+		assert.Nil(t, writer.bundle)
+	})
+}
@@ -0,0 +1,69 @@
+package bugreport
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+)
+
+// InspectReport is a redacted overview of the system, one section per source
+type InspectReport struct {
+	Sections   []InspectSection
+	Skipped    []SkippedDTO
+	Redactions int
+}
+
+// InspectSection is the output of one source
+type InspectSection struct {
+	Title   string
+	Content string
+}
+
+// InspectUseCase gathers the same kind of diagnostics as a bug report, but
+// returns them for printing instead of writing an archive. Collectors must
+// not change anything.
+type InspectUseCase struct {
+	collectors []bugreport.Collector
+	redactor   *bugreport.Redactor
+}
+
+// NewInspectUseCase creates a new use case instance
+func NewInspectUseCase(collectors []bugreport.Collector, redactor *bugreport.Redactor) *InspectUseCase {
+	return &InspectUseCase{
+		collectors: collectors,
+		redactor:   redactor,
+	}
+}
+
+// Execute runs every collector in order and redacts the results. A failing
+// collector is listed as skipped instead of aborting.
+func (uc *InspectUseCase) Execute(ctx context.Context) (*InspectReport, error) {
+	report := &InspectReport{}
+
+	for _, collector := range uc.collectors {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		artifacts, err := collector.Collect(ctx)
+		if err != nil {
+			reason, _ := uc.redactor.Redact(err.Error())
+			report.Skipped = append(report.Skipped, SkippedDTO{Source: collector.Name(), Reason: reason})
+			continue
+		}
+
+		var sb strings.Builder
+		for _, artifact := range artifacts {
+			content, count := uc.redactor.Redact(string(artifact.Content()))
+			report.Redactions += count
+			sb.WriteString(strings.TrimRight(content, "\n") + "\n")
+		}
+		report.Sections = append(report.Sections, InspectSection{Title: collector.Name(), Content: sb.String()})
+	}
+
+	if len(report.Sections) == 0 {
+		return nil, bugreport.ErrEmptyBundle
+	}
+	return report, nil
+}
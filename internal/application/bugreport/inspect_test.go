@@ -0,0 +1,47 @@
+package bugreport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	bugreportApp "github.com/rebelopsio/gohan/internal/application/bugreport"
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	redactor := bugreport.NewRedactor(bugreport.Identity{Username: "alice", HomeDir: "/home/alice"})
+
+	t.Run("keeps the collectors' order and redacts their output", func(t *testing.T) {
+		uc := bugreportApp.NewInspectUseCase([]bugreport.Collector{
+			&stubCollector{name: "versions", content: map[string]string{"system/versions.txt": "gohan: dev"}},
+			&stubCollector{name: "crashes", err: errors.New("open /home/alice/.cache/hyprland: permission denied")},
+			&stubCollector{name: "deployed configs", content: map[string]string{"gohan/config-status.txt": "modified /home/alice/.config/kitty/kitty.conf\n"}},
+		}, redactor)
+
+		report, err := uc.Execute(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, report.Sections, 2)
+		assert.Equal(t, "versions", report.Sections[0].Title)
+		assert.Equal(t, "gohan: dev\n", report.Sections[0].Content)
+		assert.Equal(t, "modified ~/.config/kitty/kitty.conf\n", report.Sections[1].Content)
+		assert.Equal(t, 1, report.Redactions)
+
+		require.Len(t, report.Skipped, 1)
+		assert.Equal(t, "crashes", report.Skipped[0].Source)
+		assert.NotContains(t, report.Skipped[0].Reason, "alice")
+	})
+
+	t.Run("fails when nothing was collected", func(t *testing.T) {
+		uc := bugreportApp.NewInspectUseCase([]bugreport.Collector{
+			&stubCollector{name: "gpu", err: errors.New("lspci not installed")},
+		}, redactor)
+
+		_, err := uc.Execute(ctx)
+		assert.ErrorIs(t, err, bugreport.ErrEmptyBundle)
+	})
+}
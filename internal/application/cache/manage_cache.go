@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/domain/cache"
+)
+
+// CacheStore is the interface for measuring and emptying caches
+type CacheStore interface {
+	Measure(ctx context.Context, location cache.Location) (cache.Usage, error)
+	// Clean empties the cache and returns the bytes freed
+	Clean(ctx context.Context, location cache.Location) (uint64, error)
+}
+
+// CacheDTO describes the size of one cache
+type CacheDTO struct {
+	Name        string
+	Description string
+	Path        string
+	Bytes       uint64
+	Files       int
+	Error       string // Set when the cache could not be measured
+}
+
+// CacheStatusResponse lists the caches gohan manages
+type CacheStatusResponse struct {
+	Caches     []CacheDTO
+	TotalBytes uint64
+}
+
+// CleanCacheRequest selects the caches to clean
+type CleanCacheRequest struct {
+	Kinds  []cache.Kind // Empty cleans every cache
+	DryRun bool         // Report what would be freed without deleting
+}
+
+// CleanedCacheDTO describes the outcome of cleaning one cache
+type CleanedCacheDTO struct {
+	Name       string
+	Path       string
+	FreedBytes uint64
+	Skipped    string // Why the cache was left alone, e.g. it needs root
+}
+
+// CleanCacheResponse contains what was cleaned
+type CleanCacheResponse struct {
+	Caches     []CleanedCacheDTO
+	FreedBytes uint64
+	DryRun     bool
+}
+
+// CacheStatusUseCase reports the size of each cache
+type CacheStatusUseCase struct {
+	store     CacheStore
+	locations []cache.Location
+}
+
+// NewCacheStatusUseCase creates a new use case instance
+func NewCacheStatusUseCase(store CacheStore, locations []cache.Location) *CacheStatusUseCase {
+	return &CacheStatusUseCase{store: store, locations: locations}
+}
+
+// Execute measures every cache. A cache that cannot be measured is reported
+// with its error rather than failing the whole report.
+func (uc *CacheStatusUseCase) Execute(ctx context.Context) (*CacheStatusResponse, error) {
+	response := &CacheStatusResponse{Caches: make([]CacheDTO, 0, len(uc.locations))}
+	for _, location := range uc.locations {
+		dto := CacheDTO{
+			Name:        location.Kind().String(),
+			Description: location.Kind().Description(),
+			Path:        location.Path(),
+		}
+
+		usage, err := uc.store.Measure(ctx, location)
+		if err != nil {
+			dto.Error = err.Error()
+		} else {
+			dto.Bytes = usage.Bytes()
+			dto.Files = usage.Files()
+			response.TotalBytes += usage.Bytes()
+		}
+		response.Caches = append(response.Caches, dto)
+	}
+	return response, nil
+}
+
+// CleanCacheUseCase empties caches
+type CleanCacheUseCase struct {
+	store     CacheStore
+	locations []cache.Location
+}
+
+// NewCleanCacheUseCase creates a new use case instance
+func NewCleanCacheUseCase(store CacheStore, locations []cache.Location) *CleanCacheUseCase {
+	return &CleanCacheUseCase{store: store, locations: locations}
+}
+
+// Execute cleans the selected caches. Caches that need root are skipped
+// when gohan runs as a regular user.
+func (uc *CleanCacheUseCase) Execute(ctx context.Context, req CleanCacheRequest) (*CleanCacheResponse, error) {
+	selected := make(map[cache.Kind]bool, len(req.Kinds))
+	for _, kind := range req.Kinds {
+		selected[kind] = true
+	}
+
+	response := &CleanCacheResponse{DryRun: req.DryRun}
+	for _, location := range uc.locations {
+		if len(selected) > 0 && !selected[location.Kind()] {
+			continue
+		}
+
+		cleaned := CleanedCacheDTO{Name: location.Kind().String(), Path: location.Path()}
+		var err error
+		if req.DryRun {
+			var usage cache.Usage
+			if usage, err = uc.store.Measure(ctx, location); err == nil {
+				cleaned.FreedBytes = usage.Bytes()
+			}
+		} else {
+			cleaned.FreedBytes, err = uc.store.Clean(ctx, location)
+		}
+
+		switch {
+		case errors.Is(err, cache.ErrPermissionRequired):
+			cleaned.Skipped = "requires root"
+		case err != nil:
+			return nil, fmt.Errorf("failed to clean %s: %w", location.Kind().Description(), err)
+		}
+
+		response.FreedBytes += cleaned.FreedBytes
+		response.Caches = append(response.Caches, cleaned)
+	}
+	return response, nil
+}
+
+// ReclaimSpace cleans every cache gohan may clean, for callers running low
+// on disk space, and returns the bytes freed
+func (uc *CleanCacheUseCase) ReclaimSpace(ctx context.Context) (uint64, error) {
+	response, err := uc.Execute(ctx, CleanCacheRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return response.FreedBytes, nil
+}
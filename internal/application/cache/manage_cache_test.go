@@ -0,0 +1,106 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/cache"
+	domain "github.com/rebelopsio/gohan/internal/domain/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore holds cache sizes in memory
+type fakeStore struct {
+	bytes   map[domain.Kind]uint64
+	errs    map[domain.Kind]error
+	cleaned []domain.Kind
+}
+
+func (s *fakeStore) Measure(ctx context.Context, location domain.Location) (domain.Usage, error) {
+	if err := s.errs[location.Kind()]; err != nil {
+		return domain.Usage{}, err
+	}
+	return domain.NewUsage(location, s.bytes[location.Kind()], 1), nil
+}
+
+func (s *fakeStore) Clean(ctx context.Context, location domain.Location) (uint64, error) {
+	if err := s.errs[location.Kind()]; err != nil {
+		return 0, err
+	}
+	freed := s.bytes[location.Kind()]
+	s.bytes[location.Kind()] = 0
+	s.cleaned = append(s.cleaned, location.Kind())
+	return freed, nil
+}
+
+func locations(t *testing.T) []domain.Location {
+	t.Helper()
+
+	var locations []domain.Location
+	for kind, path := range map[domain.Kind]string{
+		domain.KindAPTArchives:      "/var/cache/apt/archives",
+		domain.KindBuild:            "/home/alice/.cache/gohan/build",
+		domain.KindTemplateRegistry: "/home/alice/.cache/gohan/templates",
+	} {
+		location, err := domain.NewLocation(kind, path)
+		require.NoError(t, err)
+		locations = append(locations, location)
+	}
+	return locations
+}
+
+func TestCacheStatusUseCase(t *testing.T) {
+	store := &fakeStore{
+		bytes: map[domain.Kind]uint64{domain.KindBuild: 300, domain.KindTemplateRegistry: 20},
+		errs:  map[domain.Kind]error{domain.KindAPTArchives: errors.New("permission denied")},
+	}
+
+	response, err := cache.NewCacheStatusUseCase(store, locations(t)).Execute(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, response.Caches, 3)
+	assert.Equal(t, uint64(320), response.TotalBytes)
+
+	for _, c := range response.Caches {
+		if c.Name == "apt" {
+			assert.Equal(t, "permission denied", c.Error)
+		}
+	}
+}
+
+func TestCleanCacheUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cleans the selected caches", func(t *testing.T) {
+		store := &fakeStore{bytes: map[domain.Kind]uint64{domain.KindBuild: 300, domain.KindTemplateRegistry: 20}}
+
+		response, err := cache.NewCleanCacheUseCase(store, locations(t)).Execute(ctx, cache.CleanCacheRequest{
+			Kinds: []domain.Kind{domain.KindBuild},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(300), response.FreedBytes)
+		assert.Equal(t, []domain.Kind{domain.KindBuild}, store.cleaned)
+	})
+
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		store := &fakeStore{bytes: map[domain.Kind]uint64{domain.KindBuild: 300}}
+
+		response, err := cache.NewCleanCacheUseCase(store, locations(t)).Execute(ctx, cache.CleanCacheRequest{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(300), response.FreedBytes)
+		assert.Empty(t, store.cleaned)
+	})
+
+	t.Run("skips caches that need root", func(t *testing.T) {
+		store := &fakeStore{
+			bytes: map[domain.Kind]uint64{domain.KindBuild: 300},
+			errs:  map[domain.Kind]error{domain.KindAPTArchives: domain.ErrPermissionRequired},
+		}
+
+		freed, err := cache.NewCleanCacheUseCase(store, locations(t)).ReclaimSpace(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(300), freed)
+		assert.ElementsMatch(t, []domain.Kind{domain.KindBuild, domain.KindTemplateRegistry}, store.cleaned)
+	})
+}
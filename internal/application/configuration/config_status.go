@@ -0,0 +1,197 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+)
+
+// Drift statuses of a file in a configuration directory
+const (
+	FileStatusClean     = "clean"     // Holds what its template renders
+	FileStatusModified  = "modified"  // Edited since it was deployed
+	FileStatusOutdated  = "outdated"  // Not edited, but its template changed; gohan config upgrade applies it
+	FileStatusMissing   = "missing"   // Deployed, then removed
+	FileStatusUnmanaged = "unmanaged" // Next to deployed files, but not deployed by gohan
+)
+
+// ConfigStatusResponse reports how the configuration files differ from
+// what their templates render
+type ConfigStatusResponse struct {
+	Files     []ConfigFileStatus
+	Clean     int
+	Modified  int
+	Outdated  int
+	Missing   int
+	Unmanaged int
+}
+
+// ConfigFileStatus describes one file
+type ConfigFileStatus struct {
+	TargetPath     string
+	SourceTemplate string // Empty for unmanaged files
+	Status         string
+	CurrentHash    string // SHA-256 of the file on disk; empty when missing
+	RenderedHash   string // SHA-256 of the template's rendering; empty when unmanaged
+	Error          string // Why the template could not be rendered; the deployed rendering is compared instead
+}
+
+// HasDrift returns true if any deployed file was edited or removed
+func (r *ConfigStatusResponse) HasDrift() bool {
+	return r.Modified > 0 || r.Missing > 0
+}
+
+// ConfigStatusUseCase compares the deployed configuration files with a fresh
+// rendering of their templates, using the variables each was deployed with,
+// and lists the files next to them that gohan did not deploy
+type ConfigStatusUseCase struct {
+	records        DeployRecords
+	templateEngine *templates.TemplateEngine
+}
+
+// NewConfigStatusUseCase creates a new use case instance
+func NewConfigStatusUseCase(records DeployRecords, templateEngine *templates.TemplateEngine) *ConfigStatusUseCase {
+	return &ConfigStatusUseCase{
+		records:        records,
+		templateEngine: templateEngine,
+	}
+}
+
+// Execute reports the status of each deployed file, followed by the
+// unmanaged files in the directories they were deployed to
+func (uc *ConfigStatusUseCase) Execute(ctx context.Context) (*ConfigStatusResponse, error) {
+	records, err := uc.records.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployed templates: %w", err)
+	}
+
+	response := &ConfigStatusResponse{}
+	managed := make(map[string]bool, len(records))
+	dirs := make(map[string]bool)
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		managed[record.TargetPath()] = true
+		dirs[filepath.Dir(record.TargetPath())] = true
+
+		file, err := uc.status(record)
+		if err != nil {
+			return nil, err
+		}
+		response.record(file)
+	}
+
+	unmanaged, err := unmanagedFiles(dirs, managed)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range unmanaged {
+		response.record(file)
+	}
+
+	return response, nil
+}
+
+// status compares one deployed file with its template's rendering
+func (uc *ConfigStatusUseCase) status(record configuration.DeployedTemplate) (ConfigFileStatus, error) {
+	file := ConfigFileStatus{
+		TargetPath:     record.TargetPath(),
+		SourceTemplate: record.SourceTemplate(),
+	}
+
+	// Templates gohan no longer ships leave the deployed rendering to compare with
+	rendered, err := uc.templateEngine.RenderFile(record.SourceTemplate(), templates.TemplateVars(record.Vars()))
+	if err != nil {
+		file.Error = err.Error()
+		rendered = record.Rendered()
+	} else if configuration.HasManagedBlock(record.Rendered()) {
+		rendered = configuration.WrapManagedBlock(record.TargetPath(), rendered)
+	}
+	file.RenderedHash = configuration.HashTemplate([]byte(rendered))
+
+	current, err := os.ReadFile(record.TargetPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		file.Status = FileStatusMissing
+		return file, nil
+	}
+	if err != nil {
+		return file, fmt.Errorf("failed to read %s: %w", record.TargetPath(), err)
+	}
+	file.CurrentHash = configuration.HashTemplate(current)
+
+	switch {
+	case string(current) == rendered || holdsManagedBlock(string(current), rendered):
+		file.Status = FileStatusClean
+	case !record.IsModified(string(current)) || holdsManagedBlock(string(current), record.Rendered()):
+		file.Status = FileStatusOutdated
+	default:
+		file.Status = FileStatusModified
+	}
+	return file, nil
+}
+
+// holdsManagedBlock reports whether content's managed block is block's.
+// Lines the user added around the block are not drift.
+func holdsManagedBlock(content, block string) bool {
+	replaced, ok := configuration.ReplaceManagedBlock(content, block)
+	return ok && replaced == content
+}
+
+// unmanagedFiles lists the files in dirs that were not deployed, leaving
+// out subdirectories and merges left by gohan config upgrade
+func unmanagedFiles(dirs map[string]bool, managed map[string]bool) ([]ConfigFileStatus, error) {
+	var files []ConfigFileStatus
+	for dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if !entry.Type().IsRegular() || managed[path] || strings.HasSuffix(path, MergeFileSuffix) {
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			files = append(files, ConfigFileStatus{
+				TargetPath:  path,
+				Status:      FileStatusUnmanaged,
+				CurrentHash: configuration.HashTemplate(content),
+			})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].TargetPath < files[j].TargetPath })
+	return files, nil
+}
+
+// record adds a file to the response and updates the counts
+func (r *ConfigStatusResponse) record(file ConfigFileStatus) {
+	switch file.Status {
+	case FileStatusClean:
+		r.Clean++
+	case FileStatusModified:
+		r.Modified++
+	case FileStatusOutdated:
+		r.Outdated++
+	case FileStatusMissing:
+		r.Missing++
+	case FileStatusUnmanaged:
+		r.Unmanaged++
+	}
+	r.Files = append(r.Files, file)
+}
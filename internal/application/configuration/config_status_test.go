@@ -0,0 +1,101 @@
+package configuration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/configuration"
+	domainConfig "github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigStatusUseCase_Execute(t *testing.T) {
+	status := func(t *testing.T, f *templateUpgradeFixture) *configuration.ConfigStatusResponse {
+		t.Helper()
+		resp, err := configuration.NewConfigStatusUseCase(f.records, templates.NewTemplateEngine()).Execute(context.Background())
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("reports a file holding its rendering as clean", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+
+		resp := status(t, f)
+
+		require.Len(t, resp.Files, 1)
+		file := resp.Files[0]
+		assert.Equal(t, configuration.FileStatusClean, file.Status)
+		assert.Equal(t, domainConfig.HashTemplate([]byte("user = alice\n")), file.CurrentHash)
+		assert.Equal(t, file.CurrentHash, file.RenderedHash)
+		assert.False(t, resp.HasDrift())
+	})
+
+	t.Run("reports local edits as modified", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+		f.editTarget(t, "user = bob\n")
+
+		resp := status(t, f)
+
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, configuration.FileStatusModified, resp.Files[0].Status)
+		assert.NotEqual(t, resp.Files[0].CurrentHash, resp.Files[0].RenderedHash)
+		assert.Equal(t, 1, resp.Modified)
+		assert.True(t, resp.HasDrift())
+	})
+
+	t.Run("tells template updates apart from local edits", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+		f.writeTemplate(t, "user = {{username}}\nshell = zsh\n")
+
+		resp := status(t, f)
+
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, configuration.FileStatusOutdated, resp.Files[0].Status)
+		assert.False(t, resp.HasDrift())
+	})
+
+	t.Run("does not count additions around a managed block as edits", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+		f.deployManaged(t)
+		f.editTarget(t, "# mine\n"+f.targetContent(t))
+
+		resp := status(t, f)
+
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, configuration.FileStatusClean, resp.Files[0].Status)
+
+		f.writeTemplate(t, "user = {{username}}\nshell = zsh\n")
+		resp = status(t, f)
+		assert.Equal(t, configuration.FileStatusOutdated, resp.Files[0].Status)
+	})
+
+	t.Run("reports removed files as missing", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+		require.NoError(t, os.Remove(f.target))
+
+		resp := status(t, f)
+
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, configuration.FileStatusMissing, resp.Files[0].Status)
+		assert.Empty(t, resp.Files[0].CurrentHash)
+	})
+
+	t.Run("lists files gohan did not deploy next to deployed ones", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+		extra := filepath.Join(filepath.Dir(f.target), "local.conf")
+		require.NoError(t, os.WriteFile(extra, []byte("mine\n"), 0644))
+		require.NoError(t, os.WriteFile(f.target+configuration.MergeFileSuffix, []byte("merge\n"), 0644))
+
+		resp := status(t, f)
+
+		require.Len(t, resp.Files, 2)
+		assert.Equal(t, extra, resp.Files[1].TargetPath)
+		assert.Equal(t, configuration.FileStatusUnmanaged, resp.Files[1].Status)
+		assert.Equal(t, domainConfig.HashTemplate([]byte("mine\n")), resp.Files[1].CurrentHash)
+		assert.Equal(t, 1, resp.Unmanaged)
+	})
+}
@@ -2,22 +2,41 @@ package configuration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/migration"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
 )
 
+// ErrNoConfigurations is returned when none of the requested components
+// has configuration files to deploy
+var ErrNoConfigurations = errors.New("no configurations to deploy")
+
+// ErrNoPendingDir is returned when deferring a deployment to the next login
+// without a pending directory
+var ErrNoPendingDir = errors.New("no pending deployment directory configured")
+
 // DeployConfigRequest contains parameters for configuration deployment
 type DeployConfigRequest struct {
-	Components      []string // Which components to deploy (hyprland, waybar, kitty, etc.)
+	Components      []string // Which components to deploy (hyprland, waybar, kitty, portals, etc.)
 	SkipBackup      bool     // Skip backup of existing configurations
 	DryRun          bool     // Preview without actually deploying
 	Force           bool     // Overwrite without prompting
 	CustomVars      map[string]string // Additional template variables
 	ShowProgress    bool     // Show progress during deployment
+	RenderingMode   installation.RenderingMode // Standard or lite rendering (empty means standard)
+	Accessibility   installation.AccessibilitySettings // Reduced motion, large text and high contrast
+	Weather         installation.WeatherLocation // Location of the Waybar weather module; empty leaves it out
+	Portals         installation.PortalSelection // Installed portal backends; empty assumes Hyprland's and GTK's
+	LockScreen      installation.LockScreenSettings // Lock screen background, avatar and clock
+	StageDir        string   // Render the files under this directory, at their target paths, instead of deploying them
+	NextLogin       bool     // Stage the files in the pending directory, moved into place at the next login, instead of deploying them
 }
 
 // DeployConfigResponse contains deployment results
@@ -29,17 +48,41 @@ type DeployConfigResponse struct {
 	SuccessfulFiles int
 	FailedFiles     int
 	SkippedFiles    int
+	UnchangedFiles  int // Already held the rendered content; not rewritten or backed up
+	ConflictFiles   int // Edited files whose edits overlap the template's changes; left as they were
+	PendingFiles    int // Deferred to the next login
 	DurationMs      int64
 	DryRun          bool
+	Warnings        []string // Failed post_deploy hooks
 }
 
 // DeployedFileInfo contains information about a deployed file
 type DeployedFileInfo struct {
-	Component    string
-	TargetPath   string
-	Status       string // "deployed", "skipped", "failed", "dry-run"
-	BackedUp     bool
-	Error        string
+	Component      string
+	TargetPath     string
+	Status         string // "deployed", "unchanged", "conflict", "skipped", "failed", "dry-run", "staged", "pending"
+	Action         string // "created", "updated", "merged", "conflict", "unchanged", "skipped", "failed"; for a dry run or staging, what would happen
+	SourceTemplate string // Template the file is rendered from
+	BackedUp       bool
+	BackupID       string // Backup holding the previous version, if one was made
+	BackupPath     string
+	BytesWritten   int64
+	Diff           string // For a dry run, unified diff of the change to the file
+	Conflicts      int    // Regions where the user's edits and the template's changes overlap
+	MergePath      string // Merge with conflict markers to resolve, for conflicts
+	StagedPath     string // Where the file was rendered, when staged
+	Error          string
+}
+
+// DeployHooks runs the commands the user configured before and after
+// configuration is deployed
+type DeployHooks interface {
+	Run(ctx context.Context, invocation installation.HookInvocation) error
+}
+
+// LoginHook applies deployments deferred to the next login
+type LoginHook interface {
+	Install(ctx context.Context) error
 }
 
 // ProgressCallback is called for each file deployment
@@ -49,6 +92,10 @@ type ProgressCallback func(component string, filePath string, progress float64)
 type ConfigDeployUseCase struct {
 	deployer       *configservice.ConfigDeployer
 	templateEngine *templates.TemplateEngine
+	hooks          DeployHooks // Optional
+	overrideDir    string      // Optional; the user's copies of templates, used in their place
+	pendingDir     string      // Optional; deployments deferred to the next login
+	loginHook      LoginHook   // Optional
 	homeDir        string
 }
 
@@ -65,6 +112,34 @@ func NewConfigDeployUseCase(
 	}
 }
 
+// WithHooks returns a copy of the use case running the user's pre_deploy
+// and post_deploy hooks around each deployment other than a dry run
+func (uc *ConfigDeployUseCase) WithHooks(hooks DeployHooks) *ConfigDeployUseCase {
+	copied := *uc
+	copied.hooks = hooks
+	return &copied
+}
+
+// WithTemplateOverrides returns a copy of the use case that renders a
+// file from the user's copy of its template under dir, when there is one.
+// Copies mirror the templates directory: dir/kitty/kitty.conf.tmpl
+// replaces templates/kitty/kitty.conf.tmpl.
+func (uc *ConfigDeployUseCase) WithTemplateOverrides(dir string) *ConfigDeployUseCase {
+	copied := *uc
+	copied.overrideDir = dir
+	return &copied
+}
+
+// WithPendingDeploys returns a copy of the use case that can defer
+// deployments to the next login, staging them in dir for hook to move
+// into place
+func (uc *ConfigDeployUseCase) WithPendingDeploys(dir string, hook LoginHook) *ConfigDeployUseCase {
+	copied := *uc
+	copied.pendingDir = dir
+	copied.loginHook = hook
+	return &copied
+}
+
 // Execute runs configuration deployment
 func (uc *ConfigDeployUseCase) Execute(ctx context.Context, req DeployConfigRequest) (*DeployConfigResponse, error) {
 	// Determine home directory (use custom if provided for testing)
@@ -76,14 +151,20 @@ func (uc *ConfigDeployUseCase) Execute(ctx context.Context, req DeployConfigRequ
 	}
 
 	// Build configuration file list
-	configs := uc.buildConfigList(req.Components, homeDir)
+	configs := uc.withOverrides(uc.buildConfigList(req.Components, homeDir, req.RenderingMode))
 
 	if len(configs) == 0 {
-		return nil, fmt.Errorf("no configurations to deploy for components: %v", req.Components)
+		return nil, fmt.Errorf("%w for components: %v", ErrNoConfigurations, req.Components)
 	}
 
 	// Prepare template variables
-	vars := uc.prepareTemplateVars(req.CustomVars)
+	vars := uc.prepareTemplateVars(req.CustomVars, req.RenderingMode, req.Accessibility, req.Weather, req.Portals, req.LockScreen)
+	if err := uc.validateWeatherModule(configs, vars, req.Weather); err != nil {
+		return nil, err
+	}
+	if err := validateLockScreen(configs, req.LockScreen, homeDir); err != nil {
+		return nil, err
+	}
 
 	response := &DeployConfigResponse{
 		TotalFiles:      len(configs),
@@ -93,45 +174,32 @@ func (uc *ConfigDeployUseCase) Execute(ctx context.Context, req DeployConfigRequ
 
 	// Dry run - just show what would be deployed
 	if req.DryRun {
-		for _, config := range configs {
-			response.DeployedFiles = append(response.DeployedFiles, DeployedFileInfo{
-				Component:  extractComponent(config.TargetPath),
-				TargetPath: config.TargetPath,
-				Status:     "dry-run",
-			})
-		}
+		response.DeployedFiles = uc.dryRunFiles(ctx, configs, vars)
 		return response, nil
 	}
 
-	// Deploy configurations
-	for _, config := range configs {
-		result, err := uc.deployer.DeployWithBackup(ctx, config, vars)
-
-		fileInfo := DeployedFileInfo{
-			Component:  extractComponent(config.TargetPath),
-			TargetPath: result.FilePath,
+	// Staging - render where the files can be inspected
+	if req.StageDir != "" {
+		for _, file := range uc.stageFiles(ctx, configs, vars, req.StageDir) {
+			response.record(file)
 		}
+		return response, nil
+	}
 
-		if err != nil {
-			fileInfo.Status = "failed"
-			fileInfo.Error = err.Error()
-			response.FailedFiles++
-		} else if result.Success {
-			fileInfo.Status = "deployed"
-			fileInfo.BackedUp = result.BackupID != ""
-			response.SuccessfulFiles++
-
-			// Store backup info (use last one)
-			if result.BackupID != "" {
-				response.BackupID = result.BackupID
-				response.BackupPath = result.BackupPath
-			}
-		}
+	// Next login - stage where the login hook moves them into place
+	if req.NextLogin {
+		return uc.deferToNextLogin(ctx, configs, vars, response)
+	}
 
-		response.DeployedFiles = append(response.DeployedFiles, fileInfo)
+	if err := uc.runHooks(ctx, installation.HookPreDeploy, req.Components, response); err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	err := uc.deploy(ctx, configs, vars, response, nil)
+	if err == nil && response.FailedFiles == 0 {
+		_ = uc.runHooks(ctx, installation.HookPostDeploy, req.Components, response)
+	}
+	return response, err
 }
 
 // ExecuteWithProgress runs deployment with progress callbacks
@@ -149,14 +217,20 @@ func (uc *ConfigDeployUseCase) ExecuteWithProgress(
 	}
 
 	// Build configuration file list
-	configs := uc.buildConfigList(req.Components, homeDir)
+	configs := uc.withOverrides(uc.buildConfigList(req.Components, homeDir, req.RenderingMode))
 
 	if len(configs) == 0 {
-		return nil, fmt.Errorf("no configurations to deploy for components: %v", req.Components)
+		return nil, fmt.Errorf("%w for components: %v", ErrNoConfigurations, req.Components)
 	}
 
 	// Prepare template variables
-	vars := uc.prepareTemplateVars(req.CustomVars)
+	vars := uc.prepareTemplateVars(req.CustomVars, req.RenderingMode, req.Accessibility, req.Weather, req.Portals, req.LockScreen)
+	if err := uc.validateWeatherModule(configs, vars, req.Weather); err != nil {
+		return nil, err
+	}
+	if err := validateLockScreen(configs, req.LockScreen, homeDir); err != nil {
+		return nil, err
+	}
 
 	response := &DeployConfigResponse{
 		TotalFiles:    len(configs),
@@ -166,25 +240,53 @@ func (uc *ConfigDeployUseCase) ExecuteWithProgress(
 
 	// Dry run - just show what would be deployed
 	if req.DryRun {
-		for _, config := range configs {
-			response.DeployedFiles = append(response.DeployedFiles, DeployedFileInfo{
-				Component:  extractComponent(config.TargetPath),
-				TargetPath: config.TargetPath,
-				Status:     "dry-run",
-			})
+		response.DeployedFiles = uc.dryRunFiles(ctx, configs, vars)
+		return response, nil
+	}
+
+	// Staging - render where the files can be inspected
+	if req.StageDir != "" {
+		for _, file := range uc.stageFiles(ctx, configs, vars, req.StageDir) {
+			response.record(file)
 		}
 		return response, nil
 	}
 
-	// Deploy with progress
+	// Next login - stage where the login hook moves them into place
+	if req.NextLogin {
+		return uc.deferToNextLogin(ctx, configs, vars, response)
+	}
+
+	if err := uc.runHooks(ctx, installation.HookPreDeploy, req.Components, response); err != nil {
+		return nil, err
+	}
+
+	err := uc.deploy(ctx, configs, vars, response, progressFn)
+	if err == nil && response.FailedFiles == 0 {
+		_ = uc.runHooks(ctx, installation.HookPostDeploy, req.Components, response)
+	}
+	return response, err
+}
+
+// deploy deploys the files as one transaction, recording each file's
+// outcome in the response and reporting progress to progressFn if set
+func (uc *ConfigDeployUseCase) deploy(
+	ctx context.Context,
+	configs []configservice.ConfigurationFile,
+	vars templates.TemplateVars,
+	response *DeployConfigResponse,
+	progressFn ProgressCallback,
+) error {
 	progressChan := make(chan configservice.DeploymentProgress)
-	done := make(chan error)
+	done := make(chan error, 1)
 
 	go func() {
 		done <- uc.deployer.DeployConfigurations(ctx, configs, vars, progressChan)
+		close(progressChan)
 	}()
 
 	// Process progress updates
+	reported := make(map[string]bool, len(configs))
 	for progress := range progressChan {
 		if progressFn != nil {
 			component := extractComponent(progress.FilePath)
@@ -192,30 +294,277 @@ func (uc *ConfigDeployUseCase) ExecuteWithProgress(
 		}
 
 		// Track results
-		if progress.Status == "completed" {
-			response.DeployedFiles = append(response.DeployedFiles, DeployedFileInfo{
-				Component:  extractComponent(progress.FilePath),
-				TargetPath: progress.FilePath,
-				Status:     "deployed",
-			})
-			response.SuccessfulFiles++
-		} else if progress.Status == "failed" {
-			response.DeployedFiles = append(response.DeployedFiles, DeployedFileInfo{
-				Component:  extractComponent(progress.FilePath),
-				TargetPath: progress.FilePath,
-				Status:     "failed",
-				Error:      progress.Error.Error(),
-			})
-			response.FailedFiles++
+		if (progress.Status == "completed" || progress.Status == "failed") && progress.Result != nil {
+			response.record(deployedFileInfo(progress.Result))
+			reported[progress.FilePath] = true
+		}
+	}
+
+	// Deployment is all or nothing; after a failure the other files were
+	// left, or put back, as they were
+	for _, config := range configs {
+		if reported[config.TargetPath] {
+			continue
 		}
+		response.record(DeployedFileInfo{
+			Component:      extractComponent(config.TargetPath),
+			TargetPath:     config.TargetPath,
+			Status:         "skipped",
+			Action:         string(configservice.ActionSkipped),
+			SourceTemplate: config.SourceTemplate,
+		})
 	}
 
 	// Wait for completion
-	err := <-done
+	return <-done
+}
+
+// deferToNextLogin stages the files in the pending directory, replacing
+// any deployment pending there, and installs the login hook applying them
+func (uc *ConfigDeployUseCase) deferToNextLogin(
+	ctx context.Context,
+	configs []configservice.ConfigurationFile,
+	vars templates.TemplateVars,
+	response *DeployConfigResponse,
+) (*DeployConfigResponse, error) {
+	if uc.pendingDir == "" {
+		return nil, ErrNoPendingDir
+	}
+
+	results, err := uc.deployer.DeferConfigurations(ctx, configs, vars, uc.pendingDir)
+	for _, result := range results {
+		file := deployedFileInfo(result)
+		if file.Status == "deployed" {
+			file.Status = "pending"
+		}
+		response.record(file)
+	}
+	if err != nil {
+		return response, err
+	}
+
+	if response.PendingFiles > 0 && uc.loginHook != nil {
+		if err := uc.loginHook.Install(ctx); err != nil {
+			response.Warnings = append(response.Warnings,
+				fmt.Sprintf("failed to install the login hook, run gohan config apply-pending before starting Hyprland: %v", err))
+		}
+	}
+	return response, nil
+}
+
+// PendingDeployment returns the deployment deferred to the next login,
+// reporting false when nothing is pending
+func (uc *ConfigDeployUseCase) PendingDeployment() (configservice.PendingDeployment, bool, error) {
+	if uc.pendingDir == "" {
+		return configservice.PendingDeployment{}, false, nil
+	}
+	return uc.deployer.Pending(uc.pendingDir)
+}
+
+// ApplyPending moves the deployment deferred to the next login into place,
+// with the deploy hooks around it. The response is nil when nothing is
+// pending.
+func (uc *ConfigDeployUseCase) ApplyPending(ctx context.Context) (*DeployConfigResponse, error) {
+	pending, found, err := uc.PendingDeployment()
+	if err != nil || !found {
+		return nil, err
+	}
+
+	response := &DeployConfigResponse{
+		TotalFiles:    len(pending.Targets),
+		DeployedFiles: make([]DeployedFileInfo, 0, len(pending.Targets)),
+	}
+	if err := uc.runHooks(ctx, installation.HookPreDeploy, nil, response); err != nil {
+		return nil, err
+	}
+
+	results, err := uc.deployer.ApplyPending(ctx, uc.pendingDir)
+	for _, result := range results {
+		response.record(deployedFileInfo(result))
+	}
+	if err == nil && response.FailedFiles == 0 {
+		_ = uc.runHooks(ctx, installation.HookPostDeploy, nil, response)
+	}
 	return response, err
 }
 
-func (uc *ConfigDeployUseCase) buildConfigList(components []string, homeDir string) []configservice.ConfigurationFile {
+// runHooks runs the user's hooks for phase, if any, passing post_deploy
+// hooks the files that were written. A failing pre_deploy hook is returned
+// so nothing is deployed; a failing post_deploy hook is added to the
+// response's warnings.
+func (uc *ConfigDeployUseCase) runHooks(
+	ctx context.Context,
+	phase installation.HookPhase,
+	components []string,
+	response *DeployConfigResponse,
+) error {
+	if uc.hooks == nil {
+		return nil
+	}
+
+	invocation, err := installation.NewHookInvocation(phase, "", components)
+	if err != nil {
+		return err
+	}
+	var written []string
+	for _, file := range response.DeployedFiles {
+		if file.Status == "deployed" {
+			written = append(written, file.TargetPath)
+		}
+	}
+	invocation = invocation.WithDeployedFiles(written)
+
+	if err := uc.hooks.Run(ctx, invocation); err != nil {
+		if phase.IsPre() {
+			return err
+		}
+		response.Warnings = append(response.Warnings, err.Error())
+	}
+	return nil
+}
+
+// record adds a file result to the response and updates the counts
+func (r *DeployConfigResponse) record(file DeployedFileInfo) {
+	switch file.Status {
+	case "deployed":
+		r.SuccessfulFiles++
+	case "failed":
+		r.FailedFiles++
+	case "unchanged":
+		r.UnchangedFiles++
+	case "conflict":
+		r.ConflictFiles++
+	case "skipped":
+		r.SkippedFiles++
+	case "pending":
+		r.PendingFiles++
+	}
+
+	// Keep the last backup made for callers that only show one
+	if file.BackupID != "" {
+		r.BackupID = file.BackupID
+		r.BackupPath = file.BackupPath
+	}
+
+	r.DeployedFiles = append(r.DeployedFiles, file)
+}
+
+// deployedFileInfo converts a deployer result to the per-file response entry
+func deployedFileInfo(result *configservice.DeploymentResult) DeployedFileInfo {
+	file := DeployedFileInfo{
+		Component:      extractComponent(result.FilePath),
+		TargetPath:     result.FilePath,
+		Action:         string(result.Action),
+		SourceTemplate: result.SourceTemplate,
+		BackedUp:       result.BackupID != "",
+		BackupID:       result.BackupID,
+		BackupPath:     result.BackupPath,
+		BytesWritten:   result.BytesWritten,
+		Conflicts:      result.Conflicts,
+		MergePath:      result.MergePath,
+	}
+
+	switch {
+	case result.Error != nil:
+		file.Status = "failed"
+		file.Error = result.Error.Error()
+	case result.Action == configservice.ActionUnchanged:
+		file.Status = "unchanged"
+	case result.Action == configservice.ActionConflict:
+		file.Status = "conflict"
+	case result.Action == configservice.ActionSkipped:
+		file.Status = "skipped"
+	default:
+		file.Status = "deployed"
+	}
+
+	return file
+}
+
+// dryRunFiles renders each file in memory and reports whether it would be
+// created, updated or left unchanged, with the diff of the change
+func (uc *ConfigDeployUseCase) dryRunFiles(
+	ctx context.Context,
+	configs []configservice.ConfigurationFile,
+	vars templates.TemplateVars,
+) []DeployedFileInfo {
+	files := make([]DeployedFileInfo, 0, len(configs))
+	for _, config := range configs {
+		file := DeployedFileInfo{
+			Component:      extractComponent(config.TargetPath),
+			TargetPath:     config.TargetPath,
+			Status:         "dry-run",
+			SourceTemplate: config.SourceTemplate,
+		}
+
+		action, diff, err := uc.deployer.PreviewDiff(ctx, config, vars)
+		file.Action = string(action)
+		file.Diff = diff
+		if err != nil {
+			file.Error = err.Error()
+		}
+
+		files = append(files, file)
+	}
+	return files
+}
+
+// stageFiles renders each file under dir, at its target path, and reports
+// what deploying it would do
+func (uc *ConfigDeployUseCase) stageFiles(
+	ctx context.Context,
+	configs []configservice.ConfigurationFile,
+	vars templates.TemplateVars,
+	dir string,
+) []DeployedFileInfo {
+	files := make([]DeployedFileInfo, 0, len(configs))
+	for _, config := range configs {
+		file := DeployedFileInfo{
+			Component:      extractComponent(config.TargetPath),
+			TargetPath:     config.TargetPath,
+			Status:         "staged",
+			SourceTemplate: config.SourceTemplate,
+		}
+
+		action, stagedPath, err := uc.deployer.Stage(ctx, config, vars, dir)
+		file.Action = string(action)
+		file.StagedPath = stagedPath
+		if err != nil {
+			file.Status = "failed"
+			file.Error = err.Error()
+		}
+
+		files = append(files, file)
+	}
+	return files
+}
+
+// withOverrides points each file at the user's copy of its template, when
+// there is one
+func (uc *ConfigDeployUseCase) withOverrides(configs []configservice.ConfigurationFile) []configservice.ConfigurationFile {
+	if uc.overrideDir == "" {
+		return configs
+	}
+	for i, config := range configs {
+		override := uc.overridePath(config.SourceTemplate)
+		if info, err := os.Stat(override); err == nil && info.Mode().IsRegular() {
+			configs[i].SourceTemplate = override
+		}
+	}
+	return configs
+}
+
+// overridePath is where the user's copy of a shipped template goes
+func (uc *ConfigDeployUseCase) overridePath(sourceTemplate string) string {
+	return filepath.Join(uc.overrideDir, templateName(sourceTemplate))
+}
+
+// templateName is a shipped template's path within the templates directory
+func templateName(sourceTemplate string) string {
+	return strings.TrimPrefix(filepath.ToSlash(sourceTemplate), "templates/")
+}
+
+func (uc *ConfigDeployUseCase) buildConfigList(components []string, homeDir string, mode installation.RenderingMode) []configservice.ConfigurationFile {
 	configs := []configservice.ConfigurationFile{}
 
 	configDir := filepath.Join(homeDir, ".config")
@@ -233,19 +582,22 @@ func (uc *ConfigDeployUseCase) buildConfigList(components []string, homeDir stri
 				TargetPath:     filepath.Join(configDir, "hypr/hyprland.conf"),
 				Permissions:    0644,
 				BackupBefore:   true,
+				ManagedBlock:   true,
 			})
 		case "waybar":
 			configs = append(configs, configservice.ConfigurationFile{
-				SourceTemplate: "templates/waybar/config.jsonc",
+				SourceTemplate: "templates/waybar/" + mode.WaybarConfigTemplate(),
 				TargetPath:     filepath.Join(configDir, "waybar/config.jsonc"),
 				Permissions:    0644,
 				BackupBefore:   true,
+				ManagedBlock:   true,
 			})
 			configs = append(configs, configservice.ConfigurationFile{
 				SourceTemplate: "templates/waybar/style.css.tmpl",
 				TargetPath:     filepath.Join(configDir, "waybar/style.css"),
 				Permissions:    0644,
 				BackupBefore:   true,
+				ManagedBlock:   true,
 			})
 		case "kitty":
 			configs = append(configs, configservice.ConfigurationFile{
@@ -253,6 +605,40 @@ func (uc *ConfigDeployUseCase) buildConfigList(components []string, homeDir stri
 				TargetPath:     filepath.Join(configDir, "kitty/kitty.conf"),
 				Permissions:    0644,
 				BackupBefore:   true,
+				ManagedBlock:   true,
+			})
+		case "alacritty":
+			configs = append(configs, configservice.ConfigurationFile{
+				SourceTemplate: "templates/alacritty/alacritty.toml.tmpl",
+				TargetPath:     filepath.Join(configDir, "alacritty/alacritty.toml"),
+				Permissions:    0644,
+				BackupBefore:   true,
+				ManagedBlock:   true,
+			})
+		case "portals":
+			configs = append(configs, configservice.ConfigurationFile{
+				SourceTemplate: "templates/xdg-desktop-portal/" + installation.PortalsConfigName,
+				TargetPath:     filepath.Join(configDir, "xdg-desktop-portal", installation.PortalsConfigName),
+				Permissions:    0644,
+				BackupBefore:   true,
+				ManagedBlock:   true,
+			})
+		case "hyprlock":
+			configs = append(configs, configservice.ConfigurationFile{
+				SourceTemplate: "templates/hyprland/hyprlock.conf",
+				TargetPath:     filepath.Join(configDir, "hypr/hyprlock.conf"),
+				Permissions:    0644,
+				BackupBefore:   true,
+				Sensitive:      true,
+				ManagedBlock:   true,
+			})
+		case "swaylock":
+			configs = append(configs, configservice.ConfigurationFile{
+				SourceTemplate: "templates/swaylock/config",
+				TargetPath:     filepath.Join(configDir, "swaylock/config"),
+				Permissions:    0644,
+				BackupBefore:   true,
+				ManagedBlock:   true,
 			})
 		case "fuzzel":
 			configs = append(configs, configservice.ConfigurationFile{
@@ -260,6 +646,7 @@ func (uc *ConfigDeployUseCase) buildConfigList(components []string, homeDir stri
 				TargetPath:     filepath.Join(configDir, "fuzzel/fuzzel.ini"),
 				Permissions:    0644,
 				BackupBefore:   true,
+				ManagedBlock:   true,
 			})
 		}
 	}
@@ -267,7 +654,59 @@ func (uc *ConfigDeployUseCase) buildConfigList(components []string, homeDir stri
 	return configs
 }
 
-func (uc *ConfigDeployUseCase) prepareTemplateVars(customVars map[string]string) templates.TemplateVars {
+// validateWeatherModule checks that the Waybar configs still parse with the
+// weather module added
+func (uc *ConfigDeployUseCase) validateWeatherModule(
+	configs []configservice.ConfigurationFile,
+	vars templates.TemplateVars,
+	weather installation.WeatherLocation,
+) error {
+	if weather.IsEmpty() {
+		return nil
+	}
+	for _, config := range configs {
+		if filepath.Ext(config.SourceTemplate) != ".jsonc" {
+			continue
+		}
+		if err := uc.templateEngine.ValidateJSONCFile(config.SourceTemplate, vars); err != nil {
+			return fmt.Errorf("weather module for %s does not render a valid Waybar config: %w", weather.City(), err)
+		}
+	}
+	return nil
+}
+
+// validateLockScreen checks that the images the lock screen shows exist
+// when its configuration is deployed
+func validateLockScreen(configs []configservice.ConfigurationFile, lock installation.LockScreenSettings, homeDir string) error {
+	deployed := false
+	for _, config := range configs {
+		if installation.IsLockScreenConfig(config.TargetPath) {
+			deployed = true
+			break
+		}
+	}
+	if !deployed {
+		return nil
+	}
+
+	_, missing := lock.DropMissingImages(homeDir, func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf("lock screen images do not exist: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (uc *ConfigDeployUseCase) prepareTemplateVars(
+	customVars map[string]string,
+	mode installation.RenderingMode,
+	accessibility installation.AccessibilitySettings,
+	weather installation.WeatherLocation,
+	portals installation.PortalSelection,
+	lockScreen installation.LockScreenSettings,
+) templates.TemplateVars {
 	// Default theme: Catppuccin Mocha colors (without # prefix)
 	vars := templates.TemplateVars{
 		// User variables
@@ -303,6 +742,60 @@ func (uc *ConfigDeployUseCase) prepareTemplateVars(customVars map[string]string)
 		"theme_lavender":  "b4befe",
 	}
 
+	// Default terminal, locker, idle and wallpaper commands
+	var alternatives installation.AlternativeSelection
+	for k, v := range alternatives.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Power profile bindings and Waybar module stay empty until installed
+	for k, v := range installation.PowerTemplateVars("") {
+		vars[k] = v
+	}
+
+	// Night light autostart and Waybar toggle stay empty until installed
+	for k, v := range installation.NightLightTemplateVars("", installation.NightLightLocation{}) {
+		vars[k] = v
+	}
+
+	// Blur, shadow and animation toggles for the rendering mode
+	for k, v := range mode.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Font and cursor sizes, reduced motion and high contrast
+	for k, v := range accessibility.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Waybar weather module, left out without a location
+	for k, v := range weather.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Single-GPU default; installations order AQ_DRM_DEVICES for the render GPU
+	var gpus installation.GPUSelection
+	for k, v := range gpus.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Portal backends for the Hyprland session
+	for k, v := range portals.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Lock screen background, avatar and clock
+	for k, v := range lockScreen.TemplateVars() {
+		vars[k] = v
+	}
+
+	// us keyboard layout and auto-detected monitors; imported settings
+	// arrive as custom variables
+	var migrated migration.Settings
+	for k, v := range migrated.TemplateVars() {
+		vars[k] = v
+	}
+
 	// Merge custom variables (can override defaults including theme)
 	for k, v := range customVars {
 		vars[k] = v
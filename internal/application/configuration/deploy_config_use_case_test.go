@@ -2,11 +2,14 @@ package configuration_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/rebelopsio/gohan/internal/application/configuration"
+	domainConfig "github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
@@ -93,6 +96,332 @@ func TestConfigDeployUseCase_Execute_DryRun(t *testing.T) {
 	}
 }
 
+func TestConfigDeployUseCase_Execute_ReportsFileActions(t *testing.T) {
+	useCase, tmpDir := setupTestUseCase(t)
+	t.Chdir(tmpDir) // Templates are resolved relative to the working directory
+	home := filepath.Join(tmpDir, "home")
+
+	createTestTemplate(t, tmpDir, "hyprland", "hyprland.conf.tmpl", "monitor = {{theme_name}}")
+	createTestTemplate(t, tmpDir, "kitty", "kitty.conf.tmpl", "font_size 11")
+
+	kittyPath := filepath.Join(home, ".config", "kitty", "kitty.conf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(kittyPath), 0755))
+	require.NoError(t, os.WriteFile(kittyPath, []byte("old"), 0644))
+
+	t.Run("dry run previews the action for each file", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), configuration.DeployConfigRequest{
+			Components: []string{"hyprland", "kitty"},
+			DryRun:     true,
+			CustomVars: map[string]string{"home": home},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.DeployedFiles, 2)
+		assert.Equal(t, "created", resp.DeployedFiles[0].Action)
+		assert.Equal(t, "updated", resp.DeployedFiles[1].Action)
+		assert.Equal(t, "templates/kitty/kitty.conf.tmpl", resp.DeployedFiles[1].SourceTemplate)
+
+		hyprlandPath := filepath.Join(home, ".config", "hypr", "hyprland.conf")
+		begin := "+# " + domainConfig.ManagedBlockBegin + " - replaced on every deploy, add your settings outside this block\n"
+		end := "+# " + domainConfig.ManagedBlockEnd + "\n"
+		assert.Equal(t, "--- /dev/null\n+++ "+hyprlandPath+"\n@@ -0,0 +1,3 @@\n"+begin+"+monitor = mocha\n"+end,
+			resp.DeployedFiles[0].Diff)
+		assert.Equal(t, "--- "+kittyPath+"\n+++ "+kittyPath+"\n@@ -1 +1,3 @@\n-old\n\\ No newline at end of file\n"+begin+"+font_size 11\n"+end,
+			resp.DeployedFiles[1].Diff)
+		assert.NoFileExists(t, hyprlandPath)
+	})
+
+	t.Run("deployment is all or nothing", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), configuration.DeployConfigRequest{
+			Components: []string{"hyprland", "kitty", "fuzzel"},
+			CustomVars: map[string]string{"home": home},
+		})
+
+		require.Error(t, err)
+		require.Len(t, resp.DeployedFiles, 3)
+		assert.Equal(t, 0, resp.SuccessfulFiles)
+		assert.Equal(t, 1, resp.FailedFiles)
+		assert.Equal(t, 2, resp.SkippedFiles)
+
+		actions := make(map[string]string, len(resp.DeployedFiles))
+		for _, file := range resp.DeployedFiles {
+			actions[file.Component] = file.Action
+		}
+		assert.Equal(t, map[string]string{"hypr": "skipped", "kitty": "skipped", "fuzzel": "failed"}, actions)
+
+		assert.NoFileExists(t, filepath.Join(home, ".config", "hypr", "hyprland.conf"))
+		content, err := os.ReadFile(kittyPath)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(content))
+	})
+
+	t.Run("deployment reports what happened to each file", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), configuration.DeployConfigRequest{
+			Components: []string{"hyprland", "kitty"},
+			CustomVars: map[string]string{"home": home},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.DeployedFiles, 2)
+		assert.Equal(t, 2, resp.SuccessfulFiles)
+		assert.Equal(t, 0, resp.FailedFiles)
+
+		hyprland := resp.DeployedFiles[0]
+		assert.Equal(t, "deployed", hyprland.Status)
+		assert.Equal(t, "created", hyprland.Action)
+		assert.Equal(t, int64(len(domainConfig.WrapManagedBlock("hyprland.conf", "monitor = mocha"))), hyprland.BytesWritten)
+		assert.Empty(t, hyprland.BackupID)
+
+		kitty := resp.DeployedFiles[1]
+		assert.Equal(t, "updated", kitty.Action)
+		assert.True(t, kitty.BackedUp)
+		assert.NotEmpty(t, kitty.BackupID)
+		assert.Equal(t, kitty.BackupID, resp.BackupID)
+	})
+
+	t.Run("progress deployment marks files after a failure as skipped", func(t *testing.T) {
+		resp, err := useCase.ExecuteWithProgress(context.Background(), configuration.DeployConfigRequest{
+			Components: []string{"fuzzel", "hyprland"},
+			CustomVars: map[string]string{"home": home},
+		}, nil)
+
+		require.Error(t, err)
+		require.Len(t, resp.DeployedFiles, 2)
+		assert.Equal(t, "failed", resp.DeployedFiles[0].Action)
+		assert.Equal(t, "skipped", resp.DeployedFiles[1].Action)
+		assert.Equal(t, 1, resp.FailedFiles)
+		assert.Equal(t, 1, resp.SkippedFiles)
+	})
+}
+
+func TestConfigDeployUseCase_Execute_Idempotent(t *testing.T) {
+	useCase, tmpDir := setupTestUseCase(t)
+	t.Chdir(tmpDir)
+	home := filepath.Join(tmpDir, "home")
+	createTestTemplate(t, tmpDir, "kitty", "kitty.conf.tmpl", "font_size 11")
+
+	request := configuration.DeployConfigRequest{
+		Components: []string{"kitty"},
+		CustomVars: map[string]string{"home": home},
+	}
+
+	first, err := useCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, first.DeployedFiles, 1)
+	assert.Equal(t, "created", first.DeployedFiles[0].Action)
+
+	second, err := useCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, second.DeployedFiles, 1)
+	assert.Equal(t, "unchanged", second.DeployedFiles[0].Action)
+	assert.Equal(t, "unchanged", second.DeployedFiles[0].Status)
+	assert.Equal(t, 1, second.UnchangedFiles)
+	assert.Equal(t, 0, second.SuccessfulFiles)
+	assert.Empty(t, second.BackupID, "unchanged files are not backed up")
+
+	request.DryRun = true
+	preview, err := useCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "unchanged", preview.DeployedFiles[0].Action)
+	assert.Empty(t, preview.DeployedFiles[0].Diff)
+}
+
+func TestConfigDeployUseCase_Execute_TemplateOverrides(t *testing.T) {
+	useCase, tmpDir := setupTestUseCase(t)
+	t.Chdir(tmpDir)
+	home := filepath.Join(tmpDir, "home")
+	createTestTemplate(t, tmpDir, "hyprland", "hyprland.conf.tmpl", "gaps_in = 5")
+	createTestTemplate(t, tmpDir, "kitty", "kitty.conf.tmpl", "font_size 11")
+
+	overrideDir := filepath.Join(tmpDir, "overrides")
+	override := filepath.Join(overrideDir, "kitty", "kitty.conf.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(override), 0755))
+	require.NoError(t, os.WriteFile(override, []byte("font_size {{theme_name}}"), 0644))
+	useCase = useCase.WithTemplateOverrides(overrideDir)
+
+	stagingDir := filepath.Join(tmpDir, "staged")
+	resp, err := useCase.Execute(context.Background(), configuration.DeployConfigRequest{
+		Components: []string{"hyprland", "kitty"},
+		CustomVars: map[string]string{"home": home},
+		StageDir:   stagingDir,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.DeployedFiles, 2)
+
+	hyprland, kitty := resp.DeployedFiles[0], resp.DeployedFiles[1]
+	assert.Equal(t, "templates/hyprland/hyprland.conf.tmpl", hyprland.SourceTemplate, "templates without a copy are used as shipped")
+	assert.Equal(t, override, kitty.SourceTemplate)
+	assert.Equal(t, "staged", kitty.Status)
+	assert.Equal(t, "created", kitty.Action)
+
+	kittyPath := filepath.Join(home, ".config", "kitty", "kitty.conf")
+	assert.Equal(t, filepath.Join(stagingDir, kittyPath), kitty.StagedPath)
+	content, err := os.ReadFile(kitty.StagedPath)
+	require.NoError(t, err)
+	assert.Equal(t, domainConfig.WrapManagedBlock(kittyPath, "font_size mocha"), string(content))
+	assert.NoFileExists(t, kittyPath, "staging leaves the target alone")
+}
+
+// countingLoginHook counts installs
+type countingLoginHook struct {
+	installs int
+}
+
+func (h *countingLoginHook) Install(ctx context.Context) error {
+	h.installs++
+	return nil
+}
+
+func TestConfigDeployUseCase_Execute_NextLogin(t *testing.T) {
+	useCase, tmpDir := setupTestUseCase(t)
+	t.Chdir(tmpDir)
+	home := filepath.Join(tmpDir, "home")
+	createTestTemplate(t, tmpDir, "hyprland", "hyprland.conf.tmpl", "gaps_in = 5")
+
+	request := configuration.DeployConfigRequest{
+		Components: []string{"hyprland"},
+		CustomVars: map[string]string{"home": home},
+		NextLogin:  true,
+	}
+	_, err := useCase.Execute(context.Background(), request)
+	assert.ErrorIs(t, err, configuration.ErrNoPendingDir)
+
+	hook := &countingLoginHook{}
+	useCase = useCase.WithPendingDeploys(filepath.Join(tmpDir, "pending"), hook)
+	hyprPath := filepath.Join(home, ".config", "hypr", "hyprland.conf")
+
+	resp, err := useCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, resp.DeployedFiles, 1)
+	assert.Equal(t, "pending", resp.DeployedFiles[0].Status)
+	assert.Equal(t, "created", resp.DeployedFiles[0].Action)
+	assert.Equal(t, 1, resp.PendingFiles)
+	assert.Equal(t, 1, hook.installs)
+	assert.NoFileExists(t, hyprPath)
+
+	pending, found, err := useCase.PendingDeployment()
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, []string{hyprPath}, pending.Targets)
+
+	applied, err := useCase.ApplyPending(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, applied)
+	assert.Equal(t, 1, applied.SuccessfulFiles)
+	assert.FileExists(t, hyprPath)
+
+	applied, err = useCase.ApplyPending(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, applied, "nothing is pending")
+}
+
+func TestConfigDeployUseCase_Execute_Conflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	templateEngine := templates.NewTemplateEngine()
+	deployer := configservice.NewConfigDeployer(templateEngine, backup.NewBackupService(filepath.Join(tmpDir, "backups"))).
+		WithRecords(configservice.NewDeployRecordStore(filepath.Join(tmpDir, configservice.DeployRecordsFileName)))
+	useCase := configuration.NewConfigDeployUseCase(deployer, templateEngine)
+
+	home := filepath.Join(tmpDir, "home")
+	target := filepath.Join(home, ".config", "kitty", "kitty.conf")
+	request := configuration.DeployConfigRequest{
+		Components: []string{"kitty"},
+		CustomVars: map[string]string{"home": home},
+	}
+
+	createTestTemplate(t, tmpDir, "kitty", "kitty.conf.tmpl", "font_size 11\n")
+	_, err := useCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+
+	// The user and the next template both change the font size
+	edited := domainConfig.WrapManagedBlock(target, "font_size 14\n")
+	require.NoError(t, os.WriteFile(target, []byte(edited), 0644))
+	createTestTemplate(t, tmpDir, "kitty", "kitty.conf.tmpl", "font_size 12\n")
+
+	resp, err := useCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, resp.DeployedFiles, 1)
+	file := resp.DeployedFiles[0]
+	assert.Equal(t, "conflict", file.Status)
+	assert.Equal(t, 1, file.Conflicts)
+	assert.Equal(t, target+domainConfig.MergeFileSuffix, file.MergePath)
+	assert.Equal(t, 1, resp.ConflictFiles)
+	assert.Zero(t, resp.SuccessfulFiles)
+
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, edited, string(content))
+}
+
+// recordingHooks records each invocation and fails the failing phase
+type recordingHooks struct {
+	failing     installation.HookPhase
+	invocations []installation.HookInvocation
+}
+
+func (h *recordingHooks) Run(ctx context.Context, invocation installation.HookInvocation) error {
+	h.invocations = append(h.invocations, invocation)
+	if invocation.Phase() == h.failing {
+		return errors.New("hook exited with status 1")
+	}
+	return nil
+}
+
+func TestConfigDeployUseCase_Execute_Hooks(t *testing.T) {
+	useCase, tmpDir := setupTestUseCase(t)
+	t.Chdir(tmpDir)
+	home := filepath.Join(tmpDir, "home")
+	createTestTemplate(t, tmpDir, "kitty", "kitty.conf.tmpl", "font_size 11")
+	kittyPath := filepath.Join(home, ".config", "kitty", "kitty.conf")
+
+	request := configuration.DeployConfigRequest{
+		Components: []string{"kitty"},
+		CustomVars: map[string]string{"home": home},
+	}
+
+	t.Run("a failing pre_deploy hook deploys nothing", func(t *testing.T) {
+		hooks := &recordingHooks{failing: installation.HookPreDeploy}
+
+		_, err := useCase.WithHooks(hooks).Execute(context.Background(), request)
+
+		require.Error(t, err)
+		assert.NoFileExists(t, kittyPath)
+		assert.Len(t, hooks.invocations, 1)
+	})
+
+	t.Run("post_deploy hooks get the written files", func(t *testing.T) {
+		hooks := &recordingHooks{}
+
+		resp, err := useCase.WithHooks(hooks).Execute(context.Background(), request)
+
+		require.NoError(t, err)
+		require.Len(t, hooks.invocations, 2)
+		assert.Equal(t, installation.HookPostDeploy, hooks.invocations[1].Phase())
+		assert.Contains(t, hooks.invocations[1].Environment(), "GOHAN_DEPLOYED_FILES="+kittyPath)
+		assert.Empty(t, resp.Warnings)
+	})
+
+	t.Run("a failing post_deploy hook is a warning", func(t *testing.T) {
+		resp, err := useCase.WithHooks(&recordingHooks{failing: installation.HookPostDeploy}).
+			ExecuteWithProgress(context.Background(), request, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Warnings, 1)
+	})
+
+	t.Run("dry runs skip the hooks", func(t *testing.T) {
+		hooks := &recordingHooks{}
+		dryRun := request
+		dryRun.DryRun = true
+
+		_, err := useCase.WithHooks(hooks).Execute(context.Background(), dryRun)
+
+		require.NoError(t, err)
+		assert.Empty(t, hooks.invocations)
+	})
+}
+
 func TestConfigDeployUseCase_Execute_RealDeployment(t *testing.T) {
 	t.Skip("Skipping real deployment test until template files are created")
 	// TODO: Uncomment when templates are added to templates/ directory
@@ -256,3 +585,57 @@ type ProgressUpdate struct {
 	FilePath  string
 	Progress  float64
 }
+
+func TestConfigDeployUseCase_Execute_LockScreen(t *testing.T) {
+	useCase, tmpDir := setupTestUseCase(t)
+	t.Chdir(tmpDir)
+	home := filepath.Join(tmpDir, "home")
+	createTestTemplate(t, tmpDir, "hyprland", "hyprlock.conf",
+		"path = {{lock_background_path}}\nblur_passes = {{lock_blur_passes}}\n{{lock_avatar}}\nclock = {{lock_clock_format}}\n")
+
+	avatar := filepath.Join(tmpDir, "face.png")
+	lock, err := installation.NewLockScreenSettings("image", "~/lock.png", avatar, "12h", 0)
+	require.NoError(t, err)
+	request := configuration.DeployConfigRequest{
+		Components: []string{"hyprlock"},
+		CustomVars: map[string]string{"home": home},
+		LockScreen: lock,
+	}
+
+	t.Run("fails when a referenced image does not exist", func(t *testing.T) {
+		_, err := useCase.Execute(context.Background(), request)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "~/lock.png")
+		assert.Contains(t, err.Error(), avatar)
+		assert.NoFileExists(t, filepath.Join(home, ".config", "hypr", "hyprlock.conf"))
+	})
+
+	t.Run("renders the background, avatar and clock", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(home, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(home, "lock.png"), []byte("png"), 0644))
+		require.NoError(t, os.WriteFile(avatar, []byte("png"), 0644))
+
+		resp, err := useCase.Execute(context.Background(), request)
+		require.NoError(t, err)
+		require.Len(t, resp.DeployedFiles, 1)
+
+		content, err := os.ReadFile(filepath.Join(home, ".config", "hypr", "hyprlock.conf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "path = ~/lock.png\nblur_passes = 0\n")
+		assert.Contains(t, string(content), "path = "+avatar)
+		assert.Contains(t, string(content), "clock = %I:%M %p")
+	})
+
+	t.Run("ignores images of lock screens that are not deployed", func(t *testing.T) {
+		createTestTemplate(t, tmpDir, "kitty", "kitty.conf.tmpl", "font_size 11")
+		missing, err := installation.NewLockScreenSettings("image", "/nonexistent/lock.png", "", "", 0)
+		require.NoError(t, err)
+
+		_, err = useCase.Execute(context.Background(), configuration.DeployConfigRequest{
+			Components: []string{"kitty"},
+			CustomVars: map[string]string{"home": home},
+			LockScreen: missing,
+		})
+		assert.NoError(t, err)
+	})
+}
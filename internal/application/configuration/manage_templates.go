@@ -0,0 +1,320 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// CreateTemplateRequest contains parameters for saving a configuration template
+type CreateTemplateRequest struct {
+	Name              string
+	Description       string
+	Author            string
+	Category          string // development, production, testing or custom (default)
+	Tags              []string
+	Components        []TemplateComponent
+	DiskRequiredBytes uint64
+	GPURequired       bool
+}
+
+// TemplateComponent is a component a template installs
+type TemplateComponent struct {
+	Name    string
+	Version string // Defaults to "latest"
+}
+
+// ListTemplatesRequest filters the templates listed; both filters are optional
+type ListTemplatesRequest struct {
+	Category string
+	Tag      string
+}
+
+// SearchTemplatesRequest contains the criteria of a combined template search;
+// a template must match all of them
+type SearchTemplatesRequest struct {
+	Query    string   // Name substring, case-insensitive
+	Category string   // Empty for any category
+	Tags     []string // Every tag must be present
+}
+
+// TagTemplateRequest contains the tags to add to and remove from a template
+type TagTemplateRequest struct {
+	Template string // Name or ID
+	Add      []string
+	Remove   []string
+}
+
+// TemplateDTO describes a saved configuration template
+type TemplateDTO struct {
+	ID                string
+	Name              string
+	Description       string
+	Author            string
+	Category          string
+	Tags              []string
+	Components        []TemplateComponent
+	DiskRequiredBytes uint64
+	GPURequired       bool
+	CreatedAt         string // RFC 3339
+	Version           int
+}
+
+// ListTemplatesResponse contains the templates matching the filters
+type ListTemplatesResponse struct {
+	Templates []TemplateDTO
+}
+
+// CreateTemplateUseCase validates and saves a new configuration template
+type CreateTemplateUseCase struct {
+	repo configuration.Repository
+}
+
+// NewCreateTemplateUseCase creates a new CreateTemplateUseCase
+func NewCreateTemplateUseCase(repo configuration.Repository) *CreateTemplateUseCase {
+	return &CreateTemplateUseCase{repo: repo}
+}
+
+// Execute saves the template. Names are unique.
+func (u *CreateTemplateUseCase) Execute(ctx context.Context, req CreateTemplateRequest) (*TemplateDTO, error) {
+	category, err := configuration.ParseConfigurationCategory(req.Category)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := configuration.NewConfigurationMetadata(req.Name, req.Description, req.Author, req.Tags, category)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]installation.ComponentSelection, 0, len(req.Components))
+	for _, comp := range req.Components {
+		name := strings.TrimSpace(comp.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: component name cannot be empty", configuration.ErrInvalidManifest)
+		}
+		version := comp.Version
+		if strings.TrimSpace(version) == "" {
+			version = "latest"
+		}
+		selection, err := installation.NewComponentSelection(installation.ComponentName(name), version, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: component %s: %v", configuration.ErrInvalidManifest, name, err)
+		}
+		components = append(components, selection)
+	}
+	manifest, err := configuration.NewConfigurationManifest(components, req.DiskRequiredBytes, req.GPURequired)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := u.repo.ExistsByName(ctx, metadata.Name().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check template name: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: %s", configuration.ErrDuplicateTemplate, metadata.Name())
+	}
+
+	template, err := configuration.NewConfigurationTemplate(metadata, manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.Save(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+
+	dto := toTemplateDTO(template)
+	return &dto, nil
+}
+
+// ListTemplatesUseCase lists saved configuration templates
+type ListTemplatesUseCase struct {
+	repo configuration.Repository
+}
+
+// NewListTemplatesUseCase creates a new ListTemplatesUseCase
+func NewListTemplatesUseCase(repo configuration.Repository) *ListTemplatesUseCase {
+	return &ListTemplatesUseCase{repo: repo}
+}
+
+// Execute lists templates, most recent first
+func (u *ListTemplatesUseCase) Execute(ctx context.Context, req ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	var tags []string
+	if req.Tag != "" {
+		tags = []string{req.Tag}
+	}
+	return searchTemplates(ctx, u.repo, SearchTemplatesRequest{Category: req.Category, Tags: tags})
+}
+
+// SearchTemplatesUseCase searches configuration templates by name, category
+// and tags at once
+type SearchTemplatesUseCase struct {
+	repo configuration.Repository
+}
+
+// NewSearchTemplatesUseCase creates a new SearchTemplatesUseCase
+func NewSearchTemplatesUseCase(repo configuration.Repository) *SearchTemplatesUseCase {
+	return &SearchTemplatesUseCase{repo: repo}
+}
+
+// Execute returns the matching templates, most recent first
+func (u *SearchTemplatesUseCase) Execute(ctx context.Context, req SearchTemplatesRequest) (*ListTemplatesResponse, error) {
+	return searchTemplates(ctx, u.repo, req)
+}
+
+// searchTemplates runs a search against the repository
+func searchTemplates(ctx context.Context, repo configuration.Repository, req SearchTemplatesRequest) (*ListTemplatesResponse, error) {
+	var category configuration.ConfigurationCategory
+	if strings.TrimSpace(req.Category) != "" {
+		parsed, err := configuration.ParseConfigurationCategory(req.Category)
+		if err != nil {
+			return nil, err
+		}
+		category = parsed
+	}
+
+	templates, err := repo.Search(ctx, configuration.NewTemplateQuery(req.Query, category, req.Tags))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search templates: %w", err)
+	}
+
+	response := &ListTemplatesResponse{Templates: make([]TemplateDTO, 0, len(templates))}
+	for _, template := range templates {
+		response.Templates = append(response.Templates, toTemplateDTO(template))
+	}
+	return response, nil
+}
+
+// ShowTemplateUseCase looks up one configuration template
+type ShowTemplateUseCase struct {
+	repo configuration.Repository
+}
+
+// NewShowTemplateUseCase creates a new ShowTemplateUseCase
+func NewShowTemplateUseCase(repo configuration.Repository) *ShowTemplateUseCase {
+	return &ShowTemplateUseCase{repo: repo}
+}
+
+// Execute returns the template with the given name or ID
+func (u *ShowTemplateUseCase) Execute(ctx context.Context, ref string) (*TemplateDTO, error) {
+	template, err := findTemplate(ctx, u.repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := toTemplateDTO(template)
+	return &dto, nil
+}
+
+// DeleteTemplateUseCase removes a configuration template
+type DeleteTemplateUseCase struct {
+	repo configuration.Repository
+}
+
+// NewDeleteTemplateUseCase creates a new DeleteTemplateUseCase
+func NewDeleteTemplateUseCase(repo configuration.Repository) *DeleteTemplateUseCase {
+	return &DeleteTemplateUseCase{repo: repo}
+}
+
+// Execute removes the template with the given name or ID
+func (u *DeleteTemplateUseCase) Execute(ctx context.Context, ref string) error {
+	template, err := findTemplate(ctx, u.repo, ref)
+	if err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, template.ID())
+}
+
+// TagTemplateUseCase adds and removes tags on a configuration template
+type TagTemplateUseCase struct {
+	repo configuration.Repository
+}
+
+// NewTagTemplateUseCase creates a new TagTemplateUseCase
+func NewTagTemplateUseCase(repo configuration.Repository) *TagTemplateUseCase {
+	return &TagTemplateUseCase{repo: repo}
+}
+
+// Execute updates the tags; removals are applied after additions
+func (u *TagTemplateUseCase) Execute(ctx context.Context, req TagTemplateRequest) (*TemplateDTO, error) {
+	template, err := findTemplate(ctx, u.repo, req.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	remove := make(map[string]bool, len(req.Remove))
+	for _, tag := range req.Remove {
+		remove[strings.TrimSpace(tag)] = true
+	}
+	var tags []string
+	for _, tag := range append(template.Metadata().Tags(), req.Add...) {
+		if !remove[strings.TrimSpace(tag)] {
+			tags = append(tags, tag)
+		}
+	}
+
+	if err := template.Retag(tags); err != nil {
+		return nil, err
+	}
+	if err := u.repo.Save(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+
+	dto := toTemplateDTO(template)
+	return &dto, nil
+}
+
+// findTemplate looks a template up by name, then by ID
+func findTemplate(ctx context.Context, repo configuration.Repository, ref string) (*configuration.ConfigurationTemplate, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("%w: no name given", configuration.ErrTemplateNotFound)
+	}
+
+	template, err := repo.FindByName(ctx, ref)
+	if err == nil {
+		return template, nil
+	}
+	if !errors.Is(err, configuration.ErrTemplateNotFound) {
+		return nil, err
+	}
+
+	template, err = repo.FindByID(ctx, ref)
+	if errors.Is(err, configuration.ErrTemplateNotFound) {
+		return nil, fmt.Errorf("%w: %s", configuration.ErrTemplateNotFound, ref)
+	}
+	return template, err
+}
+
+// toTemplateDTO converts a template for presentation
+func toTemplateDTO(template *configuration.ConfigurationTemplate) TemplateDTO {
+	metadata := template.Metadata()
+	manifest := template.Manifest()
+
+	components := make([]TemplateComponent, 0, manifest.ComponentCount())
+	for _, comp := range manifest.Components() {
+		components = append(components, TemplateComponent{
+			Name:    comp.Component().String(),
+			Version: comp.Version(),
+		})
+	}
+
+	return TemplateDTO{
+		ID:                template.ID(),
+		Name:              metadata.Name().String(),
+		Description:       metadata.Description(),
+		Author:            metadata.Author(),
+		Category:          string(metadata.Category()),
+		Tags:              metadata.Tags(),
+		Components:        components,
+		DiskRequiredBytes: manifest.DiskRequiredBytes(),
+		GPURequired:       manifest.GPURequired(),
+		CreatedAt:         template.CreatedAt().Format(time.RFC3339),
+		Version:           template.Version(),
+	}
+}
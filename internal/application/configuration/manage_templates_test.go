@@ -0,0 +1,154 @@
+package configuration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/configuration"
+	domain "github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/infrastructure/configuration/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveTemplate(t *testing.T, repo domain.Repository, name, category string, tags ...string) *configuration.TemplateDTO {
+	t.Helper()
+
+	template, err := configuration.NewCreateTemplateUseCase(repo).Execute(context.Background(), configuration.CreateTemplateRequest{
+		Name:     name,
+		Category: category,
+		Tags:     tags,
+		Components: []configuration.TemplateComponent{
+			{Name: "hyprland", Version: "0.41.2"},
+			{Name: "waybar"},
+		},
+	})
+	require.NoError(t, err)
+	return template
+}
+
+func TestCreateTemplateUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves a valid template", func(t *testing.T) {
+		repo := repository.NewMemoryRepository()
+		template := saveTemplate(t, repo, "laptop", "", "minimal")
+
+		assert.Equal(t, "custom", template.Category)
+		assert.Equal(t, "latest", template.Components[1].Version, "missing versions default to latest")
+		assert.Equal(t, 1, repo.Count())
+	})
+
+	t.Run("rejects a duplicate name", func(t *testing.T) {
+		repo := repository.NewMemoryRepository()
+		saveTemplate(t, repo, "laptop", "")
+
+		_, err := configuration.NewCreateTemplateUseCase(repo).Execute(ctx, configuration.CreateTemplateRequest{
+			Name:       "laptop",
+			Components: []configuration.TemplateComponent{{Name: "hyprland"}},
+		})
+		assert.ErrorIs(t, err, domain.ErrDuplicateTemplate)
+	})
+
+	t.Run("validates the request", func(t *testing.T) {
+		create := configuration.NewCreateTemplateUseCase(repository.NewMemoryRepository())
+
+		_, err := create.Execute(ctx, configuration.CreateTemplateRequest{
+			Name:       "no-core",
+			Components: []configuration.TemplateComponent{{Name: "waybar"}},
+		})
+		assert.ErrorIs(t, err, domain.ErrMissingCoreComponent)
+
+		_, err = create.Execute(ctx, configuration.CreateTemplateRequest{
+			Name:       "bad-category",
+			Category:   "gaming",
+			Components: []configuration.TemplateComponent{{Name: "hyprland"}},
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidCategory)
+
+		_, err = create.Execute(ctx, configuration.CreateTemplateRequest{
+			Components: []configuration.TemplateComponent{{Name: "hyprland"}},
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidConfigurationName)
+	})
+}
+
+func TestListTemplatesUseCase(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	saveTemplate(t, repo, "work", "development", "laptop")
+	saveTemplate(t, repo, "home", "custom", "laptop")
+	saveTemplate(t, repo, "ci", "testing")
+
+	list := configuration.NewListTemplatesUseCase(repo)
+	names := func(req configuration.ListTemplatesRequest) []string {
+		response, err := list.Execute(context.Background(), req)
+		require.NoError(t, err)
+		var names []string
+		for _, template := range response.Templates {
+			names = append(names, template.Name)
+		}
+		return names
+	}
+
+	assert.Len(t, names(configuration.ListTemplatesRequest{}), 3)
+	assert.ElementsMatch(t, []string{"work", "home"}, names(configuration.ListTemplatesRequest{Tag: "laptop"}))
+	assert.Equal(t, []string{"ci"}, names(configuration.ListTemplatesRequest{Category: "testing"}))
+	assert.Equal(t, []string{"work"}, names(configuration.ListTemplatesRequest{Category: "development", Tag: "laptop"}))
+}
+
+func TestSearchTemplatesUseCase(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	saveTemplate(t, repo, "work-laptop", "development", "laptop", "minimal")
+	saveTemplate(t, repo, "home-laptop", "custom", "laptop")
+	saveTemplate(t, repo, "work-desktop", "development", "minimal")
+
+	search := configuration.NewSearchTemplatesUseCase(repo)
+	names := func(req configuration.SearchTemplatesRequest) []string {
+		response, err := search.Execute(context.Background(), req)
+		require.NoError(t, err)
+		var names []string
+		for _, template := range response.Templates {
+			names = append(names, template.Name)
+		}
+		return names
+	}
+
+	assert.ElementsMatch(t, []string{"work-laptop", "work-desktop"}, names(configuration.SearchTemplatesRequest{Query: "WORK"}))
+	assert.Equal(t, []string{"work-laptop"}, names(configuration.SearchTemplatesRequest{Tags: []string{"laptop", "minimal"}}))
+	assert.Equal(t, []string{"work-desktop"}, names(configuration.SearchTemplatesRequest{Query: "desk", Category: "development", Tags: []string{"minimal"}}))
+	assert.Empty(t, names(configuration.SearchTemplatesRequest{Query: "home", Category: "development"}))
+
+	_, err := search.Execute(context.Background(), configuration.SearchTemplatesRequest{Category: "gaming"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCategory)
+}
+
+func TestTagTemplateUseCase(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	saved := saveTemplate(t, repo, "laptop", "", "minimal", "old")
+
+	template, err := configuration.NewTagTemplateUseCase(repo).Execute(context.Background(), configuration.TagTemplateRequest{
+		Template: "laptop",
+		Add:      []string{"battery"},
+		Remove:   []string{"old"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"minimal", "battery"}, template.Tags)
+	assert.Equal(t, 2, template.Version)
+
+	// Templates can also be referenced by ID
+	shown, err := configuration.NewShowTemplateUseCase(repo).Execute(context.Background(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"minimal", "battery"}, shown.Tags)
+}
+
+func TestDeleteTemplateUseCase(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	saveTemplate(t, repo, "laptop", "")
+
+	remove := configuration.NewDeleteTemplateUseCase(repo)
+	require.NoError(t, remove.Execute(ctx, "laptop"))
+	assert.Equal(t, 0, repo.Count())
+
+	assert.ErrorIs(t, remove.Execute(ctx, "laptop"), domain.ErrTemplateNotFound)
+}
@@ -0,0 +1,200 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+)
+
+// MergeFileSuffix is appended to a file's path for the merge with
+// conflicts left to resolve by hand
+const MergeFileSuffix = configuration.MergeFileSuffix
+
+// DeployRecords lists the files deployed from templates
+type DeployRecords interface {
+	List() ([]configuration.DeployedTemplate, error)
+}
+
+// UpgradeTemplatesRequest contains parameters for a template upgrade
+type UpgradeTemplatesRequest struct {
+	DryRun bool // Report the pending updates without writing anything
+}
+
+// UpgradeTemplatesResponse lists the deployed files whose template changed
+type UpgradeTemplatesResponse struct {
+	Files     []TemplateUpgradeInfo
+	Upgraded  int
+	Conflicts int
+	Failed    int
+	DryRun    bool
+}
+
+// TemplateUpgradeInfo describes the update of one deployed file
+type TemplateUpgradeInfo struct {
+	TargetPath     string
+	SourceTemplate string
+	Action         string // "re-render", "merge", "conflict" or "resolved" (a hand-resolved merge)
+	Status         string // "pending", "upgraded", "conflict", "missing" or "failed"
+	Conflicts      int
+	MergePath      string // Merge to resolve, for conflicts
+	BackupID       string // Backup holding the previous version
+	Error          string
+}
+
+// UpgradeTemplatesUseCase brings deployed files up to date with changed
+// templates. Files nobody edited are rendered again; edited files get the
+// template's changes merged with the edits, and overlapping changes are
+// left in a merge file to resolve.
+type UpgradeTemplatesUseCase struct {
+	records        DeployRecords
+	deployer       *configservice.ConfigDeployer
+	templateEngine *templates.TemplateEngine
+}
+
+// NewUpgradeTemplatesUseCase creates a new use case instance. The deployer
+// should record what it deploys, so upgraded files are merged against
+// their new rendering next time.
+func NewUpgradeTemplatesUseCase(
+	records DeployRecords,
+	deployer *configservice.ConfigDeployer,
+	templateEngine *templates.TemplateEngine,
+) *UpgradeTemplatesUseCase {
+	return &UpgradeTemplatesUseCase{
+		records:        records,
+		deployer:       deployer,
+		templateEngine: templateEngine,
+	}
+}
+
+// Execute upgrades, or with DryRun lists, the files whose template changed
+// since they were deployed
+func (uc *UpgradeTemplatesUseCase) Execute(ctx context.Context, req UpgradeTemplatesRequest) (*UpgradeTemplatesResponse, error) {
+	records, err := uc.records.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployed templates: %w", err)
+	}
+
+	response := &UpgradeTemplatesResponse{DryRun: req.DryRun}
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		template, err := os.ReadFile(record.SourceTemplate())
+		if err != nil || !record.IsOutdated(configuration.HashTemplate(template)) {
+			// Templates gohan no longer ships have nothing to upgrade to
+			continue
+		}
+
+		info := uc.upgrade(ctx, record, string(template), req.DryRun)
+		switch info.Status {
+		case "upgraded":
+			response.Upgraded++
+		case "conflict":
+			response.Conflicts++
+		case "failed":
+			response.Failed++
+		}
+		response.Files = append(response.Files, info)
+	}
+
+	return response, nil
+}
+
+// upgrade plans and, unless dryRun, applies the update of one file
+func (uc *UpgradeTemplatesUseCase) upgrade(
+	ctx context.Context,
+	record configuration.DeployedTemplate,
+	template string,
+	dryRun bool,
+) TemplateUpgradeInfo {
+	info := TemplateUpgradeInfo{
+		TargetPath:     record.TargetPath(),
+		SourceTemplate: record.SourceTemplate(),
+	}
+	fail := func(err error) TemplateUpgradeInfo {
+		info.Status = "failed"
+		info.Error = err.Error()
+		return info
+	}
+
+	stat, err := os.Stat(record.TargetPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		// Removed by the user; gohan config deploy brings it back
+		info.Status = "missing"
+		return info
+	}
+	if err != nil {
+		return fail(err)
+	}
+	current, err := os.ReadFile(record.TargetPath())
+	if err != nil {
+		return fail(err)
+	}
+
+	vars := templates.TemplateVars(record.Vars())
+	updated, err := uc.templateEngine.ProcessTemplate(template, vars)
+	if err != nil {
+		return fail(err)
+	}
+	managed := configuration.HasManagedBlock(record.Rendered())
+	if managed {
+		updated = configuration.WrapManagedBlock(record.TargetPath(), updated)
+	}
+
+	// A merge resolved by hand since the last run is used as is
+	mergePath := record.TargetPath() + MergeFileSuffix
+	plan := configuration.PlanTemplateUpgrade(record, string(current), updated)
+	content := plan.Content()
+	info.Action = plan.Action().String()
+	info.Conflicts = plan.Conflicts()
+	if resolved, err := os.ReadFile(mergePath); err == nil {
+		info.MergePath = mergePath
+		if configuration.HasConflictMarkers(string(resolved)) {
+			info.Action = configuration.UpgradeConflict.String()
+			info.Status = "conflict"
+			return info
+		}
+		content = string(resolved)
+		info.Action = "resolved"
+		info.Conflicts = 0
+	}
+
+	if dryRun {
+		info.Status = "pending"
+		return info
+	}
+
+	if info.Action == configuration.UpgradeConflict.String() {
+		if err := os.WriteFile(mergePath, []byte(content), stat.Mode().Perm()); err != nil {
+			return fail(fmt.Errorf("failed to write merge: %w", err))
+		}
+		info.MergePath = mergePath
+		info.Status = "conflict"
+		return info
+	}
+
+	result, err := uc.deployer.DeployMerged(ctx, configservice.ConfigurationFile{
+		SourceTemplate: record.SourceTemplate(),
+		TargetPath:     record.TargetPath(),
+		Permissions:    stat.Mode().Perm(),
+		BackupBefore:   true,
+		ManagedBlock:   managed,
+	}, vars, content)
+	if err != nil {
+		return fail(err)
+	}
+	if info.Action == "resolved" {
+		_ = os.Remove(mergePath)
+	}
+
+	info.Status = "upgraded"
+	info.BackupID = result.BackupID
+	return info
+}
@@ -0,0 +1,201 @@
+package configuration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/configuration"
+	domainConfig "github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// templateUpgradeFixture deploys a template, so that tests can edit the
+// deployed file and change the template before upgrading
+type templateUpgradeFixture struct {
+	useCase  *configuration.UpgradeTemplatesUseCase
+	deployer *configservice.ConfigDeployer
+	records  *configservice.DeployRecordStore
+	template string
+	target   string
+}
+
+func newTemplateUpgradeFixture(t *testing.T, template string) *templateUpgradeFixture {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	templateEngine := templates.NewTemplateEngine()
+	records := configservice.NewDeployRecordStore(filepath.Join(tmpDir, configservice.DeployRecordsFileName))
+	deployer := configservice.NewConfigDeployer(templateEngine, backup.NewBackupService(filepath.Join(tmpDir, "backups"))).
+		WithRecords(records)
+
+	f := &templateUpgradeFixture{
+		useCase:  configuration.NewUpgradeTemplatesUseCase(records, deployer, templateEngine),
+		deployer: deployer,
+		records:  records,
+		template: filepath.Join(tmpDir, "templates", "test.conf.tmpl"),
+		target:   filepath.Join(tmpDir, "config", "test.conf"),
+	}
+	require.NoError(t, os.MkdirAll(filepath.Dir(f.template), 0755))
+	f.writeTemplate(t, template)
+
+	err := deployer.DeployConfiguration(context.Background(), configservice.ConfigurationFile{
+		SourceTemplate: f.template,
+		TargetPath:     f.target,
+		Permissions:    0644,
+	}, templates.TemplateVars{"username": "alice"})
+	require.NoError(t, err)
+	return f
+}
+
+// deployManaged redeploys the target inside a managed block
+func (f *templateUpgradeFixture) deployManaged(t *testing.T) {
+	t.Helper()
+	err := f.deployer.DeployConfiguration(context.Background(), configservice.ConfigurationFile{
+		SourceTemplate: f.template,
+		TargetPath:     f.target,
+		Permissions:    0644,
+		ManagedBlock:   true,
+	}, templates.TemplateVars{"username": "alice"})
+	require.NoError(t, err)
+}
+
+func (f *templateUpgradeFixture) writeTemplate(t *testing.T, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(f.template, []byte(content), 0644))
+}
+
+func (f *templateUpgradeFixture) editTarget(t *testing.T, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(f.target, []byte(content), 0644))
+}
+
+func (f *templateUpgradeFixture) targetContent(t *testing.T) string {
+	t.Helper()
+	content, err := os.ReadFile(f.target)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func (f *templateUpgradeFixture) upgrade(t *testing.T, dryRun bool) *configuration.UpgradeTemplatesResponse {
+	t.Helper()
+	resp, err := f.useCase.Execute(context.Background(), configuration.UpgradeTemplatesRequest{DryRun: dryRun})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestUpgradeTemplatesUseCase_Execute(t *testing.T) {
+	t.Run("skips files whose template is unchanged", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+		f.editTarget(t, "user = alice\nmine\n")
+
+		resp := f.upgrade(t, false)
+		assert.Empty(t, resp.Files)
+		assert.Equal(t, "user = alice\nmine\n", f.targetContent(t))
+	})
+
+	t.Run("lists pending updates on a dry run without writing", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\ngaps = 5\n")
+		f.writeTemplate(t, "user = {{username}}\ngaps = 10\n")
+
+		resp := f.upgrade(t, true)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "re-render", resp.Files[0].Action)
+		assert.Equal(t, "pending", resp.Files[0].Status)
+		assert.Equal(t, "user = alice\ngaps = 5\n", f.targetContent(t))
+	})
+
+	t.Run("re-renders files nobody edited with the recorded variables", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\ngaps = 5\n")
+		f.writeTemplate(t, "user = {{username}}\ngaps = 10\n")
+
+		resp := f.upgrade(t, false)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "re-render", resp.Files[0].Action)
+		assert.Equal(t, "upgraded", resp.Files[0].Status)
+		assert.NotEmpty(t, resp.Files[0].BackupID)
+		assert.Equal(t, 1, resp.Upgraded)
+		assert.Equal(t, "user = alice\ngaps = 10\n", f.targetContent(t))
+
+		// The upgrade is recorded, so there is nothing left to do
+		assert.Empty(t, f.upgrade(t, false).Files)
+	})
+
+	t.Run("merges the template's changes with the user's edits", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\ngaps = 5\nlayout = dwindle\nborder = 2\n")
+		f.editTarget(t, "user = alice\ngaps = 8\nlayout = dwindle\nborder = 2\n")
+		f.writeTemplate(t, "user = {{username}}\ngaps = 5\nlayout = dwindle\nborder = 3\n")
+
+		resp := f.upgrade(t, false)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "merge", resp.Files[0].Action)
+		assert.Equal(t, "upgraded", resp.Files[0].Status)
+		assert.Equal(t, "user = alice\ngaps = 8\nlayout = dwindle\nborder = 3\n", f.targetContent(t))
+
+		// Later upgrades merge against the new rendering, keeping the edit
+		f.writeTemplate(t, "user = {{username}}\ngaps = 5\nlayout = dwindle\nborder = 3\nrounding = 4\n")
+		f.upgrade(t, false)
+		assert.Equal(t, "user = alice\ngaps = 8\nlayout = dwindle\nborder = 3\nrounding = 4\n", f.targetContent(t))
+	})
+
+	t.Run("upgrades the managed block of files with user additions", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+		f.deployManaged(t)
+		f.editTarget(t, f.targetContent(t)+"mine\n")
+		f.writeTemplate(t, "user = {{username}}\nshell = zsh\n")
+
+		resp := f.upgrade(t, false)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "upgraded", resp.Files[0].Status)
+		assert.Equal(t, domainConfig.WrapManagedBlock(f.target, "user = alice\nshell = zsh\n")+"mine\n", f.targetContent(t))
+	})
+
+	t.Run("leaves conflicts in a merge file until they are resolved", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\ngaps = 5\n")
+		f.editTarget(t, "user = alice\ngaps = 8\n")
+		f.writeTemplate(t, "user = {{username}}\ngaps = 10\n")
+
+		resp := f.upgrade(t, false)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "conflict", resp.Files[0].Status)
+		assert.Equal(t, 1, resp.Files[0].Conflicts)
+		assert.Equal(t, f.target+configuration.MergeFileSuffix, resp.Files[0].MergePath)
+		assert.Equal(t, 1, resp.Conflicts)
+		assert.Equal(t, "user = alice\ngaps = 8\n", f.targetContent(t))
+
+		merge, err := os.ReadFile(resp.Files[0].MergePath)
+		require.NoError(t, err)
+		assert.Contains(t, string(merge), "<<<<<<< your changes\ngaps = 8\n=======\ngaps = 10\n>>>>>>> updated template\n")
+
+		// Unresolved, it is reported again
+		resp = f.upgrade(t, false)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "conflict", resp.Files[0].Status)
+
+		// Resolved, it is applied and removed
+		require.NoError(t, os.WriteFile(resp.Files[0].MergePath, []byte("user = alice\ngaps = 9\n"), 0644))
+		resp = f.upgrade(t, false)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "resolved", resp.Files[0].Action)
+		assert.Equal(t, "upgraded", resp.Files[0].Status)
+		assert.Equal(t, "user = alice\ngaps = 9\n", f.targetContent(t))
+		assert.NoFileExists(t, f.target+configuration.MergeFileSuffix)
+		assert.Empty(t, f.upgrade(t, false).Files)
+	})
+
+	t.Run("reports removed files", func(t *testing.T) {
+		f := newTemplateUpgradeFixture(t, "user = {{username}}\n")
+		require.NoError(t, os.Remove(f.target))
+		f.writeTemplate(t, "name = {{username}}\n")
+
+		resp := f.upgrade(t, false)
+		require.Len(t, resp.Files, 1)
+		assert.Equal(t, "missing", resp.Files[0].Status)
+		assert.NoFileExists(t, f.target)
+	})
+}
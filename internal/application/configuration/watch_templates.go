@@ -0,0 +1,315 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+)
+
+// ErrNoTemplateOverrides is returned when watching without a template
+// override directory
+var ErrNoTemplateOverrides = errors.New("no template override directory configured")
+
+// DefaultWatchInterval is how often the override directory is checked
+// when the request does not say
+const DefaultWatchInterval = 500 * time.Millisecond
+
+// watchableComponents are the components whose templates a change can
+// affect when the request names none
+var watchableComponents = []string{"hyprland", "waybar", "kitty", "alacritty", "portals", "hyprlock", "swaylock", "fuzzel"}
+
+// ConfigReloader makes the running desktop components read their deployed
+// configuration again and returns the ones it reloaded
+type ConfigReloader interface {
+	Reload(ctx context.Context) ([]string, error)
+}
+
+// WatchTemplatesRequest contains parameters for watching the template
+// override directory
+type WatchTemplatesRequest struct {
+	Deploy     DeployConfigRequest // Components and settings to render with; empty components watch them all
+	StagingDir string              // Where affected files are rendered and validated
+	Apply      bool                // Deploy the files that validate and reload the desktop
+	Interval   time.Duration       // How often to check for changes; zero takes DefaultWatchInterval
+}
+
+// TemplateChangeEvent reports what was done about one set of changed
+// templates
+type TemplateChangeEvent struct {
+	Templates  []string           // Changed files, relative to the override directory
+	Components []string           // Components rendered from them; empty when none is
+	Staged     []DeployedFileInfo // The affected files rendered to the staging directory
+	Problems   []string           // Why rendered files are invalid; nothing is applied while there are any
+	Deployed   []DeployedFileInfo // Files deployed, with Apply
+	Reloaded   []string           // Components reloaded, with Apply
+	Err        error              // Rendering, deploying or reloading failed
+}
+
+// WatchTemplatesUseCase re-renders configuration whenever the user's
+// template copies change, so template edits can be checked as they are
+// made
+type WatchTemplatesUseCase struct {
+	deploy   *ConfigDeployUseCase
+	reloader ConfigReloader // Optional
+}
+
+// NewWatchTemplatesUseCase creates a new use case instance. The deploy use
+// case needs template overrides.
+func NewWatchTemplatesUseCase(deploy *ConfigDeployUseCase) *WatchTemplatesUseCase {
+	return &WatchTemplatesUseCase{deploy: deploy}
+}
+
+// WithReloader returns a copy of the use case that reloads the desktop
+// after applying changes
+func (uc *WatchTemplatesUseCase) WithReloader(reloader ConfigReloader) *WatchTemplatesUseCase {
+	copied := *uc
+	copied.reloader = reloader
+	return &copied
+}
+
+// OverrideDir returns the directory watched
+func (uc *WatchTemplatesUseCase) OverrideDir() string {
+	return uc.deploy.overrideDir
+}
+
+// Execute watches the override directory until ctx is done, calling
+// onChange after handling each set of changes. Changed templates are
+// rendered to the staging directory and validated; with Apply, files that
+// validate are deployed and the desktop reloaded.
+func (uc *WatchTemplatesUseCase) Execute(
+	ctx context.Context,
+	req WatchTemplatesRequest,
+	onChange func(TemplateChangeEvent),
+) error {
+	dir := uc.deploy.overrideDir
+	if dir == "" {
+		return ErrNoTemplateOverrides
+	}
+	if req.StagingDir == "" {
+		return fmt.Errorf("staging directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create template override directory: %w", err)
+	}
+	interval := req.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	previous, err := snapshotTemplates(dir)
+	if err != nil {
+		return err
+	}
+
+	// Changes are handled once the directory stays the same for an
+	// interval, so a save written in several steps is handled once
+	pending := make(map[string]bool)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := snapshotTemplates(dir)
+		if err != nil {
+			return err
+		}
+		changed := changedTemplates(previous, current)
+		previous = current
+		for _, name := range changed {
+			pending[name] = true
+		}
+		if len(changed) > 0 || len(pending) == 0 {
+			continue
+		}
+
+		names := make([]string, 0, len(pending))
+		for name := range pending {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		pending = make(map[string]bool)
+
+		event := uc.handle(ctx, req, names)
+		if onChange != nil {
+			onChange(event)
+		}
+	}
+}
+
+// handle renders and validates the files affected by changed templates,
+// and deploys them with Apply
+func (uc *WatchTemplatesUseCase) handle(ctx context.Context, req WatchTemplatesRequest, changed []string) TemplateChangeEvent {
+	event := TemplateChangeEvent{
+		Templates:  changed,
+		Components: uc.affectedComponents(changed, req.Deploy),
+	}
+	if len(event.Components) == 0 {
+		return event
+	}
+
+	stage := req.Deploy
+	stage.Components = event.Components
+	stage.DryRun = false
+	stage.StageDir = req.StagingDir
+	staged, err := uc.deploy.Execute(ctx, stage)
+	if err != nil {
+		event.Err = err
+		return event
+	}
+	event.Staged = staged.DeployedFiles
+	event.Problems = validateStaged(staged.DeployedFiles)
+	if !req.Apply || len(event.Problems) > 0 {
+		return event
+	}
+
+	deploy := req.Deploy
+	deploy.Components = event.Components
+	deploy.DryRun = false
+	deploy.StageDir = ""
+	deployed, err := uc.deploy.Execute(ctx, deploy)
+	if deployed != nil {
+		event.Deployed = deployed.DeployedFiles
+	}
+	if err != nil {
+		event.Err = err
+		return event
+	}
+	if deployed.FailedFiles > 0 {
+		event.Err = fmt.Errorf("%d files failed to deploy", deployed.FailedFiles)
+		return event
+	}
+	if uc.reloader == nil || deployed.SuccessfulFiles == 0 {
+		return event
+	}
+
+	event.Reloaded, err = uc.reloader.Reload(ctx)
+	if err != nil {
+		event.Err = fmt.Errorf("reload failed: %w", err)
+	}
+	return event
+}
+
+// affectedComponents returns the components rendering any of the changed
+// templates, among the requested ones
+func (uc *WatchTemplatesUseCase) affectedComponents(changed []string, req DeployConfigRequest) []string {
+	candidates := req.Components
+	if len(candidates) == 0 {
+		candidates = watchableComponents
+	}
+
+	names := make(map[string]bool, len(changed))
+	for _, name := range changed {
+		names[filepath.ToSlash(name)] = true
+	}
+
+	var affected []string
+	for _, component := range candidates {
+		for _, config := range uc.deploy.buildConfigList([]string{component}, "", req.RenderingMode) {
+			if names[templateName(config.SourceTemplate)] {
+				affected = append(affected, component)
+				break
+			}
+		}
+	}
+	return affected
+}
+
+// validateStaged checks the staged files: JSON configs must parse and no
+// placeholder may be left unreplaced
+func validateStaged(files []DeployedFileInfo) []string {
+	var problems []string
+	for _, file := range files {
+		if file.Error != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", file.TargetPath, file.Error))
+			continue
+		}
+		content, err := os.ReadFile(file.StagedPath)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", file.TargetPath, err))
+			continue
+		}
+		if ext := filepath.Ext(file.TargetPath); ext == ".json" || ext == ".jsonc" {
+			if err := templates.ValidateJSONC(string(content)); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", file.TargetPath, err))
+			}
+		}
+		if names := templates.UnresolvedPlaceholders(string(content)); len(names) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: unknown template variables %s", file.TargetPath, strings.Join(names, ", ")))
+		}
+	}
+	return problems
+}
+
+// templateStamp is what a change to a template file alters
+type templateStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshotTemplates stamps each template file under dir by its path
+// relative to dir. Hidden files and editor backups are left out.
+func snapshotTemplates(dir string) (map[string]templateStamp, error) {
+	snapshot := make(map[string]templateStamp)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // Removed while walking
+			}
+			return err
+		}
+		name := entry.Name()
+		if path != dir && strings.HasPrefix(name, ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || strings.HasSuffix(name, "~") || strings.HasSuffix(name, ".swp") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil // Removed while walking
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		snapshot[rel] = templateStamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template override directory: %w", err)
+	}
+	return snapshot, nil
+}
+
+// changedTemplates returns the files added, modified or removed between
+// two snapshots, sorted
+func changedTemplates(previous, current map[string]templateStamp) []string {
+	var changed []string
+	for name, stamp := range current {
+		if before, ok := previous[name]; !ok || before != stamp {
+			changed = append(changed, name)
+		}
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,140 @@
+package configuration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/configuration"
+	domainConfig "github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubReloader counts reloads
+type stubReloader struct {
+	reloads int
+}
+
+func (r *stubReloader) Reload(ctx context.Context) ([]string, error) {
+	r.reloads++
+	return []string{"kitty"}, nil
+}
+
+// watchTemplates runs the watch in the background and returns its events
+func watchTemplates(
+	t *testing.T,
+	useCase *configuration.WatchTemplatesUseCase,
+	req configuration.WatchTemplatesRequest,
+) <-chan configuration.TemplateChangeEvent {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan configuration.TemplateChangeEvent, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- useCase.Execute(ctx, req, func(event configuration.TemplateChangeEvent) {
+			events <- event
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	// Let the watch take its first snapshot
+	time.Sleep(50 * time.Millisecond)
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan configuration.TemplateChangeEvent) configuration.TemplateChangeEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+		return configuration.TemplateChangeEvent{}
+	}
+}
+
+func TestWatchTemplatesUseCase_Execute(t *testing.T) {
+	deploy, tmpDir := setupTestUseCase(t)
+	t.Chdir(tmpDir)
+	home := filepath.Join(tmpDir, "home")
+	createTestTemplate(t, tmpDir, "kitty", "kitty.conf.tmpl", "font_size 11")
+	createTestTemplate(t, tmpDir, "hyprland", "hyprland.conf.tmpl", "gaps_in = 5")
+
+	overrideDir := filepath.Join(tmpDir, "overrides")
+	writeOverride := func(t *testing.T, name, content string) {
+		t.Helper()
+		path := filepath.Join(overrideDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	kittyPath := filepath.Join(home, ".config", "kitty", "kitty.conf")
+	stagingDir := filepath.Join(tmpDir, "staged")
+	useCase := configuration.NewWatchTemplatesUseCase(deploy.WithTemplateOverrides(overrideDir))
+
+	t.Run("requires an override directory", func(t *testing.T) {
+		err := configuration.NewWatchTemplatesUseCase(deploy).Execute(context.Background(), configuration.WatchTemplatesRequest{
+			StagingDir: stagingDir,
+		}, nil)
+		assert.ErrorIs(t, err, configuration.ErrNoTemplateOverrides)
+	})
+
+	t.Run("stages and validates the files a changed template renders", func(t *testing.T) {
+		events := watchTemplates(t, useCase, configuration.WatchTemplatesRequest{
+			Deploy:     configuration.DeployConfigRequest{CustomVars: map[string]string{"home": home}},
+			StagingDir: stagingDir,
+			Interval:   10 * time.Millisecond,
+		})
+
+		writeOverride(t, "kitty/kitty.conf.tmpl", "font_size {{font_sizee}}")
+		event := nextEvent(t, events)
+		assert.Equal(t, []string{"kitty/kitty.conf.tmpl"}, event.Templates)
+		assert.Equal(t, []string{"kitty"}, event.Components)
+		require.NoError(t, event.Err)
+		require.Len(t, event.Staged, 1)
+		assert.Equal(t, filepath.Join(stagingDir, kittyPath), event.Staged[0].StagedPath)
+		require.Len(t, event.Problems, 1)
+		assert.Contains(t, event.Problems[0], "unknown template variables font_sizee")
+		assert.NoFileExists(t, kittyPath)
+
+		writeOverride(t, "notes.txt", "remember the gaps")
+		event = nextEvent(t, events)
+		assert.Equal(t, []string{"notes.txt"}, event.Templates)
+		assert.Empty(t, event.Components, "no component renders it")
+	})
+
+	t.Run("applies valid changes and reloads", func(t *testing.T) {
+		reloader := &stubReloader{}
+		events := watchTemplates(t, useCase.WithReloader(reloader), configuration.WatchTemplatesRequest{
+			Deploy:     configuration.DeployConfigRequest{CustomVars: map[string]string{"home": home}},
+			StagingDir: stagingDir,
+			Apply:      true,
+			Interval:   10 * time.Millisecond,
+		})
+
+		writeOverride(t, "kitty/kitty.conf.tmpl", "font_size 13")
+		event := nextEvent(t, events)
+		require.NoError(t, event.Err)
+		assert.Empty(t, event.Problems)
+		require.Len(t, event.Deployed, 1)
+		assert.Equal(t, "deployed", event.Deployed[0].Status)
+		assert.Equal(t, []string{"kitty"}, event.Reloaded)
+		assert.Equal(t, 1, reloader.reloads)
+
+		content, err := os.ReadFile(kittyPath)
+		require.NoError(t, err)
+		assert.Equal(t, domainConfig.WrapManagedBlock(kittyPath, "font_size 13"), string(content))
+
+		writeOverride(t, "kitty/kitty.conf.tmpl", "font_size {{font_sizee}}")
+		event = nextEvent(t, events)
+		assert.NotEmpty(t, event.Problems)
+		assert.Empty(t, event.Deployed, "invalid changes are not applied")
+		assert.Equal(t, 1, reloader.reloads)
+	})
+}
@@ -0,0 +1,292 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// FormatJSONL writes one JSON object per line
+const FormatJSONL = "jsonl"
+
+// SchemaVersion is written on every line. It only changes when a field is
+// renamed, removed or changes meaning; new fields are added without it.
+const SchemaVersion = 1
+
+// Line types, in the type field of every line
+const (
+	LineTypeSession = "session"
+	LineTypeEvent   = "event"
+	LineTypeHistory = "history"
+)
+
+// Event kinds, in the kind field of event lines
+const (
+	EventKindPhase     = "phase"     // A progress phase started
+	EventKindComponent = "component" // A component moved to a new state
+	EventKindWarning   = "warning"   // A warning was raised
+)
+
+// ErrUnsupportedFormat is returned for an export format other than jsonl
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// SessionLine is an installation session. Times are UTC RFC 3339.
+type SessionLine struct {
+	Type                string     `json:"type"`
+	SchemaVersion       int        `json:"schema_version"`
+	SessionID           string     `json:"session_id"`
+	Status              string     `json:"status"`
+	StartedAt           time.Time  `json:"started_at"`
+	CompletedAt         *time.Time `json:"completed_at,omitempty"` // Absent until the session finished
+	DurationMs          int64      `json:"duration_ms"`            // 0 until the session finished
+	FailureReason       string     `json:"failure_reason,omitempty"`
+	Scope               string     `json:"scope,omitempty"`
+	Components          []string   `json:"components"`
+	ComponentsInstalled int        `json:"components_installed"`
+	WarningsCount       int        `json:"warnings_count"`
+	RebootRequired      bool       `json:"reboot_required"`
+}
+
+// EventLine is a step of a session, written after the session's line in
+// the order the steps happened
+type EventLine struct {
+	Type          string    `json:"type"`
+	SchemaVersion int       `json:"schema_version"`
+	SessionID     string    `json:"session_id"`
+	Kind          string    `json:"kind"` // phase, component or warning
+	Name          string    `json:"name"` // Phase, component, or warning source
+	State         string    `json:"state,omitempty"`
+	Detail        string    `json:"detail,omitempty"` // Component error or warning message
+	At            time.Time `json:"at"`
+}
+
+// HistoryLine is an installation history record
+type HistoryLine struct {
+	Type           string        `json:"type"`
+	SchemaVersion  int           `json:"schema_version"`
+	RecordID       string        `json:"record_id"`
+	SessionID      string        `json:"session_id"`
+	Outcome        string        `json:"outcome"`
+	PackageName    string        `json:"package_name"`
+	TargetVersion  string        `json:"target_version"`
+	RecordedAt     time.Time     `json:"recorded_at"`
+	InstalledAt    time.Time     `json:"installed_at"`
+	CompletedAt    time.Time     `json:"completed_at"`
+	DurationMs     int64         `json:"duration_ms"`
+	PackageCount   int           `json:"package_count"`
+	TotalSizeBytes uint64        `json:"total_size_bytes"`
+	Packages       []PackageLine `json:"packages"`
+	FailureReason  string        `json:"failure_reason,omitempty"`
+	FailurePhase   string        `json:"failure_phase,omitempty"`
+	Hostname       string        `json:"hostname"`
+	OSVersion      string        `json:"os_version"`
+	KernelVersion  string        `json:"kernel_version"`
+	GohanVersion   string        `json:"gohan_version"`
+	Architecture   string        `json:"architecture,omitempty"`
+	GPUVendor      string        `json:"gpu_vendor,omitempty"`
+	Scope          string        `json:"scope"`
+	Warnings       []string      `json:"warnings,omitempty"`
+}
+
+// PackageLine is a package installed by a history record
+type PackageLine struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	SizeBytes uint64 `json:"size_bytes"`
+}
+
+// HistoryRecordFinder finds installation history records
+type HistoryRecordFinder interface {
+	FindAll(ctx context.Context, filter history.RecordFilter) ([]history.InstallationRecord, error)
+}
+
+// ExportDataRequest selects what to export
+type ExportDataRequest struct {
+	Format string    // Only jsonl
+	Since  time.Time // Zero exports everything
+}
+
+// ExportDataResponse counts what was exported
+type ExportDataResponse struct {
+	Sessions       int
+	Events         int
+	HistoryRecords int
+}
+
+// ExportDataUseCase writes installation sessions, their events and
+// history records for loading into external tools such as DuckDB or
+// Grafana Loki
+type ExportDataUseCase struct {
+	sessions installation.InstallationSessionRepository
+	history  HistoryRecordFinder
+}
+
+// NewExportDataUseCase creates a new use case instance
+func NewExportDataUseCase(sessions installation.InstallationSessionRepository, history HistoryRecordFinder) *ExportDataUseCase {
+	return &ExportDataUseCase{
+		sessions: sessions,
+		history:  history,
+	}
+}
+
+// Execute writes the sessions started and records made since the
+// request's time, oldest first: each session followed by its events, then
+// the history records
+func (uc *ExportDataUseCase) Execute(ctx context.Context, w io.Writer, req ExportDataRequest) (*ExportDataResponse, error) {
+	if req.Format != FormatJSONL {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, req.Format)
+	}
+
+	sessions, err := uc.sessions.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	records, err := uc.history.FindAll(ctx, history.NewRecordFilter())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load history: %w", err)
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt().Before(sessions[j].StartedAt())
+	})
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].RecordedAt().Before(records[j].RecordedAt())
+	})
+
+	encoder := json.NewEncoder(w)
+	response := &ExportDataResponse{}
+	for _, session := range sessions {
+		if session.StartedAt().Before(req.Since) {
+			continue
+		}
+		if err := encoder.Encode(sessionLine(session)); err != nil {
+			return nil, fmt.Errorf("failed to write session %s: %w", session.ID(), err)
+		}
+		response.Sessions++
+
+		for _, event := range eventLines(session) {
+			if err := encoder.Encode(event); err != nil {
+				return nil, fmt.Errorf("failed to write events of session %s: %w", session.ID(), err)
+			}
+			response.Events++
+		}
+	}
+
+	for _, record := range records {
+		if record.RecordedAt().Before(req.Since) {
+			continue
+		}
+		if err := encoder.Encode(historyLine(record)); err != nil {
+			return nil, fmt.Errorf("failed to write history record %s: %w", record.ID(), err)
+		}
+		response.HistoryRecords++
+	}
+
+	return response, nil
+}
+
+func sessionLine(session *installation.InstallationSession) SessionLine {
+	line := SessionLine{
+		Type:                LineTypeSession,
+		SchemaVersion:       SchemaVersion,
+		SessionID:           session.ID(),
+		Status:              session.Status().String(),
+		StartedAt:           session.StartedAt().UTC(),
+		FailureReason:       session.FailureReason(),
+		Scope:               session.Scope(),
+		Components:          []string{},
+		ComponentsInstalled: len(session.InstalledComponents()),
+		WarningsCount:       len(session.Warnings()),
+		RebootRequired:      session.RebootRequirement().IsRequired(),
+	}
+	if completedAt := session.CompletedAt(); !completedAt.IsZero() {
+		completedAt = completedAt.UTC()
+		line.CompletedAt = &completedAt
+		line.DurationMs = session.Duration().Milliseconds()
+	}
+	for _, component := range session.Configuration().Components() {
+		line.Components = append(line.Components, string(component.Component()))
+	}
+	return line
+}
+
+// eventLines merges a session's timeline and warnings in time order
+func eventLines(session *installation.InstallationSession) []EventLine {
+	var events []EventLine
+	for _, entry := range session.Timeline() {
+		kind := EventKindPhase
+		if entry.Kind() == installation.TimelineEntryComponent {
+			kind = EventKindComponent
+		}
+		events = append(events, EventLine{
+			Type:          LineTypeEvent,
+			SchemaVersion: SchemaVersion,
+			SessionID:     session.ID(),
+			Kind:          kind,
+			Name:          entry.Name(),
+			State:         entry.State().String(),
+			Detail:        entry.Detail(),
+			At:            entry.At().UTC(),
+		})
+	}
+	for _, warning := range session.Warnings() {
+		events = append(events, EventLine{
+			Type:          LineTypeEvent,
+			SchemaVersion: SchemaVersion,
+			SessionID:     session.ID(),
+			Kind:          EventKindWarning,
+			Name:          warning.Source().String(),
+			Detail:        warning.Message(),
+			At:            warning.RaisedAt().UTC(),
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+	return events
+}
+
+func historyLine(record history.InstallationRecord) HistoryLine {
+	metadata := record.Metadata()
+	systemContext := record.SystemContext()
+	line := HistoryLine{
+		Type:           LineTypeHistory,
+		SchemaVersion:  SchemaVersion,
+		RecordID:       record.ID().String(),
+		SessionID:      record.SessionID(),
+		Outcome:        record.Outcome().String(),
+		PackageName:    metadata.PackageName(),
+		TargetVersion:  metadata.TargetVersion(),
+		RecordedAt:     record.RecordedAt().UTC(),
+		InstalledAt:    metadata.InstalledAt().UTC(),
+		CompletedAt:    metadata.CompletedAt().UTC(),
+		DurationMs:     metadata.DurationMs(),
+		PackageCount:   metadata.PackageCount(),
+		TotalSizeBytes: metadata.TotalSizeBytes(),
+		Packages:       []PackageLine{},
+		Hostname:       systemContext.Hostname(),
+		OSVersion:      systemContext.OSVersion(),
+		KernelVersion:  systemContext.KernelVersion(),
+		GohanVersion:   systemContext.GohanVersion(),
+		Architecture:   systemContext.Architecture(),
+		GPUVendor:      systemContext.GPUVendor(),
+		Scope:          record.Scope().String(),
+		Warnings:       record.Warnings(),
+	}
+	for _, pkg := range metadata.InstalledPackages() {
+		line.Packages = append(line.Packages, PackageLine{Name: pkg.Name(), Version: pkg.Version(), SizeBytes: pkg.SizeBytes()})
+	}
+	if details := record.FailureDetails(); details != nil {
+		line.FailureReason = details.Reason()
+		line.FailurePhase = details.Phase()
+	}
+	return line
+}
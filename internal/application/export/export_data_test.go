@@ -0,0 +1,145 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/export"
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// fakeHistory returns a fixed set of records
+type fakeHistory struct {
+	records []history.InstallationRecord
+}
+
+func (f fakeHistory) FindAll(ctx context.Context, filter history.RecordFilter) ([]history.InstallationRecord, error) {
+	return f.records, nil
+}
+
+var base = time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+
+func at(minutes int) time.Time {
+	return base.Add(time.Duration(minutes) * time.Minute)
+}
+
+// fixtures returns a failed session, a completed one started a month
+// later, and the history record of the completed one
+func fixtures(t *testing.T) (*repository.MemorySessionRepository, fakeHistory) {
+	t.Helper()
+	ctx := context.Background()
+
+	hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.45.0", nil)
+	require.NoError(t, err)
+	waybar, err := installation.NewComponentSelection(installation.ComponentWaybar, "0.11.0", nil)
+	require.NoError(t, err)
+	config, err := installation.NewInstallationConfiguration(
+		[]installation.ComponentSelection{hyprland, waybar}, nil, installation.DiskSpace{}, false)
+	require.NoError(t, err)
+
+	entry := func(kind installation.TimelineEntryKind, name string, state installation.ComponentState, detail string, minutes int) installation.TimelineEntry {
+		e, err := installation.ReconstructTimelineEntry(kind, name, state, detail, at(minutes))
+		require.NoError(t, err)
+		return e
+	}
+
+	sessions := repository.NewMemorySessionRepository()
+
+	failed, err := installation.ReconstructInstallationSession(
+		"0b6e2f14-failed", config, installation.StatusFailed, nil, nil, at(0), at(4), "installation of waybar failed")
+	require.NoError(t, err)
+	failed.RestoreTimeline([]installation.TimelineEntry{
+		entry(installation.TimelineEntryPhase, "Preflight", "", "", 0),
+		entry(installation.TimelineEntryPhase, "Installing", "", "", 1),
+		entry(installation.TimelineEntryComponent, "waybar", installation.ComponentStateInstalling, "", 2),
+		entry(installation.TimelineEntryComponent, "waybar", installation.ComponentStateFailed, "apt-get exited with status 100", 4),
+	})
+	warning, err := installation.ReconstructInstallationWarning(installation.WarningSourceAvailability, "rofi-wayland is not available", at(1))
+	require.NoError(t, err)
+	failed.AddWarning(warning)
+	require.NoError(t, sessions.Save(ctx, failed))
+
+	installed, err := installation.NewInstalledComponent(installation.ComponentHyprland, "0.45.0", nil)
+	require.NoError(t, err)
+	completed, err := installation.ReconstructInstallationSession(
+		"7c1d9a30-completed", config, installation.StatusCompleted, nil,
+		[]*installation.InstalledComponent{installed}, at(30*24*60), at(30*24*60+6), "")
+	require.NoError(t, err)
+	completed.SetScope("system")
+	completed.SetRebootRequirement(installation.NewRebootRequirement("A new kernel was installed (linux-image-6.12.0-1-amd64)"))
+	completed.RestoreTimeline([]installation.TimelineEntry{
+		entry(installation.TimelineEntryPhase, "Installing", "", "", 30*24*60),
+		entry(installation.TimelineEntryComponent, "hyprland", installation.ComponentStateInstalling, "", 30*24*60+1),
+		entry(installation.TimelineEntryComponent, "hyprland", installation.ComponentStateConfigured, "", 30*24*60+5),
+	})
+	require.NoError(t, sessions.Save(ctx, completed))
+
+	pkg, err := history.NewInstalledPackage("hyprland", "0.45.0", 52428800)
+	require.NoError(t, err)
+	metadata, err := history.NewInstallationMetadata("hyprland", "0.45.0", at(30*24*60), at(30*24*60+6), []history.InstalledPackage{pkg})
+	require.NoError(t, err)
+	systemContext, err := history.NewSystemContext("Debian GNU/Linux 13 (trixie)", "6.12.0-1-amd64", "1.4.0", "workstation")
+	require.NoError(t, err)
+	outcome, err := history.NewInstallationOutcome("success")
+	require.NoError(t, err)
+	recordID, err := history.ParseRecordID("5a0f6c4e-8d2b-4c1a-9e3f-7b6d5c4a3b21")
+	require.NoError(t, err)
+	record, err := history.ReconstructInstallationRecord(
+		recordID, completed.ID(), outcome, metadata, systemContext.WithHardware("amd64", "amd"), nil, at(30*24*60+6))
+	require.NoError(t, err)
+
+	return sessions, fakeHistory{records: []history.InstallationRecord{record.WithScope(history.SystemScope())}}
+}
+
+func TestExportDataUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		since  time.Time
+		golden string
+		counts export.ExportDataResponse
+	}{
+		{"everything", time.Time{}, "export_all.jsonl", export.ExportDataResponse{Sessions: 2, Events: 8, HistoryRecords: 1}},
+		{"since a date", at(24 * 60), "export_since.jsonl", export.ExportDataResponse{Sessions: 1, Events: 3, HistoryRecords: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions, records := fixtures(t)
+			var out bytes.Buffer
+
+			response, err := export.NewExportDataUseCase(sessions, records).
+				Execute(ctx, &out, export.ExportDataRequest{Format: export.FormatJSONL, Since: tt.since})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.counts, *response)
+
+			golden := filepath.Join("testdata", tt.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, out.Bytes(), 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), out.String())
+		})
+	}
+
+	t.Run("rejects other formats", func(t *testing.T) {
+		sessions, records := fixtures(t)
+		_, err := export.NewExportDataUseCase(sessions, records).
+			Execute(ctx, &bytes.Buffer{}, export.ExportDataRequest{Format: "csv"})
+		assert.ErrorIs(t, err, export.ErrUnsupportedFormat)
+	})
+}
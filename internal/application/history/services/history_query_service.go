@@ -2,19 +2,29 @@ package services
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/domain/history"
 )
 
 // HistoryQueryService provides read-only operations for querying installation history
 type HistoryQueryService struct {
-	historyRepo history.Repository
+	historyRepos []history.Repository
 }
 
 // NewHistoryQueryService creates a new history query service
 func NewHistoryQueryService(historyRepo history.Repository) *HistoryQueryService {
+	return NewMergedHistoryQueryService(historyRepo)
+}
+
+// NewMergedHistoryQueryService creates a history query service presenting
+// the records of several repositories, e.g. system and per-user history,
+// as a single history ordered newest first
+func NewMergedHistoryQueryService(historyRepos ...history.Repository) *HistoryQueryService {
 	return &HistoryQueryService{
-		historyRepo: historyRepo,
+		historyRepos: historyRepos,
 	}
 }
 
@@ -23,7 +33,21 @@ func (s *HistoryQueryService) ListRecords(
 	ctx context.Context,
 	filter history.RecordFilter,
 ) ([]history.InstallationRecord, error) {
-	return s.historyRepo.FindAll(ctx, filter)
+	if len(s.historyRepos) == 1 {
+		return s.historyRepos[0].FindAll(ctx, filter)
+	}
+
+	var records []history.InstallationRecord
+	for _, repo := range s.historyRepos {
+		found, err := repo.FindAll(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, found...)
+	}
+
+	sortNewestFirst(records)
+	return records, nil
 }
 
 // GetRecordByID retrieves a specific installation record by its ID
@@ -31,7 +55,14 @@ func (s *HistoryQueryService) GetRecordByID(
 	ctx context.Context,
 	id history.RecordID,
 ) (history.InstallationRecord, error) {
-	return s.historyRepo.FindByID(ctx, id)
+	for _, repo := range s.historyRepos {
+		record, err := repo.FindByID(ctx, id)
+		if errors.Is(err, history.ErrRecordNotFound) {
+			continue
+		}
+		return record, err
+	}
+	return history.InstallationRecord{}, history.ErrRecordNotFound
 }
 
 // ListRecent retrieves the most recent installation records up to the specified limit
@@ -39,7 +70,44 @@ func (s *HistoryQueryService) ListRecent(
 	ctx context.Context,
 	limit int,
 ) ([]history.InstallationRecord, error) {
-	return s.historyRepo.FindRecent(ctx, limit)
+	if len(s.historyRepos) == 1 {
+		return s.historyRepos[0].FindRecent(ctx, limit)
+	}
+
+	var records []history.InstallationRecord
+	for _, repo := range s.historyRepos {
+		found, err := repo.FindRecent(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, found...)
+	}
+
+	sortNewestFirst(records)
+	if limit >= 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+// RecentPhaseTimings returns the phase timings of the most recent
+// successful installations among the last limit records, oldest first
+func (s *HistoryQueryService) RecentPhaseTimings(
+	ctx context.Context,
+	limit int,
+) ([][]history.PhaseTiming, error) {
+	records, err := s.ListRecent(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs [][]history.PhaseTiming
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].WasSuccessful() && records[i].HasPhaseTimings() {
+			runs = append(runs, records[i].PhaseTimings())
+		}
+	}
+	return runs, nil
 }
 
 // CountRecords returns the number of records matching the provided filter
@@ -47,5 +115,33 @@ func (s *HistoryQueryService) CountRecords(
 	ctx context.Context,
 	filter history.RecordFilter,
 ) (int, error) {
-	return s.historyRepo.Count(ctx, filter)
+	total := 0
+	for _, repo := range s.historyRepos {
+		count, err := repo.Count(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// ChangesBetween reports the packages and configuration files that changed
+// between two points in history
+func (s *HistoryQueryService) ChangesBetween(
+	ctx context.Context,
+	from, to time.Time,
+) (history.ChangeSet, error) {
+	records, err := s.ListRecords(ctx, history.NewRecordFilter())
+	if err != nil {
+		return history.ChangeSet{}, err
+	}
+	return history.ChangesBetween(records, from, to)
+}
+
+// sortNewestFirst orders records by when they were recorded, newest first
+func sortNewestFirst(records []history.InstallationRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].RecordedAt().After(records[j].RecordedAt())
+	})
 }
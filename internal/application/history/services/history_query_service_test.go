@@ -176,6 +176,78 @@ func TestHistoryQueryService_CountRecords(t *testing.T) {
 
 // Helper functions
 
+func TestHistoryQueryService_MergedRepositories(t *testing.T) {
+	ctx := context.Background()
+	systemRepo := memory.NewHistoryRepository()
+	userRepo := memory.NewHistoryRepository()
+	service := services.NewMergedHistoryQueryService(systemRepo, userRepo)
+
+	oldest := createSuccessRecordAtTime(t, "hyprland", time.Now().Add(-3*time.Hour))
+	middle := createSuccessRecordAtTime(t, "waybar", time.Now().Add(-2*time.Hour))
+	newest := createSuccessRecordAtTime(t, "kitty", time.Now().Add(-1*time.Hour))
+	require.NoError(t, systemRepo.Save(ctx, oldest))
+	require.NoError(t, userRepo.Save(ctx, middle))
+	require.NoError(t, systemRepo.Save(ctx, newest))
+
+	t.Run("lists records from every repository newest first", func(t *testing.T) {
+		records, err := service.ListRecords(ctx, history.NewRecordFilter())
+
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "kitty", records[0].PackageName())
+		assert.Equal(t, "waybar", records[1].PackageName())
+		assert.Equal(t, "hyprland", records[2].PackageName())
+	})
+
+	t.Run("limits recent records across repositories", func(t *testing.T) {
+		records, err := service.ListRecent(ctx, 2)
+
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "kitty", records[0].PackageName())
+		assert.Equal(t, "waybar", records[1].PackageName())
+	})
+
+	t.Run("finds a record in any repository", func(t *testing.T) {
+		record, err := service.GetRecordByID(ctx, middle.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, "waybar", record.PackageName())
+
+		missingID, err := history.NewRecordID()
+		require.NoError(t, err)
+		_, err = service.GetRecordByID(ctx, missingID)
+		assert.ErrorIs(t, err, history.ErrRecordNotFound)
+	})
+
+	t.Run("counts records across repositories", func(t *testing.T) {
+		count, err := service.CountRecords(ctx, history.NewRecordFilter())
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+}
+
+func TestHistoryQueryService_ChangesBetween(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryQueryService(repo)
+	ctx := context.Background()
+
+	before := createSuccessRecordAtTime(t, "hyprland", time.Now().Add(-48*time.Hour))
+	after := createSuccessRecordAtTime(t, "waybar", time.Now().Add(-1*time.Hour))
+	require.NoError(t, repo.Save(ctx, before))
+	require.NoError(t, repo.Save(ctx, after))
+
+	changes, err := service.ChangesBetween(ctx, time.Now().Add(-24*time.Hour), time.Now())
+
+	require.NoError(t, err)
+	require.Len(t, changes.Records, 1)
+	assert.Equal(t, after.ID(), changes.Records[0].ID())
+	require.Len(t, changes.Packages, 1)
+	assert.Equal(t, "waybar", changes.Packages[0].Name)
+	assert.True(t, changes.Packages[0].IsNewInstall())
+}
+
 func createSuccessRecord(t *testing.T, packageName string, installedAt time.Time) history.InstallationRecord {
 	return createSuccessRecordAtTime(t, packageName, installedAt)
 }
@@ -12,7 +12,9 @@ import (
 
 // HistoryRecordingService records installation sessions to history
 type HistoryRecordingService struct {
-	historyRepo history.Repository
+	historyRepo      history.Repository
+	contextProvider  history.SystemContextProvider
+	packageInventory history.PackageInventory
 }
 
 // NewHistoryRecordingService creates a new history recording service
@@ -22,6 +24,21 @@ func NewHistoryRecordingService(historyRepo history.Repository) *HistoryRecordin
 	}
 }
 
+// NewHistoryRecordingServiceWithProviders creates a history recording service
+// that captures the system context and the packages actually installed from
+// the given providers. Either provider may be nil.
+func NewHistoryRecordingServiceWithProviders(
+	historyRepo history.Repository,
+	contextProvider history.SystemContextProvider,
+	packageInventory history.PackageInventory,
+) *HistoryRecordingService {
+	return &HistoryRecordingService{
+		historyRepo:      historyRepo,
+		contextProvider:  contextProvider,
+		packageInventory: packageInventory,
+	}
+}
+
 // RecordInstallation creates a history record from a completed installation session
 func (s *HistoryRecordingService) RecordInstallation(
 	ctx context.Context,
@@ -47,23 +64,9 @@ func (s *HistoryRecordingService) RecordInstallation(
 	}
 
 	// Build installed packages list
-	var installedPackages []history.InstalledPackage
-	for _, comp := range session.InstalledComponents() {
-		// Determine size
-		var sizeBytes uint64 = 1024 // Default 1KB if not available
-		if comp.PackageInfo() != nil {
-			sizeBytes = comp.PackageInfo().SizeBytes()
-		}
-
-		pkg, err := history.NewInstalledPackage(
-			string(comp.Component()),
-			comp.Version(),
-			sizeBytes,
-		)
-		if err != nil {
-			return history.RecordID{}, fmt.Errorf("failed to create installed package: %w", err)
-		}
-		installedPackages = append(installedPackages, pkg)
+	installedPackages, err := s.collectInstalledPackages(ctx, session)
+	if err != nil {
+		return history.RecordID{}, err
 	}
 
 	// Get installation times
@@ -94,11 +97,16 @@ func (s *HistoryRecordingService) RecordInstallation(
 	}
 
 	// Capture system context
-	systemContext, err := s.captureSystemContext()
+	systemContext, err := s.captureSystemContext(ctx)
 	if err != nil {
 		return history.RecordID{}, fmt.Errorf("failed to capture system context: %w", err)
 	}
 
+	// Remember which GPU the user chose to render on
+	if render, ok := config.GPUs().RenderGPU(); ok {
+		systemContext = systemContext.WithRenderGPU(render.String())
+	}
+
 	// Build failure details if failed
 	var failureDetails *history.FailureDetails
 	if session.IsFailed() && session.FailureReason() != "" {
@@ -127,6 +135,107 @@ func (s *HistoryRecordingService) RecordInstallation(
 		return history.RecordID{}, fmt.Errorf("failed to create installation record: %w", err)
 	}
 
+	// Carry over warnings raised during the installation
+	warnings := make([]string, 0, len(session.Warnings()))
+	for _, w := range session.Warnings() {
+		warnings = append(warnings, w.String())
+	}
+	record = record.WithWarnings(warnings)
+
+	// Keep the record in the scope the session was started in
+	scope, err := history.ParseScope(session.Scope())
+	if err != nil {
+		return history.RecordID{}, fmt.Errorf("failed to parse session scope: %w", err)
+	}
+	record = record.WithScope(scope)
+
+	// Embed the preflight results that blocked or degraded the installation
+	if sessionChecks := session.PreflightChecks(); len(sessionChecks) > 0 {
+		checks := make([]history.PreflightCheck, 0, len(sessionChecks))
+		for _, c := range sessionChecks {
+			check, err := history.NewPreflightCheck(c.Requirement(), c.Status(), c.Actual(), c.Expected(), c.Message())
+			if err != nil {
+				return history.RecordID{}, fmt.Errorf("failed to create preflight check: %w", err)
+			}
+			checks = append(checks, check)
+		}
+		record = record.WithPreflightChecks(checks)
+	}
+
+	// Keep the package conflicts and how they were resolved
+	if resolutions := session.ConflictResolutions(); len(resolutions) > 0 {
+		conflicts := make([]history.ConflictResolution, 0, len(resolutions))
+		for _, r := range resolutions {
+			conflict, err := history.NewConflictResolution(
+				r.Conflict().PackageName(),
+				r.Conflict().ConflictingPackage(),
+				r.Conflict().Reason(),
+				r.Action().String(),
+				r.IsApplied(),
+			)
+			if err != nil {
+				return history.RecordID{}, fmt.Errorf("failed to create conflict resolution: %w", err)
+			}
+			conflicts = append(conflicts, conflict)
+		}
+		record = record.WithConflicts(conflicts)
+	}
+
+	// Keep the components left out, so a shorter installed set is explained
+	if sessionSkipped := session.SkippedComponents(); len(sessionSkipped) > 0 {
+		skipped := make([]history.SkippedComponent, 0, len(sessionSkipped))
+		for _, c := range sessionSkipped {
+			component, err := history.NewSkippedComponent(string(c.Component()), c.SkipReason().String(), c.SkipDetail())
+			if err != nil {
+				return history.RecordID{}, fmt.Errorf("failed to create skipped component: %w", err)
+			}
+			skipped = append(skipped, component)
+		}
+		record = record.WithSkippedComponents(skipped)
+	}
+
+	// Keep how far off the time estimate was, to calibrate later estimates
+	if sessionTimings := session.PhaseTimings(); len(sessionTimings) > 0 {
+		timings := make([]history.PhaseTiming, 0, len(sessionTimings))
+		for _, t := range sessionTimings {
+			timing, err := history.NewPhaseTiming(t.Phase().String(), t.Estimated(), t.Actual())
+			if err != nil {
+				return history.RecordID{}, fmt.Errorf("failed to create phase timing: %w", err)
+			}
+			timings = append(timings, timing)
+		}
+		record = record.WithPhaseTimings(timings)
+	}
+
+	// Keep the deployed config hashes so later changes can be diffed
+	if deployed := session.DeployedConfigs(); len(deployed) > 0 {
+		files := make([]history.ConfigFile, 0, len(deployed))
+		for _, d := range deployed {
+			file, err := history.NewConfigFile(d.Path(), d.Hash())
+			if err != nil {
+				return history.RecordID{}, fmt.Errorf("failed to create config file entry: %w", err)
+			}
+			files = append(files, file)
+		}
+		record = record.WithConfigFiles(files)
+	}
+
+	// Keep how gohan was run so the conditions can be reproduced
+	if invocation := session.Invocation(); !invocation.IsZero() {
+		recorded, err := history.NewInvocation(
+			invocation.CommandLine(),
+			invocation.Flags(),
+			invocation.ConfigHash(),
+			invocation.Version(),
+			invocation.Commit(),
+			invocation.User(),
+		)
+		if err != nil {
+			return history.RecordID{}, fmt.Errorf("failed to create invocation: %w", err)
+		}
+		record = record.WithInvocation(recorded)
+	}
+
 	// Save to repository
 	if err := s.historyRepo.Save(ctx, record); err != nil {
 		return history.RecordID{}, fmt.Errorf("failed to save installation record: %w", err)
@@ -135,8 +244,58 @@ func (s *HistoryRecordingService) RecordInstallation(
 	return record.ID(), nil
 }
 
+// collectInstalledPackages returns what dpkg actually installed during the
+// session, falling back to the installed components when no inventory is
+// available or it reports nothing
+func (s *HistoryRecordingService) collectInstalledPackages(
+	ctx context.Context,
+	session *installation.InstallationSession,
+) ([]history.InstalledPackage, error) {
+	if s.packageInventory != nil && !session.StartedAt().IsZero() && !session.CompletedAt().IsZero() {
+		packages, err := s.packageInventory.InstalledBetween(ctx, session.StartedAt(), session.CompletedAt())
+		if err == nil && len(packages) > 0 {
+			return packages, nil
+		}
+	}
+
+	var installedPackages []history.InstalledPackage
+	for _, comp := range session.InstalledComponents() {
+		// Determine size
+		var sizeBytes uint64 = 1024 // Default 1KB if not available
+		if comp.PackageInfo() != nil {
+			sizeBytes = comp.PackageInfo().SizeBytes()
+		}
+
+		pkg, err := history.NewInstalledPackage(
+			string(comp.Component()),
+			comp.Version(),
+			sizeBytes,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create installed package: %w", err)
+		}
+		installedPackages = append(installedPackages, pkg)
+	}
+
+	return installedPackages, nil
+}
+
+// PurgeExpired removes records older than the retention policy allows and
+// returns how many were removed
+func (s *HistoryRecordingService) PurgeExpired(ctx context.Context, policy history.RetentionPolicy) (int, error) {
+	removed, err := s.historyRepo.PurgeOlderThan(ctx, policy.CutoffDate())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge history: %w", err)
+	}
+	return removed, nil
+}
+
 // captureSystemContext captures current system information
-func (s *HistoryRecordingService) captureSystemContext() (history.SystemContext, error) {
+func (s *HistoryRecordingService) captureSystemContext(ctx context.Context) (history.SystemContext, error) {
+	if s.contextProvider != nil {
+		return s.contextProvider.CurrentSystemContext(ctx)
+	}
+
 	// Get OS version from /etc/os-release or similar
 	osVersion := s.detectOSVersion()
 
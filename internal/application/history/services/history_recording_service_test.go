@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/rebelopsio/gohan/internal/application/history/services"
+	"github.com/rebelopsio/gohan/internal/domain/history"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/infrastructure/memory"
 	"github.com/stretchr/testify/assert"
@@ -99,6 +100,39 @@ func TestHistoryRecordingService_CapturesSystemContext(t *testing.T) {
 	_ = sysCtx.Hostname()
 }
 
+type stubSystemContextProvider struct {
+	sysCtx history.SystemContext
+	err    error
+}
+
+func (p *stubSystemContextProvider) CurrentSystemContext(ctx context.Context) (history.SystemContext, error) {
+	return p.sysCtx, p.err
+}
+
+func TestHistoryRecordingService_UsesSystemContextProvider(t *testing.T) {
+	sysCtx, err := history.NewSystemContext("Debian GNU/Linux trixie/sid", "6.12.6-amd64", "1.4.0", "workstation")
+	require.NoError(t, err)
+	sysCtx = sysCtx.WithHardware("amd64", "nvidia")
+
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingServiceWithProviders(repo, &stubSystemContextProvider{sysCtx: sysCtx}, nil)
+	ctx := context.Background()
+
+	recordID, err := service.RecordInstallation(ctx, createCompletedSession(t))
+	require.NoError(t, err)
+
+	record, err := repo.FindByID(ctx, recordID)
+	require.NoError(t, err)
+
+	recorded := record.SystemContext()
+	assert.Equal(t, "Debian GNU/Linux trixie/sid", recorded.OSVersion())
+	assert.Equal(t, "6.12.6-amd64", recorded.KernelVersion())
+	assert.Equal(t, "1.4.0", recorded.GohanVersion())
+	assert.Equal(t, "workstation", recorded.Hostname())
+	assert.Equal(t, "amd64", recorded.Architecture())
+	assert.Equal(t, "nvidia", recorded.GPUVendor())
+}
+
 func TestHistoryRecordingService_CapturesInstalledPackages(t *testing.T) {
 	repo := memory.NewHistoryRepository()
 	service := services.NewHistoryRecordingService(repo)
@@ -127,6 +161,213 @@ func TestHistoryRecordingService_CapturesInstalledPackages(t *testing.T) {
 	}
 }
 
+type stubPackageInventory struct {
+	packages []history.InstalledPackage
+	err      error
+}
+
+func (i *stubPackageInventory) InstalledBetween(ctx context.Context, from, to time.Time) ([]history.InstalledPackage, error) {
+	return i.packages, i.err
+}
+
+func TestHistoryRecordingService_UsesPackageInventory(t *testing.T) {
+	t.Run("records packages dpkg actually installed", func(t *testing.T) {
+		hyprland, err := history.NewInstalledPackage("hyprland", "0.45.2-1", 12*1024*1024)
+		require.NoError(t, err)
+		aquamarine, err := history.NewInstalledPackage("libaquamarine5", "0.4.5-1", 512*1024)
+		require.NoError(t, err)
+
+		repo := memory.NewHistoryRepository()
+		inventory := &stubPackageInventory{packages: []history.InstalledPackage{hyprland, aquamarine}}
+		service := services.NewHistoryRecordingServiceWithProviders(repo, nil, inventory)
+		ctx := context.Background()
+
+		recordID, err := service.RecordInstallation(ctx, createCompletedSession(t))
+		require.NoError(t, err)
+
+		record, err := repo.FindByID(ctx, recordID)
+		require.NoError(t, err)
+
+		packages := record.Metadata().InstalledPackages()
+		require.Len(t, packages, 2)
+		assert.Equal(t, "libaquamarine5", packages[1].Name())
+		assert.Equal(t, uint64(512*1024), packages[1].SizeBytes())
+	})
+
+	t.Run("falls back to components when inventory is unavailable", func(t *testing.T) {
+		repo := memory.NewHistoryRepository()
+		inventory := &stubPackageInventory{err: assert.AnError}
+		service := services.NewHistoryRecordingServiceWithProviders(repo, nil, inventory)
+		ctx := context.Background()
+
+		session := createCompletedSession(t)
+		recordID, err := service.RecordInstallation(ctx, session)
+		require.NoError(t, err)
+
+		record, err := repo.FindByID(ctx, recordID)
+		require.NoError(t, err)
+		assert.Len(t, record.Metadata().InstalledPackages(), len(session.InstalledComponents()))
+	})
+}
+
+func TestHistoryRecordingService_EmbedsPreflightChecks(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingService(repo)
+	ctx := context.Background()
+
+	session := createFailedSession(t)
+	blocker, err := installation.NewPreflightCheck("disk_space", "fail", "5.0 GB available", "10 GB", "Free up disk space")
+	require.NoError(t, err)
+	session.RecordPreflightChecks([]installation.PreflightCheck{blocker})
+
+	recordID, err := service.RecordInstallation(ctx, session)
+	require.NoError(t, err)
+
+	record, err := repo.FindByID(ctx, recordID)
+	require.NoError(t, err)
+
+	require.True(t, record.HasPreflightChecks())
+	check := record.PreflightChecks()[0]
+	assert.Equal(t, "disk_space", check.Requirement())
+	assert.Equal(t, "5.0 GB available", check.Actual())
+	assert.Equal(t, "Free up disk space", check.Message())
+}
+
+func TestHistoryRecordingService_RecordsDeployedConfigs(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingService(repo)
+	ctx := context.Background()
+
+	session := createCompletedSession(t)
+	deployed, err := installation.NewDeployedConfig("/home/user/.config/hypr/hyprland.conf", "abc123")
+	require.NoError(t, err)
+	session.RecordDeployedConfigs([]installation.DeployedConfig{deployed})
+
+	recordID, err := service.RecordInstallation(ctx, session)
+	require.NoError(t, err)
+
+	record, err := repo.FindByID(ctx, recordID)
+	require.NoError(t, err)
+
+	require.Len(t, record.ConfigFiles(), 1)
+	assert.Equal(t, "/home/user/.config/hypr/hyprland.conf", record.ConfigFiles()[0].Path())
+	assert.Equal(t, "abc123", record.ConfigFiles()[0].Hash())
+}
+
+func TestHistoryRecordingService_RecordsInvocation(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingService(repo)
+	ctx := context.Background()
+
+	session := createCompletedSession(t)
+	invocation, err := installation.NewInvocation(
+		[]string{"gohan", "install", "--components", "hyprland,waybar"},
+		map[string]string{"components": "hyprland,waybar"},
+		"abc123", "1.2.0", "deadbeef", "root (sudo from alice)",
+	)
+	require.NoError(t, err)
+	session.SetInvocation(invocation)
+
+	recordID, err := service.RecordInstallation(ctx, session)
+	require.NoError(t, err)
+
+	record, err := repo.FindByID(ctx, recordID)
+	require.NoError(t, err)
+
+	recorded := record.Invocation()
+	assert.Equal(t, "gohan install --components hyprland,waybar", recorded.CommandLine())
+	assert.Equal(t, map[string]string{"components": "hyprland,waybar"}, recorded.Flags())
+	assert.Equal(t, "abc123", recorded.ConfigHash())
+	assert.Equal(t, "deadbeef", recorded.Commit())
+	assert.Equal(t, "root (sudo from alice)", recorded.User())
+}
+
+func TestHistoryRecordingService_RecordsRenderGPU(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingService(repo)
+	ctx := context.Background()
+
+	diskSpace, err := installation.NewDiskSpace(21474836480, 0)
+	require.NoError(t, err)
+	config, err := installation.NewInstallationConfiguration(
+		[]installation.ComponentSelection{
+			mustCreateComponent(t, installation.ComponentHyprland, "0.45.0", 15728640),
+		},
+		nil,
+		diskSpace,
+		false,
+	)
+	require.NoError(t, err)
+
+	intel, err := installation.NewGPUDevice("intel", "UHD Graphics 620", "0000:00:02.0", "/dev/dri/card0")
+	require.NoError(t, err)
+	nvidia, err := installation.NewGPUDevice("nvidia", "GeForce MX150", "0000:01:00.0", "/dev/dri/card1")
+	require.NoError(t, err)
+	gpus, err := installation.NewGPUSelection([]installation.GPUDevice{intel, nvidia}, "0000:01:00.0")
+	require.NoError(t, err)
+
+	session, err := installation.NewInstallationSession(config.WithGPUs(gpus))
+	require.NoError(t, err)
+	snapshot, err := installation.NewSystemSnapshot("/tmp/snapshots", diskSpace, []string{})
+	require.NoError(t, err)
+	require.NoError(t, session.StartPreparation(snapshot))
+	require.NoError(t, session.StartInstalling())
+	require.NoError(t, session.Fail("driver did not load"))
+
+	recordID, err := service.RecordInstallation(ctx, session)
+	require.NoError(t, err)
+
+	record, err := repo.FindByID(ctx, recordID)
+	require.NoError(t, err)
+	assert.Equal(t, "nvidia GeForce MX150 [0000:01:00.0]", record.SystemContext().RenderGPU())
+}
+
+func TestHistoryRecordingService_RecordsConflicts(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingService(repo)
+	ctx := context.Background()
+
+	session := createCompletedSession(t)
+	conflict, err := installation.NewPackageConflict("hyprland", "hyprland-git", "conflicting package versions")
+	require.NoError(t, err)
+	session.RecordConflict(conflict, installation.ActionRemove)
+	_, err = session.ResolveConflict(conflict)
+	require.NoError(t, err)
+
+	recordID, err := service.RecordInstallation(ctx, session)
+	require.NoError(t, err)
+
+	record, err := repo.FindByID(ctx, recordID)
+	require.NoError(t, err)
+
+	require.Len(t, record.Conflicts(), 1)
+	recorded := record.Conflicts()[0]
+	assert.Equal(t, "hyprland-git", recorded.ConflictingPackage())
+	assert.Equal(t, "remove", recorded.Strategy())
+	assert.True(t, recorded.Applied())
+}
+
+func TestHistoryRecordingService_RecordsSkippedComponents(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingService(repo)
+	ctx := context.Background()
+
+	session := createCompletedSessionWithMultipleComponents(t)
+	require.NoError(t, session.SkipComponent(installation.ComponentWaybar, installation.SkipReasonUnavailable, "not in the configured repositories"))
+
+	recordID, err := service.RecordInstallation(ctx, session)
+	require.NoError(t, err)
+
+	record, err := repo.FindByID(ctx, recordID)
+	require.NoError(t, err)
+
+	require.Len(t, record.SkippedComponents(), 1)
+	skipped := record.SkippedComponents()[0]
+	assert.Equal(t, "waybar", skipped.Component())
+	assert.Equal(t, "unavailable", skipped.Reason())
+	assert.Equal(t, "not in the configured repositories", skipped.Detail())
+}
+
 func TestHistoryRecordingService_CaptureDuration(t *testing.T) {
 	repo := memory.NewHistoryRepository()
 	service := services.NewHistoryRecordingService(repo)
@@ -148,8 +389,57 @@ func TestHistoryRecordingService_CaptureDuration(t *testing.T) {
 	assert.True(t, metadata.CompletedAt().After(metadata.InstalledAt()) || metadata.CompletedAt().Equal(metadata.InstalledAt()))
 }
 
+func TestHistoryRecordingService_CapturesWarnings(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingService(repo)
+	ctx := context.Background()
+
+	session := createCompletedSession(t)
+	warning, err := installation.NewInstallationWarning(installation.WarningSourcePreflight, "Low disk space")
+	require.NoError(t, err)
+	session.AddWarning(warning)
+
+	recordID, err := service.RecordInstallation(ctx, session)
+	require.NoError(t, err)
+
+	record, err := repo.FindByID(ctx, recordID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[preflight] Low disk space"}, record.Warnings())
+}
+
 // Helper functions to create test sessions
 
+func TestHistoryRecordingService_PurgeExpired(t *testing.T) {
+	repo := memory.NewHistoryRepository()
+	service := services.NewHistoryRecordingService(repo)
+	ctx := context.Background()
+
+	recentID, err := service.RecordInstallation(ctx, createCompletedSession(t))
+	require.NoError(t, err)
+	recent, err := repo.FindByID(ctx, recentID)
+	require.NoError(t, err)
+
+	old, err := history.NewInstallationRecord(
+		"old-session",
+		recent.Outcome(),
+		recent.Metadata(),
+		recent.SystemContext(),
+		nil,
+		time.Now().AddDate(0, 0, -120),
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, old))
+
+	removed, err := service.PurgeExpired(ctx, history.DefaultRetentionPolicy())
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	_, err = repo.FindByID(ctx, old.ID())
+	assert.ErrorIs(t, err, history.ErrRecordNotFound)
+	_, err = repo.FindByID(ctx, recentID)
+	assert.NoError(t, err)
+}
+
 func createCompletedSession(t *testing.T) *installation.InstallationSession {
 	// Create config
 	components := []installation.ComponentSelection{
@@ -0,0 +1,45 @@
+package dto
+
+// CatalogComponent is an installable component and the packages it is made
+// of
+type CatalogComponent struct {
+	Name         string
+	Core         bool
+	Driver       bool
+	Groups       []string
+	Packages     []string
+	Availability map[string]bool // By suite: whether every required package of the component is available
+}
+
+// CatalogPackagesRequest narrows the packages listed; empty fields match
+// every package
+type CatalogPackagesRequest struct {
+	Component string
+	Group     string
+	Suite     string // Only packages available in this suite
+}
+
+// CatalogPackage is a package gohan knows how to install
+type CatalogPackage struct {
+	Name         string
+	Component    string // Empty for packages outside any component
+	Group        string
+	Description  string
+	Required     bool // Installed by a minimal installation of its component
+	Alternatives []string
+	Availability map[string]bool // By suite
+}
+
+// CatalogProfile is an installation profile, built-in or custom
+type CatalogProfile struct {
+	ID            string // What gohan install and gohan profile diff take
+	Name          string
+	Description   string
+	Builtin       bool
+	RenderingMode string
+	Packages      []string
+	OptIn         []string // Packages offered but only installed when chosen
+	Components    []string
+	Unavailable   map[string][]string // By suite: packages of the profile not known to be available there
+	Error         string              // Why a custom profile could not be read
+}
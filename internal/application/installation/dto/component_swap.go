@@ -0,0 +1,31 @@
+package dto
+
+// SwapComponentRequest represents a request to replace an installed
+// alternative, such as the terminal, with another provider of its slot
+type SwapComponentRequest struct {
+	From string // Package being replaced
+	To   string // Replacement package
+
+	// Keep the replaced package installed instead of removing it
+	KeepPrevious bool
+
+	// How gohan was run to request the swap; nil if unknown
+	Invocation *InvocationRequest
+}
+
+// SwapComponentResponse describes a completed swap
+type SwapComponentResponse struct {
+	SessionID string // Session recording the swap in history
+	Slot      string
+	From      string
+	To        string
+
+	InstalledPackages []string
+	RemovedPackages   []string
+
+	// Template variables that changed, with their new values
+	Variables map[string]string
+
+	// Configuration files rendered again
+	ConfigFiles []string
+}
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PlanFormatVersion is the plan file format written by this version of gohan
+const PlanFormatVersion = 1
+
+// InstallationPlan is a reviewable record of what an installation will do.
+// It is written by gohan install --emit-plan and applied with --plan, on
+// the same machine or another one.
+type InstallationPlan struct {
+	FormatVersion int    `json:"format_version"`
+	CreatedAt     string `json:"created_at"` // RFC 3339
+	Hostname      string `json:"hostname,omitempty"`
+
+	Components          []PlannedComponent `json:"components"`
+	Alternatives        []string           `json:"alternatives,omitempty"`
+	RenderingMode       string             `json:"rendering_mode"`
+	Accessibility       []string           `json:"accessibility,omitempty"`
+	GPU                 *GPURequest        `json:"gpu,omitempty"`
+	MergeExistingConfig bool               `json:"merge_existing_config"`
+
+	// Enabled apt sources on the planning machine, as sources.list lines
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Configuration files deployed, relative to ~/.config
+	ConfigFiles []string `json:"config_files,omitempty"`
+
+	// Total installed size of the planned packages
+	EstimatedSizeBytes uint64 `json:"estimated_size_bytes"`
+
+	// Set once the plan is signed; covers every other field
+	Signature *PlanSignature `json:"signature,omitempty"`
+}
+
+// PlannedComponent is a component pinned to the version the plan installs
+type PlannedComponent struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	PackageName string `json:"package"`
+	SizeBytes   uint64 `json:"size_bytes,omitempty"`
+}
+
+// PlanSignature authenticates a plan
+type PlanSignature struct {
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"` // base64
+	Value     string `json:"value"`      // base64
+	Signer    string `json:"signer"`     // Fingerprint of the public key
+}
+
+// SigningPayload returns the bytes a signature covers: the plan without
+// its signature, in a stable encoding
+func (p InstallationPlan) SigningPayload() ([]byte, error) {
+	p.Signature = nil
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plan: %w", err)
+	}
+	return payload, nil
+}
+
+// Request converts the plan back into an installation request
+func (p InstallationPlan) Request() InstallationRequest {
+	components := make([]ComponentRequest, 0, len(p.Components))
+	for _, c := range p.Components {
+		components = append(components, ComponentRequest{
+			Name:        c.Name,
+			Version:     c.Version,
+			PackageName: c.PackageName,
+			SizeBytes:   c.SizeBytes,
+		})
+	}
+
+	return InstallationRequest{
+		Components:          components,
+		GPU:                 p.GPU,
+		RequiredSpace:       p.EstimatedSizeBytes,
+		MergeExistingConfig: p.MergeExistingConfig,
+		Alternatives:        p.Alternatives,
+		RenderingMode:       p.RenderingMode,
+		Accessibility:       p.Accessibility,
+	}
+}
+
+// StartFromPlanRequest represents a request to install what a plan describes
+type StartFromPlanRequest struct {
+	Plan InstallationPlan
+
+	// Fingerprints of signers trusted besides the local key
+	TrustedSigners []string
+
+	// Detected on the machine applying the plan
+	AvailableSpace uint64
+	GPUs           []GPUDeviceRequest
+	RenderGPU      string
+	Scope          string
+
+	// How gohan was run to apply the plan; nil if unknown
+	Invocation *InvocationRequest
+}
@@ -8,6 +8,12 @@ type InstallationRequest struct {
 	// GPU configuration
 	GPU *GPURequest
 
+	// Every GPU detected on the system
+	GPUs []GPUDeviceRequest
+
+	// PCI slot of the GPU Hyprland renders on; empty selects the first GPU
+	RenderGPU string
+
 	// Available disk space in bytes
 	AvailableSpace uint64
 
@@ -19,6 +25,46 @@ type InstallationRequest struct {
 
 	// Backup directory for configuration files
 	BackupDirectory string
+
+	// Chosen providers for alternative slots, as "slot=package" or a bare
+	// package name (e.g. "terminal=alacritty", "swaylock")
+	Alternatives []string
+
+	// Rendering mode: "auto" (default), "standard" or "lite"
+	RenderingMode string
+
+	// Accessibility options: "reduced-motion", "large-text", "high-contrast"
+	Accessibility []string
+
+	// History scope: "system" (default) or "user:<name>"
+	Scope string
+
+	// How gohan was run to make the request; nil if unknown
+	Invocation *InvocationRequest
+
+	// Simulate the installation: packages are not installed or removed
+	// and configuration files are rendered into the session's workspace
+	DryRun bool
+}
+
+// InvocationRequest records how gohan was run, so the conditions of a
+// failure can be reproduced
+type InvocationRequest struct {
+	// Command line, program name first
+	Args []string
+
+	// Flags that were set, by name
+	Flags map[string]string
+
+	// SHA-256 of the configuration file; empty if there was none
+	ConfigHash string
+
+	// gohan build
+	Version string
+	Commit  string
+
+	// Invoking user, e.g. "alice" or "root (sudo from alice)"
+	User string
 }
 
 // ComponentRequest represents a component to install
@@ -38,6 +84,14 @@ type GPURequest struct {
 	DriverName     string
 }
 
+// GPUDeviceRequest represents a detected GPU
+type GPUDeviceRequest struct {
+	Vendor    string
+	Model     string
+	PCISlot   string
+	DRMDevice string
+}
+
 // InstallationResponse represents the result of starting an installation
 type InstallationResponse struct {
 	SessionID   string
@@ -45,6 +99,7 @@ type InstallationResponse struct {
 	Message     string
 	StartedAt   string
 	ComponentCount int
+	DryRun      bool
 }
 
 // InstallationProgressResponse represents installation progress
@@ -57,6 +112,81 @@ type InstallationProgressResponse struct {
 	EstimatedRemaining string
 	ComponentsInstalled int
 	ComponentsTotal     int
+	ComponentsSkipped   int // Left out, see each component's SkipReason
+	WarningsCount       int
+	Warnings            []WarningDTO
+	Conflicts           []ConflictDTO
+	Components          []ComponentStatusDTO
+
+	// RFC 3339 timestamps; empty when not yet reached
+	StartedAt   string
+	UpdatedAt   string
+	CompletedAt string
+
+	// Preflight validation session run before installing; empty if none
+	PreflightSessionID string
+
+	// PreflightBlockers names the requirements that stopped the
+	// installation before it began; empty otherwise
+	PreflightBlockers []string
+
+	// ArtifactsDir is the working directory kept after a failed
+	// installation for debugging, or after a dry run with the rendered
+	// configuration; empty otherwise
+	ArtifactsDir string
+
+	// RebootRequired is set when the installation only takes full effect
+	// after a reboot; RebootReasons says why
+	RebootRequired bool
+	RebootReasons  []string
+
+	// DryRun is set when the installation was only simulated
+	DryRun bool
+
+	// PhaseTimings compares the estimate made when the installation started
+	// with how long each phase took; empty until it completes
+	PhaseTimings []PhaseTimingDTO
+}
+
+// PhaseTimingDTO represents the estimated and actual duration of a phase
+type PhaseTimingDTO struct {
+	Phase     string
+	Estimated string
+	Actual    string
+	Delta     string // Positive when the phase took longer than estimated
+}
+
+// WarningDTO represents a non-fatal issue raised during installation
+type WarningDTO struct {
+	Source   string
+	Message  string
+	RaisedAt string
+}
+
+// ConflictDTO represents a package conflict and how it was resolved
+type ConflictDTO struct {
+	PackageName        string
+	ConflictingPackage string
+	Reason             string
+	Strategy           string
+	Applied            bool
+}
+
+// ComponentStatusDTO represents where one component is in the installation
+type ComponentStatusDTO struct {
+	Name      string
+	State     string // pending, downloading, installing, configured, verified, failed, skipped
+	Error     string // Why the component failed; empty otherwise
+	UpdatedAt string
+
+	// Why a skipped component was left out: already_installed,
+	// unavailable, excluded, architecture or lite_mode; SkipDetail says
+	// what was found. Empty unless skipped.
+	SkipReason string
+	SkipDetail string
+
+	// How long verifying the component took; empty until it was verified
+	VerifyDuration string
 }
 
 // InstallationCompleteResponse represents completed installation
@@ -74,6 +204,68 @@ type InstalledComponentDTO struct {
 	Version    string
 	InstalledAt string
 	Verified   bool
+
+	// Package the component was installed from; empty if unknown
+	PackageName string
+	SizeBytes   uint64
+
+	// Outcome of verifying the component; empty until it was verified
+	VerifiedAt           string
+	VerifyDuration       string
+	VerificationProblems []string
+}
+
+// InstallationDetailResponse is everything recorded about an installation
+// session: its progress, what was requested, the snapshot taken before
+// installing, what was installed, and where to find its backups and
+// history
+type InstallationDetailResponse struct {
+	InstallationProgressResponse
+
+	Configuration       InstallationConfigurationDTO
+	Snapshot            *SnapshotDTO // nil until preparation took one
+	InstalledComponents []InstalledComponentDTO
+
+	// Why the installation failed or was cancelled; empty otherwise
+	FailureReason string
+
+	// Backups of the configuration files the installation replaced
+	BackupIDs []string
+
+	// History records of the installation; empty until it finished
+	HistoryRecordIDs []string
+}
+
+// InstallationConfigurationDTO represents what an installation was asked
+// to install
+type InstallationConfigurationDTO struct {
+	Components          []ConfiguredComponentDTO
+	TotalSizeBytes      uint64
+	DownloadBytes       uint64
+	AvailableSpaceBytes uint64
+	RequiredSpaceBytes  uint64
+	MergeExistingConfig bool
+	RenderingMode       string
+	Alternatives        []string // As "slot=package"
+	Accessibility       []string
+}
+
+// ConfiguredComponentDTO represents a requested component
+type ConfiguredComponentDTO struct {
+	Name        string
+	Version     string
+	PackageName string
+	SizeBytes   uint64
+}
+
+// SnapshotDTO represents the system snapshot taken before installing
+type SnapshotDTO struct {
+	ID               string
+	Path             string
+	CreatedAt        string
+	PackageCount     int
+	Corrupted        bool
+	CorruptionReason string
 }
 
 // InstallationErrorResponse represents an installation error
@@ -98,8 +290,41 @@ type InstallationSessionSummary struct {
 	Status              string
 	CurrentPhase        string
 	PercentComplete     int
+	Message             string
 	ComponentsInstalled int
 	ComponentsTotal     int
+	WarningsCount       int
 	StartedAt           string
+	UpdatedAt           string
 	CompletedAt         string
 }
+
+// CancelInstallationRequest represents a request to stop an installation
+type CancelInstallationRequest struct {
+	// Stop immediately, interrupting the package being installed, instead
+	// of after it finishes
+	Force bool
+}
+
+// SessionTimelineResponse is where the time of an installation session
+// went: how long each phase and component took, and where it failed
+type SessionTimelineResponse struct {
+	SessionID     string
+	Status        string
+	StartedAt     string
+	CompletedAt   string
+	DurationMs    int64 // Until completion, or the latest step of an unfinished session
+	FailureReason string
+
+	Phases     []TimelineSpanDTO // In the order they ran
+	Components []TimelineSpanDTO // In the order they were started
+}
+
+// TimelineSpanDTO represents a phase or component on a session timeline
+type TimelineSpanDTO struct {
+	Name       string
+	OffsetMs   int64 // Since the session started
+	DurationMs int64
+	Failed     bool
+	Error      string
+}
@@ -0,0 +1,59 @@
+package dto
+
+// ProfileDiffRequest names the profiles to compare, built-in or custom
+type ProfileDiffRequest struct {
+	From string
+	To   string
+}
+
+// ProfileDiffResponse describes what installing one profile instead of
+// another would change
+type ProfileDiffResponse struct {
+	From ProfileSummary
+	To   ProfileSummary
+
+	AddedPackages   []PackageChange
+	RemovedPackages []PackageChange
+	CommonPackages  int
+
+	AddedComponents   []string
+	RemovedComponents []string
+
+	// Configuration files relative to ~/.config
+	AddedConfigs   []string
+	RemovedConfigs []string
+	ChangedConfigs []ConfigChange
+
+	// Estimated change in installed size; positive when To is larger
+	SizeChangeBytes int64
+}
+
+// ProfileSummary describes one side of a profile comparison
+type ProfileSummary struct {
+	Name          string
+	Description   string
+	RenderingMode string
+	Packages      int
+	Components    int
+	OptIn         []string // Packages offered but only installed when chosen
+
+	// Installed size of the packages whose size could be resolved
+	EstimatedSizeBytes uint64
+	UnknownSizes       int
+}
+
+// PackageChange is a package only one of the profiles installs
+type PackageChange struct {
+	Name        string
+	Component   string // Empty for packages outside any component
+	Description string
+	SizeBytes   uint64 // 0 when the size could not be resolved
+}
+
+// ConfigChange is a configuration file both profiles deploy from different
+// templates
+type ConfigChange struct {
+	Path         string
+	FromTemplate string
+	ToTemplate   string
+}
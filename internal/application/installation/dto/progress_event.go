@@ -0,0 +1,21 @@
+package dto
+
+// ProgressEventDTO is an installation progress update streamed to clients
+// while a session executes
+type ProgressEventDTO struct {
+	SessionID       string
+	EventType       string
+	Status          string
+	PercentComplete int
+	Message         string
+	OccurredAt      string // RFC 3339
+
+	// Sequence numbers the session's events from 1, so a client can resume
+	// a stream after the last one it received; 0 for the current state sent
+	// when a stream opens
+	Sequence uint64
+
+	// Final is set on the last event of an execution; the stream ends
+	// after it
+	Final bool
+}
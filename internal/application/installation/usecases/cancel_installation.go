@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 )
 
-// CancelInstallationUseCase cancels an in-progress installation
+// CancelInstallationUseCase cancels an installation. The session is left
+// cancelled rather than failed, so executing it again resumes it.
 type CancelInstallationUseCase struct {
 	sessionRepo installation.InstallationSessionRepository
+	running     *RunningInstallations
 }
 
 // NewCancelInstallationUseCase creates a new CancelInstallationUseCase
@@ -19,23 +22,53 @@ func NewCancelInstallationUseCase(sessionRepo installation.InstallationSessionRe
 	}
 }
 
-// Execute cancels the installation session with the given ID
-func (u *CancelInstallationUseCase) Execute(ctx context.Context, sessionID string) error {
+// WithRunningInstallations lets the use case stop executions registered by
+// an ExecuteInstallationUseCase sharing the registry
+func (u *CancelInstallationUseCase) WithRunningInstallations(running *RunningInstallations) *CancelInstallationUseCase {
+	u.running = running
+	return u
+}
+
+// Execute cancels the installation session with the given ID and returns
+// its resulting state. A running installation stops after the package it
+// is installing, or immediately with Force; the call waits until it has.
+func (u *CancelInstallationUseCase) Execute(
+	ctx context.Context,
+	sessionID string,
+	request dto.CancelInstallationRequest,
+) (*dto.InstallationProgressResponse, error) {
+	if done, ok := u.running.requestCancel(sessionID, request.Force); ok {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("installation has not stopped yet: %w", ctx.Err())
+		}
+
+		// The execution recorded the outcome; it may also have finished
+		// before it saw the request
+		session, err := u.sessionRepo.FindByID(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find session: %w", err)
+		}
+		return buildStatusResponse(session, nil), nil
+	}
+
 	// Retrieve session from repository
 	session, err := u.sessionRepo.FindByID(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to find session: %w", err)
+		return nil, fmt.Errorf("failed to find session: %w", err)
 	}
 
-	// Cancel the session (mark as failed with cancellation reason)
-	if err := session.Fail("installation cancelled by user"); err != nil {
-		return fmt.Errorf("failed to cancel installation: %w", err)
+	// Nothing is executing the session here: it has not started, or the
+	// process running it has gone away
+	if err := session.Cancel(installation.ErrInstallationCancelled.Error()); err != nil {
+		return nil, fmt.Errorf("failed to cancel installation: %w", err)
 	}
 
 	// Save the updated session
 	if err := u.sessionRepo.Save(ctx, session); err != nil {
-		return fmt.Errorf("failed to save cancelled session: %w", err)
+		return nil, fmt.Errorf("failed to save cancelled session: %w", err)
 	}
 
-	return nil
+	return buildStatusResponse(session, nil), nil
 }
@@ -3,11 +3,14 @@ package usecases_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,14 +45,15 @@ func TestCancelInstallationUseCase_Execute(t *testing.T) {
 		require.NoError(t, err)
 
 		// Cancel the session
-		err = useCase.Execute(ctx, session.ID())
+		response, err := useCase.Execute(ctx, session.ID(), dto.CancelInstallationRequest{})
 
 		require.NoError(t, err)
+		assert.Equal(t, "cancelled", response.Status)
 
-		// Verify session was cancelled
+		// Verify session was cancelled and can be resumed
 		cancelledSession, err := sessionRepo.FindByID(ctx, session.ID())
 		require.NoError(t, err)
-		assert.Equal(t, installation.StatusFailed, cancelledSession.Status())
+		assert.Equal(t, installation.StatusCancelled, cancelledSession.Status())
 		assert.Contains(t, cancelledSession.FailureReason(), "cancelled")
 	})
 
@@ -58,7 +62,7 @@ func TestCancelInstallationUseCase_Execute(t *testing.T) {
 		useCase := usecases.NewCancelInstallationUseCase(sessionRepo)
 		ctx := context.Background()
 
-		err := useCase.Execute(ctx, "nonexistent")
+		_, err := useCase.Execute(ctx, "nonexistent", dto.CancelInstallationRequest{})
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, installation.ErrSessionNotFound)
@@ -98,8 +102,123 @@ func TestCancelInstallationUseCase_Execute(t *testing.T) {
 		require.NoError(t, err)
 
 		// Try to cancel already failed session
-		err = useCase.Execute(ctx, session.ID())
+		_, err = useCase.Execute(ctx, session.ID(), dto.CancelInstallationRequest{})
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, installation.ErrSessionAlreadyComplete)
+	})
+}
+
+func TestCancelInstallationUseCase_RunningInstallation(t *testing.T) {
+	// newRunningSession starts executing a two-component session whose
+	// first package blocks in install, then fails with installErr
+	newRunningSession := func(t *testing.T, install func(ctx context.Context), installErr error) (
+		*usecases.CancelInstallationUseCase,
+		*usecases.RunningInstallations,
+		*MockPackageManager,
+		*installation.InstallationSession,
+		<-chan *dto.InstallationProgressResponse,
+	) {
+		pkg, err := installation.NewPackageInfo("hyprland", "0.35.0", 50*uint64(installation.MB), nil)
+		require.NoError(t, err)
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", &pkg)
+		require.NoError(t, err)
+		waybar, err := installation.NewComponentSelection(installation.ComponentWaybar, "0.9.24", nil)
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{hyprland, waybar}, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		sessionRepo := repository.NewMemorySessionRepository()
+		require.NoError(t, sessionRepo.Save(context.Background(), session))
+
+		conflictResolver := new(MockConflictResolver)
+		conflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		progressEstimator := new(MockProgressEstimator)
+		progressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).Return(50)
+		progressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).Return(time.Minute)
+		pkgManager := new(MockPackageManager)
+		pkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").
+			Run(func(args mock.Arguments) { install(args.Get(0).(context.Context)) }).
+			Return(installErr).Once()
+		preflightValidator := NewMockPreflightValidator()
+		preflightValidator.On("Run", mock.Anything).Return(nil)
+
+		running := usecases.NewRunningInstallations()
+		executeUseCase := usecases.NewExecuteInstallationUseCase(
+			sessionRepo,
+			conflictResolver,
+			progressEstimator,
+			new(MockConfigurationMerger),
+			pkgManager,
+			nil,
+			preflightValidator.Factory(),
+			nil,
+		).WithRunningInstallations(running)
+
+		results := make(chan *dto.InstallationProgressResponse, 1)
+		go func() {
+			response, err := executeUseCase.Execute(context.Background(), session.ID())
+			assert.NoError(t, err)
+			results <- response
+		}()
+
+		cancelUseCase := usecases.NewCancelInstallationUseCase(sessionRepo).WithRunningInstallations(running)
+		return cancelUseCase, running, pkgManager, session, results
+	}
+
+	t.Run("graceful cancel stops after the current package", func(t *testing.T) {
+		installing := make(chan struct{})
+		release := make(chan struct{})
+		cancelUseCase, running, pkgManager, session, results := newRunningSession(t, func(context.Context) {
+			close(installing)
+			<-release
+		}, nil)
+		<-installing
+
+		cancelled := make(chan *dto.InstallationProgressResponse, 1)
+		go func() {
+			response, err := cancelUseCase.Execute(context.Background(), session.ID(), dto.CancelInstallationRequest{})
+			assert.NoError(t, err)
+			cancelled <- response
+		}()
+		require.Eventually(t, func() bool { return running.IsCancelling(session.ID()) }, time.Second, time.Millisecond)
+		close(release)
+
+		response := <-cancelled
+		assert.Equal(t, "cancelled", response.Status)
+		assert.Equal(t, 1, response.ComponentsInstalled)
+		assert.Equal(t, "cancelled", (<-results).Status)
+
+		// The package being installed finished; the next one never started
+		assert.True(t, session.IsInstalled(installation.ComponentHyprland))
+		pkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, "waybar", mock.Anything)
+		assert.False(t, running.IsRunning(session.ID()))
+	})
+
+	t.Run("force cancel interrupts the current package", func(t *testing.T) {
+		installing := make(chan struct{})
+		cancelUseCase, _, _, session, results := newRunningSession(t, func(ctx context.Context) {
+			close(installing)
+			<-ctx.Done()
+		}, context.Canceled)
+		<-installing
+
+		response, err := cancelUseCase.Execute(context.Background(), session.ID(), dto.CancelInstallationRequest{Force: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, "cancelled", response.Status)
+		assert.Equal(t, "cancelled", (<-results).Status)
+		assert.Equal(t, installation.StatusCancelled, session.Status())
+
+		// The interrupted component is pending again, not failed
+		status, ok := session.ComponentStatus(installation.ComponentHyprland)
+		require.True(t, ok)
+		assert.True(t, status.IsPending())
+		assert.Empty(t, session.FailedComponents())
 	})
 }
@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// ProfileLoader looks up installation profiles by name, built-in or custom
+type ProfileLoader interface {
+	Load(name string) (installation.InstallationProfile, error)
+}
+
+// CompareProfilesUseCase lists what differs between two installation
+// profiles: packages, components, estimated sizes and configuration files
+type CompareProfilesUseCase struct {
+	profiles        ProfileLoader
+	packageResolver PackageResolver // Optional
+}
+
+// NewCompareProfilesUseCase creates a new use case instance
+func NewCompareProfilesUseCase(profiles ProfileLoader) *CompareProfilesUseCase {
+	return &CompareProfilesUseCase{profiles: profiles}
+}
+
+// WithPackageResolver estimates sizes from the installed size of each
+// package's candidate version
+func (u *CompareProfilesUseCase) WithPackageResolver(resolver PackageResolver) *CompareProfilesUseCase {
+	u.packageResolver = resolver
+	return u
+}
+
+// Execute compares the profiles. Packages whose size cannot be resolved,
+// such as ones missing from the configured repositories, count as unknown
+// rather than failing the comparison.
+func (u *CompareProfilesUseCase) Execute(ctx context.Context, request dto.ProfileDiffRequest) (*dto.ProfileDiffResponse, error) {
+	from, err := u.profiles.Load(request.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := u.profiles.Load(request.To)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes, err := u.resolveSizes(ctx, from.Packages, to.Packages)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := installation.DiffProfiles(from, to)
+	response := &dto.ProfileDiffResponse{
+		From:           summarizeProfile(from, sizes),
+		To:             summarizeProfile(to, sizes),
+		CommonPackages: len(diff.CommonPackages()),
+	}
+	for _, pkg := range diff.AddedPackages() {
+		response.AddedPackages = append(response.AddedPackages, packageChange(pkg, sizes))
+	}
+	for _, pkg := range diff.RemovedPackages() {
+		response.RemovedPackages = append(response.RemovedPackages, packageChange(pkg, sizes))
+	}
+	for _, component := range diff.AddedComponents() {
+		response.AddedComponents = append(response.AddedComponents, string(component))
+	}
+	for _, component := range diff.RemovedComponents() {
+		response.RemovedComponents = append(response.RemovedComponents, string(component))
+	}
+	response.SizeChangeBytes = int64(response.To.EstimatedSizeBytes) - int64(response.From.EstimatedSizeBytes)
+
+	compareConfigs(response, from, to)
+	return response, nil
+}
+
+// resolveSizes looks up the installed size of every package once
+func (u *CompareProfilesUseCase) resolveSizes(ctx context.Context, packageLists ...[]string) (map[string]uint64, error) {
+	sizes := make(map[string]uint64)
+	if u.packageResolver == nil {
+		return sizes, nil
+	}
+
+	for _, packages := range packageLists {
+		for _, pkg := range packages {
+			if _, ok := sizes[pkg]; ok {
+				continue
+			}
+			info, err := u.packageResolver.ResolvePackage(ctx, pkg)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				sizes[pkg] = 0
+				continue
+			}
+			sizes[pkg] = info.SizeBytes()
+		}
+	}
+	return sizes, nil
+}
+
+func summarizeProfile(profile installation.InstallationProfile, sizes map[string]uint64) dto.ProfileSummary {
+	summary := dto.ProfileSummary{
+		Name:          profile.Name,
+		Description:   profile.Description,
+		RenderingMode: profile.RenderingMode().String(),
+		Packages:      len(profile.Packages),
+		Components:    len(profile.Components()),
+		OptIn:         profile.OptIn,
+	}
+	for _, pkg := range profile.Packages {
+		if sizes[pkg] == 0 {
+			summary.UnknownSizes++
+			continue
+		}
+		summary.EstimatedSizeBytes += sizes[pkg]
+	}
+	return summary
+}
+
+func packageChange(pkg string, sizes map[string]uint64) dto.PackageChange {
+	change := dto.PackageChange{Name: pkg, SizeBytes: sizes[pkg]}
+	if definition, ok := installation.FindPackageDefinition(pkg); ok {
+		change.Component = string(definition.Component)
+		change.Description = definition.Description
+	}
+	return change
+}
+
+// compareConfigs records the configuration files only one profile deploys
+// and those both deploy from different templates, such as Waybar's lite
+// configuration
+func compareConfigs(response *dto.ProfileDiffResponse, from, to installation.InstallationProfile) {
+	// Relative to ~/.config, like installation plans
+	fromFiles, _ := configFilesFor(from.Components(), from.Alternatives(), from.RenderingMode(), "")
+	toFiles, _ := configFilesFor(to.Components(), to.Alternatives(), to.RenderingMode(), "")
+
+	fromTemplates := make(map[string]string, len(fromFiles))
+	for _, file := range fromFiles {
+		fromTemplates[file.TargetPath] = file.SourceTemplate
+	}
+	toTemplates := make(map[string]string, len(toFiles))
+	for _, file := range toFiles {
+		toTemplates[file.TargetPath] = file.SourceTemplate
+
+		fromTemplate, ok := fromTemplates[file.TargetPath]
+		switch {
+		case !ok:
+			response.AddedConfigs = append(response.AddedConfigs, file.TargetPath)
+		case fromTemplate != file.SourceTemplate:
+			response.ChangedConfigs = append(response.ChangedConfigs, dto.ConfigChange{
+				Path:         file.TargetPath,
+				FromTemplate: filepath.Base(fromTemplate),
+				ToTemplate:   filepath.Base(file.SourceTemplate),
+			})
+		}
+	}
+	for _, file := range fromFiles {
+		if _, ok := toTemplates[file.TargetPath]; !ok {
+			response.RemovedConfigs = append(response.RemovedConfigs, file.TargetPath)
+		}
+	}
+}
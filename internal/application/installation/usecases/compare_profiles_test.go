@@ -0,0 +1,108 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// builtinProfiles loads only the built-in profiles
+type builtinProfiles struct{}
+
+func (builtinProfiles) Load(name string) (installation.InstallationProfile, error) {
+	return installation.LookupProfile(name)
+}
+
+// sizedPackageResolver resolves packages to fixed sizes and fails for the
+// rest
+type sizedPackageResolver struct {
+	sizes map[string]uint64
+}
+
+func (r *sizedPackageResolver) ResolvePackage(ctx context.Context, packageName string) (installation.PackageInfo, error) {
+	size, ok := r.sizes[packageName]
+	if !ok {
+		return installation.PackageInfo{}, errors.New("no installation candidate")
+	}
+	return installation.NewPackageInfo(packageName, "1.0-1", size, nil)
+}
+
+func TestCompareProfilesUseCase(t *testing.T) {
+	t.Run("lists what recommended adds to minimal", func(t *testing.T) {
+		useCase := usecases.NewCompareProfilesUseCase(builtinProfiles{})
+
+		diff, err := useCase.Execute(context.Background(), dto.ProfileDiffRequest{From: "minimal", To: "recommended"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Minimal", diff.From.Name)
+		assert.Equal(t, "Recommended", diff.To.Name)
+		assert.Empty(t, diff.RemovedPackages)
+		assert.Equal(t, len(installation.GetMinimalProfile().Packages), diff.CommonPackages)
+
+		var added []string
+		for _, change := range diff.AddedPackages {
+			added = append(added, change.Name)
+		}
+		assert.Contains(t, added, "nautilus")
+		assert.Contains(t, added, "hyprland-backgrounds")
+	})
+
+	t.Run("estimates sizes and counts unresolved packages", func(t *testing.T) {
+		useCase := usecases.NewCompareProfilesUseCase(builtinProfiles{}).
+			WithPackageResolver(&sizedPackageResolver{sizes: map[string]uint64{
+				"nautilus":  3 * uint64(installation.MB),
+				"kitty":     8 * uint64(installation.MB),
+				"alacritty": 5 * uint64(installation.MB),
+			}})
+
+		diff, err := useCase.Execute(context.Background(), dto.ProfileDiffRequest{From: "recommended", To: "full"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 11*uint64(installation.MB), diff.From.EstimatedSizeBytes)
+		assert.Equal(t, 16*uint64(installation.MB), diff.To.EstimatedSizeBytes)
+		assert.Equal(t, int64(5*installation.MB), diff.SizeChangeBytes)
+		assert.Equal(t, len(installation.GetRecommendedProfile().Packages)-2, diff.From.UnknownSizes)
+
+		for _, change := range diff.AddedPackages {
+			if change.Name == "alacritty" {
+				assert.Equal(t, 5*uint64(installation.MB), change.SizeBytes)
+				assert.Equal(t, "kitty", change.Component)
+				assert.NotEmpty(t, change.Description)
+			}
+		}
+	})
+
+	t.Run("reports components and rendering modes", func(t *testing.T) {
+		useCase := usecases.NewCompareProfilesUseCase(builtinProfiles{})
+
+		diff, err := useCase.Execute(context.Background(), dto.ProfileDiffRequest{From: "recommended", To: "lite"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "standard", diff.From.RenderingMode)
+		assert.Equal(t, "lite", diff.To.RenderingMode)
+		assert.NotEmpty(t, diff.RemovedPackages)
+	})
+
+	t.Run("rejects unknown profiles", func(t *testing.T) {
+		useCase := usecases.NewCompareProfilesUseCase(builtinProfiles{})
+
+		_, err := useCase.Execute(context.Background(), dto.ProfileDiffRequest{From: "minimal", To: "gaming"})
+		assert.ErrorIs(t, err, installation.ErrUnknownProfile)
+	})
+
+	t.Run("stops when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		useCase := usecases.NewCompareProfilesUseCase(builtinProfiles{}).
+			WithPackageResolver(&sizedPackageResolver{})
+
+		_, err := useCase.Execute(ctx, dto.ProfileDiffRequest{From: "minimal", To: "full"})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -2,13 +2,18 @@ package usecases
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
 	"github.com/rebelopsio/gohan/internal/domain/history"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
@@ -17,6 +22,10 @@ import (
 	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
 )
 
+// ErrDryRunUnsupported is returned when executing a dry-run session without
+// a dry-run package manager
+var ErrDryRunUnsupported = errors.New("dry runs are not supported by this installer")
+
 // PackageManager defines the interface for installing packages
 type PackageManager interface {
 	InstallPackage(ctx context.Context, packageName, version string) error
@@ -33,9 +42,91 @@ type PreflightValidator interface {
 	Run(ctx context.Context) error
 	Session() *preflight.ValidationSession
 	Progress() <-chan preflightTUI.ProgressUpdate
+
+	// TotalChecks returns how many checks Run performs
+	TotalChecks() int
+}
+
+// PreflightValidatorFactory creates the validator for one installation, so
+// checks can adapt to the components it selected
+type PreflightValidatorFactory func(config installation.InstallationConfiguration) PreflightValidator
+
+// PackageAvailabilityChecker is optionally implemented by package managers
+// that can tell whether a package is installable from the configured repositories
+type PackageAvailabilityChecker interface {
+	IsPackageAvailable(ctx context.Context, packageName string) (bool, error)
+}
+
+// PackageDownloader is optionally implemented by package managers that can
+// fetch a package before installing it, so download and install failures
+// are reported separately
+type PackageDownloader interface {
+	DownloadPackage(ctx context.Context, packageName, version string) error
+}
+
+// SessionWorkspaces keeps a private working directory for each installation
+// session, for its rendered output, downloads, build trees and temporary
+// files
+type SessionWorkspaces interface {
+	Create(sessionID string) (string, error)
+	Remove(sessionID string) error
+}
+
+// WeatherLocationProvider resolves the location the Waybar weather module
+// reports on
+type WeatherLocationProvider interface {
+	Location() (installation.WeatherLocation, error)
+}
+
+// NightLightLocationProvider resolves the location the night light computes
+// sunrise and sunset for
+type NightLightLocationProvider interface {
+	Location() (installation.NightLightLocation, error)
+}
+
+// FirstRunOnboarding sets up the first-login tour of the key bindings
+type FirstRunOnboarding interface {
+	// ExecuteFirstRun enables the tour unless it was set up before
+	ExecuteFirstRun(ctx context.Context) (bool, error)
+}
+
+// ImportedVarsLoader loads template variables imported from another
+// desktop, such as the keyboard and monitor layouts
+type ImportedVarsLoader interface {
+	Load() (map[string]string, error)
+}
+
+// PortalDetector finds the installed xdg-desktop-portal backends
+type PortalDetector interface {
+	Selection() (installation.PortalSelection, error)
+}
+
+// GPUDriverSetup finishes setting up GPU drivers once their packages are
+// installed
+type GPUDriverSetup interface {
+	UpdateInitramfs(ctx context.Context) error
+	Inspect(driver installation.ComponentName) (installation.GPUDriverState, error)
+}
+
+// RebootDetector finds what an installation changed that only takes effect
+// after a reboot, given the components it installed and when it started
+type RebootDetector interface {
+	Detect(ctx context.Context, components []installation.ComponentName, since time.Time) (installation.RebootRequirement, error)
+}
+
+// EventNotifier tells others about an installation's lifecycle events:
+// installation.started, installation.completed and installation.failed
+type EventNotifier interface {
+	Notify(ctx context.Context, event installation.DomainEvent) error
+}
+
+// LifecycleHooks runs the commands the user configured for a phase of the
+// installation
+type LifecycleHooks interface {
+	Run(ctx context.Context, invocation installation.HookInvocation) error
 }
 
-// ProgressCallback is called during installation to report progress
+// ProgressCallback reports the progress of an execution's steps
 type ProgressCallback func(phase string, percent int, message string, componentsInstalled, componentsTotal int)
 
 // ExecuteInstallationUseCase handles executing an installation session
@@ -46,8 +137,25 @@ type ExecuteInstallationUseCase struct {
 	configMerger       installation.ConfigurationMerger
 	packageManager     PackageManager
 	historyRecorder    HistoryRecorder
-	preflightValidator PreflightValidator
+	newPreflight       PreflightValidatorFactory
 	configDeployer     *configservice.ConfigDeployer
+	running            *RunningInstallations
+	broadcaster        *ProgressBroadcaster                  // Optional
+	preflightRepo      preflight.ValidationSessionRepository // Optional
+	workspaces         SessionWorkspaces                     // Optional
+	weather            WeatherLocationProvider               // Optional
+	nightLight         NightLightLocationProvider            // Optional
+	onboarding         FirstRunOnboarding                    // Optional
+	importedVars       ImportedVarsLoader                    // Optional
+	portals            PortalDetector                        // Optional
+	gpuDrivers         GPUDriverSetup                        // Optional
+	reboot             RebootDetector                        // Optional
+	notifier           EventNotifier                         // Optional
+	hooks              LifecycleHooks                        // Optional
+	lockScreen         installation.LockScreenSettings       // Zero value is the default lock screen
+	dryRunPackages     PackageManager                        // Optional; runs dry-run sessions
+	dryRunConflicts    installation.ConflictResolver         // Optional; runs dry-run sessions
+	homeDir            string                                // Empty deploys to the current user's home
 }
 
 // NewExecuteInstallationUseCase creates a new execute installation use case
@@ -58,7 +166,7 @@ func NewExecuteInstallationUseCase(
 	configMerger installation.ConfigurationMerger,
 	packageManager PackageManager,
 	historyRecorder HistoryRecorder,
-	preflightValidator PreflightValidator,
+	newPreflight PreflightValidatorFactory,
 	configDeployer *configservice.ConfigDeployer,
 ) *ExecuteInstallationUseCase {
 	return &ExecuteInstallationUseCase{
@@ -68,70 +176,320 @@ func NewExecuteInstallationUseCase(
 		configMerger:       configMerger,
 		packageManager:     packageManager,
 		historyRecorder:    historyRecorder,
-		preflightValidator: preflightValidator,
+		newPreflight:       newPreflight,
 		configDeployer:     configDeployer,
 	}
 }
 
+// WithRunningInstallations registers executions in the given registry so
+// a CancelInstallationUseCase sharing it can stop them
+func (u *ExecuteInstallationUseCase) WithRunningInstallations(running *RunningInstallations) *ExecuteInstallationUseCase {
+	u.running = running
+	return u
+}
+
+// WithProgressBroadcaster publishes every progress report to the
+// broadcaster's sinks, and the state each execution ends in as a final
+// update
+func (u *ExecuteInstallationUseCase) WithProgressBroadcaster(broadcaster *ProgressBroadcaster) *ExecuteInstallationUseCase {
+	u.broadcaster = broadcaster
+	return u
+}
+
+// WithPreflightRepository persists the preflight session run before each
+// installation, so it can be looked up from the installation session
+func (u *ExecuteInstallationUseCase) WithPreflightRepository(repo preflight.ValidationSessionRepository) *ExecuteInstallationUseCase {
+	u.preflightRepo = repo
+	return u
+}
+
+// WithWorkspaces gives each execution its own working directory, removed
+// when the installation succeeds and kept when it fails
+func (u *ExecuteInstallationUseCase) WithWorkspaces(workspaces SessionWorkspaces) *ExecuteInstallationUseCase {
+	u.workspaces = workspaces
+	return u
+}
+
+// WithWeather adds a Waybar weather module reporting on the location the
+// provider resolves
+func (u *ExecuteInstallationUseCase) WithWeather(provider WeatherLocationProvider) *ExecuteInstallationUseCase {
+	u.weather = provider
+	return u
+}
+
+// WithNightLight has an installed night light follow the sun at the
+// location the provider resolves
+func (u *ExecuteInstallationUseCase) WithNightLight(provider NightLightLocationProvider) *ExecuteInstallationUseCase {
+	u.nightLight = provider
+	return u
+}
+
+// WithOnboarding sets up the first-login tour once Hyprland's configuration
+// is deployed
+func (u *ExecuteInstallationUseCase) WithOnboarding(onboarding FirstRunOnboarding) *ExecuteInstallationUseCase {
+	u.onboarding = onboarding
+	return u
+}
+
+// WithImportedVars renders the configuration with the variables imported
+// by gohan migrate in place of the defaults
+func (u *ExecuteInstallationUseCase) WithImportedVars(loader ImportedVarsLoader) *ExecuteInstallationUseCase {
+	u.importedVars = loader
+	return u
+}
+
+// WithPortals chooses the portal backends for the Hyprland session among
+// those installed, and warns about backends competing with Hyprland's
+func (u *ExecuteInstallationUseCase) WithPortals(detector PortalDetector) *ExecuteInstallationUseCase {
+	u.portals = detector
+	return u
+}
+
+// WithGPUDriverSetup rebuilds the initramfs after GPU drivers are
+// installed and warns about kernel setup they still need
+func (u *ExecuteInstallationUseCase) WithGPUDriverSetup(setup GPUDriverSetup) *ExecuteInstallationUseCase {
+	u.gpuDrivers = setup
+	return u
+}
+
+// WithRebootDetection records on completed sessions whether they need a
+// reboot to take full effect
+func (u *ExecuteInstallationUseCase) WithRebootDetection(detector RebootDetector) *ExecuteInstallationUseCase {
+	u.reboot = detector
+	return u
+}
+
+// WithEventNotifier sends the installation's lifecycle events to the
+// notifier. Failed notifications are reported but never fail the
+// installation. The notifier is called on the installation's path, so
+// slow deliveries belong on a queue such as notifications.AsyncNotifier.
+func (u *ExecuteInstallationUseCase) WithEventNotifier(notifier EventNotifier) *ExecuteInstallationUseCase {
+	u.notifier = notifier
+	return u
+}
+
+// WithHooks runs the user's hooks before and after installing and deploying
+// configuration. A failing pre_install or pre_deploy hook fails the
+// installation; failing post hooks are recorded as warnings.
+func (u *ExecuteInstallationUseCase) WithHooks(hooks LifecycleHooks) *ExecuteInstallationUseCase {
+	u.hooks = hooks
+	return u
+}
+
+// WithLockScreen renders the hyprlock and swaylock screens with the given
+// background, avatar and clock
+func (u *ExecuteInstallationUseCase) WithLockScreen(lock installation.LockScreenSettings) *ExecuteInstallationUseCase {
+	u.lockScreen = lock
+	return u
+}
+
+// WithHomeDir deploys configuration files under dir instead of the
+// current user's home, such as into a test's temporary directory
+func (u *ExecuteInstallationUseCase) WithHomeDir(dir string) *ExecuteInstallationUseCase {
+	u.homeDir = dir
+	return u
+}
+
+// WithDryRun runs sessions configured as dry runs against the given
+// package manager and conflict resolver, which must not change the system.
+// Without them such sessions fail with ErrDryRunUnsupported.
+func (u *ExecuteInstallationUseCase) WithDryRun(packageManager PackageManager, conflictResolver installation.ConflictResolver) *ExecuteInstallationUseCase {
+	u.dryRunPackages = packageManager
+	u.dryRunConflicts = conflictResolver
+	return u
+}
+
 // Execute executes an installation session
-// The progressCallback parameter is optional and will be called with progress updates
-func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID string, progressCallback ProgressCallback) (*dto.InstallationProgressResponse, error) {
+// Progress updates go to the progress broadcaster's sinks
+// A cancelled session is resumed: components it already installed are skipped
+func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID string) (*dto.InstallationProgressResponse, error) {
 	// Retrieve the session
 	session, err := u.sessionRepo.FindByID(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
 
+	if session.Configuration().DryRun() {
+		if u.dryRunPackages == nil || u.dryRunConflicts == nil {
+			return nil, ErrDryRunUnsupported
+		}
+		u = u.simulated()
+	}
+
+	// Register the run so it can be cancelled; runCtx ends on a forced cancel
+	runCtx, run, err := u.running.start(ctx, session.ID())
+	if err != nil {
+		return nil, err
+	}
+	defer u.running.finish(session.ID(), run)
+	defer func() { u.broadcaster.Publish(finalProgressUpdate(session)) }()
+
+	if session.IsCancelled() {
+		if err := session.Resume(); err != nil {
+			return nil, err
+		}
+	}
+
+	u.notify(ctx, installation.NewInstallationStartedEvent(session.ID()))
+
+	// A resumed session reuses the working directory it was cancelled with
+	workspace := ""
+	if u.workspaces != nil {
+		if workspace, err = u.workspaces.Create(session.ID()); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := u.execute(runCtx, session, run, workspace)
+
+	// A forced cancel can interrupt a step that has no failure path of its
+	// own, such as saving; make sure the session still records it
+	if cancelledByUser(runCtx) && session.IsInProgress() {
+		return u.handleCancellation(ctx, session)
+	}
+
+	// Keep the artifacts of a failed run for debugging, and those of a dry
+	// run for inspection
+	if workspace != "" {
+		dryRun := session.Configuration().DryRun()
+		switch {
+		case session.IsCompleted() && !dryRun:
+			// Best effort; a leftover directory is cleaned with the caches
+			_ = u.workspaces.Remove(session.ID())
+		case (session.IsFailed() || dryRun) && response != nil:
+			response.ArtifactsDir = workspace
+		}
+	}
+	return response, err
+}
+
+// simulated returns a copy of the use case for dry-run sessions. Packages
+// go through the dry-run package manager and conflict resolver, deployed
+// files are not recorded for template upgrades, and the GPU driver setup,
+// first-login tour, reboot detection, history, notifications and hooks
+// are left out.
+func (u *ExecuteInstallationUseCase) simulated() *ExecuteInstallationUseCase {
+	simulated := *u
+	simulated.packageManager = u.dryRunPackages
+	simulated.conflictResolver = u.dryRunConflicts
+	if u.configDeployer != nil {
+		simulated.configDeployer = u.configDeployer.WithRecords(nil)
+	}
+	simulated.historyRecorder = nil
+	simulated.gpuDrivers = nil
+	simulated.onboarding = nil
+	simulated.reboot = nil
+	simulated.notifier = nil
+	simulated.hooks = nil
+	return &simulated
+}
+
+// execute runs the installation phases, stopping early when run is asked
+// to stop
+func (u *ExecuteInstallationUseCase) execute(
+	ctx context.Context,
+	session *installation.InstallationSession,
+	run *installationRun,
+	workspace string,
+) (*dto.InstallationProgressResponse, error) {
 	// Get total components for progress reporting
 	totalComponents := len(session.Configuration().Components())
 
-	// Step 1: Run preflight checks (0-15%)
-	if progressCallback != nil {
-		progressCallback("Running Preflight Checks", 0, "Initializing system validation", 0, totalComponents)
+	// Record every progress report on the session so clients polling the
+	// status and list endpoints see the same detail as the sinks
+	progressCallback := func(phase string, percent int, message string, componentsInstalled, componentsTotal int) {
+		session.UpdateProgress(installation.NewInstallationProgress(phase, percent, message, time.Now()))
+		_ = u.sessionRepo.Save(ctx, session)
+		u.broadcaster.Publish(ProgressUpdate{
+			SessionID:           session.ID(),
+			Phase:               phase,
+			Percent:             percent,
+			Message:             message,
+			ComponentsInstalled: componentsInstalled,
+			ComponentsTotal:     componentsTotal,
+			Status:              session.Status(),
+			OccurredAt:          time.Now(),
+		})
 	}
 
-	// Run preflight checks in a goroutine and map progress
+	// Step 1: Run preflight checks (0-15%)
+	progressCallback("Running Preflight Checks", 0, "Initializing system validation", 0, totalComponents)
+
+	// Run preflight checks for the selected components in a goroutine and
+	// map progress
+	validator := u.newPreflight(session.Configuration())
 	preflightDone := make(chan struct{})
 	go func() {
 		defer close(preflightDone)
-		_ = u.preflightValidator.Run(ctx)
+		_ = validator.Run(ctx)
 	}()
 
 	// Monitor preflight progress and report it
 	checkNum := 0
-	totalChecks := 5 // Debian, GPU, Disk, Connectivity, Repos
-	for update := range u.preflightValidator.Progress() {
-		checkNum++
+	totalChecks := max(validator.TotalChecks(), 1)
+	for update := range validator.Progress() {
+		if update.Result != nil {
+			checkNum++
+		}
 		// Map preflight progress to 0-15% range
-		percent := (checkNum * 15) / totalChecks
+		percent := (min(checkNum, totalChecks) * 15) / totalChecks
 
-		if progressCallback != nil {
-			progressCallback(
-				"Running Preflight Checks",
-				percent,
-				update.Message,
-				0,
-				totalComponents,
-			)
-		}
+		progressCallback(
+			"Running Preflight Checks",
+			percent,
+			update.Message,
+			0,
+			totalComponents,
+		)
 	}
 
 	// Wait for preflight to complete
 	<-preflightDone
 
+	// Checks cut short by a cancel are not blockers
+	if run.stopRequested() {
+		return u.handleCancellation(ctx, session)
+	}
+
 	// Check if we can proceed
-	preflightSession := u.preflightValidator.Session()
+	preflightSession := validator.Session()
+	session.SetPreflightSessionID(preflightSession.ID())
+	if u.preflightRepo != nil {
+		if err := u.preflightRepo.Save(ctx, preflightSession); err != nil {
+			recordWarning(session, installation.WarningSourcePreflight, fmt.Sprintf("Preflight results were not saved: %v", err))
+		}
+	}
+
+	// Keep the results on the session so history explains blocked or
+	// degraded installs
+	if !preflightSession.CanProceed() || preflightSession.HasWarnings() {
+		session.RecordPreflightChecks(buildPreflightChecks(preflightSession.Results()))
+	}
 	if !preflightSession.CanProceed() {
 		// Installation is blocked - return error with guidance
 		return u.handlePreflightBlockers(ctx, session, preflightSession)
 	}
 
+	// Keep the measured throughput so time estimates reflect this machine
+	if throughput, ok := preflight.MeasuredThroughput(preflightSession.Results()); ok {
+		systemContext, err := installation.NewSystemContext(
+			throughput.DiskWriteBytesPerSec(),
+			throughput.DownloadBytesPerSec(),
+			time.Now(),
+		)
+		if err == nil {
+			session.SetSystemContext(systemContext)
+		}
+	}
+
 	// Report warnings if any
-	if preflightSession.HasWarnings() && progressCallback != nil {
+	if preflightSession.HasWarnings() {
 		warnings := preflightSession.WarningResults()
 		warningMsgs := make([]string, 0, len(warnings))
 		for _, w := range warnings {
 			warningMsgs = append(warningMsgs, w.FormatMessage())
+			recordWarning(session, installation.WarningSourcePreflight,
+				fmt.Sprintf("%s: %s", w.RequirementName(), w.Guidance().Message()))
 		}
 		progressCallback(
 			"Preflight Warnings",
@@ -143,17 +501,17 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 	}
 
 	// Report initial progress
-	if progressCallback != nil {
-		progressCallback("Starting Preparation", 15, "Preflight checks passed", 0, totalComponents)
-	}
+	progressCallback("Starting Preparation", 15, "Preflight checks passed", 0, totalComponents)
 
 	// Create a system snapshot before starting
 	// TODO: In real implementation, capture actual system state
 	config := session.Configuration()
 
-	if progressCallback != nil {
-		progressCallback("Creating Snapshot", 20, "Creating system snapshot", 0, totalComponents)
-	}
+	// Use lite mode on low-end systems unless the user chose a mode
+	renderingMode := config.RenderingMode().Resolve(preflight.LiteModeRecommended(preflightSession.Results()))
+	alternatives := renderingMode.Alternatives(config.Alternatives())
+
+	progressCallback("Creating Snapshot", 20, "Creating system snapshot", 0, totalComponents)
 
 	snapshot, err := installation.NewSystemSnapshot(
 		"/var/lib/gohan/snapshots",
@@ -172,16 +530,26 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 		}
 	}
 
+	// Estimate the phases now the throughput is known, to report how far
+	// off the estimate was once the installation completes
+	if planner, ok := u.progressEstimator.(installation.PhasePlanner); ok {
+		if plan, ok := planner.PlanPhases(session.SystemContext(), config.EstimatedDownloadBytes()); ok {
+			session.SetPhaseEstimate(plan)
+		}
+	}
+
 	// Save updated session state
 	if err := u.sessionRepo.Save(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to save session state: %w", err)
 	}
 
-	// Detect conflicts
-	if progressCallback != nil {
-		progressCallback("Checking Requirements", 25, "Detecting package conflicts", 0, totalComponents)
+	if err := u.runHooks(ctx, session, installation.HookPreInstall, workspace, nil); err != nil {
+		return u.handleInstallationError(ctx, session, err.Error())
 	}
 
+	// Detect conflicts
+	progressCallback("Checking Requirements", 25, "Detecting package conflicts", 0, totalComponents)
+
 	conflicts, err := u.conflictResolver.DetectConflicts(ctx, config.Components())
 	if err != nil {
 		return u.handleInstallationError(ctx, session, fmt.Sprintf("conflict detection failed: %v", err))
@@ -189,15 +557,26 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 
 	// Resolve conflicts if any
 	if len(conflicts) > 0 {
-		if progressCallback != nil {
-			progressCallback("Resolving Conflicts", 30, fmt.Sprintf("Resolving %d package conflicts", len(conflicts)), 0, totalComponents)
-		}
+		progressCallback("Resolving Conflicts", 30, fmt.Sprintf("Resolving %d package conflicts", len(conflicts)), 0, totalComponents)
 
 		for _, conflict := range conflicts {
 			// Default strategy: remove conflicting package
-			if err := u.conflictResolver.ResolveConflict(ctx, conflict, installation.ActionRemove); err != nil {
+			strategy := installation.ActionRemove
+			session.RecordConflict(conflict, strategy)
+
+			if err := u.conflictResolver.ResolveConflict(ctx, conflict, strategy); err != nil {
 				return u.handleInstallationError(ctx, session, fmt.Sprintf("conflict resolution failed: %v", err))
 			}
+			if _, err := session.ResolveConflict(conflict); err != nil {
+				return u.handleInstallationError(ctx, session, fmt.Sprintf("failed to record conflict resolution: %v", err))
+			}
+			recordWarning(session, installation.WarningSourceConflict,
+				fmt.Sprintf("Removed %s to resolve conflict with %s (%s)",
+					conflict.ConflictingPackage(), conflict.PackageName(), conflict.Reason()))
+		}
+
+		if err := u.sessionRepo.Save(ctx, session); err != nil {
+			return nil, fmt.Errorf("failed to save session state: %w", err)
 		}
 	}
 
@@ -214,31 +593,75 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 
 	// Install each component
 	components := config.Components()
+	installedPackages := make(map[installation.ComponentName]string, len(components))
+	estimator := installation.NewComponentTimeEstimator(session.SystemContext())
 	for i, comp := range components {
+		// A graceful cancel stops between packages
+		if run.stopRequested() {
+			return u.handleCancellation(ctx, session)
+		}
+
 		// Extract package name and version
-		packageName := componentToPackageName(comp.Component())
+		packageName := alternatives.PackageForComponent(comp.Component(), componentToPackageName(comp.Component()))
 		version := comp.Version()
 
+		// A resumed session keeps what it installed before it was cancelled
+		if session.IsInstalled(comp.Component()) {
+			installedPackages[comp.Component()] = packageName
+			continue
+		}
+
 		// Calculate progress percentage (35-80% range for installations)
 		// Each component gets equal portion of the 45% range
 		baseProgress := 35
 		progressRange := 45
 		componentProgress := baseProgress + (progressRange * i / len(components))
 
-		if progressCallback != nil {
-			progressCallback(
-				"Installing Components",
-				componentProgress,
-				fmt.Sprintf("Installing %s (%d/%d)", packageName, i+1, len(components)),
-				i,
-				totalComponents,
-			)
+		label := fmt.Sprintf("Installing %s (%d/%d)", packageName, i+1, len(components))
+		message := label
+		if eta := installation.DescribeEstimate(estimator.Estimate(comp.DownloadBytes())); eta != "" {
+			message = fmt.Sprintf("%s — %s", message, eta)
+		}
+		progressCallback("Installing Components", componentProgress, message, i, totalComponents)
+
+		// Lite mode leaves out heavy optional packages
+		if renderingMode.IsLite() && !comp.IsCore() && installation.IsHeavyPackage(packageName) {
+			skipComponent(session, comp.Component(), installation.SkipReasonLiteMode,
+				fmt.Sprintf("package %s is too heavy for lite mode", packageName))
+			_ = u.sessionRepo.Save(ctx, session)
+			continue
+		}
+
+		// Skip optional components whose packages cannot be installed
+		if skip, err := u.checkAvailability(ctx, session, comp.Component(), packageName); err != nil {
+			return u.handleComponentError(ctx, session, comp.Component(), err.Error())
+		} else if skip {
+			_ = u.sessionRepo.Save(ctx, session)
+			continue
+		}
+
+		// apt's own progress moves within the component's share
+		report := packageProgress(label, componentProgress, baseProgress+(progressRange*(i+1)/len(components)),
+			i, totalComponents, progressCallback)
+
+		// Fetch the package first when the package manager supports it
+		started := time.Now()
+		if downloader, ok := u.packageManager.(PackageDownloader); ok {
+			_ = session.MarkComponent(comp.Component(), installation.ComponentStateDownloading)
+			_ = u.sessionRepo.Save(ctx, session)
+			if err := u.downloadPackage(ctx, downloader, packageName, version, report); err != nil {
+				return u.handleComponentError(ctx, session, comp.Component(), fmt.Sprintf("failed to download %s: %v", packageName, err))
+			}
 		}
 
 		// Install the package
-		if err := u.packageManager.InstallPackage(ctx, packageName, version); err != nil {
-			return u.handleInstallationError(ctx, session, fmt.Sprintf("failed to install %s: %v", packageName, err))
+		_ = session.MarkComponent(comp.Component(), installation.ComponentStateInstalling)
+		_ = u.sessionRepo.Save(ctx, session)
+		if err := u.installPackage(ctx, packageName, version, report); err != nil {
+			return u.handleComponentError(ctx, session, comp.Component(), fmt.Sprintf("failed to install %s: %v", packageName, err))
 		}
+		installedPackages[comp.Component()] = packageName
+		estimator.Observe(comp.DownloadBytes(), time.Since(started))
 
 		// Create installed component
 		var pkgInfo *installation.PackageInfo
@@ -277,15 +700,13 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 		)
 
 		// Report completion of this component
-		if progressCallback != nil {
-			progressCallback(
-				"Installing Components",
-				baseProgress + (progressRange * (i+1) / len(components)),
-				fmt.Sprintf("Installed %s successfully", packageName),
-				i+1,
-				totalComponents,
-			)
-		}
+		progressCallback(
+			"Installing Components",
+			baseProgress + (progressRange * (i+1) / len(components)),
+			fmt.Sprintf("Installed %s successfully", packageName),
+			i+1,
+			totalComponents,
+		)
 
 		// Save progress
 		if err := u.sessionRepo.Save(ctx, session); err != nil {
@@ -293,11 +714,13 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 		}
 	}
 
-	// Move to configuring phase
-	if progressCallback != nil {
-		progressCallback("Configuring", 85, "Applying configuration files", len(components), totalComponents)
+	if run.stopRequested() {
+		return u.handleCancellation(ctx, session)
 	}
 
+	// Move to configuring phase
+	progressCallback("Configuring", 85, "Applying configuration files", len(components), totalComponents)
+
 	if err := session.StartConfiguring(); err != nil {
 		if err != installation.ErrInvalidStateTransition {
 			return u.handleInstallationError(ctx, session, fmt.Sprintf("failed to start configuring: %v", err))
@@ -309,16 +732,24 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 	}
 
 	// Deploy configuration files if config deployer is available
+	var deployedFiles map[installation.ComponentName][]string
 	if u.configDeployer != nil {
-		if err := u.deployConfigurations(ctx, session, progressCallback); err != nil {
+		if err := u.runHooks(ctx, session, installation.HookPreDeploy, workspace, nil); err != nil {
+			return u.handleInstallationError(ctx, session, err.Error())
+		}
+		deployedFiles, err = u.deployConfigurations(ctx, session, workspace, renderingMode, alternatives, progressCallback)
+		if err != nil {
 			return u.handleInstallationError(ctx, session, fmt.Sprintf("failed to deploy configurations: %v", err))
 		}
+		_ = u.runHooks(ctx, session, installation.HookPostDeploy, workspace, flattenDeployedFiles(deployedFiles))
 	}
+	if u.gpuDrivers != nil {
+		u.setUpGPUDrivers(ctx, session)
+	}
+	markInstalledComponents(session, installation.ComponentStateConfigured)
 
 	// Move to verifying phase
-	if progressCallback != nil {
-		progressCallback("Verifying", 90, "Verifying installation", len(components), totalComponents)
-	}
+	progressCallback("Verifying", 90, "Verifying installation", len(components), totalComponents)
 
 	if err := session.StartVerifying(); err != nil {
 		if err != installation.ErrInvalidStateTransition {
@@ -326,33 +757,68 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 		}
 	}
 
+	// Components are independent, so verify them concurrently
+	verifications, err := u.verifyComponents(ctx, verificationTargets(session, installedPackages, deployedFiles))
+	if err != nil {
+		return u.handleInstallationError(ctx, session, fmt.Sprintf("verification interrupted: %v", err))
+	}
+	var unverified []string
+	for _, verification := range verifications {
+		_ = session.RecordComponentVerification(verification)
+		if !verification.Passed() {
+			unverified = append(unverified, string(verification.Component()))
+		}
+	}
+
 	if err := u.sessionRepo.Save(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to save session state: %w", err)
 	}
 
+	if len(unverified) > 0 {
+		return u.handleInstallationError(ctx, session, fmt.Sprintf("verification failed for %s", strings.Join(unverified, ", ")))
+	}
+
+	// Introduce the key bindings on the first login to the new desktop
+	if u.onboarding != nil && len(deployedFiles[installation.ComponentHyprland]) > 0 {
+		if _, err := u.onboarding.ExecuteFirstRun(ctx); err != nil {
+			recordWarning(session, installation.WarningSourceOnboarding,
+				fmt.Sprintf("First-login tour was not set up: %v", err))
+		}
+	}
+
+	_ = u.runHooks(ctx, session, installation.HookPostInstall, workspace, nil)
+
 	// Complete the installation
-	if progressCallback != nil {
-		progressCallback("Finalizing", 95, "Cleaning up temporary files", len(components), totalComponents)
+	progressCallback("Finalizing", 95, "Cleaning up temporary files", len(components), totalComponents)
+
+	if u.reboot != nil {
+		u.detectReboot(ctx, session)
 	}
 
 	if err := session.Complete(); err != nil {
 		return u.handleInstallationError(ctx, session, fmt.Sprintf("failed to complete installation: %v", err))
 	}
 
+	session.UpdateProgress(installation.NewInstallationProgress(
+		"Completed",
+		100,
+		"Installation completed successfully",
+		time.Now(),
+	))
+
 	// Calculate duration
 	duration := time.Since(session.StartedAt())
 
-	// Emit completion event (could be published to event bus in real implementation)
-	_ = installation.NewInstallationCompletedEvent(
-		session.ID(),
-		duration,
-		len(session.InstalledComponents()),
-	)
-
 	if err := u.sessionRepo.Save(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to save session state: %w", err)
 	}
 
+	u.notify(ctx, installation.NewInstallationCompletedEvent(
+		session.ID(),
+		duration,
+		len(session.InstalledComponents()),
+	))
+
 	// Record installation to history
 	if u.historyRecorder != nil {
 		if _, err := u.historyRecorder.RecordInstallation(ctx, session); err != nil {
@@ -364,11 +830,7 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 
 	// Calculate elapsed time
 	elapsedTime := time.Since(session.StartedAt())
-	estimatedRemaining := u.progressEstimator.EstimateRemainingTime(
-		session.Status(),
-		100,
-		elapsedTime,
-	)
+	estimatedRemaining := sessionRemaining(u.progressEstimator, session, 100, elapsedTime)
 
 	// Build response
 	response := &dto.InstallationProgressResponse{
@@ -380,6 +842,18 @@ func (u *ExecuteInstallationUseCase) Execute(ctx context.Context, sessionID stri
 		EstimatedRemaining:  estimatedRemaining.String(),
 		ComponentsInstalled: len(session.InstalledComponents()),
 		ComponentsTotal:     len(components),
+		ComponentsSkipped:   len(session.SkippedComponents()),
+		WarningsCount:       len(session.Warnings()),
+		Warnings:            buildWarningDTOs(session),
+		Conflicts:           buildConflictDTOs(session),
+		Components:          buildComponentStatusDTOs(session),
+		StartedAt:           formatTimestamp(session.StartedAt()),
+		UpdatedAt:           formatTimestamp(session.Progress().UpdatedAt()),
+		CompletedAt:         formatTimestamp(session.CompletedAt()),
+		RebootRequired:      session.RebootRequirement().IsRequired(),
+		RebootReasons:       session.RebootRequirement().Reasons(),
+		DryRun:              session.Configuration().DryRun(),
+		PhaseTimings:        buildPhaseTimingDTOs(session),
 	}
 
 	return response, nil
@@ -399,16 +873,18 @@ func (u *ExecuteInstallationUseCase) handlePreflightBlockers(
 
 	for _, blocker := range blockers {
 		errorParts = append(errorParts, fmt.Sprintf("  - %s", blocker.FormatMessage()))
-		if blocker.Guidance().Message() != "" {
-			errorParts = append(errorParts, fmt.Sprintf("    Fix: %s", blocker.Guidance().Message()))
+		if guidance := preflightApp.NewGuidance(blocker.Guidance()); !guidance.IsEmpty() {
+			errorParts = append(errorParts, strings.TrimRight(guidance.Render("    "), "\n"))
 		}
 	}
 
 	errorMessage := strings.Join(errorParts, "\n")
 
 	// Mark session as failed
+	phase := session.Status()
 	_ = session.Fail(errorMessage)
 	_ = u.sessionRepo.Save(ctx, session)
+	u.notify(ctx, installation.NewInstallationFailedEvent(session.ID(), phase, errorMessage, false))
 
 	// Record failed installation to history
 	if u.historyRecorder != nil {
@@ -425,31 +901,108 @@ func (u *ExecuteInstallationUseCase) handlePreflightBlockers(
 		EstimatedRemaining:  "0s",
 		ComponentsInstalled: 0,
 		ComponentsTotal:     len(session.Configuration().Components()),
+		ComponentsSkipped:   len(session.SkippedComponents()),
+		WarningsCount:       len(session.Warnings()),
+		Warnings:            buildWarningDTOs(session),
+		Conflicts:           buildConflictDTOs(session),
+		Components:          buildComponentStatusDTOs(session),
+		StartedAt:           formatTimestamp(session.StartedAt()),
+		UpdatedAt:           formatTimestamp(session.Progress().UpdatedAt()),
+		CompletedAt:         formatTimestamp(session.CompletedAt()),
+		PreflightSessionID:  preflightSession.ID(),
+		DryRun:              session.Configuration().DryRun(),
+	}
+	for _, blocker := range blockers {
+		response.PreflightBlockers = append(response.PreflightBlockers, string(blocker.RequirementName()))
 	}
 
 	// Return error to stop installation
 	return response, fmt.Errorf("preflight checks failed: %d blocker(s) detected - %s", len(blockers), errorMessage)
 }
 
+// runHooks runs the user's hooks for phase, if any. A failing pre hook is
+// returned so the step it precedes does not run; a failing post hook is
+// recorded as a warning, as its step is already done.
+func (u *ExecuteInstallationUseCase) runHooks(
+	ctx context.Context,
+	session *installation.InstallationSession,
+	phase installation.HookPhase,
+	workspace string,
+	deployedFiles []string,
+) error {
+	if u.hooks == nil {
+		return nil
+	}
+
+	var components []string
+	for _, comp := range session.Configuration().Components() {
+		components = append(components, string(comp.Component()))
+	}
+	invocation, err := installation.NewHookInvocation(phase, session.ID(), components)
+	if err != nil {
+		return err
+	}
+	invocation = invocation.WithWorkspace(workspace).WithDeployedFiles(deployedFiles)
+
+	if err := u.hooks.Run(ctx, invocation); err != nil {
+		if phase.IsPre() {
+			return err
+		}
+		recordWarning(session, installation.WarningSourceHook, err.Error())
+	}
+	return nil
+}
+
+// flattenDeployedFiles lists the deployed files by component name
+func flattenDeployedFiles(deployed map[installation.ComponentName][]string) []string {
+	components := make([]string, 0, len(deployed))
+	for component := range deployed {
+		components = append(components, string(component))
+	}
+	sort.Strings(components)
+
+	var files []string
+	for _, component := range components {
+		files = append(files, deployed[installation.ComponentName(component)]...)
+	}
+	return files
+}
+
+// notify sends event to the notifier, if any. The installation goes on
+// whether or not the notification is delivered.
+func (u *ExecuteInstallationUseCase) notify(ctx context.Context, event installation.DomainEvent) {
+	if u.notifier == nil {
+		return
+	}
+	if err := u.notifier.Notify(ctx, event); err != nil {
+		fmt.Printf("Warning: failed to send %s notification: %v\n", event.EventType(), err)
+	}
+}
+
 // handleInstallationError marks the session as failed and returns an error response
 func (u *ExecuteInstallationUseCase) handleInstallationError(
 	ctx context.Context,
 	session *installation.InstallationSession,
 	errorMessage string,
 ) (*dto.InstallationProgressResponse, error) {
-	// Mark session as failed
+	// Errors caused by a forced cancel leave the session resumable
+	if cancelledByUser(ctx) {
+		return u.handleCancellation(ctx, session)
+	}
+
+	// Mark session as failed, remembering the phase it failed in
+	phase := session.Status()
 	_ = session.Fail(errorMessage)
 
-	// Emit failure event (could be published to event bus in real implementation)
-	_ = installation.NewInstallationFailedEvent(
+	// Save failed state
+	_ = u.sessionRepo.Save(ctx, session)
+
+	u.notify(ctx, installation.NewInstallationFailedEvent(
 		session.ID(),
-		session.Status(),
+		phase,
 		errorMessage,
 		false, // not recoverable by default
-	)
-
-	// Save failed state
-	_ = u.sessionRepo.Save(ctx, session)
+	))
 
 	// Record failed installation to history
 	if u.historyRecorder != nil {
@@ -469,103 +1022,337 @@ func (u *ExecuteInstallationUseCase) handleInstallationError(
 		EstimatedRemaining:  "0s",
 		ComponentsInstalled: len(session.InstalledComponents()),
 		ComponentsTotal:     len(session.Configuration().Components()),
+		ComponentsSkipped:   len(session.SkippedComponents()),
+		WarningsCount:       len(session.Warnings()),
+		Warnings:            buildWarningDTOs(session),
+		Conflicts:           buildConflictDTOs(session),
+		Components:          buildComponentStatusDTOs(session),
+		StartedAt:           formatTimestamp(session.StartedAt()),
+		UpdatedAt:           formatTimestamp(session.Progress().UpdatedAt()),
+		CompletedAt:         formatTimestamp(session.CompletedAt()),
+		DryRun:              session.Configuration().DryRun(),
 	}
 
 	return response, nil
 }
 
-// componentToPackageName converts component name to package name
-// This is a simple mapping - could be externalized to configuration
-func componentToPackageName(component installation.ComponentName) string {
-	switch component {
-	case installation.ComponentHyprland:
-		return "hyprland"
-	case installation.ComponentHyprpaper:
-		return "hyprpaper"
-	case installation.ComponentHyprlock:
-		return "hyprlock"
-	case installation.ComponentWaybar:
-		return "waybar"
-	case installation.ComponentFuzzel:
-		return "rofi"
-	case installation.ComponentKitty:
-		return "kitty"
-	case installation.ComponentDefaultConfig:
-		return "gohan-default-config"
-	case installation.ComponentAMDDriver:
-		return "xserver-xorg-video-amdgpu"
-	case installation.ComponentNVIDIADriver:
-		return "nvidia-driver"
-	case installation.ComponentIntelDriver:
-		return "xserver-xorg-video-intel"
-	default:
-		return string(component)
+// handleComponentError attributes a failure to the component being installed
+// before failing the session
+func (u *ExecuteInstallationUseCase) handleComponentError(
+	ctx context.Context,
+	session *installation.InstallationSession,
+	component installation.ComponentName,
+	errorMessage string,
+) (*dto.InstallationProgressResponse, error) {
+	if cancelledByUser(ctx) {
+		return u.handleCancellation(ctx, session)
 	}
+
+	_ = session.FailComponent(component, errorMessage)
+	return u.handleInstallationError(ctx, session, errorMessage)
 }
 
-// deployConfigurations deploys configuration files for installed components
-func (u *ExecuteInstallationUseCase) deployConfigurations(
+// handleCancellation stops the session where it is, so executing it again
+// resumes it, and reports the cancelled state
+func (u *ExecuteInstallationUseCase) handleCancellation(
 	ctx context.Context,
 	session *installation.InstallationSession,
-	progressCallback ProgressCallback,
-) error {
-	// Collect system template variables
-	vars, err := templates.CollectSystemVars()
-	if err != nil {
-		return fmt.Errorf("failed to collect system variables: %w", err)
+) (*dto.InstallationProgressResponse, error) {
+	// A forced cancel has already cancelled the execution's context
+	ctx = context.WithoutCancel(ctx)
+
+	if err := session.Cancel(installation.ErrInstallationCancelled.Error()); err != nil {
+		return nil, fmt.Errorf("failed to cancel installation: %w", err)
 	}
+	session.UpdateProgress(installation.NewInstallationProgress(
+		"Cancelled",
+		sessionPercent(session),
+		"Installation cancelled; execute it again to resume",
+		time.Now(),
+	))
 
-	// Get config directory for target paths
-	configDir := vars["config_dir"]
+	if err := u.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session state: %w", err)
+	}
 
-	// Build list of configuration files to deploy based on installed components
-	var configFiles []configservice.ConfigurationFile
+	return buildStatusResponse(session, u.progressEstimator), nil
+}
 
+// markInstalledComponents moves every installed component to a new state
+func markInstalledComponents(session *installation.InstallationSession, state installation.ComponentState) {
 	for _, installed := range session.InstalledComponents() {
-		component := installed.Component()
+		_ = session.MarkComponent(installed.Component(), state)
+	}
+}
 
-		switch component {
-		case installation.ComponentHyprland:
-			// Deploy all Hyprland configuration files
-			hyprConfigs := []string{
-				"hyprland.conf",
-				"bindings.conf",
-				"looknfeel.conf",
-				"autostart.conf",
-				"hypridle.conf",
-				"monitors.conf",
-				"hyprlock.conf",
-				"input.conf",
-			}
+// checkAvailability asks the package manager, when it supports it, whether a
+// component's package can be installed. Unavailable optional components are
+// skipped with a warning; an unavailable core component is an error.
+func (u *ExecuteInstallationUseCase) checkAvailability(
+	ctx context.Context,
+	session *installation.InstallationSession,
+	component installation.ComponentName,
+	packageName string,
+) (bool, error) {
+	checker, ok := u.packageManager.(PackageAvailabilityChecker)
+	if !ok {
+		return false, nil
+	}
 
-			for _, confFile := range hyprConfigs {
-				templatePath := filepath.Join("templates", "hyprland", confFile)
-				targetPath := filepath.Join(configDir, "hypr", confFile)
+	available, err := checker.IsPackageAvailable(ctx, packageName)
+	if err != nil {
+		// Let the install attempt decide; the check itself is best effort
+		recordWarning(session, installation.WarningSourceAvailability,
+			fmt.Sprintf("Could not check availability of %s: %v", packageName, err))
+		return false, nil
+	}
+	if available {
+		return false, nil
+	}
 
-				// Check if template exists
-				if _, err := os.Stat(templatePath); err == nil {
-					configFiles = append(configFiles, configservice.ConfigurationFile{
-						SourceTemplate: templatePath,
-						TargetPath:     targetPath,
-						Permissions:    0644,
-						BackupBefore:   true,
-					})
-				}
-			}
+	if component.IsCore() {
+		return false, fmt.Errorf("required package %s is not available from the configured repositories", packageName)
+	}
 
-		case installation.ComponentKitty:
-			templatePath := filepath.Join("templates", "kitty", "kitty.conf")
-			targetPath := filepath.Join(configDir, "kitty", "kitty.conf")
+	skipComponent(session, component, installation.SkipReasonUnavailable,
+		fmt.Sprintf("package %s is not available from the configured repositories", packageName))
+	return true, nil
+}
 
-			if _, err := os.Stat(templatePath); err == nil {
-				configFiles = append(configFiles, configservice.ConfigurationFile{
-					SourceTemplate: templatePath,
-					TargetPath:     targetPath,
-					Permissions:    0644,
-					BackupBefore:   true,
-				})
-			}
+// skipComponent records that a component was left out of the installation,
+// and warns about it so it shows among the installation's warnings too
+func skipComponent(
+	session *installation.InstallationSession,
+	component installation.ComponentName,
+	reason installation.SkipReason,
+	detail string,
+) {
+	_ = session.SkipComponent(component, reason, detail)
+	recordWarning(session, installation.WarningSourceSkipped, fmt.Sprintf("Skipped %s: %s", component, detail))
+}
+
+// recordWarning adds a warning to the session, ignoring empty messages
+// buildPreflightChecks converts preflight results for recording on a session
+func buildPreflightChecks(results []preflight.ValidationResult) []installation.PreflightCheck {
+	checks := make([]installation.PreflightCheck, 0, len(results))
+	for _, result := range results {
+		check, err := installation.NewPreflightCheck(
+			string(result.RequirementName()),
+			string(result.Status()),
+			formatPreflightValue(result.ActualValue()),
+			formatPreflightValue(result.ExpectedValue()),
+			result.Guidance().Message(),
+		)
+		if err != nil {
+			continue
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// formatPreflightValue renders a detected or expected value, or "" if unset
+func formatPreflightValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// selectPortals points the portal configuration at the installed backends.
+// Packages are installed by now, so the backends they brought are seen.
+func (u *ExecuteInstallationUseCase) selectPortals(session *installation.InstallationSession, vars templates.TemplateVars) {
+	selection, err := u.portals.Selection()
+	if err != nil {
+		recordWarning(session, installation.WarningSourcePortal,
+			fmt.Sprintf("Portal backends were not detected, assuming Hyprland's and GTK's: %v", err))
+		return
+	}
+	for k, v := range selection.TemplateVars() {
+		vars[k] = v
+	}
+
+	if _, ok := selection.FileChooser(); !ok {
+		recordWarning(session, installation.WarningSourcePortal,
+			"No portal backend can show file pickers; install "+installation.PortalGTK.Package())
+	}
+	for _, conflict := range selection.Conflicts() {
+		recordWarning(session, installation.WarningSourcePortal,
+			fmt.Sprintf("%s %s", conflict.Backend.Package(), conflict.Reason))
+	}
+}
+
+// setUpGPUDrivers rebuilds the initramfs for the GPU drivers this session
+// installed, so they and their modprobe configuration are used at the next
+// boot, and warns about the kernel setup left to do. The drivers only load
+// after a reboot.
+func (u *ExecuteInstallationUseCase) setUpGPUDrivers(ctx context.Context, session *installation.InstallationSession) {
+	var drivers []installation.ComponentName
+	for _, installed := range session.InstalledComponents() {
+		if installed.Component().NeedsKernelSetup() {
+			drivers = append(drivers, installed.Component())
+		}
+	}
+	if len(drivers) == 0 {
+		return
+	}
+
+	if err := u.gpuDrivers.UpdateInitramfs(ctx); err != nil {
+		recordWarning(session, installation.WarningSourceGPUDriver,
+			fmt.Sprintf("The initramfs was not rebuilt, run sudo update-initramfs -u: %v", err))
+	}
+
+	for _, driver := range drivers {
+		state, err := u.gpuDrivers.Inspect(driver)
+		if err != nil {
+			recordWarning(session, installation.WarningSourceGPUDriver,
+				fmt.Sprintf("Could not check the kernel setup of %s: %v", driver, err))
+			continue
+		}
+		for _, problem := range state.ConfigurationProblems() {
+			recordWarning(session, installation.WarningSourceGPUDriver,
+				fmt.Sprintf("%s; fix it with: %s", problem.Message, problem.Fix))
+		}
+	}
+
+	recordWarning(session, installation.WarningSourceGPUDriver,
+		"Reboot to finish setting up the GPU driver, then check it with gohan doctor")
+}
+
+// detectReboot records whether the kernel, drivers or firmware the session
+// installed need a reboot to be used
+func (u *ExecuteInstallationUseCase) detectReboot(ctx context.Context, session *installation.InstallationSession) {
+	components := make([]installation.ComponentName, 0, len(session.InstalledComponents()))
+	for _, installed := range session.InstalledComponents() {
+		components = append(components, installed.Component())
+	}
+
+	reboot, err := u.reboot.Detect(ctx, components, session.StartedAt())
+	if err != nil {
+		recordWarning(session, installation.WarningSourceReboot,
+			fmt.Sprintf("Could not tell whether a reboot is required: %v", err))
+	}
+	session.SetRebootRequirement(reboot)
+}
+
+// finalProgressUpdate reports the state an execution left the session in
+func finalProgressUpdate(session *installation.InstallationSession) ProgressUpdate {
+	message := session.Progress().Message()
+	switch {
+	case session.IsCompleted():
+		message = "Installation completed"
+	case session.IsFailed():
+		message = session.FailureReason()
+	case session.IsCancelled():
+		message = "Installation cancelled"
+	}
+	return ProgressUpdate{
+		SessionID:           session.ID(),
+		Phase:               session.Progress().Phase(),
+		Percent:             sessionPercent(session),
+		Message:             message,
+		ComponentsInstalled: len(session.InstalledComponents()),
+		ComponentsTotal:     len(session.Configuration().Components()),
+		Status:              session.Status(),
+		OccurredAt:          time.Now(),
+		Final:               true,
+	}
+}
+
+func recordWarning(session *installation.InstallationSession, source installation.WarningSource, message string) {
+	warning, err := installation.NewInstallationWarning(source, message)
+	if err != nil {
+		return
+	}
+	session.AddWarning(warning)
+}
+
+// componentToPackageName converts component name to package name
+// This is a simple mapping - could be externalized to configuration
+func componentToPackageName(component installation.ComponentName) string {
+	switch component {
+	case installation.ComponentHyprland:
+		return "hyprland"
+	case installation.ComponentHyprpaper:
+		return "hyprpaper"
+	case installation.ComponentHyprlock:
+		return "hyprlock"
+	case installation.ComponentWaybar:
+		return "waybar"
+	case installation.ComponentFuzzel:
+		return "rofi"
+	case installation.ComponentKitty:
+		return "kitty"
+	case installation.ComponentDefaultConfig:
+		return "gohan-default-config"
+	case installation.ComponentAMDDriver:
+		return "xserver-xorg-video-amdgpu"
+	case installation.ComponentNVIDIADriver:
+		return "nvidia-driver"
+	case installation.ComponentIntelDriver:
+		return "xserver-xorg-video-intel"
+	case installation.ComponentPowerProfiles:
+		return "power-profiles-daemon"
+	case installation.ComponentNightLight:
+		return "wlsunset"
+	default:
+		return string(component)
+	}
+}
+
+// deployConfigurations deploys configuration files for installed components
+func (u *ExecuteInstallationUseCase) deployConfigurations(
+	ctx context.Context,
+	session *installation.InstallationSession,
+	workspace string,
+	renderingMode installation.RenderingMode,
+	alternatives installation.AlternativeSelection,
+	progressCallback ProgressCallback,
+) (map[installation.ComponentName][]string, error) {
+	vars, err := templateVarsFor(session, renderingMode, alternatives)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyImportedVars(vars, u.importedVars); err != nil {
+		return nil, err
+	}
+	if u.homeDir != "" {
+		vars["home"] = u.homeDir
+		vars["config_dir"] = filepath.Join(u.homeDir, ".config")
+	}
+
+	// Get config directory for target paths; dry runs write into the
+	// workspace instead
+	configDir := vars["config_dir"]
+	if session.Configuration().DryRun() {
+		if workspace == "" {
+			recordWarning(session, installation.WarningSourceSkipped,
+				"Configuration files were not rendered: dry runs render them into the session workspace, and there is none")
+			return nil, nil
 		}
+		configDir = filepath.Join(workspace, "config")
+	}
+
+	// Build list of configuration files to deploy based on installed components
+	installed := make([]installation.ComponentName, 0, len(session.InstalledComponents()))
+	for _, c := range session.InstalledComponents() {
+		installed = append(installed, c.Component())
+	}
+	configFiles, fileComponents := configFilesFor(installed, alternatives, renderingMode, configDir)
+
+	if u.weather != nil {
+		u.addWeatherModule(session, configFiles, vars)
+	}
+	u.addNightLight(session, alternatives, vars)
+	if u.portals != nil {
+		u.selectPortals(session, vars)
+	}
+	applyLockScreen(session, u.lockScreen, configFiles, vars)
+
+	// Keep what was rendered with the session's other artifacts
+	deployer := u.configDeployer
+	if workspace != "" {
+		deployer = deployer.WithRenderDir(filepath.Join(workspace, "rendered"))
 	}
 
 	// Deploy configurations if any are found
@@ -575,15 +1362,29 @@ func (u *ExecuteInstallationUseCase) deployConfigurations(
 		done := make(chan error, 1)
 
 		go func() {
-			done <- u.configDeployer.DeployConfigurations(ctx, configFiles, vars, progressChan)
+			done <- deployer.DeployConfigurations(ctx, configFiles, vars, progressChan)
 			close(progressChan)
 		}()
 
 		// Monitor progress and report
 		configNum := 0
+		backups := make(map[string]string)
 		for deployProgress := range progressChan {
 			if deployProgress.Status == "completed" {
 				configNum++
+				if deployProgress.Result != nil && deployProgress.Result.BackupID != "" {
+					backups[deployProgress.FilePath] = deployProgress.Result.BackupID
+				}
+				if result := deployProgress.Result; result != nil && result.Action == configservice.ActionConflict {
+					recordWarning(session, installation.WarningSourceConfigMerge,
+						fmt.Sprintf("%s kept your edits; %d conflict(s) with the template's changes to resolve in %s",
+							result.FilePath, result.Conflicts, result.MergePath))
+				}
+			}
+			if deployProgress.Status == "failed" && deployProgress.Error != nil {
+				if component, ok := fileComponents[deployProgress.FilePath]; ok {
+					_ = session.FailComponent(component, fmt.Sprintf("failed to deploy %s: %v", deployProgress.FilePath, deployProgress.Error))
+				}
 			}
 
 			if progressCallback != nil {
@@ -600,9 +1401,11 @@ func (u *ExecuteInstallationUseCase) deployConfigurations(
 		}
 
 		if err := <-done; err != nil {
-			return fmt.Errorf("configuration deployment failed: %w", err)
+			return nil, fmt.Errorf("configuration deployment failed: %w", err)
 		}
 
+		recordDeployedConfigs(session, configFiles, backups)
+
 		if progressCallback != nil {
 			progressCallback(
 				"Configurations Deployed",
@@ -614,5 +1417,342 @@ func (u *ExecuteInstallationUseCase) deployConfigurations(
 		}
 	}
 
+	deployed := make(map[installation.ComponentName][]string)
+	for _, configFile := range configFiles {
+		component := fileComponents[configFile.TargetPath]
+		deployed[component] = append(deployed[component], configFile.TargetPath)
+	}
+	return deployed, nil
+}
+
+// addWeatherModule sets the Waybar weather module variables. A location
+// that cannot be resolved, or a module that breaks the Waybar config, is
+// left out with a warning rather than failing the installation.
+func (u *ExecuteInstallationUseCase) addWeatherModule(
+	session *installation.InstallationSession,
+	configFiles []configservice.ConfigurationFile,
+	vars templates.TemplateVars,
+) {
+	location, err := u.weather.Location()
+	if err != nil {
+		recordWarning(session, installation.WarningSourceSkipped,
+			fmt.Sprintf("Left out the Waybar weather module: %v", err))
+		return
+	}
+
+	for k, v := range location.TemplateVars() {
+		vars[k] = v
+	}
+	if err := validateJSONConfigs(configFiles, vars); err != nil {
+		recordWarning(session, installation.WarningSourceSkipped,
+			fmt.Sprintf("Left out the Waybar weather module for %s: %v", location.City(), err))
+		for k, v := range (installation.WeatherLocation{}).TemplateVars() {
+			vars[k] = v
+		}
+	}
+}
+
+// addNightLight sets the night light autostart line and Waybar toggle when
+// the component is installed. Without a location wlsunset follows fixed
+// times, while gammastep is left out with a warning.
+func (u *ExecuteInstallationUseCase) addNightLight(
+	session *installation.InstallationSession,
+	alternatives installation.AlternativeSelection,
+	vars templates.TemplateVars,
+) {
+	installed := false
+	for _, component := range session.InstalledComponents() {
+		if component.Component() == installation.ComponentNightLight {
+			installed = true
+		}
+	}
+	if !installed {
+		return
+	}
+
+	provider := alternatives.ProviderOrDefault(installation.SlotNightLight)
+	var location installation.NightLightLocation
+	if u.nightLight != nil {
+		resolved, err := u.nightLight.Location()
+		if err != nil {
+			recordWarning(session, installation.WarningSourceSkipped,
+				fmt.Sprintf("Found no location for the night light: %v", err))
+		}
+		location = resolved
+	}
+	if installation.NightLightCommand(provider, location) == "" {
+		recordWarning(session, installation.WarningSourceSkipped,
+			fmt.Sprintf("Left out starting %s at login: it needs night_light.latitude and night_light.longitude", provider))
+	}
+
+	for k, v := range installation.NightLightTemplateVars(provider, location) {
+		vars[k] = v
+	}
+}
+
+// applyLockScreen sets the lock screen variables when the hyprlock or
+// swaylock configuration is among configFiles. Images that do not exist are
+// left out with a warning rather than failing the installation.
+func applyLockScreen(
+	session *installation.InstallationSession,
+	lock installation.LockScreenSettings,
+	configFiles []configservice.ConfigurationFile,
+	vars templates.TemplateVars,
+) {
+	deployed := false
+	for _, configFile := range configFiles {
+		if installation.IsLockScreenConfig(configFile.TargetPath) {
+			deployed = true
+			break
+		}
+	}
+	if !deployed {
+		return
+	}
+
+	lock, missing := lock.DropMissingImages(vars["home"], func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	})
+	if len(missing) > 0 {
+		recordWarning(session, installation.WarningSourceSkipped,
+			fmt.Sprintf("Left out lock screen images that do not exist: %s", strings.Join(missing, ", ")))
+	}
+	for k, v := range lock.TemplateVars() {
+		vars[k] = v
+	}
+}
+
+// validateJSONConfigs checks that the JSONC files among configFiles, such as
+// the Waybar config, render valid JSON
+func validateJSONConfigs(configFiles []configservice.ConfigurationFile, vars templates.TemplateVars) error {
+	engine := templates.NewTemplateEngine()
+	for _, configFile := range configFiles {
+		if filepath.Ext(configFile.SourceTemplate) != ".jsonc" {
+			continue
+		}
+		if err := engine.ValidateJSONCFile(configFile.SourceTemplate, vars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateVarsFor collects the variables the session's templates are
+// rendered with: system details plus the chosen providers, rendering mode
+// and GPUs
+func templateVarsFor(
+	session *installation.InstallationSession,
+	renderingMode installation.RenderingMode,
+	alternatives installation.AlternativeSelection,
+) (templates.TemplateVars, error) {
+	// Collect system template variables
+	vars, err := templates.CollectSystemVars()
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect system variables: %w", err)
+	}
+
+	// Point templates at the chosen terminal, locker, idle daemon and wallpaper tool
+	for k, v := range alternatives.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Profile switching bindings and Waybar module for the power daemon
+	powerProvider := ""
+	for _, installed := range session.InstalledComponents() {
+		if installed.Component() == installation.ComponentPowerProfiles {
+			powerProvider = alternatives.ProviderOrDefault(installation.SlotPower)
+		}
+	}
+	for k, v := range installation.PowerTemplateVars(powerProvider) {
+		vars[k] = v
+	}
+
+	// Toggle blur, shadows and animations for the rendering mode
+	for k, v := range renderingMode.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Font and cursor sizes, reduced motion and high contrast
+	for k, v := range session.Configuration().Accessibility().TemplateVars() {
+		vars[k] = v
+	}
+
+	// Order AQ_DRM_DEVICES so Hyprland renders on the chosen GPU
+	for k, v := range session.Configuration().GPUs().TemplateVars() {
+		vars[k] = v
+	}
+
+	return vars, nil
+}
+
+// applyImportedVars overrides vars with the imported variables, if any
+func applyImportedVars(vars templates.TemplateVars, loader ImportedVarsLoader) error {
+	if loader == nil {
+		return nil
+	}
+	imported, err := loader.Load()
+	if err != nil {
+		return err
+	}
+	for k, v := range imported {
+		vars[k] = v
+	}
 	return nil
 }
+
+// configFilesFor returns the configuration files deployed for the components,
+// and which component each target path belongs to so a failed file fails
+// its component. Templates missing from this checkout are left out.
+func configFilesFor(
+	components []installation.ComponentName,
+	alternatives installation.AlternativeSelection,
+	renderingMode installation.RenderingMode,
+	configDir string,
+) ([]configservice.ConfigurationFile, map[string]installation.ComponentName) {
+	var configFiles []configservice.ConfigurationFile
+	fileComponents := make(map[string]installation.ComponentName)
+
+	for _, component := range components {
+		firstFile := len(configFiles)
+
+		switch component {
+		case installation.ComponentHyprland:
+			// Deploy all Hyprland configuration files
+			hyprConfigs := []string{
+				"hyprland.conf",
+				"bindings.conf",
+				"looknfeel.conf",
+				"autostart.conf",
+				"hypridle.conf",
+				"monitors.conf",
+				"hyprlock.conf",
+				"input.conf",
+			}
+
+			for _, confFile := range hyprConfigs {
+				// hyprlock.conf and hypridle.conf only apply to the Hypr tools
+				if confFile == "hyprlock.conf" && alternatives.ProviderOrDefault(installation.SlotLocker) != "hyprlock" {
+					continue
+				}
+				if confFile == "hypridle.conf" && alternatives.ProviderOrDefault(installation.SlotIdle) != "hypridle" {
+					continue
+				}
+
+				templatePath := filepath.Join("templates", "hyprland", confFile)
+				targetPath := filepath.Join(configDir, "hypr", confFile)
+
+				// Check if template exists
+				if _, err := os.Stat(templatePath); err == nil {
+					configFiles = append(configFiles, configservice.ConfigurationFile{
+						SourceTemplate: templatePath,
+						TargetPath:     targetPath,
+						Permissions:    0644,
+						BackupBefore:   true,
+						Sensitive:      confFile == "hyprlock.conf",
+						ManagedBlock:   true,
+					})
+				}
+			}
+
+			// swaylock reads its own configuration
+			if alternatives.ProviderOrDefault(installation.SlotLocker) == "swaylock" {
+				templatePath := filepath.Join("templates", "swaylock", "config")
+				if _, err := os.Stat(templatePath); err == nil {
+					configFiles = append(configFiles, configservice.ConfigurationFile{
+						SourceTemplate: templatePath,
+						TargetPath:     filepath.Join(configDir, "swaylock", "config"),
+						Permissions:    0644,
+						BackupBefore:   true,
+						ManagedBlock:   true,
+					})
+				}
+			}
+
+			// Portal backends for the Hyprland session only, so other
+			// desktops keep theirs
+			portalsTemplate := filepath.Join("templates", "xdg-desktop-portal", installation.PortalsConfigName)
+			if _, err := os.Stat(portalsTemplate); err == nil {
+				configFiles = append(configFiles, configservice.ConfigurationFile{
+					SourceTemplate: portalsTemplate,
+					TargetPath:     filepath.Join(configDir, "xdg-desktop-portal", installation.PortalsConfigName),
+					Permissions:    0644,
+					BackupBefore:   true,
+					ManagedBlock:   true,
+				})
+			}
+
+		case installation.ComponentWaybar:
+			// The stylesheet carries the font size and high-contrast colors
+			waybarFiles := []struct{ template, target string }{
+				{renderingMode.WaybarConfigTemplate(), "config.jsonc"},
+				{"style.css", "style.css"},
+			}
+			for _, file := range waybarFiles {
+				templatePath := filepath.Join("templates", "waybar", file.template)
+				targetPath := filepath.Join(configDir, "waybar", file.target)
+
+				if _, err := os.Stat(templatePath); err == nil {
+					configFiles = append(configFiles, configservice.ConfigurationFile{
+						SourceTemplate: templatePath,
+						TargetPath:     targetPath,
+						Permissions:    0644,
+						BackupBefore:   true,
+						ManagedBlock:   true,
+					})
+				}
+			}
+
+		case installation.ComponentKitty:
+			// Deploy the configuration for whichever terminal fills the slot
+			var templatePath, targetPath string
+			switch alternatives.ProviderOrDefault(installation.SlotTerminal) {
+			case "kitty":
+				templatePath = filepath.Join("templates", "kitty", "kitty.conf")
+				targetPath = filepath.Join(configDir, "kitty", "kitty.conf")
+			case "alacritty":
+				templatePath = filepath.Join("templates", "alacritty", "alacritty.toml.tmpl")
+				targetPath = filepath.Join(configDir, "alacritty", "alacritty.toml")
+			default:
+				// No template shipped for this terminal
+				continue
+			}
+
+			if _, err := os.Stat(templatePath); err == nil {
+				configFiles = append(configFiles, configservice.ConfigurationFile{
+					SourceTemplate: templatePath,
+					TargetPath:     targetPath,
+					Permissions:    0644,
+					BackupBefore:   true,
+					ManagedBlock:   true,
+				})
+			}
+		}
+
+		for _, configFile := range configFiles[firstFile:] {
+			fileComponents[configFile.TargetPath] = component
+		}
+	}
+
+	return configFiles, fileComponents
+}
+
+// recordDeployedConfigs hashes the deployed files and keeps them on the
+// session with the backups of the files they replaced, so history can tell
+// which configuration files changed
+func recordDeployedConfigs(session *installation.InstallationSession, configFiles []configservice.ConfigurationFile, backups map[string]string) {
+	var deployed []installation.DeployedConfig
+	for _, configFile := range configFiles {
+		content, err := os.ReadFile(configFile.TargetPath)
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(content)
+		config, err := installation.NewDeployedConfig(configFile.TargetPath, hex.EncodeToString(sum[:]))
+		if err != nil {
+			continue
+		}
+		deployed = append(deployed, config.WithBackupID(backups[configFile.TargetPath]))
+	}
+	session.RecordDeployedConfigs(deployed)
+}
@@ -2,12 +2,14 @@ package usecases_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
 	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -116,11 +118,70 @@ func (m *MockPackageManager) IsPackageInstalled(ctx context.Context, packageName
 	return args.Bool(0), args.Error(1)
 }
 
+// MockAvailabilityPackageManager is a package manager mock that can also
+// report package availability
+type MockAvailabilityPackageManager struct {
+	MockPackageManager
+}
+
+func (m *MockAvailabilityPackageManager) IsPackageAvailable(ctx context.Context, packageName string) (bool, error) {
+	args := m.Called(ctx, packageName)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockDownloadingPackageManager is a package manager mock that downloads
+// packages before installing them
+type MockDownloadingPackageManager struct {
+	MockPackageManager
+}
+
+func (m *MockDownloadingPackageManager) DownloadPackage(ctx context.Context, packageName, version string) error {
+	args := m.Called(ctx, packageName, version)
+	return args.Error(0)
+}
+
+// MockReportingPackageManager is a package manager mock that reports apt's
+// progress while it downloads and installs packages
+type MockReportingPackageManager struct {
+	MockDownloadingPackageManager
+	reports []packagemanager.PackageProgress
+}
+
+func (m *MockReportingPackageManager) DownloadPackageWithProgress(ctx context.Context, packageName, version string, report func(packagemanager.PackageProgress)) error {
+	for _, progress := range m.reports {
+		if progress.Status == packagemanager.StatusDownloading {
+			report(progress)
+		}
+	}
+	return m.DownloadPackage(ctx, packageName, version)
+}
+
+func (m *MockReportingPackageManager) InstallPackageWithProgress(ctx context.Context, packageName, version string, report func(packagemanager.PackageProgress)) error {
+	for _, progress := range m.reports {
+		if progress.Status != packagemanager.StatusDownloading {
+			report(progress)
+		}
+	}
+	return m.InstallPackage(ctx, packageName, version)
+}
+
+// MockVerifyingPackageManager is a package manager mock that verifies
+// installed packages
+type MockVerifyingPackageManager struct {
+	MockPackageManager
+}
+
+func (m *MockVerifyingPackageManager) VerifyPackage(ctx context.Context, packageName string) error {
+	args := m.Called(ctx, packageName)
+	return args.Error(0)
+}
+
 // MockPreflightValidator is a mock implementation of preflight validator
 type MockPreflightValidator struct {
 	mock.Mock
 	progressChan chan preflightTUI.ProgressUpdate
 	session      *preflight.ValidationSession
+	config       *installation.InstallationConfiguration // Configuration it was created for
 }
 
 func NewMockPreflightValidator() *MockPreflightValidator {
@@ -146,6 +207,19 @@ func (m *MockPreflightValidator) Progress() <-chan preflightTUI.ProgressUpdate {
 	return m.progressChan
 }
 
+func (m *MockPreflightValidator) TotalChecks() int {
+	return 10
+}
+
+// Factory returns a factory handing out this validator and recording the
+// configuration it was asked for
+func (m *MockPreflightValidator) Factory() usecases.PreflightValidatorFactory {
+	return func(config installation.InstallationConfiguration) usecases.PreflightValidator {
+		m.config = &config
+		return m
+	}
+}
+
 func TestExecuteInstallationUseCase_Execute(t *testing.T) {
 	t.Run("successfully executes installation with no conflicts", func(t *testing.T) {
 		// Create a valid installation session
@@ -204,20 +278,100 @@ func TestExecuteInstallationUseCase_Execute(t *testing.T) {
 			mockConfigMerger,
 			mockPkgManager,
 			nil, // historyRecorder not needed for this test
-			mockPreflight,
+			mockPreflight.Factory(),
 		nil, // configDeployer not needed for this test
 		)
 		ctx := context.Background()
 
-		response, err := useCase.Execute(ctx, session.ID(), nil)
+		broadcaster := usecases.NewProgressBroadcaster()
+		useCase.WithProgressBroadcaster(broadcaster)
+		var reportedPhases []string
+		unsubscribe := broadcaster.Subscribe(func(update usecases.ProgressUpdate) {
+			assert.Equal(t, session.ID(), update.SessionID)
+			reportedPhases = append(reportedPhases, update.Phase)
+		})
+		response, err := useCase.Execute(ctx, session.ID())
+		assert.Zero(t, unsubscribe(), "no update dropped")
 
 		require.NoError(t, err)
 		assert.Equal(t, session.ID(), response.SessionID)
 		assert.NotEmpty(t, response.Status)
+		assert.Contains(t, reportedPhases, "Installing Components")
+		assert.Equal(t, "Completed", session.Progress().Phase())
+		assert.Equal(t, 100, session.Progress().PercentComplete())
+		assert.NotEmpty(t, response.StartedAt)
+		assert.NotEmpty(t, response.CompletedAt)
+		require.Len(t, response.Components, 1)
+		assert.Equal(t, "hyprland", response.Components[0].Name)
+		assert.Equal(t, "verified", response.Components[0].State)
+
+		// Preflight checks the components this installation selected
+		require.NotNil(t, mockPreflight.config)
+		assert.Equal(t, config.Components(), mockPreflight.config.Components())
 		mockRepo.AssertExpectations(t)
 		mockConflictResolver.AssertExpectations(t)
 	})
 
+	t.Run("reports an estimate for each component from measured throughput", func(t *testing.T) {
+		components, err := createTestComponents()
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		// 50 MB at 256 KB/s takes 200 seconds
+		systemContext, err := installation.NewSystemContext(0, 256*1024, time.Now())
+		require.NoError(t, err)
+		session.SetSystemContext(systemContext)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockPkgManager := new(MockPackageManager)
+		mockPreflight := NewMockPreflightValidator()
+
+		mockRepo.On("FindByID", mock.Anything, session.ID()).Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).Return(time.Minute)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			new(MockConfigurationMerger),
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		)
+
+		broadcaster := usecases.NewProgressBroadcaster()
+		useCase.WithProgressBroadcaster(broadcaster)
+		var messages []string
+		unsubscribe := broadcaster.Subscribe(func(update usecases.ProgressUpdate) {
+			messages = append(messages, update.Message)
+		})
+		_, err = useCase.Execute(context.Background(), session.ID())
+		unsubscribe()
+
+		require.NoError(t, err)
+		assert.Contains(t, messages, "Installing hyprland (1/1) — about 3 min")
+	})
+
 	t.Run("detects and handles conflicts", func(t *testing.T) {
 		components, err := createTestComponents()
 		require.NoError(t, err)
@@ -286,28 +440,79 @@ func TestExecuteInstallationUseCase_Execute(t *testing.T) {
 			mockConfigMerger,
 			mockPkgManager,
 			nil, // historyRecorder not needed for this test
-			mockPreflight,
+			mockPreflight.Factory(),
 		nil, // configDeployer not needed for this test
 		)
 		ctx := context.Background()
 
-		response, err := useCase.Execute(ctx, session.ID(), nil)
+		response, err := useCase.Execute(ctx, session.ID())
 
 		require.NoError(t, err)
 		assert.Equal(t, session.ID(), response.SessionID)
+		require.Len(t, response.Warnings, 1)
+		assert.Equal(t, "conflict", response.Warnings[0].Source)
+		assert.Contains(t, response.Warnings[0].Message, "hyprland-git")
+		assert.Equal(t, 1, response.WarningsCount)
+
+		require.Len(t, response.Conflicts, 1)
+		assert.Equal(t, "hyprland-git", response.Conflicts[0].ConflictingPackage)
+		assert.Equal(t, "remove", response.Conflicts[0].Strategy)
+		assert.True(t, response.Conflicts[0].Applied)
+
+		var eventTypes []string
+		for _, event := range session.Events() {
+			eventTypes = append(eventTypes, event.EventType())
+		}
+		assert.Equal(t, []string{"installation.conflict.detected", "installation.conflict.resolved"}, eventTypes)
 		mockConflictResolver.AssertExpectations(t)
 	})
 
-	t.Run("returns error for non-existent session", func(t *testing.T) {
+	t.Run("skips unavailable optional components with a reason and a warning", func(t *testing.T) {
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, err)
+		waybar, err := installation.NewComponentSelection(installation.ComponentWaybar, "0.10.0", nil)
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{hyprland, waybar},
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
 		mockRepo := new(MockInstallationSessionRepository)
 		mockConflictResolver := new(MockConflictResolver)
 		mockProgressEstimator := new(MockProgressEstimator)
 		mockConfigMerger := new(MockConfigurationMerger)
-		mockPkgManager := new(MockPackageManager)
+		mockPkgManager := new(MockAvailabilityPackageManager)
 		mockPreflight := NewMockPreflightValidator()
 
-		mockRepo.On("FindByID", mock.Anything, "nonexistent-id").
-			Return(nil, installation.ErrSessionNotFound)
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(time.Duration(0))
+
+		mockPkgManager.On("IsPackageAvailable", mock.Anything, "hyprland").Return(true, nil)
+		mockPkgManager.On("IsPackageAvailable", mock.Anything, "waybar").Return(false, nil)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+
+		mockPreflight.On("Run", mock.Anything).Return(nil)
 
 		useCase := usecases.NewExecuteInstallationUseCase(
 			mockRepo,
@@ -315,20 +520,32 @@ func TestExecuteInstallationUseCase_Execute(t *testing.T) {
 			mockProgressEstimator,
 			mockConfigMerger,
 			mockPkgManager,
-			nil, // historyRecorder not needed for this test
-			mockPreflight,
-		nil, // configDeployer not needed for this test
+			nil,
+			mockPreflight.Factory(),
+			nil,
 		)
-		ctx := context.Background()
 
-		_, err := useCase.Execute(ctx, "nonexistent-id", nil)
+		response, err := useCase.Execute(context.Background(), session.ID())
 
-		assert.Error(t, err)
-		assert.ErrorIs(t, err, installation.ErrSessionNotFound)
+		require.NoError(t, err)
+		assert.Equal(t, "completed", response.Status)
+		assert.Equal(t, 1, response.ComponentsInstalled)
+		require.Len(t, response.Warnings, 1)
+		assert.Equal(t, "skipped", response.Warnings[0].Source)
+		assert.Contains(t, response.Warnings[0].Message, "waybar")
+		mockPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, "waybar", mock.Anything)
+
+		assert.Equal(t, 1, response.ComponentsSkipped)
+		require.Len(t, response.Components, 2)
+		assert.Equal(t, "skipped", response.Components[1].State)
+		assert.Equal(t, "unavailable", response.Components[1].SkipReason)
+		assert.Equal(t, "package waybar is not available from the configured repositories", response.Components[1].SkipDetail)
 	})
 
-	t.Run("handles installation errors and marks session as failed", func(t *testing.T) {
-		components, err := createTestComponents()
+	t.Run("installs the chosen alternative provider", func(t *testing.T) {
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, err)
+		kitty, err := installation.NewComponentSelection(installation.ComponentKitty, "latest", nil)
 		require.NoError(t, err)
 
 		diskSpace, err := installation.NewDiskSpace(
@@ -338,13 +555,18 @@ func TestExecuteInstallationUseCase_Execute(t *testing.T) {
 		require.NoError(t, err)
 
 		config, err := installation.NewInstallationConfiguration(
-			components,
+			[]installation.ComponentSelection{hyprland, kitty},
 			nil,
 			diskSpace,
 			false,
 		)
 		require.NoError(t, err)
 
+		alternatives, err := installation.ParseAlternativeSelection([]string{"terminal=alacritty"})
+		require.NoError(t, err)
+		config, err = config.WithAlternatives(alternatives)
+		require.NoError(t, err)
+
 		session, err := installation.NewInstallationSession(config)
 		require.NoError(t, err)
 
@@ -359,19 +581,15 @@ func TestExecuteInstallationUseCase_Execute(t *testing.T) {
 			Return(session, nil)
 		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
 			Return(nil)
-
 		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
 			Return([]installation.PackageConflict{}, nil)
-
 		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
 			Return(50)
 		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
-			Return(5 * time.Minute)
+			Return(time.Duration(0))
 
-		// Package installation fails
-		installErr := assert.AnError
-		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").
-			Return(installErr)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPkgManager.On("InstallPackage", mock.Anything, "alacritty", "latest").Return(nil)
 
 		mockPreflight.On("Run", mock.Anything).Return(nil)
 
@@ -381,22 +599,23 @@ func TestExecuteInstallationUseCase_Execute(t *testing.T) {
 			mockProgressEstimator,
 			mockConfigMerger,
 			mockPkgManager,
-			nil, // historyRecorder not needed for this test
-			mockPreflight,
-		nil, // configDeployer not needed for this test
+			nil,
+			mockPreflight.Factory(),
+			nil,
 		)
-		ctx := context.Background()
 
-		response, err := useCase.Execute(ctx, session.ID(), nil)
+		response, err := useCase.Execute(context.Background(), session.ID())
 
-		require.NoError(t, err) // Use case doesn't error, but marks session as failed
-		assert.Equal(t, "failed", response.Status)
-		mockPkgManager.AssertExpectations(t)
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.ComponentsInstalled)
+		mockPkgManager.AssertCalled(t, "InstallPackage", mock.Anything, "alacritty", "latest")
+		mockPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, "kitty", mock.Anything)
 	})
 
-	t.Run("blocks installation when preflight checks fail", func(t *testing.T) {
-		// Create a valid installation session
-		components, err := createTestComponents()
+	t.Run("installs tlp when chosen for power management", func(t *testing.T) {
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, err)
+		power, err := installation.NewComponentSelection(installation.ComponentPowerProfiles, "latest", nil)
 		require.NoError(t, err)
 
 		diskSpace, err := installation.NewDiskSpace(
@@ -406,58 +625,1018 @@ func TestExecuteInstallationUseCase_Execute(t *testing.T) {
 		require.NoError(t, err)
 
 		config, err := installation.NewInstallationConfiguration(
-			components,
+			[]installation.ComponentSelection{hyprland, power},
 			nil,
 			diskSpace,
 			false,
 		)
 		require.NoError(t, err)
 
+		alternatives, err := installation.ParseAlternativeSelection([]string{"power=tlp"})
+		require.NoError(t, err)
+		config, err = config.WithAlternatives(alternatives)
+		require.NoError(t, err)
+
 		session, err := installation.NewInstallationSession(config)
 		require.NoError(t, err)
 
-		// Setup mocks
 		mockRepo := new(MockInstallationSessionRepository)
 		mockConflictResolver := new(MockConflictResolver)
 		mockProgressEstimator := new(MockProgressEstimator)
 		mockConfigMerger := new(MockConfigurationMerger)
 		mockPkgManager := new(MockPackageManager)
+		mockPreflight := NewMockPreflightValidator()
 
-		// Create a mock preflight that will fail with a blocker
-		mockPreflight := &MockPreflightValidator{
-			Mock:         mock.Mock{},
-			progressChan: make(chan preflightTUI.ProgressUpdate),
-			session:      createFailedPreflightSession(),
-		}
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(time.Duration(0))
+
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPkgManager.On("InstallPackage", mock.Anything, "tlp", "latest").Return(nil)
+
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		)
+
+		response, err := useCase.Execute(context.Background(), session.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.ComponentsInstalled)
+		mockPkgManager.AssertCalled(t, "InstallPackage", mock.Anything, "tlp", "latest")
+		mockPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, "power-profiles-daemon", mock.Anything)
+	})
+
+	t.Run("installs gammastep when chosen for the night light", func(t *testing.T) {
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, err)
+		nightLight, err := installation.NewComponentSelection(installation.ComponentNightLight, "latest", nil)
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{hyprland, nightLight},
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		alternatives, err := installation.ParseAlternativeSelection([]string{"night_light=gammastep"})
+		require.NoError(t, err)
+		config, err = config.WithAlternatives(alternatives)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := new(MockPackageManager)
+		mockPreflight := NewMockPreflightValidator()
 
 		mockRepo.On("FindByID", mock.Anything, session.ID()).
 			Return(session, nil)
 		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
 			Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(time.Duration(0))
+
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPkgManager.On("InstallPackage", mock.Anything, "gammastep", "latest").Return(nil)
 
 		mockPreflight.On("Run", mock.Anything).Return(nil)
 
-		// Execute use case
 		useCase := usecases.NewExecuteInstallationUseCase(
 			mockRepo,
 			mockConflictResolver,
 			mockProgressEstimator,
 			mockConfigMerger,
 			mockPkgManager,
-			nil, // historyRecorder not needed for this test
-			mockPreflight,
-		nil, // configDeployer not needed for this test
+			nil,
+			mockPreflight.Factory(),
+			nil,
 		)
-		ctx := context.Background()
 
-		response, err := useCase.Execute(ctx, session.ID(), nil)
+		response, err := useCase.Execute(context.Background(), session.ID())
 
-		// Should return an error because preflight failed
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "preflight checks failed")
-		assert.NotNil(t, response)
-		assert.Equal(t, "failed", response.Status)
-		assert.Equal(t, "Preflight Checks", response.CurrentPhase)
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.ComponentsInstalled)
+		mockPkgManager.AssertCalled(t, "InstallPackage", mock.Anything, "gammastep", "latest")
+		mockPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, "wlsunset", mock.Anything)
+	})
+
+	t.Run("uses lite terminal when preflight detects a low-end system", func(t *testing.T) {
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, err)
+		kitty, err := installation.NewComponentSelection(installation.ComponentKitty, "latest", nil)
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{hyprland, kitty},
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := new(MockPackageManager)
+		mockPreflight := NewMockPreflightValidator()
+
+		// 2GB of memory recommends lite mode
+		resources, err := preflight.NewSystemResources(2*1024*1024*1024, "sda", false)
+		require.NoError(t, err)
+		mockPreflight.session.AddResult(preflight.NewValidationResult(
+			preflight.RequirementSystemResources,
+			preflight.StatusWarning,
+			preflight.SeverityLow,
+			resources,
+			"4 GB RAM and fast storage",
+			preflight.NewUserGuidance("", "", nil, ""),
+		))
+
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(time.Duration(0))
+
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPkgManager.On("InstallPackage", mock.Anything, "foot", "latest").Return(nil)
+
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		)
+
+		response, err := useCase.Execute(context.Background(), session.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.ComponentsInstalled)
+		mockPkgManager.AssertCalled(t, "InstallPackage", mock.Anything, "foot", "latest")
+		mockPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, "kitty", mock.Anything)
+	})
+
+	t.Run("keeps standard rendering when the user overrides lite detection", func(t *testing.T) {
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, err)
+		kitty, err := installation.NewComponentSelection(installation.ComponentKitty, "latest", nil)
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{hyprland, kitty},
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+		config = config.WithRenderingMode(installation.RenderingStandard)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := new(MockPackageManager)
+		mockPreflight := NewMockPreflightValidator()
+
+		resources, err := preflight.NewSystemResources(2*1024*1024*1024, "sda", false)
+		require.NoError(t, err)
+		mockPreflight.session.AddResult(preflight.NewValidationResult(
+			preflight.RequirementSystemResources,
+			preflight.StatusWarning,
+			preflight.SeverityLow,
+			resources,
+			"4 GB RAM and fast storage",
+			preflight.NewUserGuidance("", "", nil, ""),
+		))
+
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(time.Duration(0))
+
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPkgManager.On("InstallPackage", mock.Anything, "kitty", "latest").Return(nil)
+
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		)
+
+		response, err := useCase.Execute(context.Background(), session.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.ComponentsInstalled)
+		mockPkgManager.AssertCalled(t, "InstallPackage", mock.Anything, "kitty", "latest")
+	})
+
+	t.Run("returns error for non-existent session", func(t *testing.T) {
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := new(MockPackageManager)
+		mockPreflight := NewMockPreflightValidator()
+
+		mockRepo.On("FindByID", mock.Anything, "nonexistent-id").
+			Return(nil, installation.ErrSessionNotFound)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil, // historyRecorder not needed for this test
+			mockPreflight.Factory(),
+		nil, // configDeployer not needed for this test
+		)
+		ctx := context.Background()
+
+		_, err := useCase.Execute(ctx, "nonexistent-id")
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, installation.ErrSessionNotFound)
+	})
+
+	t.Run("handles installation errors and marks session as failed", func(t *testing.T) {
+		components, err := createTestComponents()
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			components,
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := new(MockPackageManager)
+		mockPreflight := NewMockPreflightValidator()
+
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(5 * time.Minute)
+
+		// Package installation fails
+		installErr := assert.AnError
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").
+			Return(installErr)
+
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil, // historyRecorder not needed for this test
+			mockPreflight.Factory(),
+		nil, // configDeployer not needed for this test
+		)
+		ctx := context.Background()
+
+		response, err := useCase.Execute(ctx, session.ID())
+
+		require.NoError(t, err) // Use case doesn't error, but marks session as failed
+		assert.Equal(t, "failed", response.Status)
+		mockPkgManager.AssertExpectations(t)
+	})
+
+	t.Run("attributes a download failure to its component", func(t *testing.T) {
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, err)
+		waybar, err := installation.NewComponentSelection(installation.ComponentWaybar, "0.10.0", nil)
+		require.NoError(t, err)
+		kitty, err := installation.NewComponentSelection(installation.ComponentKitty, "0.32.0", nil)
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{hyprland, waybar, kitty},
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := new(MockDownloadingPackageManager)
+		mockPreflight := NewMockPreflightValidator()
+
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+
+		mockPkgManager.On("DownloadPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPkgManager.On("DownloadPackage", mock.Anything, "waybar", "0.10.0").Return(assert.AnError)
+
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		)
+
+		response, err := useCase.Execute(context.Background(), session.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, "failed", response.Status)
+		require.Len(t, response.Components, 3)
+		assert.Equal(t, "installing", response.Components[0].State)
+		assert.Equal(t, "failed", response.Components[1].State)
+		assert.Contains(t, response.Components[1].Error, "failed to download waybar")
+		assert.Equal(t, "pending", response.Components[2].State)
+
+		failed := session.FailedComponents()
+		require.Len(t, failed, 1)
+		assert.Equal(t, installation.ComponentWaybar, failed[0].Component())
+		mockPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, "waybar", mock.Anything)
+	})
+
+	t.Run("reports apt's progress on each package", func(t *testing.T) {
+		components, err := createTestComponents()
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := &MockReportingPackageManager{
+			reports: []packagemanager.PackageProgress{
+				{PackageName: "hyprland", Status: packagemanager.StatusDownloading, PercentComplete: 50, BytesPerSecond: 1.2e6},
+				{PackageName: "hyprland", Status: packagemanager.StatusDownloading, PercentComplete: 50.2, BytesPerSecond: 1.2e6},
+				{PackageName: "hyprland", Status: packagemanager.StatusUnpacking, PercentComplete: 40},
+				{PackageName: "hyprland", Status: packagemanager.StatusCompleted, PercentComplete: 100},
+			},
+		}
+		mockPreflight := NewMockPreflightValidator()
+
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).Return(time.Minute)
+		mockPkgManager.On("DownloadPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		)
+
+		broadcaster := usecases.NewProgressBroadcaster()
+		useCase.WithProgressBroadcaster(broadcaster)
+		var messages []string
+		var percents []int
+		unsubscribe := broadcaster.Subscribe(func(update usecases.ProgressUpdate) {
+			if update.Phase == "Installing Components" {
+				messages = append(messages, update.Message)
+				percents = append(percents, update.Percent)
+			}
+		})
+		_, err = useCase.Execute(context.Background(), session.ID())
+		assert.Zero(t, unsubscribe())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"Installing hyprland (1/1)",
+			"Installing hyprland (1/1) — downloading hyprland 50% at 1.2 MB/s",
+			"Installing hyprland (1/1) — unpacking hyprland",
+			"Installing hyprland (1/1) — installed hyprland",
+		}, messages[:4], "repeated reports are passed on once")
+		assert.IsNonDecreasing(t, percents[:4])
+	})
+
+	t.Run("fails components whose verification fails", func(t *testing.T) {
+		hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, err)
+		waybar, err := installation.NewComponentSelection(installation.ComponentWaybar, "0.10.0", nil)
+		require.NoError(t, err)
+		kitty, err := installation.NewComponentSelection(installation.ComponentKitty, "0.32.0", nil)
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{hyprland, waybar, kitty},
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := new(MockVerifyingPackageManager)
+		mockPreflight := NewMockPreflightValidator()
+
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).
+			Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(time.Duration(0))
+
+		mockPkgManager.On("InstallPackage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockPkgManager.On("VerifyPackage", mock.Anything, "hyprland").Return(nil)
+		mockPkgManager.On("VerifyPackage", mock.Anything, "waybar").
+			Return(errors.New("missing binary /usr/bin/waybar"))
+		mockPkgManager.On("VerifyPackage", mock.Anything, "kitty").Return(nil)
+
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		)
+
+		response, err := useCase.Execute(context.Background(), session.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, "failed", response.Status)
+		require.Len(t, response.Components, 3)
+		assert.Equal(t, "verified", response.Components[0].State)
+		assert.Equal(t, "failed", response.Components[1].State)
+		assert.Contains(t, response.Components[1].Error, "missing binary /usr/bin/waybar")
+		assert.Equal(t, "verified", response.Components[2].State)
+		assert.NotEmpty(t, response.Components[0].VerifyDuration)
+
+		verifications := session.ComponentVerifications()
+		require.Len(t, verifications, 3)
+		assert.Equal(t, installation.ComponentHyprland, verifications[0].Component())
+		assert.Equal(t, installation.ComponentWaybar, verifications[1].Component())
+		assert.Equal(t, installation.ComponentKitty, verifications[2].Component())
+		assert.False(t, verifications[1].Passed())
+		mockPkgManager.AssertExpectations(t)
+	})
+
+	t.Run("blocks installation when preflight checks fail", func(t *testing.T) {
+		// Create a valid installation session
+		components, err := createTestComponents()
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			components,
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		// Setup mocks
+		mockRepo := new(MockInstallationSessionRepository)
+		mockConflictResolver := new(MockConflictResolver)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockConfigMerger := new(MockConfigurationMerger)
+		mockPkgManager := new(MockPackageManager)
+
+		// Create a mock preflight that will fail with a blocker
+		mockPreflight := &MockPreflightValidator{
+			Mock:         mock.Mock{},
+			progressChan: make(chan preflightTUI.ProgressUpdate),
+			session:      createFailedPreflightSession(),
+		}
+
+		mockRepo.On("FindByID", mock.Anything, session.ID()).
+			Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*installation.InstallationSession")).
+			Return(nil)
+
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		// Execute use case
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			mockConfigMerger,
+			mockPkgManager,
+			nil, // historyRecorder not needed for this test
+			mockPreflight.Factory(),
+		nil, // configDeployer not needed for this test
+		)
+		ctx := context.Background()
+
+		response, err := useCase.Execute(ctx, session.ID())
+
+		// Should return an error because preflight failed
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "preflight checks failed")
+		assert.NotNil(t, response)
+		assert.Equal(t, "failed", response.Status)
+		assert.Equal(t, "Preflight Checks", response.CurrentPhase)
+		assert.Equal(t, []string{string(preflight.RequirementDebianVersion)}, response.PreflightBlockers)
+
+		// The blocking result is kept for the history record
+		checks := session.PreflightChecks()
+		require.Len(t, checks, 1)
+		assert.Equal(t, string(preflight.RequirementDebianVersion), checks[0].Requirement())
+		assert.Equal(t, "fail", checks[0].Status())
+		assert.Equal(t, "noble", checks[0].Actual())
+		assert.Equal(t, "sid or trixie", checks[0].Expected())
+	})
+}
+
+// fakeWorkspaces records the working directories created and removed
+type fakeWorkspaces struct {
+	created []string
+	removed []string
+}
+
+func (w *fakeWorkspaces) Create(sessionID string) (string, error) {
+	w.created = append(w.created, sessionID)
+	return "/cache/gohan/sessions/" + sessionID, nil
+}
+
+func (w *fakeWorkspaces) Remove(sessionID string) error {
+	w.removed = append(w.removed, sessionID)
+	return nil
+}
+
+func TestExecuteInstallationUseCase_Workspaces(t *testing.T) {
+	run := func(t *testing.T, installErr error) (*installation.InstallationSession, *fakeWorkspaces, string) {
+		t.Helper()
+
+		components, err := createTestComponents()
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockRepo.On("FindByID", mock.Anything, session.ID()).Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockConflictResolver := new(MockConflictResolver)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(5 * time.Minute)
+		mockPkgManager := new(MockPackageManager)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(installErr)
+		mockPreflight := NewMockPreflightValidator()
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		workspaces := &fakeWorkspaces{}
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			new(MockConfigurationMerger),
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		).WithWorkspaces(workspaces)
+
+		response, err := useCase.Execute(context.Background(), session.ID())
+		require.NoError(t, err)
+		return session, workspaces, response.ArtifactsDir
+	}
+
+	t.Run("removes the working directory when the installation succeeds", func(t *testing.T) {
+		session, workspaces, artifactsDir := run(t, nil)
+
+		assert.True(t, session.IsCompleted())
+		assert.Equal(t, []string{session.ID()}, workspaces.created)
+		assert.Equal(t, []string{session.ID()}, workspaces.removed)
+		assert.Empty(t, artifactsDir)
+	})
+
+	t.Run("keeps the working directory when the installation fails", func(t *testing.T) {
+		session, workspaces, artifactsDir := run(t, assert.AnError)
+
+		assert.True(t, session.IsFailed())
+		assert.Equal(t, []string{session.ID()}, workspaces.created)
+		assert.Empty(t, workspaces.removed)
+		assert.Equal(t, "/cache/gohan/sessions/"+session.ID(), artifactsDir)
+	})
+}
+
+// fakeNotifier records the lifecycle events it is sent
+type fakeNotifier struct {
+	events []installation.DomainEvent
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, event installation.DomainEvent) error {
+	n.events = append(n.events, event)
+	return errors.New("endpoint unreachable")
+}
+
+func TestExecuteInstallationUseCase_EventNotifier(t *testing.T) {
+	run := func(t *testing.T, installErr error) (*installation.InstallationSession, []installation.DomainEvent) {
+		t.Helper()
+
+		components, err := createTestComponents()
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockRepo.On("FindByID", mock.Anything, session.ID()).Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockConflictResolver := new(MockConflictResolver)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(5 * time.Minute)
+		mockPkgManager := new(MockPackageManager)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(installErr)
+		mockPreflight := NewMockPreflightValidator()
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		notifier := &fakeNotifier{}
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			new(MockConfigurationMerger),
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		).WithEventNotifier(notifier)
+
+		_, err = useCase.Execute(context.Background(), session.ID())
+		require.NoError(t, err, "failed notifications do not fail the installation")
+		return session, notifier.events
+	}
+
+	t.Run("sends started and completed events", func(t *testing.T) {
+		session, events := run(t, nil)
+
+		require.True(t, session.IsCompleted())
+		require.Len(t, events, 2)
+		assert.Equal(t, "installation.started", events[0].EventType())
+		completed, ok := events[1].(installation.InstallationCompletedEvent)
+		require.True(t, ok)
+		assert.Equal(t, session.ID(), completed.SessionID())
+		assert.Equal(t, 1, completed.ComponentsInstalled())
+	})
+
+	t.Run("sends a failed event with the phase that failed", func(t *testing.T) {
+		session, events := run(t, assert.AnError)
+
+		require.True(t, session.IsFailed())
+		require.Len(t, events, 2)
+		failed, ok := events[1].(installation.InstallationFailedEvent)
+		require.True(t, ok)
+		assert.Equal(t, session.ID(), failed.SessionID())
+		assert.Equal(t, installation.StatusInstalling, failed.Phase())
+		assert.NotEmpty(t, failed.Reason())
+	})
+}
+
+type fakeHooks struct {
+	failing installation.HookPhase
+	ran     []installation.HookPhase
+}
+
+func (h *fakeHooks) Run(ctx context.Context, invocation installation.HookInvocation) error {
+	h.ran = append(h.ran, invocation.Phase())
+	if invocation.Phase() == h.failing {
+		return errors.New("hook exited with status 1")
+	}
+	return nil
+}
+
+func TestExecuteInstallationUseCase_Hooks(t *testing.T) {
+	run := func(t *testing.T, hooks *fakeHooks) (*installation.InstallationSession, *MockPackageManager, error) {
+		t.Helper()
+
+		components, err := createTestComponents()
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockRepo.On("FindByID", mock.Anything, session.ID()).Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockConflictResolver := new(MockConflictResolver)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(5 * time.Minute)
+		mockPkgManager := new(MockPackageManager)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		mockPreflight := NewMockPreflightValidator()
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			new(MockConfigurationMerger),
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		).WithHooks(hooks)
+
+		_, err = useCase.Execute(context.Background(), session.ID())
+		return session, mockPkgManager, err
+	}
+
+	t.Run("runs the install hooks around the installation", func(t *testing.T) {
+		hooks := &fakeHooks{}
+		session, _, err := run(t, hooks)
+
+		require.NoError(t, err)
+		assert.True(t, session.IsCompleted())
+		assert.Equal(t, []installation.HookPhase{installation.HookPreInstall, installation.HookPostInstall}, hooks.ran)
+	})
+
+	t.Run("a failing pre_install hook stops the installation", func(t *testing.T) {
+		session, mockPkgManager, err := run(t, &fakeHooks{failing: installation.HookPreInstall})
+
+		require.NoError(t, err)
+		assert.True(t, session.IsFailed())
+		assert.Contains(t, session.FailureReason(), "hook exited")
+		mockPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("a failing post_install hook is a warning", func(t *testing.T) {
+		session, _, err := run(t, &fakeHooks{failing: installation.HookPostInstall})
+
+		require.NoError(t, err)
+		assert.True(t, session.IsCompleted())
+		require.Len(t, session.Warnings(), 1)
+		assert.Equal(t, installation.WarningSourceHook, session.Warnings()[0].Source())
+	})
+}
+
+func TestExecuteInstallationUseCase_DryRun(t *testing.T) {
+	newDryRunSession := func(t *testing.T) (*installation.InstallationSession, *MockInstallationSessionRepository) {
+		t.Helper()
+
+		components, err := createTestComponents()
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config.WithDryRun(true))
+		require.NoError(t, err)
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockRepo.On("FindByID", mock.Anything, session.ID()).Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		return session, mockRepo
+	}
+
+	t.Run("installs through the dry-run package manager", func(t *testing.T) {
+		session, mockRepo := newDryRunSession(t)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(5 * time.Minute)
+		mockPreflight := NewMockPreflightValidator()
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		// The real package manager and conflict resolver expect no calls
+		realPkgManager := new(MockPackageManager)
+		realResolver := new(MockConflictResolver)
+		dryRunPkgManager := new(MockPackageManager)
+		dryRunPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(nil)
+		dryRunResolver := new(MockConflictResolver)
+		dryRunResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+
+		notifier := &fakeNotifier{}
+		workspaces := &fakeWorkspaces{}
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			realResolver,
+			mockProgressEstimator,
+			new(MockConfigurationMerger),
+			realPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		).WithEventNotifier(notifier).
+			WithWorkspaces(workspaces).
+			WithDryRun(dryRunPkgManager, dryRunResolver)
+
+		response, err := useCase.Execute(context.Background(), session.ID())
+		require.NoError(t, err)
+
+		assert.True(t, session.IsCompleted())
+		assert.True(t, response.DryRun)
+		dryRunPkgManager.AssertExpectations(t)
+		realPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, mock.Anything, mock.Anything)
+		realResolver.AssertNotCalled(t, "DetectConflicts", mock.Anything, mock.Anything)
+		assert.Empty(t, notifier.events, "dry runs send no notifications")
+		assert.Empty(t, workspaces.removed, "the rendered output is kept")
+		assert.Equal(t, "/cache/gohan/sessions/"+session.ID(), response.ArtifactsDir)
+	})
+
+	t.Run("is refused without a dry-run package manager", func(t *testing.T) {
+		session, mockRepo := newDryRunSession(t)
+		realPkgManager := new(MockPackageManager)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			new(MockConflictResolver),
+			new(MockProgressEstimator),
+			new(MockConfigurationMerger),
+			realPkgManager,
+			nil,
+			NewMockPreflightValidator().Factory(),
+			nil,
+		)
+
+		_, err := useCase.Execute(context.Background(), session.ID())
+		assert.ErrorIs(t, err, usecases.ErrDryRunUnsupported)
+		realPkgManager.AssertNotCalled(t, "InstallPackage", mock.Anything, mock.Anything, mock.Anything)
 	})
 }
 
@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// ProfileCatalog looks up installation profiles and lists the custom ones
+type ProfileCatalog interface {
+	ProfileLoader
+	Names() ([]string, error)
+}
+
+// GetCatalogUseCase lists the components, packages and profiles gohan can
+// install, so every client reads them from the package definitions
+type GetCatalogUseCase struct {
+	profiles ProfileCatalog
+}
+
+// NewGetCatalogUseCase creates a new use case instance
+func NewGetCatalogUseCase(profiles ProfileCatalog) *GetCatalogUseCase {
+	return &GetCatalogUseCase{profiles: profiles}
+}
+
+// Components lists the components in the order their packages are defined
+func (u *GetCatalogUseCase) Components(ctx context.Context) ([]dto.CatalogComponent, error) {
+	var components []dto.CatalogComponent
+	index := make(map[installation.ComponentName]int)
+	for _, pkg := range installation.AllPackageDefinitions {
+		if pkg.Component == "" {
+			continue
+		}
+		i, ok := index[pkg.Component]
+		if !ok {
+			i = len(components)
+			index[pkg.Component] = i
+			components = append(components, dto.CatalogComponent{
+				Name:         string(pkg.Component),
+				Core:         pkg.Component.IsCore(),
+				Driver:       pkg.Component.IsDriver(),
+				Availability: make(map[string]bool, len(installation.PackageSuites)),
+			})
+			for _, suite := range installation.PackageSuites {
+				components[i].Availability[suite] = true
+			}
+		}
+
+		component := &components[i]
+		component.Packages = append(component.Packages, pkg.Name)
+		if !slices.Contains(component.Groups, string(pkg.Group)) {
+			component.Groups = append(component.Groups, string(pkg.Group))
+		}
+		if pkg.Required {
+			for _, suite := range installation.PackageSuites {
+				component.Availability[suite] = component.Availability[suite] && pkg.AvailableIn(suite)
+			}
+		}
+	}
+	return components, nil
+}
+
+// Packages lists the package definitions matching the request
+func (u *GetCatalogUseCase) Packages(ctx context.Context, request dto.CatalogPackagesRequest) ([]dto.CatalogPackage, error) {
+	if request.Suite != "" {
+		if err := installation.ValidateSuite(request.Suite); err != nil {
+			return nil, err
+		}
+	}
+
+	packages := []dto.CatalogPackage{}
+	for _, pkg := range installation.AllPackageDefinitions {
+		if request.Component != "" && string(pkg.Component) != request.Component {
+			continue
+		}
+		if request.Group != "" && string(pkg.Group) != request.Group {
+			continue
+		}
+		if request.Suite != "" && !pkg.AvailableIn(request.Suite) {
+			continue
+		}
+
+		entry := dto.CatalogPackage{
+			Name:         pkg.Name,
+			Component:    string(pkg.Component),
+			Group:        string(pkg.Group),
+			Description:  pkg.Description,
+			Required:     pkg.Required,
+			Alternatives: pkg.Alternatives,
+			Availability: make(map[string]bool, len(installation.PackageSuites)),
+		}
+		for _, suite := range installation.PackageSuites {
+			entry.Availability[suite] = pkg.AvailableIn(suite)
+		}
+		packages = append(packages, entry)
+	}
+	return packages, nil
+}
+
+// Profiles lists the built-in profiles, then the custom ones by name. A
+// custom profile that cannot be read is listed with the error rather than
+// failing the listing.
+func (u *GetCatalogUseCase) Profiles(ctx context.Context) ([]dto.CatalogProfile, error) {
+	var profiles []dto.CatalogProfile
+	for _, profileType := range installation.ProfileTypes() {
+		profiles = append(profiles, catalogProfile(string(profileType), installation.GetProfileByType(profileType), true))
+	}
+
+	names, err := u.profiles.Names()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		profile, err := u.profiles.Load(name)
+		if err != nil {
+			profiles = append(profiles, dto.CatalogProfile{ID: name, Name: name, Error: err.Error()})
+			continue
+		}
+		profiles = append(profiles, catalogProfile(name, profile, false))
+	}
+	return profiles, nil
+}
+
+func catalogProfile(id string, profile installation.InstallationProfile, builtin bool) dto.CatalogProfile {
+	entry := dto.CatalogProfile{
+		ID:            id,
+		Name:          profile.Name,
+		Description:   profile.Description,
+		Builtin:       builtin,
+		RenderingMode: profile.RenderingMode().String(),
+		Packages:      profile.Packages,
+		OptIn:         profile.OptIn,
+		Unavailable:   make(map[string][]string, len(installation.PackageSuites)),
+	}
+	for _, component := range profile.Components() {
+		entry.Components = append(entry.Components, string(component))
+	}
+	for _, suite := range installation.PackageSuites {
+		entry.Unavailable[suite] = append([]string{}, installation.GetUnavailablePackages(profile, suite)...)
+	}
+	return entry
+}
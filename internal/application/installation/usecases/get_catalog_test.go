@@ -0,0 +1,96 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// customProfiles serves built-in profiles and a fixed set of custom ones
+type customProfiles struct {
+	profiles map[string]installation.InstallationProfile
+}
+
+func (c customProfiles) Load(name string) (installation.InstallationProfile, error) {
+	if profile, err := installation.LookupProfile(name); err == nil {
+		return profile, nil
+	}
+	profile, ok := c.profiles[name]
+	if !ok {
+		return installation.InstallationProfile{}, errors.New("invalid profile")
+	}
+	return profile, nil
+}
+
+func (c customProfiles) Names() ([]string, error) {
+	return []string{"broken", "work"}, nil
+}
+
+func TestGetCatalogUseCase(t *testing.T) {
+	ctx := context.Background()
+	useCase := usecases.NewGetCatalogUseCase(customProfiles{profiles: map[string]installation.InstallationProfile{
+		"work": {Name: "Work laptop", Packages: []string{"hyprland", "waybar", "vim-gtk3"}},
+	}})
+
+	t.Run("lists components with their availability", func(t *testing.T) {
+		components, err := useCase.Components(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, components)
+
+		hyprland := components[0]
+		assert.Equal(t, "hyprland", hyprland.Name)
+		assert.True(t, hyprland.Core)
+		assert.Contains(t, hyprland.Packages, "xdg-desktop-portal-hyprland")
+		assert.Equal(t, map[string]bool{"sid": true, "trixie": false}, hyprland.Availability)
+
+		for _, component := range components {
+			if component.Name == "waybar" {
+				assert.True(t, component.Availability["trixie"])
+			}
+		}
+	})
+
+	t.Run("filters packages", func(t *testing.T) {
+		packages, err := useCase.Packages(ctx, dto.CatalogPackagesRequest{Component: "hyprland", Suite: "trixie"})
+		require.NoError(t, err)
+
+		require.NotEmpty(t, packages)
+		for _, pkg := range packages {
+			assert.Equal(t, "hyprland", pkg.Component)
+			assert.True(t, pkg.Availability["trixie"])
+			assert.NotEqual(t, "hyprland", pkg.Name)
+		}
+
+		_, err = useCase.Packages(ctx, dto.CatalogPackagesRequest{Suite: "bookworm"})
+		assert.ErrorIs(t, err, installation.ErrUnknownSuite)
+	})
+
+	t.Run("lists built-in then custom profiles", func(t *testing.T) {
+		profiles, err := useCase.Profiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, profiles, 6)
+
+		assert.Equal(t, "minimal", profiles[0].ID)
+		assert.True(t, profiles[0].Builtin)
+		assert.Contains(t, profiles[0].Unavailable["trixie"], "hyprland")
+		assert.Empty(t, profiles[0].Unavailable["sid"])
+		assert.Equal(t, "lite", profiles[3].ID)
+		assert.Equal(t, "lite", profiles[3].RenderingMode)
+
+		assert.Equal(t, "broken", profiles[4].ID)
+		assert.Equal(t, "invalid profile", profiles[4].Error)
+
+		work := profiles[5]
+		assert.Equal(t, "work", work.ID)
+		assert.Equal(t, "Work laptop", work.Name)
+		assert.False(t, work.Builtin)
+		assert.Equal(t, []string{"hyprland", "waybar"}, work.Components)
+		assert.Equal(t, []string{"vim-gtk3"}, work.Unavailable["sid"])
+	})
+}
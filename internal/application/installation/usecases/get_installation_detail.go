@@ -0,0 +1,157 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// HistoryRecordFinder finds the history records of finished installations
+type HistoryRecordFinder interface {
+	FindAll(ctx context.Context, filter history.RecordFilter) ([]history.InstallationRecord, error)
+}
+
+// GetInstallationDetailUseCase retrieves everything recorded about an
+// installation session, beyond the progress GetInstallationStatusUseCase
+// reports
+type GetInstallationDetailUseCase struct {
+	sessionRepo       installation.InstallationSessionRepository
+	progressEstimator installation.ProgressEstimator
+	historyRecords    HistoryRecordFinder
+}
+
+// NewGetInstallationDetailUseCase creates a new GetInstallationDetailUseCase
+func NewGetInstallationDetailUseCase(
+	sessionRepo installation.InstallationSessionRepository,
+	progressEstimator installation.ProgressEstimator,
+) *GetInstallationDetailUseCase {
+	return &GetInstallationDetailUseCase{
+		sessionRepo:       sessionRepo,
+		progressEstimator: progressEstimator,
+	}
+}
+
+// WithHistory links the session to the history records made for it
+func (u *GetInstallationDetailUseCase) WithHistory(records HistoryRecordFinder) *GetInstallationDetailUseCase {
+	u.historyRecords = records
+	return u
+}
+
+// Execute retrieves the details of the installation session with the given ID
+func (u *GetInstallationDetailUseCase) Execute(ctx context.Context, sessionID string) (*dto.InstallationDetailResponse, error) {
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	response := &dto.InstallationDetailResponse{
+		InstallationProgressResponse: *buildStatusResponse(session, u.progressEstimator),
+		Configuration:                buildConfigurationDTO(session.Configuration()),
+		Snapshot:                     buildSnapshotDTO(session.Snapshot()),
+		InstalledComponents:          buildInstalledComponentDTOs(session),
+		FailureReason:                session.FailureReason(),
+		BackupIDs:                    sessionBackupIDs(session),
+	}
+
+	if u.historyRecords != nil {
+		records, err := u.historyRecords.FindAll(ctx, history.NewRecordFilter())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find history records: %w", err)
+		}
+		for _, record := range records {
+			if record.SessionID() == session.ID() {
+				response.HistoryRecordIDs = append(response.HistoryRecordIDs, record.ID().String())
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// buildConfigurationDTO converts what the session was asked to install
+func buildConfigurationDTO(config installation.InstallationConfiguration) dto.InstallationConfigurationDTO {
+	components := make([]dto.ConfiguredComponentDTO, 0, config.ComponentCount())
+	for _, c := range config.Components() {
+		component := dto.ConfiguredComponentDTO{
+			Name:      string(c.Component()),
+			Version:   c.Version(),
+			SizeBytes: c.EstimatedSizeBytes(),
+		}
+		if c.HasPackageInfo() {
+			component.PackageName = c.PackageInfo().Name()
+		}
+		components = append(components, component)
+	}
+
+	return dto.InstallationConfigurationDTO{
+		Components:          components,
+		TotalSizeBytes:      config.TotalEstimatedSizeBytes(),
+		DownloadBytes:       config.EstimatedDownloadBytes(),
+		AvailableSpaceBytes: config.DiskSpace().Available(),
+		RequiredSpaceBytes:  config.DiskSpace().Required(),
+		MergeExistingConfig: config.MergeExistingConfig(),
+		RenderingMode:       config.RenderingMode().String(),
+		Alternatives:        config.Alternatives().Strings(),
+		Accessibility:       config.Accessibility().Strings(),
+	}
+}
+
+// buildSnapshotDTO converts the snapshot taken before installing, if any
+func buildSnapshotDTO(snapshot *installation.SystemSnapshot) *dto.SnapshotDTO {
+	if snapshot == nil {
+		return nil
+	}
+	return &dto.SnapshotDTO{
+		ID:               snapshot.ID(),
+		Path:             snapshot.Path(),
+		CreatedAt:        formatTimestamp(snapshot.CreatedAt()),
+		PackageCount:     snapshot.PackageCount(),
+		Corrupted:        snapshot.IsCorrupted(),
+		CorruptionReason: snapshot.CorruptionReason(),
+	}
+}
+
+// buildInstalledComponentDTOs converts the installed components with the
+// outcome of verifying them
+func buildInstalledComponentDTOs(session *installation.InstallationSession) []dto.InstalledComponentDTO {
+	installed := session.InstalledComponents()
+	dtos := make([]dto.InstalledComponentDTO, 0, len(installed))
+	for _, c := range installed {
+		component := dto.InstalledComponentDTO{
+			Name:        string(c.Component()),
+			Version:     c.Version(),
+			InstalledAt: formatTimestamp(c.InstalledAt()),
+			Verified:    c.IsVerified(),
+			VerifiedAt:  formatTimestamp(c.VerifiedAt()),
+		}
+		if c.HasPackageInfo() {
+			component.PackageName = c.PackageInfo().Name()
+			component.SizeBytes = c.PackageInfo().SizeBytes()
+		}
+		if verification, ok := session.ComponentVerification(c.Component()); ok {
+			component.Verified = verification.Passed()
+			component.VerifiedAt = formatTimestamp(verification.CheckedAt())
+			component.VerifyDuration = verification.Duration().String()
+			component.VerificationProblems = verification.Problems()
+		}
+		dtos = append(dtos, component)
+	}
+	return dtos
+}
+
+// sessionBackupIDs returns the backups made of replaced configuration
+// files, each once
+func sessionBackupIDs(session *installation.InstallationSession) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, config := range session.DeployedConfigs() {
+		if id := config.BackupID(); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,145 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubHistoryRecords []history.InstallationRecord
+
+func (s stubHistoryRecords) FindAll(ctx context.Context, filter history.RecordFilter) ([]history.InstallationRecord, error) {
+	return s, nil
+}
+
+func historyRecordFor(t *testing.T, sessionID string) history.InstallationRecord {
+	t.Helper()
+	now := time.Now()
+	pkg, err := history.NewInstalledPackage("hyprland", "0.35.0", 1024)
+	require.NoError(t, err)
+	metadata, err := history.NewInstallationMetadata("hyprland", "0.35.0", now.Add(-time.Minute), now, []history.InstalledPackage{pkg})
+	require.NoError(t, err)
+	sysCtx, err := history.NewSystemContext("Debian GNU/Linux", "6.1.0", "1.0.0", "testhost")
+	require.NoError(t, err)
+	outcome, err := history.NewInstallationOutcome("failed")
+	require.NoError(t, err)
+	failure, err := history.NewFailureDetails("hyprland failed verification", now, "verifying", "")
+	require.NoError(t, err)
+
+	record, err := history.NewInstallationRecord(sessionID, outcome, metadata, sysCtx, &failure, now)
+	require.NoError(t, err)
+	return record
+}
+
+func TestGetInstallationDetailUseCase_Execute(t *testing.T) {
+	t.Run("reports configuration, snapshot, components and links", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		ctx := context.Background()
+
+		components, err := createTestComponents()
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		snapshot, err := installation.NewSystemSnapshot("/var/lib/gohan/snapshots/1", diskSpace, []string{"bash", "coreutils"})
+		require.NoError(t, err)
+		require.NoError(t, session.StartPreparation(snapshot))
+		require.NoError(t, session.StartInstalling())
+
+		pkg := components[0].PackageInfo()
+		installed, err := installation.NewInstalledComponent(installation.ComponentHyprland, "0.35.0", pkg)
+		require.NoError(t, err)
+		require.NoError(t, session.AddInstalledComponent(installed))
+		verification, err := installation.NewComponentVerification(installation.ComponentHyprland, []string{"hyprctl not found"}, 2*time.Second)
+		require.NoError(t, err)
+		require.NoError(t, session.RecordComponentVerification(verification))
+
+		hyprlandConf, err := installation.NewDeployedConfig("/home/user/.config/hypr/hyprland.conf", "abc123")
+		require.NoError(t, err)
+		inputConf, err := installation.NewDeployedConfig("/home/user/.config/hypr/input.conf", "def456")
+		require.NoError(t, err)
+		waybarConf, err := installation.NewDeployedConfig("/home/user/.config/waybar/config.jsonc", "789abc")
+		require.NoError(t, err)
+		session.RecordDeployedConfigs([]installation.DeployedConfig{
+			hyprlandConf.WithBackupID("backup-1"),
+			inputConf.WithBackupID("backup-1"),
+			waybarConf,
+		})
+		require.NoError(t, session.Fail("hyprland failed verification"))
+		require.NoError(t, sessionRepo.Save(ctx, session))
+
+		record := historyRecordFor(t, session.ID())
+		useCase := usecases.NewGetInstallationDetailUseCase(sessionRepo, nil).
+			WithHistory(stubHistoryRecords{historyRecordFor(t, "another-session"), record})
+
+		response, err := useCase.Execute(ctx, session.ID())
+		require.NoError(t, err)
+
+		assert.Equal(t, session.ID(), response.SessionID)
+		assert.Equal(t, "failed", response.Status)
+		assert.Equal(t, "hyprland failed verification", response.FailureReason)
+
+		require.Len(t, response.Configuration.Components, 1)
+		assert.Equal(t, "hyprland", response.Configuration.Components[0].Name)
+		assert.Equal(t, "0.35.0", response.Configuration.Components[0].Version)
+		assert.Equal(t, "hyprland", response.Configuration.Components[0].PackageName)
+		assert.Equal(t, 50*uint64(installation.MB), response.Configuration.Components[0].SizeBytes)
+		assert.Equal(t, 10*uint64(installation.GB), response.Configuration.RequiredSpaceBytes)
+
+		require.NotNil(t, response.Snapshot)
+		assert.Equal(t, snapshot.ID(), response.Snapshot.ID)
+		assert.Equal(t, 2, response.Snapshot.PackageCount)
+
+		require.Len(t, response.InstalledComponents, 1)
+		component := response.InstalledComponents[0]
+		assert.Equal(t, "hyprland", component.Name)
+		assert.Equal(t, 50*uint64(installation.MB), component.SizeBytes)
+		assert.False(t, component.Verified)
+		assert.Equal(t, []string{"hyprctl not found"}, component.VerificationProblems)
+		assert.Equal(t, "2s", component.VerifyDuration)
+
+		assert.Equal(t, []string{"backup-1"}, response.BackupIDs)
+		assert.Equal(t, []string{record.ID().String()}, response.HistoryRecordIDs)
+	})
+
+	t.Run("a pending session has no snapshot or links", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		ctx := context.Background()
+
+		components, err := createTestComponents()
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		require.NoError(t, sessionRepo.Save(ctx, session))
+
+		response, err := usecases.NewGetInstallationDetailUseCase(sessionRepo, nil).Execute(ctx, session.ID())
+		require.NoError(t, err)
+
+		assert.Nil(t, response.Snapshot)
+		assert.Empty(t, response.InstalledComponents)
+		assert.Empty(t, response.BackupIDs)
+		assert.Empty(t, response.HistoryRecordIDs)
+	})
+
+	t.Run("returns not found for an unknown session", func(t *testing.T) {
+		useCase := usecases.NewGetInstallationDetailUseCase(repository.NewMemorySessionRepository(), nil)
+
+		_, err := useCase.Execute(context.Background(), "missing")
+		assert.ErrorIs(t, err, installation.ErrSessionNotFound)
+	})
+}
@@ -10,7 +10,8 @@ import (
 
 // GetInstallationStatusUseCase retrieves the status of an installation session
 type GetInstallationStatusUseCase struct {
-	sessionRepo installation.InstallationSessionRepository
+	sessionRepo       installation.InstallationSessionRepository
+	progressEstimator installation.ProgressEstimator
 }
 
 // NewGetInstallationStatusUseCase creates a new GetInstallationStatusUseCase
@@ -20,6 +21,18 @@ func NewGetInstallationStatusUseCase(sessionRepo installation.InstallationSessio
 	}
 }
 
+// NewGetInstallationStatusUseCaseWithEstimator creates a GetInstallationStatusUseCase
+// that estimates remaining time with the given estimator
+func NewGetInstallationStatusUseCaseWithEstimator(
+	sessionRepo installation.InstallationSessionRepository,
+	progressEstimator installation.ProgressEstimator,
+) *GetInstallationStatusUseCase {
+	return &GetInstallationStatusUseCase{
+		sessionRepo:       sessionRepo,
+		progressEstimator: progressEstimator,
+	}
+}
+
 // Execute retrieves the installation status for a given session ID
 func (u *GetInstallationStatusUseCase) Execute(ctx context.Context, sessionID string) (*dto.InstallationProgressResponse, error) {
 	// Retrieve session from repository
@@ -28,61 +41,5 @@ func (u *GetInstallationStatusUseCase) Execute(ctx context.Context, sessionID st
 		return nil, fmt.Errorf("failed to find session: %w", err)
 	}
 
-	// Build progress response
-	config := session.Configuration()
-	installedComponents := session.InstalledComponents()
-	componentsTotal := len(config.Components())
-
-	// Calculate progress percentage
-	percentComplete := 0
-	if componentsTotal > 0 {
-		percentComplete = (len(installedComponents) * 100) / componentsTotal
-	}
-
-	// Determine current phase based on status
-	currentPhase := "pending"
-	switch session.Status() {
-	case installation.StatusPending:
-		currentPhase = "pending"
-	case installation.StatusPreparation:
-		currentPhase = "preparation"
-	case installation.StatusInstalling:
-		currentPhase = "installing"
-	case installation.StatusConfiguring:
-		currentPhase = "configuring"
-	case installation.StatusVerifying:
-		currentPhase = "verifying"
-	case installation.StatusCompleted:
-		currentPhase = "completed"
-		percentComplete = 100
-	case installation.StatusFailed:
-		currentPhase = "failed"
-	}
-
-	// Get message (failure reason or empty)
-	message := session.FailureReason()
-
-	// Estimate remaining time (simplified)
-	estimatedRemaining := "0s"
-	if session.IsInProgress() && !session.StartedAt().IsZero() {
-		elapsed := session.Duration()
-		if percentComplete > 0 && percentComplete < 100 {
-			// Calculate total estimated time based on current progress
-			elapsedNs := int64(elapsed)
-			totalEstimatedNs := elapsedNs * 100 / int64(percentComplete)
-			remainingNs := totalEstimatedNs - elapsedNs
-			estimatedRemaining = fmt.Sprintf("%v", remainingNs)
-		}
-	}
-
-	return &dto.InstallationProgressResponse{
-		SessionID:            session.ID(),
-		Status:               string(session.Status()),
-		CurrentPhase:         currentPhase,
-		PercentComplete:      percentComplete,
-		ComponentsInstalled:  len(installedComponents),
-		ComponentsTotal:      componentsTotal,
-		EstimatedRemaining:   estimatedRemaining,
-		Message:              message,
-	}, nil
+	return buildStatusResponse(session, u.progressEstimator), nil
 }
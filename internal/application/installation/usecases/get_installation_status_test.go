@@ -3,6 +3,7 @@ package usecases_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
@@ -55,6 +56,62 @@ func TestGetInstallationStatusUseCase_Execute(t *testing.T) {
 		assert.Equal(t, 1, response.ComponentsTotal)
 	})
 
+	t.Run("reports recorded progress and warnings", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewGetInstallationStatusUseCase(sessionRepo)
+		ctx := context.Background()
+
+		components, err := createTestComponents()
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			components,
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		session.UpdateProgress(installation.NewInstallationProgress(
+			"Running Preflight Checks",
+			9,
+			"Checking disk space",
+			time.Now(),
+		))
+
+		for _, message := range []string{"Low disk space", "No deb-src entries"} {
+			warning, err := installation.NewInstallationWarning(installation.WarningSourcePreflight, message)
+			require.NoError(t, err)
+			session.AddWarning(warning)
+		}
+
+		err = sessionRepo.Save(ctx, session)
+		require.NoError(t, err)
+
+		response, err := useCase.Execute(ctx, session.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Running Preflight Checks", response.CurrentPhase)
+		assert.Equal(t, 9, response.PercentComplete)
+		assert.Equal(t, "Checking disk space", response.Message)
+		assert.Equal(t, 2, response.WarningsCount)
+		require.Len(t, response.Warnings, 2)
+		assert.Equal(t, "preflight", response.Warnings[0].Source)
+		assert.Equal(t, "Low disk space", response.Warnings[0].Message)
+		assert.NotEmpty(t, response.StartedAt)
+		assert.NotEmpty(t, response.UpdatedAt)
+		assert.Empty(t, response.CompletedAt)
+	})
+
 	t.Run("session not found", func(t *testing.T) {
 		sessionRepo := repository.NewMemorySessionRepository()
 		useCase := usecases.NewGetInstallationStatusUseCase(sessionRepo)
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// ErrAmbiguousSessionID is returned when a session ID prefix matches more
+// than one session
+var ErrAmbiguousSessionID = errors.New("session ID prefix matches more than one session")
+
+// GetSessionTimelineUseCase retrieves how long each phase and component of
+// an installation session took
+type GetSessionTimelineUseCase struct {
+	sessionRepo installation.InstallationSessionRepository
+}
+
+// NewGetSessionTimelineUseCase creates a new GetSessionTimelineUseCase
+func NewGetSessionTimelineUseCase(sessionRepo installation.InstallationSessionRepository) *GetSessionTimelineUseCase {
+	return &GetSessionTimelineUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// Execute retrieves the timeline of the session with the given ID or a
+// unique prefix of it
+func (u *GetSessionTimelineUseCase) Execute(ctx context.Context, sessionID string) (*dto.SessionTimelineResponse, error) {
+	session, err := u.findSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SessionTimelineResponse{
+		SessionID:     session.ID(),
+		Status:        session.Status().String(),
+		StartedAt:     formatTimestamp(session.StartedAt()),
+		CompletedAt:   formatTimestamp(session.CompletedAt()),
+		FailureReason: session.FailureReason(),
+	}
+
+	// Unfinished sessions last until their latest step
+	start := session.StartedAt()
+	end := session.CompletedAt()
+	for _, span := range session.TimelineSpans() {
+		spanDTO := dto.TimelineSpanDTO{
+			Name:       span.Name(),
+			OffsetMs:   span.Start().Sub(start).Milliseconds(),
+			DurationMs: span.Duration().Milliseconds(),
+			Failed:     span.Failed(),
+			Error:      span.Detail(),
+		}
+		if span.Kind() == installation.TimelineEntryPhase {
+			response.Phases = append(response.Phases, spanDTO)
+		} else {
+			response.Components = append(response.Components, spanDTO)
+		}
+		if span.End().After(end) {
+			end = span.End()
+		}
+	}
+	if !end.IsZero() {
+		response.DurationMs = end.Sub(start).Milliseconds()
+	}
+
+	return response, nil
+}
+
+// findSession finds a session by its ID, or else by a unique prefix of it
+func (u *GetSessionTimelineUseCase) findSession(ctx context.Context, sessionID string) (*installation.InstallationSession, error) {
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
+	if err == nil {
+		return session, nil
+	}
+	if !errors.Is(err, installation.ErrSessionNotFound) || sessionID == "" {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	sessions, listErr := u.sessionRepo.List(ctx)
+	if listErr != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", listErr)
+	}
+
+	var match *installation.InstallationSession
+	for _, candidate := range sessions {
+		if !strings.HasPrefix(candidate.ID(), sessionID) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("%w: %s", ErrAmbiguousSessionID, sessionID)
+		}
+		match = candidate
+	}
+	if match == nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+	return match, nil
+}
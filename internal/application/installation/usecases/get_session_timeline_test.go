@@ -0,0 +1,92 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSessionTimelineUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	newSession := func(t *testing.T) *installation.InstallationSession {
+		t.Helper()
+		components, err := createTestComponents()
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		return session
+	}
+
+	t.Run("reports phases and components relative to the start", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		session := newSession(t)
+		started := session.StartedAt()
+		session.UpdateProgress(installation.NewInstallationProgress("Preflight", 5, "Checking", started))
+		session.UpdateProgress(installation.NewInstallationProgress("Installing", 30, "Installing hyprland", started.Add(2*time.Second)))
+		require.NoError(t, session.MarkComponent(installation.ComponentHyprland, installation.ComponentStateInstalling))
+		require.NoError(t, session.FailComponent(installation.ComponentHyprland, "apt-get exited with status 100"))
+		require.NoError(t, session.Fail("installation of hyprland failed"))
+		require.NoError(t, sessionRepo.Save(ctx, session))
+
+		response, err := usecases.NewGetSessionTimelineUseCase(sessionRepo).Execute(ctx, session.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, session.ID(), response.SessionID)
+		assert.Equal(t, "failed", response.Status)
+		assert.Equal(t, "installation of hyprland failed", response.FailureReason)
+		require.Len(t, response.Phases, 2)
+		assert.Equal(t, "Preflight", response.Phases[0].Name)
+		assert.Equal(t, int64(0), response.Phases[0].OffsetMs)
+		assert.Equal(t, int64(2000), response.Phases[0].DurationMs)
+		assert.Equal(t, int64(2000), response.Phases[1].OffsetMs)
+		assert.True(t, response.Phases[1].Failed)
+		require.Len(t, response.Components, 1)
+		assert.Equal(t, "hyprland", response.Components[0].Name)
+		assert.True(t, response.Components[0].Failed)
+		assert.Equal(t, "apt-get exited with status 100", response.Components[0].Error)
+		assert.Equal(t, int64(2000), response.DurationMs)
+	})
+
+	t.Run("finds a session by a unique prefix", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		session := newSession(t)
+		require.NoError(t, sessionRepo.Save(ctx, session))
+
+		response, err := usecases.NewGetSessionTimelineUseCase(sessionRepo).Execute(ctx, session.ID()[:8])
+
+		require.NoError(t, err)
+		assert.Equal(t, session.ID(), response.SessionID)
+		assert.Empty(t, response.Phases)
+	})
+
+	t.Run("rejects a prefix matching several sessions", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		for _, id := range []string{"3f2a9c-first", "3f2a9c-second"} {
+			session, err := installation.ReconstructInstallationSession(
+				id, newSession(t).Configuration(), installation.StatusPending, nil, nil, time.Now(), time.Time{}, "")
+			require.NoError(t, err)
+			require.NoError(t, sessionRepo.Save(ctx, session))
+		}
+
+		_, err := usecases.NewGetSessionTimelineUseCase(sessionRepo).Execute(ctx, "3f2a9c")
+
+		assert.ErrorIs(t, err, usecases.ErrAmbiguousSessionID)
+	})
+
+	t.Run("returns not found for an unknown session", func(t *testing.T) {
+		_, err := usecases.NewGetSessionTimelineUseCase(repository.NewMemorySessionRepository()).Execute(ctx, "missing")
+
+		assert.ErrorIs(t, err, installation.ErrSessionNotFound)
+	})
+}
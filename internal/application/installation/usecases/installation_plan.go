@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+var (
+	// ErrPlanUnsigned is returned when applying a plan without a signature
+	ErrPlanUnsigned = errors.New("installation plan is not signed")
+
+	// ErrPlanUntrusted is returned when a plan was signed by a key that is
+	// neither the local key nor explicitly trusted
+	ErrPlanUntrusted = errors.New("installation plan signer is not trusted")
+
+	// ErrPlanFormat is returned for plans written by an incompatible gohan
+	ErrPlanFormat = errors.New("unsupported installation plan format")
+)
+
+// PackageResolver looks up the version and installed size a package would
+// be installed at
+type PackageResolver interface {
+	ResolvePackage(ctx context.Context, packageName string) (installation.PackageInfo, error)
+}
+
+// RepositoryLister lists the enabled apt repositories packages come from
+type RepositoryLister interface {
+	ListRepositories(ctx context.Context) ([]string, error)
+}
+
+// PlanSigner signs plans with the local key and verifies signatures
+type PlanSigner interface {
+	Sign(payload []byte) (dto.PlanSignature, error)
+	Verify(payload []byte, signature dto.PlanSignature) error
+
+	// Fingerprint identifies the local signing key
+	Fingerprint() (string, error)
+}
+
+// WithPackageResolver pins "latest" components to the version the package
+// manager would install when planning
+func (u *StartInstallationUseCase) WithPackageResolver(resolver PackageResolver) *StartInstallationUseCase {
+	u.packageResolver = resolver
+	return u
+}
+
+// WithRepositoryLister records the enabled repositories in plans
+func (u *StartInstallationUseCase) WithRepositoryLister(lister RepositoryLister) *StartInstallationUseCase {
+	u.repositoryLister = lister
+	return u
+}
+
+// WithPlanSigner signs emitted plans and verifies applied ones
+func (u *StartInstallationUseCase) WithPlanSigner(signer PlanSigner) *StartInstallationUseCase {
+	u.planSigner = signer
+	return u
+}
+
+// Plan validates a request like Execute and describes what installing it
+// would do, without creating a session. Component versions are pinned so
+// applying the plan later, or elsewhere, installs exactly the same packages.
+func (u *StartInstallationUseCase) Plan(ctx context.Context, request dto.InstallationRequest) (*dto.InstallationPlan, error) {
+	config, err := u.buildConfiguration(request)
+	if err != nil {
+		return nil, err
+	}
+
+	alternatives := config.Alternatives()
+	plan := &dto.InstallationPlan{
+		FormatVersion:       dto.PlanFormatVersion,
+		CreatedAt:           time.Now().UTC().Format(time.RFC3339),
+		Alternatives:        alternatives.Strings(),
+		RenderingMode:       config.RenderingMode().String(),
+		Accessibility:       config.Accessibility().Strings(),
+		GPU:                 request.GPU,
+		MergeExistingConfig: config.MergeExistingConfig(),
+	}
+	plan.Hostname, _ = os.Hostname()
+
+	names := make([]installation.ComponentName, 0, config.ComponentCount())
+	for _, comp := range config.Components() {
+		names = append(names, comp.Component())
+
+		planned := dto.PlannedComponent{
+			Name:        string(comp.Component()),
+			Version:     comp.Version(),
+			PackageName: alternatives.PackageForComponent(comp.Component(), componentToPackageName(comp.Component())),
+		}
+		if comp.PackageInfo() != nil {
+			planned.SizeBytes = comp.PackageInfo().SizeBytes()
+		}
+
+		if u.packageResolver != nil && (planned.Version == "latest" || planned.SizeBytes == 0) {
+			info, err := u.packageResolver.ResolvePackage(ctx, planned.PackageName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve %s: %w", planned.PackageName, err)
+			}
+			if planned.Version == "latest" {
+				planned.Version = info.Version()
+			}
+			if planned.SizeBytes == 0 {
+				planned.SizeBytes = info.SizeBytes()
+			}
+		}
+
+		plan.Components = append(plan.Components, planned)
+		plan.EstimatedSizeBytes += planned.SizeBytes
+	}
+
+	if u.repositoryLister != nil {
+		repositories, err := u.repositoryLister.ListRepositories(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		plan.Repositories = repositories
+	}
+
+	// Relative to ~/.config, so the plan does not depend on the home directory
+	configFiles, _ := configFilesFor(names, alternatives, config.RenderingMode(), "")
+	for _, file := range configFiles {
+		plan.ConfigFiles = append(plan.ConfigFiles, file.TargetPath)
+	}
+
+	if u.planSigner != nil {
+		payload, err := plan.SigningPayload()
+		if err != nil {
+			return nil, err
+		}
+		signature, err := u.planSigner.Sign(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign plan: %w", err)
+		}
+		plan.Signature = &signature
+	}
+
+	return plan, nil
+}
+
+// StartFromPlan verifies a plan and starts an installation session for it.
+// The plan must be signed by the local key or one of the trusted signers.
+func (u *StartInstallationUseCase) StartFromPlan(ctx context.Context, request dto.StartFromPlanRequest) (*dto.InstallationResponse, error) {
+	if err := u.VerifyPlan(request.Plan, request.TrustedSigners); err != nil {
+		return nil, err
+	}
+
+	installRequest := request.Plan.Request()
+	installRequest.AvailableSpace = request.AvailableSpace
+	installRequest.GPUs = request.GPUs
+	installRequest.RenderGPU = request.RenderGPU
+	installRequest.Scope = request.Scope
+	installRequest.Invocation = request.Invocation
+
+	return u.Execute(ctx, installRequest)
+}
+
+// VerifyPlan checks a plan's format and signature, and that its signer is
+// the local key or one of trustedSigners
+func (u *StartInstallationUseCase) VerifyPlan(plan dto.InstallationPlan, trustedSigners []string) error {
+	if plan.FormatVersion != dto.PlanFormatVersion {
+		return fmt.Errorf("%w: version %d (expected %d)", ErrPlanFormat, plan.FormatVersion, dto.PlanFormatVersion)
+	}
+	if plan.Signature == nil {
+		return ErrPlanUnsigned
+	}
+	if u.planSigner == nil {
+		return fmt.Errorf("cannot verify plan: no signer configured")
+	}
+
+	payload, err := plan.SigningPayload()
+	if err != nil {
+		return err
+	}
+	if err := u.planSigner.Verify(payload, *plan.Signature); err != nil {
+		return err
+	}
+
+	trusted := trustedSigners
+	if local, err := u.planSigner.Fingerprint(); err == nil {
+		trusted = append([]string{local}, trusted...)
+	}
+	for _, signer := range trusted {
+		if strings.EqualFold(signer, plan.Signature.Signer) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrPlanUntrusted, plan.Signature.Signer)
+}
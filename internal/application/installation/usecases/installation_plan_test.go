@@ -0,0 +1,129 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/plansigner"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPackageResolver resolves every package to a fixed version
+type stubPackageResolver struct {
+	versions map[string]string
+}
+
+func (r *stubPackageResolver) ResolvePackage(ctx context.Context, packageName string) (installation.PackageInfo, error) {
+	return installation.NewPackageInfo(packageName, r.versions[packageName], 4*uint64(installation.MB), nil)
+}
+
+// stubRepositoryLister returns fixed sources
+type stubRepositoryLister struct{}
+
+func (stubRepositoryLister) ListRepositories(ctx context.Context) ([]string, error) {
+	return []string{"deb http://deb.debian.org/debian sid main"}, nil
+}
+
+func newPlanningUseCase(t *testing.T, keyName string) *usecases.StartInstallationUseCase {
+	return usecases.NewStartInstallationUseCase(repository.NewMemorySessionRepository()).
+		WithPackageResolver(&stubPackageResolver{versions: map[string]string{
+			"hyprland": "0.41.2-1",
+			"waybar":   "0.10.3-1",
+		}}).
+		WithRepositoryLister(stubRepositoryLister{}).
+		WithPlanSigner(plansigner.NewEd25519SignerWithKey(filepath.Join(t.TempDir(), keyName)))
+}
+
+func planRequest() dto.InstallationRequest {
+	return dto.InstallationRequest{
+		Components: []dto.ComponentRequest{
+			{Name: "hyprland", Version: "latest"},
+			{Name: "waybar", Version: "latest"},
+		},
+		AvailableSpace: 100 * uint64(installation.GB),
+		RequiredSpace:  10 * uint64(installation.GB),
+	}
+}
+
+func TestStartInstallationUseCase_Plan(t *testing.T) {
+	useCase := newPlanningUseCase(t, "plan.key")
+
+	plan, err := useCase.Plan(context.Background(), planRequest())
+	require.NoError(t, err)
+
+	assert.Equal(t, dto.PlanFormatVersion, plan.FormatVersion)
+	assert.NotEmpty(t, plan.CreatedAt)
+	require.Len(t, plan.Components, 2)
+	assert.Equal(t, "0.41.2-1", plan.Components[0].Version, "latest is pinned")
+	assert.Equal(t, "0.10.3-1", plan.Components[1].Version)
+	assert.Equal(t, 8*uint64(installation.MB), plan.EstimatedSizeBytes)
+	assert.Equal(t, []string{"deb http://deb.debian.org/debian sid main"}, plan.Repositories)
+	require.NotNil(t, plan.Signature)
+	assert.NoError(t, useCase.VerifyPlan(*plan, nil))
+}
+
+func TestStartInstallationUseCase_StartFromPlan(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("starts a session with the pinned versions", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := newPlanningUseCase(t, "plan.key")
+		plan, err := useCase.Plan(ctx, planRequest())
+		require.NoError(t, err)
+
+		// Round-trip through the file format
+		data, err := json.Marshal(plan)
+		require.NoError(t, err)
+		var loaded dto.InstallationPlan
+		require.NoError(t, json.Unmarshal(data, &loaded))
+
+		applying := usecases.NewStartInstallationUseCase(sessionRepo).
+			WithPlanSigner(plansigner.NewEd25519SignerWithKey(filepath.Join(t.TempDir(), "other.key")))
+		response, err := applying.StartFromPlan(ctx, dto.StartFromPlanRequest{
+			Plan:           loaded,
+			TrustedSigners: []string{plan.Signature.Signer},
+			AvailableSpace: 100 * uint64(installation.GB),
+		})
+		require.NoError(t, err)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		components := session.Configuration().Components()
+		require.Len(t, components, 2)
+		assert.Equal(t, "0.41.2-1", components[0].Version())
+	})
+
+	t.Run("rejects a modified plan", func(t *testing.T) {
+		useCase := newPlanningUseCase(t, "plan.key")
+		plan, err := useCase.Plan(ctx, planRequest())
+		require.NoError(t, err)
+
+		plan.Components[0].Version = "0.40.0-1"
+		_, err = useCase.StartFromPlan(ctx, dto.StartFromPlanRequest{Plan: *plan, AvailableSpace: 100 * uint64(installation.GB)})
+		assert.ErrorIs(t, err, plansigner.ErrInvalidSignature)
+	})
+
+	t.Run("rejects a plan from an untrusted signer", func(t *testing.T) {
+		plan, err := newPlanningUseCase(t, "a.key").Plan(ctx, planRequest())
+		require.NoError(t, err)
+
+		_, err = newPlanningUseCase(t, "b.key").StartFromPlan(ctx, dto.StartFromPlanRequest{Plan: *plan, AvailableSpace: 100 * uint64(installation.GB)})
+		assert.ErrorIs(t, err, usecases.ErrPlanUntrusted)
+	})
+
+	t.Run("rejects an unsigned plan", func(t *testing.T) {
+		plan, err := newPlanningUseCase(t, "plan.key").Plan(ctx, planRequest())
+		require.NoError(t, err)
+		plan.Signature = nil
+
+		_, err = newPlanningUseCase(t, "plan.key").StartFromPlan(ctx, dto.StartFromPlanRequest{Plan: *plan})
+		assert.ErrorIs(t, err, usecases.ErrPlanUnsigned)
+	})
+}
@@ -43,55 +43,19 @@ func (u *ListInstallationsUseCase) Execute(ctx context.Context) (*dto.ListInstal
 
 // buildSessionSummary creates a session summary from a domain session
 func buildSessionSummary(session *installation.InstallationSession) dto.InstallationSessionSummary {
-	config := session.Configuration()
-	installedComponents := session.InstalledComponents()
-	componentsTotal := len(config.Components())
-
-	// Calculate progress percentage
-	percentComplete := 0
-	if componentsTotal > 0 {
-		percentComplete = (len(installedComponents) * 100) / componentsTotal
-	}
-
-	// Determine current phase based on status
-	currentPhase := "pending"
-	switch session.Status() {
-	case installation.StatusPending:
-		currentPhase = "pending"
-	case installation.StatusPreparation:
-		currentPhase = "preparation"
-	case installation.StatusInstalling:
-		currentPhase = "installing"
-	case installation.StatusConfiguring:
-		currentPhase = "configuring"
-	case installation.StatusVerifying:
-		currentPhase = "verifying"
-	case installation.StatusCompleted:
-		currentPhase = "completed"
-		percentComplete = 100
-	case installation.StatusFailed:
-		currentPhase = "failed"
-	}
-
-	// Format timestamps
-	startedAt := ""
-	if !session.StartedAt().IsZero() {
-		startedAt = session.StartedAt().Format("2006-01-02T15:04:05Z07:00")
-	}
-
-	completedAt := ""
-	if !session.CompletedAt().IsZero() {
-		completedAt = session.CompletedAt().Format("2006-01-02T15:04:05Z07:00")
-	}
+	progress := session.Progress()
 
 	return dto.InstallationSessionSummary{
 		SessionID:           session.ID(),
 		Status:              string(session.Status()),
-		CurrentPhase:        currentPhase,
-		PercentComplete:     percentComplete,
-		ComponentsInstalled: len(installedComponents),
-		ComponentsTotal:     componentsTotal,
-		StartedAt:           startedAt,
-		CompletedAt:         completedAt,
+		CurrentPhase:        sessionPhase(session),
+		PercentComplete:     sessionPercent(session),
+		Message:             sessionMessage(session),
+		ComponentsInstalled: len(session.InstalledComponents()),
+		ComponentsTotal:     len(session.Configuration().Components()),
+		WarningsCount:       len(session.Warnings()),
+		StartedAt:           formatTimestamp(session.StartedAt()),
+		UpdatedAt:           formatTimestamp(progress.UpdatedAt()),
+		CompletedAt:         formatTimestamp(session.CompletedAt()),
 	}
 }
@@ -3,6 +3,7 @@ package usecases_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
@@ -132,5 +133,59 @@ func TestListInstallationsUseCase_Execute(t *testing.T) {
 		assert.Equal(t, session.ID(), sessionInfo.SessionID)
 		assert.Equal(t, "pending", sessionInfo.Status)
 		assert.Equal(t, 1, sessionInfo.ComponentsTotal)
+		assert.NotEmpty(t, sessionInfo.StartedAt)
+		assert.Empty(t, sessionInfo.UpdatedAt)
+	})
+
+	t.Run("includes recorded progress in summary", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewListInstallationsUseCase(sessionRepo)
+		ctx := context.Background()
+
+		components, err := createTestComponents()
+		require.NoError(t, err)
+
+		diskSpace, err := installation.NewDiskSpace(
+			100*uint64(installation.GB),
+			10*uint64(installation.GB),
+		)
+		require.NoError(t, err)
+
+		config, err := installation.NewInstallationConfiguration(
+			components,
+			nil,
+			diskSpace,
+			false,
+		)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		session.UpdateProgress(installation.NewInstallationProgress(
+			"Installing Components",
+			35,
+			"Installing hyprland (1/1)",
+			time.Now(),
+		))
+
+		warning, err := installation.NewInstallationWarning(installation.WarningSourceConflict, "Removed foot")
+		require.NoError(t, err)
+		session.AddWarning(warning)
+
+		err = sessionRepo.Save(ctx, session)
+		require.NoError(t, err)
+
+		response, err := useCase.Execute(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, response.Sessions, 1)
+
+		sessionInfo := response.Sessions[0]
+		assert.Equal(t, "Installing Components", sessionInfo.CurrentPhase)
+		assert.Equal(t, 35, sessionInfo.PercentComplete)
+		assert.Equal(t, "Installing hyprland (1/1)", sessionInfo.Message)
+		assert.Equal(t, 1, sessionInfo.WarningsCount)
+		assert.NotEmpty(t, sessionInfo.UpdatedAt)
 	})
 }
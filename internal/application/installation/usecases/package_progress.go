@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
+)
+
+// PackageProgressReporter is optionally implemented by package managers
+// that report apt's progress while they download and install a package
+type PackageProgressReporter interface {
+	DownloadPackageWithProgress(ctx context.Context, packageName, version string, report func(packagemanager.PackageProgress)) error
+	InstallPackageWithProgress(ctx context.Context, packageName, version string, report func(packagemanager.PackageProgress)) error
+}
+
+// downloadPackage fetches a package, reporting apt's progress when the
+// package manager can
+func (u *ExecuteInstallationUseCase) downloadPackage(
+	ctx context.Context,
+	downloader PackageDownloader,
+	packageName, version string,
+	report func(packagemanager.PackageProgress),
+) error {
+	if reporter, ok := u.packageManager.(PackageProgressReporter); ok {
+		return reporter.DownloadPackageWithProgress(ctx, packageName, version, report)
+	}
+	return downloader.DownloadPackage(ctx, packageName, version)
+}
+
+// installPackage installs a package, reporting apt's progress when the
+// package manager can
+func (u *ExecuteInstallationUseCase) installPackage(
+	ctx context.Context,
+	packageName, version string,
+	report func(packagemanager.PackageProgress),
+) error {
+	if reporter, ok := u.packageManager.(PackageProgressReporter); ok {
+		return reporter.InstallPackageWithProgress(ctx, packageName, version, report)
+	}
+	return u.packageManager.InstallPackage(ctx, packageName, version)
+}
+
+// packageProgress passes apt's reports on a component to progressCallback,
+// placing downloads in the first half of the component's share of the
+// installation, from start to end percent, and dpkg's work in the second.
+// apt reports several times a second, so only a new step or whole percent
+// is passed on.
+func packageProgress(
+	label string,
+	start, end int,
+	componentsInstalled, componentsTotal int,
+	progressCallback ProgressCallback,
+) func(packagemanager.PackageProgress) {
+	lastPercent, lastMessage := -1, ""
+	return func(progress packagemanager.PackageProgress) {
+		from, to := start+(end-start)/2, end
+		if progress.Status == packagemanager.StatusDownloading {
+			from, to = start, start+(end-start)/2
+		}
+		percent := from + int(float64(to-from)*min(max(progress.PercentComplete, 0), 100)/100)
+
+		message := label
+		if detail := describePackageProgress(progress); detail != "" {
+			message = fmt.Sprintf("%s — %s", label, detail)
+		}
+		if percent == lastPercent && message == lastMessage {
+			return
+		}
+		lastPercent, lastMessage = percent, message
+
+		progressCallback("Installing Components", percent, message, componentsInstalled, componentsTotal)
+	}
+}
+
+// describePackageProgress phrases one of apt's reports for progress
+// messages, such as "downloading hyprland 42% at 1.2 MB/s, about 1 min left"
+// or "unpacking libhyprutils0"
+func describePackageProgress(progress packagemanager.PackageProgress) string {
+	switch progress.Status {
+	case packagemanager.StatusDownloading:
+		description := fmt.Sprintf("downloading %.0f%%", progress.PercentComplete)
+		if progress.PackageName != "" {
+			description = fmt.Sprintf("downloading %s %.0f%%", progress.PackageName, progress.PercentComplete)
+		}
+		if progress.BytesPerSecond > 0 {
+			description += " at " + formatRate(progress.BytesPerSecond)
+		}
+		if eta := installation.DescribeEstimate(progress.Remaining); eta != "" {
+			description += ", " + eta + " left"
+		}
+		return description
+	case packagemanager.StatusUnpacking, packagemanager.StatusConfiguring:
+		if progress.PackageName == "" {
+			return progress.Status
+		}
+		return progress.Status + " " + progress.PackageName
+	case packagemanager.StatusCompleted:
+		if progress.PackageName == "" {
+			return ""
+		}
+		return "installed " + progress.PackageName
+	default:
+		return progress.Message
+	}
+}
+
+// formatRate formats a download speed the way apt does, in powers of 1000
+func formatRate(bytesPerSecond float64) string {
+	switch {
+	case bytesPerSecond >= 1e9:
+		return fmt.Sprintf("%.1f GB/s", bytesPerSecond/1e9)
+	case bytesPerSecond >= 1e6:
+		return fmt.Sprintf("%.1f MB/s", bytesPerSecond/1e6)
+	case bytesPerSecond >= 1e3:
+		return fmt.Sprintf("%.0f kB/s", bytesPerSecond/1e3)
+	default:
+		return fmt.Sprintf("%.0f B/s", bytesPerSecond)
+	}
+}
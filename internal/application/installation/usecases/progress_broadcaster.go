@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// progressSinkBuffer is how many updates a slow sink can fall behind
+// before updates are dropped
+const progressSinkBuffer = 64
+
+// ProgressUpdate is one progress report of an executing installation
+type ProgressUpdate struct {
+	SessionID           string
+	Phase               string
+	Percent             int
+	Message             string
+	ComponentsInstalled int
+	ComponentsTotal     int
+	Status              installation.InstallationStatus
+	OccurredAt          time.Time
+	Final               bool // Set on the last update of an execution, with the state it ended in
+}
+
+// ProgressSink receives the progress updates of installations
+type ProgressSink func(update ProgressUpdate)
+
+// ProgressBroadcaster fans the progress updates of executing installations
+// out to any number of sinks, such as a terminal UI, a logger or an event
+// store. Each sink runs on its own goroutine with its own buffer, so a slow
+// sink neither holds up the installation nor the other sinks; updates it
+// falls too far behind on are dropped, except an execution's final update.
+type ProgressBroadcaster struct {
+	mu    sync.Mutex
+	sinks map[*progressSinkRunner]struct{}
+}
+
+// progressSinkRunner delivers the buffered updates of one sink
+type progressSinkRunner struct {
+	updates chan ProgressUpdate
+	done    chan struct{}
+	dropped atomic.Int64
+}
+
+// NewProgressBroadcaster creates a broadcaster without sinks
+func NewProgressBroadcaster() *ProgressBroadcaster {
+	return &ProgressBroadcaster{sinks: make(map[*progressSinkRunner]struct{})}
+}
+
+// Subscribe registers a sink for the updates of every installation; sinks
+// interested in one session compare the update's SessionID. The returned
+// function unregisters the sink once the updates already buffered for it
+// are delivered, and returns how many updates the sink missed because it
+// fell behind. It may be called more than once.
+func (b *ProgressBroadcaster) Subscribe(sink ProgressSink) (unsubscribe func() int) {
+	return b.SubscribeBuffered(sink, progressSinkBuffer)
+}
+
+// SubscribeBuffered registers a sink like Subscribe, buffering up to buffer
+// updates for it, for sinks that must not miss any
+func (b *ProgressBroadcaster) SubscribeBuffered(sink ProgressSink, buffer int) (unsubscribe func() int) {
+	runner := &progressSinkRunner{
+		updates: make(chan ProgressUpdate, buffer),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(runner.done)
+		for update := range runner.updates {
+			sink(update)
+		}
+	}()
+
+	b.mu.Lock()
+	b.sinks[runner] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() int {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.sinks, runner)
+			close(runner.updates)
+			b.mu.Unlock()
+		})
+		<-runner.done
+		return int(runner.dropped.Load())
+	}
+}
+
+// Publish hands an update to every sink without waiting for them. A final
+// update makes room for itself in a full buffer, so sinks always learn how
+// an execution ended. Nil broadcasters publish nothing.
+func (b *ProgressBroadcaster) Publish(update ProgressUpdate) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for runner := range b.sinks {
+		select {
+		case runner.updates <- update:
+			continue
+		default:
+		}
+
+		runner.dropped.Add(1)
+		if update.Final {
+			// The oldest buffered update is dropped instead
+			select {
+			case <-runner.updates:
+			default:
+			}
+			select {
+			case runner.updates <- update:
+			default:
+			}
+		}
+	}
+}
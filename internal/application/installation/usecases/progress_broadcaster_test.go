@@ -0,0 +1,80 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressBroadcaster(t *testing.T) {
+	t.Run("delivers every update to every sink in order", func(t *testing.T) {
+		broadcaster := usecases.NewProgressBroadcaster()
+		var tui, logged []int
+		unsubscribeTUI := broadcaster.Subscribe(func(update usecases.ProgressUpdate) { tui = append(tui, update.Percent) })
+		unsubscribeLog := broadcaster.Subscribe(func(update usecases.ProgressUpdate) { logged = append(logged, update.Percent) })
+
+		for percent := range 10 {
+			broadcaster.Publish(usecases.ProgressUpdate{SessionID: "s1", Percent: percent})
+		}
+
+		assert.Zero(t, unsubscribeTUI())
+		assert.Zero(t, unsubscribeLog())
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, tui)
+		assert.Equal(t, tui, logged)
+	})
+
+	t.Run("a stuck sink holds up neither the publisher nor the other sinks", func(t *testing.T) {
+		broadcaster := usecases.NewProgressBroadcaster()
+		release := make(chan struct{})
+		unsubscribeStuck := broadcaster.Subscribe(func(usecases.ProgressUpdate) { <-release })
+		received := make(chan struct{})
+		unsubscribe := broadcaster.Subscribe(func(usecases.ProgressUpdate) { received <- struct{}{} })
+
+		for range 200 {
+			broadcaster.Publish(usecases.ProgressUpdate{SessionID: "s1"})
+			<-received
+		}
+		close(release)
+
+		assert.GreaterOrEqual(t, unsubscribeStuck(), 200-64-1, "updates beyond the stuck sink's buffer are dropped")
+		assert.Zero(t, unsubscribe())
+	})
+
+	t.Run("a final update is not dropped by a sink that fell behind", func(t *testing.T) {
+		broadcaster := usecases.NewProgressBroadcaster()
+		release := make(chan struct{})
+		var last usecases.ProgressUpdate
+		unsubscribe := broadcaster.Subscribe(func(update usecases.ProgressUpdate) {
+			<-release
+			last = update
+		})
+
+		for range 100 {
+			broadcaster.Publish(usecases.ProgressUpdate{SessionID: "s1"})
+		}
+		broadcaster.Publish(usecases.ProgressUpdate{SessionID: "s1", Final: true})
+		close(release)
+
+		assert.NotZero(t, unsubscribe())
+		assert.True(t, last.Final)
+	})
+
+	t.Run("unsubscribed sinks get no more updates", func(t *testing.T) {
+		broadcaster := usecases.NewProgressBroadcaster()
+		var received int
+		unsubscribe := broadcaster.Subscribe(func(usecases.ProgressUpdate) { received++ })
+
+		broadcaster.Publish(usecases.ProgressUpdate{})
+		unsubscribe()
+		unsubscribe()
+		broadcaster.Publish(usecases.ProgressUpdate{})
+
+		assert.Equal(t, 1, received)
+	})
+
+	t.Run("nil broadcasters publish nothing", func(t *testing.T) {
+		var broadcaster *usecases.ProgressBroadcaster
+		assert.NotPanics(t, func() { broadcaster.Publish(usecases.ProgressUpdate{}) })
+	})
+}
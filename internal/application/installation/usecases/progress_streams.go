@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"sync"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+)
+
+// progressEventType is the event type of the updates streamed while an
+// installation executes
+const progressEventType = "installation.progress.updated"
+
+// progressStreamBuffer is how many updates a slow subscriber can fall
+// behind before updates are dropped; later updates supersede them
+const progressStreamBuffer = 32
+
+// ProgressStreams fans the progress events of running executions out to
+// subscribers, such as WebSocket and Server-Sent Events clients, so they
+// need not poll the status. Each session's events are numbered, and the
+// latest of a running execution are kept for clients resuming a stream.
+// A session is forgotten once its execution has ended. The streams are a
+// sink of the ProgressBroadcaster.
+type ProgressStreams struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan dto.ProgressEventDTO]struct{}
+	sequences   map[string]uint64
+	recent      map[string][]dto.ProgressEventDTO
+}
+
+// NewProgressStreams creates streams without subscribers
+func NewProgressStreams() *ProgressStreams {
+	return &ProgressStreams{
+		subscribers: make(map[string]map[chan dto.ProgressEventDTO]struct{}),
+		sequences:   make(map[string]uint64),
+		recent:      make(map[string][]dto.ProgressEventDTO),
+	}
+}
+
+// Subscribe returns the progress events of a session's next or current
+// execution. The channel is closed after the execution's final event, or
+// by the returned function, which must be called once done.
+func (s *ProgressStreams) Subscribe(sessionID string) (<-chan dto.ProgressEventDTO, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subscribe(sessionID)
+}
+
+// Resume subscribes like Subscribe and also returns the kept events of the
+// session that came after lastSequence, oldest first. resumed is false when
+// some of those events are no longer kept, so the client has to catch up
+// from the session's status instead.
+func (s *ProgressStreams) Resume(sessionID string, lastSequence uint64) (missed []dto.ProgressEventDTO, resumed bool, events <-chan dto.ProgressEventDTO, unsubscribe func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	recent := s.recent[sessionID]
+	switch {
+	case lastSequence == s.sequences[sessionID]:
+		resumed = true
+	case lastSequence < s.sequences[sessionID] && len(recent) > 0 && recent[0].Sequence <= lastSequence+1:
+		resumed = true
+		for _, event := range recent {
+			if event.Sequence > lastSequence {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	events, unsubscribe = s.subscribe(sessionID)
+	return missed, resumed, events, unsubscribe
+}
+
+// subscribe adds a subscriber to the session; the caller holds the lock
+func (s *ProgressStreams) subscribe(sessionID string) (<-chan dto.ProgressEventDTO, func()) {
+	ch := make(chan dto.ProgressEventDTO, progressStreamBuffer)
+
+	if s.subscribers[sessionID] == nil {
+		s.subscribers[sessionID] = make(map[chan dto.ProgressEventDTO]struct{})
+	}
+	s.subscribers[sessionID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subscribers[sessionID][ch]; ok {
+			delete(s.subscribers[sessionID], ch)
+			if len(s.subscribers[sessionID]) == 0 {
+				delete(s.subscribers, sessionID)
+			}
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// Publish is the streams' ProgressSink: it sends an update to the session's
+// subscribers without waiting for them, and ends the session's streams with
+// the final update of an execution.
+func (s *ProgressStreams) Publish(update ProgressUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if update.Final {
+		s.finish(update)
+		return
+	}
+
+	message := s.number(progressEventDTO(update))
+	recent := append(s.recent[update.SessionID], message)
+	if len(recent) > progressStreamBuffer {
+		recent = recent[len(recent)-progressStreamBuffer:]
+	}
+	s.recent[update.SessionID] = recent
+	for ch := range s.subscribers[update.SessionID] {
+		select {
+		case ch <- message:
+		default:
+		}
+	}
+}
+
+// finish sends the final update of an execution, ends the session's
+// streams and forgets the session. The final update makes room for itself
+// in a full buffer, so subscribers always learn how the execution ended.
+// The caller holds the lock.
+func (s *ProgressStreams) finish(update ProgressUpdate) {
+	message := s.number(progressEventDTO(update))
+	delete(s.recent, update.SessionID)
+	delete(s.sequences, update.SessionID)
+	for ch := range s.subscribers[update.SessionID] {
+		select {
+		case ch <- message:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- message:
+			default:
+			}
+		}
+		close(ch)
+	}
+	delete(s.subscribers, update.SessionID)
+}
+
+// number gives an event the session's next sequence number; the caller
+// holds the lock
+func (s *ProgressStreams) number(event dto.ProgressEventDTO) dto.ProgressEventDTO {
+	s.sequences[event.SessionID]++
+	event.Sequence = s.sequences[event.SessionID]
+	return event
+}
+
+func progressEventDTO(update ProgressUpdate) dto.ProgressEventDTO {
+	return dto.ProgressEventDTO{
+		SessionID:       update.SessionID,
+		EventType:       progressEventType,
+		Status:          update.Status.String(),
+		PercentComplete: update.Percent,
+		Message:         update.Message,
+		OccurredAt:      update.OccurredAt.Format(timestampFormat),
+		Final:           update.Final,
+	}
+}
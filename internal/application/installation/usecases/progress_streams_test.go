@@ -0,0 +1,177 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressStreams(t *testing.T) {
+	run := func(t *testing.T, streams *usecases.ProgressStreams, session *installation.InstallationSession, installErr error) {
+		t.Helper()
+		broadcaster := usecases.NewProgressBroadcaster()
+		defer broadcaster.Subscribe(streams.Publish)()
+
+		mockRepo := new(MockInstallationSessionRepository)
+		mockRepo.On("FindByID", mock.Anything, session.ID()).Return(session, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockConflictResolver := new(MockConflictResolver)
+		mockConflictResolver.On("DetectConflicts", mock.Anything, mock.Anything).
+			Return([]installation.PackageConflict{}, nil)
+		mockProgressEstimator := new(MockProgressEstimator)
+		mockProgressEstimator.On("CalculatePhaseProgress", mock.Anything, mock.Anything, mock.Anything).Return(50)
+		mockProgressEstimator.On("EstimateRemainingTime", mock.Anything, mock.Anything, mock.Anything).
+			Return(5 * time.Minute)
+		mockPkgManager := new(MockPackageManager)
+		mockPkgManager.On("InstallPackage", mock.Anything, "hyprland", "0.35.0").Return(installErr)
+		mockPreflight := NewMockPreflightValidator()
+		mockPreflight.On("Run", mock.Anything).Return(nil)
+
+		useCase := usecases.NewExecuteInstallationUseCase(
+			mockRepo,
+			mockConflictResolver,
+			mockProgressEstimator,
+			new(MockConfigurationMerger),
+			mockPkgManager,
+			nil,
+			mockPreflight.Factory(),
+			nil,
+		).WithProgressBroadcaster(broadcaster)
+
+		_, err := useCase.Execute(context.Background(), session.ID())
+		require.NoError(t, err)
+	}
+
+	newSession := func(t *testing.T) *installation.InstallationSession {
+		t.Helper()
+		components, err := createTestComponents()
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(components, nil, diskSpace, false)
+		require.NoError(t, err)
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		return session
+	}
+
+	collect := func(events <-chan dto.ProgressEventDTO) []dto.ProgressEventDTO {
+		var collected []dto.ProgressEventDTO
+		for event := range events {
+			collected = append(collected, event)
+		}
+		return collected
+	}
+
+	t.Run("streams each progress report and ends with the outcome", func(t *testing.T) {
+		streams := usecases.NewProgressStreams()
+		session := newSession(t)
+		events, unsubscribe := streams.Subscribe(session.ID())
+		defer unsubscribe()
+
+		run(t, streams, session, nil)
+
+		collected := collect(events)
+		require.NotEmpty(t, collected)
+		assert.Equal(t, "Initializing system validation", collected[0].Message)
+		assert.Equal(t, "installation.progress.updated", collected[0].EventType)
+		for _, event := range collected[:len(collected)-1] {
+			assert.Equal(t, session.ID(), event.SessionID)
+			assert.False(t, event.Final)
+		}
+
+		last := collected[len(collected)-1]
+		assert.True(t, last.Final)
+		assert.Equal(t, "completed", last.Status)
+		assert.Equal(t, 100, last.PercentComplete)
+	})
+
+	t.Run("reports why an execution failed", func(t *testing.T) {
+		streams := usecases.NewProgressStreams()
+		session := newSession(t)
+		events, unsubscribe := streams.Subscribe(session.ID())
+		defer unsubscribe()
+
+		run(t, streams, session, assert.AnError)
+
+		collected := collect(events)
+		require.NotEmpty(t, collected)
+		last := collected[len(collected)-1]
+		assert.True(t, last.Final)
+		assert.Equal(t, "failed", last.Status)
+		assert.Equal(t, session.FailureReason(), last.Message)
+	})
+
+	t.Run("only streams the subscribed session", func(t *testing.T) {
+		streams := usecases.NewProgressStreams()
+		other, unsubscribe := streams.Subscribe("another-session")
+
+		run(t, streams, newSession(t), nil)
+
+		unsubscribe()
+		assert.Empty(t, collect(other))
+	})
+
+	t.Run("ends the stream on unsubscribe", func(t *testing.T) {
+		events, unsubscribe := usecases.NewProgressStreams().Subscribe("session")
+		unsubscribe()
+		unsubscribe()
+
+		_, open := <-events
+		assert.False(t, open)
+	})
+
+	t.Run("numbers each session's events in order", func(t *testing.T) {
+		streams := usecases.NewProgressStreams()
+		session := newSession(t)
+		events, unsubscribe := streams.Subscribe(session.ID())
+		defer unsubscribe()
+
+		run(t, streams, session, nil)
+
+		for i, event := range collect(events) {
+			assert.Equal(t, uint64(i+1), event.Sequence)
+		}
+	})
+
+	t.Run("resumes after the last event a client received", func(t *testing.T) {
+		streams := usecases.NewProgressStreams()
+		session := newSession(t)
+		events, unsubscribe := streams.Subscribe(session.ID())
+		defer unsubscribe()
+
+		run(t, streams, session, nil)
+		collected := collect(events)
+		require.NotEmpty(t, collected)
+		last := collected[len(collected)-1].Sequence
+
+		// The final event ends the execution and the session is forgotten,
+		// so clients catch up from the status
+		_, resumed, _, unsubscribeFinal := streams.Resume(session.ID(), last)
+		defer unsubscribeFinal()
+		assert.False(t, resumed)
+
+		_, resumed, _, unsubscribeBehind := streams.Resume(session.ID(), last-1)
+		defer unsubscribeBehind()
+		assert.False(t, resumed)
+
+		// A client given the final state from the status is up to date
+		missed, resumed, _, unsubscribeCurrent := streams.Resume(session.ID(), 0)
+		defer unsubscribeCurrent()
+		assert.True(t, resumed)
+		assert.Empty(t, missed)
+	})
+
+	t.Run("does not resume unknown events", func(t *testing.T) {
+		_, resumed, _, unsubscribe := usecases.NewProgressStreams().Resume("session", 7)
+		defer unsubscribe()
+		assert.False(t, resumed)
+	})
+}
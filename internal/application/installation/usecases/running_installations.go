@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// ErrInstallationRunning is returned when a session is executed while an
+// earlier execution of it is still running
+var ErrInstallationRunning = errors.New("installation is already running")
+
+// RunningInstallations tracks the sessions being executed in this process
+// so a cancel request can reach them
+type RunningInstallations struct {
+	mu   sync.Mutex
+	runs map[string]*installationRun
+}
+
+// installationRun is one execution of a session
+type installationRun struct {
+	cancel context.CancelCauseFunc
+	stop   atomic.Bool
+	done   chan struct{}
+}
+
+// NewRunningInstallations creates an empty registry
+func NewRunningInstallations() *RunningInstallations {
+	return &RunningInstallations{
+		runs: make(map[string]*installationRun),
+	}
+}
+
+// start registers an execution of a session. The returned context is
+// cancelled when the execution is force-cancelled. A nil registry tracks
+// nothing.
+func (r *RunningInstallations) start(ctx context.Context, sessionID string) (context.Context, *installationRun, error) {
+	if r == nil {
+		return ctx, nil, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.runs[sessionID]; ok {
+		return nil, nil, ErrInstallationRunning
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	run := &installationRun{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	r.runs[sessionID] = run
+	return runCtx, run, nil
+}
+
+// finish unregisters an execution and wakes anyone waiting for it to stop
+func (r *RunningInstallations) finish(sessionID string, run *installationRun) {
+	if r == nil || run == nil {
+		return
+	}
+
+	r.mu.Lock()
+	delete(r.runs, sessionID)
+	r.mu.Unlock()
+
+	run.cancel(nil)
+	close(run.done)
+}
+
+// requestCancel asks a running execution to stop: after the package being
+// installed, or immediately when force is set. It returns a channel closed
+// once the execution has stopped, or false if the session is not running.
+func (r *RunningInstallations) requestCancel(sessionID string, force bool) (<-chan struct{}, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run, ok := r.runs[sessionID]
+	if !ok {
+		return nil, false
+	}
+
+	run.stop.Store(true)
+	if force {
+		run.cancel(installation.ErrInstallationCancelled)
+	}
+	return run.done, true
+}
+
+// IsRunning reports whether a session is being executed
+func (r *RunningInstallations) IsRunning(sessionID string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.runs[sessionID]
+	return ok
+}
+
+// IsCancelling reports whether a running session has been asked to stop
+// and has not stopped yet
+func (r *RunningInstallations) IsCancelling(sessionID string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run, ok := r.runs[sessionID]
+	return ok && run.stopRequested()
+}
+
+// stopRequested reports whether the execution should stop before starting
+// its next step
+func (run *installationRun) stopRequested() bool {
+	return run != nil && run.stop.Load()
+}
+
+// cancelledByUser reports whether ctx was cancelled by a cancel request
+// rather than a timeout or a caller going away
+func cancelledByUser(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), installation.ErrInstallationCancelled)
+}
@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// timestampFormat is used for all timestamps in installation responses
+const timestampFormat = "2006-01-02T15:04:05Z07:00"
+
+// sessionPhase returns the phase to report for a session
+// Progress recorded during execution is preferred over the coarse status
+func sessionPhase(session *installation.InstallationSession) string {
+	if progress := session.Progress(); !progress.IsZero() {
+		return progress.Phase()
+	}
+
+	switch session.Status() {
+	case installation.StatusPreparation:
+		return "preparation"
+	case installation.StatusInstalling:
+		return "installing"
+	case installation.StatusConfiguring:
+		return "configuring"
+	case installation.StatusVerifying:
+		return "verifying"
+	case installation.StatusCompleted:
+		return "completed"
+	case installation.StatusFailed:
+		return "failed"
+	case installation.StatusCancelled:
+		return "cancelled"
+	default:
+		return "pending"
+	}
+}
+
+// buildStatusResponse reports a session's current state. Remaining time
+// uses the estimator when given, otherwise it is extrapolated from progress.
+func buildStatusResponse(
+	session *installation.InstallationSession,
+	progressEstimator installation.ProgressEstimator,
+) *dto.InstallationProgressResponse {
+	percentComplete := sessionPercent(session)
+	progress := session.Progress()
+
+	// Estimate remaining time
+	estimatedRemaining := "0s"
+	if session.IsInProgress() && !session.StartedAt().IsZero() {
+		elapsed := session.Duration()
+		if progressEstimator != nil {
+			estimatedRemaining = sessionRemaining(progressEstimator, session, percentComplete, elapsed).String()
+		} else if percentComplete > 0 && percentComplete < 100 {
+			// Calculate total estimated time based on current progress
+			elapsedNs := int64(elapsed)
+			totalEstimatedNs := elapsedNs * 100 / int64(percentComplete)
+			remainingNs := totalEstimatedNs - elapsedNs
+			estimatedRemaining = time.Duration(remainingNs).String()
+		}
+	}
+
+	return &dto.InstallationProgressResponse{
+		SessionID:           session.ID(),
+		Status:              string(session.Status()),
+		CurrentPhase:        sessionPhase(session),
+		PercentComplete:     percentComplete,
+		Message:             sessionMessage(session),
+		EstimatedRemaining:  estimatedRemaining,
+		ComponentsInstalled: len(session.InstalledComponents()),
+		ComponentsTotal:     len(session.Configuration().Components()),
+		ComponentsSkipped:   len(session.SkippedComponents()),
+		WarningsCount:       len(session.Warnings()),
+		Warnings:            buildWarningDTOs(session),
+		Conflicts:           buildConflictDTOs(session),
+		Components:          buildComponentStatusDTOs(session),
+		StartedAt:           formatTimestamp(session.StartedAt()),
+		UpdatedAt:           formatTimestamp(progress.UpdatedAt()),
+		CompletedAt:         formatTimestamp(session.CompletedAt()),
+		PreflightSessionID:  session.PreflightSessionID(),
+		RebootRequired:      session.RebootRequirement().IsRequired(),
+		RebootReasons:       session.RebootRequirement().Reasons(),
+		DryRun:              session.Configuration().DryRun(),
+	}
+}
+
+// sessionPercent returns overall progress for a session (0-100)
+// Sessions that have not reported progress fall back to the share of
+// installed components
+func sessionPercent(session *installation.InstallationSession) int {
+	if session.IsCompleted() {
+		return 100
+	}
+
+	if progress := session.Progress(); !progress.IsZero() {
+		return progress.PercentComplete()
+	}
+
+	componentsTotal := len(session.Configuration().Components())
+	if componentsTotal == 0 {
+		return 0
+	}
+	return (len(session.InstalledComponents()) * 100) / componentsTotal
+}
+
+// sessionRemaining estimates the time left for a session, using the disk
+// and download throughput measured during preflight when the estimator
+// supports it
+func sessionRemaining(
+	estimator installation.ProgressEstimator,
+	session *installation.InstallationSession,
+	percentComplete int,
+	elapsed time.Duration,
+) time.Duration {
+	if aware, ok := estimator.(installation.ThroughputAwareEstimator); ok {
+		return aware.EstimateRemainingTimeWithContext(
+			session.SystemContext(),
+			session.Configuration().EstimatedDownloadBytes(),
+			session.Status(),
+			percentComplete,
+			elapsed,
+		)
+	}
+	return estimator.EstimateRemainingTime(session.Status(), percentComplete, elapsed)
+}
+
+// sessionMessage returns the failure reason for failed sessions and the
+// latest progress message otherwise
+func sessionMessage(session *installation.InstallationSession) string {
+	if reason := session.FailureReason(); reason != "" {
+		return reason
+	}
+	return session.Progress().Message()
+}
+
+// formatTimestamp formats a timestamp for responses, returning an empty
+// string for zero times
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(timestampFormat)
+}
+
+// buildWarningDTOs converts the warnings raised on a session for responses
+func buildWarningDTOs(session *installation.InstallationSession) []dto.WarningDTO {
+	warnings := session.Warnings()
+	dtos := make([]dto.WarningDTO, 0, len(warnings))
+	for _, w := range warnings {
+		dtos = append(dtos, dto.WarningDTO{
+			Source:   w.Source().String(),
+			Message:  w.Message(),
+			RaisedAt: formatTimestamp(w.RaisedAt()),
+		})
+	}
+	return dtos
+}
+
+// buildConflictDTOs converts the session's conflicts and their resolutions to DTOs
+func buildConflictDTOs(session *installation.InstallationSession) []dto.ConflictDTO {
+	resolutions := session.ConflictResolutions()
+	dtos := make([]dto.ConflictDTO, 0, len(resolutions))
+	for _, r := range resolutions {
+		dtos = append(dtos, dto.ConflictDTO{
+			PackageName:        r.Conflict().PackageName(),
+			ConflictingPackage: r.Conflict().ConflictingPackage(),
+			Reason:             r.Conflict().Reason(),
+			Strategy:           r.Action().String(),
+			Applied:            r.IsApplied(),
+		})
+	}
+	return dtos
+}
+
+// buildPhaseTimingDTOs converts the timings of a completed session's phases to DTOs
+func buildPhaseTimingDTOs(session *installation.InstallationSession) []dto.PhaseTimingDTO {
+	timings := session.PhaseTimings()
+	dtos := make([]dto.PhaseTimingDTO, 0, len(timings))
+	for _, t := range timings {
+		dtos = append(dtos, dto.PhaseTimingDTO{
+			Phase:     t.Phase().String(),
+			Estimated: t.Estimated().Round(time.Second).String(),
+			Actual:    t.Actual().Round(time.Second).String(),
+			Delta:     formatDelta(t.Delta()),
+		})
+	}
+	return dtos
+}
+
+// formatDelta renders how far a phase ran over its estimate, signed so
+// that "+30s" reads as slower than expected
+func formatDelta(delta time.Duration) string {
+	delta = delta.Round(time.Second)
+	if delta > 0 {
+		return "+" + delta.String()
+	}
+	return delta.String()
+}
+
+// buildComponentStatusDTOs converts the state of each configured component to DTOs
+func buildComponentStatusDTOs(session *installation.InstallationSession) []dto.ComponentStatusDTO {
+	statuses := session.ComponentStatuses()
+	dtos := make([]dto.ComponentStatusDTO, 0, len(statuses))
+	for _, s := range statuses {
+		status := dto.ComponentStatusDTO{
+			Name:       string(s.Component()),
+			State:      s.State().String(),
+			Error:      s.ErrorMessage(),
+			UpdatedAt:  formatTimestamp(s.UpdatedAt()),
+			SkipReason: s.SkipReason().String(),
+			SkipDetail: s.SkipDetail(),
+		}
+		if verification, ok := session.ComponentVerification(s.Component()); ok {
+			status.VerifyDuration = verification.Duration().String()
+		}
+		dtos = append(dtos, status)
+	}
+	return dtos
+}
@@ -5,12 +5,16 @@ import (
 	"fmt"
 
 	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/history"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 )
 
 // StartInstallationUseCase handles starting a new installation session
 type StartInstallationUseCase struct{
-	sessionRepo installation.InstallationSessionRepository
+	sessionRepo      installation.InstallationSessionRepository
+	packageResolver  PackageResolver  // optional, pins versions in plans
+	repositoryLister RepositoryLister // optional, records sources in plans
+	planSigner       PlanSigner       // optional, signs and verifies plans
 }
 
 // NewStartInstallationUseCase creates a new start installation use case
@@ -22,15 +26,62 @@ func NewStartInstallationUseCase(sessionRepo installation.InstallationSessionRep
 
 // Execute starts a new installation session
 func (u *StartInstallationUseCase) Execute(ctx context.Context, request dto.InstallationRequest) (*dto.InstallationResponse, error) {
+	config, err := u.buildConfiguration(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Records are written to the history of the requesting scope
+	scope, err := history.ParseScope(request.Scope)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scope %q: %w", request.Scope, err)
+	}
+
+	// Create installation session
+	session, err := installation.NewInstallationSession(config)
+	if err != nil {
+		return nil, err
+	}
+	session.SetScope(scope.String())
+
+	if request.Invocation != nil {
+		invocation, err := toInvocation(*request.Invocation)
+		if err != nil {
+			return nil, err
+		}
+		session.SetInvocation(invocation)
+	}
+
+	// Save session to repository
+	if err := u.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	// Build response
+	response := &dto.InstallationResponse{
+		SessionID:      session.ID(),
+		Status:         session.Status().String(),
+		Message:        "Installation session created successfully",
+		StartedAt:      session.StartedAt().Format("2006-01-02T15:04:05Z07:00"),
+		ComponentCount: config.ComponentCount(),
+		DryRun:         config.DryRun(),
+	}
+
+	return response, nil
+}
+
+// buildConfiguration validates a request and converts it to an installation
+// configuration
+func (u *StartInstallationUseCase) buildConfiguration(request dto.InstallationRequest) (installation.InstallationConfiguration, error) {
 	// Validate request
 	if len(request.Components) == 0 {
-		return nil, fmt.Errorf("at least one component required: %w", installation.ErrInvalidConfiguration)
+		return installation.InstallationConfiguration{}, fmt.Errorf("at least one component required: %w", installation.ErrInvalidConfiguration)
 	}
 
 	// Convert DTOs to domain objects
 	components, err := u.convertComponents(request.Components)
 	if err != nil {
-		return nil, err
+		return installation.InstallationConfiguration{}, err
 	}
 
 	// Convert GPU support if provided
@@ -38,7 +89,7 @@ func (u *StartInstallationUseCase) Execute(ctx context.Context, request dto.Inst
 	if request.GPU != nil {
 		gpu, err := u.convertGPUSupport(request.GPU)
 		if err != nil {
-			return nil, err
+			return installation.InstallationConfiguration{}, err
 		}
 		gpuSupport = &gpu
 	}
@@ -46,7 +97,7 @@ func (u *StartInstallationUseCase) Execute(ctx context.Context, request dto.Inst
 	// Create disk space
 	diskSpace, err := installation.NewDiskSpace(request.AvailableSpace, request.RequiredSpace)
 	if err != nil {
-		return nil, err
+		return installation.InstallationConfiguration{}, err
 	}
 
 	// Create installation configuration
@@ -57,30 +108,40 @@ func (u *StartInstallationUseCase) Execute(ctx context.Context, request dto.Inst
 		request.MergeExistingConfig,
 	)
 	if err != nil {
-		return nil, err
+		return installation.InstallationConfiguration{}, err
 	}
 
-	// Create installation session
-	session, err := installation.NewInstallationSession(config)
+	// Apply alternative choices, rejecting conflicting providers
+	alternatives, err := installation.ParseAlternativeSelection(request.Alternatives)
 	if err != nil {
-		return nil, err
+		return installation.InstallationConfiguration{}, err
+	}
+	config, err = config.WithAlternatives(alternatives)
+	if err != nil {
+		return installation.InstallationConfiguration{}, err
 	}
 
-	// Save session to repository
-	if err := u.sessionRepo.Save(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to save session: %w", err)
+	// Auto mode is resolved against preflight results at execution time
+	renderingMode, err := installation.ParseRenderingMode(request.RenderingMode)
+	if err != nil {
+		return installation.InstallationConfiguration{}, err
 	}
+	config = config.WithRenderingMode(renderingMode)
 
-	// Build response
-	response := &dto.InstallationResponse{
-		SessionID:      session.ID(),
-		Status:         session.Status().String(),
-		Message:        "Installation session created successfully",
-		StartedAt:      session.StartedAt().Format("2006-01-02T15:04:05Z07:00"),
-		ComponentCount: config.ComponentCount(),
+	accessibility, err := installation.ParseAccessibilitySettings(request.Accessibility)
+	if err != nil {
+		return installation.InstallationConfiguration{}, err
 	}
+	config = config.WithAccessibility(accessibility)
 
-	return response, nil
+	// Keep every GPU and the render choice for the Hyprland environment
+	gpus, err := u.convertGPUSelection(request.GPUs, request.RenderGPU)
+	if err != nil {
+		return installation.InstallationConfiguration{}, err
+	}
+	config = config.WithGPUs(gpus)
+
+	return config.WithDryRun(request.DryRun), nil
 }
 
 // convertComponents converts DTO components to domain component selections
@@ -132,6 +193,20 @@ func (u *StartInstallationUseCase) convertGPUSupport(dtoGPU *dto.GPURequest) (in
 	return installation.NewGPUSupport(dtoGPU.Vendor, dtoGPU.RequiresDriver, driverComponent)
 }
 
+// convertGPUSelection converts detected GPU DTOs to a domain GPU selection
+func (u *StartInstallationUseCase) convertGPUSelection(dtoGPUs []dto.GPUDeviceRequest, renderGPU string) (installation.GPUSelection, error) {
+	devices := make([]installation.GPUDevice, 0, len(dtoGPUs))
+	for _, g := range dtoGPUs {
+		device, err := installation.NewGPUDevice(g.Vendor, g.Model, g.PCISlot, g.DRMDevice)
+		if err != nil {
+			return installation.GPUSelection{}, fmt.Errorf("invalid GPU %s: %w", g.PCISlot, err)
+		}
+		devices = append(devices, device)
+	}
+
+	return installation.NewGPUSelection(devices, renderGPU)
+}
+
 // ConvertComponentName converts a string component name to ComponentName enum
 func ConvertComponentName(name string) installation.ComponentName {
 	switch name {
@@ -160,3 +235,15 @@ func ConvertComponentName(name string) installation.ComponentName {
 		return installation.ComponentName(name)
 	}
 }
+
+// toInvocation converts an invocation DTO to the domain value object
+func toInvocation(request dto.InvocationRequest) (installation.Invocation, error) {
+	return installation.NewInvocation(
+		request.Args,
+		request.Flags,
+		request.ConfigHash,
+		request.Version,
+		request.Commit,
+		request.User,
+	)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/rebelopsio/gohan/internal/application/installation/dto"
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/history"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
 	"github.com/stretchr/testify/assert"
@@ -125,6 +126,273 @@ func TestStartInstallationUseCase_Execute(t *testing.T) {
 		assert.NotEmpty(t, response.SessionID)
 	})
 
+	t.Run("stores chosen alternatives on the session", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+				{Name: "kitty", Version: "latest"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+			Alternatives:   []string{"terminal=alacritty", "swaylock"},
+		}
+
+		response, err := useCase.Execute(ctx, request)
+		require.NoError(t, err)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+
+		alternatives := session.Configuration().Alternatives()
+		terminal, _ := alternatives.Provider(installation.SlotTerminal)
+		locker, _ := alternatives.Provider(installation.SlotLocker)
+		assert.Equal(t, "alacritty", terminal)
+		assert.Equal(t, "swaylock", locker)
+	})
+
+	t.Run("stores chosen rendering mode on the session", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+			RenderingMode:  "standard",
+		}
+
+		response, err := useCase.Execute(ctx, request)
+		require.NoError(t, err)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		assert.Equal(t, installation.RenderingStandard, session.Configuration().RenderingMode())
+	})
+
+	t.Run("marks dry-run requests on the session", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+			DryRun:         true,
+		}
+
+		response, err := useCase.Execute(ctx, request)
+		require.NoError(t, err)
+		assert.True(t, response.DryRun)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		assert.True(t, session.Configuration().DryRun())
+	})
+
+	t.Run("rejects unknown rendering mode", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+			RenderingMode:  "ultra",
+		}
+
+		_, err := useCase.Execute(context.Background(), request)
+		assert.ErrorIs(t, err, installation.ErrInvalidRenderingMode)
+	})
+
+	t.Run("stores accessibility options on the session", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+			Accessibility:  []string{"large-text", "high-contrast"},
+		}
+
+		response, err := useCase.Execute(ctx, request)
+		require.NoError(t, err)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		settings := session.Configuration().Accessibility()
+		assert.True(t, settings.LargeText())
+		assert.True(t, settings.HighContrast())
+		assert.False(t, settings.ReducedMotion())
+
+		request.Accessibility = []string{"bigger"}
+		_, err = useCase.Execute(ctx, request)
+		assert.ErrorIs(t, err, installation.ErrInvalidAccessibilityOption)
+	})
+
+	t.Run("records the history scope on the session", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+			Scope:          "user:alice",
+		}
+
+		response, err := useCase.Execute(ctx, request)
+		require.NoError(t, err)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		assert.Equal(t, "user:alice", session.Scope())
+	})
+
+	t.Run("defaults to the system scope", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+		}
+
+		response, err := useCase.Execute(ctx, request)
+		require.NoError(t, err)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		assert.Equal(t, "system", session.Scope())
+	})
+
+	t.Run("records the invocation on the session", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			Invocation: &dto.InvocationRequest{
+				Args:    []string{"gohan", "install", "--components", "hyprland"},
+				Flags:   map[string]string{"components": "hyprland"},
+				Version: "1.2.0",
+				Commit:  "deadbeef",
+				User:    "alice",
+			},
+		}
+
+		response, err := useCase.Execute(ctx, request)
+		require.NoError(t, err)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		assert.Equal(t, "gohan install --components hyprland", session.Invocation().CommandLine())
+		assert.Equal(t, "deadbeef", session.Invocation().Commit())
+
+		request.Invocation = &dto.InvocationRequest{}
+		_, err = useCase.Execute(ctx, request)
+		assert.ErrorIs(t, err, installation.ErrInvalidInvocation, "an invocation needs a command line")
+	})
+
+	t.Run("rejects an invalid scope", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+			Scope:          "everyone",
+		}
+
+		_, err := useCase.Execute(context.Background(), request)
+		assert.ErrorIs(t, err, history.ErrInvalidScope)
+	})
+
+	t.Run("rejects conflicting alternatives", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+				{Name: "swaybg", Version: "latest"},
+				{Name: "hyprpaper", Version: "latest"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+		}
+
+		_, err := useCase.Execute(ctx, request)
+		assert.ErrorIs(t, err, installation.ErrConflictingAlternatives)
+
+		request.Components = request.Components[:2]
+		request.Alternatives = []string{"kitty", "foot"}
+
+		_, err = useCase.Execute(ctx, request)
+		assert.ErrorIs(t, err, installation.ErrConflictingAlternatives)
+	})
+
+	t.Run("records detected GPUs and the render choice", func(t *testing.T) {
+		sessionRepo := repository.NewMemorySessionRepository()
+		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
+		ctx := context.Background()
+
+		request := dto.InstallationRequest{
+			Components: []dto.ComponentRequest{
+				{Name: "hyprland", Version: "0.35.0"},
+			},
+			AvailableSpace: 100 * uint64(installation.GB),
+			RequiredSpace:  10 * uint64(installation.GB),
+			GPUs: []dto.GPUDeviceRequest{
+				{Vendor: "intel", Model: "UHD Graphics 620", PCISlot: "0000:00:02.0", DRMDevice: "/dev/dri/card0"},
+				{Vendor: "nvidia", Model: "GeForce MX150", PCISlot: "0000:01:00.0", DRMDevice: "/dev/dri/card1"},
+			},
+			RenderGPU: "0000:01:00.0",
+		}
+
+		response, err := useCase.Execute(ctx, request)
+		require.NoError(t, err)
+
+		session, err := sessionRepo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		gpus := session.Configuration().GPUs()
+		assert.True(t, gpus.IsMultiGPU())
+		assert.Equal(t, []string{"/dev/dri/card1", "/dev/dri/card0"}, gpus.DRMDevices())
+
+		request.RenderGPU = "0000:02:00.0"
+		_, err = useCase.Execute(ctx, request)
+		assert.ErrorIs(t, err, installation.ErrUnknownRenderGPU)
+	})
+
 	t.Run("validates component names", func(t *testing.T) {
 		sessionRepo := repository.NewMemorySessionRepository()
 		useCase := usecases.NewStartInstallationUseCase(sessionRepo)
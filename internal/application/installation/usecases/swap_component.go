@@ -0,0 +1,384 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+)
+
+var (
+	// ErrNoCompletedInstallation is returned when there is no installation
+	// to change yet
+	ErrNoCompletedInstallation = errors.New("no completed installation found")
+
+	// ErrProviderNotInstalled is returned when the package being replaced
+	// is not the one filling its slot
+	ErrProviderNotInstalled = errors.New("package to replace is not installed")
+)
+
+// PackageRemover defines the interface for removing packages
+type PackageRemover interface {
+	RemovePackage(ctx context.Context, packageName string) error
+}
+
+// SwapComponentUseCase replaces the provider of an alternative slot, such as
+// the terminal, on an existing installation. The replacement is installed
+// and the affected configuration rendered before the old package is
+// removed, so a failure leaves a working desktop. The swap is kept as a
+// session of its own, which makes it the latest installation and records
+// it in history.
+type SwapComponentUseCase struct {
+	sessionRepo     installation.InstallationSessionRepository
+	packageManager  PackageManager
+	packageRemover  PackageRemover
+	historyRecorder HistoryRecorder
+	configDeployer  *configservice.ConfigDeployer
+	importedVars    ImportedVarsLoader              // Optional
+	lockScreen      installation.LockScreenSettings // Zero value is the default lock screen
+}
+
+// NewSwapComponentUseCase creates a new SwapComponentUseCase
+func NewSwapComponentUseCase(
+	sessionRepo installation.InstallationSessionRepository,
+	packageManager PackageManager,
+	packageRemover PackageRemover,
+	historyRecorder HistoryRecorder,
+	configDeployer *configservice.ConfigDeployer,
+) *SwapComponentUseCase {
+	return &SwapComponentUseCase{
+		sessionRepo:     sessionRepo,
+		packageManager:  packageManager,
+		packageRemover:  packageRemover,
+		historyRecorder: historyRecorder,
+		configDeployer:  configDeployer,
+	}
+}
+
+// WithImportedVars renders the configuration with the variables imported
+// by gohan migrate, as installations do
+func (u *SwapComponentUseCase) WithImportedVars(loader ImportedVarsLoader) *SwapComponentUseCase {
+	u.importedVars = loader
+	return u
+}
+
+// WithLockScreen renders the lock screen of a swapped-in locker as
+// installations do
+func (u *SwapComponentUseCase) WithLockScreen(lock installation.LockScreenSettings) *SwapComponentUseCase {
+	u.lockScreen = lock
+	return u
+}
+
+// Execute swaps request.From for request.To
+func (u *SwapComponentUseCase) Execute(ctx context.Context, request dto.SwapComponentRequest) (*dto.SwapComponentResponse, error) {
+	swap, err := installation.NewAlternativeSwap(request.From, request.To)
+	if err != nil {
+		return nil, err
+	}
+	var invocation installation.Invocation
+	if request.Invocation != nil {
+		if invocation, err = toInvocation(*request.Invocation); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := u.latestInstallation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !current.IsInstalled(swap.From().Component) {
+		return nil, fmt.Errorf("%w: the installation has no %s", ErrProviderNotInstalled, swap.Slot())
+	}
+	installed, err := u.packageManager.IsPackageInstalled(ctx, swap.From().Package)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s: %w", swap.From().Package, err)
+	}
+	if !installed {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotInstalled, swap.From().Package)
+	}
+
+	session, err := u.newSwapSession(ctx, current, swap, invocation)
+	if err != nil {
+		return nil, err
+	}
+
+	// Install the replacement first so the slot is never empty
+	for _, pkg := range swap.InstalledPackages() {
+		if err := u.packageManager.InstallPackage(ctx, pkg, ""); err != nil {
+			return nil, u.fail(ctx, session, fmt.Sprintf("failed to install %s: %v", pkg, err))
+		}
+	}
+	if err := u.addInstalledComponents(session, current, swap); err != nil {
+		return nil, u.fail(ctx, session, err.Error())
+	}
+
+	if err := session.StartConfiguring(); err != nil {
+		return nil, err
+	}
+	variables := swap.ChangedTemplateVars(current.Configuration().Alternatives())
+	configFiles, err := u.renderConfigs(ctx, session, current, swap, variables)
+	if err != nil {
+		return nil, u.fail(ctx, session, fmt.Sprintf("failed to render configuration: %v", err))
+	}
+
+	// The desktop no longer refers to the old package; a failed removal
+	// only leaves it installed
+	var removed []string
+	if !request.KeepPrevious {
+		for _, pkg := range swap.RemovedPackages() {
+			if err := u.packageRemover.RemovePackage(ctx, pkg); err != nil {
+				recordWarning(session, installation.WarningSourceRemoval, fmt.Sprintf("Could not remove %s: %v", pkg, err))
+				continue
+			}
+			removed = append(removed, pkg)
+		}
+	}
+
+	if err := session.StartVerifying(); err != nil {
+		return nil, err
+	}
+	markInstalledComponents(session, installation.ComponentStateVerified)
+	if err := session.Complete(); err != nil {
+		return nil, err
+	}
+	if err := u.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session state: %w", err)
+	}
+
+	if u.historyRecorder != nil {
+		if _, err := u.historyRecorder.RecordInstallation(ctx, session); err != nil {
+			// History recording is not critical to the swap
+			fmt.Printf("Warning: failed to record swap to history: %v\n", err)
+		}
+	}
+
+	targets := make([]string, 0, len(configFiles))
+	for _, configFile := range configFiles {
+		targets = append(targets, configFile.TargetPath)
+	}
+
+	return &dto.SwapComponentResponse{
+		SessionID:         session.ID(),
+		Slot:              swap.Slot().String(),
+		From:              swap.From().Package,
+		To:                swap.To().Package,
+		InstalledPackages: swap.InstalledPackages(),
+		RemovedPackages:   removed,
+		Variables:         variables,
+		ConfigFiles:       targets,
+	}, nil
+}
+
+// latestInstallation returns the most recently completed session
+func (u *SwapComponentUseCase) latestInstallation(ctx context.Context) (*installation.InstallationSession, error) {
+	sessions, err := u.sessionRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installations: %w", err)
+	}
+
+	var latest *installation.InstallationSession
+	for _, session := range sessions {
+		if !session.IsCompleted() {
+			continue
+		}
+		if latest == nil || session.CompletedAt().After(latest.CompletedAt()) {
+			latest = session
+		}
+	}
+	if latest == nil {
+		return nil, ErrNoCompletedInstallation
+	}
+	return latest, nil
+}
+
+// newSwapSession starts a session with the current configuration, the
+// replacement selected for its slot
+func (u *SwapComponentUseCase) newSwapSession(
+	ctx context.Context,
+	current *installation.InstallationSession,
+	swap installation.AlternativeSwap,
+	invocation installation.Invocation,
+) (*installation.InstallationSession, error) {
+	config := current.Configuration()
+
+	replacement, err := u.replacementSelection(ctx, swap)
+	if err != nil {
+		return nil, err
+	}
+	components := make([]installation.ComponentSelection, 0, config.ComponentCount())
+	for _, comp := range config.Components() {
+		if comp.Component() == swap.From().Component {
+			comp = replacement
+		}
+		components = append(components, comp)
+	}
+
+	swapped, err := installation.NewInstallationConfiguration(components, config.GPUSupport(), config.DiskSpace(), config.MergeExistingConfig())
+	if err != nil {
+		return nil, err
+	}
+	swapped, err = swapped.WithAlternatives(swap.Apply(config.Alternatives()))
+	if err != nil {
+		return nil, err
+	}
+	swapped = swapped.WithRenderingMode(config.RenderingMode()).
+		WithGPUs(config.GPUs()).
+		WithAccessibility(config.Accessibility())
+
+	session, err := installation.NewInstallationSession(swapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	session.SetScope(current.Scope())
+	session.SetInvocation(invocation)
+
+	snapshot, err := installation.NewSystemSnapshot("/var/lib/gohan/snapshots", config.DiskSpace(), []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create system snapshot: %w", err)
+	}
+	if err := session.StartPreparation(snapshot); err != nil {
+		return nil, err
+	}
+	if err := session.StartInstalling(); err != nil {
+		return nil, err
+	}
+	if err := u.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session state: %w", err)
+	}
+
+	return session, nil
+}
+
+// replacementSelection selects the replacement's component at the version
+// the package manager will install, when it can tell
+func (u *SwapComponentUseCase) replacementSelection(ctx context.Context, swap installation.AlternativeSwap) (installation.ComponentSelection, error) {
+	if resolver, ok := u.packageManager.(PackageResolver); ok {
+		pkg, err := resolver.ResolvePackage(ctx, swap.To().Package)
+		if err != nil {
+			return installation.ComponentSelection{}, fmt.Errorf("failed to resolve %s: %w", swap.To().Package, err)
+		}
+		return installation.NewComponentSelection(swap.To().Component, pkg.Version(), &pkg)
+	}
+	return installation.NewComponentSelection(swap.To().Component, "latest", nil)
+}
+
+// addInstalledComponents carries the components of the current installation
+// over to the swap session, with the replacement in place of the old one
+func (u *SwapComponentUseCase) addInstalledComponents(
+	session *installation.InstallationSession,
+	current *installation.InstallationSession,
+	swap installation.AlternativeSwap,
+) error {
+	for _, installed := range current.InstalledComponents() {
+		if installed.Component() == swap.From().Component {
+			continue
+		}
+		if err := session.AddInstalledComponent(installed); err != nil {
+			return fmt.Errorf("failed to add installed component: %w", err)
+		}
+	}
+
+	for _, comp := range session.Configuration().Components() {
+		if comp.Component() != swap.To().Component {
+			continue
+		}
+		replacement, err := installation.NewInstalledComponent(comp.Component(), comp.Version(), comp.PackageInfo())
+		if err != nil {
+			return fmt.Errorf("failed to create installed component: %w", err)
+		}
+		return session.AddInstalledComponent(replacement)
+	}
+	return nil
+}
+
+// renderConfigs deploys the configuration files the swap affects: files
+// only the replacement has, such as its own configuration, and files whose
+// template uses a variable the swap changed, such as the keybindings
+// launching the terminal
+func (u *SwapComponentUseCase) renderConfigs(
+	ctx context.Context,
+	session *installation.InstallationSession,
+	current *installation.InstallationSession,
+	swap installation.AlternativeSwap,
+	variables map[string]string,
+) ([]configservice.ConfigurationFile, error) {
+	config := session.Configuration()
+	renderingMode := config.RenderingMode().Resolve(false)
+
+	vars, err := templateVarsFor(session, renderingMode, config.Alternatives())
+	if err != nil {
+		return nil, err
+	}
+	if err := applyImportedVars(vars, u.importedVars); err != nil {
+		return nil, err
+	}
+	configDir := vars["config_dir"]
+
+	before := make([]installation.ComponentName, 0, len(current.InstalledComponents()))
+	for _, c := range current.InstalledComponents() {
+		before = append(before, c.Component())
+	}
+	after := make([]installation.ComponentName, 0, len(session.InstalledComponents()))
+	for _, c := range session.InstalledComponents() {
+		after = append(after, c.Component())
+	}
+
+	previousFiles, _ := configFilesFor(before, swap.Before(current.Configuration().Alternatives()), renderingMode, configDir)
+	previous := make(map[string]bool, len(previousFiles))
+	for _, configFile := range previousFiles {
+		previous[configFile.TargetPath] = true
+	}
+
+	candidates, _ := configFilesFor(after, config.Alternatives(), renderingMode, configDir)
+	var configFiles []configservice.ConfigurationFile
+	for _, configFile := range candidates {
+		if !previous[configFile.TargetPath] || templateUsesAny(configFile.SourceTemplate, variables) {
+			configFiles = append(configFiles, configFile)
+		}
+	}
+
+	if len(configFiles) == 0 {
+		return nil, nil
+	}
+	applyLockScreen(session, u.lockScreen, configFiles, vars)
+	if err := u.configDeployer.DeployConfigurations(ctx, configFiles, vars, nil); err != nil {
+		return nil, err
+	}
+	recordDeployedConfigs(session, configFiles, nil)
+
+	return configFiles, nil
+}
+
+// fail marks the swap session as failed, records it and returns the error
+func (u *SwapComponentUseCase) fail(ctx context.Context, session *installation.InstallationSession, reason string) error {
+	_ = session.Fail(reason)
+	_ = u.sessionRepo.Save(ctx, session)
+
+	if u.historyRecorder != nil {
+		if _, err := u.historyRecorder.RecordInstallation(ctx, session); err != nil {
+			fmt.Printf("Warning: failed to record failed swap to history: %v\n", err)
+		}
+	}
+
+	return fmt.Errorf("%w: %s", installation.ErrInstallationFailed, reason)
+}
+
+// templateUsesAny returns true if the template has a placeholder for any of
+// the variables
+func templateUsesAny(templatePath string, variables map[string]string) bool {
+	content, err := os.ReadFile(templatePath)
+	if err != nil {
+		return false
+	}
+	for name := range variables {
+		if strings.Contains(string(content), "{{"+name+"}}") {
+			return true
+		}
+	}
+	return false
+}
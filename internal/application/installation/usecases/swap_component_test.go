@@ -0,0 +1,184 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePackageRemover records removed packages
+type fakePackageRemover struct {
+	removed []string
+	fail    map[string]error
+}
+
+func (r *fakePackageRemover) RemovePackage(ctx context.Context, packageName string) error {
+	if err := r.fail[packageName]; err != nil {
+		return err
+	}
+	r.removed = append(r.removed, packageName)
+	return nil
+}
+
+// fakeHistoryRecorder records the sessions it is given
+type fakeHistoryRecorder struct {
+	sessions []*installation.InstallationSession
+}
+
+func (r *fakeHistoryRecorder) RecordInstallation(ctx context.Context, session *installation.InstallationSession) (history.RecordID, error) {
+	r.sessions = append(r.sessions, session)
+	return history.NewRecordID()
+}
+
+// saveCompletedInstallation stores a completed installation of Hyprland and kitty
+func saveCompletedInstallation(t *testing.T, repo installation.InstallationSessionRepository) *installation.InstallationSession {
+	t.Helper()
+
+	components, err := createTestComponents()
+	require.NoError(t, err)
+	kitty, err := installation.NewComponentSelection(installation.ComponentKitty, "0.32.2", nil)
+	require.NoError(t, err)
+	components = append(components, kitty)
+
+	config, err := installation.NewInstallationConfiguration(components, nil, installation.DiskSpace{}, false)
+	require.NoError(t, err)
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+
+	snapshot, err := installation.NewSystemSnapshot("/var/lib/gohan/snapshots", installation.DiskSpace{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, session.StartPreparation(snapshot))
+	require.NoError(t, session.StartInstalling())
+	for _, comp := range components {
+		installed, err := installation.NewInstalledComponent(comp.Component(), comp.Version(), nil)
+		require.NoError(t, err)
+		require.NoError(t, session.AddInstalledComponent(installed))
+	}
+	require.NoError(t, session.StartConfiguring())
+	require.NoError(t, session.StartVerifying())
+	require.NoError(t, session.Complete())
+	require.NoError(t, repo.Save(context.Background(), session))
+
+	return session
+}
+
+func TestSwapComponentUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replaces the terminal and records the swap", func(t *testing.T) {
+		repo := repository.NewMemorySessionRepository()
+		saveCompletedInstallation(t, repo)
+
+		packageManager := new(MockPackageManager)
+		packageManager.On("IsPackageInstalled", ctx, "kitty").Return(true, nil)
+		packageManager.On("InstallPackage", ctx, "alacritty", "").Return(nil)
+		remover := &fakePackageRemover{}
+		recorder := &fakeHistoryRecorder{}
+
+		useCase := usecases.NewSwapComponentUseCase(repo, packageManager, remover, recorder, nil)
+		response, err := useCase.Execute(ctx, dto.SwapComponentRequest{From: "kitty", To: "alacritty"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "terminal", response.Slot)
+		assert.Equal(t, []string{"alacritty"}, response.InstalledPackages)
+		assert.Equal(t, []string{"kitty", "kitty-terminfo"}, response.RemovedPackages)
+		assert.Equal(t, []string{"kitty", "kitty-terminfo"}, remover.removed)
+		assert.Equal(t, "alacritty", response.Variables["terminal"])
+		packageManager.AssertExpectations(t)
+
+		session, err := repo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		assert.True(t, session.IsCompleted())
+		assert.True(t, session.IsInstalled(installation.ComponentHyprland), "other components are carried over")
+		assert.True(t, session.IsInstalled(installation.ComponentKitty))
+		terminal, _ := session.Configuration().Alternatives().Provider(installation.SlotTerminal)
+		assert.Equal(t, "alacritty", terminal)
+
+		require.Len(t, recorder.sessions, 1)
+		assert.Equal(t, response.SessionID, recorder.sessions[0].ID())
+	})
+
+	t.Run("keeps the previous package on request", func(t *testing.T) {
+		repo := repository.NewMemorySessionRepository()
+		saveCompletedInstallation(t, repo)
+
+		packageManager := new(MockPackageManager)
+		packageManager.On("IsPackageInstalled", ctx, "kitty").Return(true, nil)
+		packageManager.On("InstallPackage", ctx, "foot", "").Return(nil)
+		remover := &fakePackageRemover{}
+
+		useCase := usecases.NewSwapComponentUseCase(repo, packageManager, remover, nil, nil)
+		response, err := useCase.Execute(ctx, dto.SwapComponentRequest{From: "kitty", To: "foot", KeepPrevious: true})
+		require.NoError(t, err)
+
+		assert.Empty(t, response.RemovedPackages)
+		assert.Empty(t, remover.removed)
+	})
+
+	t.Run("a failed removal is a warning", func(t *testing.T) {
+		repo := repository.NewMemorySessionRepository()
+		saveCompletedInstallation(t, repo)
+
+		packageManager := new(MockPackageManager)
+		packageManager.On("IsPackageInstalled", ctx, "kitty").Return(true, nil)
+		packageManager.On("InstallPackage", ctx, "alacritty", "").Return(nil)
+		remover := &fakePackageRemover{fail: map[string]error{"kitty-terminfo": errors.New("held")}}
+
+		useCase := usecases.NewSwapComponentUseCase(repo, packageManager, remover, nil, nil)
+		response, err := useCase.Execute(ctx, dto.SwapComponentRequest{From: "kitty", To: "alacritty"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"kitty"}, response.RemovedPackages)
+		session, err := repo.FindByID(ctx, response.SessionID)
+		require.NoError(t, err)
+		require.Len(t, session.Warnings(), 1)
+		assert.Equal(t, installation.WarningSourceRemoval, session.Warnings()[0].Source())
+	})
+
+	t.Run("a failed install leaves the old package and fails the session", func(t *testing.T) {
+		repo := repository.NewMemorySessionRepository()
+		saveCompletedInstallation(t, repo)
+
+		packageManager := new(MockPackageManager)
+		packageManager.On("IsPackageInstalled", ctx, "kitty").Return(true, nil)
+		packageManager.On("InstallPackage", ctx, "alacritty", "").Return(errors.New("unavailable"))
+		remover := &fakePackageRemover{}
+		recorder := &fakeHistoryRecorder{}
+
+		useCase := usecases.NewSwapComponentUseCase(repo, packageManager, remover, recorder, nil)
+		_, err := useCase.Execute(ctx, dto.SwapComponentRequest{From: "kitty", To: "alacritty"})
+		assert.ErrorIs(t, err, installation.ErrInstallationFailed)
+
+		assert.Empty(t, remover.removed)
+		require.Len(t, recorder.sessions, 1)
+		assert.True(t, recorder.sessions[0].IsFailed())
+	})
+
+	t.Run("rejects a package that is not installed", func(t *testing.T) {
+		repo := repository.NewMemorySessionRepository()
+		saveCompletedInstallation(t, repo)
+
+		packageManager := new(MockPackageManager)
+		packageManager.On("IsPackageInstalled", ctx, "kitty").Return(false, nil)
+
+		useCase := usecases.NewSwapComponentUseCase(repo, packageManager, &fakePackageRemover{}, nil, nil)
+		_, err := useCase.Execute(ctx, dto.SwapComponentRequest{From: "kitty", To: "alacritty"})
+		assert.ErrorIs(t, err, usecases.ErrProviderNotInstalled)
+		packageManager.AssertNotCalled(t, "InstallPackage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("needs a completed installation", func(t *testing.T) {
+		useCase := usecases.NewSwapComponentUseCase(repository.NewMemorySessionRepository(), new(MockPackageManager), &fakePackageRemover{}, nil, nil)
+		_, err := useCase.Execute(ctx, dto.SwapComponentRequest{From: "kitty", To: "alacritty"})
+		assert.ErrorIs(t, err, usecases.ErrNoCompletedInstallation)
+	})
+}
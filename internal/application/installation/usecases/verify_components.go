@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// verificationWorkers bounds how many components are verified at once
+const verificationWorkers = 4
+
+// PackageVerifier is optionally implemented by package managers that can
+// check an installed package's dpkg status and binaries
+type PackageVerifier interface {
+	VerifyPackage(ctx context.Context, packageName string) error
+}
+
+// verificationTarget is an installed component and what to check for it
+type verificationTarget struct {
+	component   installation.ComponentName
+	packageName string
+	configPaths []string
+}
+
+// verificationTargets lists the installed components, in installation
+// order, with the package and configuration files each one brought in
+func verificationTargets(
+	session *installation.InstallationSession,
+	packages map[installation.ComponentName]string,
+	configPaths map[installation.ComponentName][]string,
+) []verificationTarget {
+	installed := session.InstalledComponents()
+	targets := make([]verificationTarget, 0, len(installed))
+	for _, component := range installed {
+		targets = append(targets, verificationTarget{
+			component:   component.Component(),
+			packageName: packages[component.Component()],
+			configPaths: configPaths[component.Component()],
+		})
+	}
+	return targets
+}
+
+// verifyComponents checks every target on a bounded pool of workers. The
+// results are in target order however the checks interleave, so reports
+// and persisted sessions are deterministic.
+func (u *ExecuteInstallationUseCase) verifyComponents(
+	ctx context.Context,
+	targets []verificationTarget,
+) ([]installation.ComponentVerification, error) {
+	results := make([]installation.ComponentVerification, len(targets))
+	errs := make([]error, len(targets))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(verificationWorkers, len(targets)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = u.verifyComponent(ctx, targets[i])
+			}
+		}()
+	}
+
+	for i := range targets {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// verifyComponent checks the component's package and configuration files
+func (u *ExecuteInstallationUseCase) verifyComponent(
+	ctx context.Context,
+	target verificationTarget,
+) (installation.ComponentVerification, error) {
+	start := time.Now()
+	var problems []string
+
+	if verifier, ok := u.packageManager.(PackageVerifier); ok && target.packageName != "" {
+		if err := verifier.VerifyPackage(ctx, target.packageName); err != nil {
+			problems = append(problems, strings.Split(err.Error(), "\n")...)
+		}
+	}
+
+	for _, path := range target.configPaths {
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("configuration %s is missing", path))
+		case info.Size() == 0:
+			problems = append(problems, fmt.Sprintf("configuration %s is empty", path))
+		}
+	}
+
+	return installation.NewComponentVerification(target.component, problems, time.Since(start))
+}
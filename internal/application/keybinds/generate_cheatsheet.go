@@ -0,0 +1,76 @@
+package keybinds
+
+import (
+	"context"
+
+	"github.com/rebelopsio/gohan/internal/domain/keybinds"
+)
+
+// ConfigSource is the interface for reading the configured key bindings
+type ConfigSource interface {
+	Read() (string, error)
+	Path() string
+}
+
+// CheatSheetRenderer is the interface for writing a cheat sheet out
+type CheatSheetRenderer interface {
+	Render(sheet *keybinds.CheatSheet, format keybinds.Format) (string, error)
+}
+
+// GenerateCheatSheetRequest contains parameters for a cheat sheet
+type GenerateCheatSheetRequest struct {
+	Format string // text, markdown or html; defaults to text
+}
+
+// GenerateCheatSheetResponse contains the rendered cheat sheet
+type GenerateCheatSheetResponse struct {
+	Format   string
+	Source   string // Configuration the bindings were read from
+	Bindings int
+	Content  string
+}
+
+// GenerateCheatSheetUseCase renders the key bindings Hyprland is
+// configured with
+type GenerateCheatSheetUseCase struct {
+	source   ConfigSource
+	renderer CheatSheetRenderer
+}
+
+// NewGenerateCheatSheetUseCase creates a new use case instance
+func NewGenerateCheatSheetUseCase(source ConfigSource, renderer CheatSheetRenderer) *GenerateCheatSheetUseCase {
+	return &GenerateCheatSheetUseCase{
+		source:   source,
+		renderer: renderer,
+	}
+}
+
+// Execute reads the configuration and renders its bindings
+func (uc *GenerateCheatSheetUseCase) Execute(ctx context.Context, req GenerateCheatSheetRequest) (*GenerateCheatSheetResponse, error) {
+	format, err := keybinds.ParseFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := uc.source.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := keybinds.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := uc.renderer.Render(sheet, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GenerateCheatSheetResponse{
+		Format:   string(format),
+		Source:   uc.source.Path(),
+		Bindings: sheet.Len(),
+		Content:  rendered,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package keybinds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/keybinds"
+	domain "github.com/rebelopsio/gohan/internal/domain/keybinds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource returns fixed configuration
+type fakeSource struct {
+	content string
+	err     error
+}
+
+func (s *fakeSource) Read() (string, error) {
+	return s.content, s.err
+}
+
+func (s *fakeSource) Path() string {
+	return "/home/alice/.config/hypr/hyprland.conf"
+}
+
+// fakeRenderer records the format it was asked for
+type fakeRenderer struct {
+	format domain.Format
+}
+
+func (r *fakeRenderer) Render(sheet *domain.CheatSheet, format domain.Format) (string, error) {
+	r.format = format
+	return "rendered", nil
+}
+
+func TestGenerateCheatSheetUseCase(t *testing.T) {
+	t.Run("renders the configured bindings", func(t *testing.T) {
+		source := &fakeSource{content: "bind = SUPER, Return, exec, kitty\nbind = SUPER, B, exec, firefox\n"}
+		renderer := &fakeRenderer{}
+
+		response, err := keybinds.NewGenerateCheatSheetUseCase(source, renderer).
+			Execute(context.Background(), keybinds.GenerateCheatSheetRequest{Format: "markdown"})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.FormatMarkdown, renderer.format)
+		assert.Equal(t, "markdown", response.Format)
+		assert.Equal(t, source.Path(), response.Source)
+		assert.Equal(t, 2, response.Bindings)
+		assert.Equal(t, "rendered", response.Content)
+	})
+
+	t.Run("defaults to text", func(t *testing.T) {
+		renderer := &fakeRenderer{}
+
+		_, err := keybinds.NewGenerateCheatSheetUseCase(&fakeSource{content: "bind = SUPER, Q, killactive\n"}, renderer).
+			Execute(context.Background(), keybinds.GenerateCheatSheetRequest{})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.FormatText, renderer.format)
+	})
+
+	t.Run("rejects unknown formats before reading", func(t *testing.T) {
+		_, err := keybinds.NewGenerateCheatSheetUseCase(&fakeSource{err: errors.New("unreachable")}, &fakeRenderer{}).
+			Execute(context.Background(), keybinds.GenerateCheatSheetRequest{Format: "png"})
+		assert.ErrorIs(t, err, domain.ErrUnknownFormat)
+	})
+
+	t.Run("reports a configuration without bindings", func(t *testing.T) {
+		_, err := keybinds.NewGenerateCheatSheetUseCase(&fakeSource{content: "# empty\n"}, &fakeRenderer{}).
+			Execute(context.Background(), keybinds.GenerateCheatSheetRequest{})
+		assert.ErrorIs(t, err, domain.ErrNoBindings)
+	})
+}
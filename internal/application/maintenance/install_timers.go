@@ -0,0 +1,113 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/domain/maintenance"
+)
+
+// InstallTimersRequest contains parameters for generating maintenance timers
+type InstallTimersRequest struct {
+	Scope      maintenance.Scope
+	Executable string             // Absolute path of the gohan binary the units run
+	Tasks      []maintenance.Task // Empty schedules every task of the scope
+	DryRun     bool               // Render the units without writing or enabling them
+}
+
+// InstallTimersResponse contains the generated timers
+type InstallTimersResponse struct {
+	Scope   maintenance.Scope
+	UnitDir string
+	Timers  []InstalledTimer
+}
+
+// InstalledTimer describes a generated service and timer pair
+type InstalledTimer struct {
+	Task        maintenance.Task
+	Schedule    string
+	ServiceName string
+	TimerName   string
+	Service     string // Rendered service unit
+	Timer       string // Rendered timer unit
+}
+
+// TimerInstaller is the interface for writing and enabling systemd units
+type TimerInstaller interface {
+	UnitDir(scope maintenance.Scope) string
+	WriteUnit(scope maintenance.Scope, name, content string) error
+	Reload(ctx context.Context, scope maintenance.Scope) error
+	EnableTimer(ctx context.Context, scope maintenance.Scope, name string) error
+}
+
+// InstallTimersUseCase handles generating and enabling maintenance timers
+type InstallTimersUseCase struct {
+	installer TimerInstaller
+}
+
+// NewInstallTimersUseCase creates a new use case instance
+func NewInstallTimersUseCase(installer TimerInstaller) *InstallTimersUseCase {
+	return &InstallTimersUseCase{
+		installer: installer,
+	}
+}
+
+// Execute writes a service and timer per task, reloads systemd and enables
+// the timers. Re-running it overwrites the units, so it is safe to repeat
+// after upgrading or moving the gohan binary.
+func (uc *InstallTimersUseCase) Execute(ctx context.Context, req InstallTimersRequest) (*InstallTimersResponse, error) {
+	tasks := req.Tasks
+	if len(tasks) == 0 {
+		tasks = maintenance.TasksForScope(req.Scope)
+	}
+
+	units := make([]maintenance.TimerUnit, 0, len(tasks))
+	for _, task := range tasks {
+		unit, err := maintenance.NewTimerUnit(task, req.Scope, req.Executable)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, unit)
+	}
+
+	response := &InstallTimersResponse{
+		Scope:   req.Scope,
+		UnitDir: uc.installer.UnitDir(req.Scope),
+		Timers:  make([]InstalledTimer, 0, len(units)),
+	}
+	for _, unit := range units {
+		response.Timers = append(response.Timers, InstalledTimer{
+			Task:        unit.Task(),
+			Schedule:    unit.Task().Schedule(),
+			ServiceName: unit.ServiceName(),
+			TimerName:   unit.TimerName(),
+			Service:     unit.RenderService(),
+			Timer:       unit.RenderTimer(),
+		})
+	}
+
+	if req.DryRun {
+		return response, nil
+	}
+
+	for _, timer := range response.Timers {
+		if err := uc.installer.WriteUnit(req.Scope, timer.ServiceName, timer.Service); err != nil {
+			return nil, err
+		}
+		if err := uc.installer.WriteUnit(req.Scope, timer.TimerName, timer.Timer); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.installer.Reload(ctx, req.Scope); err != nil {
+		return nil, err
+	}
+
+	for _, timer := range response.Timers {
+		if err := uc.installer.EnableTimer(ctx, req.Scope, timer.TimerName); err != nil {
+			return nil, fmt.Errorf("units written to %s but enabling failed: %w", response.UnitDir, err)
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,165 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+)
+
+// SettingsReader reads the settings of one desktop
+type SettingsReader interface {
+	Read(ctx context.Context) (migration.Settings, error)
+}
+
+// VariableStore keeps imported template variables
+type VariableStore interface {
+	Save(vars map[string]string) error
+	Path() string
+}
+
+// WallpaperImporter puts a wallpaper image where the configuration reads
+// it from
+type WallpaperImporter interface {
+	Exists() bool
+	Import(source string) error
+	Path() string
+}
+
+// ServiceChecker reports whether a service is installed and can start
+type ServiceChecker interface {
+	CanDisable(ctx context.Context, service migration.Service) bool
+}
+
+// ImportSettingsRequest selects the desktop to import from
+type ImportSettingsRequest struct {
+	Desktop string
+	DryRun  bool // Report what would be imported without saving it
+	Force   bool // Replace a wallpaper that is already in place
+}
+
+// ServiceDTO describes a service that is safe to disable
+type ServiceDTO struct {
+	Name    string
+	User    bool
+	Reason  string
+	Command string
+}
+
+// ImportSettingsResponse describes what was imported
+type ImportSettingsResponse struct {
+	Desktop           string
+	DryRun            bool
+	Variables         map[string]string
+	VariablesPath     string
+	Wallpaper         string
+	WallpaperPath     string
+	WallpaperImported bool
+	Terminal          string
+	TerminalProvider  string // gohan terminal the default terminal maps to, if any
+	Notes             []string
+	Services          []ServiceDTO
+}
+
+// ImportSettingsUseCase imports a GNOME or KDE user's settings: the
+// keyboard layout and monitor layout become template variables, the
+// wallpaper is copied into place, and the desktop's services a Hyprland
+// session does not need are listed
+type ImportSettingsUseCase struct {
+	readers    map[migration.Desktop]SettingsReader
+	vars       VariableStore
+	wallpapers WallpaperImporter
+	services   ServiceChecker
+}
+
+// NewImportSettingsUseCase creates a new use case instance
+func NewImportSettingsUseCase(
+	readers map[migration.Desktop]SettingsReader,
+	vars VariableStore,
+	wallpapers WallpaperImporter,
+	services ServiceChecker,
+) *ImportSettingsUseCase {
+	return &ImportSettingsUseCase{
+		readers:    readers,
+		vars:       vars,
+		wallpapers: wallpapers,
+		services:   services,
+	}
+}
+
+// Execute imports the settings. Settings that cannot be read or imported
+// are reported as notes rather than failing the import.
+func (uc *ImportSettingsUseCase) Execute(ctx context.Context, req ImportSettingsRequest) (*ImportSettingsResponse, error) {
+	desktop, err := migration.ParseDesktop(req.Desktop)
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := uc.readers[desktop]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot read %s settings", migration.ErrUnknownDesktop, desktop.DisplayName())
+	}
+
+	settings, err := reader.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s settings: %w", desktop.DisplayName(), err)
+	}
+
+	resp := &ImportSettingsResponse{
+		Desktop:       desktop.DisplayName(),
+		DryRun:        req.DryRun,
+		Variables:     settings.ImportedVars(),
+		VariablesPath: uc.vars.Path(),
+		Wallpaper:     settings.Wallpaper(),
+		WallpaperPath: uc.wallpapers.Path(),
+		Terminal:      settings.Terminal(),
+		Notes:         settings.Notes(),
+	}
+	resp.TerminalProvider, _ = settings.TerminalProvider()
+
+	if !req.DryRun && len(resp.Variables) > 0 {
+		if err := uc.vars.Save(resp.Variables); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.importWallpaper(req, resp)
+
+	for _, service := range migration.ServicesSafeToDisable(desktop) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !uc.services.CanDisable(ctx, service) {
+			continue
+		}
+		resp.Services = append(resp.Services, ServiceDTO{
+			Name:    service.Name(),
+			User:    service.IsUserService(),
+			Reason:  service.Reason(),
+			Command: service.DisableCommand(),
+		})
+	}
+
+	return resp, nil
+}
+
+// importWallpaper copies the wallpaper into place unless one is there
+// already
+func (uc *ImportSettingsUseCase) importWallpaper(req ImportSettingsRequest, resp *ImportSettingsResponse) {
+	if resp.Wallpaper == "" {
+		return
+	}
+	if uc.wallpapers.Exists() && !req.Force {
+		resp.Notes = append(resp.Notes, fmt.Sprintf(
+			"Wallpaper not imported: %s already exists (use --force to replace it)", resp.WallpaperPath))
+		return
+	}
+	if req.DryRun {
+		resp.WallpaperImported = true
+		return
+	}
+	if err := uc.wallpapers.Import(resp.Wallpaper); err != nil {
+		resp.Notes = append(resp.Notes, fmt.Sprintf("Wallpaper not imported: %v", err))
+		return
+	}
+	resp.WallpaperImported = true
+}
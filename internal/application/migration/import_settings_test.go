@@ -0,0 +1,152 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	app "github.com/rebelopsio/gohan/internal/application/migration"
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubReader struct {
+	settings migration.Settings
+	err      error
+}
+
+func (r stubReader) Read(ctx context.Context) (migration.Settings, error) {
+	return r.settings, r.err
+}
+
+type fakeVarStore struct {
+	saved map[string]string
+}
+
+func (s *fakeVarStore) Save(vars map[string]string) error {
+	s.saved = vars
+	return nil
+}
+
+func (s *fakeVarStore) Path() string {
+	return "/home/ada/.gohan/template-vars.yaml"
+}
+
+type fakeWallpapers struct {
+	exists   bool
+	imported string
+	err      error
+}
+
+func (w *fakeWallpapers) Exists() bool { return w.exists }
+
+func (w *fakeWallpapers) Import(source string) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.imported = source
+	return nil
+}
+
+func (w *fakeWallpapers) Path() string { return "/home/ada/.config/gohan/wallpaper.jpg" }
+
+type stubServices map[string]bool
+
+func (s stubServices) CanDisable(ctx context.Context, service migration.Service) bool {
+	return s[service.Name()]
+}
+
+func gnomeSettings(t *testing.T) migration.Settings {
+	t.Helper()
+	layout, err := migration.ParseKeyboardLayout("de+nodeadkeys")
+	require.NoError(t, err)
+	return migration.NewSettings(migration.DesktopGNOME).
+		WithKeyboard(migration.NewKeyboard([]migration.KeyboardLayout{layout}, nil)).
+		WithWallpaper("/home/ada/Pictures/lake.jpg").
+		WithTerminal("alacritty")
+}
+
+func newUseCase(reader app.SettingsReader, vars *fakeVarStore, wallpapers *fakeWallpapers, services stubServices) *app.ImportSettingsUseCase {
+	return app.NewImportSettingsUseCase(
+		map[migration.Desktop]app.SettingsReader{migration.DesktopGNOME: reader},
+		vars, wallpapers, services,
+	)
+}
+
+func TestImportSettingsUseCase_Execute(t *testing.T) {
+	t.Run("saves variables, copies the wallpaper and lists services", func(t *testing.T) {
+		vars := &fakeVarStore{}
+		wallpapers := &fakeWallpapers{}
+		useCase := newUseCase(stubReader{settings: gnomeSettings(t)}, vars, wallpapers,
+			stubServices{"packagekit.service": true})
+
+		resp, err := useCase.Execute(context.Background(), app.ImportSettingsRequest{Desktop: "gnome"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "GNOME", resp.Desktop)
+		assert.Equal(t, map[string]string{"kb_layout": "de", "kb_variant": "nodeadkeys", "kb_options": ""}, vars.saved)
+		assert.Equal(t, vars.saved, resp.Variables)
+		assert.Equal(t, "/home/ada/Pictures/lake.jpg", wallpapers.imported)
+		assert.True(t, resp.WallpaperImported)
+		assert.Equal(t, "alacritty", resp.TerminalProvider)
+		require.Len(t, resp.Services, 1)
+		assert.Equal(t, "packagekit.service", resp.Services[0].Name)
+		assert.Equal(t, "sudo systemctl mask --now packagekit.service", resp.Services[0].Command)
+	})
+
+	t.Run("dry run saves and copies nothing", func(t *testing.T) {
+		vars := &fakeVarStore{}
+		wallpapers := &fakeWallpapers{}
+		useCase := newUseCase(stubReader{settings: gnomeSettings(t)}, vars, wallpapers, stubServices{})
+
+		resp, err := useCase.Execute(context.Background(), app.ImportSettingsRequest{Desktop: "gnome", DryRun: true})
+		require.NoError(t, err)
+
+		assert.Nil(t, vars.saved)
+		assert.Empty(t, wallpapers.imported)
+		assert.True(t, resp.WallpaperImported)
+		assert.Equal(t, "de", resp.Variables["kb_layout"])
+	})
+
+	t.Run("keeps a wallpaper in place unless forced", func(t *testing.T) {
+		wallpapers := &fakeWallpapers{exists: true}
+		useCase := newUseCase(stubReader{settings: gnomeSettings(t)}, &fakeVarStore{}, wallpapers, stubServices{})
+
+		resp, err := useCase.Execute(context.Background(), app.ImportSettingsRequest{Desktop: "gnome"})
+		require.NoError(t, err)
+		assert.False(t, resp.WallpaperImported)
+		assert.Len(t, resp.Notes, 1)
+
+		resp, err = useCase.Execute(context.Background(), app.ImportSettingsRequest{Desktop: "gnome", Force: true})
+		require.NoError(t, err)
+		assert.True(t, resp.WallpaperImported)
+	})
+
+	t.Run("notes a wallpaper that cannot be copied", func(t *testing.T) {
+		wallpapers := &fakeWallpapers{err: errors.New("wallpaper /home/ada/Pictures/lake.jpg no longer exists")}
+		useCase := newUseCase(stubReader{settings: gnomeSettings(t)}, &fakeVarStore{}, wallpapers, stubServices{})
+
+		resp, err := useCase.Execute(context.Background(), app.ImportSettingsRequest{Desktop: "gnome"})
+		require.NoError(t, err)
+		assert.False(t, resp.WallpaperImported)
+		assert.Equal(t, []string{"Wallpaper not imported: wallpaper /home/ada/Pictures/lake.jpg no longer exists"}, resp.Notes)
+	})
+
+	t.Run("rejects desktops it cannot read", func(t *testing.T) {
+		useCase := newUseCase(stubReader{}, &fakeVarStore{}, &fakeWallpapers{}, stubServices{})
+
+		_, err := useCase.Execute(context.Background(), app.ImportSettingsRequest{Desktop: "xfce"})
+		assert.ErrorIs(t, err, migration.ErrUnknownDesktop)
+
+		_, err = useCase.Execute(context.Background(), app.ImportSettingsRequest{Desktop: "kde"})
+		assert.ErrorIs(t, err, migration.ErrUnknownDesktop)
+	})
+
+	t.Run("fails when the settings cannot be read", func(t *testing.T) {
+		useCase := newUseCase(stubReader{err: errors.New("permission denied")}, &fakeVarStore{}, &fakeWallpapers{}, stubServices{})
+
+		_, err := useCase.Execute(context.Background(), app.ImportSettingsRequest{Desktop: "gnome"})
+		assert.ErrorContains(t, err, "permission denied")
+	})
+}
@@ -0,0 +1,112 @@
+package onboarding
+
+import (
+	"context"
+
+	"github.com/rebelopsio/gohan/internal/domain/onboarding"
+)
+
+// TourStore is the interface for keeping the first-login tour
+type TourStore interface {
+	Enable(tour onboarding.Tour) error
+	Disable() error
+	State() (onboarding.State, error)
+	Path() string
+}
+
+// StepDTO describes one key binding of the tour
+type StepDTO struct {
+	Keys   string
+	Action string
+}
+
+// OnboardingResponse describes the tour after a command
+type OnboardingResponse struct {
+	State      string
+	ScriptPath string
+	Steps      []StepDTO
+}
+
+// EnableOnboardingUseCase sets the tour up to run on the next login
+type EnableOnboardingUseCase struct {
+	store TourStore
+	tour  onboarding.Tour
+}
+
+// NewEnableOnboardingUseCase creates a new use case instance showing the
+// default tour
+func NewEnableOnboardingUseCase(store TourStore) *EnableOnboardingUseCase {
+	return &EnableOnboardingUseCase{store: store, tour: onboarding.DefaultTour()}
+}
+
+// Execute enables the tour, even if it ran before
+func (uc *EnableOnboardingUseCase) Execute(ctx context.Context) (*OnboardingResponse, error) {
+	if err := uc.store.Enable(uc.tour); err != nil {
+		return nil, err
+	}
+	return response(uc.store, onboarding.StatePending, uc.tour), nil
+}
+
+// ExecuteFirstRun enables the tour unless it was enabled, shown or disabled
+// before, and reports whether it did
+func (uc *EnableOnboardingUseCase) ExecuteFirstRun(ctx context.Context) (bool, error) {
+	state, err := uc.store.State()
+	if err != nil {
+		return false, err
+	}
+	if state != onboarding.StateNotSetUp {
+		return false, nil
+	}
+	if err := uc.store.Enable(uc.tour); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DisableOnboardingUseCase stops the tour from running
+type DisableOnboardingUseCase struct {
+	store TourStore
+}
+
+// NewDisableOnboardingUseCase creates a new use case instance
+func NewDisableOnboardingUseCase(store TourStore) *DisableOnboardingUseCase {
+	return &DisableOnboardingUseCase{store: store}
+}
+
+// Execute disables the tour; later installations do not enable it again
+func (uc *DisableOnboardingUseCase) Execute(ctx context.Context) (*OnboardingResponse, error) {
+	if err := uc.store.Disable(); err != nil {
+		return nil, err
+	}
+	return response(uc.store, onboarding.StateDone, onboarding.Tour{}), nil
+}
+
+// OnboardingStatusUseCase reports whether the tour runs on the next login
+type OnboardingStatusUseCase struct {
+	store TourStore
+}
+
+// NewOnboardingStatusUseCase creates a new use case instance
+func NewOnboardingStatusUseCase(store TourStore) *OnboardingStatusUseCase {
+	return &OnboardingStatusUseCase{store: store}
+}
+
+// Execute returns the tour's state and the bindings it shows
+func (uc *OnboardingStatusUseCase) Execute(ctx context.Context) (*OnboardingResponse, error) {
+	state, err := uc.store.State()
+	if err != nil {
+		return nil, err
+	}
+	return response(uc.store, state, onboarding.DefaultTour()), nil
+}
+
+func response(store TourStore, state onboarding.State, tour onboarding.Tour) *OnboardingResponse {
+	resp := &OnboardingResponse{
+		State:      state.String(),
+		ScriptPath: store.Path(),
+	}
+	for _, step := range tour.Steps() {
+		resp.Steps = append(resp.Steps, StepDTO{Keys: step.Keys(), Action: step.Action()})
+	}
+	return resp
+}
@@ -0,0 +1,105 @@
+package onboarding_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/onboarding"
+	domain "github.com/rebelopsio/gohan/internal/domain/onboarding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps the tour's state in memory
+type fakeStore struct {
+	state   domain.State
+	enabled int
+	err     error
+}
+
+func (s *fakeStore) Enable(tour domain.Tour) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.state = domain.StatePending
+	s.enabled++
+	return nil
+}
+
+func (s *fakeStore) Disable() error {
+	if s.err != nil {
+		return s.err
+	}
+	s.state = domain.StateDone
+	return nil
+}
+
+func (s *fakeStore) State() (domain.State, error) {
+	return s.state, s.err
+}
+
+func (s *fakeStore) Path() string {
+	return "/home/alice/.gohan/onboarding-tour.sh"
+}
+
+func TestEnableOnboardingUseCase(t *testing.T) {
+	t.Run("enables the tour and lists its steps", func(t *testing.T) {
+		store := &fakeStore{state: domain.StateDone}
+
+		response, err := onboarding.NewEnableOnboardingUseCase(store).Execute(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "pending", response.State)
+		assert.Equal(t, store.Path(), response.ScriptPath)
+		assert.Len(t, response.Steps, domain.DefaultTour().Len())
+		assert.Equal(t, 1, store.enabled)
+	})
+
+	t.Run("first run enables a tour never set up", func(t *testing.T) {
+		store := &fakeStore{state: domain.StateNotSetUp}
+
+		enabled, err := onboarding.NewEnableOnboardingUseCase(store).ExecuteFirstRun(context.Background())
+		require.NoError(t, err)
+
+		assert.True(t, enabled)
+		assert.Equal(t, domain.StatePending, store.state)
+	})
+
+	t.Run("first run leaves a shown or disabled tour alone", func(t *testing.T) {
+		store := &fakeStore{state: domain.StateDone}
+
+		enabled, err := onboarding.NewEnableOnboardingUseCase(store).ExecuteFirstRun(context.Background())
+		require.NoError(t, err)
+
+		assert.False(t, enabled)
+		assert.Zero(t, store.enabled)
+	})
+
+	t.Run("returns store errors", func(t *testing.T) {
+		store := &fakeStore{err: errors.New("read-only file system")}
+
+		_, err := onboarding.NewEnableOnboardingUseCase(store).ExecuteFirstRun(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestDisableOnboardingUseCase(t *testing.T) {
+	store := &fakeStore{state: domain.StatePending}
+
+	response, err := onboarding.NewDisableOnboardingUseCase(store).Execute(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "done", response.State)
+	assert.Empty(t, response.Steps)
+}
+
+func TestOnboardingStatusUseCase(t *testing.T) {
+	store := &fakeStore{state: domain.StateNotSetUp}
+
+	response, err := onboarding.NewOnboardingStatusUseCase(store).Execute(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "not-set-up", response.State)
+	assert.NotEmpty(t, response.Steps)
+}
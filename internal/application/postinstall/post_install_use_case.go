@@ -13,6 +13,7 @@ type PostInstallRequest struct {
 	ShellTheme      string
 	SetupAudio      bool
 	SetupNetwork    bool
+	SetupZram       bool
 	Services        []string
 	WallpaperDir    string
 	ShowProgress    bool
@@ -26,6 +27,7 @@ type PostInstallResponse struct {
 	ShellConfigured           bool
 	AudioConfigured           bool
 	NetworkManagerConfigured  bool
+	ZramConfigured            bool
 	ServicesEnabled           []string
 	ServicesFailed            []string
 	WallpaperCacheGenerated   bool
@@ -53,6 +55,7 @@ type Installers struct {
 	AudioInstaller          postinstall.ComponentInstaller
 	NetworkInstaller        postinstall.ComponentInstaller
 	WallpaperGenerator      postinstall.ComponentInstaller
+	ZramInstaller           postinstall.ComponentInstaller
 }
 
 // PostInstallUseCase coordinates post-installation setup
@@ -142,6 +145,13 @@ func (uc *PostInstallUseCase) getInstallers(req PostInstallRequest) []postinstal
 		}
 	}
 
+	// Compressed swap
+	if req.SetupZram {
+		if uc.installers.ZramInstaller != nil {
+			installers = append(installers, uc.installers.ZramInstaller)
+		}
+	}
+
 	// Wallpaper cache
 	if req.WallpaperDir != "" {
 		if uc.installers.WallpaperGenerator != nil {
@@ -162,6 +172,7 @@ func (uc *PostInstallUseCase) buildResponse(session *postinstall.SetupSession) *
 		ShellConfigured:          false,
 		AudioConfigured:          false,
 		NetworkManagerConfigured: false,
+		ZramConfigured:           false,
 		ServicesEnabled:          []string{},
 		ServicesFailed:           []string{},
 		WallpaperCacheGenerated:  false,
@@ -187,6 +198,8 @@ func (uc *PostInstallUseCase) buildResponse(session *postinstall.SetupSession) *
 				response.NetworkManagerConfigured = true
 			case postinstall.ComponentWallpaper:
 				response.WallpaperCacheGenerated = true
+			case postinstall.ComponentZram:
+				response.ZramConfigured = true
 			}
 		}
 
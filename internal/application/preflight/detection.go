@@ -0,0 +1,110 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// detectingValidator runs a detector when validated, so each check reports
+// progress as it runs rather than after every detector has finished.
+// Detector errors are recorded as results instead of dropping the check.
+type detectingValidator struct {
+	name        string
+	requirement preflight.RequirementName
+	detect      func(ctx context.Context) (preflight.Validator, error)
+	onStart     StartCallback
+}
+
+func (v *detectingValidator) Name() string {
+	return v.name
+}
+
+func (v *detectingValidator) RequirementName() preflight.RequirementName {
+	return v.requirement
+}
+
+func (v *detectingValidator) Validate(ctx context.Context) preflight.ValidationResult {
+	if v.onStart != nil {
+		v.onStart(v.name, v.requirement)
+	}
+
+	validator, err := v.detect(ctx)
+	if err != nil {
+		return detectionFailure(v.requirement, err)
+	}
+	return validator.Validate(ctx)
+}
+
+// detectionFailure describes a requirement that could not be checked. Only
+// the checks installation cannot do without block it.
+func detectionFailure(requirement preflight.RequirementName, err error) preflight.ValidationResult {
+	fail := func(severity preflight.Severity, expected, message, reason string, steps []string) preflight.ValidationResult {
+		return preflight.NewValidationResult(requirement, preflight.StatusFail, severity, nil, expected,
+			preflight.NewUserGuidance(message, reason, steps, ""))
+	}
+	warn := func(severity preflight.Severity, expected, message, reason string, steps []string) preflight.ValidationResult {
+		return preflight.NewValidationResult(requirement, preflight.StatusWarning, severity, nil, expected,
+			preflight.NewUserGuidance(message, reason, steps, ""))
+	}
+
+	switch requirement {
+	case preflight.RequirementDebianVersion:
+		return fail(preflight.SeverityCritical, "Debian Sid or Trixie",
+			"Unable to detect Debian version",
+			fmt.Sprintf("Failed to read or parse /etc/os-release: %v", err),
+			[]string{
+				"Ensure /etc/os-release exists and contains VERSION_CODENAME",
+				"Verify you are running Debian Sid or Trixie",
+			})
+	case preflight.RequirementGPUSupport:
+		return warn(preflight.SeverityMedium, "GPU with open-source drivers",
+			"No GPU detected. Hyprland may run with reduced performance.",
+			"lspci did not detect any VGA or 3D controllers",
+			[]string{
+				"Hyprland can run on integrated graphics but performance will be limited",
+				"Check if GPU is properly seated in PCIe slot",
+			})
+	case preflight.RequirementDiskSpace:
+		return fail(preflight.SeverityHigh, "10 GB available",
+			"Unable to check disk space",
+			fmt.Sprintf("Failed to query filesystem statistics: %v", err),
+			[]string{
+				"Verify filesystem is mounted correctly",
+				"Ensure at least 10 GB of free space across /, /var, /boot and your home directory",
+			})
+	case preflight.RequirementInternet:
+		return fail(preflight.SeverityHigh, "Internet connection required",
+			"Unable to test internet connectivity",
+			fmt.Sprintf("Network connectivity test failed: %v", err),
+			[]string{
+				"Check network configuration with 'ip addr' and 'ip route'",
+				"Verify DNS resolution with 'ping -c 3 debian.org'",
+				"Check firewall settings",
+			})
+	case preflight.RequirementSourceRepos:
+		return warn(preflight.SeverityLow, "deb-src repositories enabled",
+			"Unable to check source repositories",
+			"Could not read /etc/apt/sources.list or sources.list.d/",
+			[]string{"Manually verify /etc/apt/sources.list contains deb-src lines"})
+	case preflight.RequirementSystemResources:
+		return warn(preflight.SeverityLow, fmt.Sprintf("%d GB RAM and fast storage", preflight.LiteModeThresholdGB),
+			"Unable to check memory and storage",
+			"Could not read /proc/meminfo",
+			[]string{
+				"The standard desktop will be installed",
+				"On low-end hardware, use: gohan install --rendering lite",
+			})
+	case preflight.RequirementThroughput:
+		return warn(preflight.SeverityLow, "",
+			"Unable to measure throughput",
+			"Installation time estimates will be less accurate",
+			nil)
+	default:
+		return warn(preflight.SeverityLow, "",
+			fmt.Sprintf("Unable to check %s", requirement),
+			err.Error(),
+			nil)
+	}
+}
@@ -0,0 +1,111 @@
+package preflight
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// Guidance explains how to resolve a failed check
+type Guidance struct {
+	Message          string
+	Reason           string
+	Steps            []string // Fix steps, in order
+	DocumentationURL string
+}
+
+// FixStep is one step of guidance split into what it does and the command
+// that does it
+type FixStep struct {
+	Description string
+	Command     string // Empty when the step is not a command
+}
+
+// commandWords are the programs fix steps run; a step of the form
+// "Description: command" is only split when the command starts with one
+var commandWords = map[string]bool{
+	"apt":        true,
+	"cat":        true,
+	"df":         true,
+	"du":         true,
+	"free":       true,
+	"gohan":      true,
+	"hyprctl":    true,
+	"journalctl": true,
+	"lspci":      true,
+	"ping":       true,
+	"printf":     true,
+	"sudo":       true,
+	"systemctl":  true,
+}
+
+// IsEmpty reports whether there is no guidance to show
+func (g Guidance) IsEmpty() bool {
+	return g.Message == "" && len(g.Steps) == 0
+}
+
+// FixSteps returns the steps with their commands separated out
+func (g Guidance) FixSteps() []FixStep {
+	steps := make([]FixStep, 0, len(g.Steps))
+	for _, step := range g.Steps {
+		steps = append(steps, ParseFixStep(step))
+	}
+	return steps
+}
+
+// ParseFixStep splits "Update package lists: sudo apt update" into its
+// description and command
+func ParseFixStep(step string) FixStep {
+	description, command, found := strings.Cut(step, ": ")
+	if !found {
+		return FixStep{Description: step}
+	}
+
+	command = strings.TrimSpace(command)
+	word, _, _ := strings.Cut(command, " ")
+	if !commandWords[word] {
+		return FixStep{Description: step}
+	}
+	return FixStep{Description: strings.TrimSpace(description), Command: command}
+}
+
+// Render formats the guidance as numbered fix steps, each command on a line
+// of its own so it can be copied into a shell. Every line starts with indent.
+func (g Guidance) Render(indent string) string {
+	var b strings.Builder
+
+	if g.Message != "" {
+		fmt.Fprintf(&b, "%s%s\n", indent, g.Message)
+	}
+	if g.Reason != "" {
+		fmt.Fprintf(&b, "%sWhy: %s\n", indent, g.Reason)
+	}
+
+	if len(g.Steps) > 0 {
+		fmt.Fprintf(&b, "%sHow to fix:\n", indent)
+		for i, step := range g.FixSteps() {
+			number := fmt.Sprintf("%d. ", i+1)
+			fmt.Fprintf(&b, "%s  %s%s\n", indent, number, step.Description)
+			if step.Command != "" {
+				fmt.Fprintf(&b, "%s  %s    %s\n", indent, strings.Repeat(" ", len(number)), step.Command)
+			}
+		}
+	}
+
+	if g.DocumentationURL != "" {
+		fmt.Fprintf(&b, "%sDocs: %s\n", indent, g.DocumentationURL)
+	}
+
+	return b.String()
+}
+
+// NewGuidance converts domain guidance for display
+func NewGuidance(guidance preflight.UserGuidance) Guidance {
+	return Guidance{
+		Message:          guidance.Message(),
+		Reason:           guidance.Reason(),
+		Steps:            guidance.ActionableSteps(),
+		DocumentationURL: guidance.DocumentationURL(),
+	}
+}
@@ -0,0 +1,66 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/application/preflight"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFixStep(t *testing.T) {
+	tests := []struct {
+		step string
+		want preflight.FixStep
+	}{
+		{
+			step: "Update package lists: sudo apt update",
+			want: preflight.FixStep{Description: "Update package lists", Command: "sudo apt update"},
+		},
+		{
+			step: "Enable non-free repositories: gohan repo enable-nonfree",
+			want: preflight.FixStep{Description: "Enable non-free repositories", Command: "gohan repo enable-nonfree"},
+		},
+		{
+			step: "See documentation: https://gohan.sh/docs/installation",
+			want: preflight.FixStep{Description: "See documentation: https://gohan.sh/docs/installation"},
+		},
+		{
+			step: "Or keep existing configs: preview with 'gohan config deploy --dry-run'",
+			want: preflight.FixStep{Description: "Or keep existing configs: preview with 'gohan config deploy --dry-run'"},
+		},
+		{
+			step: "Install on Debian Sid or Trixie instead",
+			want: preflight.FixStep{Description: "Install on Debian Sid or Trixie instead"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.step, func(t *testing.T) {
+			assert.Equal(t, tt.want, preflight.ParseFixStep(tt.step))
+		})
+	}
+}
+
+func TestGuidance_Render(t *testing.T) {
+	guidance := preflight.Guidance{
+		Message: "NVIDIA GPU detected - proprietary drivers required",
+		Reason:  "NVIDIA GPUs need the nvidia-driver package",
+		Steps: []string{
+			"Update package lists: sudo apt update",
+			"Reboot once the driver is installed",
+		},
+		DocumentationURL: "https://gohan.sh/docs/nvidia-setup",
+	}
+
+	want := "  NVIDIA GPU detected - proprietary drivers required\n" +
+		"  Why: NVIDIA GPUs need the nvidia-driver package\n" +
+		"  How to fix:\n" +
+		"    1. Update package lists\n" +
+		"           sudo apt update\n" +
+		"    2. Reboot once the driver is installed\n" +
+		"  Docs: https://gohan.sh/docs/nvidia-setup\n"
+	assert.Equal(t, want, guidance.Render("  "))
+
+	assert.True(t, preflight.Guidance{}.IsEmpty())
+	assert.Empty(t, preflight.Guidance{}.Render("  "))
+}
@@ -0,0 +1,39 @@
+package preflight
+
+import (
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// InstallationScope describes what an installation puts on the system, so
+// checks can adapt to the selected components
+type InstallationScope struct {
+	// InstalledBytes is the installed size of the selected components;
+	// 0 when unknown
+	InstalledBytes uint64
+
+	// DownloadBytes is the size of the packages to download
+	DownloadBytes uint64
+
+	// NVIDIADriver is set when the NVIDIA driver component is selected
+	NVIDIADriver bool
+}
+
+// ScopeForInstallation derives the scope of checks from an installation
+// configuration
+func ScopeForInstallation(config installation.InstallationConfiguration) InstallationScope {
+	scope := InstallationScope{
+		InstalledBytes: config.TotalEstimatedSizeBytes(),
+		DownloadBytes:  config.EstimatedDownloadBytes(),
+	}
+
+	for _, component := range config.Components() {
+		if component.Component() == installation.ComponentNVIDIADriver {
+			scope.NVIDIADriver = true
+		}
+	}
+	if gpu := config.GPUSupport(); gpu != nil && gpu.DriverComponent() == installation.ComponentNVIDIADriver {
+		scope.NVIDIADriver = true
+	}
+
+	return scope
+}
@@ -0,0 +1,137 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// PreflightHistoryRequest contains parameters for listing past preflight runs
+type PreflightHistoryRequest struct {
+	// Limit keeps only the most recent runs; 0 lists all
+	Limit int
+}
+
+// PreflightHistoryResponse lists past preflight runs, oldest first, and how
+// they compare
+type PreflightHistoryResponse struct {
+	Runs []PreflightRun
+
+	// Trends describes measurements that moved steadily across the runs,
+	// e.g. free disk space shrinking
+	Trends []string
+
+	// Changes lists requirements whose status differs between the two most
+	// recent runs
+	Changes []string
+}
+
+// PreflightRun summarizes one persisted preflight session
+type PreflightRun struct {
+	SessionID    string
+	StartedAt    time.Time
+	Outcome      string
+	PassedChecks int
+	Warnings     int
+	Blockers     int
+	Statuses     map[string]string // Requirement to status
+	Policy       string            // Effective severity policy
+}
+
+// PreflightHistoryUseCase compares persisted preflight sessions over time
+type PreflightHistoryUseCase struct {
+	repo preflight.ValidationSessionRepository
+}
+
+// NewPreflightHistoryUseCase creates a new use case instance
+func NewPreflightHistoryUseCase(repo preflight.ValidationSessionRepository) *PreflightHistoryUseCase {
+	return &PreflightHistoryUseCase{repo: repo}
+}
+
+// Execute lists past runs and the trends between them
+func (uc *PreflightHistoryUseCase) Execute(ctx context.Context, req PreflightHistoryRequest) (*PreflightHistoryResponse, error) {
+	sessions, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preflight sessions: %w", err)
+	}
+
+	if req.Limit > 0 && len(sessions) > req.Limit {
+		sessions = sessions[len(sessions)-req.Limit:]
+	}
+
+	response := &PreflightHistoryResponse{
+		Runs: make([]PreflightRun, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		response.Runs = append(response.Runs, summarizeSession(session))
+	}
+
+	for _, trend := range preflight.MeasurementTrends(sessions) {
+		response.Trends = append(response.Trends, describeTrend(trend))
+	}
+
+	if n := len(response.Runs); n >= 2 {
+		response.Changes = statusChanges(response.Runs[n-2], response.Runs[n-1])
+	}
+
+	return response, nil
+}
+
+func summarizeSession(session *preflight.ValidationSession) PreflightRun {
+	run := PreflightRun{
+		SessionID: session.ID(),
+		StartedAt: session.StartedAt(),
+		Outcome:   string(session.OverallResult()),
+		Blockers:  len(session.BlockingResults()),
+		Warnings:  len(session.WarningResults()),
+		Statuses:  make(map[string]string),
+		Policy:    session.SeverityPolicy().String(),
+	}
+	for _, result := range session.Results() {
+		if result.IsPassing() {
+			run.PassedChecks++
+		}
+		run.Statuses[string(result.RequirementName())] = string(result.Status())
+	}
+	return run
+}
+
+// describeTrend renders a trend in the unit of its requirement
+func describeTrend(trend preflight.MeasurementTrend) string {
+	label, format := measurementLabel(trend.Requirement)
+	return fmt.Sprintf("%s has been %s: %s → %s over %d runs",
+		label, trend.Direction, format(trend.First), format(trend.Last), trend.Runs)
+}
+
+func measurementLabel(requirement preflight.RequirementName) (string, func(float64) string) {
+	gigabytes := func(v float64) string { return fmt.Sprintf("%.1f GB", v/float64(preflight.GB)) }
+	switch requirement {
+	case preflight.RequirementDiskSpace:
+		return "Free disk space", gigabytes
+	case preflight.RequirementSystemResources:
+		return "Memory", gigabytes
+	case preflight.RequirementThroughput:
+		return "Disk write speed", func(v float64) string { return fmt.Sprintf("%.0f MB/s", v/float64(preflight.MB)) }
+	default:
+		return string(requirement), func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	}
+}
+
+// statusChanges lists requirements whose status changed between two runs
+func statusChanges(previous, latest PreflightRun) []string {
+	var changes []string
+	for _, requirement := range slices.Sorted(maps.Keys(latest.Statuses)) {
+		before, ok := previous.Statuses[requirement]
+		after := latest.Statuses[requirement]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s: new check, %s", requirement, after))
+		} else if before != after {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", requirement, before, after))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,79 @@
+package preflight_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/preflight"
+	domainPreflight "github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSessionRepository returns fixed sessions, oldest first
+type stubSessionRepository struct {
+	domainPreflight.ValidationSessionRepository
+	sessions []*domainPreflight.ValidationSession
+}
+
+func (r *stubSessionRepository) List(ctx context.Context) ([]*domainPreflight.ValidationSession, error) {
+	return r.sessions, nil
+}
+
+func TestPreflightHistoryUseCase_Execute(t *testing.T) {
+	base := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	run := func(id string, offset time.Duration, availableGB uint64, internet domainPreflight.ValidationStatus) *domainPreflight.ValidationSession {
+		space, err := domainPreflight.NewDiskSpace(availableGB*domainPreflight.GB, 500*domainPreflight.GB, "/")
+		require.NoError(t, err)
+
+		results := []domainPreflight.ValidationResult{
+			domainPreflight.NewValidationResult(
+				domainPreflight.RequirementDiskSpace,
+				domainPreflight.StatusPass,
+				domainPreflight.SeverityLow,
+				[]domainPreflight.MountSpace{domainPreflight.NewMountSpace(space, nil)},
+				nil,
+				domainPreflight.NewUserGuidance("", "", nil, ""),
+			),
+			domainPreflight.NewValidationResult(
+				domainPreflight.RequirementInternet,
+				internet,
+				domainPreflight.SeverityMedium,
+				nil,
+				nil,
+				domainPreflight.NewUserGuidance("", "", nil, ""),
+			),
+		}
+		session, err := domainPreflight.ReconstructValidationSession(id, base.Add(offset), base.Add(offset), domainPreflight.SeverityPolicy{}, results)
+		require.NoError(t, err)
+		return session
+	}
+
+	repo := &stubSessionRepository{sessions: []*domainPreflight.ValidationSession{
+		run("first", 0, 60, domainPreflight.StatusPass),
+		run("second", time.Hour, 40, domainPreflight.StatusPass),
+		run("third", 2*time.Hour, 20, domainPreflight.StatusWarning),
+	}}
+
+	t.Run("compares runs and reports shrinking disk space", func(t *testing.T) {
+		resp, err := preflight.NewPreflightHistoryUseCase(repo).Execute(context.Background(), preflight.PreflightHistoryRequest{})
+		require.NoError(t, err)
+
+		require.Len(t, resp.Runs, 3)
+		assert.Equal(t, "first", resp.Runs[0].SessionID)
+		assert.Equal(t, 1, resp.Runs[2].Warnings)
+		assert.Equal(t, []string{"Free disk space has been shrinking: 60.0 GB → 20.0 GB over 3 runs"}, resp.Trends)
+		assert.Equal(t, []string{"internet_connectivity: pass → warning"}, resp.Changes)
+	})
+
+	t.Run("limit keeps the most recent runs", func(t *testing.T) {
+		resp, err := preflight.NewPreflightHistoryUseCase(repo).Execute(context.Background(), preflight.PreflightHistoryRequest{Limit: 1})
+		require.NoError(t, err)
+
+		require.Len(t, resp.Runs, 1)
+		assert.Equal(t, "third", resp.Runs[0].SessionID)
+		assert.Empty(t, resp.Trends)
+		assert.Empty(t, resp.Changes)
+	})
+}
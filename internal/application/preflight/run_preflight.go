@@ -3,6 +3,7 @@ package preflight
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
 )
@@ -11,6 +12,18 @@ import (
 type RunPreflightRequest struct {
 	// ShowProgress enables progress callbacks
 	ShowProgress bool
+
+	// HomeDir is where configuration files are deployed; when set, its
+	// mount point is checked for room as well
+	HomeDir string
+
+	// Scope adapts the checks to the components being installed; nil
+	// checks for a full installation
+	Scope *InstallationScope
+
+	// Only limits the run to these requirements, such as the ones that
+	// failed last time; empty runs every check
+	Only []preflight.RequirementName
 }
 
 // RunPreflightResponse contains the result of preflight checks
@@ -23,8 +36,15 @@ type RunPreflightResponse struct {
 	PassedChecks   int
 	WarningChecks  int
 	FailedChecks   int
+	IgnoredChecks  int
 	Results        []CheckResult
 	OverallMessage string
+
+	// SeverityPolicy maps requirements to the overrides applied to them
+	SeverityPolicy map[string]string
+
+	// RecommendLiteMode is set when low memory or slow storage was detected
+	RecommendLiteMode bool
 }
 
 // CheckResult represents a single check result for display
@@ -33,13 +53,23 @@ type CheckResult struct {
 	Passed         bool
 	Blocking       bool
 	Message        string
-	Guidance       string
+	Detected       string   // What was found on the system, e.g. GPU driver and VRAM
+	Guidance       Guidance // How to resolve failures and warnings
 	RequirementMet bool
+	Severity       string
+	Override       string // Severity policy override applied, if any
+	Ignored        bool   // Problem ignored by the severity policy
 }
 
 // ProgressCallback is called for each validation step
 type ProgressCallback func(validatorName string, result CheckResult)
 
+// StartCallback is called before a requirement is checked
+type StartCallback func(validatorName string, requirement preflight.RequirementName)
+
+// ResultCallback is called with each result as the session recorded it
+type ResultCallback func(validatorName string, result preflight.ValidationResult)
+
 // Detectors aggregates all system detectors
 type Detectors struct {
 	DebianDetector          preflight.DebianDetector
@@ -47,11 +77,26 @@ type Detectors struct {
 	DiskSpaceDetector       preflight.DiskSpaceDetector
 	ConnectivityChecker     preflight.ConnectivityChecker
 	SourceRepositoryChecker preflight.SourceRepositoryChecker
+	ResourceDetector        preflight.ResourceDetector              // Optional
+	PowerDaemonDetector     preflight.PowerDaemonDetector           // Optional
+	SessionDetector         preflight.SessionDetector               // Optional
+	SourcesSanityChecker    preflight.SourcesSanityChecker          // Optional
+	ThroughputProbe         preflight.ThroughputProbe               // Optional
+	WritabilityDetector     preflight.FilesystemWritabilityDetector // Optional
 }
 
 // RunPreflightUseCase coordinates all preflight validations
 type RunPreflightUseCase struct {
 	detectors Detectors
+	policy    preflight.SeverityPolicy
+	repo      preflight.ValidationSessionRepository // Optional
+	reclaimer SpaceReclaimer                        // Optional
+}
+
+// SpaceReclaimer frees disk space, such as by cleaning download caches,
+// and returns the bytes freed
+type SpaceReclaimer interface {
+	ReclaimSpace(ctx context.Context) (uint64, error)
 }
 
 // NewRunPreflightUseCase creates a new use case instance
@@ -61,25 +106,35 @@ func NewRunPreflightUseCase(detectors Detectors) *RunPreflightUseCase {
 	}
 }
 
+// WithSeverityPolicy returns a copy of the use case that applies per-requirement
+// severity overrides to the results
+func (uc *RunPreflightUseCase) WithSeverityPolicy(policy preflight.SeverityPolicy) *RunPreflightUseCase {
+	copied := *uc
+	copied.policy = policy
+	return &copied
+}
+
+// WithRepository returns a copy of the use case that persists every
+// validation session, so runs can be compared over time
+func (uc *RunPreflightUseCase) WithRepository(repo preflight.ValidationSessionRepository) *RunPreflightUseCase {
+	copied := *uc
+	copied.repo = repo
+	return &copied
+}
+
+// WithSpaceReclaimer returns a copy of the use case that frees space before
+// the disk space check when a partition is close to its requirement
+func (uc *RunPreflightUseCase) WithSpaceReclaimer(reclaimer SpaceReclaimer) *RunPreflightUseCase {
+	copied := *uc
+	copied.reclaimer = reclaimer
+	return &copied
+}
+
 // Execute runs all preflight checks
 func (uc *RunPreflightUseCase) Execute(ctx context.Context, req RunPreflightRequest) (*RunPreflightResponse, error) {
-	// Create validators
-	validators, err := uc.createValidators(ctx)
+	session, err := uc.RunSession(ctx, req, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create validators: %w", err)
-	}
-
-	// Create orchestrator
-	orchestrator := preflight.NewValidationOrchestrator(validators)
-
-	// Execute validations
-	var session *preflight.ValidationSession
-	if req.ShowProgress {
-		session = orchestrator.ExecuteValidationsWithProgress(ctx, func(name string, result preflight.ValidationResult) {
-			// Progress callback handled by CLI layer
-		})
-	} else {
-		session = orchestrator.ExecuteValidations(ctx)
+		return nil, err
 	}
 
 	// Convert to response
@@ -92,63 +147,190 @@ func (uc *RunPreflightUseCase) ExecuteWithProgress(
 	req RunPreflightRequest,
 	progressFn ProgressCallback,
 ) (*RunPreflightResponse, error) {
-	// Create validators
-	validators, err := uc.createValidators(ctx)
+	var onResult ResultCallback
+	if progressFn != nil {
+		onResult = func(name string, result preflight.ValidationResult) {
+			progressFn(name, uc.convertResult(result))
+		}
+	}
+
+	session, err := uc.RunSession(ctx, req, nil, onResult)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create validators: %w", err)
+		return nil, err
 	}
 
-	// Create orchestrator
-	orchestrator := preflight.NewValidationOrchestrator(validators)
+	return uc.buildResponse(session), nil
+}
 
-	// Execute with progress
-	session := orchestrator.ExecuteValidationsWithProgress(ctx, func(name string, result preflight.ValidationResult) {
-		if progressFn != nil {
-			progressFn(name, uc.convertResult(result))
-		}
-	})
+// RunSession runs all checks and returns the validation session, for
+// callers that keep working with it, such as the TUI and installation.
+// onStart and onResult may be nil.
+func (uc *RunPreflightUseCase) RunSession(
+	ctx context.Context,
+	req RunPreflightRequest,
+	onStart StartCallback,
+	onResult ResultCallback,
+) (*preflight.ValidationSession, error) {
+	orchestrator := preflight.NewValidationOrchestrator(uc.createValidators(req, onStart)).WithSeverityPolicy(uc.policy)
+	session := orchestrator.ExecuteValidationsWithProgress(ctx, onResult)
+
+	if err := uc.save(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
 
-	return uc.buildResponse(session), nil
+// save persists the session when a repository is configured
+func (uc *RunPreflightUseCase) save(ctx context.Context, session *preflight.ValidationSession) error {
+	if uc.repo == nil {
+		return nil
+	}
+	if err := uc.repo.Save(ctx, session); err != nil {
+		return fmt.Errorf("failed to save preflight session: %w", err)
+	}
+	return nil
+}
+
+// CheckCount returns how many checks a run with req performs
+func (uc *RunPreflightUseCase) CheckCount(req RunPreflightRequest) int {
+	return len(uc.createValidators(req, nil))
 }
 
-func (uc *RunPreflightUseCase) createValidators(ctx context.Context) ([]preflight.Validator, error) {
+// createValidators lists the checks to run. Each detector runs when its
+// check does; optional detectors that are not configured are skipped.
+func (uc *RunPreflightUseCase) createValidators(req RunPreflightRequest, onStart StartCallback) []preflight.Validator {
+	d := uc.detectors
 	validators := make([]preflight.Validator, 0)
 
-	// Debian Version Validator
-	debianVersion, err := uc.detectors.DebianDetector.DetectVersion(ctx)
-	if err == nil {
-		validators = append(validators, NewDebianVersionValidator(debianVersion))
+	only := make(map[preflight.RequirementName]bool, len(req.Only))
+	for _, requirement := range req.Only {
+		only[requirement] = true
 	}
 
-	// GPU Validator
-	gpu, err := uc.detectors.GPUDetector.PrimaryGPU(ctx)
-	if err == nil {
-		validators = append(validators, NewGPUValidator(gpu))
+	add := func(name string, requirement preflight.RequirementName, detect func(ctx context.Context) (preflight.Validator, error)) {
+		if len(only) > 0 && !only[requirement] {
+			return
+		}
+		validators = append(validators, &detectingValidator{
+			name:        name,
+			requirement: requirement,
+			detect:      detect,
+			onStart:     onStart,
+		})
 	}
 
-	// Disk Space Validator
-	diskSpace, err := uc.detectors.DiskSpaceDetector.DetectAvailableSpace(ctx, "/")
-	if err == nil {
-		validators = append(validators, NewDiskSpaceValidator(diskSpace))
+	// Debian Version Validator
+	add("Debian Version", preflight.RequirementDebianVersion, func(ctx context.Context) (preflight.Validator, error) {
+		version, err := d.DebianDetector.DetectVersion(ctx)
+		return NewDebianVersionValidator(version), err
+	})
+
+	// GPU Validator
+	add("GPU Detection", preflight.RequirementGPUSupport, func(ctx context.Context) (preflight.Validator, error) {
+		gpu, err := d.GPUDetector.PrimaryGPU(ctx)
+		return &gpuValidator{gpu: gpu, skipNVIDIADriver: req.Scope != nil && !req.Scope.NVIDIADriver}, err
+	})
+
+	// Disk Space Validator, per mount point the installation writes to
+	consumers := preflight.DefaultDiskConsumers(req.HomeDir)
+	if req.Scope != nil {
+		consumers = preflight.InstallationDiskConsumers(req.HomeDir, req.Scope.InstalledBytes, req.Scope.DownloadBytes)
 	}
+	add("Disk Space", preflight.RequirementDiskSpace, func(ctx context.Context) (preflight.Validator, error) {
+		mounts, err := preflight.DetectMountSpaces(ctx, d.DiskSpaceDetector, consumers)
+		if err == nil && uc.reclaimer != nil && len(preflight.NearlyFullMounts(mounts)) > 0 {
+			// Measure again only if cleaning freed something
+			if freed, reclaimErr := uc.reclaimer.ReclaimSpace(ctx); reclaimErr == nil && freed > 0 {
+				mounts, err = preflight.DetectMountSpaces(ctx, d.DiskSpaceDetector, consumers)
+			}
+		}
+		return NewDiskSpaceValidator(mounts), err
+	})
 
 	// Connectivity Validator
-	connectivity, err := uc.detectors.ConnectivityChecker.CheckInternetConnectivity(ctx)
-	if err == nil {
-		validators = append(validators, NewConnectivityValidator(connectivity))
-	}
+	add("Internet Connectivity", preflight.RequirementInternet, func(ctx context.Context) (preflight.Validator, error) {
+		connectivity, err := d.ConnectivityChecker.CheckInternetConnectivity(ctx)
+		return NewConnectivityValidator(connectivity), err
+	})
 
 	// Source Repository Validator
-	sourceRepos, err := uc.detectors.SourceRepositoryChecker.CheckSourceRepositories(ctx)
-	if err == nil {
-		validators = append(validators, NewSourceRepositoryValidator(sourceRepos))
+	add("Source Repositories", preflight.RequirementSourceRepos, func(ctx context.Context) (preflight.Validator, error) {
+		status, err := d.SourceRepositoryChecker.CheckSourceRepositories(ctx)
+		return NewSourceRepositoryValidator(status), err
+	})
+
+	// System Resources Validator
+	if d.ResourceDetector != nil {
+		add("System Resources", preflight.RequirementSystemResources, func(ctx context.Context) (preflight.Validator, error) {
+			resources, err := d.ResourceDetector.DetectResources(ctx)
+			return NewSystemResourcesValidator(resources), err
+		})
+	}
+
+	// Power Daemon Validator
+	if d.PowerDaemonDetector != nil {
+		add("Power Management Daemons", preflight.RequirementPowerDaemons, func(ctx context.Context) (preflight.Validator, error) {
+			daemons, err := d.PowerDaemonDetector.DetectPowerDaemons(ctx)
+			return NewPowerDaemonValidator(daemons), err
+		})
+	}
+
+	// Graphical Session Validator
+	if d.SessionDetector != nil {
+		add("Graphical Session", preflight.RequirementSession, func(ctx context.Context) (preflight.Validator, error) {
+			env, err := d.SessionDetector.DetectSession(ctx)
+			return NewSessionValidator(env), err
+		})
+	}
+
+	// Sources Sanity Validator
+	if d.SourcesSanityChecker != nil {
+		add("APT Sources Sanity", preflight.RequirementSourcesSanity, func(ctx context.Context) (preflight.Validator, error) {
+			sanity, err := d.SourcesSanityChecker.CheckSourcesSanity(ctx)
+			return NewSourcesSanityValidator(sanity), err
+		})
+	}
+
+	// Throughput Validator
+	if d.ThroughputProbe != nil {
+		add("Disk and Network Throughput", preflight.RequirementThroughput, func(ctx context.Context) (preflight.Validator, error) {
+			throughput, err := d.ThroughputProbe.ProbeThroughput(ctx)
+			return NewThroughputValidator(throughput), err
+		})
+	}
+
+	// Writable Filesystem Validator
+	if d.WritabilityDetector != nil {
+		add("Writable Filesystem", preflight.RequirementWritableRoot, func(ctx context.Context) (preflight.Validator, error) {
+			writability, err := d.WritabilityDetector.DetectWritability(ctx, preflight.PackageManagerPaths())
+			return NewWritableFilesystemValidator(writability), err
+		})
 	}
 
-	if len(validators) == 0 {
-		return nil, fmt.Errorf("no validators could be created")
+	return validators
+}
+
+// BlockingResults returns the checks that block installation
+func (r *RunPreflightResponse) BlockingResults() []CheckResult {
+	var blockers []CheckResult
+	for _, result := range r.Results {
+		if !result.Passed && result.Blocking && !result.Ignored {
+			blockers = append(blockers, result)
+		}
 	}
+	return blockers
+}
 
-	return validators, nil
+// WarningResults returns the checks that failed without blocking
+// installation
+func (r *RunPreflightResponse) WarningResults() []CheckResult {
+	var warnings []CheckResult
+	for _, result := range r.Results {
+		if !result.Passed && !result.Blocking && !result.Ignored {
+			warnings = append(warnings, result)
+		}
+	}
+	return warnings
 }
 
 func (uc *RunPreflightUseCase) buildResponse(session *preflight.ValidationSession) *RunPreflightResponse {
@@ -164,6 +346,7 @@ func (uc *RunPreflightUseCase) buildResponse(session *preflight.ValidationSessio
 	passedCount := 0
 	warningCount := 0
 	failedCount := 0
+	ignoredCount := 0
 
 	for _, result := range results {
 		checkResult := uc.convertResult(result)
@@ -171,6 +354,8 @@ func (uc *RunPreflightUseCase) buildResponse(session *preflight.ValidationSessio
 
 		if checkResult.Passed {
 			passedCount++
+		} else if checkResult.Ignored {
+			ignoredCount++
 		} else if !checkResult.Blocking {
 			warningCount++
 		} else {
@@ -181,8 +366,15 @@ func (uc *RunPreflightUseCase) buildResponse(session *preflight.ValidationSessio
 	response.PassedChecks = passedCount
 	response.WarningChecks = warningCount
 	response.FailedChecks = failedCount
+	response.IgnoredChecks = ignoredCount
 	response.Passed = !response.HasBlockers
 	response.HasWarnings = warningCount > 0
+	response.RecommendLiteMode = preflight.LiteModeRecommended(results)
+
+	response.SeverityPolicy = make(map[string]string)
+	for requirement, override := range session.SeverityPolicy().Overrides() {
+		response.SeverityPolicy[string(requirement)] = string(override)
+	}
 
 	// Overall message
 	if response.Passed {
@@ -202,13 +394,22 @@ func (uc *RunPreflightUseCase) buildResponse(session *preflight.ValidationSessio
 }
 
 func (uc *RunPreflightUseCase) convertResult(result preflight.ValidationResult) CheckResult {
+	detected := ""
+	if actual := result.ActualValue(); actual != nil {
+		detected = fmt.Sprint(actual)
+	}
+
 	return CheckResult{
 		Name:           string(result.RequirementName()),
 		Passed:         result.IsPassing(),
 		Blocking:       result.IsBlocking(),
 		Message:        result.FormatMessage(),
-		Guidance:       result.Guidance().Message(),
+		Detected:       detected,
+		Guidance:       NewGuidance(result.Guidance()),
 		RequirementMet: result.IsPassing(),
+		Severity:       string(result.Severity()),
+		Override:       string(result.Override()),
+		Ignored:        result.IsIgnored(),
 	}
 }
 
@@ -277,6 +478,10 @@ func (v *debianVersionValidator) Validate(ctx context.Context) preflight.Validat
 
 type gpuValidator struct {
 	gpu preflight.GPUType
+
+	// skipNVIDIADriver leaves out the NVIDIA driver checks when the
+	// installation does not install the driver
+	skipNVIDIADriver bool
 }
 
 func NewGPUValidator(gpu preflight.GPUType) preflight.Validator {
@@ -292,8 +497,25 @@ func (v *gpuValidator) RequirementName() preflight.RequirementName {
 }
 
 func (v *gpuValidator) Validate(ctx context.Context) preflight.ValidationResult {
+	actual := v.gpu.String()
+	if v.gpu.KernelDriver() != preflight.GPUDriverNone || v.gpu.VRAM() > 0 {
+		actual = fmt.Sprintf("%s (%s)", v.gpu, v.gpu.DriverSummary())
+	}
+
+	// nouveau keeps the nvidia module from binding until it is blacklisted
+	if v.gpu.NeedsNouveauRemoval() && !v.skipNVIDIADriver {
+		return preflight.NewValidationResult(
+			preflight.RequirementGPUSupport,
+			preflight.StatusWarning,
+			preflight.SeverityMedium,
+			actual,
+			"GPU with open-source drivers",
+			preflight.NouveauRemovalGuidance(),
+		)
+	}
+
 	// NVIDIA GPUs require proprietary drivers
-	if v.gpu.IsNVIDIA() {
+	if v.gpu.IsNVIDIA() && !v.skipNVIDIADriver {
 		guidance := preflight.NewUserGuidance(
 			"NVIDIA GPU detected - proprietary drivers required",
 			"NVIDIA GPUs need non-free repository and nvidia-driver package",
@@ -308,7 +530,7 @@ func (v *gpuValidator) Validate(ctx context.Context) preflight.ValidationResult
 			preflight.RequirementGPUSupport,
 			preflight.StatusWarning,
 			preflight.SeverityMedium,
-			v.gpu.String(),
+			actual,
 			"GPU with open-source drivers",
 			guidance,
 		)
@@ -319,18 +541,20 @@ func (v *gpuValidator) Validate(ctx context.Context) preflight.ValidationResult
 		preflight.RequirementGPUSupport,
 		preflight.StatusPass,
 		preflight.SeverityLow,
-		v.gpu.String(),
+		actual,
 		"GPU with open-source drivers",
 		preflight.NewUserGuidance("", "", nil, ""),
 	)
 }
 
 type diskSpaceValidator struct {
-	space preflight.DiskSpace
+	mounts []preflight.MountSpace
 }
 
-func NewDiskSpaceValidator(space preflight.DiskSpace) preflight.Validator {
-	return &diskSpaceValidator{space: space}
+// NewDiskSpaceValidator checks each mount point against the space the
+// installation consumes on it
+func NewDiskSpaceValidator(mounts []preflight.MountSpace) preflight.Validator {
+	return &diskSpaceValidator{mounts: mounts}
 }
 
 func (v *diskSpaceValidator) Name() string {
@@ -342,28 +566,41 @@ func (v *diskSpaceValidator) RequirementName() preflight.RequirementName {
 }
 
 func (v *diskSpaceValidator) Validate(ctx context.Context) preflight.ValidationResult {
-	const requiredGB uint64 = 10
+	expected := make([]string, 0, len(v.mounts))
+	for _, m := range v.mounts {
+		expected = append(expected, fmt.Sprintf("%s: %.2f GB", m.MountPoint(), m.RequiredGB()))
+	}
 
-	if v.space.MeetsMinimum(requiredGB) {
+	insufficient := preflight.InsufficientMounts(v.mounts)
+	if len(insufficient) == 0 {
 		return preflight.NewValidationResult(
 			preflight.RequirementDiskSpace,
 			preflight.StatusPass,
 			preflight.SeverityLow,
-			v.space.String(),
-			fmt.Sprintf("%d GB minimum", requiredGB),
+			preflight.DescribeMounts(v.mounts),
+			strings.Join(expected, "; "),
 			preflight.NewUserGuidance("", "", nil, ""),
 		)
 	}
 
-	// Insufficient disk space
+	// Insufficient disk space on at least one mount point
+	shortfalls := make([]string, 0, len(insufficient))
+	steps := make([]string, 0, len(insufficient)+3)
+	for _, m := range insufficient {
+		shortfalls = append(shortfalls, fmt.Sprintf("%.2f GB available on %s, %.2f GB required",
+			m.Space().AvailableGB(), m.MountPoint(), m.RequiredGB()))
+		steps = append(steps, fmt.Sprintf("Free up space on %s, which holds %s", m.MountPoint(), m.Purposes()))
+	}
+	steps = append(steps,
+		"Free up disk space by removing unused packages: sudo apt autoremove",
+		"Clean package cache: sudo apt clean",
+		"Remove old files or move data to external storage",
+	)
+
 	guidance := preflight.NewUserGuidance(
-		fmt.Sprintf("Insufficient disk space: %.2f GB available, %d GB required", v.space.AvailableGB(), requiredGB),
-		"Hyprland and its dependencies require at least 10GB of free disk space",
-		[]string{
-			"Free up disk space by removing unused packages: sudo apt autoremove",
-			"Clean package cache: sudo apt clean",
-			"Remove old files or move data to external storage",
-		},
+		fmt.Sprintf("Insufficient disk space: %s", strings.Join(shortfalls, "; ")),
+		"Packages unpack under /var, configs go to your home directory and kernel updates to /boot, so each partition needs its own share of the 10GB",
+		steps,
 		"https://gohan.sh/docs/troubleshooting#disk-space",
 	)
 
@@ -371,8 +608,8 @@ func (v *diskSpaceValidator) Validate(ctx context.Context) preflight.ValidationR
 		preflight.RequirementDiskSpace,
 		preflight.StatusFail,
 		preflight.SeverityHigh,
-		v.space.String(),
-		fmt.Sprintf("%d GB minimum", requiredGB),
+		preflight.DescribeMounts(v.mounts),
+		strings.Join(expected, "; "),
 		guidance,
 	)
 }
@@ -477,3 +714,354 @@ func (v *sourceRepositoryValidator) Validate(ctx context.Context) preflight.Vali
 		guidance,
 	)
 }
+
+type systemResourcesValidator struct {
+	resources preflight.SystemResources
+}
+
+func NewSystemResourcesValidator(resources preflight.SystemResources) preflight.Validator {
+	return &systemResourcesValidator{resources: resources}
+}
+
+func (v *systemResourcesValidator) Name() string {
+	return "System Resources"
+}
+
+func (v *systemResourcesValidator) RequirementName() preflight.RequirementName {
+	return preflight.RequirementSystemResources
+}
+
+func (v *systemResourcesValidator) Validate(ctx context.Context) preflight.ValidationResult {
+	expected := fmt.Sprintf("%d GB RAM and fast storage", preflight.LiteModeThresholdGB)
+
+	if !v.resources.RecommendsLiteMode() {
+		return preflight.NewValidationResult(
+			preflight.RequirementSystemResources,
+			preflight.StatusPass,
+			preflight.SeverityLow,
+			v.resources,
+			expected,
+			preflight.NewUserGuidance("", "", nil, ""),
+		)
+	}
+
+	reason := "Slow storage makes heavy packages and effects sluggish"
+	if v.resources.HasLowMemory() {
+		reason = fmt.Sprintf("Less than %d GB of memory is available for the desktop", preflight.LiteModeThresholdGB)
+	}
+
+	guidance := preflight.NewUserGuidance(
+		fmt.Sprintf("Low-end system detected (%s) - lite mode will be used", v.resources),
+		reason,
+		[]string{
+			"Lite mode skips heavy optional packages and disables blur and animations",
+			"To keep the standard desktop anyway: gohan install --rendering standard",
+		},
+		"",
+	)
+
+	return preflight.NewValidationResult(
+		preflight.RequirementSystemResources,
+		preflight.StatusWarning,
+		preflight.SeverityLow,
+		v.resources,
+		expected,
+		guidance,
+	)
+}
+
+type powerDaemonValidator struct {
+	status preflight.PowerDaemonStatus
+}
+
+func NewPowerDaemonValidator(status preflight.PowerDaemonStatus) preflight.Validator {
+	return &powerDaemonValidator{status: status}
+}
+
+func (v *powerDaemonValidator) Name() string {
+	return "Power Management Daemons"
+}
+
+func (v *powerDaemonValidator) RequirementName() preflight.RequirementName {
+	return preflight.RequirementPowerDaemons
+}
+
+func (v *powerDaemonValidator) Validate(ctx context.Context) preflight.ValidationResult {
+	expected := "at most one power management daemon"
+
+	if !v.status.HasConflict() {
+		return preflight.NewValidationResult(
+			preflight.RequirementPowerDaemons,
+			preflight.StatusPass,
+			preflight.SeverityLow,
+			v.status,
+			expected,
+			preflight.NewUserGuidance("", "", nil, ""),
+		)
+	}
+
+	steps := make([]string, 0, len(v.status.Active())+1)
+	steps = append(steps, "Keep one daemon and disable the others:")
+	for _, daemon := range v.status.Active() {
+		steps = append(steps, fmt.Sprintf("sudo systemctl disable --now %s", daemon))
+	}
+
+	guidance := preflight.NewUserGuidance(
+		fmt.Sprintf("Conflicting power management daemons are running: %s", v.status),
+		"Multiple daemons override each other's CPU governor and device power settings",
+		steps,
+		"",
+	)
+
+	return preflight.NewValidationResult(
+		preflight.RequirementPowerDaemons,
+		preflight.StatusWarning,
+		preflight.SeverityMedium,
+		v.status,
+		expected,
+		guidance,
+	)
+}
+
+type sessionValidator struct {
+	env preflight.SessionEnvironment
+}
+
+func NewSessionValidator(env preflight.SessionEnvironment) preflight.Validator {
+	return &sessionValidator{env: env}
+}
+
+func (v *sessionValidator) Name() string {
+	return "Graphical Session"
+}
+
+func (v *sessionValidator) RequirementName() preflight.RequirementName {
+	return preflight.RequirementSession
+}
+
+func (v *sessionValidator) Validate(ctx context.Context) preflight.ValidationResult {
+	expected := "no active Wayland compositor session"
+
+	if !v.env.InLiveSession() {
+		return preflight.NewValidationResult(
+			preflight.RequirementSession,
+			preflight.StatusPass,
+			preflight.SeverityLow,
+			v.env,
+			expected,
+			preflight.NewUserGuidance("", "", nil, ""),
+		)
+	}
+
+	guidance := preflight.NewUserGuidance(
+		fmt.Sprintf("A %s session is currently active", v.env.ActiveCompositor()),
+		"Replacing packages and configs under a live compositor can crash or lock up the session",
+		sessionGuidanceSteps(v.env),
+		"",
+	)
+
+	return preflight.NewValidationResult(
+		preflight.RequirementSession,
+		preflight.StatusWarning,
+		preflight.SeverityMedium,
+		v.env,
+		expected,
+		guidance,
+	)
+}
+
+// sessionGuidanceSteps explains how to install without breaking a live session
+func sessionGuidanceSteps(env preflight.SessionEnvironment) []string {
+	steps := []string{
+		"Log out and switch to a TTY (Ctrl+Alt+F3), then run gohan from there",
+	}
+	if env.HasDisplayManager() {
+		steps = append(steps, fmt.Sprintf("Stop the display manager first if it restarts the session: sudo systemctl stop %s", env.DisplayManagers()[0]))
+	}
+	steps = append(steps,
+		"Or keep existing configs: preview with 'gohan config deploy --dry-run' and deploy with backups enabled",
+	)
+	return steps
+}
+
+type sourcesSanityValidator struct {
+	sanity preflight.SourcesSanity
+}
+
+func NewSourcesSanityValidator(sanity preflight.SourcesSanity) preflight.Validator {
+	return &sourcesSanityValidator{sanity: sanity}
+}
+
+func (v *sourcesSanityValidator) Name() string {
+	return "APT Sources Sanity"
+}
+
+func (v *sourcesSanityValidator) RequirementName() preflight.RequirementName {
+	return preflight.RequirementSourcesSanity
+}
+
+func (v *sourcesSanityValidator) Validate(ctx context.Context) preflight.ValidationResult {
+	expected := "no duplicate, mixed-suite or unsigned entries"
+
+	if v.sanity.IsClean() {
+		return preflight.NewValidationResult(
+			preflight.RequirementSourcesSanity,
+			preflight.StatusPass,
+			preflight.SeverityLow,
+			v.sanity,
+			expected,
+			preflight.NewUserGuidance("", "", nil, ""),
+		)
+	}
+
+	issues := v.sanity.Issues()
+	guidance := preflight.NewUserGuidance(
+		fmt.Sprintf("%d problem(s) in apt sources, first at %s", len(issues), issues[0]),
+		"Duplicate entries, mixed releases and unsigned repositories cause confusing apt failures mid-install",
+		sourcesSanitySteps(v.sanity),
+		"",
+	)
+
+	return preflight.NewValidationResult(
+		preflight.RequirementSourcesSanity,
+		preflight.StatusWarning,
+		preflight.SeverityMedium,
+		v.sanity,
+		expected,
+		guidance,
+	)
+}
+
+// sourcesSanitySteps lists every problem followed by how to resolve them
+func sourcesSanitySteps(sanity preflight.SourcesSanity) []string {
+	steps := sanity.Issues()
+	if sanity.FixableCount() > 0 {
+		steps = append(steps, fmt.Sprintf("Fix %d duplicate(s) automatically: sudo gohan repo lint --fix", sanity.FixableCount()))
+	}
+	steps = append(steps, "Review the remaining problems with: gohan repo lint")
+	return steps
+}
+
+// Throughput Validator
+type throughputValidator struct {
+	throughput preflight.Throughput
+}
+
+func NewThroughputValidator(throughput preflight.Throughput) preflight.Validator {
+	return &throughputValidator{throughput: throughput}
+}
+
+func (v *throughputValidator) Name() string {
+	return "Disk and Network Throughput"
+}
+
+func (v *throughputValidator) RequirementName() preflight.RequirementName {
+	return preflight.RequirementThroughput
+}
+
+func (v *throughputValidator) Validate(ctx context.Context) preflight.ValidationResult {
+	expected := fmt.Sprintf("disk write >= %d MB/s, download >= %d MB/s",
+		preflight.SlowDiskWriteThreshold/preflight.MB, preflight.SlowDownloadThreshold/preflight.MB)
+
+	if !v.throughput.HasSlowDisk() && !v.throughput.HasSlowNetwork() {
+		return preflight.NewValidationResult(
+			preflight.RequirementThroughput,
+			preflight.StatusPass,
+			preflight.SeverityLow,
+			v.throughput,
+			expected,
+			preflight.NewUserGuidance("", "", nil, ""),
+		)
+	}
+
+	var slow []string
+	var steps []string
+	if v.throughput.HasSlowDisk() {
+		slow = append(slow, "disk writes")
+		steps = append(steps, "Storage looks like eMMC or SD; installing to an SSD is considerably faster")
+	}
+	if v.throughput.HasSlowNetwork() {
+		slow = append(slow, "downloads")
+		steps = append(steps, "Switch to a faster mirror with: sudo gohan repo fastest-mirror --apply")
+	}
+	steps = append(steps, "Installation estimates account for the measured speeds")
+
+	guidance := preflight.NewUserGuidance(
+		fmt.Sprintf("Slow %s measured (%s)", strings.Join(slow, " and "), v.throughput),
+		"Installation will take longer than usual",
+		steps,
+		"",
+	)
+
+	return preflight.NewValidationResult(
+		preflight.RequirementThroughput,
+		preflight.StatusWarning,
+		preflight.SeverityLow,
+		v.throughput,
+		expected,
+		guidance,
+	)
+}
+
+// Writable Filesystem Validator
+type writableFilesystemValidator struct {
+	writability preflight.FilesystemWritability
+}
+
+func NewWritableFilesystemValidator(writability preflight.FilesystemWritability) preflight.Validator {
+	return &writableFilesystemValidator{writability: writability}
+}
+
+func (v *writableFilesystemValidator) Name() string {
+	return "Writable Filesystem"
+}
+
+func (v *writableFilesystemValidator) RequirementName() preflight.RequirementName {
+	return preflight.RequirementWritableRoot
+}
+
+func (v *writableFilesystemValidator) Validate(ctx context.Context) preflight.ValidationResult {
+	expected := fmt.Sprintf("writable %s", strings.Join(preflight.PackageManagerPaths(), ", "))
+
+	if v.writability.CanInstallPackages() {
+		return preflight.NewValidationResult(
+			preflight.RequirementWritableRoot,
+			preflight.StatusPass,
+			preflight.SeverityLow,
+			v.writability,
+			expected,
+			preflight.NewUserGuidance("", "", nil, ""),
+		)
+	}
+
+	readOnly := v.writability.ReadOnlyMounts()
+	guidance := preflight.NewUserGuidance(
+		fmt.Sprintf("Packages cannot be installed: %s", readOnly[0]),
+		"apt and dpkg write to /usr, /etc/apt and /var, which are on a read-only or immutable filesystem",
+		writableFilesystemSteps(v.writability),
+		"",
+	)
+
+	return preflight.NewValidationResult(
+		preflight.RequirementWritableRoot,
+		preflight.StatusFail,
+		preflight.SeverityCritical,
+		v.writability,
+		expected,
+		guidance,
+	)
+}
+
+// writableFilesystemSteps explains how to make the package directories
+// writable, or how to install without packages
+func writableFilesystemSteps(writability preflight.FilesystemWritability) []string {
+	var steps []string
+	for _, mountPoint := range writability.ReadOnlyMountPoints() {
+		steps = append(steps, fmt.Sprintf("Remount %s read-write until the next boot: sudo mount -o remount,rw %s", mountPoint, mountPoint))
+	}
+	steps = append(steps,
+		"With overlayroot, run gohan inside 'sudo overlayroot-chroot' so packages reach the lower filesystem",
+		"Or leave packages alone and deploy only configurations with 'gohan install --user-only'",
+	)
+	return steps
+}
@@ -2,6 +2,8 @@ package preflight_test
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/rebelopsio/gohan/internal/application/preflight"
@@ -46,6 +48,23 @@ func (m *mockDiskSpaceDetector) DetectAvailableSpace(ctx context.Context, path s
 	return m.space, m.err
 }
 
+// mockMountDiskSpaceDetector reports free space per mount point
+type mockMountDiskSpaceDetector struct {
+	mounts    map[string]string
+	available map[string]uint64
+}
+
+func (m *mockMountDiskSpaceDetector) DetectAvailableSpace(ctx context.Context, path string) (domainPreflight.DiskSpace, error) {
+	return domainPreflight.NewDiskSpace(m.available[path], 500*domainPreflight.GB, path)
+}
+
+func (m *mockMountDiskSpaceDetector) DetectMountPoint(ctx context.Context, path string) (string, error) {
+	if mount, ok := m.mounts[path]; ok {
+		return mount, nil
+	}
+	return "/", nil
+}
+
 type mockConnectivityChecker struct {
 	connectivity domainPreflight.InternetConnectivity
 	err          error
@@ -68,6 +87,62 @@ func (m *mockSourceRepositoryChecker) CheckSourceRepositories(ctx context.Contex
 	return m.status, m.err
 }
 
+type mockResourceDetector struct {
+	resources domainPreflight.SystemResources
+	err       error
+}
+
+func (m *mockResourceDetector) DetectResources(ctx context.Context) (domainPreflight.SystemResources, error) {
+	return m.resources, m.err
+}
+
+type mockPowerDaemonDetector struct {
+	status domainPreflight.PowerDaemonStatus
+	err    error
+}
+
+func (m *mockPowerDaemonDetector) DetectPowerDaemons(ctx context.Context) (domainPreflight.PowerDaemonStatus, error) {
+	return m.status, m.err
+}
+
+type mockSessionDetector struct {
+	env domainPreflight.SessionEnvironment
+	err error
+}
+
+func (m *mockSessionDetector) DetectSession(ctx context.Context) (domainPreflight.SessionEnvironment, error) {
+	return m.env, m.err
+}
+
+type mockSourcesSanityChecker struct {
+	sanity domainPreflight.SourcesSanity
+	err    error
+}
+
+func (m *mockSourcesSanityChecker) CheckSourcesSanity(ctx context.Context) (domainPreflight.SourcesSanity, error) {
+	return m.sanity, m.err
+}
+
+type mockThroughputProbe struct {
+	throughput domainPreflight.Throughput
+	err        error
+}
+
+func (m *mockThroughputProbe) ProbeThroughput(ctx context.Context) (domainPreflight.Throughput, error) {
+	return m.throughput, m.err
+}
+
+type mockWritabilityDetector struct {
+	writability domainPreflight.FilesystemWritability
+	paths       []string
+	err         error
+}
+
+func (m *mockWritabilityDetector) DetectWritability(ctx context.Context, paths []string) (domainPreflight.FilesystemWritability, error) {
+	m.paths = paths
+	return m.writability, m.err
+}
+
 func TestRunPreflightUseCase_Execute_AllPass(t *testing.T) {
 	// Arrange
 	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
@@ -152,6 +227,33 @@ func TestRunPreflightUseCase_Execute_BlockingFailure(t *testing.T) {
 	assert.Contains(t, resp.OverallMessage, "1 critical issue")
 }
 
+func TestRunPreflightUseCase_Execute_OnlyFailedChecks(t *testing.T) {
+	bookworm, err := domainPreflight.NewDebianVersion("bookworm", "12")
+	require.NoError(t, err)
+
+	// The GPU detector would fail the run if it were asked
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: bookworm},
+		GPUDetector:             &mockGPUDetector{err: errors.New("not expected")},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{err: errors.New("not expected")},
+		ConnectivityChecker:     &mockConnectivityChecker{err: errors.New("not expected")},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{err: errors.New("not expected")},
+	}
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	req := preflight.RunPreflightRequest{Only: []domainPreflight.RequirementName{domainPreflight.RequirementDebianVersion}}
+	assert.Equal(t, 1, useCase.CheckCount(req))
+
+	resp, err := useCase.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.TotalChecks)
+
+	blockers := resp.BlockingResults()
+	require.Len(t, blockers, 1)
+	assert.Equal(t, string(domainPreflight.RequirementDebianVersion), blockers[0].Name)
+	assert.Equal(t, "https://gohan.sh/docs/installation", blockers[0].Guidance.DocumentationURL)
+}
+
 func TestRunPreflightUseCase_Execute_WithWarnings(t *testing.T) {
 	// Arrange - NVIDIA GPU (generates warning)
 	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
@@ -194,6 +296,167 @@ func TestRunPreflightUseCase_Execute_WithWarnings(t *testing.T) {
 	assert.Contains(t, resp.OverallMessage, "passed with warnings")
 }
 
+func TestRunPreflightUseCase_Execute_SeverityPolicy(t *testing.T) {
+	// Arrange - NVIDIA GPU and no source repos, both warnings by default
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	nvidiaGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorNVIDIA, "GeForce RTX 3080", "10de:2206")
+	require.NoError(t, err)
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: nvidiaGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: domainPreflight.NewSourceRepositoryStatus(false, []string{})},
+	}
+
+	policy, err := domainPreflight.NewSeverityPolicy(map[string]string{
+		"source_repositories": "blocker",
+		"gpu_support":         "ignore",
+	})
+	require.NoError(t, err)
+
+	useCase := preflight.NewRunPreflightUseCase(detectors).WithSeverityPolicy(policy)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, resp.Passed)
+	assert.Equal(t, 1, resp.FailedChecks)
+	assert.Equal(t, 1, resp.IgnoredChecks)
+	assert.Equal(t, 0, resp.WarningChecks)
+	assert.Equal(t, map[string]string{
+		"source_repositories": "blocker",
+		"gpu_support":         "ignore",
+	}, resp.SeverityPolicy)
+
+	for _, result := range resp.Results {
+		switch result.Name {
+		case string(domainPreflight.RequirementSourceRepos):
+			assert.True(t, result.Blocking)
+			assert.Equal(t, "blocker", result.Override)
+		case string(domainPreflight.RequirementGPUSupport):
+			assert.True(t, result.Ignored)
+			assert.Equal(t, "ignore", result.Override)
+		default:
+			assert.Empty(t, result.Override)
+		}
+	}
+}
+
+func TestRunPreflightUseCase_Execute_NVIDIAOnNouveau(t *testing.T) {
+	// Arrange - NVIDIA GPU still driven by nouveau
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	nvidiaGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorNVIDIA, "GeForce RTX 3080", "10de:2206")
+	require.NoError(t, err)
+	nvidiaGPU = nvidiaGPU.WithKernelDriver(domainPreflight.GPUDriverNouveau, "6.12.9-amd64")
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"deb-src http://deb.debian.org/debian sid main"})
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: nvidiaGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Passed)
+	assert.Equal(t, 1, resp.WarningChecks)
+
+	gpuResult := resp.Results[1]
+	require.Equal(t, string(domainPreflight.RequirementGPUSupport), gpuResult.Name)
+	assert.False(t, gpuResult.Passed)
+	assert.Contains(t, gpuResult.Guidance.Message, "nouveau")
+	assert.Equal(t, "nvidia GeForce RTX 3080 (nouveau 6.12.9-amd64)", gpuResult.Detected)
+	assert.Contains(t, strings.Join(gpuResult.Guidance.Steps, "\n"), "update-initramfs -u")
+}
+
+func TestRunPreflightUseCase_Execute_InstallationScope(t *testing.T) {
+	// Arrange - NVIDIA GPU on nouveau and 5 GB free, enough for a small selection
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	nvidiaGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorNVIDIA, "GeForce RTX 3080", "10de:2206")
+	require.NoError(t, err)
+	nvidiaGPU = nvidiaGPU.WithKernelDriver(domainPreflight.GPUDriverNouveau, "6.12.9-amd64")
+
+	diskSpace, err := domainPreflight.NewDiskSpace(5*domainPreflight.GB, 100*domainPreflight.GB, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"deb-src http://deb.debian.org/debian sid main"})
+
+	useCase := preflight.NewRunPreflightUseCase(preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: nvidiaGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+	})
+
+	t.Run("adapts to the selected components", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{
+			Scope: &preflight.InstallationScope{InstalledBytes: 2 * domainPreflight.GB, DownloadBytes: 500 * domainPreflight.MB},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Passed)
+		assert.Equal(t, 5, resp.PassedChecks, "no NVIDIA driver checks without the driver component")
+	})
+
+	t.Run("checks the NVIDIA driver when it is selected", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{
+			Scope: &preflight.InstallationScope{InstalledBytes: 2 * domainPreflight.GB, DownloadBytes: 500 * domainPreflight.MB, NVIDIADriver: true},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.WarningChecks)
+		assert.Contains(t, resp.Results[1].Guidance.Message, "nouveau")
+	})
+
+	t.Run("full installation needs the default space", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Passed)
+	})
+
+	t.Run("counts the checks it runs", func(t *testing.T) {
+		assert.Equal(t, 5, useCase.CheckCount(preflight.RunPreflightRequest{}))
+	})
+}
+
 func TestRunPreflightUseCase_Execute_InsufficientDiskSpace(t *testing.T) {
 	// Arrange - Only 5GB available (need 10GB)
 	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
@@ -244,7 +507,98 @@ func TestRunPreflightUseCase_Execute_InsufficientDiskSpace(t *testing.T) {
 	require.NotNil(t, diskResult, "Disk space check result not found")
 	assert.False(t, diskResult.Passed)
 	assert.True(t, diskResult.Blocking)
-	assert.Contains(t, diskResult.Guidance, "Insufficient disk space")
+	assert.Contains(t, diskResult.Guidance.Message, "Insufficient disk space")
+}
+
+// fakeReclaimer frees space on a mock detector's mount point
+type fakeReclaimer struct {
+	detector *mockMountDiskSpaceDetector
+	mount    string
+	freed    uint64
+	calls    int
+}
+
+func (r *fakeReclaimer) ReclaimSpace(ctx context.Context) (uint64, error) {
+	r.calls++
+	r.detector.available[r.mount] += r.freed
+	return r.freed, nil
+}
+
+func TestRunPreflightUseCase_Execute_ReclaimsSpaceWhenNearlyFull(t *testing.T) {
+	only := preflight.RunPreflightRequest{Only: []domainPreflight.RequirementName{domainPreflight.RequirementDiskSpace}}
+
+	t.Run("cleans caches on a nearly full partition", func(t *testing.T) {
+		// /var needs 3.5 GB and has barely that
+		detector := &mockMountDiskSpaceDetector{
+			mounts:    map[string]string{"/var": "/var"},
+			available: map[string]uint64{"/": 50 * domainPreflight.GB, "/var": 3*domainPreflight.GB + 600*domainPreflight.MB},
+		}
+		reclaimer := &fakeReclaimer{detector: detector, mount: "/var", freed: 2 * domainPreflight.GB}
+		useCase := preflight.NewRunPreflightUseCase(preflight.Detectors{DiskSpaceDetector: detector}).WithSpaceReclaimer(reclaimer)
+
+		resp, err := useCase.Execute(context.Background(), only)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reclaimer.calls)
+		assert.True(t, resp.Passed)
+		assert.Contains(t, resp.Results[0].Detected, "/var: 5.59 GB free")
+	})
+
+	t.Run("leaves caches alone with room to spare", func(t *testing.T) {
+		detector := &mockMountDiskSpaceDetector{
+			available: map[string]uint64{"/": 50 * domainPreflight.GB},
+		}
+		reclaimer := &fakeReclaimer{detector: detector, mount: "/", freed: domainPreflight.GB}
+		useCase := preflight.NewRunPreflightUseCase(preflight.Detectors{DiskSpaceDetector: detector}).WithSpaceReclaimer(reclaimer)
+
+		_, err := useCase.Execute(context.Background(), only)
+		require.NoError(t, err)
+		assert.Zero(t, reclaimer.calls)
+	})
+}
+
+func TestRunPreflightUseCase_Execute_FullVarPartition(t *testing.T) {
+	// Arrange - plenty of room on / and /home, but a small /var partition
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	amdGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorAMD, "Radeon RX 6800", "1002:73bf")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"deb-src http://deb.debian.org/debian sid main"})
+
+	detectors := preflight.Detectors{
+		DebianDetector: &mockDebianDetector{version: debianSid},
+		GPUDetector:    &mockGPUDetector{gpu: amdGPU},
+		DiskSpaceDetector: &mockMountDiskSpaceDetector{
+			mounts: map[string]string{"/var": "/var", "/home/alice": "/home"},
+			available: map[string]uint64{
+				"/":     50 * domainPreflight.GB,
+				"/var":  1 * domainPreflight.GB,
+				"/home": 200 * domainPreflight.GB,
+			},
+		},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{HomeDir: "/home/alice"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.HasBlockers)
+
+	diskResult := resp.Results[2]
+	require.Equal(t, string(domainPreflight.RequirementDiskSpace), diskResult.Name)
+	assert.False(t, diskResult.Passed)
+	assert.Contains(t, diskResult.Guidance.Message, "1.00 GB available on /var, 3.50 GB required")
+	assert.NotContains(t, diskResult.Guidance.Message, "on /home")
 }
 
 func TestRunPreflightUseCase_Execute_NoConnectivity(t *testing.T) {
@@ -297,7 +651,7 @@ func TestRunPreflightUseCase_Execute_NoConnectivity(t *testing.T) {
 	require.NotNil(t, connResult, "Connectivity check result not found")
 	assert.False(t, connResult.Passed)
 	assert.True(t, connResult.Blocking)
-	assert.Contains(t, connResult.Guidance, "No internet connection")
+	assert.Contains(t, connResult.Guidance.Message, "No internet connection")
 }
 
 func TestRunPreflightUseCase_ExecuteWithProgress(t *testing.T) {
@@ -347,6 +701,56 @@ func TestRunPreflightUseCase_ExecuteWithProgress(t *testing.T) {
 	assert.Equal(t, 5, len(progressCalls), "Progress callback should be called for each validator")
 }
 
+func TestRunPreflightUseCase_RunSession_DetectorErrors(t *testing.T) {
+	// Arrange
+	amdGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorAMD, "Radeon RX 6800", "1002:73bf")
+	require.NoError(t, err)
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{err: errors.New("no os-release")},
+		GPUDetector:             &mockGPUDetector{gpu: amdGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{err: errors.New("unreadable")},
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	var started []domainPreflight.RequirementName
+	var finished []domainPreflight.RequirementName
+
+	// Act
+	session, err := useCase.RunSession(context.Background(), preflight.RunPreflightRequest{},
+		func(name string, requirement domainPreflight.RequirementName) {
+			started = append(started, requirement)
+		},
+		func(name string, result domainPreflight.ValidationResult) {
+			finished = append(finished, result.RequirementName())
+		},
+	)
+
+	// Assert: failed detections are recorded, not skipped
+	require.NoError(t, err)
+	require.Len(t, session.Results(), 5)
+	assert.Equal(t, started, finished)
+
+	blockers := session.BlockingResults()
+	require.Len(t, blockers, 1)
+	assert.Equal(t, domainPreflight.RequirementDebianVersion, blockers[0].RequirementName())
+	assert.Equal(t, "Unable to detect Debian version", blockers[0].Guidance().Message())
+
+	warnings := session.WarningResults()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, domainPreflight.RequirementSourceRepos, warnings[0].RequirementName())
+}
+
 func TestRunPreflightUseCase_ConvertResult(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -409,3 +813,266 @@ func TestRunPreflightUseCase_ConvertResult(t *testing.T) {
 		})
 	}
 }
+
+func TestRunPreflightUseCase_Execute_LowEndSystem(t *testing.T) {
+	// Arrange - 2GB RAM on an SD card
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	amdGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorAMD, "Radeon", "1002:73bf")
+	require.NoError(t, err)
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"/etc/apt/sources.list"})
+
+	lowEnd, err := domainPreflight.NewSystemResources(2*domainPreflight.GB, "mmcblk0p2", true)
+	require.NoError(t, err)
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: amdGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+		ResourceDetector:        &mockResourceDetector{resources: lowEnd},
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Passed, "lite mode recommendation should not block")
+	assert.Equal(t, 6, resp.TotalChecks)
+	assert.Equal(t, 1, resp.WarningChecks)
+	assert.True(t, resp.RecommendLiteMode)
+	assert.Contains(t, resp.Results[5].Guidance.Message, "lite mode")
+}
+
+func TestRunPreflightUseCase_Execute_ConflictingPowerDaemons(t *testing.T) {
+	// Arrange
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	amdGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorAMD, "Radeon", "1002:73bf")
+	require.NoError(t, err)
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"/etc/apt/sources.list"})
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: amdGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+		PowerDaemonDetector: &mockPowerDaemonDetector{
+			status: domainPreflight.NewPowerDaemonStatus([]string{"tlp", "power-profiles-daemon"}),
+		},
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Passed, "conflicting daemons should warn, not block")
+	assert.Equal(t, 6, resp.TotalChecks)
+	assert.Equal(t, 1, resp.WarningChecks)
+	assert.Contains(t, resp.Results[5].Guidance.Message, "tlp, power-profiles-daemon")
+}
+
+func TestRunPreflightUseCase_Execute_LiveCompositorSession(t *testing.T) {
+	// Arrange
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	amdGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorAMD, "Radeon", "1002:73bf")
+	require.NoError(t, err)
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"/etc/apt/sources.list"})
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: amdGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+		SessionDetector: &mockSessionDetector{
+			env: domainPreflight.NewSessionEnvironment([]string{"sddm"}, "Hyprland"),
+		},
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Passed, "a live session should warn, not block")
+	assert.Equal(t, 6, resp.TotalChecks)
+	assert.Equal(t, 1, resp.WarningChecks)
+	assert.Contains(t, resp.Results[5].Guidance.Message, "Hyprland session is currently active")
+}
+
+func TestRunPreflightUseCase_Execute_BrokenSources(t *testing.T) {
+	// Arrange
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	amdGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorAMD, "Radeon", "1002:73bf")
+	require.NoError(t, err)
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"/etc/apt/sources.list"})
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: amdGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+		SourcesSanityChecker: &mockSourcesSanityChecker{
+			sanity: domainPreflight.NewSourcesSanity([]string{
+				"/etc/apt/sources.list:3: suite bookworm belongs to bookworm, but the other Debian entries use sid",
+			}, 0),
+		},
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Passed, "broken sources should warn, not block")
+	assert.Equal(t, 1, resp.WarningChecks)
+	assert.Contains(t, resp.Results[5].Guidance.Message, "/etc/apt/sources.list:3")
+}
+
+func TestRunPreflightUseCase_Execute_SlowStorage(t *testing.T) {
+	// Arrange
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	amdGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorAMD, "Radeon", "1002:73bf")
+	require.NoError(t, err)
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"/etc/apt/sources.list"})
+
+	// eMMC-class storage with a healthy network
+	throughput, err := domainPreflight.NewThroughput(15*domainPreflight.MB, 8*domainPreflight.MB)
+	require.NoError(t, err)
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: amdGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+		ThroughputProbe:         &mockThroughputProbe{throughput: throughput},
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Passed, "slow storage should warn, not block")
+	assert.Equal(t, 1, resp.WarningChecks)
+	assert.Contains(t, resp.Results[5].Guidance.Message, "Slow disk writes")
+	assert.NotContains(t, resp.Results[5].Guidance.Message, "downloads")
+}
+
+func TestRunPreflightUseCase_Execute_ReadOnlyRoot(t *testing.T) {
+	// Arrange
+	debianSid, err := domainPreflight.NewDebianVersion("sid", "unstable")
+	require.NoError(t, err)
+
+	amdGPU, err := domainPreflight.NewGPUType(domainPreflight.GPUVendorAMD, "Radeon", "1002:73bf")
+	require.NoError(t, err)
+
+	diskSpace, err := domainPreflight.NewDiskSpace(50*1024*1024*1024, 100*1024*1024*1024, "/")
+	require.NoError(t, err)
+
+	connectivity := domainPreflight.NewInternetConnectivity(true, []domainPreflight.ConnectivityTest{
+		{Endpoint: "debian.org", Success: true},
+	})
+
+	sourceRepos := domainPreflight.NewSourceRepositoryStatus(true, []string{"/etc/apt/sources.list"})
+
+	writability := domainPreflight.NewFilesystemWritability([]domainPreflight.ReadOnlyMount{
+		{Path: "/usr", MountPoint: "/", FSType: "squashfs"},
+		{Path: "/etc/apt", MountPoint: "/", FSType: "squashfs"},
+	})
+	detector := &mockWritabilityDetector{writability: writability}
+
+	detectors := preflight.Detectors{
+		DebianDetector:          &mockDebianDetector{version: debianSid},
+		GPUDetector:             &mockGPUDetector{gpu: amdGPU},
+		DiskSpaceDetector:       &mockDiskSpaceDetector{space: diskSpace},
+		ConnectivityChecker:     &mockConnectivityChecker{connectivity: connectivity},
+		SourceRepositoryChecker: &mockSourceRepositoryChecker{status: sourceRepos},
+		WritabilityDetector:     detector,
+	}
+
+	useCase := preflight.NewRunPreflightUseCase(detectors)
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), preflight.RunPreflightRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, resp.Passed, "a read-only root should block the installation")
+	assert.Equal(t, domainPreflight.PackageManagerPaths(), detector.paths)
+
+	blockers := resp.BlockingResults()
+	require.Len(t, blockers, 1)
+	assert.Equal(t, string(domainPreflight.RequirementWritableRoot), blockers[0].Name)
+	assert.Contains(t, blockers[0].Guidance.Message, "/usr (read-only squashfs mount /)")
+
+	fixes := blockers[0].Guidance.FixSteps()
+	assert.Equal(t, "sudo mount -o remount,rw /", fixes[0].Command, "remounting is offered as a fix")
+	assert.Contains(t, fixes[len(fixes)-1].Description, "gohan install --user-only")
+	assert.Empty(t, fixes[len(fixes)-1].Command, "the user-only mode is not run as a fix")
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+	repoInfra "github.com/rebelopsio/gohan/internal/infrastructure/repository"
+)
+
+// BenchmarkMirrorsRequest contains parameters for benchmarking mirrors
+type BenchmarkMirrorsRequest struct {
+	Mirrors     []string // Mirror base URIs; defaults to DefaultDebianMirrors
+	Suite       string   // Suite whose Release file is sampled (e.g., "sid")
+	Concurrency int      // Number of mirrors benchmarked in parallel
+}
+
+// MirrorResult is the benchmark outcome of a single mirror
+type MirrorResult struct {
+	URI        string
+	Host       string
+	Latency    time.Duration
+	Throughput float64 // bytes per second
+	Error      string
+}
+
+// BenchmarkMirrorsResponse contains ranked benchmark results
+type BenchmarkMirrorsResponse struct {
+	Results []MirrorResult // Sorted from fastest to slowest
+	Fastest string         // URI of the fastest reachable mirror, empty if none
+}
+
+// SwitchMirrorRequest contains parameters for rewriting sources to a mirror
+type SwitchMirrorRequest struct {
+	MirrorURI         string
+	SourcesListPath   string // Legacy one-line sources.list
+	DebianSourcesPath string // deb822 file that will hold the Debian archive entries
+	SnapshotDir       string // Where the previous sources are saved for revert
+}
+
+// SwitchMirrorResponse contains the result of a mirror switch
+type SwitchMirrorResponse struct {
+	Mirror            string
+	EntriesRewritten  int
+	DebianSourcesPath string
+	SnapshotPath      string
+}
+
+// RevertMirrorRequest contains parameters for reverting the last mirror switch
+type RevertMirrorRequest struct {
+	SnapshotDir string
+}
+
+// RevertMirrorResponse contains the result of a revert
+type RevertMirrorResponse struct {
+	SnapshotPath  string
+	SnapshotTime  time.Time
+	FilesRestored []string
+	FilesRemoved  []string
+}
+
+// MirrorBenchmarker is the interface for measuring mirror performance
+type MirrorBenchmarker interface {
+	Benchmark(ctx context.Context, mirror domainRepo.Mirror, suite string) domainRepo.MirrorBenchmark
+}
+
+// Deb822SourcesManager is the interface for writing deb822 sources while
+// retiring the legacy one-line format
+type Deb822SourcesManager interface {
+	SourcesListManager
+	WriteDeb822Config(path string, config *domainRepo.RepositoryConfig) error
+	DisableLegacySources(path, note string) error
+}
+
+// SourcesSnapshotter is the interface for saving and restoring sources files
+type SourcesSnapshotter interface {
+	Snapshot(paths []string, snapshotDir, reason string) (*repoInfra.SourcesSnapshot, error)
+	Latest(snapshotDir string) (*repoInfra.SourcesSnapshot, error)
+	Restore(snapshot *repoInfra.SourcesSnapshot) error
+}
+
+// BenchmarkMirrorsUseCase handles benchmarking a list of mirrors
+type BenchmarkMirrorsUseCase struct {
+	benchmarker MirrorBenchmarker
+}
+
+// NewBenchmarkMirrorsUseCase creates a new use case instance
+func NewBenchmarkMirrorsUseCase(benchmarker MirrorBenchmarker) *BenchmarkMirrorsUseCase {
+	return &BenchmarkMirrorsUseCase{
+		benchmarker: benchmarker,
+	}
+}
+
+// Execute benchmarks all requested mirrors and ranks them
+func (uc *BenchmarkMirrorsUseCase) Execute(ctx context.Context, req BenchmarkMirrorsRequest) (*BenchmarkMirrorsResponse, error) {
+	uris := req.Mirrors
+	if len(uris) == 0 {
+		uris = domainRepo.DefaultDebianMirrors
+	}
+
+	suite := req.Suite
+	if suite == "" {
+		suite = "sid"
+	}
+
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	mirrors := make([]domainRepo.Mirror, 0, len(uris))
+	for _, uri := range uris {
+		mirror, err := domainRepo.NewMirror(uri)
+		if err != nil {
+			return nil, err
+		}
+		mirrors = append(mirrors, mirror)
+	}
+
+	benchmarks := make([]domainRepo.MirrorBenchmark, len(mirrors))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, mirror := range mirrors {
+		wg.Add(1)
+		go func(i int, mirror domainRepo.Mirror) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			benchmarks[i] = uc.benchmarker.Benchmark(ctx, mirror, suite)
+		}(i, mirror)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ranked := domainRepo.RankMirrors(benchmarks)
+	response := &BenchmarkMirrorsResponse{
+		Results: make([]MirrorResult, 0, len(ranked)),
+	}
+	for _, b := range ranked {
+		result := MirrorResult{
+			URI:        b.Mirror.URI(),
+			Host:       b.Mirror.Host(),
+			Latency:    b.Latency,
+			Throughput: b.Throughput,
+		}
+		if b.Err != nil {
+			result.Error = b.Err.Error()
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	if fastest, err := domainRepo.FastestMirror(ranked); err == nil {
+		response.Fastest = fastest.Mirror.URI()
+	}
+
+	return response, nil
+}
+
+// SwitchMirrorUseCase handles rewriting apt sources to use a mirror
+type SwitchMirrorUseCase struct {
+	manager     Deb822SourcesManager
+	snapshotter SourcesSnapshotter
+}
+
+// NewSwitchMirrorUseCase creates a new use case instance
+func NewSwitchMirrorUseCase(manager Deb822SourcesManager, snapshotter SourcesSnapshotter) *SwitchMirrorUseCase {
+	return &SwitchMirrorUseCase{
+		manager:     manager,
+		snapshotter: snapshotter,
+	}
+}
+
+// Execute snapshots the current sources, rewrites the Debian archive entries
+// to the selected mirror in deb822 format and disables the legacy entries
+func (uc *SwitchMirrorUseCase) Execute(ctx context.Context, req SwitchMirrorRequest) (*SwitchMirrorResponse, error) {
+	mirror, err := domainRepo.NewMirror(req.MirrorURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect entries from both the legacy file and an existing deb822 file,
+	// so systems that already migrated are handled the same way
+	var entries []domainRepo.SourceEntry
+	legacyHasEntries := false
+	for _, path := range []string{req.SourcesListPath, req.DebianSourcesPath} {
+		exists, err := uc.manager.Exists(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", path, err)
+		}
+		if !exists {
+			continue
+		}
+
+		config, err := uc.manager.ReadConfig(path)
+		if errors.Is(err, domainRepo.ErrEmptyEntries) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read repository config: %w", err)
+		}
+
+		entries = append(entries, config.Entries()...)
+		if path == req.SourcesListPath {
+			legacyHasEntries = true
+		}
+	}
+
+	config, err := domainRepo.NewRepositoryConfig(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository config: %w", err)
+	}
+
+	rewritten := config.SwitchMirror(mirror)
+
+	snapshot, err := uc.snapshotter.Snapshot(
+		[]string{req.SourcesListPath, req.DebianSourcesPath},
+		req.SnapshotDir,
+		fmt.Sprintf("switch mirror to %s", mirror.URI()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot sources: %w", err)
+	}
+
+	if err := uc.manager.WriteDeb822Config(req.DebianSourcesPath, config); err != nil {
+		return nil, fmt.Errorf("failed to write deb822 sources: %w", err)
+	}
+
+	if legacyHasEntries {
+		note := fmt.Sprintf("Entries moved to %s by gohan; revert with: gohan repo fastest-mirror --revert", req.DebianSourcesPath)
+		if err := uc.manager.DisableLegacySources(req.SourcesListPath, note); err != nil {
+			return nil, fmt.Errorf("failed to disable legacy sources: %w", err)
+		}
+	}
+
+	return &SwitchMirrorResponse{
+		Mirror:            mirror.URI(),
+		EntriesRewritten:  rewritten,
+		DebianSourcesPath: req.DebianSourcesPath,
+		SnapshotPath:      snapshot.Dir,
+	}, nil
+}
+
+// RevertMirrorUseCase handles restoring sources from the last snapshot
+type RevertMirrorUseCase struct {
+	snapshotter SourcesSnapshotter
+}
+
+// NewRevertMirrorUseCase creates a new use case instance
+func NewRevertMirrorUseCase(snapshotter SourcesSnapshotter) *RevertMirrorUseCase {
+	return &RevertMirrorUseCase{
+		snapshotter: snapshotter,
+	}
+}
+
+// Execute restores the most recent sources snapshot
+func (uc *RevertMirrorUseCase) Execute(ctx context.Context, req RevertMirrorRequest) (*RevertMirrorResponse, error) {
+	snapshot, err := uc.snapshotter.Latest(req.SnapshotDir)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevertMirrorResponse{
+		SnapshotPath: snapshot.Dir,
+		SnapshotTime: snapshot.CreatedAt,
+	}
+	for _, file := range snapshot.Files {
+		if file.Existed {
+			response.FilesRestored = append(response.FilesRestored, file.Path)
+		} else {
+			response.FilesRemoved = append(response.FilesRemoved, file.Path)
+		}
+	}
+
+	if err := uc.snapshotter.Restore(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	return response, nil
+}
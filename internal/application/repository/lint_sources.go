@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+)
+
+// LintSourcesRequest contains parameters for checking apt sources sanity
+type LintSourcesRequest struct {
+	SourcesListPath string
+	SourcesDir      string
+	Fix             bool   // Apply automatic fixes (duplicate removal)
+	SnapshotDir     string // Where the sources are saved before fixing
+}
+
+// SourceIssueDTO describes a single problem in the apt sources
+type SourceIssueDTO struct {
+	Kind     string
+	Location string // path:line
+	Message  string
+	Fixable  bool
+}
+
+// LintSourcesResponse contains the problems found and any fixes applied
+type LintSourcesResponse struct {
+	FilesChecked  int
+	Entries       int
+	Issues        []SourceIssueDTO
+	FixableIssues int
+	FixesApplied  int
+	FilesModified []string
+	SnapshotPath  string
+}
+
+// SourcesLinter is the interface for reading located entries and repairing them
+type SourcesLinter interface {
+	ListSourcesFiles(listPath, dir string) ([]string, error)
+	ReadLocatedEntries(path string) ([]domainRepo.LocatedEntry, []domainRepo.SourceIssue, error)
+	ApplySourceFixes(path string, fixes []domainRepo.SourceFix) error
+}
+
+// LintSourcesUseCase handles checking apt sources for duplicates, mixed
+// suites and missing signed-by keys
+type LintSourcesUseCase struct {
+	linter      SourcesLinter
+	snapshotter SourcesSnapshotter
+}
+
+// NewLintSourcesUseCase creates a new use case instance
+func NewLintSourcesUseCase(linter SourcesLinter, snapshotter SourcesSnapshotter) *LintSourcesUseCase {
+	return &LintSourcesUseCase{
+		linter:      linter,
+		snapshotter: snapshotter,
+	}
+}
+
+// Execute lints every sources file and, when requested, applies the fixes
+func (uc *LintSourcesUseCase) Execute(ctx context.Context, req LintSourcesRequest) (*LintSourcesResponse, error) {
+	paths, err := uc.linter.ListSourcesFiles(req.SourcesListPath, req.SourcesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources files: %w", err)
+	}
+
+	var entries []domainRepo.LocatedEntry
+	var parseIssues []domainRepo.SourceIssue
+	for _, path := range paths {
+		fileEntries, fileIssues, err := uc.linter.ReadLocatedEntries(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+		parseIssues = append(parseIssues, fileIssues...)
+	}
+
+	report := domainRepo.LintSources(entries)
+
+	response := &LintSourcesResponse{
+		FilesChecked:  len(paths),
+		Entries:       len(entries),
+		Issues:        make([]SourceIssueDTO, 0, len(parseIssues)+len(report.Issues)),
+		FixableIssues: report.FixableIssues(),
+	}
+	for _, issue := range append(parseIssues, report.Issues...) {
+		response.Issues = append(response.Issues, SourceIssueDTO{
+			Kind:     string(issue.Kind),
+			Location: issue.Location(),
+			Message:  issue.Message,
+			Fixable:  issue.Fixable,
+		})
+	}
+
+	if !req.Fix || len(report.Fixes) == 0 {
+		return response, nil
+	}
+
+	fixesByPath := make(map[string][]domainRepo.SourceFix)
+	var modified []string
+	for _, fix := range report.Fixes {
+		if _, ok := fixesByPath[fix.Path]; !ok {
+			modified = append(modified, fix.Path)
+		}
+		fixesByPath[fix.Path] = append(fixesByPath[fix.Path], fix)
+	}
+
+	snapshot, err := uc.snapshotter.Snapshot(modified, req.SnapshotDir, "fix duplicate apt sources")
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot sources: %w", err)
+	}
+	response.SnapshotPath = snapshot.Dir
+
+	for _, path := range modified {
+		if err := uc.linter.ApplySourceFixes(path, fixesByPath[path]); err != nil {
+			return nil, fmt.Errorf("failed to fix %s: %w", path, err)
+		}
+		response.FixesApplied += len(fixesByPath[path])
+		response.FilesModified = append(response.FilesModified, path)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,439 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+)
+
+// Audit actions recorded for key operations
+const (
+	auditActionAddKey     = "key-add"
+	auditActionRemoveKey  = "key-remove"
+	auditActionRefreshKey = "key-refresh"
+)
+
+// AddKeyRequest contains parameters for adding a repository signing key
+type AddKeyRequest struct {
+	Name        string // Short name, used for the keyring and sources file names
+	URL         string // Where to download the key from
+	Fingerprint string // Pinned fingerprint the key must match
+
+	// Optional repository to add, referencing the key via Signed-By
+	RepoURI    string
+	Suites     []string
+	Components []string
+	SourcesDir string // Directory for the generated .sources file
+}
+
+// AddKeyResponse contains the result of adding a key
+type AddKeyResponse struct {
+	Name        string
+	Fingerprint string
+	KeyringPath string
+	SourcesPath string
+}
+
+// RemoveKeyRequest contains parameters for removing a managed key
+type RemoveKeyRequest struct {
+	Name string
+}
+
+// RemoveKeyResponse contains the result of removing a key
+type RemoveKeyResponse struct {
+	Name         string
+	FilesRemoved []string
+}
+
+// ListKeysResponse contains all keys managed by gohan
+type ListKeysResponse struct {
+	Keys []KeySummary
+}
+
+// RefreshKeysResponse contains the outcome for every managed key
+type RefreshKeysResponse struct {
+	Keys []KeyRefresh
+}
+
+// Failed returns the number of keys that could not be refreshed
+func (r *RefreshKeysResponse) Failed() int {
+	failed := 0
+	for _, key := range r.Keys {
+		if key.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// KeyRefresh is the outcome of refreshing one key
+type KeyRefresh struct {
+	Name    string
+	Updated bool  // The downloaded key differed and replaced the keyring
+	Err     error // Download or verification failure; the keyring is left as is
+}
+
+// KeySummary describes a managed key and the state of its keyring file
+type KeySummary struct {
+	Name        string
+	Fingerprint string
+	KeyringPath string
+	SourceURL   string
+	SourcesPath string
+	AddedAt     time.Time
+	Installed   bool // Keyring file exists
+	Verified    bool // Keyring file still contains the pinned fingerprint
+}
+
+// KeyringManager is the interface for installing and tracking repository keys
+type KeyringManager interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+	Inspect(data []byte) ([]byte, []domainRepo.PublicKey, error)
+	Install(name string, binaryKey []byte) (string, error)
+	Replace(path string, binaryKey []byte) error
+	ReadKeyring(path string) ([]byte, error)
+	RemoveFile(path string) error
+	LoadKeys() ([]domainRepo.ManagedKey, error)
+	SaveKeys(keys []domainRepo.ManagedKey) error
+	Audit(action string, key domainRepo.ManagedKey, opErr error) error
+}
+
+// AddKeyUseCase handles downloading, verifying and installing a key
+type AddKeyUseCase struct {
+	keys    KeyringManager
+	sources SourcesListManager
+}
+
+// NewAddKeyUseCase creates a new use case instance
+func NewAddKeyUseCase(keys KeyringManager, sources SourcesListManager) *AddKeyUseCase {
+	return &AddKeyUseCase{
+		keys:    keys,
+		sources: sources,
+	}
+}
+
+// Execute adds a key. Every attempt, successful or not, is audited.
+func (uc *AddKeyUseCase) Execute(ctx context.Context, req AddKeyRequest) (*AddKeyResponse, error) {
+	key := domainRepo.ManagedKey{
+		Name:      req.Name,
+		SourceURL: req.URL,
+		AddedAt:   time.Now(),
+	}
+
+	resp, err := uc.add(ctx, req, &key)
+	if auditErr := uc.keys.Audit(auditActionAddKey, key, err); auditErr != nil && err == nil {
+		return nil, fmt.Errorf("key installed but audit logging failed: %w", auditErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (uc *AddKeyUseCase) add(ctx context.Context, req AddKeyRequest, key *domainRepo.ManagedKey) (*AddKeyResponse, error) {
+	if err := domainRepo.ValidateKeyName(req.Name); err != nil {
+		return nil, err
+	}
+
+	pinned, err := domainRepo.NewKeyFingerprint(req.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	key.Fingerprint = pinned
+
+	existing, err := uc.keys.LoadKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range existing {
+		if k.Name == req.Name {
+			return nil, fmt.Errorf("%w: %s", domainRepo.ErrKeyExists, req.Name)
+		}
+	}
+
+	data, err := uc.keys.Fetch(ctx, req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	binaryKey, publicKeys, err := uc.keys.Inspect(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := domainRepo.VerifyFingerprints(pinned, publicKeys); err != nil {
+		return nil, err
+	}
+
+	// Refuse to take over a sources file gohan did not write before the
+	// keyring is installed, so a refusal leaves nothing behind
+	if req.RepoURI != "" {
+		exists, err := uc.sources.Exists(uc.sourcesPath(req))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: %s", domainRepo.ErrFileExists, uc.sourcesPath(req))
+		}
+	}
+
+	keyringPath, err := uc.keys.Install(req.Name, binaryKey)
+	if err != nil {
+		return nil, err
+	}
+	key.KeyringPath = keyringPath
+
+	if req.RepoURI != "" {
+		sourcesPath, err := uc.writeSources(req, keyringPath)
+		if err != nil {
+			_ = uc.keys.RemoveFile(keyringPath)
+			return nil, err
+		}
+		key.SourcesPath = sourcesPath
+	}
+
+	if err := uc.keys.SaveKeys(append(existing, *key)); err != nil {
+		return nil, err
+	}
+
+	return &AddKeyResponse{
+		Name:        key.Name,
+		Fingerprint: pinned.Pretty(),
+		KeyringPath: key.KeyringPath,
+		SourcesPath: key.SourcesPath,
+	}, nil
+}
+
+// writeSources creates a deb822 file for the repository signed by the key
+func (uc *AddKeyUseCase) writeSources(req AddKeyRequest, keyringPath string) (string, error) {
+	suites := req.Suites
+	if len(suites) == 0 {
+		return "", fmt.Errorf("at least one suite is required when adding a repository")
+	}
+	components := req.Components
+	if len(components) == 0 {
+		components = []string{"main"}
+	}
+
+	entries := make([]domainRepo.SourceEntry, 0, len(suites))
+	for _, suite := range suites {
+		entry := domainRepo.SourceEntry{
+			Type:     "deb",
+			URI:      req.RepoURI,
+			Suite:    suite,
+			SignedBy: keyringPath,
+		}
+		if !entry.IsFlat() {
+			entry.Components = components
+		}
+		entries = append(entries, entry)
+	}
+
+	config, err := domainRepo.NewRepositoryConfig(entries)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository: %w", err)
+	}
+
+	path := uc.sourcesPath(req)
+	if err := uc.sources.WriteConfig(path, config); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// sourcesPath returns where the sources file for a key's repository goes
+func (uc *AddKeyUseCase) sourcesPath(req AddKeyRequest) string {
+	return filepath.Join(req.SourcesDir, req.Name+".sources")
+}
+
+// RemoveKeyUseCase handles removing a managed key and its repository
+type RemoveKeyUseCase struct {
+	keys KeyringManager
+}
+
+// NewRemoveKeyUseCase creates a new use case instance
+func NewRemoveKeyUseCase(keys KeyringManager) *RemoveKeyUseCase {
+	return &RemoveKeyUseCase{
+		keys: keys,
+	}
+}
+
+// Execute removes the keyring, the sources file gohan created for it and
+// the registry entry. The operation is audited.
+func (uc *RemoveKeyUseCase) Execute(ctx context.Context, req RemoveKeyRequest) (*RemoveKeyResponse, error) {
+	key := domainRepo.ManagedKey{Name: req.Name}
+
+	resp, err := uc.remove(req, &key)
+	if auditErr := uc.keys.Audit(auditActionRemoveKey, key, err); auditErr != nil && err == nil {
+		return nil, fmt.Errorf("key removed but audit logging failed: %w", auditErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (uc *RemoveKeyUseCase) remove(req RemoveKeyRequest, key *domainRepo.ManagedKey) (*RemoveKeyResponse, error) {
+	keys, err := uc.keys.LoadKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i, k := range keys {
+		if k.Name == req.Name {
+			index = i
+			*key = k
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %s", domainRepo.ErrKeyNotFound, req.Name)
+	}
+
+	response := &RemoveKeyResponse{Name: req.Name}
+
+	// Remove the repository first so apt never references a missing keyring
+	for _, path := range []string{key.SourcesPath, key.KeyringPath} {
+		if path == "" {
+			continue
+		}
+		if err := uc.keys.RemoveFile(path); err != nil {
+			return nil, err
+		}
+		response.FilesRemoved = append(response.FilesRemoved, path)
+	}
+
+	remaining := append(keys[:index:index], keys[index+1:]...)
+	if err := uc.keys.SaveKeys(remaining); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ListKeysUseCase handles listing managed keys
+type ListKeysUseCase struct {
+	keys KeyringManager
+}
+
+// NewListKeysUseCase creates a new use case instance
+func NewListKeysUseCase(keys KeyringManager) *ListKeysUseCase {
+	return &ListKeysUseCase{
+		keys: keys,
+	}
+}
+
+// Execute lists managed keys and re-verifies each installed keyring
+// against its pinned fingerprint
+func (uc *ListKeysUseCase) Execute(ctx context.Context) (*ListKeysResponse, error) {
+	keys, err := uc.keys.LoadKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListKeysResponse{
+		Keys: make([]KeySummary, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		summary := KeySummary{
+			Name:        key.Name,
+			Fingerprint: key.Fingerprint.Pretty(),
+			KeyringPath: key.KeyringPath,
+			SourceURL:   key.SourceURL,
+			SourcesPath: key.SourcesPath,
+			AddedAt:     key.AddedAt,
+		}
+
+		if data, err := uc.keys.ReadKeyring(key.KeyringPath); err == nil {
+			summary.Installed = true
+			if _, publicKeys, err := uc.keys.Inspect(data); err == nil {
+				summary.Verified = domainRepo.VerifyFingerprints(key.Fingerprint, publicKeys) == nil
+			}
+		}
+
+		response.Keys = append(response.Keys, summary)
+	}
+
+	return response, nil
+}
+
+// RefreshKeysUseCase handles re-downloading managed keys, so rotated
+// subkeys and extended expiry dates reach the installed keyrings
+type RefreshKeysUseCase struct {
+	keys KeyringManager
+}
+
+// NewRefreshKeysUseCase creates a new use case instance
+func NewRefreshKeysUseCase(keys KeyringManager) *RefreshKeysUseCase {
+	return &RefreshKeysUseCase{
+		keys: keys,
+	}
+}
+
+// Execute downloads every managed key from its source and replaces the
+// keyring only when the download still matches the pinned fingerprint.
+// Each key is audited; one failing key does not stop the others.
+func (uc *RefreshKeysUseCase) Execute(ctx context.Context) (*RefreshKeysResponse, error) {
+	keys, err := uc.keys.LoadKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RefreshKeysResponse{
+		Keys: make([]KeyRefresh, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		updated, err := uc.refresh(ctx, key)
+		if auditErr := uc.keys.Audit(auditActionRefreshKey, key, err); auditErr != nil && err == nil {
+			err = fmt.Errorf("key refreshed but audit logging failed: %w", auditErr)
+		}
+
+		response.Keys = append(response.Keys, KeyRefresh{
+			Name:    key.Name,
+			Updated: updated,
+			Err:     err,
+		})
+	}
+
+	return response, nil
+}
+
+func (uc *RefreshKeysUseCase) refresh(ctx context.Context, key domainRepo.ManagedKey) (bool, error) {
+	data, err := uc.keys.Fetch(ctx, key.SourceURL)
+	if err != nil {
+		return false, err
+	}
+
+	binaryKey, publicKeys, err := uc.keys.Inspect(data)
+	if err != nil {
+		return false, err
+	}
+
+	if err := domainRepo.VerifyFingerprints(key.Fingerprint, publicKeys); err != nil {
+		return false, err
+	}
+
+	if installed, err := uc.keys.ReadKeyring(key.KeyringPath); err == nil && bytes.Equal(installed, binaryKey) {
+		return false, nil
+	}
+
+	if err := uc.keys.Replace(key.KeyringPath, binaryKey); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
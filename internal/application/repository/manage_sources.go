@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
@@ -13,6 +15,7 @@ import (
 // CheckRepositoryRequest contains parameters for checking repository configuration
 type CheckRepositoryRequest struct {
 	SourcesListPath string // Path to sources.list file
+	SourcesDir      string // Optional sources.list.d directory (.list and deb822 .sources files)
 }
 
 // CheckRepositoryResponse contains the current repository configuration status
@@ -25,12 +28,22 @@ type CheckRepositoryResponse struct {
 	TotalEntries         int
 	DebEntries           int
 	DebSrcEntries        int
+	DisabledEntries      int
 	SourcesListContent   string
+	Files                []SourcesFileSummary
+}
+
+// SourcesFileSummary describes a single sources file that was read
+type SourcesFileSummary struct {
+	Path    string
+	Format  string // "one-line" or "deb822"
+	Entries int
 }
 
 // EnableNonFreeRequest contains parameters for enabling non-free repositories
 type EnableNonFreeRequest struct {
 	SourcesListPath string
+	SourcesDir      string // Optional sources.list.d directory; Debian archive files in it are updated too
 	BackupFirst     bool
 }
 
@@ -38,12 +51,15 @@ type EnableNonFreeRequest struct {
 type EnableNonFreeResponse struct {
 	Modified         bool
 	BackupPath       string
+	BackupPaths      []string
+	FilesModified    []string
 	ComponentsAdded  []string
 }
 
 // EnableDebSrcRequest contains parameters for enabling deb-src
 type EnableDebSrcRequest struct {
 	SourcesListPath string
+	SourcesDir      string // Optional sources.list.d directory; Debian archive files in it are updated too
 	BackupFirst     bool
 }
 
@@ -51,6 +67,8 @@ type EnableDebSrcRequest struct {
 type EnableDebSrcResponse struct {
 	Modified       bool
 	BackupPath     string
+	BackupPaths    []string
+	FilesModified  []string
 	EntriesAdded   int
 }
 
@@ -67,12 +85,69 @@ type BackupSourcesListResponse struct {
 	Size       int64
 }
 
-// SourcesListManager is the interface for managing sources.list files
+// SourcesListManager is the interface for managing sources.list and deb822 .sources files
 type SourcesListManager interface {
 	ReadConfig(path string) (*domainRepo.RepositoryConfig, error)
 	WriteConfig(path string, config *domainRepo.RepositoryConfig) error
 	Backup(path string, backupDir string) (string, error)
 	Exists(path string) (bool, error)
+	ListSourcesFiles(listPath, dir string) ([]string, error)
+}
+
+// sourcesFile pairs a sources file path with its parsed configuration
+type sourcesFile struct {
+	path   string
+	config *domainRepo.RepositoryConfig
+}
+
+// readSourcesFiles reads the main sources file and, when dir is set, every
+// sources file in it. In directory mode, files without entries are skipped.
+func readSourcesFiles(manager SourcesListManager, listPath, dir string) ([]sourcesFile, error) {
+	if dir == "" {
+		config, err := manager.ReadConfig(listPath)
+		if err != nil {
+			return nil, err
+		}
+		return []sourcesFile{{path: listPath, config: config}}, nil
+	}
+
+	paths, err := manager.ListSourcesFiles(listPath, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]sourcesFile, 0, len(paths))
+	for _, path := range paths {
+		config, err := manager.ReadConfig(path)
+		if errors.Is(err, domainRepo.ErrEmptyEntries) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, sourcesFile{path: path, config: config})
+	}
+
+	if len(files) == 0 {
+		return nil, domainRepo.ErrEmptyEntries
+	}
+
+	return files, nil
+}
+
+// isDebianSourcesFile reports whether a sources file carries official Debian
+// archive entries. Only these files are modified when enabling components,
+// so third-party repositories are left alone.
+func isDebianSourcesFile(file sourcesFile, mainPath string) bool {
+	if file.path == mainPath {
+		return true
+	}
+	for _, entry := range file.config.Entries() {
+		if domainRepo.IsDebianArchiveURI(entry.URI) {
+			return true
+		}
+	}
+	return false
 }
 
 // CheckRepositoryUseCase handles checking repository configuration
@@ -89,38 +164,50 @@ func NewCheckRepositoryUseCase(manager SourcesListManager) *CheckRepositoryUseCa
 
 // Execute checks the current repository configuration
 func (uc *CheckRepositoryUseCase) Execute(ctx context.Context, req CheckRepositoryRequest) (*CheckRepositoryResponse, error) {
-	// Read repository config
-	config, err := uc.manager.ReadConfig(req.SourcesListPath)
+	// Read repository configs
+	files, err := readSourcesFiles(uc.manager, req.SourcesListPath, req.SourcesDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read repository config: %w", err)
 	}
 
-	// Get entries
-	entries := config.Entries()
-
-	// Count entry types
-	debCount := 0
-	debSrcCount := 0
-	for _, entry := range entries {
-		if entry.Type == "deb" {
-			debCount++
-		} else if entry.Type == "deb-src" {
-			debSrcCount++
+	response := &CheckRepositoryResponse{}
+	var contents []string
+
+	for _, file := range files {
+		config := file.config
+		entries := config.Entries()
+
+		response.HasMain = response.HasMain || config.HasComponent("main")
+		response.HasContrib = response.HasContrib || config.HasComponent("contrib")
+		response.HasNonFree = response.HasNonFree || config.HasComponent("non-free")
+		response.HasNonFreeFirmware = response.HasNonFreeFirmware || config.HasComponent("non-free-firmware")
+		response.HasDebSrc = response.HasDebSrc || config.HasDebSrc()
+
+		// Count entry types
+		for _, entry := range entries {
+			if entry.Disabled {
+				response.DisabledEntries++
+				continue
+			}
+			if entry.Type == "deb" {
+				response.DebEntries++
+			} else if entry.Type == "deb-src" {
+				response.DebSrcEntries++
+			}
 		}
+		response.TotalEntries += len(entries)
+
+		response.Files = append(response.Files, SourcesFileSummary{
+			Path:    file.path,
+			Format:  string(domainRepo.SourcesFormatForPath(file.path)),
+			Entries: len(entries),
+		})
+		contents = append(contents, config.String())
 	}
 
-	// Build response
-	return &CheckRepositoryResponse{
-		HasMain:            config.HasComponent("main"),
-		HasContrib:         config.HasComponent("contrib"),
-		HasNonFree:         config.HasComponent("non-free"),
-		HasNonFreeFirmware: config.HasComponent("non-free-firmware"),
-		HasDebSrc:          config.HasDebSrc(),
-		TotalEntries:       len(entries),
-		DebEntries:         debCount,
-		DebSrcEntries:      debSrcCount,
-		SourcesListContent: config.String(),
-	}, nil
+	response.SourcesListContent = strings.Join(contents, "\n")
+
+	return response, nil
 }
 
 // EnableNonFreeUseCase handles enabling non-free repositories
@@ -141,47 +228,55 @@ func (uc *EnableNonFreeUseCase) Execute(ctx context.Context, req EnableNonFreeRe
 		ComponentsAdded: []string{},
 	}
 
-	// Backup if requested
-	if req.BackupFirst {
-		backupPath, err := uc.manager.Backup(req.SourcesListPath, filepath.Dir(req.SourcesListPath))
-		if err != nil {
-			return nil, fmt.Errorf("failed to backup sources.list: %w", err)
-		}
-		response.BackupPath = backupPath
-	}
-
-	// Read repository config
-	config, err := uc.manager.ReadConfig(req.SourcesListPath)
+	// Read repository configs
+	files, err := readSourcesFiles(uc.manager, req.SourcesListPath, req.SourcesDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read repository config: %w", err)
 	}
 
-	// Check what's missing
-	needsNonFree := !config.HasComponent("non-free")
-	needsNonFreeFirmware := !config.HasComponent("non-free-firmware")
+	for _, file := range files {
+		if !isDebianSourcesFile(file, req.SourcesListPath) {
+			continue
+		}
+		config := file.config
+
+		// Check what's missing
+		var added []string
+		for _, component := range []string{"non-free", "non-free-firmware"} {
+			if !config.HasComponent(component) {
+				config.AddComponent(component)
+				added = append(added, component)
+			}
+		}
 
-	if !needsNonFree && !needsNonFreeFirmware {
-		// Already has everything
-		return response, nil
-	}
+		if len(added) == 0 {
+			// Already has everything
+			continue
+		}
 
-	// Add components
-	if needsNonFree {
-		config.AddComponent("non-free")
-		response.ComponentsAdded = append(response.ComponentsAdded, "non-free")
-		response.Modified = true
-	}
+		// Backup if requested
+		if req.BackupFirst {
+			backupPath, err := uc.manager.Backup(file.path, filepath.Dir(req.SourcesListPath))
+			if err != nil {
+				return nil, fmt.Errorf("failed to backup %s: %w", file.path, err)
+			}
+			if response.BackupPath == "" {
+				response.BackupPath = backupPath
+			}
+			response.BackupPaths = append(response.BackupPaths, backupPath)
+		}
 
-	if needsNonFreeFirmware {
-		config.AddComponent("non-free-firmware")
-		response.ComponentsAdded = append(response.ComponentsAdded, "non-free-firmware")
-		response.Modified = true
-	}
+		// Write updated sources file
+		if err := uc.manager.WriteConfig(file.path, config); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.path, err)
+		}
 
-	// Write updated sources.list
-	if response.Modified {
-		if err := uc.manager.WriteConfig(req.SourcesListPath, config); err != nil {
-			return nil, fmt.Errorf("failed to write sources.list: %w", err)
+		response.Modified = true
+		response.FilesModified = append(response.FilesModified, file.path)
+		for _, component := range added {
+			if !containsString(response.ComponentsAdded, component) {
+				response.ComponentsAdded = append(response.ComponentsAdded, component)
+			}
 		}
 	}
 
@@ -204,42 +299,51 @@ func NewEnableDebSrcUseCase(manager SourcesListManager) *EnableDebSrcUseCase {
 func (uc *EnableDebSrcUseCase) Execute(ctx context.Context, req EnableDebSrcRequest) (*EnableDebSrcResponse, error) {
 	response := &EnableDebSrcResponse{}
 
-	// Backup if requested
-	if req.BackupFirst {
-		backupPath, err := uc.manager.Backup(req.SourcesListPath, filepath.Dir(req.SourcesListPath))
-		if err != nil {
-			return nil, fmt.Errorf("failed to backup sources.list: %w", err)
-		}
-		response.BackupPath = backupPath
-	}
-
-	// Read repository config
-	config, err := uc.manager.ReadConfig(req.SourcesListPath)
+	// Read repository configs
+	files, err := readSourcesFiles(uc.manager, req.SourcesListPath, req.SourcesDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read repository config: %w", err)
 	}
 
-	// Check if deb-src already enabled
-	if config.HasDebSrc() {
-		return response, nil
-	}
+	for _, file := range files {
+		if !isDebianSourcesFile(file, req.SourcesListPath) {
+			continue
+		}
+		config := file.config
 
-	// Count entries before
-	entriesBefore := len(config.Entries())
+		// Check if deb-src already enabled
+		if config.HasDebSrc() {
+			continue
+		}
 
-	// Enable deb-src
-	config.EnableDebSrc()
+		// Enable deb-src and count the entries added
+		entriesBefore := len(config.Entries())
+		config.EnableDebSrc()
+		added := len(config.Entries()) - entriesBefore
+		if added == 0 {
+			continue
+		}
 
-	// Count entries after
-	entriesAfter := len(config.Entries())
-	response.EntriesAdded = entriesAfter - entriesBefore
-	response.Modified = response.EntriesAdded > 0
+		// Backup if requested
+		if req.BackupFirst {
+			backupPath, err := uc.manager.Backup(file.path, filepath.Dir(req.SourcesListPath))
+			if err != nil {
+				return nil, fmt.Errorf("failed to backup %s: %w", file.path, err)
+			}
+			if response.BackupPath == "" {
+				response.BackupPath = backupPath
+			}
+			response.BackupPaths = append(response.BackupPaths, backupPath)
+		}
 
-	// Write updated sources.list
-	if response.Modified {
-		if err := uc.manager.WriteConfig(req.SourcesListPath, config); err != nil {
-			return nil, fmt.Errorf("failed to write sources.list: %w", err)
+		// Write updated sources file
+		if err := uc.manager.WriteConfig(file.path, config); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.path, err)
 		}
+
+		response.EntriesAdded += added
+		response.Modified = true
+		response.FilesModified = append(response.FilesModified, file.path)
 	}
 
 	return response, nil
@@ -277,3 +381,12 @@ func (uc *BackupSourcesListUseCase) Execute(ctx context.Context, req BackupSourc
 		Size:       info.Size(),
 	}, nil
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
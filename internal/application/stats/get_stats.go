@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/stats"
+)
+
+// GetStatsResponse contains aggregated local usage statistics
+type GetStatsResponse struct {
+	TotalRuns       int
+	SucceededRuns   int
+	FailedRuns      int
+	SuccessRate     float64
+	AverageDuration time.Duration
+	TotalComponents int
+	FailurePhases   []PhaseCountDTO
+	FirstRun        time.Time
+	LastRun         time.Time
+}
+
+// PhaseCountDTO represents failures in one installation phase
+type PhaseCountDTO struct {
+	Phase string
+	Count int
+}
+
+// GetStatsUseCase summarizes the locally recorded install runs
+type GetStatsUseCase struct {
+	repo stats.Repository
+}
+
+// NewGetStatsUseCase creates a new use case instance
+func NewGetStatsUseCase(repo stats.Repository) *GetStatsUseCase {
+	return &GetStatsUseCase{repo: repo}
+}
+
+// Execute returns the aggregated statistics
+func (uc *GetStatsUseCase) Execute(ctx context.Context) (*GetStatsResponse, error) {
+	runs, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load statistics: %w", err)
+	}
+
+	summary := stats.Summarize(runs)
+	response := &GetStatsResponse{
+		TotalRuns:       summary.TotalRuns,
+		SucceededRuns:   summary.SucceededRuns,
+		FailedRuns:      summary.FailedRuns,
+		SuccessRate:     summary.SuccessRate(),
+		AverageDuration: summary.AverageDuration,
+		TotalComponents: summary.TotalComponents,
+		FailurePhases:   make([]PhaseCountDTO, 0, len(summary.FailurePhases)),
+		FirstRun:        summary.FirstRun,
+		LastRun:         summary.LastRun,
+	}
+	for _, p := range summary.FailurePhases {
+		response.FailurePhases = append(response.FailurePhases, PhaseCountDTO{Phase: p.Phase, Count: p.Count})
+	}
+
+	return response, nil
+}
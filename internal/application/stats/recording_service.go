@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/stats"
+)
+
+// RecordingService turns finished installation sessions into anonymous
+// install run counters
+type RecordingService struct {
+	repo stats.Repository
+}
+
+// NewRecordingService creates a new stats recording service
+func NewRecordingService(repo stats.Repository) *RecordingService {
+	return &RecordingService{repo: repo}
+}
+
+// RecordInstallation records the outcome, duration and failure phase of a
+// finished session. Nothing identifying the session is stored.
+func (s *RecordingService) RecordInstallation(ctx context.Context, session *installation.InstallationSession) error {
+	var outcome stats.Outcome
+	switch {
+	case session.IsCompleted():
+		outcome = stats.OutcomeSucceeded
+	case session.IsFailed():
+		outcome = stats.OutcomeFailed
+	default:
+		return fmt.Errorf("cannot record incomplete session: status is %s", session.Status())
+	}
+
+	duration := session.CompletedAt().Sub(session.StartedAt())
+	if session.StartedAt().IsZero() || duration < 0 {
+		duration = 0
+	}
+
+	// The session keeps the last reported progress phase when it fails
+	run, err := stats.NewInstallRun(
+		session.CompletedAt(),
+		outcome,
+		duration,
+		session.Progress().Phase(),
+		len(session.Configuration().Components()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create install run: %w", err)
+	}
+
+	return s.repo.Record(ctx, run)
+}
+
+// HistoryRecorder records installation history
+type HistoryRecorder interface {
+	RecordInstallation(ctx context.Context, session *installation.InstallationSession) (history.RecordID, error)
+}
+
+// RecordingHistoryRecorder records local stats alongside installation history,
+// so stats are collected wherever history already is
+type RecordingHistoryRecorder struct {
+	next  HistoryRecorder
+	stats *RecordingService
+}
+
+// NewRecordingHistoryRecorder wraps a history recorder; next may be nil
+func NewRecordingHistoryRecorder(next HistoryRecorder, stats *RecordingService) *RecordingHistoryRecorder {
+	return &RecordingHistoryRecorder{next: next, stats: stats}
+}
+
+// RecordInstallation records stats and then delegates to the history recorder
+// Stats are best effort and never affect history recording
+func (r *RecordingHistoryRecorder) RecordInstallation(
+	ctx context.Context,
+	session *installation.InstallationSession,
+) (history.RecordID, error) {
+	if err := r.stats.RecordInstallation(ctx, session); err != nil {
+		fmt.Printf("Warning: failed to record usage statistics: %v\n", err)
+	}
+
+	if r.next == nil {
+		return history.RecordID{}, nil
+	}
+	return r.next.RecordInstallation(ctx, session)
+}
@@ -0,0 +1,159 @@
+package stats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	statsApp "github.com/rebelopsio/gohan/internal/application/stats"
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStatsRepository struct {
+	runs []stats.InstallRun
+	err  error
+}
+
+func (r *memoryStatsRepository) Record(ctx context.Context, run stats.InstallRun) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.runs = append(r.runs, run)
+	return nil
+}
+
+func (r *memoryStatsRepository) FindAll(ctx context.Context) ([]stats.InstallRun, error) {
+	return r.runs, r.err
+}
+
+func (r *memoryStatsRepository) Clear(ctx context.Context) (int, error) {
+	n := len(r.runs)
+	r.runs = nil
+	return n, nil
+}
+
+type countingHistoryRecorder struct {
+	calls int
+}
+
+func (r *countingHistoryRecorder) RecordInstallation(ctx context.Context, session *installation.InstallationSession) (history.RecordID, error) {
+	r.calls++
+	return history.NewRecordID()
+}
+
+func newSession(t *testing.T) *installation.InstallationSession {
+	t.Helper()
+
+	component, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.45.0", nil)
+	require.NoError(t, err)
+	diskSpace, err := installation.NewDiskSpace(21474836480, 0)
+	require.NoError(t, err)
+	config, err := installation.NewInstallationConfiguration(
+		[]installation.ComponentSelection{component},
+		nil,
+		diskSpace,
+		false,
+	)
+	require.NoError(t, err)
+
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+
+	snapshot, err := installation.NewSystemSnapshot("/tmp/snapshots", diskSpace, []string{})
+	require.NoError(t, err)
+	require.NoError(t, session.StartPreparation(snapshot))
+	require.NoError(t, session.StartInstalling())
+	return session
+}
+
+func TestRecordingService_RecordInstallation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records successful session", func(t *testing.T) {
+		repo := &memoryStatsRepository{}
+		session := newSession(t)
+		installed, err := installation.NewInstalledComponent(installation.ComponentHyprland, "0.45.0", nil)
+		require.NoError(t, err)
+		require.NoError(t, session.AddInstalledComponent(installed))
+		require.NoError(t, session.StartConfiguring())
+		require.NoError(t, session.StartVerifying())
+		require.NoError(t, session.Complete())
+
+		require.NoError(t, statsApp.NewRecordingService(repo).RecordInstallation(ctx, session))
+		require.Len(t, repo.runs, 1)
+		assert.True(t, repo.runs[0].Succeeded())
+		assert.Equal(t, 1, repo.runs[0].ComponentCount())
+		assert.Empty(t, repo.runs[0].FailurePhase())
+	})
+
+	t.Run("records failure phase from last progress", func(t *testing.T) {
+		repo := &memoryStatsRepository{}
+		session := newSession(t)
+		session.UpdateProgress(installation.NewInstallationProgress("Installing Components", 40, "Installing hyprland", time.Now()))
+		require.NoError(t, session.Fail("package conflict"))
+
+		require.NoError(t, statsApp.NewRecordingService(repo).RecordInstallation(ctx, session))
+		require.Len(t, repo.runs, 1)
+		assert.Equal(t, stats.OutcomeFailed, repo.runs[0].Outcome())
+		assert.Equal(t, "Installing Components", repo.runs[0].FailurePhase())
+	})
+
+	t.Run("rejects unfinished session", func(t *testing.T) {
+		repo := &memoryStatsRepository{}
+		err := statsApp.NewRecordingService(repo).RecordInstallation(ctx, newSession(t))
+		assert.Error(t, err)
+		assert.Empty(t, repo.runs)
+	})
+}
+
+func TestRecordingHistoryRecorder(t *testing.T) {
+	ctx := context.Background()
+	session := newSession(t)
+	require.NoError(t, session.Fail("boom"))
+
+	t.Run("records stats and history", func(t *testing.T) {
+		repo := &memoryStatsRepository{}
+		next := &countingHistoryRecorder{}
+		recorder := statsApp.NewRecordingHistoryRecorder(next, statsApp.NewRecordingService(repo))
+
+		id, err := recorder.RecordInstallation(ctx, session)
+		require.NoError(t, err)
+		assert.True(t, id.IsValid())
+		assert.Equal(t, 1, next.calls)
+		assert.Len(t, repo.runs, 1)
+	})
+
+	t.Run("stats failure does not affect history", func(t *testing.T) {
+		repo := &memoryStatsRepository{err: errors.New("database locked")}
+		next := &countingHistoryRecorder{}
+		recorder := statsApp.NewRecordingHistoryRecorder(next, statsApp.NewRecordingService(repo))
+
+		_, err := recorder.RecordInstallation(ctx, session)
+		require.NoError(t, err)
+		assert.Equal(t, 1, next.calls)
+	})
+}
+
+func TestGetStatsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	succeeded, err := stats.NewInstallRun(now, stats.OutcomeSucceeded, 3*time.Minute, "", 2)
+	require.NoError(t, err)
+	failed, err := stats.NewInstallRun(now, stats.OutcomeFailed, time.Minute, "Running Preflight Checks", 1)
+	require.NoError(t, err)
+
+	repo := &memoryStatsRepository{runs: []stats.InstallRun{succeeded, failed}}
+	resp, err := statsApp.NewGetStatsUseCase(repo).Execute(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, resp.TotalRuns)
+	assert.Equal(t, 50.0, resp.SuccessRate)
+	assert.Equal(t, 2*time.Minute, resp.AverageDuration)
+	assert.Equal(t, []statsApp.PhaseCountDTO{{Phase: "Running Preflight Checks", Count: 1}}, resp.FailurePhases)
+}
@@ -14,12 +14,19 @@ type ThemeApplier interface {
 	ApplyTheme(ctx context.Context, th *theme.Theme) error
 }
 
+// ReloadService is the interface for signaling running components to pick
+// up an applied theme
+type ReloadService interface {
+	Reload(ctx context.Context) ([]string, error)
+}
+
 // ApplyThemeResult contains the result of applying a theme
 type ApplyThemeResult struct {
 	Success            bool
 	ThemeName          string
 	Message            string
 	AffectedComponents []string
+	ReloadedComponents []string
 	BackupID           string
 }
 
@@ -29,6 +36,7 @@ type ApplyThemeUseCase struct {
 	applier      ThemeApplier
 	stateStore   themeInfra.ThemeStateStore
 	historyStore themeInfra.ThemeHistoryStore
+	reloader     ReloadService
 }
 
 // NewApplyThemeUseCase creates a new apply theme use case
@@ -46,6 +54,14 @@ func NewApplyThemeUseCase(
 	}
 }
 
+// WithReloadService returns a copy of the use case that reloads running
+// components after applying a theme, so it shows without logging out
+func (uc *ApplyThemeUseCase) WithReloadService(reloader ReloadService) *ApplyThemeUseCase {
+	copied := *uc
+	copied.reloader = reloader
+	return &copied
+}
+
 // Execute applies the specified theme
 func (uc *ApplyThemeUseCase) Execute(ctx context.Context, themeName string) (*ApplyThemeResult, error) {
 	// Find the theme
@@ -100,8 +116,23 @@ func (uc *ApplyThemeUseCase) Execute(ctx context.Context, themeName string) (*Ap
 	}
 
 	return &ApplyThemeResult{
-		Success:   true,
-		ThemeName: themeName,
-		Message:   fmt.Sprintf("Successfully applied theme '%s'", th.DisplayName()),
+		Success:            true,
+		ThemeName:          themeName,
+		Message:            fmt.Sprintf("Successfully applied theme '%s'", th.DisplayName()),
+		ReloadedComponents: reloadComponents(ctx, uc.reloader),
 	}, nil
 }
+
+// reloadComponents reloads running components when reloader is set. The
+// theme is already applied, so failures only warn.
+func reloadComponents(ctx context.Context, reloader ReloadService) []string {
+	if reloader == nil {
+		return nil
+	}
+
+	reloaded, err := reloader.Reload(ctx)
+	if err != nil {
+		fmt.Printf("Warning: some components failed to reload: %v\n", err)
+	}
+	return reloaded
+}
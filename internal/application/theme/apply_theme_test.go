@@ -2,6 +2,7 @@ package theme
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/rebelopsio/gohan/internal/domain/theme"
@@ -109,3 +110,51 @@ func TestApplyThemeUseCase_SetsActiveTheme(t *testing.T) {
 		assert.Equal(t, theme.ThemeLatte, active.Name())
 	})
 }
+
+type fakeReloadService struct {
+	calls    int
+	reloaded []string
+	err      error
+}
+
+func (f *fakeReloadService) Reload(ctx context.Context) ([]string, error) {
+	f.calls++
+	return f.reloaded, f.err
+}
+
+func TestApplyThemeUseCase_ReloadsComponents(t *testing.T) {
+	newUseCase := func(t *testing.T, reloader ReloadService) *ApplyThemeUseCase {
+		registry := theme.NewThemeRegistry()
+		require.NoError(t, theme.InitializeStandardThemes(registry))
+		return NewApplyThemeUseCase(registry, nil, nil, nil).WithReloadService(reloader)
+	}
+
+	t.Run("reloads running components after applying", func(t *testing.T) {
+		reloader := &fakeReloadService{reloaded: []string{"hyprland", "waybar"}}
+
+		result, err := newUseCase(t, reloader).Execute(context.Background(), "nord")
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, reloader.calls)
+		assert.Equal(t, []string{"hyprland", "waybar"}, result.ReloadedComponents)
+	})
+
+	t.Run("a failed reload does not fail the apply", func(t *testing.T) {
+		reloader := &fakeReloadService{reloaded: []string{"waybar"}, err: errors.New("hyprland: exit status 1")}
+
+		result, err := newUseCase(t, reloader).Execute(context.Background(), "latte")
+		require.NoError(t, err)
+
+		assert.True(t, result.Success)
+		assert.Equal(t, []string{"waybar"}, result.ReloadedComponents)
+	})
+
+	t.Run("does not reload for the active theme", func(t *testing.T) {
+		reloader := &fakeReloadService{}
+
+		_, err := newUseCase(t, reloader).Execute(context.Background(), "mocha")
+		require.NoError(t, err)
+
+		assert.Zero(t, reloader.calls)
+	})
+}
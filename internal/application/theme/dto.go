@@ -8,6 +8,7 @@ type ThemeInfo struct {
 	Description string
 	Variant     string // "dark" or "light"
 	IsActive    bool
+	Custom      bool // Imported from a palette file
 	PreviewURL  string
 	ColorScheme map[string]string
 }
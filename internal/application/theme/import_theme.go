@@ -0,0 +1,64 @@
+package theme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/domain/theme"
+)
+
+// CustomThemeStore keeps the user's custom themes
+type CustomThemeStore interface {
+	SaveCustomTheme(ctx context.Context, def theme.CustomThemeDefinition) error
+}
+
+// ImportThemeResult contains the result of importing a custom theme
+type ImportThemeResult struct {
+	Theme    ThemeInfo
+	Replaced bool // A custom theme of the same name was imported before
+}
+
+// ImportThemeUseCase validates a custom theme and stores it, so it can be
+// listed, previewed and applied like the standard themes
+type ImportThemeUseCase struct {
+	registry theme.ThemeRegistry
+	store    CustomThemeStore
+}
+
+// NewImportThemeUseCase creates a new import theme use case
+func NewImportThemeUseCase(registry theme.ThemeRegistry, store CustomThemeStore) *ImportThemeUseCase {
+	return &ImportThemeUseCase{
+		registry: registry,
+		store:    store,
+	}
+}
+
+// Execute imports the theme. A custom theme of the same name is replaced;
+// a standard theme cannot be.
+func (uc *ImportThemeUseCase) Execute(ctx context.Context, def theme.CustomThemeDefinition) (*ImportThemeResult, error) {
+	th, err := theme.NewCustomTheme(def)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportThemeResult{Theme: themeToDTO(th, false)}
+	existing, err := uc.registry.FindByName(ctx, th.Name())
+	switch {
+	case err == nil && !existing.IsCustom():
+		return nil, fmt.Errorf("%w: %s is a standard theme; choose another name", theme.ErrThemeAlreadyRegistered, th.Name())
+	case err == nil:
+		result.Replaced = true
+	case errors.Is(err, theme.ErrThemeNotFound):
+		if err := uc.registry.Register(th); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err := uc.store.SaveCustomTheme(ctx, def); err != nil {
+		return nil, fmt.Errorf("failed to save theme: %w", err)
+	}
+	return result, nil
+}
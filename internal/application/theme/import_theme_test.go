@@ -0,0 +1,81 @@
+package theme
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCustomThemeStore records saved themes
+type mockCustomThemeStore struct {
+	saved []theme.CustomThemeDefinition
+}
+
+func (m *mockCustomThemeStore) SaveCustomTheme(ctx context.Context, def theme.CustomThemeDefinition) error {
+	m.saved = append(m.saved, def)
+	return nil
+}
+
+func TestImportThemeUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	solarized := theme.CustomThemeDefinition{
+		Name:    "solarized",
+		Variant: "dark",
+		Colors:  map[string]string{"base": "#002b36", "text": "#839496", "accent": "#268bd2"},
+	}
+
+	newUseCase := func(t *testing.T) (*ImportThemeUseCase, theme.ThemeRegistry, *mockCustomThemeStore) {
+		registry := theme.NewThemeRegistry()
+		require.NoError(t, theme.InitializeStandardThemes(registry))
+		store := &mockCustomThemeStore{}
+		return NewImportThemeUseCase(registry, store), registry, store
+	}
+
+	t.Run("makes the theme selectable", func(t *testing.T) {
+		useCase, registry, store := newUseCase(t)
+
+		result, err := useCase.Execute(ctx, solarized)
+		require.NoError(t, err)
+
+		assert.Equal(t, "solarized", result.Theme.Name)
+		assert.Equal(t, "#268bd2", result.Theme.ColorScheme["mauve"])
+		assert.False(t, result.Replaced)
+		assert.Len(t, store.saved, 1)
+
+		applied, err := NewApplyThemeUseCase(registry, nil, nil, nil).Execute(ctx, "solarized")
+		require.NoError(t, err)
+		assert.True(t, applied.Success)
+	})
+
+	t.Run("replaces a custom theme of the same name", func(t *testing.T) {
+		useCase, _, store := newUseCase(t)
+		_, err := useCase.Execute(ctx, solarized)
+		require.NoError(t, err)
+
+		result, err := useCase.Execute(ctx, solarized)
+		require.NoError(t, err)
+		assert.True(t, result.Replaced)
+		assert.Len(t, store.saved, 2)
+	})
+
+	t.Run("keeps standard themes", func(t *testing.T) {
+		useCase, _, store := newUseCase(t)
+		nord := solarized
+		nord.Name = "nord"
+
+		_, err := useCase.Execute(ctx, nord)
+		assert.ErrorIs(t, err, theme.ErrThemeAlreadyRegistered)
+		assert.Empty(t, store.saved)
+	})
+
+	t.Run("rejects an invalid palette", func(t *testing.T) {
+		useCase, _, store := newUseCase(t)
+
+		_, err := useCase.Execute(ctx, theme.CustomThemeDefinition{Name: "empty", Variant: "dark"})
+		assert.ErrorIs(t, err, theme.ErrInvalidPalette)
+		assert.Empty(t, store.saved)
+	})
+}
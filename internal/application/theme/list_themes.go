@@ -48,6 +48,7 @@ func themeToDTO(th *theme.Theme, isActive bool) ThemeInfo {
 		Description: th.Description(),
 		Variant:     string(th.Variant()),
 		IsActive:    isActive,
+		Custom:      th.IsCustom(),
 		PreviewURL:  th.PreviewURL(),
 		ColorScheme: colorSchemeToMap(th.ColorScheme()),
 	}
@@ -24,7 +24,7 @@ func TestListThemesUseCase_Execute(t *testing.T) {
 				require.NoError(t, err)
 				return registry
 			},
-			wantCount: 5,
+			wantCount: 10,
 			checkResult: func(t *testing.T, themes []ThemeInfo) {
 				names := make([]string, len(themes))
 				for i, th := range themes {
@@ -53,7 +53,7 @@ func TestListThemesUseCase_Execute(t *testing.T) {
 				// Mocha is default active
 				return registry
 			},
-			wantCount: 5,
+			wantCount: 10,
 			checkResult: func(t *testing.T, themes []ThemeInfo) {
 				var activeCount int
 				var mochaActive bool
@@ -77,7 +77,7 @@ func TestListThemesUseCase_Execute(t *testing.T) {
 				require.NoError(t, err)
 				return registry
 			},
-			wantCount: 5,
+			wantCount: 10,
 			checkResult: func(t *testing.T, themes []ThemeInfo) {
 				for _, th := range themes {
 					assert.NotEmpty(t, th.Name, "theme should have name")
@@ -15,6 +15,8 @@ type RollbackThemeResult struct {
 	RestoredTheme theme.ThemeName
 	PreviousTheme theme.ThemeName
 	Message       string
+
+	ReloadedComponents []string
 }
 
 // RollbackThemeUseCase rolls back to the previous theme
@@ -23,6 +25,7 @@ type RollbackThemeUseCase struct {
 	historyStore themeInfra.ThemeHistoryStore
 	applier      ThemeApplier
 	stateStore   themeInfra.ThemeStateStore
+	reloader     ReloadService
 }
 
 // NewRollbackThemeUseCase creates a new rollback theme use case
@@ -40,6 +43,14 @@ func NewRollbackThemeUseCase(
 	}
 }
 
+// WithReloadService returns a copy of the use case that reloads running
+// components after restoring a theme
+func (uc *RollbackThemeUseCase) WithReloadService(reloader ReloadService) *RollbackThemeUseCase {
+	copied := *uc
+	copied.reloader = reloader
+	return &copied
+}
+
 // Execute rolls back to the previous theme in history
 func (uc *RollbackThemeUseCase) Execute(ctx context.Context) (*RollbackThemeResult, error) {
 	// Get current active theme for result reporting
@@ -100,5 +111,7 @@ func (uc *RollbackThemeUseCase) Execute(ctx context.Context) (*RollbackThemeResu
 		RestoredTheme: previousTheme.Name(),
 		PreviousTheme: currentThemeName,
 		Message:       fmt.Sprintf("Successfully rolled back from '%s' to '%s'", currentThemeName, previousTheme.Name()),
+
+		ReloadedComponents: reloadComponents(ctx, uc.reloader),
 	}, nil
 }
@@ -10,6 +10,7 @@ import (
 type DoctorRequest struct {
 	ShowProgress bool
 	QuickCheck   bool // If true, only run critical checks
+	SmokeTest    bool // If true, also start the compositor headless
 }
 
 // DoctorResponse contains verification results
@@ -44,6 +45,14 @@ type Checkers struct {
 	HyprlandChecker     verification.VerificationChecker
 	ThemeChecker        verification.VerificationChecker
 	ConfigChecker       verification.VerificationChecker
+	KeyboardChecker     verification.VerificationChecker
+	SwapChecker         verification.VerificationChecker
+	PermissionsChecker  verification.VerificationChecker
+	PortalChecker       verification.VerificationChecker
+	GPUDriverChecker    verification.VerificationChecker
+	PackagesChecker     verification.VerificationChecker
+	SessionChecker      verification.VerificationChecker
+	SessionSmokeChecker verification.VerificationChecker // Opt-in, see DoctorRequest.SmokeTest
 	// Additional checkers can be added here
 }
 
@@ -62,7 +71,7 @@ func NewDoctorUseCase(checkers Checkers) *DoctorUseCase {
 // Execute runs system verification
 func (uc *DoctorUseCase) Execute(ctx context.Context, req DoctorRequest) (*DoctorResponse, error) {
 	// Create list of checkers to run
-	checkerList := uc.getCheckers(req.QuickCheck)
+	checkerList := uc.getCheckers(req)
 
 	// Create orchestrator
 	orchestrator := verification.NewVerificationOrchestrator(checkerList)
@@ -88,7 +97,7 @@ func (uc *DoctorUseCase) ExecuteWithProgress(
 	progressFn ProgressCallback,
 ) (*DoctorResponse, error) {
 	// Create list of checkers to run
-	checkerList := uc.getCheckers(req.QuickCheck)
+	checkerList := uc.getCheckers(req)
 
 	// Create orchestrator
 	orchestrator := verification.NewVerificationOrchestrator(checkerList)
@@ -103,7 +112,7 @@ func (uc *DoctorUseCase) ExecuteWithProgress(
 	return uc.buildResponse(report), nil
 }
 
-func (uc *DoctorUseCase) getCheckers(quickCheck bool) []verification.VerificationChecker {
+func (uc *DoctorUseCase) getCheckers(req DoctorRequest) []verification.VerificationChecker {
 	checkers := []verification.VerificationChecker{}
 
 	// Always include critical checkers
@@ -113,12 +122,38 @@ func (uc *DoctorUseCase) getCheckers(quickCheck bool) []verification.Verificatio
 	if uc.checkers.ConfigChecker != nil {
 		checkers = append(checkers, uc.checkers.ConfigChecker)
 	}
+	if uc.checkers.KeyboardChecker != nil {
+		checkers = append(checkers, uc.checkers.KeyboardChecker)
+	}
 
 	// Include additional checkers for full check
-	if !quickCheck {
+	if !req.QuickCheck {
 		if uc.checkers.ThemeChecker != nil {
 			checkers = append(checkers, uc.checkers.ThemeChecker)
 		}
+		if uc.checkers.SwapChecker != nil {
+			checkers = append(checkers, uc.checkers.SwapChecker)
+		}
+		if uc.checkers.PermissionsChecker != nil {
+			checkers = append(checkers, uc.checkers.PermissionsChecker)
+		}
+		if uc.checkers.PortalChecker != nil {
+			checkers = append(checkers, uc.checkers.PortalChecker)
+		}
+		if uc.checkers.GPUDriverChecker != nil {
+			checkers = append(checkers, uc.checkers.GPUDriverChecker)
+		}
+		if uc.checkers.PackagesChecker != nil {
+			checkers = append(checkers, uc.checkers.PackagesChecker)
+		}
+		if uc.checkers.SessionChecker != nil {
+			checkers = append(checkers, uc.checkers.SessionChecker)
+		}
+	}
+
+	// Starting the compositor takes seconds, so only on request
+	if req.SmokeTest && uc.checkers.SessionSmokeChecker != nil {
+		checkers = append(checkers, uc.checkers.SessionSmokeChecker)
 	}
 
 	return checkers
@@ -0,0 +1,73 @@
+package wallpaper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+)
+
+// ErrNoRotation is returned when showing the next wallpaper without a
+// directory to rotate through
+var ErrNoRotation = errors.New("no wallpaper rotation set up")
+
+// NextWallpaperResponse describes the wallpaper the rotation moved to
+type NextWallpaperResponse struct {
+	Tool     wallpaper.Tool
+	Image    string
+	Reloaded bool
+}
+
+// NextWallpaperUseCase shows another image from the rotation directory on
+// every monitor without its own wallpaper. The rotation timer runs it.
+type NextWallpaperUseCase struct {
+	store    WallpaperStore
+	packages PackageChecker
+	applier  WallpaperApplier
+	intn     func(n int) int
+}
+
+// NewNextWallpaperUseCase creates a new use case instance
+func NewNextWallpaperUseCase(store WallpaperStore, packages PackageChecker, applier WallpaperApplier) *NextWallpaperUseCase {
+	return &NextWallpaperUseCase{
+		store:    store,
+		packages: packages,
+		applier:  applier,
+		intn:     rand.IntN,
+	}
+}
+
+// Execute links another image from the rotation directory in place and
+// reloads the daemon
+func (uc *NextWallpaperUseCase) Execute(ctx context.Context) (*NextWallpaperResponse, error) {
+	dir := uc.store.RotationDir()
+	if dir == "" {
+		return nil, fmt.Errorf("%w: gohan wallpaper set <dir> --rotate <interval>", ErrNoRotation)
+	}
+
+	tool, err := findInstalledTool(ctx, uc.packages)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := uc.store.Images(dir)
+	if err != nil {
+		return nil, err
+	}
+	image, err := wallpaper.PickImage(images, uc.store.Source(wallpaper.AllMonitors), uc.intn)
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s", err, dir)
+	}
+
+	if err := uc.store.Import(wallpaper.AllMonitors, image, true); err != nil {
+		return nil, err
+	}
+	_, reloaded, err := applyLayout(ctx, uc.store, uc.applier, tool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NextWallpaperResponse{Tool: tool, Image: image, Reloaded: reloaded}, nil
+}
@@ -0,0 +1,212 @@
+package wallpaper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+)
+
+var (
+	// ErrNoWallpaperTool is returned when neither hyprpaper nor swaybg is
+	// installed
+	ErrNoWallpaperTool = errors.New("no wallpaper tool installed")
+	// ErrRotationNeedsDirectory is returned when rotating a single image
+	ErrRotationNeedsDirectory = errors.New("rotation needs a directory of images")
+	// ErrRotationPerMonitor is returned when rotating the wallpaper of one
+	// monitor
+	ErrRotationPerMonitor = errors.New("rotation applies to every monitor")
+)
+
+// WallpaperStore keeps the wallpapers shown in a directory gohan manages
+type WallpaperStore interface {
+	// Layout returns the wallpaper in place for each monitor
+	Layout() (wallpaper.Layout, error)
+	// Import puts image in place as the monitor's wallpaper, linking to it
+	// when link is set and copying it otherwise
+	Import(monitor, image string, link bool) error
+	// Source returns the image a monitor's wallpaper links to, or "" for a
+	// copied one
+	Source(monitor string) string
+	// Images lists the images in dir
+	Images(dir string) ([]string, error)
+	// RotationDir returns the directory rotated through, or "" without one
+	RotationDir() string
+	// SetRotationDir links the directory to rotate through; "" removes it
+	SetRotationDir(dir string) error
+}
+
+// PackageChecker is the interface for finding the installed wallpaper tool
+type PackageChecker interface {
+	IsPackageInstalled(ctx context.Context, packageName string) (bool, error)
+}
+
+// WallpaperApplier writes the daemon's configuration for a layout and
+// restarts it in the running session
+type WallpaperApplier interface {
+	Apply(ctx context.Context, tool wallpaper.Tool, layout wallpaper.Layout) (reloaded bool, err error)
+}
+
+// RotationScheduler installs and removes the rotation timer
+type RotationScheduler interface {
+	Schedule(ctx context.Context, rotation wallpaper.Rotation) error
+	Unschedule(ctx context.Context) error
+}
+
+// SetWallpaperRequest contains parameters for setting a wallpaper
+type SetWallpaperRequest struct {
+	Path       string        // Image, or directory to pick an image from
+	Monitor    string        // wallpaper.AllMonitors for every monitor without its own
+	Rotate     time.Duration // Show another image from Path every interval; 0 keeps the image
+	Executable string        // Absolute path of the gohan binary the rotation runs
+}
+
+// SetWallpaperResponse describes the wallpaper now in place
+type SetWallpaperResponse struct {
+	Tool     wallpaper.Tool
+	Monitor  string
+	Image    string // Image shown, from Path
+	Layout   []wallpaper.Assignment
+	Reloaded bool          // The daemon was restarted; otherwise it applies at next login
+	Rotate   time.Duration // 0 without rotation
+}
+
+// SetWallpaperUseCase puts a wallpaper in place, renders the installed
+// daemon's configuration and reloads it
+type SetWallpaperUseCase struct {
+	store     WallpaperStore
+	packages  PackageChecker
+	applier   WallpaperApplier
+	scheduler RotationScheduler
+	intn      func(n int) int
+}
+
+// NewSetWallpaperUseCase creates a new use case instance
+func NewSetWallpaperUseCase(
+	store WallpaperStore,
+	packages PackageChecker,
+	applier WallpaperApplier,
+	scheduler RotationScheduler,
+) *SetWallpaperUseCase {
+	return &SetWallpaperUseCase{
+		store:     store,
+		packages:  packages,
+		applier:   applier,
+		scheduler: scheduler,
+		intn:      rand.IntN,
+	}
+}
+
+// Execute sets the wallpaper of a monitor. A directory shows one of its
+// images at random; with Rotate, a timer keeps picking another. Setting
+// the wallpaper of every monitor again stops an earlier rotation.
+func (uc *SetWallpaperUseCase) Execute(ctx context.Context, req SetWallpaperRequest) (*SetWallpaperResponse, error) {
+	if err := wallpaper.ValidateMonitor(req.Monitor); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(req.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallpaper: %w", err)
+	}
+	if req.Rotate > 0 {
+		switch {
+		case !info.IsDir():
+			return nil, ErrRotationNeedsDirectory
+		case req.Monitor != wallpaper.AllMonitors:
+			return nil, ErrRotationPerMonitor
+		}
+	}
+
+	var rotation wallpaper.Rotation
+	if req.Rotate > 0 {
+		if rotation, err = wallpaper.NewRotation(req.Rotate, req.Executable); err != nil {
+			return nil, err
+		}
+	}
+
+	tool, err := findInstalledTool(ctx, uc.packages)
+	if err != nil {
+		return nil, err
+	}
+
+	image, link := req.Path, false
+	if info.IsDir() {
+		images, err := uc.store.Images(req.Path)
+		if err != nil {
+			return nil, err
+		}
+		if image, err = wallpaper.PickImage(images, uc.store.Source(req.Monitor), uc.intn); err != nil {
+			return nil, fmt.Errorf("%w in %s", err, req.Path)
+		}
+		link = req.Rotate > 0
+	} else if !wallpaper.IsImage(req.Path) {
+		return nil, fmt.Errorf("%w: %s", wallpaper.ErrNotAnImage, req.Path)
+	}
+
+	if err := uc.store.Import(req.Monitor, image, link); err != nil {
+		return nil, err
+	}
+	layout, reloaded, err := applyLayout(ctx, uc.store, uc.applier, tool)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.Rotate > 0:
+		if err := uc.store.SetRotationDir(req.Path); err != nil {
+			return nil, err
+		}
+		if err := uc.scheduler.Schedule(ctx, rotation); err != nil {
+			return nil, err
+		}
+	case req.Monitor == wallpaper.AllMonitors && uc.store.RotationDir() != "":
+		if err := uc.scheduler.Unschedule(ctx); err != nil {
+			return nil, err
+		}
+		if err := uc.store.SetRotationDir(""); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SetWallpaperResponse{
+		Tool:     tool,
+		Monitor:  req.Monitor,
+		Image:    image,
+		Layout:   layout.Assignments(),
+		Reloaded: reloaded,
+		Rotate:   req.Rotate,
+	}, nil
+}
+
+// findInstalledTool returns the wallpaper daemon that is installed,
+// preferring hyprpaper when both are
+func findInstalledTool(ctx context.Context, packages PackageChecker) (wallpaper.Tool, error) {
+	for _, tool := range wallpaper.Tools {
+		installed, err := packages.IsPackageInstalled(ctx, string(tool))
+		if err != nil {
+			return "", fmt.Errorf("failed to check for %s: %w", tool, err)
+		}
+		if installed {
+			return tool, nil
+		}
+	}
+	return "", fmt.Errorf("%w: install hyprpaper or swaybg", ErrNoWallpaperTool)
+}
+
+// applyLayout hands the wallpapers in the store to the daemon
+func applyLayout(ctx context.Context, store WallpaperStore, applier WallpaperApplier, tool wallpaper.Tool) (wallpaper.Layout, bool, error) {
+	layout, err := store.Layout()
+	if err != nil {
+		return wallpaper.Layout{}, false, err
+	}
+	reloaded, err := applier.Apply(ctx, tool, layout)
+	if err != nil {
+		return wallpaper.Layout{}, false, err
+	}
+	return layout, reloaded, nil
+}
@@ -0,0 +1,180 @@
+package wallpaper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	wallpaperApp "github.com/rebelopsio/gohan/internal/application/wallpaper"
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+	wallpaperInfra "github.com/rebelopsio/gohan/internal/infrastructure/wallpaper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePackages map[string]bool
+
+func (f fakePackages) IsPackageInstalled(ctx context.Context, name string) (bool, error) {
+	return f[name], nil
+}
+
+type fakeApplier struct {
+	tool   wallpaper.Tool
+	layout wallpaper.Layout
+}
+
+func (f *fakeApplier) Apply(ctx context.Context, tool wallpaper.Tool, layout wallpaper.Layout) (bool, error) {
+	f.tool, f.layout = tool, layout
+	return true, nil
+}
+
+type fakeScheduler struct {
+	scheduled   *wallpaper.Rotation
+	unscheduled bool
+}
+
+func (f *fakeScheduler) Schedule(ctx context.Context, rotation wallpaper.Rotation) error {
+	f.scheduled = &rotation
+	return nil
+}
+
+func (f *fakeScheduler) Unschedule(ctx context.Context) error {
+	f.unscheduled = true
+	return nil
+}
+
+// writeImages creates images named names in a new directory
+func writeImages(t *testing.T, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+	return dir
+}
+
+func TestSetWallpaperUseCase_Execute(t *testing.T) {
+	setup := func(t *testing.T, installed ...string) (*wallpaperApp.SetWallpaperUseCase, *wallpaperInfra.FileStore, *fakeApplier, *fakeScheduler) {
+		packages := fakePackages{}
+		for _, name := range installed {
+			packages[name] = true
+		}
+		store := wallpaperInfra.NewFileStore(t.TempDir())
+		applier := &fakeApplier{}
+		scheduler := &fakeScheduler{}
+		return wallpaperApp.NewSetWallpaperUseCase(store, packages, applier, scheduler), store, applier, scheduler
+	}
+
+	t.Run("sets the wallpaper of every monitor with the installed tool", func(t *testing.T) {
+		uc, store, applier, _ := setup(t, "swaybg")
+		image := filepath.Join(writeImages(t, "mountains.jpg"), "mountains.jpg")
+
+		response, err := uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{Path: image})
+		require.NoError(t, err)
+
+		assert.Equal(t, wallpaper.ToolSwaybg, response.Tool)
+		assert.Equal(t, wallpaper.ToolSwaybg, applier.tool)
+		assert.Equal(t, []wallpaper.Assignment{{Monitor: wallpaper.AllMonitors, Image: store.Path(wallpaper.AllMonitors)}}, response.Layout)
+		content, err := os.ReadFile(store.Path(wallpaper.AllMonitors))
+		require.NoError(t, err)
+		assert.Equal(t, "mountains.jpg", string(content))
+	})
+
+	t.Run("keeps the default wallpaper when setting one monitor's", func(t *testing.T) {
+		uc, store, _, _ := setup(t, "hyprpaper", "swaybg")
+		dir := writeImages(t, "all.jpg", "side.png")
+
+		_, err := uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{Path: filepath.Join(dir, "all.jpg")})
+		require.NoError(t, err)
+		response, err := uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{Path: filepath.Join(dir, "side.png"), Monitor: "DP-1"})
+		require.NoError(t, err)
+
+		assert.Equal(t, wallpaper.ToolHyprpaper, response.Tool, "hyprpaper is preferred")
+		assert.Equal(t, []wallpaper.Assignment{
+			{Monitor: wallpaper.AllMonitors, Image: store.Path(wallpaper.AllMonitors)},
+			{Monitor: "DP-1", Image: store.Path("DP-1")},
+		}, response.Layout)
+	})
+
+	t.Run("rotates through a directory", func(t *testing.T) {
+		uc, store, _, scheduler := setup(t, "hyprpaper")
+		dir := writeImages(t, "a.jpg", "notes.txt")
+
+		response, err := uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{
+			Path:       dir,
+			Rotate:     30 * time.Minute,
+			Executable: "/usr/bin/gohan",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, filepath.Join(dir, "a.jpg"), response.Image)
+		assert.Equal(t, filepath.Join(dir, "a.jpg"), store.Source(wallpaper.AllMonitors), "rotated images are linked")
+		assert.Equal(t, dir, store.RotationDir())
+		require.NotNil(t, scheduler.scheduled)
+		assert.Equal(t, 30*time.Minute, scheduler.scheduled.Interval())
+	})
+
+	t.Run("setting a single image stops the rotation", func(t *testing.T) {
+		uc, store, _, scheduler := setup(t, "hyprpaper")
+		dir := writeImages(t, "a.jpg")
+		require.NoError(t, store.SetRotationDir(dir))
+
+		_, err := uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{Path: filepath.Join(dir, "a.jpg")})
+		require.NoError(t, err)
+
+		assert.True(t, scheduler.unscheduled)
+		assert.Empty(t, store.RotationDir())
+	})
+
+	t.Run("rejects rotating one image or one monitor", func(t *testing.T) {
+		uc, _, _, _ := setup(t, "hyprpaper")
+		dir := writeImages(t, "a.jpg")
+
+		_, err := uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{Path: filepath.Join(dir, "a.jpg"), Rotate: time.Hour, Executable: "/usr/bin/gohan"})
+		assert.ErrorIs(t, err, wallpaperApp.ErrRotationNeedsDirectory)
+
+		_, err = uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{Path: dir, Monitor: "DP-1", Rotate: time.Hour, Executable: "/usr/bin/gohan"})
+		assert.ErrorIs(t, err, wallpaperApp.ErrRotationPerMonitor)
+	})
+
+	t.Run("fails without a wallpaper tool", func(t *testing.T) {
+		uc, _, _, _ := setup(t)
+		image := filepath.Join(writeImages(t, "a.jpg"), "a.jpg")
+
+		_, err := uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{Path: image})
+		assert.ErrorIs(t, err, wallpaperApp.ErrNoWallpaperTool)
+	})
+
+	t.Run("rejects files that are not images", func(t *testing.T) {
+		uc, _, _, _ := setup(t, "swaybg")
+		file := filepath.Join(writeImages(t, "notes.txt"), "notes.txt")
+
+		_, err := uc.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{Path: file})
+		assert.ErrorIs(t, err, wallpaper.ErrNotAnImage)
+	})
+}
+
+func TestNextWallpaperUseCase_Execute(t *testing.T) {
+	t.Run("links another image from the rotation directory", func(t *testing.T) {
+		store := wallpaperInfra.NewFileStore(t.TempDir())
+		dir := writeImages(t, "a.jpg", "b.jpg")
+		require.NoError(t, store.SetRotationDir(dir))
+		require.NoError(t, store.Import(wallpaper.AllMonitors, filepath.Join(dir, "a.jpg"), true))
+		uc := wallpaperApp.NewNextWallpaperUseCase(store, fakePackages{"swaybg": true}, &fakeApplier{})
+
+		response, err := uc.Execute(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, filepath.Join(dir, "b.jpg"), response.Image, "the current image is skipped")
+		assert.Equal(t, filepath.Join(dir, "b.jpg"), store.Source(wallpaper.AllMonitors))
+	})
+
+	t.Run("fails without a rotation", func(t *testing.T) {
+		uc := wallpaperApp.NewNextWallpaperUseCase(wallpaperInfra.NewFileStore(t.TempDir()), fakePackages{"swaybg": true}, &fakeApplier{})
+
+		_, err := uc.Execute(context.Background())
+		assert.ErrorIs(t, err, wallpaperApp.ErrNoRotation)
+	})
+}
@@ -47,10 +47,16 @@ var backupListCmd = &cobra.Command{
 	Long: `Display a list of all available backups.
 
 Shows backup ID, description, creation time, file count, and total size.
+Backups are listed newest first from the backup index, so large backup
+stores list quickly; use --limit and --offset to page through them.
 
 Examples:
   # List all backups
-  gohan backup list`,
+  gohan backup list
+
+  # List the 20 newest backups, then the next 20
+  gohan backup list --limit 20
+  gohan backup list --limit 20 --offset 20`,
 	RunE: runBackupList,
 }
 
@@ -90,6 +96,12 @@ Examples:
 	RunE: runBackupCleanup,
 }
 
+// Default backup retention, shared with the scheduled backup prune
+const (
+	defaultBackupRetentionDays = 30
+	defaultBackupKeepMinimum   = 5
+)
+
 // Flags
 var (
 	backupDescription string
@@ -98,6 +110,8 @@ var (
 	retentionDays     int
 	keepMinimum       int
 	backupDryRun      bool
+	backupListLimit   int
+	backupListOffset  int
 )
 
 func init() {
@@ -113,12 +127,16 @@ func init() {
 	backupCreateCmd.Flags().StringVar(&backupDescription, "description", "", "Backup description")
 	backupCreateCmd.Flags().StringSliceVar(&backupPaths, "paths", nil, "Specific paths to backup")
 
+	// List flags
+	backupListCmd.Flags().IntVar(&backupListLimit, "limit", 0, "Maximum number of backups to show (0 = all)")
+	backupListCmd.Flags().IntVar(&backupListOffset, "offset", 0, "Number of newest backups to skip")
+
 	// Restore flags
 	backupRestoreCmd.Flags().StringSliceVar(&backupSelective, "selective", nil, "Restore only specific paths")
 
 	// Cleanup flags
-	backupCleanupCmd.Flags().IntVar(&retentionDays, "retention-days", 30, "Keep backups newer than this many days")
-	backupCleanupCmd.Flags().IntVar(&keepMinimum, "keep-minimum", 5, "Always keep at least this many backups")
+	backupCleanupCmd.Flags().IntVar(&retentionDays, "retention-days", defaultBackupRetentionDays, "Keep backups newer than this many days")
+	backupCleanupCmd.Flags().IntVar(&keepMinimum, "keep-minimum", defaultBackupKeepMinimum, "Always keep at least this many backups")
 	backupCleanupCmd.Flags().BoolVar(&backupDryRun, "dry-run", false, "Show what would be removed without actually removing")
 }
 
@@ -142,15 +160,7 @@ func runBackupCreate(cmd *cobra.Command, args []string) error {
 	// Determine paths to back up
 	paths := backupPaths
 	if len(paths) == 0 {
-		// Default paths
-		configDir := filepath.Join(homeDir, ".config")
-		paths = []string{
-			filepath.Join(configDir, "hypr"),
-			filepath.Join(configDir, "waybar"),
-			filepath.Join(configDir, "kitty"),
-			filepath.Join(configDir, "rofi"),
-			filepath.Join(configDir, "mako"),
-		}
+		paths = backupApp.DefaultPaths(homeDir)
 	}
 
 	// Execute use case
@@ -193,7 +203,10 @@ func runBackupList(cmd *cobra.Command, args []string) error {
 	useCase := backupApp.NewListBackupsUseCase(repo)
 
 	// Execute use case
-	resp, err := useCase.Execute(ctx)
+	resp, err := useCase.Execute(ctx, backupApp.ListBackupsRequest{
+		Offset: backupListOffset,
+		Limit:  backupListLimit,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list backups: %w", err)
 	}
@@ -202,6 +215,10 @@ func runBackupList(cmd *cobra.Command, args []string) error {
 		fmt.Println("No backups found.")
 		return nil
 	}
+	if len(resp.Backups) == 0 {
+		fmt.Printf("No backups past offset %d (total: %d backups)\n", resp.Offset, resp.Total)
+		return nil
+	}
 
 	// Display backups in table format
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
@@ -219,7 +236,14 @@ func runBackupList(cmd *cobra.Command, args []string) error {
 	}
 
 	w.Flush()
-	fmt.Printf("\nTotal: %d backups\n", resp.Total)
+	if resp.HasMore || resp.Offset > 0 {
+		fmt.Printf("\nShowing %d-%d of %d backups\n", resp.Offset+1, resp.Offset+len(resp.Backups), resp.Total)
+		if resp.HasMore {
+			fmt.Printf("Next page: gohan backup list --limit %d --offset %d\n", backupListLimit, resp.Offset+len(resp.Backups))
+		}
+	} else {
+		fmt.Printf("\nTotal: %d backups\n", resp.Total)
+	}
 
 	return nil
 }
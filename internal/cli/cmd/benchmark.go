@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	benchmarkApp "github.com/rebelopsio/gohan/internal/application/benchmark"
+	benchmarkInfra "github.com/rebelopsio/gohan/internal/infrastructure/benchmark"
+	"github.com/spf13/cobra"
+)
+
+// benchmarkCmd represents the compositor benchmark command
+var benchmarkCmd = &cobra.Command{
+	Use:   "benchmark",
+	Short: "Measure compositor performance",
+	Long: `Run a quick performance sanity check from within a Hyprland session.
+
+The benchmark measures:
+- Frame timing: monitor frame budget and how long the compositor main loop
+  takes to answer IPC requests (slow answers mean missed frames)
+- Input latency: synthetic cursor moves until Hyprland reports them
+- GPU utilization (amdgpu and NVIDIA)
+
+Every run is saved as a JSON report you can attach to bug reports. The first
+run becomes the baseline; later runs are compared against it so you can see
+the effect of driver or configuration changes.
+
+Examples:
+  # Run the benchmark and compare with the baseline
+  gohan benchmark
+
+  # Sample for longer
+  gohan benchmark --duration 30s
+
+  # Record a new baseline (e.g. before changing drivers)
+  gohan benchmark --baseline`,
+	RunE: runBenchmark,
+}
+
+// Flags
+var (
+	benchmarkDuration time.Duration
+	benchmarkSamples  int
+	benchmarkBaseline bool
+)
+
+func init() {
+	rootCmd.AddCommand(benchmarkCmd)
+
+	benchmarkCmd.Flags().DurationVar(&benchmarkDuration, "duration", benchmarkApp.DefaultDuration, "How long to sample frame timing and GPU load")
+	benchmarkCmd.Flags().IntVar(&benchmarkSamples, "samples", benchmarkApp.DefaultLatencySamples, "Number of synthetic input events for latency")
+	benchmarkCmd.Flags().BoolVar(&benchmarkBaseline, "baseline", false, "Save this run as the new baseline")
+}
+
+func runBenchmark(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	homeDir, _ := os.UserHomeDir()
+	store := benchmarkInfra.NewFileReportStore(filepath.Join(homeDir, ".local/share/gohan/benchmarks"))
+
+	sampler := benchmarkInfra.NewHyprlandSampler()
+	useCase := benchmarkApp.NewRunBenchmarkUseCase(benchmarkApp.Samplers{
+		FrameTiming:  sampler,
+		InputLatency: sampler,
+		GPU:          benchmarkInfra.NewSystemGPUSampler(),
+		Environment:  benchmarkInfra.NewSystemEnvironmentDetector(),
+	}, store)
+
+	fmt.Printf("⏱  Benchmarking for about %s, keep using the desktop normally...\n", benchmarkDuration*2)
+
+	resp, err := useCase.Execute(ctx, benchmarkApp.RunBenchmarkRequest{
+		Duration:       benchmarkDuration,
+		LatencySamples: benchmarkSamples,
+		SetBaseline:    benchmarkBaseline,
+	})
+	if err != nil {
+		return fmt.Errorf("benchmark failed: %w", err)
+	}
+
+	fmt.Println("\n" + strings.Repeat("═", 60))
+	fmt.Printf("  BENCHMARK RESULTS\n")
+	fmt.Println(strings.Repeat("═", 60) + "\n")
+
+	env := resp.Environment
+	fmt.Printf("Hyprland:  %s\n", valueOrUnknown(env.HyprlandVersion))
+	fmt.Printf("Kernel:    %s\n", valueOrUnknown(env.KernelVersion))
+	fmt.Printf("GPU:       %s (%s)\n", valueOrUnknown(env.GPU), valueOrUnknown(env.GPUDriver))
+	fmt.Printf("Monitor:   %s\n", valueOrUnknown(env.Monitor))
+	fmt.Println()
+
+	if resp.HasBaseline {
+		fmt.Printf("Compared with baseline from %s:\n\n", resp.BaselineDate.Format("2006-01-02 15:04"))
+		for _, c := range resp.Comparisons {
+			icon := "="
+			if c.Regressed {
+				icon = "✗"
+			} else if c.Improved {
+				icon = "✓"
+			}
+			fmt.Printf("  %s %-28s %9.2f %-3s (baseline %.2f, %+.1f%%)\n",
+				icon, c.Name, c.Current, c.Unit, c.Baseline, c.PercentChange)
+		}
+	} else {
+		for _, m := range resp.Metrics {
+			fmt.Printf("  %-30s %9.2f %s\n", m.Name, m.Value, m.Unit)
+		}
+	}
+
+	if len(resp.Notes) > 0 {
+		fmt.Println("\n⚠ Skipped measurements:")
+		for _, note := range resp.Notes {
+			fmt.Printf("  • %s\n", note)
+		}
+	}
+
+	fmt.Println("\n" + strings.Repeat("─", 60))
+	if resp.Regressions > 0 {
+		fmt.Printf("✗  %d metric(s) regressed by more than 10%% since the baseline\n", resp.Regressions)
+	}
+	if resp.BaselineSaved {
+		fmt.Println("📌 Saved as the new baseline")
+	}
+	fmt.Printf("📄 Report: %s\n", resp.ReportPath)
+	fmt.Println("   Attach this file to bug reports about performance.")
+	fmt.Println(strings.Repeat("─", 60) + "\n")
+
+	return nil
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	bugreportApp "github.com/rebelopsio/gohan/internal/application/bugreport"
+	historyServices "github.com/rebelopsio/gohan/internal/application/history/services"
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+	"github.com/rebelopsio/gohan/internal/config"
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+	bugreportInfra "github.com/rebelopsio/gohan/internal/infrastructure/bugreport"
+	historyRepo "github.com/rebelopsio/gohan/internal/infrastructure/history/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/history/sysinfo"
+	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
+	"github.com/spf13/cobra"
+)
+
+// bugreportCmd represents the bug report bundle command
+var bugreportCmd = &cobra.Command{
+	Use:   "bugreport",
+	Short: "Collect diagnostics into a bug report archive",
+	Long: `Gather everything needed to investigate a problem into a single tarball.
+
+The archive contains:
+- The gohan log file (when logging.file is configured)
+- Fresh preflight check results
+- Recent installation history records
+- Hyprland logs and crash reports (~/.cache/hyprland)
+- gohan, Hyprland, kernel and OS versions
+- GPU hardware and driver information
+- Hyprland, Waybar and terminal configuration files
+
+Personal information is redacted before anything is written: your user and
+host names, home directory, email, IP and MAC addresses, and values of
+password, token, secret and API key settings. A MANIFEST.txt inside the
+archive lists every file and anything that could not be collected.
+
+Examples:
+  # Create gohan-bugreport-<timestamp>.tar.gz in the current directory
+  gohan bugreport
+
+  # Choose where to write the archive
+  gohan bugreport --output /tmp/report.tar.gz
+
+  # Skip the preflight checks (e.g. when offline)
+  gohan bugreport --no-preflight`,
+	RunE: runBugReport,
+}
+
+// Flags
+var (
+	bugreportOutput      string
+	bugreportHistory     int
+	bugreportNoPreflight bool
+)
+
+func init() {
+	rootCmd.AddCommand(bugreportCmd)
+
+	bugreportCmd.Flags().StringVarP(&bugreportOutput, "output", "o", "", "Archive path (default: ./gohan-bugreport-<timestamp>.tar.gz)")
+	bugreportCmd.Flags().IntVar(&bugreportHistory, "history", bugreportApp.DefaultHistoryRecords, "Number of recent installation records to include")
+	bugreportCmd.Flags().BoolVar(&bugreportNoPreflight, "no-preflight", false, "Do not run preflight checks")
+}
+
+func runBugReport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	homeDir, _ := os.UserHomeDir()
+	configDir := filepath.Join(homeDir, ".config")
+
+	var collectors []bugreport.Collector
+
+	// Session log, only available when logging to a file is configured
+	if cfg, err := config.Load(); err == nil && cfg.Logging.File != "" {
+		collectors = append(collectors, bugreportInfra.NewFileCollector(
+			"session log", "gohan", []string{cfg.Logging.File}, 1))
+	}
+
+	if !bugreportNoPreflight {
+		collectors = append(collectors, bugreportApp.NewPreflightCollector(
+			preflightApp.NewRunPreflightUseCase(preflightTUI.SystemDetectors())))
+	}
+
+	repo, err := historyRepo.NewSQLiteRepository(historyDBPathForScope(sysinfo.CurrentScope()))
+	if err == nil {
+		defer repo.Close()
+		collectors = append(collectors, bugreportApp.NewHistoryCollector(
+			historyServices.NewHistoryQueryService(repo), bugreportHistory))
+	} else {
+		fmt.Fprintf(os.Stderr, "Warning: history unavailable: %v\n", err)
+	}
+
+	// Newer Hyprland releases log to the runtime directory instead of ~/.cache
+	hyprlandLogs := []string{filepath.Join(homeDir, ".cache/hyprland/hyprland*.log")}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		hyprlandLogs = append(hyprlandLogs, filepath.Join(runtimeDir, "hypr/*/hyprland.log"))
+	}
+
+	collectors = append(collectors,
+		bugreportInfra.NewFileCollector("hyprland logs", "hyprland", hyprlandLogs, 3),
+		bugreportInfra.NewFileCollector("hyprland crash reports", "hyprland/crashes", []string{
+			filepath.Join(homeDir, ".cache/hyprland/hyprlandCrashReport*.txt"),
+		}, 3),
+		bugreportInfra.NewVersionsCollector(version),
+		bugreportInfra.NewGPUCollector(),
+		bugreportInfra.NewFileCollector("configs", "config", []string{
+			filepath.Join(configDir, "hypr/*.conf"),
+			filepath.Join(configDir, "waybar/config*"),
+			filepath.Join(configDir, "kitty/kitty.conf"),
+			filepath.Join(configDir, "alacritty/alacritty.toml"),
+			filepath.Join(configDir, "foot/foot.ini"),
+		}, 0),
+		bugreportInfra.NewGohanConfigCollector(config.GetConfigPath(), "config"),
+	)
+
+	identity := bugreport.Identity{HomeDir: homeDir}
+	if u, err := user.Current(); err == nil {
+		identity.Username = u.Username
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		identity.Hostname = hostname
+	}
+
+	useCase := bugreportApp.NewCreateBugReportUseCase(
+		collectors,
+		bugreport.NewRedactor(identity),
+		bugreportInfra.NewTarballWriter(),
+	)
+
+	fmt.Println("🔍 Collecting diagnostics...")
+
+	resp, err := useCase.Execute(ctx, bugreportApp.CreateBugReportRequest{OutputPath: bugreportOutput})
+	if err != nil {
+		return fmt.Errorf("failed to create bug report: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("Included (%d files):\n", len(resp.Files))
+	for _, f := range resp.Files {
+		fmt.Printf("  ✓ %-44s %s\n", f.Name, formatSize(uint64(f.Size)))
+	}
+
+	if len(resp.Skipped) > 0 {
+		fmt.Println("\nNot collected:")
+		for _, s := range resp.Skipped {
+			fmt.Printf("  ⚠ %s: %s\n", s.Source, s.Reason)
+		}
+	}
+
+	fmt.Println("\n" + strings.Repeat("─", 60))
+	fmt.Printf("🔒 Redacted %d personal value(s)\n", resp.Redactions)
+	fmt.Printf("📦 Bug report: %s\n", resp.Path)
+	fmt.Printf("   Review it before sharing: tar -tzf %s\n", resp.Path)
+	fmt.Println(strings.Repeat("─", 60))
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	cacheApp "github.com/rebelopsio/gohan/internal/application/cache"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/rebelopsio/gohan/internal/domain/cache"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for cache clean command
+	cacheCleanDryRun bool
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Report and clean download caches",
+	Long: `Report and clean the caches downloads accumulate in:
+
+  apt        apt package archives (/var/cache/apt/archives)
+  build      gohan build cache (cache.build_dir)
+  templates  template registry cache (cache.template_registry_dir)
+  sessions   failed session artifacts (cache.sessions_dir)
+
+Before installing, gohan cleans these caches by itself when the disk space
+check finds a partition close to its requirement (cache.auto_clean).`,
+}
+
+// cacheStatusCmd represents the cache status command
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the size of each cache",
+	RunE:  runCacheStatus,
+}
+
+// cacheCleanCmd represents the cache clean command
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean [apt|build|templates|sessions]...",
+	Short: "Empty caches",
+	Long: `Empty the named caches, or all of them when none is named. The apt cache is
+cleaned with apt-get clean and needs root; as a regular user it is skipped.
+
+Examples:
+  # Clean everything gohan may clean
+  sudo gohan cache clean
+
+  # Only the build cache
+  gohan cache clean build
+
+  # See what would be freed
+  gohan cache clean --dry-run`,
+	ValidArgs: []string{string(cache.KindAPTArchives), string(cache.KindBuild), string(cache.KindTemplateRegistry), string(cache.KindSessions)},
+	RunE:      runCacheClean,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheCleanCmd)
+
+	cacheCleanCmd.Flags().BoolVar(&cacheCleanDryRun, "dry-run", false, "Show what would be freed without deleting anything")
+}
+
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	response, err := c.CacheStatusUseCase.Execute(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to measure caches: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CACHE\tSIZE\tFILES\tPATH")
+	for _, entry := range response.Caches {
+		size := formatSize(entry.Bytes)
+		if entry.Error != "" {
+			size = "unknown"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", entry.Name, size, entry.Files, entry.Path)
+	}
+	fmt.Fprintf(w, "total\t%s\t\t\n", formatSize(response.TotalBytes))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, entry := range response.Caches {
+		if entry.Error != "" {
+			fmt.Printf("\n⚠ %s: %s\n", entry.Description, entry.Error)
+		}
+	}
+	return nil
+}
+
+func runCacheClean(cmd *cobra.Command, args []string) error {
+	kinds := make([]cache.Kind, 0, len(args))
+	for _, arg := range args {
+		kind, err := cache.ParseKind(arg)
+		if err != nil {
+			return err
+		}
+		kinds = append(kinds, kind)
+	}
+
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	response, err := c.CleanCacheUseCase.Execute(context.Background(), cacheApp.CleanCacheRequest{
+		Kinds:  kinds,
+		DryRun: cacheCleanDryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	verb := "Freed"
+	if response.DryRun {
+		verb = "Would free"
+	}
+	for _, cleaned := range response.Caches {
+		if cleaned.Skipped != "" {
+			fmt.Printf("- %-10s skipped: %s\n", cleaned.Name, cleaned.Skipped)
+			continue
+		}
+		fmt.Printf("✓ %-10s %s %s\n", cleaned.Name, verb, formatSize(cleaned.FreedBytes))
+	}
+	fmt.Printf("\n%s %s in total\n", verb, formatSize(response.FreedBytes))
+	return nil
+}
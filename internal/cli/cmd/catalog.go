@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/spf13/cobra"
+)
+
+var (
+	catalogJSON      bool
+	catalogComponent string
+	catalogGroup     string
+	catalogSuite     string
+)
+
+// catalogCmd represents the catalog command
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "List the components, packages and profiles gohan installs",
+	Long: `List what gohan knows how to install: components, the Debian packages
+they are made of with the suites each is available in, and the built-in
+and custom installation profiles.
+
+The same catalog is served by gohan server under /api/v1/catalog.`,
+}
+
+// catalogComponentsCmd represents the catalog components command
+var catalogComponentsCmd = &cobra.Command{
+	Use:   "components",
+	Short: "List installable components",
+	Long: `List installable components with their packages. A component is
+available in a suite when all of its required packages are.`,
+	Args: cobra.NoArgs,
+	RunE: runCatalogComponents,
+}
+
+// catalogPackagesCmd represents the catalog packages command
+var catalogPackagesCmd = &cobra.Command{
+	Use:   "packages",
+	Short: "List package definitions",
+	Long: `List the packages gohan installs, with their component, group and the
+Debian suites they are available in.
+
+Examples:
+  # Packages available on trixie
+  gohan catalog packages --suite trixie
+
+  # Packages of the Hyprland component
+  gohan catalog packages --component hyprland`,
+	Args: cobra.NoArgs,
+	RunE: runCatalogPackages,
+}
+
+// catalogProfilesCmd represents the catalog profiles command
+var catalogProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List installation profiles",
+	Long: `List the built-in installation profiles and the custom ones in
+~/.gohan/profiles, with the packages each cannot install per suite.`,
+	Args: cobra.NoArgs,
+	RunE: runCatalogProfiles,
+}
+
+func init() {
+	rootCmd.AddCommand(catalogCmd)
+	catalogCmd.AddCommand(catalogComponentsCmd)
+	catalogCmd.AddCommand(catalogPackagesCmd)
+	catalogCmd.AddCommand(catalogProfilesCmd)
+
+	catalogCmd.PersistentFlags().BoolVar(&catalogJSON, "json", false, "Output in JSON format")
+	catalogPackagesCmd.Flags().StringVar(&catalogComponent, "component", "", "Only packages of this component")
+	catalogPackagesCmd.Flags().StringVar(&catalogGroup, "group", "", "Only packages in this group")
+	catalogPackagesCmd.Flags().StringVar(&catalogSuite, "suite", "", "Only packages available in this suite (sid or trixie)")
+}
+
+func runCatalogComponents(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	components, err := c.GetCatalogUseCase.Components(context.Background())
+	if err != nil {
+		return err
+	}
+	if catalogJSON {
+		return printCatalogJSON(components)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tGROUPS\tAVAILABLE IN\tPACKAGES")
+	for _, component := range components {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			component.Name,
+			strings.Join(component.Groups, ", "),
+			availableSuites(component.Availability),
+			strings.Join(component.Packages, ", "))
+	}
+	return w.Flush()
+}
+
+func runCatalogPackages(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	packages, err := c.GetCatalogUseCase.Packages(context.Background(), dto.CatalogPackagesRequest{
+		Component: catalogComponent,
+		Group:     catalogGroup,
+		Suite:     catalogSuite,
+	})
+	if err != nil {
+		return err
+	}
+	if catalogJSON {
+		return printCatalogJSON(packages)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PACKAGE\tCOMPONENT\tGROUP\tREQUIRED\tAVAILABLE IN\tDESCRIPTION")
+	for _, pkg := range packages {
+		component := pkg.Component
+		if component == "" {
+			component = "-"
+		}
+		required := "no"
+		if pkg.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			pkg.Name, component, pkg.Group, required, availableSuites(pkg.Availability), pkg.Description)
+	}
+	return w.Flush()
+}
+
+func runCatalogProfiles(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	profiles, err := c.GetCatalogUseCase.Profiles(context.Background())
+	if err != nil {
+		return err
+	}
+	if catalogJSON {
+		return printCatalogJSON(profiles)
+	}
+
+	for i, profile := range profiles {
+		if i > 0 {
+			fmt.Println()
+		}
+		kind := "custom"
+		if profile.Builtin {
+			kind = "built-in"
+		}
+		fmt.Printf("%s (%s)\n", profile.ID, kind)
+		if profile.Error != "" {
+			fmt.Printf("  ✗ %s\n", profile.Error)
+			continue
+		}
+		fmt.Printf("  Name:       %s\n", profile.Name)
+		if profile.Description != "" {
+			fmt.Printf("  About:      %s\n", profile.Description)
+		}
+		fmt.Printf("  Rendering:  %s\n", profile.RenderingMode)
+		fmt.Printf("  Components: %s\n", strings.Join(profile.Components, ", "))
+		fmt.Printf("  Packages:   %d\n", len(profile.Packages))
+		if len(profile.OptIn) > 0 {
+			fmt.Printf("  Opt-in:     %s\n", strings.Join(profile.OptIn, ", "))
+		}
+		suites := make([]string, 0, len(profile.Unavailable))
+		for suite := range profile.Unavailable {
+			suites = append(suites, suite)
+		}
+		sort.Strings(suites)
+		for _, suite := range suites {
+			if unavailable := profile.Unavailable[suite]; len(unavailable) > 0 {
+				fmt.Printf("  Not in %s: %s\n", suite, strings.Join(unavailable, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// availableSuites lists the suites marked available, sorted
+func availableSuites(availability map[string]bool) string {
+	var suites []string
+	for suite, available := range availability {
+		if available {
+			suites = append(suites, suite)
+		}
+	}
+	if len(suites) == 0 {
+		return "-"
+	}
+	sort.Strings(suites)
+	return strings.Join(suites, ", ")
+}
+
+func printCatalogJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode catalog: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for component swap command
+	swapKeepPrevious bool
+	swapBackground   bool
+)
+
+// componentCmd groups commands changing installed components
+var componentCmd = &cobra.Command{
+	Use:   "component",
+	Short: "Change the components of an existing installation",
+	Long: `Change the components of an existing installation.
+
+Some roles are filled by exactly one of several interchangeable packages:
+
+  terminal     kitty, alacritty, foot
+  locker       hyprlock, swaylock
+  idle         hypridle, swayidle
+  wallpaper    swaybg, hyprpaper
+  power        power-profiles-daemon, tlp
+  night_light  wlsunset, gammastep`,
+}
+
+// componentSwapCmd replaces one provider with another
+var componentSwapCmd = &cobra.Command{
+	Use:   "swap <current> <replacement>",
+	Short: "Replace a component with another package filling the same role",
+	Long: `Replace a component with another package filling the same role.
+
+The replacement is installed first. Configuration that refers to the role is
+then rendered again, such as the keybindings launching the terminal, along
+with the replacement's own configuration. Existing files are backed up
+before they are overwritten. Finally the replaced package and its companion
+packages are removed, unless --keep-previous is given.
+
+The swap is recorded in installation history like an installation.
+
+Examples:
+  # Use alacritty instead of kitty
+  gohan component swap kitty alacritty
+
+  # Try swaylock but keep hyprlock installed
+  gohan component swap hyprlock swaylock --keep-previous
+
+  # Swap without slowing down the running desktop
+  gohan component swap kitty foot --background`,
+	Args: cobra.ExactArgs(2),
+	RunE: runComponentSwap,
+}
+
+func init() {
+	rootCmd.AddCommand(componentCmd)
+	componentCmd.AddCommand(componentSwapCmd)
+
+	componentSwapCmd.Flags().BoolVar(&swapKeepPrevious, "keep-previous", false, "Keep the replaced package installed")
+	componentSwapCmd.Flags().BoolVar(&swapBackground, "background", false, "Run apt and dpkg at low CPU and IO priority (installation.background)")
+}
+
+func runComponentSwap(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	var opts []container.Option
+	if swapBackground {
+		opts = append(opts, container.WithBackgroundPriority())
+	}
+	c, err := container.New(opts...)
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	fmt.Printf("Swapping %s for %s...\n", args[0], args[1])
+
+	response, err := c.SwapComponentUseCase.Execute(ctx, dto.SwapComponentRequest{
+		From:         args[0],
+		To:           args[1],
+		KeepPrevious: swapKeepPrevious,
+		Invocation:   currentInvocation(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to swap %s for %s: %w", args[0], args[1], err)
+	}
+
+	fmt.Printf("\n✓ %s is now %s\n\n", response.Slot, response.To)
+	fmt.Printf("Installed:  %v\n", response.InstalledPackages)
+	if len(response.RemovedPackages) > 0 {
+		fmt.Printf("Removed:    %v\n", response.RemovedPackages)
+	}
+
+	if len(response.Variables) > 0 {
+		names := make([]string, 0, len(response.Variables))
+		for name := range response.Variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println("\nUpdated template variables:")
+		for _, name := range names {
+			fmt.Printf("  %-18s %s\n", name, response.Variables[name])
+		}
+	}
+
+	if len(response.ConfigFiles) > 0 {
+		fmt.Println("\nRendered configuration:")
+		for _, path := range response.ConfigFiles {
+			fmt.Printf("  %s\n", path)
+		}
+		fmt.Println("\nReload Hyprland (hyprctl reload) to use the new keybindings.")
+	}
+
+	fmt.Println("\nView the change with: gohan history list")
+	return nil
+}
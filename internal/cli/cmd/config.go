@@ -1,16 +1,29 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+	verificationApp "github.com/rebelopsio/gohan/internal/application/verification"
+	"github.com/rebelopsio/gohan/internal/config"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/portals"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/weather"
+	maintenanceInfra "github.com/rebelopsio/gohan/internal/infrastructure/maintenance"
+	migrationInfra "github.com/rebelopsio/gohan/internal/infrastructure/migration"
+	preflightInfra "github.com/rebelopsio/gohan/internal/infrastructure/preflight/detectors"
+	verificationInfra "github.com/rebelopsio/gohan/internal/infrastructure/verification/checkers"
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +45,8 @@ var configDeployCmd = &cobra.Command{
 
 Automatically backs up existing configurations before deploying new ones.
 Templates are personalized for your system (username, paths, etc.).
+Only the part between the GOHAN MANAGED BLOCK markers is replaced, so
+settings you add above or below it are kept.
 
 Examples:
   # Deploy all configurations
@@ -40,11 +55,38 @@ Examples:
   # Deploy specific components
   gohan config deploy --components hyprland,waybar
 
-  # Preview without deploying
+  # Choose the portal backends for screen sharing and file pickers
+  gohan config deploy --components portals
+
+  # Apply the lock_screen settings from ~/.gohan/config.yaml
+  gohan config deploy --components hyprlock
+
+  # Preview without deploying, with a diff of each change
   gohan config deploy --dry-run
 
   # Deploy with progress
-  gohan config deploy --progress`,
+  gohan config deploy --progress
+
+  # Deploy the lightweight variant (no blur/animations, lighter Waybar)
+  gohan config deploy --rendering lite
+
+  # Deploy with larger fonts and without animations
+  gohan config deploy --accessibility large-text,reduced-motion
+
+  # Leave the running session alone and apply on the next login
+  gohan config deploy --live next-login
+
+When Hyprland is running and hyprland.conf would change, you are asked
+whether to deploy now, since Hyprland reloads it at once and a broken
+version can take the session down, or on the next login: the files are
+then staged in ~/.gohan/pending and a systemd user service moves them into
+place before Hyprland starts. --live answers the question up front.
+
+Your copies of templates in templates.override_dir (~/.gohan/templates)
+are used in place of the shipped ones; see gohan config watch.
+
+The hooks.pre_deploy and hooks.post_deploy commands in ~/.gohan/config.yaml
+run before and after the files are written, except for a dry run.`,
 	RunE: runConfigDeploy,
 }
 
@@ -61,6 +103,9 @@ var (
 	configDryRun      bool
 	configForce       bool
 	configSkipBackup  bool
+	configRendering   string
+	configA11y        []string
+	configLive        string
 )
 
 func init() {
@@ -69,42 +114,39 @@ func init() {
 	configCmd.AddCommand(configListCmd)
 
 	// Deploy flags
-	configDeployCmd.Flags().StringSliceVar(&configComponents, "components", []string{}, "Components to deploy (hyprland,waybar,kitty,fuzzel)")
-	configDeployCmd.Flags().BoolVar(&configDryRun, "dry-run", false, "Preview deployment without making changes")
+	configDeployCmd.Flags().StringSliceVar(&configComponents, "components", []string{}, "Components to deploy (hyprland,waybar,kitty,fuzzel,portals,hyprlock,swaylock)")
+	configDeployCmd.Flags().BoolVar(&configDryRun, "dry-run", false, "Show the changes as diffs without deploying")
 	configDeployCmd.Flags().BoolVar(&configForce, "force", false, "Force deployment without prompting")
 	configDeployCmd.Flags().BoolVar(&configSkipBackup, "skip-backup", false, "Skip backup of existing configurations")
 	configDeployCmd.Flags().BoolVar(&showProgress, "progress", false, "Show progress during deployment")
+	configDeployCmd.Flags().StringVar(&configRendering, "rendering", "", "Rendering mode: auto, standard or lite (default: auto from system resources)")
+	configDeployCmd.Flags().StringSliceVar(&configA11y, "accessibility", nil, "Accessibility options: reduced-motion, large-text, high-contrast or none (default: from the accessibility settings)")
+	configDeployCmd.Flags().StringVar(&configLive, "live", "", "When Hyprland is running and hyprland.conf would change: prompt, now or next-login (default: prompt)")
 }
 
 func runConfigDeploy(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	// Create infrastructure components
-	templateEngine := templates.NewTemplateEngine()
-
-	// Use default backup location
-	homeDir, _ := os.UserHomeDir()
-	backupRoot := filepath.Join(homeDir, ".local/share/gohan/backups")
-	backupService := backup.NewBackupService(backupRoot)
-
-	deployer := configservice.NewConfigDeployer(templateEngine, backupService)
-
-	// Create use case
-	useCase := configApp.NewConfigDeployUseCase(deployer, templateEngine)
-
-	// Build request
-	request := configApp.DeployConfigRequest{
-		Components:   configComponents,
-		DryRun:       configDryRun,
-		Force:        configForce,
-		SkipBackup:   configSkipBackup,
-		ShowProgress: showProgress,
-		CustomVars:   make(map[string]string),
+	useCase, request, err := newConfigDeploy(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	request.Components = configComponents
+	request.DryRun = configDryRun
+	request.Force = configForce
+	request.SkipBackup = configSkipBackup
+	request.ShowProgress = showProgress
+
+	if !request.DryRun {
+		nextLogin, err := guardLiveSession(ctx, useCase, request)
+		if err != nil {
+			return err
+		}
+		request.NextLogin = nextLogin
 	}
 
 	// Execute with or without progress
 	var resp *configApp.DeployConfigResponse
-	var err error
 
 	if showProgress {
 		fmt.Println("📦 Deploying configurations...")
@@ -141,9 +183,18 @@ func runConfigDeploy(cmd *cobra.Command, args []string) error {
 		if resp.FailedFiles > 0 {
 			fmt.Printf("Failed:           %d ✗\n", resp.FailedFiles)
 		}
+		if resp.UnchangedFiles > 0 {
+			fmt.Printf("Unchanged:        %d =\n", resp.UnchangedFiles)
+		}
+		if resp.ConflictFiles > 0 {
+			fmt.Printf("Conflicts:        %d ⚠\n", resp.ConflictFiles)
+		}
 		if resp.SkippedFiles > 0 {
 			fmt.Printf("Skipped:          %d ⊘\n", resp.SkippedFiles)
 		}
+		if resp.PendingFiles > 0 {
+			fmt.Printf("Next login:       %d ⏳\n", resp.PendingFiles)
+		}
 	}
 
 	// Backup info
@@ -160,13 +211,42 @@ func runConfigDeploy(cmd *cobra.Command, args []string) error {
 		fmt.Println("Files:")
 		for _, file := range resp.DeployedFiles {
 			icon := getDeployStatusIcon(file.Status)
-			fmt.Printf("  %s [%s] %s\n", icon, file.Component, file.TargetPath)
+			fmt.Printf("  %s [%s] %s (%s)\n", icon, file.Component, file.TargetPath, file.Action)
+			if file.BytesWritten > 0 {
+				fmt.Printf("     Wrote %d bytes from %s\n", file.BytesWritten, file.SourceTemplate)
+			}
+			if file.BackupID != "" {
+				fmt.Printf("     Previous version backed up as %s\n", file.BackupID)
+			}
+			if file.MergePath != "" {
+				fmt.Printf("     %d conflict(s) with your edits, kept as they were; resolve in %s\n", file.Conflicts, file.MergePath)
+			}
 			if file.Error != "" {
 				fmt.Printf("     Error: %s\n", file.Error)
 			}
 		}
 	}
 
+	// What a dry run would change, as unified diffs
+	if resp.DryRun {
+		for _, file := range resp.DeployedFiles {
+			if file.Diff != "" {
+				fmt.Println()
+				printDiff(file.Diff)
+			}
+		}
+		fmt.Println()
+	}
+
+	// post_deploy hooks that failed after the files were written, or a
+	// login hook that could not be installed
+	for _, warning := range resp.Warnings {
+		fmt.Printf("⚠  %s\n", warning)
+	}
+	if len(resp.Warnings) > 0 {
+		fmt.Println()
+	}
+
 	fmt.Println(strings.Repeat("─", 60))
 
 	// Status message
@@ -174,6 +254,10 @@ func runConfigDeploy(cmd *cobra.Command, args []string) error {
 		fmt.Println("ℹ️  This was a dry-run. Run without --dry-run to deploy.")
 	} else if resp.FailedFiles > 0 {
 		fmt.Println("⚠  Some files failed to deploy. Check errors above.")
+	} else if resp.ConflictFiles > 0 {
+		fmt.Println("⚠  Resolve the conflicts in the .gohan-merge files, then run gohan config deploy again.")
+	} else if resp.PendingFiles > 0 {
+		fmt.Println("⏳ Staged for the next login; log out and back in to apply.")
 	} else {
 		fmt.Println("✓  Configuration deployment completed successfully!")
 	}
@@ -185,11 +269,238 @@ func runConfigDeploy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%d file(s) failed to deploy", resp.FailedFiles)
 	}
 
+	if !resp.DryRun && deployedComponent(resp, "hyprland") {
+		return verifyKeyboardLayout(ctx)
+	}
+
+	return nil
+}
+
+// newConfigDeploy builds the deploy use case and request shared by the
+// config commands from ~/.gohan/config.yaml and the --rendering and
+// --accessibility flags
+func newConfigDeploy(ctx context.Context, cmd *cobra.Command) (*configApp.ConfigDeployUseCase, configApp.DeployConfigRequest, error) {
+	// Create infrastructure components
+	templateEngine := templates.NewTemplateEngine()
+
+	// Use default backup location
+	homeDir, _ := os.UserHomeDir()
+	backupRoot := filepath.Join(homeDir, ".local/share/gohan/backups")
+	backupService := backup.NewBackupService(backupRoot)
+
+	deployer := configservice.NewConfigDeployer(templateEngine, backupService).
+		WithRecords(configservice.NewDeployRecordStore(filepath.Join(config.GetDataDir(), configservice.DeployRecordsFileName)))
+	var accessibility installation.AccessibilitySettings
+	var weatherLocation installation.WeatherLocation
+	var lockScreen installation.LockScreenSettings
+	var hooks configApp.DeployHooks
+	var overrideDir string
+	if cfg, err := config.Load(); err == nil {
+		policy := deployer.PermissionPolicy()
+		if !cfg.Permissions.RespectUmask {
+			policy.Umask = 0
+		}
+		policy.StrictSensitive = cfg.Permissions.StrictSensitive
+		deployer = deployer.WithPermissionPolicy(policy)
+
+		accessibility = installation.NewAccessibilitySettings(
+			cfg.Accessibility.ReducedMotion,
+			cfg.Accessibility.LargeText,
+			cfg.Accessibility.HighContrast,
+		)
+
+		lock := cfg.LockScreen
+		lockScreen, err = installation.NewLockScreenSettings(lock.Background, lock.Image, lock.Avatar, lock.Clock, lock.ClockSize)
+		if err != nil {
+			return nil, configApp.DeployConfigRequest{}, fmt.Errorf("invalid lock_screen in config: %w", err)
+		}
+
+		if cfg.Weather.Enabled {
+			location, err := weather.NewLocationResolver(cfg.Weather.City).Location()
+			if err != nil {
+				fmt.Printf("⚠ Leaving out the Waybar weather module: %v\n", err)
+			}
+			weatherLocation = location
+		}
+
+		runner, err := container.NewHookRunner(cfg.Hooks)
+		if err != nil {
+			return nil, configApp.DeployConfigRequest{}, err
+		}
+		hooks = runner
+		overrideDir = cfg.Templates.OverrideDir
+	}
+
+	// Options on the command line replace the configured ones
+	if cmd.Flags().Changed("accessibility") {
+		parsed, err := installation.ParseAccessibilitySettings(configA11y)
+		if err != nil {
+			return nil, configApp.DeployConfigRequest{}, err
+		}
+		accessibility = parsed
+	}
+
+	// Create use case
+	useCase := configApp.NewConfigDeployUseCase(deployer, templateEngine)
+	if hooks != nil {
+		useCase = useCase.WithHooks(hooks)
+	}
+	if overrideDir != "" {
+		useCase = useCase.WithTemplateOverrides(overrideDir)
+	}
+	useCase = useCase.WithPendingDeploys(filepath.Join(config.GetDataDir(), configservice.PendingDirName), pendingLoginHook())
+
+	// Resolve automatic rendering from detected memory and storage
+	mode, err := installation.ParseRenderingMode(configRendering)
+	if err != nil {
+		return nil, configApp.DeployConfigRequest{}, err
+	}
+	if mode.IsAuto() {
+		lowEnd := false
+		if resources, err := preflightInfra.NewSystemResourceDetector().DetectResources(ctx); err == nil {
+			lowEnd = resources.RecommendsLiteMode()
+		}
+		mode = mode.Resolve(lowEnd)
+	}
+
+	// Keyboard and monitor layouts imported with gohan migrate
+	importedVars, err := migrationInfra.NewVarStore(filepath.Join(config.GetDataDir(), migrationInfra.VarsFileName)).Load()
+	if err != nil {
+		return nil, configApp.DeployConfigRequest{}, err
+	}
+
+	// Choose portal backends among the installed ones
+	portalSelection, err := portals.NewDetector().Selection()
+	if err != nil {
+		return nil, configApp.DeployConfigRequest{}, err
+	}
+
+	// Build request
+	request := configApp.DeployConfigRequest{
+		CustomVars:    importedVars,
+		RenderingMode: mode,
+		Accessibility: accessibility,
+		Weather:       weatherLocation,
+		Portals:       portalSelection,
+		LockScreen:    lockScreen,
+	}
+
+	return useCase, request, nil
+}
+
+// guardLiveSession decides whether a deployment that would replace the
+// running Hyprland session's configuration waits for the next login,
+// asking when --live does not say. It reports false when Hyprland is not
+// running or hyprland.conf would not change.
+func guardLiveSession(ctx context.Context, useCase *configApp.ConfigDeployUseCase, request configApp.DeployConfigRequest) (bool, error) {
+	action, err := configuration.ParseLiveSessionAction(configLive)
+	if err != nil {
+		return false, err
+	}
+	if action == configuration.LiveSessionNow {
+		return false, nil
+	}
+
+	session, err := preflightInfra.NewSystemSessionDetector().DetectSession(ctx)
+	if err != nil || session.ActiveCompositor() != "Hyprland" {
+		return false, err
+	}
+	if action == configuration.LiveSessionNextLogin {
+		return true, nil
+	}
+
+	preview := request
+	preview.DryRun = true
+	resp, err := useCase.Execute(ctx, preview)
+	if err != nil {
+		return false, err
+	}
+	var replaced []string
+	for _, file := range resp.DeployedFiles {
+		if configuration.IsLiveSessionConfig(file.TargetPath) && file.Action != string(configservice.ActionUnchanged) {
+			replaced = append(replaced, file.TargetPath)
+		}
+	}
+	if len(replaced) == 0 {
+		return false, nil
+	}
+
+	fmt.Printf("⚠  Hyprland is running and %s would change.\n", strings.Join(replaced, ", "))
+	fmt.Println("   Hyprland reloads it at once; a broken version can take the session down.")
+	if !stdinIsTerminal() {
+		return false, fmt.Errorf("not deploying into the running session without confirmation; rerun with --live now or --live next-login")
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("Deploy [n]ow, on the next [l]ogin, or [c]ancel? ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println()
+			return false, fmt.Errorf("deployment cancelled")
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "n", "now":
+			return false, nil
+		case "l", "login":
+			return true, nil
+		case "c", "cancel", "q":
+			return false, fmt.Errorf("deployment cancelled")
+		}
+	}
+}
+
+// pendingLoginHook returns the hook applying deployments deferred to the
+// next login, or nil when the gohan binary cannot be located
+func pendingLoginHook() configApp.LoginHook {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+	unit, err := configuration.NewPendingApplyUnit(executable, filepath.Join(config.GetDataDir(), configservice.PendingDirName))
+	if err != nil {
+		return nil
+	}
+	return configservice.NewLoginHook(maintenanceInfra.NewSystemdTimerInstaller(), unit)
+}
+
+// deployedComponent returns true if files of the component were written
+func deployedComponent(resp *configApp.DeployConfigResponse, component string) bool {
+	for _, file := range resp.DeployedFiles {
+		if file.Component == component && file.Status == "deployed" {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyKeyboardLayout checks the deployed input configuration, so a layout
+// xkb cannot load is caught before the user locks the screen with it
+func verifyKeyboardLayout(ctx context.Context) error {
+	doctor := verificationApp.NewDoctorUseCase(verificationApp.Checkers{
+		KeyboardChecker: verificationInfra.NewKeyboardChecker(),
+	})
+	resp, err := doctor.Execute(ctx, verificationApp.DoctorRequest{QuickCheck: true})
+	if err != nil {
+		return fmt.Errorf("failed to verify keyboard layout: %w", err)
+	}
+
+	for _, result := range resp.Results {
+		displayDoctorResult(result)
+	}
+	fmt.Println()
+
+	if resp.CriticalIssues > 0 {
+		return fmt.Errorf("found %d critical issue(s)", resp.CriticalIssues)
+	}
 	return nil
 }
 
 func runConfigList(cmd *cobra.Command, args []string) {
-	fmt.Println("Available Configuration Components:\n")
+	fmt.Print("Available Configuration Components:\n\n")
 
 	components := []struct {
 		name        string
@@ -216,6 +527,21 @@ func runConfigList(cmd *cobra.Command, args []string) {
 			description: "Application launcher configuration",
 			files:       []string{"~/.config/fuzzel/fuzzel.ini"},
 		},
+		{
+			name:        "hyprlock",
+			description: "Lock screen background, avatar and clock (deployed only when listed)",
+			files:       []string{"~/.config/hypr/hyprlock.conf"},
+		},
+		{
+			name:        "swaylock",
+			description: "Lock screen for the swaylock alternative (deployed only when listed)",
+			files:       []string{"~/.config/swaylock/config"},
+		},
+		{
+			name:        "portals",
+			description: "Desktop portal backends for the Hyprland session (deployed only when listed)",
+			files:       []string{"~/.config/xdg-desktop-portal/hyprland-portals.conf"},
+		},
 	}
 
 	for _, comp := range components {
@@ -232,16 +558,48 @@ func runConfigList(cmd *cobra.Command, args []string) {
 	fmt.Println("  gohan config deploy --components hyprland,waybar")
 }
 
+// Diff colors; lipgloss leaves them out when stdout is not a terminal or
+// NO_COLOR is set
+var (
+	diffHeaderStyle  = lipgloss.NewStyle().Bold(true)
+	diffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+// printDiff prints a unified diff, coloring added and removed lines
+func printDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+			line = diffHeaderStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			line = diffHunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = diffAddedStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = diffRemovedStyle.Render(line)
+		}
+		fmt.Println(line)
+	}
+}
+
 func getDeployStatusIcon(status string) string {
 	switch status {
 	case "deployed":
 		return "✓"
+	case "unchanged":
+		return "="
+	case "conflict":
+		return "⚠"
 	case "failed":
 		return "✗"
 	case "skipped":
 		return "⊘"
-	case "dry-run":
+	case "dry-run", "staged":
 		return "ℹ"
+	case "pending":
+		return "⏳"
 	default:
 		return "?"
 	}
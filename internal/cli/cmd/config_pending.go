@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/spf13/cobra"
+)
+
+// configApplyPendingCmd applies a deployment deferred to the next login
+var configApplyPendingCmd = &cobra.Command{
+	Use:   "apply-pending",
+	Short: "Apply configuration deployed for the next login",
+	Long: `Move configuration staged with gohan config deploy --live next-login
+into place.
+
+The gohan-apply-pending systemd user service runs this at login, before
+Hyprland starts. Run it yourself from a TTY to apply the files without
+logging out and back in. Files changed since they were staged are left
+alone; deploy them again.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigApplyPending,
+}
+
+func init() {
+	configCmd.AddCommand(configApplyPendingCmd)
+}
+
+func runConfigApplyPending(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	resp, err := c.ConfigDeployUseCase.ApplyPending(context.Background())
+	if resp == nil && err == nil {
+		fmt.Println("✓ No configuration is waiting for the next login")
+		return nil
+	}
+	if resp != nil {
+		for _, file := range resp.DeployedFiles {
+			fmt.Printf("  %s %s (%s)\n", getDeployStatusIcon(file.Status), file.TargetPath, file.Action)
+			if file.Error != "" {
+				fmt.Printf("     Error: %s\n", file.Error)
+			}
+		}
+		for _, warning := range resp.Warnings {
+			fmt.Printf("⚠  %s\n", warning)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply pending configuration: %w", err)
+	}
+	if resp.FailedFiles > 0 {
+		return fmt.Errorf("%d file(s) failed to apply", resp.FailedFiles)
+	}
+	fmt.Printf("✓ Applied %d configuration file(s)\n", resp.SuccessfulFiles)
+	return nil
+}
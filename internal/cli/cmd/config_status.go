@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/spf13/cobra"
+)
+
+var configStatusAll bool
+
+// configStatusCmd reports local edits to deployed configurations
+var configStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show local changes to deployed configurations",
+	Long: `Compare the deployed configuration files with a fresh rendering of
+their templates, using the variables each file was deployed with.
+
+Files are reported as:
+
+  • modified   edited since gohan deployed them
+  • outdated   not edited, but their template changed; gohan config upgrade
+               applies the change
+  • missing    deployed, then removed; gohan config deploy puts them back
+  • unmanaged  in a directory gohan deploys to, but not deployed by gohan
+
+Each file is listed with the SHA-256 of its content on disk and of the
+template's rendering.
+
+Examples:
+  # Show the files that differ from their templates
+  gohan config status
+
+  # Include the unchanged files
+  gohan config status --all`,
+	Args: cobra.NoArgs,
+	RunE: runConfigStatus,
+}
+
+func init() {
+	configCmd.AddCommand(configStatusCmd)
+
+	configStatusCmd.Flags().BoolVar(&configStatusAll, "all", false, "Also list files that match their templates")
+}
+
+func runConfigStatus(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	resp, err := c.ConfigStatusUseCase.Execute(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Files) == 0 {
+		fmt.Println("No configurations deployed yet. Deploy them with: gohan config deploy")
+		return nil
+	}
+
+	for _, file := range resp.Files {
+		if file.Status == configApp.FileStatusClean && !configStatusAll {
+			continue
+		}
+		displayConfigFileStatus(file)
+	}
+
+	fmt.Printf("\nClean: %d, modified: %d, outdated: %d, missing: %d, unmanaged: %d\n",
+		resp.Clean, resp.Modified, resp.Outdated, resp.Missing, resp.Unmanaged)
+	if !resp.HasDrift() && resp.Outdated == 0 {
+		fmt.Println("✓ Deployed configurations match their templates")
+	}
+	if resp.Outdated > 0 {
+		fmt.Println("Apply the template updates with: gohan config upgrade")
+	}
+	return nil
+}
+
+func displayConfigFileStatus(file configApp.ConfigFileStatus) {
+	icon := "✓"
+	switch file.Status {
+	case configApp.FileStatusModified:
+		icon = "M"
+	case configApp.FileStatusOutdated:
+		icon = "U"
+	case configApp.FileStatusMissing:
+		icon = "D"
+	case configApp.FileStatusUnmanaged:
+		icon = "?"
+	}
+	fmt.Printf("  %s %-10s %s\n", icon, file.Status, file.TargetPath)
+
+	if file.CurrentHash != "" {
+		fmt.Printf("      on disk:  %s\n", shortHash(file.CurrentHash))
+	}
+	if file.RenderedHash != "" && file.RenderedHash != file.CurrentHash {
+		fmt.Printf("      template: %s\n", shortHash(file.RenderedHash))
+	}
+	if file.Error != "" {
+		fmt.Printf("      ⚠ compared with the deployed rendering: %s\n", file.Error)
+	}
+}
+
+// shortHash shortens a SHA-256 for display, like git's abbreviated hashes
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
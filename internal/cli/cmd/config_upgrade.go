@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/spf13/cobra"
+)
+
+var configUpgradeDryRun bool
+
+// configUpgradeCmd brings deployed files up to date with changed templates
+var configUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Apply template updates to deployed configurations",
+	Long: `Bring deployed configuration files up to date after a gohan update
+changed the templates they were rendered from.
+
+Each deployment records the template's version and what it rendered.
+Only files whose template changed since are touched:
+
+  • Files you have not edited are rendered again from the new template.
+  • Edited files get the template's changes merged with your edits.
+  • Where your edits and the template's changes overlap, the file is left
+    as it is and the merge, with conflict markers, is written next to it
+    as <file>.gohan-merge. Resolve the conflicts in that file and run
+    gohan config upgrade again to apply it.
+
+Replaced files are backed up first.
+
+Examples:
+  # Show the pending template updates
+  gohan config upgrade --dry-run
+
+  # Apply them
+  gohan config upgrade`,
+	Args: cobra.NoArgs,
+	RunE: runConfigUpgrade,
+}
+
+func init() {
+	configCmd.AddCommand(configUpgradeCmd)
+
+	configUpgradeCmd.Flags().BoolVar(&configUpgradeDryRun, "dry-run", false, "Show the pending template updates without applying them")
+}
+
+func runConfigUpgrade(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	resp, err := c.UpgradeTemplatesUseCase.Execute(context.Background(), configApp.UpgradeTemplatesRequest{
+		DryRun: configUpgradeDryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Files) == 0 {
+		fmt.Println("✓ All deployed configurations are up to date with their templates")
+		return nil
+	}
+
+	if resp.DryRun {
+		fmt.Printf("Pending template updates (%d):\n\n", len(resp.Files))
+	} else {
+		fmt.Printf("Template updates (%d):\n\n", len(resp.Files))
+	}
+	for _, file := range resp.Files {
+		displayTemplateUpgrade(file)
+	}
+	fmt.Println()
+
+	if resp.DryRun {
+		fmt.Println("Apply them with: gohan config upgrade")
+		return nil
+	}
+
+	fmt.Printf("Upgraded: %d, conflicts: %d, failed: %d\n", resp.Upgraded, resp.Conflicts, resp.Failed)
+	if resp.Conflicts > 0 {
+		fmt.Println("\nResolve the conflicts in the .gohan-merge files, then run gohan config upgrade again.")
+	}
+	if resp.Failed > 0 {
+		return fmt.Errorf("%d file(s) failed to upgrade", resp.Failed)
+	}
+	return nil
+}
+
+func displayTemplateUpgrade(file configApp.TemplateUpgradeInfo) {
+	switch file.Status {
+	case "missing":
+		fmt.Printf("  ⊘ %s (removed; gohan config deploy puts it back)\n", file.TargetPath)
+		return
+	case "failed":
+		fmt.Printf("  ✗ %s: %s\n", file.TargetPath, file.Error)
+		return
+	}
+
+	icon := "✓"
+	switch {
+	case file.Status == "pending":
+		icon = "ℹ"
+	case file.Status == "conflict":
+		icon = "⚠"
+	}
+
+	description := file.Action
+	switch file.Action {
+	case "re-render":
+		description = "not edited, rendered again"
+	case "merge":
+		description = "your edits merged with the template's changes"
+	case "resolved":
+		description = "resolved merge applied"
+		if file.Status == "pending" {
+			description = "resolved merge ready to apply"
+		}
+	case "conflict":
+		description = fmt.Sprintf("%d conflict(s) with your edits", file.Conflicts)
+		if file.Conflicts == 0 {
+			description = "conflicts with your edits still unresolved"
+		}
+	}
+	fmt.Printf("  %s %s (%s)\n", icon, file.TargetPath, description)
+
+	if file.MergePath != "" && file.Status == "conflict" {
+		fmt.Printf("      Resolve in: %s\n", file.MergePath)
+	}
+	if file.BackupID != "" {
+		fmt.Printf("      Backup: %s\n", file.BackupID)
+	}
+}
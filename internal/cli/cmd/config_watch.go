@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+	themeInfra "github.com/rebelopsio/gohan/internal/infrastructure/theme"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configWatchApply      bool
+	configWatchStagingDir string
+	configWatchInterval   time.Duration
+)
+
+// configWatchCmd re-renders configuration as the user's templates change
+var configWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Re-render configurations as you edit your templates",
+	Long: `Watch your copies of gohan's templates and render the configuration
+files they affect each time you save one.
+
+Copies live in templates.override_dir (~/.gohan/templates) and mirror the
+templates directory: ~/.gohan/templates/kitty/kitty.conf.tmpl is used in
+place of templates/kitty/kitty.conf.tmpl by gohan config deploy and
+gohan config watch alike.
+
+Each change renders the affected files to the staging directory and
+validates them: JSON configs must parse and no {{variable}} may be left
+unreplaced. With --apply, files that validate are deployed as with gohan
+config deploy and Hyprland, Waybar, mako and kitty are reloaded.
+
+Examples:
+  # Render to the staging directory as you edit
+  gohan config watch
+
+  # Deploy and reload on every valid save
+  gohan config watch --apply
+
+  # Only watch the templates Waybar is rendered from
+  gohan config watch --components waybar --apply`,
+	Args: cobra.NoArgs,
+	RunE: runConfigWatch,
+}
+
+func init() {
+	configCmd.AddCommand(configWatchCmd)
+
+	configWatchCmd.Flags().BoolVar(&configWatchApply, "apply", false, "Deploy files that validate and reload the desktop")
+	configWatchCmd.Flags().StringVar(&configWatchStagingDir, "staging-dir", filepath.Join(os.TempDir(), "gohan-watch"), "Directory the affected files are rendered to")
+	configWatchCmd.Flags().DurationVar(&configWatchInterval, "interval", configApp.DefaultWatchInterval, "How often to check for changes")
+	configWatchCmd.Flags().StringSliceVar(&configComponents, "components", []string{}, "Components to watch (default: all)")
+	configWatchCmd.Flags().StringVar(&configRendering, "rendering", "", "Rendering mode: auto, standard or lite (default: auto from system resources)")
+	configWatchCmd.Flags().StringSliceVar(&configA11y, "accessibility", nil, "Accessibility options: reduced-motion, large-text, high-contrast or none (default: from the accessibility settings)")
+}
+
+func runConfigWatch(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	deploy, request, err := newConfigDeploy(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	request.Components = configComponents
+
+	useCase := configApp.NewWatchTemplatesUseCase(deploy).
+		WithReloader(themeInfra.NewReloadService(themeInfra.NewSystemCommandExecutor(), themeInfra.ComponentTargetPath("kitty")))
+
+	fmt.Printf("👀 Watching %s\n", useCase.OverrideDir())
+	if configWatchApply {
+		fmt.Println("   Valid changes are deployed and the desktop reloaded")
+	} else {
+		fmt.Printf("   Rendering to %s\n", configWatchStagingDir)
+	}
+	fmt.Println("   Press Ctrl+C to stop")
+	fmt.Println()
+
+	return useCase.Execute(ctx, configApp.WatchTemplatesRequest{
+		Deploy:     request,
+		StagingDir: configWatchStagingDir,
+		Apply:      configWatchApply,
+		Interval:   configWatchInterval,
+	}, displayTemplateChange)
+}
+
+// displayTemplateChange prints what was done about changed templates
+func displayTemplateChange(event configApp.TemplateChangeEvent) {
+	fmt.Printf("[%s] Changed: %s\n", time.Now().Format("15:04:05"), strings.Join(event.Templates, ", "))
+	if len(event.Components) == 0 {
+		fmt.Println("  No watched component is rendered from it")
+		fmt.Println()
+		return
+	}
+
+	for _, file := range event.Staged {
+		fmt.Printf("  %s %s → %s\n", getDeployStatusIcon(file.Status), file.TargetPath, file.StagedPath)
+	}
+	for _, problem := range event.Problems {
+		fmt.Printf("  ✗ %s\n", problem)
+	}
+	if len(event.Problems) == 0 && event.Err == nil && len(event.Staged) > 0 {
+		fmt.Println("  ✓ Valid")
+	}
+	for _, file := range event.Deployed {
+		fmt.Printf("  %s %s (%s)\n", getDeployStatusIcon(file.Status), file.TargetPath, file.Action)
+	}
+	if len(event.Reloaded) > 0 {
+		fmt.Printf("  🔄 Reloaded %s\n", strings.Join(event.Reloaded, ", "))
+	}
+	if event.Err != nil {
+		fmt.Printf("  ✗ %v\n", event.Err)
+	}
+	fmt.Println()
+}
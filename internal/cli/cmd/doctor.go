@@ -2,10 +2,16 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	verificationApp "github.com/rebelopsio/gohan/internal/application/verification"
+	"github.com/rebelopsio/gohan/internal/config"
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/infrastructure/history/sysinfo"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
 	verificationInfra "github.com/rebelopsio/gohan/internal/infrastructure/verification/checkers"
 	"github.com/spf13/cobra"
 )
@@ -19,8 +25,14 @@ var doctorCmd = &cobra.Command{
 The doctor command checks critical components to ensure your
 system is correctly configured and functioning properly. It verifies:
 - Hyprland binary installation
-- Configuration files
+- Configuration files, including a syntax check of hyprland.conf
+  and the files it sources
+- Packages of the last installation are still installed
+- GPU driver modules are loaded
+- A display manager session or TTY launcher starts Hyprland
 - Theme application
+- Swap and zram status
+- Configuration ownership and permissions
 - And more...
 
 Examples:
@@ -31,13 +43,22 @@ Examples:
   gohan doctor --progress
 
   # Quick check (critical only)
-  gohan doctor --quick`,
+  gohan doctor --quick
+
+  # Print the report as JSON, for scripts and monitoring
+  gohan doctor --json
+
+  # Also start Hyprland headless with your configuration for a few
+  # seconds, to catch config or driver problems before logging out
+  gohan doctor --smoke-test`,
 	RunE: runDoctor,
 }
 
 // Flags
 var (
 	quickCheck bool
+	smokeTest  bool
+	doctorJSON bool
 )
 
 func init() {
@@ -46,19 +67,17 @@ func init() {
 	// Flags
 	doctorCmd.Flags().BoolVar(&quickCheck, "quick", false, "Run only critical checks")
 	doctorCmd.Flags().BoolVar(&showProgress, "progress", false, "Show progress during checks")
+	doctorCmd.Flags().BoolVar(&smokeTest, "smoke-test", false, "Start Hyprland headless to confirm it comes up with your configuration")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Print the health report as JSON")
+	doctorCmd.MarkFlagsMutuallyExclusive("json", "progress")
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	// Create checkers
-	checkers := verificationApp.Checkers{
-		HyprlandChecker: verificationInfra.NewHyprlandChecker(),
-		ThemeChecker:    verificationInfra.NewThemeChecker(),
-		ConfigChecker:   verificationInfra.NewConfigChecker(),
-	}
-
 	// Create use case
+	checkers, closeCheckers := newDoctorCheckers()
+	defer closeCheckers()
 	useCase := verificationApp.NewDoctorUseCase(checkers)
 
 	// Execute with or without progress
@@ -74,6 +93,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 			verificationApp.DoctorRequest{
 				ShowProgress: true,
 				QuickCheck:   quickCheck,
+				SmokeTest:    smokeTest,
 			},
 			func(checkerName string, result verificationApp.CheckResultDTO) {
 				// Display progress
@@ -84,6 +104,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	} else {
 		resp, err = useCase.Execute(ctx, verificationApp.DoctorRequest{
 			QuickCheck: quickCheck,
+			SmokeTest:  smokeTest,
 		})
 	}
 
@@ -91,6 +112,18 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("health check failed: %w", err)
 	}
 
+	if doctorJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			return fmt.Errorf("failed to encode health report: %w", err)
+		}
+		if resp.CriticalIssues > 0 {
+			return fmt.Errorf("found %d critical issue(s)", resp.CriticalIssues)
+		}
+		return nil
+	}
+
 	// Display results
 	fmt.Println("\n" + strings.Repeat("═", 60))
 	fmt.Printf("  SYSTEM HEALTH CHECK RESULTS\n")
@@ -151,6 +184,49 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// newDoctorCheckers creates the checkers shared by gohan doctor and the
+// scheduled health check, and a function closing what they opened
+func newDoctorCheckers() (verificationApp.Checkers, func()) {
+	// Strict checks on sensitive files follow the deployment policy
+	strictSensitive := false
+	if cfg, err := config.Load(); err == nil {
+		strictSensitive = cfg.Permissions.StrictSensitive
+	}
+
+	// Installed packages are those of the last installation, whether it
+	// ran for this user or system-wide
+	var packagesChecker *verificationInfra.PackagesChecker
+	repos, closeRepos, err := openHistoryRepos([]history.Scope{sysinfo.CurrentScope(), history.SystemScope()}, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Not checking installed packages: %v\n", err)
+		closeRepos = func() {}
+	} else {
+		packagesChecker = verificationInfra.NewPackagesChecker(
+			verificationInfra.NewHistoryPackageSource(repos...),
+			packagemanager.NewAPTManager(),
+		)
+	}
+
+	checkers := verificationApp.Checkers{
+		HyprlandChecker:    verificationInfra.NewHyprlandChecker(),
+		ThemeChecker:       verificationInfra.NewThemeChecker(),
+		ConfigChecker:      verificationInfra.NewConfigChecker(),
+		KeyboardChecker:    verificationInfra.NewKeyboardChecker(),
+		SwapChecker:        verificationInfra.NewSwapChecker(),
+		PermissionsChecker: verificationInfra.NewPermissionsChecker(strictSensitive),
+		PortalChecker:      verificationInfra.NewPortalChecker(),
+		GPUDriverChecker:   verificationInfra.NewGPUDriverChecker(),
+		SessionChecker:     verificationInfra.NewSessionFileChecker(),
+
+		SessionSmokeChecker: verificationInfra.NewSessionSmokeChecker(),
+	}
+	// A nil pointer in the interface would not be skipped
+	if packagesChecker != nil {
+		checkers.PackagesChecker = packagesChecker
+	}
+	return checkers, closeRepos
+}
+
 func displayDoctorResult(result verificationApp.CheckResultDTO) {
 	statusIcon := getStatusIcon(result.Status)
 
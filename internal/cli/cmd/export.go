@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	exportApp "github.com/rebelopsio/gohan/internal/application/export"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export sessions and history for analytics",
+	Long: `Write installation sessions, their events and installation history records
+for loading into external tools such as DuckDB or Grafana Loki.
+
+Each line is a JSON object with a "type" of session, event or history and
+a "schema_version". Sessions are written oldest first, each followed by its
+events (phases started, component state changes and warnings) in the order
+they happened, then the history records. Times are UTC RFC 3339.
+
+--since takes an age such as 90d, 2w or 12h, or a date (YYYY-MM-DD), and
+keeps the sessions started and records made since then.
+
+Examples:
+  # Export the last 90 days
+  gohan export --format jsonl --since 90d > gohan.jsonl
+
+  # Write everything to a file
+  gohan export --output gohan.jsonl
+
+  # Query it with DuckDB
+  duckdb -c "SELECT status, count(*) FROM read_json_auto('gohan.jsonl') WHERE type = 'session' GROUP BY status"`,
+	RunE: runExport,
+}
+
+// Flags
+var (
+	exportDataFormat string
+	exportDataSince  string
+	exportDataOutput string
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportDataFormat, "format", exportApp.FormatJSONL, "Output format (jsonl)")
+	exportCmd.Flags().StringVar(&exportDataSince, "since", "", "Only export data from this age (90d, 2w, 12h) or date (YYYY-MM-DD) on")
+	exportCmd.Flags().StringVarP(&exportDataOutput, "output", "o", "", "File to write (default: standard output)")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	since, err := parseSince(exportDataSince, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	var out io.Writer = os.Stdout
+	if exportDataOutput != "" {
+		file, err := os.Create(exportDataOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", exportDataOutput, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	useCase := exportApp.NewExportDataUseCase(c.InstallationRepo, c.HistoryRepo)
+	resp, err := useCase.Execute(context.Background(), out, exportApp.ExportDataRequest{
+		Format: exportDataFormat,
+		Since:  since,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Counts go to stderr so they never mix with exported lines
+	fmt.Fprintf(os.Stderr, "Exported %d session(s), %d event(s) and %d history record(s)\n",
+		resp.Sessions, resp.Events, resp.HistoryRecords)
+	return nil
+}
+
+// parseSince turns an age with a d (days), w (weeks) or Go duration unit,
+// or a YYYY-MM-DD date, into the time it starts. Empty means everything.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil || n < 0 {
+				return time.Time{}, fmt.Errorf("%q is not an age such as 90d", value)
+			}
+			return now.Add(-time.Duration(n) * unit), nil
+		}
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return time.Time{}, fmt.Errorf("%q is not an age such as 90d or a date such as 2025-10-01", value)
+	}
+	return now.Add(-age), nil
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,8 +11,10 @@ import (
 	"time"
 
 	"github.com/rebelopsio/gohan/internal/application/history/services"
+	"github.com/rebelopsio/gohan/internal/config"
 	"github.com/rebelopsio/gohan/internal/domain/history"
 	historyRepo "github.com/rebelopsio/gohan/internal/infrastructure/history/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/history/sysinfo"
 	historyTUI "github.com/rebelopsio/gohan/internal/tui/history"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -23,6 +26,12 @@ var (
 	listStatus string
 	listFrom   string
 	listTo     string
+	listScope  string
+
+	// Flags for history changes command
+	changesSince string
+	changesUntil string
+	changesScope string
 
 	// Flags for history export command
 	exportOutput string
@@ -58,7 +67,14 @@ Examples:
   gohan history list --limit 10
 
   # List installations in a date range
-  gohan history list --from 2025-10-01 --to 2025-10-31`,
+  gohan history list --from 2025-10-01 --to 2025-10-31
+
+  # List system-wide and your own installations together
+  gohan history list --scope all
+
+History is kept per scope: installations run as root are recorded in the
+system-wide database, other users keep their own history in ~/.gohan.
+By default the scope of the current user is listed.`,
 	RunE: runHistoryList,
 }
 
@@ -76,6 +92,8 @@ The show command displays:
   - All installed packages
   - Failure details (if applicable)
 
+The record is looked up in both the system-wide and your own history.
+
 Example:
   gohan history show abc123-def456-ghi789`,
 	Args: cobra.ExactArgs(1),
@@ -107,31 +125,66 @@ Example:
 	RunE: runHistoryBrowse,
 }
 
+// historyChangesCmd represents the history changes command
+var historyChangesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "Show what gohan changed since a point in history",
+	Long: `Diff the installed packages and deployed configuration files between
+two points in history.
+
+A point is either a record ID (or a unique prefix of one, as shown by
+'gohan history list') or a date (YYYY-MM-DD). A record point includes
+that record; a --since date starts at midnight and an --until date ends
+at the end of the day. Without --until the diff runs up to now.
+
+Examples:
+  # What changed since a specific installation
+  gohan history changes --since 3f2a9c1d
+
+  # What changed last Tuesday
+  gohan history changes --since 2025-10-14 --until 2025-10-14
+
+  # Include system-wide installations
+  gohan history changes --since 2025-10-01 --scope all`,
+	RunE: runHistoryChanges,
+}
+
 func init() {
 	// Add subcommands to history
 	historyCmd.AddCommand(historyListCmd)
 	historyCmd.AddCommand(historyShowCmd)
 	historyCmd.AddCommand(historyBrowseCmd)
+	historyCmd.AddCommand(historyChangesCmd)
 
 	// Flags for list command
 	historyListCmd.Flags().IntVarP(&listLimit, "limit", "n", 20, "Limit number of results")
 	historyListCmd.Flags().StringVar(&listStatus, "status", "", "Filter by status (success/failed)")
 	historyListCmd.Flags().StringVar(&listFrom, "from", "", "Start date (YYYY-MM-DD)")
 	historyListCmd.Flags().StringVar(&listTo, "to", "", "End date (YYYY-MM-DD)")
+	historyListCmd.Flags().StringVar(&listScope, "scope", "", "History to list (user/system/all, default: current user)")
+
+	// Flags for changes command
+	historyChangesCmd.Flags().StringVar(&changesSince, "since", "", "Record ID or date (YYYY-MM-DD) to diff from")
+	historyChangesCmd.Flags().StringVar(&changesUntil, "until", "", "Record ID or date (YYYY-MM-DD) to diff to (default: now)")
+	historyChangesCmd.Flags().StringVar(&changesScope, "scope", "", "History to read (user/system/all, default: current user)")
+	_ = historyChangesCmd.MarkFlagRequired("since")
 }
 
 func runHistoryList(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	// Initialize repository and service
-	dbPath := getHistoryDBPath()
-	repo, err := historyRepo.NewSQLiteRepository(dbPath)
+	// Initialize repositories and service
+	scopes, err := resolveHistoryScopes(listScope)
 	if err != nil {
-		return fmt.Errorf("failed to open history database: %w", err)
+		return err
 	}
-	defer repo.Close()
+	repos, closeRepos, err := openHistoryRepos(scopes, len(scopes) > 1)
+	if err != nil {
+		return err
+	}
+	defer closeRepos()
 
-	service := services.NewHistoryQueryService(repo)
+	service := services.NewMergedHistoryQueryService(repos...)
 
 	// Build filter
 	filter := history.NewRecordFilter()
@@ -177,7 +230,7 @@ func runHistoryList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	displayRecordsList(records)
+	displayRecordsList(records, len(scopes) > 1)
 	return nil
 }
 
@@ -185,15 +238,18 @@ func runHistoryShow(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	recordIDStr := args[0]
 
-	// Initialize repository and service
-	dbPath := getHistoryDBPath()
-	repo, err := historyRepo.NewSQLiteRepository(dbPath)
+	// Initialize repositories and service
+	scopes, err := resolveHistoryScopes(historyScopeAll)
 	if err != nil {
-		return fmt.Errorf("failed to open history database: %w", err)
+		return err
 	}
-	defer repo.Close()
+	repos, closeRepos, err := openHistoryRepos(scopes, true)
+	if err != nil {
+		return err
+	}
+	defer closeRepos()
 
-	service := services.NewHistoryQueryService(repo)
+	service := services.NewMergedHistoryQueryService(repos...)
 
 	// Parse record ID
 	recordID, err := history.ParseRecordID(recordIDStr)
@@ -204,7 +260,7 @@ func runHistoryShow(cmd *cobra.Command, args []string) error {
 	// Retrieve record
 	record, err := service.GetRecordByID(ctx, recordID)
 	if err != nil {
-		if err == history.ErrRecordNotFound {
+		if errors.Is(err, history.ErrRecordNotFound) {
 			return fmt.Errorf("record not found: %s", recordIDStr)
 		}
 		return fmt.Errorf("failed to retrieve record: %w", err)
@@ -217,7 +273,7 @@ func runHistoryShow(cmd *cobra.Command, args []string) error {
 
 func runHistoryBrowse(cmd *cobra.Command, args []string) error {
 	// Initialize repository and service
-	dbPath := getHistoryDBPath()
+	dbPath := historyDBPathForScope(sysinfo.CurrentScope())
 	repo, err := historyRepo.NewSQLiteRepository(dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open history database: %w", err)
@@ -237,12 +293,141 @@ func runHistoryBrowse(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runHistoryChanges(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Initialize repositories and service
+	scopes, err := resolveHistoryScopes(changesScope)
+	if err != nil {
+		return err
+	}
+	repos, closeRepos, err := openHistoryRepos(scopes, len(scopes) > 1)
+	if err != nil {
+		return err
+	}
+	defer closeRepos()
+
+	service := services.NewMergedHistoryQueryService(repos...)
+
+	records, err := service.ListRecords(ctx, history.NewRecordFilter())
+	if err != nil {
+		return fmt.Errorf("failed to query history: %w", err)
+	}
+
+	from, err := resolveHistoryPoint(changesSince, records, false)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	to := time.Now()
+	if changesUntil != "" {
+		to, err = resolveHistoryPoint(changesUntil, records, true)
+		if err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+
+	changes, err := history.ChangesBetween(records, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to compute changes: %w", err)
+	}
+
+	displayChanges(changes, changesUntil == "")
+	return nil
+}
+
+// resolveHistoryPoint turns a record ID, record ID prefix or date into a
+// point in time. A record point is the moment the record was written, so
+// diffing from it includes the record itself in the baseline.
+func resolveHistoryPoint(value string, records []history.InstallationRecord, endOfDay bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		if endOfDay {
+			return date.Add(24*time.Hour - time.Nanosecond), nil
+		}
+		// Just before midnight, so records written at midnight count as changes
+		return date.Add(-time.Nanosecond), nil
+	}
+
+	var matches []history.InstallationRecord
+	for _, record := range records {
+		if strings.HasPrefix(record.ID().String(), value) {
+			matches = append(matches, record)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return time.Time{}, fmt.Errorf("%q is neither a date (YYYY-MM-DD) nor a known record ID", value)
+	case 1:
+		return matches[0].RecordedAt(), nil
+	default:
+		return time.Time{}, fmt.Errorf("record ID prefix %q is ambiguous (%d matches)", value, len(matches))
+	}
+}
+
+// displayChanges displays the packages and config files changed between two points
+func displayChanges(changes history.ChangeSet, untilNow bool) {
+	until := changes.To.Format("2006-01-02 15:04")
+	if untilNow {
+		until = "now"
+	}
+	fmt.Printf("📋 Changes from %s to %s\n\n", changes.From.Format("2006-01-02 15:04"), until)
+
+	if len(changes.Records) == 0 {
+		fmt.Println("No installations recorded in this period.")
+		return
+	}
+
+	fmt.Printf("Installations (%d):\n", len(changes.Records))
+	for _, record := range changes.Records {
+		fmt.Printf("  %s  %s  %-16s %s\n",
+			truncateID(record.ID().String(), 8),
+			record.RecordedAt().Format("2006-01-02 15:04"),
+			record.PackageName(),
+			formatStatus(record.Outcome()))
+	}
+	fmt.Println()
+
+	if changes.IsEmpty() {
+		fmt.Println("No packages or configuration files changed.")
+		return
+	}
+
+	if len(changes.Packages) > 0 {
+		fmt.Printf("Packages (%d):\n", len(changes.Packages))
+		for _, pkg := range changes.Packages {
+			if pkg.IsNewInstall() {
+				fmt.Printf("  + %s %s\n", pkg.Name, pkg.ToVersion)
+			} else {
+				fmt.Printf("  ~ %s %s → %s\n", pkg.Name, pkg.FromVersion, pkg.ToVersion)
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(changes.Configs) > 0 {
+		fmt.Printf("Config Files (%d):\n", len(changes.Configs))
+		for _, cfg := range changes.Configs {
+			if cfg.IsNewFile() {
+				fmt.Printf("  + %s (%s)\n", cfg.Path, truncateID(cfg.ToHash, 12))
+			} else {
+				fmt.Printf("  ~ %s (%s → %s)\n", cfg.Path, truncateID(cfg.FromHash, 12), truncateID(cfg.ToHash, 12))
+			}
+		}
+		fmt.Println()
+	}
+}
+
 // displayRecordsList displays records in a table format
-func displayRecordsList(records []history.InstallationRecord) {
+func displayRecordsList(records []history.InstallationRecord, showScope bool) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	defer w.Flush()
 
 	// Header
+	if showScope {
+		fmt.Fprint(w, "SCOPE\t")
+	}
 	fmt.Fprintln(w, "ID\tPACKAGE\tVERSION\tSTATUS\tINSTALLED\tDURATION")
 	fmt.Fprintln(w, strings.Repeat("-", 80))
 
@@ -255,6 +440,9 @@ func displayRecordsList(records []history.InstallationRecord) {
 		installed := record.InstalledAt().Format("2006-01-02 15:04")
 		duration := formatDuration(record.Duration())
 
+		if showScope {
+			fmt.Fprintf(w, "%s\t", record.Scope())
+		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
 			id, pkg, version, status, installed, duration)
 	}
@@ -281,6 +469,21 @@ func displayRecordDetails(record history.InstallationRecord) {
 	fmt.Printf("Duration:       %s\n", formatDuration(record.Duration()))
 	fmt.Println()
 
+	// Estimated vs actual duration per phase
+	if record.HasPhaseTimings() {
+		timings := record.PhaseTimings()
+		var estimated, actual time.Duration
+		fmt.Println("Phase Timings:")
+		for _, t := range timings {
+			fmt.Printf("  - %s\n", t)
+			estimated += t.Estimated()
+			actual += t.Actual()
+		}
+		fmt.Printf("  Estimated %s, took %s (%s)\n",
+			estimated.Round(time.Second), actual.Round(time.Second), history.FormatDelta(actual-estimated))
+		fmt.Println()
+	}
+
 	// System context
 	sysCtx := record.SystemContext()
 	fmt.Println("System Context:")
@@ -292,8 +495,34 @@ func displayRecordDetails(record history.InstallationRecord) {
 	if sysCtx.Hostname() != "" {
 		fmt.Printf("  Hostname:     %s\n", sysCtx.Hostname())
 	}
+	if sysCtx.Architecture() != "" {
+		fmt.Printf("  Arch:         %s\n", sysCtx.Architecture())
+	}
+	if sysCtx.GPUVendor() != "" {
+		fmt.Printf("  GPU:          %s\n", sysCtx.GPUVendor())
+	}
+	if sysCtx.RenderGPU() != "" {
+		fmt.Printf("  Render GPU:   %s\n", sysCtx.RenderGPU())
+	}
 	fmt.Println()
 
+	// How gohan was run
+	if invocation := record.Invocation(); !invocation.IsZero() {
+		fmt.Println("Invocation:")
+		fmt.Printf("  Command:      %s\n", invocation.CommandLine())
+		for _, name := range invocation.FlagNames() {
+			fmt.Printf("  --%-12s %s\n", name, invocation.Flags()[name])
+		}
+		if invocation.ConfigHash() != "" {
+			fmt.Printf("  Config:       sha256:%s\n", invocation.ConfigHash())
+		} else {
+			fmt.Println("  Config:       defaults (no config file)")
+		}
+		fmt.Printf("  Build:        %s (%s)\n", invocation.Version(), invocation.Commit())
+		fmt.Printf("  User:         %s\n", invocation.User())
+		fmt.Println()
+	}
+
 	// Installed packages
 	metadata := record.Metadata()
 	packages := metadata.InstalledPackages()
@@ -308,6 +537,64 @@ func displayRecordDetails(record history.InstallationRecord) {
 		fmt.Println()
 	}
 
+	// Components left out
+	if skipped := record.SkippedComponents(); len(skipped) > 0 {
+		fmt.Printf("Skipped Components (%d):\n", len(skipped))
+		for _, c := range skipped {
+			fmt.Printf("  - %s\n", c)
+		}
+		fmt.Println()
+	}
+
+	// Deployed configuration files
+	if files := record.ConfigFiles(); len(files) > 0 {
+		fmt.Printf("Config Files (%d):\n", len(files))
+		for _, f := range files {
+			fmt.Printf("  - %s\n", f)
+		}
+		fmt.Println()
+	}
+
+	// Warnings
+	if record.HasWarnings() {
+		warnings := record.Warnings()
+		fmt.Printf("Warnings (%d):\n", len(warnings))
+		for _, w := range warnings {
+			fmt.Printf("  - %s\n", w)
+		}
+		fmt.Println()
+	}
+
+	// Package conflicts
+	if conflicts := record.Conflicts(); len(conflicts) > 0 {
+		fmt.Printf("Conflicts (%d):\n", len(conflicts))
+		for _, c := range conflicts {
+			fmt.Printf("  - %s\n", c)
+			if c.Reason() != "" {
+				fmt.Printf("      %s\n", c.Reason())
+			}
+		}
+		fmt.Println()
+	}
+
+	// Preflight results
+	if record.HasPreflightChecks() {
+		checks := record.PreflightChecks()
+		fmt.Printf("Preflight Checks (%d):\n", len(checks))
+		for _, c := range checks {
+			fmt.Printf("  %s %-20s %s\n", formatCheckStatus(c.Status()), c.Requirement(), c.Actual())
+			if !c.Passed() {
+				if c.Expected() != "" {
+					fmt.Printf("      expected: %s\n", c.Expected())
+				}
+				if c.Message() != "" {
+					fmt.Printf("      %s\n", c.Message())
+				}
+			}
+		}
+		fmt.Println()
+	}
+
 	// Failure details
 	if record.HasFailureDetails() {
 		fd := record.FailureDetails()
@@ -337,6 +624,90 @@ func getHistoryDBPath() string {
 	return filepath.Join(gohanDir, "history.db")
 }
 
+const (
+	historyScopeUser   = "user"
+	historyScopeSystem = "system"
+	historyScopeAll    = "all"
+)
+
+// resolveHistoryScopes turns the --scope flag into the scopes to read.
+// An empty flag selects the scope of the running process.
+func resolveHistoryScopes(flag string) ([]history.Scope, error) {
+	current := sysinfo.CurrentScope()
+
+	switch flag {
+	case "":
+		return []history.Scope{current}, nil
+	case historyScopeSystem:
+		return []history.Scope{history.SystemScope()}, nil
+	case historyScopeUser:
+		if current.IsSystem() {
+			return nil, fmt.Errorf("--scope user is not available as root, use --scope system")
+		}
+		return []history.Scope{current}, nil
+	case historyScopeAll:
+		if current.IsSystem() {
+			return []history.Scope{current}, nil
+		}
+		return []history.Scope{history.SystemScope(), current}, nil
+	default:
+		return nil, fmt.Errorf("invalid scope %q (expected user, system or all)", flag)
+	}
+}
+
+// openHistoryRepos opens the history database of each scope. With
+// skipMissing, scopes without a database yet are left out instead of
+// creating an empty one.
+func openHistoryRepos(scopes []history.Scope, skipMissing bool) ([]history.Repository, func(), error) {
+	var repos []history.Repository
+	var opened []*historyRepo.SQLiteRepository
+	closeAll := func() {
+		for _, repo := range opened {
+			repo.Close()
+		}
+	}
+
+	for _, scope := range scopes {
+		dbPath := historyDBPathForScope(scope)
+		if skipMissing {
+			if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+				continue
+			}
+		}
+
+		repo, err := historyRepo.NewSQLiteRepository(dbPath)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to open %s history database: %w", scope, err)
+		}
+		opened = append(opened, repo)
+		repos = append(repos, repo)
+	}
+
+	return repos, closeAll, nil
+}
+
+// historyDBPathForScope returns where the history of a scope is stored:
+// the configured system-wide database for the system scope, ~/.gohan for users
+func historyDBPathForScope(scope history.Scope) string {
+	if !scope.IsSystem() {
+		return getHistoryDBPath()
+	}
+
+	dbPath := config.DefaultConfig().Database.SystemHistoryDB
+	if cfg, err := config.Load(); err == nil && cfg.Database.SystemHistoryDB != "" {
+		dbPath = cfg.Database.SystemHistoryDB
+	}
+
+	if sysinfo.CurrentScope().IsSystem() {
+		if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to create history directory: %v\n", err)
+		}
+	}
+
+	return dbPath
+}
+
 func truncateID(id string, length int) string {
 	if len(id) <= length {
 		return id
@@ -357,6 +728,17 @@ func formatStatus(outcome history.InstallationOutcome) string {
 	}
 }
 
+func formatCheckStatus(status string) string {
+	switch status {
+	case "pass":
+		return "✓"
+	case "warning":
+		return "⚠"
+	default:
+		return "✗"
+	}
+}
+
 func formatDuration(d time.Duration) string {
 	if d < time.Second {
 		return "< 1s"
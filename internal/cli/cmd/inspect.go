@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bugreportApp "github.com/rebelopsio/gohan/internal/application/bugreport"
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+	historyServices "github.com/rebelopsio/gohan/internal/application/history/services"
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+	"github.com/rebelopsio/gohan/internal/config"
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	bugreportInfra "github.com/rebelopsio/gohan/internal/infrastructure/bugreport"
+	historyRepo "github.com/rebelopsio/gohan/internal/infrastructure/history/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/history/sysinfo"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
+	"github.com/spf13/cobra"
+)
+
+// inspectCmd prints a read-only overview for support requests
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Print a read-only system overview for support",
+	Long: `Print an overview of this gohan setup to paste into a support thread.
+
+Nothing is installed, deployed or written. The overview contains:
+- gohan, Hyprland, kernel and OS versions
+- Installed versions of the packages gohan manages
+- Deployed configuration files, with their hashes and the hashes their
+  templates render to (see gohan config status)
+- A fresh preflight check run
+- The last installation session
+- Template updates waiting for gohan config upgrade
+
+Personal information is redacted as in gohan bugreport: your user and host
+names, home directory, email, IP and MAC addresses, and secret settings.
+
+Examples:
+  # Print the overview
+  gohan inspect
+
+  # Skip the preflight checks (e.g. when offline)
+  gohan inspect --no-preflight`,
+	Args: cobra.NoArgs,
+	RunE: runInspect,
+}
+
+var inspectNoPreflight bool
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+
+	inspectCmd.Flags().BoolVar(&inspectNoPreflight, "no-preflight", false, "Do not run preflight checks")
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	homeDir, _ := os.UserHomeDir()
+
+	packages := make([]string, 0, len(installation.AllPackageDefinitions))
+	for _, pkg := range installation.AllPackageDefinitions {
+		packages = append(packages, pkg.Name)
+	}
+
+	// Templates are only rendered in memory; the upgrade is a dry run and
+	// never reaches the deployer
+	records := configservice.NewDeployRecordStore(filepath.Join(config.GetDataDir(), configservice.DeployRecordsFileName))
+	collectors := []bugreport.Collector{
+		bugreportInfra.NewVersionsCollector(version),
+		bugreportInfra.NewPackageVersionsCollector(packages),
+		bugreportApp.NewConfigStatusCollector(configApp.NewConfigStatusUseCase(records, templates.NewTemplateEngine())),
+	}
+
+	if !inspectNoPreflight {
+		collectors = append(collectors, bugreportApp.NewPreflightCollector(
+			preflightApp.NewRunPreflightUseCase(preflightTUI.SystemDetectors())))
+	}
+
+	// Opening the database would create it, so only an existing one is read
+	historyDB := historyDBPathForScope(sysinfo.CurrentScope())
+	if _, err := os.Stat(historyDB); err == nil {
+		repo, err := historyRepo.NewSQLiteRepository(historyDB)
+		if err == nil {
+			defer repo.Close()
+			collectors = append(collectors, bugreportApp.NewHistoryCollector(
+				historyServices.NewHistoryQueryService(repo), 1))
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: history unavailable: %v\n", err)
+		}
+	}
+
+	collectors = append(collectors, bugreportApp.NewTemplateUpdatesCollector(
+		configApp.NewUpgradeTemplatesUseCase(records, nil, templates.NewTemplateEngine())))
+
+	identity := bugreport.Identity{HomeDir: homeDir}
+	if u, err := user.Current(); err == nil {
+		identity.Username = u.Username
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		identity.Hostname = hostname
+	}
+
+	report, err := bugreportApp.NewInspectUseCase(collectors, bugreport.NewRedactor(identity)).Execute(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to inspect system: %w", err)
+	}
+
+	fmt.Printf("gohan inspect, %s\n", time.Now().UTC().Format(time.RFC3339))
+	for _, section := range report.Sections {
+		fmt.Printf("\n## %s\n", section.Title)
+		fmt.Print(section.Content)
+	}
+
+	if len(report.Skipped) > 0 {
+		fmt.Println("\n## not collected")
+		for _, s := range report.Skipped {
+			fmt.Printf("%s: %s\n", s.Source, s.Reason)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "\n%s\n", strings.Repeat("─", 60))
+	fmt.Fprintf(os.Stderr, "🔒 Redacted %d personal value(s); review before sharing\n", report.Redactions)
+	return nil
+}
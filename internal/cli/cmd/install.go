@@ -7,10 +7,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
 	"github.com/rebelopsio/gohan/internal/config"
 	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/rebelopsio/gohan/internal/infrastructure/history/sysinfo"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/reboot"
+	preflightInfra "github.com/rebelopsio/gohan/internal/infrastructure/preflight/detectors"
 	installTUI "github.com/rebelopsio/gohan/internal/tui/installation"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -23,8 +32,21 @@ var (
 	requiredSpace  uint64
 	useAPI         bool
 	dryRun         bool
+	alternatives   []string
+	renderingMode  string
+	a11yOptions    []string
+	renderGPU      string
+	emitPlan       string
+	planFile       string
+	trustSigners   []string
+	background     bool
+	rebootAfter    bool
+	userOnly       bool
 )
 
+// rebootDelay leaves time to cancel a reboot scheduled with --reboot
+const rebootDelay = time.Minute
+
 // installCmd represents the install command
 var installCmd = &cobra.Command{
 	Use:   "install",
@@ -46,7 +68,43 @@ Examples:
   gohan install --use-api --api-url http://server:8080
 
   # Specify GPU vendor
-  gohan install --gpu amd`,
+  gohan install --gpu amd
+
+  # Choose alternative providers (terminal, locker, idle, wallpaper, power, night_light)
+  gohan install --components hyprland,kitty --alternatives terminal=alacritty,locker=swaylock
+
+  # Laptop power management with tlp instead of power-profiles-daemon
+  gohan install --components hyprland,power_profiles --alternatives power=tlp
+
+  # Shift the screen color at night with gammastep instead of wlsunset
+  gohan install --components hyprland,night_light --alternatives night_light=gammastep
+
+  # Force the lightweight desktop (no blur/animations, lighter Waybar)
+  gohan install --rendering lite
+
+  # Larger fonts and high-contrast colors without animations
+  gohan install --accessibility reduced-motion,large-text,high-contrast
+
+  # Render on a specific GPU on multi-GPU systems (PCI address from lspci -D)
+  gohan install --render-gpu 0000:01:00.0
+
+  # Keep the desktop responsive while installing from a live session
+  gohan install --components waybar,rofi --background
+
+  # Write a signed plan for review instead of installing
+  gohan install --components hyprland,waybar --emit-plan plan.json
+
+  # Install exactly what a reviewed plan describes
+  gohan install --plan plan.json
+
+  # Apply a plan signed on another machine
+  gohan install --plan plan.json --trust-signer <fingerprint>
+
+  # Unattended install that reboots by itself when a new kernel or driver needs it
+  gohan install --components hyprland,nvidia_driver --reboot
+
+  # Read-only or immutable root: deploy configurations without packages
+  gohan install --components hyprland,waybar --user-only`,
 	RunE: runInstall,
 }
 
@@ -57,21 +115,92 @@ func init() {
 	installCmd.Flags().Uint64Var(&requiredSpace, "required-space", 10737418240, "Required disk space in bytes (default: 10GB)")
 	installCmd.Flags().BoolVar(&useAPI, "use-api", false, "Use remote API instead of local execution")
 	installCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Dry-run mode (no actual installation)")
+	installCmd.Flags().StringSliceVar(&alternatives, "alternatives", nil, "Providers for alternative slots as slot=package (terminal, locker, idle, wallpaper, power, night_light)")
+	installCmd.Flags().StringVar(&renderingMode, "rendering", "", "Rendering mode: auto, standard or lite (default: auto from preflight)")
+	installCmd.Flags().StringSliceVar(&a11yOptions, "accessibility", nil, "Accessibility options: reduced-motion, large-text, high-contrast or none (asked interactively when not given)")
+	installCmd.Flags().StringVar(&renderGPU, "render-gpu", "", "PCI address of the GPU Hyprland renders on (asked interactively when several GPUs are found)")
+	installCmd.Flags().StringVar(&emitPlan, "emit-plan", "", "Write a signed installation plan to this file instead of installing")
+	installCmd.Flags().StringVar(&planFile, "plan", "", "Install exactly what a plan file describes")
+	installCmd.Flags().StringSliceVar(&trustSigners, "trust-signer", nil, "Fingerprint of a plan signer to trust besides the local key (repeatable)")
+	installCmd.Flags().BoolVar(&background, "background", false, "Run apt and dpkg at low CPU and IO priority (installation.background)")
+	installCmd.Flags().BoolVar(&rebootAfter, "reboot", false, "Reboot a minute after the installation when it needs a reboot to take effect (for unattended installs)")
+	installCmd.Flags().BoolVar(&userOnly, "user-only", false, "Only deploy configurations for the components, without installing packages (for read-only root filesystems)")
+	installCmd.MarkFlagsMutuallyExclusive("plan", "emit-plan")
+	installCmd.MarkFlagsMutuallyExclusive("plan", "components")
+	installCmd.MarkFlagsMutuallyExclusive("plan", "alternatives")
+	installCmd.MarkFlagsMutuallyExclusive("plan", "rendering")
+	installCmd.MarkFlagsMutuallyExclusive("plan", "accessibility")
+	installCmd.MarkFlagsMutuallyExclusive("plan", "gpu")
+	installCmd.MarkFlagsMutuallyExclusive("plan", "use-api")
+	installCmd.MarkFlagsMutuallyExclusive("emit-plan", "use-api")
+	installCmd.MarkFlagsMutuallyExclusive("background", "use-api")
+	installCmd.MarkFlagsMutuallyExclusive("reboot", "use-api")
+	installCmd.MarkFlagsMutuallyExclusive("reboot", "dry-run")
+	for _, flag := range []string{"use-api", "plan", "emit-plan", "background", "reboot", "gpu", "alternatives", "render-gpu"} {
+		installCmd.MarkFlagsMutuallyExclusive("user-only", flag)
+	}
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if userOnly {
+		return runInstallUserOnly(ctx, cmd)
+	}
+
 	// Build installation request
 	request := buildInstallationRequest()
 
+	// Plans carry their own accessibility options
+	if planFile == "" {
+		if err := selectAccessibility(cmd, &request); err != nil {
+			return err
+		}
+	}
+
 	logVerbose("Installation request: %+v", request)
 
+	if emitPlan != "" {
+		return runEmitPlan(ctx, request)
+	}
+
+	var plan *dto.InstallationPlan
+	if planFile != "" {
+		loaded, err := loadInstallationPlan(planFile)
+		if err != nil {
+			return err
+		}
+		plan = loaded
+
+		// The plan decides what is installed; the machine decides the rest
+		planned := plan.Request()
+		planned.AvailableSpace = request.AvailableSpace
+		planned.Scope = request.Scope
+		request = planned
+	}
+	request.Invocation = currentInvocation(cmd)
+
 	if useAPI {
 		return runInstallViaAPI(ctx, request)
 	}
 
-	return runInstallLocal(ctx, request)
+	return runInstallLocal(ctx, request, plan)
+}
+
+// runInstallUserOnly deploys the configuration of the selected components
+// without touching packages, for systems whose root filesystem is read-only
+func runInstallUserOnly(ctx context.Context, cmd *cobra.Command) error {
+	writability, err := preflightInfra.NewSystemFilesystemWritabilityDetector().DetectWritability(ctx, preflight.PackageManagerPaths())
+	if err == nil && !writability.CanInstallPackages() {
+		fmt.Printf("Package directories are not writable (%s)\n", writability)
+	}
+	fmt.Println("Deploying configurations only; packages are not installed")
+
+	configComponents = components
+	configDryRun = dryRun
+	configRendering = renderingMode
+	configA11y = a11yOptions
+	return runConfigDeploy(cmd, nil)
 }
 
 func buildInstallationRequest() dto.InstallationRequest {
@@ -88,6 +217,11 @@ func buildInstallationRequest() dto.InstallationRequest {
 		Components:     componentRequests,
 		AvailableSpace: availableSpace,
 		RequiredSpace:  requiredSpace,
+		Alternatives:   alternatives,
+		RenderingMode:  renderingMode,
+		Accessibility:  a11yOptions,
+		Scope:          sysinfo.CurrentScope().String(),
+		DryRun:         dryRun,
 	}
 
 	// Add GPU if specified
@@ -102,35 +236,85 @@ func buildInstallationRequest() dto.InstallationRequest {
 	return request
 }
 
-func runInstallLocal(ctx context.Context, request dto.InstallationRequest) error {
+func runInstallLocal(ctx context.Context, request dto.InstallationRequest, plan *dto.InstallationPlan) error {
 	fmt.Println("Starting local installation...")
 
-	// Set dry-run mode in config if flag is set
+	// Record every GPU and let the user pick the render GPU
+	if err := selectGPUs(ctx, &request); err != nil {
+		return err
+	}
+
+	var opts []container.Option
 	if dryRun {
-		// Load config to modify it
-		cfg, err := config.Load()
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
-		}
-		cfg.Installation.DryRun = true
-		// Save temporarily (will be reset on next load)
-		if err := cfg.Save(); err != nil {
-			return fmt.Errorf("failed to save config: %w", err)
-		}
+		opts = append(opts, container.WithDryRun())
 		fmt.Println("Running in dry-run mode (no actual installation)")
 	}
-
-	// Initialize dependency container (will use dry-run setting from config)
-	c, err := container.New()
+	if background {
+		opts = append(opts, container.WithBackgroundPriority())
+	}
+	c, err := container.New(opts...)
 	if err != nil {
 		return fmt.Errorf("failed to initialize container: %w", err)
 	}
 	defer c.Close()
 
+	// Blocked installations can be fixed and started again without
+	// leaving gohan
+	var finalProgress *dto.InstallationProgressResponse
+	for {
+		finalProgress, err = runInstallSession(ctx, c, request, plan)
+		if err != nil {
+			return err
+		}
+		if finalProgress == nil || len(finalProgress.PreflightBlockers) == 0 || !stdinIsTerminal() {
+			break
+		}
+
+		resolved, err := resolvePreflightBlockers(ctx, c, finalProgress)
+		if err != nil {
+			return err
+		}
+		if !resolved {
+			break
+		}
+		fmt.Println("\nAll blockers resolved. Starting the installation again...")
+	}
+
+	if rebootAfter && finalProgress != nil && finalProgress.Status == "completed" && finalProgress.RebootRequired {
+		if err := reboot.NewScheduler().Schedule(ctx, rebootDelay); err != nil {
+			return err
+		}
+		fmt.Printf("\n🔁 Rebooting in %s to finish the installation (cancel with: shutdown -c)\n", rebootDelay)
+	}
+
+	fmt.Println("\nView installation history with: gohan history browse")
+	return nil
+}
+
+// runInstallSession starts an installation session and shows its progress,
+// returning the final progress of the session
+func runInstallSession(ctx context.Context, c *container.Container, request dto.InstallationRequest, plan *dto.InstallationPlan) (*dto.InstallationProgressResponse, error) {
 	// Start installation using pre-wired use cases
-	response, err := c.StartInstallationUseCase.Execute(ctx, request)
-	if err != nil {
-		return fmt.Errorf("failed to start installation: %w", err)
+	var response *dto.InstallationResponse
+	var err error
+	if plan != nil {
+		response, err = c.StartInstallationUseCase.StartFromPlan(ctx, dto.StartFromPlanRequest{
+			Plan:           *plan,
+			TrustedSigners: trustSigners,
+			AvailableSpace: request.AvailableSpace,
+			GPUs:           request.GPUs,
+			RenderGPU:      request.RenderGPU,
+			Scope:          request.Scope,
+			Invocation:     request.Invocation,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply plan %s: %w", planFile, err)
+		}
+	} else {
+		response, err = c.StartInstallationUseCase.Execute(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start installation: %w", err)
+		}
 	}
 
 	// Get package name and version for display
@@ -144,6 +328,9 @@ func runInstallLocal(ctx context.Context, request dto.InstallationRequest) error
 	// Create progress channel
 	progressChan := make(chan installTUI.ProgressUpdate, 100)
 
+	// Final response, read after the viewer exits for the post-install report
+	var finalProgress *dto.InstallationProgressResponse
+
 	// Launch installation in a goroutine with real progress updates
 	go func() {
 		defer close(progressChan)
@@ -156,19 +343,25 @@ func runInstallLocal(ctx context.Context, request dto.InstallationRequest) error
 			ComponentsTotal: response.ComponentCount,
 		}
 
-		// Create progress callback that feeds the TUI
-		progressCallback := func(phase string, percent int, message string, componentsInstalled, componentsTotal int) {
+		// Feed this session's progress to the TUI
+		unsubscribe := c.ProgressBroadcaster.Subscribe(func(update usecases.ProgressUpdate) {
+			// The outcome is reported below, from the execution's result
+			if update.SessionID != response.SessionID || update.Final {
+				return
+			}
 			progressChan <- installTUI.ProgressUpdate{
-				Phase:               phase,
-				PercentComplete:     percent,
-				Message:             message,
-				ComponentsInstalled: componentsInstalled,
-				ComponentsTotal:     componentsTotal,
+				Phase:               update.Phase,
+				PercentComplete:     update.Percent,
+				Message:             update.Message,
+				ComponentsInstalled: update.ComponentsInstalled,
+				ComponentsTotal:     update.ComponentsTotal,
 			}
-		}
+		})
 
 		// Execute the actual installation with real progress updates
-		progress, err := c.ExecuteInstallationUseCase.Execute(ctx, response.SessionID, progressCallback)
+		progress, err := c.ExecuteInstallationUseCase.Execute(ctx, response.SessionID)
+		unsubscribe()
+		finalProgress = progress
 
 		// Final update
 		if err != nil {
@@ -188,6 +381,7 @@ func runInstallLocal(ctx context.Context, request dto.InstallationRequest) error
 				ComponentsInstalled: progress.ComponentsInstalled,
 				ComponentsTotal:     progress.ComponentsTotal,
 				IsComplete:          true,
+				Components:          toTUIComponents(progress.Components),
 			}
 		} else {
 			progressChan <- installTUI.ProgressUpdate{
@@ -196,6 +390,7 @@ func runInstallLocal(ctx context.Context, request dto.InstallationRequest) error
 				IsComplete:   true,
 				IsError:      true,
 				ErrorMessage: progress.Message,
+				Components:   toTUIComponents(progress.Components),
 			}
 		}
 	}()
@@ -205,13 +400,76 @@ func runInstallLocal(ctx context.Context, request dto.InstallationRequest) error
 	p := tea.NewProgram(viewer, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("failed to run progress viewer: %w", err)
+		return nil, fmt.Errorf("failed to run progress viewer: %w", err)
 	}
 
-	fmt.Println("\nView installation history with: gohan history browse")
+	if finalProgress != nil {
+		printFailedComponents(finalProgress.Components)
+		printInstallationConflicts(finalProgress.Conflicts)
+		printInstallationWarnings(finalProgress.Warnings)
+		printPhaseTimings(finalProgress.PhaseTimings)
+		printRebootRequirement(finalProgress)
+		switch {
+		case finalProgress.ArtifactsDir != "" && finalProgress.DryRun:
+			fmt.Printf("\nDry run; the configuration was rendered into: %s\n", finalProgress.ArtifactsDir)
+		case finalProgress.ArtifactsDir != "":
+			fmt.Printf("\nArtifacts kept for debugging: %s\n", finalProgress.ArtifactsDir)
+			fmt.Printf("Locate them later with: gohan sessions artifacts %s\n", finalProgress.SessionID)
+		}
+	}
+
+	return finalProgress, nil
+}
+
+// runEmitPlan writes a signed plan for the request without installing
+func runEmitPlan(ctx context.Context, request dto.InstallationRequest) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	plan, err := c.StartInstallationUseCase.Plan(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to plan installation: %w", err)
+	}
+
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+	if err := os.WriteFile(emitPlan, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+
+	fmt.Printf("✓ Installation plan written to %s\n\n", emitPlan)
+	for _, component := range plan.Components {
+		fmt.Printf("  %-16s %-20s %s\n", component.Name, component.Version, formatBytes(int64(component.SizeBytes)))
+	}
+	fmt.Printf("\nEstimated size:  %s\n", formatBytes(int64(plan.EstimatedSizeBytes)))
+	fmt.Printf("Config files:    %d\n", len(plan.ConfigFiles))
+	fmt.Printf("Repositories:    %d\n", len(plan.Repositories))
+	if plan.Signature != nil {
+		fmt.Printf("Signed by:       %s\n", plan.Signature.Signer)
+	}
+	fmt.Printf("\nApply with: gohan install --plan %s\n", emitPlan)
 	return nil
 }
 
+// loadInstallationPlan reads a plan file; it is verified when applied
+func loadInstallationPlan(path string) (*dto.InstallationPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	var plan dto.InstallationPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("invalid plan %s: %w", path, err)
+	}
+	return &plan, nil
+}
+
 func runInstallViaAPI(ctx context.Context, request dto.InstallationRequest) error {
 	fmt.Printf("Connecting to API server at %s...\n", apiURL)
 
@@ -266,5 +524,222 @@ func runInstallViaAPI(ctx context.Context, request dto.InstallationRequest) erro
 		fmt.Printf("\n✗ Installation failed: %s\n", progressResponse.Message)
 	}
 
+	printFailedComponents(progressResponse.Components)
+	printInstallationConflicts(progressResponse.Conflicts)
+	printInstallationWarnings(progressResponse.Warnings)
+	printPhaseTimings(progressResponse.PhaseTimings)
+	printRebootRequirement(&progressResponse)
+
+	return nil
+}
+
+// selectGPUs adds the detected GPUs to the request. When several GPUs are
+// found and no --render-gpu was given, the user picks one interactively.
+func selectGPUs(ctx context.Context, request *dto.InstallationRequest) error {
+	request.RenderGPU = renderGPU
+
+	gpus, err := preflightInfra.NewSystemGPUDetector().DetectGPUs(ctx)
+	if err != nil {
+		// GPU detection is best effort; Aquamarine picks a GPU on its own
+		logVerbose("GPU detection failed: %v", err)
+		gpus = nil
+	}
+
+	options := make([]installTUI.GPUOption, 0, len(gpus))
+	for _, gpu := range gpus {
+		if gpu.PCISlot() == "" {
+			continue
+		}
+		request.GPUs = append(request.GPUs, dto.GPUDeviceRequest{
+			Vendor:    string(gpu.Vendor()),
+			Model:     gpu.Model(),
+			PCISlot:   gpu.PCISlot(),
+			DRMDevice: gpu.DRMDevice(),
+		})
+		options = append(options, installTUI.GPUOption{
+			PCISlot:     gpu.PCISlot(),
+			Description: fmt.Sprintf("%s (%s)", gpu, gpu.DriverSummary()),
+		})
+	}
+
+	if len(options) < 2 || request.RenderGPU != "" || !stdinIsTerminal() {
+		return nil
+	}
+
+	p := tea.NewProgram(installTUI.NewGPUPicker(options))
+	finalModel, err := p.Run()
+	if err != nil {
+		return fmt.Errorf("failed to run GPU picker: %w", err)
+	}
+
+	picker, ok := finalModel.(installTUI.GPUPicker)
+	if !ok {
+		return fmt.Errorf("unexpected model type")
+	}
+	selected, ok := picker.Selected()
+	if !ok {
+		return fmt.Errorf("installation cancelled: no render GPU selected")
+	}
+	request.RenderGPU = selected.PCISlot
+	return nil
+}
+
+// selectAccessibility fills in the accessibility options from the
+// configuration unless --accessibility was given, letting the user adjust
+// them interactively
+func selectAccessibility(cmd *cobra.Command, request *dto.InstallationRequest) error {
+	if cmd.Flags().Changed("accessibility") {
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	settings := installation.NewAccessibilitySettings(
+		cfg.Accessibility.ReducedMotion,
+		cfg.Accessibility.LargeText,
+		cfg.Accessibility.HighContrast,
+	)
+	request.Accessibility = settings.Strings()
+
+	if !stdinIsTerminal() {
+		return nil
+	}
+
+	options := make([]installTUI.AccessibilityOption, 0, len(installation.AllAccessibilityOptions))
+	for _, option := range installation.AllAccessibilityOptions {
+		options = append(options, installTUI.AccessibilityOption{
+			Name:        option.String(),
+			Description: option.Description(),
+			Enabled:     settings.Has(option),
+		})
+	}
+
+	p := tea.NewProgram(installTUI.NewAccessibilityPicker(options))
+	finalModel, err := p.Run()
+	if err != nil {
+		return fmt.Errorf("failed to run accessibility picker: %w", err)
+	}
+
+	picker, ok := finalModel.(installTUI.AccessibilityPicker)
+	if !ok {
+		return fmt.Errorf("unexpected model type")
+	}
+	if !picker.Confirmed() {
+		return fmt.Errorf("installation cancelled")
+	}
+	request.Accessibility = picker.Enabled()
 	return nil
 }
+
+// stdinIsTerminal reports whether the user can answer interactive prompts
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// printInstallationConflicts lists the package conflicts detected during
+// installation and the strategy used to resolve each
+func printInstallationConflicts(conflicts []dto.ConflictDTO) {
+	if len(conflicts) == 0 {
+		return
+	}
+
+	fmt.Printf("\n🔀 %d package conflict(s) detected:\n", len(conflicts))
+	for _, c := range conflicts {
+		status := "✓"
+		if !c.Applied {
+			status = "✗"
+		}
+		fmt.Printf("  %s %s conflicts with %s: %s\n", status, c.PackageName, c.ConflictingPackage, c.Strategy)
+		if c.Reason != "" {
+			fmt.Printf("      %s\n", c.Reason)
+		}
+	}
+}
+
+// toTUIComponents converts component statuses for the progress viewer
+func toTUIComponents(components []dto.ComponentStatusDTO) []installTUI.ComponentStatus {
+	statuses := make([]installTUI.ComponentStatus, 0, len(components))
+	for _, c := range components {
+		statuses = append(statuses, installTUI.ComponentStatus{Name: c.Name, State: c.State, Error: c.Error, SkipReason: c.SkipReason})
+	}
+	return statuses
+}
+
+// printFailedComponents names the component the installation stopped on,
+// the components it skipped and why, and the components it never reached
+func printFailedComponents(components []dto.ComponentStatusDTO) {
+	var failed, skipped []dto.ComponentStatusDTO
+	var untouched []string
+	for _, c := range components {
+		switch c.State {
+		case "failed":
+			failed = append(failed, c)
+		case "skipped":
+			skipped = append(skipped, c)
+		case "pending":
+			untouched = append(untouched, c.Name)
+		}
+	}
+	if len(failed) == 0 && len(skipped) == 0 && len(untouched) == 0 {
+		return
+	}
+
+	fmt.Println("\n📦 Components not installed:")
+	for _, c := range failed {
+		fmt.Printf("  ✗ %s: %s\n", c.Name, c.Error)
+	}
+	for _, c := range skipped {
+		fmt.Printf("  - %s skipped (%s): %s\n", c.Name, c.SkipReason, c.SkipDetail)
+	}
+	if len(untouched) > 0 {
+		fmt.Printf("  · not started: %s\n", strings.Join(untouched, ", "))
+	}
+}
+
+// printInstallationWarnings lists warnings raised during installation so
+// they are not lost once the progress display closes
+func printInstallationWarnings(warnings []dto.WarningDTO) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Printf("\n⚠️  %d warning(s) raised during installation:\n", len(warnings))
+	for _, w := range warnings {
+		fmt.Printf("  - [%s] %s\n", w.Source, w.Message)
+	}
+}
+
+// printPhaseTimings compares each phase's estimated duration with how long
+// it took, so users can see where the estimate was off
+func printPhaseTimings(timings []dto.PhaseTimingDTO) {
+	if len(timings) == 0 {
+		return
+	}
+
+	fmt.Println("\n⏱️  Estimated vs actual duration:")
+	for _, t := range timings {
+		fmt.Printf("  %-12s estimated %-8s took %-8s (%s)\n", t.Phase, t.Estimated, t.Actual, t.Delta)
+	}
+}
+
+// printRebootRequirement tells the user, last so it is not missed, that
+// the installation needs a reboot and why
+func printRebootRequirement(progress *dto.InstallationProgressResponse) {
+	if !progress.RebootRequired {
+		return
+	}
+
+	fmt.Println("\n🔁 Reboot required to finish the installation:")
+	for _, reason := range progress.RebootReasons {
+		fmt.Printf("  - %s\n", reason)
+	}
+	if !rebootAfter {
+		fmt.Println("  Reboot when convenient, or pass --reboot to have gohan do it")
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/user"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// currentInvocation records how gohan was run: the command line, the flags
+// set on cmd, the configuration file in effect, this build and the user
+func currentInvocation(cmd *cobra.Command) *dto.InvocationRequest {
+	flags := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		flags[f.Name] = f.Value.String()
+	})
+
+	return &dto.InvocationRequest{
+		Args:       os.Args,
+		Flags:      flags,
+		ConfigHash: configFileHash(config.GetConfigPath()),
+		Version:    version,
+		Commit:     commit,
+		User:       invokingUser(),
+	}
+}
+
+// configFileHash returns the SHA-256 of the configuration file, or "" if
+// there is none and defaults apply
+func configFileHash(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// invokingUser names the user running gohan, noting who used sudo
+func invokingUser() string {
+	name := os.Getenv("USER")
+	if current, err := user.Current(); err == nil {
+		name = current.Username
+	}
+
+	if sudoUser := os.Getenv("SUDO_USER"); sudoUser != "" && sudoUser != name {
+		return fmt.Sprintf("%s (sudo from %s)", name, sudoUser)
+	}
+	return name
+}
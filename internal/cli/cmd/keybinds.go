@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	keybindsApp "github.com/rebelopsio/gohan/internal/application/keybinds"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/rebelopsio/gohan/internal/domain/keybinds"
+	keybindsInfra "github.com/rebelopsio/gohan/internal/infrastructure/keybinds"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for keybinds cheatsheet command
+	cheatsheetFormat string
+	cheatsheetOutput string
+	cheatsheetFile   string
+	cheatsheetOpen   bool
+)
+
+// keybindsCmd represents the keybinds command
+var keybindsCmd = &cobra.Command{
+	Use:   "keybinds",
+	Short: "Work with the Hyprland key bindings",
+}
+
+// keybindsCheatsheetCmd represents the keybinds cheatsheet command
+var keybindsCheatsheetCmd = &cobra.Command{
+	Use:   "cheatsheet",
+	Short: "Show a cheat sheet of the configured key bindings",
+	Long: `Show a cheat sheet of the key bindings Hyprland is configured with.
+
+The bindings are read from ~/.config/hypr/hyprland.conf and the files its
+source lines include, so bindings you added or changed are listed too.
+Each binding is described by the comment above it and grouped under the
+section heading it appears in. SUPER+F1 opens the cheat sheet as a page
+in the browser.
+
+Examples:
+  # Print the cheat sheet in the terminal
+  gohan keybinds cheatsheet
+
+  # Write it as markdown
+  gohan keybinds cheatsheet --format markdown --output keybinds.md
+
+  # Open it in the browser
+  gohan keybinds cheatsheet --open`,
+	Args: cobra.NoArgs,
+	RunE: runKeybindsCheatsheet,
+}
+
+func init() {
+	rootCmd.AddCommand(keybindsCmd)
+	keybindsCmd.AddCommand(keybindsCheatsheetCmd)
+
+	keybindsCheatsheetCmd.Flags().StringVar(&cheatsheetFormat, "format", "", "Output format: text, markdown or html (default text, html with --open)")
+	keybindsCheatsheetCmd.Flags().StringVarP(&cheatsheetOutput, "output", "o", "", "Write the cheat sheet to a file instead of stdout")
+	keybindsCheatsheetCmd.Flags().StringVar(&cheatsheetFile, "file", "", "Hyprland configuration to read (default ~/.config/hypr/hyprland.conf)")
+	keybindsCheatsheetCmd.Flags().BoolVar(&cheatsheetOpen, "open", false, "Write an HTML cheat sheet and open it in the browser")
+}
+
+func runKeybindsCheatsheet(cmd *cobra.Command, args []string) error {
+	format := cheatsheetFormat
+	if cheatsheetOpen {
+		if format != "" && format != string(keybinds.FormatHTML) {
+			return fmt.Errorf("--open needs the html format")
+		}
+		format = string(keybinds.FormatHTML)
+	}
+
+	var useCase *keybindsApp.GenerateCheatSheetUseCase
+	if cheatsheetFile != "" {
+		useCase = keybindsApp.NewGenerateCheatSheetUseCase(
+			keybindsInfra.NewConfigReader(cheatsheetFile),
+			keybindsInfra.NewRenderer(),
+		)
+	} else {
+		c, err := container.New()
+		if err != nil {
+			return fmt.Errorf("failed to initialize container: %w", err)
+		}
+		defer c.Close()
+		useCase = c.GenerateCheatSheetUseCase
+	}
+
+	response, err := useCase.Execute(context.Background(), keybindsApp.GenerateCheatSheetRequest{
+		Format: format,
+	})
+	if err != nil {
+		return err
+	}
+
+	output := cheatsheetOutput
+	if cheatsheetOpen && output == "" {
+		output = filepath.Join(os.TempDir(), "gohan-keybinds.html")
+	}
+	if output == "" {
+		fmt.Print(response.Content)
+		return nil
+	}
+
+	if err := os.WriteFile(output, []byte(response.Content), 0644); err != nil {
+		return fmt.Errorf("failed to write cheat sheet: %w", err)
+	}
+	if !cheatsheetOpen {
+		fmt.Printf("✓ Wrote %d key bindings from %s to %s\n", response.Bindings, response.Source, output)
+		return nil
+	}
+
+	if err := exec.Command("xdg-open", output).Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", output, err)
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	backupApp "github.com/rebelopsio/gohan/internal/application/backup"
+	"github.com/rebelopsio/gohan/internal/application/history/services"
+	maintenanceApp "github.com/rebelopsio/gohan/internal/application/maintenance"
+	repoApp "github.com/rebelopsio/gohan/internal/application/repository"
+	verificationApp "github.com/rebelopsio/gohan/internal/application/verification"
+	"github.com/rebelopsio/gohan/internal/config"
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/maintenance"
+	historyRepo "github.com/rebelopsio/gohan/internal/infrastructure/history/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/history/sysinfo"
+	backupInfra "github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
+	maintenanceInfra "github.com/rebelopsio/gohan/internal/infrastructure/maintenance"
+	repoInfra "github.com/rebelopsio/gohan/internal/infrastructure/repository"
+	"github.com/spf13/cobra"
+)
+
+// maintenanceCmd groups the scheduled maintenance commands
+var maintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Schedule and run long-term maintenance tasks",
+	Long: `Schedule and run long-term maintenance tasks.
+
+gohan can generate systemd timers so routine hygiene runs on its own:
+
+  backup-prune    weekly   Remove configuration backups past retention (user)
+  history-purge   monthly  Remove installation history past retention (user, system)
+  health-check    daily    Run the gohan doctor checks (user)
+  pin-refresh     weekly   Re-download signing keys, verified against their pins (system)
+
+Each timer starts a oneshot service that calls gohan maintenance run <task>.`,
+}
+
+// maintenanceInstallTimersCmd generates and enables the timers
+var maintenanceInstallTimersCmd = &cobra.Command{
+	Use:   "install-timers",
+	Short: "Generate and enable systemd timers for maintenance tasks",
+	Long: `Generate a systemd service and timer for each maintenance task and enable them.
+
+User timers are written to ~/.config/systemd/user and run in your systemd user
+instance. With --system, timers are written to /etc/systemd/system and run as
+root; this requires sudo. Running the command again regenerates the units, for
+example after moving the gohan binary.
+
+Examples:
+  # Schedule the user tasks
+  gohan maintenance install-timers
+
+  # Schedule the system tasks (history purge and signing key refresh)
+  sudo gohan maintenance install-timers --system
+
+  # Show the generated units without installing them
+  gohan maintenance install-timers --dry-run
+
+  # Schedule only the health check
+  gohan maintenance install-timers --task health-check`,
+	RunE: runMaintenanceInstallTimers,
+}
+
+// maintenanceRunCmd runs one task, as the generated services do
+var maintenanceRunCmd = &cobra.Command{
+	Use:   "run <task>",
+	Short: "Run a maintenance task now",
+	Long: `Run a maintenance task now.
+
+This is what the generated services call; it can also be run by hand.
+A failing task exits non-zero, so the service shows up in
+systemctl --failed (or systemctl --user --failed).
+
+Tasks: backup-prune, history-purge, health-check, pin-refresh
+
+Examples:
+  gohan maintenance run health-check
+  sudo gohan maintenance run pin-refresh`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"backup-prune", "history-purge", "health-check", "pin-refresh"},
+	RunE:      runMaintenanceRun,
+}
+
+// Flags
+var (
+	maintenanceSystem bool
+	maintenanceTasks  []string
+	maintenanceDryRun bool
+)
+
+func init() {
+	rootCmd.AddCommand(maintenanceCmd)
+
+	maintenanceCmd.AddCommand(maintenanceInstallTimersCmd)
+	maintenanceCmd.AddCommand(maintenanceRunCmd)
+
+	maintenanceInstallTimersCmd.Flags().BoolVar(&maintenanceSystem, "system", false, "Install system timers instead of user timers (requires root)")
+	maintenanceInstallTimersCmd.Flags().StringSliceVar(&maintenanceTasks, "task", nil, "Task to schedule (repeatable, defaults to every task of the scope)")
+	maintenanceInstallTimersCmd.Flags().BoolVar(&maintenanceDryRun, "dry-run", false, "Print the units without installing them")
+}
+
+func runMaintenanceInstallTimers(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	scope := maintenance.ScopeUser
+	if maintenanceSystem {
+		scope = maintenance.ScopeSystem
+	}
+	if !maintenanceDryRun {
+		if scope.IsSystem() && os.Geteuid() != 0 {
+			return fmt.Errorf("system timers require root: sudo gohan maintenance install-timers --system")
+		}
+		if !scope.IsSystem() && os.Geteuid() == 0 {
+			return fmt.Errorf("user timers cannot be installed as root, use --system or run without sudo")
+		}
+	}
+
+	tasks := make([]maintenance.Task, 0, len(maintenanceTasks))
+	for _, name := range maintenanceTasks {
+		task, err := maintenance.ParseTask(name)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, task)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the gohan binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	useCase := maintenanceApp.NewInstallTimersUseCase(maintenanceInfra.NewSystemdTimerInstaller())
+	resp, err := useCase.Execute(ctx, maintenanceApp.InstallTimersRequest{
+		Scope:      scope,
+		Executable: executable,
+		Tasks:      tasks,
+		DryRun:     maintenanceDryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install timers: %w", err)
+	}
+
+	if maintenanceDryRun {
+		for _, timer := range resp.Timers {
+			fmt.Printf("# %s\n%s\n", filepath.Join(resp.UnitDir, timer.ServiceName), timer.Service)
+			fmt.Printf("# %s\n%s\n", filepath.Join(resp.UnitDir, timer.TimerName), timer.Timer)
+		}
+		return nil
+	}
+
+	fmt.Printf("✓ Installed %d %s timers in %s\n\n", len(resp.Timers), resp.Scope, resp.UnitDir)
+	for _, timer := range resp.Timers {
+		fmt.Printf("  %-28s %-8s %s\n", timer.TimerName, timer.Schedule, timer.Task.Description())
+	}
+
+	systemctl := "systemctl"
+	if !scope.IsSystem() {
+		systemctl = "systemctl --user"
+	}
+	fmt.Printf("\nInspect with: %s list-timers 'gohan-*'\n", systemctl)
+
+	return nil
+}
+
+func runMaintenanceRun(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	task, err := maintenance.ParseTask(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch task {
+	case maintenance.TaskBackupPrune:
+		return runBackupPrune(ctx)
+	case maintenance.TaskHistoryPurge:
+		return runHistoryPurge(ctx)
+	case maintenance.TaskHealthCheck:
+		return runScheduledHealthCheck(ctx)
+	case maintenance.TaskPinRefresh:
+		return runPinRefresh(ctx)
+	default:
+		return fmt.Errorf("%w: %s", maintenance.ErrUnknownTask, task)
+	}
+}
+
+// runBackupPrune applies the default backup retention
+func runBackupPrune(ctx context.Context) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	backupRoot := filepath.Join(homeDir, ".config", "gohan", "backups")
+	useCase := backupApp.NewCleanupBackupsUseCase(backupInfra.NewRepositoryAdapter(backupRoot))
+
+	resp, err := useCase.Execute(ctx, backupApp.CleanupBackupsRequest{
+		RetentionDays: defaultBackupRetentionDays,
+		KeepMinimum:   defaultBackupKeepMinimum,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune backups: %w", err)
+	}
+
+	fmt.Printf("Removed %d backups (%s), %d remaining\n", resp.RemovedCount, formatBytes(resp.FreedBytes), resp.RemainingCount)
+	return nil
+}
+
+// runHistoryPurge applies the configured history retention to the history
+// of the running scope
+func runHistoryPurge(ctx context.Context) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	days := cfg.Installation.HistoryRetentionDays
+	if days == 0 {
+		fmt.Println("History retention is unlimited (history_retention_days: 0), nothing to purge")
+		return nil
+	}
+	policy, err := history.NewRetentionPolicy(days)
+	if err != nil {
+		return fmt.Errorf("invalid history_retention_days: %w", err)
+	}
+
+	scope := sysinfo.CurrentScope()
+	dbPath := historyDBPathForScope(scope)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		fmt.Printf("No %s history yet, nothing to purge\n", scope)
+		return nil
+	}
+
+	repo, err := historyRepo.NewSQLiteRepository(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open history database: %w", err)
+	}
+	defer repo.Close()
+
+	removed, err := services.NewHistoryRecordingService(repo).PurgeExpired(ctx, policy)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed %d %s history records older than %d days\n", removed, scope, policy.RetentionDays())
+	return nil
+}
+
+// runScheduledHealthCheck runs the doctor checks and fails on any failed check
+func runScheduledHealthCheck(ctx context.Context) error {
+	checkers, closeCheckers := newDoctorCheckers()
+	defer closeCheckers()
+	useCase := verificationApp.NewDoctorUseCase(checkers)
+	resp, err := useCase.Execute(ctx, verificationApp.DoctorRequest{})
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+
+	fmt.Printf("Health: %s (%d passed, %d warnings, %d failed)\n",
+		strings.ToUpper(resp.OverallStatus), resp.PassedChecks, resp.WarningChecks, resp.FailedChecks)
+	for _, result := range resp.Results {
+		if result.Status != "pass" {
+			fmt.Printf("%s %s: %s\n", getStatusIcon(result.Status), result.Component, result.Message)
+		}
+	}
+
+	if resp.FailedChecks > 0 {
+		return fmt.Errorf("%d check(s) failed, run gohan doctor for details", resp.FailedChecks)
+	}
+	return nil
+}
+
+// runPinRefresh re-downloads managed signing keys
+func runPinRefresh(ctx context.Context) error {
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("refreshing signing keys requires root: sudo gohan maintenance run pin-refresh")
+	}
+
+	resp, err := repoApp.NewRefreshKeysUseCase(repoInfra.NewFileKeyringManager()).Execute(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to refresh keys: %w", err)
+	}
+
+	if len(resp.Keys) == 0 {
+		fmt.Println("No signing keys managed by gohan")
+		return nil
+	}
+
+	for _, key := range resp.Keys {
+		switch {
+		case key.Err != nil:
+			fmt.Printf("✗ %s: %v\n", key.Name, key.Err)
+		case key.Updated:
+			fmt.Printf("✓ %s: updated\n", key.Name)
+		default:
+			fmt.Printf("✓ %s: unchanged\n", key.Name)
+		}
+	}
+
+	if failed := resp.Failed(); failed > 0 {
+		return fmt.Errorf("%d key(s) could not be refreshed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	migrationApp "github.com/rebelopsio/gohan/internal/application/migration"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for migrate command
+	migrateFrom   string
+	migrateDryRun bool
+	migrateForce  bool
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Import your GNOME or KDE settings",
+	Long: `Import settings from the desktop you used before Hyprland.
+
+The keyboard layouts and options, and the monitor layout, are saved as
+template variables in ~/.gohan/template-vars.yaml, which installations
+and gohan config deploy render the configuration with. The wallpaper is
+copied to ~/.config/gohan/wallpaper.jpg. If the default terminal is one
+gohan installs, the command to switch to it is shown.
+
+GNOME settings are read with gsettings and from ~/.config/monitors.xml;
+KDE Plasma settings from kxkbrc, kdeglobals, the desktop's wallpaper
+configuration and the latest KScreen layout.
+
+Services the old desktop runs that a Hyprland session does not need are
+listed with the command to disable each; none are disabled for you.
+
+Examples:
+  # See what would be imported from GNOME
+  gohan migrate --from gnome --dry-run
+
+  # Import from KDE Plasma and apply the settings
+  gohan migrate --from kde
+  gohan config deploy`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVar(&migrateFrom, "from", "", "Desktop to import from: gnome or kde")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Show what would be imported without saving it")
+	migrateCmd.Flags().BoolVar(&migrateForce, "force", false, "Replace a wallpaper that is already in place")
+	_ = migrateCmd.MarkFlagRequired("from")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	resp, err := c.ImportSettingsUseCase.Execute(context.Background(), migrationApp.ImportSettingsRequest{
+		Desktop: migrateFrom,
+		DryRun:  migrateDryRun,
+		Force:   migrateForce,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.DryRun {
+		fmt.Printf("Settings that would be imported from %s:\n\n", resp.Desktop)
+	} else {
+		fmt.Printf("Imported settings from %s:\n\n", resp.Desktop)
+	}
+
+	printImportedVars(resp.Variables)
+	if resp.WallpaperImported {
+		fmt.Printf("  ✓ Wallpaper: %s → %s\n", resp.Wallpaper, resp.WallpaperPath)
+	}
+	if resp.TerminalProvider != "" {
+		fmt.Printf("  ✓ Terminal: %s (switch with: gohan component swap <current terminal> %s)\n",
+			resp.Terminal, resp.TerminalProvider)
+	} else if resp.Terminal != "" {
+		fmt.Printf("  - Terminal: %s is not one gohan installs; kitty stays the default\n", resp.Terminal)
+	}
+
+	if len(resp.Notes) > 0 {
+		fmt.Println()
+		for _, note := range resp.Notes {
+			fmt.Printf("  ⚠ %s\n", note)
+		}
+	}
+
+	if len(resp.Variables) > 0 && !resp.DryRun {
+		fmt.Printf("\nSaved to %s. Apply them with: gohan config deploy\n", resp.VariablesPath)
+	}
+
+	if len(resp.Services) > 0 {
+		fmt.Printf("\n%s services a Hyprland session does not need:\n", resp.Desktop)
+		for _, service := range resp.Services {
+			fmt.Printf("\n  %s\n    %s\n    $ %s\n", service.Name, service.Reason, service.Command)
+		}
+	}
+	return nil
+}
+
+func printImportedVars(vars map[string]string) {
+	if layout, ok := vars["kb_layout"]; ok {
+		line := "  ✓ Keyboard: " + layout
+		if variant := strings.Trim(vars["kb_variant"], ","); variant != "" {
+			line += " (variants " + vars["kb_variant"] + ")"
+		}
+		if options := vars["kb_options"]; options != "" {
+			line += ", options " + options
+		}
+		fmt.Println(line)
+	}
+	if monitors, ok := vars["monitor_config"]; ok {
+		fmt.Println("  ✓ Monitors:")
+		for _, line := range strings.Split(monitors, "\n") {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	onboardingApp "github.com/rebelopsio/gohan/internal/application/onboarding"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/rebelopsio/gohan/internal/domain/onboarding"
+	"github.com/spf13/cobra"
+)
+
+// onboardingCmd represents the onboarding command
+var onboardingCmd = &cobra.Command{
+	Use:   "onboarding",
+	Short: "Manage the first-login tour of the key bindings",
+	Long: `Manage the first-login tour of the key bindings.
+
+The tour is a script in ~/.gohan that Hyprland's autostart runs on login.
+It walks through the key bindings gohan sets up as a series of
+notifications, then removes itself so it is shown only once. An
+installation that deploys the Hyprland configuration enables the tour,
+unless it was shown or disabled before.`,
+}
+
+// onboardingEnableCmd represents the onboarding enable command
+var onboardingEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Show the tour on the next login",
+	Long: `Show the tour on the next login, even if it was shown or disabled before.
+
+Examples:
+  # Show the tour again after logging out and back in
+  gohan onboarding enable`,
+	Args: cobra.NoArgs,
+	RunE: runOnboardingEnable,
+}
+
+// onboardingDisableCmd represents the onboarding disable command
+var onboardingDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Do not show the tour",
+	Long: `Remove the tour script. Later installations do not enable the tour again;
+use gohan onboarding enable to bring it back.`,
+	Args: cobra.NoArgs,
+	RunE: runOnboardingDisable,
+}
+
+// onboardingStatusCmd represents the onboarding status command
+var onboardingStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the tour runs on the next login",
+	Args:  cobra.NoArgs,
+	RunE:  runOnboardingStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(onboardingCmd)
+	onboardingCmd.AddCommand(onboardingEnableCmd)
+	onboardingCmd.AddCommand(onboardingDisableCmd)
+	onboardingCmd.AddCommand(onboardingStatusCmd)
+}
+
+func runOnboardingEnable(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	response, err := c.EnableOnboardingUseCase.Execute(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ The tour runs on the next login (%s)\n\n", response.ScriptPath)
+	printOnboardingSteps(response)
+	return nil
+}
+
+func runOnboardingDisable(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	if _, err := c.DisableOnboardingUseCase.Execute(context.Background()); err != nil {
+		return err
+	}
+
+	fmt.Println("✓ The tour is disabled")
+	return nil
+}
+
+func runOnboardingStatus(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	response, err := c.OnboardingStatusUseCase.Execute(context.Background())
+	if err != nil {
+		return err
+	}
+
+	switch response.State {
+	case onboarding.StatePending.String():
+		fmt.Printf("The tour runs on the next login (%s)\n\n", response.ScriptPath)
+	case onboarding.StateDone.String():
+		fmt.Println("The tour was shown or disabled; gohan onboarding enable shows it again")
+		fmt.Println()
+	default:
+		fmt.Println("The tour is not set up; installing the Hyprland configuration enables it")
+		fmt.Println()
+	}
+	printOnboardingSteps(response)
+	return nil
+}
+
+func printOnboardingSteps(response *onboardingApp.OnboardingResponse) {
+	if len(response.Steps) == 0 {
+		return
+	}
+	fmt.Println("Key bindings in the tour:")
+	for _, step := range response.Steps {
+		fmt.Printf("  %-20s %s\n", step.Keys, step.Action)
+	}
+}
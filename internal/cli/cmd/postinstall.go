@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	postinstallApp "github.com/rebelopsio/gohan/internal/application/postinstall"
+	"github.com/rebelopsio/gohan/internal/config"
 	"github.com/rebelopsio/gohan/internal/domain/postinstall"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
 	postinstallInfra "github.com/rebelopsio/gohan/internal/infrastructure/postinstall"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +24,7 @@ The post-install command sets up:
 - Shell configuration with theme
 - Audio system (PipeWire)
 - Network manager
+- Compressed swap (zram)
 - Wallpaper cache generation
 
 Examples:
@@ -34,6 +37,9 @@ Examples:
   # Complete setup with all components
   gohan post-install --display-manager sddm --shell zsh --audio --network
 
+  # Enable zram swap (recommended on low-RAM machines)
+  gohan post-install --zram
+
   # TTY launch (no display manager)
   gohan post-install --display-manager tty
 
@@ -49,6 +55,7 @@ var (
 	shellThemeFlag     string
 	setupAudioFlag     bool
 	setupNetworkFlag   bool
+	setupZramFlag      bool
 	wallpaperDirFlag   string
 )
 
@@ -61,6 +68,7 @@ func init() {
 	postInstallCmd.Flags().StringVar(&shellThemeFlag, "shell-theme", "default", "Theme for shell configuration")
 	postInstallCmd.Flags().BoolVar(&setupAudioFlag, "audio", false, "Setup audio system (PipeWire)")
 	postInstallCmd.Flags().BoolVar(&setupNetworkFlag, "network", false, "Setup network manager")
+	postInstallCmd.Flags().BoolVar(&setupZramFlag, "zram", false, "Setup compressed swap (systemd-zram-generator)")
 	postInstallCmd.Flags().StringVar(&wallpaperDirFlag, "wallpaper-dir", "/usr/share/wallpapers", "Directory containing wallpapers")
 	postInstallCmd.Flags().BoolVar(&showProgress, "progress", false, "Show progress during setup")
 }
@@ -87,8 +95,22 @@ func runPostInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create infrastructure components
-	packageMgr := postinstallInfra.NewAPTPackageManagerAdapter()
-	serviceMgr := postinstallInfra.NewSystemdServiceManager()
+	cfg, cfgErr := config.Load()
+	if cfgErr != nil {
+		cfg = config.DefaultConfig()
+	}
+	aptOptions := packagemanager.Options(cfg.Apt.Options)
+	if err := aptOptions.Validate(); err != nil {
+		return fmt.Errorf("invalid apt options: %w", err)
+	}
+	packageMgr := postinstallInfra.NewAPTPackageManagerAdapterWithManager(
+		packagemanager.NewAPTManager().WithTimeouts(packagemanager.Timeouts{
+			Install: cfg.Timeouts.PackageInstall,
+			Update:  cfg.Timeouts.PackageCacheUpdate,
+			Query:   cfg.Timeouts.PackageQuery,
+		}).WithOptions(aptOptions),
+	)
+	serviceMgr := postinstallInfra.NewSystemdServiceManagerWithTimeout(cfg.Timeouts.ServiceCommand)
 
 	// Create installers
 	installers := postinstallApp.Installers{}
@@ -109,6 +131,10 @@ func runPostInstall(cmd *cobra.Command, args []string) error {
 		installers.NetworkInstaller = postinstallInfra.NewNetworkInstaller(packageMgr, serviceMgr)
 	}
 
+	if setupZramFlag {
+		installers.ZramInstaller = postinstallInfra.NewZramInstaller(packageMgr, serviceMgr)
+	}
+
 	if wallpaperDirFlag != "" {
 		installers.WallpaperGenerator = postinstallInfra.NewWallpaperCacheGenerator(wallpaperDirFlag)
 	}
@@ -123,6 +149,7 @@ func runPostInstall(cmd *cobra.Command, args []string) error {
 		ShellTheme:     shellThemeFlag,
 		SetupAudio:     setupAudioFlag,
 		SetupNetwork:   setupNetworkFlag,
+		SetupZram:      setupZramFlag,
 		WallpaperDir:   wallpaperDirFlag,
 		ShowProgress:   showProgress,
 	}
@@ -162,6 +189,7 @@ func runPostInstall(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Shell:            %s\n", formatBoolStatus(resp.ShellConfigured))
 	fmt.Printf("Audio:            %s\n", formatBoolStatus(resp.AudioConfigured))
 	fmt.Printf("Network:          %s\n", formatBoolStatus(resp.NetworkManagerConfigured))
+	fmt.Printf("Zram Swap:        %s\n", formatBoolStatus(resp.ZramConfigured))
 	fmt.Printf("Wallpaper Cache:  %s\n", formatBoolStatus(resp.WallpaperCacheGenerated))
 	fmt.Printf("Duration:         %dms\n", resp.DurationMs)
 	fmt.Println()
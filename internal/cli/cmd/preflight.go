@@ -2,11 +2,18 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
-	preflightInfra "github.com/rebelopsio/gohan/internal/infrastructure/preflight/detectors"
+	"github.com/rebelopsio/gohan/internal/config"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	preflightRepository "github.com/rebelopsio/gohan/internal/infrastructure/preflight/repository"
+	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
 	"github.com/spf13/cobra"
 )
 
@@ -22,7 +29,14 @@ The preflight command checks:
 - GPU detection and driver requirements
 - Available disk space (minimum 10GB)
 - Internet connectivity
-- Source repository configuration`,
+- Source repository configuration
+
+Severities can be overridden per requirement in the gohan config:
+
+  preflight:
+    severities:
+      source_repositories: blocker
+      gpu_support: ignore`,
 }
 
 // preflightCheckCmd runs all preflight checks
@@ -40,13 +54,38 @@ Examples:
   gohan preflight check
 
   # Run with progress output
-  gohan preflight check --progress`,
+  gohan preflight check --progress
+
+  # Print the results, with full fix guidance, as JSON
+  gohan preflight check --json`,
 	RunE: runPreflightCheck,
 }
 
+// preflightHistoryCmd compares past preflight runs
+var preflightHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Compare past preflight runs",
+	Long: `List past preflight runs and how they compare over time.
+
+Every preflight run, including the one before each installation, is kept
+in ~/.gohan/preflight.db. The history shows the outcome of each run, the
+checks whose status changed in the latest run, and measurements that have
+been moving steadily in one direction, such as free disk space shrinking.
+
+Examples:
+  # Compare all recorded runs
+  gohan preflight history
+
+  # Compare the last 5 runs
+  gohan preflight history --limit 5`,
+	RunE: runPreflightHistory,
+}
+
 // Flags
 var (
-	showProgress bool
+	showProgress  bool
+	preflightJSON bool
+	historyLimit  int
 )
 
 func init() {
@@ -54,25 +93,38 @@ func init() {
 
 	// Add subcommands
 	preflightCmd.AddCommand(preflightCheckCmd)
+	preflightCmd.AddCommand(preflightHistoryCmd)
 
 	// Flags
 	preflightCheckCmd.Flags().BoolVar(&showProgress, "progress", false, "Show progress as checks run")
+	preflightCheckCmd.Flags().BoolVar(&preflightJSON, "json", false, "Output results as JSON")
+	preflightCheckCmd.MarkFlagsMutuallyExclusive("progress", "json")
+	preflightHistoryCmd.Flags().IntVar(&historyLimit, "limit", 10, "Number of most recent runs to compare (0 for all)")
 }
 
 func runPreflightCheck(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	// Create detectors
-	detectors := preflightApp.Detectors{
-		DebianDetector:          preflightInfra.NewDebianVersionDetector(),
-		GPUDetector:             preflightInfra.NewSystemGPUDetector(),
-		DiskSpaceDetector:       preflightInfra.NewSystemDiskSpaceDetector(),
-		ConnectivityChecker:     preflightInfra.NewSystemConnectivityChecker(),
-		SourceRepositoryChecker: preflightInfra.NewSystemSourceRepositoryChecker(),
+	// Create use case
+	useCase := preflightApp.NewRunPreflightUseCase(preflightTUI.SystemDetectors())
+	if cfg, err := config.Load(); err == nil {
+		policy, err := preflight.NewSeverityPolicy(cfg.Preflight.Severities)
+		if err != nil {
+			return fmt.Errorf("invalid preflight severities in config: %w", err)
+		}
+		useCase = useCase.WithSeverityPolicy(policy)
+
+		// Keep the run so it can be compared with later ones
+		repo, err := preflightRepository.NewSQLiteRepository(cfg.Database.PreflightDB)
+		if err != nil {
+			return fmt.Errorf("failed to open preflight database: %w", err)
+		}
+		defer repo.Close()
+		useCase = useCase.WithRepository(repo)
 	}
 
-	// Create use case
-	useCase := preflightApp.NewRunPreflightUseCase(detectors)
+	// Configs are deployed to $HOME, which may be its own partition
+	homeDir, _ := os.UserHomeDir()
 
 	// Execute with or without progress
 	var resp *preflightApp.RunPreflightResponse
@@ -84,11 +136,13 @@ func runPreflightCheck(cmd *cobra.Command, args []string) error {
 
 		resp, err = useCase.ExecuteWithProgress(
 			ctx,
-			preflightApp.RunPreflightRequest{ShowProgress: true},
+			preflightApp.RunPreflightRequest{ShowProgress: true, HomeDir: homeDir},
 			func(validatorName string, result preflightApp.CheckResult) {
 				// Display progress
 				status := "✓"
-				if !result.Passed {
+				if result.Ignored {
+					status = "-"
+				} else if !result.Passed {
 					if result.Blocking {
 						status = "✗"
 					} else {
@@ -99,13 +153,25 @@ func runPreflightCheck(cmd *cobra.Command, args []string) error {
 			},
 		)
 	} else {
-		resp, err = useCase.Execute(ctx, preflightApp.RunPreflightRequest{})
+		resp, err = useCase.Execute(ctx, preflightApp.RunPreflightRequest{HomeDir: homeDir})
 	}
 
 	if err != nil {
 		return fmt.Errorf("preflight checks failed: %w", err)
 	}
 
+	if preflightJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		if !resp.Passed {
+			return fmt.Errorf("preflight checks failed with %d blocking issue(s)", resp.FailedChecks)
+		}
+		return nil
+	}
+
 	// Display results
 	fmt.Println("\n" + strings.Repeat("═", 60))
 	fmt.Printf("  PREFLIGHT CHECK RESULTS\n")
@@ -120,6 +186,12 @@ func runPreflightCheck(cmd *cobra.Command, args []string) error {
 	if resp.FailedChecks > 0 {
 		fmt.Printf("Failed:          %d ✗\n", resp.FailedChecks)
 	}
+	if resp.IgnoredChecks > 0 {
+		fmt.Printf("Ignored:         %d -\n", resp.IgnoredChecks)
+	}
+	if len(resp.SeverityPolicy) > 0 {
+		fmt.Printf("Severity policy: %s\n", formatSeverityPolicy(resp.SeverityPolicy))
+	}
 	fmt.Println()
 
 	// Detailed results
@@ -145,6 +217,9 @@ func runPreflightCheck(cmd *cobra.Command, args []string) error {
 		} else {
 			fmt.Printf("✓  %s\n", resp.OverallMessage)
 		}
+		if resp.RecommendLiteMode {
+			fmt.Println("\n🪶 Lite mode will be selected automatically (override with --rendering standard).")
+		}
 	} else {
 		fmt.Printf("✗  %s\n", resp.OverallMessage)
 		fmt.Println("\nPlease resolve the blocking issues above before attempting installation.")
@@ -155,11 +230,77 @@ func runPreflightCheck(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runPreflightHistory(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Never create the database just to look at it
+	if _, err := os.Stat(cfg.Database.PreflightDB); os.IsNotExist(err) {
+		fmt.Println("No preflight runs recorded yet. Run: gohan preflight check")
+		return nil
+	}
+
+	repo, err := preflightRepository.NewSQLiteRepository(cfg.Database.PreflightDB)
+	if err != nil {
+		return fmt.Errorf("failed to open preflight database: %w", err)
+	}
+	defer repo.Close()
+
+	resp, err := preflightApp.NewPreflightHistoryUseCase(repo).Execute(ctx, preflightApp.PreflightHistoryRequest{
+		Limit: historyLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Runs) == 0 {
+		fmt.Println("No preflight runs recorded yet. Run: gohan preflight check")
+		return nil
+	}
+
+	fmt.Println("\n" + strings.Repeat("═", 60))
+	fmt.Printf("  PREFLIGHT HISTORY\n")
+	fmt.Println(strings.Repeat("═", 60) + "\n")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STARTED\tOUTCOME\tPASSED\tWARNINGS\tBLOCKERS\tSESSION")
+	for _, run := range resp.Runs {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\n",
+			run.StartedAt.Local().Format("2006-01-02 15:04"),
+			run.Outcome, run.PassedChecks, run.Warnings, run.Blockers, run.SessionID)
+	}
+	w.Flush()
+
+	if len(resp.Changes) > 0 {
+		fmt.Println("\nChanged since the previous run:")
+		for _, change := range resp.Changes {
+			fmt.Printf("  • %s\n", change)
+		}
+	}
+
+	if len(resp.Trends) > 0 {
+		fmt.Println("\nTrends:")
+		for _, trend := range resp.Trends {
+			fmt.Printf("  • %s\n", trend)
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
 func displayCheckResult(result preflightApp.CheckResult) {
 	// Status icon
 	status := "✓"
 	statusColor := "green"
-	if !result.Passed {
+	if result.Ignored {
+		status = "-"
+		statusColor = "gray"
+	} else if !result.Passed {
 		if result.Blocking {
 			status = "✗"
 			statusColor = "red"
@@ -171,16 +312,32 @@ func displayCheckResult(result preflightApp.CheckResult) {
 
 	// Result name and status
 	fmt.Printf("\n%s %s\n", status, result.Name)
+	if result.Override != "" {
+		fmt.Printf("   Severity overridden by config: %s\n", result.Override)
+	}
 
 	// Message
 	if result.Message != "" {
 		fmt.Printf("   %s\n", result.Message)
 	}
+	if result.Detected != "" {
+		fmt.Printf("   Detected: %s\n", result.Detected)
+	}
 
 	// Guidance (only for failures/warnings)
-	if !result.Passed && result.Guidance != "" {
-		fmt.Printf("\n   💡 %s\n", result.Guidance)
+	if !result.Passed && !result.Guidance.IsEmpty() {
+		fmt.Printf("\n   💡 %s", strings.TrimPrefix(result.Guidance.Render("      "), "      "))
 	}
 
 	_ = statusColor // For future color output support
 }
+
+// formatSeverityPolicy lists overrides as "requirement=override", sorted
+func formatSeverityPolicy(policy map[string]string) string {
+	entries := make([]string, 0, len(policy))
+	for requirement, override := range policy {
+		entries = append(entries, fmt.Sprintf("%s=%s", requirement, override))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ", ")
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
+)
+
+// fixCommand is a command from the guidance of a blocking check
+type fixCommand struct {
+	check string
+	step  preflightApp.FixStep
+}
+
+// resolvePreflightBlockers walks the user through fixing the checks that
+// blocked an installation: it shows their guidance, runs the suggested
+// commands on request and checks again, running only the checks that
+// failed. It returns true once nothing blocks the installation any more,
+// and false if the user gives up.
+func resolvePreflightBlockers(ctx context.Context, c *container.Container, progress *dto.InstallationProgressResponse) (bool, error) {
+	session, err := c.InstallationRepo.FindByID(ctx, progress.SessionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load installation session: %w", err)
+	}
+	scope := preflightApp.ScopeForInstallation(session.Configuration())
+
+	policy, err := preflight.NewSeverityPolicy(c.Config.Preflight.Severities)
+	if err != nil {
+		return false, fmt.Errorf("invalid preflight severities in config: %w", err)
+	}
+	useCase := preflightApp.NewRunPreflightUseCase(preflightTUI.SystemDetectors()).
+		WithSeverityPolicy(policy).
+		WithRepository(c.PreflightRepo)
+
+	homeDir, _ := os.UserHomeDir()
+	only := make([]preflight.RequirementName, 0, len(progress.PreflightBlockers))
+	for _, name := range progress.PreflightBlockers {
+		only = append(only, preflight.RequirementName(name))
+	}
+
+	recheck := func() ([]preflightApp.CheckResult, error) {
+		fmt.Printf("\n🔍 Checking %d blocked requirement(s) again...\n", len(only))
+		resp, err := useCase.Execute(ctx, preflightApp.RunPreflightRequest{
+			HomeDir: homeDir,
+			Scope:   &scope,
+			Only:    only,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("preflight checks failed: %w", err)
+		}
+
+		blockers := resp.BlockingResults()
+		only = only[:0]
+		for _, blocker := range blockers {
+			only = append(only, preflight.RequirementName(blocker.Name))
+		}
+		return blockers, nil
+	}
+
+	blockers, err := recheck()
+	if err != nil {
+		return false, err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for len(blockers) > 0 {
+		commands := printPreflightBlockers(blockers)
+
+		if len(commands) > 0 {
+			fmt.Printf("\nRun a fix [1-%d], run [a]ll fixes, [r]e-check or [q]uit: ", len(commands))
+		} else {
+			fmt.Print("\nFix the issues above, then [r]e-check or [q]uit: ")
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// No more input: treat as quitting
+			fmt.Println()
+			return false, nil
+		}
+
+		choice := strings.ToLower(strings.TrimSpace(line))
+		switch {
+		case choice == "q":
+			return false, nil
+		case choice == "r":
+		case choice == "a" && len(commands) > 0:
+			for _, command := range commands {
+				if err := runFixCommand(ctx, command.step.Command); err != nil {
+					fmt.Printf("✗ %v\n", err)
+					break
+				}
+			}
+		default:
+			n, err := strconv.Atoi(choice)
+			if err != nil || n < 1 || n > len(commands) {
+				fmt.Printf("Unknown choice %q\n", choice)
+				continue
+			}
+			if err := runFixCommand(ctx, commands[n-1].step.Command); err != nil {
+				fmt.Printf("✗ %v\n", err)
+			}
+		}
+
+		if blockers, err = recheck(); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// printPreflightBlockers shows the blocking checks with their guidance and
+// returns the fix commands, numbered in the order printed
+func printPreflightBlockers(blockers []preflightApp.CheckResult) []fixCommand {
+	fmt.Printf("\n✗ %d check(s) block the installation:\n", len(blockers))
+
+	var commands []fixCommand
+	for _, blocker := range blockers {
+		fmt.Printf("\n✗ %s\n", blocker.Name)
+		if blocker.Message != "" {
+			fmt.Printf("   %s\n", blocker.Message)
+		}
+		fmt.Print(blocker.Guidance.Render("   "))
+
+		for _, step := range blocker.Guidance.FixSteps() {
+			if step.Command != "" {
+				commands = append(commands, fixCommand{check: blocker.Name, step: step})
+			}
+		}
+	}
+
+	if len(commands) > 0 {
+		fmt.Println("\nSuggested fixes:")
+		for i, command := range commands {
+			fmt.Printf("  %d. %s (%s)\n", i+1, command.step.Description, command.check)
+			fmt.Printf("       %s\n", command.step.Command)
+		}
+	}
+	return commands
+}
+
+// runFixCommand runs a suggested fix in the user's terminal
+func runFixCommand(ctx context.Context, command string) error {
+	fmt.Printf("\n$ %s\n", command)
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", command, err)
+	}
+	return nil
+}
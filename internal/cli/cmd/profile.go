@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/spf13/cobra"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Inspect installation profiles",
+	Long: `Inspect installation profiles.
+
+The built-in profiles are minimal, recommended, full and lite. Custom
+profiles are YAML files in ~/.gohan/profiles, named after the file:
+
+  name: Work laptop
+  extends: recommended
+  rendering: lite
+  packages: [alacritty]
+  exclude: [nautilus]`,
+}
+
+// profileDiffCmd represents the profile diff command
+var profileDiffCmd = &cobra.Command{
+	Use:   "diff <from> <to>",
+	Short: "Show what differs between two profiles",
+	Long: `Show the packages, components, estimated sizes and configuration files that
+differ between two profiles. Sizes are the installed sizes of the versions
+apt would install now; packages apt does not know are counted as unknown.
+
+A profile is a built-in name, the name of a file in ~/.gohan/profiles, or
+the path to a YAML file.
+
+Examples:
+  # What would upgrading from minimal to recommended add?
+  gohan profile diff minimal recommended
+
+  # Compare a custom profile with the one it extends
+  gohan profile diff recommended work-laptop`,
+	Args: cobra.ExactArgs(2),
+	RunE: runProfileDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileDiffCmd)
+}
+
+func runProfileDiff(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	diff, err := c.CompareProfilesUseCase.Execute(context.Background(), dto.ProfileDiffRequest{
+		From: args[0],
+		To:   args[1],
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s → %s\n", diff.From.Name, diff.To.Name)
+	if diff.From.RenderingMode != diff.To.RenderingMode {
+		fmt.Printf("Rendering: %s → %s\n", diff.From.RenderingMode, diff.To.RenderingMode)
+	}
+
+	fmt.Printf("\nPackages: %d added, %d removed, %d in both\n",
+		len(diff.AddedPackages), len(diff.RemovedPackages), diff.CommonPackages)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	printPackageChanges(w, "+", diff.AddedPackages)
+	printPackageChanges(w, "-", diff.RemovedPackages)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(diff.AddedComponents) > 0 || len(diff.RemovedComponents) > 0 {
+		fmt.Println("\nComponents:")
+		for _, component := range diff.AddedComponents {
+			fmt.Printf("  + %s\n", component)
+		}
+		for _, component := range diff.RemovedComponents {
+			fmt.Printf("  - %s\n", component)
+		}
+	}
+
+	if len(diff.AddedConfigs) > 0 || len(diff.RemovedConfigs) > 0 || len(diff.ChangedConfigs) > 0 {
+		fmt.Println("\nConfiguration files (~/.config):")
+		for _, path := range diff.AddedConfigs {
+			fmt.Printf("  + %s\n", path)
+		}
+		for _, path := range diff.RemovedConfigs {
+			fmt.Printf("  - %s\n", path)
+		}
+		for _, change := range diff.ChangedConfigs {
+			fmt.Printf("  ~ %s (%s → %s)\n", change.Path, change.FromTemplate, change.ToTemplate)
+		}
+	}
+
+	if len(diff.To.OptIn) > 0 {
+		fmt.Printf("\nOpt-in with %s (gohan install --components <component>):\n", diff.To.Name)
+		for _, pkg := range diff.To.OptIn {
+			component := "-"
+			if definition, ok := installation.FindPackageDefinition(pkg); ok && definition.Component != "" {
+				component = string(definition.Component)
+			}
+			fmt.Printf("  %s (%s)\n", pkg, component)
+		}
+	}
+
+	fmt.Printf("\nEstimated size: %s → %s (%s)\n",
+		profileSize(diff.From), profileSize(diff.To), signedSize(diff.SizeChangeBytes))
+	if unknown := diff.From.UnknownSizes + diff.To.UnknownSizes; unknown > 0 {
+		fmt.Println("Sizes leave out packages apt could not resolve; run apt-get update or check the repositories.")
+	}
+	return nil
+}
+
+func printPackageChanges(w *tabwriter.Writer, sign string, changes []dto.PackageChange) {
+	for _, change := range changes {
+		size := "?"
+		if change.SizeBytes > 0 {
+			size = formatSize(change.SizeBytes)
+		}
+		component := change.Component
+		if component == "" {
+			component = "-"
+		}
+		fmt.Fprintf(w, "  %s %s\t%s\t%s\t%s\n", sign, change.Name, component, size, change.Description)
+	}
+}
+
+func profileSize(summary dto.ProfileSummary) string {
+	if summary.UnknownSizes == 0 {
+		return formatSize(summary.EstimatedSizeBytes)
+	}
+	return fmt.Sprintf("%s + %d unknown", formatSize(summary.EstimatedSizeBytes), summary.UnknownSizes)
+}
+
+func signedSize(bytes int64) string {
+	if bytes < 0 {
+		return "-" + formatSize(uint64(-bytes))
+	}
+	return "+" + formatSize(uint64(bytes))
+}
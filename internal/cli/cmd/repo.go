@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	repoApp "github.com/rebelopsio/gohan/internal/application/repository"
 	repoInfra "github.com/rebelopsio/gohan/internal/infrastructure/repository"
@@ -40,11 +41,12 @@ Examples:
 var repoCheckCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check repository configuration",
-	Long: `Check the current sources.list configuration.
+	Long: `Check the current apt sources configuration.
 
-This command analyzes your /etc/apt/sources.list file to check which
-repository components are enabled (main, contrib, non-free, etc.) and
-whether deb-src entries are available.
+This command analyzes /etc/apt/sources.list and the files in
+/etc/apt/sources.list.d (both one-line .list files and deb822 .sources
+files) to check which repository components are enabled (main, contrib,
+non-free, etc.) and whether deb-src entries are available.
 
 Examples:
   # Check current repository configuration
@@ -107,10 +109,144 @@ Examples:
 	RunE: runBackupSources,
 }
 
+// fastestMirrorCmd benchmarks mirrors and optionally switches to the fastest
+var fastestMirrorCmd = &cobra.Command{
+	Use:   "fastest-mirror",
+	Short: "Find and switch to the fastest Debian mirror",
+	Long: `Benchmark Debian mirrors and optionally rewrite sources to use the fastest.
+
+Each mirror is measured by the latency and download throughput of its
+Release file. With --apply, the Debian archive entries are rewritten to the
+selected mirror in deb822 format (/etc/apt/sources.list.d/debian.sources)
+and the legacy sources.list entries are commented out. The previous sources
+are snapshotted first and can be restored with --revert.
+
+Examples:
+  # Benchmark the default mirror list
+  gohan repo fastest-mirror
+
+  # Benchmark and switch to the fastest mirror
+  gohan repo fastest-mirror --apply
+
+  # Switch to a specific mirror without benchmarking
+  gohan repo fastest-mirror --use http://ftp.de.debian.org/debian
+
+  # Undo the last mirror switch
+  gohan repo fastest-mirror --revert`,
+	RunE: runFastestMirror,
+}
+
+// repoLintCmd checks apt sources for common mistakes
+var repoLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check apt sources for duplicates and mixed suites",
+	Long: `Check all apt sources for problems that cause confusing apt failures.
+
+Every entry in /etc/apt/sources.list and /etc/apt/sources.list.d is checked
+for lines apt cannot parse, duplicate entries, Debian entries that mix
+releases (e.g. bookworm and sid) and third-party repositories without a
+signed-by key. Each problem is reported with its file and line.
+
+With --fix, duplicate one-line entries are commented out or reduced to
+their missing components and fully duplicate deb822 stanzas are disabled.
+The sources are snapshotted first. Mixed suites and missing keys are never
+changed automatically.
+
+Examples:
+  # Report problems
+  gohan repo lint
+
+  # Remove duplicate entries
+  gohan repo lint --fix`,
+	RunE: runRepoLint,
+}
+
+// repoKeyCmd groups the signing key management commands
+var repoKeyCmd = &cobra.Command{
+	Use:   "key",
+	Short: "Manage signing keys for third-party repositories",
+	Long: `Manage signing keys for third-party repositories.
+
+Keys are downloaded, verified against a pinned fingerprint and installed
+under /usr/share/keyrings. Repositories added alongside a key reference it
+via Signed-By, so the key is only trusted for that repository. Every add
+and remove is recorded in /var/log/gohan/repo-audit.log.`,
+}
+
+// repoKeyListCmd lists managed keys
+var repoKeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List signing keys managed by gohan",
+	Long: `List signing keys managed by gohan.
+
+Each installed keyring is re-checked against its pinned fingerprint.
+
+Examples:
+  gohan repo key list`,
+	RunE: runRepoKeyList,
+}
+
+// repoKeyAddCmd adds a key
+var repoKeyAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Download, verify and install a signing key",
+	Long: `Download a repository signing key, verify it and install it.
+
+The key must match the fingerprint given with --fingerprint, otherwise
+nothing is installed. With --repo, a deb822 sources file referencing the
+key via Signed-By is created in /etc/apt/sources.list.d.
+
+Examples:
+  # Install a key only
+  gohan repo key add example --url https://repo.example.com/key.asc \
+    --fingerprint "ABCD 1234 ..."
+
+  # Install a key and add its repository
+  gohan repo key add example --url https://repo.example.com/key.asc \
+    --fingerprint "ABCD 1234 ..." --repo https://repo.example.com/apt --suite stable`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRepoKeyAdd,
+}
+
+// repoKeyRemoveCmd removes a key
+var repoKeyRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a managed signing key and its repository",
+	Long: `Remove a signing key installed by gohan.
+
+The keyring file and any sources file gohan created for it are deleted.
+
+Examples:
+  gohan repo key remove example`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRepoKeyRemove,
+}
+
+// Locations of the apt sources; sources.list.d may hold both one-line .list
+// files and deb822 .sources files
+const (
+	aptSourcesListPath = "/etc/apt/sources.list"
+	aptSourcesDir      = "/etc/apt/sources.list.d"
+)
+
 // Flags
 var (
 	noBackup  bool
 	backupDir string
+
+	mirrorList   []string
+	mirrorSuite  string
+	mirrorApply  bool
+	mirrorUse    string
+	mirrorRevert bool
+
+	lintFix bool
+
+	keyURL         string
+	keyFingerprint string
+	keyRepoURI     string
+	keySuites      []string
+	keyComponents  []string
 )
 
 func init() {
@@ -122,6 +258,13 @@ func init() {
 	repoCmd.AddCommand(enableNonFreeCmd)
 	repoCmd.AddCommand(enableDebSrcCmd)
 	repoCmd.AddCommand(backupSourcesCmd)
+	repoCmd.AddCommand(fastestMirrorCmd)
+	repoCmd.AddCommand(repoLintCmd)
+	repoCmd.AddCommand(repoKeyCmd)
+
+	repoKeyCmd.AddCommand(repoKeyListCmd)
+	repoKeyCmd.AddCommand(repoKeyAddCmd)
+	repoKeyCmd.AddCommand(repoKeyRemoveCmd)
 
 	// Flags for enable commands
 	enableNonFreeCmd.Flags().BoolVar(&noBackup, "no-backup", false, "Skip creating backup before changes")
@@ -129,6 +272,26 @@ func init() {
 
 	// Flags for backup command
 	backupSourcesCmd.Flags().StringVar(&backupDir, "dir", "/etc/apt", "Directory to store backup")
+
+	// Flags for fastest-mirror command
+	fastestMirrorCmd.Flags().StringSliceVar(&mirrorList, "mirror", nil, "Mirror URI to benchmark (repeatable, defaults to a built-in list)")
+	fastestMirrorCmd.Flags().StringVar(&mirrorSuite, "suite", "", "Suite to benchmark against (defaults to the detected codename)")
+	fastestMirrorCmd.Flags().BoolVar(&mirrorApply, "apply", false, "Rewrite sources to use the fastest mirror")
+	fastestMirrorCmd.Flags().StringVar(&mirrorUse, "use", "", "Rewrite sources to use this mirror without benchmarking")
+	fastestMirrorCmd.Flags().BoolVar(&mirrorRevert, "revert", false, "Restore the sources saved before the last mirror switch")
+	fastestMirrorCmd.MarkFlagsMutuallyExclusive("apply", "use", "revert")
+
+	// Flags for lint command
+	repoLintCmd.Flags().BoolVar(&lintFix, "fix", false, "Remove duplicate entries (sources are snapshotted first)")
+
+	// Flags for key add command
+	repoKeyAddCmd.Flags().StringVar(&keyURL, "url", "", "URL to download the key from")
+	repoKeyAddCmd.Flags().StringVar(&keyFingerprint, "fingerprint", "", "Pinned fingerprint the key must match")
+	repoKeyAddCmd.Flags().StringVar(&keyRepoURI, "repo", "", "Repository URI to add, signed by this key")
+	repoKeyAddCmd.Flags().StringSliceVar(&keySuites, "suite", nil, "Repository suite (repeatable)")
+	repoKeyAddCmd.Flags().StringSliceVar(&keyComponents, "component", []string{"main"}, "Repository component (repeatable)")
+	repoKeyAddCmd.MarkFlagRequired("url")
+	repoKeyAddCmd.MarkFlagRequired("fingerprint")
 }
 
 func runDetectVersion(cmd *cobra.Command, args []string) error {
@@ -188,7 +351,8 @@ func runRepoCheck(cmd *cobra.Command, args []string) error {
 
 	// Execute
 	resp, err := useCase.Execute(ctx, repoApp.CheckRepositoryRequest{
-		SourcesListPath: "/etc/apt/sources.list",
+		SourcesListPath: aptSourcesListPath,
+		SourcesDir:      aptSourcesDir,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to check repositories: %w", err)
@@ -206,8 +370,14 @@ func runRepoCheck(cmd *cobra.Command, args []string) error {
 	fmt.Printf("\nEntries:\n")
 	fmt.Printf("  deb entries:       %d\n", resp.DebEntries)
 	fmt.Printf("  deb-src entries:   %d\n", resp.DebSrcEntries)
+	fmt.Printf("  disabled entries:  %d\n", resp.DisabledEntries)
 	fmt.Printf("  total entries:     %d\n", resp.TotalEntries)
 
+	fmt.Printf("\nFiles:\n")
+	for _, file := range resp.Files {
+		fmt.Printf("  %-45s %-8s %d entries\n", file.Path, file.Format, file.Entries)
+	}
+
 	fmt.Printf("\nSource repositories: %s\n", formatBool(resp.HasDebSrc))
 
 	// Recommendations
@@ -236,7 +406,8 @@ func runEnableNonFree(cmd *cobra.Command, args []string) error {
 
 	// Execute
 	resp, err := useCase.Execute(ctx, repoApp.EnableNonFreeRequest{
-		SourcesListPath: "/etc/apt/sources.list",
+		SourcesListPath: aptSourcesListPath,
+		SourcesDir:      aptSourcesDir,
 		BackupFirst:     !noBackup,
 	})
 	if err != nil {
@@ -251,9 +422,7 @@ func runEnableNonFree(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("✓ Non-free repositories enabled\n\n")
 
-	if resp.BackupPath != "" {
-		fmt.Printf("Backup created: %s\n", resp.BackupPath)
-	}
+	printModifiedSources(resp.FilesModified, resp.BackupPaths)
 
 	fmt.Printf("Components added:\n")
 	for _, comp := range resp.ComponentsAdded {
@@ -278,7 +447,8 @@ func runEnableDebSrc(cmd *cobra.Command, args []string) error {
 
 	// Execute
 	resp, err := useCase.Execute(ctx, repoApp.EnableDebSrcRequest{
-		SourcesListPath: "/etc/apt/sources.list",
+		SourcesListPath: aptSourcesListPath,
+		SourcesDir:      aptSourcesDir,
 		BackupFirst:     !noBackup,
 	})
 	if err != nil {
@@ -293,9 +463,7 @@ func runEnableDebSrc(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("✓ Source repositories enabled\n\n")
 
-	if resp.BackupPath != "" {
-		fmt.Printf("Backup created: %s\n", resp.BackupPath)
-	}
+	printModifiedSources(resp.FilesModified, resp.BackupPaths)
 
 	fmt.Printf("Entries added:  %d\n", resp.EntriesAdded)
 
@@ -317,7 +485,7 @@ func runBackupSources(cmd *cobra.Command, args []string) error {
 
 	// Execute
 	resp, err := useCase.Execute(ctx, repoApp.BackupSourcesListRequest{
-		SourcesListPath: "/etc/apt/sources.list",
+		SourcesListPath: aptSourcesListPath,
 		BackupDir:       backupDir,
 	})
 	if err != nil {
@@ -333,6 +501,229 @@ func runBackupSources(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runFastestMirror(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	manager := repoInfra.NewFileSourcesManager()
+	snapshotter := repoInfra.NewFileSourcesSnapshotter()
+
+	if mirrorRevert {
+		resp, err := repoApp.NewRevertMirrorUseCase(snapshotter).Execute(ctx, repoApp.RevertMirrorRequest{
+			SnapshotDir: repoInfra.DefaultSourcesSnapshotDir,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revert mirror switch: %w", err)
+		}
+
+		fmt.Printf("✓ Sources restored from snapshot taken %s\n\n", resp.SnapshotTime.Format("2006-01-02 15:04:05"))
+		for _, path := range resp.FilesRestored {
+			fmt.Printf("  restored: %s\n", path)
+		}
+		for _, path := range resp.FilesRemoved {
+			fmt.Printf("  removed:  %s\n", path)
+		}
+		fmt.Printf("\n💡 Next step: sudo apt update\n")
+		return nil
+	}
+
+	selected := mirrorUse
+	if selected == "" {
+		suite := mirrorSuite
+		if suite == "" {
+			if dv, err := repoInfra.NewSystemVersionDetector().DetectVersion(); err == nil {
+				suite = dv.Codename()
+			}
+		}
+
+		fmt.Printf("⏱  Benchmarking mirrors...\n\n")
+		resp, err := repoApp.NewBenchmarkMirrorsUseCase(repoInfra.NewHTTPMirrorBenchmarker()).Execute(ctx, repoApp.BenchmarkMirrorsRequest{
+			Mirrors: mirrorList,
+			Suite:   suite,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to benchmark mirrors: %w", err)
+		}
+
+		fmt.Printf("%-45s %10s %12s\n", "MIRROR", "LATENCY", "THROUGHPUT")
+		for _, result := range resp.Results {
+			if result.Error != "" {
+				fmt.Printf("%-45s %10s %12s  (%s)\n", result.URI, "-", "-", result.Error)
+				continue
+			}
+			fmt.Printf("%-45s %10s %10.0f KB/s\n", result.URI, result.Latency.Round(time.Millisecond), result.Throughput/1024)
+		}
+
+		if resp.Fastest == "" {
+			return fmt.Errorf("no reachable mirror found")
+		}
+		fmt.Printf("\n🏆 Fastest mirror: %s\n", resp.Fastest)
+
+		if !mirrorApply {
+			fmt.Printf("\n💡 Switch to it with: gohan repo fastest-mirror --apply\n")
+			return nil
+		}
+		selected = resp.Fastest
+	}
+
+	resp, err := repoApp.NewSwitchMirrorUseCase(manager, snapshotter).Execute(ctx, repoApp.SwitchMirrorRequest{
+		MirrorURI:         selected,
+		SourcesListPath:   aptSourcesListPath,
+		DebianSourcesPath: repoInfra.DefaultDebianSourcesPath,
+		SnapshotDir:       repoInfra.DefaultSourcesSnapshotDir,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to switch mirror: %w", err)
+	}
+
+	fmt.Printf("\n✓ Sources switched to %s\n\n", resp.Mirror)
+	fmt.Printf("Entries rewritten: %d\n", resp.EntriesRewritten)
+	fmt.Printf("Sources file:      %s\n", resp.DebianSourcesPath)
+	fmt.Printf("Snapshot:          %s\n", resp.SnapshotPath)
+
+	fmt.Printf("\n💡 Next steps:\n")
+	fmt.Printf("  1. Update package lists: sudo apt update\n")
+	fmt.Printf("  2. Undo if needed: gohan repo fastest-mirror --revert\n")
+
+	return nil
+}
+
+func runRepoLint(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	useCase := repoApp.NewLintSourcesUseCase(repoInfra.NewFileSourcesManager(), repoInfra.NewFileSourcesSnapshotter())
+	resp, err := useCase.Execute(ctx, repoApp.LintSourcesRequest{
+		SourcesListPath: aptSourcesListPath,
+		SourcesDir:      aptSourcesDir,
+		Fix:             lintFix,
+		SnapshotDir:     repoInfra.DefaultSourcesLintSnapshotDir,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lint sources: %w", err)
+	}
+
+	fmt.Printf("🔎 Checked %d entries in %d files\n\n", resp.Entries, resp.FilesChecked)
+
+	if len(resp.Issues) == 0 {
+		fmt.Printf("✓ No problems found\n")
+		return nil
+	}
+
+	for _, issue := range resp.Issues {
+		fmt.Printf("%s\n", issue.Location)
+		fmt.Printf("  ⚠ [%s] %s\n", issue.Kind, issue.Message)
+	}
+	fmt.Println()
+
+	if resp.FixesApplied > 0 {
+		fmt.Printf("✓ Applied %d fix(es)\n\n", resp.FixesApplied)
+		for _, path := range resp.FilesModified {
+			fmt.Printf("Modified:       %s\n", path)
+		}
+		fmt.Printf("Snapshot:       %s\n", resp.SnapshotPath)
+		fmt.Printf("\n💡 Next step: sudo apt update\n")
+		return nil
+	}
+
+	if resp.FixableIssues > 0 {
+		fmt.Printf("💡 %d of %d problems can be fixed with: gohan repo lint --fix\n", resp.FixableIssues, len(resp.Issues))
+	}
+
+	return nil
+}
+
+func runRepoKeyList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	resp, err := repoApp.NewListKeysUseCase(repoInfra.NewFileKeyringManager()).Execute(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	if len(resp.Keys) == 0 {
+		fmt.Printf("ℹ️  No signing keys managed by gohan\n")
+		return nil
+	}
+
+	fmt.Printf("🔑 Managed Signing Keys\n\n")
+	for _, key := range resp.Keys {
+		status := "✓ verified"
+		if !key.Installed {
+			status = "✗ keyring missing"
+		} else if !key.Verified {
+			status = "✗ fingerprint mismatch"
+		}
+
+		fmt.Printf("%s (%s)\n", key.Name, status)
+		fmt.Printf("  Fingerprint: %s\n", key.Fingerprint)
+		fmt.Printf("  Keyring:     %s\n", key.KeyringPath)
+		fmt.Printf("  Source:      %s\n", key.SourceURL)
+		if key.SourcesPath != "" {
+			fmt.Printf("  Repository:  %s\n", key.SourcesPath)
+		}
+		fmt.Printf("  Added:       %s\n\n", key.AddedAt.Format("2006-01-02 15:04:05"))
+	}
+
+	return nil
+}
+
+func runRepoKeyAdd(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	useCase := repoApp.NewAddKeyUseCase(repoInfra.NewFileKeyringManager(), repoInfra.NewFileSourcesManager())
+	resp, err := useCase.Execute(ctx, repoApp.AddKeyRequest{
+		Name:        args[0],
+		URL:         keyURL,
+		Fingerprint: keyFingerprint,
+		RepoURI:     keyRepoURI,
+		Suites:      keySuites,
+		Components:  keyComponents,
+		SourcesDir:  aptSourcesDir,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add key: %w", err)
+	}
+
+	fmt.Printf("✓ Key %s installed\n\n", resp.Name)
+	fmt.Printf("Fingerprint: %s\n", resp.Fingerprint)
+	fmt.Printf("Keyring:     %s\n", resp.KeyringPath)
+	if resp.SourcesPath != "" {
+		fmt.Printf("Repository:  %s\n", resp.SourcesPath)
+		fmt.Printf("\n💡 Next step: sudo apt update\n")
+	}
+
+	return nil
+}
+
+func runRepoKeyRemove(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	resp, err := repoApp.NewRemoveKeyUseCase(repoInfra.NewFileKeyringManager()).Execute(ctx, repoApp.RemoveKeyRequest{
+		Name: args[0],
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove key: %w", err)
+	}
+
+	fmt.Printf("✓ Key %s removed\n\n", resp.Name)
+	for _, path := range resp.FilesRemoved {
+		fmt.Printf("  removed: %s\n", path)
+	}
+
+	return nil
+}
+
+func printModifiedSources(files, backups []string) {
+	for _, path := range files {
+		fmt.Printf("Modified:       %s\n", path)
+	}
+	for _, path := range backups {
+		fmt.Printf("Backup created: %s\n", path)
+	}
+	if len(files) > 0 || len(backups) > 0 {
+		fmt.Println()
+	}
+}
+
 func formatBool(b bool) string {
 	if b {
 		return "✓"
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/rebelopsio/gohan/internal/container"
 	"github.com/spf13/cobra"
 )
 
@@ -65,6 +66,7 @@ func SetVersion(v, c, d string) {
 	version = v
 	commit = c
 	date = d
+	container.SetVersion(v)
 }
 
 // logVerbose prints verbose output if enabled
@@ -10,14 +10,14 @@ import (
 	"time"
 
 	"github.com/rebelopsio/gohan/internal/container"
-	httpinfra "github.com/rebelopsio/gohan/internal/infrastructure/http"
-	"github.com/rebelopsio/gohan/internal/infrastructure/http/handlers"
 	"github.com/spf13/cobra"
 )
 
 var (
-	serverHost string
-	serverPort int
+	serverHost     string
+	serverPort     int
+	serverCertFile string
+	serverKeyFile  string
 )
 
 // serverCmd represents the server command
@@ -29,17 +29,33 @@ management and provides a REST API for client applications.
 
 The server supports:
   - Installation management
+  - Saved configuration templates
   - Progress monitoring
   - Session persistence
   - Multiple concurrent installations
 
-Configuration can be provided via environment variables or command-line flags.`,
+Configuration can be provided via environment variables or command-line flags.
+
+Installation, configuration, backup and preflight routes can require a
+static API token, a client certificate (mutual TLS) or either, set under
+api.auth in the config; the health check and the catalog stay open:
+
+  api:
+    tls:
+      cert_file: /etc/gohan/server.crt
+      key_file: /etc/gohan/server.key
+    auth:
+      token_file: /etc/gohan/api-token
+      client_ca_file: /etc/gohan/clients-ca.crt
+      allowed_clients: [deploy-bot]`,
 	RunE: runServer,
 }
 
 func init() {
 	serverCmd.Flags().StringVar(&serverHost, "host", "0.0.0.0", "Server host")
 	serverCmd.Flags().IntVar(&serverPort, "port", 8080, "Server port")
+	serverCmd.Flags().StringVar(&serverCertFile, "cert", "", "TLS certificate file; serves HTTPS (default: api.tls.cert_file)")
+	serverCmd.Flags().StringVar(&serverKeyFile, "key", "", "TLS key file (default: api.tls.key_file)")
 }
 
 func runServer(cmd *cobra.Command, args []string) error {
@@ -57,29 +73,21 @@ func runServer(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("port") {
 		c.Config.API.Port = serverPort
 	}
+	if cmd.Flags().Changed("cert") {
+		c.Config.API.TLS.CertFile = serverCertFile
+	}
+	if cmd.Flags().Changed("key") {
+		c.Config.API.TLS.KeyFile = serverKeyFile
+	}
 
 	log.Println("Starting Gohan Installation Server...")
 	log.Printf("Configuration: Host=%s Port=%d", c.Config.API.Host, c.Config.API.Port)
 
-	// Create handlers using pre-wired use cases from container
-	installationHandler := handlers.NewInstallationHandler(
-		c.StartInstallationUseCase,
-		c.ExecuteInstallationUseCase,
-		c.GetStatusUseCase,
-		c.ListInstallationsUseCase,
-		c.CancelInstallationUseCase,
-	)
-
-	// Create HTTP server
-	serverConfig := httpinfra.Config{
-		Host:         c.Config.API.Host,
-		Port:         c.Config.API.Port,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+	server, err := c.APIServer()
+	if err != nil {
+		return err
 	}
 
-	server := httpinfra.NewServer(serverConfig, installationHandler, false)
-
 	// Start server in a goroutine
 	serverErrors := make(chan error, 1)
 	go func() {
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for sessions artifacts command
+	sessionsPathOnly bool
+
+	// Flags for sessions timeline command
+	timelineWidth int
+)
+
+// sessionsCmd represents the sessions command
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Inspect installation sessions",
+	Long: `Inspect installation sessions.
+
+Every installation works in its own directory under cache.sessions_dir
+(~/.cache/gohan/sessions, or $XDG_CACHE_HOME/gohan/sessions), holding
+the configuration files as rendered, downloads, build trees and temporary
+files. The directory is removed when the installation succeeds and kept
+when it fails, so what went wrong can be inspected.`,
+}
+
+// sessionsArtifactsCmd represents the sessions artifacts command
+var sessionsArtifactsCmd = &cobra.Command{
+	Use:   "artifacts <session-id>",
+	Short: "Locate the artifacts a failed installation kept",
+	Long: `Show where the working directory of an installation session is kept. The
+session ID may be shortened to any unique prefix.
+
+Examples:
+  # Show the directory and its size
+  gohan sessions artifacts 3f2a9c
+
+  # Open it
+  cd "$(gohan sessions artifacts 3f2a9c --path)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionsArtifacts,
+}
+
+// sessionsTimelineCmd represents the sessions timeline command
+var sessionsTimelineCmd = &cobra.Command{
+	Use:   "timeline <session-id>",
+	Short: "Show where the time of an installation went",
+	Long: `Draw a timeline of an installation session: a bar for each phase and for
+each component from when it began downloading until it was installed, on a
+shared time scale with its duration. The phase the installation failed in
+and the component it stopped on are marked with ✗. The session ID may be
+shortened to any unique prefix.
+
+Examples:
+  # Show the timeline of a session
+  gohan sessions timeline 3f2a9c
+
+  # Draw wider bars
+  gohan sessions timeline 3f2a9c --width 80`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionsTimeline,
+}
+
+func init() {
+	rootCmd.AddCommand(sessionsCmd)
+	sessionsCmd.AddCommand(sessionsArtifactsCmd)
+	sessionsCmd.AddCommand(sessionsTimelineCmd)
+
+	sessionsArtifactsCmd.Flags().BoolVar(&sessionsPathOnly, "path", false, "Print only the directory")
+	sessionsTimelineCmd.Flags().IntVar(&timelineWidth, "width", 40, "Width of the timeline bars in characters")
+}
+
+func runSessionsArtifacts(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	workspace, err := c.SessionWorkspaces.Find(args[0])
+	if err != nil {
+		return err
+	}
+
+	if sessionsPathOnly {
+		fmt.Println(workspace.Path)
+		return nil
+	}
+
+	fmt.Printf("Session:   %s\n", workspace.SessionID)
+	fmt.Printf("Artifacts: %s\n", workspace.Path)
+	fmt.Printf("Size:      %s in %d files\n", formatSize(workspace.Bytes), workspace.Files)
+	fmt.Printf("Modified:  %s\n", workspace.ModifiedAt.Format("2006-01-02 15:04:05"))
+	fmt.Println("\nRemove it with: gohan cache clean sessions")
+	return nil
+}
+
+func runSessionsTimeline(cmd *cobra.Command, args []string) error {
+	if timelineWidth < 10 {
+		return fmt.Errorf("--width must be at least 10")
+	}
+
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	timeline, err := c.GetTimelineUseCase.Execute(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Session:   %s\n", timeline.SessionID)
+	fmt.Printf("Status:    %s\n", timeline.Status)
+	fmt.Printf("Started:   %s\n", timeline.StartedAt)
+	fmt.Printf("Duration:  %s\n", formatDuration(time.Duration(timeline.DurationMs)*time.Millisecond))
+
+	if len(timeline.Phases) == 0 && len(timeline.Components) == 0 {
+		fmt.Println("\nNo timeline was recorded for this session.")
+		return nil
+	}
+
+	nameWidth := 0
+	for _, span := range append(timeline.Phases, timeline.Components...) {
+		nameWidth = max(nameWidth, len(span.Name))
+	}
+
+	printTimelineSection("Phases", timeline.Phases, timeline.DurationMs, nameWidth)
+	printTimelineSection("Components", timeline.Components, timeline.DurationMs, nameWidth)
+
+	if timeline.FailureReason != "" {
+		fmt.Printf("\n✗ Failed: %s\n", timeline.FailureReason)
+	}
+	return nil
+}
+
+// printTimelineSection draws a bar per span, placed on the session's time
+// scale, followed by its duration
+func printTimelineSection(title string, spans []dto.TimelineSpanDTO, totalMs int64, nameWidth int) {
+	if len(spans) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%s\n", title)
+	for _, span := range spans {
+		mark := ""
+		if span.Failed {
+			mark = "  ✗"
+			if span.Error != "" {
+				mark += " " + span.Error
+			}
+		}
+		fmt.Printf("  %-*s |%s| %8s%s\n", nameWidth, span.Name,
+			timelineBar(span.OffsetMs, span.DurationMs, totalMs, timelineWidth, span.Failed),
+			formatDuration(time.Duration(span.DurationMs)*time.Millisecond), mark)
+	}
+}
+
+// timelineBar draws a span as a bar of width cells scaled to the session's
+// duration. Every span gets at least one cell so short ones stay visible.
+func timelineBar(offsetMs, durationMs, totalMs int64, width int, failed bool) string {
+	start, length := 0, width
+	if totalMs > 0 {
+		start = max(min(int(offsetMs*int64(width)/totalMs), width-1), 0)
+		length = int(durationMs * int64(width) / totalMs)
+	}
+	length = max(min(length, width-start), 1)
+
+	fill := "█"
+	if failed {
+		fill = "▓"
+	}
+	return strings.Repeat(" ", start) + strings.Repeat(fill, length) + strings.Repeat(" ", width-start-length)
+}
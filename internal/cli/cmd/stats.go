@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	statsApp "github.com/rebelopsio/gohan/internal/application/stats"
+	"github.com/rebelopsio/gohan/internal/config"
+	statsRepo "github.com/rebelopsio/gohan/internal/infrastructure/stats"
+	"github.com/spf13/cobra"
+)
+
+// statsCmd represents the local usage statistics command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show local installation statistics",
+	Long: `Show statistics about installations run on this machine.
+
+Statistics are opt-in and strictly local: when enabled, gohan records only
+anonymous counters (outcome, duration, failure phase and number of
+components) in ~/.gohan/stats.db. No package names, host names or paths are
+stored, and nothing is ever transmitted.
+
+Examples:
+  # Show statistics
+  gohan stats
+
+  # Start recording statistics
+  gohan stats enable
+
+  # Stop recording (existing data is kept)
+  gohan stats disable
+
+  # Delete all recorded statistics
+  gohan stats reset`,
+	RunE: runStats,
+}
+
+var statsEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Start recording local statistics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setStatsEnabled(true)
+	},
+}
+
+var statsDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Stop recording local statistics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setStatsEnabled(false)
+	},
+}
+
+var statsResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Delete all recorded statistics",
+	RunE:  runStatsReset,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.AddCommand(statsEnableCmd)
+	statsCmd.AddCommand(statsDisableCmd)
+	statsCmd.AddCommand(statsResetCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Show existing data even when recording is off, but never create
+	// the database just to look at it
+	if _, err := os.Stat(cfg.Database.StatsDB); os.IsNotExist(err) {
+		printStatsDisabledHint(cfg.Stats.Enabled)
+		fmt.Println("No installations recorded yet.")
+		return nil
+	}
+
+	repo, err := statsRepo.NewSQLiteRepository(cfg.Database.StatsDB)
+	if err != nil {
+		return fmt.Errorf("failed to open stats database: %w", err)
+	}
+	defer repo.Close()
+
+	resp, err := statsApp.NewGetStatsUseCase(repo).Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	printStatsDisabledHint(cfg.Stats.Enabled)
+
+	if resp.TotalRuns == 0 {
+		fmt.Println("No installations recorded yet.")
+		return nil
+	}
+
+	fmt.Println("\n" + strings.Repeat("═", 60))
+	fmt.Printf("  LOCAL INSTALLATION STATISTICS\n")
+	fmt.Println(strings.Repeat("═", 60) + "\n")
+
+	fmt.Printf("Installs run:      %d\n", resp.TotalRuns)
+	fmt.Printf("Succeeded:         %d ✓\n", resp.SucceededRuns)
+	if resp.FailedRuns > 0 {
+		fmt.Printf("Failed:            %d ✗\n", resp.FailedRuns)
+	}
+	fmt.Printf("Success rate:      %.0f%%\n", resp.SuccessRate)
+	fmt.Printf("Average duration:  %s\n", formatDuration(resp.AverageDuration))
+	fmt.Printf("Components:        %d requested\n", resp.TotalComponents)
+	fmt.Printf("Period:            %s – %s\n",
+		resp.FirstRun.Local().Format("2006-01-02"), resp.LastRun.Local().Format("2006-01-02"))
+
+	if len(resp.FailurePhases) > 0 {
+		fmt.Println("\nFailures by phase:")
+		for _, p := range resp.FailurePhases {
+			fmt.Printf("  %-30s %d\n", p.Phase, p.Count)
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func printStatsDisabledHint(enabled bool) {
+	if !enabled {
+		fmt.Println("ℹ️  Local statistics are disabled. Enable with: gohan stats enable")
+		fmt.Println()
+	}
+}
+
+func setStatsEnabled(enabled bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg.Stats.Enabled = enabled
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if enabled {
+		fmt.Printf("✓ Local statistics enabled (stored in %s, never transmitted)\n", cfg.Database.StatsDB)
+	} else {
+		fmt.Println("✓ Local statistics disabled. Existing data is kept; remove it with: gohan stats reset")
+	}
+	return nil
+}
+
+func runStatsReset(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if _, err := os.Stat(cfg.Database.StatsDB); os.IsNotExist(err) {
+		fmt.Println("No statistics recorded.")
+		return nil
+	}
+
+	repo, err := statsRepo.NewSQLiteRepository(cfg.Database.StatsDB)
+	if err != nil {
+		return fmt.Errorf("failed to open stats database: %w", err)
+	}
+	defer repo.Close()
+
+	removed, err := repo.Clear(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Deleted %d recorded installation(s)\n", removed)
+	return nil
+}
@@ -61,13 +61,60 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Status:        %s\n", statusResponse.Status)
 	fmt.Printf("  Phase:         %s\n", statusResponse.CurrentPhase)
 	fmt.Printf("  Progress:      %d%%\n", statusResponse.PercentComplete)
-	fmt.Printf("  Components:    %d/%d installed\n", statusResponse.ComponentsInstalled, statusResponse.ComponentsTotal)
+	if statusResponse.ComponentsSkipped > 0 {
+		fmt.Printf("  Components:    %d/%d installed, %d skipped\n",
+			statusResponse.ComponentsInstalled, statusResponse.ComponentsTotal, statusResponse.ComponentsSkipped)
+	} else {
+		fmt.Printf("  Components:    %d/%d installed\n", statusResponse.ComponentsInstalled, statusResponse.ComponentsTotal)
+	}
 	if statusResponse.EstimatedRemaining != "0s" {
 		fmt.Printf("  Est. Time:     %s\n", statusResponse.EstimatedRemaining)
 	}
 	if statusResponse.Message != "" {
 		fmt.Printf("  Message:       %s\n", statusResponse.Message)
 	}
+	if statusResponse.WarningsCount > 0 {
+		fmt.Printf("  Warnings:      %d\n", statusResponse.WarningsCount)
+		for _, w := range statusResponse.Warnings {
+			fmt.Printf("    - [%s] %s\n", w.Source, w.Message)
+		}
+	}
+	if statusResponse.RebootRequired {
+		fmt.Println("  Reboot:        required")
+		for _, reason := range statusResponse.RebootReasons {
+			fmt.Printf("    - %s\n", reason)
+		}
+	}
+	if len(statusResponse.Components) > 0 {
+		fmt.Println("  Component states:")
+		for _, c := range statusResponse.Components {
+			switch {
+			case c.Error != "":
+				fmt.Printf("    - %-16s %s: %s\n", c.Name, c.State, c.Error)
+			case c.SkipReason != "":
+				fmt.Printf("    - %-16s %s (%s): %s\n", c.Name, c.State, c.SkipReason, c.SkipDetail)
+			case c.VerifyDuration != "":
+				fmt.Printf("    - %-16s %s (checked in %s)\n", c.Name, c.State, c.VerifyDuration)
+			default:
+				fmt.Printf("    - %-16s %s\n", c.Name, c.State)
+			}
+		}
+	}
+	if len(statusResponse.Conflicts) > 0 {
+		fmt.Printf("  Conflicts:     %d\n", len(statusResponse.Conflicts))
+		for _, c := range statusResponse.Conflicts {
+			fmt.Printf("    - %s conflicts with %s: %s\n", c.PackageName, c.ConflictingPackage, c.Strategy)
+		}
+	}
+	if statusResponse.StartedAt != "" {
+		fmt.Printf("  Started:       %s\n", statusResponse.StartedAt)
+	}
+	if statusResponse.UpdatedAt != "" {
+		fmt.Printf("  Updated:       %s\n", statusResponse.UpdatedAt)
+	}
+	if statusResponse.CompletedAt != "" {
+		fmt.Printf("  Completed:     %s\n", statusResponse.CompletedAt)
+	}
 
 	return nil
 }
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	tea "github.com/charmbracelet/bubbletea"
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+	"github.com/rebelopsio/gohan/internal/container"
+	templateTUI "github.com/rebelopsio/gohan/internal/tui/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for template create command
+	templateComponents   []string
+	templateCategory     string
+	templateTags         []string
+	templateDescription  string
+	templateAuthor       string
+	templateGPURequired  bool
+	templateDiskRequired uint64
+
+	// Flags for template list command
+	templateListCategory string
+	templateListTag      string
+
+	// Flags for template search command
+	templateSearchCategory string
+	templateSearchTags     []string
+
+	// Flags for template tag command
+	templateAddTags    []string
+	templateRemoveTags []string
+)
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage saved configuration templates",
+	Long: `Save, list, inspect and remove configuration templates.
+
+A template names a set of components with their versions, so a setup can be
+saved once and reused. Templates are kept in ~/.gohan/configurations.db
+(database.configuration_db) and are also available from the API server.`,
+}
+
+// templateCreateCmd represents the template create command
+var templateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Save a configuration template",
+	Long: `Save a configuration template. Names are unique and every template must
+include hyprland.
+
+Components are given as name or name@version; without a version the latest
+available version is used.
+
+Examples:
+  gohan template create laptop --components hyprland,waybar,kitty --tag minimal
+
+  gohan template create work --components hyprland@0.41.2,waybar \
+    --category development --description "Work laptop"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateCreate,
+}
+
+// templateListCmd represents the template list command
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configuration templates",
+	Long: `List saved configuration templates, most recent first.
+
+Examples:
+  gohan template list
+  gohan template list --category development
+  gohan template list --tag laptop`,
+	RunE: runTemplateList,
+}
+
+// templateSearchCmd represents the template search command
+var templateSearchCmd = &cobra.Command{
+	Use:   "search [text]",
+	Short: "Search configuration templates",
+	Long: `Search configuration templates by name, category and tags at once. The
+text matches part of a template name, ignoring case; a template must carry
+every --tag given.
+
+Examples:
+  gohan template search laptop
+  gohan template search --tag laptop --tag minimal --category development`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplateSearch,
+}
+
+// templateBrowseCmd represents the template browse command
+var templateBrowseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Browse configuration templates interactively",
+	Long: `Launch an interactive terminal UI to search and inspect configuration
+templates.
+
+Type to search: #tag requires a tag, @category selects a category and other
+words match template names.
+
+Navigation:
+  ↑/↓: Move
+  Enter: View details
+  Esc: Back, clear the search, or quit`,
+	RunE: runTemplateBrowse,
+}
+
+// templateShowCmd represents the template show command
+var templateShowCmd = &cobra.Command{
+	Use:   "show <name|id>",
+	Short: "Show a configuration template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateShow,
+}
+
+// templateDeleteCmd represents the template delete command
+var templateDeleteCmd = &cobra.Command{
+	Use:   "delete <name|id>",
+	Short: "Delete a configuration template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateDelete,
+}
+
+// templateTagCmd represents the template tag command
+var templateTagCmd = &cobra.Command{
+	Use:   "tag <name|id>",
+	Short: "Add or remove tags on a configuration template",
+	Long: `Add or remove tags on a configuration template. Removals are applied after
+additions.
+
+Example:
+  gohan template tag laptop --add battery --remove old`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateTag,
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateCreateCmd)
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateSearchCmd)
+	templateCmd.AddCommand(templateBrowseCmd)
+	templateCmd.AddCommand(templateShowCmd)
+	templateCmd.AddCommand(templateDeleteCmd)
+	templateCmd.AddCommand(templateTagCmd)
+
+	// Flags for create command
+	templateCreateCmd.Flags().StringSliceVar(&templateComponents, "components", nil, "Components to include (name or name@version, comma-separated)")
+	templateCreateCmd.Flags().StringVar(&templateCategory, "category", "", "Category (development/production/testing/custom, default: custom)")
+	templateCreateCmd.Flags().StringSliceVar(&templateTags, "tag", nil, "Tags (repeatable or comma-separated)")
+	templateCreateCmd.Flags().StringVar(&templateDescription, "description", "", "Description")
+	templateCreateCmd.Flags().StringVar(&templateAuthor, "author", "", "Author")
+	templateCreateCmd.Flags().BoolVar(&templateGPURequired, "gpu-required", false, "Require a GPU")
+	templateCreateCmd.Flags().Uint64Var(&templateDiskRequired, "disk-required", 0, "Disk space required in bytes")
+	_ = templateCreateCmd.MarkFlagRequired("components")
+
+	// Flags for list command
+	templateListCmd.Flags().StringVar(&templateListCategory, "category", "", "Filter by category")
+	templateListCmd.Flags().StringVar(&templateListTag, "tag", "", "Filter by tag")
+
+	// Flags for search command
+	templateSearchCmd.Flags().StringVar(&templateSearchCategory, "category", "", "Filter by category")
+	templateSearchCmd.Flags().StringSliceVar(&templateSearchTags, "tag", nil, "Required tags (repeatable or comma-separated)")
+
+	// Flags for tag command
+	templateTagCmd.Flags().StringSliceVar(&templateAddTags, "add", nil, "Tags to add")
+	templateTagCmd.Flags().StringSliceVar(&templateRemoveTags, "remove", nil, "Tags to remove")
+}
+
+func runTemplateCreate(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	components := make([]configApp.TemplateComponent, 0, len(templateComponents))
+	for _, spec := range templateComponents {
+		name, version, _ := strings.Cut(spec, "@")
+		components = append(components, configApp.TemplateComponent{Name: name, Version: version})
+	}
+
+	template, err := c.CreateTemplateUseCase.Execute(context.Background(), configApp.CreateTemplateRequest{
+		Name:              args[0],
+		Description:       templateDescription,
+		Author:            templateAuthor,
+		Category:          templateCategory,
+		Tags:              templateTags,
+		Components:        components,
+		DiskRequiredBytes: templateDiskRequired,
+		GPURequired:       templateGPURequired,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	fmt.Printf("✓ Saved template %s (%s)\n", template.Name, template.ID)
+	return nil
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	response, err := c.ListTemplatesUseCase.Execute(context.Background(), configApp.ListTemplatesRequest{
+		Category: templateListCategory,
+		Tag:      templateListTag,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	return printTemplateTable(response.Templates)
+}
+
+func runTemplateSearch(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	request := configApp.SearchTemplatesRequest{
+		Category: templateSearchCategory,
+		Tags:     templateSearchTags,
+	}
+	if len(args) > 0 {
+		request.Query = args[0]
+	}
+
+	response, err := c.SearchTemplatesUseCase.Execute(context.Background(), request)
+	if err != nil {
+		return fmt.Errorf("failed to search templates: %w", err)
+	}
+
+	return printTemplateTable(response.Templates)
+}
+
+func runTemplateBrowse(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	browser := templateTUI.NewBrowser(c.SearchTemplatesUseCase)
+	p := tea.NewProgram(browser, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("failed to run browser: %w", err)
+	}
+	return nil
+}
+
+// printTemplateTable prints templates one per line
+func printTemplateTable(templates []configApp.TemplateDTO) error {
+	if len(templates) == 0 {
+		fmt.Println("No templates found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCATEGORY\tCOMPONENTS\tTAGS\tCREATED")
+	for _, template := range templates {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+			template.Name,
+			template.Category,
+			len(template.Components),
+			strings.Join(template.Tags, ","),
+			template.CreatedAt,
+		)
+	}
+	return w.Flush()
+}
+
+func runTemplateShow(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	template, err := c.ShowTemplateUseCase.Execute(context.Background(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to show template: %w", err)
+	}
+
+	printTemplate(template)
+	return nil
+}
+
+func runTemplateDelete(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	if err := c.DeleteTemplateUseCase.Execute(context.Background(), args[0]); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	fmt.Printf("✓ Deleted template %s\n", args[0])
+	return nil
+}
+
+func runTemplateTag(cmd *cobra.Command, args []string) error {
+	if len(templateAddTags) == 0 && len(templateRemoveTags) == 0 {
+		return fmt.Errorf("nothing to change: use --add or --remove")
+	}
+
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	template, err := c.TagTemplateUseCase.Execute(context.Background(), configApp.TagTemplateRequest{
+		Template: args[0],
+		Add:      templateAddTags,
+		Remove:   templateRemoveTags,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag template: %w", err)
+	}
+
+	fmt.Printf("✓ %s tags: %s\n", template.Name, strings.Join(template.Tags, ", "))
+	return nil
+}
+
+// printTemplate prints the details of a template
+func printTemplate(template *configApp.TemplateDTO) {
+	fmt.Printf("Name:        %s\n", template.Name)
+	fmt.Printf("ID:          %s\n", template.ID)
+	fmt.Printf("Category:    %s\n", template.Category)
+	if template.Description != "" {
+		fmt.Printf("Description: %s\n", template.Description)
+	}
+	if template.Author != "" {
+		fmt.Printf("Author:      %s\n", template.Author)
+	}
+	if len(template.Tags) > 0 {
+		fmt.Printf("Tags:        %s\n", strings.Join(template.Tags, ", "))
+	}
+	fmt.Printf("Created:     %s (version %d)\n", template.CreatedAt, template.Version)
+	if template.GPURequired {
+		fmt.Println("GPU:         required")
+	}
+	if template.DiskRequiredBytes > 0 {
+		fmt.Printf("Disk:        %d bytes required\n", template.DiskRequiredBytes)
+	}
+
+	fmt.Println("\nComponents:")
+	for _, comp := range template.Components {
+		fmt.Printf("  %-20s %s\n", comp.Name, comp.Version)
+	}
+}
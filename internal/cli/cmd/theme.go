@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	tea "github.com/charmbracelet/bubbletea"
 	themeApp "github.com/rebelopsio/gohan/internal/application/theme"
+	"github.com/rebelopsio/gohan/internal/config"
 	"github.com/rebelopsio/gohan/internal/container"
 	"github.com/rebelopsio/gohan/internal/domain/theme"
 	themeInfra "github.com/rebelopsio/gohan/internal/infrastructure/theme"
@@ -101,19 +103,21 @@ Examples:
 
 // themeSetCmd applies a theme
 var themeSetCmd = &cobra.Command{
-	Use:   "set <theme-name>",
-	Short: "Apply a theme",
+	Use:     "set <theme-name>",
+	Aliases: []string{"apply"},
+	Short:   "Apply a theme",
 	Long: `Apply a theme to your desktop environment.
 
-This will update configuration files for Hyprland, Waybar, Kitty, and other
-components to use the selected theme.
+This will re-render and redeploy the configuration files for Hyprland, Waybar,
+Kitty, and other components with the selected theme's palette, including any
+colors the theme sets for a single component.
 
 Examples:
   # Apply the latte theme
   gohan theme set latte
 
-  # Apply the mocha theme
-  gohan theme set mocha`,
+  # Apply the nord theme
+  gohan theme apply nord`,
 	Args: cobra.ExactArgs(1),
 	RunE: runThemeSet,
 }
@@ -138,9 +142,40 @@ Examples:
 	RunE: runThemeRollback,
 }
 
+// themeImportCmd imports a custom theme from a palette file
+var themeImportCmd = &cobra.Command{
+	Use:   "import <palette-file>",
+	Short: "Import a custom theme",
+	Long: `Import a custom theme from a TOML or JSON palette file.
+
+The palette sets base, text and accent colors, and optionally any other
+palette color. The theme is stored alongside the standard themes, so it can
+be previewed, picked and applied the same way. Importing a file again
+replaces the theme of the same name.
+
+Example palette (mytheme.toml):
+  name = "solarized"
+  display_name = "Solarized Dark"
+  variant = "dark"
+
+  [colors]
+  base = "#002b36"
+  text = "#839496"
+  accent = "#268bd2"
+  red = "#dc322f"
+
+Examples:
+  # Import a theme and apply it
+  gohan theme import ./mytheme.toml
+  gohan theme set solarized`,
+	Args: cobra.ExactArgs(1),
+	RunE: runThemeImport,
+}
+
 var (
 	variantFilter string
 	verboseOutput bool
+	themeNoReload bool
 )
 
 func init() {
@@ -151,12 +186,17 @@ func init() {
 	themeCmd.AddCommand(themePickCmd)
 	themeCmd.AddCommand(themeSetCmd)
 	themeCmd.AddCommand(themeRollbackCmd)
+	themeCmd.AddCommand(themeImportCmd)
 
 	// Flags for list command
 	themeListCmd.Flags().StringVar(&variantFilter, "variant", "", "Filter by variant (dark or light)")
 
 	// Flags for show command
 	themeShowCmd.Flags().BoolVarP(&verboseOutput, "verbose", "v", false, "Show detailed color information")
+
+	// Flags for set and rollback commands
+	themeSetCmd.Flags().BoolVar(&themeNoReload, "no-reload", false, "Don't reload running components")
+	themeRollbackCmd.Flags().BoolVar(&themeNoReload, "no-reload", false, "Don't reload running components")
 }
 
 func runThemeList(cmd *cobra.Command, args []string) error {
@@ -204,12 +244,16 @@ func runThemeList(cmd *cobra.Command, args []string) error {
 		}
 
 		variantDisplay := fmt.Sprintf("(%s)", t.Variant)
+		author := t.Author
+		if t.Custom && author != "custom" {
+			author += ", custom"
+		}
 		fmt.Fprintf(w, "  %s %s\t%s\t%s\t[%s]\n",
 			activeMarker,
 			t.Name,
 			t.DisplayName,
 			variantDisplay,
-			t.Author)
+			author)
 	}
 	w.Flush()
 
@@ -361,7 +405,8 @@ func runThemePick(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create use case with real theme applier, state store, and history store
-	applyUseCase := themeApp.NewApplyThemeUseCase(registry, c.ThemeApplier, c.ThemeStateStore, c.ThemeHistoryStore)
+	applyUseCase := themeApp.NewApplyThemeUseCase(registry, c.ThemeApplier, c.ThemeStateStore, c.ThemeHistoryStore).
+		WithReloadService(c.ThemeReloadService)
 
 	// Execute - convert ThemeName to string
 	result, err := applyUseCase.Execute(ctx, string(selected.Name()))
@@ -376,6 +421,7 @@ func runThemePick(cmd *cobra.Command, args []string) error {
 	fmt.Println("  - Waybar configuration")
 	fmt.Println("  - Kitty terminal colors")
 	fmt.Println("  - Rofi/Fuzzel theme")
+	printReloadedComponents(result.ReloadedComponents)
 
 	return nil
 }
@@ -405,6 +451,9 @@ func runThemeSet(cmd *cobra.Command, args []string) error {
 
 	// Create use case with real theme applier, state store, and history store from container
 	applyUseCase := themeApp.NewApplyThemeUseCase(registry, c.ThemeApplier, c.ThemeStateStore, c.ThemeHistoryStore)
+	if !themeNoReload {
+		applyUseCase = applyUseCase.WithReloadService(c.ThemeReloadService)
+	}
 
 	// Execute
 	fmt.Printf("Applying theme '%s'...\n", themeName)
@@ -422,6 +471,7 @@ func runThemeSet(cmd *cobra.Command, args []string) error {
 		fmt.Println("  - Waybar configuration")
 		fmt.Println("  - Kitty terminal colors")
 		fmt.Println("  - Rofi/Fuzzel theme")
+		printReloadedComponents(result.ReloadedComponents)
 		fmt.Println("\nBackups have been created. Use 'gohan theme rollback' to restore previous theme.")
 		fmt.Println()
 	}
@@ -429,6 +479,49 @@ func runThemeSet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runThemeImport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	def, err := themeInfra.LoadPaletteFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Initialize dependency container
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	// Initialize theme registry with saved state
+	registry, err := initializeThemeRegistry(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := themeApp.NewImportThemeUseCase(registry, c.ThemeRepo).Execute(ctx, def)
+	if err != nil {
+		return fmt.Errorf("failed to import theme: %w", err)
+	}
+
+	verb := "Imported"
+	if result.Replaced {
+		verb = "Replaced"
+	}
+	fmt.Printf("\n✓ %s theme '%s' (%s, %s)\n", verb, result.Theme.Name, result.Theme.DisplayName, result.Theme.Variant)
+	fmt.Printf("\nUse 'gohan theme set %s' to apply it\n", result.Theme.Name)
+	return nil
+}
+
+// printReloadedComponents lists the running components that show the new
+// theme already
+func printReloadedComponents(components []string) {
+	if len(components) > 0 {
+		fmt.Printf("Reloaded: %s\n", strings.Join(components, ", "))
+	}
+}
+
 // loadSavedThemeState loads the saved theme state and sets it as active
 func loadSavedThemeState(ctx context.Context, registry theme.ThemeRegistry, stateStore themeInfra.ThemeStateStore) error {
 	// Check if state exists
@@ -492,6 +585,9 @@ func runThemeRollback(cmd *cobra.Command, args []string) error {
 		c.ThemeApplier,
 		c.ThemeStateStore,
 	)
+	if !themeNoReload {
+		rollbackUseCase = rollbackUseCase.WithReloadService(c.ThemeReloadService)
+	}
 
 	// Execute
 	fmt.Println("Rolling back to previous theme...")
@@ -513,6 +609,7 @@ func runThemeRollback(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Restored theme: %s\n", result.RestoredTheme)
 		fmt.Printf("Previous theme: %s\n", result.PreviousTheme)
 		fmt.Println("\nConfiguration files have been updated.")
+		printReloadedComponents(result.ReloadedComponents)
 		fmt.Println("Use 'gohan theme rollback' again to continue rolling back through history.")
 		fmt.Println()
 	}
@@ -528,8 +625,32 @@ func initializeThemeRegistry(ctx context.Context) (theme.ThemeRegistry, error) {
 	}
 
 	// Load saved state if exists
+	dbPath := config.DefaultConfig().Database.ThemeDB
+	if cfg, err := config.Load(); err == nil && cfg.Database.ThemeDB != "" {
+		dbPath = cfg.Database.ThemeDB
+	}
+	stateStore, err := themeInfra.NewSQLiteRepository(dbPath)
+	if err != nil {
+		// No database yet - use default theme
+		return registry, nil
+	}
+	defer stateStore.Close()
+
 	stateFilePath, _ := themeInfra.GetDefaultStateFilePath()
-	stateStore := themeInfra.NewFileThemeStateStore(stateFilePath)
+	historyFilePath, _ := themeInfra.GetDefaultHistoryFilePath()
+	_ = stateStore.ImportLegacy(ctx,
+		themeInfra.NewFileThemeStateStore(stateFilePath),
+		themeInfra.NewFileThemeHistoryStore(historyFilePath),
+	)
+
+	// Custom themes come before the saved state, which may name one
+	customThemes, err := stateStore.ListCustomThemes(ctx)
+	if err == nil {
+		err = theme.RegisterCustomThemes(registry, customThemes)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load custom themes: %v\n", err)
+	}
 
 	if err := loadSavedThemeState(ctx, registry, stateStore); err != nil {
 		// Don't fail - just use default theme
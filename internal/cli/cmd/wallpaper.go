@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	wallpaperApp "github.com/rebelopsio/gohan/internal/application/wallpaper"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for wallpaper set command
+	wallpaperMonitor string
+	wallpaperRotate  time.Duration
+)
+
+// wallpaperCmd groups the wallpaper commands
+var wallpaperCmd = &cobra.Command{
+	Use:   "wallpaper",
+	Short: "Change the desktop wallpaper",
+}
+
+// wallpaperSetCmd sets the wallpaper of every monitor or one of them
+var wallpaperSetCmd = &cobra.Command{
+	Use:   "set <image|dir>",
+	Short: "Set the wallpaper, per monitor or rotating through a directory",
+	Long: `Set the wallpaper shown by hyprpaper or swaybg, whichever is installed.
+
+The image is copied to ~/.config/gohan/wallpaper.jpg, which hyprlock shows
+too, or to ~/.config/gohan/wallpapers/<monitor>.jpg with --monitor. Monitors
+without their own wallpaper show the default one. For hyprpaper,
+~/.config/hypr/hyprpaper.conf is rendered; for swaybg, the exec-once line in
+~/.config/hypr/autostart.conf is. The daemon is then restarted in the running
+Hyprland session.
+
+Given a directory, one of its images is picked at random. With --rotate, a
+systemd user timer (gohan-wallpaper.timer) picks another every interval;
+setting a wallpaper for every monitor again stops the rotation.
+
+Examples:
+  # Set the wallpaper of every monitor
+  gohan wallpaper set ~/Pictures/mountains.jpg
+
+  # Give the second monitor its own wallpaper
+  gohan wallpaper set ~/Pictures/forest.png --monitor HDMI-A-1
+
+  # Show a different image from a directory every 30 minutes
+  gohan wallpaper set ~/Pictures/wallpapers --rotate 30m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWallpaperSet,
+}
+
+// wallpaperNextCmd moves the rotation on, as the timer does
+var wallpaperNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the next wallpaper of the rotation",
+	Long: `Show another image from the directory set with gohan wallpaper set --rotate.
+
+This is what gohan-wallpaper.service runs; it can also be run by hand or
+bound to a key.`,
+	Args: cobra.NoArgs,
+	RunE: runWallpaperNext,
+}
+
+func init() {
+	rootCmd.AddCommand(wallpaperCmd)
+	wallpaperCmd.AddCommand(wallpaperSetCmd)
+	wallpaperCmd.AddCommand(wallpaperNextCmd)
+
+	wallpaperSetCmd.Flags().StringVar(&wallpaperMonitor, "monitor", wallpaper.AllMonitors, "Monitor to set the wallpaper of, such as DP-1 (default every monitor)")
+	wallpaperSetCmd.Flags().DurationVar(&wallpaperRotate, "rotate", 0, "Pick another image from the directory at this interval, such as 30m")
+}
+
+func runWallpaperSet(cmd *cobra.Command, args []string) error {
+	var executable string
+	if wallpaperRotate > 0 {
+		if os.Geteuid() == 0 {
+			return fmt.Errorf("the rotation timer cannot be installed as root, run without sudo")
+		}
+		var err error
+		if executable, err = os.Executable(); err != nil {
+			return fmt.Errorf("failed to locate the gohan binary: %w", err)
+		}
+		if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+			executable = resolved
+		}
+	}
+
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	response, err := c.SetWallpaperUseCase.Execute(context.Background(), wallpaperApp.SetWallpaperRequest{
+		Path:       args[0],
+		Monitor:    wallpaperMonitor,
+		Rotate:     wallpaperRotate,
+		Executable: executable,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set wallpaper: %w", err)
+	}
+
+	target := "every monitor"
+	if response.Monitor != wallpaper.AllMonitors {
+		target = response.Monitor
+	}
+	fmt.Printf("✓ %s set as the wallpaper of %s (%s)\n", response.Image, target, response.Tool)
+	if response.Rotate > 0 {
+		fmt.Printf("  Rotating every %s: systemctl --user list-timers %s\n", response.Rotate, wallpaper.RotationTimerName)
+	}
+	printWallpaperReload(response.Tool, response.Reloaded)
+	return nil
+}
+
+func runWallpaperNext(cmd *cobra.Command, args []string) error {
+	c, err := container.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer c.Close()
+
+	response, err := c.NextWallpaperUseCase.Execute(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to change wallpaper: %w", err)
+	}
+
+	fmt.Printf("✓ Showing %s\n", response.Image)
+	printWallpaperReload(response.Tool, response.Reloaded)
+	return nil
+}
+
+// printWallpaperReload tells when a wallpaper takes effect
+func printWallpaperReload(tool wallpaper.Tool, reloaded bool) {
+	if !reloaded {
+		fmt.Printf("  No Hyprland session found; %s shows it at next login\n", tool)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -21,6 +22,48 @@ type Config struct {
 
 	// Logging settings
 	Logging LoggingConfig `yaml:"logging"`
+
+	// Local usage statistics
+	Stats StatsConfig `yaml:"stats"`
+
+	// Per-operation timeouts for external commands
+	Timeouts TimeoutsConfig `yaml:"timeouts"`
+
+	// Frontend that installs and removes packages: apt or nala
+	PackageManager string `yaml:"package_manager"`
+
+	// Options for apt-get and apt-cache
+	Apt AptConfig `yaml:"apt"`
+
+	// Modes of deployed configuration files
+	Permissions PermissionsConfig `yaml:"permissions"`
+
+	// Preflight check policy
+	Preflight PreflightConfig `yaml:"preflight"`
+
+	// Download caches
+	Cache CacheConfig `yaml:"cache"`
+
+	// Accessibility options for rendered configuration
+	Accessibility AccessibilityConfig `yaml:"accessibility"`
+
+	// Waybar weather module
+	Weather WeatherConfig `yaml:"weather"`
+
+	// Screen color temperature of the night_light component
+	NightLight NightLightConfig `yaml:"night_light"`
+
+	// hyprlock and swaylock screens
+	LockScreen LockScreenConfig `yaml:"lock_screen"`
+
+	// Installation lifecycle notifications
+	Webhooks WebhooksConfig `yaml:"webhooks"`
+
+	// Commands run before and after installing and deploying
+	Hooks HooksConfig `yaml:"hooks"`
+
+	// The user's copies of gohan's configuration templates
+	Templates TemplatesConfig `yaml:"templates"`
 }
 
 // DatabaseConfig holds database configuration
@@ -28,8 +71,23 @@ type DatabaseConfig struct {
 	// History database path
 	HistoryDB string `yaml:"history_db"`
 
+	// System-wide history database path, used when running as root
+	SystemHistoryDB string `yaml:"system_history_db"`
+
 	// Installation session database path
 	InstallationDB string `yaml:"installation_db"`
+
+	// Local usage statistics database path
+	StatsDB string `yaml:"stats_db"`
+
+	// Preflight validation session database path
+	PreflightDB string `yaml:"preflight_db"`
+
+	// Saved configuration template database path
+	ConfigurationDB string `yaml:"configuration_db"`
+
+	// Active theme and theme history database path
+	ThemeDB string `yaml:"theme_db"`
 }
 
 // APIConfig holds API server configuration
@@ -42,6 +100,38 @@ type APIConfig struct {
 
 	// Enable CORS
 	EnableCORS bool `yaml:"enable_cors"`
+
+	// Serve HTTPS with this certificate and key
+	TLS APITLSConfig `yaml:"tls"`
+
+	// How clients authenticate to the installation, configuration and
+	// backup routes
+	Auth APIAuthConfig `yaml:"auth"`
+}
+
+// APITLSConfig holds the API server's certificate
+type APITLSConfig struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// APIAuthConfig holds how API clients authenticate. With neither a token
+// nor a client CA configured, the API is open; with both, either
+// credential is accepted.
+type APIAuthConfig struct {
+	// Static token clients send as "Authorization: Bearer <token>"
+	Token string `yaml:"token"`
+
+	// File holding the token, so it stays out of the configuration file
+	TokenFile string `yaml:"token_file"`
+
+	// CA client certificates are verified against, for mutual TLS;
+	// requires tls.cert_file and tls.key_file
+	ClientCAFile string `yaml:"client_ca_file"`
+
+	// Common or DNS names of the client certificates accepted; empty
+	// accepts any certificate the CA signed
+	AllowedClients []string `yaml:"allowed_clients"`
 }
 
 // InstallationConfig holds installation-specific settings
@@ -57,6 +147,24 @@ type InstallationConfig struct {
 
 	// Dry-run mode (no actual package installation)
 	DryRun bool `yaml:"dry_run"`
+
+	// Priority package operations run with under --background
+	Background BackgroundConfig `yaml:"background"`
+}
+
+// BackgroundConfig holds the CPU and IO priority apt and dpkg run with when
+// installing with --background
+type BackgroundConfig struct {
+	// nice value, 0 to 19
+	Nice int `yaml:"nice"`
+
+	// ionice best-effort level, 0 to 7
+	IOLevel int `yaml:"io_level"`
+
+	// CPU and IO weights of the systemd scope used when running as root,
+	// 1 to 10000 against a default of 100
+	CPUWeight int `yaml:"cpu_weight"`
+	IOWeight  int `yaml:"io_weight"`
 }
 
 // LoggingConfig holds logging configuration
@@ -68,15 +176,194 @@ type LoggingConfig struct {
 	File string `yaml:"file"`
 }
 
+// StatsConfig holds local usage statistics settings
+type StatsConfig struct {
+	// Record anonymous install counters in the local stats database.
+	// Off by default; nothing is ever sent anywhere.
+	Enabled bool `yaml:"enabled"`
+}
+
+// TimeoutsConfig bounds how long external commands may run before they are
+// cancelled, so a stuck apt lock or unreachable mirror cannot hang a session.
+// Durations use Go syntax ("30m", "45s"); 0 disables the bound.
+type TimeoutsConfig struct {
+	// apt-get install, download and remove
+	PackageInstall time.Duration `yaml:"package_install"`
+
+	// apt-get update
+	PackageCacheUpdate time.Duration `yaml:"package_cache_update"`
+
+	// dpkg-query and apt-cache lookups
+	PackageQuery time.Duration `yaml:"package_query"`
+
+	// systemctl calls made after installation
+	ServiceCommand time.Duration `yaml:"service_command"`
+}
+
+// AptConfig holds options added to gohan's apt-get and apt-cache calls.
+// apt.conf and apt.conf.d are read as usual; these apply on top of them.
+type AptConfig struct {
+	// Configuration items passed with -o, from name (such as
+	// Acquire::http::Proxy) to value
+	Options map[string]string `yaml:"options"`
+}
+
+// PermissionsConfig controls the modes of deployed configuration files and
+// the directories created for them
+type PermissionsConfig struct {
+	// Clear the process umask bits from file and directory modes
+	RespectUmask bool `yaml:"respect_umask"`
+
+	// Write sensitive files such as hyprlock.conf as 0600 inside a 0700
+	// directory
+	StrictSensitive bool `yaml:"strict_sensitive"`
+}
+
+// PreflightConfig holds preflight check settings
+type PreflightConfig struct {
+	// Per-requirement severity overrides, from requirement name (such as
+	// source_repositories or gpu_support) to blocker, warning or ignore
+	Severities map[string]string `yaml:"severities"`
+}
+
+// CacheConfig holds the locations of gohan's download caches
+type CacheConfig struct {
+	// Sources and artifacts downloaded to build components
+	BuildDir string `yaml:"build_dir"`
+
+	// Configuration templates fetched from a template registry
+	TemplateRegistryDir string `yaml:"template_registry_dir"`
+
+	// Per-session working directories; a failed installation's is kept
+	// for debugging
+	SessionsDir string `yaml:"sessions_dir"`
+
+	// Clean the caches before installing when the disk space check finds a
+	// partition close to its requirement
+	AutoClean bool `yaml:"auto_clean"`
+}
+
+// AccessibilityConfig holds the accessibility options used when none are
+// given on the command line
+type AccessibilityConfig struct {
+	// Turn off animations, blur and shadows
+	ReducedMotion bool `yaml:"reduced_motion"`
+
+	// Larger fonts in Hyprland, Waybar and the terminal, and a larger cursor
+	LargeText bool `yaml:"large_text"`
+
+	// White on black colors with yellow focus borders
+	HighContrast bool `yaml:"high_contrast"`
+}
+
+// WeatherConfig holds the Waybar weather module settings
+type WeatherConfig struct {
+	// Add a weather module to Waybar
+	Enabled bool `yaml:"enabled"`
+
+	// City to report on; empty takes the city of the system timezone
+	City string `yaml:"city"`
+}
+
+// NightLightConfig holds the night light settings
+type NightLightConfig struct {
+	// Location sunrise and sunset are computed for, in degrees north and
+	// east; both zero takes the coordinates of the system timezone
+	Latitude  float64 `yaml:"latitude"`
+	Longitude float64 `yaml:"longitude"`
+}
+
+// LockScreenConfig holds the lock screen settings
+type LockScreenConfig struct {
+	// screenshot (blurred) or image
+	Background string `yaml:"background"`
+
+	// Image behind an image background; empty takes the wallpaper
+	Image string `yaml:"image"`
+
+	// Image shown above the password field; empty shows none
+	Avatar string `yaml:"avatar"`
+
+	// 24h or 12h
+	Clock string `yaml:"clock"`
+
+	// Clock font size; 0 takes the default
+	ClockSize int `yaml:"clock_size"`
+}
+
+// WebhooksConfig holds the endpoints told when an installation starts,
+// completes or fails
+type WebhooksConfig struct {
+	// URLs receiving a JSON POST for each event; empty sends nothing
+	URLs []string `yaml:"urls"`
+
+	// Key signing each body with HMAC-SHA256 in the X-Gohan-Signature
+	// header; empty sends unsigned bodies
+	Secret string `yaml:"secret"`
+
+	// Deliveries tried per URL before giving up
+	Attempts int `yaml:"attempts"`
+
+	// Delay before the first retry, doubled after each further failure
+	Backoff time.Duration `yaml:"backoff"`
+
+	// Time allowed for each delivery
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// HooksConfig holds shell commands run at points of an installation or
+// configuration deployment, each with sh -c and GOHAN_* variables
+// describing the session
+type HooksConfig struct {
+	// After preflight checks pass, before packages are installed; a
+	// failing command fails the installation
+	PreInstall []string `yaml:"pre_install"`
+
+	// After a successful installation is verified
+	PostInstall []string `yaml:"post_install"`
+
+	// Before configuration files are written; a failing command stops the
+	// deployment
+	PreDeploy []string `yaml:"pre_deploy"`
+
+	// After configuration files are written
+	PostDeploy []string `yaml:"post_deploy"`
+
+	// Time each command may run; 0 disables the bound
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Directory holding each session's hook output as <session-id>.log
+	LogDir string `yaml:"log_dir"`
+}
+
+// TemplatesConfig holds where the user keeps their own copies of gohan's
+// configuration templates
+type TemplatesConfig struct {
+	// Copies used in place of the shipped templates, laid out like the
+	// templates directory: kitty/kitty.conf.tmpl replaces
+	// templates/kitty/kitty.conf.tmpl
+	OverrideDir string `yaml:"override_dir"`
+}
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	homeDir, _ := os.UserHomeDir()
 	gohanDir := filepath.Join(homeDir, ".gohan")
+	cacheDir := filepath.Join(homeDir, ".cache", "gohan")
+	if userCacheDir, err := os.UserCacheDir(); err == nil {
+		// Honors XDG_CACHE_HOME
+		cacheDir = filepath.Join(userCacheDir, "gohan")
+	}
 
 	return &Config{
 		Database: DatabaseConfig{
-			HistoryDB:      filepath.Join(gohanDir, "history.db"),
-			InstallationDB: filepath.Join(gohanDir, "installations.db"),
+			HistoryDB:       filepath.Join(gohanDir, "history.db"),
+			SystemHistoryDB: "/var/lib/gohan/history.db",
+			InstallationDB:  filepath.Join(gohanDir, "installations.db"),
+			StatsDB:         filepath.Join(gohanDir, "stats.db"),
+			PreflightDB:     filepath.Join(gohanDir, "preflight.db"),
+			ConfigurationDB: filepath.Join(gohanDir, "configurations.db"),
+			ThemeDB:         filepath.Join(gohanDir, "themes.db"),
 		},
 		API: APIConfig{
 			Host:       "localhost",
@@ -87,11 +374,49 @@ func DefaultConfig() *Config {
 			SnapshotDir:          filepath.Join(gohanDir, "snapshots"),
 			AutoBackup:           true,
 			HistoryRetentionDays: 90,
+			Background: BackgroundConfig{
+				Nice:      10,
+				IOLevel:   7,
+				CPUWeight: 20,
+				IOWeight:  20,
+			},
 		},
 		Logging: LoggingConfig{
 			Level: "info",
 			File:  "",
 		},
+		Stats: StatsConfig{
+			Enabled: false,
+		},
+		Timeouts: TimeoutsConfig{
+			PackageInstall:     30 * time.Minute,
+			PackageCacheUpdate: 10 * time.Minute,
+			PackageQuery:       30 * time.Second,
+			ServiceCommand:     time.Minute,
+		},
+		PackageManager: "apt",
+		Permissions: PermissionsConfig{
+			RespectUmask:    true,
+			StrictSensitive: false,
+		},
+		Cache: CacheConfig{
+			BuildDir:            filepath.Join(cacheDir, "build"),
+			TemplateRegistryDir: filepath.Join(cacheDir, "templates"),
+			SessionsDir:         filepath.Join(cacheDir, "sessions"),
+			AutoClean:           true,
+		},
+		Webhooks: WebhooksConfig{
+			Attempts: 3,
+			Backoff:  2 * time.Second,
+			Timeout:  10 * time.Second,
+		},
+		Hooks: HooksConfig{
+			Timeout: 5 * time.Minute,
+			LogDir:  filepath.Join(gohanDir, "logs"),
+		},
+		Templates: TemplatesConfig{
+			OverrideDir: filepath.Join(gohanDir, "templates"),
+		},
 	}
 }
 
@@ -151,6 +476,10 @@ func (c *Config) EnsureDirectories() error {
 	dirs := []string{
 		filepath.Dir(c.Database.HistoryDB),
 		filepath.Dir(c.Database.InstallationDB),
+		filepath.Dir(c.Database.StatsDB),
+		filepath.Dir(c.Database.PreflightDB),
+		filepath.Dir(c.Database.ConfigurationDB),
+		filepath.Dir(c.Database.ThemeDB),
 		c.Installation.SnapshotDir,
 	}
 
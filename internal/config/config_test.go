@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/config"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,11 @@ func TestDefaultConfig(t *testing.T) {
 	homeDir, _ := os.UserHomeDir()
 	gohanDir := filepath.Join(homeDir, ".gohan")
 	assert.Equal(t, filepath.Join(gohanDir, "history.db"), cfg.Database.HistoryDB)
+	assert.Equal(t, "/var/lib/gohan/history.db", cfg.Database.SystemHistoryDB)
 	assert.Equal(t, filepath.Join(gohanDir, "installations.db"), cfg.Database.InstallationDB)
+	assert.Equal(t, filepath.Join(gohanDir, "stats.db"), cfg.Database.StatsDB)
+	assert.Equal(t, filepath.Join(gohanDir, "configurations.db"), cfg.Database.ConfigurationDB)
+	assert.Equal(t, filepath.Join(gohanDir, "themes.db"), cfg.Database.ThemeDB)
 
 	// Installation defaults
 	assert.Equal(t, filepath.Join(gohanDir, "snapshots"), cfg.Installation.SnapshotDir)
@@ -34,6 +39,18 @@ func TestDefaultConfig(t *testing.T) {
 	// Logging defaults
 	assert.Equal(t, "info", cfg.Logging.Level)
 	assert.Equal(t, "", cfg.Logging.File)
+
+	// Usage statistics are opt-in
+	assert.False(t, cfg.Stats.Enabled)
+
+	// Timeout defaults
+	assert.Equal(t, 30*time.Minute, cfg.Timeouts.PackageInstall)
+	assert.Equal(t, 10*time.Minute, cfg.Timeouts.PackageCacheUpdate)
+	assert.Equal(t, 30*time.Second, cfg.Timeouts.PackageQuery)
+	assert.Equal(t, time.Minute, cfg.Timeouts.ServiceCommand)
+
+	// Package manager default
+	assert.Equal(t, "apt", cfg.PackageManager)
 }
 
 func TestLoad(t *testing.T) {
@@ -48,6 +65,72 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, 8080, cfg.API.Port)
 		assert.Equal(t, "info", cfg.Logging.Level)
 	})
+
+	t.Run("parses timeouts as durations and keeps unset defaults", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".gohan"), 0755))
+		require.NoError(t, os.WriteFile(config.GetConfigPath(), []byte("timeouts:\n  package_cache_update: 90s\n  package_install: 0s\n"), 0644))
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Second, cfg.Timeouts.PackageCacheUpdate)
+		assert.Zero(t, cfg.Timeouts.PackageInstall)
+		assert.Equal(t, 30*time.Second, cfg.Timeouts.PackageQuery)
+	})
+
+	t.Run("parses apt options", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".gohan"), 0755))
+		require.NoError(t, os.WriteFile(config.GetConfigPath(), []byte("apt:\n  options:\n    Acquire::http::Proxy: http://apt-cache.internal:3142\n"), 0644))
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"Acquire::http::Proxy": "http://apt-cache.internal:3142"}, cfg.Apt.Options)
+	})
+
+	t.Run("parses webhooks and keeps default retries", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".gohan"), 0755))
+		require.NoError(t, os.WriteFile(config.GetConfigPath(), []byte("webhooks:\n  urls:\n    - https://hooks.example.com/gohan\n  secret: s3cret\n  backoff: 5s\n"), 0644))
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://hooks.example.com/gohan"}, cfg.Webhooks.URLs)
+		assert.Equal(t, "s3cret", cfg.Webhooks.Secret)
+		assert.Equal(t, 5*time.Second, cfg.Webhooks.Backoff)
+		assert.Equal(t, 3, cfg.Webhooks.Attempts)
+	})
+
+	t.Run("parses hooks and keeps the default timeout", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".gohan"), 0755))
+		require.NoError(t, os.WriteFile(config.GetConfigPath(), []byte("hooks:\n  post_deploy:\n    - ~/bin/link-dotfiles\n"), 0644))
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"~/bin/link-dotfiles"}, cfg.Hooks.PostDeploy)
+		assert.Empty(t, cfg.Hooks.PreInstall)
+		assert.Equal(t, 5*time.Minute, cfg.Hooks.Timeout)
+		assert.Equal(t, filepath.Join(home, ".gohan", "logs"), cfg.Hooks.LogDir)
+	})
+
+	t.Run("keeps template overrides under the gohan directory", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, ".gohan", "templates"), cfg.Templates.OverrideDir)
+	})
 }
 
 func TestConfig_EnsureDirectories(t *testing.T) {
@@ -1,54 +1,210 @@
 package container
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	backupApp "github.com/rebelopsio/gohan/internal/application/backup"
+	cacheApp "github.com/rebelopsio/gohan/internal/application/cache"
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
 	historyServices "github.com/rebelopsio/gohan/internal/application/history/services"
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	keybindsApp "github.com/rebelopsio/gohan/internal/application/keybinds"
+	migrationApp "github.com/rebelopsio/gohan/internal/application/migration"
+	onboardingApp "github.com/rebelopsio/gohan/internal/application/onboarding"
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+	statsApp "github.com/rebelopsio/gohan/internal/application/stats"
+	wallpaperApp "github.com/rebelopsio/gohan/internal/application/wallpaper"
 	"github.com/rebelopsio/gohan/internal/config"
+	"github.com/rebelopsio/gohan/internal/domain/cache"
 	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	cacheInfra "github.com/rebelopsio/gohan/internal/infrastructure/cache"
+	configRepo "github.com/rebelopsio/gohan/internal/infrastructure/configuration/repository"
 	historyRepo "github.com/rebelopsio/gohan/internal/infrastructure/history/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/history/sysinfo"
+	"github.com/rebelopsio/gohan/internal/infrastructure/http/middleware"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/gpudriver"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/hooks"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/nightlight"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/plansigner"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/portals"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/profiles"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/reboot"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/services"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/weather"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/workspace"
+	keybindsInfra "github.com/rebelopsio/gohan/internal/infrastructure/keybinds"
+	maintenanceInfra "github.com/rebelopsio/gohan/internal/infrastructure/maintenance"
+	migrationInfra "github.com/rebelopsio/gohan/internal/infrastructure/migration"
+	"github.com/rebelopsio/gohan/internal/infrastructure/notifications"
+	onboardingInfra "github.com/rebelopsio/gohan/internal/infrastructure/onboarding"
+	preflightInfra "github.com/rebelopsio/gohan/internal/infrastructure/preflight/detectors"
+	preflightRepository "github.com/rebelopsio/gohan/internal/infrastructure/preflight/repository"
+	repoInfra "github.com/rebelopsio/gohan/internal/infrastructure/repository"
+	statsRepo "github.com/rebelopsio/gohan/internal/infrastructure/stats"
 	themeInfra "github.com/rebelopsio/gohan/internal/infrastructure/theme"
+	wallpaperInfra "github.com/rebelopsio/gohan/internal/infrastructure/wallpaper"
 	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
 )
 
+// notifierDrainTimeout bounds how long Close waits for queued
+// notifications to be delivered
+const notifierDrainTimeout = 15 * time.Second
+
+// gohanVersion is recorded in the system context of history records
+var gohanVersion = "dev"
+
+// SetVersion sets the gohan version recorded in installation history
+func SetVersion(v string) {
+	gohanVersion = v
+}
+
+// NewHookRunner creates the runner for the hooks in gohan's configuration
+func NewHookRunner(cfg config.HooksConfig) (*hooks.Runner, error) {
+	runner, err := hooks.NewRunner(map[installation.HookPhase][]string{
+		installation.HookPreInstall:  cfg.PreInstall,
+		installation.HookPostInstall: cfg.PostInstall,
+		installation.HookPreDeploy:   cfg.PreDeploy,
+		installation.HookPostDeploy:  cfg.PostDeploy,
+	}, cfg.LogDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hooks in config: %w", err)
+	}
+	return runner.WithTimeout(cfg.Timeout), nil
+}
+
 // Container holds all dependencies for the application
 type Container struct {
 	Config *config.Config
 
 	// Repositories
-	HistoryRepo      *historyRepo.SQLiteRepository
-	InstallationRepo installation.InstallationSessionRepository
+	HistoryRepo       *historyRepo.SQLiteRepository
+	InstallationRepo  installation.InstallationSessionRepository
+	StatsRepo         *statsRepo.SQLiteRepository // nil unless stats are enabled
+	PreflightRepo     *preflightRepository.SQLiteRepository
+	ConfigurationRepo *configRepo.SQLiteRepository
+	ThemeRepo         *themeInfra.SQLiteRepository
 
 	// Services
 	HistoryQueryService     *historyServices.HistoryQueryService
 	HistoryRecordingService *historyServices.HistoryRecordingService
+	StatsRecordingService   *statsApp.RecordingService
 	ProgressEstimator       *services.ProgressEstimator
 	ConfigMerger            *services.ConfigurationMerger
 	PackageManager          *packagemanager.APTManager
+	DryRunPackageManager    *packagemanager.APTManager // Simulates installs for dry-run sessions
 	ConfigDeployer          *configservice.ConfigDeployer
 	ThemeApplier            *themeInfra.ThemeApplierImpl
+	ThemeReloadService      *themeInfra.ReloadService
 	ThemeStateStore         themeInfra.ThemeStateStore
 	ThemeHistoryStore       themeInfra.ThemeHistoryStore
+	SessionWorkspaces       *workspace.Store
+	HookRunner              *hooks.Runner
+	ProgressStreams         *usecases.ProgressStreams
+	ProgressBroadcaster     *usecases.ProgressBroadcaster
+	Notifier                *notifications.AsyncNotifier // nil unless webhooks are configured
 
 	// Use Cases
 	StartInstallationUseCase   *usecases.StartInstallationUseCase
 	ExecuteInstallationUseCase *usecases.ExecuteInstallationUseCase
 	GetStatusUseCase           *usecases.GetInstallationStatusUseCase
+	GetDetailUseCase           *usecases.GetInstallationDetailUseCase
+	GetTimelineUseCase         *usecases.GetSessionTimelineUseCase
 	ListInstallationsUseCase   *usecases.ListInstallationsUseCase
 	CancelInstallationUseCase  *usecases.CancelInstallationUseCase
+	SwapComponentUseCase       *usecases.SwapComponentUseCase
+	CompareProfilesUseCase     *usecases.CompareProfilesUseCase
+	GetCatalogUseCase          *usecases.GetCatalogUseCase
+
+	// Configuration template use cases
+	CreateTemplateUseCase  *configApp.CreateTemplateUseCase
+	ListTemplatesUseCase   *configApp.ListTemplatesUseCase
+	SearchTemplatesUseCase *configApp.SearchTemplatesUseCase
+	ShowTemplateUseCase    *configApp.ShowTemplateUseCase
+	DeleteTemplateUseCase  *configApp.DeleteTemplateUseCase
+	TagTemplateUseCase     *configApp.TagTemplateUseCase
+
+	// Configuration deployment, as run by gohan config deploy and the API
+	ConfigDeployUseCase *configApp.ConfigDeployUseCase
+
+	// Configuration backups, stored under BackupRoot
+	BackupRoot           string
+	ListBackupsUseCase   *backupApp.ListBackupsUseCase
+	GetBackupUseCase     *backupApp.GetBackupUseCase
+	CreateBackupUseCase  *backupApp.CreateBackupUseCase
+	RestoreBackupUseCase *backupApp.RestoreBackupUseCase
+
+	// Files deployed from templates, bringing them up to date when a newer
+	// gohan changes the templates, and finding local edits
+	DeployRecords           *configservice.DeployRecordStore
+	UpgradeTemplatesUseCase *configApp.UpgradeTemplatesUseCase
+	ConfigStatusUseCase     *configApp.ConfigStatusUseCase
+
+	// Download cache use cases
+	CacheStatusUseCase *cacheApp.CacheStatusUseCase
+	CleanCacheUseCase  *cacheApp.CleanCacheUseCase
+
+	// Preflight checks of this machine
+	RunPreflightUseCase *preflightApp.RunPreflightUseCase
+
+	// Key binding cheat sheet use case
+	GenerateCheatSheetUseCase *keybindsApp.GenerateCheatSheetUseCase
+
+	// First-login tour use cases
+	EnableOnboardingUseCase  *onboardingApp.EnableOnboardingUseCase
+	DisableOnboardingUseCase *onboardingApp.DisableOnboardingUseCase
+	OnboardingStatusUseCase  *onboardingApp.OnboardingStatusUseCase
+
+	// Settings imported from GNOME or KDE, and the template variables
+	// they became
+	ImportSettingsUseCase *migrationApp.ImportSettingsUseCase
+	ImportedVars          *migrationInfra.VarStore
+
+	// Wallpaper use cases
+	SetWallpaperUseCase  *wallpaperApp.SetWallpaperUseCase
+	NextWallpaperUseCase *wallpaperApp.NextWallpaperUseCase
+
+	// Run package operations at background priority
+	background bool
+
+	// Simulate package operations whatever the configuration says
+	dryRun bool
+}
+
+// Option adjusts how a container is built for one invocation
+type Option func(*Container)
+
+// WithBackgroundPriority runs apt and dpkg at the priority configured in
+// installation.background, so a desktop session stays usable while
+// packages install
+func WithBackgroundPriority() Option {
+	return func(c *Container) {
+		c.background = true
+	}
+}
+
+// WithDryRun wires the dry-run package manager in place of the real one,
+// as installation.dry_run does, for this invocation only
+func WithDryRun() Option {
+	return func(c *Container) {
+		c.dryRun = true
+	}
 }
 
 // New creates a new dependency container
-func New() (*Container, error) {
+func New(opts ...Option) (*Container, error) {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -58,6 +214,9 @@ func New() (*Container, error) {
 	c := &Container{
 		Config: cfg,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
 
 	// Initialize repositories
 	if err := c.initRepositories(); err != nil {
@@ -65,18 +224,39 @@ func New() (*Container, error) {
 	}
 
 	// Initialize services
-	c.initServices()
+	if err := c.initServices(); err != nil {
+		return nil, fmt.Errorf("failed to initialize services: %w", err)
+	}
 
 	// Initialize use cases
-	c.initUseCases()
+	if err := c.initUseCases(); err != nil {
+		return nil, fmt.Errorf("failed to initialize use cases: %w", err)
+	}
 
 	return c, nil
 }
 
+// historyDBPath returns the history database for the process scope:
+// root records system-wide history, other users keep their own
+func (c *Container) historyDBPath() string {
+	if !sysinfo.CurrentScope().IsSystem() {
+		return c.Config.Database.HistoryDB
+	}
+
+	path := c.Config.Database.SystemHistoryDB
+	if path == "" {
+		return c.Config.Database.HistoryDB
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return c.Config.Database.HistoryDB
+	}
+	return path
+}
+
 // initRepositories initializes all repositories
 func (c *Container) initRepositories() error {
 	// History repository
-	historyRepo, err := historyRepo.NewSQLiteRepository(c.Config.Database.HistoryDB)
+	historyRepo, err := historyRepo.NewSQLiteRepository(c.historyDBPath())
 	if err != nil {
 		return fmt.Errorf("failed to create history repository: %w", err)
 	}
@@ -86,6 +266,36 @@ func (c *Container) initRepositories() error {
 	// Note: Using memory repository for now as SQLite reconstruction not fully implemented
 	c.InstallationRepo = repository.NewMemorySessionRepository()
 
+	// Local usage statistics are opt-in
+	if c.Config.Stats.Enabled {
+		statsRepo, err := statsRepo.NewSQLiteRepository(c.Config.Database.StatsDB)
+		if err != nil {
+			return fmt.Errorf("failed to create stats repository: %w", err)
+		}
+		c.StatsRepo = statsRepo
+	}
+
+	// Preflight sessions, referenced from installation sessions
+	preflightRepo, err := preflightRepository.NewSQLiteRepository(c.Config.Database.PreflightDB)
+	if err != nil {
+		return fmt.Errorf("failed to create preflight repository: %w", err)
+	}
+	c.PreflightRepo = preflightRepo
+
+	// Saved configuration templates
+	configurationRepo, err := configRepo.NewSQLiteRepository(c.Config.Database.ConfigurationDB)
+	if err != nil {
+		return fmt.Errorf("failed to create configuration repository: %w", err)
+	}
+	c.ConfigurationRepo = configurationRepo
+
+	// Active theme and theme history
+	themeRepo, err := themeInfra.NewSQLiteRepository(c.Config.Database.ThemeDB)
+	if err != nil {
+		return fmt.Errorf("failed to create theme repository: %w", err)
+	}
+	c.ThemeRepo = themeRepo
+
 	// TODO: Switch to SQLite when reconstruction is complete
 	// installationRepo, err := repository.NewSQLiteSessionRepository(c.Config.Database.InstallationDB)
 	// if err != nil {
@@ -96,72 +306,465 @@ func (c *Container) initRepositories() error {
 	return nil
 }
 
+// phaseCalibrationRecords is how many recent installations the progress
+// estimator is calibrated with
+const phaseCalibrationRecords = 20
+
+// calibrateProgressEstimator tunes the estimator's phase weights to how
+// long installations took on this machine. It is best effort; without
+// history the default weights are used.
+func (c *Container) calibrateProgressEstimator() {
+	records, err := c.HistoryQueryService.RecentPhaseTimings(context.Background(), phaseCalibrationRecords)
+	if err != nil {
+		return
+	}
+
+	runs := make([][]installation.PhaseTiming, 0, len(records))
+	for _, recorded := range records {
+		timings := make([]installation.PhaseTiming, 0, len(recorded))
+		for _, r := range recorded {
+			timing, err := installation.NewPhaseTiming(installation.InstallationStatus(r.Phase()), r.Estimated(), r.Actual())
+			if err != nil {
+				continue
+			}
+			timings = append(timings, timing)
+		}
+		runs = append(runs, timings)
+	}
+	c.ProgressEstimator.Calibrate(runs)
+}
+
 // initServices initializes all application services
-func (c *Container) initServices() {
+func (c *Container) initServices() error {
 	// History services
 	c.HistoryQueryService = historyServices.NewHistoryQueryService(c.HistoryRepo)
-	c.HistoryRecordingService = historyServices.NewHistoryRecordingService(c.HistoryRepo)
+	c.HistoryRecordingService = historyServices.NewHistoryRecordingServiceWithProviders(
+		c.HistoryRepo,
+		sysinfo.NewSystemContextProvider(gohanVersion, preflightInfra.NewSystemGPUDetector()),
+		packagemanager.NewDpkgInventory(),
+	)
+	if c.StatsRepo != nil {
+		c.StatsRecordingService = statsApp.NewRecordingService(c.StatsRepo)
+	}
 
 	// Installation services
 	c.ProgressEstimator = services.NewProgressEstimator()
+	c.calibrateProgressEstimator()
 	c.ConfigMerger = services.NewConfigurationMerger()
 
 	// Choose package manager based on dry-run setting
-	if c.Config.Installation.DryRun {
+	if c.dryRun || c.Config.Installation.DryRun {
 		c.PackageManager = packagemanager.NewAPTManagerDryRun()
 	} else {
 		c.PackageManager = packagemanager.NewAPTManager()
 	}
+	timeouts := packagemanager.Timeouts{
+		Install: c.Config.Timeouts.PackageInstall,
+		Update:  c.Config.Timeouts.PackageCacheUpdate,
+		Query:   c.Config.Timeouts.PackageQuery,
+	}
+	c.PackageManager = c.PackageManager.WithTimeouts(timeouts)
+	aptOptions := packagemanager.Options(c.Config.Apt.Options)
+	if err := aptOptions.Validate(); err != nil {
+		return fmt.Errorf("invalid apt options: %w", err)
+	}
+	backend, err := packagemanager.LookupBackend(c.Config.PackageManager)
+	if err != nil {
+		return fmt.Errorf("invalid package_manager: %w", err)
+	}
+	c.PackageManager = c.PackageManager.WithOptions(aptOptions).WithBackend(backend)
+	// Sessions requested as dry runs simulate even when the rest of the
+	// container installs for real
+	c.DryRunPackageManager = packagemanager.NewAPTManagerDryRun().WithTimeouts(timeouts).WithOptions(aptOptions).WithBackend(backend)
+	if c.background {
+		background := c.Config.Installation.Background
+		priority := packagemanager.Priority{
+			Nice:      background.Nice,
+			IOLevel:   background.IOLevel,
+			CPUWeight: background.CPUWeight,
+			IOWeight:  background.IOWeight,
+		}
+		if err := priority.Validate(); err != nil {
+			return fmt.Errorf("invalid background priority: %w", err)
+		}
+		c.PackageManager = c.PackageManager.WithPriority(priority)
+	}
 
 	// Configuration deployment services
 	homeDir, _ := os.UserHomeDir()
 	backupDir := filepath.Join(homeDir, ".config", "gohan", "backups")
 	templateEngine := templates.NewTemplateEngine()
 	backupService := backup.NewBackupService(backupDir)
-	c.ConfigDeployer = configservice.NewConfigDeployer(templateEngine, backupService)
+	backupRepo := backup.NewRepositoryAdapter(backupDir)
+	c.BackupRoot = backupDir
+	c.ListBackupsUseCase = backupApp.NewListBackupsUseCase(backupRepo)
+	c.GetBackupUseCase = backupApp.NewGetBackupUseCase(backupRepo)
+	c.CreateBackupUseCase = backupApp.NewCreateBackupUseCase(backupRepo)
+	c.RestoreBackupUseCase = backupApp.NewRestoreBackupUseCase(backupRepo)
+	policy := configservice.DefaultPermissionPolicy()
+	if !c.Config.Permissions.RespectUmask {
+		policy.Umask = 0
+	}
+	policy.StrictSensitive = c.Config.Permissions.StrictSensitive
+	c.DeployRecords = configservice.NewDeployRecordStore(filepath.Join(config.GetDataDir(), configservice.DeployRecordsFileName))
+	c.ConfigDeployer = configservice.NewConfigDeployer(templateEngine, backupService).
+		WithPermissionPolicy(policy).
+		WithRecords(c.DeployRecords)
+	hookRunner, err := NewHookRunner(c.Config.Hooks)
+	if err != nil {
+		return err
+	}
+	c.HookRunner = hookRunner
+	c.ConfigDeployUseCase = configApp.NewConfigDeployUseCase(c.ConfigDeployer, templateEngine).
+		WithHooks(c.HookRunner).
+		WithTemplateOverrides(c.Config.Templates.OverrideDir).
+		WithPendingDeploys(filepath.Join(config.GetDataDir(), configservice.PendingDirName), nil)
+	c.SessionWorkspaces = workspace.NewStore(c.Config.Cache.SessionsDir)
 
 	// Theme services
-	c.ThemeApplier = themeInfra.NewThemeApplier(c.ConfigDeployer)
+	accessibility := installation.NewAccessibilitySettings(
+		c.Config.Accessibility.ReducedMotion,
+		c.Config.Accessibility.LargeText,
+		c.Config.Accessibility.HighContrast,
+	)
+	c.ThemeApplier = themeInfra.NewThemeApplier(c.ConfigDeployer).WithTemplateVars(accessibility.TemplateVars())
+	c.ThemeReloadService = themeInfra.NewReloadService(themeInfra.NewSystemCommandExecutor(), themeInfra.ComponentTargetPath("kitty"))
 
-	// Theme state store
+	// Theme state and history, carried over from the JSON files earlier
+	// versions kept; unreadable files leave the default theme active
 	stateFilePath, _ := themeInfra.GetDefaultStateFilePath()
-	c.ThemeStateStore = themeInfra.NewFileThemeStateStore(stateFilePath)
-
-	// Theme history store
 	historyFilePath, _ := themeInfra.GetDefaultHistoryFilePath()
-	c.ThemeHistoryStore = themeInfra.NewFileThemeHistoryStore(historyFilePath)
+	_ = c.ThemeRepo.ImportLegacy(context.Background(),
+		themeInfra.NewFileThemeStateStore(stateFilePath),
+		themeInfra.NewFileThemeHistoryStore(historyFilePath),
+	)
+	c.ThemeStateStore = c.ThemeRepo
+	c.ThemeHistoryStore = c.ThemeRepo
+	return nil
 }
 
 // initUseCases initializes all use cases
-func (c *Container) initUseCases() {
-	c.StartInstallationUseCase = usecases.NewStartInstallationUseCase(c.InstallationRepo)
+func (c *Container) initUseCases() error {
+	severityPolicy, err := preflight.NewSeverityPolicy(c.Config.Preflight.Severities)
+	if err != nil {
+		return fmt.Errorf("invalid preflight severities: %w", err)
+	}
+
+	// Plans pin package versions and are signed with the user's key
+	c.StartInstallationUseCase = usecases.NewStartInstallationUseCase(c.InstallationRepo).
+		WithPackageResolver(c.PackageManager).
+		WithRepositoryLister(repoInfra.NewSystemRepositoryLister()).
+		WithPlanSigner(plansigner.NewEd25519Signer())
+
+	// Custom profiles live next to the configuration file
+	profileLoader := profiles.NewLoader(filepath.Join(config.GetDataDir(), "profiles"))
+	c.CompareProfilesUseCase = usecases.NewCompareProfilesUseCase(profileLoader).
+		WithPackageResolver(c.PackageManager)
+	c.GetCatalogUseCase = usecases.NewGetCatalogUseCase(profileLoader)
+
+	// Stats are recorded wherever installation history is
+	var historyRecorder usecases.HistoryRecorder = c.HistoryRecordingService
+	if c.StatsRecordingService != nil {
+		historyRecorder = statsApp.NewRecordingHistoryRecorder(c.HistoryRecordingService, c.StatsRecordingService)
+	}
+
+	cacheLocations, err := c.cacheLocations()
+	if err != nil {
+		return err
+	}
+	cacheStore := cacheInfra.NewFilesystemStore()
+	c.CacheStatusUseCase = cacheApp.NewCacheStatusUseCase(cacheStore, cacheLocations)
+	c.CleanCacheUseCase = cacheApp.NewCleanCacheUseCase(cacheStore, cacheLocations)
+
+	// Runs are kept so they can be compared with later ones
+	c.RunPreflightUseCase = preflightApp.NewRunPreflightUseCase(preflightTUI.SystemDetectors()).
+		WithSeverityPolicy(severityPolicy).
+		WithRepository(c.PreflightRepo)
+	if c.Config.Cache.AutoClean {
+		c.RunPreflightUseCase = c.RunPreflightUseCase.WithSpaceReclaimer(c.CleanCacheUseCase)
+	}
+
+	// A fresh runner per installation, checking the components it selected
+	newPreflight := func(config installation.InstallationConfiguration) usecases.PreflightValidator {
+		runner := preflightTUI.NewValidationRunner().WithSeverityPolicy(severityPolicy).WithInstallation(config)
+		if c.Config.Cache.AutoClean {
+			runner = runner.WithSpaceReclaimer(c.CleanCacheUseCase)
+		}
+		return runner
+	}
+
+	// The cheat sheet follows Hyprland's source lines from its main file
+	homeDir, _ := os.UserHomeDir()
+	c.GenerateCheatSheetUseCase = keybindsApp.NewGenerateCheatSheetUseCase(
+		keybindsInfra.NewConfigReader(filepath.Join(homeDir, ".config", "hypr", "hyprland.conf")),
+		keybindsInfra.NewRenderer(),
+	)
 
+	// The tour script lives in gohan's data directory, where Hyprland's
+	// autostart looks for it
+	tourStore := onboardingInfra.NewScriptStore(config.GetDataDir())
+	c.EnableOnboardingUseCase = onboardingApp.NewEnableOnboardingUseCase(tourStore)
+	c.DisableOnboardingUseCase = onboardingApp.NewDisableOnboardingUseCase(tourStore)
+	c.OnboardingStatusUseCase = onboardingApp.NewOnboardingStatusUseCase(tourStore)
+
+	// Imported keyboard and monitor layouts are rendered into every
+	// deployment; the wallpaper goes where swaybg and hyprlock read it
+	c.ImportedVars = migrationInfra.NewVarStore(filepath.Join(config.GetDataDir(), migrationInfra.VarsFileName))
+	c.ImportSettingsUseCase = migrationApp.NewImportSettingsUseCase(
+		map[migration.Desktop]migrationApp.SettingsReader{
+			migration.DesktopGNOME: migrationInfra.NewGNOMEReader(homeDir),
+			migration.DesktopKDE:   migrationInfra.NewKDEReader(homeDir),
+		},
+		c.ImportedVars,
+		migrationInfra.NewWallpaperStore(filepath.Join(homeDir, ".config", "gohan", "wallpaper.jpg")),
+		migrationInfra.NewServiceChecker(),
+	)
+
+	// Wallpapers are kept next to the one migration imports, which
+	// hyprlock reads too
+	wallpaperStore := wallpaperInfra.NewFileStore(filepath.Join(homeDir, ".config", "gohan"))
+	wallpaperApplier := wallpaperInfra.NewHyprApplier(filepath.Join(homeDir, ".config", "hypr"), themeInfra.NewSystemCommandExecutor())
+	c.SetWallpaperUseCase = wallpaperApp.NewSetWallpaperUseCase(
+		wallpaperStore,
+		c.PackageManager,
+		wallpaperApplier,
+		wallpaperInfra.NewTimerScheduler(maintenanceInfra.NewSystemdTimerInstaller()),
+	)
+	c.NextWallpaperUseCase = wallpaperApp.NewNextWallpaperUseCase(wallpaperStore, c.PackageManager, wallpaperApplier)
+
+	// Shared so cancel requests can reach running executions
+	running := usecases.NewRunningInstallations()
+	// Shared so the terminal UI and other sinks can follow executions
+	c.ProgressBroadcaster = usecases.NewProgressBroadcaster()
+	// A sink of the broadcaster, so API clients can follow executions as
+	// they run
+	c.ProgressStreams = usecases.NewProgressStreams()
+	c.ProgressBroadcaster.Subscribe(c.ProgressStreams.Publish)
 	c.ExecuteInstallationUseCase = usecases.NewExecuteInstallationUseCase(
 		c.InstallationRepo,
 		c.PackageManager, // ConflictResolver
 		c.ProgressEstimator,
 		c.ConfigMerger,
 		c.PackageManager, // PackageManager
-		c.HistoryRecordingService, // HistoryRecorder
-		preflightTUI.NewValidationRunner(), // PreflightValidator
+		historyRecorder,  // HistoryRecorder
+		newPreflight,     // PreflightValidatorFactory
 		c.ConfigDeployer,
-	)
+	).WithRunningInstallations(running).
+		WithProgressBroadcaster(c.ProgressBroadcaster).
+		WithPreflightRepository(c.PreflightRepo).
+		WithWorkspaces(c.SessionWorkspaces).
+		WithOnboarding(c.EnableOnboardingUseCase).
+		WithImportedVars(c.ImportedVars).
+		WithPortals(portals.NewDetector()).
+		WithGPUDriverSetup(gpudriver.NewInspector()).
+		WithRebootDetection(reboot.NewDetector(packagemanager.NewDpkgInventory())).
+		WithDryRun(c.DryRunPackageManager, c.DryRunPackageManager)
+	if c.Config.Weather.Enabled {
+		c.ExecuteInstallationUseCase.WithWeather(weather.NewLocationResolver(c.Config.Weather.City))
+	}
+	c.ExecuteInstallationUseCase.WithNightLight(nightlight.NewLocationResolver(c.Config.NightLight.Latitude, c.Config.NightLight.Longitude))
+	lock := c.Config.LockScreen
+	lockScreen, err := installation.NewLockScreenSettings(lock.Background, lock.Image, lock.Avatar, lock.Clock, lock.ClockSize)
+	if err != nil {
+		return fmt.Errorf("invalid lock_screen in config: %w", err)
+	}
+	c.ExecuteInstallationUseCase.WithLockScreen(lockScreen)
+	if webhooks := c.Config.Webhooks; len(webhooks.URLs) > 0 {
+		notifier, err := notifications.NewWebhookNotifier(webhooks.URLs, webhooks.Secret)
+		if err != nil {
+			return fmt.Errorf("invalid webhooks in config: %w", err)
+		}
+		notifier = notifier.WithRetry(notifications.RetryPolicy{
+			Attempts: webhooks.Attempts,
+			Backoff:  webhooks.Backoff,
+		})
+		if webhooks.Timeout > 0 {
+			notifier = notifier.WithClient(&http.Client{Timeout: webhooks.Timeout})
+		}
+		c.Notifier = notifications.NewAsyncNotifier(notifier, notifications.DefaultQueueSize, func(event installation.DomainEvent, err error) {
+			fmt.Printf("Warning: failed to send %s notification: %v\n", event.EventType(), err)
+		})
+		c.ExecuteInstallationUseCase.WithEventNotifier(c.Notifier)
+	}
+	c.ExecuteInstallationUseCase.WithHooks(c.HookRunner)
 
-	c.GetStatusUseCase = usecases.NewGetInstallationStatusUseCase(c.InstallationRepo)
+	c.GetStatusUseCase = usecases.NewGetInstallationStatusUseCaseWithEstimator(c.InstallationRepo, c.ProgressEstimator)
+	c.GetDetailUseCase = usecases.NewGetInstallationDetailUseCase(c.InstallationRepo, c.ProgressEstimator).
+		WithHistory(c.HistoryRepo)
+	c.GetTimelineUseCase = usecases.NewGetSessionTimelineUseCase(c.InstallationRepo)
 	c.ListInstallationsUseCase = usecases.NewListInstallationsUseCase(c.InstallationRepo)
-	c.CancelInstallationUseCase = usecases.NewCancelInstallationUseCase(c.InstallationRepo)
+	c.CancelInstallationUseCase = usecases.NewCancelInstallationUseCase(c.InstallationRepo).WithRunningInstallations(running)
+	c.SwapComponentUseCase = usecases.NewSwapComponentUseCase(
+		c.InstallationRepo,
+		c.PackageManager, // PackageManager
+		c.PackageManager, // PackageRemover
+		historyRecorder,
+		c.ConfigDeployer,
+	).WithImportedVars(c.ImportedVars).
+		WithLockScreen(lockScreen)
+
+	c.CreateTemplateUseCase = configApp.NewCreateTemplateUseCase(c.ConfigurationRepo)
+	c.ListTemplatesUseCase = configApp.NewListTemplatesUseCase(c.ConfigurationRepo)
+	c.SearchTemplatesUseCase = configApp.NewSearchTemplatesUseCase(c.ConfigurationRepo)
+	c.ShowTemplateUseCase = configApp.NewShowTemplateUseCase(c.ConfigurationRepo)
+	c.DeleteTemplateUseCase = configApp.NewDeleteTemplateUseCase(c.ConfigurationRepo)
+	c.TagTemplateUseCase = configApp.NewTagTemplateUseCase(c.ConfigurationRepo)
+	c.UpgradeTemplatesUseCase = configApp.NewUpgradeTemplatesUseCase(
+		c.DeployRecords,
+		c.ConfigDeployer,
+		templates.NewTemplateEngine(),
+	)
+	c.ConfigStatusUseCase = configApp.NewConfigStatusUseCase(c.DeployRecords, templates.NewTemplateEngine())
+
+	return nil
+}
+
+// ConfigDeployDefaults returns the request a configuration deployment
+// starts from: the configured accessibility and lock screen, the weather
+// location, the installed portal backends, imported variables and the
+// rendering mode suited to this system
+func (c *Container) ConfigDeployDefaults(ctx context.Context) (configApp.DeployConfigRequest, error) {
+	lock := c.Config.LockScreen
+	lockScreen, err := installation.NewLockScreenSettings(lock.Background, lock.Image, lock.Avatar, lock.Clock, lock.ClockSize)
+	if err != nil {
+		return configApp.DeployConfigRequest{}, fmt.Errorf("invalid lock_screen in config: %w", err)
+	}
+
+	// A location that cannot be resolved leaves the weather module out
+	var weatherLocation installation.WeatherLocation
+	if c.Config.Weather.Enabled {
+		weatherLocation, _ = weather.NewLocationResolver(c.Config.Weather.City).Location()
+	}
+
+	portalSelection, err := portals.NewDetector().Selection()
+	if err != nil {
+		return configApp.DeployConfigRequest{}, err
+	}
+
+	importedVars, err := c.ImportedVars.Load()
+	if err != nil {
+		return configApp.DeployConfigRequest{}, err
+	}
+
+	lowEnd := false
+	if resources, err := preflightInfra.NewSystemResourceDetector().DetectResources(ctx); err == nil {
+		lowEnd = resources.RecommendsLiteMode()
+	}
+
+	return configApp.DeployConfigRequest{
+		CustomVars:    importedVars,
+		RenderingMode: installation.RenderingAuto.Resolve(lowEnd),
+		Accessibility: installation.NewAccessibilitySettings(
+			c.Config.Accessibility.ReducedMotion,
+			c.Config.Accessibility.LargeText,
+			c.Config.Accessibility.HighContrast,
+		),
+		Weather:    weatherLocation,
+		Portals:    portalSelection,
+		LockScreen: lockScreen,
+	}, nil
+}
+
+// APIAuth returns the authentication the API server requires, from the
+// api.auth settings. It is not enabled when neither a token nor a client
+// CA is configured.
+func (c *Container) APIAuth() (*middleware.Auth, error) {
+	cfg := c.Config.API.Auth
+	var authenticators []middleware.Authenticator
+
+	token := cfg.Token
+	if cfg.TokenFile != "" {
+		if token != "" {
+			return nil, fmt.Errorf("api.auth: set token or token_file, not both")
+		}
+		data, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("api.auth: failed to read token file: %w", err)
+		}
+		if token = strings.TrimSpace(string(data)); token == "" {
+			return nil, fmt.Errorf("api.auth: token file %s is empty", cfg.TokenFile)
+		}
+	}
+	if token != "" {
+		tokenAuth, err := middleware.NewTokenAuthenticator(token)
+		if err != nil {
+			return nil, fmt.Errorf("api.auth: %w", err)
+		}
+		authenticators = append(authenticators, tokenAuth)
+	}
+
+	if cfg.ClientCAFile != "" {
+		if c.Config.API.TLS.CertFile == "" || c.Config.API.TLS.KeyFile == "" {
+			return nil, fmt.Errorf("api.auth: client_ca_file requires api.tls.cert_file and api.tls.key_file")
+		}
+		authenticators = append(authenticators, middleware.NewClientCertAuthenticator(cfg.AllowedClients))
+	}
+
+	return middleware.NewAuth(authenticators...), nil
+}
+
+// cacheLocations returns the download caches gohan manages
+func (c *Container) cacheLocations() ([]cache.Location, error) {
+	paths := map[cache.Kind]string{
+		cache.KindAPTArchives:      cacheInfra.APTArchivesDir,
+		cache.KindBuild:            c.Config.Cache.BuildDir,
+		cache.KindTemplateRegistry: c.Config.Cache.TemplateRegistryDir,
+		cache.KindSessions:         c.Config.Cache.SessionsDir,
+	}
+
+	locations := make([]cache.Location, 0, len(paths))
+	for _, kind := range cache.AllKinds() {
+		location, err := cache.NewLocation(kind, paths[kind])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache configuration: %w", err)
+		}
+		locations = append(locations, location)
+	}
+	return locations, nil
 }
 
 // Close closes all resources
 func (c *Container) Close() error {
 	var errs []error
 
+	if c.Notifier != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), notifierDrainTimeout)
+		if err := c.Notifier.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("notifications: %w", err))
+		}
+		cancel()
+	}
+
 	if c.HistoryRepo != nil {
 		if err := c.HistoryRepo.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("history repo: %w", err))
 		}
 	}
 
+	if c.StatsRepo != nil {
+		if err := c.StatsRepo.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("stats repo: %w", err))
+		}
+	}
+
+	if c.PreflightRepo != nil {
+		if err := c.PreflightRepo.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("preflight repo: %w", err))
+		}
+	}
+
+	if c.ConfigurationRepo != nil {
+		if err := c.ConfigurationRepo.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("configuration repo: %w", err))
+		}
+	}
+
+	if c.ThemeRepo != nil {
+		if err := c.ThemeRepo.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("theme repo: %w", err))
+		}
+	}
+
 	// Close installation repo if it implements io.Closer
 	if closer, ok := c.InstallationRepo.(interface{ Close() error }); ok && closer != nil {
 		if err := closer.Close(); err != nil {
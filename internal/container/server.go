@@ -0,0 +1,76 @@
+package container
+
+import (
+	"os"
+	"time"
+
+	httpinfra "github.com/rebelopsio/gohan/internal/infrastructure/http"
+	"github.com/rebelopsio/gohan/internal/infrastructure/http/handlers"
+)
+
+// APIServer builds the HTTP API server from the api settings, serving
+// every route gohan has. Both the gohan server command and the standalone
+// server binary run it.
+func (c *Container) APIServer() (*httpinfra.Server, error) {
+	installationHandler := handlers.NewInstallationHandler(
+		c.StartInstallationUseCase,
+		c.ExecuteInstallationUseCase,
+		c.GetStatusUseCase,
+		c.ListInstallationsUseCase,
+		c.CancelInstallationUseCase,
+	).WithDetailUseCase(c.GetDetailUseCase).
+		WithProgressStreams(c.ProgressStreams)
+
+	templateHandler := handlers.NewTemplateHandler(
+		c.CreateTemplateUseCase,
+		c.ListTemplatesUseCase,
+		c.SearchTemplatesUseCase,
+		c.ShowTemplateUseCase,
+		c.DeleteTemplateUseCase,
+		c.TagTemplateUseCase,
+	)
+
+	configurationHandler := handlers.NewConfigurationHandler(c.ConfigDeployUseCase).
+		WithDefaults(c.ConfigDeployDefaults)
+
+	homeDir, _ := os.UserHomeDir()
+	backupHandler := handlers.NewBackupHandler(
+		c.ListBackupsUseCase,
+		c.GetBackupUseCase,
+		c.CreateBackupUseCase,
+		c.RestoreBackupUseCase,
+		c.BackupRoot,
+		homeDir,
+	)
+
+	serverConfig := httpinfra.Config{
+		Host:         c.Config.API.Host,
+		Port:         c.Config.API.Port,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	server := httpinfra.NewServer(serverConfig, installationHandler, false).
+		WithTemplateHandler(templateHandler).
+		WithConfigurationHandler(configurationHandler).
+		WithBackupHandler(backupHandler).
+		WithConfigsAPI(configurationHandler, backupHandler).
+		WithPreflightHandler(handlers.NewPreflightHandler(c.RunPreflightUseCase, homeDir)).
+		WithCatalogHandler(handlers.NewCatalogHandler(c.GetCatalogUseCase))
+
+	auth, err := c.APIAuth()
+	if err != nil {
+		return nil, err
+	}
+	server.WithAuth(auth)
+
+	if tlsCfg := c.Config.API.TLS; tlsCfg.CertFile != "" {
+		tlsConfig, err := httpinfra.NewTLSConfig(c.Config.API.Auth.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		server.WithTLS(tlsConfig, tlsCfg.CertFile, tlsCfg.KeyFile)
+	}
+
+	return server, nil
+}
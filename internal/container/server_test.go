@@ -0,0 +1,52 @@
+package container_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rebelopsio/gohan/internal/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_APIServer(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "")
+
+	c, err := container.New()
+	require.NoError(t, err)
+	defer c.Close()
+
+	server, err := c.APIServer()
+	require.NoError(t, err)
+
+	routes := make(map[string]bool)
+	require.NoError(t, chi.Walk(server.Router(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routes[method+" "+route] = true
+		return nil
+	}))
+
+	for _, route := range []string{
+		"POST /api/installation/start",
+		"GET /api/v1/installations/{sessionID}/progress/ws",
+		"GET /api/v1/installations/{sessionID}/events",
+		"GET /api/templates/",
+		"POST /api/configurations/deploy",
+		"GET /api/backups/",
+		"POST /api/v1/configs/deploy",
+		"POST /api/v1/preflight",
+		"GET /api/v1/catalog/components",
+	} {
+		assert.True(t, routes[route], "missing route %s", route)
+	}
+
+	t.Run("progress streams are wired", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		server.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/installations/missing/events", nil))
+		assert.NotEqual(t, http.StatusNotImplemented, rec.Code)
+	})
+}
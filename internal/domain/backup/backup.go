@@ -3,6 +3,7 @@ package backup
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -32,10 +33,13 @@ type Backup struct {
 
 // BackupFile represents a single file in a backup (value object)
 type BackupFile struct {
-	OriginalPath string      // Where the file came from
-	BackupPath   string      // Where it's stored in backup
-	Permissions  os.FileMode // File permissions
-	SizeBytes    int64       // File size in bytes
+	OriginalPath string            // Where the file came from
+	BackupPath   string            // Where it's stored in backup
+	Permissions  os.FileMode       // File permissions
+	SizeBytes    int64             // File size in bytes
+	LinkTarget   string            // Symlink target; empty for regular files
+	ModTime      time.Time         // Modification time to restore
+	Xattrs       map[string][]byte // Extended attributes to restore
 }
 
 // NewBackup creates a new backup with a timestamp-based ID
@@ -52,6 +56,15 @@ func NewBackup(description string) (*Backup, error) {
 	}, nil
 }
 
+// ValidateID checks that id names a single backup, so a backup ID taken
+// from a request cannot reach outside the backup directory
+func ValidateID(id string) error {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return fmt.Errorf("%w: %q", ErrInvalidBackupID, id)
+	}
+	return nil
+}
+
 // ID returns the backup's unique identifier
 func (b *Backup) ID() string {
 	return b.id
@@ -166,6 +179,11 @@ func (b *Backup) Validate() error {
 	return nil
 }
 
+// IsSymlink returns true if the file was backed up as a symbolic link
+func (f BackupFile) IsSymlink() bool {
+	return f.LinkTarget != ""
+}
+
 // Validate validates a backup file
 func (f BackupFile) Validate() error {
 	if f.OriginalPath == "" {
@@ -240,3 +240,35 @@ func TestBackupFile_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupSummary_Age(t *testing.T) {
+	summary := backup.BackupSummary{
+		ID:        "2025-01-29_120000",
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+	}
+
+	assert.InDelta(t, (2 * time.Hour).Seconds(), summary.Age().Seconds(), 5)
+}
+
+func TestBackupFile_IsSymlink(t *testing.T) {
+	link := backup.BackupFile{
+		OriginalPath: "/config/theme.conf",
+		BackupPath:   "/backup/theme.conf",
+		LinkTarget:   "../themes/mocha.conf",
+	}
+	regular := backup.BackupFile{
+		OriginalPath: "/config/hyprland.conf",
+		BackupPath:   "/backup/hyprland.conf",
+	}
+
+	assert.True(t, link.IsSymlink())
+	assert.False(t, regular.IsSymlink())
+}
+
+func TestValidateID(t *testing.T) {
+	assert.NoError(t, backup.ValidateID("2025-01-29_120000"))
+
+	for _, id := range []string{"", ".", "..", "../etc", "a/b", `a\b`} {
+		assert.ErrorIs(t, backup.ValidateID(id), backup.ErrInvalidBackupID, id)
+	}
+}
@@ -13,6 +13,11 @@ type Repository interface {
 	// FindAll retrieves all backups sorted by creation date (newest first)
 	FindAll(ctx context.Context) ([]*Backup, error)
 
+	// FindSummaries retrieves one page of backup summaries, newest first,
+	// without loading file lists. A limit of 0 returns every backup from
+	// offset on. The second result is the total number of backups.
+	FindSummaries(ctx context.Context, offset, limit int) ([]BackupSummary, int, error)
+
 	// Delete removes a backup
 	Delete(ctx context.Context, id string) error
 
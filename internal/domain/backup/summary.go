@@ -0,0 +1,18 @@
+package backup
+
+import "time"
+
+// BackupSummary describes a backup without its file list, so stores with
+// many backups can be listed without reading every manifest (value object)
+type BackupSummary struct {
+	ID          string    // Backup ID
+	Description string    // Backup description
+	CreatedAt   time.Time // When the backup was created
+	FileCount   int       // Number of files in the backup
+	SizeBytes   int64     // Total size of the backed up files
+}
+
+// Age returns how long ago the backup was created
+func (s BackupSummary) Age() time.Duration {
+	return time.Since(s.CreatedAt)
+}
@@ -0,0 +1,12 @@
+package benchmark
+
+import "errors"
+
+// Domain errors for benchmarking
+var (
+	ErrNotInHyprlandSession = errors.New("benchmark must run inside a Hyprland session")
+	ErrInvalidMetric        = errors.New("metric is invalid")
+	ErrNoSamples            = errors.New("no samples collected")
+	ErrNoMetrics            = errors.New("report must have at least one metric")
+	ErrBaselineNotFound     = errors.New("no baseline report found")
+)
@@ -0,0 +1,131 @@
+package benchmark
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// MetricName identifies a benchmark measurement
+type MetricName string
+
+const (
+	MetricRefreshRate       MetricName = "refresh_rate_hz"          // Focused monitor refresh rate
+	MetricFrameBudget       MetricName = "frame_budget_ms"          // Time available per frame
+	MetricMainLoopAvg       MetricName = "main_loop_latency_avg_ms" // Average compositor IPC round trip
+	MetricMainLoopP95       MetricName = "main_loop_latency_p95_ms" // 95th percentile IPC round trip
+	MetricMainLoopMax       MetricName = "main_loop_latency_max_ms" // Worst IPC round trip (stall)
+	MetricMissedFrameBudget MetricName = "missed_frame_budget_pct"  // Samples slower than one frame
+	MetricInputLatencyAvg   MetricName = "input_latency_avg_ms"     // Synthetic cursor move to reported position
+	MetricInputLatencyP95   MetricName = "input_latency_p95_ms"     // 95th percentile input latency
+	MetricGPUUtilizationAvg MetricName = "gpu_utilization_avg_pct"  // Average GPU busy percentage
+	MetricGPUUtilizationMax MetricName = "gpu_utilization_max_pct"  // Peak GPU busy percentage
+)
+
+// String returns the string representation of the metric name
+func (n MetricName) String() string {
+	return string(n)
+}
+
+// HigherIsBetter returns true if an increase in the metric is an improvement
+func (n MetricName) HigherIsBetter() bool {
+	return n == MetricRefreshRate
+}
+
+// Metric is a single named measurement
+type Metric struct {
+	name  MetricName
+	value float64
+	unit  string
+}
+
+// NewMetric creates a new metric, rejecting empty names and negative or
+// non-finite values
+func NewMetric(name MetricName, value float64, unit string) (Metric, error) {
+	if strings.TrimSpace(string(name)) == "" {
+		return Metric{}, fmt.Errorf("%w: name is required", ErrInvalidMetric)
+	}
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return Metric{}, fmt.Errorf("%w: %s has invalid value %v", ErrInvalidMetric, name, value)
+	}
+
+	return Metric{
+		name:  name,
+		value: value,
+		unit:  strings.TrimSpace(unit),
+	}, nil
+}
+
+// Name returns the metric name
+func (m Metric) Name() MetricName {
+	return m.name
+}
+
+// Value returns the measured value
+func (m Metric) Value() float64 {
+	return m.value
+}
+
+// Unit returns the unit of the value
+func (m Metric) Unit() string {
+	return m.unit
+}
+
+// String returns human-readable representation
+func (m Metric) String() string {
+	return fmt.Sprintf("%s: %.2f %s", m.name, m.value, m.unit)
+}
+
+// SampleStats summarizes a series of samples
+type SampleStats struct {
+	Count int
+	Min   float64
+	Avg   float64
+	P95   float64
+	Max   float64
+}
+
+// Summarize computes min, average, 95th percentile and max of samples
+func Summarize(samples []float64) (SampleStats, error) {
+	if len(samples) == 0 {
+		return SampleStats{}, ErrNoSamples
+	}
+
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, s := range sorted {
+		sum += s
+	}
+
+	// Nearest-rank percentile
+	rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+
+	return SampleStats{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Avg:   sum / float64(len(sorted)),
+		P95:   sorted[rank],
+		Max:   sorted[len(sorted)-1],
+	}, nil
+}
+
+// FractionAbove returns the percentage of samples strictly above threshold
+func FractionAbove(samples []float64, threshold float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	above := 0
+	for _, s := range samples {
+		if s > threshold {
+			above++
+		}
+	}
+	return 100 * float64(above) / float64(len(samples))
+}
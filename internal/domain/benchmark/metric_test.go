@@ -0,0 +1,82 @@
+package benchmark_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMetric(t *testing.T) {
+	t.Run("creates metric", func(t *testing.T) {
+		m, err := benchmark.NewMetric(benchmark.MetricMainLoopAvg, 1.5, "ms")
+		require.NoError(t, err)
+		assert.Equal(t, benchmark.MetricMainLoopAvg, m.Name())
+		assert.Equal(t, 1.5, m.Value())
+		assert.Equal(t, "ms", m.Unit())
+	})
+
+	tests := []struct {
+		name  string
+		value float64
+	}{
+		{"negative", -1},
+		{"NaN", math.NaN()},
+		{"infinite", math.Inf(1)},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name+" value", func(t *testing.T) {
+			_, err := benchmark.NewMetric(benchmark.MetricMainLoopAvg, tt.value, "ms")
+			assert.ErrorIs(t, err, benchmark.ErrInvalidMetric)
+		})
+	}
+}
+
+func TestMetricName_HigherIsBetter(t *testing.T) {
+	assert.True(t, benchmark.MetricRefreshRate.HigherIsBetter())
+	assert.False(t, benchmark.MetricMainLoopP95.HigherIsBetter())
+	assert.False(t, benchmark.MetricInputLatencyAvg.HigherIsBetter())
+	assert.False(t, benchmark.MetricGPUUtilizationAvg.HigherIsBetter())
+}
+
+func TestSummarize(t *testing.T) {
+	t.Run("computes statistics", func(t *testing.T) {
+		samples := make([]float64, 0, 20)
+		for i := 20; i >= 1; i-- {
+			samples = append(samples, float64(i))
+		}
+
+		stats, err := benchmark.Summarize(samples)
+		require.NoError(t, err)
+		assert.Equal(t, 20, stats.Count)
+		assert.Equal(t, 1.0, stats.Min)
+		assert.Equal(t, 10.5, stats.Avg)
+		assert.Equal(t, 19.0, stats.P95)
+		assert.Equal(t, 20.0, stats.Max)
+	})
+
+	t.Run("single sample", func(t *testing.T) {
+		stats, err := benchmark.Summarize([]float64{4})
+		require.NoError(t, err)
+		assert.Equal(t, 4.0, stats.P95)
+	})
+
+	t.Run("does not reorder input", func(t *testing.T) {
+		samples := []float64{3, 1, 2}
+		_, err := benchmark.Summarize(samples)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{3, 1, 2}, samples)
+	})
+
+	t.Run("rejects empty samples", func(t *testing.T) {
+		_, err := benchmark.Summarize(nil)
+		assert.ErrorIs(t, err, benchmark.ErrNoSamples)
+	})
+}
+
+func TestFractionAbove(t *testing.T) {
+	assert.Equal(t, 50.0, benchmark.FractionAbove([]float64{1, 2, 3, 4}, 2))
+	assert.Equal(t, 0.0, benchmark.FractionAbove(nil, 2))
+}
@@ -0,0 +1,147 @@
+package benchmark
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RegressionThreshold is the relative change (in percent) in the wrong
+// direction that counts as a regression against the baseline
+const RegressionThreshold = 10.0
+
+// Environment describes the system a benchmark ran on, so reports taken
+// before and after a driver or config change can be told apart
+type Environment struct {
+	HyprlandVersion string
+	KernelVersion   string
+	GPU             string
+	GPUDriver       string
+	Monitor         string
+}
+
+// Report is the result of a benchmark run
+type Report struct {
+	id          string
+	createdAt   time.Time
+	environment Environment
+	metrics     []Metric
+	notes       []string
+}
+
+// NewReport creates a new benchmark report
+func NewReport(environment Environment, metrics []Metric, notes []string) (*Report, error) {
+	return ReconstructReport(uuid.New().String(), time.Now(), environment, metrics, notes)
+}
+
+// ReconstructReport recreates a stored report
+func ReconstructReport(
+	id string,
+	createdAt time.Time,
+	environment Environment,
+	metrics []Metric,
+	notes []string,
+) (*Report, error) {
+	if len(metrics) == 0 {
+		return nil, ErrNoMetrics
+	}
+
+	return &Report{
+		id:          strings.TrimSpace(id),
+		createdAt:   createdAt,
+		environment: environment,
+		metrics:     append([]Metric(nil), metrics...),
+		notes:       append([]string(nil), notes...),
+	}, nil
+}
+
+// ID returns the report identifier
+func (r *Report) ID() string {
+	return r.id
+}
+
+// CreatedAt returns when the benchmark ran
+func (r *Report) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Environment returns the system the benchmark ran on
+func (r *Report) Environment() Environment {
+	return r.environment
+}
+
+// Metrics returns the measured metrics
+func (r *Report) Metrics() []Metric {
+	return append([]Metric(nil), r.metrics...)
+}
+
+// Notes returns notes about measurements that were skipped or degraded
+func (r *Report) Notes() []string {
+	return append([]string(nil), r.notes...)
+}
+
+// Metric returns the metric with the given name
+func (r *Report) Metric(name MetricName) (Metric, bool) {
+	for _, m := range r.metrics {
+		if m.name == name {
+			return m, true
+		}
+	}
+	return Metric{}, false
+}
+
+// MetricComparison compares one metric against the baseline
+type MetricComparison struct {
+	Name          MetricName
+	Unit          string
+	Baseline      float64
+	Current       float64
+	PercentChange float64
+	Regressed     bool
+}
+
+// Improved returns true if the metric moved in the better direction
+func (c MetricComparison) Improved() bool {
+	if c.Name.HigherIsBetter() {
+		return c.Current > c.Baseline
+	}
+	return c.Current < c.Baseline
+}
+
+// Compare compares the report's metrics against a baseline report
+// Metrics missing from either report are skipped
+func (r *Report) Compare(baseline *Report) []MetricComparison {
+	comparisons := make([]MetricComparison, 0, len(r.metrics))
+
+	for _, current := range r.metrics {
+		base, ok := baseline.Metric(current.name)
+		if !ok {
+			continue
+		}
+
+		change := 0.0
+		if base.value != 0 {
+			change = 100 * (current.value - base.value) / base.value
+		} else if current.value != 0 {
+			change = math.Inf(1)
+		}
+
+		worse := change
+		if current.name.HigherIsBetter() {
+			worse = -change
+		}
+
+		comparisons = append(comparisons, MetricComparison{
+			Name:          current.name,
+			Unit:          current.unit,
+			Baseline:      base.value,
+			Current:       current.value,
+			PercentChange: change,
+			Regressed:     worse > RegressionThreshold,
+		})
+	}
+
+	return comparisons
+}
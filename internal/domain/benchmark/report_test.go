@@ -0,0 +1,91 @@
+package benchmark_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustMetric(t *testing.T, name benchmark.MetricName, value float64, unit string) benchmark.Metric {
+	t.Helper()
+	m, err := benchmark.NewMetric(name, value, unit)
+	require.NoError(t, err)
+	return m
+}
+
+func TestNewReport(t *testing.T) {
+	t.Run("creates report", func(t *testing.T) {
+		report, err := benchmark.NewReport(
+			benchmark.Environment{HyprlandVersion: "0.45.0"},
+			[]benchmark.Metric{mustMetric(t, benchmark.MetricRefreshRate, 60, "Hz")},
+			nil,
+		)
+		require.NoError(t, err)
+		assert.NotEmpty(t, report.ID())
+		assert.False(t, report.CreatedAt().IsZero())
+		assert.Equal(t, "0.45.0", report.Environment().HyprlandVersion)
+
+		m, ok := report.Metric(benchmark.MetricRefreshRate)
+		require.True(t, ok)
+		assert.Equal(t, 60.0, m.Value())
+
+		_, ok = report.Metric(benchmark.MetricGPUUtilizationAvg)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects report without metrics", func(t *testing.T) {
+		_, err := benchmark.NewReport(benchmark.Environment{}, nil, []string{"nothing measured"})
+		assert.ErrorIs(t, err, benchmark.ErrNoMetrics)
+	})
+}
+
+func TestReport_Compare(t *testing.T) {
+	baseline, err := benchmark.NewReport(benchmark.Environment{}, []benchmark.Metric{
+		mustMetric(t, benchmark.MetricRefreshRate, 144, "Hz"),
+		mustMetric(t, benchmark.MetricMainLoopP95, 2, "ms"),
+		mustMetric(t, benchmark.MetricInputLatencyAvg, 10, "ms"),
+		mustMetric(t, benchmark.MetricGPUUtilizationAvg, 20, "%"),
+	}, nil)
+	require.NoError(t, err)
+
+	current, err := benchmark.NewReport(benchmark.Environment{}, []benchmark.Metric{
+		mustMetric(t, benchmark.MetricRefreshRate, 60, "Hz"),
+		mustMetric(t, benchmark.MetricMainLoopP95, 3, "ms"),
+		mustMetric(t, benchmark.MetricInputLatencyAvg, 8, "ms"),
+		mustMetric(t, benchmark.MetricMissedFrameBudget, 1, "%"),
+	}, nil)
+	require.NoError(t, err)
+
+	comparisons := current.Compare(baseline)
+	byName := make(map[benchmark.MetricName]benchmark.MetricComparison)
+	for _, c := range comparisons {
+		byName[c.Name] = c
+	}
+
+	t.Run("skips metrics missing from either report", func(t *testing.T) {
+		assert.Len(t, comparisons, 3)
+		assert.NotContains(t, byName, benchmark.MetricMissedFrameBudget)
+		assert.NotContains(t, byName, benchmark.MetricGPUUtilizationAvg)
+	})
+
+	t.Run("lower refresh rate is a regression", func(t *testing.T) {
+		c := byName[benchmark.MetricRefreshRate]
+		assert.True(t, c.Regressed)
+		assert.False(t, c.Improved())
+	})
+
+	t.Run("slower main loop is a regression", func(t *testing.T) {
+		c := byName[benchmark.MetricMainLoopP95]
+		assert.InDelta(t, 50.0, c.PercentChange, 0.001)
+		assert.True(t, c.Regressed)
+	})
+
+	t.Run("lower latency is an improvement", func(t *testing.T) {
+		c := byName[benchmark.MetricInputLatencyAvg]
+		assert.InDelta(t, -20.0, c.PercentChange, 0.001)
+		assert.False(t, c.Regressed)
+		assert.True(t, c.Improved())
+	})
+}
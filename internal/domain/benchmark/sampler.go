@@ -0,0 +1,45 @@
+package benchmark
+
+import (
+	"context"
+	"time"
+)
+
+// FrameTimingSampler measures compositor frame timing
+type FrameTimingSampler interface {
+	// SampleFrameTiming samples frame timing for the given duration
+	SampleFrameTiming(ctx context.Context, duration time.Duration) ([]Metric, error)
+}
+
+// InputLatencyProbe measures input handling latency
+type InputLatencyProbe interface {
+	// MeasureInputLatency injects synthetic input the given number of times
+	MeasureInputLatency(ctx context.Context, samples int) ([]Metric, error)
+}
+
+// GPUSampler measures GPU utilization
+type GPUSampler interface {
+	// SampleGPUUtilization samples GPU busy percentage for the given duration
+	SampleGPUUtilization(ctx context.Context, duration time.Duration) ([]Metric, error)
+}
+
+// EnvironmentDetector describes the system being benchmarked
+type EnvironmentDetector interface {
+	// InHyprlandSession returns true if running inside a Hyprland session
+	InHyprlandSession() bool
+
+	// DetectEnvironment reports versions, GPU and monitor
+	DetectEnvironment(ctx context.Context) (Environment, error)
+}
+
+// ReportStore persists benchmark reports
+type ReportStore interface {
+	// Save stores a report and returns where it was written
+	Save(ctx context.Context, report *Report) (string, error)
+
+	// SaveBaseline marks a report as the baseline for comparisons
+	SaveBaseline(ctx context.Context, report *Report) error
+
+	// LoadBaseline returns the baseline report or ErrBaselineNotFound
+	LoadBaseline(ctx context.Context) (*Report, error)
+}
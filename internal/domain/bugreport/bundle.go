@@ -0,0 +1,141 @@
+package bugreport
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ManifestName is the file in every bundle that lists its contents
+const ManifestName = "MANIFEST.txt"
+
+// Artifact is a single file in a bug report bundle
+type Artifact struct {
+	name    string
+	content []byte
+}
+
+// NewArtifact creates an artifact stored under name inside the bundle
+func NewArtifact(name string, content []byte) (Artifact, error) {
+	name = strings.TrimSpace(name)
+	clean := path.Clean(name)
+	if name == "" || path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return Artifact{}, fmt.Errorf("%w: %q", ErrInvalidArtifactName, name)
+	}
+
+	return Artifact{
+		name:    clean,
+		content: append([]byte(nil), content...),
+	}, nil
+}
+
+// Name returns the path of the artifact inside the bundle
+func (a Artifact) Name() string {
+	return a.name
+}
+
+// Content returns the artifact content
+func (a Artifact) Content() []byte {
+	return append([]byte(nil), a.content...)
+}
+
+// Size returns the content size in bytes
+func (a Artifact) Size() int {
+	return len(a.content)
+}
+
+// SkippedSource records a source that could not be collected
+type SkippedSource struct {
+	Source string
+	Reason string
+}
+
+// Bundle is a redacted collection of diagnostics attached to a bug report
+type Bundle struct {
+	createdAt  time.Time
+	artifacts  []Artifact
+	skipped    []SkippedSource
+	redactions int
+}
+
+// NewBundle creates an empty bundle
+func NewBundle(createdAt time.Time) *Bundle {
+	return &Bundle{createdAt: createdAt}
+}
+
+// CreatedAt returns when the bundle was created
+func (b *Bundle) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// Add redacts the artifact with the redactor and adds it to the bundle
+func (b *Bundle) Add(artifact Artifact, redactor *Redactor) error {
+	for _, existing := range b.artifacts {
+		if existing.name == artifact.name {
+			return fmt.Errorf("%w: %s", ErrDuplicateArtifact, artifact.name)
+		}
+	}
+
+	if redactor != nil {
+		redacted, count := redactor.Redact(string(artifact.content))
+		artifact.content = []byte(redacted)
+		b.redactions += count
+	}
+
+	b.artifacts = append(b.artifacts, artifact)
+	return nil
+}
+
+// Skip records a source that was not included and why
+func (b *Bundle) Skip(source, reason string) {
+	b.skipped = append(b.skipped, SkippedSource{Source: source, Reason: reason})
+}
+
+// Artifacts returns the bundle artifacts sorted by name
+func (b *Bundle) Artifacts() []Artifact {
+	artifacts := append([]Artifact(nil), b.artifacts...)
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].name < artifacts[j].name
+	})
+	return artifacts
+}
+
+// Skipped returns the sources that were not collected
+func (b *Bundle) Skipped() []SkippedSource {
+	return append([]SkippedSource(nil), b.skipped...)
+}
+
+// Redactions returns the number of values redacted across all artifacts
+func (b *Bundle) Redactions() int {
+	return b.redactions
+}
+
+// IsEmpty returns true if no artifact was collected
+func (b *Bundle) IsEmpty() bool {
+	return len(b.artifacts) == 0
+}
+
+// Manifest renders the bundle contents for reviewers
+func (b *Bundle) Manifest() string {
+	var sb strings.Builder
+
+	sb.WriteString("gohan bug report\n")
+	fmt.Fprintf(&sb, "Created: %s\n", b.createdAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&sb, "Redacted values: %d\n\n", b.redactions)
+
+	sb.WriteString("Files:\n")
+	for _, a := range b.Artifacts() {
+		fmt.Fprintf(&sb, "  %-48s %8d bytes\n", a.name, len(a.content))
+	}
+
+	if len(b.skipped) > 0 {
+		sb.WriteString("\nNot collected:\n")
+		for _, s := range b.skipped {
+			fmt.Fprintf(&sb, "  %s: %s\n", s.Source, s.Reason)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package bugreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArtifact(t *testing.T) {
+	t.Run("cleans name", func(t *testing.T) {
+		a, err := bugreport.NewArtifact("config//hypr/./hyprland.conf", []byte("x"))
+		require.NoError(t, err)
+		assert.Equal(t, "config/hypr/hyprland.conf", a.Name())
+		assert.Equal(t, 1, a.Size())
+	})
+
+	for _, name := range []string{"", "/etc/passwd", "../escape", "a/../../escape", "."} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			_, err := bugreport.NewArtifact(name, nil)
+			assert.ErrorIs(t, err, bugreport.ErrInvalidArtifactName)
+		})
+	}
+}
+
+func TestBundle(t *testing.T) {
+	redactor := bugreport.NewRedactor(bugreport.Identity{Username: "alice", HomeDir: "/home/alice"})
+	createdAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("redacts added artifacts", func(t *testing.T) {
+		bundle := bugreport.NewBundle(createdAt)
+		a, err := bugreport.NewArtifact("log.txt", []byte("opened /home/alice/file"))
+		require.NoError(t, err)
+
+		require.NoError(t, bundle.Add(a, redactor))
+		assert.Equal(t, "opened ~/file", string(bundle.Artifacts()[0].Content()))
+		assert.Equal(t, 1, bundle.Redactions())
+	})
+
+	t.Run("rejects duplicate artifacts", func(t *testing.T) {
+		bundle := bugreport.NewBundle(createdAt)
+		a, err := bugreport.NewArtifact("log.txt", []byte("x"))
+		require.NoError(t, err)
+
+		require.NoError(t, bundle.Add(a, redactor))
+		assert.ErrorIs(t, bundle.Add(a, redactor), bugreport.ErrDuplicateArtifact)
+	})
+
+	t.Run("manifest lists files and skipped sources", func(t *testing.T) {
+		bundle := bugreport.NewBundle(createdAt)
+		assert.True(t, bundle.IsEmpty())
+
+		b, _ := bugreport.NewArtifact("b.txt", []byte("bb"))
+		a, _ := bugreport.NewArtifact("a.txt", []byte("a"))
+		require.NoError(t, bundle.Add(b, nil))
+		require.NoError(t, bundle.Add(a, nil))
+		bundle.Skip("gpu", "lspci not installed")
+
+		assert.False(t, bundle.IsEmpty())
+		assert.Equal(t, "a.txt", bundle.Artifacts()[0].Name())
+
+		manifest := bundle.Manifest()
+		assert.Contains(t, manifest, "2025-10-01T12:00:00Z")
+		assert.Contains(t, manifest, "a.txt")
+		assert.Contains(t, manifest, "b.txt")
+		assert.Contains(t, manifest, "gpu: lspci not installed")
+	})
+}
@@ -0,0 +1,27 @@
+package bugreport
+
+import (
+	"context"
+	"time"
+)
+
+// Collector gathers one category of diagnostics for a bug report
+type Collector interface {
+	// Name identifies the source in the bundle manifest
+	Name() string
+
+	// Collect returns the artifacts for this source
+	// An error means the source is recorded as not collected
+	Collect(ctx context.Context) ([]Artifact, error)
+}
+
+// BundleWriter persists a bundle as an archive
+type BundleWriter interface {
+	// Write stores the bundle at path
+	Write(ctx context.Context, bundle *Bundle, path string) error
+}
+
+// DefaultFileName returns the archive name for a bundle created at t
+func DefaultFileName(t time.Time) string {
+	return "gohan-bugreport-" + t.Format("20060102-150405") + ".tar.gz"
+}
@@ -0,0 +1,10 @@
+package bugreport
+
+import "errors"
+
+// Domain errors for bug report bundles
+var (
+	ErrInvalidArtifactName = errors.New("artifact name must be a relative path inside the bundle")
+	ErrDuplicateArtifact   = errors.New("artifact already exists in bundle")
+	ErrEmptyBundle         = errors.New("bug report bundle has no artifacts")
+)
@@ -0,0 +1,134 @@
+package bugreport
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Redaction placeholders
+const (
+	RedactedUser     = "<user>"
+	RedactedHost     = "<hostname>"
+	RedactedEmail    = "<email>"
+	RedactedIP       = "<ip>"
+	RedactedMAC      = "<mac>"
+	RedactedSecret   = "<redacted>"
+	RedactedHomePath = "~"
+)
+
+// Identity holds the values that identify the machine and its user
+type Identity struct {
+	Username string
+	Hostname string
+	HomeDir  string
+}
+
+// genericNames are identity values too common to redact without mangling
+// unrelated log text
+var genericNames = map[string]bool{
+	"root":      true,
+	"user":      true,
+	"debian":    true,
+	"localhost": true,
+}
+
+var (
+	// Authorization values have a scheme before the credentials, so the
+	// whole rest of the line goes
+	authPattern     = regexp.MustCompile(`(?im)\b((?:[a-z0-9_-]*[_-])?authorization)(\s*[=:]\s*)(.*\S)`)
+	bearerPattern   = regexp.MustCompile(`(?i)\b(bearer)(\s+)([A-Za-z0-9._~+/=-]+)`)
+	userinfoPattern = regexp.MustCompile(`(?i)\b([a-z][a-z0-9+.-]*://)[^\s/?#@<>]+@`)
+	secretPattern   = regexp.MustCompile(`(?i)\b((?:[a-z0-9_]*_)?(?:password|passwd|secret|token|api_?key|authorization)[a-z0-9_]*)(\s*[=:]\s*)("[^"]*"|'[^']*'|[^\s,;]+)`)
+	emailPattern    = regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)
+	macPattern      = regexp.MustCompile(`\b(?:[0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}\b`)
+	ipv4Pattern     = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+)
+
+// Redactor removes personally identifiable information from text
+type Redactor struct {
+	identity Identity
+	userRe   *regexp.Regexp
+	hostRe   *regexp.Regexp
+}
+
+// NewRedactor creates a redactor for the given identity
+// Generic user and host names such as root or localhost are left alone
+func NewRedactor(identity Identity) *Redactor {
+	r := &Redactor{identity: identity}
+
+	if name := strings.TrimSpace(identity.Username); len(name) >= 2 && !genericNames[strings.ToLower(name)] {
+		r.userRe = regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+	}
+	if host := strings.TrimSpace(identity.Hostname); len(host) >= 2 && !genericNames[strings.ToLower(host)] {
+		r.hostRe = regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(host) + `\b`)
+	}
+
+	return r
+}
+
+// Redact returns text with secrets, URL credentials, the home directory,
+// user and host names, email addresses, MAC addresses and non-loopback IPv4
+// addresses replaced by placeholders, along with the number of replacements
+func (r *Redactor) Redact(text string) (string, int) {
+	count := 0
+
+	for _, re := range []*regexp.Regexp{authPattern, bearerPattern, secretPattern} {
+		text = redactValues(re, text, &count)
+	}
+	text = userinfoPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := userinfoPattern.FindStringSubmatch(match)
+		count++
+		return parts[1] + RedactedSecret + "@"
+	})
+
+	if home := strings.TrimRight(r.identity.HomeDir, "/"); home != "" && home != "/root" {
+		n := strings.Count(text, home)
+		if n > 0 {
+			text = strings.ReplaceAll(text, home, RedactedHomePath)
+			count += n
+		}
+	}
+
+	text, count = replaceCounting(emailPattern, text, RedactedEmail, count)
+	text, count = replaceCounting(macPattern, text, RedactedMAC, count)
+
+	text = ipv4Pattern.ReplaceAllStringFunc(text, func(match string) string {
+		ip := net.ParseIP(match)
+		if ip == nil || ip.IsLoopback() || ip.IsUnspecified() {
+			return match
+		}
+		count++
+		return RedactedIP
+	})
+
+	// Host names often contain the user name, so they go first
+	if r.hostRe != nil {
+		text, count = replaceCounting(r.hostRe, text, RedactedHost, count)
+	}
+	if r.userRe != nil {
+		text, count = replaceCounting(r.userRe, text, RedactedUser, count)
+	}
+
+	return text, count
+}
+
+// redactValues replaces the value, the third group of re, of every match
+// not already redacted
+func redactValues(re *regexp.Regexp, text string, count *int) string {
+	return re.ReplaceAllStringFunc(text, func(match string) string {
+		parts := re.FindStringSubmatch(match)
+		if parts[3] == RedactedSecret {
+			return match
+		}
+		*count++
+		return parts[1] + parts[2] + RedactedSecret
+	})
+}
+
+func replaceCounting(re *regexp.Regexp, text, placeholder string, count int) (string, int) {
+	return re.ReplaceAllStringFunc(text, func(string) string {
+		count++
+		return placeholder
+	}), count
+}
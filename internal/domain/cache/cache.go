@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrUnknownCache is returned for a cache name gohan does not manage
+	ErrUnknownCache = errors.New("unknown cache")
+
+	// ErrInvalidLocation is returned for a cache without an absolute path
+	ErrInvalidLocation = errors.New("invalid cache location")
+
+	// ErrPermissionRequired is returned when cleaning a cache needs root
+	ErrPermissionRequired = errors.New("cleaning this cache requires root")
+)
+
+// Kind names a cache of downloaded files gohan can report on and clean
+type Kind string
+
+const (
+	// KindAPTArchives is the apt download cache under /var/cache/apt/archives
+	KindAPTArchives Kind = "apt"
+
+	// KindBuild holds sources and artifacts gohan downloads to build from
+	KindBuild Kind = "build"
+
+	// KindTemplateRegistry holds configuration templates fetched from a
+	// template registry
+	KindTemplateRegistry Kind = "templates"
+
+	// KindSessions holds the working directories failed installations
+	// leave behind for debugging
+	KindSessions Kind = "sessions"
+)
+
+// AllKinds returns every cache in a stable order
+func AllKinds() []Kind {
+	return []Kind{KindAPTArchives, KindBuild, KindTemplateRegistry, KindSessions}
+}
+
+// ParseKind converts a cache name to a Kind
+func ParseKind(name string) (Kind, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, kind := range AllKinds() {
+		if string(kind) == name {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownCache, name)
+}
+
+// String returns the cache name
+func (k Kind) String() string {
+	return string(k)
+}
+
+// Description returns what the cache holds
+func (k Kind) Description() string {
+	switch k {
+	case KindAPTArchives:
+		return "apt package archives"
+	case KindBuild:
+		return "gohan build cache"
+	case KindTemplateRegistry:
+		return "template registry cache"
+	case KindSessions:
+		return "failed session artifacts"
+	default:
+		return string(k)
+	}
+}
+
+// Location is where a cache is kept
+type Location struct {
+	kind Kind
+	path string
+}
+
+// NewLocation creates a cache location; the path must be absolute
+func NewLocation(kind Kind, path string) (Location, error) {
+	if _, err := ParseKind(string(kind)); err != nil {
+		return Location{}, err
+	}
+	if !filepath.IsAbs(path) {
+		return Location{}, fmt.Errorf("%w: %s path %q is not absolute", ErrInvalidLocation, kind, path)
+	}
+	return Location{kind: kind, path: filepath.Clean(path)}, nil
+}
+
+// Kind returns which cache this is
+func (l Location) Kind() Kind {
+	return l.kind
+}
+
+// Path returns the cache directory
+func (l Location) Path() string {
+	return l.path
+}
+
+// Usage is how much a cache holds
+type Usage struct {
+	location Location
+	bytes    uint64
+	files    int
+}
+
+// NewUsage records the size of a cache
+func NewUsage(location Location, bytes uint64, files int) Usage {
+	return Usage{location: location, bytes: bytes, files: files}
+}
+
+// Location returns the cache measured
+func (u Usage) Location() Location {
+	return u.location
+}
+
+// Bytes returns the size of the cached files
+func (u Usage) Bytes() uint64 {
+	return u.bytes
+}
+
+// Files returns the number of cached files
+func (u Usage) Files() int {
+	return u.files
+}
+
+// IsEmpty returns true if the cache holds nothing
+func (u Usage) IsEmpty() bool {
+	return u.files == 0
+}
@@ -0,0 +1,42 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKind(t *testing.T) {
+	kind, err := cache.ParseKind(" APT ")
+	require.NoError(t, err)
+	assert.Equal(t, cache.KindAPTArchives, kind)
+
+	_, err = cache.ParseKind("thumbnails")
+	assert.ErrorIs(t, err, cache.ErrUnknownCache)
+}
+
+func TestNewLocation(t *testing.T) {
+	location, err := cache.NewLocation(cache.KindBuild, "/home/alice/.cache/gohan/build/")
+	require.NoError(t, err)
+	assert.Equal(t, "/home/alice/.cache/gohan/build", location.Path())
+	assert.Equal(t, cache.KindBuild, location.Kind())
+
+	_, err = cache.NewLocation(cache.KindBuild, "relative/build")
+	assert.ErrorIs(t, err, cache.ErrInvalidLocation)
+
+	_, err = cache.NewLocation(cache.Kind("thumbnails"), "/tmp")
+	assert.ErrorIs(t, err, cache.ErrUnknownCache)
+}
+
+func TestUsage(t *testing.T) {
+	location, err := cache.NewLocation(cache.KindAPTArchives, "/var/cache/apt/archives")
+	require.NoError(t, err)
+
+	usage := cache.NewUsage(location, 2048, 3)
+	assert.Equal(t, uint64(2048), usage.Bytes())
+	assert.Equal(t, 3, usage.Files())
+	assert.False(t, usage.IsEmpty())
+	assert.True(t, cache.NewUsage(location, 0, 0).IsEmpty())
+}
@@ -1,6 +1,9 @@
 package configuration
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // ConfigurationCategory represents the type/purpose of a configuration
 type ConfigurationCategory string
@@ -28,6 +31,19 @@ func (c ConfigurationCategory) String() string {
 	}
 }
 
+// ParseConfigurationCategory parses a user supplied category
+// An empty string selects the custom category
+func ParseConfigurationCategory(value string) (ConfigurationCategory, error) {
+	switch category := ConfigurationCategory(strings.ToLower(strings.TrimSpace(value))); category {
+	case "":
+		return CategoryCustom, nil
+	case CategoryDevelopment, CategoryProduction, CategoryTesting, CategoryCustom:
+		return category, nil
+	default:
+		return "", fmt.Errorf("%w: %q (expected development, production, testing or custom)", ErrInvalidCategory, value)
+	}
+}
+
 // ConfigurationMetadata is a value object containing descriptive information about a configuration
 type ConfigurationMetadata struct {
 	name        ConfigurationName
@@ -146,6 +162,16 @@ func (m ConfigurationMetadata) HasTags() bool {
 	return len(m.tags) > 0
 }
 
+// HasTag returns true if the metadata carries the tag
+func (m ConfigurationMetadata) HasTag(tag string) bool {
+	for _, t := range m.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // TagCount returns the number of tags
 func (m ConfigurationMetadata) TagCount() int {
 	return len(m.tags)
@@ -155,3 +181,15 @@ func (m ConfigurationMetadata) TagCount() int {
 func (m ConfigurationMetadata) Category() ConfigurationCategory {
 	return m.category
 }
+
+// WithTags returns a copy of the metadata with the given tags, validated
+// like the tags of new metadata
+func (m ConfigurationMetadata) WithTags(tags []string) (ConfigurationMetadata, error) {
+	processedTags, err := processTags(tags)
+	if err != nil {
+		return ConfigurationMetadata{}, err
+	}
+
+	m.tags = processedTags
+	return m, nil
+}
@@ -169,3 +169,28 @@ func TestConfigurationCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestParseConfigurationCategory(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    configuration.ConfigurationCategory
+		wantErr bool
+	}{
+		{"", configuration.CategoryCustom, false},
+		{"development", configuration.CategoryDevelopment, false},
+		{" Production ", configuration.CategoryProduction, false},
+		{"gaming", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			category, err := configuration.ParseConfigurationCategory(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, configuration.ErrInvalidCategory)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, category)
+		})
+	}
+}
@@ -91,6 +91,18 @@ func (t *ConfigurationTemplate) Version() int {
 	return t.version
 }
 
+// Retag replaces the template's tags and bumps its version
+func (t *ConfigurationTemplate) Retag(tags []string) error {
+	metadata, err := t.metadata.WithTags(tags)
+	if err != nil {
+		return err
+	}
+
+	t.metadata = metadata
+	t.version++
+	return nil
+}
+
 // Age returns how long ago the template was created
 func (t *ConfigurationTemplate) Age() time.Duration {
 	return time.Since(t.createdAt)
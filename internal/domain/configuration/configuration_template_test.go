@@ -176,3 +176,17 @@ func TestConfigurationTemplate_String(t *testing.T) {
 	assert.Contains(t, str, "Development")
 	assert.NotEmpty(t, str)
 }
+
+func TestConfigurationTemplate_Retag(t *testing.T) {
+	template, err := configuration.NewConfigurationTemplate(createValidMetadata(t, "laptop"), createValidManifest(t))
+	require.NoError(t, err)
+
+	require.NoError(t, template.Retag([]string{"laptop", "minimal", "laptop"}))
+	assert.Equal(t, []string{"laptop", "minimal"}, template.Metadata().Tags())
+	assert.Equal(t, "Test configuration", template.Metadata().Description(), "other metadata is kept")
+	assert.Equal(t, 2, template.Version())
+
+	err = template.Retag([]string{" "})
+	assert.ErrorIs(t, err, configuration.ErrInvalidTag)
+	assert.Equal(t, []string{"laptop", "minimal"}, template.Metadata().Tags(), "invalid tags leave the template unchanged")
+}
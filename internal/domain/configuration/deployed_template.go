@@ -0,0 +1,113 @@
+package configuration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeployedTemplate records a configuration file rendered from a template:
+// the template's hash at the time, the variables it was rendered with and
+// the rendered content. When a newer gohan ships a changed template, the
+// rendered content is the common ancestor for merging the user's edits
+// with the new version.
+type DeployedTemplate struct {
+	targetPath     string
+	sourceTemplate string
+	templateHash   string
+	rendered       string
+	vars           map[string]string
+	deployedAt     time.Time
+}
+
+// NewDeployedTemplate creates a deployed template record. Target, template
+// and template hash are required.
+func NewDeployedTemplate(
+	targetPath string,
+	sourceTemplate string,
+	templateHash string,
+	rendered string,
+	vars map[string]string,
+	deployedAt time.Time,
+) (DeployedTemplate, error) {
+	targetPath = strings.TrimSpace(targetPath)
+	sourceTemplate = strings.TrimSpace(sourceTemplate)
+	templateHash = strings.TrimSpace(templateHash)
+	if targetPath == "" {
+		return DeployedTemplate{}, fmt.Errorf("%w: target path cannot be empty", ErrInvalidDeployedTemplate)
+	}
+	if sourceTemplate == "" {
+		return DeployedTemplate{}, fmt.Errorf("%w: source template cannot be empty", ErrInvalidDeployedTemplate)
+	}
+	if templateHash == "" {
+		return DeployedTemplate{}, fmt.Errorf("%w: template hash cannot be empty", ErrInvalidDeployedTemplate)
+	}
+
+	copied := make(map[string]string, len(vars))
+	for k, v := range vars {
+		copied[k] = v
+	}
+
+	return DeployedTemplate{
+		targetPath:     targetPath,
+		sourceTemplate: sourceTemplate,
+		templateHash:   templateHash,
+		rendered:       rendered,
+		vars:           copied,
+		deployedAt:     deployedAt,
+	}, nil
+}
+
+// TargetPath returns where the file was deployed
+func (d DeployedTemplate) TargetPath() string {
+	return d.targetPath
+}
+
+// SourceTemplate returns the template the file was rendered from
+func (d DeployedTemplate) SourceTemplate() string {
+	return d.sourceTemplate
+}
+
+// TemplateHash returns the SHA-256 of the template when it was rendered
+func (d DeployedTemplate) TemplateHash() string {
+	return d.templateHash
+}
+
+// Rendered returns the content the template rendered to
+func (d DeployedTemplate) Rendered() string {
+	return d.rendered
+}
+
+// Vars returns a copy of the variables the template was rendered with
+func (d DeployedTemplate) Vars() map[string]string {
+	vars := make(map[string]string, len(d.vars))
+	for k, v := range d.vars {
+		vars[k] = v
+	}
+	return vars
+}
+
+// DeployedAt returns when the file was deployed
+func (d DeployedTemplate) DeployedAt() time.Time {
+	return d.deployedAt
+}
+
+// IsOutdated returns true if the template changed since the file was
+// rendered from it
+func (d DeployedTemplate) IsOutdated(currentTemplateHash string) bool {
+	return d.templateHash != currentTemplateHash
+}
+
+// IsModified returns true if the file's content is no longer what the
+// template rendered, because it was edited since
+func (d DeployedTemplate) IsModified(current string) bool {
+	return current != d.rendered
+}
+
+// HashTemplate returns the hash recorded for template content
+func HashTemplate(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
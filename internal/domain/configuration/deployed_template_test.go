@@ -0,0 +1,67 @@
+package configuration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeployedTemplate(t *testing.T) {
+	now := time.Now()
+
+	t.Run("requires target, template and hash", func(t *testing.T) {
+		_, err := configuration.NewDeployedTemplate("", "tmpl", "hash", "", nil, now)
+		assert.ErrorIs(t, err, configuration.ErrInvalidDeployedTemplate)
+		_, err = configuration.NewDeployedTemplate("/target", " ", "hash", "", nil, now)
+		assert.ErrorIs(t, err, configuration.ErrInvalidDeployedTemplate)
+		_, err = configuration.NewDeployedTemplate("/target", "tmpl", "", "", nil, now)
+		assert.ErrorIs(t, err, configuration.ErrInvalidDeployedTemplate)
+	})
+
+	t.Run("copies the variables", func(t *testing.T) {
+		vars := map[string]string{"username": "alice"}
+		record, err := configuration.NewDeployedTemplate("/target", "tmpl", "hash", "rendered", vars, now)
+		require.NoError(t, err)
+
+		vars["username"] = "bob"
+		record.Vars()["username"] = "carol"
+		assert.Equal(t, "alice", record.Vars()["username"])
+	})
+
+	t.Run("detects outdated templates and edited files", func(t *testing.T) {
+		hash := configuration.HashTemplate([]byte("template v1"))
+		record, err := configuration.NewDeployedTemplate("/target", "tmpl", hash, "rendered\n", nil, now)
+		require.NoError(t, err)
+
+		assert.False(t, record.IsOutdated(configuration.HashTemplate([]byte("template v1"))))
+		assert.True(t, record.IsOutdated(configuration.HashTemplate([]byte("template v2"))))
+		assert.False(t, record.IsModified("rendered\n"))
+		assert.True(t, record.IsModified("rendered\nedited\n"))
+	})
+}
+
+func TestPlanTemplateUpgrade(t *testing.T) {
+	record, err := configuration.NewDeployedTemplate("/target", "tmpl", "hash", "a\nb\nc\n", nil, time.Now())
+	require.NoError(t, err)
+
+	t.Run("re-renders files nobody edited", func(t *testing.T) {
+		plan := configuration.PlanTemplateUpgrade(record, "a\nb\nc\n", "a\nB\nc\n")
+		assert.Equal(t, configuration.UpgradeRerender, plan.Action())
+		assert.Equal(t, "a\nB\nc\n", plan.Content())
+	})
+
+	t.Run("merges edited files", func(t *testing.T) {
+		plan := configuration.PlanTemplateUpgrade(record, "A\nb\nc\n", "a\nb\nC\n")
+		assert.Equal(t, configuration.UpgradeMerge, plan.Action())
+		assert.Equal(t, "A\nb\nC\n", plan.Content())
+	})
+
+	t.Run("reports overlapping changes as conflicts", func(t *testing.T) {
+		plan := configuration.PlanTemplateUpgrade(record, "a\nmine\nc\n", "a\ntheirs\nc\n")
+		assert.Equal(t, configuration.UpgradeConflict, plan.Action())
+		assert.Equal(t, 1, plan.Conflicts())
+	})
+}
@@ -11,6 +11,7 @@ var (
 	ErrDescriptionTooLong       = errors.New("description exceeds maximum length")
 	ErrTooManyTags              = errors.New("too many tags (maximum 10)")
 	ErrInvalidTag               = errors.New("tag is invalid")
+	ErrInvalidCategory          = errors.New("configuration category is invalid")
 
 	// Manifest errors
 	ErrInvalidManifest          = errors.New("configuration manifest is invalid")
@@ -23,6 +24,12 @@ var (
 	ErrDuplicateTemplate    = errors.New("configuration template with this name already exists")
 	ErrInvalidTemplateState = errors.New("configuration template is in invalid state")
 
+	// Deployed template errors
+	ErrInvalidDeployedTemplate = errors.New("deployed template record is invalid")
+
+	// Live session errors
+	ErrInvalidLiveSessionAction = errors.New("live session action is invalid")
+
 	// Composition errors
 	ErrConflictingComponents = errors.New("configurations have conflicting components")
 	ErrIncompatibleConfigs   = errors.New("configurations are incompatible for composition")
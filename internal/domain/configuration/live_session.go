@@ -0,0 +1,83 @@
+package configuration
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// PendingApplyServiceName is the systemd user unit moving a deployment
+// deferred to the next login into place
+const PendingApplyServiceName = "gohan-apply-pending.service"
+
+// LiveSessionAction is what a deployment does when it would replace the
+// configuration the running Hyprland session reads
+type LiveSessionAction string
+
+const (
+	// LiveSessionPrompt asks which of the other actions to take
+	LiveSessionPrompt LiveSessionAction = "prompt"
+	// LiveSessionNow deploys at once; Hyprland reloads the file as soon as
+	// it changes
+	LiveSessionNow LiveSessionAction = "now"
+	// LiveSessionNextLogin stages the deployment in the pending directory,
+	// swapped in at the next login before Hyprland starts
+	LiveSessionNextLogin LiveSessionAction = "next-login"
+)
+
+// ParseLiveSessionAction parses prompt, now or next-login; empty is prompt
+func ParseLiveSessionAction(value string) (LiveSessionAction, error) {
+	switch action := LiveSessionAction(strings.ToLower(strings.TrimSpace(value))); action {
+	case "":
+		return LiveSessionPrompt, nil
+	case LiveSessionPrompt, LiveSessionNow, LiveSessionNextLogin:
+		return action, nil
+	default:
+		return "", fmt.Errorf("%w: %q (expected prompt, now or next-login)", ErrInvalidLiveSessionAction, value)
+	}
+}
+
+// IsLiveSessionConfig reports whether a target is read by the running
+// compositor, so replacing it changes the session at once and a broken
+// version can take the session down
+func IsLiveSessionConfig(targetPath string) bool {
+	return strings.HasSuffix(filepath.ToSlash(targetPath), "/hypr/hyprland.conf")
+}
+
+// PendingApplyUnit is the systemd user service that applies a deployment
+// deferred to the next login. It runs when the user's systemd instance
+// starts, before the graphical session, and only while a deployment is
+// pending.
+type PendingApplyUnit struct {
+	executable string
+	pendingDir string
+}
+
+// NewPendingApplyUnit creates the unit. executable is the absolute path of
+// the gohan binary and pendingDir the absolute path of the pending
+// deployment.
+func NewPendingApplyUnit(executable, pendingDir string) (PendingApplyUnit, error) {
+	if !filepath.IsAbs(executable) {
+		return PendingApplyUnit{}, fmt.Errorf("executable must be an absolute path: %q", executable)
+	}
+	if !filepath.IsAbs(pendingDir) {
+		return PendingApplyUnit{}, fmt.Errorf("pending directory must be an absolute path: %q", pendingDir)
+	}
+	return PendingApplyUnit{executable: executable, pendingDir: pendingDir}, nil
+}
+
+// RenderService returns the oneshot service applying the pending deployment
+func (u PendingApplyUnit) RenderService() string {
+	var b strings.Builder
+	b.WriteString("# Managed by gohan\n")
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=Apply the gohan configuration deployed for the next login\n")
+	fmt.Fprintf(&b, "ConditionDirectoryNotEmpty=%s\n", u.pendingDir)
+	b.WriteString("Before=graphical-session-pre.target\n")
+	b.WriteString("\n[Service]\n")
+	b.WriteString("Type=oneshot\n")
+	fmt.Fprintf(&b, "ExecStart=%s config apply-pending\n", u.executable)
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=default.target\n")
+	return b.String()
+}
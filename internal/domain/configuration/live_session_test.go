@@ -0,0 +1,41 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLiveSessionAction(t *testing.T) {
+	action, err := configuration.ParseLiveSessionAction("")
+	require.NoError(t, err)
+	assert.Equal(t, configuration.LiveSessionPrompt, action)
+
+	action, err = configuration.ParseLiveSessionAction("Next-Login")
+	require.NoError(t, err)
+	assert.Equal(t, configuration.LiveSessionNextLogin, action)
+
+	_, err = configuration.ParseLiveSessionAction("later")
+	assert.ErrorIs(t, err, configuration.ErrInvalidLiveSessionAction)
+}
+
+func TestIsLiveSessionConfig(t *testing.T) {
+	assert.True(t, configuration.IsLiveSessionConfig("/home/alice/.config/hypr/hyprland.conf"))
+	assert.False(t, configuration.IsLiveSessionConfig("/home/alice/.config/hypr/hyprlock.conf"))
+	assert.False(t, configuration.IsLiveSessionConfig("/home/alice/.config/kitty/kitty.conf"))
+}
+
+func TestPendingApplyUnit(t *testing.T) {
+	_, err := configuration.NewPendingApplyUnit("gohan", "/home/alice/.gohan/pending")
+	assert.Error(t, err)
+
+	unit, err := configuration.NewPendingApplyUnit("/usr/local/bin/gohan", "/home/alice/.gohan/pending")
+	require.NoError(t, err)
+
+	service := unit.RenderService()
+	assert.Contains(t, service, "ConditionDirectoryNotEmpty=/home/alice/.gohan/pending\n")
+	assert.Contains(t, service, "ExecStart=/usr/local/bin/gohan config apply-pending\n")
+	assert.Contains(t, service, "WantedBy=default.target\n")
+}
@@ -0,0 +1,99 @@
+package configuration
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Markers around the part of a deployed file that gohan owns. Everything
+// before the begin line and after the end line belongs to the user and
+// survives later deployments.
+const (
+	ManagedBlockBegin = "GOHAN MANAGED BLOCK BEGIN"
+	ManagedBlockEnd   = "GOHAN MANAGED BLOCK END"
+)
+
+// managedBlockNote tells users where their own settings go
+const managedBlockNote = " - replaced on every deploy, add your settings outside this block"
+
+// commentSyntax is how a file type comments out a line
+type commentSyntax struct {
+	open, close string
+}
+
+// managedBlockSyntax maps file extensions to their comment syntax. JSON has
+// no comments and a single top-level value, so JSON files are not wrapped
+// and are replaced whole.
+var managedBlockSyntax = map[string]commentSyntax{
+	"":      {open: "# "}, // e.g. swaylock/config
+	".conf": {open: "# "},
+	".ini":  {open: "# "},
+	".toml": {open: "# "},
+	".css":  {open: "/* ", close: " */"},
+}
+
+// SupportsManagedBlock reports whether the file at path can carry a
+// managed block
+func SupportsManagedBlock(path string) bool {
+	_, ok := managedBlockSyntax[filepath.Ext(path)]
+	return ok
+}
+
+// WrapManagedBlock surrounds content with managed-block markers commented
+// out for the file at path. Content of file types without comments is
+// returned as is.
+func WrapManagedBlock(path, content string) string {
+	syntax, ok := managedBlockSyntax[filepath.Ext(path)]
+	if !ok {
+		return content
+	}
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return syntax.open + ManagedBlockBegin + managedBlockNote + syntax.close + "\n" +
+		content +
+		syntax.open + ManagedBlockEnd + syntax.close + "\n"
+}
+
+// HasManagedBlock reports whether content contains a complete managed block
+func HasManagedBlock(content string) bool {
+	_, _, ok := findManagedBlock(content)
+	return ok
+}
+
+// ReplaceManagedBlock replaces the managed block in existing with block, a
+// rendering wrapped by WrapManagedBlock, keeping the user's lines around
+// it. It reports false when either has no managed block, in which case
+// the whole file is the deployer's to replace.
+func ReplaceManagedBlock(existing, block string) (string, bool) {
+	if !HasManagedBlock(block) {
+		return "", false
+	}
+	start, end, ok := findManagedBlock(existing)
+	if !ok {
+		return "", false
+	}
+	return existing[:start] + block + existing[end:], true
+}
+
+// findManagedBlock returns the offsets of the first managed block in
+// content, from the start of its begin line to past its end line
+func findManagedBlock(content string) (start, end int, ok bool) {
+	begin := strings.Index(content, ManagedBlockBegin)
+	if begin < 0 {
+		return 0, 0, false
+	}
+	start = strings.LastIndex(content[:begin], "\n") + 1
+
+	closing := strings.Index(content[begin:], ManagedBlockEnd)
+	if closing < 0 {
+		return 0, 0, false
+	}
+	end = begin + closing
+	if newline := strings.Index(content[end:], "\n"); newline >= 0 {
+		end += newline + 1
+	} else {
+		end = len(content)
+	}
+	return start, end, true
+}
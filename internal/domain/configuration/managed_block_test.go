@@ -0,0 +1,53 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapManagedBlock(t *testing.T) {
+	t.Run("uses the file type's comments", func(t *testing.T) {
+		conf := configuration.WrapManagedBlock("kitty.conf", "font_size 11")
+		assert.Equal(t,
+			"# GOHAN MANAGED BLOCK BEGIN - replaced on every deploy, add your settings outside this block\n"+
+				"font_size 11\n"+
+				"# GOHAN MANAGED BLOCK END\n",
+			conf)
+
+		css := configuration.WrapManagedBlock("style.css", "* { font-size: 11px; }\n")
+		assert.Contains(t, css, "/* GOHAN MANAGED BLOCK END */\n")
+	})
+
+	t.Run("leaves JSON alone", func(t *testing.T) {
+		assert.False(t, configuration.SupportsManagedBlock("config.jsonc"))
+		assert.Equal(t, `{"layer": "top"}`, configuration.WrapManagedBlock("config.jsonc", `{"layer": "top"}`))
+	})
+}
+
+func TestReplaceManagedBlock(t *testing.T) {
+	oldBlock := configuration.WrapManagedBlock("hyprland.conf", "gaps_in = 5\n")
+	newBlock := configuration.WrapManagedBlock("hyprland.conf", "gaps_in = 8\n")
+
+	t.Run("keeps the user's lines around the block", func(t *testing.T) {
+		existing := "source = ~/.config/hypr/mine.conf\n" + oldBlock + "bind = SUPER, B, exec, firefox\n"
+
+		got, ok := configuration.ReplaceManagedBlock(existing, newBlock)
+		require.True(t, ok)
+		assert.Equal(t, "source = ~/.config/hypr/mine.conf\n"+newBlock+"bind = SUPER, B, exec, firefox\n", got)
+	})
+
+	t.Run("reports files without a block", func(t *testing.T) {
+		_, ok := configuration.ReplaceManagedBlock("gaps_in = 5\n", newBlock)
+		assert.False(t, ok)
+
+		_, ok = configuration.ReplaceManagedBlock(oldBlock, "gaps_in = 8\n")
+		assert.False(t, ok)
+	})
+
+	t.Run("ignores an unterminated block", func(t *testing.T) {
+		assert.False(t, configuration.HasManagedBlock("# GOHAN MANAGED BLOCK BEGIN\ngaps_in = 5\n"))
+	})
+}
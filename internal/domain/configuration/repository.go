@@ -32,6 +32,10 @@ type Repository interface {
 	// Results are ordered by creation date (most recent first)
 	ListByTag(ctx context.Context, tag string) ([]*ConfigurationTemplate, error)
 
+	// Search retrieves templates matching every criterion of the query
+	// Results are ordered by creation date (most recent first)
+	Search(ctx context.Context, query TemplateQuery) ([]*ConfigurationTemplate, error)
+
 	// Delete removes a configuration template by ID
 	// Returns ErrTemplateNotFound if the template doesn't exist
 	Delete(ctx context.Context, id string) error
@@ -0,0 +1,183 @@
+package configuration
+
+import "strings"
+
+// Markers around lines that both the user and the template changed
+const (
+	ConflictStart     = "<<<<<<< your changes"
+	ConflictSeparator = "======="
+	ConflictEnd       = ">>>>>>> updated template"
+)
+
+// MergeFileSuffix is appended to a file's path for a merge with conflicts
+// left to resolve by hand
+const MergeFileSuffix = ".gohan-merge"
+
+// MergeResult is the outcome of a three-way merge
+type MergeResult struct {
+	content   string
+	conflicts int
+}
+
+// Content returns the merged content, with conflict markers where both
+// sides changed the same lines
+func (r MergeResult) Content() string {
+	return r.content
+}
+
+// Conflicts returns the number of conflicting regions
+func (r MergeResult) Conflicts() int {
+	return r.conflicts
+}
+
+// HasConflicts returns true if the merge needs resolving by hand
+func (r MergeResult) HasConflicts() bool {
+	return r.conflicts > 0
+}
+
+// MergeThreeWay merges line by line the changes made from base to current
+// (the user's edits) with those made from base to updated (the new
+// template's rendering). Regions only one side changed take that side's
+// lines; regions both changed differently are conflicts.
+func MergeThreeWay(base, current, updated string) MergeResult {
+	baseLines := splitLines(base)
+	currentLines := splitLines(current)
+	updatedLines := splitLines(updated)
+	toCurrent := matchLines(baseLines, currentLines)
+	toUpdated := matchLines(baseLines, updatedLines)
+
+	var merged []string
+	conflicts := 0
+	b, c, u := 0, 0, 0
+	for {
+		// The next base line both sides kept ends the region
+		next := -1
+		for i := b; i < len(baseLines); i++ {
+			if toCurrent[i] >= 0 && toUpdated[i] >= 0 {
+				next = i
+				break
+			}
+		}
+		bEnd, cEnd, uEnd := len(baseLines), len(currentLines), len(updatedLines)
+		if next >= 0 {
+			bEnd, cEnd, uEnd = next, toCurrent[next], toUpdated[next]
+		}
+
+		lines, conflict := mergeRegion(baseLines[b:bEnd], currentLines[c:cEnd], updatedLines[u:uEnd])
+		merged = append(merged, lines...)
+		if conflict {
+			conflicts++
+		}
+
+		if next < 0 {
+			break
+		}
+		merged = append(merged, baseLines[next])
+		b, c, u = next+1, toCurrent[next]+1, toUpdated[next]+1
+	}
+
+	return MergeResult{content: strings.Join(merged, ""), conflicts: conflicts}
+}
+
+// HasConflictMarkers returns true if content still holds unresolved
+// conflict markers
+func HasConflictMarkers(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if line == ConflictStart || line == ConflictEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeRegion resolves one region between lines both sides kept
+func mergeRegion(base, current, updated []string) ([]string, bool) {
+	switch {
+	case equalLines(current, updated), equalLines(updated, base):
+		return current, false
+	case equalLines(current, base):
+		return updated, false
+	}
+
+	lines := []string{ConflictStart + "\n"}
+	lines = append(lines, terminated(current)...)
+	lines = append(lines, ConflictSeparator+"\n")
+	lines = append(lines, terminated(updated)...)
+	lines = append(lines, ConflictEnd+"\n")
+	return lines, true
+}
+
+// splitLines splits content into lines that keep their newline
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// terminated returns the lines, the last one ending with a newline so a
+// marker can follow it
+func terminated(lines []string) []string {
+	if len(lines) == 0 || strings.HasSuffix(lines[len(lines)-1], "\n") {
+		return lines
+	}
+	out := append([]string(nil), lines...)
+	out[len(out)-1] += "\n"
+	return out
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLines pairs the lines of a with those of b along a longest common
+// subsequence; unmatched lines of a map to -1
+func matchLines(a, b []string) []int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	matches := make([]int, len(a))
+	for i := range matches {
+		matches[i] = -1
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			matches[i] = j
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return matches
+}
@@ -0,0 +1,87 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeThreeWay(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          string
+		current       string
+		updated       string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:    "takes template changes to unedited lines",
+			base:    "gaps_in = 5\ngaps_out = 10\n",
+			current: "gaps_in = 5\ngaps_out = 10\n",
+			updated: "gaps_in = 5\ngaps_out = 20\n",
+			want:    "gaps_in = 5\ngaps_out = 20\n",
+		},
+		{
+			name:    "keeps edits the template did not touch",
+			base:    "one\ntwo\nthree\nfour\n",
+			current: "one\nTWO\nthree\nfour\n",
+			updated: "one\ntwo\nthree\nFOUR\n",
+			want:    "one\nTWO\nthree\nFOUR\n",
+		},
+		{
+			name:    "keeps lines the user added and the template added",
+			base:    "one\ntwo\n",
+			current: "zero\none\ntwo\n",
+			updated: "one\ntwo\nthree\n",
+			want:    "zero\none\ntwo\nthree\n",
+		},
+		{
+			name:    "takes the same change made on both sides once",
+			base:    "one\ntwo\n",
+			current: "one\n2\n",
+			updated: "one\n2\n",
+			want:    "one\n2\n",
+		},
+		{
+			name:    "drops lines the template removed",
+			base:    "one\nold\ntwo\n",
+			current: "one\nold\ntwo\nmine\n",
+			updated: "one\ntwo\n",
+			want:    "one\ntwo\nmine\n",
+		},
+		{
+			name:          "marks overlapping changes",
+			base:          "one\ntwo\nthree\n",
+			current:       "one\nmine\nthree\n",
+			updated:       "one\ntheirs\nthree\n",
+			want:          "one\n<<<<<<< your changes\nmine\n=======\ntheirs\n>>>>>>> updated template\nthree\n",
+			wantConflicts: 1,
+		},
+		{
+			name:          "terminates the last line before a marker",
+			base:          "one\ntwo",
+			current:       "one\nmine",
+			updated:       "one\ntheirs",
+			want:          "one\n<<<<<<< your changes\nmine\n=======\ntheirs\n>>>>>>> updated template\n",
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := configuration.MergeThreeWay(tt.base, tt.current, tt.updated)
+			assert.Equal(t, tt.want, result.Content())
+			assert.Equal(t, tt.wantConflicts, result.Conflicts())
+			assert.Equal(t, tt.wantConflicts > 0, result.HasConflicts())
+		})
+	}
+}
+
+func TestHasConflictMarkers(t *testing.T) {
+	conflicted := configuration.MergeThreeWay("a\n", "b\n", "c\n").Content()
+	assert.True(t, configuration.HasConflictMarkers(conflicted))
+	assert.False(t, configuration.HasConflictMarkers("a\n=======\nb\n"))
+	assert.False(t, configuration.HasConflictMarkers("resolved\n"))
+}
@@ -0,0 +1,80 @@
+package configuration
+
+import "strings"
+
+// TemplateQuery is a value object selecting configuration templates
+// Empty fields match every template; all given criteria must match
+type TemplateQuery struct {
+	nameContains string
+	category     ConfigurationCategory
+	tags         []string
+}
+
+// NewTemplateQuery creates a query for templates whose name contains the
+// given text (case-insensitive), in the category if one is given, carrying
+// every one of the tags
+func NewTemplateQuery(nameContains string, category ConfigurationCategory, tags []string) TemplateQuery {
+	query := TemplateQuery{
+		nameContains: strings.TrimSpace(nameContains),
+		category:     category,
+	}
+
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		query.tags = append(query.tags, tag)
+	}
+
+	return query
+}
+
+// NameContains returns the name substring to match
+func (q TemplateQuery) NameContains() string {
+	return q.nameContains
+}
+
+// Category returns the category to match, empty for any
+func (q TemplateQuery) Category() ConfigurationCategory {
+	return q.category
+}
+
+// Tags returns a copy of the tags a template must carry
+func (q TemplateQuery) Tags() []string {
+	if len(q.tags) == 0 {
+		return nil
+	}
+	tags := make([]string, len(q.tags))
+	copy(tags, q.tags)
+	return tags
+}
+
+// IsEmpty returns true if the query matches every template
+func (q TemplateQuery) IsEmpty() bool {
+	return q.nameContains == "" && q.category == "" && len(q.tags) == 0
+}
+
+// Matches returns true if the template satisfies every criterion
+func (q TemplateQuery) Matches(template *ConfigurationTemplate) bool {
+	metadata := template.Metadata()
+
+	if q.category != "" && metadata.Category() != q.category {
+		return false
+	}
+
+	if q.nameContains != "" &&
+		!strings.Contains(strings.ToLower(metadata.Name().String()), strings.ToLower(q.nameContains)) {
+		return false
+	}
+
+	for _, tag := range q.tags {
+		if !metadata.HasTag(tag) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,58 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queryTestTemplate(t *testing.T, name string, category configuration.ConfigurationCategory, tags ...string) *configuration.ConfigurationTemplate {
+	t.Helper()
+
+	hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.41.2", nil)
+	require.NoError(t, err)
+	manifest, err := configuration.NewConfigurationManifest([]installation.ComponentSelection{hyprland}, 0, false)
+	require.NoError(t, err)
+	metadata, err := configuration.NewConfigurationMetadata(name, "", "", tags, category)
+	require.NoError(t, err)
+	template, err := configuration.NewConfigurationTemplate(metadata, manifest)
+	require.NoError(t, err)
+	return template
+}
+
+func TestTemplateQuery_Matches(t *testing.T) {
+	template := queryTestTemplate(t, "Work Laptop", configuration.CategoryDevelopment, "laptop", "minimal")
+
+	tests := []struct {
+		name  string
+		query configuration.TemplateQuery
+		want  bool
+	}{
+		{"empty query", configuration.NewTemplateQuery("", "", nil), true},
+		{"name substring ignores case", configuration.NewTemplateQuery("laptop", "", nil), true},
+		{"name mismatch", configuration.NewTemplateQuery("desktop", "", nil), false},
+		{"category", configuration.NewTemplateQuery("", configuration.CategoryDevelopment, nil), true},
+		{"category mismatch", configuration.NewTemplateQuery("", configuration.CategoryTesting, nil), false},
+		{"all tags", configuration.NewTemplateQuery("", "", []string{"laptop", "minimal"}), true},
+		{"missing tag", configuration.NewTemplateQuery("", "", []string{"laptop", "gaming"}), false},
+		{"combined", configuration.NewTemplateQuery("work", configuration.CategoryDevelopment, []string{"minimal"}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.Matches(template))
+		})
+	}
+}
+
+func TestNewTemplateQuery(t *testing.T) {
+	query := configuration.NewTemplateQuery("  work ", "", []string{"laptop", " laptop", "", "minimal"})
+
+	assert.Equal(t, "work", query.NameContains())
+	assert.Equal(t, []string{"laptop", "minimal"}, query.Tags(), "tags are trimmed and deduplicated")
+	assert.False(t, query.IsEmpty())
+	assert.True(t, configuration.NewTemplateQuery(" ", "", []string{""}).IsEmpty())
+}
@@ -0,0 +1,69 @@
+package configuration
+
+// TemplateUpgradeAction is how a deployed file takes a new template version
+type TemplateUpgradeAction string
+
+const (
+	// UpgradeRerender replaces a file nobody edited with the new rendering
+	UpgradeRerender TemplateUpgradeAction = "re-render"
+	// UpgradeMerge keeps the user's edits and applies the template's changes
+	UpgradeMerge TemplateUpgradeAction = "merge"
+	// UpgradeConflict means edits and template changes overlap and the
+	// merge must be resolved by hand
+	UpgradeConflict TemplateUpgradeAction = "conflict"
+)
+
+// String returns the action's name
+func (a TemplateUpgradeAction) String() string {
+	return string(a)
+}
+
+// TemplateUpgrade is the plan for bringing one deployed file up to date
+// with its template
+type TemplateUpgrade struct {
+	record    DeployedTemplate
+	action    TemplateUpgradeAction
+	content   string
+	conflicts int
+}
+
+// PlanTemplateUpgrade decides how the file deployed as record, now holding
+// current, takes updated, the new template's rendering
+func PlanTemplateUpgrade(record DeployedTemplate, current, updated string) TemplateUpgrade {
+	if !record.IsModified(current) {
+		return TemplateUpgrade{record: record, action: UpgradeRerender, content: updated}
+	}
+
+	merge := MergeThreeWay(record.Rendered(), current, updated)
+	action := UpgradeMerge
+	if merge.HasConflicts() {
+		action = UpgradeConflict
+	}
+	return TemplateUpgrade{
+		record:    record,
+		action:    action,
+		content:   merge.Content(),
+		conflicts: merge.Conflicts(),
+	}
+}
+
+// Record returns the deployed file's record
+func (u TemplateUpgrade) Record() DeployedTemplate {
+	return u.record
+}
+
+// Action returns how the file takes the new template
+func (u TemplateUpgrade) Action() TemplateUpgradeAction {
+	return u.action
+}
+
+// Content returns the file's new content; with conflicts, it holds the
+// conflict markers to resolve
+func (u TemplateUpgrade) Content() string {
+	return u.content
+}
+
+// Conflicts returns the number of conflicting regions
+func (u TemplateUpgrade) Conflicts() int {
+	return u.conflicts
+}
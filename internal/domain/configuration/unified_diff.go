@@ -0,0 +1,116 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is how many unchanged lines surround each change in a hunk
+const diffContext = 3
+
+// noNewline follows a line that ends its file without a newline
+const noNewline = "\\ No newline at end of file\n"
+
+// diffLine is one line of an edit script: kept (' '), removed ('-') or
+// added ('+')
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// UnifiedDiff returns the changes from one content to another in unified
+// diff format, with fromName and toName in the file headers. Identical
+// content gives "".
+func UnifiedDiff(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+
+	script := editScript(splitLines(from), splitLines(to))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+
+	// Line numbers before each script entry, in from and in to
+	fromLine, toLine := make([]int, len(script)+1), make([]int, len(script)+1)
+	for i, line := range script {
+		fromLine[i+1], toLine[i+1] = fromLine[i], toLine[i]
+		if line.op != '+' {
+			fromLine[i+1]++
+		}
+		if line.op != '-' {
+			toLine[i+1]++
+		}
+	}
+
+	for start := 0; start < len(script); {
+		// Find the next change and extend the hunk while changes follow
+		// within twice the context
+		first := start
+		for first < len(script) && script[first].op == ' ' {
+			first++
+		}
+		if first == len(script) {
+			break
+		}
+		last := first
+		for i := first + 1; i < len(script) && i <= last+2*diffContext; i++ {
+			if script[i].op != ' ' {
+				last = i
+			}
+		}
+
+		begin := max(first-diffContext, start)
+		end := min(last+diffContext+1, len(script))
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(fromLine[begin], fromLine[end]-fromLine[begin]),
+			hunkRange(toLine[begin], toLine[end]-toLine[begin]))
+		for _, line := range script[begin:end] {
+			out.WriteByte(line.op)
+			out.WriteString(line.text)
+			if !strings.HasSuffix(line.text, "\n") {
+				out.WriteString("\n" + noNewline)
+			}
+		}
+		start = end
+	}
+
+	return out.String()
+}
+
+// hunkRange formats the start and length of a hunk side. An empty side
+// starts at the line before it.
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	default:
+		return fmt.Sprintf("%d,%d", before+1, count)
+	}
+}
+
+// editScript turns a into b along a longest common subsequence, removals
+// before additions within each changed region
+func editScript(a, b []string) []diffLine {
+	matches := matchLines(a, b)
+
+	var script []diffLine
+	j := 0
+	for i, line := range a {
+		if matches[i] < 0 {
+			script = append(script, diffLine{op: '-', text: line})
+			continue
+		}
+		for ; j < matches[i]; j++ {
+			script = append(script, diffLine{op: '+', text: b[j]})
+		}
+		script = append(script, diffLine{op: ' ', text: line})
+		j++
+	}
+	for ; j < len(b); j++ {
+		script = append(script, diffLine{op: '+', text: b[j]})
+	}
+	return script
+}
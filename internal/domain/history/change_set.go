@@ -0,0 +1,116 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+// PackageChange describes a package whose installed version changed
+// between two points in history
+type PackageChange struct {
+	Name        string
+	FromVersion string // Empty when the package was newly installed
+	ToVersion   string
+}
+
+// IsNewInstall returns true if the package was not installed before
+func (c PackageChange) IsNewInstall() bool {
+	return c.FromVersion == ""
+}
+
+// ConfigChange describes a configuration file whose deployed content
+// changed between two points in history
+type ConfigChange struct {
+	Path     string
+	FromHash string // Empty when the file was newly deployed
+	ToHash   string
+}
+
+// IsNewFile returns true if the file was not deployed before
+func (c ConfigChange) IsNewFile() bool {
+	return c.FromHash == ""
+}
+
+// ChangeSet is what gohan changed on the machine between two points in
+// history: the records in between and the resulting package and config diffs
+type ChangeSet struct {
+	From     time.Time
+	To       time.Time
+	Records  []InstallationRecord // Oldest first
+	Packages []PackageChange      // Sorted by name
+	Configs  []ConfigChange       // Sorted by path
+}
+
+// IsEmpty returns true if nothing changed between the two points
+func (c ChangeSet) IsEmpty() bool {
+	return len(c.Packages) == 0 && len(c.Configs) == 0
+}
+
+// ChangesBetween diffs the installed package set and deployed config hashes
+// at from against those at to. The state at a point in time is built by
+// replaying the successful records up to it, later records winning.
+func ChangesBetween(records []InstallationRecord, from, to time.Time) (ChangeSet, error) {
+	if from.IsZero() || to.IsZero() {
+		return ChangeSet{}, ErrInvalidPeriod
+	}
+	if to.Before(from) {
+		return ChangeSet{}, ErrInvalidTimeRange
+	}
+
+	ordered := make([]InstallationRecord, len(records))
+	copy(ordered, records)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].RecordedAt().Before(ordered[j].RecordedAt())
+	})
+
+	changes := ChangeSet{From: from, To: to}
+	beforePackages, afterPackages := make(map[string]string), make(map[string]string)
+	beforeConfigs, afterConfigs := make(map[string]string), make(map[string]string)
+
+	for _, record := range ordered {
+		recordedAt := record.RecordedAt()
+		if recordedAt.After(to) {
+			break
+		}
+		inWindow := recordedAt.After(from)
+		if inWindow {
+			changes.Records = append(changes.Records, record)
+		}
+		if !record.WasSuccessful() {
+			continue
+		}
+
+		for _, pkg := range record.Metadata().InstalledPackages() {
+			afterPackages[pkg.Name()] = pkg.Version()
+			if !inWindow {
+				beforePackages[pkg.Name()] = pkg.Version()
+			}
+		}
+		for _, file := range record.ConfigFiles() {
+			afterConfigs[file.Path()] = file.Hash()
+			if !inWindow {
+				beforeConfigs[file.Path()] = file.Hash()
+			}
+		}
+	}
+
+	for name, version := range afterPackages {
+		if previous, ok := beforePackages[name]; !ok || previous != version {
+			changes.Packages = append(changes.Packages, PackageChange{Name: name, FromVersion: previous, ToVersion: version})
+		}
+	}
+	sort.Slice(changes.Packages, func(i, j int) bool {
+		return changes.Packages[i].Name < changes.Packages[j].Name
+	})
+
+	for path, hash := range afterConfigs {
+		if previous, ok := beforeConfigs[path]; !ok || previous != hash {
+			changes.Configs = append(changes.Configs, ConfigChange{Path: path, FromHash: previous, ToHash: hash})
+		}
+	}
+	sort.Slice(changes.Configs, func(i, j int) bool {
+		return changes.Configs[i].Path < changes.Configs[j].Path
+	})
+
+	return changes, nil
+}
@@ -0,0 +1,125 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigFile(t *testing.T) {
+	file, err := history.NewConfigFile(" /home/user/.config/hypr/hyprland.conf ", "0123456789abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user/.config/hypr/hyprland.conf", file.Path())
+	assert.Equal(t, "0123456789abcdef", file.Hash())
+	assert.Equal(t, "/home/user/.config/hypr/hyprland.conf (0123456789ab)", file.String())
+
+	_, err = history.NewConfigFile("", "abc")
+	assert.ErrorIs(t, err, history.ErrInvalidConfigFile)
+	_, err = history.NewConfigFile("/etc/foo", " ")
+	assert.ErrorIs(t, err, history.ErrInvalidConfigFile)
+}
+
+func TestInstallationRecord_WithConfigFiles(t *testing.T) {
+	record := createTestRecord(t, "success", nil, 1)
+	assert.Empty(t, record.ConfigFiles())
+
+	file, err := history.NewConfigFile("/home/user/.config/waybar/config.jsonc", "abc")
+	require.NoError(t, err)
+	withFiles := record.WithConfigFiles([]history.ConfigFile{file})
+
+	assert.Equal(t, []history.ConfigFile{file}, withFiles.ConfigFiles())
+	assert.Empty(t, record.ConfigFiles(), "original record is unchanged")
+}
+
+func TestChangesBetween(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	initial := createChangeRecord(t, "success", base,
+		map[string]string{"hyprland": "0.40.0", "waybar": "0.10.0"},
+		map[string]string{"/home/u/.config/hypr/hyprland.conf": "aaa"})
+	upgrade := createChangeRecord(t, "success", base.Add(48*time.Hour),
+		map[string]string{"hyprland": "0.41.0", "kitty": "0.35.0"},
+		map[string]string{"/home/u/.config/hypr/hyprland.conf": "bbb", "/home/u/.config/kitty/kitty.conf": "ccc"})
+	failed := createChangeRecord(t, "failed", base.Add(72*time.Hour),
+		map[string]string{"fuzzel": "1.9.0"},
+		nil)
+	later := createChangeRecord(t, "success", base.Add(96*time.Hour),
+		map[string]string{"waybar": "0.11.0"},
+		nil)
+	records := []history.InstallationRecord{later, failed, upgrade, initial}
+
+	t.Run("diffs packages and configs between two points", func(t *testing.T) {
+		changes, err := history.ChangesBetween(records, base.Add(time.Hour), base.Add(80*time.Hour))
+		require.NoError(t, err)
+
+		require.Len(t, changes.Records, 2)
+		assert.Equal(t, upgrade.ID(), changes.Records[0].ID())
+		assert.Equal(t, failed.ID(), changes.Records[1].ID())
+
+		assert.Equal(t, []history.PackageChange{
+			{Name: "hyprland", FromVersion: "0.40.0", ToVersion: "0.41.0"},
+			{Name: "kitty", ToVersion: "0.35.0"},
+		}, changes.Packages)
+		assert.True(t, changes.Packages[1].IsNewInstall())
+
+		assert.Equal(t, []history.ConfigChange{
+			{Path: "/home/u/.config/hypr/hyprland.conf", FromHash: "aaa", ToHash: "bbb"},
+			{Path: "/home/u/.config/kitty/kitty.conf", ToHash: "ccc"},
+		}, changes.Configs)
+		assert.True(t, changes.Configs[1].IsNewFile())
+	})
+
+	t.Run("nothing changed in a quiet window", func(t *testing.T) {
+		changes, err := history.ChangesBetween(records, base.Add(time.Hour), base.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.True(t, changes.IsEmpty())
+		assert.Empty(t, changes.Records)
+	})
+
+	t.Run("rejects an inverted range", func(t *testing.T) {
+		_, err := history.ChangesBetween(records, base.Add(time.Hour), base)
+		assert.ErrorIs(t, err, history.ErrInvalidTimeRange)
+	})
+}
+
+func createChangeRecord(
+	t *testing.T,
+	outcomeStr string,
+	recordedAt time.Time,
+	packageVersions map[string]string,
+	configHashes map[string]string,
+) history.InstallationRecord {
+	var packages []history.InstalledPackage
+	for name, version := range packageVersions {
+		pkg, err := history.NewInstalledPackage(name, version, 1024)
+		require.NoError(t, err)
+		packages = append(packages, pkg)
+	}
+
+	installedAt := recordedAt.Add(-time.Minute)
+	metadata, err := history.NewInstallationMetadata("hyprland", "latest", installedAt, recordedAt, packages)
+	require.NoError(t, err)
+
+	systemCtx, _ := history.NewSystemContext("OS", "", "", "")
+	outcome, _ := history.NewInstallationOutcome(outcomeStr)
+
+	var failureDetails *history.FailureDetails
+	if outcomeStr == "failed" {
+		fd, _ := history.NewFailureDetails("test failure", recordedAt, "test", "ERR")
+		failureDetails = &fd
+	}
+
+	record, err := history.NewInstallationRecord("session-123", outcome, metadata, systemCtx, failureDetails, recordedAt)
+	require.NoError(t, err)
+
+	var files []history.ConfigFile
+	for path, hash := range configHashes {
+		file, err := history.NewConfigFile(path, hash)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+	return record.WithConfigFiles(files)
+}
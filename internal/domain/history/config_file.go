@@ -0,0 +1,47 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigFile is a value object for a configuration file deployed by an
+// installation, identified by its path and the SHA-256 of its content
+type ConfigFile struct {
+	path string
+	hash string
+}
+
+// NewConfigFile creates a config file entry. Path and hash are required.
+func NewConfigFile(path, hash string) (ConfigFile, error) {
+	path = strings.TrimSpace(path)
+	hash = strings.TrimSpace(hash)
+	if path == "" || hash == "" {
+		return ConfigFile{}, ErrInvalidConfigFile
+	}
+
+	return ConfigFile{path: path, hash: hash}, nil
+}
+
+// Path returns where the file was deployed
+func (f ConfigFile) Path() string {
+	return f.path
+}
+
+// Hash returns the SHA-256 of the deployed content
+func (f ConfigFile) Hash() string {
+	return f.hash
+}
+
+// String returns human-readable representation
+func (f ConfigFile) String() string {
+	return fmt.Sprintf("%s (%s)", f.path, shortHash(f.hash))
+}
+
+// shortHash abbreviates a content hash for display
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,69 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConflictResolution is a value object for a package conflict detected
+// during an installation and the strategy chosen to resolve it
+type ConflictResolution struct {
+	packageName        string
+	conflictingPackage string
+	reason             string
+	strategy           string
+	applied            bool
+}
+
+// NewConflictResolution creates a conflict resolution. Both package names
+// and the strategy are required.
+func NewConflictResolution(packageName, conflictingPackage, reason, strategy string, applied bool) (ConflictResolution, error) {
+	packageName = strings.TrimSpace(packageName)
+	conflictingPackage = strings.TrimSpace(conflictingPackage)
+	strategy = strings.TrimSpace(strategy)
+	if packageName == "" || conflictingPackage == "" || strategy == "" {
+		return ConflictResolution{}, ErrInvalidConflictResolution
+	}
+
+	return ConflictResolution{
+		packageName:        packageName,
+		conflictingPackage: conflictingPackage,
+		reason:             strings.TrimSpace(reason),
+		strategy:           strategy,
+		applied:            applied,
+	}, nil
+}
+
+// PackageName returns the package being installed
+func (c ConflictResolution) PackageName() string {
+	return c.packageName
+}
+
+// ConflictingPackage returns the package it conflicted with
+func (c ConflictResolution) ConflictingPackage() string {
+	return c.conflictingPackage
+}
+
+// Reason returns why the packages conflict
+func (c ConflictResolution) Reason() string {
+	return c.reason
+}
+
+// Strategy returns the resolution strategy (remove, replace, skip or abort)
+func (c ConflictResolution) Strategy() string {
+	return c.strategy
+}
+
+// Applied returns true if the resolution was carried out
+func (c ConflictResolution) Applied() bool {
+	return c.applied
+}
+
+// String returns human-readable representation
+func (c ConflictResolution) String() string {
+	status := "applied"
+	if !c.applied {
+		status = "not applied"
+	}
+	return fmt.Sprintf("%s conflicts with %s: %s (%s)", c.packageName, c.conflictingPackage, c.strategy, status)
+}
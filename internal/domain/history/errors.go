@@ -32,6 +32,27 @@ var (
 	// System context errors
 	ErrInvalidSystemContext = errors.New("system context is invalid")
 
+	// Scope errors
+	ErrInvalidScope = errors.New("history scope is invalid")
+
+	// Preflight check errors
+	ErrInvalidPreflightCheck = errors.New("preflight check is invalid")
+
+	// Conflict errors
+	ErrInvalidConflictResolution = errors.New("conflict resolution is invalid")
+
+	// Skipped component errors
+	ErrInvalidSkippedComponent = errors.New("skipped component is invalid")
+
+	// Invocation errors
+	ErrInvalidInvocation = errors.New("invocation is invalid")
+
+	// Phase timing errors
+	ErrInvalidPhaseTiming = errors.New("phase timing is invalid")
+
+	// Config file errors
+	ErrInvalidConfigFile = errors.New("config file is invalid")
+
 	// Period errors
 	ErrInvalidPeriod = errors.New("installation period is invalid")
 
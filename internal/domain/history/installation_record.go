@@ -13,6 +13,14 @@ type InstallationRecord struct {
 	metadata       InstallationMetadata
 	systemContext  SystemContext
 	failureDetails *FailureDetails
+	warnings       []string
+	preflight      []PreflightCheck
+	configFiles    []ConfigFile
+	conflicts      []ConflictResolution
+	skipped        []SkippedComponent
+	phaseTimings   []PhaseTiming
+	scope          Scope
+	invocation     Invocation
 	recordedAt     time.Time
 }
 
@@ -140,6 +148,141 @@ func (r InstallationRecord) RecordedAt() time.Time {
 	return r.recordedAt
 }
 
+// Warnings returns a copy of the warnings raised during the installation
+func (r InstallationRecord) Warnings() []string {
+	warnings := make([]string, len(r.warnings))
+	copy(warnings, r.warnings)
+	return warnings
+}
+
+// HasWarnings returns true if warnings were raised during the installation
+func (r InstallationRecord) HasWarnings() bool {
+	return len(r.warnings) > 0
+}
+
+// WithWarnings returns a copy of the record carrying the given warnings
+// Blank warnings are dropped
+func (r InstallationRecord) WithWarnings(warnings []string) InstallationRecord {
+	kept := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		if w = strings.TrimSpace(w); w != "" {
+			kept = append(kept, w)
+		}
+	}
+	r.warnings = kept
+	return r
+}
+
+// PreflightChecks returns a copy of the preflight results embedded in the record
+func (r InstallationRecord) PreflightChecks() []PreflightCheck {
+	checks := make([]PreflightCheck, len(r.preflight))
+	copy(checks, r.preflight)
+	return checks
+}
+
+// HasPreflightChecks returns true if preflight results were embedded
+func (r InstallationRecord) HasPreflightChecks() bool {
+	return len(r.preflight) > 0
+}
+
+// WithPreflightChecks returns a copy of the record carrying the preflight
+// results that blocked or degraded the installation
+func (r InstallationRecord) WithPreflightChecks(checks []PreflightCheck) InstallationRecord {
+	r.preflight = make([]PreflightCheck, len(checks))
+	copy(r.preflight, checks)
+	return r
+}
+
+// ConfigFiles returns a copy of the configuration files the installation deployed
+func (r InstallationRecord) ConfigFiles() []ConfigFile {
+	files := make([]ConfigFile, len(r.configFiles))
+	copy(files, r.configFiles)
+	return files
+}
+
+// WithConfigFiles returns a copy of the record carrying the deployed
+// configuration files and their content hashes
+func (r InstallationRecord) WithConfigFiles(files []ConfigFile) InstallationRecord {
+	r.configFiles = make([]ConfigFile, len(files))
+	copy(r.configFiles, files)
+	return r
+}
+
+// Conflicts returns a copy of the package conflicts the installation resolved
+func (r InstallationRecord) Conflicts() []ConflictResolution {
+	conflicts := make([]ConflictResolution, len(r.conflicts))
+	copy(conflicts, r.conflicts)
+	return conflicts
+}
+
+// WithConflicts returns a copy of the record carrying the package conflicts
+// detected during installation and how they were resolved
+func (r InstallationRecord) WithConflicts(conflicts []ConflictResolution) InstallationRecord {
+	r.conflicts = make([]ConflictResolution, len(conflicts))
+	copy(r.conflicts, conflicts)
+	return r
+}
+
+// SkippedComponents returns a copy of the components the installation
+// left out
+func (r InstallationRecord) SkippedComponents() []SkippedComponent {
+	skipped := make([]SkippedComponent, len(r.skipped))
+	copy(skipped, r.skipped)
+	return skipped
+}
+
+// WithSkippedComponents returns a copy of the record carrying the
+// components the installation left out and why
+func (r InstallationRecord) WithSkippedComponents(skipped []SkippedComponent) InstallationRecord {
+	r.skipped = make([]SkippedComponent, len(skipped))
+	copy(r.skipped, skipped)
+	return r
+}
+
+// PhaseTimings returns a copy of the estimated and actual durations of
+// the installation's phases
+func (r InstallationRecord) PhaseTimings() []PhaseTiming {
+	timings := make([]PhaseTiming, len(r.phaseTimings))
+	copy(timings, r.phaseTimings)
+	return timings
+}
+
+// HasPhaseTimings returns true if the installation's phases were timed
+func (r InstallationRecord) HasPhaseTimings() bool {
+	return len(r.phaseTimings) > 0
+}
+
+// WithPhaseTimings returns a copy of the record carrying how long each
+// phase was estimated to take and took
+func (r InstallationRecord) WithPhaseTimings(timings []PhaseTiming) InstallationRecord {
+	r.phaseTimings = make([]PhaseTiming, len(timings))
+	copy(r.phaseTimings, timings)
+	return r
+}
+
+// Scope returns whose history the record belongs to
+func (r InstallationRecord) Scope() Scope {
+	return r.scope
+}
+
+// WithScope returns a copy of the record in the given scope
+func (r InstallationRecord) WithScope(scope Scope) InstallationRecord {
+	r.scope = scope
+	return r
+}
+
+// Invocation returns how gohan was run for the installation; zero if it
+// was not recorded
+func (r InstallationRecord) Invocation() Invocation {
+	return r.invocation
+}
+
+// WithInvocation returns a copy of the record carrying how gohan was run
+func (r InstallationRecord) WithInvocation(invocation Invocation) InstallationRecord {
+	r.invocation = invocation
+	return r
+}
+
 // WasSuccessful returns true if installation was successful
 func (r InstallationRecord) WasSuccessful() bool {
 	return r.outcome.IsSuccessful()
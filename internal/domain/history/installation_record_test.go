@@ -293,6 +293,98 @@ func TestInstallationRecord_HasFailureDetails(t *testing.T) {
 	})
 }
 
+func TestInstallationRecord_WithWarnings(t *testing.T) {
+	record := createTestRecord(t, "success", nil, 1)
+	assert.False(t, record.HasWarnings())
+	assert.Empty(t, record.Warnings())
+
+	withWarnings := record.WithWarnings([]string{"[preflight] Low disk space", "  ", "[skipped] Skipped hyprpaper"})
+
+	assert.True(t, withWarnings.HasWarnings())
+	assert.Equal(t, []string{"[preflight] Low disk space", "[skipped] Skipped hyprpaper"}, withWarnings.Warnings())
+	assert.Equal(t, record.ID(), withWarnings.ID())
+	assert.False(t, record.HasWarnings(), "Original record should be unchanged")
+}
+
+func TestInstallationRecord_WithPreflightChecks(t *testing.T) {
+	record := createTestRecord(t, "failed", nil, 1)
+	assert.False(t, record.HasPreflightChecks())
+
+	blocker, err := history.NewPreflightCheck("disk_space", "fail", "5.0 GB available", "10 GB", "Free up disk space")
+	require.NoError(t, err)
+
+	withChecks := record.WithPreflightChecks([]history.PreflightCheck{blocker})
+
+	require.True(t, withChecks.HasPreflightChecks())
+	assert.Equal(t, "disk_space: fail (actual: 5.0 GB available, expected: 10 GB)", withChecks.PreflightChecks()[0].String())
+	assert.False(t, withChecks.PreflightChecks()[0].Passed())
+	assert.False(t, record.HasPreflightChecks(), "Original record should be unchanged")
+}
+
+func TestInstallationRecord_WithConflicts(t *testing.T) {
+	record := createTestRecord(t, "success", nil, 1)
+	assert.Empty(t, record.Conflicts())
+
+	conflict, err := history.NewConflictResolution("hyprland", "sway", "both provide a session", "remove", true)
+	require.NoError(t, err)
+	withConflicts := record.WithConflicts([]history.ConflictResolution{conflict})
+
+	require.Len(t, withConflicts.Conflicts(), 1)
+	assert.Equal(t, "hyprland conflicts with sway: remove (applied)", withConflicts.Conflicts()[0].String())
+	assert.Empty(t, record.Conflicts(), "original record is unchanged")
+
+	_, err = history.NewConflictResolution("hyprland", "", "", "remove", false)
+	assert.ErrorIs(t, err, history.ErrInvalidConflictResolution)
+}
+
+func TestInstallationRecord_WithScope(t *testing.T) {
+	record := createTestRecord(t, "success", nil, 1)
+	assert.True(t, record.Scope().IsSystem(), "records default to the system scope")
+
+	scope, err := history.NewUserScope("alice")
+	require.NoError(t, err)
+
+	scoped := record.WithScope(scope)
+
+	assert.Equal(t, "user:alice", scoped.Scope().String())
+	assert.Equal(t, record.ID(), scoped.ID())
+	assert.True(t, record.Scope().IsSystem(), "Original record should be unchanged")
+}
+
+func TestInstallationRecord_WithInvocation(t *testing.T) {
+	record := createTestRecord(t, "success", nil, 1)
+	assert.True(t, record.Invocation().IsZero())
+
+	invocation, err := history.NewInvocation(
+		"gohan install --components hyprland",
+		map[string]string{"components": "hyprland"},
+		"abc123", "1.2.0", "deadbeef", "alice",
+	)
+	require.NoError(t, err)
+	withInvocation := record.WithInvocation(invocation)
+
+	assert.Equal(t, "gohan install --components hyprland", withInvocation.Invocation().CommandLine())
+	assert.Equal(t, []string{"components"}, withInvocation.Invocation().FlagNames())
+	assert.Equal(t, "gohan install --components hyprland (gohan 1.2.0, alice)", withInvocation.Invocation().String())
+	assert.True(t, record.Invocation().IsZero(), "original record is unchanged")
+
+	_, err = history.NewInvocation("  ", nil, "", "", "", "")
+	assert.ErrorIs(t, err, history.ErrInvalidInvocation)
+}
+
+func TestNewPreflightCheck(t *testing.T) {
+	_, err := history.NewPreflightCheck("  ", "fail", "", "", "")
+	assert.ErrorIs(t, err, history.ErrInvalidPreflightCheck)
+
+	_, err = history.NewPreflightCheck("gpu_support", "", "", "", "")
+	assert.ErrorIs(t, err, history.ErrInvalidPreflightCheck)
+
+	check, err := history.NewPreflightCheck("gpu_support", "pass", "", "", "")
+	require.NoError(t, err)
+	assert.True(t, check.Passed())
+	assert.Equal(t, "gpu_support: pass", check.String())
+}
+
 func TestInstallationRecord_PackageName(t *testing.T) {
 	record := createTestRecord(t, "success", nil, 1)
 	assert.Equal(t, "test-package", record.PackageName())
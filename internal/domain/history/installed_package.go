@@ -1,8 +1,10 @@
 package history
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // InstalledPackage is a value object representing a package that was installed
@@ -61,3 +63,10 @@ func (p InstalledPackage) Equals(other InstalledPackage) bool {
 func (p InstalledPackage) String() string {
 	return fmt.Sprintf("%s v%s (%.2f MB)", p.name, p.version, p.SizeMB())
 }
+
+// PackageInventory reports what the package manager actually installed
+type PackageInventory interface {
+	// InstalledBetween returns the packages installed or upgraded between
+	// from and to, with their installed versions and sizes
+	InstalledBetween(ctx context.Context, from, to time.Time) ([]InstalledPackage, error)
+}
@@ -0,0 +1,103 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Invocation is a value object recording how gohan was run for an
+// installation, so support can reproduce the conditions of a failure
+type Invocation struct {
+	commandLine string
+	flags       map[string]string
+	configHash  string
+	version     string
+	commit      string
+	user        string
+}
+
+// NewInvocation creates an invocation. The command line is required.
+func NewInvocation(commandLine string, flags map[string]string, configHash, version, commit, user string) (Invocation, error) {
+	commandLine = strings.TrimSpace(commandLine)
+	if commandLine == "" {
+		return Invocation{}, ErrInvalidInvocation
+	}
+
+	invocation := Invocation{
+		commandLine: commandLine,
+		configHash:  strings.TrimSpace(configHash),
+		version:     strings.TrimSpace(version),
+		commit:      strings.TrimSpace(commit),
+		user:        strings.TrimSpace(user),
+	}
+	if len(flags) > 0 {
+		invocation.flags = make(map[string]string, len(flags))
+		for name, value := range flags {
+			invocation.flags[name] = value
+		}
+	}
+
+	return invocation, nil
+}
+
+// CommandLine returns the command line, quoted for a POSIX shell
+func (i Invocation) CommandLine() string {
+	return i.commandLine
+}
+
+// Flags returns a copy of the flags that were set, by name
+func (i Invocation) Flags() map[string]string {
+	if i.flags == nil {
+		return nil
+	}
+	flags := make(map[string]string, len(i.flags))
+	for name, value := range i.flags {
+		flags[name] = value
+	}
+	return flags
+}
+
+// FlagNames returns the names of the flags that were set, sorted
+func (i Invocation) FlagNames() []string {
+	names := make([]string, 0, len(i.flags))
+	for name := range i.flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConfigHash returns the SHA-256 of the configuration file, or "" if
+// there was none
+func (i Invocation) ConfigHash() string {
+	return i.configHash
+}
+
+// Version returns the gohan version
+func (i Invocation) Version() string {
+	return i.version
+}
+
+// Commit returns the commit gohan was built from
+func (i Invocation) Commit() string {
+	return i.commit
+}
+
+// User returns the invoking user
+func (i Invocation) User() string {
+	return i.user
+}
+
+// IsZero returns true if no invocation was recorded
+func (i Invocation) IsZero() bool {
+	return i.commandLine == ""
+}
+
+// String returns human-readable representation
+func (i Invocation) String() string {
+	if i.IsZero() {
+		return "invocation not recorded"
+	}
+	return fmt.Sprintf("%s (gohan %s, %s)", i.commandLine, i.version, i.user)
+}
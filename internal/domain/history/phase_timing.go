@@ -0,0 +1,63 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PhaseTiming is a value object for how long a phase of an installation
+// was estimated to take when it started, and how long it took
+type PhaseTiming struct {
+	phase     string
+	estimated time.Duration
+	actual    time.Duration
+}
+
+// NewPhaseTiming creates a phase timing. The phase is required and
+// durations cannot be negative.
+func NewPhaseTiming(phase string, estimated, actual time.Duration) (PhaseTiming, error) {
+	phase = strings.TrimSpace(phase)
+	if phase == "" || estimated < 0 || actual < 0 {
+		return PhaseTiming{}, ErrInvalidPhaseTiming
+	}
+
+	return PhaseTiming{phase: phase, estimated: estimated, actual: actual}, nil
+}
+
+// Phase returns the installation phase
+func (t PhaseTiming) Phase() string {
+	return t.phase
+}
+
+// Estimated returns how long the phase was expected to take
+func (t PhaseTiming) Estimated() time.Duration {
+	return t.estimated
+}
+
+// Actual returns how long the phase took
+func (t PhaseTiming) Actual() time.Duration {
+	return t.actual
+}
+
+// Delta returns how much longer the phase took than estimated; negative
+// when it was faster
+func (t PhaseTiming) Delta() time.Duration {
+	return t.actual - t.estimated
+}
+
+// String returns human-readable representation
+func (t PhaseTiming) String() string {
+	return fmt.Sprintf("%s: estimated %s, took %s (%s)",
+		t.phase, t.estimated.Round(time.Second), t.actual.Round(time.Second), FormatDelta(t.Delta()))
+}
+
+// FormatDelta phrases how far an actual duration was from its estimate,
+// such as "+1m30s" for slower and "-20s" for faster
+func FormatDelta(delta time.Duration) string {
+	delta = delta.Round(time.Second)
+	if delta >= 0 {
+		return "+" + delta.String()
+	}
+	return delta.String()
+}
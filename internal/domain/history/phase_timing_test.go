@@ -0,0 +1,32 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPhaseTiming(t *testing.T) {
+	t.Run("describes how far the phase was from its estimate", func(t *testing.T) {
+		timing, err := history.NewPhaseTiming("installing", 2*time.Minute, 210*time.Second)
+		require.NoError(t, err)
+
+		assert.Equal(t, 90*time.Second, timing.Delta())
+		assert.Equal(t, "installing: estimated 2m0s, took 3m30s (+1m30s)", timing.String())
+	})
+
+	t.Run("rejects invalid timings", func(t *testing.T) {
+		_, err := history.NewPhaseTiming(" ", time.Minute, time.Minute)
+		assert.ErrorIs(t, err, history.ErrInvalidPhaseTiming)
+		_, err = history.NewPhaseTiming("verifying", time.Minute, -time.Second)
+		assert.ErrorIs(t, err, history.ErrInvalidPhaseTiming)
+	})
+}
+
+func TestFormatDelta(t *testing.T) {
+	assert.Equal(t, "+1m30s", history.FormatDelta(90*time.Second))
+	assert.Equal(t, "-20s", history.FormatDelta(-20*time.Second))
+}
@@ -0,0 +1,72 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreflightCheck is a value object for a preflight result embedded in a
+// record, explaining why an installation was blocked or degraded
+type PreflightCheck struct {
+	requirement string
+	status      string
+	actual      string
+	expected    string
+	message     string
+}
+
+// NewPreflightCheck creates a preflight check. Requirement and status are required.
+func NewPreflightCheck(requirement, status, actual, expected, message string) (PreflightCheck, error) {
+	requirement = strings.TrimSpace(requirement)
+	status = strings.TrimSpace(status)
+	if requirement == "" || status == "" {
+		return PreflightCheck{}, ErrInvalidPreflightCheck
+	}
+
+	return PreflightCheck{
+		requirement: requirement,
+		status:      status,
+		actual:      strings.TrimSpace(actual),
+		expected:    strings.TrimSpace(expected),
+		message:     strings.TrimSpace(message),
+	}, nil
+}
+
+// Requirement returns the name of the checked requirement
+func (c PreflightCheck) Requirement() string {
+	return c.requirement
+}
+
+// Status returns the check status (pass, warning or fail)
+func (c PreflightCheck) Status() string {
+	return c.status
+}
+
+// Actual returns what was detected
+func (c PreflightCheck) Actual() string {
+	return c.actual
+}
+
+// Expected returns what the requirement expects
+func (c PreflightCheck) Expected() string {
+	return c.expected
+}
+
+// Message returns the guidance shown for the check
+func (c PreflightCheck) Message() string {
+	return c.message
+}
+
+// Passed returns true if the check passed
+func (c PreflightCheck) Passed() bool {
+	return c.status == "pass"
+}
+
+// String returns human-readable representation
+func (c PreflightCheck) String() string {
+	s := fmt.Sprintf("%s: %s", c.requirement, c.status)
+	if c.actual != "" || c.expected != "" {
+		s += fmt.Sprintf(" (actual: %s, expected: %s)", c.actual, c.expected)
+	}
+	return s
+}
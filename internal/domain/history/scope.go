@@ -0,0 +1,65 @@
+package history
+
+import "strings"
+
+const (
+	scopeSystem     = "system"
+	scopeUserPrefix = "user:"
+)
+
+// Scope identifies whose history a record belongs to: the system (packages
+// installed as root) or a single user (configs deployed for that user)
+type Scope struct {
+	user string
+}
+
+// SystemScope returns the scope for system-wide installations
+func SystemScope() Scope {
+	return Scope{}
+}
+
+// NewUserScope creates the scope for a user's own installations
+func NewUserScope(user string) (Scope, error) {
+	user = strings.TrimSpace(user)
+	if user == "" || strings.ContainsAny(user, ": \t") {
+		return Scope{}, ErrInvalidScope
+	}
+	return Scope{user: user}, nil
+}
+
+// ParseScope parses "system" or "user:<name>". An empty string is the
+// system scope, which is what records written before scopes existed used.
+func ParseScope(s string) (Scope, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "" || s == scopeSystem:
+		return SystemScope(), nil
+	case strings.HasPrefix(s, scopeUserPrefix):
+		return NewUserScope(strings.TrimPrefix(s, scopeUserPrefix))
+	default:
+		return Scope{}, ErrInvalidScope
+	}
+}
+
+// IsSystem returns true for the system-wide scope
+func (s Scope) IsSystem() bool {
+	return s.user == ""
+}
+
+// User returns the user name for user scopes, or "" for the system scope
+func (s Scope) User() string {
+	return s.user
+}
+
+// Equals checks if two scopes are the same
+func (s Scope) Equals(other Scope) bool {
+	return s.user == other.user
+}
+
+// String returns "system" or "user:<name>"
+func (s Scope) String() string {
+	if s.IsSystem() {
+		return scopeSystem
+	}
+	return scopeUserPrefix + s.user
+}
@@ -0,0 +1,51 @@
+package history_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		isSystem bool
+		wantErr  bool
+	}{
+		{"system", "system", "system", true, false},
+		{"empty is system", "", "system", true, false},
+		{"user", "user:alice", "user:alice", false, false},
+		{"trims whitespace", "  user:alice ", "user:alice", false, false},
+		{"user without name", "user:", "", false, true},
+		{"name with colon", "user:a:b", "", false, true},
+		{"unknown scope", "global", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, err := history.ParseScope(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, history.ErrInvalidScope)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, scope.String())
+			assert.Equal(t, tt.isSystem, scope.IsSystem())
+		})
+	}
+}
+
+func TestScope_User(t *testing.T) {
+	scope, err := history.NewUserScope("alice")
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice", scope.User())
+	assert.False(t, scope.Equals(history.SystemScope()))
+	assert.Empty(t, history.SystemScope().User())
+}
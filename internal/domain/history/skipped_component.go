@@ -0,0 +1,49 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SkippedComponent is a value object for a component an installation left
+// out, with the reason code and what was found
+type SkippedComponent struct {
+	component string
+	reason    string
+	detail    string
+}
+
+// NewSkippedComponent creates a skipped component entry. The component and
+// reason are required.
+func NewSkippedComponent(component, reason, detail string) (SkippedComponent, error) {
+	component = strings.TrimSpace(component)
+	reason = strings.TrimSpace(reason)
+	if component == "" || reason == "" {
+		return SkippedComponent{}, ErrInvalidSkippedComponent
+	}
+
+	return SkippedComponent{component: component, reason: reason, detail: strings.TrimSpace(detail)}, nil
+}
+
+// Component returns the component left out
+func (c SkippedComponent) Component() string {
+	return c.component
+}
+
+// Reason returns why it was left out, such as unavailable or lite_mode
+func (c SkippedComponent) Reason() string {
+	return c.reason
+}
+
+// Detail returns what was found, such as the package that is missing
+func (c SkippedComponent) Detail() string {
+	return c.detail
+}
+
+// String returns human-readable representation
+func (c SkippedComponent) String() string {
+	if c.detail == "" {
+		return fmt.Sprintf("%s (%s)", c.component, c.reason)
+	}
+	return fmt.Sprintf("%s (%s): %s", c.component, c.reason, c.detail)
+}
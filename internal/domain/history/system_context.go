@@ -1,6 +1,7 @@
 package history
 
 import (
+	"context"
 	"strings"
 )
 
@@ -10,6 +11,9 @@ type SystemContext struct {
 	kernelVersion string
 	gohanVersion  string
 	hostname      string
+	architecture  string
+	gpuVendor     string
+	renderGPU     string
 }
 
 // NewSystemContext creates system context value object
@@ -57,3 +61,37 @@ func (s SystemContext) GohanVersion() string {
 func (s SystemContext) Hostname() string {
 	return s.hostname
 }
+
+// Architecture returns the CPU architecture, e.g. amd64
+func (s SystemContext) Architecture() string {
+	return s.architecture
+}
+
+// GPUVendor returns the vendor of the primary GPU
+func (s SystemContext) GPUVendor() string {
+	return s.gpuVendor
+}
+
+// WithHardware returns a copy of the context with architecture and GPU vendor set
+func (s SystemContext) WithHardware(architecture, gpuVendor string) SystemContext {
+	s.architecture = strings.TrimSpace(architecture)
+	s.gpuVendor = strings.TrimSpace(gpuVendor)
+	return s
+}
+
+// RenderGPU returns the GPU Hyprland was configured to render on, or "" if
+// no choice was recorded
+func (s SystemContext) RenderGPU() string {
+	return s.renderGPU
+}
+
+// WithRenderGPU returns a copy of the context with the chosen render GPU set
+func (s SystemContext) WithRenderGPU(gpu string) SystemContext {
+	s.renderGPU = strings.TrimSpace(gpu)
+	return s
+}
+
+// SystemContextProvider captures the context of the system gohan is running on
+type SystemContextProvider interface {
+	CurrentSystemContext(ctx context.Context) (SystemContext, error)
+}
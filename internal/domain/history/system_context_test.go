@@ -122,3 +122,25 @@ func TestSystemContext_AllowsEmptyOptionalFields(t *testing.T) {
 	assert.Empty(t, ctx.GohanVersion())
 	assert.Empty(t, ctx.Hostname())
 }
+
+func TestSystemContext_WithHardware(t *testing.T) {
+	ctx, err := history.NewSystemContext("Debian GNU/Linux 13", "6.1.0-13-amd64", "1.0.0", "myserver")
+	require.NoError(t, err)
+
+	withHardware := ctx.WithHardware(" arm64 ", "amd")
+
+	assert.Equal(t, "arm64", withHardware.Architecture())
+	assert.Equal(t, "amd", withHardware.GPUVendor())
+	assert.Equal(t, "Debian GNU/Linux 13", withHardware.OSVersion())
+	assert.Empty(t, ctx.Architecture(), "original context should be unchanged")
+}
+
+func TestSystemContext_WithRenderGPU(t *testing.T) {
+	ctx, err := history.NewSystemContext("Debian GNU/Linux 13", "6.1.0-13-amd64", "1.0.0", "myserver")
+	require.NoError(t, err)
+
+	withRender := ctx.WithRenderGPU(" nvidia GeForce MX150 [0000:01:00.0] ")
+
+	assert.Equal(t, "nvidia GeForce MX150 [0000:01:00.0]", withRender.RenderGPU())
+	assert.Empty(t, ctx.RenderGPU(), "original context should be unchanged")
+}
@@ -0,0 +1,250 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AccessibilityOption is an accessibility adjustment applied to every
+// rendered configuration
+type AccessibilityOption string
+
+const (
+	AccessibilityReducedMotion AccessibilityOption = "reduced-motion" // No animations or blur
+	AccessibilityLargeText     AccessibilityOption = "large-text"     // Larger fonts and cursor
+	AccessibilityHighContrast  AccessibilityOption = "high-contrast"  // Black, white and yellow with thick borders
+)
+
+// AllAccessibilityOptions lists the options in display order
+var AllAccessibilityOptions = []AccessibilityOption{
+	AccessibilityReducedMotion,
+	AccessibilityLargeText,
+	AccessibilityHighContrast,
+}
+
+// String returns the string representation of the option
+func (o AccessibilityOption) String() string {
+	return string(o)
+}
+
+// Description describes the option for selection lists
+func (o AccessibilityOption) Description() string {
+	switch o {
+	case AccessibilityReducedMotion:
+		return "Reduced motion: no animations, blur or shadows"
+	case AccessibilityLargeText:
+		return "Large text: bigger fonts in Hyprland, Waybar and the terminal, and a bigger cursor"
+	case AccessibilityHighContrast:
+		return "High contrast: white on black with yellow focus borders"
+	default:
+		return string(o)
+	}
+}
+
+// AccessibilitySettings is the set of accessibility options an installation
+// renders its configuration with. The zero value enables none.
+type AccessibilitySettings struct {
+	reducedMotion bool
+	largeText     bool
+	highContrast  bool
+}
+
+// NewAccessibilitySettings creates settings from individual switches
+func NewAccessibilitySettings(reducedMotion, largeText, highContrast bool) AccessibilitySettings {
+	return AccessibilitySettings{
+		reducedMotion: reducedMotion,
+		largeText:     largeText,
+		highContrast:  highContrast,
+	}
+}
+
+// ParseAccessibilitySettings parses option names such as "reduced-motion";
+// "none" and empty entries are ignored
+func ParseAccessibilitySettings(values []string) (AccessibilitySettings, error) {
+	var settings AccessibilitySettings
+	for _, value := range values {
+		switch AccessibilityOption(strings.ToLower(strings.TrimSpace(value))) {
+		case "", "none":
+		case AccessibilityReducedMotion:
+			settings.reducedMotion = true
+		case AccessibilityLargeText:
+			settings.largeText = true
+		case AccessibilityHighContrast:
+			settings.highContrast = true
+		default:
+			return AccessibilitySettings{}, fmt.Errorf("%w: %q (expected reduced-motion, large-text or high-contrast)",
+				ErrInvalidAccessibilityOption, value)
+		}
+	}
+	return settings, nil
+}
+
+// ReducedMotion returns true if animations and blur are turned off
+func (s AccessibilitySettings) ReducedMotion() bool {
+	return s.reducedMotion
+}
+
+// LargeText returns true if fonts and the cursor are enlarged
+func (s AccessibilitySettings) LargeText() bool {
+	return s.largeText
+}
+
+// HighContrast returns true if high-contrast colors are used
+func (s AccessibilitySettings) HighContrast() bool {
+	return s.highContrast
+}
+
+// Has returns true if the option is enabled
+func (s AccessibilitySettings) Has(option AccessibilityOption) bool {
+	switch option {
+	case AccessibilityReducedMotion:
+		return s.reducedMotion
+	case AccessibilityLargeText:
+		return s.largeText
+	case AccessibilityHighContrast:
+		return s.highContrast
+	default:
+		return false
+	}
+}
+
+// IsEmpty returns true if no option is enabled
+func (s AccessibilitySettings) IsEmpty() bool {
+	return !s.reducedMotion && !s.largeText && !s.highContrast
+}
+
+// Options returns the enabled options in display order
+func (s AccessibilitySettings) Options() []AccessibilityOption {
+	var options []AccessibilityOption
+	for _, option := range AllAccessibilityOptions {
+		if s.Has(option) {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// Strings returns the names of the enabled options
+func (s AccessibilitySettings) Strings() []string {
+	var names []string
+	for _, option := range s.Options() {
+		names = append(names, option.String())
+	}
+	return names
+}
+
+// String returns the enabled options as "reduced-motion, large-text", or
+// "none"
+func (s AccessibilitySettings) String() string {
+	if s.IsEmpty() {
+		return "none"
+	}
+	return strings.Join(s.Strings(), ", ")
+}
+
+// Default and large sizes, in points for fonts and pixels for the cursor
+const (
+	defaultTerminalFontSize = "11.0"
+	largeTerminalFontSize   = "14.0"
+	defaultUIFontSize       = "12"
+	largeUIFontSize         = "16"
+	defaultWaybarFontSize   = "13"
+	largeWaybarFontSize     = "17"
+	defaultCursorSize       = "24"
+	largeCursorSize         = "36"
+)
+
+// highContrastHyprland overrides themed border colors; later Hyprland
+// settings win
+const highContrastHyprland = `# High contrast
+general {
+    col.active_border = rgb(ffff00)
+    col.inactive_border = rgb(ffffff)
+}`
+
+// highContrastKitty overrides the terminal palette; later kitty settings win
+const highContrastKitty = `# High contrast
+foreground #ffffff
+background #000000
+selection_foreground #000000
+selection_background #ffff00
+cursor #ffff00
+cursor_text_color #000000
+url_color #00ffff
+active_border_color #ffff00
+inactive_border_color #ffffff
+active_tab_foreground #000000
+active_tab_background #ffff00
+inactive_tab_foreground #ffffff
+inactive_tab_background #000000
+background_opacity 1.0`
+
+// highContrastWaybar overrides the bar styles; later CSS rules win
+const highContrastWaybar = `/* High contrast */
+window#waybar {
+    background-color: #000000;
+    color: #ffffff;
+    border-bottom: 2px solid #ffffff;
+}
+
+#workspaces button,
+#custom-logo, #clock, #battery, #cpu, #memory, #network, #pulseaudio,
+#idle_inhibitor, #tray, #custom-power {
+    color: #ffffff;
+    background-color: #000000;
+}
+
+#workspaces button.active,
+#workspaces button.focused {
+    color: #000000;
+    background-color: #ffff00;
+}
+
+#workspaces button.urgent,
+#battery.critical:not(.charging) {
+    color: #000000;
+    background-color: #ff0000;
+}`
+
+// TemplateVars returns the template variables for font and cursor sizes,
+// effects and high-contrast overrides. They are applied after the rendering
+// mode, so reduced motion turns effects off even in standard mode.
+func (s AccessibilitySettings) TemplateVars() map[string]string {
+	vars := map[string]string{
+		"reduced_motion":        fmt.Sprintf("%t", s.reducedMotion),
+		"terminal_font_size":    defaultTerminalFontSize,
+		"ui_font_size":          defaultUIFontSize,
+		"waybar_font_size":      defaultWaybarFontSize,
+		"cursor_size":           defaultCursorSize,
+		"border_size":           "2",
+		"active_border_color":   "rgba(33ccffee) rgba(00ff99ee) 45deg",
+		"inactive_border_color": "rgba(595959aa)",
+		"hyprland_contrast":     "",
+		"kitty_contrast":        "",
+		"waybar_contrast":       "",
+	}
+
+	if s.reducedMotion {
+		vars["blur_enabled"] = "false"
+		vars["shadow_enabled"] = "false"
+		vars["animations_enabled"] = "false"
+	}
+
+	if s.largeText {
+		vars["terminal_font_size"] = largeTerminalFontSize
+		vars["ui_font_size"] = largeUIFontSize
+		vars["waybar_font_size"] = largeWaybarFontSize
+		vars["cursor_size"] = largeCursorSize
+	}
+
+	if s.highContrast {
+		vars["border_size"] = "4"
+		vars["active_border_color"] = "rgb(ffff00)"
+		vars["inactive_border_color"] = "rgb(ffffff)"
+		vars["hyprland_contrast"] = highContrastHyprland
+		vars["kitty_contrast"] = highContrastKitty
+		vars["waybar_contrast"] = highContrastWaybar
+	}
+
+	return vars
+}
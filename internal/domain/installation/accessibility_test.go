@@ -0,0 +1,64 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccessibilitySettings(t *testing.T) {
+	settings, err := installation.ParseAccessibilitySettings([]string{" Large-Text ", "reduced-motion", ""})
+	require.NoError(t, err)
+	assert.True(t, settings.LargeText())
+	assert.True(t, settings.ReducedMotion())
+	assert.False(t, settings.HighContrast())
+	assert.Equal(t, "reduced-motion, large-text", settings.String(), "options are listed in display order")
+
+	none, err := installation.ParseAccessibilitySettings([]string{"none"})
+	require.NoError(t, err)
+	assert.True(t, none.IsEmpty())
+	assert.Equal(t, "none", none.String())
+	assert.Empty(t, none.Strings())
+
+	_, err = installation.ParseAccessibilitySettings([]string{"zoom"})
+	assert.ErrorIs(t, err, installation.ErrInvalidAccessibilityOption)
+}
+
+func TestAccessibilitySettings_TemplateVars(t *testing.T) {
+	t.Run("defaults leave the configuration unchanged", func(t *testing.T) {
+		var settings installation.AccessibilitySettings
+		vars := settings.TemplateVars()
+
+		assert.Equal(t, "11.0", vars["terminal_font_size"])
+		assert.Equal(t, "24", vars["cursor_size"])
+		assert.Equal(t, "2", vars["border_size"])
+		assert.Empty(t, vars["kitty_contrast"])
+		assert.NotContains(t, vars, "animations_enabled", "the rendering mode decides effects")
+	})
+
+	t.Run("reduced motion turns effects off", func(t *testing.T) {
+		vars := installation.NewAccessibilitySettings(true, false, false).TemplateVars()
+		assert.Equal(t, "false", vars["animations_enabled"])
+		assert.Equal(t, "false", vars["blur_enabled"])
+		assert.Equal(t, "false", vars["shadow_enabled"])
+	})
+
+	t.Run("large text enlarges fonts and the cursor", func(t *testing.T) {
+		vars := installation.NewAccessibilitySettings(false, true, false).TemplateVars()
+		assert.Equal(t, "14.0", vars["terminal_font_size"])
+		assert.Equal(t, "16", vars["ui_font_size"])
+		assert.Equal(t, "17", vars["waybar_font_size"])
+		assert.Equal(t, "36", vars["cursor_size"])
+	})
+
+	t.Run("high contrast overrides colors in every component", func(t *testing.T) {
+		vars := installation.NewAccessibilitySettings(false, false, true).TemplateVars()
+		assert.Equal(t, "4", vars["border_size"])
+		assert.Equal(t, "rgb(ffff00)", vars["active_border_color"])
+		assert.Contains(t, vars["hyprland_contrast"], "col.active_border = rgb(ffff00)")
+		assert.Contains(t, vars["kitty_contrast"], "background #000000")
+		assert.Contains(t, vars["waybar_contrast"], "background-color: #000000")
+	})
+}
@@ -0,0 +1,102 @@
+package installation
+
+import "fmt"
+
+// AlternativeSwap is a value object replacing the provider of a slot with
+// another provider of the same slot, such as kitty with alacritty
+type AlternativeSwap struct {
+	slot AlternativeSlot
+	from AlternativeProvider
+	to   AlternativeProvider
+}
+
+// NewAlternativeSwap creates a swap from one provider package to another
+// Both packages must provide the same slot and must differ
+func NewAlternativeSwap(from, to string) (AlternativeSwap, error) {
+	fromSlot, fromProvider, ok := findProvider(from)
+	if !ok {
+		return AlternativeSwap{}, fmt.Errorf("%w: %q is not a known alternative", ErrInvalidAlternative, from)
+	}
+	toSlot, toProvider, ok := findProvider(to)
+	if !ok {
+		return AlternativeSwap{}, fmt.Errorf("%w: %q is not a known alternative", ErrInvalidAlternative, to)
+	}
+	if fromSlot != toSlot {
+		return AlternativeSwap{}, fmt.Errorf("%w: %s provides %s but %s provides %s",
+			ErrInvalidAlternative, from, fromSlot, to, toSlot)
+	}
+	if from == to {
+		return AlternativeSwap{}, fmt.Errorf("%w: %s is swapped for itself", ErrInvalidAlternative, from)
+	}
+
+	return AlternativeSwap{slot: fromSlot, from: fromProvider, to: toProvider}, nil
+}
+
+// Slot returns the slot whose provider changes
+func (s AlternativeSwap) Slot() AlternativeSlot {
+	return s.slot
+}
+
+// From returns the provider being replaced
+func (s AlternativeSwap) From() AlternativeProvider {
+	return s.from
+}
+
+// To returns the replacement provider
+func (s AlternativeSwap) To() AlternativeProvider {
+	return s.to
+}
+
+// Before returns a copy of the selection choosing the replaced provider
+func (s AlternativeSwap) Before(selection AlternativeSelection) AlternativeSelection {
+	return selection.withChoice(s.slot, s.from.Package)
+}
+
+// Apply returns a copy of the selection choosing the replacement provider
+func (s AlternativeSwap) Apply(selection AlternativeSelection) AlternativeSelection {
+	return selection.withChoice(s.slot, s.to.Package)
+}
+
+// InstalledPackages returns the packages to install: the replacement and
+// its companions
+func (s AlternativeSwap) InstalledPackages() []string {
+	return append([]string{s.to.Package}, s.to.Companions...)
+}
+
+// RemovedPackages returns the packages to remove: the replaced provider and
+// the companions the replacement does not also need
+func (s AlternativeSwap) RemovedPackages() []string {
+	kept := make(map[string]bool)
+	for _, pkg := range s.InstalledPackages() {
+		kept[pkg] = true
+	}
+
+	removed := []string{s.from.Package}
+	for _, companion := range s.from.Companions {
+		if !kept[companion] {
+			removed = append(removed, companion)
+		}
+	}
+	return removed
+}
+
+// ChangedTemplateVars returns the template variables whose value differs
+// after the swap, with their new values
+func (s AlternativeSwap) ChangedTemplateVars(selection AlternativeSelection) map[string]string {
+	before := s.Before(selection).TemplateVars()
+
+	changed := make(map[string]string)
+	for name, value := range s.Apply(selection).TemplateVars() {
+		if before[name] != value {
+			changed[name] = value
+		}
+	}
+	return changed
+}
+
+// withChoice returns a copy of the selection choosing pkg for the slot
+func (s AlternativeSelection) withChoice(slot AlternativeSlot, pkg string) AlternativeSelection {
+	choices := s.Choices()
+	choices[slot] = pkg
+	return AlternativeSelection{choices: choices}
+}
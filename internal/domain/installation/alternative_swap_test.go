@@ -0,0 +1,70 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlternativeSwap(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		wantSlot installation.AlternativeSlot
+		wantErr  bool
+	}{
+		{name: "terminal", from: "kitty", to: "alacritty", wantSlot: installation.SlotTerminal},
+		{name: "wallpaper tool", from: "swaybg", to: "hyprpaper", wantSlot: installation.SlotWallpaper},
+		{name: "unknown package", from: "kitty", to: "xterm", wantErr: true},
+		{name: "different slots", from: "kitty", to: "swaylock", wantErr: true},
+		{name: "same package", from: "foot", to: "foot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			swap, err := installation.NewAlternativeSwap(tt.from, tt.to)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, installation.ErrInvalidAlternative)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSlot, swap.Slot())
+			assert.Equal(t, tt.from, swap.From().Package)
+			assert.Equal(t, tt.to, swap.To().Package)
+		})
+	}
+}
+
+func TestAlternativeSwap_Packages(t *testing.T) {
+	swap, err := installation.NewAlternativeSwap("kitty", "alacritty")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"alacritty"}, swap.InstalledPackages())
+	assert.Equal(t, []string{"kitty", "kitty-terminfo"}, swap.RemovedPackages(), "companions go with their provider")
+
+	back, err := installation.NewAlternativeSwap("alacritty", "kitty")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kitty", "kitty-terminfo"}, back.InstalledPackages())
+	assert.Equal(t, []string{"alacritty"}, back.RemovedPackages())
+}
+
+func TestAlternativeSwap_ChangedTemplateVars(t *testing.T) {
+	selection, err := installation.ParseAlternativeSelection([]string{"swaylock"})
+	require.NoError(t, err)
+
+	swap, err := installation.NewAlternativeSwap("kitty", "foot")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"terminal":          "foot",
+		"floating_terminal": "foot --app-id=floating",
+	}, swap.ChangedTemplateVars(selection))
+
+	applied := swap.Apply(selection)
+	terminal, _ := applied.Provider(installation.SlotTerminal)
+	locker, _ := applied.Provider(installation.SlotLocker)
+	assert.Equal(t, "foot", terminal)
+	assert.Equal(t, "swaylock", locker, "other slots are kept")
+}
@@ -0,0 +1,375 @@
+package installation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AlternativeSlot identifies a role that exactly one package fills,
+// such as the terminal emulator or the screen locker
+type AlternativeSlot string
+
+const (
+	SlotTerminal   AlternativeSlot = "terminal"    // Terminal emulator
+	SlotLocker     AlternativeSlot = "locker"      // Screen locker
+	SlotIdle       AlternativeSlot = "idle"        // Idle management daemon
+	SlotWallpaper  AlternativeSlot = "wallpaper"   // Wallpaper tool
+	SlotPower      AlternativeSlot = "power"       // Power management daemon
+	SlotNightLight AlternativeSlot = "night_light" // Screen color temperature tool
+)
+
+// String returns the string representation of the slot
+func (s AlternativeSlot) String() string {
+	return string(s)
+}
+
+// AlternativeProvider is a package that can fill an alternative slot
+type AlternativeProvider struct {
+	Package    string
+	Component  ComponentName
+	Companions []string // Packages installed and removed together with the provider
+}
+
+// AlternativeGroup lists the interchangeable providers for a slot
+type AlternativeGroup struct {
+	Slot      AlternativeSlot
+	Providers []AlternativeProvider
+	Default   string // Provider the shipped templates are written for
+}
+
+// alternativeGroups defines every slot gohan resolves
+var alternativeGroups = []AlternativeGroup{
+	{
+		Slot: SlotTerminal,
+		Providers: []AlternativeProvider{
+			{Package: "kitty", Component: ComponentKitty, Companions: []string{"kitty-terminfo"}},
+			{Package: "alacritty", Component: ComponentKitty},
+			{Package: "foot", Component: ComponentKitty},
+		},
+		Default: "kitty",
+	},
+	{
+		Slot: SlotLocker,
+		Providers: []AlternativeProvider{
+			{Package: "hyprlock", Component: ComponentHyprlock},
+			{Package: "swaylock", Component: ComponentHyprlock},
+		},
+		Default: "hyprlock",
+	},
+	{
+		Slot: SlotIdle,
+		Providers: []AlternativeProvider{
+			{Package: "hypridle", Component: ComponentHypridle},
+			{Package: "swayidle", Component: ComponentHypridle},
+		},
+		Default: "hypridle",
+	},
+	{
+		Slot: SlotWallpaper,
+		Providers: []AlternativeProvider{
+			{Package: "swaybg", Component: ComponentSwaybg},
+			{Package: "hyprpaper", Component: ComponentHyprpaper},
+		},
+		Default: "swaybg",
+	},
+	{
+		Slot: SlotPower,
+		Providers: []AlternativeProvider{
+			{Package: "power-profiles-daemon", Component: ComponentPowerProfiles},
+			{Package: "tlp", Component: ComponentPowerProfiles},
+		},
+		Default: "power-profiles-daemon",
+	},
+	{
+		Slot: SlotNightLight,
+		Providers: []AlternativeProvider{
+			{Package: "wlsunset", Component: ComponentNightLight},
+			{Package: "gammastep", Component: ComponentNightLight},
+		},
+		Default: "wlsunset",
+	},
+}
+
+// GetAlternativeGroups returns all alternative groups
+func GetAlternativeGroups() []AlternativeGroup {
+	groups := make([]AlternativeGroup, len(alternativeGroups))
+	copy(groups, alternativeGroups)
+	return groups
+}
+
+// GetAlternativeGroup returns the group for a slot
+func GetAlternativeGroup(slot AlternativeSlot) (AlternativeGroup, bool) {
+	for _, group := range alternativeGroups {
+		if group.Slot == slot {
+			return group, true
+		}
+	}
+	return AlternativeGroup{}, false
+}
+
+// Provider returns the provider for a package in the group
+func (g AlternativeGroup) Provider(packageName string) (AlternativeProvider, bool) {
+	for _, p := range g.Providers {
+		if p.Package == packageName {
+			return p, true
+		}
+	}
+	return AlternativeProvider{}, false
+}
+
+// findProvider returns the slot and provider for a package name
+func findProvider(packageName string) (AlternativeSlot, AlternativeProvider, bool) {
+	for _, group := range alternativeGroups {
+		if p, ok := group.Provider(packageName); ok {
+			return group.Slot, p, true
+		}
+	}
+	return "", AlternativeProvider{}, false
+}
+
+// AlternativeSelection is a value object holding the chosen provider per slot
+// Slots without a choice keep whatever the profile or component implies
+type AlternativeSelection struct {
+	choices map[AlternativeSlot]string
+}
+
+// NewAlternativeSelection creates a selection from slot to package choices
+// Every package must be a provider for its slot
+func NewAlternativeSelection(choices map[AlternativeSlot]string) (AlternativeSelection, error) {
+	selection := AlternativeSelection{choices: make(map[AlternativeSlot]string, len(choices))}
+
+	for slot, pkg := range choices {
+		group, ok := GetAlternativeGroup(slot)
+		if !ok {
+			return AlternativeSelection{}, fmt.Errorf("%w: unknown slot %q", ErrInvalidAlternative, slot)
+		}
+		if _, ok := group.Provider(pkg); !ok {
+			return AlternativeSelection{}, fmt.Errorf("%w: %q does not provide %s", ErrInvalidAlternative, pkg, slot)
+		}
+		selection.choices[slot] = pkg
+	}
+
+	return selection, nil
+}
+
+// ParseAlternativeSelection parses user choices of the form "slot=package"
+// or a bare provider package name. Requesting two different providers for
+// the same slot is rejected.
+func ParseAlternativeSelection(values []string) (AlternativeSelection, error) {
+	choices := make(map[AlternativeSlot]string)
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		var slot AlternativeSlot
+		pkg := value
+		if name, provider, found := strings.Cut(value, "="); found {
+			slot = AlternativeSlot(strings.TrimSpace(name))
+			pkg = strings.TrimSpace(provider)
+		} else {
+			s, _, ok := findProvider(pkg)
+			if !ok {
+				return AlternativeSelection{}, fmt.Errorf("%w: %q is not a known alternative", ErrInvalidAlternative, pkg)
+			}
+			slot = s
+		}
+
+		if existing, ok := choices[slot]; ok && existing != pkg {
+			return AlternativeSelection{}, fmt.Errorf("%w: %s requested as both %s and %s",
+				ErrConflictingAlternatives, slot, existing, pkg)
+		}
+		choices[slot] = pkg
+	}
+
+	return NewAlternativeSelection(choices)
+}
+
+// Provider returns the chosen package for a slot
+func (s AlternativeSelection) Provider(slot AlternativeSlot) (string, bool) {
+	pkg, ok := s.choices[slot]
+	return pkg, ok
+}
+
+// ProviderOrDefault returns the chosen package for a slot, falling back to
+// the provider the shipped templates are written for
+func (s AlternativeSelection) ProviderOrDefault(slot AlternativeSlot) string {
+	if pkg, ok := s.choices[slot]; ok {
+		return pkg
+	}
+	group, _ := GetAlternativeGroup(slot)
+	return group.Default
+}
+
+// WithFallback returns a copy of the selection choosing pkg for the slot
+// when nothing has been chosen for it yet
+func (s AlternativeSelection) WithFallback(slot AlternativeSlot, pkg string) AlternativeSelection {
+	choices := s.Choices()
+	if _, ok := choices[slot]; !ok {
+		choices[slot] = pkg
+	}
+	return AlternativeSelection{choices: choices}
+}
+
+// IsEmpty returns true if no slot has been chosen
+func (s AlternativeSelection) IsEmpty() bool {
+	return len(s.choices) == 0
+}
+
+// Choices returns a copy of the chosen package per slot
+func (s AlternativeSelection) Choices() map[AlternativeSlot]string {
+	choices := make(map[AlternativeSlot]string, len(s.choices))
+	for slot, pkg := range s.choices {
+		choices[slot] = pkg
+	}
+	return choices
+}
+
+// Strings returns the selection as sorted "slot=package" values,
+// the inverse of ParseAlternativeSelection
+func (s AlternativeSelection) Strings() []string {
+	values := make([]string, 0, len(s.choices))
+	for slot, pkg := range s.choices {
+		values = append(values, slot.String()+"="+pkg)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// PackageForComponent returns the package to install for a component,
+// honouring the chosen provider when the component fills a slot.
+// fallback is returned for components the selection does not affect.
+func (s AlternativeSelection) PackageForComponent(component ComponentName, fallback string) string {
+	for _, group := range alternativeGroups {
+		pkg, ok := s.choices[group.Slot]
+		if !ok {
+			continue
+		}
+		if p, _ := group.Provider(pkg); p.Component == component {
+			return pkg
+		}
+	}
+	return fallback
+}
+
+// ValidateComponents ensures the requested components do not ask for two
+// providers of the same slot, either directly (e.g. swaybg and hyprpaper)
+// or by contradicting the selection
+func (s AlternativeSelection) ValidateComponents(components []ComponentSelection) error {
+	for _, group := range alternativeGroups {
+		requested := make(map[ComponentName]bool)
+		for _, comp := range components {
+			for _, p := range group.Providers {
+				if p.Component == comp.Component() {
+					requested[comp.Component()] = true
+				}
+			}
+		}
+
+		if len(requested) > 1 {
+			names := make([]string, 0, len(requested))
+			for name := range requested {
+				names = append(names, string(name))
+			}
+			sort.Strings(names)
+			return fmt.Errorf("%w: %s requested by %s",
+				ErrConflictingAlternatives, group.Slot, strings.Join(names, " and "))
+		}
+
+		pkg, ok := s.choices[group.Slot]
+		if !ok {
+			continue
+		}
+		chosen, _ := group.Provider(pkg)
+		for name := range requested {
+			if name != chosen.Component {
+				return fmt.Errorf("%w: component %s conflicts with %s=%s",
+					ErrConflictingAlternatives, name, group.Slot, pkg)
+			}
+		}
+	}
+
+	return nil
+}
+
+// TemplateVars returns the template variables describing the providers,
+// using the defaults for slots without a choice
+func (s AlternativeSelection) TemplateVars() map[string]string {
+	locker := s.ProviderOrDefault(SlotLocker)
+
+	idleCommand := "hypridle"
+	if s.ProviderOrDefault(SlotIdle) == "swayidle" {
+		idleCommand = fmt.Sprintf("swayidle -w timeout 300 '%s' before-sleep '%s'", locker, locker)
+	}
+
+	wallpaperCommand := "swaybg -i ~/.config/gohan/wallpaper.jpg -m fill"
+	if s.ProviderOrDefault(SlotWallpaper) == "hyprpaper" {
+		wallpaperCommand = "hyprpaper"
+	}
+
+	terminal := s.ProviderOrDefault(SlotTerminal)
+	floatingTerminal := terminal + " --class=floating"
+	if terminal == "foot" {
+		floatingTerminal = "foot --app-id=floating"
+	}
+
+	return map[string]string{
+		"terminal":          terminal,
+		"floating_terminal": floatingTerminal,
+		"screen_locker":     locker,
+		"idle_command":      idleCommand,
+		"wallpaper_command": wallpaperCommand,
+	}
+}
+
+// ResolveProfileAlternatives returns a copy of the profile with exactly one
+// provider per slot. The chosen provider replaces the profile's own; slots
+// without a choice keep the first provider the profile lists.
+func ResolveProfileAlternatives(profile InstallationProfile, selection AlternativeSelection) InstallationProfile {
+	owner := make(map[string]AlternativeSlot)
+	for _, group := range alternativeGroups {
+		for _, p := range group.Providers {
+			owner[p.Package] = group.Slot
+			for _, companion := range p.Companions {
+				owner[companion] = group.Slot
+			}
+		}
+	}
+
+	resolved := make(map[AlternativeSlot]bool)
+	packages := make([]string, 0, len(profile.Packages))
+
+	for _, pkg := range profile.Packages {
+		slot, ok := owner[pkg]
+		if !ok {
+			packages = append(packages, pkg)
+			continue
+		}
+		if resolved[slot] {
+			continue
+		}
+
+		group, _ := GetAlternativeGroup(slot)
+		provider, isProvider := group.Provider(pkg)
+		if chosen, ok := selection.Provider(slot); ok {
+			provider, _ = group.Provider(chosen)
+		} else if !isProvider {
+			// Companion listed before its provider; wait for the provider
+			continue
+		}
+
+		packages = append(packages, provider.Package)
+		packages = append(packages, provider.Companions...)
+		resolved[slot] = true
+	}
+
+	return InstallationProfile{
+		Name:        profile.Name,
+		Description: profile.Description,
+		Packages:    packages,
+		OptIn:       profile.OptIn,
+	}
+}
@@ -0,0 +1,208 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlternativeSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[installation.AlternativeSlot]string
+		wantErr error
+	}{
+		{
+			name:   "slot and package",
+			values: []string{"terminal=alacritty", "locker=swaylock"},
+			want: map[installation.AlternativeSlot]string{
+				installation.SlotTerminal: "alacritty",
+				installation.SlotLocker:   "swaylock",
+			},
+		},
+		{
+			name:   "bare package name",
+			values: []string{"foot", " hyprpaper "},
+			want: map[installation.AlternativeSlot]string{
+				installation.SlotTerminal:  "foot",
+				installation.SlotWallpaper: "hyprpaper",
+			},
+		},
+		{
+			name:   "same provider twice",
+			values: []string{"kitty", "terminal=kitty"},
+			want: map[installation.AlternativeSlot]string{
+				installation.SlotTerminal: "kitty",
+			},
+		},
+		{
+			name:    "two providers for one slot",
+			values:  []string{"kitty", "alacritty"},
+			wantErr: installation.ErrConflictingAlternatives,
+		},
+		{
+			name:    "unknown package",
+			values:  []string{"xterm"},
+			wantErr: installation.ErrInvalidAlternative,
+		},
+		{
+			name:    "package in wrong slot",
+			values:  []string{"locker=kitty"},
+			wantErr: installation.ErrInvalidAlternative,
+		},
+		{
+			name:    "unknown slot",
+			values:  []string{"browser=firefox"},
+			wantErr: installation.ErrInvalidAlternative,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selection, err := installation.ParseAlternativeSelection(tt.values)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, selection.Choices())
+		})
+	}
+}
+
+func TestAlternativeSelection_Strings(t *testing.T) {
+	selection, err := installation.ParseAlternativeSelection([]string{"swayidle", "terminal=foot"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"idle=swayidle", "terminal=foot"}, selection.Strings())
+
+	roundTrip, err := installation.ParseAlternativeSelection(selection.Strings())
+	require.NoError(t, err)
+	assert.Equal(t, selection.Choices(), roundTrip.Choices())
+}
+
+func TestAlternativeSelection_PackageForComponent(t *testing.T) {
+	selection, err := installation.ParseAlternativeSelection([]string{"alacritty", "swaylock"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "alacritty", selection.PackageForComponent(installation.ComponentKitty, "kitty"))
+	assert.Equal(t, "swaylock", selection.PackageForComponent(installation.ComponentHyprlock, "hyprlock"))
+	assert.Equal(t, "waybar", selection.PackageForComponent(installation.ComponentWaybar, "waybar"))
+
+	var empty installation.AlternativeSelection
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, "kitty", empty.PackageForComponent(installation.ComponentKitty, "kitty"))
+}
+
+func TestAlternativeSelection_ValidateComponents(t *testing.T) {
+	hyprland := mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0")
+	swaybg := mustCreateComponentSelection(t, installation.ComponentSwaybg, "1.2.0")
+	hyprpaper := mustCreateComponentSelection(t, installation.ComponentHyprpaper, "0.6.0")
+
+	t.Run("accepts one provider per slot", func(t *testing.T) {
+		selection, err := installation.ParseAlternativeSelection([]string{"wallpaper=hyprpaper"})
+		require.NoError(t, err)
+
+		assert.NoError(t, selection.ValidateComponents([]installation.ComponentSelection{hyprland, hyprpaper}))
+	})
+
+	t.Run("rejects two providers for the same slot", func(t *testing.T) {
+		var selection installation.AlternativeSelection
+
+		err := selection.ValidateComponents([]installation.ComponentSelection{hyprland, swaybg, hyprpaper})
+		assert.ErrorIs(t, err, installation.ErrConflictingAlternatives)
+	})
+
+	t.Run("rejects components contradicting the selection", func(t *testing.T) {
+		selection, err := installation.ParseAlternativeSelection([]string{"wallpaper=hyprpaper"})
+		require.NoError(t, err)
+
+		err = selection.ValidateComponents([]installation.ComponentSelection{hyprland, swaybg})
+		assert.ErrorIs(t, err, installation.ErrConflictingAlternatives)
+	})
+}
+
+func TestAlternativeSelection_TemplateVars(t *testing.T) {
+	t.Run("defaults match shipped templates", func(t *testing.T) {
+		var selection installation.AlternativeSelection
+		vars := selection.TemplateVars()
+
+		assert.Equal(t, "kitty", vars["terminal"])
+		assert.Equal(t, "hyprlock", vars["screen_locker"])
+		assert.Equal(t, "hypridle", vars["idle_command"])
+		assert.Contains(t, vars["wallpaper_command"], "swaybg")
+	})
+
+	t.Run("sway tools", func(t *testing.T) {
+		selection, err := installation.ParseAlternativeSelection([]string{"foot", "swaylock", "swayidle", "hyprpaper"})
+		require.NoError(t, err)
+		vars := selection.TemplateVars()
+
+		assert.Equal(t, "foot", vars["terminal"])
+		assert.Equal(t, "swaylock", vars["screen_locker"])
+		assert.Contains(t, vars["idle_command"], "swayidle")
+		assert.Contains(t, vars["idle_command"], "swaylock")
+		assert.Equal(t, "hyprpaper", vars["wallpaper_command"])
+	})
+}
+
+func TestResolveProfileAlternatives(t *testing.T) {
+	t.Run("full profile keeps one terminal", func(t *testing.T) {
+		var selection installation.AlternativeSelection
+		resolved := installation.ResolveProfileAlternatives(installation.GetFullProfile(), selection)
+		packages := toSet(resolved.Packages)
+
+		assert.True(t, packages["kitty"])
+		assert.True(t, packages["kitty-terminfo"])
+		assert.False(t, packages["alacritty"])
+		assert.Equal(t, len(packages), len(resolved.Packages), "resolved profile should not contain duplicates")
+	})
+
+	t.Run("selection replaces provider and companions", func(t *testing.T) {
+		selection, err := installation.ParseAlternativeSelection([]string{"alacritty", "hyprlock", "hypridle", "hyprpaper"})
+		require.NoError(t, err)
+
+		resolved := installation.ResolveProfileAlternatives(installation.GetMinimalProfile(), selection)
+		packages := toSet(resolved.Packages)
+
+		for _, pkg := range []string{"alacritty", "hyprlock", "hypridle", "hyprpaper", "hyprland", "waybar"} {
+			assert.True(t, packages[pkg], "resolved profile should include %s", pkg)
+		}
+		for _, pkg := range []string{"kitty", "kitty-terminfo", "swaylock", "swayidle", "swaybg"} {
+			assert.False(t, packages[pkg], "resolved profile should not include %s", pkg)
+		}
+		assert.Equal(t, "Minimal", resolved.Name)
+	})
+
+	t.Run("does not modify the original profile", func(t *testing.T) {
+		profile := installation.GetMinimalProfile()
+		selection, err := installation.ParseAlternativeSelection([]string{"foot"})
+		require.NoError(t, err)
+
+		installation.ResolveProfileAlternatives(profile, selection)
+		assert.Contains(t, profile.Packages, "kitty")
+	})
+}
+
+func TestInstallationConfiguration_WithAlternatives(t *testing.T) {
+	config := mustCreateConfiguration(t, []installation.ComponentSelection{
+		mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0"),
+		mustCreateComponentSelection(t, installation.ComponentKitty, "0.32.0"),
+	})
+
+	selection, err := installation.ParseAlternativeSelection([]string{"terminal=foot"})
+	require.NoError(t, err)
+
+	withAlternatives, err := config.WithAlternatives(selection)
+	require.NoError(t, err)
+
+	provider, ok := withAlternatives.Alternatives().Provider(installation.SlotTerminal)
+	assert.True(t, ok)
+	assert.Equal(t, "foot", provider)
+	assert.True(t, config.Alternatives().IsEmpty(), "original configuration should be unchanged")
+}
@@ -68,6 +68,15 @@ func (c ComponentSelection) EstimatedSizeBytes() uint64 {
 	return c.packageInfo.SizeBytes()
 }
 
+// DownloadBytes returns the estimated size, or DefaultComponentDownloadBytes
+// when the package size is unknown
+func (c ComponentSelection) DownloadBytes() uint64 {
+	if size := c.EstimatedSizeBytes(); size > 0 {
+		return size
+	}
+	return DefaultComponentDownloadBytes
+}
+
 // String returns human-readable representation
 func (c ComponentSelection) String() string {
 	if c.packageInfo != nil {
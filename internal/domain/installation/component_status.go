@@ -0,0 +1,214 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ComponentState is where a single component is in the installation lifecycle
+type ComponentState string
+
+const (
+	ComponentStatePending     ComponentState = "pending"     // Not touched yet
+	ComponentStateDownloading ComponentState = "downloading" // Package being fetched
+	ComponentStateInstalling  ComponentState = "installing"  // Package being installed
+	ComponentStateConfigured  ComponentState = "configured"  // Configuration files deployed
+	ComponentStateVerified    ComponentState = "verified"    // Installation verified
+	ComponentStateFailed      ComponentState = "failed"      // Installation stopped on this component
+	ComponentStateSkipped     ComponentState = "skipped"     // Left out of the installation, see SkipReason
+)
+
+// String returns the string representation of ComponentState
+func (s ComponentState) String() string {
+	return string(s)
+}
+
+// IsValid checks if the component state is one of the known states
+func (s ComponentState) IsValid() bool {
+	switch s {
+	case ComponentStatePending, ComponentStateDownloading, ComponentStateInstalling,
+		ComponentStateConfigured, ComponentStateVerified, ComponentStateFailed, ComponentStateSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// SkipReason is why a component was left out of an installation
+type SkipReason string
+
+const (
+	SkipReasonAlreadyInstalled SkipReason = "already_installed" // Its package was installed before the session
+	SkipReasonUnavailable      SkipReason = "unavailable"       // Its package is not available from the configured suite
+	SkipReasonExcluded         SkipReason = "excluded"          // The user left it out
+	SkipReasonArchitecture     SkipReason = "architecture"      // Its package is not built for this architecture
+	SkipReasonLiteMode         SkipReason = "lite_mode"         // Its package is too heavy for lite mode
+)
+
+// String returns the string representation of SkipReason
+func (r SkipReason) String() string {
+	return string(r)
+}
+
+// IsValid checks if the skip reason is one of the known reasons
+func (r SkipReason) IsValid() bool {
+	switch r {
+	case SkipReasonAlreadyInstalled, SkipReasonUnavailable, SkipReasonExcluded,
+		SkipReasonArchitecture, SkipReasonLiteMode:
+		return true
+	default:
+		return false
+	}
+}
+
+// ComponentStatus is a value object for the state of one component in a
+// session, with the error that stopped it when it failed, or why it was
+// skipped
+type ComponentStatus struct {
+	component  ComponentName
+	state      ComponentState
+	message    string // Error of a failed component, detail of a skipped one
+	skipReason SkipReason
+	updatedAt  time.Time
+}
+
+// NewComponentStatus creates a component status updated now
+func NewComponentStatus(component ComponentName, state ComponentState, errorMessage string) (ComponentStatus, error) {
+	return ReconstructComponentStatus(component, state, errorMessage, time.Now())
+}
+
+// NewSkippedComponentStatus creates the status of a component skipped for
+// reason, updated now. detail says what was found, such as the package
+// that is missing.
+func NewSkippedComponentStatus(component ComponentName, reason SkipReason, detail string) (ComponentStatus, error) {
+	return ReconstructSkippedComponentStatus(component, reason, detail, time.Now())
+}
+
+// ReconstructComponentStatus reconstructs a component status from persistent
+// storage. A failed component must carry the error that stopped it; use
+// ReconstructSkippedComponentStatus for skipped ones.
+func ReconstructComponentStatus(
+	component ComponentName,
+	state ComponentState,
+	errorMessage string,
+	updatedAt time.Time,
+) (ComponentStatus, error) {
+	if state == ComponentStateSkipped {
+		return ComponentStatus{}, fmt.Errorf("%w: skipped component must have a skip reason", ErrInvalidComponentStatus)
+	}
+	return reconstructComponentStatus(component, state, errorMessage, "", updatedAt)
+}
+
+// ReconstructSkippedComponentStatus reconstructs the status of a skipped
+// component from persistent storage
+func ReconstructSkippedComponentStatus(
+	component ComponentName,
+	reason SkipReason,
+	detail string,
+	updatedAt time.Time,
+) (ComponentStatus, error) {
+	if !reason.IsValid() {
+		return ComponentStatus{}, fmt.Errorf("%w: unknown skip reason %q", ErrInvalidComponentStatus, reason)
+	}
+	return reconstructComponentStatus(component, ComponentStateSkipped, detail, reason, updatedAt)
+}
+
+func reconstructComponentStatus(
+	component ComponentName,
+	state ComponentState,
+	message string,
+	skipReason SkipReason,
+	updatedAt time.Time,
+) (ComponentStatus, error) {
+	message = strings.TrimSpace(message)
+	if component == "" {
+		return ComponentStatus{}, fmt.Errorf("%w: component cannot be empty", ErrInvalidComponentStatus)
+	}
+	if !state.IsValid() {
+		return ComponentStatus{}, fmt.Errorf("%w: unknown state %q", ErrInvalidComponentStatus, state)
+	}
+	if state == ComponentStateFailed && message == "" {
+		return ComponentStatus{}, fmt.Errorf("%w: failed component must have an error message", ErrInvalidComponentStatus)
+	}
+	if state != ComponentStateFailed && state != ComponentStateSkipped {
+		message = ""
+	}
+	if updatedAt.IsZero() {
+		return ComponentStatus{}, fmt.Errorf("%w: updated time cannot be zero", ErrInvalidComponentStatus)
+	}
+
+	return ComponentStatus{
+		component:  component,
+		state:      state,
+		message:    message,
+		skipReason: skipReason,
+		updatedAt:  updatedAt,
+	}, nil
+}
+
+// Component returns the component this status belongs to
+func (s ComponentStatus) Component() ComponentName {
+	return s.component
+}
+
+// State returns the component's current state
+func (s ComponentStatus) State() ComponentState {
+	return s.state
+}
+
+// ErrorMessage returns why the component failed, or "" if it did not
+func (s ComponentStatus) ErrorMessage() string {
+	if s.state != ComponentStateFailed {
+		return ""
+	}
+	return s.message
+}
+
+// SkipReason returns why the component was skipped, or "" if it was not
+func (s ComponentStatus) SkipReason() SkipReason {
+	return s.skipReason
+}
+
+// SkipDetail returns what was found when the component was skipped, such
+// as the package that is missing; empty if it was not skipped
+func (s ComponentStatus) SkipDetail() string {
+	if s.state != ComponentStateSkipped {
+		return ""
+	}
+	return s.message
+}
+
+// UpdatedAt returns when the state last changed
+func (s ComponentStatus) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// IsFailed returns true if installation stopped on this component
+func (s ComponentStatus) IsFailed() bool {
+	return s.state == ComponentStateFailed
+}
+
+// IsSkipped returns true if the component was left out of the installation
+func (s ComponentStatus) IsSkipped() bool {
+	return s.state == ComponentStateSkipped
+}
+
+// IsPending returns true if the installation never touched this component
+func (s ComponentStatus) IsPending() bool {
+	return s.state == ComponentStatePending
+}
+
+// String returns human-readable representation
+func (s ComponentStatus) String() string {
+	if s.state == ComponentStateSkipped {
+		if s.message != "" {
+			return fmt.Sprintf("%s: %s, %s (%s)", s.component, s.state, s.skipReason, s.message)
+		}
+		return fmt.Sprintf("%s: %s, %s", s.component, s.state, s.skipReason)
+	}
+	if s.message != "" {
+		return fmt.Sprintf("%s: %s (%s)", s.component, s.state, s.message)
+	}
+	return fmt.Sprintf("%s: %s", s.component, s.state)
+}
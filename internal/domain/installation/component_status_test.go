@@ -0,0 +1,172 @@
+package installation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComponentStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		component installation.ComponentName
+		state     installation.ComponentState
+		message   string
+		wantErr   bool
+	}{
+		{"pending component", installation.ComponentHyprland, installation.ComponentStatePending, "", false},
+		{"failed with cause", installation.ComponentWaybar, installation.ComponentStateFailed, "exit status 100", false},
+		{"empty component", "", installation.ComponentStatePending, "", true},
+		{"unknown state", installation.ComponentKitty, "unpacking", "", true},
+		{"failed without cause", installation.ComponentKitty, installation.ComponentStateFailed, "  ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := installation.NewComponentStatus(tt.component, tt.state, tt.message)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, installation.ErrInvalidComponentStatus)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.component, status.Component())
+			assert.Equal(t, tt.state, status.State())
+			assert.Equal(t, tt.message, status.ErrorMessage())
+			assert.False(t, status.UpdatedAt().IsZero())
+		})
+	}
+}
+
+func TestReconstructComponentStatus(t *testing.T) {
+	updatedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	status, err := installation.ReconstructComponentStatus(
+		installation.ComponentWaybar, installation.ComponentStateFailed, "exit status 100", updatedAt)
+	require.NoError(t, err)
+	assert.Equal(t, updatedAt, status.UpdatedAt())
+	assert.True(t, status.IsFailed())
+	assert.Equal(t, "waybar: failed (exit status 100)", status.String())
+
+	// Only failures keep an error message
+	status, err = installation.ReconstructComponentStatus(
+		installation.ComponentWaybar, installation.ComponentStateVerified, "stale", updatedAt)
+	require.NoError(t, err)
+	assert.Empty(t, status.ErrorMessage())
+	assert.Equal(t, "waybar: verified", status.String())
+
+	_, err = installation.ReconstructComponentStatus(
+		installation.ComponentWaybar, installation.ComponentStatePending, "", time.Time{})
+	assert.ErrorIs(t, err, installation.ErrInvalidComponentStatus)
+
+	// Skipped components carry why
+	_, err = installation.ReconstructComponentStatus(
+		installation.ComponentWaybar, installation.ComponentStateSkipped, "", updatedAt)
+	assert.ErrorIs(t, err, installation.ErrInvalidComponentStatus)
+
+	status, err = installation.ReconstructSkippedComponentStatus(
+		installation.ComponentWaybar, installation.SkipReasonUnavailable, "package waybar is not available", updatedAt)
+	require.NoError(t, err)
+	assert.True(t, status.IsSkipped())
+	assert.Equal(t, installation.SkipReasonUnavailable, status.SkipReason())
+	assert.Equal(t, "package waybar is not available", status.SkipDetail())
+	assert.Empty(t, status.ErrorMessage())
+	assert.Equal(t, "waybar: skipped, unavailable (package waybar is not available)", status.String())
+
+	_, err = installation.ReconstructSkippedComponentStatus(
+		installation.ComponentWaybar, "bored", "", updatedAt)
+	assert.ErrorIs(t, err, installation.ErrInvalidComponentStatus)
+}
+
+func TestInstallationSession_ComponentStatuses(t *testing.T) {
+	config := mustCreateConfiguration(t, []installation.ComponentSelection{
+		mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0"),
+		mustCreateComponentSelection(t, installation.ComponentWaybar, "0.10.0"),
+	})
+
+	t.Run("starts every configured component as pending", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		statuses := session.ComponentStatuses()
+		require.Len(t, statuses, 2)
+		assert.Equal(t, installation.ComponentHyprland, statuses[0].Component())
+		assert.Equal(t, installation.ComponentWaybar, statuses[1].Component())
+		for _, status := range statuses {
+			assert.True(t, status.IsPending())
+		}
+	})
+
+	t.Run("tracks state changes and failure causes", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		require.NoError(t, session.MarkComponent(installation.ComponentHyprland, installation.ComponentStateInstalling))
+		require.NoError(t, session.FailComponent(installation.ComponentHyprland, "failed to install hyprland"))
+
+		status, ok := session.ComponentStatus(installation.ComponentHyprland)
+		require.True(t, ok)
+		assert.Equal(t, installation.ComponentStateFailed, status.State())
+		assert.Equal(t, "failed to install hyprland", status.ErrorMessage())
+
+		failed := session.FailedComponents()
+		require.Len(t, failed, 1)
+		assert.Equal(t, installation.ComponentHyprland, failed[0].Component())
+
+		waybar, ok := session.ComponentStatus(installation.ComponentWaybar)
+		require.True(t, ok)
+		assert.True(t, waybar.IsPending())
+	})
+
+	t.Run("records skipped components with their reason", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		err = session.MarkComponent(installation.ComponentWaybar, installation.ComponentStateSkipped)
+		assert.ErrorIs(t, err, installation.ErrInvalidComponentStatus, "skipping needs a reason")
+
+		require.NoError(t, session.SkipComponent(installation.ComponentWaybar, installation.SkipReasonLiteMode, "package waybar is too heavy for lite mode"))
+
+		skipped := session.SkippedComponents()
+		require.Len(t, skipped, 1)
+		assert.Equal(t, installation.ComponentWaybar, skipped[0].Component())
+		assert.Equal(t, installation.SkipReasonLiteMode, skipped[0].SkipReason())
+		assert.Equal(t, "package waybar is too heavy for lite mode", skipped[0].SkipDetail())
+		assert.Empty(t, session.FailedComponents())
+	})
+
+	t.Run("rejects components outside the configuration", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		err = session.MarkComponent(installation.ComponentKitty, installation.ComponentStateInstalling)
+		assert.ErrorIs(t, err, installation.ErrComponentNotFound)
+	})
+
+	t.Run("requires a cause to fail a component", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		err = session.MarkComponent(installation.ComponentHyprland, installation.ComponentStateFailed)
+		assert.ErrorIs(t, err, installation.ErrInvalidComponentStatus)
+
+		err = session.FailComponent(installation.ComponentHyprland, "")
+		assert.ErrorIs(t, err, installation.ErrInvalidComponentStatus)
+	})
+
+	t.Run("returns a defensive copy", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		statuses := session.ComponentStatuses()
+		statuses[0] = installation.ComponentStatus{}
+
+		status, ok := session.ComponentStatus(installation.ComponentHyprland)
+		require.True(t, ok)
+		assert.True(t, status.IsPending())
+	})
+}
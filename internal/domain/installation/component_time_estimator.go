@@ -0,0 +1,73 @@
+package installation
+
+import (
+	"fmt"
+	"time"
+)
+
+// ComponentTimeEstimator estimates how long each component takes to
+// download and install. It starts from the throughput measured during
+// preflight and leans on the rate observed on installed components as more
+// of them complete.
+type ComponentTimeEstimator struct {
+	systemContext SystemContext
+	observedBytes uint64
+	observedTime  time.Duration
+}
+
+// NewComponentTimeEstimator creates an estimator for a system whose
+// throughput may or may not have been measured
+func NewComponentTimeEstimator(systemContext SystemContext) *ComponentTimeEstimator {
+	return &ComponentTimeEstimator{systemContext: systemContext}
+}
+
+// Observe records how long a component with the given download size took
+// to download and install
+func (e *ComponentTimeEstimator) Observe(downloadBytes uint64, took time.Duration) {
+	if downloadBytes == 0 || took <= 0 {
+		return
+	}
+	e.observedBytes += downloadBytes
+	e.observedTime += took
+}
+
+// Estimate returns how long a component with the given download size is
+// expected to take, or 0 when neither a measurement nor an observation is
+// available to base it on
+func (e *ComponentTimeEstimator) Estimate(downloadBytes uint64) time.Duration {
+	measured := e.systemContext.EstimatePackageTime(downloadBytes)
+	if e.observedBytes == 0 {
+		return measured
+	}
+
+	observed := float64(e.observedTime) * float64(downloadBytes) / float64(e.observedBytes)
+	if !e.systemContext.IsMeasured() {
+		return time.Duration(observed)
+	}
+
+	// A single small component says little, so observations count for
+	// more as their volume grows
+	weight := float64(e.observedBytes) / float64(e.observedBytes+DefaultComponentDownloadBytes)
+	return time.Duration(observed*weight + float64(measured)*(1-weight))
+}
+
+// DescribeEstimate phrases an estimated duration for progress messages,
+// such as "about 3 min"; zero and negative durations give ""
+func DescribeEstimate(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return ""
+	case d < time.Minute:
+		return "under a minute"
+	}
+
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("about %d min", int(d.Minutes()))
+	}
+	hours := int(d.Hours())
+	if minutes := int(d.Minutes()) % 60; minutes > 0 {
+		return fmt.Sprintf("about %d h %d min", hours, minutes)
+	}
+	return fmt.Sprintf("about %d h", hours)
+}
@@ -0,0 +1,70 @@
+package installation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentTimeEstimator_Estimate(t *testing.T) {
+	t.Run("nothing to base an estimate on", func(t *testing.T) {
+		estimator := installation.NewComponentTimeEstimator(installation.SystemContext{})
+
+		assert.Zero(t, estimator.Estimate(100*installation.MB))
+	})
+
+	t.Run("measured throughput before any component is installed", func(t *testing.T) {
+		systemContext, err := installation.NewSystemContext(0, float64(installation.MB), time.Now())
+		require.NoError(t, err)
+		estimator := installation.NewComponentTimeEstimator(systemContext)
+
+		assert.Equal(t, 100*time.Second, estimator.Estimate(100*installation.MB))
+	})
+
+	t.Run("observed rate without a measurement", func(t *testing.T) {
+		estimator := installation.NewComponentTimeEstimator(installation.SystemContext{})
+		estimator.Observe(50*installation.MB, 25*time.Second)
+		estimator.Observe(0, time.Hour) // ignored
+
+		assert.Equal(t, 50*time.Second, estimator.Estimate(100*installation.MB))
+	})
+
+	t.Run("observations outweigh the measurement as they add up", func(t *testing.T) {
+		systemContext, err := installation.NewSystemContext(0, float64(installation.MB), time.Now())
+		require.NoError(t, err)
+		estimator := installation.NewComponentTimeEstimator(systemContext)
+
+		// Installing runs four times slower than the measurement predicts
+		estimator.Observe(100*installation.MB, 400*time.Second)
+		first := estimator.Estimate(100 * installation.MB)
+		assert.Equal(t, 250*time.Second, first, "equal weight after one default-sized component")
+
+		estimator.Observe(300*installation.MB, 1200*time.Second)
+		second := estimator.Estimate(100 * installation.MB)
+		assert.Greater(t, second, first)
+		assert.Less(t, second, 400*time.Second)
+	})
+}
+
+func TestDescribeEstimate(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, ""},
+		{-time.Second, ""},
+		{20 * time.Second, "under a minute"},
+		{150 * time.Second, "about 3 min"},
+		{59*time.Minute + 40*time.Second, "about 1 h"},
+		{80 * time.Minute, "about 1 h 20 min"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.duration.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, installation.DescribeEstimate(tt.duration))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ComponentVerification is a value object for the outcome of verifying one
+// installed component, with how long the checks took
+type ComponentVerification struct {
+	component ComponentName
+	problems  []string
+	duration  time.Duration
+	checkedAt time.Time
+}
+
+// NewComponentVerification creates a verification checked now. A component
+// with no problems passed.
+func NewComponentVerification(component ComponentName, problems []string, duration time.Duration) (ComponentVerification, error) {
+	return ReconstructComponentVerification(component, problems, duration, time.Now())
+}
+
+// ReconstructComponentVerification reconstructs a verification from
+// persistent storage
+func ReconstructComponentVerification(
+	component ComponentName,
+	problems []string,
+	duration time.Duration,
+	checkedAt time.Time,
+) (ComponentVerification, error) {
+	if component == "" {
+		return ComponentVerification{}, fmt.Errorf("%w: component cannot be empty", ErrInvalidComponentVerification)
+	}
+	if duration < 0 {
+		return ComponentVerification{}, fmt.Errorf("%w: duration cannot be negative", ErrInvalidComponentVerification)
+	}
+	if checkedAt.IsZero() {
+		return ComponentVerification{}, fmt.Errorf("%w: checked time cannot be zero", ErrInvalidComponentVerification)
+	}
+
+	var kept []string
+	for _, problem := range problems {
+		if problem = strings.TrimSpace(problem); problem != "" {
+			kept = append(kept, problem)
+		}
+	}
+
+	return ComponentVerification{
+		component: component,
+		problems:  kept,
+		duration:  duration,
+		checkedAt: checkedAt,
+	}, nil
+}
+
+// Component returns the verified component
+func (v ComponentVerification) Component() ComponentName {
+	return v.component
+}
+
+// Problems returns a defensive copy of what failed verification
+func (v ComponentVerification) Problems() []string {
+	problems := make([]string, len(v.problems))
+	copy(problems, v.problems)
+	return problems
+}
+
+// Passed returns true if every check passed
+func (v ComponentVerification) Passed() bool {
+	return len(v.problems) == 0
+}
+
+// Duration returns how long verifying the component took
+func (v ComponentVerification) Duration() time.Duration {
+	return v.duration
+}
+
+// CheckedAt returns when the component was verified
+func (v ComponentVerification) CheckedAt() time.Time {
+	return v.checkedAt
+}
+
+// String returns human-readable representation
+func (v ComponentVerification) String() string {
+	if v.Passed() {
+		return fmt.Sprintf("%s: passed in %s", v.component, v.duration)
+	}
+	return fmt.Sprintf("%s: failed in %s (%s)", v.component, v.duration, strings.Join(v.problems, "; "))
+}
@@ -0,0 +1,102 @@
+package installation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComponentVerification(t *testing.T) {
+	t.Run("passes without problems", func(t *testing.T) {
+		verification, err := installation.NewComponentVerification(installation.ComponentWaybar, []string{" ", ""}, 120*time.Millisecond)
+
+		require.NoError(t, err)
+		assert.True(t, verification.Passed())
+		assert.Empty(t, verification.Problems())
+		assert.Equal(t, 120*time.Millisecond, verification.Duration())
+		assert.False(t, verification.CheckedAt().IsZero())
+		assert.Equal(t, "waybar: passed in 120ms", verification.String())
+	})
+
+	t.Run("fails with problems", func(t *testing.T) {
+		verification, err := installation.NewComponentVerification(installation.ComponentWaybar,
+			[]string{"missing binary /usr/bin/waybar"}, time.Second)
+
+		require.NoError(t, err)
+		assert.False(t, verification.Passed())
+		assert.Equal(t, []string{"missing binary /usr/bin/waybar"}, verification.Problems())
+		assert.Equal(t, "waybar: failed in 1s (missing binary /usr/bin/waybar)", verification.String())
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		_, err := installation.NewComponentVerification("", nil, time.Second)
+		assert.ErrorIs(t, err, installation.ErrInvalidComponentVerification)
+
+		_, err = installation.NewComponentVerification(installation.ComponentWaybar, nil, -time.Second)
+		assert.ErrorIs(t, err, installation.ErrInvalidComponentVerification)
+
+		_, err = installation.ReconstructComponentVerification(installation.ComponentWaybar, nil, time.Second, time.Time{})
+		assert.ErrorIs(t, err, installation.ErrInvalidComponentVerification)
+	})
+}
+
+func TestInstallationSession_RecordComponentVerification(t *testing.T) {
+	config := mustCreateConfiguration(t, []installation.ComponentSelection{
+		mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0"),
+		mustCreateComponentSelection(t, installation.ComponentWaybar, "0.10.0"),
+	})
+
+	t.Run("moves components to verified or failed", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		passed, err := installation.NewComponentVerification(installation.ComponentHyprland, nil, time.Second)
+		require.NoError(t, err)
+		failed, err := installation.NewComponentVerification(installation.ComponentWaybar,
+			[]string{"configuration waybar/config.jsonc is empty"}, time.Second)
+		require.NoError(t, err)
+
+		require.NoError(t, session.RecordComponentVerification(passed))
+		require.NoError(t, session.RecordComponentVerification(failed))
+
+		hyprland, _ := session.ComponentStatus(installation.ComponentHyprland)
+		assert.Equal(t, installation.ComponentStateVerified, hyprland.State())
+
+		waybar, _ := session.ComponentStatus(installation.ComponentWaybar)
+		assert.True(t, waybar.IsFailed())
+		assert.Equal(t, "verification failed: configuration waybar/config.jsonc is empty", waybar.ErrorMessage())
+
+		verifications := session.ComponentVerifications()
+		require.Len(t, verifications, 2)
+		assert.Equal(t, installation.ComponentHyprland, verifications[0].Component())
+		assert.Equal(t, installation.ComponentWaybar, verifications[1].Component())
+	})
+
+	t.Run("replaces an earlier verification of the same component", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		first, _ := installation.NewComponentVerification(installation.ComponentHyprland, []string{"missing"}, time.Second)
+		second, _ := installation.NewComponentVerification(installation.ComponentHyprland, nil, 2*time.Second)
+		require.NoError(t, session.RecordComponentVerification(first))
+		require.NoError(t, session.RecordComponentVerification(second))
+
+		verification, ok := session.ComponentVerification(installation.ComponentHyprland)
+		require.True(t, ok)
+		assert.True(t, verification.Passed())
+		assert.Len(t, session.ComponentVerifications(), 1)
+	})
+
+	t.Run("rejects components outside the configuration", func(t *testing.T) {
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+
+		verification, _ := installation.NewComponentVerification(installation.ComponentKitty, nil, time.Second)
+
+		assert.ErrorIs(t, session.RecordComponentVerification(verification), installation.ErrComponentNotFound)
+		assert.Empty(t, session.ComponentVerifications())
+	})
+}
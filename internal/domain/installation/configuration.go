@@ -8,6 +8,11 @@ type InstallationConfiguration struct {
 	gpuSupport        *GPUSupport
 	diskSpace         DiskSpace
 	mergeExistingConf bool
+	alternatives      AlternativeSelection
+	renderingMode     RenderingMode
+	gpus              GPUSelection
+	accessibility     AccessibilitySettings
+	dryRun            bool
 }
 
 // NewInstallationConfiguration creates a new installation configuration value object
@@ -93,6 +98,77 @@ func (c InstallationConfiguration) MergeExistingConfig() bool {
 	return c.mergeExistingConf
 }
 
+// Alternatives returns the providers chosen for alternative slots
+func (c InstallationConfiguration) Alternatives() AlternativeSelection {
+	return c.alternatives
+}
+
+// WithAlternatives returns a copy of the configuration using the given
+// providers for alternative slots
+// Returns ErrConflictingAlternatives if the components request a different
+// provider for a slot, or two providers of the same slot
+func (c InstallationConfiguration) WithAlternatives(selection AlternativeSelection) (InstallationConfiguration, error) {
+	if err := selection.ValidateComponents(c.components); err != nil {
+		return InstallationConfiguration{}, err
+	}
+
+	c.alternatives = selection
+	return c, nil
+}
+
+// RenderingMode returns the requested rendering mode
+// Unset modes are reported as RenderingAuto
+func (c InstallationConfiguration) RenderingMode() RenderingMode {
+	if c.renderingMode == "" {
+		return RenderingAuto
+	}
+	return c.renderingMode
+}
+
+// WithRenderingMode returns a copy of the configuration using the given
+// rendering mode
+func (c InstallationConfiguration) WithRenderingMode(mode RenderingMode) InstallationConfiguration {
+	c.renderingMode = mode
+	return c
+}
+
+// GPUs returns the detected GPUs and the chosen render GPU
+func (c InstallationConfiguration) GPUs() GPUSelection {
+	return c.gpus
+}
+
+// WithGPUs returns a copy of the configuration with the detected GPUs and
+// the chosen render GPU
+func (c InstallationConfiguration) WithGPUs(gpus GPUSelection) InstallationConfiguration {
+	c.gpus = gpus
+	return c
+}
+
+// Accessibility returns the accessibility options the configuration is rendered
+// with
+func (c InstallationConfiguration) Accessibility() AccessibilitySettings {
+	return c.accessibility
+}
+
+// WithAccessibility returns a copy of the configuration using the given
+// accessibility options
+func (c InstallationConfiguration) WithAccessibility(settings AccessibilitySettings) InstallationConfiguration {
+	c.accessibility = settings
+	return c
+}
+
+// DryRun returns true if the installation is only simulated
+func (c InstallationConfiguration) DryRun() bool {
+	return c.dryRun
+}
+
+// WithDryRun returns a copy of the configuration that simulates the
+// installation instead of changing the system
+func (c InstallationConfiguration) WithDryRun(dryRun bool) InstallationConfiguration {
+	c.dryRun = dryRun
+	return c
+}
+
 // TotalEstimatedSizeBytes returns the sum of all component sizes
 // Returns 0 if components don't have package info
 func (c InstallationConfiguration) TotalEstimatedSizeBytes() uint64 {
@@ -108,6 +184,17 @@ func (c InstallationConfiguration) TotalEstimatedSizeMB() float64 {
 	return float64(c.TotalEstimatedSizeBytes()) / float64(MB)
 }
 
+// EstimatedDownloadBytes returns the expected download size of the
+// configuration, assuming DefaultComponentDownloadBytes for components
+// without package information
+func (c InstallationConfiguration) EstimatedDownloadBytes() uint64 {
+	var total uint64
+	for _, comp := range c.components {
+		total += comp.DownloadBytes()
+	}
+	return total
+}
+
 // String returns human-readable representation
 func (c InstallationConfiguration) String() string {
 	gpuInfo := "no GPU config"
@@ -120,6 +207,11 @@ func (c InstallationConfiguration) String() string {
 		mergeInfo = ", merge existing"
 	}
 
-	return fmt.Sprintf("Installation: %d components, %s%s",
-		len(c.components), gpuInfo, mergeInfo)
+	dryRunInfo := ""
+	if c.dryRun {
+		dryRunInfo = ", dry run"
+	}
+
+	return fmt.Sprintf("Installation: %d components, %s%s%s",
+		len(c.components), gpuInfo, mergeInfo, dryRunInfo)
 }
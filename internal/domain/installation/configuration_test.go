@@ -220,6 +220,25 @@ func TestInstallationConfiguration_String(t *testing.T) {
 	str := config.String()
 	assert.Contains(t, str, "2 components")
 	assert.Contains(t, str, "GPU: amd")
+	assert.NotContains(t, str, "dry run")
+	assert.Contains(t, config.WithDryRun(true).String(), "dry run")
+}
+
+func TestInstallationConfiguration_WithDryRun(t *testing.T) {
+	config, err := installation.NewInstallationConfiguration(
+		[]installation.ComponentSelection{
+			mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0"),
+		},
+		nil,
+		mustCreateDiskSpace(t, 20*installation.GB, 10*installation.GB),
+		false,
+	)
+	require.NoError(t, err)
+
+	dryRun := config.WithDryRun(true)
+	assert.True(t, dryRun.DryRun())
+	assert.False(t, config.DryRun(), "the original configuration is unchanged")
+	assert.Equal(t, config.Components(), dryRun.Components())
 }
 
 func TestInstallationConfiguration_ValueObjectImmutability(t *testing.T) {
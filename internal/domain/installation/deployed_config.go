@@ -0,0 +1,50 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DeployedConfig is a value object for a configuration file written during
+// installation, identified by its path and the SHA-256 of its content
+type DeployedConfig struct {
+	path     string
+	hash     string
+	backupID string
+}
+
+// NewDeployedConfig creates a deployed config. Path and hash are required.
+func NewDeployedConfig(path, hash string) (DeployedConfig, error) {
+	path = strings.TrimSpace(path)
+	hash = strings.TrimSpace(hash)
+	if path == "" {
+		return DeployedConfig{}, fmt.Errorf("%w: path cannot be empty", ErrInvalidDeployedConfig)
+	}
+	if hash == "" {
+		return DeployedConfig{}, fmt.Errorf("%w: hash cannot be empty", ErrInvalidDeployedConfig)
+	}
+
+	return DeployedConfig{path: path, hash: hash}, nil
+}
+
+// Path returns where the file was deployed
+func (c DeployedConfig) Path() string {
+	return c.path
+}
+
+// Hash returns the SHA-256 of the deployed content
+func (c DeployedConfig) Hash() string {
+	return c.hash
+}
+
+// BackupID returns the backup holding the file the deployment replaced;
+// empty when nothing was replaced
+func (c DeployedConfig) BackupID() string {
+	return c.backupID
+}
+
+// WithBackupID returns a copy recording the backup of the replaced file
+func (c DeployedConfig) WithBackupID(backupID string) DeployedConfig {
+	c.backupID = strings.TrimSpace(backupID)
+	return c
+}
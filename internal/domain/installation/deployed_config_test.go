@@ -0,0 +1,65 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeployedConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		hash    string
+		wantErr bool
+	}{
+		{"valid config", "/home/user/.config/hypr/hyprland.conf", "abc123", false},
+		{"empty path", " ", "abc123", true},
+		{"empty hash", "/home/user/.config/hypr/hyprland.conf", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := installation.NewDeployedConfig(tt.path, tt.hash)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, installation.ErrInvalidDeployedConfig)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.path, config.Path())
+			assert.Equal(t, tt.hash, config.Hash())
+		})
+	}
+}
+
+func TestDeployedConfig_WithBackupID(t *testing.T) {
+	config, err := installation.NewDeployedConfig("/home/user/.config/hypr/hyprland.conf", "abc123")
+	require.NoError(t, err)
+	assert.Empty(t, config.BackupID())
+
+	backedUp := config.WithBackupID(" backup-1 ")
+	assert.Equal(t, "backup-1", backedUp.BackupID())
+	assert.Empty(t, config.BackupID(), "original is unchanged")
+}
+
+func TestInstallationSession_RecordDeployedConfigs(t *testing.T) {
+	session, err := installation.NewInstallationSession(createValidConfig(t))
+	require.NoError(t, err)
+	assert.Empty(t, session.DeployedConfigs())
+
+	config, err := installation.NewDeployedConfig("/home/user/.config/waybar/config.jsonc", "def456")
+	require.NoError(t, err)
+	session.RecordDeployedConfigs([]installation.DeployedConfig{config})
+
+	configs := session.DeployedConfigs()
+	require.Len(t, configs, 1)
+	assert.Equal(t, config, configs[0])
+
+	// Returned slice is a copy
+	configs[0] = installation.DeployedConfig{}
+	assert.Equal(t, config, session.DeployedConfigs()[0])
+}
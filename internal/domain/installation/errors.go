@@ -10,16 +10,39 @@ var (
 	ErrInvalidGPUSupport         = errors.New("invalid GPU support configuration")
 	ErrInvalidConfiguration      = errors.New("invalid installation configuration")
 	ErrInvalidProgress           = errors.New("invalid installation progress")
+	ErrInvalidWarning            = errors.New("invalid installation warning")
+	ErrInvalidAlternative        = errors.New("invalid alternative selection")
+	ErrInvalidRenderingMode      = errors.New("invalid rendering mode")
+	ErrInvalidAccessibilityOption = errors.New("invalid accessibility option")
+	ErrInvalidWeatherLocation    = errors.New("invalid weather location")
+	ErrInvalidNightLightLocation = errors.New("invalid night light location")
+	ErrInvalidHookPhase          = errors.New("invalid hook phase")
+	ErrInvalidLockScreen         = errors.New("invalid lock screen settings")
+	ErrInvalidSystemContext      = errors.New("invalid system context")
+	ErrInvalidPreflightCheck     = errors.New("invalid preflight check")
+	ErrInvalidDeployedConfig     = errors.New("invalid deployed config")
+	ErrInvalidGPUDevice          = errors.New("invalid GPU device")
+	ErrUnknownRenderGPU          = errors.New("render GPU is not among the detected GPUs")
+	ErrInvalidComponentStatus    = errors.New("invalid component status")
+	ErrInvalidComponentVerification = errors.New("invalid component verification")
+	ErrInvalidTimelineEntry      = errors.New("invalid timeline entry")
+	ErrInvalidInvocation         = errors.New("invalid invocation")
+	ErrInvalidPhaseTiming        = errors.New("invalid phase timing")
+	ErrUnknownProfile            = errors.New("unknown installation profile")
+	ErrUnknownSuite              = errors.New("unknown Debian suite")
 
 	// Installation Session errors
-	ErrInsufficientDiskSpace  = errors.New("insufficient disk space for installation")
-	ErrPackageConflict        = errors.New("package conflict detected")
-	ErrNetworkInterruption    = errors.New("network connection interrupted")
-	ErrInstallationFailed     = errors.New("installation failed")
-	ErrRollbackFailed         = errors.New("rollback operation failed")
-	ErrInvalidStateTransition = errors.New("invalid state transition")
-	ErrSessionNotStarted      = errors.New("installation session not started")
-	ErrSessionAlreadyComplete = errors.New("installation session already completed")
+	ErrInsufficientDiskSpace   = errors.New("insufficient disk space for installation")
+	ErrPackageConflict         = errors.New("package conflict detected")
+	ErrConflictNotRecorded     = errors.New("conflict was not recorded on the session")
+	ErrConflictingAlternatives = errors.New("conflicting alternatives requested")
+	ErrNetworkInterruption     = errors.New("network connection interrupted")
+	ErrInstallationFailed      = errors.New("installation failed")
+	ErrInstallationCancelled   = errors.New("installation cancelled by user")
+	ErrRollbackFailed          = errors.New("rollback operation failed")
+	ErrInvalidStateTransition  = errors.New("invalid state transition")
+	ErrSessionNotStarted       = errors.New("installation session not started")
+	ErrSessionAlreadyComplete  = errors.New("installation session already completed")
 
 	// Component errors
 	ErrComponentNotFound      = errors.New("component not found")
@@ -20,12 +20,17 @@ func TestErrors_AreSentinelErrors(t *testing.T) {
 		{"ErrInvalidGPUSupport", ErrInvalidGPUSupport},
 		{"ErrInvalidConfiguration", ErrInvalidConfiguration},
 		{"ErrInvalidProgress", ErrInvalidProgress},
+		{"ErrInvalidWarning", ErrInvalidWarning},
+		{"ErrInvalidAlternative", ErrInvalidAlternative},
+		{"ErrInvalidRenderingMode", ErrInvalidRenderingMode},
 
 		// Installation Session errors
 		{"ErrInsufficientDiskSpace", ErrInsufficientDiskSpace},
 		{"ErrPackageConflict", ErrPackageConflict},
+		{"ErrConflictingAlternatives", ErrConflictingAlternatives},
 		{"ErrNetworkInterruption", ErrNetworkInterruption},
 		{"ErrInstallationFailed", ErrInstallationFailed},
+		{"ErrInstallationCancelled", ErrInstallationCancelled},
 		{"ErrRollbackFailed", ErrRollbackFailed},
 		{"ErrInvalidStateTransition", ErrInvalidStateTransition},
 		{"ErrSessionNotStarted", ErrSessionNotStarted},
@@ -152,10 +157,15 @@ func TestErrors_AreDistinct(t *testing.T) {
 		ErrInvalidGPUSupport,
 		ErrInvalidConfiguration,
 		ErrInvalidProgress,
+		ErrInvalidWarning,
+		ErrInvalidAlternative,
+		ErrInvalidRenderingMode,
 		ErrInsufficientDiskSpace,
 		ErrPackageConflict,
+		ErrConflictingAlternatives,
 		ErrNetworkInterruption,
 		ErrInstallationFailed,
+		ErrInstallationCancelled,
 		ErrRollbackFailed,
 		ErrInvalidStateTransition,
 		ErrSessionNotStarted,
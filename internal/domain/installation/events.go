@@ -378,6 +378,53 @@ func (e ConflictDetectedEvent) Severity() string {
 	return e.severity
 }
 
+// ConflictResolvedEvent signals a package conflict was resolved with a strategy
+type ConflictResolvedEvent struct {
+	occurredAt         time.Time
+	sessionID          string
+	packageName        string
+	conflictingPackage string
+	strategy           ResolutionAction
+}
+
+// NewConflictResolvedEvent creates a new conflict resolved event
+func NewConflictResolvedEvent(
+	sessionID, packageName, conflictingPackage string,
+	strategy ResolutionAction,
+) ConflictResolvedEvent {
+	return ConflictResolvedEvent{
+		occurredAt:         time.Now(),
+		sessionID:          sessionID,
+		packageName:        packageName,
+		conflictingPackage: conflictingPackage,
+		strategy:           strategy,
+	}
+}
+
+func (e ConflictResolvedEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e ConflictResolvedEvent) EventType() string {
+	return "installation.conflict.resolved"
+}
+
+func (e ConflictResolvedEvent) SessionID() string {
+	return e.sessionID
+}
+
+func (e ConflictResolvedEvent) PackageName() string {
+	return e.packageName
+}
+
+func (e ConflictResolvedEvent) ConflictingPackage() string {
+	return e.conflictingPackage
+}
+
+func (e ConflictResolvedEvent) Strategy() ResolutionAction {
+	return e.strategy
+}
+
 // BackupCreatedEvent signals a backup was successfully created
 type BackupCreatedEvent struct {
 	occurredAt    time.Time
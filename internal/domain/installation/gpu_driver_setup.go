@@ -0,0 +1,181 @@
+package installation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kernel modules GPU drivers load or compete with
+const (
+	ModuleNVIDIA    = "nvidia"
+	ModuleNVIDIADRM = "nvidia_drm"
+	ModuleNouveau   = "nouveau"
+	ModuleAMDGPU    = "amdgpu"
+)
+
+// Fixes for the kernel setup of the NVIDIA driver
+const (
+	nouveauBlacklistFix = "printf 'blacklist nouveau\\noptions nouveau modeset=0\\n' | sudo tee /etc/modprobe.d/blacklist-nouveau.conf && sudo update-initramfs -u"
+	nvidiaModesetFix    = "echo 'options nvidia-drm modeset=1' | sudo tee /etc/modprobe.d/nvidia-drm-modeset.conf && sudo update-initramfs -u"
+)
+
+// NeedsKernelSetup returns true for GPU drivers that need steps after
+// their packages are installed: the NVIDIA driver and the AMD firmware
+// are loaded from the initramfs, which must be rebuilt, and take effect
+// after a reboot
+func (c ComponentName) NeedsKernelSetup() bool {
+	return c == ComponentNVIDIADriver || c == ComponentAMDDriver
+}
+
+// KernelModule returns the kernel module that drives the GPU for a driver
+// component, or "" for drivers without kernel setup
+func (c ComponentName) KernelModule() string {
+	switch c {
+	case ComponentNVIDIADriver:
+		return ModuleNVIDIA
+	case ComponentAMDDriver:
+		return ModuleAMDGPU
+	default:
+		return ""
+	}
+}
+
+// GPUDriverProblem is something in the kernel setup of a GPU driver that
+// keeps it from working, and how to fix it
+type GPUDriverProblem struct {
+	Message string
+	Fix     string
+	Reboot  bool // Fixed by rebooting, or needs a reboot after the fix
+}
+
+// GPUDriverState is the kernel setup found for an installed GPU driver:
+// whether nouveau is blacklisted and NVIDIA kernel mode setting enabled,
+// which only matter to the NVIDIA driver, and the loaded modules
+type GPUDriverState struct {
+	driver             ComponentName
+	nouveauBlacklisted bool
+	modesetEnabled     bool
+	loaded             map[string]bool
+}
+
+// NewGPUDriverState creates the state of a driver that needs kernel setup
+func NewGPUDriverState(driver ComponentName, nouveauBlacklisted, modesetEnabled bool, loadedModules []string) (GPUDriverState, error) {
+	if !driver.NeedsKernelSetup() {
+		return GPUDriverState{}, fmt.Errorf("%w: %s needs no kernel setup", ErrInvalidGPUSupport, driver)
+	}
+
+	loaded := make(map[string]bool, len(loadedModules))
+	for _, module := range loadedModules {
+		loaded[strings.TrimSpace(module)] = true
+	}
+
+	return GPUDriverState{
+		driver:             driver,
+		nouveauBlacklisted: nouveauBlacklisted,
+		modesetEnabled:     modesetEnabled,
+		loaded:             loaded,
+	}, nil
+}
+
+// Driver returns the driver component
+func (s GPUDriverState) Driver() ComponentName {
+	return s.driver
+}
+
+// NouveauBlacklisted returns true if modprobe is told not to load nouveau
+func (s GPUDriverState) NouveauBlacklisted() bool {
+	return s.nouveauBlacklisted
+}
+
+// ModesetEnabled returns true if nvidia-drm is set to do kernel mode
+// setting, which Hyprland needs
+func (s GPUDriverState) ModesetEnabled() bool {
+	return s.modesetEnabled
+}
+
+// IsLoaded returns true if the kernel module is loaded
+func (s GPUDriverState) IsLoaded(module string) bool {
+	return s.loaded[module]
+}
+
+// LoadedModules returns the loaded modules in a stable order
+func (s GPUDriverState) LoadedModules() []string {
+	modules := make([]string, 0, len(s.loaded))
+	for module := range s.loaded {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// ConfigurationProblems returns the kernel configuration that keeps the
+// driver from working once loaded: for the NVIDIA driver, nouveau not
+// being blacklisted and kernel mode setting being off
+func (s GPUDriverState) ConfigurationProblems() []GPUDriverProblem {
+	if s.driver != ComponentNVIDIADriver {
+		return nil
+	}
+
+	var problems []GPUDriverProblem
+	if !s.nouveauBlacklisted {
+		problems = append(problems, GPUDriverProblem{
+			Message: "nouveau is not blacklisted, so it can claim the GPU before the nvidia driver",
+			Fix:     nouveauBlacklistFix,
+			Reboot:  true,
+		})
+	}
+	if !s.modesetEnabled {
+		problems = append(problems, GPUDriverProblem{
+			Message: "nvidia-drm kernel mode setting is off; Hyprland needs nvidia-drm.modeset=1",
+			Fix:     nvidiaModesetFix,
+			Reboot:  true,
+		})
+	}
+	return problems
+}
+
+// LoadProblems returns the modules that are not in the state the driver
+// needs, which a reboot after installing the driver sets right
+func (s GPUDriverState) LoadProblems() []GPUDriverProblem {
+	var problems []GPUDriverProblem
+	if s.driver == ComponentNVIDIADriver && s.loaded[ModuleNouveau] {
+		problems = append(problems, GPUDriverProblem{
+			Message: "nouveau is still driving the GPU",
+			Fix:     "Reboot so the nvidia driver takes over the GPU",
+			Reboot:  true,
+		})
+	}
+
+	module := s.driver.KernelModule()
+	if !s.loaded[module] {
+		problems = append(problems, GPUDriverProblem{
+			Message: fmt.Sprintf("The %s module is not loaded", module),
+			Fix:     fmt.Sprintf("Reboot to load the %s driver; if it is still not loaded, check: sudo dmesg | grep -i %s", module, module),
+			Reboot:  true,
+		})
+	} else if s.driver == ComponentNVIDIADriver && !s.loaded[ModuleNVIDIADRM] {
+		problems = append(problems, GPUDriverProblem{
+			Message: "The nvidia_drm module is not loaded, so Wayland compositors cannot use the GPU",
+			Fix:     nvidiaModesetFix + " && sudo reboot",
+			Reboot:  true,
+		})
+	}
+	return problems
+}
+
+// Problems returns the configuration problems followed by the load
+// problems
+func (s GPUDriverState) Problems() []GPUDriverProblem {
+	return append(s.ConfigurationProblems(), s.LoadProblems()...)
+}
+
+// RebootRequired returns true if a reboot is needed for the driver to work
+func (s GPUDriverState) RebootRequired() bool {
+	for _, problem := range s.Problems() {
+		if problem.Reboot {
+			return true
+		}
+	}
+	return false
+}
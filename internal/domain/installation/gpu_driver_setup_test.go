@@ -0,0 +1,71 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGPUDriverState(t *testing.T) {
+	t.Run("accepts drivers that need kernel setup", func(t *testing.T) {
+		for _, driver := range []installation.ComponentName{installation.ComponentNVIDIADriver, installation.ComponentAMDDriver} {
+			state, err := installation.NewGPUDriverState(driver, false, false, nil)
+			require.NoError(t, err)
+			assert.Equal(t, driver, state.Driver())
+		}
+	})
+
+	t.Run("rejects other components", func(t *testing.T) {
+		for _, driver := range []installation.ComponentName{installation.ComponentIntelDriver, installation.ComponentHyprland} {
+			_, err := installation.NewGPUDriverState(driver, true, true, nil)
+			assert.ErrorIs(t, err, installation.ErrInvalidGPUSupport)
+		}
+	})
+}
+
+func TestGPUDriverState_ConfigurationProblems(t *testing.T) {
+	t.Run("NVIDIA needs nouveau blacklisted and modeset on", func(t *testing.T) {
+		state, err := installation.NewGPUDriverState(installation.ComponentNVIDIADriver, false, false, nil)
+		require.NoError(t, err)
+
+		problems := state.ConfigurationProblems()
+		require.Len(t, problems, 2)
+		assert.Contains(t, problems[0].Fix, "blacklist nouveau")
+		assert.Contains(t, problems[1].Fix, "nvidia-drm modeset=1")
+		for _, problem := range problems {
+			assert.Contains(t, problem.Fix, "update-initramfs -u")
+		}
+	})
+
+	t.Run("AMD needs neither", func(t *testing.T) {
+		state, err := installation.NewGPUDriverState(installation.ComponentAMDDriver, false, false, nil)
+		require.NoError(t, err)
+		assert.Empty(t, state.ConfigurationProblems())
+	})
+}
+
+func TestGPUDriverState_LoadProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   installation.ComponentName
+		loaded   []string
+		problems int
+	}{
+		{"NVIDIA loaded with DRM", installation.ComponentNVIDIADriver, []string{"nvidia", "nvidia_drm", "nvidia_modeset"}, 0},
+		{"NVIDIA before reboot", installation.ComponentNVIDIADriver, []string{"nouveau"}, 2},
+		{"NVIDIA without DRM", installation.ComponentNVIDIADriver, []string{"nvidia"}, 1},
+		{"AMD loaded", installation.ComponentAMDDriver, []string{"amdgpu"}, 0},
+		{"AMD not loaded", installation.ComponentAMDDriver, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := installation.NewGPUDriverState(tt.driver, true, true, tt.loaded)
+			require.NoError(t, err)
+			assert.Len(t, state.LoadProblems(), tt.problems)
+			assert.Equal(t, tt.problems > 0, state.RebootRequired())
+		})
+	}
+}
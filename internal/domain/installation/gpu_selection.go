@@ -0,0 +1,163 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GPUDevice is a GPU detected on the system
+type GPUDevice struct {
+	vendor    string
+	model     string
+	pciSlot   string
+	drmDevice string
+}
+
+// NewGPUDevice creates a GPU device value object
+// Vendor and PCI slot are required; the DRM device is empty when no kernel
+// driver is bound
+func NewGPUDevice(vendor, model, pciSlot, drmDevice string) (GPUDevice, error) {
+	vendor = strings.ToLower(strings.TrimSpace(vendor))
+	pciSlot = strings.TrimSpace(pciSlot)
+	if vendor == "" || pciSlot == "" {
+		return GPUDevice{}, ErrInvalidGPUDevice
+	}
+
+	return GPUDevice{
+		vendor:    vendor,
+		model:     strings.TrimSpace(model),
+		pciSlot:   pciSlot,
+		drmDevice: strings.TrimSpace(drmDevice),
+	}, nil
+}
+
+// Vendor returns the GPU vendor (normalized)
+func (d GPUDevice) Vendor() string {
+	return d.vendor
+}
+
+// Model returns the GPU model name
+func (d GPUDevice) Model() string {
+	return d.model
+}
+
+// PCISlot returns the PCI address, e.g. 0000:01:00.0
+func (d GPUDevice) PCISlot() string {
+	return d.pciSlot
+}
+
+// DRMDevice returns the DRM card node, e.g. /dev/dri/card1
+func (d GPUDevice) DRMDevice() string {
+	return d.drmDevice
+}
+
+// String returns human-readable representation
+func (d GPUDevice) String() string {
+	name := d.vendor
+	if d.model != "" {
+		name = fmt.Sprintf("%s %s", d.vendor, d.model)
+	}
+	return fmt.Sprintf("%s [%s]", name, d.pciSlot)
+}
+
+// GPUSelection holds every GPU detected on the system and the one Hyprland
+// should render on
+type GPUSelection struct {
+	devices    []GPUDevice
+	renderSlot string
+}
+
+// NewGPUSelection creates a GPU selection. An empty render slot selects the
+// first device; a slot that matches no device returns ErrUnknownRenderGPU.
+func NewGPUSelection(devices []GPUDevice, renderSlot string) (GPUSelection, error) {
+	renderSlot = strings.TrimSpace(renderSlot)
+	if len(devices) == 0 {
+		if renderSlot != "" {
+			return GPUSelection{}, ErrUnknownRenderGPU
+		}
+		return GPUSelection{}, nil
+	}
+
+	if renderSlot == "" {
+		renderSlot = devices[0].PCISlot()
+	}
+
+	found := false
+	for _, d := range devices {
+		if d.PCISlot() == renderSlot {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return GPUSelection{}, fmt.Errorf("%w: %s", ErrUnknownRenderGPU, renderSlot)
+	}
+
+	devicesCopy := make([]GPUDevice, len(devices))
+	copy(devicesCopy, devices)
+
+	return GPUSelection{
+		devices:    devicesCopy,
+		renderSlot: renderSlot,
+	}, nil
+}
+
+// Devices returns a defensive copy of the detected GPUs
+func (s GPUSelection) Devices() []GPUDevice {
+	devices := make([]GPUDevice, len(s.devices))
+	copy(devices, s.devices)
+	return devices
+}
+
+// IsEmpty returns true if no GPUs were recorded
+func (s GPUSelection) IsEmpty() bool {
+	return len(s.devices) == 0
+}
+
+// IsMultiGPU returns true if more than one GPU was detected
+func (s GPUSelection) IsMultiGPU() bool {
+	return len(s.devices) > 1
+}
+
+// RenderGPU returns the GPU Hyprland renders on
+func (s GPUSelection) RenderGPU() (GPUDevice, bool) {
+	for _, d := range s.devices {
+		if d.PCISlot() == s.renderSlot {
+			return d, true
+		}
+	}
+	return GPUDevice{}, false
+}
+
+// DRMDevices returns the DRM card nodes with the render GPU first, in the
+// order Aquamarine should open them. GPUs without a card node are skipped.
+func (s GPUSelection) DRMDevices() []string {
+	var ordered []string
+	if render, ok := s.RenderGPU(); ok && render.DRMDevice() != "" {
+		ordered = append(ordered, render.DRMDevice())
+	}
+	for _, d := range s.devices {
+		if d.PCISlot() != s.renderSlot && d.DRMDevice() != "" {
+			ordered = append(ordered, d.DRMDevice())
+		}
+	}
+	return ordered
+}
+
+// TemplateVars returns the Hyprland environment selecting the render GPU.
+// AQ_DRM_DEVICES is only set on multi-GPU systems; with a single GPU
+// Aquamarine picks it on its own.
+func (s GPUSelection) TemplateVars() map[string]string {
+	drmDevices := s.DRMDevices()
+	if !s.IsMultiGPU() || len(drmDevices) == 0 {
+		return map[string]string{
+			"gpu_env": "# Single GPU: Aquamarine selects it automatically",
+		}
+	}
+
+	render, _ := s.RenderGPU()
+	return map[string]string{
+		"gpu_env": fmt.Sprintf("# Render on %s, other GPUs drive their own outputs\nenv = AQ_DRM_DEVICES,%s",
+			render, strings.Join(drmDevices, ":")),
+	}
+}
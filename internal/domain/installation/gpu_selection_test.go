@@ -0,0 +1,87 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGPUDevice(t *testing.T, vendor, model, slot, drm string) installation.GPUDevice {
+	t.Helper()
+	device, err := installation.NewGPUDevice(vendor, model, slot, drm)
+	require.NoError(t, err)
+	return device
+}
+
+func TestNewGPUDevice(t *testing.T) {
+	device, err := installation.NewGPUDevice(" NVIDIA ", "GeForce MX150", "0000:01:00.0", "/dev/dri/card1")
+	require.NoError(t, err)
+	assert.Equal(t, "nvidia", device.Vendor())
+	assert.Equal(t, "nvidia GeForce MX150 [0000:01:00.0]", device.String())
+
+	_, err = installation.NewGPUDevice("", "GeForce MX150", "0000:01:00.0", "")
+	assert.ErrorIs(t, err, installation.ErrInvalidGPUDevice)
+
+	_, err = installation.NewGPUDevice("nvidia", "GeForce MX150", " ", "")
+	assert.ErrorIs(t, err, installation.ErrInvalidGPUDevice)
+}
+
+func TestNewGPUSelection(t *testing.T) {
+	intel := newTestGPUDevice(t, "intel", "UHD Graphics 620", "0000:00:02.0", "/dev/dri/card0")
+	nvidia := newTestGPUDevice(t, "nvidia", "GeForce MX150", "0000:01:00.0", "/dev/dri/card1")
+
+	t.Run("defaults to the first GPU", func(t *testing.T) {
+		selection, err := installation.NewGPUSelection([]installation.GPUDevice{intel, nvidia}, "")
+		require.NoError(t, err)
+
+		render, ok := selection.RenderGPU()
+		require.True(t, ok)
+		assert.Equal(t, intel, render)
+		assert.True(t, selection.IsMultiGPU())
+	})
+
+	t.Run("rejects unknown render GPU", func(t *testing.T) {
+		_, err := installation.NewGPUSelection([]installation.GPUDevice{intel, nvidia}, "0000:02:00.0")
+		assert.ErrorIs(t, err, installation.ErrUnknownRenderGPU)
+
+		_, err = installation.NewGPUSelection(nil, "0000:01:00.0")
+		assert.ErrorIs(t, err, installation.ErrUnknownRenderGPU)
+	})
+
+	t.Run("empty selection", func(t *testing.T) {
+		selection, err := installation.NewGPUSelection(nil, "")
+		require.NoError(t, err)
+
+		assert.True(t, selection.IsEmpty())
+		_, ok := selection.RenderGPU()
+		assert.False(t, ok)
+	})
+}
+
+func TestGPUSelection_TemplateVars(t *testing.T) {
+	intel := newTestGPUDevice(t, "intel", "UHD Graphics 620", "0000:00:02.0", "/dev/dri/card0")
+	nvidia := newTestGPUDevice(t, "nvidia", "GeForce MX150", "0000:01:00.0", "/dev/dri/card1")
+	unbound := newTestGPUDevice(t, "amd", "Radeon RX 580", "0000:02:00.0", "")
+
+	t.Run("render GPU is listed first", func(t *testing.T) {
+		selection, err := installation.NewGPUSelection([]installation.GPUDevice{intel, unbound, nvidia}, "0000:01:00.0")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"/dev/dri/card1", "/dev/dri/card0"}, selection.DRMDevices())
+		assert.Contains(t, selection.TemplateVars()["gpu_env"], "env = AQ_DRM_DEVICES,/dev/dri/card1:/dev/dri/card0")
+	})
+
+	t.Run("single GPU leaves AQ_DRM_DEVICES unset", func(t *testing.T) {
+		selection, err := installation.NewGPUSelection([]installation.GPUDevice{intel}, "")
+		require.NoError(t, err)
+
+		assert.NotContains(t, selection.TemplateVars()["gpu_env"], "AQ_DRM_DEVICES")
+	})
+
+	t.Run("zero value leaves AQ_DRM_DEVICES unset", func(t *testing.T) {
+		var selection installation.GPUSelection
+		assert.NotContains(t, selection.TemplateVars()["gpu_env"], "AQ_DRM_DEVICES")
+	})
+}
@@ -0,0 +1,111 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HookPhase is a point of an installation or configuration deployment at
+// which the user's hooks run
+type HookPhase string
+
+const (
+	HookPreInstall  HookPhase = "pre_install"  // Preflight checks passed, before packages are installed
+	HookPostInstall HookPhase = "post_install" // Installation verified, before it is completed
+	HookPreDeploy   HookPhase = "pre_deploy"   // Before configuration files are written
+	HookPostDeploy  HookPhase = "post_deploy"  // After configuration files are written
+)
+
+// HookPhases returns every phase in the order they run
+func HookPhases() []HookPhase {
+	return []HookPhase{HookPreInstall, HookPreDeploy, HookPostDeploy, HookPostInstall}
+}
+
+// IsValid checks if the phase is known
+func (p HookPhase) IsValid() bool {
+	for _, phase := range HookPhases() {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPre reports whether the phase runs before its step, so a failing hook
+// stops the step rather than only being reported
+func (p HookPhase) IsPre() bool {
+	return p == HookPreInstall || p == HookPreDeploy
+}
+
+// String returns the string representation of HookPhase
+func (p HookPhase) String() string {
+	return string(p)
+}
+
+// HookInvocation is a value object describing what a hook runs for. Hooks
+// receive it as GOHAN_* environment variables.
+type HookInvocation struct {
+	phase         HookPhase
+	sessionID     string
+	components    []string
+	deployedFiles []string
+	workspace     string
+}
+
+// NewHookInvocation creates an invocation of the phase's hooks. sessionID
+// is empty for deployments outside an installation.
+func NewHookInvocation(phase HookPhase, sessionID string, components []string) (HookInvocation, error) {
+	if !phase.IsValid() {
+		return HookInvocation{}, fmt.Errorf("%w: %q", ErrInvalidHookPhase, phase)
+	}
+
+	copied := make([]string, len(components))
+	copy(copied, components)
+	return HookInvocation{phase: phase, sessionID: sessionID, components: copied}, nil
+}
+
+// WithDeployedFiles returns a copy of the invocation listing the
+// configuration files that were written
+func (i HookInvocation) WithDeployedFiles(files []string) HookInvocation {
+	i.deployedFiles = make([]string, len(files))
+	copy(i.deployedFiles, files)
+	return i
+}
+
+// WithWorkspace returns a copy of the invocation naming the session's
+// working directory
+func (i HookInvocation) WithWorkspace(dir string) HookInvocation {
+	i.workspace = dir
+	return i
+}
+
+// Phase returns the phase the hooks run at
+func (i HookInvocation) Phase() HookPhase {
+	return i.phase
+}
+
+// SessionID returns the installation session, or "" outside one
+func (i HookInvocation) SessionID() string {
+	return i.sessionID
+}
+
+// Environment returns the variables describing the invocation as
+// NAME=value pairs. Components are separated by commas and files by
+// newlines, so paths with spaces survive; variables without a value are
+// left out.
+func (i HookInvocation) Environment() []string {
+	env := []string{"GOHAN_HOOK=" + string(i.phase)}
+	if i.sessionID != "" {
+		env = append(env, "GOHAN_SESSION_ID="+i.sessionID)
+	}
+	if len(i.components) > 0 {
+		env = append(env, "GOHAN_COMPONENTS="+strings.Join(i.components, ","))
+	}
+	if len(i.deployedFiles) > 0 {
+		env = append(env, "GOHAN_DEPLOYED_FILES="+strings.Join(i.deployedFiles, "\n"))
+	}
+	if i.workspace != "" {
+		env = append(env, "GOHAN_WORKSPACE="+i.workspace)
+	}
+	return env
+}
@@ -0,0 +1,48 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHookInvocation(t *testing.T) {
+	t.Run("rejects unknown phases", func(t *testing.T) {
+		_, err := installation.NewHookInvocation("post_reboot", "", nil)
+		assert.ErrorIs(t, err, installation.ErrInvalidHookPhase)
+	})
+
+	t.Run("describes the session in the environment", func(t *testing.T) {
+		invocation, err := installation.NewHookInvocation(installation.HookPostDeploy, "3f2a9c", []string{"hyprland", "waybar"})
+		require.NoError(t, err)
+
+		env := invocation.
+			WithDeployedFiles([]string{"/home/me/.config/hypr/hyprland.conf", "/home/me/My Files/waybar.jsonc"}).
+			WithWorkspace("/home/me/.cache/gohan/sessions/3f2a9c").
+			Environment()
+
+		assert.Equal(t, []string{
+			"GOHAN_HOOK=post_deploy",
+			"GOHAN_SESSION_ID=3f2a9c",
+			"GOHAN_COMPONENTS=hyprland,waybar",
+			"GOHAN_DEPLOYED_FILES=/home/me/.config/hypr/hyprland.conf\n/home/me/My Files/waybar.jsonc",
+			"GOHAN_WORKSPACE=/home/me/.cache/gohan/sessions/3f2a9c",
+		}, env)
+	})
+
+	t.Run("leaves out what does not apply", func(t *testing.T) {
+		invocation, err := installation.NewHookInvocation(installation.HookPreDeploy, "", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"GOHAN_HOOK=pre_deploy"}, invocation.Environment())
+	})
+}
+
+func TestHookPhase_IsPre(t *testing.T) {
+	assert.True(t, installation.HookPreInstall.IsPre())
+	assert.True(t, installation.HookPreDeploy.IsPre())
+	assert.False(t, installation.HookPostDeploy.IsPre())
+	assert.False(t, installation.HookPostInstall.IsPre())
+}
@@ -0,0 +1,56 @@
+package installation
+
+import "time"
+
+// InstallationProgress is a value object capturing the most recent progress
+// report for a session: the fine-grained phase, percentage and message shown
+// to users
+type InstallationProgress struct {
+	phase     string
+	percent   int
+	message   string
+	updatedAt time.Time
+}
+
+// NewInstallationProgress creates a progress report
+// Percent is clamped to 0-100
+func NewInstallationProgress(phase string, percent int, message string, updatedAt time.Time) InstallationProgress {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+
+	return InstallationProgress{
+		phase:     phase,
+		percent:   percent,
+		message:   message,
+		updatedAt: updatedAt,
+	}
+}
+
+// Phase returns the human-readable phase being executed
+func (p InstallationProgress) Phase() string {
+	return p.phase
+}
+
+// PercentComplete returns overall progress (0-100)
+func (p InstallationProgress) PercentComplete() int {
+	return p.percent
+}
+
+// Message returns the latest progress message
+func (p InstallationProgress) Message() string {
+	return p.message
+}
+
+// UpdatedAt returns when the progress was reported
+func (p InstallationProgress) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// IsZero returns true if no progress has been reported
+func (p InstallationProgress) IsZero() bool {
+	return p.updatedAt.IsZero()
+}
@@ -0,0 +1,51 @@
+package installation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInstallationProgress(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name            string
+		percent         int
+		expectedPercent int
+	}{
+		{"keeps valid percent", 42, 42},
+		{"clamps negative percent", -5, 0},
+		{"clamps percent above 100", 150, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := installation.NewInstallationProgress("Installing Components", tt.percent, "Installing kitty", now)
+
+			assert.Equal(t, "Installing Components", progress.Phase())
+			assert.Equal(t, tt.expectedPercent, progress.PercentComplete())
+			assert.Equal(t, "Installing kitty", progress.Message())
+			assert.Equal(t, now, progress.UpdatedAt())
+			assert.False(t, progress.IsZero())
+		})
+	}
+}
+
+func TestInstallationSession_UpdateProgress(t *testing.T) {
+	config := mustCreateConfiguration(t, []installation.ComponentSelection{
+		mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0"),
+	})
+
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+	assert.True(t, session.Progress().IsZero(), "New session should have no progress")
+
+	progress := installation.NewInstallationProgress("Running Preflight Checks", 6, "Checking disk space", time.Now())
+	session.UpdateProgress(progress)
+
+	assert.Equal(t, progress, session.Progress())
+}
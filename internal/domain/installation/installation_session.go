@@ -2,6 +2,7 @@ package installation
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +19,22 @@ type InstallationSession struct {
 	startedAt            time.Time
 	completedAt          time.Time
 	failureReason        string
+	progress             InstallationProgress
+	warnings             []InstallationWarning
+	systemContext        SystemContext
+	preflightChecks      []PreflightCheck
+	preflightSessionID   string
+	deployedConfigs      []DeployedConfig
+	conflicts            []ConflictResolution
+	componentStatuses    []ComponentStatus
+	verifications        []ComponentVerification
+	events               []DomainEvent
+	scope                string
+	invocation           Invocation
+	reboot               RebootRequirement
+	timeline             []TimelineEntry
+	phaseStarts          []phaseStart
+	phaseEstimate        map[InstallationStatus]time.Duration
 }
 
 // NewInstallationSession creates a new installation session aggregate root
@@ -30,6 +47,7 @@ func NewInstallationSession(configuration InstallationConfiguration) (*Installat
 		status:              StatusPending,
 		installedComponents: make([]*InstalledComponent, 0),
 		startedAt:           time.Now(),
+		componentStatuses:   pendingComponentStatuses(configuration, time.Now()),
 	}, nil
 }
 
@@ -83,9 +101,24 @@ func ReconstructInstallationSession(
 		startedAt:           startedAt,
 		completedAt:         completedAt,
 		failureReason:       failureReason,
+		componentStatuses:   pendingComponentStatuses(configuration, startedAt),
 	}, nil
 }
 
+// pendingComponentStatuses starts every configured component as pending
+func pendingComponentStatuses(configuration InstallationConfiguration, at time.Time) []ComponentStatus {
+	components := configuration.Components()
+	statuses := make([]ComponentStatus, 0, len(components))
+	for _, c := range components {
+		statuses = append(statuses, ComponentStatus{
+			component: c.Component(),
+			state:     ComponentStatePending,
+			updatedAt: at,
+		})
+	}
+	return statuses
+}
+
 // ID returns the unique identifier for this session
 func (s *InstallationSession) ID() string {
 	return s.id
@@ -124,12 +157,366 @@ func (s *InstallationSession) CompletedAt() time.Time {
 	return s.completedAt
 }
 
-// FailureReason returns why the installation failed
-// Empty string if not failed
+// FailureReason returns why the installation failed or was cancelled
+// Empty string if neither
 func (s *InstallationSession) FailureReason() string {
 	return s.failureReason
 }
 
+// Progress returns the most recent progress report
+// Returns a zero value if no progress has been reported
+func (s *InstallationSession) Progress() InstallationProgress {
+	return s.progress
+}
+
+// UpdateProgress records the latest progress report for this session, and
+// on the timeline when it starts a new phase. Reports made once the session
+// has stopped only describe how it ended, so are not phases.
+func (s *InstallationSession) UpdateProgress(progress InstallationProgress) {
+	stopped := s.status.IsTerminal() || s.status == StatusCancelled
+	if !stopped && progress.Phase() != "" && progress.Phase() != s.progress.Phase() {
+		s.timeline = append(s.timeline, newPhaseEntry(progress.Phase(), progress.UpdatedAt()))
+	}
+	s.progress = progress
+}
+
+// SystemContext returns the throughput measured for this system
+// Returns a zero value if nothing was measured
+func (s *InstallationSession) SystemContext() SystemContext {
+	return s.systemContext
+}
+
+// SetSystemContext records the throughput measured during preflight so
+// progress estimates can account for slow disks and networks
+func (s *InstallationSession) SetSystemContext(systemContext SystemContext) {
+	s.systemContext = systemContext
+}
+
+// Scope returns whose history the session is recorded in, "system" or
+// "user:<name>". Empty for sessions created before scopes existed.
+func (s *InstallationSession) Scope() string {
+	return s.scope
+}
+
+// SetScope sets whose history the session is recorded in
+func (s *InstallationSession) SetScope(scope string) {
+	s.scope = scope
+}
+
+// RebootRequirement returns why the installation needs a reboot to take
+// full effect; not required until the installation finds a reason
+func (s *InstallationSession) RebootRequirement() RebootRequirement {
+	return s.reboot
+}
+
+// SetRebootRequirement records whether the installation needs a reboot
+func (s *InstallationSession) SetRebootRequirement(reboot RebootRequirement) {
+	s.reboot = reboot
+}
+
+// Invocation returns how gohan was run to start the session. Zero for
+// sessions started through the API or before invocations were recorded.
+func (s *InstallationSession) Invocation() Invocation {
+	return s.invocation
+}
+
+// SetInvocation records how gohan was run to start the session
+func (s *InstallationSession) SetInvocation(invocation Invocation) {
+	s.invocation = invocation
+}
+
+// RecordPreflightChecks keeps the preflight results that blocked or
+// degraded this installation
+func (s *InstallationSession) RecordPreflightChecks(checks []PreflightCheck) {
+	s.preflightChecks = make([]PreflightCheck, len(checks))
+	copy(s.preflightChecks, checks)
+}
+
+// PreflightChecks returns a defensive copy of the recorded preflight results
+func (s *InstallationSession) PreflightChecks() []PreflightCheck {
+	checks := make([]PreflightCheck, len(s.preflightChecks))
+	copy(checks, s.preflightChecks)
+	return checks
+}
+
+// PreflightSessionID returns the preflight validation session run before
+// this installation, or "" if none was recorded
+func (s *InstallationSession) PreflightSessionID() string {
+	return s.preflightSessionID
+}
+
+// SetPreflightSessionID references the preflight validation session run
+// before this installation
+func (s *InstallationSession) SetPreflightSessionID(id string) {
+	s.preflightSessionID = id
+}
+
+// RecordDeployedConfigs keeps the configuration files written by this
+// installation, so history can tell which files changed
+func (s *InstallationSession) RecordDeployedConfigs(configs []DeployedConfig) {
+	s.deployedConfigs = make([]DeployedConfig, len(configs))
+	copy(s.deployedConfigs, configs)
+}
+
+// DeployedConfigs returns a defensive copy of the recorded configuration files
+func (s *InstallationSession) DeployedConfigs() []DeployedConfig {
+	configs := make([]DeployedConfig, len(s.deployedConfigs))
+	copy(configs, s.deployedConfigs)
+	return configs
+}
+
+// RecordConflict records a detected package conflict and the strategy chosen
+// to resolve it, raising a ConflictDetectedEvent
+func (s *InstallationSession) RecordConflict(conflict PackageConflict, strategy ResolutionAction) ConflictDetectedEvent {
+	s.conflicts = append(s.conflicts, NewConflictResolution(conflict, strategy))
+
+	severity := "resolvable"
+	if strategy == ActionAbort {
+		severity = "blocking"
+	}
+	event := NewConflictDetectedEvent(s.id, conflict.PackageName(), conflict.ConflictingPackage(), severity)
+	s.events = append(s.events, event)
+	return event
+}
+
+// ResolveConflict marks a recorded conflict as resolved with its chosen
+// strategy, raising a ConflictResolvedEvent
+func (s *InstallationSession) ResolveConflict(conflict PackageConflict) (ConflictResolvedEvent, error) {
+	for i, resolution := range s.conflicts {
+		if !resolution.Conflict().Equals(conflict) || resolution.IsApplied() {
+			continue
+		}
+		s.conflicts[i] = resolution.MarkAsApplied()
+
+		event := NewConflictResolvedEvent(s.id, conflict.PackageName(), conflict.ConflictingPackage(), resolution.Action())
+		s.events = append(s.events, event)
+		return event, nil
+	}
+	return ConflictResolvedEvent{}, ErrConflictNotRecorded
+}
+
+// ConflictResolutions returns a defensive copy of the conflicts detected
+// during installation and how each was resolved
+func (s *InstallationSession) ConflictResolutions() []ConflictResolution {
+	resolutions := make([]ConflictResolution, len(s.conflicts))
+	copy(resolutions, s.conflicts)
+	return resolutions
+}
+
+// RestoreConflictResolutions replaces the recorded conflicts without raising
+// events, for reconstructing a persisted session
+func (s *InstallationSession) RestoreConflictResolutions(resolutions []ConflictResolution) {
+	s.conflicts = make([]ConflictResolution, len(resolutions))
+	copy(s.conflicts, resolutions)
+}
+
+// ComponentStatuses returns a defensive copy of every configured
+// component's state, in configuration order
+func (s *InstallationSession) ComponentStatuses() []ComponentStatus {
+	statuses := make([]ComponentStatus, len(s.componentStatuses))
+	copy(statuses, s.componentStatuses)
+	return statuses
+}
+
+// ComponentStatus returns the state of a configured component
+func (s *InstallationSession) ComponentStatus(component ComponentName) (ComponentStatus, bool) {
+	for _, status := range s.componentStatuses {
+		if status.Component() == component {
+			return status, true
+		}
+	}
+	return ComponentStatus{}, false
+}
+
+// FailedComponents returns the components the installation stopped on
+func (s *InstallationSession) FailedComponents() []ComponentStatus {
+	var failed []ComponentStatus
+	for _, status := range s.componentStatuses {
+		if status.IsFailed() {
+			failed = append(failed, status)
+		}
+	}
+	return failed
+}
+
+// SkippedComponents returns the components left out of the installation,
+// with why, in configuration order
+func (s *InstallationSession) SkippedComponents() []ComponentStatus {
+	var skipped []ComponentStatus
+	for _, status := range s.componentStatuses {
+		if status.IsSkipped() {
+			skipped = append(skipped, status)
+		}
+	}
+	return skipped
+}
+
+// MarkComponent moves a configured component to a new state. Use
+// FailComponent to record a failure with its cause, and SkipComponent to
+// leave a component out.
+func (s *InstallationSession) MarkComponent(component ComponentName, state ComponentState) error {
+	switch state {
+	case ComponentStateFailed:
+		return fmt.Errorf("%w: use FailComponent to record a failure", ErrInvalidComponentStatus)
+	case ComponentStateSkipped:
+		return fmt.Errorf("%w: use SkipComponent to skip a component", ErrInvalidComponentStatus)
+	}
+	return s.setComponentStatus(component, state, "")
+}
+
+// FailComponent records that installation stopped on a component and why
+func (s *InstallationSession) FailComponent(component ComponentName, reason string) error {
+	return s.setComponentStatus(component, ComponentStateFailed, reason)
+}
+
+// SkipComponent records that a configured component was left out of the
+// installation, for reason; detail says what was found, such as the
+// package that is missing
+func (s *InstallationSession) SkipComponent(component ComponentName, reason SkipReason, detail string) error {
+	status, err := NewSkippedComponentStatus(component, reason, detail)
+	if err != nil {
+		return err
+	}
+	return s.replaceComponentStatus(status)
+}
+
+func (s *InstallationSession) setComponentStatus(component ComponentName, state ComponentState, reason string) error {
+	status, err := NewComponentStatus(component, state, reason)
+	if err != nil {
+		return err
+	}
+	return s.replaceComponentStatus(status)
+}
+
+func (s *InstallationSession) replaceComponentStatus(status ComponentStatus) error {
+	for i := range s.componentStatuses {
+		if s.componentStatuses[i].Component() == status.Component() {
+			s.componentStatuses[i] = status
+			s.timeline = append(s.timeline, newComponentEntry(status))
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not part of this installation", ErrComponentNotFound, status.Component())
+}
+
+// RestoreComponentStatuses replaces the component states, for reconstructing
+// a persisted session
+func (s *InstallationSession) RestoreComponentStatuses(statuses []ComponentStatus) {
+	s.componentStatuses = make([]ComponentStatus, len(statuses))
+	copy(s.componentStatuses, statuses)
+}
+
+// RecordComponentVerification keeps a component's verification outcome and
+// moves the component to verified, or to failed with the problems found
+func (s *InstallationSession) RecordComponentVerification(verification ComponentVerification) error {
+	var err error
+	if verification.Passed() {
+		err = s.setComponentStatus(verification.Component(), ComponentStateVerified, "")
+	} else {
+		err = s.setComponentStatus(verification.Component(), ComponentStateFailed,
+			"verification failed: "+strings.Join(verification.Problems(), "; "))
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range s.verifications {
+		if s.verifications[i].Component() == verification.Component() {
+			s.verifications[i] = verification
+			return nil
+		}
+	}
+	s.verifications = append(s.verifications, verification)
+	return nil
+}
+
+// ComponentVerifications returns a defensive copy of the verification
+// outcomes, in the order they were recorded
+func (s *InstallationSession) ComponentVerifications() []ComponentVerification {
+	verifications := make([]ComponentVerification, len(s.verifications))
+	copy(verifications, s.verifications)
+	return verifications
+}
+
+// ComponentVerification returns the verification outcome of a component
+func (s *InstallationSession) ComponentVerification(component ComponentName) (ComponentVerification, bool) {
+	for _, verification := range s.verifications {
+		if verification.Component() == component {
+			return verification, true
+		}
+	}
+	return ComponentVerification{}, false
+}
+
+// RestoreComponentVerifications replaces the verification outcomes, for
+// reconstructing a persisted session
+func (s *InstallationSession) RestoreComponentVerifications(verifications []ComponentVerification) {
+	s.verifications = make([]ComponentVerification, len(verifications))
+	copy(s.verifications, verifications)
+}
+
+// Timeline returns a defensive copy of the phases started and component
+// state changes, in the order they happened
+func (s *InstallationSession) Timeline() []TimelineEntry {
+	timeline := make([]TimelineEntry, len(s.timeline))
+	copy(timeline, s.timeline)
+	return timeline
+}
+
+// RestoreTimeline replaces the timeline, for reconstructing a persisted
+// session
+func (s *InstallationSession) RestoreTimeline(timeline []TimelineEntry) {
+	s.timeline = make([]TimelineEntry, len(timeline))
+	copy(s.timeline, timeline)
+}
+
+// TimelineSpans returns how long each phase and component took. Spans of a
+// session still running, or cancelled, end at its latest step.
+func (s *InstallationSession) TimelineSpans() []TimelineSpan {
+	end := s.completedAt
+	if end.IsZero() {
+		end = s.startedAt
+		for _, entry := range s.timeline {
+			if entry.At().After(end) {
+				end = entry.At()
+			}
+		}
+		if s.progress.UpdatedAt().After(end) {
+			end = s.progress.UpdatedAt()
+		}
+	}
+
+	failure := ""
+	if s.status == StatusFailed {
+		failure = s.failureReason
+	}
+	return BuildTimelineSpans(s.timeline, end, failure)
+}
+
+// Events returns the domain events raised by this session since it was loaded
+func (s *InstallationSession) Events() []DomainEvent {
+	events := make([]DomainEvent, len(s.events))
+	copy(events, s.events)
+	return events
+}
+
+// AddWarning records a non-fatal issue raised during installation
+func (s *InstallationSession) AddWarning(warning InstallationWarning) {
+	s.warnings = append(s.warnings, warning)
+}
+
+// Warnings returns a defensive copy of the warnings raised so far
+func (s *InstallationSession) Warnings() []InstallationWarning {
+	warnings := make([]InstallationWarning, len(s.warnings))
+	copy(warnings, s.warnings)
+	return warnings
+}
+
+// HasWarnings returns true if any warnings were raised
+func (s *InstallationSession) HasWarnings() bool {
+	return len(s.warnings) > 0
+}
+
 // StartPreparation transitions to preparation phase and attaches snapshot
 func (s *InstallationSession) StartPreparation(snapshot *SystemSnapshot) error {
 	if !s.status.CanTransitionTo(StatusPreparation) {
@@ -142,6 +529,8 @@ func (s *InstallationSession) StartPreparation(snapshot *SystemSnapshot) error {
 
 	s.status = StatusPreparation
 	s.snapshot = snapshot
+	// A resumed session is timed from its last start
+	s.phaseStarts = []phaseStart{{phase: StatusPreparation, at: time.Now()}}
 	return nil
 }
 
@@ -152,6 +541,7 @@ func (s *InstallationSession) StartInstalling() error {
 	}
 
 	s.status = StatusInstalling
+	s.enterPhase(StatusInstalling)
 	return nil
 }
 
@@ -177,6 +567,7 @@ func (s *InstallationSession) StartConfiguring() error {
 	}
 
 	s.status = StatusConfiguring
+	s.enterPhase(StatusConfiguring)
 	return nil
 }
 
@@ -187,9 +578,49 @@ func (s *InstallationSession) StartVerifying() error {
 	}
 
 	s.status = StatusVerifying
+	s.enterPhase(StatusVerifying)
 	return nil
 }
 
+// enterPhase records when a timed phase began
+func (s *InstallationSession) enterPhase(phase InstallationStatus) {
+	if len(s.phaseStarts) > 0 {
+		s.phaseStarts = append(s.phaseStarts, phaseStart{phase: phase, at: time.Now()})
+	}
+}
+
+// SetPhaseEstimate records how long each phase is expected to take, to
+// compare with how long they take once the installation completes
+func (s *InstallationSession) SetPhaseEstimate(estimate map[InstallationStatus]time.Duration) {
+	s.phaseEstimate = make(map[InstallationStatus]time.Duration, len(estimate))
+	for phase, d := range estimate {
+		s.phaseEstimate[phase] = d
+	}
+}
+
+// PhaseTimings compares the estimate of each phase from preparation on
+// with how long it took. It returns nil until the installation completes,
+// and when no estimate was made.
+func (s *InstallationSession) PhaseTimings() []PhaseTiming {
+	if s.status != StatusCompleted || len(s.phaseEstimate) == 0 {
+		return nil
+	}
+
+	timings := make([]PhaseTiming, 0, len(s.phaseStarts))
+	for i, start := range s.phaseStarts {
+		end := s.completedAt
+		if i+1 < len(s.phaseStarts) {
+			end = s.phaseStarts[i+1].at
+		}
+		timing, err := NewPhaseTiming(start.phase, s.phaseEstimate[start.phase], max(end.Sub(start.at), 0))
+		if err != nil {
+			continue
+		}
+		timings = append(timings, timing)
+	}
+	return timings
+}
+
 // Complete marks the installation as successfully completed
 // Enforces that at least one component was installed
 func (s *InstallationSession) Complete() error {
@@ -219,6 +650,63 @@ func (s *InstallationSession) Fail(reason string) error {
 	return nil
 }
 
+// Cancel stops the installation so it can be resumed later. Installed
+// components are kept; components that were being downloaded or installed
+// go back to pending.
+func (s *InstallationSession) Cancel(reason string) error {
+	if s.status.IsTerminal() {
+		return ErrSessionAlreadyComplete
+	}
+	if s.status == StatusCancelled {
+		return nil
+	}
+
+	now := time.Now()
+	for i, status := range s.componentStatuses {
+		if s.IsInstalled(status.component) {
+			continue
+		}
+		if status.state == ComponentStateDownloading || status.state == ComponentStateInstalling {
+			s.componentStatuses[i] = ComponentStatus{
+				component: status.component,
+				state:     ComponentStatePending,
+				updatedAt: now,
+			}
+		}
+	}
+
+	s.status = StatusCancelled
+	s.failureReason = reason
+	return nil
+}
+
+// Resume returns a cancelled session to pending so it can be executed again
+func (s *InstallationSession) Resume() error {
+	if s.status != StatusCancelled {
+		return ErrInvalidStateTransition
+	}
+
+	s.status = StatusPending
+	s.failureReason = ""
+	return nil
+}
+
+// IsCancelled returns true if the installation was stopped by the user
+func (s *InstallationSession) IsCancelled() bool {
+	return s.status == StatusCancelled
+}
+
+// IsInstalled returns true if the component was already installed in this
+// session, so a resumed installation can skip it
+func (s *InstallationSession) IsInstalled(component ComponentName) bool {
+	for _, installed := range s.installedComponents {
+		if installed.Component() == component {
+			return true
+		}
+	}
+	return false
+}
+
 // IsInProgress returns true if installation is actively running
 func (s *InstallationSession) IsInProgress() bool {
 	return s.status == StatusPreparation ||
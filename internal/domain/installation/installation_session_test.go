@@ -163,6 +163,59 @@ func TestInstallationSession_Fail(t *testing.T) {
 	assert.False(t, session.CompletedAt().IsZero(), "CompletedAt should be set on failure")
 }
 
+func TestInstallationSession_CancelAndResume(t *testing.T) {
+	config := mustCreateConfiguration(t, []installation.ComponentSelection{
+		mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0"),
+		mustCreateComponentSelection(t, installation.ComponentWaybar, "0.9.0"),
+	})
+
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+
+	snapshot, _ := installation.NewSystemSnapshot("/var/backup/test",
+		mustCreateDiskSpace(t, 100*installation.GB, 10*installation.GB), nil)
+	require.NoError(t, session.StartPreparation(snapshot))
+	require.NoError(t, session.StartInstalling())
+
+	component, _ := installation.NewInstalledComponent(installation.ComponentHyprland, "0.35.0", nil)
+	require.NoError(t, session.AddInstalledComponent(component))
+	require.NoError(t, session.MarkComponent(installation.ComponentWaybar, installation.ComponentStateInstalling))
+
+	t.Run("cancel keeps installed components and resets interrupted ones", func(t *testing.T) {
+		require.NoError(t, session.Cancel("cancelled by user"))
+
+		assert.Equal(t, installation.StatusCancelled, session.Status())
+		assert.True(t, session.IsCancelled())
+		assert.False(t, session.IsInProgress())
+		assert.Equal(t, "cancelled by user", session.FailureReason())
+		assert.True(t, session.IsInstalled(installation.ComponentHyprland))
+		assert.False(t, session.IsInstalled(installation.ComponentWaybar))
+
+		status, ok := session.ComponentStatus(installation.ComponentWaybar)
+		require.True(t, ok)
+		assert.True(t, status.IsPending())
+	})
+
+	t.Run("cancelling twice is a no-op", func(t *testing.T) {
+		assert.NoError(t, session.Cancel("again"))
+		assert.Equal(t, "cancelled by user", session.FailureReason())
+	})
+
+	t.Run("resume returns the session to pending", func(t *testing.T) {
+		require.NoError(t, session.Resume())
+
+		assert.Equal(t, installation.StatusPending, session.Status())
+		assert.Empty(t, session.FailureReason())
+		assert.Len(t, session.InstalledComponents(), 1)
+		assert.ErrorIs(t, session.Resume(), installation.ErrInvalidStateTransition)
+	})
+
+	t.Run("finished sessions cannot be cancelled", func(t *testing.T) {
+		require.NoError(t, session.Fail("boom"))
+		assert.ErrorIs(t, session.Cancel("too late"), installation.ErrSessionAlreadyComplete)
+	})
+}
+
 func TestInstallationSession_StateTransitions(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -313,3 +366,47 @@ func mustCreateConfiguration(t *testing.T, components []installation.ComponentSe
 	require.NoError(t, err)
 	return config
 }
+
+func TestInstallationSession_Conflicts(t *testing.T) {
+	session, err := installation.NewInstallationSession(createValidConfig(t))
+	require.NoError(t, err)
+
+	conflict, err := installation.NewPackageConflict("hyprland", "sway", "both provide a Wayland session")
+	require.NoError(t, err)
+
+	detected := session.RecordConflict(conflict, installation.ActionRemove)
+	assert.Equal(t, "installation.conflict.detected", detected.EventType())
+	assert.Equal(t, session.ID(), detected.SessionID())
+	assert.Equal(t, "sway", detected.ConflictingPackage())
+	assert.Equal(t, "resolvable", detected.Severity())
+
+	resolutions := session.ConflictResolutions()
+	require.Len(t, resolutions, 1)
+	assert.False(t, resolutions[0].IsApplied())
+	assert.Equal(t, installation.ActionRemove, resolutions[0].Action())
+
+	resolved, err := session.ResolveConflict(conflict)
+	require.NoError(t, err)
+	assert.Equal(t, "installation.conflict.resolved", resolved.EventType())
+	assert.Equal(t, installation.ActionRemove, resolved.Strategy())
+	assert.True(t, session.ConflictResolutions()[0].IsApplied())
+
+	events := session.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "installation.conflict.detected", events[0].EventType())
+	assert.Equal(t, "installation.conflict.resolved", events[1].EventType())
+
+	t.Run("resolving an unknown conflict fails", func(t *testing.T) {
+		other, err := installation.NewPackageConflict("waybar", "polybar", "")
+		require.NoError(t, err)
+		_, err = session.ResolveConflict(other)
+		assert.ErrorIs(t, err, installation.ErrConflictNotRecorded)
+	})
+
+	t.Run("aborting conflicts are blocking", func(t *testing.T) {
+		other, err := installation.NewPackageConflict("kitty", "foot", "")
+		require.NoError(t, err)
+		event := session.RecordConflict(other, installation.ActionAbort)
+		assert.Equal(t, "blocking", event.Severity())
+	})
+}
@@ -0,0 +1,82 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WarningSource identifies which part of the installation raised a warning
+type WarningSource string
+
+const (
+	WarningSourcePreflight    WarningSource = "preflight"    // Non-blocking preflight check result
+	WarningSourceAvailability WarningSource = "availability" // Package not available from configured repositories
+	WarningSourceConflict     WarningSource = "conflict"     // Package conflict resolved automatically
+	WarningSourceSkipped      WarningSource = "skipped"      // Component skipped during installation
+	WarningSourceRemoval      WarningSource = "removal"      // Replaced package could not be removed
+	WarningSourceOnboarding   WarningSource = "onboarding"   // First-login tour could not be set up
+	WarningSourcePortal       WarningSource = "portal"       // Portal backend competing with Hyprland's
+	WarningSourceGPUDriver    WarningSource = "gpu-driver"   // GPU driver setup needing attention or a reboot
+	WarningSourceReboot       WarningSource = "reboot"       // Whether a reboot is needed could not be told
+	WarningSourceHook         WarningSource = "hook"         // User hook after a phase failed
+	WarningSourceConfigMerge  WarningSource = "config-merge" // Edited configuration file conflicting with its template's changes
+)
+
+// String returns the string representation of WarningSource
+func (s WarningSource) String() string {
+	return string(s)
+}
+
+// InstallationWarning is a value object for a non-fatal issue raised during
+// an installation that the user should review afterwards
+type InstallationWarning struct {
+	source   WarningSource
+	message  string
+	raisedAt time.Time
+}
+
+// NewInstallationWarning creates a warning raised now
+func NewInstallationWarning(source WarningSource, message string) (InstallationWarning, error) {
+	return ReconstructInstallationWarning(source, message, time.Now())
+}
+
+// ReconstructInstallationWarning reconstructs a warning from persistent storage
+func ReconstructInstallationWarning(source WarningSource, message string, raisedAt time.Time) (InstallationWarning, error) {
+	message = strings.TrimSpace(message)
+	if source == "" {
+		return InstallationWarning{}, fmt.Errorf("%w: source cannot be empty", ErrInvalidWarning)
+	}
+	if message == "" {
+		return InstallationWarning{}, fmt.Errorf("%w: message cannot be empty", ErrInvalidWarning)
+	}
+	if raisedAt.IsZero() {
+		return InstallationWarning{}, fmt.Errorf("%w: raised time cannot be zero", ErrInvalidWarning)
+	}
+
+	return InstallationWarning{
+		source:   source,
+		message:  message,
+		raisedAt: raisedAt,
+	}, nil
+}
+
+// Source returns where the warning came from
+func (w InstallationWarning) Source() WarningSource {
+	return w.source
+}
+
+// Message returns the warning text
+func (w InstallationWarning) Message() string {
+	return w.message
+}
+
+// RaisedAt returns when the warning was raised
+func (w InstallationWarning) RaisedAt() time.Time {
+	return w.raisedAt
+}
+
+// String returns human-readable representation
+func (w InstallationWarning) String() string {
+	return fmt.Sprintf("[%s] %s", w.source, w.message)
+}
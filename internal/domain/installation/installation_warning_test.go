@@ -0,0 +1,75 @@
+package installation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInstallationWarning(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  installation.WarningSource
+		message string
+		wantErr bool
+	}{
+		{"valid preflight warning", installation.WarningSourcePreflight, "Low disk space", false},
+		{"trims message", installation.WarningSourceConflict, "  Removed foot  ", false},
+		{"empty source", "", "Low disk space", true},
+		{"empty message", installation.WarningSourceSkipped, "   ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning, err := installation.NewInstallationWarning(tt.source, tt.message)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, installation.ErrInvalidWarning)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.source, warning.Source())
+			assert.NotEmpty(t, warning.Message())
+			assert.False(t, warning.RaisedAt().IsZero())
+		})
+	}
+}
+
+func TestReconstructInstallationWarning(t *testing.T) {
+	raisedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	warning, err := installation.ReconstructInstallationWarning(installation.WarningSourceAvailability, "waybar not found", raisedAt)
+	require.NoError(t, err)
+	assert.Equal(t, raisedAt, warning.RaisedAt())
+	assert.Equal(t, "[availability] waybar not found", warning.String())
+
+	_, err = installation.ReconstructInstallationWarning(installation.WarningSourceAvailability, "waybar not found", time.Time{})
+	assert.ErrorIs(t, err, installation.ErrInvalidWarning)
+}
+
+func TestInstallationSession_Warnings(t *testing.T) {
+	config := mustCreateConfiguration(t, []installation.ComponentSelection{
+		mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0"),
+	})
+
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+	assert.False(t, session.HasWarnings())
+	assert.Empty(t, session.Warnings())
+
+	warning, err := installation.NewInstallationWarning(installation.WarningSourcePreflight, "Low disk space")
+	require.NoError(t, err)
+	session.AddWarning(warning)
+
+	assert.True(t, session.HasWarnings())
+	require.Len(t, session.Warnings(), 1)
+	assert.Equal(t, warning, session.Warnings()[0])
+
+	// Returned slice is a copy
+	session.Warnings()[0] = installation.InstallationWarning{}
+	assert.Equal(t, warning, session.Warnings()[0])
+}
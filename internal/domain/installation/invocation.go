@@ -0,0 +1,140 @@
+package installation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Invocation is a value object recording how gohan was run to start a
+// session: the command line, the flags that were set, the configuration
+// file in effect, the gohan build and the invoking user. Support uses it
+// to reproduce the conditions of a reported failure.
+type Invocation struct {
+	args       []string
+	flags      map[string]string
+	configHash string
+	version    string
+	commit     string
+	user       string
+}
+
+// NewInvocation creates an invocation. The command line is required;
+// configHash is empty when no configuration file was present.
+func NewInvocation(args []string, flags map[string]string, configHash, version, commit, user string) (Invocation, error) {
+	if len(args) == 0 {
+		return Invocation{}, fmt.Errorf("%w: command line is required", ErrInvalidInvocation)
+	}
+
+	invocation := Invocation{
+		args:       make([]string, len(args)),
+		configHash: strings.TrimSpace(configHash),
+		version:    strings.TrimSpace(version),
+		commit:     strings.TrimSpace(commit),
+		user:       strings.TrimSpace(user),
+	}
+	copy(invocation.args, args)
+
+	if len(flags) > 0 {
+		invocation.flags = make(map[string]string, len(flags))
+		for name, value := range flags {
+			invocation.flags[name] = value
+		}
+	}
+
+	return invocation, nil
+}
+
+// Args returns a copy of the command line, program name first
+func (i Invocation) Args() []string {
+	if i.args == nil {
+		return nil
+	}
+	args := make([]string, len(i.args))
+	copy(args, i.args)
+	return args
+}
+
+// CommandLine returns the command line quoted for a POSIX shell
+func (i Invocation) CommandLine() string {
+	return QuoteCommandLine(i.args)
+}
+
+// Flags returns a copy of the flags that were set, by name
+func (i Invocation) Flags() map[string]string {
+	if i.flags == nil {
+		return nil
+	}
+	flags := make(map[string]string, len(i.flags))
+	for name, value := range i.flags {
+		flags[name] = value
+	}
+	return flags
+}
+
+// FlagNames returns the names of the flags that were set, sorted
+func (i Invocation) FlagNames() []string {
+	names := make([]string, 0, len(i.flags))
+	for name := range i.flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConfigHash returns the SHA-256 of the configuration file, or "" if
+// there was none
+func (i Invocation) ConfigHash() string {
+	return i.configHash
+}
+
+// Version returns the gohan version
+func (i Invocation) Version() string {
+	return i.version
+}
+
+// Commit returns the commit gohan was built from
+func (i Invocation) Commit() string {
+	return i.commit
+}
+
+// User returns the invoking user
+func (i Invocation) User() string {
+	return i.user
+}
+
+// IsZero returns true if no invocation was recorded
+func (i Invocation) IsZero() bool {
+	return len(i.args) == 0
+}
+
+// String returns human-readable representation
+func (i Invocation) String() string {
+	if i.IsZero() {
+		return "invocation not recorded"
+	}
+	return fmt.Sprintf("%s (gohan %s, %s)", i.CommandLine(), i.version, i.user)
+}
+
+// QuoteCommandLine joins arguments into a command line for a POSIX shell,
+// single-quoting those that need it
+func QuoteCommandLine(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg != "" && strings.IndexFunc(arg, needsQuoting) < 0 {
+			quoted = append(quoted, arg)
+			continue
+		}
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+	return strings.Join(quoted, " ")
+}
+
+// needsQuoting returns true for characters a shell would interpret
+func needsQuoting(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	}
+	return !strings.ContainsRune("-_./:=,@+%", r)
+}
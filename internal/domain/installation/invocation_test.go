@@ -0,0 +1,56 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvocation(t *testing.T) {
+	t.Run("copies its inputs", func(t *testing.T) {
+		args := []string{"gohan", "install", "--components", "hyprland,waybar"}
+		flags := map[string]string{"components": "hyprland,waybar", "dry-run": "true"}
+
+		invocation, err := installation.NewInvocation(args, flags, "abc123", "1.2.0", "deadbeef", "alice")
+		require.NoError(t, err)
+
+		args[1] = "changed"
+		flags["dry-run"] = "false"
+		assert.Equal(t, "install", invocation.Args()[1])
+		assert.Equal(t, "true", invocation.Flags()["dry-run"])
+		assert.Equal(t, []string{"components", "dry-run"}, invocation.FlagNames())
+		assert.Equal(t, "abc123", invocation.ConfigHash())
+		assert.Equal(t, "1.2.0", invocation.Version())
+		assert.Equal(t, "deadbeef", invocation.Commit())
+		assert.Equal(t, "alice", invocation.User())
+		assert.False(t, invocation.IsZero())
+	})
+
+	t.Run("requires a command line", func(t *testing.T) {
+		_, err := installation.NewInvocation(nil, nil, "", "", "", "")
+		assert.ErrorIs(t, err, installation.ErrInvalidInvocation)
+		assert.True(t, installation.Invocation{}.IsZero())
+	})
+}
+
+func TestQuoteCommandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"plain", []string{"gohan", "install", "--components=hyprland,waybar"}, "gohan install --components=hyprland,waybar"},
+		{"spaces", []string{"gohan", "template", "create", "my laptop"}, "gohan template create 'my laptop'"},
+		{"single quote", []string{"echo", "it's"}, `echo 'it'\''s'`},
+		{"empty argument", []string{"gohan", ""}, "gohan ''"},
+		{"shell characters", []string{"gohan", "$HOME;ls"}, "gohan '$HOME;ls'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, installation.QuoteCommandLine(tt.args))
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package installation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// LockBackground is what the lock screen shows behind the password field
+type LockBackground string
+
+const (
+	LockBackgroundScreenshot LockBackground = "screenshot" // Blurred screenshot of the desktop
+	LockBackgroundImage      LockBackground = "image"      // Static image
+)
+
+// ClockFormat is how the lock screen shows the time
+type ClockFormat string
+
+const (
+	Clock24Hour ClockFormat = "24h" // 14:05
+	Clock12Hour ClockFormat = "12h" // 02:05 PM
+)
+
+// DefaultLockImage is the wallpaper, which static lock screen backgrounds
+// show unless another image is set
+const DefaultLockImage = "~/.config/gohan/wallpaper.jpg"
+
+// Clock font sizes accepted on the lock screen
+const (
+	DefaultLockClockSize = 72
+	minLockClockSize     = 24
+	maxLockClockSize     = 200
+)
+
+// LockScreenSettings configure the hyprlock and swaylock screens: the
+// background, an avatar above the password field and the clock. The zero
+// value is a blurred screenshot with a 24-hour clock and no avatar.
+type LockScreenSettings struct {
+	background LockBackground
+	image      string
+	avatar     string
+	clock      ClockFormat
+	clockSize  int
+}
+
+// NewLockScreenSettings creates lock screen settings. Empty values take the
+// defaults: a blurred screenshot, the wallpaper for static backgrounds, no
+// avatar and a 24-hour clock; a clockSize of 0 takes the default size.
+// Image paths must be absolute or start with ~/, and may not hold # or line
+// breaks, which would end the line in the rendered configuration.
+func NewLockScreenSettings(background, image, avatar, clock string, clockSize int) (LockScreenSettings, error) {
+	s := LockScreenSettings{
+		background: LockBackground(strings.ToLower(strings.TrimSpace(background))),
+		image:      strings.TrimSpace(image),
+		avatar:     strings.TrimSpace(avatar),
+		clock:      ClockFormat(strings.ToLower(strings.TrimSpace(clock))),
+		clockSize:  clockSize,
+	}
+
+	switch s.background {
+	case "", LockBackgroundScreenshot, LockBackgroundImage:
+	default:
+		return LockScreenSettings{}, fmt.Errorf("%w: unknown background %q (want screenshot or image)", ErrInvalidLockScreen, background)
+	}
+	switch s.clock {
+	case "", Clock24Hour, Clock12Hour:
+	default:
+		return LockScreenSettings{}, fmt.Errorf("%w: unknown clock format %q (want 24h or 12h)", ErrInvalidLockScreen, clock)
+	}
+	if s.clockSize != 0 && (s.clockSize < minLockClockSize || s.clockSize > maxLockClockSize) {
+		return LockScreenSettings{}, fmt.Errorf("%w: clock size %d is outside %d-%d", ErrInvalidLockScreen, s.clockSize, minLockClockSize, maxLockClockSize)
+	}
+	for _, path := range []string{s.image, s.avatar} {
+		if err := validateLockImagePath(path); err != nil {
+			return LockScreenSettings{}, err
+		}
+	}
+
+	return s, nil
+}
+
+func validateLockImagePath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if !filepath.IsAbs(path) && !strings.HasPrefix(path, "~/") {
+		return fmt.Errorf("%w: image path %q must be absolute or start with ~/", ErrInvalidLockScreen, path)
+	}
+	if strings.ContainsAny(path, "#\r\n") {
+		return fmt.Errorf("%w: image path %q contains # or a line break", ErrInvalidLockScreen, path)
+	}
+	return nil
+}
+
+// Background returns what the lock screen shows behind the password field
+func (s LockScreenSettings) Background() LockBackground {
+	if s.background == "" {
+		return LockBackgroundScreenshot
+	}
+	return s.background
+}
+
+// Image returns the static background image, or "" for a screenshot
+// background
+func (s LockScreenSettings) Image() string {
+	if s.Background() != LockBackgroundImage {
+		return ""
+	}
+	if s.image == "" {
+		return DefaultLockImage
+	}
+	return s.image
+}
+
+// Avatar returns the image shown above the password field, if any
+func (s LockScreenSettings) Avatar() string {
+	return s.avatar
+}
+
+// Clock returns how the time is shown
+func (s LockScreenSettings) Clock() ClockFormat {
+	if s.clock == "" {
+		return Clock24Hour
+	}
+	return s.clock
+}
+
+// ClockSize returns the clock's font size
+func (s LockScreenSettings) ClockSize() int {
+	if s.clockSize == 0 {
+		return DefaultLockClockSize
+	}
+	return s.clockSize
+}
+
+// DropMissingImages returns the settings without the images exists reports
+// missing, with ~ standing for homeDir: a missing background image falls
+// back to a blurred screenshot and a missing avatar is left out. It also
+// returns the missing paths.
+func (s LockScreenSettings) DropMissingImages(homeDir string, exists func(path string) bool) (LockScreenSettings, []string) {
+	var missing []string
+	if image := s.Image(); image != "" && !exists(expandHome(image, homeDir)) {
+		missing = append(missing, image)
+		s.background = LockBackgroundScreenshot
+	}
+	if s.avatar != "" && !exists(expandHome(s.avatar, homeDir)) {
+		missing = append(missing, s.avatar)
+		s.avatar = ""
+	}
+	return s, missing
+}
+
+// TemplateVars returns the variables the hyprlock and swaylock templates
+// are rendered with. swaylock cannot capture the screen, so it shows a
+// plain color unless the background is an image, and shows neither avatar
+// nor clock.
+func (s LockScreenSettings) TemplateVars() map[string]string {
+	vars := map[string]string{
+		"lock_background_path": "screenshot",
+		"lock_blur_passes":     "3",
+		"lock_avatar":          "",
+		"lock_clock_format":    "%H:%M",
+		"lock_clock_size":      strconv.Itoa(s.ClockSize()),
+		"swaylock_image":       "",
+	}
+
+	if image := s.Image(); image != "" {
+		vars["lock_background_path"] = image
+		vars["lock_blur_passes"] = "0"
+		vars["swaylock_image"] = "image=" + image + "\nscaling=fill"
+	}
+	if s.avatar != "" {
+		vars["lock_avatar"] = `image {
+    monitor =
+    path = ` + s.avatar + `
+    size = 150
+    rounding = -1
+    border_size = 3
+    border_color = rgb(137, 180, 250)
+    position = 0, 80
+    halign = center
+    valign = center
+}`
+	}
+	if s.Clock() == Clock12Hour {
+		vars["lock_clock_format"] = "%I:%M %p"
+	}
+
+	return vars
+}
+
+// IsLockScreenConfig returns true if targetPath is the hyprlock or
+// swaylock configuration
+func IsLockScreenConfig(targetPath string) bool {
+	targetPath = filepath.ToSlash(targetPath)
+	return strings.HasSuffix(targetPath, "/hypr/hyprlock.conf") || strings.HasSuffix(targetPath, "/swaylock/config")
+}
+
+// expandHome replaces a leading ~/ with homeDir
+func expandHome(path, homeDir string) string {
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(homeDir, path[2:])
+	}
+	return path
+}
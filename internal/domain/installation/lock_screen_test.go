@@ -0,0 +1,120 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLockScreenSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		background string
+		image      string
+		avatar     string
+		clock      string
+		clockSize  int
+		wantErr    bool
+	}{
+		{name: "defaults"},
+		{name: "static image", background: "Image", image: "/usr/share/backgrounds/lock.png", clock: "12h", clockSize: 96},
+		{name: "avatar under home", avatar: "~/.face"},
+		{name: "unknown background", background: "video", wantErr: true},
+		{name: "unknown clock format", clock: "36h", wantErr: true},
+		{name: "clock too small", clockSize: 10, wantErr: true},
+		{name: "clock too large", clockSize: 500, wantErr: true},
+		{name: "relative image", background: "image", image: "lock.png", wantErr: true},
+		{name: "image with a comment character", background: "image", image: "/tmp/#lock.png", wantErr: true},
+		{name: "avatar with a line break", avatar: "/tmp/face.png\npath = /etc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := installation.NewLockScreenSettings(tt.background, tt.image, tt.avatar, tt.clock, tt.clockSize)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, installation.ErrInvalidLockScreen)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLockScreenSettings_Defaults(t *testing.T) {
+	var lock installation.LockScreenSettings
+
+	assert.Equal(t, installation.LockBackgroundScreenshot, lock.Background())
+	assert.Empty(t, lock.Image())
+	assert.Empty(t, lock.Avatar())
+	assert.Equal(t, installation.Clock24Hour, lock.Clock())
+	assert.Equal(t, installation.DefaultLockClockSize, lock.ClockSize())
+
+	image, err := installation.NewLockScreenSettings("image", "", "", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, installation.DefaultLockImage, image.Image(), "static backgrounds default to the wallpaper")
+}
+
+func TestLockScreenSettings_TemplateVars(t *testing.T) {
+	t.Run("blurred screenshot by default", func(t *testing.T) {
+		vars := installation.LockScreenSettings{}.TemplateVars()
+
+		assert.Equal(t, "screenshot", vars["lock_background_path"])
+		assert.Equal(t, "3", vars["lock_blur_passes"])
+		assert.Empty(t, vars["lock_avatar"])
+		assert.Equal(t, "%H:%M", vars["lock_clock_format"])
+		assert.Equal(t, "72", vars["lock_clock_size"])
+		assert.Empty(t, vars["swaylock_image"])
+	})
+
+	t.Run("static image, avatar and 12-hour clock", func(t *testing.T) {
+		lock, err := installation.NewLockScreenSettings("image", "~/Pictures/lock.png", "~/.face", "12h", 96)
+		require.NoError(t, err)
+		vars := lock.TemplateVars()
+
+		assert.Equal(t, "~/Pictures/lock.png", vars["lock_background_path"])
+		assert.Equal(t, "0", vars["lock_blur_passes"])
+		assert.Contains(t, vars["lock_avatar"], "path = ~/.face")
+		assert.Equal(t, "%I:%M %p", vars["lock_clock_format"])
+		assert.Equal(t, "96", vars["lock_clock_size"])
+		assert.Equal(t, "image=~/Pictures/lock.png\nscaling=fill", vars["swaylock_image"])
+	})
+}
+
+func TestLockScreenSettings_DropMissingImages(t *testing.T) {
+	lock, err := installation.NewLockScreenSettings("image", "~/lock.png", "/var/lib/faces/alice", "", 0)
+	require.NoError(t, err)
+
+	t.Run("keeps images that exist", func(t *testing.T) {
+		var checked []string
+		kept, missing := lock.DropMissingImages("/home/alice", func(path string) bool {
+			checked = append(checked, path)
+			return true
+		})
+
+		assert.Empty(t, missing)
+		assert.Equal(t, lock, kept)
+		assert.Equal(t, []string{"/home/alice/lock.png", "/var/lib/faces/alice"}, checked)
+	})
+
+	t.Run("falls back to a screenshot without an avatar", func(t *testing.T) {
+		kept, missing := lock.DropMissingImages("/home/alice", func(string) bool { return false })
+
+		assert.Equal(t, []string{"~/lock.png", "/var/lib/faces/alice"}, missing)
+		assert.Equal(t, installation.LockBackgroundScreenshot, kept.Background())
+		assert.Empty(t, kept.Avatar())
+	})
+
+	t.Run("checks nothing for a screenshot without an avatar", func(t *testing.T) {
+		_, missing := installation.LockScreenSettings{}.DropMissingImages("/home/alice", func(string) bool { return false })
+		assert.Empty(t, missing)
+	})
+}
+
+func TestIsLockScreenConfig(t *testing.T) {
+	assert.True(t, installation.IsLockScreenConfig("/home/alice/.config/hypr/hyprlock.conf"))
+	assert.True(t, installation.IsLockScreenConfig("/home/alice/.config/swaylock/config"))
+	assert.False(t, installation.IsLockScreenConfig("/home/alice/.config/hypr/hyprland.conf"))
+	assert.False(t, installation.IsLockScreenConfig("/home/alice/.config/mako/config"))
+}
@@ -0,0 +1,171 @@
+package installation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Color temperatures the night light shifts between, in Kelvin
+const (
+	nightLightDayTemperature   = 6500
+	nightLightNightTemperature = 4000
+)
+
+// Sunrise and sunset the night light follows when no location is known
+const (
+	nightLightSunrise = "06:30"
+	nightLightSunset  = "19:30"
+)
+
+// NightLightLocation is where the night light computes sunrise and sunset
+// for. The zero value means the location is unknown.
+type NightLightLocation struct {
+	latitude  float64
+	longitude float64
+	set       bool
+}
+
+// NewNightLightLocation creates a location from degrees north and east
+func NewNightLightLocation(latitude, longitude float64) (NightLightLocation, error) {
+	if latitude < -90 || latitude > 90 {
+		return NightLightLocation{}, fmt.Errorf("%w: latitude %g is outside -90 to 90", ErrInvalidNightLightLocation, latitude)
+	}
+	if longitude < -180 || longitude > 180 {
+		return NightLightLocation{}, fmt.Errorf("%w: longitude %g is outside -180 to 180", ErrInvalidNightLightLocation, longitude)
+	}
+	return NightLightLocation{latitude: latitude, longitude: longitude, set: true}, nil
+}
+
+// ParseISO6709Coordinates parses the coordinates of a timezone in the
+// tz database's zone.tab, such as +5230+01322 for Europe/Berlin, given as
+// ±DDMM±DDDMM or ±DDMMSS±DDDMMSS
+func ParseISO6709Coordinates(coordinates string) (NightLightLocation, error) {
+	// The longitude starts at the second sign
+	split := -1
+	if len(coordinates) > 1 {
+		split = strings.IndexAny(coordinates[1:], "+-")
+	}
+	if split < 0 {
+		return NightLightLocation{}, fmt.Errorf("%w: coordinates %q have no longitude", ErrInvalidNightLightLocation, coordinates)
+	}
+	split++
+
+	latitude, err := parseISO6709Degrees(coordinates[:split], 2)
+	if err != nil {
+		return NightLightLocation{}, fmt.Errorf("%w: latitude of %q: %v", ErrInvalidNightLightLocation, coordinates, err)
+	}
+	longitude, err := parseISO6709Degrees(coordinates[split:], 3)
+	if err != nil {
+		return NightLightLocation{}, fmt.Errorf("%w: longitude of %q: %v", ErrInvalidNightLightLocation, coordinates, err)
+	}
+	return NewNightLightLocation(latitude, longitude)
+}
+
+// parseISO6709Degrees parses a signed angle whose degrees take the given
+// number of digits, followed by minutes and optionally seconds
+func parseISO6709Degrees(value string, degreeDigits int) (float64, error) {
+	if len(value) < 1+degreeDigits+2 || (value[0] != '+' && value[0] != '-') {
+		return 0, fmt.Errorf("malformed angle %q", value)
+	}
+
+	parts := []string{value[1 : 1+degreeDigits], value[1+degreeDigits : 3+degreeDigits]}
+	switch rest := value[3+degreeDigits:]; len(rest) {
+	case 0:
+	case 2:
+		parts = append(parts, rest)
+	default:
+		return 0, fmt.Errorf("malformed angle %q", value)
+	}
+
+	degrees := 0.0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, fmt.Errorf("malformed angle %q", value)
+		}
+		degrees += float64(n) / []float64{1, 60, 3600}[i]
+	}
+	if value[0] == '-' {
+		degrees = -degrees
+	}
+	return degrees, nil
+}
+
+// Latitude returns degrees north
+func (l NightLightLocation) Latitude() float64 {
+	return l.latitude
+}
+
+// Longitude returns degrees east
+func (l NightLightLocation) Longitude() float64 {
+	return l.longitude
+}
+
+// IsEmpty reports whether the location is unknown
+func (l NightLightLocation) IsEmpty() bool {
+	return !l.set
+}
+
+// String returns the coordinates
+func (l NightLightLocation) String() string {
+	if l.IsEmpty() {
+		return "unknown"
+	}
+	return fmt.Sprintf("%.2f, %.2f", l.latitude, l.longitude)
+}
+
+// NightLightCommand returns the command running the provider for a
+// location. wlsunset follows fixed times without one; gammastep cannot run
+// without a location, so it has no command then.
+func NightLightCommand(provider string, location NightLightLocation) string {
+	if provider == "gammastep" {
+		if location.IsEmpty() {
+			return ""
+		}
+		return fmt.Sprintf("gammastep -l %.2f:%.2f -t %d:%d",
+			location.latitude, location.longitude, nightLightDayTemperature, nightLightNightTemperature)
+	}
+
+	if location.IsEmpty() {
+		return fmt.Sprintf("wlsunset -S %s -s %s -T %d -t %d",
+			nightLightSunrise, nightLightSunset, nightLightDayTemperature, nightLightNightTemperature)
+	}
+	return fmt.Sprintf("wlsunset -l %.2f -L %.2f -T %d -t %d",
+		location.latitude, location.longitude, nightLightDayTemperature, nightLightNightTemperature)
+}
+
+// NightLightTemplateVars returns the Hyprland autostart line and the
+// Waybar toggle for the night light provider. An empty provider means the
+// component is not installed and everything renders empty.
+func NightLightTemplateVars(provider string, location NightLightLocation) map[string]string {
+	command := ""
+	if provider != "" {
+		command = NightLightCommand(provider, location)
+	}
+	if command == "" {
+		autostart := "# Install the night_light component to shift the screen color at night"
+		if provider != "" {
+			autostart = "# " + provider + " needs a location; set night_light.latitude and night_light.longitude"
+		}
+		return map[string]string{
+			"night_light_autostart":     autostart,
+			"waybar_night_light_module": "",
+			"waybar_night_light_config": "",
+		}
+	}
+
+	// The toggle stops the running provider or starts it again
+	return map[string]string{
+		"night_light_autostart":     "exec-once = " + command,
+		"waybar_night_light_module": `"custom/night-light",`,
+		"waybar_night_light_config": `"custom/night-light": {
+    "exec": "pgrep -x ` + provider + ` >/dev/null && echo '🌙' || echo '☀'",
+    "interval": 5,
+    "format": "{}",
+    "tooltip-format": "Night light (click to toggle)",
+    "on-click": "pkill -x ` + provider + ` || ` + command + `",
+    "exec-on-event": true
+  },`,
+	}
+}
@@ -0,0 +1,76 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseISO6709Coordinates(t *testing.T) {
+	t.Run("parses degrees and minutes", func(t *testing.T) {
+		location, err := installation.ParseISO6709Coordinates("+5230+01322")
+		require.NoError(t, err)
+
+		assert.InDelta(t, 52.5, location.Latitude(), 0.001)
+		assert.InDelta(t, 13.367, location.Longitude(), 0.001)
+	})
+
+	t.Run("parses seconds and southern and western angles", func(t *testing.T) {
+		location, err := installation.ParseISO6709Coordinates("-133636-1720549")
+		require.NoError(t, err)
+
+		assert.InDelta(t, -13.61, location.Latitude(), 0.001)
+		assert.InDelta(t, -172.097, location.Longitude(), 0.001)
+	})
+
+	t.Run("rejects malformed coordinates", func(t *testing.T) {
+		for _, coordinates := range []string{"", "+5230", "+52x0+01322", "+5230+0132"} {
+			_, err := installation.ParseISO6709Coordinates(coordinates)
+			assert.ErrorIs(t, err, installation.ErrInvalidNightLightLocation, coordinates)
+		}
+	})
+}
+
+func TestNightLightTemplateVars(t *testing.T) {
+	berlin, err := installation.NewNightLightLocation(52.5, 13.37)
+	require.NoError(t, err)
+
+	t.Run("renders empty when not installed", func(t *testing.T) {
+		vars := installation.NightLightTemplateVars("", berlin)
+
+		assert.Empty(t, vars["waybar_night_light_module"])
+		assert.NotContains(t, vars["night_light_autostart"], "exec-once")
+	})
+
+	t.Run("follows the sun at the location with wlsunset", func(t *testing.T) {
+		vars := installation.NightLightTemplateVars("wlsunset", berlin)
+
+		assert.Equal(t, "exec-once = wlsunset -l 52.50 -L 13.37 -T 6500 -t 4000", vars["night_light_autostart"])
+		assert.Equal(t, `"custom/night-light",`, vars["waybar_night_light_module"])
+		assert.Contains(t, vars["waybar_night_light_config"], `"on-click": "pkill -x wlsunset || wlsunset -l 52.50`)
+	})
+
+	t.Run("follows fixed times with wlsunset without a location", func(t *testing.T) {
+		vars := installation.NightLightTemplateVars("wlsunset", installation.NightLightLocation{})
+
+		assert.Equal(t, "exec-once = wlsunset -S 06:30 -s 19:30 -T 6500 -t 4000", vars["night_light_autostart"])
+	})
+
+	t.Run("gammastep needs a location", func(t *testing.T) {
+		vars := installation.NightLightTemplateVars("gammastep", installation.NightLightLocation{})
+		assert.NotContains(t, vars["night_light_autostart"], "exec-once")
+		assert.Empty(t, vars["waybar_night_light_config"])
+
+		vars = installation.NightLightTemplateVars("gammastep", berlin)
+		assert.Equal(t, "exec-once = gammastep -l 52.50:13.37 -t 6500:4000", vars["night_light_autostart"])
+	})
+}
+
+func TestRecommendedProfileOffersNightLight(t *testing.T) {
+	assert.Contains(t, installation.GetRecommendedProfile().OptIn, "wlsunset")
+	assert.NotContains(t, installation.GetRecommendedProfile().Packages, "wlsunset")
+	assert.Contains(t, installation.GetFullProfile().Packages, "wlsunset")
+	assert.Contains(t, installation.GetLiteProfile().OptIn, "wlsunset")
+}
@@ -50,6 +50,12 @@ func (p PackageConflict) Reason() string {
 	return p.reason
 }
 
+// Equals checks if two conflicts involve the same packages
+func (p PackageConflict) Equals(other PackageConflict) bool {
+	return p.packageName == other.packageName &&
+		p.conflictingPackage == other.conflictingPackage
+}
+
 // String returns human-readable representation
 func (p PackageConflict) String() string {
 	return fmt.Sprintf("Package conflict: %s conflicts with %s (%s)",
@@ -1,5 +1,10 @@
 package installation
 
+import (
+	"fmt"
+	"strings"
+)
+
 // PackageDefinition represents a Debian package with its metadata
 type PackageDefinition struct {
 	Name         string
@@ -25,6 +30,9 @@ const (
 	GroupDevelopment PackageGroup = "development" // Development tools
 )
 
+// PackageSuites are the Debian suites package availability is tracked for
+var PackageSuites = []string{"sid", "trixie"}
+
 // AllPackageDefinitions returns all available package definitions for Debian
 var AllPackageDefinitions = []PackageDefinition{
 	// ========================================================================
@@ -48,6 +56,15 @@ var AllPackageDefinitions = []PackageDefinition{
 		Required:     true,
 		Description:  "xdg-desktop-portal backend for Hyprland",
 	},
+	{
+		Name:         "xdg-desktop-portal-gtk",
+		Component:    ComponentHyprland,
+		Group:        GroupCore,
+		DebianSid:    true,
+		DebianTrixie: true,
+		Required:     true,
+		Description:  "xdg-desktop-portal backend for file pickers, which the Hyprland backend lacks",
+	},
 	{
 		Name:         "hyprland-backgrounds",
 		Component:    ComponentHyprland,
@@ -118,6 +135,36 @@ var AllPackageDefinitions = []PackageDefinition{
 		Description:  "Idle management daemon for Wayland",
 		Alternatives: []string{"hypridle"},
 	},
+	{
+		Name:         "hyprpaper",
+		Component:    ComponentHyprpaper,
+		Group:        GroupEssential,
+		DebianSid:    true,
+		DebianTrixie: false,
+		Required:     false,
+		Description:  "Wallpaper utility for Hyprland",
+		Alternatives: []string{"swaybg"},
+	},
+	{
+		Name:         "hyprlock",
+		Component:    ComponentHyprlock,
+		Group:        GroupEssential,
+		DebianSid:    true,
+		DebianTrixie: false,
+		Required:     false,
+		Description:  "Screen locker for Hyprland",
+		Alternatives: []string{"swaylock"},
+	},
+	{
+		Name:         "hypridle",
+		Component:    ComponentHypridle,
+		Group:        GroupEssential,
+		DebianSid:    true,
+		DebianTrixie: false,
+		Required:     false,
+		Description:  "Idle management daemon for Hyprland",
+		Alternatives: []string{"swayidle"},
+	},
 
 	// ========================================================================
 	// TERMINAL EMULATORS
@@ -278,6 +325,46 @@ var AllPackageDefinitions = []PackageDefinition{
 		Description:  "PolicyKit authentication agent",
 		Alternatives: []string{"polkit-kde-agent-1"},
 	},
+	{
+		Name:         "power-profiles-daemon",
+		Component:    ComponentPowerProfiles,
+		Group:        GroupDesktop,
+		DebianSid:    true,
+		DebianTrixie: true,
+		Required:     false,
+		Description:  "Power profile switching for laptops",
+		Alternatives: []string{"tlp"},
+	},
+	{
+		Name:         "tlp",
+		Component:    ComponentPowerProfiles,
+		Group:        GroupDesktop,
+		DebianSid:    true,
+		DebianTrixie: true,
+		Required:     false,
+		Description:  "Advanced laptop power management",
+		Alternatives: []string{"power-profiles-daemon"},
+	},
+	{
+		Name:         "wlsunset",
+		Component:    ComponentNightLight,
+		Group:        GroupDesktop,
+		DebianSid:    true,
+		DebianTrixie: true,
+		Required:     false,
+		Description:  "Day/night screen color temperature for Wayland",
+		Alternatives: []string{"gammastep"},
+	},
+	{
+		Name:         "gammastep",
+		Component:    ComponentNightLight,
+		Group:        GroupDesktop,
+		DebianSid:    true,
+		DebianTrixie: true,
+		Required:     false,
+		Description:  "Screen color temperature by sun position",
+		Alternatives: []string{"wlsunset"},
+	},
 	{
 		Name:         "xdg-utils",
 		Component:    "",
@@ -438,6 +525,28 @@ var AllPackageDefinitions = []PackageDefinition{
 	},
 }
 
+// AvailableIn reports whether the package is available in a Debian suite
+func (p PackageDefinition) AvailableIn(suite string) bool {
+	switch suite {
+	case "sid":
+		return p.DebianSid
+	case "trixie":
+		return p.DebianTrixie
+	}
+	return false
+}
+
+// ValidateSuite returns ErrUnknownSuite for a suite package availability
+// is not tracked for
+func ValidateSuite(suite string) error {
+	for _, known := range PackageSuites {
+		if suite == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q (availability is known for %s)", ErrUnknownSuite, suite, strings.Join(PackageSuites, " and "))
+}
+
 // GetPackagesByComponent returns all package definitions for a component
 func GetPackagesByComponent(component ComponentName) []PackageDefinition {
 	var packages []PackageDefinition
@@ -306,6 +306,20 @@ func TestIsPackageAvailable(t *testing.T) {
 	}
 }
 
+func TestPackageDefinition_AvailableIn(t *testing.T) {
+	hyprland, ok := installation.FindPackageDefinition("hyprland")
+	require.True(t, ok)
+
+	assert.True(t, hyprland.AvailableIn("sid"))
+	assert.False(t, hyprland.AvailableIn("trixie"))
+	assert.False(t, hyprland.AvailableIn("bookworm"))
+
+	for _, suite := range installation.PackageSuites {
+		assert.NoError(t, installation.ValidateSuite(suite))
+	}
+	assert.ErrorIs(t, installation.ValidateSuite("bookworm"), installation.ErrUnknownSuite)
+}
+
 func TestPackageDefinitionStructure(t *testing.T) {
 	t.Run("All packages have required fields", func(t *testing.T) {
 		for _, pkg := range installation.AllPackageDefinitions {
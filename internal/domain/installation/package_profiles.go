@@ -5,6 +5,8 @@ type InstallationProfile struct {
 	Name        string
 	Description string
 	Packages    []string
+	OptIn       []string      // Packages offered but only installed when chosen
+	Rendering   RenderingMode // Mode the configuration is rendered in; empty for standard
 }
 
 // ProfileType identifies different installation profile types
@@ -14,6 +16,7 @@ const (
 	ProfileMinimal     ProfileType = "minimal"     // Bare minimum for functional Hyprland
 	ProfileRecommended ProfileType = "recommended" // Recommended setup with common tools
 	ProfileFull        ProfileType = "full"        // Complete setup with all features
+	ProfileLite        ProfileType = "lite"        // Recommended tools without heavy extras, for low-end hardware
 )
 
 // GetMinimalProfile returns the minimal installation profile
@@ -26,6 +29,7 @@ func GetMinimalProfile() InstallationProfile {
 			// Core Hyprland
 			"hyprland",
 			"xdg-desktop-portal-hyprland",
+			"xdg-desktop-portal-gtk", // File pickers
 
 			// Essential Wayland tools
 			"waybar",
@@ -97,6 +101,9 @@ func GetRecommendedProfile() InstallationProfile {
 		Name:        "Recommended",
 		Description: "Recommended setup with common tools and utilities",
 		Packages:    append(minimal.Packages, additionalPackages...),
+		OptIn: []string{
+			"wlsunset", // Night light
+		},
 	}
 }
 
@@ -112,6 +119,9 @@ func GetFullProfile() InstallationProfile {
 		// Calculator
 		"gnome-calculator",
 
+		// Night light
+		"wlsunset",
+
 		// Mesa drivers (for Intel/AMD)
 		"mesa-vulkan-drivers",
 		"libgl1-mesa-dri",
@@ -124,6 +134,26 @@ func GetFullProfile() InstallationProfile {
 	}
 }
 
+// GetLiteProfile returns the lite installation profile
+// This is recommended without heavy optional packages, using foot as the terminal
+func GetLiteProfile() InstallationProfile {
+	recommended := GetRecommendedProfile()
+
+	var packages []string
+	for _, pkg := range recommended.Packages {
+		if !IsHeavyPackage(pkg) {
+			packages = append(packages, pkg)
+		}
+	}
+
+	profile := ResolveProfileAlternatives(InstallationProfile{Packages: packages}, RenderingLite.Alternatives(AlternativeSelection{}))
+	profile.OptIn = recommended.OptIn
+	profile.Name = "Lite"
+	profile.Description = "Lightweight setup for systems with limited memory or slow storage"
+	profile.Rendering = RenderingLite
+	return profile
+}
+
 // GetGPUProfile returns packages for specific GPU vendor
 func GetGPUProfile(vendor string) InstallationProfile {
 	switch vendor {
@@ -169,6 +199,11 @@ func GetGPUProfile(vendor string) InstallationProfile {
 	}
 }
 
+// ProfileTypes returns the built-in profile types
+func ProfileTypes() []ProfileType {
+	return []ProfileType{ProfileMinimal, ProfileRecommended, ProfileFull, ProfileLite}
+}
+
 // GetProfileByType returns an installation profile by its type
 func GetProfileByType(profileType ProfileType) InstallationProfile {
 	switch profileType {
@@ -178,6 +213,8 @@ func GetProfileByType(profileType ProfileType) InstallationProfile {
 		return GetRecommendedProfile()
 	case ProfileFull:
 		return GetFullProfile()
+	case ProfileLite:
+		return GetLiteProfile()
 	default:
 		return GetRecommendedProfile()
 	}
@@ -189,6 +226,7 @@ func GetAllProfiles() []InstallationProfile {
 		GetMinimalProfile(),
 		GetRecommendedProfile(),
 		GetFullProfile(),
+		GetLiteProfile(),
 	}
 }
 
@@ -217,7 +217,7 @@ func TestGetProfileByType(t *testing.T) {
 func TestGetAllProfiles(t *testing.T) {
 	profiles := installation.GetAllProfiles()
 
-	assert.Len(t, profiles, 3, "Should have exactly 3 profiles")
+	assert.Len(t, profiles, 4, "Should have exactly 4 profiles")
 
 	names := make(map[string]bool)
 	for _, profile := range profiles {
@@ -227,6 +227,29 @@ func TestGetAllProfiles(t *testing.T) {
 	assert.True(t, names["Minimal"], "Should include Minimal profile")
 	assert.True(t, names["Recommended"], "Should include Recommended profile")
 	assert.True(t, names["Full"], "Should include Full profile")
+	assert.True(t, names["Lite"], "Should include Lite profile")
+}
+
+func TestGetLiteProfile(t *testing.T) {
+	recommended := installation.GetRecommendedProfile()
+	lite := installation.GetLiteProfile()
+
+	assert.Equal(t, "Lite", lite.Name)
+	assert.NotEmpty(t, lite.Description)
+	assert.Less(t, len(lite.Packages), len(recommended.Packages),
+		"Lite should have fewer packages than recommended")
+
+	packageSet := toSet(lite.Packages)
+
+	for _, pkg := range []string{"hyprland", "waybar", "fuzzel", "foot", "grim", "cliphist"} {
+		assert.True(t, packageSet[pkg], "Lite profile should include %s", pkg)
+	}
+
+	for _, pkg := range []string{"kitty", "kitty-terminfo", "nautilus", "hyprland-backgrounds", "fonts-noto"} {
+		assert.False(t, packageSet[pkg], "Lite profile should not include %s", pkg)
+	}
+
+	assert.Equal(t, lite.Name, installation.GetProfileByType(installation.ProfileLite).Name)
 }
 
 func TestCombineProfiles(t *testing.T) {
@@ -0,0 +1,62 @@
+package installation
+
+import (
+	"fmt"
+	"time"
+)
+
+// PhaseTiming compares how long a phase of an installation was estimated
+// to take, when the installation started, with how long it took
+type PhaseTiming struct {
+	phase     InstallationStatus
+	estimated time.Duration
+	actual    time.Duration
+}
+
+// NewPhaseTiming creates a phase timing. The phase is required and
+// durations cannot be negative.
+func NewPhaseTiming(phase InstallationStatus, estimated, actual time.Duration) (PhaseTiming, error) {
+	if phase == "" {
+		return PhaseTiming{}, fmt.Errorf("%w: phase cannot be empty", ErrInvalidPhaseTiming)
+	}
+	if estimated < 0 || actual < 0 {
+		return PhaseTiming{}, fmt.Errorf("%w: durations cannot be negative", ErrInvalidPhaseTiming)
+	}
+	return PhaseTiming{phase: phase, estimated: estimated, actual: actual}, nil
+}
+
+// Phase returns the timed phase
+func (t PhaseTiming) Phase() InstallationStatus {
+	return t.phase
+}
+
+// Estimated returns how long the phase was expected to take
+func (t PhaseTiming) Estimated() time.Duration {
+	return t.estimated
+}
+
+// Actual returns how long the phase took
+func (t PhaseTiming) Actual() time.Duration {
+	return t.actual
+}
+
+// Delta returns how much longer the phase took than estimated; negative
+// when it was faster
+func (t PhaseTiming) Delta() time.Duration {
+	return t.actual - t.estimated
+}
+
+// TotalPhaseTimings sums the estimated and actual durations of timings
+func TotalPhaseTimings(timings []PhaseTiming) (estimated, actual time.Duration) {
+	for _, t := range timings {
+		estimated += t.estimated
+		actual += t.actual
+	}
+	return estimated, actual
+}
+
+// phaseStart is when a session entered a phase
+type phaseStart struct {
+	phase InstallationStatus
+	at    time.Time
+}
@@ -0,0 +1,82 @@
+package installation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPhaseTiming(t *testing.T) {
+	t.Run("compares actual with estimated duration", func(t *testing.T) {
+		timing, err := installation.NewPhaseTiming(installation.StatusInstalling, 2*time.Minute, 150*time.Second)
+		require.NoError(t, err)
+
+		assert.Equal(t, installation.StatusInstalling, timing.Phase())
+		assert.Equal(t, 30*time.Second, timing.Delta())
+	})
+
+	t.Run("rejects invalid timings", func(t *testing.T) {
+		_, err := installation.NewPhaseTiming("", time.Minute, time.Minute)
+		assert.ErrorIs(t, err, installation.ErrInvalidPhaseTiming)
+		_, err = installation.NewPhaseTiming(installation.StatusVerifying, -time.Second, time.Minute)
+		assert.ErrorIs(t, err, installation.ErrInvalidPhaseTiming)
+	})
+}
+
+func TestInstallationSession_PhaseTimings(t *testing.T) {
+	newSession := func(t *testing.T) *installation.InstallationSession {
+		config := mustCreateConfiguration(t, []installation.ComponentSelection{
+			mustCreateComponentSelection(t, installation.ComponentHyprland, "0.35.0"),
+		})
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		return session
+	}
+	complete := func(t *testing.T, session *installation.InstallationSession) {
+		snapshot, _ := installation.NewSystemSnapshot("/var/backup/test",
+			mustCreateDiskSpace(t, 100*installation.GB, 10*installation.GB), nil)
+		require.NoError(t, session.StartPreparation(snapshot))
+		require.NoError(t, session.StartInstalling())
+		component, _ := installation.NewInstalledComponent(installation.ComponentHyprland, "0.35.0", nil)
+		require.NoError(t, session.AddInstalledComponent(component))
+		require.NoError(t, session.StartConfiguring())
+		require.NoError(t, session.StartVerifying())
+		require.NoError(t, session.Complete())
+	}
+
+	t.Run("times every phase of a completed session", func(t *testing.T) {
+		session := newSession(t)
+		session.SetPhaseEstimate(map[installation.InstallationStatus]time.Duration{
+			installation.StatusInstalling: time.Minute,
+		})
+		complete(t, session)
+
+		timings := session.PhaseTimings()
+		require.Len(t, timings, 4)
+		phases := make([]installation.InstallationStatus, 0, len(timings))
+		for _, timing := range timings {
+			phases = append(phases, timing.Phase())
+		}
+		assert.Equal(t, []installation.InstallationStatus{
+			installation.StatusPreparation,
+			installation.StatusInstalling,
+			installation.StatusConfiguring,
+			installation.StatusVerifying,
+		}, phases)
+		assert.Equal(t, time.Minute, timings[1].Estimated())
+
+		estimated, actual := installation.TotalPhaseTimings(timings)
+		assert.Equal(t, time.Minute, estimated)
+		assert.LessOrEqual(t, actual, session.CompletedAt().Sub(session.StartedAt()))
+	})
+
+	t.Run("has nothing to compare without an estimate", func(t *testing.T) {
+		session := newSession(t)
+		complete(t, session)
+
+		assert.Empty(t, session.PhaseTimings())
+	})
+}
@@ -0,0 +1,275 @@
+package installation
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PortalBackend is an xdg-desktop-portal backend, named as its .portal
+// file in /usr/share/xdg-desktop-portal/portals
+type PortalBackend string
+
+const (
+	PortalHyprland PortalBackend = "hyprland"
+	PortalGTK      PortalBackend = "gtk"
+	PortalGNOME    PortalBackend = "gnome"
+	PortalKDE      PortalBackend = "kde"
+	PortalWLR      PortalBackend = "wlr"
+)
+
+// Package returns the Debian package providing the backend
+func (b PortalBackend) Package() string {
+	return "xdg-desktop-portal-" + string(b)
+}
+
+// String returns the backend's name
+func (b PortalBackend) String() string {
+	return string(b)
+}
+
+// PortalInterface is a portal interface backends implement
+type PortalInterface string
+
+const (
+	PortalScreenCast      PortalInterface = "org.freedesktop.impl.portal.ScreenCast"
+	PortalScreenshot      PortalInterface = "org.freedesktop.impl.portal.Screenshot"
+	PortalGlobalShortcuts PortalInterface = "org.freedesktop.impl.portal.GlobalShortcuts"
+	PortalFileChooser     PortalInterface = "org.freedesktop.impl.portal.FileChooser"
+	PortalSettings        PortalInterface = "org.freedesktop.impl.portal.Settings"
+)
+
+// FrontendInterface returns the interface applications call on
+// org.freedesktop.portal.Desktop for this backend interface
+func (i PortalInterface) FrontendInterface() string {
+	return strings.Replace(string(i), ".impl.portal.", ".portal.", 1)
+}
+
+// ShortName returns the interface without its namespace, like ScreenCast
+func (i PortalInterface) ShortName() string {
+	return string(i)[strings.LastIndex(string(i), ".")+1:]
+}
+
+// PortalsConfigName is the portal configuration xdg-desktop-portal reads
+// for sessions whose XDG_CURRENT_DESKTOP is Hyprland. GNOME and KDE
+// sessions read their own, so the choices made here do not follow the
+// user into those desktops the way portals.conf would.
+const PortalsConfigName = "hyprland-portals.conf"
+
+// hyprlandInterfaces are what the Hyprland backend implements; the
+// others fall to the file chooser backend
+var hyprlandInterfaces = []PortalInterface{
+	PortalScreenCast,
+	PortalScreenshot,
+	PortalGlobalShortcuts,
+}
+
+// fileChooserBackends are the backends that can show a file picker outside
+// their own desktop, in order of preference. The GNOME backend's picker
+// works under Hyprland, but its screen casting needs GNOME Shell.
+var fileChooserBackends = []PortalBackend{PortalGTK, PortalKDE, PortalGNOME}
+
+// PortalPreference is the backend chosen for one interface
+type PortalPreference struct {
+	Interface PortalInterface
+	Backend   PortalBackend
+}
+
+// PortalConflict is an installed backend that competes with the Hyprland
+// backend
+type PortalConflict struct {
+	Backend PortalBackend
+	Reason  string
+}
+
+// PortalSelection chooses which installed backend serves each interface
+// in the Hyprland session
+type PortalSelection struct {
+	installed map[PortalBackend]bool
+}
+
+// NewPortalSelection creates a selection among the installed backends
+func NewPortalSelection(installed []PortalBackend) PortalSelection {
+	selection := PortalSelection{installed: make(map[PortalBackend]bool, len(installed))}
+	for _, backend := range installed {
+		selection.installed[backend] = true
+	}
+	return selection
+}
+
+// Installed returns the installed backends in a stable order
+func (s PortalSelection) Installed() []PortalBackend {
+	backends := make([]PortalBackend, 0, len(s.installed))
+	for backend := range s.installed {
+		backends = append(backends, backend)
+	}
+	sort.Slice(backends, func(i, j int) bool { return backends[i] < backends[j] })
+	return backends
+}
+
+// IsInstalled returns true if the backend is installed
+func (s PortalSelection) IsInstalled(backend PortalBackend) bool {
+	return s.installed[backend]
+}
+
+// FileChooser returns the backend serving file pickers. Without detected
+// backends, the GTK backend gohan installs is assumed.
+func (s PortalSelection) FileChooser() (PortalBackend, bool) {
+	if len(s.installed) == 0 {
+		return PortalGTK, true
+	}
+	for _, backend := range fileChooserBackends {
+		if s.installed[backend] {
+			return backend, true
+		}
+	}
+	return "", false
+}
+
+// Preferences returns the backend chosen for each interface: Hyprland's
+// for screen sharing, screenshots and global shortcuts, and the file
+// chooser backend for file pickers and appearance settings
+func (s PortalSelection) Preferences() []PortalPreference {
+	preferences := make([]PortalPreference, 0, len(hyprlandInterfaces)+2)
+	for _, iface := range hyprlandInterfaces {
+		preferences = append(preferences, PortalPreference{Interface: iface, Backend: PortalHyprland})
+	}
+	if fileChooser, ok := s.FileChooser(); ok {
+		preferences = append(preferences,
+			PortalPreference{Interface: PortalFileChooser, Backend: fileChooser},
+			PortalPreference{Interface: PortalSettings, Backend: fileChooser},
+		)
+	}
+	return preferences
+}
+
+// Defaults returns the backends tried, in order, for interfaces without a
+// preference
+func (s PortalSelection) Defaults() []PortalBackend {
+	defaults := []PortalBackend{PortalHyprland}
+	if fileChooser, ok := s.FileChooser(); ok {
+		defaults = append(defaults, fileChooser)
+	}
+	return defaults
+}
+
+// Conflicts returns the installed backends that take over interfaces the
+// Hyprland backend should serve when the configuration does not rule them
+// out, and those that should be removed outright
+func (s PortalSelection) Conflicts() []PortalConflict {
+	var conflicts []PortalConflict
+	if s.installed[PortalWLR] {
+		conflicts = append(conflicts, PortalConflict{
+			Backend: PortalWLR,
+			Reason:  "implements screen sharing for wlroots compositors and breaks it under Hyprland; remove it: sudo apt remove " + PortalWLR.Package(),
+		})
+	}
+	if s.installed[PortalGNOME] {
+		conflicts = append(conflicts, PortalConflict{
+			Backend: PortalGNOME,
+			Reason:  "serves screen sharing only inside GNOME Shell; keep it for GNOME sessions, the Hyprland session is configured not to use it",
+		})
+	}
+	if s.installed[PortalKDE] {
+		conflicts = append(conflicts, PortalConflict{
+			Backend: PortalKDE,
+			Reason:  "serves screen sharing only inside Plasma; keep it for KDE sessions, the Hyprland session is configured not to use it",
+		})
+	}
+	return conflicts
+}
+
+// TemplateVars returns the [preferred] section's entries as
+// portal_preferences
+func (s PortalSelection) TemplateVars() map[string]string {
+	defaults := make([]string, 0, 2)
+	for _, backend := range s.Defaults() {
+		defaults = append(defaults, string(backend))
+	}
+	lines := []string{"default=" + strings.Join(defaults, ";")}
+	for _, preference := range s.Preferences() {
+		lines = append(lines, fmt.Sprintf("%s=%s", preference.Interface, preference.Backend))
+	}
+	return map[string]string{"portal_preferences": strings.Join(lines, "\n")}
+}
+
+// PortalsConfig is a parsed portal configuration's [preferred] section
+type PortalsConfig struct {
+	defaults  []PortalBackend
+	preferred map[PortalInterface][]PortalBackend
+}
+
+// ParsePortalsConfig parses a portals.conf file
+func ParsePortalsConfig(content string) (PortalsConfig, error) {
+	config := PortalsConfig{preferred: make(map[PortalInterface][]PortalBackend)}
+	section := ""
+	sawPreferred := false
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			sawPreferred = sawPreferred || section == "preferred"
+			continue
+		}
+		if section != "preferred" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return PortalsConfig{}, fmt.Errorf("invalid portal configuration line %q", line)
+		}
+		backends := parseBackendList(value)
+		if key = strings.TrimSpace(key); key == "default" {
+			config.defaults = backends
+		} else {
+			config.preferred[PortalInterface(key)] = backends
+		}
+	}
+	if !sawPreferred {
+		return PortalsConfig{}, fmt.Errorf("portal configuration has no [preferred] section")
+	}
+	return config, nil
+}
+
+// BackendFor returns the backends xdg-desktop-portal tries for the
+// interface, in order
+func (c PortalsConfig) BackendFor(iface PortalInterface) []PortalBackend {
+	if backends, ok := c.preferred[iface]; ok {
+		return append([]PortalBackend(nil), backends...)
+	}
+	return append([]PortalBackend(nil), c.defaults...)
+}
+
+// Mismatches returns the interfaces whose first backend differs from the
+// selection's choice
+func (c PortalsConfig) Mismatches(selection PortalSelection) []string {
+	var mismatches []string
+	for _, preference := range selection.Preferences() {
+		backends := c.BackendFor(preference.Interface)
+		if len(backends) == 0 || backends[0] != preference.Backend {
+			configured := "none"
+			if len(backends) > 0 {
+				configured = string(backends[0])
+			}
+			mismatches = append(mismatches, fmt.Sprintf("%s uses %s instead of %s",
+				preference.Interface.ShortName(), configured, preference.Backend))
+		}
+	}
+	return mismatches
+}
+
+func parseBackendList(value string) []PortalBackend {
+	var backends []PortalBackend
+	for _, name := range strings.Split(value, ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			backends = append(backends, PortalBackend(name))
+		}
+	}
+	return backends
+}
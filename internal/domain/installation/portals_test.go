@@ -0,0 +1,115 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortalSelection_FileChooser(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed []installation.PortalBackend
+		want      installation.PortalBackend
+		wantOK    bool
+	}{
+		{"prefers gtk", []installation.PortalBackend{installation.PortalHyprland, installation.PortalKDE, installation.PortalGTK}, installation.PortalGTK, true},
+		{"falls back to kde", []installation.PortalBackend{installation.PortalHyprland, installation.PortalGNOME, installation.PortalKDE}, installation.PortalKDE, true},
+		{"falls back to gnome", []installation.PortalBackend{installation.PortalHyprland, installation.PortalGNOME}, installation.PortalGNOME, true},
+		{"none besides hyprland", []installation.PortalBackend{installation.PortalHyprland}, "", false},
+		{"nothing detected assumes gtk", nil, installation.PortalGTK, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := installation.NewPortalSelection(tt.installed).FileChooser()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPortalSelection_TemplateVars(t *testing.T) {
+	t.Run("routes screen sharing to hyprland and pickers to gtk", func(t *testing.T) {
+		selection := installation.NewPortalSelection([]installation.PortalBackend{
+			installation.PortalHyprland, installation.PortalGTK, installation.PortalGNOME,
+		})
+
+		assert.Equal(t, "default=hyprland;gtk\n"+
+			"org.freedesktop.impl.portal.ScreenCast=hyprland\n"+
+			"org.freedesktop.impl.portal.Screenshot=hyprland\n"+
+			"org.freedesktop.impl.portal.GlobalShortcuts=hyprland\n"+
+			"org.freedesktop.impl.portal.FileChooser=gtk\n"+
+			"org.freedesktop.impl.portal.Settings=gtk",
+			selection.TemplateVars()["portal_preferences"])
+	})
+
+	t.Run("without a file chooser backend only hyprland is listed", func(t *testing.T) {
+		selection := installation.NewPortalSelection([]installation.PortalBackend{installation.PortalHyprland})
+
+		assert.Equal(t, "default=hyprland\n"+
+			"org.freedesktop.impl.portal.ScreenCast=hyprland\n"+
+			"org.freedesktop.impl.portal.Screenshot=hyprland\n"+
+			"org.freedesktop.impl.portal.GlobalShortcuts=hyprland",
+			selection.TemplateVars()["portal_preferences"])
+	})
+}
+
+func TestPortalSelection_Conflicts(t *testing.T) {
+	selection := installation.NewPortalSelection([]installation.PortalBackend{
+		installation.PortalHyprland, installation.PortalGTK, installation.PortalWLR, installation.PortalKDE,
+	})
+
+	conflicts := selection.Conflicts()
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, installation.PortalWLR, conflicts[0].Backend)
+	assert.Contains(t, conflicts[0].Reason, "sudo apt remove xdg-desktop-portal-wlr")
+	assert.Equal(t, installation.PortalKDE, conflicts[1].Backend)
+
+	assert.Empty(t, installation.NewPortalSelection([]installation.PortalBackend{installation.PortalHyprland, installation.PortalGTK}).Conflicts())
+}
+
+func TestPortalInterface_Names(t *testing.T) {
+	assert.Equal(t, "org.freedesktop.portal.ScreenCast", installation.PortalScreenCast.FrontendInterface())
+	assert.Equal(t, "FileChooser", installation.PortalFileChooser.ShortName())
+}
+
+func TestParsePortalsConfig(t *testing.T) {
+	selection := installation.NewPortalSelection([]installation.PortalBackend{
+		installation.PortalHyprland, installation.PortalGTK, installation.PortalGNOME,
+	})
+
+	t.Run("the generated configuration matches the selection", func(t *testing.T) {
+		config, err := installation.ParsePortalsConfig("# Generated by gohan\n[preferred]\n" +
+			selection.TemplateVars()["portal_preferences"] + "\n")
+		require.NoError(t, err)
+
+		assert.Empty(t, config.Mismatches(selection))
+		assert.Equal(t, []installation.PortalBackend{installation.PortalHyprland, installation.PortalGTK},
+			config.BackendFor("org.freedesktop.impl.portal.Inhibit"))
+	})
+
+	t.Run("reports interfaces another backend serves", func(t *testing.T) {
+		config, err := installation.ParsePortalsConfig("[preferred]\ndefault=gnome;gtk\norg.freedesktop.impl.portal.Screenshot=hyprland\n")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"ScreenCast uses gnome instead of hyprland",
+			"GlobalShortcuts uses gnome instead of hyprland",
+			"FileChooser uses gnome instead of gtk",
+			"Settings uses gnome instead of gtk",
+		}, config.Mismatches(selection))
+	})
+
+	t.Run("rejects files without a preferred section", func(t *testing.T) {
+		_, err := installation.ParsePortalsConfig("[other]\ndefault=hyprland\n")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects malformed lines", func(t *testing.T) {
+		_, err := installation.ParsePortalsConfig("[preferred]\ndefault\n")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,92 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PowerProfile is a power mode the keybindings can switch to
+type PowerProfile string
+
+const (
+	PowerSaver       PowerProfile = "power-saver"
+	PowerBalanced    PowerProfile = "balanced"
+	PowerPerformance PowerProfile = "performance"
+)
+
+// powerProfileKeys maps each profile to its keybinding (SUPER CTRL + key)
+var powerProfileKeys = []struct {
+	profile PowerProfile
+	key     string
+}{
+	{PowerSaver, "F1"},
+	{PowerBalanced, "F2"},
+	{PowerPerformance, "F3"},
+}
+
+// PowerProfileCommand returns the command switching the provider to a profile
+// tlp has no balanced mode, so balanced returns it to automatic AC/battery
+// switching
+func PowerProfileCommand(provider string, profile PowerProfile) string {
+	if provider == "tlp" {
+		switch profile {
+		case PowerSaver:
+			return "pkexec tlp bat"
+		case PowerPerformance:
+			return "pkexec tlp ac"
+		default:
+			return "pkexec tlp start"
+		}
+	}
+	return "powerprofilesctl set " + string(profile)
+}
+
+// PowerTemplateVars returns the Hyprland keybindings and Waybar module for
+// the power management provider. An empty provider means the component is
+// not installed and everything renders empty.
+func PowerTemplateVars(provider string) map[string]string {
+	if provider == "" {
+		return map[string]string{
+			"power_bindings":      "# Install the power_profiles component to enable profile switching",
+			"waybar_power_module": "",
+			"waybar_power_config": "",
+		}
+	}
+
+	bindings := make([]string, 0, len(powerProfileKeys))
+	for _, binding := range powerProfileKeys {
+		bindings = append(bindings, fmt.Sprintf("bind = $mainMod CTRL, %s, exec, %s",
+			binding.key, PowerProfileCommand(provider, binding.profile)))
+	}
+
+	vars := map[string]string{
+		"power_bindings": strings.Join(bindings, "\n"),
+	}
+
+	// power-profiles-daemon has a native Waybar module; tlp needs a custom one
+	if provider == "tlp" {
+		vars["waybar_power_module"] = `"custom/power-profile",`
+		vars["waybar_power_config"] = `"custom/power-profile": {
+    "exec": "tlp-stat -s | awk -F'= ' '/^Mode/ {print $2}'",
+    "interval": 30,
+    "format": "⚡ {}",
+    "tooltip": false,
+    "on-click": "` + PowerProfileCommand(provider, PowerBalanced) + `"
+  },`
+	} else {
+		vars["waybar_power_module"] = `"power-profiles-daemon",`
+		vars["waybar_power_config"] = `"power-profiles-daemon": {
+    "format": "{icon}",
+    "tooltip-format": "Power profile: {profile}\nDriver: {driver}",
+    "tooltip": true,
+    "format-icons": {
+      "default": "⚡",
+      "performance": "⚡",
+      "balanced": "⚖",
+      "power-saver": "🍃"
+    }
+  },`
+	}
+
+	return vars
+}
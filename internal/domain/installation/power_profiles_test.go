@@ -0,0 +1,56 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPowerProfileCommand(t *testing.T) {
+	assert.Equal(t, "powerprofilesctl set power-saver",
+		installation.PowerProfileCommand("power-profiles-daemon", installation.PowerSaver))
+	assert.Equal(t, "pkexec tlp bat", installation.PowerProfileCommand("tlp", installation.PowerSaver))
+	assert.Equal(t, "pkexec tlp ac", installation.PowerProfileCommand("tlp", installation.PowerPerformance))
+	assert.Equal(t, "pkexec tlp start", installation.PowerProfileCommand("tlp", installation.PowerBalanced))
+}
+
+func TestPowerTemplateVars(t *testing.T) {
+	t.Run("renders empty when not installed", func(t *testing.T) {
+		vars := installation.PowerTemplateVars("")
+
+		assert.Empty(t, vars["waybar_power_module"])
+		assert.Empty(t, vars["waybar_power_config"])
+		assert.NotContains(t, vars["power_bindings"], "bind =")
+	})
+
+	t.Run("uses the native Waybar module for power-profiles-daemon", func(t *testing.T) {
+		vars := installation.PowerTemplateVars("power-profiles-daemon")
+
+		assert.Equal(t, `"power-profiles-daemon",`, vars["waybar_power_module"])
+		assert.Contains(t, vars["power_bindings"], "bind = $mainMod CTRL, F3, exec, powerprofilesctl set performance")
+	})
+
+	t.Run("uses a custom Waybar module for tlp", func(t *testing.T) {
+		vars := installation.PowerTemplateVars("tlp")
+
+		assert.Equal(t, `"custom/power-profile",`, vars["waybar_power_module"])
+		assert.Contains(t, vars["waybar_power_config"], "tlp-stat -s")
+		assert.Contains(t, vars["power_bindings"], "pkexec tlp bat")
+	})
+}
+
+func TestPowerAlternatives(t *testing.T) {
+	t.Run("tlp replaces power-profiles-daemon", func(t *testing.T) {
+		selection, err := installation.ParseAlternativeSelection([]string{"tlp"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "tlp", selection.PackageForComponent(installation.ComponentPowerProfiles, "power-profiles-daemon"))
+	})
+
+	t.Run("rejects both daemons", func(t *testing.T) {
+		_, err := installation.ParseAlternativeSelection([]string{"tlp", "power=power-profiles-daemon"})
+		assert.ErrorIs(t, err, installation.ErrConflictingAlternatives)
+	})
+}
@@ -0,0 +1,61 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreflightCheck is a value object for a preflight result recorded on a
+// session, so blocked or degraded installs can be explained afterwards
+type PreflightCheck struct {
+	requirement string
+	status      string
+	actual      string
+	expected    string
+	message     string
+}
+
+// NewPreflightCheck creates a preflight check. Requirement and status are required.
+func NewPreflightCheck(requirement, status, actual, expected, message string) (PreflightCheck, error) {
+	requirement = strings.TrimSpace(requirement)
+	status = strings.TrimSpace(status)
+	if requirement == "" {
+		return PreflightCheck{}, fmt.Errorf("%w: requirement cannot be empty", ErrInvalidPreflightCheck)
+	}
+	if status == "" {
+		return PreflightCheck{}, fmt.Errorf("%w: status cannot be empty", ErrInvalidPreflightCheck)
+	}
+
+	return PreflightCheck{
+		requirement: requirement,
+		status:      status,
+		actual:      strings.TrimSpace(actual),
+		expected:    strings.TrimSpace(expected),
+		message:     strings.TrimSpace(message),
+	}, nil
+}
+
+// Requirement returns the name of the checked requirement
+func (c PreflightCheck) Requirement() string {
+	return c.requirement
+}
+
+// Status returns the check status (pass, warning or fail)
+func (c PreflightCheck) Status() string {
+	return c.status
+}
+
+// Actual returns what was detected
+func (c PreflightCheck) Actual() string {
+	return c.actual
+}
+
+// Expected returns what the requirement expects
+func (c PreflightCheck) Expected() string {
+	return c.expected
+}
+
+// Message returns the guidance shown for the check
+func (c PreflightCheck) Message() string {
+	return c.message
+}
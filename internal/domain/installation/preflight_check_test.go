@@ -0,0 +1,56 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPreflightCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		requirement string
+		status      string
+		wantErr     bool
+	}{
+		{"valid blocker", "disk_space", "fail", false},
+		{"empty requirement", " ", "fail", true},
+		{"empty status", "disk_space", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, err := installation.NewPreflightCheck(tt.requirement, tt.status, " 5 GB ", "10 GB", "Free up space")
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, installation.ErrInvalidPreflightCheck)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.requirement, check.Requirement())
+			assert.Equal(t, tt.status, check.Status())
+			assert.Equal(t, "5 GB", check.Actual())
+			assert.Equal(t, "10 GB", check.Expected())
+			assert.Equal(t, "Free up space", check.Message())
+		})
+	}
+}
+
+func TestInstallationSession_RecordPreflightChecks(t *testing.T) {
+	session, err := installation.NewInstallationSession(createValidConfig(t))
+	require.NoError(t, err)
+	assert.Empty(t, session.PreflightChecks())
+
+	check, err := installation.NewPreflightCheck("gpu_support", "warning", "nvidia", "amd or intel", "Install the NVIDIA driver")
+	require.NoError(t, err)
+
+	checks := []installation.PreflightCheck{check}
+	session.RecordPreflightChecks(checks)
+	checks[0] = installation.PreflightCheck{}
+
+	require.Len(t, session.PreflightChecks(), 1)
+	assert.Equal(t, "gpu_support", session.PreflightChecks()[0].Requirement())
+}
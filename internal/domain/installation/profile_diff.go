@@ -0,0 +1,175 @@
+package installation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LookupProfile returns the built-in profile with the given name. Unlike
+// GetProfileByType it does not fall back to the recommended profile.
+func LookupProfile(name string) (InstallationProfile, error) {
+	switch ProfileType(strings.ToLower(strings.TrimSpace(name))) {
+	case ProfileMinimal:
+		return GetMinimalProfile(), nil
+	case ProfileRecommended:
+		return GetRecommendedProfile(), nil
+	case ProfileFull:
+		return GetFullProfile(), nil
+	case ProfileLite:
+		return GetLiteProfile(), nil
+	}
+	return InstallationProfile{}, fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+}
+
+// RenderingMode returns the mode the profile's configuration is rendered
+// in
+func (p InstallationProfile) RenderingMode() RenderingMode {
+	if p.Rendering == "" || p.Rendering.IsAuto() {
+		return RenderingStandard
+	}
+	return p.Rendering
+}
+
+// Components returns the components the profile's packages belong to, in
+// a stable order. Packages without a component, such as fonts, are left
+// out.
+func (p InstallationProfile) Components() []ComponentName {
+	seen := make(map[ComponentName]bool)
+	var components []ComponentName
+	for _, pkg := range p.Packages {
+		definition, ok := FindPackageDefinition(pkg)
+		component := definition.Component
+		if !ok || component == "" || seen[component] {
+			continue
+		}
+		seen[component] = true
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i] < components[j] })
+	return components
+}
+
+// Alternatives returns the providers the profile installs for alternative
+// slots. When a profile installs several providers of a slot, the first
+// one listed fills it.
+func (p InstallationProfile) Alternatives() AlternativeSelection {
+	choices := make(map[AlternativeSlot]string)
+	for _, pkg := range p.Packages {
+		slot, provider, ok := findProvider(pkg)
+		if !ok {
+			continue
+		}
+		if _, taken := choices[slot]; !taken {
+			choices[slot] = provider.Package
+		}
+	}
+	return AlternativeSelection{choices: choices}
+}
+
+// FindPackageDefinition returns the definition of a known package
+func FindPackageDefinition(packageName string) (PackageDefinition, bool) {
+	for _, pkg := range AllPackageDefinitions {
+		if pkg.Name == packageName {
+			return pkg, true
+		}
+	}
+	return PackageDefinition{}, false
+}
+
+// ProfileDiff is what changes when installing one profile instead of
+// another
+type ProfileDiff struct {
+	from    InstallationProfile
+	to      InstallationProfile
+	added   []string
+	removed []string
+	common  []string
+}
+
+// DiffProfiles compares the packages of two profiles
+func DiffProfiles(from, to InstallationProfile) ProfileDiff {
+	fromSet := toPackageSet(from.Packages)
+	toSet := toPackageSet(to.Packages)
+
+	diff := ProfileDiff{from: from, to: to}
+	for pkg := range toSet {
+		if fromSet[pkg] {
+			diff.common = append(diff.common, pkg)
+		} else {
+			diff.added = append(diff.added, pkg)
+		}
+	}
+	for pkg := range fromSet {
+		if !toSet[pkg] {
+			diff.removed = append(diff.removed, pkg)
+		}
+	}
+	sort.Strings(diff.added)
+	sort.Strings(diff.removed)
+	sort.Strings(diff.common)
+	return diff
+}
+
+// From returns the profile compared from
+func (d ProfileDiff) From() InstallationProfile {
+	return d.from
+}
+
+// To returns the profile compared to
+func (d ProfileDiff) To() InstallationProfile {
+	return d.to
+}
+
+// AddedPackages returns packages only the second profile installs
+func (d ProfileDiff) AddedPackages() []string {
+	return append([]string(nil), d.added...)
+}
+
+// RemovedPackages returns packages only the first profile installs
+func (d ProfileDiff) RemovedPackages() []string {
+	return append([]string(nil), d.removed...)
+}
+
+// CommonPackages returns packages both profiles install
+func (d ProfileDiff) CommonPackages() []string {
+	return append([]string(nil), d.common...)
+}
+
+// AddedComponents returns components only the second profile installs
+func (d ProfileDiff) AddedComponents() []ComponentName {
+	return componentsMissing(d.to.Components(), d.from.Components())
+}
+
+// RemovedComponents returns components only the first profile installs
+func (d ProfileDiff) RemovedComponents() []ComponentName {
+	return componentsMissing(d.from.Components(), d.to.Components())
+}
+
+// IsEmpty returns true if both profiles install the same packages
+func (d ProfileDiff) IsEmpty() bool {
+	return len(d.added) == 0 && len(d.removed) == 0
+}
+
+// componentsMissing returns the components of a that b lacks
+func componentsMissing(a, b []ComponentName) []ComponentName {
+	present := make(map[ComponentName]bool, len(b))
+	for _, component := range b {
+		present[component] = true
+	}
+	var missing []ComponentName
+	for _, component := range a {
+		if !present[component] {
+			missing = append(missing, component)
+		}
+	}
+	return missing
+}
+
+func toPackageSet(packages []string) map[string]bool {
+	set := make(map[string]bool, len(packages))
+	for _, pkg := range packages {
+		set[pkg] = true
+	}
+	return set
+}
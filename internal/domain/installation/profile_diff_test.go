@@ -0,0 +1,78 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupProfile(t *testing.T) {
+	for _, name := range []string{"minimal", "Recommended", " full ", "lite"} {
+		profile, err := installation.LookupProfile(name)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, profile.Packages, name)
+	}
+
+	_, err := installation.LookupProfile("gaming")
+	assert.ErrorIs(t, err, installation.ErrUnknownProfile)
+}
+
+func TestInstallationProfile_Components(t *testing.T) {
+	profile := installation.InstallationProfile{
+		Packages: []string{"waybar", "kitty", "kitty-terminfo", "grim", "unknown-package"},
+	}
+
+	assert.Equal(t, []installation.ComponentName{installation.ComponentKitty, installation.ComponentWaybar},
+		profile.Components())
+}
+
+func TestInstallationProfile_Alternatives(t *testing.T) {
+	t.Run("uses the providers the profile installs", func(t *testing.T) {
+		alternatives := installation.GetLiteProfile().Alternatives()
+
+		assert.Equal(t, "foot", alternatives.ProviderOrDefault(installation.SlotTerminal))
+	})
+
+	t.Run("the first provider of a slot wins", func(t *testing.T) {
+		alternatives := installation.GetFullProfile().Alternatives()
+
+		assert.Equal(t, "kitty", alternatives.ProviderOrDefault(installation.SlotTerminal))
+	})
+}
+
+func TestInstallationProfile_RenderingMode(t *testing.T) {
+	assert.Equal(t, installation.RenderingStandard, installation.GetRecommendedProfile().RenderingMode())
+	assert.Equal(t, installation.RenderingLite, installation.GetLiteProfile().RenderingMode())
+}
+
+func TestDiffProfiles(t *testing.T) {
+	t.Run("minimal to recommended only adds", func(t *testing.T) {
+		diff := installation.DiffProfiles(installation.GetMinimalProfile(), installation.GetRecommendedProfile())
+
+		assert.Contains(t, diff.AddedPackages(), "cliphist")
+		assert.Empty(t, diff.RemovedPackages())
+		assert.ElementsMatch(t, installation.GetMinimalProfile().Packages, diff.CommonPackages())
+		assert.False(t, diff.IsEmpty())
+	})
+
+	t.Run("lists packages and components on both sides", func(t *testing.T) {
+		from := installation.InstallationProfile{Packages: []string{"hyprland", "kitty", "grim"}}
+		to := installation.InstallationProfile{Packages: []string{"hyprland", "waybar", "grim"}}
+
+		diff := installation.DiffProfiles(from, to)
+
+		assert.Equal(t, []string{"waybar"}, diff.AddedPackages())
+		assert.Equal(t, []string{"kitty"}, diff.RemovedPackages())
+		assert.Equal(t, []string{"grim", "hyprland"}, diff.CommonPackages())
+		assert.Equal(t, []installation.ComponentName{installation.ComponentWaybar}, diff.AddedComponents())
+		assert.Equal(t, []installation.ComponentName{installation.ComponentKitty}, diff.RemovedComponents())
+	})
+
+	t.Run("a profile compared with itself is empty", func(t *testing.T) {
+		profile := installation.GetFullProfile()
+
+		assert.True(t, installation.DiffProfiles(profile, profile).IsEmpty())
+	})
+}
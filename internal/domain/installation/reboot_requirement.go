@@ -0,0 +1,94 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RebootRequiredFlag is the file Debian's package scripts create when an
+// upgrade only takes effect after a reboot; the packages that asked for it
+// are listed in RebootRequiredPackagesFile
+const (
+	RebootRequiredFlag         = "/var/run/reboot-required"
+	RebootRequiredPackagesFile = "/var/run/reboot-required.pkgs"
+)
+
+// RebootRequirement lists why an installation only takes full effect after
+// a reboot: a new kernel, GPU driver or firmware, or the system's own
+// reboot-required flag. No reasons means no reboot is needed.
+type RebootRequirement struct {
+	reasons []string
+}
+
+// NewRebootRequirement creates a requirement from its reasons. Blank and
+// repeated reasons are dropped.
+func NewRebootRequirement(reasons ...string) RebootRequirement {
+	seen := make(map[string]bool, len(reasons))
+	var kept []string
+	for _, reason := range reasons {
+		reason = strings.TrimSpace(reason)
+		if reason == "" || seen[reason] {
+			continue
+		}
+		seen[reason] = true
+		kept = append(kept, reason)
+	}
+	return RebootRequirement{reasons: kept}
+}
+
+// IsRequired returns true if a reboot is needed
+func (r RebootRequirement) IsRequired() bool {
+	return len(r.reasons) > 0
+}
+
+// Reasons returns why a reboot is needed
+func (r RebootRequirement) Reasons() []string {
+	return append([]string(nil), r.reasons...)
+}
+
+// RebootReasonForPackage returns why installing a package needs a reboot,
+// or "" if it takes effect right away. Kernels, firmware, CPU microcode and
+// the kernel side of the GPU drivers are only loaded at boot.
+func RebootReasonForPackage(name string) string {
+	name, _, _ = strings.Cut(name, ":")
+	switch {
+	case strings.HasPrefix(name, "linux-image-"):
+		return fmt.Sprintf("A new kernel was installed (%s)", name)
+	case strings.HasSuffix(name, "-microcode"):
+		return fmt.Sprintf("CPU microcode was updated (%s)", name)
+	case strings.HasPrefix(name, "firmware-"):
+		return fmt.Sprintf("Firmware was installed (%s)", name)
+	case strings.HasPrefix(name, "nvidia-kernel-"):
+		return fmt.Sprintf("The NVIDIA kernel module was built (%s)", name)
+	}
+	return ""
+}
+
+// DetectRebootRequirement works out whether an installation needs a reboot
+// from the GPU driver components it installed, every package dpkg
+// installed meanwhile (dependencies included), and whether the system flag
+// is set, with the packages that set it
+func DetectRebootRequirement(
+	components []ComponentName,
+	packages []string,
+	flagged bool,
+	flaggedPackages []string,
+) RebootRequirement {
+	var reasons []string
+	for _, component := range components {
+		if component.NeedsKernelSetup() {
+			reasons = append(reasons, fmt.Sprintf("The %s GPU driver was installed; its %s module loads at boot", component, component.KernelModule()))
+		}
+	}
+	for _, name := range packages {
+		reasons = append(reasons, RebootReasonForPackage(name))
+	}
+	if flagged {
+		reason := "The system reports a reboot is required (" + RebootRequiredFlag + ")"
+		if len(flaggedPackages) > 0 {
+			reason = fmt.Sprintf("The system reports a reboot is required for %s", strings.Join(flaggedPackages, ", "))
+		}
+		reasons = append(reasons, reason)
+	}
+	return NewRebootRequirement(reasons...)
+}
@@ -0,0 +1,64 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRebootRequirement(t *testing.T) {
+	t.Run("is not required without reasons", func(t *testing.T) {
+		assert.False(t, installation.NewRebootRequirement().IsRequired())
+		assert.False(t, installation.NewRebootRequirement("", "  ").IsRequired())
+	})
+
+	t.Run("drops repeated reasons", func(t *testing.T) {
+		requirement := installation.NewRebootRequirement("New kernel", "New kernel", "Firmware")
+		assert.True(t, requirement.IsRequired())
+		assert.Equal(t, []string{"New kernel", "Firmware"}, requirement.Reasons())
+	})
+}
+
+func TestRebootReasonForPackage(t *testing.T) {
+	tests := []struct {
+		name   string
+		reboot bool
+	}{
+		{"linux-image-6.12.0-1-amd64", true},
+		{"linux-image-amd64:amd64", true},
+		{"firmware-amd-graphics", true},
+		{"intel-microcode", true},
+		{"nvidia-kernel-dkms", true},
+		{"linux-headers-amd64", false},
+		{"hyprland", false},
+		{"xserver-xorg-video-amdgpu", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.reboot, installation.RebootReasonForPackage(tt.name) != "")
+		})
+	}
+}
+
+func TestDetectRebootRequirement(t *testing.T) {
+	t.Run("GPU drivers that load at boot need a reboot", func(t *testing.T) {
+		requirement := installation.DetectRebootRequirement(
+			[]installation.ComponentName{installation.ComponentHyprland, installation.ComponentNVIDIADriver}, nil, false, nil)
+		assert.True(t, requirement.IsRequired())
+		assert.Len(t, requirement.Reasons(), 1)
+	})
+
+	t.Run("the system flag needs a reboot", func(t *testing.T) {
+		requirement := installation.DetectRebootRequirement(nil, []string{"hyprland"}, true, nil)
+		assert.Equal(t, []string{"The system reports a reboot is required (/var/run/reboot-required)"}, requirement.Reasons())
+	})
+
+	t.Run("user-space changes need none", func(t *testing.T) {
+		requirement := installation.DetectRebootRequirement(
+			[]installation.ComponentName{installation.ComponentHyprland, installation.ComponentIntelDriver},
+			[]string{"hyprland", "waybar"}, false, nil)
+		assert.False(t, requirement.IsRequired())
+	})
+}
@@ -0,0 +1,110 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderingMode controls how heavy the rendered desktop configuration is
+type RenderingMode string
+
+const (
+	RenderingAuto     RenderingMode = "auto"     // Chosen from preflight resource detection
+	RenderingStandard RenderingMode = "standard" // Blur, shadows, animations and full Waybar
+	RenderingLite     RenderingMode = "lite"     // No effects and a lighter Waybar for low-end hardware
+)
+
+// ParseRenderingMode parses a user supplied rendering mode
+// An empty string selects automatic detection
+func ParseRenderingMode(value string) (RenderingMode, error) {
+	switch mode := RenderingMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return RenderingAuto, nil
+	case RenderingAuto, RenderingStandard, RenderingLite:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q (expected auto, standard or lite)", ErrInvalidRenderingMode, value)
+	}
+}
+
+// String returns the string representation of the mode
+func (m RenderingMode) String() string {
+	return string(m)
+}
+
+// IsLite returns true if lite rendering is selected
+func (m RenderingMode) IsLite() bool {
+	return m == RenderingLite
+}
+
+// IsAuto returns true if the mode should be chosen automatically
+// The zero value is treated as automatic
+func (m RenderingMode) IsAuto() bool {
+	return m == RenderingAuto || m == ""
+}
+
+// Resolve returns the concrete mode to render with, choosing lite when the
+// mode is automatic and the system is low-end
+func (m RenderingMode) Resolve(lowEndSystem bool) RenderingMode {
+	if !m.IsAuto() {
+		return m
+	}
+	if lowEndSystem {
+		return RenderingLite
+	}
+	return RenderingStandard
+}
+
+// TemplateVars returns the template variables toggling visual effects
+func (m RenderingMode) TemplateVars() map[string]string {
+	effects := "true"
+	if m.IsLite() {
+		effects = "false"
+	}
+
+	return map[string]string{
+		"rendering_mode":     m.Resolve(false).String(),
+		"blur_enabled":       effects,
+		"shadow_enabled":     effects,
+		"animations_enabled": effects,
+	}
+}
+
+// WaybarConfigTemplate returns the Waybar configuration template for the mode
+// Lite mode drops polling modules such as cpu and memory
+func (m RenderingMode) WaybarConfigTemplate() string {
+	if m.IsLite() {
+		return "config-lite.jsonc"
+	}
+	return "config.jsonc"
+}
+
+// heavyPackages are optional packages skipped in lite mode because of their
+// memory footprint or dependency chains
+var heavyPackages = map[string]bool{
+	"hyprland-backgrounds":   true,
+	"nautilus":               true,
+	"gnome-calculator":       true,
+	"network-manager-gnome":  true,
+	"blueman":                true,
+	"qt6-wayland":            true,
+	"fonts-noto":             true,
+	"fonts-noto-color-emoji": true,
+}
+
+// liteTerminal replaces the default terminal in lite mode
+const liteTerminal = "foot"
+
+// IsHeavyPackage returns true if a package is skipped in lite mode
+func IsHeavyPackage(packageName string) bool {
+	return heavyPackages[packageName]
+}
+
+// Alternatives returns the selection to use for the mode
+// Lite mode prefers foot unless the user chose a terminal
+func (m RenderingMode) Alternatives(selection AlternativeSelection) AlternativeSelection {
+	if !m.IsLite() {
+		return selection
+	}
+	return selection.WithFallback(SlotTerminal, liteTerminal)
+}
@@ -0,0 +1,77 @@
+package installation_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRenderingMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    installation.RenderingMode
+		wantErr bool
+	}{
+		{"", installation.RenderingAuto, false},
+		{"auto", installation.RenderingAuto, false},
+		{"Lite", installation.RenderingLite, false},
+		{" standard ", installation.RenderingStandard, false},
+		{"fancy", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			mode, err := installation.ParseRenderingMode(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, installation.ErrInvalidRenderingMode)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mode)
+		})
+	}
+}
+
+func TestRenderingMode_Resolve(t *testing.T) {
+	assert.Equal(t, installation.RenderingLite, installation.RenderingAuto.Resolve(true))
+	assert.Equal(t, installation.RenderingStandard, installation.RenderingAuto.Resolve(false))
+	assert.Equal(t, installation.RenderingLite, installation.RenderingMode("").Resolve(true))
+
+	// Explicit choices override detection
+	assert.Equal(t, installation.RenderingStandard, installation.RenderingStandard.Resolve(true))
+	assert.Equal(t, installation.RenderingLite, installation.RenderingLite.Resolve(false))
+}
+
+func TestRenderingMode_TemplateVars(t *testing.T) {
+	standard := installation.RenderingStandard.TemplateVars()
+	assert.Equal(t, "true", standard["blur_enabled"])
+	assert.Equal(t, "true", standard["animations_enabled"])
+	assert.Equal(t, "standard", standard["rendering_mode"])
+	assert.Equal(t, "config.jsonc", installation.RenderingStandard.WaybarConfigTemplate())
+
+	lite := installation.RenderingLite.TemplateVars()
+	assert.Equal(t, "false", lite["blur_enabled"])
+	assert.Equal(t, "false", lite["shadow_enabled"])
+	assert.Equal(t, "false", lite["animations_enabled"])
+	assert.Equal(t, "lite", lite["rendering_mode"])
+	assert.Equal(t, "config-lite.jsonc", installation.RenderingLite.WaybarConfigTemplate())
+}
+
+func TestRenderingMode_Alternatives(t *testing.T) {
+	var none installation.AlternativeSelection
+
+	terminal, _ := installation.RenderingLite.Alternatives(none).Provider(installation.SlotTerminal)
+	assert.Equal(t, "foot", terminal)
+
+	_, ok := installation.RenderingStandard.Alternatives(none).Provider(installation.SlotTerminal)
+	assert.False(t, ok)
+
+	chosen, err := installation.ParseAlternativeSelection([]string{"alacritty"})
+	require.NoError(t, err)
+	terminal, _ = installation.RenderingLite.Alternatives(chosen).Provider(installation.SlotTerminal)
+	assert.Equal(t, "alacritty", terminal, "user choice should win over the lite default")
+}
@@ -33,6 +33,29 @@ type ProgressEstimator interface {
 	) int
 }
 
+// ThroughputAwareEstimator is optionally implemented by progress estimators
+// that can calibrate their estimates with measured disk and network speeds
+type ThroughputAwareEstimator interface {
+	// EstimateRemainingTimeWithContext estimates the remaining time of an
+	// installation downloading downloadBytes on a system with the given context
+	EstimateRemainingTimeWithContext(
+		systemContext SystemContext,
+		downloadBytes uint64,
+		currentPhase InstallationStatus,
+		percentComplete int,
+		elapsedTime time.Duration,
+	) time.Duration
+}
+
+// PhasePlanner is optionally implemented by progress estimators that can
+// estimate up front how long each phase of an installation takes
+type PhasePlanner interface {
+	// PlanPhases estimates the phases from preparation on of an
+	// installation downloading downloadBytes on a system with the given
+	// context. It returns false when nothing was measured to base it on.
+	PlanPhases(systemContext SystemContext, downloadBytes uint64) (map[InstallationStatus]time.Duration, bool)
+}
+
 // ConfigurationMerger is a domain service for merging installation configurations
 // Handles the logic of combining new configurations with existing ones
 type ConfigurationMerger interface {
@@ -0,0 +1,193 @@
+package installation
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimelineEntryKind tells what a timeline entry records
+type TimelineEntryKind string
+
+const (
+	TimelineEntryPhase     TimelineEntryKind = "phase"     // A progress phase started
+	TimelineEntryComponent TimelineEntryKind = "component" // A component moved to a new state
+)
+
+// TimelineEntry is a value object for one step of a session, kept so where
+// the time went can be told once the session is over
+type TimelineEntry struct {
+	kind   TimelineEntryKind
+	name   string
+	state  ComponentState
+	detail string
+	at     time.Time
+}
+
+// newPhaseEntry records a phase starting at the given time
+func newPhaseEntry(phase string, at time.Time) TimelineEntry {
+	return TimelineEntry{kind: TimelineEntryPhase, name: phase, at: at}
+}
+
+// newComponentEntry records a component status change
+func newComponentEntry(status ComponentStatus) TimelineEntry {
+	return TimelineEntry{
+		kind:   TimelineEntryComponent,
+		name:   string(status.Component()),
+		state:  status.State(),
+		detail: status.ErrorMessage(),
+		at:     status.UpdatedAt(),
+	}
+}
+
+// ReconstructTimelineEntry reconstructs a timeline entry from persistent
+// storage. State is only set on component entries; detail is the error of
+// a failed component.
+func ReconstructTimelineEntry(kind TimelineEntryKind, name string, state ComponentState, detail string, at time.Time) (TimelineEntry, error) {
+	if kind != TimelineEntryPhase && kind != TimelineEntryComponent {
+		return TimelineEntry{}, fmt.Errorf("%w: unknown kind %q", ErrInvalidTimelineEntry, kind)
+	}
+	if name == "" {
+		return TimelineEntry{}, fmt.Errorf("%w: name cannot be empty", ErrInvalidTimelineEntry)
+	}
+	if kind == TimelineEntryComponent && !state.IsValid() {
+		return TimelineEntry{}, fmt.Errorf("%w: unknown component state %q", ErrInvalidTimelineEntry, state)
+	}
+	if at.IsZero() {
+		return TimelineEntry{}, fmt.Errorf("%w: time cannot be zero", ErrInvalidTimelineEntry)
+	}
+
+	return TimelineEntry{kind: kind, name: name, state: state, detail: detail, at: at}, nil
+}
+
+// Kind returns what the entry records
+func (e TimelineEntry) Kind() TimelineEntryKind {
+	return e.kind
+}
+
+// Name returns the phase or component name
+func (e TimelineEntry) Name() string {
+	return e.name
+}
+
+// State returns the state a component moved to; empty for phases
+func (e TimelineEntry) State() ComponentState {
+	return e.state
+}
+
+// Detail returns why a component failed; empty otherwise
+func (e TimelineEntry) Detail() string {
+	return e.detail
+}
+
+// At returns when the step happened
+func (e TimelineEntry) At() time.Time {
+	return e.at
+}
+
+// TimelineSpan is a value object for a stretch of a session: a phase from
+// its start to the next one, or a component from when it began downloading
+// or installing until it was done with that
+type TimelineSpan struct {
+	kind   TimelineEntryKind
+	name   string
+	start  time.Time
+	end    time.Time
+	failed bool
+	detail string
+}
+
+// Kind returns whether the span is a phase or a component
+func (s TimelineSpan) Kind() TimelineEntryKind {
+	return s.kind
+}
+
+// Name returns the phase or component name
+func (s TimelineSpan) Name() string {
+	return s.name
+}
+
+// Start returns when the span began
+func (s TimelineSpan) Start() time.Time {
+	return s.start
+}
+
+// End returns when the span ended
+func (s TimelineSpan) End() time.Time {
+	return s.end
+}
+
+// Duration returns how long the span took
+func (s TimelineSpan) Duration() time.Duration {
+	return s.end.Sub(s.start)
+}
+
+// Failed returns true if the session failed in this phase, or the
+// component failed
+func (s TimelineSpan) Failed() bool {
+	return s.failed
+}
+
+// Detail returns why the span failed; empty otherwise
+func (s TimelineSpan) Detail() string {
+	return s.detail
+}
+
+// BuildTimelineSpans turns a session's entries into phase spans, in the
+// order the phases ran, followed by component spans in the order work on
+// them began. A component span lasts while it is downloaded and installed.
+// The last phase and any component still being installed run until end. failure is why the session failed, and marks its last phase
+// failed; empty when it did not fail.
+func BuildTimelineSpans(entries []TimelineEntry, end time.Time, failure string) []TimelineSpan {
+	var phases []TimelineSpan
+	var components []TimelineSpan
+	componentIndex := make(map[string]int)
+	active := make(map[string]bool)
+
+	for _, entry := range entries {
+		switch entry.kind {
+		case TimelineEntryPhase:
+			if n := len(phases); n > 0 {
+				phases[n-1].end = entry.at
+			}
+			phases = append(phases, TimelineSpan{kind: TimelineEntryPhase, name: entry.name, start: entry.at, end: end})
+
+		case TimelineEntryComponent:
+			i, started := componentIndex[entry.name]
+			if !started {
+				// Nothing was done for components not started or skipped
+				if entry.state == ComponentStatePending || entry.state == ComponentStateSkipped {
+					continue
+				}
+				i = len(components)
+				componentIndex[entry.name] = i
+				components = append(components, TimelineSpan{kind: TimelineEntryComponent, name: entry.name, start: entry.at})
+			}
+
+			// Later changes, such as failing verification, mark the span
+			// without stretching it over the phases in between
+			span := &components[i]
+			span.failed = entry.state == ComponentStateFailed
+			span.detail = entry.detail
+			if active[entry.name] || !started {
+				span.end = entry.at
+				active[entry.name] = entry.state == ComponentStateDownloading || entry.state == ComponentStateInstalling
+				if active[entry.name] {
+					span.end = end
+				}
+			}
+		}
+	}
+
+	if n := len(phases); n > 0 && failure != "" {
+		phases[n-1].failed = true
+		phases[n-1].detail = failure
+	}
+
+	spans := append(phases, components...)
+	for i := range spans {
+		if spans[i].end.Before(spans[i].start) {
+			spans[i].end = spans[i].start
+		}
+	}
+	return spans
+}
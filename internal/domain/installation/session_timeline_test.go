@@ -0,0 +1,140 @@
+package installation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconstructTimelineEntry(t *testing.T) {
+	at := time.Now()
+
+	t.Run("reconstructs phase and component entries", func(t *testing.T) {
+		phase, err := installation.ReconstructTimelineEntry(installation.TimelineEntryPhase, "Installing packages", "", "", at)
+		require.NoError(t, err)
+		assert.Equal(t, "Installing packages", phase.Name())
+
+		component, err := installation.ReconstructTimelineEntry(installation.TimelineEntryComponent, "waybar", installation.ComponentStateFailed, "apt-get failed", at)
+		require.NoError(t, err)
+		assert.Equal(t, installation.ComponentStateFailed, component.State())
+		assert.Equal(t, "apt-get failed", component.Detail())
+	})
+
+	t.Run("rejects invalid entries", func(t *testing.T) {
+		_, err := installation.ReconstructTimelineEntry("event", "x", "", "", at)
+		assert.ErrorIs(t, err, installation.ErrInvalidTimelineEntry)
+		_, err = installation.ReconstructTimelineEntry(installation.TimelineEntryPhase, "", "", "", at)
+		assert.ErrorIs(t, err, installation.ErrInvalidTimelineEntry)
+		_, err = installation.ReconstructTimelineEntry(installation.TimelineEntryComponent, "waybar", "stuck", "", at)
+		assert.ErrorIs(t, err, installation.ErrInvalidTimelineEntry)
+		_, err = installation.ReconstructTimelineEntry(installation.TimelineEntryPhase, "Installing", "", "", time.Time{})
+		assert.ErrorIs(t, err, installation.ErrInvalidTimelineEntry)
+	})
+}
+
+func TestBuildTimelineSpans(t *testing.T) {
+	start := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+	phase := func(name string, seconds int) installation.TimelineEntry {
+		entry, err := installation.ReconstructTimelineEntry(installation.TimelineEntryPhase, name, "", "", at(seconds))
+		require.NoError(t, err)
+		return entry
+	}
+	component := func(name string, state installation.ComponentState, detail string, seconds int) installation.TimelineEntry {
+		entry, err := installation.ReconstructTimelineEntry(installation.TimelineEntryComponent, name, state, detail, at(seconds))
+		require.NoError(t, err)
+		return entry
+	}
+
+	entries := []installation.TimelineEntry{
+		phase("Preflight", 0),
+		phase("Installing", 10),
+		component("hyprland", installation.ComponentStateDownloading, "", 10),
+		component("hyprland", installation.ComponentStateInstalling, "", 20),
+		component("hyprland", installation.ComponentStateConfigured, "", 40),
+		component("waybar", installation.ComponentStateInstalling, "", 40),
+		component("waybar", installation.ComponentStateConfigured, "", 50),
+		phase("Verifying", 60),
+		component("waybar", installation.ComponentStateFailed, "verification failed: waybar not running", 70),
+	}
+
+	spans := installation.BuildTimelineSpans(entries, at(75), "verification failed")
+	require.Len(t, spans, 5)
+
+	t.Run("phases run until the next one, the last until the end", func(t *testing.T) {
+		assert.Equal(t, "Preflight", spans[0].Name())
+		assert.Equal(t, 10*time.Second, spans[0].Duration())
+		assert.Equal(t, 50*time.Second, spans[1].Duration())
+		assert.Equal(t, 15*time.Second, spans[2].Duration())
+	})
+
+	t.Run("the session failed in its last phase", func(t *testing.T) {
+		assert.False(t, spans[1].Failed())
+		assert.True(t, spans[2].Failed())
+		assert.Equal(t, "verification failed", spans[2].Detail())
+	})
+
+	t.Run("components last while downloaded and installed", func(t *testing.T) {
+		assert.Equal(t, installation.TimelineEntryComponent, spans[3].Kind())
+		assert.Equal(t, "hyprland", spans[3].Name())
+		assert.Equal(t, at(10), spans[3].Start())
+		assert.Equal(t, 30*time.Second, spans[3].Duration())
+		assert.False(t, spans[3].Failed())
+	})
+
+	t.Run("a later failure marks a component without stretching it", func(t *testing.T) {
+		assert.Equal(t, "waybar", spans[4].Name())
+		assert.Equal(t, 10*time.Second, spans[4].Duration())
+		assert.True(t, spans[4].Failed())
+		assert.Equal(t, "verification failed: waybar not running", spans[4].Detail())
+	})
+
+	t.Run("a component still installing runs until the end", func(t *testing.T) {
+		spans := installation.BuildTimelineSpans([]installation.TimelineEntry{
+			component("hyprland", installation.ComponentStatePending, "", 0),
+			component("hyprland", installation.ComponentStateInstalling, "", 5),
+		}, at(30), "")
+		require.Len(t, spans, 1)
+		assert.Equal(t, 25*time.Second, spans[0].Duration())
+	})
+}
+
+func TestInstallationSession_Timeline(t *testing.T) {
+	components := []installation.ComponentSelection{}
+	hyprland, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", nil)
+	require.NoError(t, err)
+	components = append(components, hyprland)
+	config, err := installation.NewInstallationConfiguration(components, nil, installation.DiskSpace{}, false)
+	require.NoError(t, err)
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+
+	now := time.Now()
+	session.UpdateProgress(installation.NewInstallationProgress("Preflight", 5, "Checking", now))
+	session.UpdateProgress(installation.NewInstallationProgress("Preflight", 8, "Still checking", now))
+	session.UpdateProgress(installation.NewInstallationProgress("Installing", 20, "Installing hyprland", now))
+	require.NoError(t, session.MarkComponent(installation.ComponentHyprland, installation.ComponentStateInstalling))
+
+	t.Run("records each new phase and component change", func(t *testing.T) {
+		timeline := session.Timeline()
+		require.Len(t, timeline, 3)
+		assert.Equal(t, "Preflight", timeline[0].Name())
+		assert.Equal(t, "Installing", timeline[1].Name())
+		assert.Equal(t, installation.TimelineEntryComponent, timeline[2].Kind())
+		assert.Equal(t, installation.ComponentStateInstalling, timeline[2].State())
+	})
+
+	t.Run("does not record progress reported once stopped", func(t *testing.T) {
+		require.NoError(t, session.Fail("apt-get failed"))
+		session.UpdateProgress(installation.NewInstallationProgress("Failed", 20, "apt-get failed", time.Now()))
+
+		assert.Len(t, session.Timeline(), 3)
+		spans := session.TimelineSpans()
+		require.Len(t, spans, 3)
+		assert.True(t, spans[1].Failed())
+		assert.Equal(t, "apt-get failed", spans[1].Detail())
+	})
+}
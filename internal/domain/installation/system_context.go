@@ -0,0 +1,82 @@
+package installation
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultComponentDownloadBytes is assumed for components whose package
+	// size is unknown; Hyprland components pull in sizeable dependencies
+	DefaultComponentDownloadBytes = 100 * MB
+	// UnpackWriteAmplification is how many bytes are written to disk for each
+	// downloaded byte while unpacking and configuring packages
+	UnpackWriteAmplification = 3
+)
+
+// SystemContext captures the disk and network throughput measured for the
+// system an installation runs on. A zero speed means it was not measured.
+type SystemContext struct {
+	diskWriteBytesPerSec float64
+	downloadBytesPerSec  float64
+	measuredAt           time.Time
+}
+
+// NewSystemContext creates a new system context value object
+func NewSystemContext(diskWriteBytesPerSec, downloadBytesPerSec float64, measuredAt time.Time) (SystemContext, error) {
+	if diskWriteBytesPerSec < 0 || downloadBytesPerSec < 0 {
+		return SystemContext{}, ErrInvalidSystemContext
+	}
+	if (diskWriteBytesPerSec > 0 || downloadBytesPerSec > 0) && measuredAt.IsZero() {
+		return SystemContext{}, ErrInvalidSystemContext
+	}
+
+	return SystemContext{
+		diskWriteBytesPerSec: diskWriteBytesPerSec,
+		downloadBytesPerSec:  downloadBytesPerSec,
+		measuredAt:           measuredAt,
+	}, nil
+}
+
+// DiskWriteBytesPerSec returns the measured disk write speed
+func (c SystemContext) DiskWriteBytesPerSec() float64 {
+	return c.diskWriteBytesPerSec
+}
+
+// DownloadBytesPerSec returns the measured download speed
+func (c SystemContext) DownloadBytesPerSec() float64 {
+	return c.downloadBytesPerSec
+}
+
+// MeasuredAt returns when the throughput was measured
+func (c SystemContext) MeasuredAt() time.Time {
+	return c.measuredAt
+}
+
+// IsMeasured returns true if any throughput was measured
+func (c SystemContext) IsMeasured() bool {
+	return c.diskWriteBytesPerSec > 0 || c.downloadBytesPerSec > 0
+}
+
+// EstimatePackageTime estimates how long downloading and unpacking the given
+// number of package bytes takes at the measured speeds. Unmeasured speeds
+// contribute nothing.
+func (c SystemContext) EstimatePackageTime(downloadBytes uint64) time.Duration {
+	var seconds float64
+	if c.downloadBytesPerSec > 0 {
+		seconds += float64(downloadBytes) / c.downloadBytesPerSec
+	}
+	if c.diskWriteBytesPerSec > 0 {
+		seconds += float64(downloadBytes) * UnpackWriteAmplification / c.diskWriteBytesPerSec
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// String returns human-readable representation
+func (c SystemContext) String() string {
+	if !c.IsMeasured() {
+		return "throughput not measured"
+	}
+	return fmt.Sprintf("disk write %.1f MB/s, download %.1f MB/s",
+		c.diskWriteBytesPerSec/float64(MB), c.downloadBytesPerSec/float64(MB))
+}
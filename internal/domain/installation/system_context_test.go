@@ -0,0 +1,58 @@
+package installation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSystemContext(t *testing.T) {
+	now := time.Now()
+
+	t.Run("rejects negative speeds", func(t *testing.T) {
+		_, err := installation.NewSystemContext(-1, 0, now)
+		assert.ErrorIs(t, err, installation.ErrInvalidSystemContext)
+	})
+
+	t.Run("measured context requires measurement time", func(t *testing.T) {
+		_, err := installation.NewSystemContext(10*float64(installation.MB), 0, time.Time{})
+		assert.ErrorIs(t, err, installation.ErrInvalidSystemContext)
+	})
+
+	t.Run("unmeasured context", func(t *testing.T) {
+		ctx, err := installation.NewSystemContext(0, 0, time.Time{})
+		require.NoError(t, err)
+
+		assert.False(t, ctx.IsMeasured())
+		assert.Equal(t, time.Duration(0), ctx.EstimatePackageTime(100*installation.MB))
+		assert.Equal(t, "throughput not measured", ctx.String())
+	})
+}
+
+func TestSystemContext_EstimatePackageTime(t *testing.T) {
+	ctx, err := installation.NewSystemContext(30*float64(installation.MB), 10*float64(installation.MB), time.Now())
+	require.NoError(t, err)
+
+	// 100MB download at 10MB/s plus 300MB unpacked at 30MB/s
+	assert.Equal(t, 20*time.Second, ctx.EstimatePackageTime(100*installation.MB))
+}
+
+func TestInstallationConfiguration_EstimatedDownloadBytes(t *testing.T) {
+	pkg, err := installation.NewPackageInfo("hyprland", "0.45.0", 50*installation.MB, nil)
+	require.NoError(t, err)
+	withInfo, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.45.0", &pkg)
+	require.NoError(t, err)
+	withoutInfo, err := installation.NewComponentSelection(installation.ComponentWaybar, "0.10.0", nil)
+	require.NoError(t, err)
+	diskSpace, err := installation.NewDiskSpace(100*installation.GB, 10*installation.GB)
+	require.NoError(t, err)
+
+	config, err := installation.NewInstallationConfiguration(
+		[]installation.ComponentSelection{withInfo, withoutInfo}, nil, diskSpace, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(50*installation.MB+installation.DefaultComponentDownloadBytes), config.EstimatedDownloadBytes())
+}
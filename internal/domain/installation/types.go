@@ -16,6 +16,7 @@ const (
 	StatusFailed      InstallationStatus = "failed"       // Failed with error
 	StatusRollingBack InstallationStatus = "rolling_back" // Restoring previous state
 	StatusRolledBack  InstallationStatus = "rolled_back"  // Rollback completed
+	StatusCancelled   InstallationStatus = "cancelled"    // Stopped by the user, can be resumed
 )
 
 // InstallationPhase represents distinct steps in the installation process
@@ -48,6 +49,8 @@ const (
 	ComponentKitty         ComponentName = "kitty"           // Terminal emulator
 	ComponentMako          ComponentName = "mako"            // Notification daemon
 	ComponentSwaybg        ComponentName = "swaybg"          // Wallpaper daemon
+	ComponentPowerProfiles ComponentName = "power_profiles"  // Laptop power management
+	ComponentNightLight    ComponentName = "night_light"     // Screen color temperature
 	ComponentDefaultConfig ComponentName = "default_config"  // Default configuration files
 	ComponentAMDDriver     ComponentName = "amd_driver"      // AMD GPU drivers
 	ComponentNVIDIADriver  ComponentName = "nvidia_driver"   // NVIDIA GPU drivers
@@ -128,6 +131,7 @@ func (s InstallationStatus) CanTransitionTo(newStatus InstallationStatus) bool {
 		StatusConfiguring: {StatusVerifying},
 		StatusVerifying:   {StatusCompleted},
 		StatusRollingBack: {StatusRolledBack},
+		StatusCancelled:   {StatusPending},
 	}
 
 	allowed, exists := validTransitions[s]
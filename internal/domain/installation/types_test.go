@@ -380,6 +380,13 @@ func TestInstallationStatus_CanTransitionTo(t *testing.T) {
 			want:      false,
 			rationale: "Cannot transition from terminal state",
 		},
+		{
+			name:      "Cancelled to Pending",
+			from:      StatusCancelled,
+			to:        StatusPending,
+			want:      true,
+			rationale: "Cancelled sessions can be resumed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,110 @@
+package installation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// WeatherLocationSource records where a weather location came from
+type WeatherLocationSource string
+
+const (
+	WeatherLocationConfigured WeatherLocationSource = "configured" // City set in the configuration
+	WeatherLocationTimezone   WeatherLocationSource = "timezone"   // Derived from the system timezone
+)
+
+// maxWeatherCityLength bounds configured city names
+const maxWeatherCityLength = 100
+
+// WeatherLocation is the approximate location the Waybar weather module
+// reports on. The zero value disables the module.
+type WeatherLocation struct {
+	city   string
+	source WeatherLocationSource
+}
+
+// NewWeatherLocation creates a location for a city name. Names are limited
+// to letters, digits, spaces and - , . so they can be embedded in the module
+// command and its JSON unescaped.
+func NewWeatherLocation(city string, source WeatherLocationSource) (WeatherLocation, error) {
+	city = strings.Join(strings.Fields(city), " ")
+	if city == "" {
+		return WeatherLocation{}, fmt.Errorf("%w: city is empty", ErrInvalidWeatherLocation)
+	}
+	if len(city) > maxWeatherCityLength {
+		return WeatherLocation{}, fmt.Errorf("%w: city is longer than %d characters", ErrInvalidWeatherLocation, maxWeatherCityLength)
+	}
+	for _, r := range city {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" -,.", r) {
+			return WeatherLocation{}, fmt.Errorf("%w: %q contains %q", ErrInvalidWeatherLocation, city, r)
+		}
+	}
+
+	switch source {
+	case WeatherLocationConfigured, WeatherLocationTimezone:
+	default:
+		return WeatherLocation{}, fmt.Errorf("%w: unknown source %q", ErrInvalidWeatherLocation, source)
+	}
+
+	return WeatherLocation{city: city, source: source}, nil
+}
+
+// WeatherLocationFromTimezone derives a location from an IANA timezone
+// name, such as Berlin from Europe/Berlin. Timezones not named after a
+// city, such as UTC or Etc/GMT+2, have no location.
+func WeatherLocationFromTimezone(timezone string) (WeatherLocation, error) {
+	parts := strings.Split(strings.TrimSpace(timezone), "/")
+	if len(parts) < 2 || parts[0] == "Etc" {
+		return WeatherLocation{}, fmt.Errorf("%w: timezone %q does not name a city", ErrInvalidWeatherLocation, timezone)
+	}
+	return NewWeatherLocation(strings.ReplaceAll(parts[len(parts)-1], "_", " "), WeatherLocationTimezone)
+}
+
+// City returns the city name
+func (l WeatherLocation) City() string {
+	return l.city
+}
+
+// Source returns where the location came from
+func (l WeatherLocation) Source() WeatherLocationSource {
+	return l.source
+}
+
+// IsEmpty reports whether no location is set
+func (l WeatherLocation) IsEmpty() bool {
+	return l.city == ""
+}
+
+// String returns the city and where it came from
+func (l WeatherLocation) String() string {
+	if l.IsEmpty() {
+		return "none"
+	}
+	return fmt.Sprintf("%s (%s)", l.city, l.source)
+}
+
+// TemplateVars returns the Waybar weather module for the location. It
+// queries wttr.in, which needs no API key; without a location the module
+// renders empty.
+func (l WeatherLocation) TemplateVars() map[string]string {
+	if l.IsEmpty() {
+		return map[string]string{
+			"waybar_weather_module": "",
+			"waybar_weather_config": "",
+		}
+	}
+
+	forecast := "https://wttr.in/" + url.PathEscape(l.city)
+	return map[string]string{
+		"waybar_weather_module": `"custom/weather",`,
+		"waybar_weather_config": `"custom/weather": {
+    "exec": "curl -sf --max-time 10 '` + forecast + `?format=%c+%t'",
+    "interval": 1800,
+    "format": "{}",
+    "tooltip-format": "Weather in ` + l.city + `",
+    "on-click": "xdg-open '` + forecast + `'"
+  },`,
+	}
+}
@@ -0,0 +1,68 @@
+package installation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWeatherLocation(t *testing.T) {
+	t.Run("normalizes spacing", func(t *testing.T) {
+		location, err := installation.NewWeatherLocation("  New   York ", installation.WeatherLocationConfigured)
+
+		require.NoError(t, err)
+		assert.Equal(t, "New York", location.City())
+		assert.Equal(t, "New York (configured)", location.String())
+	})
+
+	t.Run("rejects names that would need escaping", func(t *testing.T) {
+		for _, city := range []string{"", "Berlin'; rm -rf ~", `Berlin"`, "Berlin\\", strings.Repeat("a", 101)} {
+			_, err := installation.NewWeatherLocation(city, installation.WeatherLocationConfigured)
+			assert.ErrorIs(t, err, installation.ErrInvalidWeatherLocation, city)
+		}
+	})
+
+	t.Run("rejects an unknown source", func(t *testing.T) {
+		_, err := installation.NewWeatherLocation("Berlin", "geoip")
+		assert.ErrorIs(t, err, installation.ErrInvalidWeatherLocation)
+	})
+}
+
+func TestWeatherLocationFromTimezone(t *testing.T) {
+	location, err := installation.WeatherLocationFromTimezone("America/Indiana/Indianapolis")
+	require.NoError(t, err)
+	assert.Equal(t, "Indianapolis", location.City())
+	assert.Equal(t, installation.WeatherLocationTimezone, location.Source())
+
+	location, err = installation.WeatherLocationFromTimezone("America/Los_Angeles")
+	require.NoError(t, err)
+	assert.Equal(t, "Los Angeles", location.City())
+
+	for _, timezone := range []string{"UTC", "Etc/GMT+2", ""} {
+		_, err := installation.WeatherLocationFromTimezone(timezone)
+		assert.ErrorIs(t, err, installation.ErrInvalidWeatherLocation, timezone)
+	}
+}
+
+func TestWeatherLocation_TemplateVars(t *testing.T) {
+	t.Run("renders empty without a location", func(t *testing.T) {
+		var location installation.WeatherLocation
+		vars := location.TemplateVars()
+
+		assert.True(t, location.IsEmpty())
+		assert.Empty(t, vars["waybar_weather_module"])
+		assert.Empty(t, vars["waybar_weather_config"])
+	})
+
+	t.Run("queries wttr.in for the city", func(t *testing.T) {
+		location, err := installation.NewWeatherLocation("Buenos Aires", installation.WeatherLocationTimezone)
+		require.NoError(t, err)
+		vars := location.TemplateVars()
+
+		assert.Equal(t, `"custom/weather",`, vars["waybar_weather_module"])
+		assert.Contains(t, vars["waybar_weather_config"], "https://wttr.in/Buenos%20Aires?format=%c+%t")
+	})
+}
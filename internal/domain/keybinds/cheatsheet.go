@@ -0,0 +1,372 @@
+package keybinds
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrNoBindings is returned for a configuration without key bindings
+	ErrNoBindings = errors.New("no key bindings found")
+
+	// ErrUnknownFormat is returned for a cheat sheet format gohan cannot
+	// render
+	ErrUnknownFormat = errors.New("unknown cheat sheet format")
+)
+
+// Format is a way of rendering the cheat sheet
+type Format string
+
+const (
+	// FormatText renders aligned columns for a terminal
+	FormatText Format = "text"
+
+	// FormatMarkdown renders a table per section
+	FormatMarkdown Format = "markdown"
+
+	// FormatHTML renders a standalone page to open in a browser
+	FormatHTML Format = "html"
+)
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case FormatText, "":
+		return FormatText, nil
+	case FormatMarkdown, "md":
+		return FormatMarkdown, nil
+	case FormatHTML:
+		return FormatHTML, nil
+	}
+	return "", fmt.Errorf("%w: %q (use text, markdown or html)", ErrUnknownFormat, name)
+}
+
+// Entry is one row of the cheat sheet: keys, what they are for and the
+// command they run
+type Entry struct {
+	keys        string
+	description string
+	command     string
+}
+
+// Keys returns the keys to press, such as SUPER+SHIFT+Return
+func (e Entry) Keys() string {
+	return e.keys
+}
+
+// Description returns what the keys are for, taken from the comment above
+// the binding; it may be empty
+func (e Entry) Description() string {
+	return e.description
+}
+
+// Command returns the dispatcher and arguments the keys run
+func (e Entry) Command() string {
+	return e.command
+}
+
+// Section groups entries under a heading of the configuration
+type Section struct {
+	title   string
+	entries []Entry
+}
+
+// Title returns the section heading
+func (s Section) Title() string {
+	return s.title
+}
+
+// Entries returns the section's rows in configuration order
+func (s Section) Entries() []Entry {
+	return append([]Entry(nil), s.entries...)
+}
+
+// CheatSheet lists the key bindings of a Hyprland configuration
+type CheatSheet struct {
+	sections []Section
+}
+
+// Sections returns the sections in configuration order
+func (c *CheatSheet) Sections() []Section {
+	return append([]Section(nil), c.sections...)
+}
+
+// Len returns the number of entries across all sections
+func (c *CheatSheet) Len() int {
+	n := 0
+	for _, section := range c.sections {
+		n += len(section.entries)
+	}
+	return n
+}
+
+// defaultSection holds bindings above the first section heading
+const defaultSection = "General"
+
+// binding is one bind line with its variables expanded
+type binding struct {
+	mods        []string
+	key         string
+	dispatcher  string
+	args        string
+	description string
+}
+
+// Parse builds a cheat sheet from Hyprland configuration. Section headings
+// are the comments framed by rules of '=' characters, and the comment right
+// above a run of bindings describes them. Bindings of a run that differ
+// only in the key, such as SUPER+1 to SUPER+0, become one entry.
+func Parse(content string) (*CheatSheet, error) {
+	vars := make(map[string]string)
+	sheet := &CheatSheet{}
+
+	title := defaultSection
+	var comment string
+	var run []binding
+	afterRule := false
+
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		entries := collapse(run)
+		if n := len(sheet.sections); n > 0 && sheet.sections[n-1].title == title {
+			sheet.sections[n-1].entries = append(sheet.sections[n-1].entries, entries...)
+		} else {
+			sheet.sections = append(sheet.sections, Section{title: title, entries: entries})
+		}
+		run = nil
+	}
+
+	for _, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+
+		switch {
+		case line == "":
+			flush()
+			comment = ""
+			afterRule = false
+
+		case strings.HasPrefix(line, "#"):
+			text := strings.TrimSpace(strings.TrimLeft(line, "#"))
+			if text != "" && strings.Trim(text, "=-") == "" {
+				afterRule = true
+				continue
+			}
+			flush()
+			if afterRule && text != "" {
+				title = sectionTitle(text)
+				comment = ""
+				afterRule = false
+				continue
+			}
+			comment = text
+
+		case strings.HasPrefix(line, "$"):
+			name, value, ok := strings.Cut(line[1:], "=")
+			if ok {
+				vars[strings.TrimSpace(name)] = strings.TrimSpace(expand(value, vars))
+			}
+
+		default:
+			afterRule = false
+			b, ok := parseBinding(expand(line, vars))
+			if !ok {
+				continue
+			}
+			if b.description == "" {
+				b.description = comment
+			}
+			run = append(run, b)
+		}
+	}
+	flush()
+
+	if len(sheet.sections) == 0 {
+		return nil, ErrNoBindings
+	}
+	return sheet, nil
+}
+
+// parseBinding reads a bind line such as "bind = SUPER, Q, killactive" and
+// its variants with flags (binde, bindm, ...). bindd lines carry their own
+// description before the dispatcher.
+func parseBinding(line string) (binding, bool) {
+	keyword, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return binding{}, false
+	}
+	keyword = strings.TrimSpace(keyword)
+	if !strings.HasPrefix(keyword, "bind") || strings.Trim(keyword[4:], "lrenmtisdpoc") != "" {
+		return binding{}, false
+	}
+	// Trailing comments are not part of the command
+	if i := strings.Index(value, " #"); i >= 0 && !strings.Contains(value[i:], "##") {
+		value = value[:i]
+	}
+
+	fields := 4
+	hasDescription := strings.Contains(keyword[4:], "d")
+	if hasDescription {
+		fields = 5
+	}
+	parts := strings.SplitN(value, ",", fields)
+	if len(parts) < fields-1 {
+		return binding{}, false
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	b := binding{
+		mods: modifiers(parts[0]),
+		key:  keyName(parts[1]),
+	}
+	rest := parts[2:]
+	if hasDescription {
+		b.description = rest[0]
+		rest = rest[1:]
+	}
+	b.dispatcher = rest[0]
+	if len(rest) > 1 {
+		b.args = rest[1]
+	}
+	if b.key == "" || b.dispatcher == "" {
+		return binding{}, false
+	}
+	return b, true
+}
+
+// collapse turns a run of bindings into entries, merging bindings that
+// differ only in the key and bindings of the same keys
+func collapse(run []binding) []Entry {
+	var entries []Entry
+	for i := 0; i < len(run); {
+		b := run[i]
+
+		// One key running several dispatchers, like ALT+TAB cycling and
+		// raising the window
+		j := i + 1
+		command := b.command()
+		for ; j < len(run) && sameMods(run[j], b) && run[j].key == b.key; j++ {
+			command += "; " + run[j].command()
+		}
+		if j > i+1 {
+			entries = append(entries, Entry{keys: b.keys(b.key), description: b.description, command: command})
+			i = j
+			continue
+		}
+
+		// Keys doing the same thing, like SUPER+1 to SUPER+0. exec runs
+		// something different per key unless the command is the same.
+		j = i + 1
+		sameArgs := true
+		for ; j < len(run) && sameMods(run[j], b) && run[j].dispatcher == b.dispatcher &&
+			run[j].description == b.description; j++ {
+			if run[j].args != b.args {
+				sameArgs = false
+			}
+		}
+		if j > i+1 && (sameArgs || b.dispatcher != "exec") {
+			keys := make([]string, 0, j-i)
+			for _, other := range run[i:j] {
+				keys = append(keys, other.key)
+			}
+			command = b.dispatcher
+			if sameArgs {
+				command = b.command()
+			}
+			entries = append(entries, Entry{keys: b.keys(keyRange(keys)), description: b.description, command: command})
+			i = j
+			continue
+		}
+
+		entries = append(entries, Entry{keys: b.keys(b.key), description: b.description, command: command})
+		i++
+	}
+	return entries
+}
+
+func (b binding) keys(key string) string {
+	return strings.Join(append(append([]string(nil), b.mods...), key), "+")
+}
+
+func (b binding) command() string {
+	if b.args == "" {
+		return b.dispatcher
+	}
+	return b.dispatcher + " " + b.args
+}
+
+func sameMods(a, b binding) bool {
+	return strings.Join(a.mods, "+") == strings.Join(b.mods, "+")
+}
+
+// keyRange writes a few keys as A/B/C and a longer series as first…last
+func keyRange(keys []string) string {
+	if len(keys) <= 4 {
+		return strings.Join(keys, "/")
+	}
+	return keys[0] + "…" + keys[len(keys)-1]
+}
+
+// modifiers normalizes "SUPER SHIFT", "SUPER_SHIFT" and "super&shift"
+func modifiers(value string) []string {
+	fields := strings.FieldsFunc(strings.ToUpper(value), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '&'
+	})
+	for i, mod := range fields {
+		if mod == "CONTROL" {
+			fields[i] = "CTRL"
+		}
+	}
+	return fields
+}
+
+// mouseKeys names the mouse buttons and wheel directions binds use
+var mouseKeys = map[string]string{
+	"mouse:272":  "Left click",
+	"mouse:273":  "Right click",
+	"mouse:274":  "Middle click",
+	"mouse_down": "Scroll down",
+	"mouse_up":   "Scroll up",
+}
+
+func keyName(key string) string {
+	if name, ok := mouseKeys[strings.ToLower(key)]; ok {
+		return name
+	}
+	if len(key) == 1 {
+		return strings.ToUpper(key)
+	}
+	return key
+}
+
+// sectionTitle turns a heading like "APPLICATION LAUNCHERS" into
+// "Application launchers"
+func sectionTitle(text string) string {
+	if strings.ToUpper(text) != text {
+		return text
+	}
+	lower := strings.ToLower(text)
+	return strings.ToUpper(lower[:1]) + lower[1:]
+}
+
+// expand replaces $variables, longest names first so $mainMod is not
+// mistaken for $main
+func expand(line string, vars map[string]string) string {
+	if !strings.Contains(line, "$") || len(vars) == 0 {
+		return line
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	for _, name := range names {
+		line = strings.ReplaceAll(line, "$"+name, vars[name])
+	}
+	return line
+}
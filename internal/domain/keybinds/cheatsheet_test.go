@@ -0,0 +1,143 @@
+package keybinds_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/keybinds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const config = `# Keybindings configuration
+
+$mainMod = SUPER
+$term = kitty
+
+# ============================================================================
+# APPLICATION LAUNCHERS
+# ============================================================================
+
+# Terminal
+bind = $mainMod, Return, exec, $term
+
+# Window switcher
+bind = ALT, TAB, cyclenext
+bind = ALT, TAB, bringactivetotop
+
+# ============================================================================
+# WORKSPACE MANAGEMENT
+# ============================================================================
+
+# Switch workspaces
+bind = $mainMod, 1, workspace, 1
+bind = $mainMod, 2, workspace, 2
+bind = $mainMod, 3, workspace, 3
+bind = $mainMod, 4, workspace, 4
+bind = $mainMod, 0, workspace, 10
+
+# Move windows with the mouse
+bindm = $mainMod, mouse:272, movewindow
+
+# Power profiles
+bind = $mainMod CTRL, 1, exec, powerprofilesctl set power-saver
+bind = $mainMod CTRL, 2, exec, powerprofilesctl set balanced
+bindd = $mainMod, q, Close the window, killactive
+`
+
+func entries(t *testing.T, sheet *keybinds.CheatSheet) map[string]keybinds.Entry {
+	t.Helper()
+	byKeys := make(map[string]keybinds.Entry)
+	for _, section := range sheet.Sections() {
+		for _, entry := range section.Entries() {
+			byKeys[entry.Keys()] = entry
+		}
+	}
+	return byKeys
+}
+
+func TestParse(t *testing.T) {
+	sheet, err := keybinds.Parse(config)
+	require.NoError(t, err)
+
+	t.Run("groups bindings under section headings", func(t *testing.T) {
+		sections := sheet.Sections()
+		require.Len(t, sections, 2)
+		assert.Equal(t, "Application launchers", sections[0].Title())
+		assert.Equal(t, "Workspace management", sections[1].Title())
+	})
+
+	t.Run("expands variables and describes bindings by their comment", func(t *testing.T) {
+		entry := entries(t, sheet)["SUPER+Return"]
+		assert.Equal(t, "Terminal", entry.Description())
+		assert.Equal(t, "exec kitty", entry.Command())
+	})
+
+	t.Run("merges dispatchers bound to the same keys", func(t *testing.T) {
+		assert.Equal(t, "cyclenext; bringactivetotop", entries(t, sheet)["ALT+TAB"].Command())
+	})
+
+	t.Run("collapses keys doing the same thing", func(t *testing.T) {
+		entry, ok := entries(t, sheet)["SUPER+1…0"]
+		require.True(t, ok)
+		assert.Equal(t, "Switch workspaces", entry.Description())
+		assert.Equal(t, "workspace", entry.Command())
+	})
+
+	t.Run("keeps exec bindings running different commands apart", func(t *testing.T) {
+		byKeys := entries(t, sheet)
+		assert.Equal(t, "exec powerprofilesctl set power-saver", byKeys["SUPER+CTRL+1"].Command())
+		assert.Equal(t, "exec powerprofilesctl set balanced", byKeys["SUPER+CTRL+2"].Command())
+	})
+
+	t.Run("names mouse buttons", func(t *testing.T) {
+		assert.Contains(t, entries(t, sheet), "SUPER+Left click")
+	})
+
+	t.Run("reads descriptions of bindd lines", func(t *testing.T) {
+		entry := entries(t, sheet)["SUPER+Q"]
+		assert.Equal(t, "Close the window", entry.Description())
+		assert.Equal(t, "killactive", entry.Command())
+	})
+
+	t.Run("counts entries", func(t *testing.T) {
+		assert.Equal(t, 7, sheet.Len())
+	})
+}
+
+func TestParse_NoBindings(t *testing.T) {
+	_, err := keybinds.Parse("$mainMod = SUPER\n# nothing bound\n")
+	assert.ErrorIs(t, err, keybinds.ErrNoBindings)
+}
+
+func TestParse_BindingsWithoutSection(t *testing.T) {
+	sheet, err := keybinds.Parse("bind = SUPER_SHIFT, e, exit\n")
+	require.NoError(t, err)
+
+	sections := sheet.Sections()
+	require.Len(t, sections, 1)
+	assert.Equal(t, "General", sections[0].Title())
+	entry := sections[0].Entries()[0]
+	assert.Equal(t, "SUPER+SHIFT+E", entry.Keys())
+	assert.Empty(t, entry.Description())
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		want keybinds.Format
+	}{
+		{"", keybinds.FormatText},
+		{"text", keybinds.FormatText},
+		{"md", keybinds.FormatMarkdown},
+		{"Markdown", keybinds.FormatMarkdown},
+		{"html", keybinds.FormatHTML},
+	}
+	for _, tt := range tests {
+		got, err := keybinds.ParseFormat(tt.name)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, got, tt.name)
+	}
+
+	_, err := keybinds.ParseFormat("png")
+	assert.ErrorIs(t, err, keybinds.ErrUnknownFormat)
+}
@@ -0,0 +1,124 @@
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownTask is returned for a task name gohan does not schedule
+var ErrUnknownTask = errors.New("unknown maintenance task")
+
+// Task is a recurring hygiene job run by a generated systemd timer
+type Task string
+
+const (
+	// TaskBackupPrune removes configuration backups past their retention
+	TaskBackupPrune Task = "backup-prune"
+
+	// TaskHistoryPurge removes installation history past its retention
+	TaskHistoryPurge Task = "history-purge"
+
+	// TaskHealthCheck runs the gohan doctor checks
+	TaskHealthCheck Task = "health-check"
+
+	// TaskPinRefresh re-downloads managed signing keys and verifies them
+	// against their pinned fingerprints
+	TaskPinRefresh Task = "pin-refresh"
+)
+
+// AllTasks returns every maintenance task in a stable order
+func AllTasks() []Task {
+	return []Task{TaskBackupPrune, TaskHistoryPurge, TaskHealthCheck, TaskPinRefresh}
+}
+
+// ParseTask converts a task name to a Task
+func ParseTask(name string) (Task, error) {
+	for _, task := range AllTasks() {
+		if string(task) == name {
+			return task, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownTask, name)
+}
+
+// String returns the task name
+func (t Task) String() string {
+	return string(t)
+}
+
+// Description returns a one-line summary used in the generated units
+func (t Task) Description() string {
+	switch t {
+	case TaskBackupPrune:
+		return "Prune old gohan configuration backups"
+	case TaskHistoryPurge:
+		return "Purge expired gohan installation history"
+	case TaskHealthCheck:
+		return "Run gohan health checks"
+	case TaskPinRefresh:
+		return "Refresh gohan-managed repository signing keys"
+	default:
+		return fmt.Sprintf("gohan maintenance: %s", string(t))
+	}
+}
+
+// Schedule returns the systemd OnCalendar expression for the task.
+// Frequent tasks are cheap; key refreshes hit the network, so run weekly.
+func (t Task) Schedule() string {
+	switch t {
+	case TaskHealthCheck:
+		return "daily"
+	case TaskHistoryPurge:
+		return "monthly"
+	default:
+		return "weekly"
+	}
+}
+
+// Scopes returns where the task can run. Backups and health checks cover
+// the user's configuration; signing keys live under /usr/share/keyrings and
+// need root; history is kept per user and system-wide.
+func (t Task) Scopes() []Scope {
+	switch t {
+	case TaskBackupPrune, TaskHealthCheck:
+		return []Scope{ScopeUser}
+	case TaskPinRefresh:
+		return []Scope{ScopeSystem}
+	default:
+		return []Scope{ScopeUser, ScopeSystem}
+	}
+}
+
+// RunsIn returns true if the task can be scheduled in the scope
+func (t Task) RunsIn(scope Scope) bool {
+	for _, s := range t.Scopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// TasksForScope returns the tasks scheduled in a scope
+func TasksForScope(scope Scope) []Task {
+	var tasks []Task
+	for _, task := range AllTasks() {
+		if task.RunsIn(scope) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// Scope selects between the user and system systemd instances
+type Scope string
+
+const (
+	ScopeUser   Scope = "user"
+	ScopeSystem Scope = "system"
+)
+
+// IsSystem returns true for the system instance
+func (s Scope) IsSystem() bool {
+	return s == ScopeSystem
+}
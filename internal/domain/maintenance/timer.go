@@ -0,0 +1,83 @@
+package maintenance
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unitPrefix namespaces the generated units
+const unitPrefix = "gohan-"
+
+// TimerUnit is the service and timer pair that runs a task on schedule
+type TimerUnit struct {
+	task       Task
+	scope      Scope
+	executable string
+}
+
+// NewTimerUnit creates the units for a task. executable is the absolute
+// path of the gohan binary the service runs.
+func NewTimerUnit(task Task, scope Scope, executable string) (TimerUnit, error) {
+	if !task.RunsIn(scope) {
+		return TimerUnit{}, fmt.Errorf("%s cannot run as a %s timer", task, scope)
+	}
+	if !strings.HasPrefix(executable, "/") {
+		return TimerUnit{}, fmt.Errorf("executable must be an absolute path: %q", executable)
+	}
+	return TimerUnit{task: task, scope: scope, executable: executable}, nil
+}
+
+// Task returns the scheduled task
+func (u TimerUnit) Task() Task {
+	return u.task
+}
+
+// Scope returns the systemd instance the units belong to
+func (u TimerUnit) Scope() Scope {
+	return u.scope
+}
+
+// ServiceName returns the service unit file name
+func (u TimerUnit) ServiceName() string {
+	return unitPrefix + string(u.task) + ".service"
+}
+
+// TimerName returns the timer unit file name
+func (u TimerUnit) TimerName() string {
+	return unitPrefix + string(u.task) + ".timer"
+}
+
+// RenderService returns the oneshot service running the task
+func (u TimerUnit) RenderService() string {
+	var b strings.Builder
+	b.WriteString("# Managed by gohan\n")
+	b.WriteString("[Unit]\n")
+	fmt.Fprintf(&b, "Description=%s\n", u.task.Description())
+	if u.task == TaskPinRefresh {
+		b.WriteString("Wants=network-online.target\n")
+		b.WriteString("After=network-online.target\n")
+	}
+	b.WriteString("\n[Service]\n")
+	b.WriteString("Type=oneshot\n")
+	fmt.Fprintf(&b, "ExecStart=%s maintenance run %s\n", u.executable, u.task)
+	b.WriteString("Nice=10\n")
+	b.WriteString("IOSchedulingClass=idle\n")
+	return b.String()
+}
+
+// RenderTimer returns the timer scheduling the service. Persistent catches
+// up on runs missed while the machine was off; the randomized delay keeps
+// the timers from all firing at once.
+func (u TimerUnit) RenderTimer() string {
+	var b strings.Builder
+	b.WriteString("# Managed by gohan\n")
+	b.WriteString("[Unit]\n")
+	fmt.Fprintf(&b, "Description=%s (%s)\n", u.task.Description(), u.task.Schedule())
+	b.WriteString("\n[Timer]\n")
+	fmt.Fprintf(&b, "OnCalendar=%s\n", u.task.Schedule())
+	b.WriteString("Persistent=true\n")
+	b.WriteString("RandomizedDelaySec=1h\n")
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=timers.target\n")
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package maintenance_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/maintenance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTask(t *testing.T) {
+	task, err := maintenance.ParseTask("history-purge")
+	require.NoError(t, err)
+	assert.Equal(t, maintenance.TaskHistoryPurge, task)
+
+	_, err = maintenance.ParseTask("defrag")
+	assert.ErrorIs(t, err, maintenance.ErrUnknownTask)
+}
+
+func TestTasksForScope(t *testing.T) {
+	assert.Equal(t, []maintenance.Task{
+		maintenance.TaskBackupPrune,
+		maintenance.TaskHistoryPurge,
+		maintenance.TaskHealthCheck,
+	}, maintenance.TasksForScope(maintenance.ScopeUser))
+
+	assert.Equal(t, []maintenance.Task{
+		maintenance.TaskHistoryPurge,
+		maintenance.TaskPinRefresh,
+	}, maintenance.TasksForScope(maintenance.ScopeSystem))
+}
+
+func TestNewTimerUnit(t *testing.T) {
+	t.Run("rejects a task outside its scope", func(t *testing.T) {
+		_, err := maintenance.NewTimerUnit(maintenance.TaskPinRefresh, maintenance.ScopeUser, "/usr/bin/gohan")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects a relative executable", func(t *testing.T) {
+		_, err := maintenance.NewTimerUnit(maintenance.TaskHealthCheck, maintenance.ScopeUser, "gohan")
+		assert.Error(t, err)
+	})
+}
+
+func TestTimerUnit_Render(t *testing.T) {
+	unit, err := maintenance.NewTimerUnit(maintenance.TaskBackupPrune, maintenance.ScopeUser, "/usr/local/bin/gohan")
+	require.NoError(t, err)
+
+	assert.Equal(t, "gohan-backup-prune.service", unit.ServiceName())
+	assert.Equal(t, "gohan-backup-prune.timer", unit.TimerName())
+
+	service := unit.RenderService()
+	assert.Contains(t, service, "Type=oneshot\n")
+	assert.Contains(t, service, "ExecStart=/usr/local/bin/gohan maintenance run backup-prune\n")
+	assert.NotContains(t, service, "network-online.target")
+
+	timer := unit.RenderTimer()
+	assert.Contains(t, timer, "OnCalendar=weekly\n")
+	assert.Contains(t, timer, "Persistent=true\n")
+	assert.Contains(t, timer, "WantedBy=timers.target\n")
+}
+
+func TestTimerUnit_PinRefreshWaitsForNetwork(t *testing.T) {
+	unit, err := maintenance.NewTimerUnit(maintenance.TaskPinRefresh, maintenance.ScopeSystem, "/usr/bin/gohan")
+	require.NoError(t, err)
+
+	assert.Contains(t, unit.RenderService(), "After=network-online.target\n")
+}
@@ -0,0 +1,56 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownDesktop is returned for a desktop settings cannot be
+	// imported from
+	ErrUnknownDesktop = errors.New("unknown desktop")
+
+	// ErrInvalidMonitor is returned for a monitor without a connector or a
+	// usable mode
+	ErrInvalidMonitor = errors.New("invalid monitor")
+
+	// ErrInvalidKeyboardLayout is returned for a keyboard layout without a
+	// name
+	ErrInvalidKeyboardLayout = errors.New("invalid keyboard layout")
+)
+
+// Desktop is a desktop environment settings are imported from
+type Desktop string
+
+const (
+	DesktopGNOME Desktop = "gnome"
+	DesktopKDE   Desktop = "kde"
+)
+
+// ParseDesktop parses a desktop name; plasma is accepted for KDE
+func ParseDesktop(name string) (Desktop, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "gnome":
+		return DesktopGNOME, nil
+	case "kde", "plasma":
+		return DesktopKDE, nil
+	}
+	return "", fmt.Errorf("%w: %q (supported desktops are gnome and kde)", ErrUnknownDesktop, name)
+}
+
+// String returns the desktop's name
+func (d Desktop) String() string {
+	return string(d)
+}
+
+// DisplayName returns the desktop's name as users know it
+func (d Desktop) DisplayName() string {
+	switch d {
+	case DesktopGNOME:
+		return "GNOME"
+	case DesktopKDE:
+		return "KDE Plasma"
+	}
+	return string(d)
+}
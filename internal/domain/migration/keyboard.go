@@ -0,0 +1,116 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyboardLayout is an XKB layout with an optional variant, such as de
+// with the nodeadkeys variant
+type KeyboardLayout struct {
+	layout  string
+	variant string
+}
+
+// NewKeyboardLayout creates a keyboard layout
+func NewKeyboardLayout(layout, variant string) (KeyboardLayout, error) {
+	layout = strings.TrimSpace(layout)
+	variant = strings.TrimSpace(variant)
+	if layout == "" {
+		return KeyboardLayout{}, fmt.Errorf("%w: layout is required", ErrInvalidKeyboardLayout)
+	}
+	if strings.ContainsAny(layout+variant, ", ") {
+		return KeyboardLayout{}, fmt.Errorf("%w: %q", ErrInvalidKeyboardLayout, layout)
+	}
+	return KeyboardLayout{layout: layout, variant: variant}, nil
+}
+
+// ParseKeyboardLayout parses a layout written the way GNOME stores it,
+// with the variant after a plus, like de+nodeadkeys
+func ParseKeyboardLayout(source string) (KeyboardLayout, error) {
+	layout, variant, _ := strings.Cut(source, "+")
+	return NewKeyboardLayout(layout, variant)
+}
+
+// Layout returns the XKB layout
+func (k KeyboardLayout) Layout() string {
+	return k.layout
+}
+
+// Variant returns the XKB variant, empty for the layout's default
+func (k KeyboardLayout) Variant() string {
+	return k.variant
+}
+
+// String returns the layout as layout+variant
+func (k KeyboardLayout) String() string {
+	if k.variant == "" {
+		return k.layout
+	}
+	return k.layout + "+" + k.variant
+}
+
+// Keyboard is the keyboard layouts to switch between, the first being the
+// default, and the XKB options such as caps:escape
+type Keyboard struct {
+	layouts []KeyboardLayout
+	options []string
+}
+
+// NewKeyboard creates a keyboard configuration. Blank and repeated options
+// are dropped.
+func NewKeyboard(layouts []KeyboardLayout, options []string) Keyboard {
+	keyboard := Keyboard{layouts: append([]KeyboardLayout(nil), layouts...)}
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[option] {
+			continue
+		}
+		seen[option] = true
+		keyboard.options = append(keyboard.options, option)
+	}
+	return keyboard
+}
+
+// Layouts returns the layouts, the default first
+func (k Keyboard) Layouts() []KeyboardLayout {
+	return append([]KeyboardLayout(nil), k.layouts...)
+}
+
+// Options returns the XKB options
+func (k Keyboard) Options() []string {
+	return append([]string(nil), k.options...)
+}
+
+// IsEmpty returns true if neither layouts nor options are set
+func (k Keyboard) IsEmpty() bool {
+	return len(k.layouts) == 0 && len(k.options) == 0
+}
+
+// TemplateVars returns the kb_layout, kb_variant and kb_options values
+// Hyprland's input section takes: comma-separated lists, with the variants
+// lined up with their layouts
+func (k Keyboard) TemplateVars() map[string]string {
+	layouts := make([]string, 0, len(k.layouts))
+	variants := make([]string, 0, len(k.layouts))
+	hasVariant := false
+	for _, layout := range k.layouts {
+		layouts = append(layouts, layout.layout)
+		variants = append(variants, layout.variant)
+		hasVariant = hasVariant || layout.variant != ""
+	}
+
+	vars := map[string]string{
+		"kb_layout":  "us",
+		"kb_variant": "",
+		"kb_options": strings.Join(k.options, ","),
+	}
+	if len(layouts) > 0 {
+		vars["kb_layout"] = strings.Join(layouts, ",")
+	}
+	if hasVariant {
+		vars["kb_variant"] = strings.Join(variants, ",")
+	}
+	return vars
+}
@@ -0,0 +1,108 @@
+package migration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Transform is how a monitor's output is rotated and flipped, numbered as
+// Hyprland's transform option numbers it
+type Transform int
+
+const (
+	TransformNormal Transform = iota
+	Transform90
+	Transform180
+	Transform270
+	TransformFlipped
+	TransformFlipped90
+	TransformFlipped180
+	TransformFlipped270
+)
+
+// Monitor is where and how one monitor is laid out
+type Monitor struct {
+	connector string
+	width     int
+	height    int
+	refresh   float64
+	x         int
+	y         int
+	scale     float64
+	transform Transform
+	disabled  bool
+}
+
+// NewMonitor creates an enabled monitor for a connector such as DP-1. A
+// refresh rate of zero leaves the rate to Hyprland; a scale of zero means
+// 1.
+func NewMonitor(connector string, width, height int, refresh float64, x, y int, scale float64) (Monitor, error) {
+	connector = strings.TrimSpace(connector)
+	if connector == "" {
+		return Monitor{}, fmt.Errorf("%w: connector is required", ErrInvalidMonitor)
+	}
+	if width <= 0 || height <= 0 {
+		return Monitor{}, fmt.Errorf("%w: %s has no resolution", ErrInvalidMonitor, connector)
+	}
+	if refresh < 0 || scale < 0 {
+		return Monitor{}, fmt.Errorf("%w: %s has a negative refresh rate or scale", ErrInvalidMonitor, connector)
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	return Monitor{
+		connector: connector,
+		width:     width,
+		height:    height,
+		refresh:   refresh,
+		x:         x,
+		y:         y,
+		scale:     scale,
+	}, nil
+}
+
+// NewDisabledMonitor creates a monitor that is turned off
+func NewDisabledMonitor(connector string) (Monitor, error) {
+	connector = strings.TrimSpace(connector)
+	if connector == "" {
+		return Monitor{}, fmt.Errorf("%w: connector is required", ErrInvalidMonitor)
+	}
+	return Monitor{connector: connector, disabled: true}, nil
+}
+
+// WithTransform returns a copy of the monitor rotated or flipped
+func (m Monitor) WithTransform(transform Transform) Monitor {
+	if transform >= TransformNormal && transform <= TransformFlipped270 {
+		m.transform = transform
+	}
+	return m
+}
+
+// Connector returns the connector the monitor is plugged into
+func (m Monitor) Connector() string {
+	return m.connector
+}
+
+// IsDisabled returns true if the monitor is turned off
+func (m Monitor) IsDisabled() bool {
+	return m.disabled
+}
+
+// HyprlandLine returns the monitor as a Hyprland monitor line
+func (m Monitor) HyprlandLine() string {
+	if m.disabled {
+		return fmt.Sprintf("monitor = %s, disable", m.connector)
+	}
+
+	mode := fmt.Sprintf("%dx%d", m.width, m.height)
+	if m.refresh > 0 {
+		mode += "@" + strconv.FormatFloat(m.refresh, 'f', -1, 64)
+	}
+	line := fmt.Sprintf("monitor = %s, %s, %dx%d, %s",
+		m.connector, mode, m.x, m.y, strconv.FormatFloat(m.scale, 'f', -1, 64))
+	if m.transform != TransformNormal {
+		line += fmt.Sprintf(", transform, %d", m.transform)
+	}
+	return line
+}
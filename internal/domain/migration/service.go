@@ -0,0 +1,88 @@
+package migration
+
+// Service is a systemd unit a desktop runs that a Hyprland session does
+// not need
+type Service struct {
+	name   string
+	user   bool
+	reason string
+}
+
+// Name returns the unit's name
+func (s Service) Name() string {
+	return s.name
+}
+
+// IsUserService returns true for units run by the user's systemd instance
+func (s Service) IsUserService() bool {
+	return s.user
+}
+
+// Reason returns what the service does and why it can go
+func (s Service) Reason() string {
+	return s.reason
+}
+
+// DisableCommand returns the command that stops the service and keeps it
+// from starting again. Masking is used because these services are started
+// on demand over D-Bus, which disabling alone does not prevent.
+func (s Service) DisableCommand() string {
+	if s.user {
+		return "systemctl --user mask --now " + s.name
+	}
+	return "sudo systemctl mask --now " + s.name
+}
+
+var packageKit = Service{
+	name:   "packagekit.service",
+	reason: "Checks for updates in the background for the desktop's software center; apt and gohan update cover this",
+}
+
+var servicesSafeToDisable = map[Desktop][]Service{
+	DesktopGNOME: {
+		packageKit,
+		{
+			name:   "tracker-miner-fs-3.service",
+			user:   true,
+			reason: "Indexes files for the GNOME Shell search, which Hyprland does not have",
+		},
+		{
+			name:   "evolution-source-registry.service",
+			user:   true,
+			reason: "Keeps GNOME's online accounts for calendars and contacts; keep it if you use Evolution or GNOME Calendar",
+		},
+		{
+			name:   "evolution-calendar-factory.service",
+			user:   true,
+			reason: "Serves calendar events to the GNOME Shell clock; keep it if you use Evolution or GNOME Calendar",
+		},
+		{
+			name:   "evolution-addressbook-factory.service",
+			user:   true,
+			reason: "Serves contacts to the GNOME Shell search; keep it if you use Evolution or GNOME Contacts",
+		},
+		{
+			name:   "gnome-remote-desktop.service",
+			reason: "Shares GNOME sessions over RDP, which does not work with Hyprland",
+		},
+	},
+	DesktopKDE: {
+		packageKit,
+		{
+			name:   "kde-baloo.service",
+			user:   true,
+			reason: "Indexes files for KRunner and Dolphin search",
+		},
+		{
+			name:   "plasma-kactivitymanagerd.service",
+			user:   true,
+			reason: "Tracks Plasma activities and recent files, which Hyprland does not use",
+		},
+	},
+}
+
+// ServicesSafeToDisable returns the services the desktop runs that are
+// safe to disable once it is no longer used
+func ServicesSafeToDisable(desktop Desktop) []Service {
+	return append([]Service(nil), servicesSafeToDisable[desktop]...)
+}
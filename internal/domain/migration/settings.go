@@ -0,0 +1,140 @@
+package migration
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// Settings are what could be read from a desktop's configuration. Settings
+// that were not found are left unset, and notes explain why.
+type Settings struct {
+	desktop   Desktop
+	wallpaper string
+	monitors  []Monitor
+	keyboard  Keyboard
+	terminal  string
+	notes     []string
+}
+
+// NewSettings creates empty settings read from the desktop
+func NewSettings(desktop Desktop) Settings {
+	return Settings{desktop: desktop}
+}
+
+// WithWallpaper returns a copy of the settings with the wallpaper image
+func (s Settings) WithWallpaper(path string) Settings {
+	s.wallpaper = strings.TrimSpace(path)
+	return s
+}
+
+// WithMonitors returns a copy of the settings with the monitor layout
+func (s Settings) WithMonitors(monitors []Monitor) Settings {
+	s.monitors = append([]Monitor(nil), monitors...)
+	return s
+}
+
+// WithKeyboard returns a copy of the settings with the keyboard layouts
+func (s Settings) WithKeyboard(keyboard Keyboard) Settings {
+	s.keyboard = keyboard
+	return s
+}
+
+// WithTerminal returns a copy of the settings with the command of the
+// default terminal, such as gnome-terminal or alacritty
+func (s Settings) WithTerminal(command string) Settings {
+	s.terminal = strings.TrimSpace(command)
+	return s
+}
+
+// WithNote returns a copy of the settings with a note on something that
+// could not be imported
+func (s Settings) WithNote(note string) Settings {
+	s.notes = append(append([]string(nil), s.notes...), note)
+	return s
+}
+
+// Desktop returns the desktop the settings were read from
+func (s Settings) Desktop() Desktop {
+	return s.desktop
+}
+
+// Wallpaper returns the path of the wallpaper image, empty if unknown
+func (s Settings) Wallpaper() string {
+	return s.wallpaper
+}
+
+// Monitors returns the monitor layout
+func (s Settings) Monitors() []Monitor {
+	return append([]Monitor(nil), s.monitors...)
+}
+
+// Keyboard returns the keyboard layouts and options
+func (s Settings) Keyboard() Keyboard {
+	return s.keyboard
+}
+
+// Terminal returns the command of the default terminal, empty if unknown
+func (s Settings) Terminal() string {
+	return s.terminal
+}
+
+// Notes returns what could not be imported and why
+func (s Settings) Notes() []string {
+	return append([]string(nil), s.notes...)
+}
+
+// TerminalProvider returns the gohan terminal the default terminal maps
+// to. Terminals gohan does not install, such as gnome-terminal or konsole,
+// do not map.
+func (s Settings) TerminalProvider() (string, bool) {
+	if s.terminal == "" {
+		return "", false
+	}
+	command := filepath.Base(strings.Fields(s.terminal)[0])
+	if command == "footclient" {
+		command = "foot"
+	}
+	group, ok := installation.GetAlternativeGroup(installation.SlotTerminal)
+	if !ok {
+		return "", false
+	}
+	if _, ok := group.Provider(command); !ok {
+		return "", false
+	}
+	return command, true
+}
+
+// MonitorConfig returns the monitor layout as Hyprland monitor lines
+func (s Settings) MonitorConfig() string {
+	lines := make([]string, 0, len(s.monitors))
+	for _, monitor := range s.monitors {
+		lines = append(lines, monitor.HyprlandLine())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TemplateVars returns the keyboard and monitor template variables, with
+// the defaults for settings that were not found: the us layout and every
+// monitor auto-detected
+func (s Settings) TemplateVars() map[string]string {
+	vars := s.keyboard.TemplateVars()
+	vars["monitor_config"] = s.MonitorConfig()
+	return vars
+}
+
+// ImportedVars returns only the template variables for settings that were
+// found, so importing them leaves the rest of the configuration alone
+func (s Settings) ImportedVars() map[string]string {
+	vars := make(map[string]string)
+	if !s.keyboard.IsEmpty() {
+		for k, v := range s.keyboard.TemplateVars() {
+			vars[k] = v
+		}
+	}
+	if len(s.monitors) > 0 {
+		vars["monitor_config"] = s.MonitorConfig()
+	}
+	return vars
+}
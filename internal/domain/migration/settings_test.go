@@ -0,0 +1,167 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDesktop(t *testing.T) {
+	for input, want := range map[string]migration.Desktop{
+		"gnome":  migration.DesktopGNOME,
+		" KDE ":  migration.DesktopKDE,
+		"plasma": migration.DesktopKDE,
+	} {
+		desktop, err := migration.ParseDesktop(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, desktop, input)
+	}
+
+	_, err := migration.ParseDesktop("xfce")
+	assert.ErrorIs(t, err, migration.ErrUnknownDesktop)
+}
+
+func TestMonitor_HyprlandLine(t *testing.T) {
+	t.Run("mode, position and scale", func(t *testing.T) {
+		monitor, err := migration.NewMonitor("DP-1", 2560, 1440, 143.91, 1920, 0, 1.25)
+		require.NoError(t, err)
+
+		assert.Equal(t, "monitor = DP-1, 2560x1440@143.91, 1920x0, 1.25", monitor.HyprlandLine())
+	})
+
+	t.Run("leaves the refresh rate to Hyprland when unknown", func(t *testing.T) {
+		monitor, err := migration.NewMonitor("eDP-1", 1920, 1080, 0, 0, 0, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, "monitor = eDP-1, 1920x1080, 0x0, 1", monitor.HyprlandLine())
+	})
+
+	t.Run("rotated", func(t *testing.T) {
+		monitor, err := migration.NewMonitor("HDMI-A-1", 1920, 1080, 60, 0, 0, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, "monitor = HDMI-A-1, 1920x1080@60, 0x0, 1, transform, 1",
+			monitor.WithTransform(migration.Transform90).HyprlandLine())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		monitor, err := migration.NewDisabledMonitor("eDP-1")
+		require.NoError(t, err)
+
+		assert.True(t, monitor.IsDisabled())
+		assert.Equal(t, "monitor = eDP-1, disable", monitor.HyprlandLine())
+	})
+
+	t.Run("requires a connector and a resolution", func(t *testing.T) {
+		_, err := migration.NewMonitor("", 1920, 1080, 60, 0, 0, 1)
+		assert.ErrorIs(t, err, migration.ErrInvalidMonitor)
+
+		_, err = migration.NewMonitor("DP-1", 0, 1080, 60, 0, 0, 1)
+		assert.ErrorIs(t, err, migration.ErrInvalidMonitor)
+	})
+}
+
+func TestKeyboard_TemplateVars(t *testing.T) {
+	t.Run("lines variants up with their layouts", func(t *testing.T) {
+		us, err := migration.ParseKeyboardLayout("us")
+		require.NoError(t, err)
+		de, err := migration.ParseKeyboardLayout("de+nodeadkeys")
+		require.NoError(t, err)
+		assert.Equal(t, "nodeadkeys", de.Variant())
+
+		keyboard := migration.NewKeyboard([]migration.KeyboardLayout{us, de}, []string{"caps:escape", "", "caps:escape", "compose:ralt"})
+
+		assert.Equal(t, map[string]string{
+			"kb_layout":  "us,de",
+			"kb_variant": ",nodeadkeys",
+			"kb_options": "caps:escape,compose:ralt",
+		}, keyboard.TemplateVars())
+	})
+
+	t.Run("defaults to us", func(t *testing.T) {
+		var keyboard migration.Keyboard
+
+		assert.True(t, keyboard.IsEmpty())
+		assert.Equal(t, map[string]string{
+			"kb_layout":  "us",
+			"kb_variant": "",
+			"kb_options": "",
+		}, keyboard.TemplateVars())
+	})
+
+	t.Run("rejects layouts Hyprland would split", func(t *testing.T) {
+		_, err := migration.NewKeyboardLayout("us,de", "")
+		assert.ErrorIs(t, err, migration.ErrInvalidKeyboardLayout)
+
+		_, err = migration.ParseKeyboardLayout("+intl")
+		assert.ErrorIs(t, err, migration.ErrInvalidKeyboardLayout)
+	})
+}
+
+func TestSettings(t *testing.T) {
+	t.Run("imports only what was found", func(t *testing.T) {
+		monitor, err := migration.NewMonitor("DP-1", 2560, 1440, 60, 0, 0, 1)
+		require.NoError(t, err)
+
+		settings := migration.NewSettings(migration.DesktopGNOME).WithMonitors([]migration.Monitor{monitor})
+
+		assert.Equal(t, map[string]string{
+			"monitor_config": "monitor = DP-1, 2560x1440@60, 0x0, 1",
+		}, settings.ImportedVars())
+	})
+
+	t.Run("template variables default every setting", func(t *testing.T) {
+		var settings migration.Settings
+
+		vars := settings.TemplateVars()
+		assert.Equal(t, "us", vars["kb_layout"])
+		assert.Equal(t, "", vars["monitor_config"])
+		assert.Empty(t, settings.ImportedVars())
+	})
+
+	t.Run("maps terminals gohan installs", func(t *testing.T) {
+		settings := migration.NewSettings(migration.DesktopGNOME)
+
+		for command, want := range map[string]string{
+			"alacritty":           "alacritty",
+			"/usr/bin/kitty -1":   "kitty",
+			"footclient --server": "foot",
+		} {
+			provider, ok := settings.WithTerminal(command).TerminalProvider()
+			assert.True(t, ok, command)
+			assert.Equal(t, want, provider, command)
+		}
+
+		_, ok := settings.WithTerminal("gnome-terminal").TerminalProvider()
+		assert.False(t, ok)
+		_, ok = settings.TerminalProvider()
+		assert.False(t, ok)
+	})
+
+	t.Run("keeps notes", func(t *testing.T) {
+		settings := migration.NewSettings(migration.DesktopKDE).WithNote("first")
+		noted := settings.WithNote("second")
+
+		assert.Equal(t, []string{"first"}, settings.Notes())
+		assert.Equal(t, []string{"first", "second"}, noted.Notes())
+	})
+}
+
+func TestServicesSafeToDisable(t *testing.T) {
+	services := migration.ServicesSafeToDisable(migration.DesktopGNOME)
+	require.NotEmpty(t, services)
+
+	byName := make(map[string]migration.Service)
+	for _, service := range services {
+		assert.NotEmpty(t, service.Reason(), service.Name())
+		byName[service.Name()] = service
+	}
+
+	assert.Equal(t, "sudo systemctl mask --now packagekit.service", byName["packagekit.service"].DisableCommand())
+	assert.Equal(t, "systemctl --user mask --now tracker-miner-fs-3.service",
+		byName["tracker-miner-fs-3.service"].DisableCommand())
+
+	assert.NotEmpty(t, migration.ServicesSafeToDisable(migration.DesktopKDE))
+}
@@ -0,0 +1,105 @@
+package onboarding
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidStep is returned for a step without keys or an action
+	ErrInvalidStep = errors.New("invalid onboarding step")
+
+	// ErrEmptyTour is returned for a tour without steps
+	ErrEmptyTour = errors.New("onboarding tour has no steps")
+)
+
+// Step introduces one key binding
+type Step struct {
+	keys   string
+	action string
+}
+
+// NewStep creates a step for the keys, written like SUPER+Return, and what
+// they do
+func NewStep(keys, action string) (Step, error) {
+	keys = strings.TrimSpace(keys)
+	action = strings.TrimSpace(action)
+	if keys == "" || action == "" {
+		return Step{}, fmt.Errorf("%w: keys and action are required", ErrInvalidStep)
+	}
+	return Step{keys: keys, action: action}, nil
+}
+
+// Keys returns the keys to press
+func (s Step) Keys() string {
+	return s.keys
+}
+
+// Action returns what the keys do
+func (s Step) Action() string {
+	return s.action
+}
+
+// String returns the step as "keys: action"
+func (s Step) String() string {
+	return s.keys + ": " + s.action
+}
+
+// Tour is the sequence of key bindings shown on first login
+type Tour struct {
+	steps []Step
+}
+
+// NewTour creates a tour of the given steps
+func NewTour(steps ...Step) (Tour, error) {
+	if len(steps) == 0 {
+		return Tour{}, ErrEmptyTour
+	}
+	return Tour{steps: append([]Step(nil), steps...)}, nil
+}
+
+// DefaultTour introduces the bindings gohan's Hyprland configuration sets
+// up, in the order a new user needs them
+func DefaultTour() Tour {
+	return Tour{steps: []Step{
+		{keys: "SUPER+Return", action: "Open a terminal"},
+		{keys: "SUPER+Space", action: "Search for and launch applications"},
+		{keys: "SUPER+W", action: "Close the focused window"},
+		{keys: "SUPER+1 … SUPER+0", action: "Switch workspaces; add SHIFT to move the window along"},
+		{keys: "SUPER+Arrows", action: "Move focus between windows"},
+		{keys: "Print", action: "Screenshot the screen to ~/Pictures/Screenshots; SHIFT+Print for a selection, CTRL for the clipboard"},
+		{keys: "SUPER+L", action: "Lock the screen"},
+		{keys: "SUPER+Escape", action: "Log out, reboot or power off"},
+		{keys: "SUPER+F1", action: "Show a cheat sheet of every key binding"},
+	}}
+}
+
+// Steps returns the steps in order
+func (t Tour) Steps() []Step {
+	return append([]Step(nil), t.steps...)
+}
+
+// Len returns the number of steps
+func (t Tour) Len() int {
+	return len(t.steps)
+}
+
+// State is whether the tour will run on the next login
+type State string
+
+const (
+	// StateNotSetUp means the tour was never enabled or disabled
+	StateNotSetUp State = "not-set-up"
+
+	// StatePending means the tour runs on the next login
+	StatePending State = "pending"
+
+	// StateDone means the tour ran or was disabled
+	StateDone State = "done"
+)
+
+// String returns the state name
+func (s State) String() string {
+	return string(s)
+}
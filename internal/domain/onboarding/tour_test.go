@@ -0,0 +1,61 @@
+package onboarding_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/onboarding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStep(t *testing.T) {
+	t.Run("trims keys and action", func(t *testing.T) {
+		step, err := onboarding.NewStep(" SUPER+Return ", " Open a terminal ")
+		require.NoError(t, err)
+
+		assert.Equal(t, "SUPER+Return", step.Keys())
+		assert.Equal(t, "Open a terminal", step.Action())
+		assert.Equal(t, "SUPER+Return: Open a terminal", step.String())
+	})
+
+	t.Run("requires keys and action", func(t *testing.T) {
+		_, err := onboarding.NewStep("", "Open a terminal")
+		assert.ErrorIs(t, err, onboarding.ErrInvalidStep)
+
+		_, err = onboarding.NewStep("SUPER+Return", " ")
+		assert.ErrorIs(t, err, onboarding.ErrInvalidStep)
+	})
+}
+
+func TestNewTour(t *testing.T) {
+	t.Run("keeps steps in order", func(t *testing.T) {
+		first, err := onboarding.NewStep("SUPER+Return", "Open a terminal")
+		require.NoError(t, err)
+		second, err := onboarding.NewStep("SUPER+Space", "Launch applications")
+		require.NoError(t, err)
+
+		tour, err := onboarding.NewTour(first, second)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, tour.Len())
+		assert.Equal(t, []onboarding.Step{first, second}, tour.Steps())
+	})
+
+	t.Run("requires a step", func(t *testing.T) {
+		_, err := onboarding.NewTour()
+		assert.ErrorIs(t, err, onboarding.ErrEmptyTour)
+	})
+}
+
+func TestDefaultTour(t *testing.T) {
+	tour := onboarding.DefaultTour()
+
+	var keys []string
+	for _, step := range tour.Steps() {
+		assert.NotEmpty(t, step.Action())
+		keys = append(keys, step.Keys())
+	}
+	assert.Equal(t, "SUPER+Return", keys[0], "the terminal comes first")
+	assert.Contains(t, keys, "SUPER+Space")
+	assert.Contains(t, keys, "Print")
+}
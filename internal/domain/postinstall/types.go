@@ -64,6 +64,7 @@ const (
 	ComponentNetwork        ComponentType = "network"
 	ComponentServices       ComponentType = "services"
 	ComponentWallpaper      ComponentType = "wallpaper"
+	ComponentZram           ComponentType = "zram"
 )
 
 // String returns the string representation
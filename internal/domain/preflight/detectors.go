@@ -26,6 +26,51 @@ type DiskSpaceDetector interface {
 	DetectAvailableSpace(ctx context.Context, path string) (DiskSpace, error)
 }
 
+// MountPointDetector is optionally implemented by disk space detectors that
+// can resolve which mount point a path lives on, so separate /var, /home and
+// /boot partitions are checked individually
+type MountPointDetector interface {
+	// DetectMountPoint returns the mount point holding path
+	DetectMountPoint(ctx context.Context, path string) (string, error)
+}
+
+// ResourceDetector detects memory and storage characteristics
+type ResourceDetector interface {
+	// DetectResources reports total memory and whether root storage is slow
+	DetectResources(ctx context.Context) (SystemResources, error)
+}
+
+// PowerDaemonDetector detects running power management daemons
+type PowerDaemonDetector interface {
+	// DetectPowerDaemons reports which known power daemons are active
+	DetectPowerDaemons(ctx context.Context) (PowerDaemonStatus, error)
+}
+
+// SessionDetector detects display managers and live compositor sessions
+type SessionDetector interface {
+	// DetectSession reports installed display managers and any active Wayland session
+	DetectSession(ctx context.Context) (SessionEnvironment, error)
+}
+
+// SourcesSanityChecker checks apt sources for duplicates and mixed suites
+type SourcesSanityChecker interface {
+	// CheckSourcesSanity reports problems in the apt sources files
+	CheckSourcesSanity(ctx context.Context) (SourcesSanity, error)
+}
+
+// ThroughputProbe samples disk write and download speed
+type ThroughputProbe interface {
+	// ProbeThroughput measures disk write and apt download throughput
+	ProbeThroughput(ctx context.Context) (Throughput, error)
+}
+
+// FilesystemWritabilityDetector detects read-only mounts, such as an
+// overlayroot or immutable root filesystem
+type FilesystemWritabilityDetector interface {
+	// DetectWritability reports which of paths live on read-only mounts
+	DetectWritability(ctx context.Context, paths []string) (FilesystemWritability, error)
+}
+
 // ConnectivityChecker checks internet connectivity
 type ConnectivityChecker interface {
 	// CheckInternetConnectivity tests internet access
@@ -4,9 +4,15 @@ import "errors"
 
 var (
 	// Entity/Value Object errors
-	ErrInvalidDebianVersion = errors.New("invalid debian version")
-	ErrInvalidGPU           = errors.New("invalid gpu configuration")
-	ErrInvalidDiskSpace     = errors.New("invalid disk space value")
+	ErrInvalidDebianVersion   = errors.New("invalid debian version")
+	ErrInvalidGPU             = errors.New("invalid gpu configuration")
+	ErrInvalidDiskSpace       = errors.New("invalid disk space value")
+	ErrInvalidSystemResources = errors.New("invalid system resources")
+	ErrInvalidThroughput      = errors.New("invalid throughput measurement")
+
+	// Policy errors
+	ErrUnknownRequirement      = errors.New("unknown preflight requirement")
+	ErrInvalidSeverityOverride = errors.New("invalid severity override")
 
 	// Repository errors
 	ErrSessionNotFound = errors.New("validation session not found")
@@ -0,0 +1,79 @@
+package preflight
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PackageManagerPaths returns the directories apt and dpkg write to while
+// installing packages
+func PackageManagerPaths() []string {
+	return []string{"/usr", "/etc/apt", "/var/lib/dpkg", "/var/cache/apt"}
+}
+
+// ReadOnlyMount is a package manager path that lives on a read-only mount
+type ReadOnlyMount struct {
+	Path       string // Directory apt or dpkg writes to
+	MountPoint string // Read-only mount holding the directory
+	FSType     string // Filesystem type, e.g. squashfs or overlay
+}
+
+// String returns human-readable representation
+func (m ReadOnlyMount) String() string {
+	if m.FSType == "" {
+		return fmt.Sprintf("%s (read-only mount %s)", m.Path, m.MountPoint)
+	}
+	return fmt.Sprintf("%s (read-only %s mount %s)", m.Path, m.FSType, m.MountPoint)
+}
+
+// FilesystemWritability reports which package manager paths are read-only,
+// as on immutable or overlayroot systems
+type FilesystemWritability struct {
+	readOnly []ReadOnlyMount
+}
+
+// NewFilesystemWritability creates a writability value object from the
+// package manager paths found on read-only mounts
+func NewFilesystemWritability(readOnly []ReadOnlyMount) FilesystemWritability {
+	copied := make([]ReadOnlyMount, len(readOnly))
+	copy(copied, readOnly)
+	return FilesystemWritability{readOnly: copied}
+}
+
+// ReadOnlyMounts returns the package manager paths on read-only mounts
+func (w FilesystemWritability) ReadOnlyMounts() []ReadOnlyMount {
+	mounts := make([]ReadOnlyMount, len(w.readOnly))
+	copy(mounts, w.readOnly)
+	return mounts
+}
+
+// CanInstallPackages returns true if apt and dpkg can write everywhere
+// they need to
+func (w FilesystemWritability) CanInstallPackages() bool {
+	return len(w.readOnly) == 0
+}
+
+// ReadOnlyMountPoints returns each read-only mount point once, in order
+func (w FilesystemWritability) ReadOnlyMountPoints() []string {
+	seen := make(map[string]bool, len(w.readOnly))
+	var mountPoints []string
+	for _, m := range w.readOnly {
+		if !seen[m.MountPoint] {
+			seen[m.MountPoint] = true
+			mountPoints = append(mountPoints, m.MountPoint)
+		}
+	}
+	return mountPoints
+}
+
+// String returns human-readable representation
+func (w FilesystemWritability) String() string {
+	if w.CanInstallPackages() {
+		return "package directories writable"
+	}
+	paths := make([]string, 0, len(w.readOnly))
+	for _, m := range w.readOnly {
+		paths = append(paths, m.Path)
+	}
+	return fmt.Sprintf("read-only: %s", strings.Join(paths, ", "))
+}
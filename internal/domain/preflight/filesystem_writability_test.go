@@ -0,0 +1,34 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesystemWritability(t *testing.T) {
+	t.Run("writable system", func(t *testing.T) {
+		writability := preflight.NewFilesystemWritability(nil)
+
+		assert.True(t, writability.CanInstallPackages())
+		assert.Empty(t, writability.ReadOnlyMountPoints())
+		assert.Equal(t, "package directories writable", writability.String())
+	})
+
+	t.Run("read-only root", func(t *testing.T) {
+		readOnly := []preflight.ReadOnlyMount{
+			{Path: "/usr", MountPoint: "/", FSType: "squashfs"},
+			{Path: "/etc/apt", MountPoint: "/", FSType: "squashfs"},
+			{Path: "/var/lib/dpkg", MountPoint: "/var", FSType: "ext4"},
+		}
+		writability := preflight.NewFilesystemWritability(readOnly)
+		readOnly[0].Path = "/changed"
+
+		assert.False(t, writability.CanInstallPackages())
+		assert.Equal(t, "/usr", writability.ReadOnlyMounts()[0].Path, "mounts are copied")
+		assert.Equal(t, []string{"/", "/var"}, writability.ReadOnlyMountPoints())
+		assert.Equal(t, "read-only: /usr, /etc/apt, /var/lib/dpkg", writability.String())
+		assert.Equal(t, "/usr (read-only squashfs mount /)", writability.ReadOnlyMounts()[0].String())
+	})
+}
@@ -7,9 +7,14 @@ import (
 
 // GPUType represents a detected GPU
 type GPUType struct {
-	vendor GPUVendor
-	model  string
-	pciID  string
+	vendor        GPUVendor
+	model         string
+	pciID         string
+	vram          uint64
+	kernelDriver  GPUKernelDriver
+	driverVersion string
+	pciSlot       string
+	drmDevice     string
 }
 
 // NewGPUType creates a new GPU type value object
@@ -40,6 +45,90 @@ func (g GPUType) PCIID() string {
 	return g.pciID
 }
 
+// WithDevice returns a copy of the GPU with its PCI address, e.g.
+// 0000:01:00.0, and DRM card node, e.g. /dev/dri/card1
+func (g GPUType) WithDevice(pciSlot, drmDevice string) GPUType {
+	g.pciSlot = strings.TrimSpace(pciSlot)
+	g.drmDevice = strings.TrimSpace(drmDevice)
+	return g
+}
+
+// PCISlot returns the PCI address of the GPU, or "" if unknown
+func (g GPUType) PCISlot() string {
+	return g.pciSlot
+}
+
+// DRMDevice returns the DRM card node of the GPU, or "" if no driver
+// created one
+func (g GPUType) DRMDevice() string {
+	return g.drmDevice
+}
+
+// WithVRAM returns a copy of the GPU with its dedicated video memory in bytes
+func (g GPUType) WithVRAM(bytes uint64) GPUType {
+	g.vram = bytes
+	return g
+}
+
+// WithKernelDriver returns a copy of the GPU with the kernel driver bound to
+// it and that driver's version
+func (g GPUType) WithKernelDriver(driver GPUKernelDriver, version string) GPUType {
+	g.kernelDriver = driver
+	g.driverVersion = strings.TrimSpace(version)
+	return g
+}
+
+// VRAM returns the dedicated video memory in bytes, or 0 if unknown or shared
+func (g GPUType) VRAM() uint64 {
+	return g.vram
+}
+
+// VRAMGB returns the dedicated video memory in GB
+func (g GPUType) VRAMGB() float64 {
+	return float64(g.vram) / float64(GB)
+}
+
+// KernelDriver returns the kernel driver in use, or GPUDriverNone if no
+// driver is bound
+func (g GPUType) KernelDriver() GPUKernelDriver {
+	return g.kernelDriver
+}
+
+// DriverVersion returns the version of the kernel driver in use
+func (g GPUType) DriverVersion() string {
+	return g.driverVersion
+}
+
+// UsesNouveau returns true when the open-source nouveau driver is bound
+func (g GPUType) UsesNouveau() bool {
+	return g.kernelDriver == GPUDriverNouveau
+}
+
+// NeedsNouveauRemoval returns true when nouveau is bound to an NVIDIA GPU
+// that will get the proprietary driver. nouveau then has to be blacklisted
+// and the initramfs rebuilt before the nvidia module can take over.
+func (g GPUType) NeedsNouveauRemoval() bool {
+	return g.RequiresProprietaryDriver() && g.UsesNouveau()
+}
+
+// DriverSummary describes the driver and VRAM, e.g. "nvidia 550.54.14, 8.00 GB VRAM"
+func (g GPUType) DriverSummary() string {
+	var parts []string
+	if g.kernelDriver != GPUDriverNone {
+		driver := string(g.kernelDriver)
+		if g.driverVersion != "" {
+			driver += " " + g.driverVersion
+		}
+		parts = append(parts, driver)
+	} else {
+		parts = append(parts, "no driver bound")
+	}
+	if g.vram > 0 {
+		parts = append(parts, fmt.Sprintf("%.2f GB VRAM", g.VRAMGB()))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // IsNVIDIA returns true for NVIDIA GPUs
 func (g GPUType) IsNVIDIA() bool {
 	return g.vendor == GPUVendorNVIDIA
@@ -72,3 +161,20 @@ func (g GPUType) String() string {
 	}
 	return string(g.vendor)
 }
+
+// NouveauRemovalGuidance explains how to hand an NVIDIA GPU over from
+// nouveau to the proprietary driver
+func NouveauRemovalGuidance() UserGuidance {
+	return NewUserGuidance(
+		"NVIDIA GPU is using the nouveau driver - it must be disabled before the proprietary driver can load",
+		"nouveau is loaded from the initramfs at boot and holds the GPU, so the nvidia module cannot bind to it",
+		[]string{
+			"Enable non-free repositories: gohan repo enable-nonfree",
+			"Install NVIDIA drivers: sudo apt install nvidia-driver firmware-misc-nonfree",
+			"Blacklist nouveau: printf 'blacklist nouveau\\noptions nouveau modeset=0\\n' | sudo tee /etc/modprobe.d/blacklist-nouveau.conf",
+			"Rebuild the initramfs so nouveau is not loaded at boot: sudo update-initramfs -u",
+			"Reboot, then confirm the nvidia driver is in use: lspci -k -d 10de:",
+		},
+		"https://gohan.sh/docs/nvidia-setup",
+	)
+}
@@ -1,6 +1,7 @@
 package preflight_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
@@ -229,3 +230,67 @@ func TestGPUType_EmptyValues(t *testing.T) {
 		assert.Equal(t, "10de:2684", gpu.PCIID())
 	})
 }
+
+func TestGPUType_DriverInfo(t *testing.T) {
+	gpu, err := preflight.NewGPUType(preflight.GPUVendorAMD, "Radeon RX 6800", "1002:73bf")
+	require.NoError(t, err)
+
+	assert.Equal(t, preflight.GPUDriverNone, gpu.KernelDriver())
+	assert.Zero(t, gpu.VRAM())
+	assert.Equal(t, "no driver bound", gpu.DriverSummary())
+
+	detected := gpu.WithKernelDriver(preflight.GPUDriverAMDGPU, " 6.12.9-amd64 ").WithVRAM(16 * preflight.GB)
+
+	assert.Equal(t, preflight.GPUDriverAMDGPU, detected.KernelDriver())
+	assert.Equal(t, "6.12.9-amd64", detected.DriverVersion())
+	assert.Equal(t, uint64(16*preflight.GB), detected.VRAM())
+	assert.InDelta(t, 16.0, detected.VRAMGB(), 0.001)
+	assert.Equal(t, "amdgpu 6.12.9-amd64, 16.00 GB VRAM", detected.DriverSummary())
+
+	// The original value is unchanged
+	assert.Equal(t, preflight.GPUDriverNone, gpu.KernelDriver())
+}
+
+func TestGPUType_WithDevice(t *testing.T) {
+	gpu, err := preflight.NewGPUType(preflight.GPUVendorNVIDIA, "GeForce MX150", "10de:1d10")
+	require.NoError(t, err)
+
+	located := gpu.WithDevice(" 0000:01:00.0 ", "/dev/dri/card1")
+
+	assert.Equal(t, "0000:01:00.0", located.PCISlot())
+	assert.Equal(t, "/dev/dri/card1", located.DRMDevice())
+	assert.Empty(t, gpu.PCISlot())
+}
+
+func TestGPUType_NeedsNouveauRemoval(t *testing.T) {
+	nvidia, err := preflight.NewGPUType(preflight.GPUVendorNVIDIA, "GeForce RTX 3070", "10de:2484")
+	require.NoError(t, err)
+	amd, err := preflight.NewGPUType(preflight.GPUVendorAMD, "Radeon HD 7970", "1002:6798")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		gpu  preflight.GPUType
+		want bool
+	}{
+		{"NVIDIA on nouveau", nvidia.WithKernelDriver(preflight.GPUDriverNouveau, ""), true},
+		{"NVIDIA on proprietary driver", nvidia.WithKernelDriver(preflight.GPUDriverNVIDIA, "550.54.14"), false},
+		{"NVIDIA without driver", nvidia, false},
+		{"AMD on radeon", amd.WithKernelDriver(preflight.GPUDriverRadeon, ""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.gpu.NeedsNouveauRemoval())
+		})
+	}
+}
+
+func TestNouveauRemovalGuidance(t *testing.T) {
+	guidance := preflight.NouveauRemovalGuidance()
+
+	assert.Contains(t, guidance.Message(), "nouveau")
+	steps := strings.Join(guidance.ActionableSteps(), "\n")
+	assert.Contains(t, steps, "/etc/modprobe.d/blacklist-nouveau.conf")
+	assert.Contains(t, steps, "update-initramfs -u")
+}
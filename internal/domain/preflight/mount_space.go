@@ -0,0 +1,184 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DiskConsumer estimates how much space an installation consumes under a path
+type DiskConsumer struct {
+	Path    string
+	Purpose string
+	Bytes   uint64
+}
+
+// DefaultDiskConsumers returns where an installation writes and how much.
+// The estimates add up to the 10 GB overall minimum, so a single-partition
+// system is held to the same requirement as before. homeDir may be empty.
+func DefaultDiskConsumers(homeDir string) []DiskConsumer {
+	consumers := []DiskConsumer{
+		{Path: "/", Purpose: "installed packages", Bytes: 6 * GB},
+		{Path: "/var", Purpose: "package downloads and unpacking", Bytes: 3*GB + 512*MB},
+		{Path: "/boot", Purpose: "kernel and initramfs updates", Bytes: 256 * MB},
+	}
+	if homeDir = strings.TrimSpace(homeDir); homeDir != "" {
+		consumers = append(consumers, DiskConsumer{Path: homeDir, Purpose: "configuration files and caches", Bytes: 256 * MB})
+	}
+	return consumers
+}
+
+// InstallationDiskConsumers returns where an installation of known size
+// writes: the installed size of its packages under /, and their downloads
+// unpacked under /var. Unknown sizes fall back to DefaultDiskConsumers.
+func InstallationDiskConsumers(homeDir string, installedBytes, downloadBytes uint64) []DiskConsumer {
+	consumers := DefaultDiskConsumers(homeDir)
+	if installedBytes == 0 {
+		return consumers
+	}
+
+	for i := range consumers {
+		switch consumers[i].Path {
+		case "/":
+			consumers[i].Bytes = installedBytes
+		case "/var":
+			// Archives stay in the apt cache while they are unpacked
+			consumers[i].Bytes = 2 * downloadBytes
+		}
+	}
+	return consumers
+}
+
+// MountSpace is the free space on one mount point together with the
+// consumers that write to it
+type MountSpace struct {
+	space     DiskSpace
+	consumers []DiskConsumer
+}
+
+// NewMountSpace creates a mount space for the filesystem measured by space
+func NewMountSpace(space DiskSpace, consumers []DiskConsumer) MountSpace {
+	kept := make([]DiskConsumer, len(consumers))
+	copy(kept, consumers)
+	return MountSpace{space: space, consumers: kept}
+}
+
+// MountPoint returns the mount point the space was measured on
+func (m MountSpace) MountPoint() string {
+	return m.space.Path()
+}
+
+// Space returns the measured disk space
+func (m MountSpace) Space() DiskSpace {
+	return m.space
+}
+
+// Consumers returns a copy of the consumers writing to this mount point
+func (m MountSpace) Consumers() []DiskConsumer {
+	consumers := make([]DiskConsumer, len(m.consumers))
+	copy(consumers, m.consumers)
+	return consumers
+}
+
+// Required returns the bytes the consumers on this mount point need
+func (m MountSpace) Required() uint64 {
+	var required uint64
+	for _, c := range m.consumers {
+		required += c.Bytes
+	}
+	return required
+}
+
+// RequiredGB returns the required space in gigabytes
+func (m MountSpace) RequiredGB() float64 {
+	return float64(m.Required()) / float64(GB)
+}
+
+// IsSufficient returns true if the mount point has room for its consumers
+func (m MountSpace) IsSufficient() bool {
+	return m.space.Available() >= m.Required()
+}
+
+// IsNearlyFull returns true if the free space on the mount point is below
+// its requirement or within a quarter of it
+func (m MountSpace) IsNearlyFull() bool {
+	required := m.Required()
+	return m.space.Available() < required+required/4
+}
+
+// Purposes returns what the consumers on this mount point write
+func (m MountSpace) Purposes() string {
+	purposes := make([]string, 0, len(m.consumers))
+	for _, c := range m.consumers {
+		purposes = append(purposes, c.Purpose)
+	}
+	return strings.Join(purposes, ", ")
+}
+
+// String returns human-readable representation
+func (m MountSpace) String() string {
+	return fmt.Sprintf("%s: %.2f GB free, %.2f GB needed", m.MountPoint(), m.space.AvailableGB(), m.RequiredGB())
+}
+
+// DetectMountSpaces groups consumers by the mount point their path lives on
+// and measures the free space of each mount point once. Detectors that
+// cannot resolve mount points measure every consumer against "/".
+func DetectMountSpaces(ctx context.Context, detector DiskSpaceDetector, consumers []DiskConsumer) ([]MountSpace, error) {
+	resolver, canResolve := detector.(MountPointDetector)
+
+	var order []string
+	byMount := make(map[string][]DiskConsumer)
+	for _, consumer := range consumers {
+		mountPoint := "/"
+		if canResolve {
+			if resolved, err := resolver.DetectMountPoint(ctx, consumer.Path); err == nil && resolved != "" {
+				mountPoint = resolved
+			}
+		}
+		if _, ok := byMount[mountPoint]; !ok {
+			order = append(order, mountPoint)
+		}
+		byMount[mountPoint] = append(byMount[mountPoint], consumer)
+	}
+
+	mounts := make([]MountSpace, 0, len(order))
+	for _, mountPoint := range order {
+		space, err := detector.DetectAvailableSpace(ctx, mountPoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check disk space on %s: %w", mountPoint, err)
+		}
+		mounts = append(mounts, NewMountSpace(space, byMount[mountPoint]))
+	}
+	return mounts, nil
+}
+
+// InsufficientMounts returns the mount points without room for their consumers
+func InsufficientMounts(mounts []MountSpace) []MountSpace {
+	var insufficient []MountSpace
+	for _, m := range mounts {
+		if !m.IsSufficient() {
+			insufficient = append(insufficient, m)
+		}
+	}
+	return insufficient
+}
+
+// NearlyFullMounts returns the mount points short of room or close to it
+func NearlyFullMounts(mounts []MountSpace) []MountSpace {
+	var nearlyFull []MountSpace
+	for _, m := range mounts {
+		if m.IsNearlyFull() {
+			nearlyFull = append(nearlyFull, m)
+		}
+	}
+	return nearlyFull
+}
+
+// DescribeMounts returns a one-line summary of every mount point checked
+func DescribeMounts(mounts []MountSpace) string {
+	parts := make([]string, 0, len(mounts))
+	for _, m := range mounts {
+		parts = append(parts, m.String())
+	}
+	return strings.Join(parts, "; ")
+}
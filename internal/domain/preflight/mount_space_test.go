@@ -0,0 +1,123 @@
+package preflight_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMountDetector resolves paths to mount points and reports free space per mount
+type fakeMountDetector struct {
+	mounts    map[string]string
+	available map[string]uint64
+	queried   []string
+}
+
+func (d *fakeMountDetector) DetectAvailableSpace(ctx context.Context, path string) (preflight.DiskSpace, error) {
+	d.queried = append(d.queried, path)
+	return preflight.NewDiskSpace(d.available[path], 500*preflight.GB, path)
+}
+
+func (d *fakeMountDetector) DetectMountPoint(ctx context.Context, path string) (string, error) {
+	if mount, ok := d.mounts[path]; ok {
+		return mount, nil
+	}
+	return "/", nil
+}
+
+// rootOnlyDetector cannot resolve mount points
+type rootOnlyDetector struct{}
+
+func (rootOnlyDetector) DetectAvailableSpace(ctx context.Context, path string) (preflight.DiskSpace, error) {
+	return preflight.NewDiskSpace(20*preflight.GB, 100*preflight.GB, path)
+}
+
+func TestDefaultDiskConsumers(t *testing.T) {
+	var total uint64
+	for _, c := range preflight.DefaultDiskConsumers("/home/alice") {
+		total += c.Bytes
+	}
+	assert.Equal(t, uint64(10*preflight.GB), total, "estimates add up to the overall minimum")
+
+	assert.Len(t, preflight.DefaultDiskConsumers(""), 3, "home is skipped when unknown")
+}
+
+func TestInstallationDiskConsumers(t *testing.T) {
+	t.Run("sizes packages and downloads from the components", func(t *testing.T) {
+		consumers := preflight.InstallationDiskConsumers("/home/alice", 2*preflight.GB, 500*preflight.MB)
+
+		bytes := make(map[string]uint64)
+		for _, c := range consumers {
+			bytes[c.Path] = c.Bytes
+		}
+		assert.Equal(t, uint64(2*preflight.GB), bytes["/"])
+		assert.Equal(t, uint64(1000*preflight.MB), bytes["/var"])
+		assert.Equal(t, uint64(256*preflight.MB), bytes["/boot"])
+		assert.Equal(t, uint64(256*preflight.MB), bytes["/home/alice"])
+	})
+
+	t.Run("falls back to the defaults without sizes", func(t *testing.T) {
+		assert.Equal(t, preflight.DefaultDiskConsumers("/home/alice"), preflight.InstallationDiskConsumers("/home/alice", 0, 0))
+	})
+}
+
+func TestDetectMountSpaces(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("checks separate partitions individually", func(t *testing.T) {
+		detector := &fakeMountDetector{
+			mounts: map[string]string{"/var": "/var", "/home/alice": "/home"},
+			available: map[string]uint64{
+				"/":     40 * preflight.GB,
+				"/var":  2 * preflight.GB,
+				"/home": 100 * preflight.GB,
+			},
+		}
+
+		mounts, err := preflight.DetectMountSpaces(ctx, detector, preflight.DefaultDiskConsumers("/home/alice"))
+		require.NoError(t, err)
+		require.Len(t, mounts, 3)
+
+		assert.Equal(t, "/", mounts[0].MountPoint())
+		assert.Equal(t, "installed packages, kernel and initramfs updates", mounts[0].Purposes())
+		assert.True(t, mounts[0].IsSufficient())
+
+		assert.Equal(t, "/var", mounts[1].MountPoint())
+		assert.False(t, mounts[1].IsSufficient())
+		assert.Equal(t, "/var: 2.00 GB free, 3.50 GB needed", mounts[1].String())
+
+		assert.Equal(t, "/home", mounts[2].MountPoint())
+		assert.True(t, mounts[2].IsSufficient())
+
+		assert.Equal(t, []string{"/", "/var", "/home"}, detector.queried, "each mount is measured once")
+	})
+
+	t.Run("falls back to root without mount resolution", func(t *testing.T) {
+		mounts, err := preflight.DetectMountSpaces(ctx, rootOnlyDetector{}, preflight.DefaultDiskConsumers("/home/alice"))
+		require.NoError(t, err)
+		require.Len(t, mounts, 1)
+		assert.Equal(t, "/", mounts[0].MountPoint())
+		assert.Equal(t, uint64(10*preflight.GB), mounts[0].Required())
+		assert.True(t, mounts[0].IsSufficient())
+	})
+}
+
+func TestNearlyFullMounts(t *testing.T) {
+	mount := func(available uint64) preflight.MountSpace {
+		space, err := preflight.NewDiskSpace(available, 100*preflight.GB, "/var")
+		require.NoError(t, err)
+		return preflight.NewMountSpace(space, []preflight.DiskConsumer{{Path: "/var", Bytes: 4 * preflight.GB}})
+	}
+
+	roomy := mount(6 * preflight.GB)
+	tight := mount(4*preflight.GB + 512*preflight.MB)
+	short := mount(3 * preflight.GB)
+
+	assert.False(t, roomy.IsNearlyFull())
+	assert.True(t, tight.IsNearlyFull())
+	assert.True(t, tight.IsSufficient(), "close to the threshold is still enough")
+	assert.Equal(t, []preflight.MountSpace{tight, short}, preflight.NearlyFullMounts([]preflight.MountSpace{roomy, tight, short}))
+}
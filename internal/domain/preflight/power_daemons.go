@@ -0,0 +1,58 @@
+package preflight
+
+import "strings"
+
+// KnownPowerDaemons lists the power management services that fight over CPU
+// governors and device power settings when more than one is running
+var KnownPowerDaemons = []string{
+	"power-profiles-daemon",
+	"tlp",
+	"auto-cpufreq",
+	"laptop-mode",
+}
+
+// PowerDaemonStatus represents the power management daemons active on the system
+type PowerDaemonStatus struct {
+	active []string
+}
+
+// NewPowerDaemonStatus creates a new power daemon status value object
+func NewPowerDaemonStatus(active []string) PowerDaemonStatus {
+	daemons := make([]string, 0, len(active))
+	for _, daemon := range active {
+		if daemon = strings.TrimSpace(daemon); daemon != "" {
+			daemons = append(daemons, daemon)
+		}
+	}
+	return PowerDaemonStatus{active: daemons}
+}
+
+// Active returns the active power daemons
+func (s PowerDaemonStatus) Active() []string {
+	active := make([]string, len(s.active))
+	copy(active, s.active)
+	return active
+}
+
+// HasConflict returns true if more than one power daemon is active
+func (s PowerDaemonStatus) HasConflict() bool {
+	return len(s.active) > 1
+}
+
+// IsActive returns true if the given daemon is active
+func (s PowerDaemonStatus) IsActive(daemon string) bool {
+	for _, active := range s.active {
+		if active == daemon {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns human-readable representation
+func (s PowerDaemonStatus) String() string {
+	if len(s.active) == 0 {
+		return "no power daemon active"
+	}
+	return strings.Join(s.active, ", ")
+}
@@ -0,0 +1,34 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerDaemonStatus(t *testing.T) {
+	t.Run("no daemons", func(t *testing.T) {
+		status := preflight.NewPowerDaemonStatus(nil)
+
+		assert.Empty(t, status.Active())
+		assert.False(t, status.HasConflict())
+		assert.Equal(t, "no power daemon active", status.String())
+	})
+
+	t.Run("single daemon does not conflict", func(t *testing.T) {
+		status := preflight.NewPowerDaemonStatus([]string{"tlp", " "})
+
+		assert.Equal(t, []string{"tlp"}, status.Active())
+		assert.True(t, status.IsActive("tlp"))
+		assert.False(t, status.IsActive("power-profiles-daemon"))
+		assert.False(t, status.HasConflict())
+	})
+
+	t.Run("multiple daemons conflict", func(t *testing.T) {
+		status := preflight.NewPowerDaemonStatus([]string{"tlp", "auto-cpufreq"})
+
+		assert.True(t, status.HasConflict())
+		assert.Equal(t, "tlp, auto-cpufreq", status.String())
+	})
+}
@@ -17,6 +17,9 @@ type ValidationResult struct {
 	expectedValue   interface{}
 	guidance        UserGuidance
 	detectedAt      time.Time
+	override        SeverityOverride
+	measurement     float64
+	measured        bool
 }
 
 // NewValidationResult creates a new validation result
@@ -40,6 +43,46 @@ func NewValidationResult(
 	}
 }
 
+// ReconstructValidationResult rebuilds a result from persistent storage.
+// Detected and expected values are restored in their stored form, and the
+// measurement recorded at the time, if any, is kept.
+func ReconstructValidationResult(
+	id string,
+	requirementName RequirementName,
+	status ValidationStatus,
+	severity Severity,
+	actualValue interface{},
+	expectedValue interface{},
+	guidance UserGuidance,
+	detectedAt time.Time,
+	override SeverityOverride,
+	measurement *float64,
+) (ValidationResult, error) {
+	if id == "" {
+		return ValidationResult{}, fmt.Errorf("result ID cannot be empty")
+	}
+	if requirementName == "" {
+		return ValidationResult{}, fmt.Errorf("requirement name cannot be empty")
+	}
+
+	result := ValidationResult{
+		id:              id,
+		requirementName: requirementName,
+		status:          status,
+		severity:        severity,
+		actualValue:     actualValue,
+		expectedValue:   expectedValue,
+		guidance:        guidance,
+		detectedAt:      detectedAt,
+		override:        override,
+	}
+	if measurement != nil {
+		result.measurement = *measurement
+		result.measured = true
+	}
+	return result, nil
+}
+
 // ID returns the result identifier
 func (r ValidationResult) ID() string {
 	return r.id
@@ -80,6 +123,48 @@ func (r ValidationResult) DetectedAt() time.Time {
 	return r.detectedAt
 }
 
+// Override returns the severity policy override applied to this result,
+// or "" if the built-in severity was kept
+func (r ValidationResult) Override() SeverityOverride {
+	return r.override
+}
+
+// Measurement returns the quantity a requirement tracks over time: the
+// lowest free space across checked mounts and the total memory in bytes,
+// or the disk write speed in bytes per second. False for requirements
+// without one.
+func (r ValidationResult) Measurement() (float64, bool) {
+	if r.measured {
+		return r.measurement, true
+	}
+
+	switch actual := r.actualValue.(type) {
+	case []MountSpace:
+		if len(actual) == 0 {
+			return 0, false
+		}
+		lowest := actual[0].Space().Available()
+		for _, mount := range actual[1:] {
+			lowest = min(lowest, mount.Space().Available())
+		}
+		return float64(lowest), true
+	case SystemResources:
+		return float64(actual.TotalMemory()), true
+	case Throughput:
+		if !actual.IsMeasured() {
+			return 0, false
+		}
+		return actual.DiskWriteBytesPerSec(), true
+	default:
+		return 0, false
+	}
+}
+
+// IsIgnored returns true if the severity policy ignores this problem
+func (r ValidationResult) IsIgnored() bool {
+	return r.status == StatusIgnored
+}
+
 // IsBlocking returns true if this failure blocks installation
 func (r ValidationResult) IsBlocking() bool {
 	return r.status == StatusFail &&
@@ -106,6 +191,8 @@ func (r ValidationResult) FormatMessage() string {
 		return fmt.Sprintf("✗ %s: %s", r.requirementName, r.guidance.Message())
 	case StatusWarning:
 		return fmt.Sprintf("⚠ %s: %s", r.requirementName, r.guidance.Message())
+	case StatusIgnored:
+		return fmt.Sprintf("- %s: Ignored by policy (%s)", r.requirementName, r.guidance.Message())
 	default:
 		return fmt.Sprintf("? %s: Unknown status", r.requirementName)
 	}
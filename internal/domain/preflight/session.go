@@ -1,6 +1,7 @@
 package preflight
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -15,6 +16,7 @@ type ValidationSession struct {
 	completedAt   time.Time
 	overallResult ValidationOutcome
 	results       []ValidationResult
+	policy        SeverityPolicy
 }
 
 // NewValidationSession creates a new validation session
@@ -26,6 +28,41 @@ func NewValidationSession() *ValidationSession {
 	}
 }
 
+// NewValidationSessionWithPolicy creates a validation session whose results
+// are adjusted by a severity policy
+func NewValidationSessionWithPolicy(policy SeverityPolicy) *ValidationSession {
+	session := NewValidationSession()
+	session.policy = policy
+	return session
+}
+
+// ReconstructValidationSession rebuilds a session from persistent storage
+func ReconstructValidationSession(
+	id string,
+	startedAt time.Time,
+	completedAt time.Time,
+	policy SeverityPolicy,
+	results []ValidationResult,
+) (*ValidationSession, error) {
+	if id == "" {
+		return nil, fmt.Errorf("session ID cannot be empty")
+	}
+	if startedAt.IsZero() {
+		return nil, fmt.Errorf("started time cannot be zero")
+	}
+
+	session := &ValidationSession{
+		id:          id,
+		startedAt:   startedAt,
+		completedAt: completedAt,
+		policy:      policy,
+		results:     make([]ValidationResult, len(results)),
+	}
+	copy(session.results, results)
+	session.recalculateOutcome()
+	return session, nil
+}
+
 // ID returns the session identifier
 func (s *ValidationSession) ID() string {
 	return s.id
@@ -65,12 +102,20 @@ func (s *ValidationSession) Results() []ValidationResult {
 	return result
 }
 
-// AddResult adds a validation result to the session
+// SeverityPolicy returns the policy applied to the session's results
+func (s *ValidationSession) SeverityPolicy() SeverityPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy
+}
+
+// AddResult adds a validation result to the session, applying the
+// session's severity policy
 func (s *ValidationSession) AddResult(result ValidationResult) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.results = append(s.results, result)
+	s.results = append(s.results, s.policy.Apply(result))
 	s.recalculateOutcome()
 }
 
@@ -187,10 +232,10 @@ func (s *ValidationSession) recalculateOutcome() {
 		return
 	}
 
-	// Check if all validations passed
+	// Check if all validations passed; ignored problems count as passed
 	allPassed := true
 	for _, result := range s.results {
-		if result.Status() != StatusPass {
+		if result.Status() != StatusPass && !result.IsIgnored() {
 			allPassed = false
 			break
 		}
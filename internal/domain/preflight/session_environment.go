@@ -0,0 +1,84 @@
+package preflight
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KnownDisplayManagers lists the display managers gohan recognizes
+var KnownDisplayManagers = []string{
+	"sddm",
+	"gdm3",
+	"lightdm",
+	"greetd",
+	"ly",
+}
+
+// KnownWaylandCompositors lists compositors whose live session can break when
+// their configs or packages are replaced underneath them
+var KnownWaylandCompositors = []string{
+	"Hyprland",
+	"sway",
+	"gnome-shell",
+	"kwin_wayland",
+	"weston",
+	"river",
+	"labwc",
+}
+
+// SessionEnvironment represents the graphical session state gohan runs in
+type SessionEnvironment struct {
+	displayManagers  []string
+	activeCompositor string
+}
+
+// NewSessionEnvironment creates a new session environment value object
+func NewSessionEnvironment(displayManagers []string, activeCompositor string) SessionEnvironment {
+	managers := make([]string, 0, len(displayManagers))
+	for _, manager := range displayManagers {
+		if manager = strings.TrimSpace(manager); manager != "" {
+			managers = append(managers, manager)
+		}
+	}
+	return SessionEnvironment{
+		displayManagers:  managers,
+		activeCompositor: strings.TrimSpace(activeCompositor),
+	}
+}
+
+// DisplayManagers returns the installed display managers
+func (s SessionEnvironment) DisplayManagers() []string {
+	managers := make([]string, len(s.displayManagers))
+	copy(managers, s.displayManagers)
+	return managers
+}
+
+// HasDisplayManager returns true if any display manager is installed
+func (s SessionEnvironment) HasDisplayManager() bool {
+	return len(s.displayManagers) > 0
+}
+
+// ActiveCompositor returns the running Wayland compositor, if any
+func (s SessionEnvironment) ActiveCompositor() string {
+	return s.activeCompositor
+}
+
+// InLiveSession returns true if a Wayland compositor session is active
+func (s SessionEnvironment) InLiveSession() bool {
+	return s.activeCompositor != ""
+}
+
+// String returns human-readable representation
+func (s SessionEnvironment) String() string {
+	session := "no graphical session"
+	if s.InLiveSession() {
+		session = fmt.Sprintf("%s session active", s.activeCompositor)
+	}
+
+	managers := "no display manager"
+	if s.HasDisplayManager() {
+		managers = "display manager: " + strings.Join(s.displayManagers, ", ")
+	}
+
+	return fmt.Sprintf("%s, %s", session, managers)
+}
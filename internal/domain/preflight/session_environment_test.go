@@ -0,0 +1,34 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionEnvironment(t *testing.T) {
+	t.Run("tty without display manager", func(t *testing.T) {
+		env := preflight.NewSessionEnvironment(nil, "")
+
+		assert.False(t, env.HasDisplayManager())
+		assert.False(t, env.InLiveSession())
+		assert.Equal(t, "no graphical session, no display manager", env.String())
+	})
+
+	t.Run("display manager installed", func(t *testing.T) {
+		env := preflight.NewSessionEnvironment([]string{"sddm", " "}, "")
+
+		assert.True(t, env.HasDisplayManager())
+		assert.Equal(t, []string{"sddm"}, env.DisplayManagers())
+		assert.False(t, env.InLiveSession())
+	})
+
+	t.Run("live compositor session", func(t *testing.T) {
+		env := preflight.NewSessionEnvironment([]string{"gdm3"}, " sway ")
+
+		assert.True(t, env.InLiveSession())
+		assert.Equal(t, "sway", env.ActiveCompositor())
+		assert.Equal(t, "sway session active, display manager: gdm3", env.String())
+	})
+}
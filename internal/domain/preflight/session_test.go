@@ -499,3 +499,28 @@ func createMediumFailureResult(requirement preflight.RequirementName) preflight.
 		preflight.NewUserGuidance("Medium failure", "", nil, ""),
 	)
 }
+
+func TestValidationSession_SeverityPolicy(t *testing.T) {
+	policy, err := preflight.NewSeverityPolicy(map[string]string{
+		"source_repositories": "blocker",
+		"gpu_support":         "ignore",
+	})
+	require.NoError(t, err)
+
+	session := preflight.NewValidationSessionWithPolicy(policy)
+	guidance := preflight.NewUserGuidance("problem", "reason", nil, "")
+
+	session.AddResult(preflight.NewValidationResult(
+		preflight.RequirementGPUSupport, preflight.StatusWarning, preflight.SeverityMedium, nil, nil, guidance))
+	session.Complete()
+
+	assert.Equal(t, policy, session.SeverityPolicy())
+	assert.False(t, session.HasWarnings())
+	assert.Equal(t, preflight.OutcomeSuccess, session.OverallResult())
+
+	session.AddResult(preflight.NewValidationResult(
+		preflight.RequirementSourceRepos, preflight.StatusWarning, preflight.SeverityMedium, nil, nil, guidance))
+
+	assert.True(t, session.HasBlockers())
+	assert.Equal(t, preflight.OutcomeBlocked, session.OverallResult())
+}
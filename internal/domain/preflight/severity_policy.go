@@ -0,0 +1,130 @@
+package preflight
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SeverityOverride replaces the built-in severity of a requirement
+type SeverityOverride string
+
+const (
+	OverrideBlocker SeverityOverride = "blocker" // Any problem blocks installation
+	OverrideWarning SeverityOverride = "warning" // Problems are reported but never block
+	OverrideIgnore  SeverityOverride = "ignore"  // Problems are recorded but not reported
+)
+
+// knownRequirements are the requirements a policy may override
+var knownRequirements = []RequirementName{
+	RequirementDebianVersion,
+	RequirementGPUSupport,
+	RequirementDiskSpace,
+	RequirementInternet,
+	RequirementSourceRepos,
+	RequirementDistribution,
+	RequirementSystemResources,
+	RequirementPowerDaemons,
+	RequirementSession,
+	RequirementSourcesSanity,
+	RequirementThroughput,
+	RequirementWritableRoot,
+}
+
+// SeverityPolicy holds per-requirement severity overrides. The zero value
+// keeps every built-in severity.
+type SeverityPolicy struct {
+	overrides map[RequirementName]SeverityOverride
+}
+
+// NewSeverityPolicy creates a policy from requirement names to overrides,
+// rejecting unknown requirements and override levels
+func NewSeverityPolicy(overrides map[string]string) (SeverityPolicy, error) {
+	policy := SeverityPolicy{overrides: make(map[RequirementName]SeverityOverride, len(overrides))}
+
+	for name, level := range overrides {
+		requirement := RequirementName(strings.TrimSpace(name))
+		if !isKnownRequirement(requirement) {
+			return SeverityPolicy{}, fmt.Errorf("%w: %q", ErrUnknownRequirement, name)
+		}
+
+		override := SeverityOverride(strings.ToLower(strings.TrimSpace(level)))
+		switch override {
+		case OverrideBlocker, OverrideWarning, OverrideIgnore:
+		default:
+			return SeverityPolicy{}, fmt.Errorf("%w: %q for %s (expected blocker, warning or ignore)",
+				ErrInvalidSeverityOverride, level, requirement)
+		}
+
+		policy.overrides[requirement] = override
+	}
+
+	return policy, nil
+}
+
+func isKnownRequirement(requirement RequirementName) bool {
+	for _, known := range knownRequirements {
+		if known == requirement {
+			return true
+		}
+	}
+	return false
+}
+
+// Override returns the override for a requirement, if any
+func (p SeverityPolicy) Override(requirement RequirementName) (SeverityOverride, bool) {
+	override, ok := p.overrides[requirement]
+	return override, ok
+}
+
+// Overrides returns a copy of all overrides
+func (p SeverityPolicy) Overrides() map[RequirementName]SeverityOverride {
+	overrides := make(map[RequirementName]SeverityOverride, len(p.overrides))
+	for requirement, override := range p.overrides {
+		overrides[requirement] = override
+	}
+	return overrides
+}
+
+// IsEmpty returns true if the policy overrides nothing
+func (p SeverityPolicy) IsEmpty() bool {
+	return len(p.overrides) == 0
+}
+
+// String lists the overrides as "requirement=override", sorted by requirement
+func (p SeverityPolicy) String() string {
+	if p.IsEmpty() {
+		return "default"
+	}
+
+	entries := make([]string, 0, len(p.overrides))
+	for requirement, override := range p.overrides {
+		entries = append(entries, fmt.Sprintf("%s=%s", requirement, override))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ", ")
+}
+
+// Apply returns the result with the policy's override for its requirement.
+// Passing results are never changed, and applying a policy twice has the
+// same effect as applying it once.
+func (p SeverityPolicy) Apply(result ValidationResult) ValidationResult {
+	override, ok := p.overrides[result.requirementName]
+	if !ok || result.status == StatusPass {
+		return result
+	}
+
+	adjusted := result
+	adjusted.override = override
+	switch override {
+	case OverrideBlocker:
+		adjusted.status = StatusFail
+		adjusted.severity = SeverityCritical
+	case OverrideWarning:
+		adjusted.status = StatusWarning
+		adjusted.severity = SeverityMedium
+	case OverrideIgnore:
+		adjusted.status = StatusIgnored
+	}
+	return adjusted
+}
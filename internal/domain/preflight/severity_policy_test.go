@@ -0,0 +1,93 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSeverityPolicy(t *testing.T) {
+	t.Run("accepts known requirements and levels", func(t *testing.T) {
+		policy, err := preflight.NewSeverityPolicy(map[string]string{
+			"source_repositories": "Blocker",
+			"gpu_support":         " ignore ",
+		})
+
+		require.NoError(t, err)
+		override, ok := policy.Override(preflight.RequirementSourceRepos)
+		assert.True(t, ok)
+		assert.Equal(t, preflight.OverrideBlocker, override)
+		assert.Equal(t, "gpu_support=ignore, source_repositories=blocker", policy.String())
+	})
+
+	t.Run("rejects unknown requirements", func(t *testing.T) {
+		_, err := preflight.NewSeverityPolicy(map[string]string{"deb_src": "blocker"})
+		assert.ErrorIs(t, err, preflight.ErrUnknownRequirement)
+	})
+
+	t.Run("rejects unknown levels", func(t *testing.T) {
+		_, err := preflight.NewSeverityPolicy(map[string]string{"gpu_support": "critical"})
+		assert.ErrorIs(t, err, preflight.ErrInvalidSeverityOverride)
+	})
+
+	t.Run("empty policy keeps defaults", func(t *testing.T) {
+		policy, err := preflight.NewSeverityPolicy(nil)
+		require.NoError(t, err)
+		assert.True(t, policy.IsEmpty())
+		assert.Equal(t, "default", policy.String())
+	})
+}
+
+func TestSeverityPolicy_Apply(t *testing.T) {
+	policy, err := preflight.NewSeverityPolicy(map[string]string{
+		"source_repositories": "blocker",
+		"gpu_support":         "ignore",
+		"debian_version":      "warning",
+	})
+	require.NoError(t, err)
+
+	guidance := preflight.NewUserGuidance("problem", "reason", nil, "")
+
+	t.Run("blocker makes a warning block installation", func(t *testing.T) {
+		result := policy.Apply(preflight.NewValidationResult(
+			preflight.RequirementSourceRepos, preflight.StatusWarning, preflight.SeverityMedium, nil, nil, guidance))
+
+		assert.True(t, result.IsBlocking())
+		assert.Equal(t, preflight.OverrideBlocker, result.Override())
+	})
+
+	t.Run("warning stops a critical failure from blocking", func(t *testing.T) {
+		result := policy.Apply(preflight.NewValidationResult(
+			preflight.RequirementDebianVersion, preflight.StatusFail, preflight.SeverityCritical, nil, nil, guidance))
+
+		assert.False(t, result.IsBlocking())
+		assert.True(t, result.IsWarning())
+	})
+
+	t.Run("ignore hides the problem", func(t *testing.T) {
+		result := policy.Apply(preflight.NewValidationResult(
+			preflight.RequirementGPUSupport, preflight.StatusWarning, preflight.SeverityMedium, nil, nil, guidance))
+
+		assert.True(t, result.IsIgnored())
+		assert.False(t, result.IsWarning())
+		assert.False(t, result.IsBlocking())
+		assert.Contains(t, result.FormatMessage(), "Ignored by policy")
+	})
+
+	t.Run("passing results are unchanged", func(t *testing.T) {
+		result := policy.Apply(preflight.NewValidationResult(
+			preflight.RequirementSourceRepos, preflight.StatusPass, preflight.SeverityLow, nil, nil, guidance))
+
+		assert.True(t, result.IsPassing())
+		assert.Empty(t, result.Override())
+	})
+
+	t.Run("applying twice is the same as once", func(t *testing.T) {
+		once := policy.Apply(preflight.NewValidationResult(
+			preflight.RequirementDebianVersion, preflight.StatusFail, preflight.SeverityCritical, nil, nil, guidance))
+
+		assert.Equal(t, once, policy.Apply(once))
+	})
+}
@@ -0,0 +1,46 @@
+package preflight
+
+import "fmt"
+
+// SourcesSanity represents the problems found in the apt sources
+type SourcesSanity struct {
+	issues  []string
+	fixable int
+}
+
+// NewSourcesSanity creates a new sources sanity value object. Issues are
+// human-readable "path:line: message" strings; fixable is how many of them
+// can be repaired automatically.
+func NewSourcesSanity(issues []string, fixable int) SourcesSanity {
+	copied := make([]string, len(issues))
+	copy(copied, issues)
+	if fixable > len(copied) {
+		fixable = len(copied)
+	}
+	return SourcesSanity{issues: copied, fixable: fixable}
+}
+
+// Issues returns the problems found, each with its file and line
+func (s SourcesSanity) Issues() []string {
+	issues := make([]string, len(s.issues))
+	copy(issues, s.issues)
+	return issues
+}
+
+// IsClean returns true if no problems were found
+func (s SourcesSanity) IsClean() bool {
+	return len(s.issues) == 0
+}
+
+// FixableCount returns the number of problems that can be fixed automatically
+func (s SourcesSanity) FixableCount() int {
+	return s.fixable
+}
+
+// String returns human-readable representation
+func (s SourcesSanity) String() string {
+	if s.IsClean() {
+		return "no problems found"
+	}
+	return fmt.Sprintf("%d problem(s) found", len(s.issues))
+}
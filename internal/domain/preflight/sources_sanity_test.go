@@ -0,0 +1,27 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourcesSanity(t *testing.T) {
+	t.Run("clean sources", func(t *testing.T) {
+		sanity := preflight.NewSourcesSanity(nil, 0)
+
+		assert.True(t, sanity.IsClean())
+		assert.Equal(t, "no problems found", sanity.String())
+	})
+
+	t.Run("issues with fixable count", func(t *testing.T) {
+		issues := []string{"/etc/apt/sources.list:3: duplicate", "/etc/apt/sources.list:4: mixed"}
+		sanity := preflight.NewSourcesSanity(issues, 5)
+
+		assert.False(t, sanity.IsClean())
+		assert.Equal(t, issues, sanity.Issues())
+		assert.Equal(t, 2, sanity.FixableCount(), "fixable count is capped at the number of issues")
+		assert.Equal(t, "2 problem(s) found", sanity.String())
+	})
+}
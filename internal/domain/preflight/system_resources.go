@@ -0,0 +1,91 @@
+package preflight
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// LiteModeThresholdGB is the total memory in GB below which lite mode is recommended
+	LiteModeThresholdGB = 4
+	// LiteModeMemoryThreshold is LiteModeThresholdGB in bytes
+	LiteModeMemoryThreshold = LiteModeThresholdGB * GB
+)
+
+// SystemResources represents the memory and root storage of the system
+type SystemResources struct {
+	totalMemory   uint64
+	storageDevice string
+	slowStorage   bool
+}
+
+// NewSystemResources creates a new system resources value object
+// slowStorage should be true for rotational disks and SD/eMMC media
+func NewSystemResources(totalMemory uint64, storageDevice string, slowStorage bool) (SystemResources, error) {
+	if totalMemory == 0 {
+		return SystemResources{}, ErrInvalidSystemResources
+	}
+
+	return SystemResources{
+		totalMemory:   totalMemory,
+		storageDevice: strings.TrimSpace(storageDevice),
+		slowStorage:   slowStorage,
+	}, nil
+}
+
+// TotalMemory returns total memory in bytes
+func (r SystemResources) TotalMemory() uint64 {
+	return r.totalMemory
+}
+
+// TotalMemoryGB returns total memory in gigabytes
+func (r SystemResources) TotalMemoryGB() float64 {
+	return float64(r.totalMemory) / float64(GB)
+}
+
+// StorageDevice returns the block device backing the root filesystem
+func (r SystemResources) StorageDevice() string {
+	return r.storageDevice
+}
+
+// HasSlowStorage returns true if the root filesystem is on slow storage
+func (r SystemResources) HasSlowStorage() bool {
+	return r.slowStorage
+}
+
+// HasLowMemory returns true if total memory is below the lite mode threshold
+func (r SystemResources) HasLowMemory() bool {
+	return r.totalMemory < LiteModeMemoryThreshold
+}
+
+// RecommendsLiteMode returns true if the system is low-end enough that the
+// lite profile and rendering mode should be used
+func (r SystemResources) RecommendsLiteMode() bool {
+	return r.HasLowMemory() || r.HasSlowStorage()
+}
+
+// String returns human-readable representation
+func (r SystemResources) String() string {
+	storage := "fast storage"
+	if r.slowStorage {
+		storage = "slow storage"
+	}
+	if r.storageDevice != "" {
+		storage = fmt.Sprintf("%s (%s)", storage, r.storageDevice)
+	}
+	return fmt.Sprintf("%.1f GB RAM, %s", r.TotalMemoryGB(), storage)
+}
+
+// LiteModeRecommended returns true if a system resources check in the
+// results detected a low-end system
+func LiteModeRecommended(results []ValidationResult) bool {
+	for _, result := range results {
+		if result.RequirementName() != RequirementSystemResources {
+			continue
+		}
+		if resources, ok := result.ActualValue().(SystemResources); ok && resources.RecommendsLiteMode() {
+			return true
+		}
+	}
+	return false
+}
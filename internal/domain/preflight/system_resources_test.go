@@ -0,0 +1,73 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSystemResources(t *testing.T) {
+	t.Run("rejects zero memory", func(t *testing.T) {
+		_, err := preflight.NewSystemResources(0, "sda", false)
+		assert.ErrorIs(t, err, preflight.ErrInvalidSystemResources)
+	})
+
+	t.Run("creates resources", func(t *testing.T) {
+		resources, err := preflight.NewSystemResources(8*preflight.GB, " nvme0n1 ", false)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(8*preflight.GB), resources.TotalMemory())
+		assert.Equal(t, 8.0, resources.TotalMemoryGB())
+		assert.Equal(t, "nvme0n1", resources.StorageDevice())
+		assert.Contains(t, resources.String(), "8.0 GB RAM")
+	})
+}
+
+func TestSystemResources_RecommendsLiteMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		memory      uint64
+		slowStorage bool
+		want        bool
+	}{
+		{"capable system", 16 * preflight.GB, false, false},
+		{"exactly 4GB", 4 * preflight.GB, false, false},
+		{"low memory", 2 * preflight.GB, false, true},
+		{"just under 4GB", 4*preflight.GB - preflight.MB, false, true},
+		{"slow storage", 16 * preflight.GB, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, err := preflight.NewSystemResources(tt.memory, "sda", tt.slowStorage)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, resources.RecommendsLiteMode())
+		})
+	}
+}
+
+func TestLiteModeRecommended(t *testing.T) {
+	lowEnd, err := preflight.NewSystemResources(2*preflight.GB, "mmcblk0p2", true)
+	require.NoError(t, err)
+	capable, err := preflight.NewSystemResources(16*preflight.GB, "nvme0n1p2", false)
+	require.NoError(t, err)
+
+	resultFor := func(resources interface{}) preflight.ValidationResult {
+		return preflight.NewValidationResult(
+			preflight.RequirementSystemResources,
+			preflight.StatusPass,
+			preflight.SeverityLow,
+			resources,
+			"4 GB RAM and fast storage",
+			preflight.UserGuidance{},
+		)
+	}
+
+	assert.True(t, preflight.LiteModeRecommended([]preflight.ValidationResult{resultFor(lowEnd)}))
+	assert.False(t, preflight.LiteModeRecommended([]preflight.ValidationResult{resultFor(capable)}))
+	assert.False(t, preflight.LiteModeRecommended([]preflight.ValidationResult{resultFor(nil)}))
+	assert.False(t, preflight.LiteModeRecommended(nil))
+}
@@ -0,0 +1,86 @@
+package preflight
+
+import "fmt"
+
+const (
+	// SlowDiskWriteThreshold is the disk write speed in bytes per second
+	// below which package unpacking is noticeably slow (typical of eMMC/SD)
+	SlowDiskWriteThreshold = 40 * MB
+	// SlowDownloadThreshold is the download speed in bytes per second below
+	// which package downloads dominate installation time
+	SlowDownloadThreshold = 1 * MB
+)
+
+// Throughput represents sampled disk write and download speeds.
+// A zero speed means the sample could not be taken.
+type Throughput struct {
+	diskWrite float64
+	download  float64
+}
+
+// NewThroughput creates a new throughput value object from speeds in bytes per second
+func NewThroughput(diskWriteBytesPerSec, downloadBytesPerSec float64) (Throughput, error) {
+	if diskWriteBytesPerSec < 0 || downloadBytesPerSec < 0 {
+		return Throughput{}, ErrInvalidThroughput
+	}
+
+	return Throughput{
+		diskWrite: diskWriteBytesPerSec,
+		download:  downloadBytesPerSec,
+	}, nil
+}
+
+// DiskWriteBytesPerSec returns the sampled disk write speed
+func (t Throughput) DiskWriteBytesPerSec() float64 {
+	return t.diskWrite
+}
+
+// DownloadBytesPerSec returns the sampled apt download speed
+func (t Throughput) DownloadBytesPerSec() float64 {
+	return t.download
+}
+
+// IsMeasured returns true if at least one speed was sampled
+func (t Throughput) IsMeasured() bool {
+	return t.diskWrite > 0 || t.download > 0
+}
+
+// HasSlowDisk returns true if the disk was sampled and is slow
+func (t Throughput) HasSlowDisk() bool {
+	return t.diskWrite > 0 && t.diskWrite < SlowDiskWriteThreshold
+}
+
+// HasSlowNetwork returns true if the download was sampled and is slow
+func (t Throughput) HasSlowNetwork() bool {
+	return t.download > 0 && t.download < SlowDownloadThreshold
+}
+
+// String returns human-readable representation
+func (t Throughput) String() string {
+	return fmt.Sprintf("disk write %s, download %s", formatSpeed(t.diskWrite), formatSpeed(t.download))
+}
+
+func formatSpeed(bytesPerSec float64) string {
+	switch {
+	case bytesPerSec <= 0:
+		return "not measured"
+	case bytesPerSec >= MB:
+		return fmt.Sprintf("%.1f MB/s", bytesPerSec/MB)
+	default:
+		return fmt.Sprintf("%.0f KB/s", bytesPerSec/1024)
+	}
+}
+
+// MeasuredThroughput returns the throughput sampled by a throughput check
+// in the results, if any
+func MeasuredThroughput(results []ValidationResult) (Throughput, bool) {
+	for _, result := range results {
+		if result.RequirementName() != RequirementThroughput {
+			continue
+		}
+		if throughput, ok := result.ActualValue().(Throughput); ok && throughput.IsMeasured() {
+			return throughput, true
+		}
+	}
+	return Throughput{}, false
+}
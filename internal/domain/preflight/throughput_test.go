@@ -0,0 +1,51 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewThroughput(t *testing.T) {
+	t.Run("rejects negative speeds", func(t *testing.T) {
+		_, err := preflight.NewThroughput(-1, 0)
+		assert.ErrorIs(t, err, preflight.ErrInvalidThroughput)
+	})
+
+	t.Run("unmeasured", func(t *testing.T) {
+		throughput, err := preflight.NewThroughput(0, 0)
+		require.NoError(t, err)
+
+		assert.False(t, throughput.IsMeasured())
+		assert.False(t, throughput.HasSlowDisk(), "unmeasured disk is not reported as slow")
+		assert.Equal(t, "disk write not measured, download not measured", throughput.String())
+	})
+
+	t.Run("slow eMMC and throttled network", func(t *testing.T) {
+		throughput, err := preflight.NewThroughput(20*preflight.MB, 512*1024)
+		require.NoError(t, err)
+
+		assert.True(t, throughput.HasSlowDisk())
+		assert.True(t, throughput.HasSlowNetwork())
+		assert.Equal(t, "disk write 20.0 MB/s, download 512 KB/s", throughput.String())
+	})
+}
+
+func TestMeasuredThroughput(t *testing.T) {
+	throughput, err := preflight.NewThroughput(200*preflight.MB, 10*preflight.MB)
+	require.NoError(t, err)
+
+	results := []preflight.ValidationResult{
+		preflight.NewValidationResult(preflight.RequirementThroughput, preflight.StatusPass, preflight.SeverityLow,
+			throughput, "", preflight.UserGuidance{}),
+	}
+
+	measured, ok := preflight.MeasuredThroughput(results)
+	require.True(t, ok)
+	assert.Equal(t, throughput, measured)
+
+	_, ok = preflight.MeasuredThroughput(nil)
+	assert.False(t, ok)
+}
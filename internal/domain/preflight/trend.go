@@ -0,0 +1,84 @@
+package preflight
+
+// TrendDirection describes how a measurement moved across sessions
+type TrendDirection string
+
+const (
+	TrendShrinking TrendDirection = "shrinking"
+	TrendGrowing   TrendDirection = "growing"
+)
+
+// MeasurementTrend is a requirement's measurement that moved steadily in
+// one direction across consecutive sessions
+type MeasurementTrend struct {
+	Requirement RequirementName
+	Direction   TrendDirection
+	First       float64
+	Last        float64
+	Runs        int
+}
+
+// MeasurementTrends finds measurements that only shrank or only grew across
+// sessions, given oldest first. Requirements measured in fewer than two
+// sessions, or that went both ways, are left out.
+func MeasurementTrends(sessions []*ValidationSession) []MeasurementTrend {
+	series := make(map[RequirementName][]float64)
+	var order []RequirementName
+
+	for _, session := range sessions {
+		for _, result := range session.Results() {
+			value, ok := result.Measurement()
+			if !ok {
+				continue
+			}
+			if _, seen := series[result.RequirementName()]; !seen {
+				order = append(order, result.RequirementName())
+			}
+			series[result.RequirementName()] = append(series[result.RequirementName()], value)
+		}
+	}
+
+	var trends []MeasurementTrend
+	for _, requirement := range order {
+		values := series[requirement]
+		if len(values) < 2 {
+			continue
+		}
+
+		direction, ok := steadyDirection(values)
+		if !ok {
+			continue
+		}
+		trends = append(trends, MeasurementTrend{
+			Requirement: requirement,
+			Direction:   direction,
+			First:       values[0],
+			Last:        values[len(values)-1],
+			Runs:        len(values),
+		})
+	}
+	return trends
+}
+
+// steadyDirection reports whether values never rose or never fell, and
+// changed overall
+func steadyDirection(values []float64) (TrendDirection, bool) {
+	first, last := values[0], values[len(values)-1]
+	if first == last {
+		return "", false
+	}
+
+	direction := TrendGrowing
+	if last < first {
+		direction = TrendShrinking
+	}
+	for i := 1; i < len(values); i++ {
+		if direction == TrendShrinking && values[i] > values[i-1] {
+			return "", false
+		}
+		if direction == TrendGrowing && values[i] < values[i-1] {
+			return "", false
+		}
+	}
+	return direction, true
+}
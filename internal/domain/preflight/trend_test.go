@@ -0,0 +1,54 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasurementTrends(t *testing.T) {
+	diskSession := func(available uint64) *preflight.ValidationSession {
+		space, err := preflight.NewDiskSpace(available*preflight.GB, 500*preflight.GB, "/")
+		require.NoError(t, err)
+
+		session := preflight.NewValidationSession()
+		session.AddResult(preflight.NewValidationResult(
+			preflight.RequirementDiskSpace,
+			preflight.StatusPass,
+			preflight.SeverityLow,
+			[]preflight.MountSpace{preflight.NewMountSpace(space, nil)},
+			nil,
+			preflight.NewUserGuidance("", "", nil, ""),
+		))
+		session.AddResult(createPassResult(preflight.RequirementDebianVersion))
+		return session
+	}
+
+	t.Run("reports steadily shrinking disk space", func(t *testing.T) {
+		trends := preflight.MeasurementTrends([]*preflight.ValidationSession{
+			diskSession(40), diskSession(30), diskSession(30), diskSession(20),
+		})
+
+		require.Len(t, trends, 1)
+		assert.Equal(t, preflight.RequirementDiskSpace, trends[0].Requirement)
+		assert.Equal(t, preflight.TrendShrinking, trends[0].Direction)
+		assert.Equal(t, float64(40*preflight.GB), trends[0].First)
+		assert.Equal(t, float64(20*preflight.GB), trends[0].Last)
+		assert.Equal(t, 4, trends[0].Runs)
+	})
+
+	t.Run("ignores measurements that went both ways", func(t *testing.T) {
+		trends := preflight.MeasurementTrends([]*preflight.ValidationSession{
+			diskSession(40), diskSession(20), diskSession(30),
+		})
+
+		assert.Empty(t, trends)
+	})
+
+	t.Run("ignores unchanged and single measurements", func(t *testing.T) {
+		assert.Empty(t, preflight.MeasurementTrends([]*preflight.ValidationSession{diskSession(40), diskSession(40)}))
+		assert.Empty(t, preflight.MeasurementTrends([]*preflight.ValidationSession{diskSession(40)}))
+	})
+}
@@ -9,6 +9,7 @@ const (
 	StatusPass    ValidationStatus = "pass"
 	StatusFail    ValidationStatus = "fail"
 	StatusWarning ValidationStatus = "warning"
+	StatusIgnored ValidationStatus = "ignored" // Problem ignored by the severity policy
 )
 
 // Severity indicates the impact level of a validation failure
@@ -35,12 +36,18 @@ const (
 type RequirementName string
 
 const (
-	RequirementDebianVersion RequirementName = "debian_version"
-	RequirementGPUSupport    RequirementName = "gpu_support"
-	RequirementDiskSpace     RequirementName = "disk_space"
-	RequirementInternet      RequirementName = "internet_connectivity"
-	RequirementSourceRepos   RequirementName = "source_repositories"
-	RequirementDistribution  RequirementName = "distribution"
+	RequirementDebianVersion   RequirementName = "debian_version"
+	RequirementGPUSupport      RequirementName = "gpu_support"
+	RequirementDiskSpace       RequirementName = "disk_space"
+	RequirementInternet        RequirementName = "internet_connectivity"
+	RequirementSourceRepos     RequirementName = "source_repositories"
+	RequirementDistribution    RequirementName = "distribution"
+	RequirementSystemResources RequirementName = "system_resources"
+	RequirementPowerDaemons    RequirementName = "power_daemons"
+	RequirementSession         RequirementName = "graphical_session"
+	RequirementSourcesSanity   RequirementName = "apt_sources"
+	RequirementThroughput      RequirementName = "io_throughput"
+	RequirementWritableRoot    RequirementName = "writable_filesystem"
 )
 
 // GPUVendor represents GPU manufacturers
@@ -53,6 +60,19 @@ const (
 	GPUVendorUnknown GPUVendor = "unknown"
 )
 
+// GPUKernelDriver is the kernel module bound to a GPU
+type GPUKernelDriver string
+
+const (
+	GPUDriverNouveau GPUKernelDriver = "nouveau"
+	GPUDriverNVIDIA  GPUKernelDriver = "nvidia"
+	GPUDriverAMDGPU  GPUKernelDriver = "amdgpu"
+	GPUDriverRadeon  GPUKernelDriver = "radeon"
+	GPUDriverI915    GPUKernelDriver = "i915"
+	GPUDriverXe      GPUKernelDriver = "xe"
+	GPUDriverNone    GPUKernelDriver = ""
+)
+
 // DomainEvent is the base interface for all domain events
 type DomainEvent interface {
 	OccurredAt() time.Time
@@ -17,6 +17,7 @@ type Validator interface {
 // ValidationOrchestrator coordinates all validation checks
 type ValidationOrchestrator struct {
 	validators []Validator
+	policy     SeverityPolicy
 }
 
 // NewValidationOrchestrator creates a new orchestrator
@@ -26,9 +27,17 @@ func NewValidationOrchestrator(validators []Validator) *ValidationOrchestrator {
 	}
 }
 
+// WithSeverityPolicy returns a copy of the orchestrator that adjusts
+// results with a severity policy
+func (o *ValidationOrchestrator) WithSeverityPolicy(policy SeverityPolicy) *ValidationOrchestrator {
+	copied := *o
+	copied.policy = policy
+	return &copied
+}
+
 // ExecuteValidations runs all validators and returns a session
 func (o *ValidationOrchestrator) ExecuteValidations(ctx context.Context) *ValidationSession {
-	session := NewValidationSession()
+	session := NewValidationSessionWithPolicy(o.policy)
 
 	for _, validator := range o.validators {
 		result := validator.Validate(ctx)
@@ -44,10 +53,10 @@ func (o *ValidationOrchestrator) ExecuteValidationsWithProgress(
 	ctx context.Context,
 	progressFn func(validator string, result ValidationResult),
 ) *ValidationSession {
-	session := NewValidationSession()
+	session := NewValidationSessionWithPolicy(o.policy)
 
 	for _, validator := range o.validators {
-		result := validator.Validate(ctx)
+		result := o.policy.Apply(validator.Validate(ctx))
 		session.AddResult(result)
 
 		if progressFn != nil {
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidFingerprint is returned when a fingerprint is not a v4 OpenPGP fingerprint
+	ErrInvalidFingerprint = errors.New("fingerprint must be 40 hexadecimal characters")
+	// ErrFingerprintMismatch is returned when a downloaded key does not match the pinned fingerprint
+	ErrFingerprintMismatch = errors.New("key fingerprint does not match pinned value")
+	// ErrUnexpectedKeys is returned when a downloaded key bundles primary keys besides the pinned one
+	ErrUnexpectedKeys = errors.New("key data must contain exactly one primary key")
+	// ErrInvalidKeyName is returned when a key name cannot be used as a keyring file name
+	ErrInvalidKeyName = errors.New("key name must contain only lowercase letters, digits and dashes")
+	// ErrKeyNotFound is returned when a managed key does not exist
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExists is returned when adding a key under a name that is already managed
+	ErrKeyExists = errors.New("key already exists")
+	// ErrFileExists is returned when a keyring or sources file gohan would create already exists
+	ErrFileExists = errors.New("file already exists and is not managed by gohan")
+)
+
+// KeyringDir is the directory holding repository signing keyrings.
+// Keys placed here are only trusted for sources that reference them via Signed-By.
+const KeyringDir = "/usr/share/keyrings"
+
+var keyNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// KeyFingerprint is a normalized OpenPGP v4 fingerprint
+type KeyFingerprint struct {
+	value string
+}
+
+// NewKeyFingerprint parses a fingerprint, accepting spaces and lowercase hex
+func NewKeyFingerprint(raw string) (KeyFingerprint, error) {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(raw), ""))
+	normalized = strings.TrimPrefix(normalized, "0X")
+
+	if len(normalized) != 40 {
+		return KeyFingerprint{}, fmt.Errorf("%w: got %d characters", ErrInvalidFingerprint, len(normalized))
+	}
+	for _, r := range normalized {
+		if !strings.ContainsRune("0123456789ABCDEF", r) {
+			return KeyFingerprint{}, fmt.Errorf("%w: invalid character %q", ErrInvalidFingerprint, r)
+		}
+	}
+
+	return KeyFingerprint{value: normalized}, nil
+}
+
+// String returns the fingerprint as uppercase hex without spaces
+func (f KeyFingerprint) String() string {
+	return f.value
+}
+
+// Pretty returns the fingerprint grouped in blocks of four characters
+func (f KeyFingerprint) Pretty() string {
+	var groups []string
+	for i := 0; i < len(f.value); i += 4 {
+		groups = append(groups, f.value[i:i+4])
+	}
+	return strings.Join(groups, " ")
+}
+
+// Equals compares two fingerprints
+func (f KeyFingerprint) Equals(other KeyFingerprint) bool {
+	return f.value == other.value
+}
+
+// IsZero returns true for an unset fingerprint
+func (f KeyFingerprint) IsZero() bool {
+	return f.value == ""
+}
+
+// ValidateKeyName checks that a key name is safe to use as a file name
+func ValidateKeyName(name string) error {
+	if !keyNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidKeyName, name)
+	}
+	return nil
+}
+
+// KeyringFileName returns the keyring file name for a managed key name
+func KeyringFileName(name string) string {
+	return name + "-archive-keyring.gpg"
+}
+
+// PublicKey is a primary key found in downloaded key data, with the
+// fingerprints of its subkeys
+type PublicKey struct {
+	Fingerprint KeyFingerprint
+	Subkeys     []KeyFingerprint
+}
+
+// VerifyFingerprints ensures downloaded key data holds exactly one primary
+// key, the pinned one. The whole data becomes the keyring apt trusts, so an
+// extra primary key would be trusted too; a subkey cannot be pinned.
+func VerifyFingerprints(pinned KeyFingerprint, keys []PublicKey) error {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.Fingerprint.String())
+	}
+	if len(keys) != 1 {
+		return fmt.Errorf("%w: expected only %s, key contains %d primary keys [%s]",
+			ErrUnexpectedKeys, pinned, len(keys), strings.Join(names, ", "))
+	}
+
+	key := keys[0]
+	if key.Fingerprint.Equals(pinned) {
+		return nil
+	}
+	for _, subkey := range key.Subkeys {
+		if subkey.Equals(pinned) {
+			return fmt.Errorf("%w: %s is a subkey of %s; pin the primary key", ErrFingerprintMismatch, pinned, key.Fingerprint)
+		}
+	}
+	return fmt.Errorf("%w: expected %s, key is %s", ErrFingerprintMismatch, pinned, key.Fingerprint)
+}
+
+// ManagedKey is a repository signing key installed and tracked by gohan
+type ManagedKey struct {
+	Name        string
+	Fingerprint KeyFingerprint
+	KeyringPath string
+	SourceURL   string
+	SourcesPath string // deb822 file referencing the key, if gohan created one
+	AddedAt     time.Time
+}
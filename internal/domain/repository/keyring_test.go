@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyFingerprint(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "uppercase without spaces",
+			raw:  "045D88D9EE300099ABF76C7B45A5D14ABD6B43EA",
+			want: "045D88D9EE300099ABF76C7B45A5D14ABD6B43EA",
+		},
+		{
+			name: "grouped lowercase",
+			raw:  "045d 88d9 ee30 0099 abf7  6c7b 45a5 d14a bd6b 43ea",
+			want: "045D88D9EE300099ABF76C7B45A5D14ABD6B43EA",
+		},
+		{
+			name:    "short key id",
+			raw:     "BD6B43EA",
+			wantErr: true,
+		},
+		{
+			name:    "non-hex characters",
+			raw:     "ZZ5D88D9EE300099ABF76C7B45A5D14ABD6B43EA",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.NewKeyFingerprint(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, repository.ErrInvalidFingerprint)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestKeyFingerprint_Pretty(t *testing.T) {
+	fp, err := repository.NewKeyFingerprint("045D88D9EE300099ABF76C7B45A5D14ABD6B43EA")
+	require.NoError(t, err)
+	assert.Equal(t, "045D 88D9 EE30 0099 ABF7 6C7B 45A5 D14A BD6B 43EA", fp.Pretty())
+}
+
+func TestVerifyFingerprints(t *testing.T) {
+	pinned, _ := repository.NewKeyFingerprint("045D88D9EE300099ABF76C7B45A5D14ABD6B43EA")
+	other, _ := repository.NewKeyFingerprint("0000000000000000000000000000000000000000")
+
+	t.Run("accepts the pinned primary key with its subkeys", func(t *testing.T) {
+		keys := []repository.PublicKey{{Fingerprint: pinned, Subkeys: []repository.KeyFingerprint{other}}}
+		assert.NoError(t, repository.VerifyFingerprints(pinned, keys))
+	})
+
+	t.Run("rejects another key", func(t *testing.T) {
+		keys := []repository.PublicKey{{Fingerprint: other}}
+		assert.ErrorIs(t, repository.VerifyFingerprints(pinned, keys), repository.ErrFingerprintMismatch)
+	})
+
+	t.Run("rejects a bundle with two primary keys", func(t *testing.T) {
+		keys := []repository.PublicKey{{Fingerprint: pinned}, {Fingerprint: other}}
+		assert.ErrorIs(t, repository.VerifyFingerprints(pinned, keys), repository.ErrUnexpectedKeys)
+	})
+
+	t.Run("does not accept a subkey as the pin", func(t *testing.T) {
+		keys := []repository.PublicKey{{Fingerprint: other, Subkeys: []repository.KeyFingerprint{pinned}}}
+		assert.ErrorIs(t, repository.VerifyFingerprints(pinned, keys), repository.ErrFingerprintMismatch)
+	})
+}
+
+func TestValidateKeyName(t *testing.T) {
+	assert.NoError(t, repository.ValidateKeyName("hyprland-extras"))
+	assert.ErrorIs(t, repository.ValidateKeyName("../etc"), repository.ErrInvalidKeyName)
+	assert.ErrorIs(t, repository.ValidateKeyName("Hyprland"), repository.ErrInvalidKeyName)
+	assert.Equal(t, "hyprland-extras-archive-keyring.gpg", repository.KeyringFileName("hyprland-extras"))
+}
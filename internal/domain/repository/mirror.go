@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidMirrorURI is returned when a mirror URI is not an http(s) URL
+	ErrInvalidMirrorURI = errors.New("mirror URI must start with http:// or https://")
+	// ErrNoReachableMirror is returned when no benchmarked mirror responded
+	ErrNoReachableMirror = errors.New("no reachable mirror found")
+)
+
+// DefaultDebianMirrors is the list of well-known Debian mirrors benchmarked
+// when the user does not provide their own list
+var DefaultDebianMirrors = []string{
+	"http://deb.debian.org/debian",
+	"http://ftp.us.debian.org/debian",
+	"http://ftp.de.debian.org/debian",
+	"http://ftp.uk.debian.org/debian",
+	"http://ftp.fr.debian.org/debian",
+	"http://mirrors.edge.kernel.org/debian",
+	"http://mirror.csclub.uwaterloo.ca/debian",
+}
+
+// Mirror represents a Debian archive mirror
+type Mirror struct {
+	uri string
+}
+
+// NewMirror creates a new mirror from its base URI
+func NewMirror(uri string) (Mirror, error) {
+	uri = strings.TrimRight(strings.TrimSpace(uri), "/")
+	if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+		return Mirror{}, fmt.Errorf("%w: %q", ErrInvalidMirrorURI, uri)
+	}
+
+	return Mirror{uri: uri}, nil
+}
+
+// URI returns the mirror base URI without a trailing slash
+func (m Mirror) URI() string {
+	return m.uri
+}
+
+// Host returns the mirror host name
+func (m Mirror) Host() string {
+	host := strings.TrimPrefix(strings.TrimPrefix(m.uri, "https://"), "http://")
+	if idx := strings.Index(host, "/"); idx >= 0 {
+		host = host[:idx]
+	}
+	return host
+}
+
+// ReleaseURL returns the URL of the Release file for a suite on this mirror
+func (m Mirror) ReleaseURL(suite string) string {
+	return fmt.Sprintf("%s/dists/%s/Release", m.uri, suite)
+}
+
+// MirrorBenchmark holds the measured performance of a single mirror
+type MirrorBenchmark struct {
+	Mirror     Mirror
+	Latency    time.Duration // Time to first response
+	Throughput float64       // Sampled download speed in bytes per second
+	Err        error         // Non-nil if the mirror could not be benchmarked
+}
+
+// Reachable returns true if the mirror responded successfully
+func (b MirrorBenchmark) Reachable() bool {
+	return b.Err == nil
+}
+
+// RankMirrors sorts benchmarks from fastest to slowest.
+// Reachable mirrors come first, ordered by throughput and then latency.
+func RankMirrors(benchmarks []MirrorBenchmark) []MirrorBenchmark {
+	ranked := make([]MirrorBenchmark, len(benchmarks))
+	copy(ranked, benchmarks)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Reachable() != b.Reachable() {
+			return a.Reachable()
+		}
+		if a.Throughput != b.Throughput {
+			return a.Throughput > b.Throughput
+		}
+		return a.Latency < b.Latency
+	})
+
+	return ranked
+}
+
+// FastestMirror returns the best reachable mirror from a set of benchmarks
+func FastestMirror(benchmarks []MirrorBenchmark) (MirrorBenchmark, error) {
+	ranked := RankMirrors(benchmarks)
+	if len(ranked) == 0 || !ranked[0].Reachable() {
+		return MirrorBenchmark{}, ErrNoReachableMirror
+	}
+	return ranked[0], nil
+}
+
+// IsDebianArchiveURI reports whether a sources entry URI points at the main
+// Debian archive (as opposed to the security archive or a third-party repo)
+func IsDebianArchiveURI(uri string) bool {
+	if strings.Contains(uri, "security.debian.org") || strings.Contains(uri, "debian-security") {
+		return false
+	}
+	return strings.HasSuffix(strings.TrimRight(uri, "/"), "/debian")
+}
+
+// SwitchMirror rewrites all Debian archive entries to use the given mirror.
+// Security and third-party entries are left untouched. Returns the number of
+// entries rewritten.
+func (rc *RepositoryConfig) SwitchMirror(mirror Mirror) int {
+	rewritten := 0
+	for i := range rc.entries {
+		if !IsDebianArchiveURI(rc.entries[i].URI) {
+			continue
+		}
+		if strings.TrimRight(rc.entries[i].URI, "/") == mirror.URI() {
+			continue
+		}
+		rc.entries[i].URI = mirror.URI()
+		rewritten++
+	}
+	return rewritten
+}
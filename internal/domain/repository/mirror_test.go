@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMirror(t *testing.T) {
+	t.Run("accepts http mirror and trims trailing slash", func(t *testing.T) {
+		mirror, err := repository.NewMirror("http://ftp.de.debian.org/debian/")
+		require.NoError(t, err)
+		assert.Equal(t, "http://ftp.de.debian.org/debian", mirror.URI())
+		assert.Equal(t, "ftp.de.debian.org", mirror.Host())
+		assert.Equal(t, "http://ftp.de.debian.org/debian/dists/sid/Release", mirror.ReleaseURL("sid"))
+	})
+
+	t.Run("rejects non-http URI", func(t *testing.T) {
+		_, err := repository.NewMirror("ftp://ftp.de.debian.org/debian")
+		assert.ErrorIs(t, err, repository.ErrInvalidMirrorURI)
+	})
+}
+
+func TestRankMirrors(t *testing.T) {
+	slow, _ := repository.NewMirror("http://slow.example/debian")
+	fast, _ := repository.NewMirror("http://fast.example/debian")
+	down, _ := repository.NewMirror("http://down.example/debian")
+
+	benchmarks := []repository.MirrorBenchmark{
+		{Mirror: down, Err: errors.New("timeout")},
+		{Mirror: slow, Latency: 200 * time.Millisecond, Throughput: 100_000},
+		{Mirror: fast, Latency: 50 * time.Millisecond, Throughput: 900_000},
+	}
+
+	ranked := repository.RankMirrors(benchmarks)
+	require.Len(t, ranked, 3)
+	assert.Equal(t, fast, ranked[0].Mirror)
+	assert.Equal(t, slow, ranked[1].Mirror)
+	assert.Equal(t, down, ranked[2].Mirror)
+
+	best, err := repository.FastestMirror(benchmarks)
+	require.NoError(t, err)
+	assert.Equal(t, fast, best.Mirror)
+}
+
+func TestFastestMirror_NoneReachable(t *testing.T) {
+	down, _ := repository.NewMirror("http://down.example/debian")
+
+	_, err := repository.FastestMirror([]repository.MirrorBenchmark{
+		{Mirror: down, Err: errors.New("connection refused")},
+	})
+	assert.ErrorIs(t, err, repository.ErrNoReachableMirror)
+}
+
+func TestRepositoryConfig_SwitchMirror(t *testing.T) {
+	config, err := repository.NewRepositoryConfig([]repository.SourceEntry{
+		{Type: "deb", URI: "http://deb.debian.org/debian", Suite: "sid", Components: []string{"main"}},
+		{Type: "deb-src", URI: "http://deb.debian.org/debian/", Suite: "sid", Components: []string{"main"}},
+		{Type: "deb", URI: "http://security.debian.org/debian-security", Suite: "trixie-security", Components: []string{"main"}},
+		{Type: "deb", URI: "https://repo.example.com/apt", Suite: "stable", Components: []string{"main"}},
+	})
+	require.NoError(t, err)
+
+	mirror, _ := repository.NewMirror("http://ftp.de.debian.org/debian")
+	rewritten := config.SwitchMirror(mirror)
+
+	assert.Equal(t, 2, rewritten)
+	entries := config.Entries()
+	assert.Equal(t, "http://ftp.de.debian.org/debian", entries[0].URI)
+	assert.Equal(t, "http://ftp.de.debian.org/debian", entries[1].URI)
+	assert.Equal(t, "http://security.debian.org/debian-security", entries[2].URI)
+	assert.Equal(t, "https://repo.example.com/apt", entries[3].URI)
+}
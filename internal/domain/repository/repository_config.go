@@ -19,17 +19,56 @@ var (
 	ErrMissingComponents = errors.New("entry must have at least one component")
 )
 
-// SourceEntry represents a single line in sources.list
+// SourcesFormat identifies the on-disk syntax of an apt sources file
+type SourcesFormat string
+
+const (
+	// SourcesFormatOneLine is the legacy one-line sources.list format
+	SourcesFormatOneLine SourcesFormat = "one-line"
+	// SourcesFormatDeb822 is the deb822 .sources format
+	SourcesFormatDeb822 SourcesFormat = "deb822"
+)
+
+// SourcesFormatForPath returns the format implied by a sources file name
+func SourcesFormatForPath(path string) SourcesFormat {
+	if strings.HasSuffix(path, ".sources") {
+		return SourcesFormatDeb822
+	}
+	return SourcesFormatOneLine
+}
+
+// SourceEntry represents a single type/URI/suite combination from apt sources
 type SourceEntry struct {
 	Type       string   // "deb" or "deb-src"
 	URI        string   // Repository URL
 	Suite      string   // Distribution suite (e.g., "sid", "trixie")
 	Components []string // Components (e.g., "main", "contrib", "non-free")
+	SignedBy   string   // Keyring path or fingerprint (signed-by option / Signed-By field)
+	Options    []string // Other one-line options in key=value form (e.g., "arch=amd64")
+	Disabled   bool     // True when a deb822 stanza has "Enabled: no"
 }
 
 // String formats the source entry as a sources.list line
 func (se SourceEntry) String() string {
-	return fmt.Sprintf("%s %s %s %s", se.Type, se.URI, se.Suite, strings.Join(se.Components, " "))
+	options := se.Options
+	if se.SignedBy != "" {
+		options = append([]string{"signed-by=" + se.SignedBy}, options...)
+	}
+
+	fields := []string{se.Type}
+	if len(options) > 0 {
+		fields = append(fields, "["+strings.Join(options, " ")+"]")
+	}
+	fields = append(fields, se.URI, se.Suite)
+	fields = append(fields, se.Components...)
+
+	return strings.Join(fields, " ")
+}
+
+// IsFlat returns true for flat repositories whose suite is an exact path
+// (ending in "/") and which therefore carry no components
+func (se SourceEntry) IsFlat() bool {
+	return strings.HasSuffix(se.Suite, "/")
 }
 
 // Validate checks if the source entry is valid
@@ -46,7 +85,7 @@ func (se SourceEntry) Validate() error {
 		return ErrMissingSuite
 	}
 
-	if len(se.Components) == 0 {
+	if len(se.Components) == 0 && !se.IsFlat() {
 		return ErrMissingComponents
 	}
 
@@ -100,30 +139,30 @@ func (rc *RepositoryConfig) Entries() []SourceEntry {
 	return entriesCopy
 }
 
-// HasComponent checks if any entry includes a specific component
+// HasComponent checks if any enabled entry includes a specific component
 func (rc *RepositoryConfig) HasComponent(component string) bool {
 	for _, entry := range rc.entries {
-		if entry.HasComponent(component) {
+		if !entry.Disabled && entry.HasComponent(component) {
 			return true
 		}
 	}
 	return false
 }
 
-// HasDebSrc checks if there are any deb-src entries
+// HasDebSrc checks if there are any enabled deb-src entries
 func (rc *RepositoryConfig) HasDebSrc() bool {
 	for _, entry := range rc.entries {
-		if entry.Type == "deb-src" {
+		if !entry.Disabled && entry.Type == "deb-src" {
 			return true
 		}
 	}
 	return false
 }
 
-// AddComponent adds a component to all deb entries
+// AddComponent adds a component to all deb entries except flat repositories
 func (rc *RepositoryConfig) AddComponent(component string) error {
 	for i := range rc.entries {
-		if rc.entries[i].Type == "deb" {
+		if rc.entries[i].Type == "deb" && !rc.entries[i].IsFlat() {
 			rc.entries[i].AddComponent(component)
 		}
 	}
@@ -153,6 +192,9 @@ func (rc *RepositoryConfig) EnableDebSrc() {
 					URI:        entry.URI,
 					Suite:      entry.Suite,
 					Components: make([]string, len(entry.Components)),
+					SignedBy:   entry.SignedBy,
+					Options:    append([]string(nil), entry.Options...),
+					Disabled:   entry.Disabled,
 				}
 				copy(debSrcEntry.Components, entry.Components)
 				newEntries = append(newEntries, debSrcEntry)
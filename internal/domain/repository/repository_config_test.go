@@ -227,6 +227,17 @@ func TestRepositoryConfig_EnableDebSrc(t *testing.T) {
 	})
 }
 
+func TestRepositoryConfig_IgnoresDisabledEntries(t *testing.T) {
+	config, err := repository.NewRepositoryConfig([]repository.SourceEntry{
+		{Type: "deb", URI: "http://deb.debian.org/debian", Suite: "sid", Components: []string{"main"}},
+		{Type: "deb-src", URI: "http://deb.debian.org/debian", Suite: "sid", Components: []string{"main", "non-free"}, Disabled: true},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, config.HasDebSrc())
+	assert.False(t, config.HasComponent("non-free"))
+}
+
 func TestRepositoryConfig_String(t *testing.T) {
 	t.Run("formats sources.list content", func(t *testing.T) {
 		entries := []repository.SourceEntry{
@@ -289,6 +300,18 @@ func TestSourceEntry_String(t *testing.T) {
 			},
 			want: "deb-src http://deb.debian.org/debian sid main",
 		},
+		{
+			name: "entry with signed-by and options",
+			entry: repository.SourceEntry{
+				Type:       "deb",
+				URI:        "https://repo.example.com/apt",
+				Suite:      "stable",
+				Components: []string{"main"},
+				SignedBy:   "/usr/share/keyrings/example.gpg",
+				Options:    []string{"arch=amd64"},
+			},
+			want: "deb [signed-by=/usr/share/keyrings/example.gpg arch=amd64] https://repo.example.com/apt stable main",
+		},
 	}
 
 	for _, tt := range tests {
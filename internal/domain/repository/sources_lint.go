@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SourceIssueKind identifies a class of problem in apt sources
+type SourceIssueKind string
+
+const (
+	// IssueInvalidEntry is a line or stanza apt cannot parse
+	IssueInvalidEntry SourceIssueKind = "invalid"
+	// IssueDuplicateEntry is an entry already configured elsewhere
+	IssueDuplicateEntry SourceIssueKind = "duplicate"
+	// IssueMixedSuites is a Debian entry from a different release than the rest
+	IssueMixedSuites SourceIssueKind = "mixed-suites"
+	// IssueMissingSignedBy is a third-party entry without a signed-by key
+	IssueMissingSignedBy SourceIssueKind = "missing-signed-by"
+)
+
+// suiteSuffixes are the pockets that belong to a base release
+var suiteSuffixes = []string{
+	"-proposed-updates",
+	"-backports-sloppy",
+	"-backports",
+	"-security",
+	"-updates",
+	"/updates",
+}
+
+// suiteAliases maps suites that belong to the same release family
+var suiteAliases = map[string]string{
+	"unstable":     "sid",
+	"experimental": "sid",
+	"rc-buggy":     "sid",
+}
+
+// LocatedEntry is a source entry together with where it was defined
+type LocatedEntry struct {
+	SourceEntry
+	Path string
+	Line int // Line of the one-line entry or first line of the deb822 stanza
+}
+
+// Location returns the entry position as path:line
+func (e LocatedEntry) Location() string {
+	return fmt.Sprintf("%s:%d", e.Path, e.Line)
+}
+
+// SourceIssue is a problem found in apt sources, with its exact location
+type SourceIssue struct {
+	Kind    SourceIssueKind
+	Path    string
+	Line    int
+	Message string
+	Fixable bool
+}
+
+// Location returns the issue position as path:line
+func (i SourceIssue) Location() string {
+	return fmt.Sprintf("%s:%d", i.Path, i.Line)
+}
+
+// String returns human-readable representation
+func (i SourceIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Location(), i.Message)
+}
+
+// SourceFix describes how to repair a single entry. Remove disables the
+// entry; otherwise the entry is rewritten as Replacement.
+type SourceFix struct {
+	Path        string
+	Line        int
+	Remove      bool
+	Replacement SourceEntry
+}
+
+// SourcesReport is the result of linting apt sources
+type SourcesReport struct {
+	Issues []SourceIssue
+	Fixes  []SourceFix
+}
+
+// FixableIssues returns the number of issues that have an automatic fix
+func (r SourcesReport) FixableIssues() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Fixable {
+			count++
+		}
+	}
+	return count
+}
+
+// LintSources checks enabled entries for duplicates, mixed Debian releases
+// and third-party entries without a signed-by key. Entries must be given in
+// the order apt reads them, so the first definition wins.
+func LintSources(entries []LocatedEntry) SourcesReport {
+	var enabled []LocatedEntry
+	for _, entry := range entries {
+		if !entry.Disabled {
+			enabled = append(enabled, entry)
+		}
+	}
+
+	var report SourcesReport
+	lintDuplicates(enabled, &report)
+	lintMixedSuites(enabled, &report)
+	lintSignedBy(enabled, &report)
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		if report.Issues[i].Path != report.Issues[j].Path {
+			return report.Issues[i].Path < report.Issues[j].Path
+		}
+		return report.Issues[i].Line < report.Issues[j].Line
+	})
+
+	return report
+}
+
+// lintDuplicates reports entries whose type/URI/suite/component targets were
+// already configured. One-line entries are fixed by dropping the duplicated
+// components; deb822 stanzas are only disabled when they are entirely
+// duplicates, since a partial rewrite would lose their other suites.
+func lintDuplicates(entries []LocatedEntry, report *SourcesReport) {
+	type target struct {
+		entryType, uri, suite, component string
+	}
+	seen := make(map[target]string)
+
+	type stanzaState struct {
+		entries, duplicates int
+	}
+	stanzas := make(map[string]*stanzaState)
+	var stanzaOrder []LocatedEntry
+
+	for _, entry := range entries {
+		components := entry.Components
+		if len(components) == 0 {
+			components = []string{""}
+		}
+		uri := strings.TrimRight(entry.URI, "/")
+
+		var duplicated, kept []string
+		firstSeen := ""
+		for _, component := range components {
+			key := target{entry.Type, uri, entry.Suite, component}
+			if location, ok := seen[key]; ok {
+				duplicated = append(duplicated, component)
+				if firstSeen == "" {
+					firstSeen = location
+				}
+				continue
+			}
+			seen[key] = entry.Location()
+			kept = append(kept, component)
+		}
+
+		deb822 := SourcesFormatForPath(entry.Path) == SourcesFormatDeb822
+		if deb822 {
+			state, ok := stanzas[entry.Location()]
+			if !ok {
+				state = &stanzaState{}
+				stanzas[entry.Location()] = state
+				stanzaOrder = append(stanzaOrder, entry)
+			}
+			state.entries++
+			if len(kept) == 0 {
+				state.duplicates++
+			}
+		}
+
+		if len(duplicated) == 0 {
+			continue
+		}
+
+		message := fmt.Sprintf("%s %s %s is already configured at %s", entry.Type, uri, entry.Suite, firstSeen)
+		if len(kept) > 0 {
+			message = fmt.Sprintf("%s %s %s components %s are already configured at %s",
+				entry.Type, uri, entry.Suite, strings.Join(duplicated, ", "), firstSeen)
+		}
+
+		issue := SourceIssue{
+			Kind:    IssueDuplicateEntry,
+			Path:    entry.Path,
+			Line:    entry.Line,
+			Message: message,
+			Fixable: !deb822,
+		}
+
+		if !deb822 {
+			fix := SourceFix{Path: entry.Path, Line: entry.Line, Remove: len(kept) == 0}
+			if !fix.Remove {
+				fix.Replacement = entry.SourceEntry
+				fix.Replacement.Components = kept
+			}
+			report.Fixes = append(report.Fixes, fix)
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+
+	// A deb822 stanza whose every entry is a duplicate can be disabled whole
+	for _, entry := range stanzaOrder {
+		state := stanzas[entry.Location()]
+		if state.duplicates != state.entries {
+			continue
+		}
+		for i := range report.Issues {
+			if report.Issues[i].Path == entry.Path && report.Issues[i].Line == entry.Line {
+				report.Issues[i].Fixable = true
+			}
+		}
+		report.Fixes = append(report.Fixes, SourceFix{Path: entry.Path, Line: entry.Line, Remove: true})
+	}
+}
+
+// lintMixedSuites reports Debian entries whose release differs from the one
+// used by most Debian entries, e.g. bookworm entries on a sid system
+func lintMixedSuites(entries []LocatedEntry, report *SourcesReport) {
+	counts := make(map[string]int)
+	var order []string
+	for _, entry := range entries {
+		if !isDebianURI(entry.URI) {
+			continue
+		}
+		release := BaseRelease(entry.Suite)
+		if counts[release] == 0 {
+			order = append(order, release)
+		}
+		counts[release]++
+	}
+
+	if len(order) < 2 {
+		return
+	}
+
+	primary := order[0]
+	for _, release := range order[1:] {
+		if counts[release] > counts[primary] {
+			primary = release
+		}
+	}
+
+	for _, entry := range entries {
+		if !isDebianURI(entry.URI) {
+			continue
+		}
+		if release := BaseRelease(entry.Suite); release != primary {
+			report.Issues = append(report.Issues, SourceIssue{
+				Kind:    IssueMixedSuites,
+				Path:    entry.Path,
+				Line:    entry.Line,
+				Message: fmt.Sprintf("suite %s belongs to %s, but the other Debian entries use %s", entry.Suite, release, primary),
+			})
+		}
+	}
+}
+
+// lintSignedBy reports third-party entries that rely on globally trusted keys
+func lintSignedBy(entries []LocatedEntry, report *SourcesReport) {
+	reported := make(map[string]bool)
+	for _, entry := range entries {
+		if isDebianURI(entry.URI) || entry.SignedBy != "" || reported[entry.Location()] {
+			continue
+		}
+		trusted := false
+		for _, option := range entry.Options {
+			if option == "trusted=yes" {
+				trusted = true
+			}
+		}
+		if trusted {
+			continue
+		}
+
+		reported[entry.Location()] = true
+		report.Issues = append(report.Issues, SourceIssue{
+			Kind:    IssueMissingSignedBy,
+			Path:    entry.Path,
+			Line:    entry.Line,
+			Message: fmt.Sprintf("%s has no signed-by key and relies on globally trusted keys", strings.TrimRight(entry.URI, "/")),
+		})
+	}
+}
+
+// BaseRelease returns the release a suite belongs to, e.g. "bookworm" for
+// "bookworm-security" and "sid" for "unstable"
+func BaseRelease(suite string) string {
+	release := strings.TrimSpace(suite)
+	for _, suffix := range suiteSuffixes {
+		if trimmed, ok := strings.CutSuffix(release, suffix); ok {
+			release = trimmed
+			break
+		}
+	}
+	if alias, ok := suiteAliases[release]; ok {
+		return alias
+	}
+	return release
+}
+
+// isDebianURI reports whether a URI serves the Debian archive or its
+// security archive, both of which are signed by the Debian archive keyring
+func isDebianURI(uri string) bool {
+	return IsDebianArchiveURI(uri) ||
+		strings.Contains(uri, "security.debian.org") ||
+		strings.Contains(uri, "debian-security")
+}
@@ -0,0 +1,132 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func located(path string, line int, entry repository.SourceEntry) repository.LocatedEntry {
+	return repository.LocatedEntry{SourceEntry: entry, Path: path, Line: line}
+}
+
+func TestLintSources_Duplicates(t *testing.T) {
+	sid := repository.SourceEntry{Type: "deb", URI: "http://deb.debian.org/debian", Suite: "sid", Components: []string{"main", "contrib"}}
+
+	t.Run("full duplicate is removed", func(t *testing.T) {
+		dup := sid
+		dup.URI = "http://deb.debian.org/debian/"
+
+		report := repository.LintSources([]repository.LocatedEntry{
+			located("/etc/apt/sources.list", 1, sid),
+			located("/etc/apt/sources.list.d/extra.list", 4, dup),
+		})
+
+		require.Len(t, report.Issues, 1)
+		issue := report.Issues[0]
+		assert.Equal(t, repository.IssueDuplicateEntry, issue.Kind)
+		assert.Equal(t, "/etc/apt/sources.list.d/extra.list:4", issue.Location())
+		assert.Contains(t, issue.Message, "/etc/apt/sources.list:1")
+		assert.True(t, issue.Fixable)
+		assert.Equal(t, []repository.SourceFix{{Path: "/etc/apt/sources.list.d/extra.list", Line: 4, Remove: true}}, report.Fixes)
+	})
+
+	t.Run("partial duplicate keeps missing components", func(t *testing.T) {
+		partial := sid
+		partial.Components = []string{"main", "non-free"}
+
+		report := repository.LintSources([]repository.LocatedEntry{
+			located("/etc/apt/sources.list", 1, sid),
+			located("/etc/apt/sources.list", 2, partial),
+		})
+
+		require.Len(t, report.Fixes, 1)
+		assert.False(t, report.Fixes[0].Remove)
+		assert.Equal(t, []string{"non-free"}, report.Fixes[0].Replacement.Components)
+		assert.Contains(t, report.Issues[0].Message, "components main")
+	})
+
+	t.Run("deb822 stanza is only fixable when entirely duplicate", func(t *testing.T) {
+		updates := sid
+		updates.Suite = "sid-updates"
+
+		report := repository.LintSources([]repository.LocatedEntry{
+			located("/etc/apt/sources.list", 1, sid),
+			located("/etc/apt/sources.list.d/debian.sources", 1, sid),
+			located("/etc/apt/sources.list.d/debian.sources", 1, updates),
+		})
+
+		require.Len(t, report.Issues, 1)
+		assert.False(t, report.Issues[0].Fixable)
+		assert.Empty(t, report.Fixes)
+	})
+
+	t.Run("disabled entries are ignored", func(t *testing.T) {
+		disabled := sid
+		disabled.Disabled = true
+
+		report := repository.LintSources([]repository.LocatedEntry{
+			located("/etc/apt/sources.list", 1, sid),
+			located("/etc/apt/sources.list.d/debian.sources", 1, disabled),
+		})
+
+		assert.Empty(t, report.Issues)
+	})
+}
+
+func TestLintSources_MixedSuites(t *testing.T) {
+	entry := func(uri, suite string) repository.SourceEntry {
+		return repository.SourceEntry{Type: "deb", URI: uri, Suite: suite, Components: []string{"main"}}
+	}
+
+	report := repository.LintSources([]repository.LocatedEntry{
+		located("/etc/apt/sources.list", 1, entry("http://deb.debian.org/debian", "sid")),
+		located("/etc/apt/sources.list", 2, entry("http://deb.debian.org/debian", "experimental")),
+		located("/etc/apt/sources.list", 3, entry("http://deb.debian.org/debian", "bookworm-updates")),
+		located("/etc/apt/sources.list", 4, entry("http://security.debian.org/debian-security", "bookworm-security")),
+	})
+
+	require.Len(t, report.Issues, 2)
+	assert.Equal(t, repository.IssueMixedSuites, report.Issues[0].Kind)
+	assert.Equal(t, 3, report.Issues[0].Line)
+	assert.Equal(t, 4, report.Issues[1].Line)
+	assert.Contains(t, report.Issues[1].Message, "belongs to bookworm")
+	assert.False(t, report.Issues[0].Fixable)
+}
+
+func TestLintSources_MissingSignedBy(t *testing.T) {
+	thirdParty := repository.SourceEntry{Type: "deb", URI: "https://repo.example.com/apt", Suite: "stable", Components: []string{"main"}}
+	signed := thirdParty
+	signed.URI = "https://other.example.com/apt"
+	signed.SignedBy = "/usr/share/keyrings/other.gpg"
+	source := thirdParty
+	source.Type = "deb-src"
+
+	report := repository.LintSources([]repository.LocatedEntry{
+		located("/etc/apt/sources.list.d/example.sources", 1, thirdParty),
+		located("/etc/apt/sources.list.d/example.sources", 1, source),
+		located("/etc/apt/sources.list.d/other.list", 1, signed),
+	})
+
+	require.Len(t, report.Issues, 1, "one issue per stanza")
+	assert.Equal(t, repository.IssueMissingSignedBy, report.Issues[0].Kind)
+	assert.Equal(t, "/etc/apt/sources.list.d/example.sources:1", report.Issues[0].Location())
+}
+
+func TestBaseRelease(t *testing.T) {
+	tests := map[string]string{
+		"sid":                       "sid",
+		"unstable":                  "sid",
+		"experimental":              "sid",
+		"trixie-updates":            "trixie",
+		"bookworm-security":         "bookworm",
+		"bookworm-backports":        "bookworm",
+		"bookworm-proposed-updates": "bookworm",
+		"buster/updates":            "buster",
+	}
+	for suite, want := range tests {
+		assert.Equal(t, want, repository.BaseRelease(suite), suite)
+	}
+}
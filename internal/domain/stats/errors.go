@@ -0,0 +1,10 @@
+package stats
+
+import "errors"
+
+// Domain errors for local usage statistics
+var (
+	ErrInvalidOutcome   = errors.New("install run outcome is invalid")
+	ErrInvalidDuration  = errors.New("install run duration must not be negative")
+	ErrInvalidTimestamp = errors.New("install run timestamp is invalid")
+)
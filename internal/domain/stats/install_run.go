@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"strings"
+	"time"
+)
+
+// Outcome is the result of an install run
+type Outcome string
+
+const (
+	OutcomeSucceeded Outcome = "succeeded"
+	OutcomeFailed    Outcome = "failed"
+)
+
+// UnknownPhase is recorded when a failed run did not report its phase
+const UnknownPhase = "unknown"
+
+// String returns the string representation
+func (o Outcome) String() string {
+	return string(o)
+}
+
+// IsValid returns true for a known outcome
+func (o Outcome) IsValid() bool {
+	return o == OutcomeSucceeded || o == OutcomeFailed
+}
+
+// InstallRun is one anonymous install counter entry.
+// It deliberately holds no package names, host names or paths, only what is
+// needed for aggregate statistics.
+type InstallRun struct {
+	recordedAt     time.Time
+	outcome        Outcome
+	duration       time.Duration
+	failurePhase   string
+	componentCount int
+}
+
+// NewInstallRun creates a new install run entry
+// The failure phase is only kept for failed runs
+func NewInstallRun(
+	recordedAt time.Time,
+	outcome Outcome,
+	duration time.Duration,
+	failurePhase string,
+	componentCount int,
+) (InstallRun, error) {
+	if recordedAt.IsZero() {
+		return InstallRun{}, ErrInvalidTimestamp
+	}
+	if !outcome.IsValid() {
+		return InstallRun{}, ErrInvalidOutcome
+	}
+	if duration < 0 {
+		return InstallRun{}, ErrInvalidDuration
+	}
+	if componentCount < 0 {
+		componentCount = 0
+	}
+
+	failurePhase = strings.TrimSpace(failurePhase)
+	if outcome == OutcomeSucceeded {
+		failurePhase = ""
+	} else if failurePhase == "" {
+		failurePhase = UnknownPhase
+	}
+
+	return InstallRun{
+		recordedAt:     recordedAt,
+		outcome:        outcome,
+		duration:       duration,
+		failurePhase:   failurePhase,
+		componentCount: componentCount,
+	}, nil
+}
+
+// RecordedAt returns when the run finished
+func (r InstallRun) RecordedAt() time.Time {
+	return r.recordedAt
+}
+
+// Outcome returns the run outcome
+func (r InstallRun) Outcome() Outcome {
+	return r.outcome
+}
+
+// Duration returns how long the run took
+func (r InstallRun) Duration() time.Duration {
+	return r.duration
+}
+
+// FailurePhase returns the phase a failed run stopped in
+func (r InstallRun) FailurePhase() string {
+	return r.failurePhase
+}
+
+// ComponentCount returns the number of requested components
+func (r InstallRun) ComponentCount() int {
+	return r.componentCount
+}
+
+// Succeeded returns true if the run completed
+func (r InstallRun) Succeeded() bool {
+	return r.outcome == OutcomeSucceeded
+}
@@ -0,0 +1,47 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInstallRun(t *testing.T) {
+	now := time.Now()
+
+	t.Run("creates successful run without failure phase", func(t *testing.T) {
+		run, err := stats.NewInstallRun(now, stats.OutcomeSucceeded, 2*time.Minute, "Installing Components", 3)
+		require.NoError(t, err)
+		assert.True(t, run.Succeeded())
+		assert.Empty(t, run.FailurePhase())
+		assert.Equal(t, 2*time.Minute, run.Duration())
+		assert.Equal(t, 3, run.ComponentCount())
+	})
+
+	t.Run("keeps failure phase of failed run", func(t *testing.T) {
+		run, err := stats.NewInstallRun(now, stats.OutcomeFailed, time.Minute, " Installing Components ", 1)
+		require.NoError(t, err)
+		assert.False(t, run.Succeeded())
+		assert.Equal(t, "Installing Components", run.FailurePhase())
+	})
+
+	t.Run("defaults missing failure phase", func(t *testing.T) {
+		run, err := stats.NewInstallRun(now, stats.OutcomeFailed, time.Minute, "", 1)
+		require.NoError(t, err)
+		assert.Equal(t, stats.UnknownPhase, run.FailurePhase())
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		_, err := stats.NewInstallRun(time.Time{}, stats.OutcomeSucceeded, 0, "", 1)
+		assert.ErrorIs(t, err, stats.ErrInvalidTimestamp)
+
+		_, err = stats.NewInstallRun(now, stats.Outcome("aborted"), 0, "", 1)
+		assert.ErrorIs(t, err, stats.ErrInvalidOutcome)
+
+		_, err = stats.NewInstallRun(now, stats.OutcomeSucceeded, -time.Second, "", 1)
+		assert.ErrorIs(t, err, stats.ErrInvalidDuration)
+	})
+}
@@ -0,0 +1,15 @@
+package stats
+
+import "context"
+
+// Repository stores install runs on the local machine only
+type Repository interface {
+	// Record persists an install run
+	Record(ctx context.Context, run InstallRun) error
+
+	// FindAll returns all recorded runs, oldest first
+	FindAll(ctx context.Context) ([]InstallRun, error)
+
+	// Clear removes all recorded runs and returns how many were removed
+	Clear(ctx context.Context) (int, error)
+}
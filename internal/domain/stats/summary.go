@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// PhaseCount is the number of failures in one installation phase
+type PhaseCount struct {
+	Phase string
+	Count int
+}
+
+// Summary aggregates install runs
+type Summary struct {
+	TotalRuns       int
+	SucceededRuns   int
+	FailedRuns      int
+	AverageDuration time.Duration
+	TotalComponents int
+	FailurePhases   []PhaseCount // Most frequent first
+	FirstRun        time.Time
+	LastRun         time.Time
+}
+
+// Summarize aggregates the given runs
+func Summarize(runs []InstallRun) Summary {
+	summary := Summary{TotalRuns: len(runs)}
+	if len(runs) == 0 {
+		return summary
+	}
+
+	var total time.Duration
+	phases := make(map[string]int)
+	for _, run := range runs {
+		total += run.duration
+		summary.TotalComponents += run.componentCount
+
+		if run.Succeeded() {
+			summary.SucceededRuns++
+		} else {
+			summary.FailedRuns++
+			phases[run.failurePhase]++
+		}
+
+		if summary.FirstRun.IsZero() || run.recordedAt.Before(summary.FirstRun) {
+			summary.FirstRun = run.recordedAt
+		}
+		if run.recordedAt.After(summary.LastRun) {
+			summary.LastRun = run.recordedAt
+		}
+	}
+	summary.AverageDuration = total / time.Duration(len(runs))
+
+	for phase, count := range phases {
+		summary.FailurePhases = append(summary.FailurePhases, PhaseCount{Phase: phase, Count: count})
+	}
+	sort.Slice(summary.FailurePhases, func(i, j int) bool {
+		a, b := summary.FailurePhases[i], summary.FailurePhases[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Phase < b.Phase
+	})
+
+	return summary
+}
+
+// SuccessRate returns the percentage of successful runs
+func (s Summary) SuccessRate() float64 {
+	if s.TotalRuns == 0 {
+		return 0
+	}
+	return 100 * float64(s.SucceededRuns) / float64(s.TotalRuns)
+}
@@ -0,0 +1,48 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		summary := stats.Summarize(nil)
+		assert.Zero(t, summary.TotalRuns)
+		assert.Zero(t, summary.SuccessRate())
+		assert.Empty(t, summary.FailurePhases)
+	})
+
+	t.Run("aggregates runs", func(t *testing.T) {
+		base := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+		newRun := func(offset time.Duration, outcome stats.Outcome, duration time.Duration, phase string) stats.InstallRun {
+			run, err := stats.NewInstallRun(base.Add(offset), outcome, duration, phase, 2)
+			require.NoError(t, err)
+			return run
+		}
+
+		summary := stats.Summarize([]stats.InstallRun{
+			newRun(time.Hour, stats.OutcomeSucceeded, 4*time.Minute, ""),
+			newRun(0, stats.OutcomeFailed, time.Minute, "Running Preflight Checks"),
+			newRun(2*time.Hour, stats.OutcomeFailed, 2*time.Minute, "Installing Components"),
+			newRun(3*time.Hour, stats.OutcomeFailed, time.Minute, "Installing Components"),
+		})
+
+		assert.Equal(t, 4, summary.TotalRuns)
+		assert.Equal(t, 1, summary.SucceededRuns)
+		assert.Equal(t, 3, summary.FailedRuns)
+		assert.Equal(t, 25.0, summary.SuccessRate())
+		assert.Equal(t, 2*time.Minute, summary.AverageDuration)
+		assert.Equal(t, 8, summary.TotalComponents)
+		assert.Equal(t, base, summary.FirstRun)
+		assert.Equal(t, base.Add(3*time.Hour), summary.LastRun)
+		assert.Equal(t, []stats.PhaseCount{
+			{Phase: "Installing Components", Count: 2},
+			{Phase: "Running Preflight Checks", Count: 1},
+		}, summary.FailurePhases)
+	})
+}
@@ -0,0 +1,144 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ErrInvalidPalette indicates a custom theme's palette failed validation
+var ErrInvalidPalette = errors.New("invalid palette")
+
+// AccentRole names the color a custom palette uses for every accent role it
+// does not set itself
+const AccentRole = "accent"
+
+// customThemeAuthor is the author of custom themes that do not name one
+const customThemeAuthor = "custom"
+
+// themeNameRegex matches names usable on the command line and in file names
+var themeNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,39}$`)
+
+// CustomThemeDefinition describes a theme the user defined in a palette
+// file. Colors map palette roles, and AccentRole, to #RRGGBB colors.
+type CustomThemeDefinition struct {
+	Name        string
+	DisplayName string // Empty takes the name
+	Author      string // Empty is "custom"
+	Description string
+	Variant     string // dark or light
+	Colors      map[string]string
+}
+
+// NewCustomTheme creates a theme from a user's definition. base, text and
+// accent are required. surface, overlay and subtext are blended from base
+// and text when left out, and accent roles that are left out take the
+// accent color.
+func NewCustomTheme(def CustomThemeDefinition) (*Theme, error) {
+	if !themeNameRegex.MatchString(def.Name) {
+		return nil, fmt.Errorf("%w: name %q must be lowercase letters, digits and dashes", ErrInvalidPalette, def.Name)
+	}
+	variant := ThemeVariant(def.Variant)
+	if variant != ThemeVariantDark && variant != ThemeVariantLight {
+		return nil, fmt.Errorf("%w: variant must be 'dark' or 'light', got %q", ErrInvalidPalette, def.Variant)
+	}
+
+	colors := make(map[string]Color, len(def.Colors))
+	for role, value := range def.Colors {
+		if role != AccentRole && !isPaletteRole(role) {
+			return nil, fmt.Errorf("%w: unknown color %q", ErrInvalidPalette, role)
+		}
+		color := Color(value)
+		if err := color.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPalette, role, err)
+		}
+		colors[role] = color
+	}
+	for _, role := range []string{"base", "text", AccentRole} {
+		if _, ok := colors[role]; !ok {
+			return nil, fmt.Errorf("%w: %s color is required", ErrInvalidPalette, role)
+		}
+	}
+
+	base, text, accent := colors["base"], colors["text"], colors[AccentRole]
+	pick := func(role string, fallback Color) Color {
+		if color, ok := colors[role]; ok {
+			return color
+		}
+		return fallback
+	}
+
+	colorScheme := ColorScheme{
+		base:    base,
+		surface: pick("surface", mixColors(base, text, 0.12)),
+		overlay: pick("overlay", mixColors(base, text, 0.24)),
+		text:    text,
+		subtext: pick("subtext", mixColors(text, base, 0.15)),
+
+		rosewater: pick("rosewater", accent),
+		flamingo:  pick("flamingo", accent),
+		pink:      pick("pink", accent),
+		mauve:     pick("mauve", accent),
+		red:       pick("red", accent),
+		maroon:    pick("maroon", accent),
+		peach:     pick("peach", accent),
+		yellow:    pick("yellow", accent),
+		green:     pick("green", accent),
+		teal:      pick("teal", accent),
+		sky:       pick("sky", accent),
+		sapphire:  pick("sapphire", accent),
+		blue:      pick("blue", accent),
+		lavender:  pick("lavender", accent),
+	}
+
+	metadata := ThemeMetadata{
+		displayName: def.DisplayName,
+		author:      def.Author,
+		description: def.Description,
+		variant:     variant,
+	}
+	if metadata.displayName == "" {
+		metadata.displayName = def.Name
+	}
+	if metadata.author == "" {
+		metadata.author = customThemeAuthor
+	}
+
+	theme, err := NewTheme(ThemeName(def.Name), metadata, colorScheme)
+	if err != nil {
+		return nil, err
+	}
+	theme.custom = true
+	return theme, nil
+}
+
+// RegisterCustomThemes registers the user's themes alongside the standard
+// ones. Definitions that fail validation or clash with a registered theme
+// are skipped and reported together.
+func RegisterCustomThemes(registry ThemeRegistry, definitions []CustomThemeDefinition) error {
+	var errs []error
+	for _, def := range definitions {
+		theme, err := NewCustomTheme(def)
+		if err == nil {
+			err = registry.Register(theme)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("custom theme %s: %w", def.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// mixColors blends from towards to by weight, between 0 and 1. Both colors
+// must be valid.
+func mixColors(from, to Color, weight float64) Color {
+	mixed := "#"
+	for i := 1; i < 7; i += 2 {
+		a, _ := strconv.ParseUint(string(from[i:i+2]), 16, 8)
+		b, _ := strconv.ParseUint(string(to[i:i+2]), 16, 8)
+		channel := float64(a) + (float64(b)-float64(a))*weight
+		mixed += fmt.Sprintf("%02x", int(channel+0.5))
+	}
+	return Color(mixed)
+}
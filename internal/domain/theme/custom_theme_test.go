@@ -0,0 +1,88 @@
+package theme
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solarizedDefinition() CustomThemeDefinition {
+	return CustomThemeDefinition{
+		Name:    "solarized",
+		Variant: "dark",
+		Colors: map[string]string{
+			"base":   "#002b36",
+			"text":   "#839496",
+			"accent": "#268bd2",
+			"red":    "#dc322f",
+		},
+	}
+}
+
+func TestNewCustomTheme(t *testing.T) {
+	t.Run("fills in left out colors", func(t *testing.T) {
+		theme, err := NewCustomTheme(solarizedDefinition())
+		require.NoError(t, err)
+
+		cs := theme.ColorScheme()
+		assert.Equal(t, Color("#002b36"), cs.Base())
+		assert.Equal(t, Color("#dc322f"), cs.Red())
+		assert.Equal(t, Color("#268bd2"), cs.Mauve(), "accent roles take the accent")
+		assert.Equal(t, Color("#103842"), cs.Surface(), "blended from base towards text")
+		assert.NoError(t, cs.Overlay().Validate())
+		assert.NoError(t, cs.Subtext().Validate())
+
+		assert.True(t, theme.IsCustom())
+		assert.Equal(t, "solarized", theme.DisplayName())
+		assert.Equal(t, "custom", theme.Author())
+		assert.True(t, theme.IsDark())
+	})
+
+	t.Run("validates the palette", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(*CustomThemeDefinition)
+		}{
+			{"missing accent", func(d *CustomThemeDefinition) { delete(d.Colors, "accent") }},
+			{"missing base", func(d *CustomThemeDefinition) { delete(d.Colors, "base") }},
+			{"malformed color", func(d *CustomThemeDefinition) { d.Colors["text"] = "839496" }},
+			{"unknown role", func(d *CustomThemeDefinition) { d.Colors["purple"] = "#6c71c4" }},
+			{"unknown variant", func(d *CustomThemeDefinition) { d.Variant = "dusk" }},
+			{"unusable name", func(d *CustomThemeDefinition) { d.Name = "My Theme" }},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				def := solarizedDefinition()
+				tt.modify(&def)
+
+				_, err := NewCustomTheme(def)
+				assert.ErrorIs(t, err, ErrInvalidPalette)
+			})
+		}
+	})
+
+	t.Run("standard themes are not custom", func(t *testing.T) {
+		assert.False(t, createMochaTheme().IsCustom())
+	})
+}
+
+func TestRegisterCustomThemes(t *testing.T) {
+	registry := NewThemeRegistry()
+	require.NoError(t, InitializeStandardThemes(registry))
+
+	clash := solarizedDefinition()
+	clash.Name = string(ThemeNord)
+
+	err := RegisterCustomThemes(registry, []CustomThemeDefinition{solarizedDefinition(), clash})
+
+	assert.ErrorIs(t, err, ErrThemeAlreadyRegistered)
+	registered, findErr := registry.FindByName(context.Background(), "solarized")
+	require.NoError(t, findErr)
+	assert.True(t, registered.IsCustom())
+	nord, findErr := registry.FindByName(context.Background(), ThemeNord)
+	require.NoError(t, findErr)
+	assert.False(t, nord.IsCustom())
+}
@@ -24,6 +24,15 @@ const (
 	ThemeFrappe     ThemeName = "frappe"
 	ThemeMacchiato  ThemeName = "macchiato"
 	ThemeGohan      ThemeName = "gohan"
+
+	// High-contrast themes for low-vision users
+	ThemeHighContrast      ThemeName = "high-contrast"
+	ThemeHighContrastLight ThemeName = "high-contrast-light"
+
+	// Popular palettes beyond Catppuccin
+	ThemeGruvbox    ThemeName = "gruvbox"
+	ThemeNord       ThemeName = "nord"
+	ThemeTokyoNight ThemeName = "tokyo-night"
 )
 
 // ThemeVariant indicates if a theme is suitable for day or night use
@@ -221,12 +230,37 @@ func (cs ColorScheme) Lavender() Color {
 	return cs.lavender
 }
 
+// PaletteRoles names the colors of a ColorScheme, as component colors
+// refer to them
+var PaletteRoles = []string{
+	"base", "surface", "overlay", "text", "subtext",
+	"rosewater", "flamingo", "pink", "mauve", "red", "maroon", "peach",
+	"yellow", "green", "teal", "sky", "sapphire", "blue", "lavender",
+}
+
+// isPaletteRole reports whether role is one of PaletteRoles
+func isPaletteRole(role string) bool {
+	for _, known := range PaletteRoles {
+		if known == role {
+			return true
+		}
+	}
+	return false
+}
+
 // Theme represents a complete visual theme
 type Theme struct {
 	name        ThemeName
 	metadata    ThemeMetadata
 	colorScheme ColorScheme
 	createdAt   time.Time
+
+	// Palette roles recolored in one component's configuration, such as a
+	// darker base behind the terminal
+	componentColors map[string]map[string]Color
+
+	// Defined by the user rather than shipped with gohan
+	custom bool
 }
 
 // NewTheme creates a new theme with validation
@@ -255,6 +289,44 @@ func NewTheme(name ThemeName, metadata ThemeMetadata, colorScheme ColorScheme) (
 	}, nil
 }
 
+// WithComponentColors returns a copy of the theme that renders component's
+// configuration with colors in place of the palette roles they name
+func (t *Theme) WithComponentColors(component string, colors map[string]Color) (*Theme, error) {
+	if component == "" {
+		return nil, fmt.Errorf("%w: component cannot be empty", ErrInvalidTheme)
+	}
+	for role, color := range colors {
+		if !isPaletteRole(role) {
+			return nil, fmt.Errorf("%w: unknown palette role %q for %s", ErrInvalidTheme, role, component)
+		}
+		if err := color.Validate(); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", component, role, err)
+		}
+	}
+
+	copied := *t
+	copied.componentColors = make(map[string]map[string]Color, len(t.componentColors)+1)
+	for name, existing := range t.componentColors {
+		copied.componentColors[name] = existing
+	}
+	overrides := make(map[string]Color, len(colors))
+	for role, color := range colors {
+		overrides[role] = color
+	}
+	copied.componentColors[component] = overrides
+	return &copied, nil
+}
+
+// ComponentColors returns the palette roles recolored for a component,
+// empty when it uses the palette as is
+func (t *Theme) ComponentColors(component string) map[string]Color {
+	colors := make(map[string]Color, len(t.componentColors[component]))
+	for role, color := range t.componentColors[component] {
+		colors[role] = color
+	}
+	return colors
+}
+
 // Name returns the theme name
 func (t *Theme) Name() ThemeName {
 	return t.name
@@ -290,6 +362,11 @@ func (t *Theme) ColorScheme() ColorScheme {
 	return t.colorScheme
 }
 
+// IsCustom returns true if the user defined the theme in a palette file
+func (t *Theme) IsCustom() bool {
+	return t.custom
+}
+
 // CreatedAt returns when the theme was created
 func (t *Theme) CreatedAt() time.Time {
 	return t.createdAt
@@ -8,6 +8,11 @@ func InitializeStandardThemes(registry ThemeRegistry) error {
 		createFrappeTheme(),
 		createMacchiatoTheme(),
 		createGohanTheme(),
+		createHighContrastTheme(),
+		createHighContrastLightTheme(),
+		createGruvboxTheme(),
+		createNordTheme(),
+		createTokyoNightTheme(),
 	}
 
 	for _, theme := range themes {
@@ -214,3 +219,207 @@ func createGohanTheme() *Theme {
 	theme, _ := NewTheme(ThemeGohan, metadata, colorScheme)
 	return theme
 }
+
+// createHighContrastTheme creates the high-contrast dark theme: white text
+// on black with saturated accents
+func createHighContrastTheme() *Theme {
+	metadata := ThemeMetadata{
+		displayName: "High Contrast",
+		author:      "Gohan Team",
+		description: "White on black with saturated accents for low vision",
+		variant:     ThemeVariantDark,
+		previewURL:  "",
+	}
+
+	colorScheme := ColorScheme{
+		// Base colors
+		base:    Color("#000000"),
+		surface: Color("#1a1a1a"),
+		overlay: Color("#ffffff"),
+		text:    Color("#ffffff"),
+		subtext: Color("#f0f0f0"),
+
+		// Accent colors
+		rosewater: Color("#ffff00"),
+		flamingo:  Color("#ff80ff"),
+		pink:      Color("#ff80ff"),
+		mauve:     Color("#ffff00"),
+		red:       Color("#ff4040"),
+		maroon:    Color("#ff4040"),
+		peach:     Color("#ffa500"),
+		yellow:    Color("#ffff00"),
+		green:     Color("#00ff00"),
+		teal:      Color("#00ffff"),
+		sky:       Color("#00ffff"),
+		sapphire:  Color("#00ffff"),
+		blue:      Color("#40a0ff"),
+		lavender:  Color("#ffffff"),
+	}
+
+	theme, _ := NewTheme(ThemeHighContrast, metadata, colorScheme)
+	return theme
+}
+
+// createHighContrastLightTheme creates the high-contrast light theme: black
+// text on white with dark accents
+func createHighContrastLightTheme() *Theme {
+	metadata := ThemeMetadata{
+		displayName: "High Contrast Light",
+		author:      "Gohan Team",
+		description: "Black on white with dark accents for low vision",
+		variant:     ThemeVariantLight,
+		previewURL:  "",
+	}
+
+	colorScheme := ColorScheme{
+		// Base colors
+		base:    Color("#ffffff"),
+		surface: Color("#e6e6e6"),
+		overlay: Color("#000000"),
+		text:    Color("#000000"),
+		subtext: Color("#1a1a1a"),
+
+		// Accent colors
+		rosewater: Color("#0000c0"),
+		flamingo:  Color("#800080"),
+		pink:      Color("#800080"),
+		mauve:     Color("#0000c0"),
+		red:       Color("#b00000"),
+		maroon:    Color("#b00000"),
+		peach:     Color("#a04000"),
+		yellow:    Color("#705000"),
+		green:     Color("#006000"),
+		teal:      Color("#005f5f"),
+		sky:       Color("#004080"),
+		sapphire:  Color("#004080"),
+		blue:      Color("#0000c0"),
+		lavender:  Color("#000000"),
+	}
+
+	theme, _ := NewTheme(ThemeHighContrastLight, metadata, colorScheme)
+	return theme
+}
+
+// createGruvboxTheme creates the Gruvbox dark theme (retro, warm)
+func createGruvboxTheme() *Theme {
+	metadata := ThemeMetadata{
+		displayName: "Gruvbox Dark",
+		author:      "morhetz",
+		description: "Retro groove colors with warm, earthy contrast",
+		variant:     ThemeVariantDark,
+		previewURL:  "https://github.com/morhetz/gruvbox",
+	}
+
+	colorScheme := ColorScheme{
+		// Base colors
+		base:    Color("#282828"),
+		surface: Color("#3c3836"),
+		overlay: Color("#504945"),
+		text:    Color("#ebdbb2"),
+		subtext: Color("#d5c4a1"),
+
+		// Accent colors
+		rosewater: Color("#d5c4a1"),
+		flamingo:  Color("#d3869b"),
+		pink:      Color("#d3869b"),
+		mauve:     Color("#d3869b"),
+		red:       Color("#fb4934"),
+		maroon:    Color("#cc241d"),
+		peach:     Color("#fe8019"),
+		yellow:    Color("#fabd2f"),
+		green:     Color("#b8bb26"),
+		teal:      Color("#8ec07c"),
+		sky:       Color("#83a598"),
+		sapphire:  Color("#458588"),
+		blue:      Color("#83a598"),
+		lavender:  Color("#bdae93"),
+	}
+
+	theme, _ := NewTheme(ThemeGruvbox, metadata, colorScheme)
+	// Terminals use Gruvbox's hard-contrast background
+	theme, _ = theme.WithComponentColors("kitty", map[string]Color{"base": "#1d2021"})
+	theme, _ = theme.WithComponentColors("alacritty", map[string]Color{"base": "#1d2021"})
+	return theme
+}
+
+// createNordTheme creates the Nord theme (arctic, cool)
+func createNordTheme() *Theme {
+	metadata := ThemeMetadata{
+		displayName: "Nord",
+		author:      "Arctic Ice Studio",
+		description: "An arctic, north-bluish clean and elegant palette",
+		variant:     ThemeVariantDark,
+		previewURL:  "https://www.nordtheme.com",
+	}
+
+	colorScheme := ColorScheme{
+		// Base colors (Polar Night and Snow Storm)
+		base:    Color("#2e3440"),
+		surface: Color("#3b4252"),
+		overlay: Color("#4c566a"),
+		text:    Color("#eceff4"),
+		subtext: Color("#d8dee9"),
+
+		// Accent colors (Frost and Aurora)
+		rosewater: Color("#e5e9f0"),
+		flamingo:  Color("#b48ead"),
+		pink:      Color("#b48ead"),
+		mauve:     Color("#b48ead"),
+		red:       Color("#bf616a"),
+		maroon:    Color("#bf616a"),
+		peach:     Color("#d08770"),
+		yellow:    Color("#ebcb8b"),
+		green:     Color("#a3be8c"),
+		teal:      Color("#8fbcbb"),
+		sky:       Color("#88c0d0"),
+		sapphire:  Color("#81a1c1"),
+		blue:      Color("#5e81ac"),
+		lavender:  Color("#81a1c1"),
+	}
+
+	theme, _ := NewTheme(ThemeNord, metadata, colorScheme)
+	// Window borders in Frost rather than Aurora purple
+	theme, _ = theme.WithComponentColors("hyprland", map[string]Color{"mauve": "#88c0d0", "blue": "#81a1c1"})
+	return theme
+}
+
+// createTokyoNightTheme creates the Tokyo Night theme (neon, dark)
+func createTokyoNightTheme() *Theme {
+	metadata := ThemeMetadata{
+		displayName: "Tokyo Night",
+		author:      "enkia",
+		description: "Celebrating the lights of Downtown Tokyo at night",
+		variant:     ThemeVariantDark,
+		previewURL:  "https://github.com/enkia/tokyo-night-vscode-theme",
+	}
+
+	colorScheme := ColorScheme{
+		// Base colors
+		base:    Color("#1a1b26"),
+		surface: Color("#292e42"),
+		overlay: Color("#414868"),
+		text:    Color("#c0caf5"),
+		subtext: Color("#a9b1d6"),
+
+		// Accent colors
+		rosewater: Color("#f7768e"),
+		flamingo:  Color("#ff007c"),
+		pink:      Color("#ff007c"),
+		mauve:     Color("#bb9af7"),
+		red:       Color("#f7768e"),
+		maroon:    Color("#db4b4b"),
+		peach:     Color("#ff9e64"),
+		yellow:    Color("#e0af68"),
+		green:     Color("#9ece6a"),
+		teal:      Color("#73daca"),
+		sky:       Color("#7dcfff"),
+		sapphire:  Color("#2ac3de"),
+		blue:      Color("#7aa2f7"),
+		lavender:  Color("#9d7cd8"),
+	}
+
+	theme, _ := NewTheme(ThemeTokyoNight, metadata, colorScheme)
+	// The bar sits on Tokyo Night's darker sidebar background
+	theme, _ = theme.WithComponentColors("waybar", map[string]Color{"base": "#16161e"})
+	return theme
+}
@@ -13,9 +13,9 @@ func TestInitializeStandardThemes(t *testing.T) {
 	err := InitializeStandardThemes(registry)
 	require.NoError(t, err)
 
-	t.Run("all 5 themes are registered", func(t *testing.T) {
+	t.Run("all 10 themes are registered", func(t *testing.T) {
 		themes := registry.ListAll()
-		assert.Len(t, themes, 5)
+		assert.Len(t, themes, 10)
 
 		themeNames := make([]ThemeName, len(themes))
 		for i, theme := range themes {
@@ -27,6 +27,11 @@ func TestInitializeStandardThemes(t *testing.T) {
 		assert.Contains(t, themeNames, ThemeFrappe)
 		assert.Contains(t, themeNames, ThemeMacchiato)
 		assert.Contains(t, themeNames, ThemeGohan)
+		assert.Contains(t, themeNames, ThemeHighContrast)
+		assert.Contains(t, themeNames, ThemeHighContrastLight)
+		assert.Contains(t, themeNames, ThemeGruvbox)
+		assert.Contains(t, themeNames, ThemeNord)
+		assert.Contains(t, themeNames, ThemeTokyoNight)
 	})
 
 	t.Run("mocha is the default active theme", func(t *testing.T) {
@@ -35,15 +40,17 @@ func TestInitializeStandardThemes(t *testing.T) {
 		assert.Equal(t, ThemeMocha, active.Name())
 	})
 
-	t.Run("4 dark themes", func(t *testing.T) {
+	t.Run("8 dark themes", func(t *testing.T) {
 		darkThemes := registry.ListByVariant(ThemeVariantDark)
-		assert.Len(t, darkThemes, 4)
+		assert.Len(t, darkThemes, 8)
 	})
 
-	t.Run("1 light theme", func(t *testing.T) {
+	t.Run("2 light themes", func(t *testing.T) {
 		lightThemes := registry.ListByVariant(ThemeVariantLight)
-		assert.Len(t, lightThemes, 1)
-		assert.Equal(t, ThemeLatte, lightThemes[0].Name())
+		require.Len(t, lightThemes, 2)
+
+		names := []ThemeName{lightThemes[0].Name(), lightThemes[1].Name()}
+		assert.ElementsMatch(t, []ThemeName{ThemeLatte, ThemeHighContrastLight}, names)
 	})
 }
 
@@ -199,3 +206,13 @@ func TestAllThemesAreUnique(t *testing.T) {
 		}
 	})
 }
+
+func TestBundledComponentColors(t *testing.T) {
+	for _, theme := range []*Theme{createGruvboxTheme(), createNordTheme(), createTokyoNightTheme()} {
+		require.NotNil(t, theme)
+	}
+
+	assert.Equal(t, map[string]Color{"base": "#1d2021"}, createGruvboxTheme().ComponentColors("kitty"))
+	assert.Equal(t, Color("#88c0d0"), createNordTheme().ComponentColors("hyprland")["mauve"])
+	assert.Empty(t, createTokyoNightTheme().ComponentColors("kitty"))
+}
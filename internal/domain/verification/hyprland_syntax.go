@@ -0,0 +1,92 @@
+package verification
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// SyntaxProblem is a line of a Hyprland configuration that Hyprland cannot
+// parse
+type SyntaxProblem struct {
+	Line    int
+	Message string
+}
+
+// String returns the problem as "line 12: unexpected }"
+func (p SyntaxProblem) String() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// CheckHyprlandSyntax finds the lines of a Hyprland configuration that are
+// neither assignments nor section braces, and sections that are closed
+// without being opened or never closed. Values are not checked; Hyprland
+// reports those itself once it runs.
+func CheckHyprlandSyntax(content string) []SyntaxProblem {
+	var problems []SyntaxProblem
+
+	// Lines where the sections still open were opened, innermost last
+	var open []int
+	var names []string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(stripHyprlandComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		// Closing braces stand on lines of their own, several at a time
+		if strings.Trim(line, "} \t") == "" {
+			for closes := strings.Count(line, "}"); closes > 0; closes-- {
+				if len(open) == 0 {
+					problems = append(problems, SyntaxProblem{Line: lineNumber, Message: "unexpected }"})
+					continue
+				}
+				open = open[:len(open)-1]
+				names = names[:len(names)-1]
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(line, "{"):
+			name := strings.TrimSpace(strings.TrimSuffix(line, "{"))
+			if name == "" || strings.ContainsAny(name, "= \t") {
+				problems = append(problems, SyntaxProblem{Line: lineNumber, Message: fmt.Sprintf("invalid section name %q", name)})
+			}
+			open = append(open, lineNumber)
+			names = append(names, name)
+		default:
+			key, _, ok := strings.Cut(line, "=")
+			key = strings.TrimSpace(key)
+			switch {
+			case !ok:
+				problems = append(problems, SyntaxProblem{Line: lineNumber, Message: fmt.Sprintf("%q is not an assignment", line)})
+			case key == "" || strings.ContainsAny(key, " \t"):
+				problems = append(problems, SyntaxProblem{Line: lineNumber, Message: fmt.Sprintf("invalid keyword %q", key)})
+			}
+		}
+	}
+
+	for i := range open {
+		problems = append(problems, SyntaxProblem{Line: open[i], Message: fmt.Sprintf("section %s is never closed", names[i])})
+	}
+	return problems
+}
+
+// stripHyprlandComment removes a trailing # comment. A doubled ## stands for
+// a literal #, as in colors written rgba(##...).
+func stripHyprlandComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' {
+			continue
+		}
+		if i+1 < len(line) && line[i+1] == '#' {
+			i++
+			continue
+		}
+		return line[:i]
+	}
+	return line
+}
@@ -0,0 +1,50 @@
+package verification_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckHyprlandSyntax(t *testing.T) {
+	t.Run("accepts assignments, sections and comments", func(t *testing.T) {
+		content := `# Hyprland configuration
+$mod = SUPER
+source = ~/.config/hypr/keybinds.conf
+exec-once = waybar
+
+general {
+    gaps_in = 5 # inner gaps
+    col.active_border = rgba(##89b4faee)
+    decoration {
+        rounding = 10
+    }
+}
+
+device:my-mouse {
+    sensitivity = -0.5
+}
+bind = $mod, Return, exec, kitty
+`
+		assert.Empty(t, verification.CheckHyprlandSyntax(content))
+	})
+
+	t.Run("reports lines that are not assignments", func(t *testing.T) {
+		problems := verification.CheckHyprlandSyntax("general {\n    gaps_in 5\n}\nexec once = waybar\n")
+
+		assert.Equal(t, []verification.SyntaxProblem{
+			{Line: 2, Message: `"gaps_in 5" is not an assignment`},
+			{Line: 4, Message: `invalid keyword "exec once"`},
+		}, problems)
+	})
+
+	t.Run("reports unbalanced sections", func(t *testing.T) {
+		problems := verification.CheckHyprlandSyntax("input {\n    kb_layout = us\n\ngeneral {\n    gaps_in = 5\n}\n}\n}\n")
+
+		assert.Equal(t, []verification.SyntaxProblem{{Line: 8, Message: "unexpected }"}}, problems)
+
+		problems = verification.CheckHyprlandSyntax("input {\n    kb_layout = us\n")
+		assert.Equal(t, "line 1: section input is never closed", problems[0].String())
+	})
+}
@@ -0,0 +1,203 @@
+package verification
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// KeyboardLayout is the xkb layout configuration of a keyboard. Several
+// layouts may be configured, each with its own variant at the same index.
+type KeyboardLayout struct {
+	layouts  []string
+	variants []string
+}
+
+// NewKeyboardLayout parses comma-separated layout and variant lists, as
+// written in kb_layout and kb_variant
+func NewKeyboardLayout(layout, variant string) KeyboardLayout {
+	return KeyboardLayout{
+		layouts:  splitList(layout),
+		variants: splitList(variant),
+	}
+}
+
+// Layouts returns the configured layouts
+func (k KeyboardLayout) Layouts() []string {
+	layouts := make([]string, len(k.layouts))
+	copy(layouts, k.layouts)
+	return layouts
+}
+
+// Variant returns the variant of the layout at index i, or "" for the
+// default variant
+func (k KeyboardLayout) Variant(i int) string {
+	if i < 0 || i >= len(k.variants) {
+		return ""
+	}
+	return k.variants[i]
+}
+
+// IsEmpty returns true if no layout is configured
+func (k KeyboardLayout) IsEmpty() bool {
+	return len(k.layouts) == 0
+}
+
+// Equal returns true if both configure the same layouts and variants
+func (k KeyboardLayout) Equal(other KeyboardLayout) bool {
+	if len(k.layouts) != len(other.layouts) {
+		return false
+	}
+	for i := range k.layouts {
+		if k.layouts[i] != other.layouts[i] || k.Variant(i) != other.Variant(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the layouts as "us, de(nodeadkeys)"
+func (k KeyboardLayout) String() string {
+	parts := make([]string, 0, len(k.layouts))
+	for i, layout := range k.layouts {
+		if variant := k.Variant(i); variant != "" {
+			parts = append(parts, fmt.Sprintf("%s(%s)", layout, variant))
+		} else {
+			parts = append(parts, layout)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MissingFrom returns the layouts and variants the registry does not know
+func (k KeyboardLayout) MissingFrom(registry XKBRegistry) []string {
+	var missing []string
+	for i, layout := range k.layouts {
+		if !registry.HasLayout(layout) {
+			missing = append(missing, fmt.Sprintf("layout %q", layout))
+			continue
+		}
+		if variant := k.Variant(i); variant != "" && !registry.HasVariant(layout, variant) {
+			missing = append(missing, fmt.Sprintf("variant %q of layout %q", variant, layout))
+		}
+	}
+	return missing
+}
+
+// ParseInputKeyboard reads kb_layout and kb_variant from the input section
+// of a Hyprland configuration. Later settings override earlier ones, as in
+// Hyprland. found is false if neither is set.
+func ParseInputKeyboard(content string) (layout, variant string, found bool) {
+	var sections []string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasSuffix(line, "{"):
+			sections = append(sections, strings.TrimSpace(strings.TrimSuffix(line, "{")))
+			continue
+		case line == "}":
+			if len(sections) > 0 {
+				sections = sections[:len(sections)-1]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		// Both "input { kb_layout = us }" and "input:kb_layout = us" are valid
+		if len(sections) == 1 && sections[0] == "input" {
+			key = "input:" + key
+		}
+		switch key {
+		case "input:kb_layout":
+			layout, found = value, true
+		case "input:kb_variant":
+			variant, found = value, true
+		}
+	}
+	return layout, variant, found
+}
+
+// XKBRegistry lists the layouts and variants xkb knows
+type XKBRegistry struct {
+	variants map[string]map[string]bool // layout -> variants
+}
+
+// ParseXKBRegistry reads the layout and variant sections of an xkb rules
+// list such as /usr/share/X11/xkb/rules/evdev.lst
+func ParseXKBRegistry(content string) XKBRegistry {
+	registry := XKBRegistry{variants: make(map[string]map[string]bool)}
+
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "!") {
+			section = strings.TrimSpace(strings.TrimPrefix(line, "!"))
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch section {
+		case "layout":
+			if _, ok := registry.variants[fields[0]]; !ok {
+				registry.variants[fields[0]] = make(map[string]bool)
+			}
+		case "variant":
+			// "nodeadkeys      de: German (no dead keys)"
+			if len(fields) < 2 {
+				continue
+			}
+			layout := strings.TrimSuffix(fields[1], ":")
+			if _, ok := registry.variants[layout]; !ok {
+				registry.variants[layout] = make(map[string]bool)
+			}
+			registry.variants[layout][fields[0]] = true
+		}
+	}
+	return registry
+}
+
+// IsEmpty returns true if the registry lists no layouts
+func (r XKBRegistry) IsEmpty() bool {
+	return len(r.variants) == 0
+}
+
+// HasLayout returns true if xkb knows the layout
+func (r XKBRegistry) HasLayout(layout string) bool {
+	_, ok := r.variants[layout]
+	return ok
+}
+
+// HasVariant returns true if xkb knows the variant of the layout
+func (r XKBRegistry) HasVariant(layout, variant string) bool {
+	return r.variants[layout][variant]
+}
+
+// splitList splits a comma-separated list, keeping empty entries between
+// commas so variants stay aligned with their layouts
+func splitList(value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
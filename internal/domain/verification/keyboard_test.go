@@ -0,0 +1,74 @@
+package verification_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+	"github.com/stretchr/testify/assert"
+)
+
+const evdevList = `! model
+  pc105           Generic 105-key PC
+
+! layout
+  us              English (US)
+  de              German
+
+! variant
+  intl            us: English (US, intl., with dead keys)
+  nodeadkeys      de: German (no dead keys)
+
+! option
+  grp                  Switching to another layout
+`
+
+func TestParseInputKeyboard(t *testing.T) {
+	t.Run("reads the input section", func(t *testing.T) {
+		layout, variant, found := verification.ParseInputKeyboard(`
+general {
+    kb_layout = fr # not an input setting
+}
+input {
+    kb_layout = us,de # primary first
+    kb_variant = ,nodeadkeys
+    touchpad {
+        natural_scroll = true
+    }
+}
+`)
+		assert.True(t, found)
+		assert.Equal(t, "us,de", layout)
+		assert.Equal(t, ",nodeadkeys", variant)
+	})
+
+	t.Run("later settings win", func(t *testing.T) {
+		layout, _, found := verification.ParseInputKeyboard("input {\n kb_layout = us\n}\ninput:kb_layout = de\n")
+		assert.True(t, found)
+		assert.Equal(t, "de", layout)
+	})
+
+	t.Run("reports no layout", func(t *testing.T) {
+		_, _, found := verification.ParseInputKeyboard("source = ~/.config/hypr/input.conf\n")
+		assert.False(t, found)
+	})
+}
+
+func TestKeyboardLayout(t *testing.T) {
+	registry := verification.ParseXKBRegistry(evdevList)
+	assert.True(t, registry.HasLayout("us"))
+	assert.False(t, registry.HasLayout("pc105"), "models are not layouts")
+	assert.True(t, registry.HasVariant("de", "nodeadkeys"))
+	assert.False(t, registry.HasVariant("us", "nodeadkeys"))
+
+	layout := verification.NewKeyboardLayout("us, de", ",nodeadkeys")
+	assert.Equal(t, "us, de(nodeadkeys)", layout.String())
+	assert.Empty(t, layout.MissingFrom(registry))
+	assert.True(t, layout.Equal(verification.NewKeyboardLayout("us,de", ",nodeadkeys")))
+	assert.False(t, layout.Equal(verification.NewKeyboardLayout("us,de", "")))
+
+	assert.Equal(t,
+		[]string{`layout "xx"`, `variant "colemak" of layout "de"`},
+		verification.NewKeyboardLayout("xx,de", ",colemak").MissingFrom(registry),
+	)
+	assert.True(t, verification.NewKeyboardLayout("", "").IsEmpty())
+}
@@ -17,6 +17,9 @@ const (
 	ComponentShell          ComponentName = "shell"
 	ComponentWallpaper      ComponentName = "wallpaper"
 	ComponentPermissions    ComponentName = "permissions"
+	ComponentSwap           ComponentName = "swap"
+	ComponentCompositor     ComponentName = "compositor"
+	ComponentKeyboard       ComponentName = "keyboard"
 )
 
 // CheckStatus represents the outcome of a verification check
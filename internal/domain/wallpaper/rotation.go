@@ -0,0 +1,74 @@
+package wallpaper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidInterval is returned for a rotation interval too short to be
+// worth redrawing the desktop for
+var ErrInvalidInterval = errors.New("invalid rotation interval")
+
+// MinRotationInterval is the shortest interval wallpapers rotate at
+const MinRotationInterval = time.Minute
+
+// Names of the systemd user units rotating the wallpaper
+const (
+	RotationServiceName = "gohan-wallpaper.service"
+	RotationTimerName   = "gohan-wallpaper.timer"
+)
+
+// Rotation is the service and timer pair that shows another image from
+// the rotation directory every interval
+type Rotation struct {
+	interval   time.Duration
+	executable string
+}
+
+// NewRotation creates the units for a rotation. executable is the absolute
+// path of the gohan binary the service runs.
+func NewRotation(interval time.Duration, executable string) (Rotation, error) {
+	if interval < MinRotationInterval {
+		return Rotation{}, fmt.Errorf("%w: %s is shorter than %s", ErrInvalidInterval, interval, MinRotationInterval)
+	}
+	if !strings.HasPrefix(executable, "/") {
+		return Rotation{}, fmt.Errorf("executable must be an absolute path: %q", executable)
+	}
+	return Rotation{interval: interval, executable: executable}, nil
+}
+
+// Interval returns how often the wallpaper changes
+func (r Rotation) Interval() time.Duration {
+	return r.interval
+}
+
+// RenderService returns the oneshot service showing the next image
+func (r Rotation) RenderService() string {
+	var b strings.Builder
+	b.WriteString("# Managed by gohan\n")
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=Show the next gohan wallpaper\n")
+	b.WriteString("\n[Service]\n")
+	b.WriteString("Type=oneshot\n")
+	fmt.Fprintf(&b, "ExecStart=%s wallpaper next\n", r.executable)
+	return b.String()
+}
+
+// RenderTimer returns the timer starting the service every interval,
+// counted from when the timer is enabled or the session starts
+func (r Rotation) RenderTimer() string {
+	seconds := int64(r.interval / time.Second)
+
+	var b strings.Builder
+	b.WriteString("# Managed by gohan\n")
+	b.WriteString("[Unit]\n")
+	fmt.Fprintf(&b, "Description=Rotate the gohan wallpaper every %s\n", r.interval)
+	b.WriteString("\n[Timer]\n")
+	fmt.Fprintf(&b, "OnActiveSec=%ds\n", seconds)
+	fmt.Fprintf(&b, "OnUnitActiveSec=%ds\n", seconds)
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=timers.target\n")
+	return b.String()
+}
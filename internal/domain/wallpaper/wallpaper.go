@@ -0,0 +1,232 @@
+// Package wallpaper models the wallpaper shown on each monitor and how it
+// is handed to the wallpaper daemon, hyprpaper or swaybg
+package wallpaper
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrUnknownTool is returned for a wallpaper daemon gohan does not manage
+	ErrUnknownTool = errors.New("unknown wallpaper tool")
+	// ErrNotAnImage is returned for a file without an image extension
+	ErrNotAnImage = errors.New("not a supported image")
+	// ErrInvalidMonitor is returned for a monitor name the daemons cannot parse
+	ErrInvalidMonitor = errors.New("invalid monitor name")
+	// ErrNoImages is returned when a directory holds no images to pick from
+	ErrNoImages = errors.New("no images found")
+)
+
+// Tool is the daemon drawing the wallpaper, one of the wallpaper
+// alternatives
+type Tool string
+
+const (
+	ToolSwaybg    Tool = "swaybg"
+	ToolHyprpaper Tool = "hyprpaper"
+)
+
+// Tools lists the supported daemons, preferred first when several are
+// installed
+var Tools = []Tool{ToolHyprpaper, ToolSwaybg}
+
+// ParseTool returns the tool with the given package name
+func ParseTool(name string) (Tool, error) {
+	for _, tool := range Tools {
+		if string(tool) == name {
+			return tool, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownTool, name)
+}
+
+// AllMonitors is the monitor of the wallpaper shown on every monitor
+// without its own
+const AllMonitors = ""
+
+// imageExtensions are the formats both hyprpaper and swaybg read
+var imageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".webp": true,
+}
+
+// IsImage reports whether path has the extension of a supported image
+func IsImage(path string) bool {
+	return imageExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// Assignment is the image shown on a monitor
+type Assignment struct {
+	Monitor string // AllMonitors for the default wallpaper
+	Image   string // Absolute path
+}
+
+// Layout is the wallpaper of each monitor
+type Layout struct {
+	assignments map[string]string
+}
+
+// NewLayout creates a layout from assignments; a later assignment to the
+// same monitor replaces an earlier one
+func NewLayout(assignments ...Assignment) (Layout, error) {
+	layout := Layout{assignments: make(map[string]string, len(assignments))}
+	for _, assignment := range assignments {
+		if err := ValidateMonitor(assignment.Monitor); err != nil {
+			return Layout{}, err
+		}
+		if assignment.Image == "" {
+			return Layout{}, fmt.Errorf("no image for monitor %q", assignment.Monitor)
+		}
+		layout.assignments[assignment.Monitor] = assignment.Image
+	}
+	return layout, nil
+}
+
+// ValidateMonitor reports whether a monitor name can be written to
+// hyprpaper.conf and passed to swaybg, such as DP-1 or HDMI-A-1
+func ValidateMonitor(monitor string) error {
+	if strings.ContainsAny(monitor, ", \t\n'\"/") {
+		return fmt.Errorf("%w: %q", ErrInvalidMonitor, monitor)
+	}
+	return nil
+}
+
+// Assignments returns the assignments, the default wallpaper first and
+// then by monitor name
+func (l Layout) Assignments() []Assignment {
+	assignments := make([]Assignment, 0, len(l.assignments))
+	for monitor, image := range l.assignments {
+		assignments = append(assignments, Assignment{Monitor: monitor, Image: image})
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		return assignments[i].Monitor < assignments[j].Monitor
+	})
+	return assignments
+}
+
+// IsEmpty reports whether no monitor has a wallpaper
+func (l Layout) IsEmpty() bool {
+	return len(l.assignments) == 0
+}
+
+// RenderHyprpaper returns the hyprpaper.conf showing the layout
+func (l Layout) RenderHyprpaper() string {
+	assignments := l.Assignments()
+
+	var b strings.Builder
+	b.WriteString("# Managed by gohan; change with gohan wallpaper set\n")
+	preloaded := make(map[string]bool, len(assignments))
+	for _, assignment := range assignments {
+		if !preloaded[assignment.Image] {
+			fmt.Fprintf(&b, "preload = %s\n", assignment.Image)
+			preloaded[assignment.Image] = true
+		}
+	}
+	for _, assignment := range assignments {
+		fmt.Fprintf(&b, "wallpaper = %s,%s\n", assignment.Monitor, assignment.Image)
+	}
+	b.WriteString("splash = false\n")
+	b.WriteString("ipc = on\n")
+	return b.String()
+}
+
+// Command returns the command starting tool with the layout. hyprpaper
+// reads hyprpaper.conf; swaybg takes each monitor's image as arguments.
+func (l Layout) Command(tool Tool) string {
+	if tool == ToolHyprpaper {
+		return "hyprpaper"
+	}
+
+	args := []string{"swaybg"}
+	for _, assignment := range l.Assignments() {
+		output := assignment.Monitor
+		if output == AllMonitors {
+			output = "'*'"
+		}
+		args = append(args, "-o", output, "-i", shellQuote(assignment.Image), "-m", "fill")
+	}
+	return strings.Join(args, " ")
+}
+
+// shellQuote quotes a path for Hyprland's exec, which runs it through sh
+func shellQuote(path string) string {
+	if !strings.ContainsAny(path, " \t'\"$\\`;&|<>()*?[]#~") {
+		return path
+	}
+	return "'" + strings.ReplaceAll(path, "'", `'\''`) + "'"
+}
+
+// autostartComment heads the wallpaper line added to an autostart.conf
+// without one
+const autostartComment = "# Wallpaper"
+
+// SetAutostart returns autostart.conf with its wallpaper daemon started by
+// command. The first exec-once line starting a wallpaper daemon is
+// replaced and any others dropped, so switching daemons does not start
+// both; without one, the line is appended.
+func SetAutostart(autostart, command string) string {
+	line := "exec-once = " + command
+
+	lines := strings.Split(autostart, "\n")
+	result := make([]string, 0, len(lines)+2)
+	replaced := false
+	for _, existing := range lines {
+		if !startsWallpaperTool(existing) {
+			result = append(result, existing)
+			continue
+		}
+		if !replaced {
+			result = append(result, line)
+			replaced = true
+		}
+	}
+	if replaced {
+		return strings.Join(result, "\n")
+	}
+
+	content := strings.TrimRight(autostart, "\n")
+	if content != "" {
+		content += "\n\n"
+	}
+	return content + autostartComment + "\n" + line + "\n"
+}
+
+// startsWallpaperTool reports whether a configuration line is an exec-once
+// of one of the wallpaper daemons
+func startsWallpaperTool(line string) bool {
+	key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+	if !ok || strings.TrimSpace(key) != "exec-once" {
+		return false
+	}
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return false
+	}
+	_, err := ParseTool(filepath.Base(fields[0]))
+	return err == nil
+}
+
+// PickImage returns one of images at random, avoiding current when there
+// is another to choose. intn returns a number in [0, n), such as
+// rand.Intn.
+func PickImage(images []string, current string, intn func(n int) int) (string, error) {
+	candidates := make([]string, 0, len(images))
+	for _, image := range images {
+		if image != current {
+			candidates = append(candidates, image)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = images
+	}
+	if len(candidates) == 0 {
+		return "", ErrNoImages
+	}
+	return candidates[intn(len(candidates))], nil
+}
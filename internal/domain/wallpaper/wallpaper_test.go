@@ -0,0 +1,120 @@
+package wallpaper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLayout(t *testing.T) {
+	t.Run("a later assignment replaces an earlier one", func(t *testing.T) {
+		layout, err := wallpaper.NewLayout(
+			wallpaper.Assignment{Monitor: "DP-1", Image: "/w/a.jpg"},
+			wallpaper.Assignment{Monitor: wallpaper.AllMonitors, Image: "/w/all.jpg"},
+			wallpaper.Assignment{Monitor: "DP-1", Image: "/w/b.jpg"},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []wallpaper.Assignment{
+			{Monitor: wallpaper.AllMonitors, Image: "/w/all.jpg"},
+			{Monitor: "DP-1", Image: "/w/b.jpg"},
+		}, layout.Assignments())
+	})
+
+	t.Run("rejects monitor names the daemons cannot parse", func(t *testing.T) {
+		_, err := wallpaper.NewLayout(wallpaper.Assignment{Monitor: "DP-1,HDMI-A-1", Image: "/w/a.jpg"})
+		assert.ErrorIs(t, err, wallpaper.ErrInvalidMonitor)
+	})
+}
+
+func TestLayout_RenderHyprpaper(t *testing.T) {
+	layout, err := wallpaper.NewLayout(
+		wallpaper.Assignment{Monitor: wallpaper.AllMonitors, Image: "/w/all.jpg"},
+		wallpaper.Assignment{Monitor: "DP-1", Image: "/w/side.png"},
+		wallpaper.Assignment{Monitor: "HDMI-A-1", Image: "/w/all.jpg"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Managed by gohan; change with gohan wallpaper set
+preload = /w/all.jpg
+preload = /w/side.png
+wallpaper = ,/w/all.jpg
+wallpaper = DP-1,/w/side.png
+wallpaper = HDMI-A-1,/w/all.jpg
+splash = false
+ipc = on
+`, layout.RenderHyprpaper())
+}
+
+func TestLayout_Command(t *testing.T) {
+	layout, err := wallpaper.NewLayout(
+		wallpaper.Assignment{Monitor: wallpaper.AllMonitors, Image: "/home/me/.config/gohan/wallpaper.jpg"},
+		wallpaper.Assignment{Monitor: "DP-1", Image: "/home/me/My Pictures/side.png"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "hyprpaper", layout.Command(wallpaper.ToolHyprpaper))
+	assert.Equal(t,
+		"swaybg -o '*' -i /home/me/.config/gohan/wallpaper.jpg -m fill -o DP-1 -i '/home/me/My Pictures/side.png' -m fill",
+		layout.Command(wallpaper.ToolSwaybg))
+}
+
+func TestSetAutostart(t *testing.T) {
+	t.Run("replaces the daemon's line in place", func(t *testing.T) {
+		autostart := "exec-once = waybar\n\n# Wallpaper\nexec-once = swaybg -i ~/.config/gohan/wallpaper.jpg -m fill\n\nexec-once = mako\n"
+
+		assert.Equal(t,
+			"exec-once = waybar\n\n# Wallpaper\nexec-once = hyprpaper\n\nexec-once = mako\n",
+			wallpaper.SetAutostart(autostart, "hyprpaper"))
+	})
+
+	t.Run("drops the lines of other daemons", func(t *testing.T) {
+		autostart := "exec-once = hyprpaper\nexec-once = /usr/bin/swaybg -i a.jpg\n"
+
+		assert.Equal(t, "exec-once = swaybg -o '*' -i b.jpg -m fill\n",
+			wallpaper.SetAutostart(autostart, "swaybg -o '*' -i b.jpg -m fill"))
+	})
+
+	t.Run("appends a line when none starts a daemon", func(t *testing.T) {
+		assert.Equal(t, "exec-once = waybar\n\n# Wallpaper\nexec-once = hyprpaper\n",
+			wallpaper.SetAutostart("exec-once = waybar\n", "hyprpaper"))
+	})
+}
+
+func TestPickImage(t *testing.T) {
+	first := func(int) int { return 0 }
+
+	t.Run("avoids the current image", func(t *testing.T) {
+		image, err := wallpaper.PickImage([]string{"/w/a.jpg", "/w/b.jpg"}, "/w/a.jpg", first)
+		require.NoError(t, err)
+		assert.Equal(t, "/w/b.jpg", image)
+	})
+
+	t.Run("keeps the only image", func(t *testing.T) {
+		image, err := wallpaper.PickImage([]string{"/w/a.jpg"}, "/w/a.jpg", first)
+		require.NoError(t, err)
+		assert.Equal(t, "/w/a.jpg", image)
+	})
+
+	t.Run("fails without images", func(t *testing.T) {
+		_, err := wallpaper.PickImage(nil, "", first)
+		assert.ErrorIs(t, err, wallpaper.ErrNoImages)
+	})
+}
+
+func TestNewRotation(t *testing.T) {
+	_, err := wallpaper.NewRotation(10*time.Second, "/usr/bin/gohan")
+	assert.ErrorIs(t, err, wallpaper.ErrInvalidInterval)
+
+	_, err = wallpaper.NewRotation(time.Hour, "gohan")
+	assert.Error(t, err)
+
+	rotation, err := wallpaper.NewRotation(30*time.Minute, "/usr/bin/gohan")
+	require.NoError(t, err)
+	assert.Contains(t, rotation.RenderService(), "ExecStart=/usr/bin/gohan wallpaper next\n")
+	assert.Contains(t, rotation.RenderTimer(), "OnUnitActiveSec=1800s\n")
+	assert.Contains(t, rotation.RenderTimer(), "WantedBy=timers.target\n")
+}
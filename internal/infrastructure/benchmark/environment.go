@@ -0,0 +1,64 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+	"github.com/rebelopsio/gohan/internal/infrastructure/preflight/detectors"
+)
+
+// SystemEnvironmentDetector describes the running system for benchmark reports
+type SystemEnvironmentDetector struct {
+	gpuDetector *detectors.SystemGPUDetector
+}
+
+// NewSystemEnvironmentDetector creates a new environment detector
+func NewSystemEnvironmentDetector() *SystemEnvironmentDetector {
+	return &SystemEnvironmentDetector{gpuDetector: detectors.NewSystemGPUDetector()}
+}
+
+// InHyprlandSession returns true if running inside a Hyprland session
+func (d *SystemEnvironmentDetector) InHyprlandSession() bool {
+	return os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != ""
+}
+
+// DetectEnvironment reports versions, GPU and monitor; fields that cannot
+// be detected are left empty
+func (d *SystemEnvironmentDetector) DetectEnvironment(ctx context.Context) (benchmark.Environment, error) {
+	env := benchmark.Environment{}
+
+	if release, err := os.ReadFile("/proc/sys/kernel/osrelease"); err == nil {
+		env.KernelVersion = strings.TrimSpace(string(release))
+	}
+
+	if gpu, err := d.gpuDetector.PrimaryGPU(ctx); err == nil {
+		env.GPU = gpu.String()
+	}
+	env.GPUDriver = gpuDriver()
+
+	ipc, err := newHyprIPC()
+	if err != nil {
+		return env, err
+	}
+	env.HyprlandVersion = hyprlandVersion(ctx, ipc)
+	if monitor, err := ipc.focusedMonitor(ctx); err == nil {
+		env.Monitor = fmt.Sprintf("%s %dx%d@%.2fHz", monitor.Name, monitor.Width, monitor.Height, monitor.RefreshRate)
+	}
+
+	return env, nil
+}
+
+// gpuDriver returns the kernel driver bound to the first DRM card
+func gpuDriver() string {
+	matches, _ := filepath.Glob("/sys/class/drm/card[0-9]*/device/driver")
+	for _, link := range matches {
+		if target, err := filepath.EvalSymlinks(link); err == nil {
+			return filepath.Base(target)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+)
+
+// gpuSampleInterval is how often GPU utilization is read
+const gpuSampleInterval = 250 * time.Millisecond
+
+// SystemGPUSampler reads GPU utilization from amdgpu sysfs or nvidia-smi
+type SystemGPUSampler struct {
+	drmDir string
+}
+
+// NewSystemGPUSampler creates a new GPU sampler
+func NewSystemGPUSampler() *SystemGPUSampler {
+	return &SystemGPUSampler{drmDir: "/sys/class/drm"}
+}
+
+// SampleGPUUtilization samples GPU busy percentage for the given duration
+func (s *SystemGPUSampler) SampleGPUUtilization(ctx context.Context, duration time.Duration) ([]benchmark.Metric, error) {
+	read, err := s.reader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []float64
+	deadline := time.Now().Add(duration)
+	for time.Now().Before(deadline) {
+		busy, err := read()
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, busy)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(gpuSampleInterval):
+		}
+	}
+
+	stats, err := benchmark.Summarize(samples)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMetrics([]metricValue{
+		{benchmark.MetricGPUUtilizationAvg, stats.Avg, "%"},
+		{benchmark.MetricGPUUtilizationMax, stats.Max, "%"},
+	})
+}
+
+// reader picks the utilization source for the installed GPU
+func (s *SystemGPUSampler) reader(ctx context.Context) (func() (float64, error), error) {
+	// amdgpu exposes a busy percentage per card
+	matches, _ := filepath.Glob(filepath.Join(s.drmDir, "card*", "device", "gpu_busy_percent"))
+	if len(matches) > 0 {
+		path := matches[0]
+		return func() (float64, error) {
+			return readPercent(path)
+		}, nil
+	}
+
+	if _, err := exec.LookPath("nvidia-smi"); err == nil {
+		return func() (float64, error) {
+			output, err := exec.CommandContext(ctx, "nvidia-smi",
+				"--query-gpu=utilization.gpu", "--format=csv,noheader,nounits").Output()
+			if err != nil {
+				return 0, fmt.Errorf("nvidia-smi failed: %w", err)
+			}
+			line := strings.SplitN(strings.TrimSpace(string(output)), "\n", 2)[0]
+			return strconv.ParseFloat(strings.TrimSpace(line), 64)
+		}, nil
+	}
+
+	return nil, fmt.Errorf("GPU utilization is not exposed by this driver (supported: amdgpu, nvidia)")
+}
+
+func readPercent(path string) (float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+}
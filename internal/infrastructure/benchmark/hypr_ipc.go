@@ -0,0 +1,105 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+)
+
+// hyprIPC talks to the Hyprland control socket, the same one hyprctl uses
+// Requests are sent directly rather than by spawning hyprctl so process
+// startup does not distort the timings
+type hyprIPC struct {
+	socketPath string
+}
+
+// newHyprIPC locates the control socket of the current Hyprland instance
+func newHyprIPC() (*hyprIPC, error) {
+	signature := os.Getenv("HYPRLAND_INSTANCE_SIGNATURE")
+	if signature == "" {
+		return nil, benchmark.ErrNotInHyprlandSession
+	}
+
+	// Hyprland 0.40+ uses XDG_RUNTIME_DIR, older releases /tmp
+	candidates := []string{}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		candidates = append(candidates, filepath.Join(runtimeDir, "hypr", signature, ".socket.sock"))
+	}
+	candidates = append(candidates, filepath.Join("/tmp/hypr", signature, ".socket.sock"))
+
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return &hyprIPC{socketPath: path}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("hyprland control socket not found for instance %s", signature)
+}
+
+// request sends a command and returns the raw response
+func (c *hyprIPC) request(ctx context.Context, command string) ([]byte, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", c.socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to hyprland: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+	}
+
+	if _, err := conn.Write([]byte(command)); err != nil {
+		return nil, fmt.Errorf("failed to send %q: %w", command, err)
+	}
+
+	return io.ReadAll(conn)
+}
+
+// requestJSON sends a JSON request (j/ prefix) and decodes the response
+func (c *hyprIPC) requestJSON(ctx context.Context, command string, v interface{}) error {
+	data, err := c.request(ctx, "j/"+command)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", command, err)
+	}
+	return nil
+}
+
+// hyprMonitor is the subset of `hyprctl -j monitors` used here
+type hyprMonitor struct {
+	Name        string  `json:"name"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	RefreshRate float64 `json:"refreshRate"`
+	Focused     bool    `json:"focused"`
+}
+
+// focusedMonitor returns the focused monitor, or the first one
+func (c *hyprIPC) focusedMonitor(ctx context.Context) (hyprMonitor, error) {
+	var monitors []hyprMonitor
+	if err := c.requestJSON(ctx, "monitors", &monitors); err != nil {
+		return hyprMonitor{}, err
+	}
+	if len(monitors) == 0 {
+		return hyprMonitor{}, fmt.Errorf("hyprland reported no monitors")
+	}
+
+	for _, m := range monitors {
+		if m.Focused {
+			return m, nil
+		}
+	}
+	return monitors[0], nil
+}
@@ -0,0 +1,168 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+)
+
+// frameSampleInterval is how often the compositor is probed while sampling
+const frameSampleInterval = 50 * time.Millisecond
+
+// HyprlandSampler measures frame timing and input latency through the
+// Hyprland control socket
+type HyprlandSampler struct{}
+
+// NewHyprlandSampler creates a new Hyprland sampler
+func NewHyprlandSampler() *HyprlandSampler {
+	return &HyprlandSampler{}
+}
+
+// SampleFrameTiming reports the focused monitor's frame budget and how long
+// the compositor main loop takes to answer requests. IPC is served between
+// frames, so slow round trips mean the compositor is missing frames.
+func (s *HyprlandSampler) SampleFrameTiming(ctx context.Context, duration time.Duration) ([]benchmark.Metric, error) {
+	ipc, err := newHyprIPC()
+	if err != nil {
+		return nil, err
+	}
+
+	monitor, err := ipc.focusedMonitor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if monitor.RefreshRate <= 0 {
+		return nil, fmt.Errorf("monitor %s reports no refresh rate", monitor.Name)
+	}
+	frameBudget := 1000 / monitor.RefreshRate
+
+	var samples []float64
+	deadline := time.Now().Add(duration)
+	for time.Now().Before(deadline) {
+		start := time.Now()
+		if _, err := ipc.request(ctx, "j/activeworkspace"); err != nil {
+			return nil, err
+		}
+		samples = append(samples, float64(time.Since(start).Microseconds())/1000)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(frameSampleInterval):
+		}
+	}
+
+	stats, err := benchmark.Summarize(samples)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMetrics([]metricValue{
+		{benchmark.MetricRefreshRate, monitor.RefreshRate, "Hz"},
+		{benchmark.MetricFrameBudget, frameBudget, "ms"},
+		{benchmark.MetricMainLoopAvg, stats.Avg, "ms"},
+		{benchmark.MetricMainLoopP95, stats.P95, "ms"},
+		{benchmark.MetricMainLoopMax, stats.Max, "ms"},
+		{benchmark.MetricMissedFrameBudget, benchmark.FractionAbove(samples, frameBudget), "%"},
+	})
+}
+
+// MeasureInputLatency moves the cursor by one pixel with a synthetic input
+// event and times how long until the compositor reports the new position.
+// The cursor is returned to where it started.
+func (s *HyprlandSampler) MeasureInputLatency(ctx context.Context, samples int) ([]benchmark.Metric, error) {
+	ipc, err := newHyprIPC()
+	if err != nil {
+		return nil, err
+	}
+
+	origin, err := cursorPosition(ctx, ipc)
+	if err != nil {
+		return nil, err
+	}
+	defer ipc.request(context.Background(), fmt.Sprintf("dispatch movecursor %d %d", origin.X, origin.Y))
+
+	latencies := make([]float64, 0, samples)
+	for i := 0; i < samples; i++ {
+		target := origin
+		if i%2 == 0 {
+			target.X++
+		}
+
+		start := time.Now()
+		if _, err := ipc.request(ctx, fmt.Sprintf("dispatch movecursor %d %d", target.X, target.Y)); err != nil {
+			return nil, err
+		}
+		for {
+			pos, err := cursorPosition(ctx, ipc)
+			if err != nil {
+				return nil, err
+			}
+			if pos == target {
+				break
+			}
+			if time.Since(start) > time.Second {
+				return nil, fmt.Errorf("cursor did not reach %d,%d within 1s", target.X, target.Y)
+			}
+		}
+		latencies = append(latencies, float64(time.Since(start).Microseconds())/1000)
+	}
+
+	stats, err := benchmark.Summarize(latencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMetrics([]metricValue{
+		{benchmark.MetricInputLatencyAvg, stats.Avg, "ms"},
+		{benchmark.MetricInputLatencyP95, stats.P95, "ms"},
+	})
+}
+
+type cursorPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func cursorPosition(ctx context.Context, ipc *hyprIPC) (cursorPos, error) {
+	var pos cursorPos
+	err := ipc.requestJSON(ctx, "cursorpos", &pos)
+	return pos, err
+}
+
+// metricValue is a metric before validation
+type metricValue struct {
+	name  benchmark.MetricName
+	value float64
+	unit  string
+}
+
+func buildMetrics(values []metricValue) ([]benchmark.Metric, error) {
+	metrics := make([]benchmark.Metric, 0, len(values))
+	for _, v := range values {
+		m, err := benchmark.NewMetric(v.name, v.value, v.unit)
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, nil
+}
+
+// hyprlandVersion returns the running Hyprland version tag
+func hyprlandVersion(ctx context.Context, ipc *hyprIPC) string {
+	var version struct {
+		Tag     string `json:"tag"`
+		Version string `json:"version"`
+	}
+	if err := ipc.requestJSON(ctx, "version", &version); err != nil {
+		return ""
+	}
+	if version.Version != "" {
+		return version.Version
+	}
+	return strings.TrimPrefix(version.Tag, "v")
+}
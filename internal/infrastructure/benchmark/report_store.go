@@ -0,0 +1,137 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+)
+
+// baselineFile is the name of the baseline report within the store directory
+const baselineFile = "baseline.json"
+
+// reportDTO is the serialized form of a benchmark report
+type reportDTO struct {
+	ID          string         `json:"id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	Environment environmentDTO `json:"environment"`
+	Metrics     []metricDTO    `json:"metrics"`
+	Notes       []string       `json:"notes,omitempty"`
+}
+
+type environmentDTO struct {
+	HyprlandVersion string `json:"hyprland_version,omitempty"`
+	KernelVersion   string `json:"kernel_version,omitempty"`
+	GPU             string `json:"gpu,omitempty"`
+	GPUDriver       string `json:"gpu_driver,omitempty"`
+	Monitor         string `json:"monitor,omitempty"`
+}
+
+type metricDTO struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// FileReportStore stores benchmark reports as JSON files in a directory
+type FileReportStore struct {
+	dir string
+}
+
+// NewFileReportStore creates a new file-based report store
+func NewFileReportStore(dir string) *FileReportStore {
+	return &FileReportStore{dir: dir}
+}
+
+// Save writes the report to a timestamped file and returns its path
+func (s *FileReportStore) Save(ctx context.Context, report *benchmark.Report) (string, error) {
+	name := fmt.Sprintf("benchmark-%s.json", report.CreatedAt().Format("20060102-150405"))
+	path := filepath.Join(s.dir, name)
+	if err := s.write(path, report); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// SaveBaseline marks the report as the baseline for later comparisons
+func (s *FileReportStore) SaveBaseline(ctx context.Context, report *benchmark.Report) error {
+	return s.write(filepath.Join(s.dir, baselineFile), report)
+}
+
+// LoadBaseline returns the baseline report
+func (s *FileReportStore) LoadBaseline(ctx context.Context) (*benchmark.Report, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, baselineFile))
+	if os.IsNotExist(err) {
+		return nil, benchmark.ErrBaselineNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var dto reportDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal baseline: %w", err)
+	}
+
+	metrics := make([]benchmark.Metric, 0, len(dto.Metrics))
+	for _, m := range dto.Metrics {
+		metric, err := benchmark.NewMetric(benchmark.MetricName(m.Name), m.Value, m.Unit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore baseline: %w", err)
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return benchmark.ReconstructReport(dto.ID, dto.CreatedAt, benchmark.Environment{
+		HyprlandVersion: dto.Environment.HyprlandVersion,
+		KernelVersion:   dto.Environment.KernelVersion,
+		GPU:             dto.Environment.GPU,
+		GPUDriver:       dto.Environment.GPUDriver,
+		Monitor:         dto.Environment.Monitor,
+	}, metrics, dto.Notes)
+}
+
+func (s *FileReportStore) write(path string, report *benchmark.Report) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create benchmark directory: %w", err)
+	}
+
+	env := report.Environment()
+	dto := reportDTO{
+		ID:        report.ID(),
+		CreatedAt: report.CreatedAt(),
+		Environment: environmentDTO{
+			HyprlandVersion: env.HyprlandVersion,
+			KernelVersion:   env.KernelVersion,
+			GPU:             env.GPU,
+			GPUDriver:       env.GPUDriver,
+			Monitor:         env.Monitor,
+		},
+		Notes: report.Notes(),
+	}
+	for _, m := range report.Metrics() {
+		dto.Metrics = append(dto.Metrics, metricDTO{Name: m.Name().String(), Value: m.Value(), Unit: m.Unit()})
+	}
+
+	data, err := json.MarshalIndent(dto, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal benchmark report: %w", err)
+	}
+
+	// Write to a temp file first so an interrupted run never leaves a
+	// truncated baseline
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write benchmark report: %w", err)
+	}
+	if err := os.Rename(tmpFile, path); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to save benchmark report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package benchmark_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/benchmark"
+	benchmarkInfra "github.com/rebelopsio/gohan/internal/infrastructure/benchmark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReportStore(t *testing.T) {
+	ctx := context.Background()
+
+	metric, err := benchmark.NewMetric(benchmark.MetricInputLatencyAvg, 7.25, "ms")
+	require.NoError(t, err)
+	report, err := benchmark.NewReport(benchmark.Environment{
+		HyprlandVersion: "0.45.0",
+		GPUDriver:       "amdgpu",
+	}, []benchmark.Metric{metric}, []string{"GPU utilization unavailable"})
+	require.NoError(t, err)
+
+	t.Run("saves timestamped report", func(t *testing.T) {
+		dir := t.TempDir()
+		store := benchmarkInfra.NewFileReportStore(filepath.Join(dir, "benchmarks"))
+
+		path, err := store.Save(ctx, report)
+		require.NoError(t, err)
+		assert.Contains(t, filepath.Base(path), "benchmark-")
+		assert.FileExists(t, path)
+
+		_, err = os.Stat(path + ".tmp")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("round-trips baseline", func(t *testing.T) {
+		store := benchmarkInfra.NewFileReportStore(t.TempDir())
+
+		require.NoError(t, store.SaveBaseline(ctx, report))
+
+		loaded, err := store.LoadBaseline(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, report.ID(), loaded.ID())
+		assert.True(t, report.CreatedAt().Equal(loaded.CreatedAt()))
+		assert.Equal(t, report.Environment(), loaded.Environment())
+		assert.Equal(t, report.Notes(), loaded.Notes())
+
+		m, ok := loaded.Metric(benchmark.MetricInputLatencyAvg)
+		require.True(t, ok)
+		assert.Equal(t, 7.25, m.Value())
+		assert.Equal(t, "ms", m.Unit())
+	})
+
+	t.Run("missing baseline", func(t *testing.T) {
+		store := benchmarkInfra.NewFileReportStore(t.TempDir())
+
+		_, err := store.LoadBaseline(ctx)
+		assert.ErrorIs(t, err, benchmark.ErrBaselineNotFound)
+	})
+}
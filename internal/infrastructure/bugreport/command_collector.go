@@ -0,0 +1,131 @@
+package bugreport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+)
+
+// commandTimeout bounds each diagnostic command so a hung tool cannot
+// block the whole report
+const commandTimeout = 10 * time.Second
+
+// Command is a diagnostic command or file whose output goes into a report
+type Command struct {
+	Title string   // Section heading in the artifact
+	Name  string   // Executable; empty when File is set
+	Args  []string // Executable arguments
+	File  string   // File to read instead of running a command
+}
+
+// CommandCollector runs commands and combines their output into one artifact
+type CommandCollector struct {
+	name     string
+	artifact string
+	header   string
+	commands []Command
+}
+
+// NewCommandCollector creates a collector writing the output of commands to
+// the named artifact. header is written before the command output.
+func NewCommandCollector(name, artifact, header string, commands []Command) *CommandCollector {
+	return &CommandCollector{
+		name:     name,
+		artifact: artifact,
+		header:   header,
+		commands: commands,
+	}
+}
+
+// NewVersionsCollector collects gohan, Hyprland, kernel and OS versions
+func NewVersionsCollector(gohanVersion string) *CommandCollector {
+	return NewCommandCollector("versions", "system/versions.txt", "gohan: "+gohanVersion+"\n", []Command{
+		{Title: "Hyprland", Name: "hyprctl", Args: []string{"version"}},
+		{Title: "Kernel", Name: "uname", Args: []string{"-srm"}},
+		{Title: "Debian", File: "/etc/debian_version"},
+		{Title: "OS release", File: "/etc/os-release"},
+		{Title: "Mesa / GL", Name: "sh", Args: []string{"-c", "glxinfo -B 2>/dev/null | grep -E 'OpenGL (renderer|version)'"}},
+	})
+}
+
+// NewPackageVersionsCollector collects the installed versions of packages;
+// packages that are not installed are left out
+func NewPackageVersionsCollector(packages []string) *CommandCollector {
+	script := `dpkg-query -W -f='${db:Status-Status} ${Package} ${Version}\n' "$@" 2>/dev/null | awk '$1 == "installed" { print $2, $3 }'`
+	return NewCommandCollector("component versions", "system/packages.txt", "", []Command{
+		{Title: "Installed packages", Name: "sh", Args: append([]string{"-c", script, "sh"}, packages...)},
+	})
+}
+
+// NewGPUCollector collects graphics hardware and driver information
+func NewGPUCollector() *CommandCollector {
+	return NewCommandCollector("gpu", "system/gpu.txt", "", []Command{
+		{Title: "PCI display devices", Name: "sh", Args: []string{"-c", "lspci -nnk | grep -A3 -E 'VGA|3D|Display'"}},
+		{Title: "Loaded GPU modules", Name: "sh", Args: []string{"-c", "lsmod | grep -E '^(amdgpu|radeon|nouveau|nvidia[a-z_]*|i915|xe) '"}},
+		{Title: "NVIDIA", Name: "nvidia-smi", Args: []string{"--query-gpu=name,driver_version", "--format=csv,noheader"}},
+	})
+}
+
+// Name returns the source name
+func (c *CommandCollector) Name() string {
+	return c.name
+}
+
+// Collect runs every command; individual failures are noted in the output
+func (c *CommandCollector) Collect(ctx context.Context) ([]bugreport.Artifact, error) {
+	var sb strings.Builder
+	sb.WriteString(c.header)
+
+	succeeded := 0
+	for _, cmd := range c.commands {
+		output, err := c.run(ctx, cmd)
+		fmt.Fprintf(&sb, "\n== %s ==\n", cmd.Title)
+		if err != nil {
+			fmt.Fprintf(&sb, "(unavailable: %v)\n", err)
+			continue
+		}
+		succeeded++
+		sb.WriteString(strings.TrimRight(output, "\n") + "\n")
+	}
+
+	if succeeded == 0 && c.header == "" {
+		return nil, fmt.Errorf("no information available")
+	}
+
+	artifact, err := bugreport.NewArtifact(c.artifact, []byte(sb.String()))
+	if err != nil {
+		return nil, err
+	}
+	return []bugreport.Artifact{artifact}, nil
+}
+
+func (c *CommandCollector) run(ctx context.Context, cmd Command) (string, error) {
+	if cmd.File != "" {
+		data, err := os.ReadFile(cmd.File)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	if _, err := exec.LookPath(cmd.Name); err != nil {
+		return "", fmt.Errorf("%s not installed", cmd.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, cmd.Name, cmd.Args...).Output()
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(string(output)) == "" {
+		return "", fmt.Errorf("no output")
+	}
+	return string(output), nil
+}
@@ -0,0 +1,105 @@
+package bugreport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+	"gopkg.in/yaml.v3"
+)
+
+// GohanConfigCollector collects gohan's own configuration file with the
+// webhook URLs masked, as their paths often carry credentials
+type GohanConfigCollector struct {
+	path string
+	dir  string
+}
+
+// NewGohanConfigCollector creates a collector storing the configuration
+// file at configPath under dir in the bundle
+func NewGohanConfigCollector(configPath, dir string) *GohanConfigCollector {
+	return &GohanConfigCollector{
+		path: configPath,
+		dir:  dir,
+	}
+}
+
+// Name returns the source name
+func (c *GohanConfigCollector) Name() string {
+	return "gohan config"
+}
+
+// Collect reads the configuration file and masks its webhook URLs
+func (c *GohanConfigCollector) Collect(ctx context.Context) ([]bugreport.Artifact, error) {
+	content, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", c.path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		// The webhook URLs cannot be found, so the file is left out
+		return nil, fmt.Errorf("failed to parse %s: %w", c.path, err)
+	}
+
+	if urls := mappingValue(mappingValue(documentRoot(&doc), "webhooks"), "urls"); urls != nil {
+		for _, item := range urls.Content {
+			if item.Kind == yaml.ScalarNode {
+				item.Value = maskURL(item.Value)
+			}
+		}
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", c.path, err)
+		}
+		if err := enc.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", c.path, err)
+		}
+		content = buf.Bytes()
+	}
+
+	artifact, err := bugreport.NewArtifact(path.Join(c.dir, filepath.Base(c.path)), content)
+	if err != nil {
+		return nil, err
+	}
+	return []bugreport.Artifact{artifact}, nil
+}
+
+// documentRoot returns the top-level node of a parsed document
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return nil
+}
+
+// mappingValue returns the value for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// maskURL keeps only the scheme and host of a URL, dropping the user
+// info, path and query where credentials are kept
+func maskURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return bugreport.RedactedSecret
+	}
+	return u.Scheme + "://" + u.Host + "/" + bugreport.RedactedSecret
+}
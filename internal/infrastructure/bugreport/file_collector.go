@@ -0,0 +1,135 @@
+package bugreport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+)
+
+// DefaultMaxFileBytes limits how much of each file is collected; logs are
+// truncated to their most recent content
+const DefaultMaxFileBytes = 1 << 20
+
+// FileCollector collects files matching glob patterns into a bundle directory
+type FileCollector struct {
+	name     string
+	dir      string
+	patterns []string
+	maxFiles int
+	maxBytes int64
+}
+
+// NewFileCollector creates a collector storing matches of patterns under dir
+// in the bundle. When more than maxFiles match, the most recently modified
+// files are kept; maxFiles <= 0 keeps all of them.
+func NewFileCollector(name, dir string, patterns []string, maxFiles int) *FileCollector {
+	return &FileCollector{
+		name:     name,
+		dir:      dir,
+		patterns: patterns,
+		maxFiles: maxFiles,
+		maxBytes: DefaultMaxFileBytes,
+	}
+}
+
+// Name returns the source name
+func (c *FileCollector) Name() string {
+	return c.name
+}
+
+// Collect reads the matching files
+func (c *FileCollector) Collect(ctx context.Context) ([]bugreport.Artifact, error) {
+	type match struct {
+		path string
+		info os.FileInfo
+	}
+
+	seen := make(map[string]bool)
+	var matches []match
+	for _, pattern := range c.patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+		for _, p := range paths {
+			info, err := os.Stat(p)
+			if err != nil || !info.Mode().IsRegular() || seen[p] {
+				continue
+			}
+			seen[p] = true
+			matches = append(matches, match{path: p, info: info})
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no files found")
+	}
+
+	// Newest first so the limit keeps the most relevant files
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].info.ModTime().After(matches[j].info.ModTime())
+	})
+	if c.maxFiles > 0 && len(matches) > c.maxFiles {
+		matches = matches[:c.maxFiles]
+	}
+
+	artifacts := make([]bugreport.Artifact, 0, len(matches))
+	usedNames := make(map[string]bool)
+	for _, m := range matches {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		content, err := readTail(m.path, m.info.Size(), c.maxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", m.path, err)
+		}
+
+		// Files from different directories may share a base name
+		name := path.Join(c.dir, filepath.Base(m.path))
+		if usedNames[name] {
+			name = path.Join(c.dir, filepath.Base(filepath.Dir(m.path)), filepath.Base(m.path))
+		}
+		usedNames[name] = true
+
+		artifact, err := bugreport.NewArtifact(name, content)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
+// readTail reads at most maxBytes from the end of the file
+func readTail(p string, size, maxBytes int64) ([]byte, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	offset := int64(0)
+	if maxBytes > 0 && size > maxBytes {
+		offset = size - maxBytes
+	}
+
+	buf := make([]byte, size-offset)
+	n, err := f.ReadAt(buf, offset)
+	if err != nil && n == 0 && size > 0 {
+		return nil, err
+	}
+	buf = buf[:n]
+
+	if offset > 0 {
+		header := fmt.Sprintf("[truncated: showing last %d of %d bytes]\n", n, size)
+		buf = append([]byte(header), buf...)
+	}
+	return buf, nil
+}
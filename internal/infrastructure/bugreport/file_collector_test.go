@@ -0,0 +1,81 @@
+package bugreport_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	bugreportInfra "github.com/rebelopsio/gohan/internal/infrastructure/bugreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCollector_Collect(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("collects matching files", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "hyprland.conf"), []byte("monitor=,preferred,auto,1"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+
+		collector := bugreportInfra.NewFileCollector("configs", "config", []string{filepath.Join(dir, "*.conf")}, 0)
+		artifacts, err := collector.Collect(ctx)
+		require.NoError(t, err)
+		require.Len(t, artifacts, 1)
+		assert.Equal(t, "config/hyprland.conf", artifacts[0].Name())
+		assert.Equal(t, "monitor=,preferred,auto,1", string(artifacts[0].Content()))
+	})
+
+	t.Run("keeps the newest files", func(t *testing.T) {
+		dir := t.TempDir()
+		old := filepath.Join(dir, "hyprlandCrashReport1.txt")
+		recent := filepath.Join(dir, "hyprlandCrashReport2.txt")
+		require.NoError(t, os.WriteFile(old, []byte("old"), 0644))
+		require.NoError(t, os.WriteFile(recent, []byte("recent"), 0644))
+		require.NoError(t, os.Chtimes(old, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+
+		collector := bugreportInfra.NewFileCollector("crashes", "crashes", []string{filepath.Join(dir, "*.txt")}, 1)
+		artifacts, err := collector.Collect(ctx)
+		require.NoError(t, err)
+		require.Len(t, artifacts, 1)
+		assert.Equal(t, "crashes/hyprlandCrashReport2.txt", artifacts[0].Name())
+	})
+
+	t.Run("disambiguates files with the same name", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, sub := range []string{"a", "b"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, sub), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, sub, "hyprland.log"), []byte(sub), 0644))
+		}
+
+		collector := bugreportInfra.NewFileCollector("logs", "hyprland", []string{filepath.Join(dir, "*", "hyprland.log")}, 0)
+		artifacts, err := collector.Collect(ctx)
+		require.NoError(t, err)
+		require.Len(t, artifacts, 2)
+		assert.NotEqual(t, artifacts[0].Name(), artifacts[1].Name())
+	})
+
+	t.Run("truncates large files to their tail", func(t *testing.T) {
+		dir := t.TempDir()
+		content := strings.Repeat("a", bugreportInfra.DefaultMaxFileBytes) + "last line"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "big.log"), []byte(content), 0644))
+
+		collector := bugreportInfra.NewFileCollector("logs", "logs", []string{filepath.Join(dir, "big.log")}, 0)
+		artifacts, err := collector.Collect(ctx)
+		require.NoError(t, err)
+		require.Len(t, artifacts, 1)
+
+		got := string(artifacts[0].Content())
+		assert.True(t, strings.HasPrefix(got, "[truncated"))
+		assert.True(t, strings.HasSuffix(got, "last line"))
+	})
+
+	t.Run("fails when nothing matches", func(t *testing.T) {
+		collector := bugreportInfra.NewFileCollector("logs", "logs", []string{filepath.Join(t.TempDir(), "*.log")}, 0)
+		_, err := collector.Collect(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package bugreport
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+)
+
+// TarballWriter writes bundles as gzip-compressed tar archives
+type TarballWriter struct{}
+
+// NewTarballWriter creates a new tarball writer
+func NewTarballWriter() *TarballWriter {
+	return &TarballWriter{}
+}
+
+// Write stores the bundle at path with all files below a single top-level
+// directory named after the archive
+func (w *TarballWriter) Write(ctx context.Context, bundle *bugreport.Bundle, archivePath string) error {
+	if bundle.IsEmpty() {
+		return bugreport.ErrEmptyBundle
+	}
+
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Reports may still contain details users want to review first, so
+	// keep them private to the user
+	tmpFile := archivePath + ".tmp"
+	f, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	if err := writeArchive(ctx, f, bundle, rootDir(archivePath)); err != nil {
+		f.Close()
+		os.Remove(tmpFile)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, archivePath); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to save archive: %w", err)
+	}
+	return nil
+}
+
+func writeArchive(ctx context.Context, f *os.File, bundle *bugreport.Bundle, root string) error {
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	add := func(name string, content []byte) error {
+		header := &tar.Header{
+			Name:    path.Join(root, name),
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: bundle.CreatedAt(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := add(bugreport.ManifestName, []byte(bundle.Manifest())); err != nil {
+		return err
+	}
+	for _, artifact := range bundle.Artifacts() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := add(artifact.Name(), artifact.Content()); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return nil
+}
+
+// rootDir derives the top-level directory from the archive file name
+func rootDir(archivePath string) string {
+	base := filepath.Base(archivePath)
+	for _, ext := range []string{".tar.gz", ".tgz"} {
+		if strings.HasSuffix(base, ext) {
+			return strings.TrimSuffix(base, ext)
+		}
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,72 @@
+package bugreport_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/bugreport"
+	bugreportInfra "github.com/rebelopsio/gohan/internal/infrastructure/bugreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readArchive(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func TestTarballWriter_Write(t *testing.T) {
+	ctx := context.Background()
+	writer := bugreportInfra.NewTarballWriter()
+
+	t.Run("writes artifacts and manifest under one directory", func(t *testing.T) {
+		bundle := bugreport.NewBundle(time.Now())
+		artifact, err := bugreport.NewArtifact("system/versions.txt", []byte("gohan: dev\n"))
+		require.NoError(t, err)
+		require.NoError(t, bundle.Add(artifact, nil))
+
+		path := filepath.Join(t.TempDir(), "out", "gohan-bugreport-test.tar.gz")
+		require.NoError(t, writer.Write(ctx, bundle, path))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		files := readArchive(t, path)
+		assert.Equal(t, "gohan: dev\n", files["gohan-bugreport-test/system/versions.txt"])
+		assert.Contains(t, files["gohan-bugreport-test/MANIFEST.txt"], "system/versions.txt")
+	})
+
+	t.Run("rejects empty bundle", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "empty.tar.gz")
+		err := writer.Write(ctx, bugreport.NewBundle(time.Now()), path)
+		assert.ErrorIs(t, err, bugreport.ErrEmptyBundle)
+		assert.NoFileExists(t, path)
+	})
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/cache"
+)
+
+// APTArchivesDir is where apt keeps downloaded packages
+const APTArchivesDir = "/var/cache/apt/archives"
+
+// defaultAptCleanTimeout bounds apt-get clean
+const defaultAptCleanTimeout = 5 * time.Minute
+
+// FilesystemStore measures caches by walking their directories. The apt
+// cache is emptied with apt-get clean, which needs root; the caches gohan
+// owns are emptied by removing their contents.
+type FilesystemStore struct {
+	isRoot   func() bool
+	aptClean func(ctx context.Context) error
+}
+
+// NewFilesystemStore creates a store for the running system
+func NewFilesystemStore() *FilesystemStore {
+	return &FilesystemStore{
+		isRoot: func() bool { return os.Geteuid() == 0 },
+		aptClean: func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, defaultAptCleanTimeout)
+			defer cancel()
+			if output, err := exec.CommandContext(ctx, "apt-get", "clean").CombinedOutput(); err != nil {
+				return fmt.Errorf("apt-get clean failed: %w\nOutput: %s", err, string(output))
+			}
+			return nil
+		},
+	}
+}
+
+// NewFilesystemStoreAsUser creates a store that never runs as root, so the
+// apt cache is measured but not cleaned
+func NewFilesystemStoreAsUser() *FilesystemStore {
+	store := NewFilesystemStore()
+	store.isRoot = func() bool { return false }
+	return store
+}
+
+// Measure adds up the files in the cache directory; a missing directory is
+// an empty cache
+func (s *FilesystemStore) Measure(ctx context.Context, location cache.Location) (cache.Usage, error) {
+	var bytes uint64
+	var files int
+
+	err := filepath.WalkDir(location.Path(), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == location.Path() {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		bytes += uint64(info.Size())
+		files++
+		return nil
+	})
+	if err != nil {
+		return cache.Usage{}, fmt.Errorf("failed to measure %s: %w", location.Path(), err)
+	}
+
+	return cache.NewUsage(location, bytes, files), nil
+}
+
+// Clean empties the cache and returns the bytes freed. The cache directory
+// itself is kept.
+func (s *FilesystemStore) Clean(ctx context.Context, location cache.Location) (uint64, error) {
+	before, err := s.Measure(ctx, location)
+	if err != nil {
+		return 0, err
+	}
+
+	if location.Kind() == cache.KindAPTArchives {
+		if !s.isRoot() {
+			return 0, cache.ErrPermissionRequired
+		}
+		if err := s.aptClean(ctx); err != nil {
+			return 0, err
+		}
+	} else if err := removeContents(location.Path()); err != nil {
+		return 0, err
+	}
+
+	after, err := s.Measure(ctx, location)
+	if err != nil {
+		return 0, err
+	}
+	if after.Bytes() > before.Bytes() {
+		return 0, nil
+	}
+	return before.Bytes() - after.Bytes(), nil
+}
+
+// removeContents deletes everything inside dir
+func removeContents(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
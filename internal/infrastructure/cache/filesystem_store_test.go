@@ -0,0 +1,60 @@
+package cache_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	domain "github.com/rebelopsio/gohan/internal/domain/cache"
+	"github.com/rebelopsio/gohan/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemStore_MeasureAndClean(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "hyprland-0.41.2"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hyprland-0.41.2", "source.tar.gz"), make([]byte, 1000), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.json"), make([]byte, 24), 0644))
+
+	location, err := domain.NewLocation(domain.KindBuild, dir)
+	require.NoError(t, err)
+	store := cache.NewFilesystemStore()
+
+	usage, err := store.Measure(ctx, location)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1024), usage.Bytes())
+	assert.Equal(t, 2, usage.Files())
+
+	freed, err := store.Clean(ctx, location)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1024), freed)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the directory is kept but emptied")
+}
+
+func TestFilesystemStore_MissingDirectory(t *testing.T) {
+	location, err := domain.NewLocation(domain.KindTemplateRegistry, filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	store := cache.NewFilesystemStore()
+
+	usage, err := store.Measure(context.Background(), location)
+	require.NoError(t, err)
+	assert.True(t, usage.IsEmpty())
+
+	freed, err := store.Clean(context.Background(), location)
+	require.NoError(t, err)
+	assert.Zero(t, freed)
+}
+
+func TestFilesystemStore_APTNeedsRoot(t *testing.T) {
+	location, err := domain.NewLocation(domain.KindAPTArchives, t.TempDir())
+	require.NoError(t, err)
+
+	_, err = cache.NewFilesystemStoreAsUser().Clean(context.Background(), location)
+	assert.ErrorIs(t, err, domain.ErrPermissionRequired)
+}
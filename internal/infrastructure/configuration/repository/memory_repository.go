@@ -142,6 +142,26 @@ func (r *MemoryRepository) ListByTag(ctx context.Context, tag string) ([]*config
 	return filtered, nil
 }
 
+// Search retrieves templates matching every criterion of the query
+func (r *MemoryRepository) Search(ctx context.Context, query configuration.TemplateQuery) ([]*configuration.ConfigurationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*configuration.ConfigurationTemplate
+	for _, template := range r.templates {
+		if query.Matches(template) {
+			filtered = append(filtered, template)
+		}
+	}
+
+	// Sort by creation date, most recent first
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt().After(filtered[j].CreatedAt())
+	})
+
+	return filtered, nil
+}
+
 // Delete removes a configuration template by ID
 func (r *MemoryRepository) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
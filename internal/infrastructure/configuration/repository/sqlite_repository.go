@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -40,6 +41,9 @@ func NewSQLiteRepository(dbPath string) (*SQLiteRepository, error) {
 	return repo, nil
 }
 
+// tagsSchemaVersion is the user_version once tags are kept in template_tags
+const tagsSchemaVersion = 1
+
 // initialize creates the necessary tables
 func (r *SQLiteRepository) initialize() error {
 	schema := `
@@ -55,10 +59,76 @@ func (r *SQLiteRepository) initialize() error {
 	CREATE INDEX IF NOT EXISTS idx_templates_name ON configuration_templates(name);
 	CREATE INDEX IF NOT EXISTS idx_templates_category ON configuration_templates(category);
 	CREATE INDEX IF NOT EXISTS idx_templates_created_at ON configuration_templates(created_at);
+
+	CREATE TABLE IF NOT EXISTS template_tags (
+		template_id TEXT NOT NULL REFERENCES configuration_templates(id) ON DELETE CASCADE,
+		tag TEXT NOT NULL,
+		PRIMARY KEY (template_id, tag)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_template_tags_tag ON template_tags(tag);
 	`
 
-	_, err := r.db.Exec(schema)
-	return err
+	if _, err := r.db.Exec(schema); err != nil {
+		return err
+	}
+
+	return r.migrateTags()
+}
+
+// migrateTags fills template_tags from the tags serialized in each
+// template, once, for databases created before the table existed
+func (r *SQLiteRepository) migrateTags() error {
+	var version int
+	if err := r.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version >= tagsSchemaVersion {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin tag migration: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT id, data FROM configuration_templates`)
+	if err != nil {
+		return fmt.Errorf("failed to read templates: %w", err)
+	}
+	tags := make(map[string][]string)
+	for rows.Next() {
+		var id, data string
+		if err := rows.Scan(&id, &data); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan template row: %w", err)
+		}
+		var model templateStorageModel
+		if err := json.Unmarshal([]byte(data), &model); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to unmarshal template %s: %w", id, err)
+		}
+		tags[id] = model.Metadata.Tags
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating templates: %w", err)
+	}
+
+	for id, templateTags := range tags {
+		for _, tag := range templateTags {
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO template_tags (template_id, tag) VALUES (?, ?)`, id, tag); err != nil {
+				return fmt.Errorf("failed to migrate tags of template %s: %w", id, err)
+			}
+		}
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", tagsSchemaVersion)); err != nil {
+		return fmt.Errorf("failed to set schema version: %w", err)
+	}
+
+	return tx.Commit()
 }
 
 // templateRecord represents a row in the database
@@ -228,6 +298,12 @@ func (r *SQLiteRepository) Save(ctx context.Context, template *configuration.Con
 		return fmt.Errorf("failed to marshal template: %w", err)
 	}
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	// Upsert query
 	query := `
 	INSERT INTO configuration_templates (id, name, category, data, created_at, version)
@@ -239,7 +315,7 @@ func (r *SQLiteRepository) Save(ctx context.Context, template *configuration.Con
 		version = excluded.version
 	`
 
-	_, err = r.db.ExecContext(
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		template.ID(),
@@ -249,8 +325,21 @@ func (r *SQLiteRepository) Save(ctx context.Context, template *configuration.Con
 		template.CreatedAt(),
 		template.Version(),
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// Replace the tag rows
+	if _, err := tx.ExecContext(ctx, `DELETE FROM template_tags WHERE template_id = ?`, template.ID()); err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+	for _, tag := range template.Metadata().Tags() {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO template_tags (template_id, tag) VALUES (?, ?)`, template.ID(), tag); err != nil {
+			return fmt.Errorf("failed to save tag %s: %w", tag, err)
+		}
+	}
+
+	return tx.Commit()
 }
 
 // FindByID retrieves a template by its unique identifier
@@ -268,7 +357,7 @@ func (r *SQLiteRepository) FindByID(ctx context.Context, id string) (*configurat
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("template not found: %s", id)
+			return nil, fmt.Errorf("template %s: %w", id, configuration.ErrTemplateNotFound)
 		}
 		return nil, fmt.Errorf("failed to query template: %w", err)
 	}
@@ -303,7 +392,7 @@ func (r *SQLiteRepository) FindByName(ctx context.Context, name string) (*config
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("template not found: %s", name)
+			return nil, fmt.Errorf("template %s: %w", name, configuration.ErrTemplateNotFound)
 		}
 		return nil, fmt.Errorf("failed to query template: %w", err)
 	}
@@ -336,61 +425,91 @@ func (r *SQLiteRepository) ExistsByName(ctx context.Context, name string) (bool,
 	return count > 0, nil
 }
 
+// templateColumns are the columns scanned by queryTemplates
+const templateColumns = `t.id, t.name, t.category, t.data, t.created_at, t.version`
+
 // List retrieves all configuration templates ordered by creation date
 func (r *SQLiteRepository) List(ctx context.Context) ([]*configuration.ConfigurationTemplate, error) {
-	query := `SELECT id, name, category, data, created_at, version FROM configuration_templates ORDER BY created_at DESC`
+	query := `SELECT ` + templateColumns + ` FROM configuration_templates t ORDER BY t.created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	templates, err := r.queryTemplates(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query templates: %w", err)
 	}
-	defer rows.Close()
+	return templates, nil
+}
 
-	var templates []*configuration.ConfigurationTemplate
+// ListByCategory retrieves templates filtered by category
+func (r *SQLiteRepository) ListByCategory(ctx context.Context, category configuration.ConfigurationCategory) ([]*configuration.ConfigurationTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM configuration_templates t WHERE t.category = ? ORDER BY t.created_at DESC`
 
-	for rows.Next() {
-		var record templateRecord
-		err := rows.Scan(
-			&record.ID,
-			&record.Name,
-			&record.Category,
-			&record.Data,
-			&record.CreatedAt,
-			&record.Version,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan template row: %w", err)
-		}
+	templates, err := r.queryTemplates(ctx, query, string(category))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates by category: %w", err)
+	}
+	return templates, nil
+}
 
-		// Deserialize storage model
-		var model templateStorageModel
-		if err := json.Unmarshal([]byte(record.Data), &model); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal template data: %w", err)
-		}
+// ListByTag retrieves templates that have the specified tag
+func (r *SQLiteRepository) ListByTag(ctx context.Context, tag string) ([]*configuration.ConfigurationTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM configuration_templates t
+	JOIN template_tags g ON g.template_id = t.id
+	WHERE g.tag = ?
+	ORDER BY t.created_at DESC`
 
-		// Convert to domain using helper
-		template, err := fromStorageModel(&model)
-		if err != nil {
-			return nil, err
-		}
+	templates, err := r.queryTemplates(ctx, query, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates by tag: %w", err)
+	}
+	return templates, nil
+}
 
-		templates = append(templates, template)
+// Search retrieves templates matching every criterion of the query
+func (r *SQLiteRepository) Search(ctx context.Context, q configuration.TemplateQuery) ([]*configuration.ConfigurationTemplate, error) {
+	var conditions []string
+	var args []interface{}
+
+	if name := q.NameContains(); name != "" {
+		// LIKE is case-insensitive for ASCII; escape its wildcards
+		conditions = append(conditions, `t.name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(name)+"%")
+	}
+	if category := q.Category(); category != "" {
+		conditions = append(conditions, `t.category = ?`)
+		args = append(args, string(category))
+	}
+	if tags := q.Tags(); len(tags) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(tags)), ", ")
+		conditions = append(conditions, `t.id IN (
+		SELECT template_id FROM template_tags WHERE tag IN (`+placeholders+`)
+		GROUP BY template_id HAVING COUNT(*) = ?)`)
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+		args = append(args, len(tags))
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating templates: %w", err)
+	query := `SELECT ` + templateColumns + ` FROM configuration_templates t`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
 	}
+	query += ` ORDER BY t.created_at DESC`
 
+	templates, err := r.queryTemplates(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search templates: %w", err)
+	}
 	return templates, nil
 }
 
-// ListByCategory retrieves templates filtered by category
-func (r *SQLiteRepository) ListByCategory(ctx context.Context, category configuration.ConfigurationCategory) ([]*configuration.ConfigurationTemplate, error) {
-	query := `SELECT id, name, category, data, created_at, version FROM configuration_templates WHERE category = ? ORDER BY created_at DESC`
+// likeEscaper escapes the LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
 
-	rows, err := r.db.QueryContext(ctx, query, string(category))
+// queryTemplates runs a query selecting templateColumns and converts the rows
+func (r *SQLiteRepository) queryTemplates(ctx context.Context, query string, args ...interface{}) ([]*configuration.ConfigurationTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query templates by category: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -432,33 +551,20 @@ func (r *SQLiteRepository) ListByCategory(ctx context.Context, category configur
 	return templates, nil
 }
 
-// ListByTag retrieves templates that have the specified tag
-func (r *SQLiteRepository) ListByTag(ctx context.Context, tag string) ([]*configuration.ConfigurationTemplate, error) {
-	// Since tags are stored in JSON, we need to fetch all and filter in memory
-	// For better performance with many templates, consider a separate tags table
-	templates, err := r.List(ctx)
+// Delete removes a configuration template by ID
+func (r *SQLiteRepository) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer tx.Rollback()
 
-	var filtered []*configuration.ConfigurationTemplate
-	for _, template := range templates {
-		for _, t := range template.Metadata().Tags() {
-			if t == tag {
-				filtered = append(filtered, template)
-				break
-			}
-		}
+	// Foreign keys are enabled per connection, so tags are removed explicitly
+	if _, err := tx.ExecContext(ctx, `DELETE FROM template_tags WHERE template_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete template tags: %w", err)
 	}
 
-	return filtered, nil
-}
-
-// Delete removes a configuration template by ID
-func (r *SQLiteRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM configuration_templates WHERE id = ?`
-
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, `DELETE FROM configuration_templates WHERE id = ?`, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete template: %w", err)
 	}
@@ -472,7 +578,7 @@ func (r *SQLiteRepository) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("template %s: %w", id, configuration.ErrTemplateNotFound)
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 // Close closes the database connection
@@ -489,6 +595,9 @@ func (r *SQLiteRepository) Count(ctx context.Context) (int, error) {
 
 // Clear removes all templates (useful for testing)
 func (r *SQLiteRepository) Clear(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM template_tags"); err != nil {
+		return err
+	}
 	_, err := r.db.ExecContext(ctx, "DELETE FROM configuration_templates")
 	return err
 }
@@ -2,10 +2,12 @@ package repository_test
 
 import (
 	"context"
+	"database/sql"
 	"path/filepath"
 	"testing"
 
 	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
 	configrepo "github.com/rebelopsio/gohan/internal/infrastructure/configuration/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -86,7 +88,7 @@ func TestSQLiteRepository_FindByID(t *testing.T) {
 		ctx := context.Background()
 
 		_, err := repo.FindByID(ctx, "non-existent-id")
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, configuration.ErrTemplateNotFound)
 	})
 
 	t.Run("reconstructs template with all fields", func(t *testing.T) {
@@ -142,7 +144,7 @@ func TestSQLiteRepository_FindByName(t *testing.T) {
 		ctx := context.Background()
 
 		_, err := repo.FindByName(ctx, "Non-existent Config")
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, configuration.ErrTemplateNotFound)
 	})
 }
 
@@ -253,6 +255,108 @@ func TestSQLiteRepository_ListByTag(t *testing.T) {
 	})
 }
 
+// createTaggedTemplate creates a template with the given category and tags
+func createTaggedTemplate(t *testing.T, name string, category configuration.ConfigurationCategory, tags ...string) *configuration.ConfigurationTemplate {
+	t.Helper()
+
+	compSel, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.32.0", nil)
+	require.NoError(t, err)
+	manifest, err := configuration.NewConfigurationManifest([]installation.ComponentSelection{compSel}, 0, false)
+	require.NoError(t, err)
+	metadata, err := configuration.NewConfigurationMetadata(name, "", "", tags, category)
+	require.NoError(t, err)
+	template, err := configuration.NewConfigurationTemplate(metadata, manifest)
+	require.NoError(t, err)
+	return template
+}
+
+func templateNames(templates []*configuration.ConfigurationTemplate) []string {
+	names := make([]string, 0, len(templates))
+	for _, template := range templates {
+		names = append(names, template.Metadata().Name().String())
+	}
+	return names
+}
+
+func TestSQLiteRepository_Search(t *testing.T) {
+	repo := setupTestSQLiteDB(t)
+	defer repo.Close()
+
+	ctx := context.Background()
+	for _, template := range []*configuration.ConfigurationTemplate{
+		createTaggedTemplate(t, "Work Laptop", configuration.CategoryDevelopment, "laptop", "minimal"),
+		createTaggedTemplate(t, "Home Laptop", configuration.CategoryCustom, "laptop"),
+		createTaggedTemplate(t, "CI_runner", configuration.CategoryTesting, "minimal"),
+	} {
+		require.NoError(t, repo.Save(ctx, template))
+	}
+
+	search := func(name string, category configuration.ConfigurationCategory, tags ...string) []string {
+		templates, err := repo.Search(ctx, configuration.NewTemplateQuery(name, category, tags))
+		require.NoError(t, err)
+		return templateNames(templates)
+	}
+
+	assert.Len(t, search("", ""), 3)
+	assert.ElementsMatch(t, []string{"Work Laptop", "Home Laptop"}, search("laptop", ""), "name match ignores case")
+	assert.Equal(t, []string{"Work Laptop"}, search("", "", "laptop", "minimal"), "every tag must match")
+	assert.Equal(t, []string{"CI_runner"}, search("", configuration.CategoryTesting, "minimal"))
+	assert.Equal(t, []string{"Home Laptop"}, search("home", configuration.CategoryCustom, "laptop"))
+	assert.Equal(t, []string{"CI_runner"}, search("_", ""), "LIKE wildcards are literal")
+	assert.Empty(t, search("%", ""))
+}
+
+func TestSQLiteRepository_TagRows(t *testing.T) {
+	t.Run("follow retagging and deletion", func(t *testing.T) {
+		repo := setupTestSQLiteDB(t)
+		defer repo.Close()
+
+		ctx := context.Background()
+		template := createTaggedTemplate(t, "Laptop", configuration.CategoryCustom, "old")
+		require.NoError(t, repo.Save(ctx, template))
+
+		require.NoError(t, template.Retag([]string{"new"}))
+		require.NoError(t, repo.Save(ctx, template))
+
+		templates, err := repo.ListByTag(ctx, "old")
+		require.NoError(t, err)
+		assert.Empty(t, templates)
+		templates, err = repo.ListByTag(ctx, "new")
+		require.NoError(t, err)
+		assert.Len(t, templates, 1)
+
+		require.NoError(t, repo.Delete(ctx, template.ID()))
+		templates, err = repo.ListByTag(ctx, "new")
+		require.NoError(t, err)
+		assert.Empty(t, templates)
+	})
+
+	t.Run("are migrated from existing templates", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "test.db")
+		ctx := context.Background()
+
+		repo, err := configrepo.NewSQLiteRepository(dbPath)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, createTaggedTemplate(t, "Laptop", configuration.CategoryCustom, "laptop")))
+		require.NoError(t, repo.Close())
+
+		// Simulate a database written before tags had their own table
+		db, err := sql.Open("sqlite3", dbPath)
+		require.NoError(t, err)
+		_, err = db.Exec("DELETE FROM template_tags; PRAGMA user_version = 0;")
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		repo, err = configrepo.NewSQLiteRepository(dbPath)
+		require.NoError(t, err)
+		defer repo.Close()
+
+		templates, err := repo.ListByTag(ctx, "laptop")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Laptop"}, templateNames(templates))
+	})
+}
+
 func TestSQLiteRepository_Delete(t *testing.T) {
 	t.Run("deletes existing template", func(t *testing.T) {
 		repo := setupTestSQLiteDB(t)
@@ -281,3 +385,39 @@ func TestSQLiteRepository_Delete(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSQLiteRepository_CanceledContext(t *testing.T) {
+	repo := setupTestSQLiteDB(t)
+	defer repo.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	template := createTestTemplate(t, "Test Config")
+
+	assert.ErrorIs(t, repo.Save(ctx, template), context.Canceled)
+
+	_, err := repo.FindByID(ctx, template.ID())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.FindByName(ctx, template.Metadata().Name().String())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.ExistsByName(ctx, template.Metadata().Name().String())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.List(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.ListByCategory(ctx, template.Metadata().Category())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.ListByTag(ctx, "test")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.Count(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.ErrorIs(t, repo.Delete(ctx, template.ID()), context.Canceled)
+	assert.ErrorIs(t, repo.Clear(ctx), context.Canceled)
+}
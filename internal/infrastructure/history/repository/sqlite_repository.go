@@ -71,6 +71,14 @@ type recordStorageModel struct {
 	Metadata       metadataDTO               `json:"metadata"`
 	SystemContext  systemContextDTO          `json:"system_context"`
 	FailureDetails *failureDetailsDTO        `json:"failure_details,omitempty"`
+	Warnings       []string                  `json:"warnings,omitempty"`
+	Preflight      []preflightCheckDTO       `json:"preflight,omitempty"`
+	ConfigFiles    []configFileDTO           `json:"config_files,omitempty"`
+	Conflicts      []conflictDTO             `json:"conflicts,omitempty"`
+	Skipped        []skippedComponentDTO     `json:"skipped_components,omitempty"`
+	PhaseTimings   []phaseTimingDTO          `json:"phase_timings,omitempty"`
+	Scope          string                    `json:"scope,omitempty"`
+	Invocation     *invocationDTO            `json:"invocation,omitempty"`
 	RecordedAt     time.Time                 `json:"recorded_at"`
 }
 
@@ -93,6 +101,51 @@ type systemContextDTO struct {
 	KernelVersion string `json:"kernel_version"`
 	GohanVersion  string `json:"gohan_version"`
 	Hostname      string `json:"hostname"`
+	Architecture  string `json:"architecture,omitempty"`
+	GPUVendor     string `json:"gpu_vendor,omitempty"`
+	RenderGPU     string `json:"render_gpu,omitempty"`
+}
+
+type preflightCheckDTO struct {
+	Requirement string `json:"requirement"`
+	Status      string `json:"status"`
+	Actual      string `json:"actual,omitempty"`
+	Expected    string `json:"expected,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+type configFileDTO struct {
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+type conflictDTO struct {
+	PackageName        string `json:"package_name"`
+	ConflictingPackage string `json:"conflicting_package"`
+	Reason             string `json:"reason,omitempty"`
+	Strategy           string `json:"strategy"`
+	Applied            bool   `json:"applied"`
+}
+
+type skippedComponentDTO struct {
+	Component string `json:"component"`
+	Reason    string `json:"reason"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+type phaseTimingDTO struct {
+	Phase     string        `json:"phase"`
+	Estimated time.Duration `json:"estimated_ns"`
+	Actual    time.Duration `json:"actual_ns"`
+}
+
+type invocationDTO struct {
+	CommandLine string            `json:"command_line"`
+	Flags       map[string]string `json:"flags,omitempty"`
+	ConfigHash  string            `json:"config_hash,omitempty"`
+	Version     string            `json:"version,omitempty"`
+	Commit      string            `json:"commit,omitempty"`
+	User        string            `json:"user,omitempty"`
 }
 
 type failureDetailsDTO struct {
@@ -130,6 +183,9 @@ func toStorageModel(record history.InstallationRecord) *recordStorageModel {
 		KernelVersion: sysCtx.KernelVersion(),
 		GohanVersion:  sysCtx.GohanVersion(),
 		Hostname:      sysCtx.Hostname(),
+		Architecture:  sysCtx.Architecture(),
+		GPUVendor:     sysCtx.GPUVendor(),
+		RenderGPU:     sysCtx.RenderGPU(),
 	}
 
 	// Convert failure details if present
@@ -144,6 +200,61 @@ func toStorageModel(record history.InstallationRecord) *recordStorageModel {
 		}
 	}
 
+	// Convert embedded preflight results
+	var preflightDTOs []preflightCheckDTO
+	for _, c := range record.PreflightChecks() {
+		preflightDTOs = append(preflightDTOs, preflightCheckDTO{
+			Requirement: c.Requirement(),
+			Status:      c.Status(),
+			Actual:      c.Actual(),
+			Expected:    c.Expected(),
+			Message:     c.Message(),
+		})
+	}
+
+	// Convert deployed config files
+	var configFileDTOs []configFileDTO
+	for _, f := range record.ConfigFiles() {
+		configFileDTOs = append(configFileDTOs, configFileDTO{Path: f.Path(), Hash: f.Hash()})
+	}
+
+	// Convert resolved conflicts
+	var conflictDTOs []conflictDTO
+	for _, c := range record.Conflicts() {
+		conflictDTOs = append(conflictDTOs, conflictDTO{
+			PackageName:        c.PackageName(),
+			ConflictingPackage: c.ConflictingPackage(),
+			Reason:             c.Reason(),
+			Strategy:           c.Strategy(),
+			Applied:            c.Applied(),
+		})
+	}
+
+	// Convert skipped components
+	var skippedDTOs []skippedComponentDTO
+	for _, c := range record.SkippedComponents() {
+		skippedDTOs = append(skippedDTOs, skippedComponentDTO{Component: c.Component(), Reason: c.Reason(), Detail: c.Detail()})
+	}
+
+	// Convert phase timings
+	var phaseTimingDTOs []phaseTimingDTO
+	for _, t := range record.PhaseTimings() {
+		phaseTimingDTOs = append(phaseTimingDTOs, phaseTimingDTO{Phase: t.Phase(), Estimated: t.Estimated(), Actual: t.Actual()})
+	}
+
+	// Convert the invocation if recorded
+	var invocationModel *invocationDTO
+	if invocation := record.Invocation(); !invocation.IsZero() {
+		invocationModel = &invocationDTO{
+			CommandLine: invocation.CommandLine(),
+			Flags:       invocation.Flags(),
+			ConfigHash:  invocation.ConfigHash(),
+			Version:     invocation.Version(),
+			Commit:      invocation.Commit(),
+			User:        invocation.User(),
+		}
+	}
+
 	return &recordStorageModel{
 		ID:             record.ID().String(),
 		SessionID:      record.SessionID(),
@@ -151,6 +262,14 @@ func toStorageModel(record history.InstallationRecord) *recordStorageModel {
 		Metadata:       metadataDTO,
 		SystemContext:  systemContextDTO,
 		FailureDetails: failureDTO,
+		Warnings:       record.Warnings(),
+		Preflight:      preflightDTOs,
+		ConfigFiles:    configFileDTOs,
+		Conflicts:      conflictDTOs,
+		Skipped:        skippedDTOs,
+		PhaseTimings:   phaseTimingDTOs,
+		Scope:          record.Scope().String(),
+		Invocation:     invocationModel,
 		RecordedAt:     record.RecordedAt(),
 	}
 }
@@ -189,6 +308,8 @@ func fromStorageModel(model *recordStorageModel) (history.InstallationRecord, er
 	if err != nil {
 		return history.InstallationRecord{}, fmt.Errorf("failed to create system context: %w", err)
 	}
+	sysCtx = sysCtx.WithHardware(model.SystemContext.Architecture, model.SystemContext.GPUVendor)
+	sysCtx = sysCtx.WithRenderGPU(model.SystemContext.RenderGPU)
 
 	// Reconstruct failure details if present
 	var failureDetails *history.FailureDetails
@@ -231,7 +352,83 @@ func fromStorageModel(model *recordStorageModel) (history.InstallationRecord, er
 		return history.InstallationRecord{}, fmt.Errorf("failed to reconstruct record: %w", err)
 	}
 
-	return record, nil
+	// Reconstruct embedded preflight results
+	var checks []history.PreflightCheck
+	for _, c := range model.Preflight {
+		check, err := history.NewPreflightCheck(c.Requirement, c.Status, c.Actual, c.Expected, c.Message)
+		if err != nil {
+			return history.InstallationRecord{}, fmt.Errorf("failed to create preflight check: %w", err)
+		}
+		checks = append(checks, check)
+	}
+
+	// Reconstruct deployed config files
+	var files []history.ConfigFile
+	for _, f := range model.ConfigFiles {
+		file, err := history.NewConfigFile(f.Path, f.Hash)
+		if err != nil {
+			return history.InstallationRecord{}, fmt.Errorf("failed to create config file entry: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	// Reconstruct resolved conflicts
+	var conflicts []history.ConflictResolution
+	for _, c := range model.Conflicts {
+		conflict, err := history.NewConflictResolution(c.PackageName, c.ConflictingPackage, c.Reason, c.Strategy, c.Applied)
+		if err != nil {
+			return history.InstallationRecord{}, fmt.Errorf("failed to create conflict resolution: %w", err)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	// Reconstruct skipped components
+	var skipped []history.SkippedComponent
+	for _, c := range model.Skipped {
+		component, err := history.NewSkippedComponent(c.Component, c.Reason, c.Detail)
+		if err != nil {
+			return history.InstallationRecord{}, fmt.Errorf("failed to create skipped component: %w", err)
+		}
+		skipped = append(skipped, component)
+	}
+
+	// Reconstruct phase timings
+	var timings []history.PhaseTiming
+	for _, t := range model.PhaseTimings {
+		timing, err := history.NewPhaseTiming(t.Phase, t.Estimated, t.Actual)
+		if err != nil {
+			return history.InstallationRecord{}, fmt.Errorf("failed to create phase timing: %w", err)
+		}
+		timings = append(timings, timing)
+	}
+
+	scope, err := history.ParseScope(model.Scope)
+	if err != nil {
+		return history.InstallationRecord{}, fmt.Errorf("failed to parse scope: %w", err)
+	}
+
+	if model.Invocation != nil {
+		invocation, err := history.NewInvocation(
+			model.Invocation.CommandLine,
+			model.Invocation.Flags,
+			model.Invocation.ConfigHash,
+			model.Invocation.Version,
+			model.Invocation.Commit,
+			model.Invocation.User,
+		)
+		if err != nil {
+			return history.InstallationRecord{}, fmt.Errorf("failed to create invocation: %w", err)
+		}
+		record = record.WithInvocation(invocation)
+	}
+
+	return record.WithWarnings(model.Warnings).
+		WithPreflightChecks(checks).
+		WithConfigFiles(files).
+		WithConflicts(conflicts).
+		WithSkippedComponents(skipped).
+		WithPhaseTimings(timings).
+		WithScope(scope), nil
 }
 
 // Save persists an installation record
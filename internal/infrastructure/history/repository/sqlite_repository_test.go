@@ -41,6 +41,37 @@ func TestSQLiteRepository_Save(t *testing.T) {
 	assert.Equal(t, record.SessionID(), found.SessionID())
 }
 
+func TestSQLiteRepository_Save_Warnings(t *testing.T) {
+	repo, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	record := createTestRecord(t, "success", 1).WithWarnings([]string{"[conflict] Removed foot"})
+
+	require.NoError(t, repo.Save(ctx, record))
+
+	found, err := repo.FindByID(ctx, record.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[conflict] Removed foot"}, found.Warnings())
+}
+
+func TestSQLiteRepository_Save_PhaseTimings(t *testing.T) {
+	repo, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	timing, err := history.NewPhaseTiming("installing", 2*time.Minute, 150*time.Second)
+	require.NoError(t, err)
+	record := createTestRecord(t, "success", 1).WithPhaseTimings([]history.PhaseTiming{timing})
+
+	require.NoError(t, repo.Save(ctx, record))
+
+	found, err := repo.FindByID(ctx, record.ID())
+	require.NoError(t, err)
+	require.True(t, found.HasPhaseTimings())
+	assert.Equal(t, []history.PhaseTiming{timing}, found.PhaseTimings())
+}
+
 func TestSQLiteRepository_Save_Update(t *testing.T) {
 	repo, cleanup := setupTestRepo(t)
 	defer cleanup()
@@ -78,6 +109,80 @@ func TestSQLiteRepository_FindByID(t *testing.T) {
 		assert.Equal(t, record.PackageName(), found.PackageName())
 		assert.Equal(t, record.TargetVersion(), found.TargetVersion())
 		assert.Equal(t, record.PackageCount(), found.PackageCount())
+		assert.Equal(t, "amd64", found.SystemContext().Architecture())
+		assert.Equal(t, "amd", found.SystemContext().GPUVendor())
+		assert.Equal(t, "amd Radeon RX 6800 [0000:03:00.0]", found.SystemContext().RenderGPU())
+	})
+
+	t.Run("record with preflight checks", func(t *testing.T) {
+		check, err := history.NewPreflightCheck("disk_space", "fail", "5.0 GB available", "10 GB", "Free up disk space")
+		require.NoError(t, err)
+		withChecks := createTestRecord(t, "failed", 1).WithPreflightChecks([]history.PreflightCheck{check})
+		require.NoError(t, repo.Save(ctx, withChecks))
+
+		found, err := repo.FindByID(ctx, withChecks.ID())
+		require.NoError(t, err)
+		require.Len(t, found.PreflightChecks(), 1)
+		assert.Equal(t, check, found.PreflightChecks()[0])
+	})
+
+	t.Run("record with deployed config files", func(t *testing.T) {
+		file, err := history.NewConfigFile("/home/user/.config/hypr/hyprland.conf", "abc123")
+		require.NoError(t, err)
+		withFiles := createTestRecord(t, "success", 1).WithConfigFiles([]history.ConfigFile{file})
+		require.NoError(t, repo.Save(ctx, withFiles))
+
+		found, err := repo.FindByID(ctx, withFiles.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []history.ConfigFile{file}, found.ConfigFiles())
+	})
+
+	t.Run("record with resolved conflicts", func(t *testing.T) {
+		conflict, err := history.NewConflictResolution("hyprland", "hyprland-git", "conflicting package versions", "remove", true)
+		require.NoError(t, err)
+		withConflicts := createTestRecord(t, "success", 1).WithConflicts([]history.ConflictResolution{conflict})
+		require.NoError(t, repo.Save(ctx, withConflicts))
+
+		found, err := repo.FindByID(ctx, withConflicts.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []history.ConflictResolution{conflict}, found.Conflicts())
+	})
+
+	t.Run("record with skipped components", func(t *testing.T) {
+		component, err := history.NewSkippedComponent("waybar", "unavailable", "not in the configured repositories")
+		require.NoError(t, err)
+		withSkipped := createTestRecord(t, "success", 1).WithSkippedComponents([]history.SkippedComponent{component})
+		require.NoError(t, repo.Save(ctx, withSkipped))
+
+		found, err := repo.FindByID(ctx, withSkipped.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []history.SkippedComponent{component}, found.SkippedComponents())
+	})
+
+	t.Run("record with user scope", func(t *testing.T) {
+		scope, err := history.NewUserScope("alice")
+		require.NoError(t, err)
+		userRecord := createTestRecord(t, "success", 1).WithScope(scope)
+		require.NoError(t, repo.Save(ctx, userRecord))
+
+		found, err := repo.FindByID(ctx, userRecord.ID())
+		require.NoError(t, err)
+		assert.True(t, scope.Equals(found.Scope()))
+	})
+
+	t.Run("record with invocation", func(t *testing.T) {
+		invocation, err := history.NewInvocation(
+			"gohan install --components hyprland",
+			map[string]string{"components": "hyprland"},
+			"abc123", "1.2.0", "deadbeef", "alice",
+		)
+		require.NoError(t, err)
+		invoked := createTestRecord(t, "success", 1).WithInvocation(invocation)
+		require.NoError(t, repo.Save(ctx, invoked))
+
+		found, err := repo.FindByID(ctx, invoked.ID())
+		require.NoError(t, err)
+		assert.Equal(t, invocation, found.Invocation())
 	})
 
 	t.Run("non-existent record", func(t *testing.T) {
@@ -276,6 +381,35 @@ func TestSQLiteRepository_Close(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSQLiteRepository_CanceledContext(t *testing.T) {
+	repo, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	record := createTestRecord(t, "success", 2)
+
+	assert.ErrorIs(t, repo.Save(ctx, record), context.Canceled)
+
+	_, err := repo.FindByID(ctx, record.ID())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.FindAll(ctx, history.NewRecordFilter())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.FindRecent(ctx, 10)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.Count(ctx, history.NewRecordFilter())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.ErrorIs(t, repo.Delete(ctx, record.ID()), context.Canceled)
+
+	_, err = repo.PurgeOlderThan(ctx, time.Now())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 // Helper functions
 
 func tempDBPath(t *testing.T) string {
@@ -317,6 +451,7 @@ func createTestRecordWithTime(t *testing.T, outcomeStr string, packageCount int,
 	)
 
 	systemCtx, _ := history.NewSystemContext("Debian GNU/Linux 13", "6.1.0-13", "1.0.0", "testhost")
+	systemCtx = systemCtx.WithHardware("amd64", "amd").WithRenderGPU("amd Radeon RX 6800 [0000:03:00.0]")
 	outcome, _ := history.NewInstallationOutcome(outcomeStr)
 
 	var failureDetails *history.FailureDetails
@@ -0,0 +1,28 @@
+package sysinfo
+
+import (
+	"os"
+	"os/user"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+)
+
+// CurrentScope returns the history scope of the running process: the
+// system scope when running as root, the invoking user's scope otherwise.
+// Under sudo the installation is system-wide, so root wins over SUDO_USER.
+func CurrentScope() history.Scope {
+	if os.Geteuid() == 0 {
+		return history.SystemScope()
+	}
+
+	name := os.Getenv("USER")
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		name = current.Username
+	}
+
+	scope, err := history.NewUserScope(name)
+	if err != nil {
+		return history.SystemScope()
+	}
+	return scope
+}
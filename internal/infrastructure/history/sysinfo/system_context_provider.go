@@ -0,0 +1,116 @@
+package sysinfo
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+const (
+	defaultOSReleasePath     = "/etc/os-release"
+	defaultKernelReleasePath = "/proc/sys/kernel/osrelease"
+
+	// fallbackOSVersion is recorded when os-release cannot be read
+	fallbackOSVersion = "Linux"
+)
+
+// SystemContextProvider implements history.SystemContextProvider by reading
+// os-release, the running kernel release and the primary GPU
+type SystemContextProvider struct {
+	gohanVersion      string
+	gpuDetector       preflight.GPUDetector
+	osReleasePath     string
+	kernelReleasePath string
+}
+
+// NewSystemContextProvider creates a provider for the running system.
+// gpuDetector may be nil, in which case no GPU vendor is recorded.
+func NewSystemContextProvider(gohanVersion string, gpuDetector preflight.GPUDetector) *SystemContextProvider {
+	return NewSystemContextProviderWithPaths(gohanVersion, gpuDetector, defaultOSReleasePath, defaultKernelReleasePath)
+}
+
+// NewSystemContextProviderWithPaths creates a provider reading os-release and
+// the kernel release from the given paths
+func NewSystemContextProviderWithPaths(
+	gohanVersion string,
+	gpuDetector preflight.GPUDetector,
+	osReleasePath string,
+	kernelReleasePath string,
+) *SystemContextProvider {
+	return &SystemContextProvider{
+		gohanVersion:      gohanVersion,
+		gpuDetector:       gpuDetector,
+		osReleasePath:     osReleasePath,
+		kernelReleasePath: kernelReleasePath,
+	}
+}
+
+// CurrentSystemContext captures the OS, kernel, gohan version, hostname,
+// architecture and GPU vendor. Details that cannot be detected are left empty.
+func (p *SystemContextProvider) CurrentSystemContext(ctx context.Context) (history.SystemContext, error) {
+	hostname, _ := os.Hostname()
+
+	systemContext, err := history.NewSystemContext(
+		p.detectOSVersion(),
+		p.detectKernelVersion(),
+		p.gohanVersion,
+		hostname,
+	)
+	if err != nil {
+		return history.SystemContext{}, err
+	}
+
+	return systemContext.WithHardware(runtime.GOARCH, p.detectGPUVendor(ctx)), nil
+}
+
+// detectOSVersion returns PRETTY_NAME from os-release, falling back to
+// NAME and VERSION_ID
+func (p *SystemContextProvider) detectOSVersion() string {
+	content, err := os.ReadFile(p.osReleasePath)
+	if err != nil {
+		return fallbackOSVersion
+	}
+
+	fields := make(map[string]string)
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+		fields[key] = strings.Trim(value, `"'`)
+	}
+
+	if pretty := fields["PRETTY_NAME"]; pretty != "" {
+		return pretty
+	}
+	if name := strings.TrimSpace(fields["NAME"] + " " + fields["VERSION_ID"]); name != "" {
+		return name
+	}
+	return fallbackOSVersion
+}
+
+// detectKernelVersion returns the running kernel release, e.g. 6.12.6-amd64
+func (p *SystemContextProvider) detectKernelVersion() string {
+	content, err := os.ReadFile(p.kernelReleasePath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// detectGPUVendor returns the vendor of the primary GPU, or "" if unknown
+func (p *SystemContextProvider) detectGPUVendor(ctx context.Context) string {
+	if p.gpuDetector == nil {
+		return ""
+	}
+
+	gpu, err := p.gpuDetector.PrimaryGPU(ctx)
+	if err != nil {
+		return ""
+	}
+	return string(gpu.Vendor())
+}
@@ -0,0 +1,88 @@
+package sysinfo_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/rebelopsio/gohan/internal/infrastructure/history/sysinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubGPUDetector struct {
+	gpu preflight.GPUType
+	err error
+}
+
+func (d *stubGPUDetector) DetectGPUs(ctx context.Context) ([]preflight.GPUType, error) {
+	return []preflight.GPUType{d.gpu}, d.err
+}
+
+func (d *stubGPUDetector) PrimaryGPU(ctx context.Context) (preflight.GPUType, error) {
+	return d.gpu, d.err
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestSystemContextProvider_CurrentSystemContext(t *testing.T) {
+	t.Run("captures os, kernel, version, architecture and gpu", func(t *testing.T) {
+		dir := t.TempDir()
+		osRelease := writeFile(t, dir, "os-release",
+			"PRETTY_NAME=\"Debian GNU/Linux trixie/sid\"\nNAME=\"Debian GNU/Linux\"\nVERSION_CODENAME=trixie\n")
+		kernel := writeFile(t, dir, "osrelease", "6.12.6-amd64\n")
+
+		gpu, err := preflight.NewGPUType(preflight.GPUVendorAMD, "Radeon", "1002:73bf")
+		require.NoError(t, err)
+
+		provider := sysinfo.NewSystemContextProviderWithPaths("1.4.0", &stubGPUDetector{gpu: gpu}, osRelease, kernel)
+
+		sysCtx, err := provider.CurrentSystemContext(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Debian GNU/Linux trixie/sid", sysCtx.OSVersion())
+		assert.Equal(t, "6.12.6-amd64", sysCtx.KernelVersion())
+		assert.Equal(t, "1.4.0", sysCtx.GohanVersion())
+		assert.Equal(t, runtime.GOARCH, sysCtx.Architecture())
+		assert.Equal(t, "amd", sysCtx.GPUVendor())
+	})
+
+	t.Run("falls back to name and version id", func(t *testing.T) {
+		dir := t.TempDir()
+		osRelease := writeFile(t, dir, "os-release", "NAME=Debian\nVERSION_ID=\"13\"\n")
+
+		provider := sysinfo.NewSystemContextProviderWithPaths("dev", nil, osRelease, filepath.Join(dir, "missing"))
+
+		sysCtx, err := provider.CurrentSystemContext(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Debian 13", sysCtx.OSVersion())
+		assert.Empty(t, sysCtx.KernelVersion())
+		assert.Empty(t, sysCtx.GPUVendor())
+	})
+
+	t.Run("still records a context when detection fails", func(t *testing.T) {
+		dir := t.TempDir()
+		provider := sysinfo.NewSystemContextProviderWithPaths(
+			"dev",
+			&stubGPUDetector{err: errors.New("lspci not found")},
+			filepath.Join(dir, "missing"),
+			filepath.Join(dir, "missing"),
+		)
+
+		sysCtx, err := provider.CurrentSystemContext(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Linux", sysCtx.OSVersion())
+		assert.Empty(t, sysCtx.GPUVendor())
+	})
+}
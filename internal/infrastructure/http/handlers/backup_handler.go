@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	backupApp "github.com/rebelopsio/gohan/internal/application/backup"
+	"github.com/rebelopsio/gohan/internal/domain/backup"
+)
+
+// restoreConfirmationTTL is how long a restore confirmation token stays valid
+const restoreConfirmationTTL = 5 * time.Minute
+
+// ListBackupsUseCase defines the interface for listing backups
+type ListBackupsUseCase interface {
+	Execute(ctx context.Context, req backupApp.ListBackupsRequest) (*backupApp.ListBackupsResponse, error)
+}
+
+// GetBackupUseCase defines the interface for looking up a backup
+type GetBackupUseCase interface {
+	Execute(ctx context.Context, backupID string) (*backupApp.BackupDetail, error)
+}
+
+// CreateBackupUseCase defines the interface for creating a backup
+type CreateBackupUseCase interface {
+	Execute(ctx context.Context, req backupApp.CreateBackupRequest) (*backupApp.CreateBackupResponse, error)
+}
+
+// RestoreBackupUseCase defines the interface for restoring a backup
+type RestoreBackupUseCase interface {
+	Execute(ctx context.Context, req backupApp.RestoreBackupRequest) (*backupApp.RestoreBackupResponse, error)
+}
+
+// BackupHandler handles HTTP requests for configuration backups. Restoring
+// overwrites the user's files, so it takes two requests: the first returns
+// what would be restored and a confirmation token, the second repeats the
+// request with the token.
+type BackupHandler struct {
+	listUseCase    ListBackupsUseCase
+	getUseCase     GetBackupUseCase
+	createUseCase  CreateBackupUseCase
+	restoreUseCase RestoreBackupUseCase
+	backupRoot     string
+	homeDir        string
+
+	mu            sync.Mutex
+	confirmations map[string]pendingRestore
+}
+
+// pendingRestore is a restore waiting for its confirmation token
+type pendingRestore struct {
+	backupID  string
+	selective []string
+	expiresAt time.Time
+}
+
+// NewBackupHandler creates a new backup handler for the backups stored under
+// backupRoot. Backups may only include paths under homeDir.
+func NewBackupHandler(
+	listUseCase ListBackupsUseCase,
+	getUseCase GetBackupUseCase,
+	createUseCase CreateBackupUseCase,
+	restoreUseCase RestoreBackupUseCase,
+	backupRoot string,
+	homeDir string,
+) *BackupHandler {
+	return &BackupHandler{
+		listUseCase:    listUseCase,
+		getUseCase:     getUseCase,
+		createUseCase:  createUseCase,
+		restoreUseCase: restoreUseCase,
+		backupRoot:     backupRoot,
+		homeDir:        homeDir,
+		confirmations:  make(map[string]pendingRestore),
+	}
+}
+
+// CreateBackupRequest is the body of POST /api/backups
+type CreateBackupRequest struct {
+	Description string
+	Paths       []string // Empty backs up the default configuration directories
+}
+
+// RestoreRequest is the body of POST /api/backups/{backupID}/restore
+type RestoreRequest struct {
+	Selective         []string // Files to restore; empty restores all
+	ConfirmationToken string   // Empty asks for a token
+}
+
+// RollbackRequest is the body of POST /api/v1/configs/rollback
+type RollbackRequest struct {
+	BackupID          string   // Backup taken by a deployment, as listed by GET /api/v1/configs/backups
+	Selective         []string // Files to restore; empty restores all
+	ConfirmationToken string   // Empty asks for a token
+}
+
+// RestoreConfirmation answers a restore request without a token
+type RestoreConfirmation struct {
+	BackupID          string
+	ConfirmationToken string
+	ExpiresAt         time.Time
+	Files             []string // Files the restore would overwrite
+}
+
+// ListBackups handles GET /api/backups and GET /api/v1/configs/backups,
+// paged by the optional limit and offset query parameters
+func (h *BackupHandler) ListBackups(w http.ResponseWriter, r *http.Request) {
+	var request backupApp.ListBackupsRequest
+	for name, target := range map[string]*int{"limit": &request.Limit, "offset": &request.Offset} {
+		raw := r.URL.Query().Get(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid query parameter", name+" must be a non-negative integer")
+			return
+		}
+		*target = value
+	}
+
+	response, err := h.listUseCase.Execute(r.Context(), request)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list backups", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetBackup handles GET /api/backups/{backupID}
+func (h *BackupHandler) GetBackup(w http.ResponseWriter, r *http.Request) {
+	response, err := h.getUseCase.Execute(r.Context(), chi.URLParam(r, "backupID"))
+	if err != nil {
+		respondWithError(w, statusForBackupError(err), "Failed to get backup", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// CreateBackup handles POST /api/backups
+func (h *BackupHandler) CreateBackup(w http.ResponseWriter, r *http.Request) {
+	// An empty body backs up the default directories
+	var body CreateBackupRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	paths := body.Paths
+	if len(paths) == 0 {
+		paths = backupApp.DefaultPaths(h.homeDir)
+	}
+	for _, path := range paths {
+		if !h.underHome(path) {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body", "path "+path+" is not an absolute path under "+h.homeDir)
+			return
+		}
+	}
+
+	response, err := h.createUseCase.Execute(r.Context(), backupApp.CreateBackupRequest{
+		Description: body.Description,
+		FilePaths:   paths,
+		BackupRoot:  h.backupRoot,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to create backup", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, response)
+}
+
+// RestoreBackup handles POST /api/backups/{backupID}/restore. Without a
+// confirmation token it restores nothing and answers 202 with a token for
+// the same backup and files; the token works once, within five minutes.
+func (h *BackupHandler) RestoreBackup(w http.ResponseWriter, r *http.Request) {
+	var body RestoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	h.restore(w, r, chi.URLParam(r, "backupID"), body)
+}
+
+// Rollback handles POST /api/v1/configs/rollback, restoring the backup a
+// deployment took. It is confirmed with a token as RestoreBackup is.
+func (h *BackupHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	var body RollbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+	if body.BackupID == "" {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", "BackupID is required")
+		return
+	}
+
+	h.restore(w, r, body.BackupID, RestoreRequest{
+		Selective:         body.Selective,
+		ConfirmationToken: body.ConfirmationToken,
+	})
+}
+
+// restore asks for confirmation of restoring backupID, or restores it when
+// body carries a token issued for the same restore
+func (h *BackupHandler) restore(w http.ResponseWriter, r *http.Request, backupID string, body RestoreRequest) {
+	if body.ConfirmationToken == "" {
+		detail, err := h.getUseCase.Execute(r.Context(), backupID)
+		if err != nil {
+			respondWithError(w, statusForBackupError(err), "Failed to restore backup", err.Error())
+			return
+		}
+
+		confirmation, err := h.requestConfirmation(detail, body.Selective)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to restore backup", err.Error())
+			return
+		}
+		respondWithJSON(w, http.StatusAccepted, confirmation)
+		return
+	}
+
+	if !h.confirm(body.ConfirmationToken, backupID, body.Selective) {
+		respondWithError(w, http.StatusPreconditionFailed, "Invalid confirmation token",
+			"the token is unknown, expired, already used or issued for a different restore")
+		return
+	}
+
+	response, err := h.restoreUseCase.Execute(r.Context(), backupApp.RestoreBackupRequest{
+		BackupID:  backupID,
+		Selective: body.Selective,
+	})
+	if err != nil {
+		respondWithError(w, statusForBackupError(err), "Failed to restore backup", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// requestConfirmation issues a token for restoring the selected files of
+// detail
+func (h *BackupHandler) requestConfirmation(detail *backupApp.BackupDetail, selective []string) (*RestoreConfirmation, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, file := range detail.Files {
+		if len(selective) == 0 || slices.Contains(selective, file.Path) {
+			files = append(files, file.Path)
+		}
+	}
+
+	now := time.Now()
+	confirmation := &RestoreConfirmation{
+		BackupID:          detail.ID,
+		ConfirmationToken: hex.EncodeToString(token),
+		ExpiresAt:         now.Add(restoreConfirmationTTL),
+		Files:             files,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for key, pending := range h.confirmations {
+		if now.After(pending.expiresAt) {
+			delete(h.confirmations, key)
+		}
+	}
+	h.confirmations[confirmation.ConfirmationToken] = pendingRestore{
+		backupID:  detail.ID,
+		selective: sortedCopy(selective),
+		expiresAt: confirmation.ExpiresAt,
+	}
+	return confirmation, nil
+}
+
+// confirm uses up token, reporting whether it was issued for restoring the
+// same files of the same backup and has not expired
+func (h *BackupHandler) confirm(token, backupID string, selective []string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pending, ok := h.confirmations[token]
+	if !ok {
+		return false
+	}
+	delete(h.confirmations, token)
+
+	return time.Now().Before(pending.expiresAt) &&
+		pending.backupID == backupID &&
+		slices.Equal(pending.selective, sortedCopy(selective))
+}
+
+// underHome reports whether path is absolute and inside the home directory
+func (h *BackupHandler) underHome(path string) bool {
+	if !filepath.IsAbs(path) || h.homeDir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(h.homeDir, filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func sortedCopy(values []string) []string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return sorted
+}
+
+// statusForBackupError maps backup domain errors to HTTP status codes;
+// anything else is treated as a server error
+func statusForBackupError(err error) int {
+	switch {
+	case errors.Is(err, backup.ErrBackupNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, backup.ErrInvalidBackupID):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
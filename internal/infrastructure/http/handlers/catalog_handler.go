@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// CatalogUseCase defines the interface for listing what can be installed
+type CatalogUseCase interface {
+	Components(ctx context.Context) ([]dto.CatalogComponent, error)
+	Packages(ctx context.Context, request dto.CatalogPackagesRequest) ([]dto.CatalogPackage, error)
+	Profiles(ctx context.Context) ([]dto.CatalogProfile, error)
+}
+
+// CatalogHandler handles HTTP requests for the package catalog
+type CatalogHandler struct {
+	useCase CatalogUseCase
+}
+
+// NewCatalogHandler creates a new catalog handler
+func NewCatalogHandler(useCase CatalogUseCase) *CatalogHandler {
+	return &CatalogHandler{useCase: useCase}
+}
+
+// ListComponents handles GET /api/v1/catalog/components
+func (h *CatalogHandler) ListComponents(w http.ResponseWriter, r *http.Request) {
+	components, err := h.useCase.Components(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list components", err.Error())
+		return
+	}
+	respondWithJSON(w, http.StatusOK, components)
+}
+
+// ListPackages handles GET /api/v1/catalog/packages, narrowed by the
+// component, group and suite query parameters
+func (h *CatalogHandler) ListPackages(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	packages, err := h.useCase.Packages(r.Context(), dto.CatalogPackagesRequest{
+		Component: query.Get("component"),
+		Group:     query.Get("group"),
+		Suite:     query.Get("suite"),
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, installation.ErrUnknownSuite) {
+			status = http.StatusBadRequest
+		}
+		respondWithError(w, status, "Failed to list packages", err.Error())
+		return
+	}
+	respondWithJSON(w, http.StatusOK, packages)
+}
+
+// ListProfiles handles GET /api/v1/catalog/profiles
+func (h *CatalogHandler) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.useCase.Profiles(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list profiles", err.Error())
+		return
+	}
+	respondWithJSON(w, http.StatusOK, profiles)
+}
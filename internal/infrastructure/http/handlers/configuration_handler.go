@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+)
+
+// DeployConfigurationUseCase defines the interface for deploying configuration files
+type DeployConfigurationUseCase interface {
+	Execute(ctx context.Context, req configApp.DeployConfigRequest) (*configApp.DeployConfigResponse, error)
+}
+
+// DeployDefaults returns the request each deployment starts from, holding
+// the settings that come from the system rather than the caller, such as
+// the lock screen, installed portals and imported variables
+type DeployDefaults func(ctx context.Context) (configApp.DeployConfigRequest, error)
+
+// reservedDeployVars choose where files are written, so callers over HTTP
+// may not set them
+var reservedDeployVars = []string{"home", "home_dir"}
+
+// ConfigurationHandler handles HTTP requests deploying configuration files
+type ConfigurationHandler struct {
+	deployUseCase DeployConfigurationUseCase
+	defaults      DeployDefaults // Optional
+}
+
+// NewConfigurationHandler creates a new configuration handler
+func NewConfigurationHandler(deployUseCase DeployConfigurationUseCase) *ConfigurationHandler {
+	return &ConfigurationHandler{deployUseCase: deployUseCase}
+}
+
+// WithDefaults starts every deployment from the request defaults returns,
+// so files render as they would from gohan config deploy
+func (h *ConfigurationHandler) WithDefaults(defaults DeployDefaults) *ConfigurationHandler {
+	h.defaults = defaults
+	return h
+}
+
+// DeployRequest is the body of POST /api/configurations/deploy and
+// POST /api/v1/configs/deploy
+type DeployRequest struct {
+	Components []string          // Empty deploys the default components
+	CustomVars map[string]string // Template variables, overriding the defaults
+	DryRun     bool              // Report what would change without writing
+}
+
+// DeployConfigurations handles POST /api/configurations/deploy and
+// POST /api/v1/configs/deploy. Files that already hold the rendered content
+// are reported unchanged and left alone, so repeating a request is safe.
+func (h *ConfigurationHandler) DeployConfigurations(w http.ResponseWriter, r *http.Request) {
+	var body DeployRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+	for _, name := range reservedDeployVars {
+		if _, ok := body.CustomVars[name]; ok {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body", "custom variable "+name+" cannot be set over the API")
+			return
+		}
+	}
+
+	var request configApp.DeployConfigRequest
+	if h.defaults != nil {
+		defaults, err := h.defaults(r.Context())
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to prepare deployment", err.Error())
+			return
+		}
+		request = defaults
+	}
+
+	vars := make(map[string]string, len(request.CustomVars)+len(body.CustomVars))
+	for k, v := range request.CustomVars {
+		vars[k] = v
+	}
+	for k, v := range body.CustomVars {
+		vars[k] = v
+	}
+	request.Components = body.Components
+	request.CustomVars = vars
+	request.DryRun = body.DryRun
+	request.Force = true
+
+	response, err := h.deployUseCase.Execute(r.Context(), request)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, configApp.ErrNoConfigurations) {
+			status = http.StatusBadRequest
+		}
+		respondWithError(w, status, "Failed to deploy configurations", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
@@ -3,11 +3,32 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rebelopsio/gohan/internal/application/installation/dto"
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// progressWriteTimeout bounds sending one progress update to a client
+	progressWriteTimeout = 10 * time.Second
+
+	// progressKeepAlive is how often an idle event stream sends a comment,
+	// so proxies do not close it between slow progress updates
+	progressKeepAlive = 15 * time.Second
+
+	// progressRetry is how long event stream clients wait before
+	// reconnecting
+	progressRetry = 3 * time.Second
 )
 
 // StartInstallationUseCase defines the interface for starting an installation
@@ -17,7 +38,7 @@ type StartInstallationUseCase interface {
 
 // ExecuteInstallationUseCase defines the interface for executing an installation
 type ExecuteInstallationUseCase interface {
-	Execute(ctx context.Context, sessionID string, progressCallback usecases.ProgressCallback) (*dto.InstallationProgressResponse, error)
+	Execute(ctx context.Context, sessionID string) (*dto.InstallationProgressResponse, error)
 }
 
 // GetInstallationStatusUseCase defines the interface for getting installation status
@@ -25,6 +46,11 @@ type GetInstallationStatusUseCase interface {
 	Execute(ctx context.Context, sessionID string) (*dto.InstallationProgressResponse, error)
 }
 
+// GetInstallationDetailUseCase defines the interface for getting the details of an installation
+type GetInstallationDetailUseCase interface {
+	Execute(ctx context.Context, sessionID string) (*dto.InstallationDetailResponse, error)
+}
+
 // ListInstallationsUseCase defines the interface for listing all installations
 type ListInstallationsUseCase interface {
 	Execute(ctx context.Context) (*dto.ListInstallationsResponse, error)
@@ -32,7 +58,13 @@ type ListInstallationsUseCase interface {
 
 // CancelInstallationUseCase defines the interface for cancelling an installation
 type CancelInstallationUseCase interface {
-	Execute(ctx context.Context, sessionID string) error
+	Execute(ctx context.Context, sessionID string, request dto.CancelInstallationRequest) (*dto.InstallationProgressResponse, error)
+}
+
+// ProgressSubscriber streams the progress of executing installations
+type ProgressSubscriber interface {
+	Subscribe(sessionID string) (<-chan dto.ProgressEventDTO, func())
+	Resume(sessionID string, lastSequence uint64) ([]dto.ProgressEventDTO, bool, <-chan dto.ProgressEventDTO, func())
 }
 
 // InstallationHandler handles HTTP requests for installation operations
@@ -42,6 +74,8 @@ type InstallationHandler struct {
 	getStatusUseCase GetInstallationStatusUseCase
 	listUseCase      ListInstallationsUseCase
 	cancelUseCase    CancelInstallationUseCase
+	detailUseCase    GetInstallationDetailUseCase
+	progressStreams  ProgressSubscriber
 }
 
 // NewInstallationHandler creates a new installation handler
@@ -61,6 +95,19 @@ func NewInstallationHandler(
 	}
 }
 
+// WithDetailUseCase serves installation details from the given use case
+func (h *InstallationHandler) WithDetailUseCase(detailUseCase GetInstallationDetailUseCase) *InstallationHandler {
+	h.detailUseCase = detailUseCase
+	return h
+}
+
+// WithProgressStreams streams installation progress over WebSockets and
+// Server-Sent Events from the given subscriber
+func (h *InstallationHandler) WithProgressStreams(streams ProgressSubscriber) *InstallationHandler {
+	h.progressStreams = streams
+	return h
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -96,10 +143,10 @@ func (h *InstallationHandler) ExecuteInstallation(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Execute use case (no progress callback for HTTP - use polling via GetStatus instead)
-	response, err := h.executeUseCase.Execute(r.Context(), sessionID, nil)
+	// Progress reaches HTTP clients through the status endpoint and the streams
+	response, err := h.executeUseCase.Execute(r.Context(), sessionID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to execute installation", err.Error())
+		respondWithError(w, statusForError(err, http.StatusInternalServerError), "Failed to execute installation", err.Error())
 		return
 	}
 
@@ -119,7 +166,35 @@ func (h *InstallationHandler) GetStatus(w http.ResponseWriter, r *http.Request)
 	// Execute use case
 	response, err := h.getStatusUseCase.Execute(r.Context(), sessionID)
 	if err != nil {
-		respondWithError(w, http.StatusNotFound, "Session not found", err.Error())
+		respondWithError(w, statusForError(err, http.StatusInternalServerError), "Failed to get installation status", err.Error())
+		return
+	}
+
+	// Return successful response
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetInstallation handles GET /api/installation/{sessionID}
+// Unlike GetStatus, the response includes the requested configuration,
+// the snapshot, the installed components and the linked backups and
+// history records.
+func (h *InstallationHandler) GetInstallation(w http.ResponseWriter, r *http.Request) {
+	if h.detailUseCase == nil {
+		respondWithError(w, http.StatusNotImplemented, "Installation details are not available", "")
+		return
+	}
+
+	// Get session ID from URL params
+	sessionID := chi.URLParam(r, "sessionID")
+	if sessionID == "" {
+		respondWithError(w, http.StatusBadRequest, "Session ID is required", "")
+		return
+	}
+
+	// Execute use case
+	response, err := h.detailUseCase.Execute(r.Context(), sessionID)
+	if err != nil {
+		respondWithError(w, statusForError(err, http.StatusInternalServerError), "Failed to get installation details", err.Error())
 		return
 	}
 
@@ -141,6 +216,9 @@ func (h *InstallationHandler) ListInstallations(w http.ResponseWriter, r *http.R
 }
 
 // CancelInstallation handles POST /api/installation/{sessionID}/cancel
+// A running installation stops after the current package, or immediately
+// with ?force=true. The response is the resulting session state; a
+// cancelled session resumes when executed again.
 func (h *InstallationHandler) CancelInstallation(w http.ResponseWriter, r *http.Request) {
 	// Get session ID from URL params
 	sessionID := chi.URLParam(r, "sessionID")
@@ -149,18 +227,272 @@ func (h *InstallationHandler) CancelInstallation(w http.ResponseWriter, r *http.
 		return
 	}
 
+	var request dto.CancelInstallationRequest
+	if force := r.URL.Query().Get("force"); force != "" {
+		value, err := strconv.ParseBool(force)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid force parameter", err.Error())
+			return
+		}
+		request.Force = value
+	}
+
 	// Execute use case
-	err := h.cancelUseCase.Execute(r.Context(), sessionID)
+	response, err := h.cancelUseCase.Execute(r.Context(), sessionID, request)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to cancel installation", err.Error())
+		respondWithError(w, statusForError(err, http.StatusInternalServerError), "Failed to cancel installation", err.Error())
 		return
 	}
 
-	// Return successful response
-	respondWithJSON(w, http.StatusOK, map[string]string{
-		"message":    "Installation cancelled successfully",
-		"session_id": sessionID,
-	})
+	// Return the resulting session state
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// StreamProgress handles GET /api/v1/installations/{sessionID}/progress/ws
+// It upgrades to a WebSocket and sends the session's current progress, then
+// each progress update of its execution as a JSON ProgressEventDTO. The
+// last update has Final set and the server closes the connection after it;
+// a finished session only gets its final state.
+func (h *InstallationHandler) StreamProgress(w http.ResponseWriter, r *http.Request) {
+	if h.progressStreams == nil {
+		respondWithError(w, http.StatusNotImplemented, "Progress streaming is not available", "")
+		return
+	}
+
+	// Get session ID from URL params
+	sessionID := chi.URLParam(r, "sessionID")
+	if sessionID == "" {
+		respondWithError(w, http.StatusBadRequest, "Session ID is required", "")
+		return
+	}
+
+	// Subscribe before reading the status so no update falls in between
+	events, unsubscribe := h.progressStreams.Subscribe(sessionID)
+	defer unsubscribe()
+
+	status, err := h.getStatusUseCase.Execute(r.Context(), sessionID)
+	if err != nil {
+		respondWithError(w, statusForError(err, http.StatusInternalServerError), "Failed to get installation status", err.Error())
+		return
+	}
+
+	// Origins are not checked, as for the other routes under CORS
+	websocket.Server{Handler: func(ws *websocket.Conn) {
+		streamProgress(ws, status, events)
+	}}.ServeHTTP(w, r)
+}
+
+// streamProgress sends the current progress, then the updates until the
+// final one or until the client goes away
+func streamProgress(ws *websocket.Conn, status *dto.InstallationProgressResponse, events <-chan dto.ProgressEventDTO) {
+	defer ws.Close()
+
+	// The server's timeouts apply to the upgrade request, not the stream
+	_ = ws.SetDeadline(time.Time{})
+
+	current := currentProgressEvent(status)
+	if err := sendProgress(ws, current); err != nil || current.Final {
+		return
+	}
+
+	// Clients only listen; reading notices when they close the connection
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard []byte
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := sendProgress(ws, event); err != nil || event.Final {
+				return
+			}
+		}
+	}
+}
+
+// sendProgress sends one progress update as JSON
+func sendProgress(ws *websocket.Conn, event dto.ProgressEventDTO) error {
+	if err := ws.SetWriteDeadline(time.Now().Add(progressWriteTimeout)); err != nil {
+		return err
+	}
+	return websocket.JSON.Send(ws, event)
+}
+
+// StreamEvents handles GET /api/v1/installations/{sessionID}/events
+// It is the Server-Sent Events fallback of StreamProgress for clients that
+// cannot open WebSockets, sending the same updates as "progress" events.
+// Each update carries its sequence number as the event ID: a client
+// reconnecting with Last-Event-ID gets the updates it missed, or the
+// current progress when those are gone. A comment is sent while the stream
+// is idle to keep it open. Once the client has the final update, a
+// reconnection gets 204 No Content, which stops EventSource retrying. The
+// final state of a session the streams have forgotten has event ID 0,
+// which the streams treat as up to date.
+func (h *InstallationHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	if h.progressStreams == nil {
+		respondWithError(w, http.StatusNotImplemented, "Progress streaming is not available", "")
+		return
+	}
+
+	// Get session ID from URL params
+	sessionID := chi.URLParam(r, "sessionID")
+	if sessionID == "" {
+		respondWithError(w, http.StatusBadRequest, "Session ID is required", "")
+		return
+	}
+
+	// Subscribe before reading the status so no update falls in between
+	var missed []dto.ProgressEventDTO
+	var resumed bool
+	var events <-chan dto.ProgressEventDTO
+	var unsubscribe func()
+	if lastSequence, err := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64); err == nil {
+		missed, resumed, events, unsubscribe = h.progressStreams.Resume(sessionID, lastSequence)
+	} else {
+		events, unsubscribe = h.progressStreams.Subscribe(sessionID)
+	}
+	defer unsubscribe()
+
+	status, err := h.getStatusUseCase.Execute(r.Context(), sessionID)
+	if err != nil {
+		respondWithError(w, statusForError(err, http.StatusInternalServerError), "Failed to get installation status", err.Error())
+		return
+	}
+
+	if resumed && len(missed) == 0 && installation.InstallationStatus(status.Status).IsTerminal() {
+		// The execution may have ended since subscribing; otherwise the
+		// client already has the final update
+		select {
+		case event, ok := <-events:
+			if ok {
+				missed = append(missed, event)
+			}
+		default:
+		}
+		if len(missed) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	streamEvents(r.Context(), w, status, missed, resumed, events)
+}
+
+// streamEvents sends the current progress, or the missed updates when
+// resuming, then the updates until the final one or until the client goes
+// away
+func streamEvents(
+	ctx context.Context,
+	w http.ResponseWriter,
+	status *dto.InstallationProgressResponse,
+	missed []dto.ProgressEventDTO,
+	resumed bool,
+	events <-chan dto.ProgressEventDTO,
+) {
+	rc := http.NewResponseController(w)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", progressRetry.Milliseconds()); err != nil {
+		return
+	}
+	if !resumed {
+		missed = []dto.ProgressEventDTO{currentProgressEvent(status)}
+	}
+	for _, event := range missed {
+		if err := sendEvent(w, rc, event); err != nil || event.Final {
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(progressKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if err := writeEvent(w, rc, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := sendEvent(w, rc, event); err != nil || event.Final {
+				return
+			}
+		}
+	}
+}
+
+// sendEvent sends one progress update as a Server-Sent Event with JSON data
+func sendEvent(w http.ResponseWriter, rc *http.ResponseController, event dto.ProgressEventDTO) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var message strings.Builder
+	if event.Sequence > 0 || event.Final {
+		fmt.Fprintf(&message, "id: %d\n", event.Sequence)
+	}
+	fmt.Fprintf(&message, "event: progress\ndata: %s\n\n", data)
+	return writeEvent(w, rc, message.String())
+}
+
+// writeEvent writes and flushes part of an event stream. The server's
+// write timeout applies to each write rather than the whole stream.
+func writeEvent(w http.ResponseWriter, rc *http.ResponseController, message string) error {
+	if err := rc.SetWriteDeadline(time.Now().Add(progressWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := io.WriteString(w, message); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// currentProgressEvent reports a session's progress as a stream's first
+// update
+func currentProgressEvent(status *dto.InstallationProgressResponse) dto.ProgressEventDTO {
+	return dto.ProgressEventDTO{
+		SessionID:       status.SessionID,
+		EventType:       "installation.progress.current",
+		Status:          status.Status,
+		PercentComplete: status.PercentComplete,
+		Message:         status.Message,
+		OccurredAt:      status.UpdatedAt,
+		Final:           installation.InstallationStatus(status.Status).IsTerminal(),
+	}
+}
+
+// statusForError maps domain errors to HTTP status codes, falling back to
+// the given status for anything else
+func statusForError(err error, fallback int) int {
+	switch {
+	case errors.Is(err, installation.ErrSessionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, installation.ErrSessionAlreadyComplete),
+		errors.Is(err, installation.ErrInvalidStateTransition),
+		errors.Is(err, usecases.ErrInstallationRunning):
+		return http.StatusConflict
+	case errors.Is(err, usecases.ErrDryRunUnsupported):
+		return http.StatusNotImplemented
+	default:
+		return fallback
+	}
 }
 
 // respondWithJSON sends a JSON response
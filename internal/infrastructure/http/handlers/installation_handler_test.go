@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/rebelopsio/gohan/internal/application/installation/dto"
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/infrastructure/http/handlers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -35,8 +37,21 @@ type MockExecuteInstallationUseCase struct {
 	mock.Mock
 }
 
-func (m *MockExecuteInstallationUseCase) Execute(ctx context.Context, sessionID string, progressCallback usecases.ProgressCallback) (*dto.InstallationProgressResponse, error) {
-	args := m.Called(ctx, sessionID, progressCallback)
+func (m *MockExecuteInstallationUseCase) Execute(ctx context.Context, sessionID string) (*dto.InstallationProgressResponse, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.InstallationProgressResponse), args.Error(1)
+}
+
+// MockCancelInstallationUseCase is a mock for the CancelInstallationUseCase
+type MockCancelInstallationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCancelInstallationUseCase) Execute(ctx context.Context, sessionID string, request dto.CancelInstallationRequest) (*dto.InstallationProgressResponse, error) {
+	args := m.Called(ctx, sessionID, request)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -171,7 +186,7 @@ func TestInstallationHandler_ExecuteInstallation(t *testing.T) {
 			ComponentsTotal:     1,
 		}
 
-		mockUseCase.On("Execute", mock.Anything, sessionID, mock.Anything).
+		mockUseCase.On("Execute", mock.Anything, sessionID).
 			Return(expectedResponse, nil)
 
 		// Create request with chi URL params
@@ -202,7 +217,7 @@ func TestInstallationHandler_ExecuteInstallation(t *testing.T) {
 
 		sessionID := "non-existent"
 
-		mockUseCase.On("Execute", mock.Anything, sessionID, mock.Anything).
+		mockUseCase.On("Execute", mock.Anything, sessionID).
 			Return(nil, assert.AnError)
 
 		req := httptest.NewRequest(http.MethodPost, "/api/installation/"+sessionID+"/execute", nil)
@@ -218,6 +233,50 @@ func TestInstallationHandler_ExecuteInstallation(t *testing.T) {
 		mockUseCase.AssertExpectations(t)
 	})
 
+	t.Run("maps a missing session to not found", func(t *testing.T) {
+		mockUseCase := new(MockExecuteInstallationUseCase)
+		handler := handlers.NewInstallationHandler(nil, mockUseCase, nil, nil, nil)
+
+		sessionID := "missing"
+
+		mockUseCase.On("Execute", mock.Anything, sessionID).
+			Return(nil, fmt.Errorf("session %s: %w", sessionID, installation.ErrSessionNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/installation/"+sessionID+"/execute", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("sessionID", sessionID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		rec := httptest.NewRecorder()
+
+		handler.ExecuteInstallation(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("maps unsupported dry runs to not implemented", func(t *testing.T) {
+		mockUseCase := new(MockExecuteInstallationUseCase)
+		handler := handlers.NewInstallationHandler(nil, mockUseCase, nil, nil, nil)
+
+		sessionID := "dry-run"
+
+		mockUseCase.On("Execute", mock.Anything, sessionID).
+			Return(nil, usecases.ErrDryRunUnsupported)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/installation/"+sessionID+"/execute", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("sessionID", sessionID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		rec := httptest.NewRecorder()
+
+		handler.ExecuteInstallation(rec, req)
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
 	t.Run("returns bad request for empty session ID", func(t *testing.T) {
 		mockUseCase := new(MockExecuteInstallationUseCase)
 		handler := handlers.NewInstallationHandler(nil, mockUseCase, nil, nil, nil)
@@ -235,6 +294,70 @@ func TestInstallationHandler_ExecuteInstallation(t *testing.T) {
 	})
 }
 
+func TestInstallationHandler_CancelInstallation(t *testing.T) {
+	newRequest := func(sessionID, query string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/installation/"+sessionID+"/cancel"+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("sessionID", sessionID)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("cancels gracefully by default and returns the session state", func(t *testing.T) {
+		mockUseCase := new(MockCancelInstallationUseCase)
+		handler := handlers.NewInstallationHandler(nil, nil, nil, nil, mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, "session-123", dto.CancelInstallationRequest{Force: false}).
+			Return(&dto.InstallationProgressResponse{SessionID: "session-123", Status: "cancelled"}, nil)
+
+		rec := httptest.NewRecorder()
+		handler.CancelInstallation(rec, newRequest("session-123", ""))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response dto.InstallationProgressResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Equal(t, "cancelled", response.Status)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("passes the force option", func(t *testing.T) {
+		mockUseCase := new(MockCancelInstallationUseCase)
+		handler := handlers.NewInstallationHandler(nil, nil, nil, nil, mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, "session-123", dto.CancelInstallationRequest{Force: true}).
+			Return(&dto.InstallationProgressResponse{SessionID: "session-123", Status: "cancelled"}, nil)
+
+		rec := httptest.NewRecorder()
+		handler.CancelInstallation(rec, newRequest("session-123", "?force=true"))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid force value", func(t *testing.T) {
+		mockUseCase := new(MockCancelInstallationUseCase)
+		handler := handlers.NewInstallationHandler(nil, nil, nil, nil, mockUseCase)
+
+		rec := httptest.NewRecorder()
+		handler.CancelInstallation(rec, newRequest("session-123", "?force=maybe"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertNotCalled(t, "Execute")
+	})
+
+	t.Run("maps a finished session to conflict", func(t *testing.T) {
+		mockUseCase := new(MockCancelInstallationUseCase)
+		handler := handlers.NewInstallationHandler(nil, nil, nil, nil, mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, "done", mock.Anything).
+			Return(nil, fmt.Errorf("failed to cancel installation: %w", installation.ErrSessionAlreadyComplete))
+
+		rec := httptest.NewRecorder()
+		handler.CancelInstallation(rec, newRequest("done", ""))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
 func TestInstallationHandler_ContentTypeValidation(t *testing.T) {
 	t.Run("accepts application/json content type", func(t *testing.T) {
 		mockUseCase := new(MockStartInstallationUseCase)
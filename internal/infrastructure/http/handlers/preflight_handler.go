@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+)
+
+// RunPreflightUseCase defines the interface for running preflight checks
+type RunPreflightUseCase interface {
+	Execute(ctx context.Context, req preflightApp.RunPreflightRequest) (*preflightApp.RunPreflightResponse, error)
+}
+
+// PreflightHandler handles HTTP requests checking the server host before
+// installing
+type PreflightHandler struct {
+	runUseCase RunPreflightUseCase
+	homeDir    string
+}
+
+// NewPreflightHandler creates a new preflight handler. The mount point of
+// homeDir, where configuration files are deployed, is checked for room as
+// well.
+func NewPreflightHandler(runUseCase RunPreflightUseCase, homeDir string) *PreflightHandler {
+	return &PreflightHandler{runUseCase: runUseCase, homeDir: homeDir}
+}
+
+// PreflightReport answers POST /api/v1/preflight: the run's results, with
+// the checks that block installation and the ones that only warn listed
+// apart
+type PreflightReport struct {
+	*preflightApp.RunPreflightResponse
+	Blockers []preflightApp.CheckResult
+	Warnings []preflightApp.CheckResult
+}
+
+// RunPreflight handles POST /api/v1/preflight, running every check on the
+// server host. A host that fails checks still gets 200; Passed and
+// Blockers say whether it can be installed on.
+func (h *PreflightHandler) RunPreflight(w http.ResponseWriter, r *http.Request) {
+	response, err := h.runUseCase.Execute(r.Context(), preflightApp.RunPreflightRequest{HomeDir: h.homeDir})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to run preflight checks", err.Error())
+		return
+	}
+
+	report := PreflightReport{
+		RunPreflightResponse: response,
+		Blockers:             []preflightApp.CheckResult{},
+		Warnings:             []preflightApp.CheckResult{},
+	}
+	report.Blockers = append(report.Blockers, response.BlockingResults()...)
+	report.Warnings = append(report.Warnings, response.WarningResults()...)
+	respondWithJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+)
+
+// CreateTemplateUseCase defines the interface for saving a configuration template
+type CreateTemplateUseCase interface {
+	Execute(ctx context.Context, req configApp.CreateTemplateRequest) (*configApp.TemplateDTO, error)
+}
+
+// ListTemplatesUseCase defines the interface for listing configuration templates
+type ListTemplatesUseCase interface {
+	Execute(ctx context.Context, req configApp.ListTemplatesRequest) (*configApp.ListTemplatesResponse, error)
+}
+
+// SearchTemplatesUseCase defines the interface for searching configuration templates
+type SearchTemplatesUseCase interface {
+	Execute(ctx context.Context, req configApp.SearchTemplatesRequest) (*configApp.ListTemplatesResponse, error)
+}
+
+// ShowTemplateUseCase defines the interface for looking up a configuration template
+type ShowTemplateUseCase interface {
+	Execute(ctx context.Context, ref string) (*configApp.TemplateDTO, error)
+}
+
+// DeleteTemplateUseCase defines the interface for removing a configuration template
+type DeleteTemplateUseCase interface {
+	Execute(ctx context.Context, ref string) error
+}
+
+// TagTemplateUseCase defines the interface for changing the tags of a template
+type TagTemplateUseCase interface {
+	Execute(ctx context.Context, req configApp.TagTemplateRequest) (*configApp.TemplateDTO, error)
+}
+
+// TemplateHandler handles HTTP requests for saved configuration templates
+type TemplateHandler struct {
+	createUseCase CreateTemplateUseCase
+	listUseCase   ListTemplatesUseCase
+	searchUseCase SearchTemplatesUseCase
+	showUseCase   ShowTemplateUseCase
+	deleteUseCase DeleteTemplateUseCase
+	tagUseCase    TagTemplateUseCase
+}
+
+// NewTemplateHandler creates a new template handler
+func NewTemplateHandler(
+	createUseCase CreateTemplateUseCase,
+	listUseCase ListTemplatesUseCase,
+	searchUseCase SearchTemplatesUseCase,
+	showUseCase ShowTemplateUseCase,
+	deleteUseCase DeleteTemplateUseCase,
+	tagUseCase TagTemplateUseCase,
+) *TemplateHandler {
+	return &TemplateHandler{
+		createUseCase: createUseCase,
+		listUseCase:   listUseCase,
+		searchUseCase: searchUseCase,
+		showUseCase:   showUseCase,
+		deleteUseCase: deleteUseCase,
+		tagUseCase:    tagUseCase,
+	}
+}
+
+// TagsRequest is the body of PATCH /api/templates/{name}/tags
+type TagsRequest struct {
+	Add    []string
+	Remove []string
+}
+
+// CreateTemplate handles POST /api/templates
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var request configApp.CreateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	response, err := h.createUseCase.Execute(r.Context(), request)
+	if err != nil {
+		respondWithError(w, statusForTemplateError(err), "Failed to create template", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, response)
+}
+
+// ListTemplates handles GET /api/templates, filtered by the optional
+// category and tag query parameters
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	request := configApp.ListTemplatesRequest{
+		Category: r.URL.Query().Get("category"),
+		Tag:      r.URL.Query().Get("tag"),
+	}
+
+	response, err := h.listUseCase.Execute(r.Context(), request)
+	if err != nil {
+		respondWithError(w, statusForTemplateError(err), "Failed to list templates", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// SearchTemplates handles GET /api/templates/search. The q parameter matches
+// a name substring, category a category and each tag parameter a required tag.
+func (h *TemplateHandler) SearchTemplates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := configApp.SearchTemplatesRequest{
+		Query:    query.Get("q"),
+		Category: query.Get("category"),
+		Tags:     query["tag"],
+	}
+
+	response, err := h.searchUseCase.Execute(r.Context(), request)
+	if err != nil {
+		respondWithError(w, statusForTemplateError(err), "Failed to search templates", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetTemplate handles GET /api/templates/{name}
+func (h *TemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	response, err := h.showUseCase.Execute(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		respondWithError(w, statusForTemplateError(err), "Failed to get template", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// DeleteTemplate handles DELETE /api/templates/{name}
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := h.deleteUseCase.Execute(r.Context(), chi.URLParam(r, "name")); err != nil {
+		respondWithError(w, statusForTemplateError(err), "Failed to delete template", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TagTemplate handles PATCH /api/templates/{name}/tags
+func (h *TemplateHandler) TagTemplate(w http.ResponseWriter, r *http.Request) {
+	var body TagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	response, err := h.tagUseCase.Execute(r.Context(), configApp.TagTemplateRequest{
+		Template: chi.URLParam(r, "name"),
+		Add:      body.Add,
+		Remove:   body.Remove,
+	})
+	if err != nil {
+		respondWithError(w, statusForTemplateError(err), "Failed to tag template", err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// statusForTemplateError maps configuration domain errors to HTTP status
+// codes; anything else is treated as a server error
+func statusForTemplateError(err error) int {
+	switch {
+	case errors.Is(err, configuration.ErrTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, configuration.ErrDuplicateTemplate):
+		return http.StatusConflict
+	case errors.Is(err, configuration.ErrInvalidConfigurationName),
+		errors.Is(err, configuration.ErrConfigurationNameTooLong),
+		errors.Is(err, configuration.ErrDescriptionTooLong),
+		errors.Is(err, configuration.ErrTooManyTags),
+		errors.Is(err, configuration.ErrInvalidTag),
+		errors.Is(err, configuration.ErrInvalidCategory),
+		errors.Is(err, configuration.ErrInvalidManifest),
+		errors.Is(err, configuration.ErrNoComponents),
+		errors.Is(err, configuration.ErrMissingCoreComponent):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
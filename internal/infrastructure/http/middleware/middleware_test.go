@@ -1,12 +1,16 @@
 package middleware_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/rebelopsio/gohan/internal/infrastructure/http/middleware"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLogger(t *testing.T) {
@@ -71,3 +75,64 @@ func TestRecovery(t *testing.T) {
 		assert.Equal(t, "OK", rec.Body.String())
 	})
 }
+
+func TestAuth(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	tokenAuth, err := middleware.NewTokenAuthenticator("s3cret")
+	require.NoError(t, err)
+
+	serve := func(auth *middleware.Auth, req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		auth.Require(ok).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("accepts the API token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+
+		assert.Equal(t, http.StatusOK, serve(middleware.NewAuth(tokenAuth), req).Code)
+	})
+
+	t.Run("rejects missing or wrong tokens", func(t *testing.T) {
+		rec := serve(middleware.NewAuth(tokenAuth), httptest.NewRequest(http.MethodGet, "/test", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Bearer")
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer guess")
+		assert.Equal(t, http.StatusUnauthorized, serve(middleware.NewAuth(tokenAuth), req).Code)
+	})
+
+	t.Run("accepts allowed client certificates", func(t *testing.T) {
+		certAuth := middleware.NewAuth(middleware.NewClientCertAuthenticator([]string{"deploy-bot"}))
+		withClient := func(name string) *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{
+				{Subject: pkix.Name{CommonName: name}},
+			}}}
+			return req
+		}
+
+		assert.Equal(t, http.StatusOK, serve(certAuth, withClient("deploy-bot")).Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(certAuth, withClient("intruder")).Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(certAuth, httptest.NewRequest(http.MethodGet, "/test", nil)).Code)
+	})
+
+	t.Run("accepts either credential when both are configured", func(t *testing.T) {
+		both := middleware.NewAuth(tokenAuth, middleware.NewClientCertAuthenticator(nil))
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+
+		assert.Equal(t, http.StatusOK, serve(both, req).Code)
+	})
+
+	t.Run("lets everything through without authenticators", func(t *testing.T) {
+		auth := middleware.NewAuth()
+
+		assert.False(t, auth.Enabled())
+		assert.Equal(t, http.StatusOK, serve(auth, httptest.NewRequest(http.MethodGet, "/test", nil)).Code)
+	})
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net/http"
@@ -18,6 +19,11 @@ import (
 type Server struct {
 	router *chi.Mux
 	server *http.Server
+	auth   *middleware.Auth // Nil leaves the API open
+
+	// Serves HTTPS when set
+	tlsCertFile string
+	tlsKeyFile  string
 }
 
 // Config holds server configuration
@@ -35,6 +41,7 @@ func NewServer(
 	enableTracing bool,
 ) *Server {
 	r := chi.NewRouter()
+	s := &Server{router: r}
 
 	// Apply middleware
 	r.Use(chimiddleware.RequestID)
@@ -66,31 +73,139 @@ func NewServer(
 	r.Route("/api", func(r chi.Router) {
 		// Installation routes
 		r.Route("/installation", func(r chi.Router) {
+			r.Use(s.requireAuth)
 			r.Get("/", installationHandler.ListInstallations)
 			r.Post("/start", installationHandler.StartInstallation)
+			r.Get("/{sessionID}", installationHandler.GetInstallation)
 			r.Post("/{sessionID}/execute", installationHandler.ExecuteInstallation)
 			r.Get("/{sessionID}/status", installationHandler.GetStatus)
 			r.Post("/{sessionID}/cancel", installationHandler.CancelInstallation)
 		})
 	})
 
+	// Installation progress streams
+	r.Route("/api/v1/installations/{sessionID}", func(r chi.Router) {
+		r.Use(s.requireAuth)
+		r.Get("/progress/ws", installationHandler.StreamProgress)
+		r.Get("/events", installationHandler.StreamEvents)
+	})
+
 	// Create HTTP server
 	addr := fmt.Sprintf("%s:%d", config.Host, config.Port)
-	srv := &http.Server{
+	s.server = &http.Server{
 		Addr:         addr,
 		Handler:      r,
 		ReadTimeout:  config.ReadTimeout,
 		WriteTimeout: config.WriteTimeout,
 	}
+	return s
+}
 
-	return &Server{
-		router: r,
-		server: srv,
-	}
+// WithAuth requires requests to the protected routes to pass auth. The
+// health check and the catalog stay open.
+func (s *Server) WithAuth(auth *middleware.Auth) *Server {
+	s.auth = auth
+	return s
+}
+
+// WithTLS serves HTTPS with the given certificate. tlsConfig verifies
+// client certificates for mutual TLS; nil takes the defaults.
+func (s *Server) WithTLS(tlsConfig *tls.Config, certFile, keyFile string) *Server {
+	s.server.TLSConfig = tlsConfig
+	s.tlsCertFile = certFile
+	s.tlsKeyFile = keyFile
+	return s
+}
+
+// requireAuth guards a protected route with the server's auth. It is
+// looked up per request, so WithAuth applies to routes mounted before it.
+func (s *Server) requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.auth.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		s.auth.Require(next).ServeHTTP(w, r)
+	})
+}
+
+// WithTemplateHandler serves the saved configuration templates under
+// /api/templates
+func (s *Server) WithTemplateHandler(templateHandler *handlers.TemplateHandler) *Server {
+	s.router.Route("/api/templates", func(r chi.Router) {
+		r.Use(s.requireAuth)
+		r.Get("/", templateHandler.ListTemplates)
+		r.Post("/", templateHandler.CreateTemplate)
+		r.Get("/search", templateHandler.SearchTemplates)
+		r.Get("/{name}", templateHandler.GetTemplate)
+		r.Delete("/{name}", templateHandler.DeleteTemplate)
+		r.Patch("/{name}/tags", templateHandler.TagTemplate)
+	})
+	return s
+}
+
+// WithConfigurationHandler serves configuration deployment under
+// /api/configurations
+func (s *Server) WithConfigurationHandler(configurationHandler *handlers.ConfigurationHandler) *Server {
+	s.router.Route("/api/configurations", func(r chi.Router) {
+		r.Use(s.requireAuth)
+		r.Post("/deploy", configurationHandler.DeployConfigurations)
+	})
+	return s
+}
+
+// WithBackupHandler serves configuration backups under /api/backups
+func (s *Server) WithBackupHandler(backupHandler *handlers.BackupHandler) *Server {
+	s.router.Route("/api/backups", func(r chi.Router) {
+		r.Use(s.requireAuth)
+		r.Get("/", backupHandler.ListBackups)
+		r.Post("/", backupHandler.CreateBackup)
+		r.Get("/{backupID}", backupHandler.GetBackup)
+		r.Post("/{backupID}/restore", backupHandler.RestoreBackup)
+	})
+	return s
+}
+
+// WithConfigsAPI serves configuration deployment and its rollback under
+// /api/v1/configs, for clients driving deployments remotely
+func (s *Server) WithConfigsAPI(configurationHandler *handlers.ConfigurationHandler, backupHandler *handlers.BackupHandler) *Server {
+	s.router.Route("/api/v1/configs", func(r chi.Router) {
+		r.Use(s.requireAuth)
+		r.Post("/deploy", configurationHandler.DeployConfigurations)
+		r.Get("/backups", backupHandler.ListBackups)
+		r.Post("/rollback", backupHandler.Rollback)
+	})
+	return s
+}
+
+// WithPreflightHandler serves preflight checks of the server host under
+// /api/v1/preflight
+func (s *Server) WithPreflightHandler(preflightHandler *handlers.PreflightHandler) *Server {
+	s.router.With(s.requireAuth).Post("/api/v1/preflight", preflightHandler.RunPreflight)
+	return s
+}
+
+// WithCatalogHandler serves the components, packages and profiles gohan
+// can install under /api/v1/catalog. The catalog only describes what gohan
+// ships, so it needs no authentication.
+func (s *Server) WithCatalogHandler(catalogHandler *handlers.CatalogHandler) *Server {
+	s.router.Route("/api/v1/catalog", func(r chi.Router) {
+		r.Get("/components", catalogHandler.ListComponents)
+		r.Get("/packages", catalogHandler.ListPackages)
+		r.Get("/profiles", catalogHandler.ListProfiles)
+	})
+	return s
 }
 
 // Start starts the HTTP server
 func (s *Server) Start() error {
+	if !s.auth.Enabled() {
+		log.Println("Warning: API authentication is disabled; set api.auth in the config to require a token or client certificate")
+	}
+	if s.tlsCertFile != "" {
+		log.Printf("Starting HTTPS server on %s", s.server.Addr)
+		return s.server.ListenAndServeTLS(s.tlsCertFile, s.tlsKeyFile)
+	}
 	log.Printf("Starting HTTP server on %s", s.server.Addr)
 	return s.server.ListenAndServe()
 }
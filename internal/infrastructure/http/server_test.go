@@ -6,16 +6,34 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	backupApp "github.com/rebelopsio/gohan/internal/application/backup"
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
 	"github.com/rebelopsio/gohan/internal/application/installation/dto"
 	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	configRepository "github.com/rebelopsio/gohan/internal/infrastructure/configuration/repository"
 	httpinfra "github.com/rebelopsio/gohan/internal/infrastructure/http"
 	"github.com/rebelopsio/gohan/internal/infrastructure/http/handlers"
+	"github.com/rebelopsio/gohan/internal/infrastructure/http/middleware"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/profiles"
+	installationRepository "github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/rebelopsio/gohan/internal/testing/harness"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
 )
 
 // MockStartInstallationUseCase is a mock for testing
@@ -36,8 +54,8 @@ type MockExecuteInstallationUseCase struct {
 	mock.Mock
 }
 
-func (m *MockExecuteInstallationUseCase) Execute(ctx context.Context, sessionID string, progressCallback usecases.ProgressCallback) (*dto.InstallationProgressResponse, error) {
-	args := m.Called(ctx, sessionID, progressCallback)
+func (m *MockExecuteInstallationUseCase) Execute(ctx context.Context, sessionID string) (*dto.InstallationProgressResponse, error) {
+	args := m.Called(ctx, sessionID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -75,9 +93,12 @@ type MockCancelInstallationUseCase struct {
 	mock.Mock
 }
 
-func (m *MockCancelInstallationUseCase) Execute(ctx context.Context, sessionID string) error {
-	args := m.Called(ctx, sessionID)
-	return args.Error(0)
+func (m *MockCancelInstallationUseCase) Execute(ctx context.Context, sessionID string, request dto.CancelInstallationRequest) (*dto.InstallationProgressResponse, error) {
+	args := m.Called(ctx, sessionID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.InstallationProgressResponse), args.Error(1)
 }
 
 func TestServer_Routes(t *testing.T) {
@@ -163,7 +184,7 @@ func TestServer_Routes(t *testing.T) {
 			ComponentsTotal:     1,
 		}
 
-		mockExecuteUseCase.On("Execute", mock.Anything, sessionID, mock.Anything).
+		mockExecuteUseCase.On("Execute", mock.Anything, sessionID).
 			Return(expectedResponse, nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/api/installation/"+sessionID+"/execute", nil)
@@ -203,3 +224,665 @@ func TestServer_Routes(t *testing.T) {
 		assert.Contains(t, rec.Header().Get("Access-Control-Allow-Methods"), "POST")
 	})
 }
+
+func TestServer_TemplateRoutes(t *testing.T) {
+	repo := configRepository.NewMemoryRepository()
+	templateHandler := handlers.NewTemplateHandler(
+		configApp.NewCreateTemplateUseCase(repo),
+		configApp.NewListTemplatesUseCase(repo),
+		configApp.NewSearchTemplatesUseCase(repo),
+		configApp.NewShowTemplateUseCase(repo),
+		configApp.NewDeleteTemplateUseCase(repo),
+		configApp.NewTagTemplateUseCase(repo),
+	)
+	installationHandler := handlers.NewInstallationHandler(nil, nil, nil, nil, nil)
+	router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).WithTemplateHandler(templateHandler).Router()
+
+	serve := func(method, target string, body interface{}) *httptest.ResponseRecorder {
+		var reader *bytes.Reader
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		} else {
+			reader = bytes.NewReader(nil)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, target, reader))
+		return rec
+	}
+
+	create := configApp.CreateTemplateRequest{
+		Name:       "laptop",
+		Category:   "development",
+		Tags:       []string{"minimal"},
+		Components: []configApp.TemplateComponent{{Name: "hyprland"}, {Name: "waybar"}},
+	}
+
+	rec := serve(http.MethodPost, "/api/templates", create)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	rec = serve(http.MethodPost, "/api/templates", create)
+	assert.Equal(t, http.StatusConflict, rec.Code, "names are unique")
+
+	rec = serve(http.MethodPost, "/api/templates", configApp.CreateTemplateRequest{Name: "empty"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(http.MethodGet, "/api/templates?tag=minimal", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list configApp.ListTemplatesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Templates, 1)
+	assert.Equal(t, "laptop", list.Templates[0].Name)
+
+	rec = serve(http.MethodGet, "/api/templates/search?q=LAP&category=development&tag=minimal", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Templates, 1)
+
+	rec = serve(http.MethodGet, "/api/templates/search?tag=minimal&tag=missing", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Empty(t, list.Templates)
+
+	rec = serve(http.MethodPatch, "/api/templates/laptop/tags", handlers.TagsRequest{Add: []string{"battery"}})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var tagged configApp.TemplateDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tagged))
+	assert.Equal(t, []string{"minimal", "battery"}, tagged.Tags)
+
+	rec = serve(http.MethodGet, "/api/templates/laptop", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(http.MethodDelete, "/api/templates/laptop", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = serve(http.MethodGet, "/api/templates/laptop", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Installation routes are unaffected
+	rec = serve(http.MethodGet, "/health", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_InstallationDetailRoute(t *testing.T) {
+	sessionRepo := installationRepository.NewMemorySessionRepository()
+	pkg, err := installation.NewPackageInfo("hyprland", "0.35.0", 50*uint64(installation.MB), nil)
+	require.NoError(t, err)
+	component, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", &pkg)
+	require.NoError(t, err)
+	diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+	require.NoError(t, err)
+	config, err := installation.NewInstallationConfiguration([]installation.ComponentSelection{component}, nil, diskSpace, false)
+	require.NoError(t, err)
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+	require.NoError(t, sessionRepo.Save(context.Background(), session))
+
+	installationHandler := handlers.NewInstallationHandler(nil, nil, nil, nil, nil).
+		WithDetailUseCase(usecases.NewGetInstallationDetailUseCase(sessionRepo, nil))
+	router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).Router()
+
+	t.Run("returns the session's details", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/installation/"+session.ID(), nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response dto.InstallationDetailResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, session.ID(), response.SessionID)
+		assert.Equal(t, "pending", response.Status)
+		require.Len(t, response.Configuration.Components, 1)
+		assert.Equal(t, "hyprland", response.Configuration.Components[0].Name)
+		assert.Equal(t, 50*uint64(installation.MB), response.Configuration.Components[0].SizeBytes)
+	})
+
+	t.Run("returns 404 for an unknown session", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/installation/missing", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("returns 501 without a detail use case", func(t *testing.T) {
+		router := httpinfra.NewServer(httpinfra.Config{}, handlers.NewInstallationHandler(nil, nil, nil, nil, nil), false).Router()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/installation/"+session.ID(), nil))
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+}
+
+// fakeProgressStreams hands out a channel the test feeds, and resumes with
+// the missed events it is given
+type fakeProgressStreams struct {
+	events       chan dto.ProgressEventDTO
+	missed       []dto.ProgressEventDTO
+	resumed      bool
+	lastSequence uint64
+}
+
+func (f *fakeProgressStreams) Subscribe(sessionID string) (<-chan dto.ProgressEventDTO, func()) {
+	return f.events, func() {}
+}
+
+func (f *fakeProgressStreams) Resume(sessionID string, lastSequence uint64) ([]dto.ProgressEventDTO, bool, <-chan dto.ProgressEventDTO, func()) {
+	f.lastSequence = lastSequence
+	return f.missed, f.resumed, f.events, func() {}
+}
+
+func TestServer_ProgressStreamRoute(t *testing.T) {
+	sessionRepo := installationRepository.NewMemorySessionRepository()
+	pkg, err := installation.NewPackageInfo("hyprland", "0.35.0", 50*uint64(installation.MB), nil)
+	require.NoError(t, err)
+	component, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", &pkg)
+	require.NoError(t, err)
+	diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+	require.NoError(t, err)
+	config, err := installation.NewInstallationConfiguration([]installation.ComponentSelection{component}, nil, diskSpace, false)
+	require.NoError(t, err)
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+	require.NoError(t, sessionRepo.Save(context.Background(), session))
+
+	streams := &fakeProgressStreams{events: make(chan dto.ProgressEventDTO, 2)}
+	installationHandler := handlers.NewInstallationHandler(nil, nil, usecases.NewGetInstallationStatusUseCase(sessionRepo), nil, nil).
+		WithProgressStreams(streams)
+	server := httptest.NewServer(httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).Router())
+	defer server.Close()
+
+	t.Run("streams the current progress then the updates until the final one", func(t *testing.T) {
+		wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/installations/" + session.ID() + "/progress/ws"
+		ws, err := websocket.Dial(wsURL, "", server.URL)
+		require.NoError(t, err)
+		defer ws.Close()
+		require.NoError(t, ws.SetDeadline(time.Now().Add(5*time.Second)))
+
+		var current dto.ProgressEventDTO
+		require.NoError(t, websocket.JSON.Receive(ws, &current))
+		assert.Equal(t, session.ID(), current.SessionID)
+		assert.Equal(t, "pending", current.Status)
+		assert.False(t, current.Final)
+
+		streams.events <- dto.ProgressEventDTO{SessionID: session.ID(), Status: "installing", PercentComplete: 40, Message: "Installed hyprland"}
+		streams.events <- dto.ProgressEventDTO{SessionID: session.ID(), Status: "completed", PercentComplete: 100, Final: true}
+
+		var update dto.ProgressEventDTO
+		require.NoError(t, websocket.JSON.Receive(ws, &update))
+		assert.Equal(t, 40, update.PercentComplete)
+		assert.Equal(t, "Installed hyprland", update.Message)
+
+		var final dto.ProgressEventDTO
+		require.NoError(t, websocket.JSON.Receive(ws, &final))
+		assert.True(t, final.Final)
+		assert.Equal(t, "completed", final.Status)
+
+		// The server closes the stream after the final update
+		assert.Error(t, websocket.JSON.Receive(ws, &final))
+	})
+
+	t.Run("returns 404 for an unknown session", func(t *testing.T) {
+		response, err := http.Get(server.URL + "/api/v1/installations/missing/progress/ws")
+		require.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("returns 501 without progress streams", func(t *testing.T) {
+		router := httpinfra.NewServer(httpinfra.Config{}, handlers.NewInstallationHandler(nil, nil, nil, nil, nil), false).Router()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/installations/"+session.ID()+"/progress/ws", nil))
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+}
+
+func TestServer_ProgressEventsRoute(t *testing.T) {
+	sessionRepo := installationRepository.NewMemorySessionRepository()
+	pkg, err := installation.NewPackageInfo("hyprland", "0.35.0", 50*uint64(installation.MB), nil)
+	require.NoError(t, err)
+	component, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.35.0", &pkg)
+	require.NoError(t, err)
+	diskSpace, err := installation.NewDiskSpace(100*uint64(installation.GB), 10*uint64(installation.GB))
+	require.NoError(t, err)
+	config, err := installation.NewInstallationConfiguration([]installation.ComponentSelection{component}, nil, diskSpace, false)
+	require.NoError(t, err)
+	session, err := installation.NewInstallationSession(config)
+	require.NoError(t, err)
+	require.NoError(t, sessionRepo.Save(context.Background(), session))
+
+	serve := func(streams *fakeProgressStreams, lastEventID string) *httptest.ResponseRecorder {
+		installationHandler := handlers.NewInstallationHandler(nil, nil, usecases.NewGetInstallationStatusUseCase(sessionRepo), nil, nil).
+			WithProgressStreams(streams)
+		router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).Router()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/installations/"+session.ID()+"/events", nil)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("streams the current progress then the updates until the final one", func(t *testing.T) {
+		streams := &fakeProgressStreams{events: make(chan dto.ProgressEventDTO, 2)}
+		streams.events <- dto.ProgressEventDTO{SessionID: session.ID(), Status: "installing", PercentComplete: 40, Sequence: 1}
+		streams.events <- dto.ProgressEventDTO{SessionID: session.ID(), Status: "completed", PercentComplete: 100, Sequence: 2, Final: true}
+
+		rec := serve(streams, "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		body := rec.Body.String()
+		assert.True(t, strings.HasPrefix(body, "retry: 3000\n\nevent: progress\ndata: {"))
+		assert.Contains(t, body, `"Status":"pending"`)
+		assert.Contains(t, body, "id: 1\nevent: progress\n")
+		assert.Contains(t, body, "id: 2\nevent: progress\n")
+		assert.True(t, strings.HasSuffix(body, "\"Final\":true}\n\n"))
+	})
+
+	t.Run("resumes with the missed updates after Last-Event-ID", func(t *testing.T) {
+		streams := &fakeProgressStreams{
+			events:  make(chan dto.ProgressEventDTO),
+			resumed: true,
+			missed: []dto.ProgressEventDTO{
+				{SessionID: session.ID(), Status: "installing", Sequence: 5},
+				{SessionID: session.ID(), Status: "completed", Sequence: 6, Final: true},
+			},
+		}
+
+		rec := serve(streams, "4")
+
+		assert.Equal(t, uint64(4), streams.lastSequence)
+		body := rec.Body.String()
+		assert.NotContains(t, body, `"Status":"pending"`)
+		assert.Contains(t, body, "id: 5\n")
+		assert.Contains(t, body, "id: 6\n")
+	})
+
+	t.Run("stops reconnections once the client has the final update", func(t *testing.T) {
+		finished, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		require.NoError(t, finished.Fail("apt-get exited with status 100"))
+		require.NoError(t, sessionRepo.Save(context.Background(), finished))
+
+		installationHandler := handlers.NewInstallationHandler(nil, nil, usecases.NewGetInstallationStatusUseCase(sessionRepo), nil, nil).
+			WithProgressStreams(&fakeProgressStreams{events: make(chan dto.ProgressEventDTO), resumed: true})
+		router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).Router()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/installations/"+finished.ID()+"/events", nil)
+		req.Header.Set("Last-Event-ID", "9")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("sends a forgotten session's final state with event ID 0", func(t *testing.T) {
+		finished, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		require.NoError(t, finished.Fail("apt-get exited with status 100"))
+		require.NoError(t, sessionRepo.Save(context.Background(), finished))
+
+		installationHandler := handlers.NewInstallationHandler(nil, nil, usecases.NewGetInstallationStatusUseCase(sessionRepo), nil, nil).
+			WithProgressStreams(&fakeProgressStreams{events: make(chan dto.ProgressEventDTO)})
+		router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).Router()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/installations/"+finished.ID()+"/events", nil)
+		req.Header.Set("Last-Event-ID", "9")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "id: 0\nevent: progress\n")
+		assert.Contains(t, rec.Body.String(), `"Status":"failed"`)
+	})
+
+	t.Run("returns 404 for an unknown session", func(t *testing.T) {
+		installationHandler := handlers.NewInstallationHandler(nil, nil, usecases.NewGetInstallationStatusUseCase(sessionRepo), nil, nil).
+			WithProgressStreams(&fakeProgressStreams{events: make(chan dto.ProgressEventDTO)})
+		router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).Router()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/installations/missing/events", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestServer_ConfigurationRoutes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(home) // Templates are resolved relative to the working directory
+	template := filepath.Join(home, "templates", "kitty", "kitty.conf.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(template), 0755))
+	require.NoError(t, os.WriteFile(template, []byte("kb_layout {{keyboard_layout}}\n"), 0644))
+
+	templateEngine := templates.NewTemplateEngine()
+	deployer := configservice.NewConfigDeployer(templateEngine, backup.NewBackupService(filepath.Join(home, "backups")))
+	configurationHandler := handlers.NewConfigurationHandler(configApp.NewConfigDeployUseCase(deployer, templateEngine)).
+		WithDefaults(func(ctx context.Context) (configApp.DeployConfigRequest, error) {
+			return configApp.DeployConfigRequest{CustomVars: map[string]string{"keyboard_layout": "de"}}, nil
+		})
+	installationHandler := handlers.NewInstallationHandler(nil, nil, nil, nil, nil)
+	router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).
+		WithConfigurationHandler(configurationHandler).Router()
+
+	deploy := func(body handlers.DeployRequest) (*httptest.ResponseRecorder, configApp.DeployConfigResponse) {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/configurations/deploy", bytes.NewReader(data)))
+
+		var response configApp.DeployConfigResponse
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		}
+		return rec, response
+	}
+	target := filepath.Join(home, ".config", "kitty", "kitty.conf")
+
+	rec, preview := deploy(handlers.DeployRequest{Components: []string{"kitty"}, DryRun: true})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Len(t, preview.DeployedFiles, 1)
+	assert.Equal(t, "created", preview.DeployedFiles[0].Action)
+	assert.NoFileExists(t, target, "dry runs write nothing")
+
+	rec, first := deploy(handlers.DeployRequest{Components: []string{"kitty"}})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, 1, first.SuccessfulFiles)
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, configuration.WrapManagedBlock(target, "kb_layout de\n"), string(content), "renders with the default variables")
+
+	rec, second := deploy(handlers.DeployRequest{Components: []string{"kitty"}})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, 1, second.UnchangedFiles, "repeating a deployment changes nothing")
+	assert.Zero(t, second.SuccessfulFiles)
+
+	rec, _ = deploy(handlers.DeployRequest{Components: []string{"unknown"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = deploy(handlers.DeployRequest{CustomVars: map[string]string{"home": "/root"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "the target directory cannot be chosen over the API")
+}
+
+func TestServer_BackupRoutes(t *testing.T) {
+	home := t.TempDir()
+	backupRoot := filepath.Join(home, ".config", "gohan", "backups")
+	hyprConf := filepath.Join(home, ".config", "hypr", "hyprland.conf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(hyprConf), 0755))
+	require.NoError(t, os.WriteFile(hyprConf, []byte("general {\n}\n"), 0644))
+
+	repo := backup.NewRepositoryAdapter(backupRoot)
+	backupHandler := handlers.NewBackupHandler(
+		backupApp.NewListBackupsUseCase(repo),
+		backupApp.NewGetBackupUseCase(repo),
+		backupApp.NewCreateBackupUseCase(repo),
+		backupApp.NewRestoreBackupUseCase(repo),
+		backupRoot,
+		home,
+	)
+	installationHandler := handlers.NewInstallationHandler(nil, nil, nil, nil, nil)
+	router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).
+		WithBackupHandler(backupHandler).Router()
+
+	request := func(method, path string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			var err error
+			data, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader(data)))
+		return rec
+	}
+
+	// Create a backup of the default directories
+	rec := request(http.MethodPost, "/api/backups", handlers.CreateBackupRequest{Description: "before deploy"})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created backupApp.CreateBackupResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, 1, created.FileCount)
+
+	rec = request(http.MethodPost, "/api/backups", handlers.CreateBackupRequest{Paths: []string{"/etc/shadow"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "only paths under the home directory are backed up")
+
+	// List and inspect it
+	rec = request(http.MethodGet, "/api/backups?limit=10", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var list backupApp.ListBackupsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Backups, 1)
+	assert.Equal(t, created.BackupID, list.Backups[0].ID)
+
+	rec = request(http.MethodGet, "/api/backups/"+created.BackupID, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var detail backupApp.BackupDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &detail))
+	assert.Equal(t, "before deploy", detail.Description)
+	require.Len(t, detail.Files, 1)
+	assert.Equal(t, hyprConf, detail.Files[0].Path)
+
+	assert.Equal(t, http.StatusNotFound, request(http.MethodGet, "/api/backups/2000-01-01_000000", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodGet, "/api/backups/..", nil).Code)
+
+	// A bad deployment overwrites the file
+	require.NoError(t, os.WriteFile(hyprConf, []byte("broken {\n"), 0644))
+	restorePath := "/api/backups/" + created.BackupID + "/restore"
+
+	// Restoring without a token only asks for confirmation
+	rec = request(http.MethodPost, restorePath, handlers.RestoreRequest{})
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var confirmation handlers.RestoreConfirmation
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &confirmation))
+	assert.Equal(t, []string{hyprConf}, confirmation.Files)
+	assert.NotEmpty(t, confirmation.ConfirmationToken)
+	content, err := os.ReadFile(hyprConf)
+	require.NoError(t, err)
+	assert.Equal(t, "broken {\n", string(content))
+
+	rec = request(http.MethodPost, restorePath, handlers.RestoreRequest{
+		Selective:         []string{hyprConf},
+		ConfirmationToken: confirmation.ConfirmationToken,
+	})
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code, "a token only confirms the restore it was issued for")
+
+	// The failed attempt used the token up
+	rec = request(http.MethodPost, restorePath, handlers.RestoreRequest{ConfirmationToken: confirmation.ConfirmationToken})
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	rec = request(http.MethodPost, restorePath, handlers.RestoreRequest{})
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &confirmation))
+	rec = request(http.MethodPost, restorePath, handlers.RestoreRequest{ConfirmationToken: confirmation.ConfirmationToken})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var restored backupApp.RestoreBackupResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &restored))
+	assert.Equal(t, 1, restored.FilesRestored)
+
+	content, err = os.ReadFile(hyprConf)
+	require.NoError(t, err)
+	assert.Equal(t, "general {\n}\n", string(content))
+}
+
+func TestServer_ConfigsAPIRoutes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(home) // Templates are resolved relative to the working directory
+	template := filepath.Join(home, "templates", "kitty", "kitty.conf.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(template), 0755))
+	require.NoError(t, os.WriteFile(template, []byte("font_size {{font_size}}\n"), 0644))
+	target := filepath.Join(home, ".config", "kitty", "kitty.conf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+	require.NoError(t, os.WriteFile(target, []byte("font_size 11\n"), 0644))
+
+	backupRoot := filepath.Join(home, "backups")
+	templateEngine := templates.NewTemplateEngine()
+	deployer := configservice.NewConfigDeployer(templateEngine, backup.NewBackupService(backupRoot))
+	configurationHandler := handlers.NewConfigurationHandler(configApp.NewConfigDeployUseCase(deployer, templateEngine))
+	repo := backup.NewRepositoryAdapter(backupRoot)
+	backupHandler := handlers.NewBackupHandler(
+		backupApp.NewListBackupsUseCase(repo),
+		backupApp.NewGetBackupUseCase(repo),
+		backupApp.NewCreateBackupUseCase(repo),
+		backupApp.NewRestoreBackupUseCase(repo),
+		backupRoot,
+		home,
+	)
+	installationHandler := handlers.NewInstallationHandler(nil, nil, nil, nil, nil)
+	router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).
+		WithConfigsAPI(configurationHandler, backupHandler).Router()
+
+	request := func(method, path string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			var err error
+			data, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader(data)))
+		return rec
+	}
+
+	// Deploy over the existing file, which is backed up first
+	rec := request(http.MethodPost, "/api/v1/configs/deploy", handlers.DeployRequest{
+		Components: []string{"kitty"},
+		CustomVars: map[string]string{"font_size": "14"},
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var deployed configApp.DeployConfigResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &deployed))
+	require.Len(t, deployed.DeployedFiles, 1)
+	backupID := deployed.DeployedFiles[0].BackupID
+	require.NotEmpty(t, backupID)
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "font_size 14")
+
+	rec = request(http.MethodGet, "/api/v1/configs/backups", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var list backupApp.ListBackupsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Backups, 1)
+	assert.Equal(t, backupID, list.Backups[0].ID)
+
+	// Rolling back is confirmed with a token
+	rec = request(http.MethodPost, "/api/v1/configs/rollback", handlers.RollbackRequest{})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "the backup must be named")
+	rec = request(http.MethodPost, "/api/v1/configs/rollback", handlers.RollbackRequest{BackupID: "2000-01-01_000000"})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = request(http.MethodPost, "/api/v1/configs/rollback", handlers.RollbackRequest{BackupID: backupID})
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var confirmation handlers.RestoreConfirmation
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &confirmation))
+	assert.Equal(t, []string{target}, confirmation.Files)
+
+	rec = request(http.MethodPost, "/api/v1/configs/rollback", handlers.RollbackRequest{
+		BackupID:          backupID,
+		ConfirmationToken: confirmation.ConfirmationToken,
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	content, err = os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "font_size 11\n", string(content))
+}
+
+func TestServer_PreflightRoute(t *testing.T) {
+	bookworm, err := preflight.NewDebianVersion("bookworm", "12")
+	require.NoError(t, err)
+	system := harness.NewSystem()
+	system.Debian = bookworm   // Unsupported, so installing is blocked
+	system.SourceRepos = false // Only warns
+	useCase := preflightApp.NewRunPreflightUseCase(preflightApp.Detectors{
+		DebianDetector:          system,
+		GPUDetector:             system,
+		DiskSpaceDetector:       system,
+		ConnectivityChecker:     system,
+		SourceRepositoryChecker: system,
+	})
+	installationHandler := handlers.NewInstallationHandler(nil, nil, nil, nil, nil)
+	router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).
+		WithPreflightHandler(handlers.NewPreflightHandler(useCase, t.TempDir())).Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/preflight", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var report handlers.PreflightReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.False(t, report.Passed)
+	assert.True(t, report.HasBlockers)
+	assert.Equal(t, 5, report.TotalChecks)
+	assert.Len(t, report.Results, 5)
+	require.Len(t, report.Blockers, 1)
+	assert.Equal(t, "debian_version", report.Blockers[0].Name)
+	require.Len(t, report.Warnings, 1)
+	assert.Equal(t, "source_repositories", report.Warnings[0].Name)
+	assert.NotEmpty(t, report.Warnings[0].Guidance.Steps)
+}
+
+func TestServer_CatalogRoutes(t *testing.T) {
+	useCase := usecases.NewGetCatalogUseCase(profiles.NewLoader(t.TempDir()))
+	installationHandler := handlers.NewInstallationHandler(nil, nil, nil, nil, nil)
+	router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).
+		WithCatalogHandler(handlers.NewCatalogHandler(useCase)).Router()
+
+	t.Run("lists packages available in a suite", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/catalog/packages?component=hyprland&suite=sid", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var packages []dto.CatalogPackage
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &packages))
+		require.NotEmpty(t, packages)
+		assert.Equal(t, "hyprland", packages[0].Name)
+		assert.False(t, packages[0].Availability["trixie"])
+	})
+
+	t.Run("rejects unknown suites", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/catalog/packages?suite=bookworm", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("lists components and profiles", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/catalog/components", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var components []dto.CatalogComponent
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &components))
+		assert.Equal(t, "hyprland", components[0].Name)
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/catalog/profiles", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var profiles []dto.CatalogProfile
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &profiles))
+		require.Len(t, profiles, 4)
+		assert.Equal(t, "minimal", profiles[0].ID)
+	})
+}
+
+func TestServer_Auth(t *testing.T) {
+	tokenAuth, err := middleware.NewTokenAuthenticator("s3cret")
+	require.NoError(t, err)
+	installationRepo := installationRepository.NewMemorySessionRepository()
+	installationHandler := handlers.NewInstallationHandler(nil, nil, nil, usecases.NewListInstallationsUseCase(installationRepo), nil)
+	catalog := usecases.NewGetCatalogUseCase(profiles.NewLoader(t.TempDir()))
+	router := httpinfra.NewServer(httpinfra.Config{}, installationHandler, false).
+		WithCatalogHandler(handlers.NewCatalogHandler(catalog)).
+		WithAuth(middleware.NewAuth(tokenAuth)).Router()
+
+	serve := func(path, token string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/installation/", ""))
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/installation/", "guess"))
+	assert.Equal(t, http.StatusOK, serve("/api/installation/", "s3cret"))
+	assert.Equal(t, http.StatusOK, serve("/health", ""), "the health check stays open")
+	assert.Equal(t, http.StatusOK, serve("/api/v1/catalog/profiles", ""), "the catalog stays open")
+}
@@ -0,0 +1,183 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// indexFileName is the index kept in the backup root
+const indexFileName = "index.json"
+
+// IndexEntry is the summary of one backup kept in the index
+type IndexEntry struct {
+	Dir         string    `json:"dir"`         // Backup directory name under the root
+	ID          string    `json:"id"`          // Backup ID from the manifest
+	Description string    `json:"description"` // User-provided description
+	CreatedAt   time.Time `json:"created_at"`  // When backup was created
+	FileCount   int       `json:"file_count"`  // Files in this backup
+	SizeBytes   int64     `json:"size_bytes"`  // Total backup size
+}
+
+// backupIndex lists every backup's summary so listing does not have to
+// parse each manifest
+type backupIndex struct {
+	Entries []IndexEntry `json:"entries"`
+}
+
+// newIndexEntry summarizes a manifest stored in dir
+func newIndexEntry(dir string, manifest *BackupManifest) IndexEntry {
+	var totalSize int64
+	for _, file := range manifest.Files {
+		totalSize += file.SizeBytes
+	}
+
+	return IndexEntry{
+		Dir:         dir,
+		ID:          manifest.ID,
+		Description: manifest.Description,
+		CreatedAt:   manifest.CreatedAt,
+		FileCount:   len(manifest.Files),
+		SizeBytes:   totalSize,
+	}
+}
+
+// loadIndex reads the index, returning an empty one if it is missing or
+// unreadable; it is rebuilt from the manifests in that case
+func (s *BackupService) loadIndex() *backupIndex {
+	data, err := os.ReadFile(filepath.Join(s.backupRoot, indexFileName))
+	if err != nil {
+		return &backupIndex{}
+	}
+
+	var index backupIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return &backupIndex{}
+	}
+	return &index
+}
+
+// saveIndex writes the index through a temporary file so a crash never
+// leaves a truncated index behind
+func (s *BackupService) saveIndex(index *backupIndex) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup index: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.backupRoot, ".index-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write backup index: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write backup index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write backup index: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.backupRoot, indexFileName)); err != nil {
+		return fmt.Errorf("failed to write backup index: %w", err)
+	}
+	return nil
+}
+
+// indexBackup adds or replaces the index entry for a backup
+func (s *BackupService) indexBackup(dir string, manifest *BackupManifest) error {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	index := s.loadIndex()
+	entry := newIndexEntry(dir, manifest)
+	for i := range index.Entries {
+		if index.Entries[i].Dir == dir {
+			index.Entries[i] = entry
+			return s.saveIndex(index)
+		}
+	}
+	index.Entries = append(index.Entries, entry)
+	return s.saveIndex(index)
+}
+
+// unindexBackup drops the index entry for a removed backup
+func (s *BackupService) unindexBackup(dir string) error {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	index := s.loadIndex()
+	kept := index.Entries[:0]
+	for _, entry := range index.Entries {
+		if entry.Dir != dir {
+			kept = append(kept, entry)
+		}
+	}
+	if len(kept) == len(index.Entries) {
+		return nil
+	}
+	index.Entries = kept
+	return s.saveIndex(index)
+}
+
+// syncIndex reconciles the index with the backup directories: entries for
+// removed directories are dropped and only directories the index does not
+// know yet have their manifests read. Entries are returned newest first.
+func (s *BackupService) syncIndex(ctx context.Context) ([]IndexEntry, error) {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	dirs, err := os.ReadDir(s.backupRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	index := s.loadIndex()
+	known := make(map[string]IndexEntry, len(index.Entries))
+	for _, entry := range index.Entries {
+		known[entry.Dir] = entry
+	}
+
+	changed := false
+	entries := make([]IndexEntry, 0, len(dirs))
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !dir.IsDir() {
+			continue
+		}
+
+		if entry, ok := known[dir.Name()]; ok {
+			entries = append(entries, entry)
+			delete(known, dir.Name())
+			continue
+		}
+
+		manifest, err := LoadManifest(filepath.Join(s.backupRoot, dir.Name()))
+		if err != nil {
+			continue // Skip invalid backups
+		}
+		entries = append(entries, newIndexEntry(dir.Name(), manifest))
+		changed = true
+	}
+	if len(known) > 0 {
+		changed = true
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+
+	if changed {
+		if err := s.saveIndex(&backupIndex{Entries: entries}); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
@@ -7,13 +7,16 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"sort"
+	"sync"
 	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/backup"
 )
 
 // BackupService handles configuration backup and restore operations
 type BackupService struct {
-	backupRoot string // Root directory for all backups
+	backupRoot string     // Root directory for all backups
+	indexMu    sync.Mutex // Serializes index updates
 }
 
 // BackupMetadata contains information about a backup
@@ -22,16 +25,20 @@ type BackupMetadata struct {
 	Path        string      `json:"path"`        // Full path to backup directory
 	Description string      `json:"description"` // User-provided description
 	CreatedAt   time.Time   `json:"created_at"`  // When backup was created
-	Files       []FileEntry `json:"files"`       // Files in this backup
+	Files       []FileEntry `json:"files"`       // Files in this backup; not loaded when listing
+	FileCount   int         `json:"file_count"`  // Number of files in this backup
 	SizeBytes   int64       `json:"size_bytes"`  // Total backup size
 }
 
 // FileEntry represents a backed up file
 type FileEntry struct {
-	OriginalPath string      `json:"original_path"` // Where file came from
-	BackupPath   string      `json:"backup_path"`   // Where it's stored in backup
-	Permissions  os.FileMode `json:"permissions"`   // File permissions
-	SizeBytes    int64       `json:"size_bytes"`    // File size
+	OriginalPath string            `json:"original_path"`         // Where file came from
+	BackupPath   string            `json:"backup_path"`           // Where it's stored in backup
+	Permissions  os.FileMode       `json:"permissions"`           // File permissions
+	SizeBytes    int64             `json:"size_bytes"`            // File size
+	LinkTarget   string            `json:"link_target,omitempty"` // Symlink target; empty for regular files
+	ModTime      time.Time         `json:"mod_time"`              // Modification time to restore
+	Xattrs       map[string][]byte `json:"xattrs,omitempty"`      // Extended attributes to restore
 }
 
 // BackupManifest is stored in each backup directory
@@ -51,9 +58,12 @@ func NewBackupService(backupRoot string) *BackupService {
 
 // BackupFile backs up a single file
 func (s *BackupService) BackupFile(ctx context.Context, filePath string) (string, error) {
-	// Get file info
-	info, err := os.Stat(filePath)
-	if err != nil {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	// Get file info without following a symlink
+	if _, err := os.Lstat(filePath); err != nil {
 		return "", fmt.Errorf("failed to stat file %s: %w", filePath, err)
 	}
 
@@ -70,21 +80,20 @@ func (s *BackupService) BackupFile(ctx context.Context, filePath string) (string
 	fileName := filepath.Base(filePath)
 	backupPath := filepath.Join(backupDir, fileName)
 
-	// Copy file preserving permissions
-	if err := copyFile(filePath, backupPath); err != nil {
+	// Copy file preserving permissions, mtime and symlinks
+	if _, err := captureFile(filePath, backupPath); err != nil {
 		return "", fmt.Errorf("failed to copy file: %w", err)
 	}
 
-	// Preserve permissions
-	if err := os.Chmod(backupPath, info.Mode().Perm()); err != nil {
-		return "", fmt.Errorf("failed to set permissions: %w", err)
-	}
-
 	return backupPath, nil
 }
 
 // BackupDirectory backs up an entire directory
 func (s *BackupService) BackupDirectory(ctx context.Context, dirPath string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	// Verify directory exists
 	info, err := os.Stat(dirPath)
 	if err != nil {
@@ -110,6 +119,10 @@ func (s *BackupService) BackupDirectory(ctx context.Context, dirPath string) (st
 
 // CreateBackup creates a complete backup of multiple files
 func (s *BackupService) CreateBackup(ctx context.Context, filePaths []string, description string) (*BackupMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Generate backup ID (timestamp)
 	timestamp := time.Now()
 	backupID := timestamp.Format("2006-01-02_150405")
@@ -126,8 +139,11 @@ func (s *BackupService) CreateBackup(ctx context.Context, filePaths []string, de
 	// Backup each file
 	var totalSize int64
 	for _, filePath := range filePaths {
-		info, err := os.Stat(filePath)
-		if err != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, err := os.Lstat(filePath); err != nil {
 			continue // Skip files that don't exist
 		}
 
@@ -135,17 +151,15 @@ func (s *BackupService) CreateBackup(ctx context.Context, filePaths []string, de
 		fileName := filepath.Base(filePath)
 		backupFilePath := filepath.Join(backupPath, fileName)
 
-		// Copy file
-		if err := copyFile(filePath, backupFilePath); err != nil {
+		// Copy file, keeping symlinks as links
+		entry, err := captureFile(filePath, backupFilePath)
+		if err != nil {
 			continue
 		}
 
-		// Preserve permissions
-		os.Chmod(backupFilePath, info.Mode().Perm())
-
 		// Add to manifest
-		manifest.AddFile(filePath, backupFilePath, info.Mode().Perm())
-		totalSize += info.Size()
+		manifest.Files = append(manifest.Files, entry)
+		totalSize += entry.SizeBytes
 	}
 
 	// Save manifest
@@ -153,6 +167,10 @@ func (s *BackupService) CreateBackup(ctx context.Context, filePaths []string, de
 		return nil, fmt.Errorf("failed to save manifest: %w", err)
 	}
 
+	// The index is rebuilt from manifests when it is stale, so a failed
+	// update only costs a slower listing
+	_ = s.indexBackup(backupID, manifest)
+
 	// Create metadata
 	metadata := &BackupMetadata{
 		ID:          backupID,
@@ -160,6 +178,7 @@ func (s *BackupService) CreateBackup(ctx context.Context, filePaths []string, de
 		Description: description,
 		CreatedAt:   timestamp,
 		Files:       manifest.Files,
+		FileCount:   len(manifest.Files),
 		SizeBytes:   totalSize,
 	}
 
@@ -178,80 +197,67 @@ func (s *BackupService) RestoreBackup(ctx context.Context, backupID string) erro
 
 	// Restore each file
 	for _, fileEntry := range manifest.Files {
-		// Read backup file
-		backupFilePath := fileEntry.BackupPath
-		content, err := os.ReadFile(backupFilePath)
-		if err != nil {
-			return fmt.Errorf("failed to read backup file %s: %w", backupFilePath, err)
-		}
-
-		// Ensure target directory exists
-		targetDir := filepath.Dir(fileEntry.OriginalPath)
-		if err := os.MkdirAll(targetDir, 0755); err != nil {
-			return fmt.Errorf("failed to create target directory: %w", err)
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		// Write to original location
-		if err := os.WriteFile(fileEntry.OriginalPath, content, fileEntry.Permissions); err != nil {
-			return fmt.Errorf("failed to restore file %s: %w", fileEntry.OriginalPath, err)
+		if err := restoreFile(fileEntry); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// ListBackups lists all available backups sorted by date (newest first)
+// ListBackups lists all available backups sorted by date (newest first).
+// Metadata comes from the backup index, so Files is not loaded; use
+// GetBackupInfo for a backup's file list.
 func (s *BackupService) ListBackups(ctx context.Context) ([]*BackupMetadata, error) {
+	backups, _, err := s.ListBackupsPage(ctx, 0, 0)
+	return backups, err
+}
+
+// ListBackupsPage lists one page of backups sorted by date (newest first)
+// and returns the total number of backups. A limit of 0 lists every backup
+// from offset on.
+func (s *BackupService) ListBackupsPage(ctx context.Context, offset, limit int) ([]*BackupMetadata, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	if offset < 0 || limit < 0 {
+		return nil, 0, fmt.Errorf("offset and limit cannot be negative")
+	}
+
 	// Create backup root if it doesn't exist
 	if err := os.MkdirAll(s.backupRoot, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create backup root: %w", err)
+		return nil, 0, fmt.Errorf("failed to create backup root: %w", err)
 	}
 
-	// Read backup directories
-	entries, err := os.ReadDir(s.backupRoot)
+	entries, err := s.syncIndex(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+		return nil, 0, err
 	}
 
-	var backups []*BackupMetadata
-
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-
-		backupPath := filepath.Join(s.backupRoot, entry.Name())
-
-		// Load manifest
-		manifest, err := LoadManifest(backupPath)
-		if err != nil {
-			continue // Skip invalid backups
-		}
-
-		// Calculate total size
-		var totalSize int64
-		for _, file := range manifest.Files {
-			totalSize += file.SizeBytes
-		}
-
-		metadata := &BackupMetadata{
-			ID:          manifest.ID,
-			Path:        backupPath,
-			Description: manifest.Description,
-			CreatedAt:   manifest.CreatedAt,
-			Files:       manifest.Files,
-			SizeBytes:   totalSize,
-		}
-
-		backups = append(backups, metadata)
+	total := len(entries)
+	start := min(offset, total)
+	end := total
+	if limit > 0 {
+		end = min(start+limit, total)
 	}
 
-	// Sort by creation time (newest first)
-	sort.Slice(backups, func(i, j int) bool {
-		return backups[i].CreatedAt.After(backups[j].CreatedAt)
-	})
+	backups := make([]*BackupMetadata, 0, end-start)
+	for _, entry := range entries[start:end] {
+		backups = append(backups, &BackupMetadata{
+			ID:          entry.ID,
+			Path:        filepath.Join(s.backupRoot, entry.Dir),
+			Description: entry.Description,
+			CreatedAt:   entry.CreatedAt,
+			FileCount:   entry.FileCount,
+			SizeBytes:   entry.SizeBytes,
+		})
+	}
 
-	return backups, nil
+	return backups, total, nil
 }
 
 // CleanupOldBackups removes backups older than retentionDays
@@ -270,12 +276,16 @@ func (s *BackupService) CleanupOldBackups(ctx context.Context, retentionDays int
 	removed := 0
 
 	for _, backup := range backups {
+		if err := ctx.Err(); err != nil {
+			return removed, err
+		}
 		if backup.CreatedAt.Before(cutoffDate) {
 			// Remove backup directory
 			if err := os.RemoveAll(backup.Path); err != nil {
 				// Log error but continue
 				continue
 			}
+			_ = s.unindexBackup(filepath.Base(backup.Path))
 			removed++
 		}
 	}
@@ -285,11 +295,18 @@ func (s *BackupService) CleanupOldBackups(ctx context.Context, retentionDays int
 
 // GetBackupInfo retrieves metadata for a specific backup
 func (s *BackupService) GetBackupInfo(ctx context.Context, backupID string) (*BackupMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	backupPath := filepath.Join(s.backupRoot, backupID)
 
 	// Check if backup exists
 	if _, err := os.Stat(backupPath); err != nil {
-		return nil, fmt.Errorf("backup %s not found: %w", backupID, err)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("backup %s: %w", backupID, backup.ErrBackupNotFound)
+		}
+		return nil, fmt.Errorf("failed to stat backup %s: %w", backupID, err)
 	}
 
 	// Load manifest
@@ -310,6 +327,7 @@ func (s *BackupService) GetBackupInfo(ctx context.Context, backupID string) (*Ba
 		Description: manifest.Description,
 		CreatedAt:   manifest.CreatedAt,
 		Files:       manifest.Files,
+		FileCount:   len(manifest.Files),
 		SizeBytes:   totalSize,
 	}
 
@@ -364,6 +382,9 @@ func LoadManifest(backupPath string) (*BackupManifest, error) {
 
 	data, err := os.ReadFile(manifestPath)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("backup %s: %w", filepath.Base(backupPath), backup.ErrBackupNotFound)
+		}
 		return nil, fmt.Errorf("failed to read manifest: %w", err)
 	}
 
@@ -395,7 +416,8 @@ func copyFile(src, dst string) error {
 	return err
 }
 
-// copyDir copies a directory recursively
+// copyDir copies a directory recursively, keeping symlinks as links and
+// preserving mtimes
 func copyDir(src, dst string) error {
 	// Get source directory info
 	srcInfo, err := os.Stat(src)
@@ -423,19 +445,15 @@ func copyDir(src, dst string) error {
 			if err := copyDir(srcPath, dstPath); err != nil {
 				return err
 			}
-		} else {
-			// Copy file
-			if err := copyFile(srcPath, dstPath); err != nil {
-				return err
-			}
+			continue
+		}
 
-			// Preserve permissions
-			info, _ := os.Stat(srcPath)
-			if info != nil {
-				os.Chmod(dstPath, info.Mode())
-			}
+		// Copy file or symlink
+		if _, err := captureFile(srcPath, dstPath); err != nil {
+			return err
 		}
 	}
 
-	return nil
+	// Set the directory mtime last; creating entries updates it
+	return os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime())
 }
@@ -7,9 +7,11 @@ import (
 	"testing"
 	"time"
 
+	domainBackup "github.com/rebelopsio/gohan/internal/domain/backup"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
 )
 
 // ========================================
@@ -306,6 +308,284 @@ func TestBackupService_ListBackups(t *testing.T) {
 	})
 }
 
+func TestBackupService_PreservesFileMetadata(t *testing.T) {
+	t.Run("backs up and restores symlinks as links", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		service := backup.NewBackupService(filepath.Join(tmpDir, "backups"))
+		ctx := context.Background()
+
+		theme := filepath.Join(tmpDir, "themes", "mocha.conf")
+		require.NoError(t, os.MkdirAll(filepath.Dir(theme), 0755))
+		require.NoError(t, os.WriteFile(theme, []byte("theme"), 0644))
+		link := filepath.Join(tmpDir, "theme.conf")
+		require.NoError(t, os.Symlink(theme, link))
+
+		metadata, err := service.CreateBackup(ctx, []string{link}, "symlink")
+		require.NoError(t, err)
+		require.Len(t, metadata.Files, 1)
+		assert.Equal(t, theme, metadata.Files[0].LinkTarget)
+
+		stored, err := os.Readlink(metadata.Files[0].BackupPath)
+		require.NoError(t, err, "backup should store the link, not a copy")
+		assert.Equal(t, theme, stored)
+
+		require.NoError(t, os.Remove(link))
+		require.NoError(t, os.WriteFile(link, []byte("replaced"), 0644))
+
+		require.NoError(t, service.RestoreBackup(ctx, metadata.ID))
+
+		restored, err := os.Readlink(link)
+		require.NoError(t, err)
+		assert.Equal(t, theme, restored)
+		content, err := os.ReadFile(theme)
+		require.NoError(t, err)
+		assert.Equal(t, "theme", string(content))
+	})
+
+	t.Run("backs up and restores dangling symlinks", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		service := backup.NewBackupService(filepath.Join(tmpDir, "backups"))
+		ctx := context.Background()
+
+		missing := filepath.Join(tmpDir, "missing.conf")
+		link := filepath.Join(tmpDir, "dangling.conf")
+		require.NoError(t, os.Symlink(missing, link))
+
+		metadata, err := service.CreateBackup(ctx, []string{link}, "dangling")
+		require.NoError(t, err)
+		require.Len(t, metadata.Files, 1, "dangling links should not be skipped")
+
+		require.NoError(t, os.Remove(link))
+		require.NoError(t, service.RestoreBackup(ctx, metadata.ID))
+
+		restored, err := os.Readlink(link)
+		require.NoError(t, err)
+		assert.Equal(t, missing, restored)
+		_, err = os.Stat(missing)
+		assert.True(t, os.IsNotExist(err), "restoring a link must not create its target")
+	})
+
+	t.Run("restores a regular file over a symlink without writing through it", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		service := backup.NewBackupService(filepath.Join(tmpDir, "backups"))
+		ctx := context.Background()
+
+		config := filepath.Join(tmpDir, "kitty.conf")
+		require.NoError(t, os.WriteFile(config, []byte("original"), 0644))
+		metadata, err := service.CreateBackup(ctx, []string{config}, "regular")
+		require.NoError(t, err)
+
+		elsewhere := filepath.Join(tmpDir, "elsewhere.conf")
+		require.NoError(t, os.WriteFile(elsewhere, []byte("untouched"), 0644))
+		require.NoError(t, os.Remove(config))
+		require.NoError(t, os.Symlink(elsewhere, config))
+
+		require.NoError(t, service.RestoreBackup(ctx, metadata.ID))
+
+		info, err := os.Lstat(config)
+		require.NoError(t, err)
+		assert.True(t, info.Mode().IsRegular())
+		content, err := os.ReadFile(elsewhere)
+		require.NoError(t, err)
+		assert.Equal(t, "untouched", string(content))
+	})
+
+	t.Run("preserves modification times", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		service := backup.NewBackupService(filepath.Join(tmpDir, "backups"))
+		ctx := context.Background()
+
+		config := filepath.Join(tmpDir, "waybar.json")
+		require.NoError(t, os.WriteFile(config, []byte("{}"), 0644))
+		mtime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		require.NoError(t, os.Chtimes(config, mtime, mtime))
+
+		metadata, err := service.CreateBackup(ctx, []string{config}, "mtime")
+		require.NoError(t, err)
+
+		info, err := os.Stat(metadata.Files[0].BackupPath)
+		require.NoError(t, err)
+		assert.True(t, mtime.Equal(info.ModTime()), "backup copy should keep the mtime")
+
+		require.NoError(t, os.WriteFile(config, []byte("changed"), 0644))
+		require.NoError(t, service.RestoreBackup(ctx, metadata.ID))
+
+		info, err = os.Stat(config)
+		require.NoError(t, err)
+		assert.True(t, mtime.Equal(info.ModTime()), "restored file should keep the mtime")
+	})
+
+	t.Run("preserves extended attributes", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		service := backup.NewBackupService(filepath.Join(tmpDir, "backups"))
+		ctx := context.Background()
+
+		config := filepath.Join(tmpDir, "hyprland.conf")
+		require.NoError(t, os.WriteFile(config, []byte("monitor=,preferred,auto,1"), 0644))
+		if err := unix.Setxattr(config, "user.gohan.test", []byte("value"), 0); err != nil {
+			t.Skipf("filesystem does not support user extended attributes: %v", err)
+		}
+
+		metadata, err := service.CreateBackup(ctx, []string{config}, "xattrs")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), metadata.Files[0].Xattrs["user.gohan.test"])
+
+		require.NoError(t, os.Remove(config))
+		require.NoError(t, service.RestoreBackup(ctx, metadata.ID))
+
+		buf := make([]byte, 64)
+		n, err := unix.Getxattr(config, "user.gohan.test", buf)
+		require.NoError(t, err)
+		assert.Equal(t, "value", string(buf[:n]))
+	})
+
+	t.Run("keeps symlinks inside backed up directories", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		service := backup.NewBackupService(filepath.Join(tmpDir, "backups"))
+
+		srcDir := filepath.Join(tmpDir, "hypr")
+		require.NoError(t, os.MkdirAll(srcDir, 0755))
+		require.NoError(t, os.Symlink("../themes/current.conf", filepath.Join(srcDir, "theme.conf")))
+
+		backupPath, err := service.BackupDirectory(context.Background(), srcDir)
+		require.NoError(t, err)
+
+		target, err := os.Readlink(filepath.Join(backupPath, "theme.conf"))
+		require.NoError(t, err)
+		assert.Equal(t, "../themes/current.conf", target)
+	})
+}
+
+func TestBackupService_ListBackupsPage(t *testing.T) {
+	// writeBackup stores a manifest with one file, the given hours ago
+	writeBackup := func(t *testing.T, backupDir string, hoursAgo int) string {
+		createdAt := time.Now().Add(-time.Duration(hoursAgo) * time.Hour)
+		id := createdAt.Format("2006-01-02_150405")
+		path := filepath.Join(backupDir, id)
+		require.NoError(t, os.MkdirAll(path, 0755))
+
+		manifest := backup.NewBackupManifest(id, "backup")
+		manifest.CreatedAt = createdAt
+		manifest.Files = []backup.FileEntry{{
+			OriginalPath: "/etc/test.conf",
+			BackupPath:   filepath.Join(path, "test.conf"),
+			Permissions:  0644,
+			SizeBytes:    42,
+		}}
+		require.NoError(t, manifest.Save(path))
+		return id
+	}
+
+	t.Run("pages newest first and reports the total", func(t *testing.T) {
+		backupDir := filepath.Join(t.TempDir(), "backups")
+		service := backup.NewBackupService(backupDir)
+		ctx := context.Background()
+
+		var ids []string
+		for hours := 1; hours <= 5; hours++ {
+			ids = append(ids, writeBackup(t, backupDir, hours))
+		}
+
+		page, total, err := service.ListBackupsPage(ctx, 1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, total)
+		require.Len(t, page, 2)
+		assert.Equal(t, ids[1], page[0].ID)
+		assert.Equal(t, ids[2], page[1].ID)
+		assert.Equal(t, 1, page[0].FileCount)
+		assert.Equal(t, int64(42), page[0].SizeBytes)
+		assert.Nil(t, page[0].Files, "listing should not load file lists")
+
+		page, total, err = service.ListBackupsPage(ctx, 10, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 5, total)
+		assert.Empty(t, page)
+	})
+
+	t.Run("rejects negative offset or limit", func(t *testing.T) {
+		service := backup.NewBackupService(filepath.Join(t.TempDir(), "backups"))
+
+		_, _, err := service.ListBackupsPage(context.Background(), -1, 0)
+		assert.Error(t, err)
+		_, _, err = service.ListBackupsPage(context.Background(), 0, -1)
+		assert.Error(t, err)
+	})
+
+	t.Run("serves listings from the index", func(t *testing.T) {
+		backupDir := filepath.Join(t.TempDir(), "backups")
+		service := backup.NewBackupService(backupDir)
+		ctx := context.Background()
+		id := writeBackup(t, backupDir, 1)
+
+		_, _, err := service.ListBackupsPage(ctx, 0, 0)
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(backupDir, "index.json"))
+		require.NoError(t, err, "listing should write the index")
+
+		// An indexed backup is listed without reading its manifest again
+		require.NoError(t, os.Remove(filepath.Join(backupDir, id, "manifest.json")))
+		backups, err := service.ListBackups(ctx)
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+		assert.Equal(t, id, backups[0].ID)
+	})
+
+	t.Run("reconciles the index with the backup directories", func(t *testing.T) {
+		backupDir := filepath.Join(t.TempDir(), "backups")
+		service := backup.NewBackupService(backupDir)
+		ctx := context.Background()
+		removedID := writeBackup(t, backupDir, 2)
+
+		_, _, err := service.ListBackupsPage(ctx, 0, 0)
+		require.NoError(t, err)
+
+		require.NoError(t, os.RemoveAll(filepath.Join(backupDir, removedID)))
+		addedID := writeBackup(t, backupDir, 1)
+
+		backups, total, err := service.ListBackupsPage(ctx, 0, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, backups, 1)
+		assert.Equal(t, addedID, backups[0].ID)
+	})
+
+	t.Run("rebuilds a corrupted index", func(t *testing.T) {
+		backupDir := filepath.Join(t.TempDir(), "backups")
+		service := backup.NewBackupService(backupDir)
+		id := writeBackup(t, backupDir, 1)
+		require.NoError(t, os.WriteFile(filepath.Join(backupDir, "index.json"), []byte("{not json"), 0644))
+
+		backups, err := service.ListBackups(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+		assert.Equal(t, id, backups[0].ID)
+	})
+
+	t.Run("keeps the index current as backups are created and removed", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		backupDir := filepath.Join(tmpDir, "backups")
+		service := backup.NewBackupService(backupDir)
+		ctx := context.Background()
+
+		oldID := writeBackup(t, backupDir, 24*40)
+		srcFile := filepath.Join(tmpDir, "test.conf")
+		require.NoError(t, os.WriteFile(srcFile, []byte("content"), 0644))
+		created, err := service.CreateBackup(ctx, []string{srcFile}, "recent")
+		require.NoError(t, err)
+
+		_, err = service.CleanupOldBackups(ctx, 30)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(backupDir, "index.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), created.ID)
+		assert.NotContains(t, string(data), oldID)
+	})
+}
+
 func TestBackupService_CleanupOldBackups(t *testing.T) {
 	t.Run("removes backups older than retention days", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -394,10 +674,48 @@ func TestBackupService_GetBackupInfo(t *testing.T) {
 		ctx := context.Background()
 		_, err := service.GetBackupInfo(ctx, "nonexistent")
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domainBackup.ErrBackupNotFound)
 	})
 }
 
+func TestBackupService_CanceledContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	service := backup.NewBackupService(filepath.Join(tmpDir, "backups"))
+
+	srcFile := filepath.Join(tmpDir, "test.conf")
+	require.NoError(t, os.WriteFile(srcFile, []byte("original"), 0644))
+
+	metadata, err := service.CreateBackup(context.Background(), []string{srcFile}, "before cancel")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(srcFile, []byte("modified"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = service.BackupFile(ctx, srcFile)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = service.BackupDirectory(ctx, tmpDir)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = service.CreateBackup(ctx, []string{srcFile}, "canceled")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = service.ListBackups(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = service.GetBackupInfo(ctx, metadata.ID)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = service.CleanupOldBackups(ctx, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.ErrorIs(t, service.RestoreBackup(ctx, metadata.ID), context.Canceled)
+	content, err := os.ReadFile(srcFile)
+	require.NoError(t, err)
+	assert.Equal(t, "modified", string(content), "a canceled restore must not touch files")
+}
+
 func TestBackupManifest(t *testing.T) {
 	t.Run("manifest contains backup metadata", func(t *testing.T) {
 		manifest := backup.NewBackupManifest("test-id", "Test backup")
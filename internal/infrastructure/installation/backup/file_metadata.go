@@ -0,0 +1,206 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/rebelopsio/gohan/internal/domain/backup"
+)
+
+// captureFile copies src to dst and records what is needed to restore it
+// faithfully: symlinks are stored as links (dangling ones included) rather
+// than dereferenced, and regular files keep their mtime and extended
+// attributes
+func captureFile(src, dst string) (FileEntry, error) {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return FileEntry{}, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return FileEntry{}, err
+	}
+
+	entry := FileEntry{
+		OriginalPath: src,
+		BackupPath:   dst,
+		Permissions:  info.Mode().Perm(),
+		SizeBytes:    info.Size(),
+		ModTime:      info.ModTime(),
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(src)
+		if err != nil {
+			return FileEntry{}, err
+		}
+		if err := replaceWithSymlink(target, dst); err != nil {
+			return FileEntry{}, err
+		}
+		entry.LinkTarget = target
+		return entry, nil
+	}
+
+	if !info.Mode().IsRegular() {
+		return FileEntry{}, fmt.Errorf("%s is not a regular file or symlink", src)
+	}
+
+	if err := copyFile(src, dst); err != nil {
+		return FileEntry{}, err
+	}
+	if err := os.Chmod(dst, info.Mode().Perm()); err != nil {
+		return FileEntry{}, err
+	}
+	if err := os.Chtimes(dst, info.ModTime(), info.ModTime()); err != nil {
+		return FileEntry{}, err
+	}
+
+	xattrs, err := readXattrs(src)
+	if err != nil {
+		return FileEntry{}, err
+	}
+	entry.Xattrs = xattrs
+
+	return entry, nil
+}
+
+// restoreFile puts a captured file back at its original path. An existing
+// symlink there is replaced rather than written through.
+func restoreFile(entry FileEntry) error {
+	if err := os.MkdirAll(filepath.Dir(entry.OriginalPath), 0755); err != nil {
+		return fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	if entry.LinkTarget != "" {
+		if err := replaceWithSymlink(entry.LinkTarget, entry.OriginalPath); err != nil {
+			return fmt.Errorf("failed to restore symlink %s: %w", entry.OriginalPath, err)
+		}
+		return nil
+	}
+
+	content, err := os.ReadFile(entry.BackupPath)
+	if err != nil {
+		return fmt.Errorf("failed to read backup file %s: %w", entry.BackupPath, err)
+	}
+
+	if info, err := os.Lstat(entry.OriginalPath); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(entry.OriginalPath); err != nil {
+			return fmt.Errorf("failed to replace symlink %s: %w", entry.OriginalPath, err)
+		}
+	}
+
+	if err := os.WriteFile(entry.OriginalPath, content, entry.Permissions); err != nil {
+		return fmt.Errorf("failed to restore file %s: %w", entry.OriginalPath, err)
+	}
+	// WriteFile keeps the mode of a file that already exists
+	if err := os.Chmod(entry.OriginalPath, entry.Permissions); err != nil {
+		return fmt.Errorf("failed to restore permissions of %s: %w", entry.OriginalPath, err)
+	}
+	if err := writeXattrs(entry.OriginalPath, entry.Xattrs); err != nil {
+		return fmt.Errorf("failed to restore extended attributes of %s: %w", entry.OriginalPath, err)
+	}
+	if !entry.ModTime.IsZero() {
+		if err := os.Chtimes(entry.OriginalPath, entry.ModTime, entry.ModTime); err != nil {
+			return fmt.Errorf("failed to restore modification time of %s: %w", entry.OriginalPath, err)
+		}
+	}
+
+	return nil
+}
+
+// CaptureBackupFile copies src into the backup at dst, preserving symlinks,
+// mtimes and extended attributes
+func CaptureBackupFile(src, dst string) (backup.BackupFile, error) {
+	entry, err := captureFile(src, dst)
+	if err != nil {
+		return backup.BackupFile{}, err
+	}
+	return toBackupFile(entry), nil
+}
+
+// RestoreBackupFile restores a backed up file to its original path
+func RestoreBackupFile(file backup.BackupFile) error {
+	return restoreFile(toFileEntry(file))
+}
+
+// replaceWithSymlink creates a symlink at path, replacing a file or link
+// that is already there
+func replaceWithSymlink(target, path string) error {
+	if info, err := os.Lstat(path); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return os.Symlink(target, path)
+}
+
+// readXattrs returns the extended attributes of path, or nil when the
+// filesystem does not support them
+func readXattrs(path string) (map[string][]byte, error) {
+	size, err := unix.Llistxattr(path, nil)
+	if err != nil {
+		if isXattrUnsupported(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list extended attributes of %s: %w", path, err)
+	}
+	if size == 0 {
+		return nil, nil
+	}
+
+	buf := make([]byte, size)
+	size, err = unix.Llistxattr(path, buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list extended attributes of %s: %w", path, err)
+	}
+
+	xattrs := make(map[string][]byte)
+	for _, name := range strings.Split(strings.TrimRight(string(buf[:size]), "\x00"), "\x00") {
+		if name == "" {
+			continue
+		}
+		valueSize, err := unix.Lgetxattr(path, name, nil)
+		if err != nil {
+			continue // Removed or unreadable since listing
+		}
+		value := make([]byte, valueSize)
+		valueSize, err = unix.Lgetxattr(path, name, value)
+		if err != nil {
+			continue
+		}
+		xattrs[name] = value[:valueSize]
+	}
+
+	if len(xattrs) == 0 {
+		return nil, nil
+	}
+	return xattrs, nil
+}
+
+// writeXattrs sets extended attributes on path. Attributes the filesystem
+// or the current user cannot set (security.* and trusted.* without root)
+// are skipped.
+func writeXattrs(path string, xattrs map[string][]byte) error {
+	for name, value := range xattrs {
+		err := unix.Lsetxattr(path, name, value, 0)
+		if err == nil || isXattrUnsupported(err) || errors.Is(err, unix.EPERM) {
+			continue
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// isXattrUnsupported reports whether err means the filesystem has no
+// extended attribute support
+func isXattrUnsupported(err error) bool {
+	return errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP)
+}
@@ -40,11 +40,17 @@ func (r *RepositoryAdapter) Save(ctx context.Context, b *backup.Backup) error {
 		return fmt.Errorf("failed to save manifest: %w", err)
 	}
 
+	// A stale index is repaired on the next listing
+	_ = r.service.indexBackup(b.ID(), manifest)
+
 	return nil
 }
 
 // FindByID retrieves a backup by its ID
 func (r *RepositoryAdapter) FindByID(ctx context.Context, id string) (*backup.Backup, error) {
+	if err := backup.ValidateID(id); err != nil {
+		return nil, err
+	}
 	backupPath := filepath.Join(r.backupRoot, id)
 
 	// Load manifest
@@ -68,8 +74,7 @@ func (r *RepositoryAdapter) FindAll(ctx context.Context) ([]*backup.Backup, erro
 	// Convert to domain backups
 	var backups []*backup.Backup
 	for _, metadata := range metadataList {
-		backupPath := filepath.Join(r.backupRoot, metadata.ID)
-		manifest, err := LoadManifest(backupPath)
+		manifest, err := LoadManifest(metadata.Path)
 		if err != nil {
 			continue // Skip invalid backups
 		}
@@ -85,10 +90,38 @@ func (r *RepositoryAdapter) FindAll(ctx context.Context) ([]*backup.Backup, erro
 	return backups, nil
 }
 
+// FindSummaries retrieves one page of backup summaries from the backup
+// index without reading manifests
+func (r *RepositoryAdapter) FindSummaries(ctx context.Context, offset, limit int) ([]backup.BackupSummary, int, error) {
+	metadataList, total, err := r.service.ListBackupsPage(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	summaries := make([]backup.BackupSummary, 0, len(metadataList))
+	for _, metadata := range metadataList {
+		summaries = append(summaries, backup.BackupSummary{
+			ID:          metadata.ID,
+			Description: metadata.Description,
+			CreatedAt:   metadata.CreatedAt,
+			FileCount:   metadata.FileCount,
+			SizeBytes:   metadata.SizeBytes,
+		})
+	}
+
+	return summaries, total, nil
+}
+
 // Delete removes a backup
 func (r *RepositoryAdapter) Delete(ctx context.Context, id string) error {
+	if err := backup.ValidateID(id); err != nil {
+		return err
+	}
 	backupPath := filepath.Join(r.backupRoot, id)
-	return os.RemoveAll(backupPath)
+	if err := os.RemoveAll(backupPath); err != nil {
+		return err
+	}
+	return r.service.unindexBackup(id)
 }
 
 // Exists checks if a backup with the given ID exists
@@ -109,12 +142,7 @@ func (r *RepositoryAdapter) Exists(ctx context.Context, id string) (bool, error)
 func (r *RepositoryAdapter) convertDomainFiles(domainFiles []backup.BackupFile) []FileEntry {
 	files := make([]FileEntry, len(domainFiles))
 	for i, f := range domainFiles {
-		files[i] = FileEntry{
-			OriginalPath: f.OriginalPath,
-			BackupPath:   f.BackupPath,
-			Permissions:  f.Permissions,
-			SizeBytes:    f.SizeBytes,
-		}
+		files[i] = toFileEntry(f)
 	}
 	return files
 }
@@ -132,14 +160,7 @@ func (r *RepositoryAdapter) convertToDomain(manifest *BackupManifest) (*backup.B
 
 	// For now, we'll create domain backup files and add them
 	for _, file := range manifest.Files {
-		domainFile := backup.BackupFile{
-			OriginalPath: file.OriginalPath,
-			BackupPath:   file.BackupPath,
-			Permissions:  file.Permissions,
-			SizeBytes:    file.SizeBytes,
-		}
-
-		if err := b.AddFile(domainFile); err != nil {
+		if err := b.AddFile(toBackupFile(file)); err != nil {
 			continue // Skip duplicates
 		}
 	}
@@ -149,3 +170,29 @@ func (r *RepositoryAdapter) convertToDomain(manifest *BackupManifest) (*backup.B
 
 	return b, nil
 }
+
+// toFileEntry converts a domain backup file to its manifest entry
+func toFileEntry(f backup.BackupFile) FileEntry {
+	return FileEntry{
+		OriginalPath: f.OriginalPath,
+		BackupPath:   f.BackupPath,
+		Permissions:  f.Permissions,
+		SizeBytes:    f.SizeBytes,
+		LinkTarget:   f.LinkTarget,
+		ModTime:      f.ModTime,
+		Xattrs:       f.Xattrs,
+	}
+}
+
+// toBackupFile converts a manifest entry to a domain backup file
+func toBackupFile(f FileEntry) backup.BackupFile {
+	return backup.BackupFile{
+		OriginalPath: f.OriginalPath,
+		BackupPath:   f.BackupPath,
+		Permissions:  f.Permissions,
+		SizeBytes:    f.SizeBytes,
+		LinkTarget:   f.LinkTarget,
+		ModTime:      f.ModTime,
+		Xattrs:       f.Xattrs,
+	}
+}
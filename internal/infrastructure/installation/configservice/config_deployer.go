@@ -1,10 +1,16 @@
 package configservice
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
 )
@@ -13,31 +19,66 @@ import (
 type ConfigDeployer struct {
 	templateEngine *templates.TemplateEngine
 	backupService  *backup.BackupService
+	policy         PermissionPolicy
+	renderDir      string         // Optional; keeps a copy of each rendered file
+	records        DeployRecorder // Optional; records each template's rendering, to merge edited files with the next
 }
 
 // ConfigurationFile represents a configuration file to deploy
 type ConfigurationFile struct {
 	SourceTemplate string      // Path to template file
 	TargetPath     string      // Where to deploy
-	Permissions    os.FileMode // File permissions
+	Permissions    os.FileMode // File permissions, before the policy's umask
 	BackupBefore   bool        // Whether to backup before overwriting
+	Sensitive      bool        // Restricted to the owner under a strict permission policy
+	ManagedBlock   bool        // Deployed inside managed-block markers, keeping the user's lines around them; JSON is replaced whole
 }
 
+// FileAction is what a deployment did to a single target file
+type FileAction string
+
+const (
+	// ActionCreated means the target did not exist and was written
+	ActionCreated FileAction = "created"
+	// ActionUpdated means an existing target was overwritten
+	ActionUpdated FileAction = "updated"
+	// ActionMerged means the user had edited the target and the template's
+	// changes were merged with the edits
+	ActionMerged FileAction = "merged"
+	// ActionConflict means the user's edits and the template's changes
+	// overlap; the target was left alone and the merge, with conflict
+	// markers, written next to it to resolve
+	ActionConflict FileAction = "conflict"
+	// ActionUnchanged means the target already held the rendered content
+	ActionUnchanged FileAction = "unchanged"
+	// ActionSkipped means the file was not deployed, e.g. because another
+	// file deployed with it failed
+	ActionSkipped FileAction = "skipped"
+	// ActionFailed means backing up, rendering or writing the file failed
+	ActionFailed FileAction = "failed"
+)
+
 // DeploymentProgress represents progress for a single configuration deployment
 type DeploymentProgress struct {
 	FilePath        string
 	Status          string // "started", "processing", "completed", "failed"
 	PercentComplete float64
 	Error           error
+	Result          *DeploymentResult // Set on "completed" and "failed"
 }
 
 // DeploymentResult contains the result of a deployment operation
 type DeploymentResult struct {
-	FilePath   string
-	Success    bool
-	BackupID   string // ID of backup if created
-	BackupPath string // Path to backup if created
-	Error      error
+	FilePath       string
+	SourceTemplate string // Template the file was rendered from
+	Action         FileAction
+	Success        bool
+	BackupID       string // ID of backup if created
+	BackupPath     string // Path to backup if created
+	BytesWritten   int64
+	Conflicts      int    // Conflicting regions, for ActionConflict
+	MergePath      string // Merge to resolve, for ActionConflict
+	Error          error
 }
 
 // NewConfigDeployer creates a new configuration deployer
@@ -45,41 +86,434 @@ func NewConfigDeployer(templateEngine *templates.TemplateEngine, backupService *
 	return &ConfigDeployer{
 		templateEngine: templateEngine,
 		backupService:  backupService,
+		policy:         DefaultPermissionPolicy(),
 	}
 }
 
+// WithPermissionPolicy returns a copy of the deployer that applies the given
+// permission policy to deployed files and created directories
+func (cd *ConfigDeployer) WithPermissionPolicy(policy PermissionPolicy) *ConfigDeployer {
+	clone := *cd
+	clone.policy = policy
+	return &clone
+}
+
+// WithRenderDir returns a copy of the deployer that also writes each
+// rendered file under dir, at its target path, so the output of a run can be
+// inspected after it
+func (cd *ConfigDeployer) WithRenderDir(dir string) *ConfigDeployer {
+	clone := *cd
+	clone.renderDir = dir
+	return &clone
+}
+
+// WithRecords returns a copy of the deployer that records the template
+// version and rendering of each deployed file, so later template versions
+// can be merged into it
+func (cd *ConfigDeployer) WithRecords(records DeployRecorder) *ConfigDeployer {
+	clone := *cd
+	clone.records = records
+	return &clone
+}
+
+// PermissionPolicy returns the policy applied to deployed files
+func (cd *ConfigDeployer) PermissionPolicy() PermissionPolicy {
+	return cd.policy
+}
+
 // DeployConfiguration deploys a single configuration file
 func (cd *ConfigDeployer) DeployConfiguration(ctx context.Context, config ConfigurationFile, vars templates.TemplateVars) error {
+	_, err := cd.deploy(ctx, config, vars, config.BackupBefore, nil)
+	return err
+}
+
+// deploy renders and writes one configuration file, backing up an existing
+// target first when backupExisting is set, and reports what it did. A
+// target that already holds the rendered content is neither backed up nor
+// rewritten. contentFor, when set, turns the rendering into the content to
+// write; otherwise the content is planned by plan.
+func (cd *ConfigDeployer) deploy(
+	ctx context.Context,
+	config ConfigurationFile,
+	vars templates.TemplateVars,
+	backupExisting bool,
+	contentFor func(rendered string) string) (*DeploymentResult, error) {
+
+	result := &DeploymentResult{
+		FilePath:       config.TargetPath,
+		SourceTemplate: config.SourceTemplate,
+		Action:         ActionFailed,
+	}
+	fail := func(err error) (*DeploymentResult, error) {
+		result.Error = err
+		return result, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fail(err)
+	}
+
+	rendered, err := cd.render(config, vars)
+	if err != nil {
+		return fail(fmt.Errorf("failed to process template: %w", err))
+	}
+	var action FileAction
+	var content string
+	conflicts := 0
+	if contentFor != nil {
+		content = contentFor(rendered)
+		action = plannedAction(config.TargetPath, content)
+	} else {
+		action, content, conflicts = cd.plan(config, rendered)
+	}
+	if err := cd.keepRendered(config.TargetPath, content); err != nil {
+		return fail(err)
+	}
+
+	if action == ActionConflict {
+		mergePath, err := leaveMerge(config.TargetPath, content)
+		if err != nil {
+			return fail(err)
+		}
+		result.Action = ActionConflict
+		result.Conflicts = conflicts
+		result.MergePath = mergePath
+		result.Success = true
+		return result, nil
+	}
+
+	if action == ActionUnchanged {
+		// Permissions are still enforced; they are not part of the hash.
+		// Best effort, as the file may belong to someone else.
+		_ = cd.policy.apply(config)
+		cd.record(config, vars, rendered)
+		result.Action = ActionUnchanged
+		result.Success = true
+		return result, nil
+	}
+
 	// Backup if requested and file exists
-	if config.BackupBefore {
-		if _, err := os.Stat(config.TargetPath); err == nil {
-			// File exists, back it up
-			_, err := cd.backupService.CreateBackup(
-				ctx,
-				[]string{config.TargetPath},
-				fmt.Sprintf("Backup before deploying %s", config.TargetPath),
-			)
-			if err != nil {
-				return fmt.Errorf("failed to backup existing file: %w", err)
-			}
+	if backupExisting && action != ActionCreated {
+		metadata, err := cd.backupService.CreateBackup(
+			ctx,
+			[]string{config.TargetPath},
+			fmt.Sprintf("Backup before deploying %s", config.TargetPath),
+		)
+		if err != nil {
+			return fail(fmt.Errorf("failed to backup existing file: %w", err))
+		}
+		result.BackupID = metadata.ID
+		result.BackupPath = metadata.Path
+	}
+
+	// Deploy
+	if _, err := cd.policy.prepareDir(config); err != nil {
+		return fail(err)
+	}
+	if err := cd.templateEngine.WriteOutput(config.TargetPath, content); err != nil {
+		return fail(fmt.Errorf("failed to process template: %w", err))
+	}
+
+	// Set permissions and ownership
+	if err := cd.policy.apply(config); err != nil {
+		return fail(err)
+	}
+	cd.record(config, vars, rendered)
+	if action == ActionMerged {
+		// Applied now if it was a merge resolved by hand
+		_ = os.Remove(config.TargetPath + configuration.MergeFileSuffix)
+	}
+
+	result.BytesWritten = int64(len(content))
+	result.Action = action
+	result.Success = true
+	return result, nil
+}
+
+// record keeps the template's hash and rendering for the deployed file.
+// It is best effort: a file that was deployed is not reported as failed
+// for want of its record, which only later template upgrades need.
+func (cd *ConfigDeployer) record(config ConfigurationFile, vars templates.TemplateVars, rendered string) {
+	if cd.records == nil {
+		return
+	}
+	template, err := os.ReadFile(config.SourceTemplate)
+	if err != nil {
+		return
+	}
+	// Templates are found relative to where gohan ran; record where
+	source, err := filepath.Abs(config.SourceTemplate)
+	if err != nil {
+		return
+	}
+	record, err := configuration.NewDeployedTemplate(
+		config.TargetPath,
+		source,
+		configuration.HashTemplate(template),
+		rendered,
+		vars,
+		time.Now(),
+	)
+	if err != nil {
+		return
+	}
+	_ = cd.records.Record(record)
+}
+
+// render renders a configuration file's template, wrapped in managed-block
+// markers when the file has them
+func (cd *ConfigDeployer) render(config ConfigurationFile, vars templates.TemplateVars) (string, error) {
+	rendered, err := cd.templateEngine.RenderFile(config.SourceTemplate, vars)
+	if err != nil {
+		return "", err
+	}
+	if config.ManagedBlock {
+		rendered = configuration.WrapManagedBlock(config.TargetPath, rendered)
+	}
+	return rendered, nil
+}
+
+// plan decides what deploying a rendering does to its target and the
+// content it writes. When the user edited the target since it was last
+// deployed and the rendering changed too, the two are merged; overlapping
+// changes are a conflict, and the content is the merge with conflict
+// markers to resolve.
+func (cd *ConfigDeployer) plan(config ConfigurationFile, rendered string) (FileAction, string, int) {
+	if content, conflicts, ok := cd.mergeEdits(config, rendered); ok {
+		switch {
+		case conflicts > 0:
+			return ActionConflict, content, conflicts
+		case plannedAction(config.TargetPath, content) == ActionUnchanged:
+			return ActionUnchanged, content, 0
 		}
+		return ActionMerged, content, 0
 	}
 
-	// Process template and deploy
-	if err := cd.templateEngine.ProcessFile(config.SourceTemplate, config.TargetPath, vars); err != nil {
-		return fmt.Errorf("failed to process template: %w", err)
+	content := deployedContent(config, rendered)
+	return plannedAction(config.TargetPath, content), content, 0
+}
+
+// mergeEdits merges the rendering with the user's edits to the target,
+// against the rendering recorded when it was last deployed. A merge the
+// user resolved by hand since, with no conflict markers left, is used as
+// is. It reports false when the file was not edited, the rendering did not
+// change or nothing was recorded, and the rendering replaces the target's
+// content as usual.
+func (cd *ConfigDeployer) mergeEdits(config ConfigurationFile, rendered string) (string, int, bool) {
+	if cd.records == nil {
+		return "", 0, false
+	}
+	record, found, err := cd.records.Find(config.TargetPath)
+	if err != nil || !found || record.Rendered() == rendered {
+		return "", 0, false
+	}
+	current, err := os.ReadFile(config.TargetPath)
+	if err != nil || !editedSince(record, string(current)) {
+		return "", 0, false
+	}
+
+	resolved, err := os.ReadFile(config.TargetPath + configuration.MergeFileSuffix)
+	if err == nil && !configuration.HasConflictMarkers(string(resolved)) {
+		return string(resolved), 0, true
+	}
+	merge := configuration.MergeThreeWay(record.Rendered(), string(current), rendered)
+	return merge.Content(), merge.Conflicts(), true
+}
+
+// editedSince reports whether current, a file's content, was edited since
+// the file was deployed as record. Lines added around a managed block are
+// the user's to add and not edits.
+func editedSince(record configuration.DeployedTemplate, current string) bool {
+	if configuration.HasManagedBlock(record.Rendered()) {
+		replaced, ok := configuration.ReplaceManagedBlock(current, record.Rendered())
+		return !ok || replaced != current
+	}
+	return record.IsModified(current)
+}
+
+// leaveMerge writes a merge with conflicts next to the target, with the
+// target's permissions, and returns its path
+func leaveMerge(targetPath, merge string) (string, error) {
+	stat, err := os.Stat(targetPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to write merge: %w", err)
+	}
+	mergePath := targetPath + configuration.MergeFileSuffix
+	if err := os.WriteFile(mergePath, []byte(merge), stat.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("failed to write merge: %w", err)
 	}
+	return mergePath, nil
+}
 
-	// Set permissions
-	if err := os.Chmod(config.TargetPath, config.Permissions); err != nil {
-		// Non-fatal - permissions are best effort
-		// Log but continue
+// deployedContent is what deploying the rendering writes: the target with
+// its managed block replaced, so the user's additions around it stay, or
+// the rendering itself for new targets and targets without a block
+func deployedContent(config ConfigurationFile, rendered string) string {
+	if !config.ManagedBlock {
+		return rendered
 	}
+	existing, err := os.ReadFile(config.TargetPath)
+	if err != nil {
+		return rendered
+	}
+	if content, ok := configuration.ReplaceManagedBlock(string(existing), rendered); ok {
+		return content
+	}
+	return rendered
+}
 
+// keepRendered writes a copy of the rendered content to the render
+// directory, if one is set
+func (cd *ConfigDeployer) keepRendered(targetPath, rendered string) error {
+	if cd.renderDir == "" {
+		return nil
+	}
+	if _, err := writeUnder(cd.renderDir, targetPath, rendered); err != nil {
+		return fmt.Errorf("failed to keep rendered output: %w", err)
+	}
 	return nil
 }
 
-// DeployConfigurations deploys multiple configuration files with progress reporting
+// writeUnder writes content under dir, at targetPath, and returns the path
+// written
+func writeUnder(dir, targetPath, content string) (string, error) {
+	path := pathUnder(dir, targetPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// pathUnder returns where targetPath goes under dir
+func pathUnder(dir, targetPath string) string {
+	return filepath.Join(dir, filepath.Clean(string(filepath.Separator)+targetPath))
+}
+
+// Stage renders a configuration file and writes the content deploying it
+// would write under dir, at its target path, leaving the target alone. It
+// returns what deploying it would do and the staged path; for a conflict,
+// the content is the merge with conflict markers.
+func (cd *ConfigDeployer) Stage(
+	ctx context.Context,
+	config ConfigurationFile,
+	vars templates.TemplateVars,
+	dir string) (FileAction, string, error) {
+
+	action, content, err := cd.preview(ctx, config, vars)
+	if err != nil {
+		return action, "", err
+	}
+	path, err := writeUnder(dir, config.TargetPath, content)
+	if err != nil {
+		return ActionFailed, "", fmt.Errorf("failed to stage %s: %w", config.TargetPath, err)
+	}
+	return action, path, nil
+}
+
+// PreviewAction renders a configuration file without writing it and
+// returns what deploying it would do
+func (cd *ConfigDeployer) PreviewAction(
+	ctx context.Context,
+	config ConfigurationFile,
+	vars templates.TemplateVars) (FileAction, error) {
+
+	action, _, err := cd.preview(ctx, config, vars)
+	return action, err
+}
+
+// preview renders a configuration file without writing it and returns
+// what deploying it would do and the content it would write
+func (cd *ConfigDeployer) preview(
+	ctx context.Context,
+	config ConfigurationFile,
+	vars templates.TemplateVars) (FileAction, string, error) {
+
+	if err := ctx.Err(); err != nil {
+		return ActionFailed, "", err
+	}
+
+	rendered, err := cd.render(config, vars)
+	if err != nil {
+		return ActionFailed, "", fmt.Errorf("failed to process template: %w", err)
+	}
+
+	action, content, _ := cd.plan(config, rendered)
+	return action, content, nil
+}
+
+// PreviewDiff renders a configuration file without writing it and returns
+// what deploying it would do, with a unified diff from the target's current
+// content to the rendering, or to the merge left to resolve for a
+// conflict. The diff is empty when nothing would change.
+func (cd *ConfigDeployer) PreviewDiff(
+	ctx context.Context,
+	config ConfigurationFile,
+	vars templates.TemplateVars) (FileAction, string, error) {
+
+	action, content, err := cd.preview(ctx, config, vars)
+	if err != nil || action == ActionUnchanged {
+		return action, "", err
+	}
+
+	fromName, toName, current := "/dev/null", config.TargetPath, ""
+	if action != ActionCreated {
+		existing, err := os.ReadFile(config.TargetPath)
+		if err != nil {
+			return action, "", fmt.Errorf("failed to read %s: %w", config.TargetPath, err)
+		}
+		fromName, current = config.TargetPath, string(existing)
+	}
+	if action == ActionConflict {
+		toName = config.TargetPath + configuration.MergeFileSuffix
+	}
+
+	return action, configuration.UnifiedDiff(fromName, toName, current, content), nil
+}
+
+// plannedAction compares the rendered content with the target by hash:
+// a missing target is created, a matching one is unchanged and anything
+// else is updated
+func plannedAction(targetPath, rendered string) FileAction {
+	existing, err := fileHash(targetPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ActionCreated
+		}
+		return ActionUpdated
+	}
+
+	renderedHash := sha256.Sum256([]byte(rendered))
+	if bytes.Equal(existing, renderedHash[:]) {
+		return ActionUnchanged
+	}
+	return ActionUpdated
+}
+
+// fileHash returns the SHA-256 of a file's content
+func fileHash(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// DeployConfigurations deploys multiple configuration files with progress
+// reporting, all or nothing. Every file is rendered and staged before any
+// target is written, the targets to replace are backed up as one set, and
+// when a file cannot be written the files written before it are put back
+// as they were. Each file is reported "started" and "processing" as it is
+// staged, and "completed" once all are in place; the file that failed is
+// reported "failed" and the others not at all.
 func (cd *ConfigDeployer) DeployConfigurations(
 	ctx context.Context,
 	configs []ConfigurationFile,
@@ -90,7 +524,23 @@ func (cd *ConfigDeployer) DeployConfigurations(
 		return nil
 	}
 
+	tx, err := cd.newDeployTransaction(vars)
+	if err != nil {
+		return err
+	}
+	defer tx.close()
+
 	totalFiles := len(configs)
+	failed := func(file *stagedFile, percentComplete float64, err error) error {
+		sendDeploymentProgress(ctx, progressChan, DeploymentProgress{
+			FilePath:        file.config.TargetPath,
+			Status:          "failed",
+			PercentComplete: percentComplete,
+			Error:           err,
+			Result:          file.result,
+		})
+		return fmt.Errorf("failed to deploy %s: %w", file.config.TargetPath, err)
+	}
 
 	for i, config := range configs {
 		// Check context
@@ -101,86 +551,85 @@ func (cd *ConfigDeployer) DeployConfigurations(
 		percentComplete := float64(i) / float64(totalFiles) * 100
 
 		// Report started
-		if progressChan != nil {
-			progressChan <- DeploymentProgress{
-				FilePath:        config.TargetPath,
-				Status:          "started",
-				PercentComplete: percentComplete,
-			}
+		sendDeploymentProgress(ctx, progressChan, DeploymentProgress{
+			FilePath:        config.TargetPath,
+			Status:          "started",
+			PercentComplete: percentComplete,
+		})
+
+		// Render and stage the file; nothing is written yet
+		file, err := tx.stage(config)
+		if err != nil {
+			return failed(file, percentComplete, err)
 		}
 
 		// Report processing
-		if progressChan != nil {
-			progressChan <- DeploymentProgress{
-				FilePath:        config.TargetPath,
-				Status:          "processing",
-				PercentComplete: percentComplete + (50.0 / float64(totalFiles)),
-			}
-		}
+		sendDeploymentProgress(ctx, progressChan, DeploymentProgress{
+			FilePath:        config.TargetPath,
+			Status:          "processing",
+			PercentComplete: percentComplete + (50.0 / float64(totalFiles)),
+		})
+	}
 
-		// Deploy the file
-		err := cd.DeployConfiguration(ctx, config, vars)
-		if err != nil {
-			// Report failure
-			if progressChan != nil {
-				progressChan <- DeploymentProgress{
-					FilePath:        config.TargetPath,
-					Status:          "failed",
-					PercentComplete: percentComplete,
-					Error:           err,
-				}
-			}
-			return fmt.Errorf("failed to deploy %s: %w", config.TargetPath, err)
-		}
+	if file, err := tx.backup(ctx); err != nil {
+		return failed(file, 0, err)
+	}
 
-		// Report completed
-		if progressChan != nil {
-			progressChan <- DeploymentProgress{
-				FilePath:        config.TargetPath,
-				Status:          "completed",
-				PercentComplete: float64(i+1) / float64(totalFiles) * 100,
-			}
+	if file, err := tx.commit(ctx); err != nil {
+		if rollbackErr := tx.rollback(); rollbackErr != nil {
+			err = fmt.Errorf("%w; rolling back failed: %v", err, rollbackErr)
+		} else {
+			err = fmt.Errorf("%w; rolled back the files written before it", err)
 		}
+		return failed(file, 0, err)
+	}
+	tx.finish()
+
+	// Report completed
+	for i, file := range tx.files {
+		sendDeploymentProgress(ctx, progressChan, DeploymentProgress{
+			FilePath:        file.config.TargetPath,
+			Status:          "completed",
+			PercentComplete: float64(i+1) / float64(totalFiles) * 100,
+			Result:          file.result,
+		})
 	}
 
 	return nil
 }
 
-// DeployWithBackup deploys a configuration and returns backup information
+// sendDeploymentProgress delivers a progress update unless the channel is nil
+// or the context is cancelled while the receiver is not reading
+func sendDeploymentProgress(ctx context.Context, progressChan chan<- DeploymentProgress, progress DeploymentProgress) {
+	if progressChan == nil {
+		return
+	}
+	select {
+	case progressChan <- progress:
+	case <-ctx.Done():
+	}
+}
+
+// DeployWithBackup deploys a configuration, backing up an existing target
+// whatever the file's BackupBefore setting, and returns what was done
 func (cd *ConfigDeployer) DeployWithBackup(
 	ctx context.Context,
 	config ConfigurationFile,
 	vars templates.TemplateVars) (*DeploymentResult, error) {
 
-	result := &DeploymentResult{
-		FilePath: config.TargetPath,
-	}
-
-	// Check if target exists
-	if _, err := os.Stat(config.TargetPath); err == nil {
-		// Create backup
-		metadata, err := cd.backupService.CreateBackup(
-			ctx,
-			[]string{config.TargetPath},
-			fmt.Sprintf("Deployment backup for %s", config.TargetPath),
-		)
-		if err != nil {
-			result.Error = fmt.Errorf("failed to create backup: %w", err)
-			return result, result.Error
-		}
-
-		result.BackupID = metadata.ID
-		result.BackupPath = metadata.Path
-	}
+	return cd.deploy(ctx, config, vars, true, nil)
+}
 
-	// Deploy
-	if err := cd.DeployConfiguration(ctx, config, vars); err != nil {
-		result.Error = err
-		return result, err
-	}
+// DeployMerged writes content, the template's rendering merged with the
+// user's edits, backing up the target first. The plain rendering is what
+// gets recorded, so the next template version is merged against it.
+func (cd *ConfigDeployer) DeployMerged(
+	ctx context.Context,
+	config ConfigurationFile,
+	vars templates.TemplateVars,
+	content string) (*DeploymentResult, error) {
 
-	result.Success = true
-	return result, nil
+	return cd.deploy(ctx, config, vars, true, func(string) string { return content })
 }
 
 // ListBackups lists all available backups
@@ -5,7 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
@@ -173,6 +175,58 @@ config = /home/alice/.config`
 		// Just verify file was created, permissions may vary by environment
 		assert.NotNil(t, info)
 	})
+
+	t.Run("keeps a copy of the rendered file in the render directory", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		renderDir := filepath.Join(tmpDir, "rendered")
+
+		deployer := setupDeployer(t, filepath.Join(tmpDir, "backups")).WithRenderDir(renderDir)
+
+		templatePath := filepath.Join(tmpDir, "test.conf")
+		require.NoError(t, os.WriteFile(templatePath, []byte("user = {{username}}"), 0644))
+
+		targetPath := filepath.Join(tmpDir, "config", "test.conf")
+		err := deployer.DeployConfiguration(context.Background(), configservice.ConfigurationFile{
+			SourceTemplate: templatePath,
+			TargetPath:     targetPath,
+			Permissions:    0644,
+		}, templates.TemplateVars{"username": "testuser"})
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(renderDir, targetPath))
+		require.NoError(t, err)
+		assert.Equal(t, "user = testuser", string(content))
+	})
+
+	t.Run("stages the file without touching the target", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		stagingDir := filepath.Join(tmpDir, "staged")
+
+		deployer := setupDeployer(t, filepath.Join(tmpDir, "backups"))
+
+		templatePath := filepath.Join(tmpDir, "test.conf")
+		require.NoError(t, os.WriteFile(templatePath, []byte("user = {{username}}"), 0644))
+		targetPath := filepath.Join(tmpDir, "config", "test.conf")
+		require.NoError(t, os.MkdirAll(filepath.Dir(targetPath), 0755))
+		require.NoError(t, os.WriteFile(targetPath, []byte("user = someone"), 0644))
+
+		action, stagedPath, err := deployer.Stage(context.Background(), configservice.ConfigurationFile{
+			SourceTemplate: templatePath,
+			TargetPath:     targetPath,
+			Permissions:    0644,
+		}, templates.TemplateVars{"username": "testuser"}, stagingDir)
+		require.NoError(t, err)
+		assert.Equal(t, configservice.ActionUpdated, action)
+		assert.Equal(t, filepath.Join(stagingDir, targetPath), stagedPath)
+
+		staged, err := os.ReadFile(stagedPath)
+		require.NoError(t, err)
+		assert.Equal(t, "user = testuser", string(staged))
+
+		target, err := os.ReadFile(targetPath)
+		require.NoError(t, err)
+		assert.Equal(t, "user = someone", string(target))
+	})
 }
 
 func TestConfigDeployer_DeployConfigurations(t *testing.T) {
@@ -288,6 +342,11 @@ func TestConfigDeployer_DeployConfigurations(t *testing.T) {
 
 		assert.True(t, hasStarted, "Should report started")
 		assert.True(t, hasCompleted, "Should report completed")
+
+		last := progress[len(progress)-1]
+		require.NotNil(t, last.Result, "completed events carry the file result")
+		assert.Equal(t, configservice.ActionCreated, last.Result.Action)
+		assert.Equal(t, int64(len("test")), last.Result.BytesWritten)
 	})
 
 	t.Run("handles deployment failure gracefully", func(t *testing.T) {
@@ -322,6 +381,278 @@ func TestConfigDeployer_DeployConfigurations(t *testing.T) {
 		err := <-done
 		assert.Error(t, err, "Should return error for invalid template")
 	})
+	t.Run("is all or nothing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		deployer := setupDeployer(t, filepath.Join(tmpDir, "backups"))
+
+		templatePath := filepath.Join(tmpDir, "test.conf")
+		require.NoError(t, os.WriteFile(templatePath, []byte("new"), 0644))
+		existing := filepath.Join(tmpDir, "config", "a.conf")
+		require.NoError(t, os.MkdirAll(filepath.Dir(existing), 0755))
+		require.NoError(t, os.WriteFile(existing, []byte("old"), 0600))
+		created := filepath.Join(tmpDir, "config", "b.conf")
+		// The third target's directory links nowhere, so it cannot be created
+		blocker := filepath.Join(tmpDir, "blocker")
+		require.NoError(t, os.Symlink(filepath.Join(tmpDir, "nowhere"), blocker))
+
+		deploy := func(t *testing.T, third configservice.ConfigurationFile) ([]configservice.DeploymentProgress, error) {
+			t.Helper()
+			configs := []configservice.ConfigurationFile{
+				{SourceTemplate: templatePath, TargetPath: existing, Permissions: 0644, BackupBefore: true},
+				{SourceTemplate: templatePath, TargetPath: created, Permissions: 0644},
+				third,
+			}
+			progressChan := make(chan configservice.DeploymentProgress, 20)
+			err := deployer.DeployConfigurations(context.Background(), configs, templates.TemplateVars{}, progressChan)
+			close(progressChan)
+			var progress []configservice.DeploymentProgress
+			for p := range progressChan {
+				progress = append(progress, p)
+			}
+			return progress, err
+		}
+		assertUntouched := func(t *testing.T) {
+			t.Helper()
+			content, err := os.ReadFile(existing)
+			require.NoError(t, err)
+			assert.Equal(t, "old", string(content))
+			info, err := os.Stat(existing)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			assert.NoFileExists(t, created)
+		}
+
+		t.Run("writes nothing when a template fails to render", func(t *testing.T) {
+			_, err := deploy(t, configservice.ConfigurationFile{
+				SourceTemplate: filepath.Join(tmpDir, "missing.tmpl"),
+				TargetPath:     filepath.Join(tmpDir, "config", "c.conf"),
+			})
+			require.Error(t, err)
+			assertUntouched(t)
+		})
+
+		t.Run("puts written files back when a later one cannot be written", func(t *testing.T) {
+			progress, err := deploy(t, configservice.ConfigurationFile{
+				SourceTemplate: templatePath,
+				TargetPath:     filepath.Join(blocker, "c.conf"),
+				Permissions:    0644,
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "rolled back")
+			assertUntouched(t)
+
+			for _, p := range progress {
+				assert.NotEqual(t, "completed", p.Status)
+			}
+			last := progress[len(progress)-1]
+			assert.Equal(t, "failed", last.Status)
+			assert.Equal(t, filepath.Join(blocker, "c.conf"), last.FilePath)
+		})
+
+		t.Run("leaves the render directory and new directories as they were", func(t *testing.T) {
+			renderDir := filepath.Join(tmpDir, "rendered")
+			require.NoError(t, os.MkdirAll(filepath.Join(renderDir, filepath.Dir(existing)), 0o700))
+			require.NoError(t, os.WriteFile(filepath.Join(renderDir, existing), []byte("earlier"), 0o600))
+			// The third copy's directory is taken by a file, so keeping it
+			// fails after the targets and the other copies are written
+			third := filepath.Join(tmpDir, "new", "dir", "c.conf")
+			require.NoError(t, os.WriteFile(filepath.Join(renderDir, tmpDir, "new"), nil, 0o600))
+			snapshot := func() map[string]string {
+				files := map[string]string{}
+				require.NoError(t, filepath.WalkDir(renderDir, func(path string, entry os.DirEntry, err error) error {
+					if err != nil || entry.IsDir() {
+						files[path] = "directory"
+						return err
+					}
+					content, err := os.ReadFile(path)
+					files[path] = string(content)
+					return err
+				}))
+				return files
+			}
+			before := snapshot()
+
+			configs := []configservice.ConfigurationFile{
+				{SourceTemplate: templatePath, TargetPath: existing, Permissions: 0644, BackupBefore: true},
+				{SourceTemplate: templatePath, TargetPath: created, Permissions: 0644},
+				{SourceTemplate: templatePath, TargetPath: third, Permissions: 0644},
+			}
+			err := deployer.WithRenderDir(renderDir).DeployConfigurations(context.Background(), configs, templates.TemplateVars{}, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "rolled back")
+			assertUntouched(t)
+			assert.NoDirExists(t, filepath.Join(tmpDir, "new"))
+			assert.Equal(t, before, snapshot())
+		})
+
+		t.Run("backs up the replaced files as one set", func(t *testing.T) {
+			other := filepath.Join(tmpDir, "config", "c.conf")
+			require.NoError(t, os.WriteFile(other, []byte("old"), 0644))
+
+			progress, err := deploy(t, configservice.ConfigurationFile{
+				SourceTemplate: templatePath,
+				TargetPath:     other,
+				Permissions:    0644,
+				BackupBefore:   true,
+			})
+			require.NoError(t, err)
+
+			backupIDs := map[string]bool{}
+			for _, p := range progress {
+				if p.Status == "completed" && p.Result.BackupID != "" {
+					backupIDs[p.Result.BackupID] = true
+				}
+			}
+			assert.Len(t, backupIDs, 1)
+			content, err := os.ReadFile(existing)
+			require.NoError(t, err)
+			assert.Equal(t, "new", string(content))
+		})
+	})
+
+	t.Run("does not block on a receiver that stopped reading", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		deployer := setupDeployer(t, filepath.Join(tmpDir, "backups"))
+
+		templatePath := filepath.Join(tmpDir, "test.conf")
+		require.NoError(t, os.WriteFile(templatePath, []byte("test"), 0644))
+
+		configs := []configservice.ConfigurationFile{
+			{SourceTemplate: templatePath, TargetPath: filepath.Join(tmpDir, "config", "a.conf"), Permissions: 0644},
+			{SourceTemplate: templatePath, TargetPath: filepath.Join(tmpDir, "config", "b.conf"), Permissions: 0644},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// Unbuffered and never read
+		progressChan := make(chan configservice.DeploymentProgress)
+
+		err := deployer.DeployConfigurations(ctx, configs, templates.TemplateVars{}, progressChan)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestConfigDeployer_CanceledContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployer := setupDeployer(t, filepath.Join(tmpDir, "backups"))
+
+	templatePath := filepath.Join(tmpDir, "test.conf")
+	require.NoError(t, os.WriteFile(templatePath, []byte("test"), 0644))
+	targetPath := filepath.Join(tmpDir, "config", "test.conf")
+	config := configservice.ConfigurationFile{
+		SourceTemplate: templatePath,
+		TargetPath:     targetPath,
+		Permissions:    0644,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("DeployConfiguration writes nothing", func(t *testing.T) {
+		err := deployer.DeployConfiguration(ctx, config, templates.TemplateVars{})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoFileExists(t, targetPath)
+	})
+
+	t.Run("DeployConfigurations stops before the first file", func(t *testing.T) {
+		err := deployer.DeployConfigurations(ctx, []configservice.ConfigurationFile{config}, templates.TemplateVars{}, nil)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoFileExists(t, targetPath)
+	})
+
+	t.Run("DeployWithBackup", func(t *testing.T) {
+		_, err := deployer.DeployWithBackup(ctx, config, templates.TemplateVars{})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestConfigDeployer_DeployWithBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployer := setupDeployer(t, filepath.Join(tmpDir, "backups"))
+	ctx := context.Background()
+
+	templatePath := filepath.Join(tmpDir, "templates", "kitty.conf.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(templatePath), 0755))
+	require.NoError(t, os.WriteFile(templatePath, []byte("font_size {{size}}"), 0644))
+
+	config := configservice.ConfigurationFile{
+		SourceTemplate: templatePath,
+		TargetPath:     filepath.Join(tmpDir, "config", "kitty.conf"),
+		Permissions:    0644,
+	}
+
+	t.Run("reports a new file as created", func(t *testing.T) {
+		result, err := deployer.DeployWithBackup(ctx, config, templates.TemplateVars{"size": "11"})
+
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, configservice.ActionCreated, result.Action)
+		assert.Equal(t, templatePath, result.SourceTemplate)
+		assert.Equal(t, int64(len("font_size 11")), result.BytesWritten)
+		assert.Empty(t, result.BackupID)
+	})
+
+	t.Run("reports an overwritten file as updated with its backup", func(t *testing.T) {
+		result, err := deployer.DeployWithBackup(ctx, config, templates.TemplateVars{"size": "12"})
+
+		require.NoError(t, err)
+		assert.Equal(t, configservice.ActionUpdated, result.Action)
+		assert.NotEmpty(t, result.BackupID)
+		assert.NotEmpty(t, result.BackupPath)
+	})
+
+	t.Run("skips rewriting and backing up identical content", func(t *testing.T) {
+		before, err := os.Stat(config.TargetPath)
+		require.NoError(t, err)
+		backups, err := deployer.ListBackups(ctx)
+		require.NoError(t, err)
+
+		result, err := deployer.DeployWithBackup(ctx, config, templates.TemplateVars{"size": "12"})
+
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, configservice.ActionUnchanged, result.Action)
+		assert.Empty(t, result.BackupID)
+		assert.Zero(t, result.BytesWritten)
+
+		after, err := os.Stat(config.TargetPath)
+		require.NoError(t, err)
+		assert.Equal(t, before.ModTime(), after.ModTime(), "file should not be rewritten")
+		afterBackups, err := deployer.ListBackups(ctx)
+		require.NoError(t, err)
+		assert.Len(t, afterBackups, len(backups))
+	})
+
+	t.Run("previews the action without writing", func(t *testing.T) {
+		action, err := deployer.PreviewAction(ctx, config, templates.TemplateVars{"size": "12"})
+		require.NoError(t, err)
+		assert.Equal(t, configservice.ActionUnchanged, action)
+
+		action, err = deployer.PreviewAction(ctx, config, templates.TemplateVars{"size": "13"})
+		require.NoError(t, err)
+		assert.Equal(t, configservice.ActionUpdated, action)
+
+		content, err := os.ReadFile(config.TargetPath)
+		require.NoError(t, err)
+		assert.Equal(t, "font_size 12", string(content))
+	})
+
+	t.Run("reports a failed render", func(t *testing.T) {
+		broken := config
+		broken.SourceTemplate = filepath.Join(tmpDir, "templates", "missing.tmpl")
+
+		result, err := deployer.DeployWithBackup(ctx, broken, templates.TemplateVars{})
+
+		require.Error(t, err)
+		assert.False(t, result.Success)
+		assert.Equal(t, configservice.ActionFailed, result.Action)
+		assert.Equal(t, err, result.Error)
+	})
 }
 
 func TestConfigDeployer_ListBackups(t *testing.T) {
@@ -431,6 +762,200 @@ func TestConfigDeployer_RollbackDeployment(t *testing.T) {
 
 // Helper functions
 
+func TestConfigDeployer_WithRecords(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := configservice.NewDeployRecordStore(filepath.Join(tmpDir, configservice.DeployRecordsFileName))
+	deployer := setupDeployer(t, filepath.Join(tmpDir, "backups")).WithRecords(store)
+
+	templatePath := filepath.Join(tmpDir, "test.conf.tmpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte("user = {{username}}\n"), 0644))
+	targetPath := filepath.Join(tmpDir, "config", "test.conf")
+	config := configservice.ConfigurationFile{
+		SourceTemplate: templatePath,
+		TargetPath:     targetPath,
+		Permissions:    0644,
+	}
+	vars := templates.TemplateVars{"username": "alice"}
+
+	t.Run("records the template and its rendering", func(t *testing.T) {
+		require.NoError(t, deployer.DeployConfiguration(context.Background(), config, vars))
+
+		records, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, targetPath, records[0].TargetPath())
+		assert.Equal(t, templatePath, records[0].SourceTemplate())
+		assert.Equal(t, "user = alice\n", records[0].Rendered())
+		assert.Equal(t, "alice", records[0].Vars()["username"])
+		assert.False(t, records[0].IsOutdated(configuration.HashTemplate([]byte("user = {{username}}\n"))))
+	})
+
+	t.Run("records the rendering, not the merged content", func(t *testing.T) {
+		_, err := deployer.DeployMerged(context.Background(), config, vars, "user = alice\nmine\n")
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(targetPath)
+		require.NoError(t, err)
+		assert.Equal(t, "user = alice\nmine\n", string(content))
+
+		records, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "user = alice\n", records[0].Rendered())
+	})
+}
+
+func TestConfigDeployer_ManagedBlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployer := setupDeployer(t, filepath.Join(tmpDir, "backups"))
+	ctx := context.Background()
+
+	templatePath := filepath.Join(tmpDir, "hyprland.conf.tmpl")
+	targetPath := filepath.Join(tmpDir, "config", "hyprland.conf")
+	config := configservice.ConfigurationFile{
+		SourceTemplate: templatePath,
+		TargetPath:     targetPath,
+		Permissions:    0644,
+		BackupBefore:   true,
+		ManagedBlock:   true,
+	}
+	vars := templates.TemplateVars{"gaps": "5"}
+	deployTemplate := func(t *testing.T, template string) *configservice.DeploymentResult {
+		t.Helper()
+		require.NoError(t, os.WriteFile(templatePath, []byte(template), 0644))
+		result, err := deployer.DeployWithBackup(ctx, config, vars)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("wraps the rendering in markers", func(t *testing.T) {
+		result := deployTemplate(t, "gaps_in = {{gaps}}\n")
+		assert.Equal(t, configservice.ActionCreated, result.Action)
+
+		content, err := os.ReadFile(targetPath)
+		require.NoError(t, err)
+		assert.Equal(t, configuration.WrapManagedBlock(targetPath, "gaps_in = 5\n"), string(content))
+	})
+
+	t.Run("redeploying keeps the user's additions", func(t *testing.T) {
+		content, err := os.ReadFile(targetPath)
+		require.NoError(t, err)
+		customized := "# mine\n" + string(content) + "bind = SUPER, B, exec, firefox\n"
+		require.NoError(t, os.WriteFile(targetPath, []byte(customized), 0644))
+
+		action, err := deployer.PreviewAction(ctx, config, vars)
+		require.NoError(t, err)
+		assert.Equal(t, configservice.ActionUnchanged, action, "additions are not drift")
+
+		require.NoError(t, os.WriteFile(templatePath, []byte("gaps_in = {{gaps}}\ngaps_out = 10\n"), 0644))
+		_, diff, err := deployer.PreviewDiff(ctx, config, vars)
+		require.NoError(t, err)
+		assert.NotContains(t, diff, "-bind")
+
+		result := deployTemplate(t, "gaps_in = {{gaps}}\ngaps_out = 10\n")
+		assert.Equal(t, configservice.ActionUpdated, result.Action)
+
+		content, err = os.ReadFile(targetPath)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"# mine\n"+configuration.WrapManagedBlock(targetPath, "gaps_in = 5\ngaps_out = 10\n")+"bind = SUPER, B, exec, firefox\n",
+			string(content))
+	})
+
+	t.Run("files without markers are replaced whole", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(targetPath, []byte("handwritten\n"), 0644))
+
+		result := deployTemplate(t, "gaps_in = {{gaps}}\n")
+		assert.NotEmpty(t, result.BackupID, "the handwritten file is backed up")
+
+		content, err := os.ReadFile(targetPath)
+		require.NoError(t, err)
+		assert.Equal(t, configuration.WrapManagedBlock(targetPath, "gaps_in = 5\n"), string(content))
+	})
+}
+
+func TestConfigDeployer_MergesEdits(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := configservice.NewDeployRecordStore(filepath.Join(tmpDir, configservice.DeployRecordsFileName))
+	deployer := setupDeployer(t, filepath.Join(tmpDir, "backups")).WithRecords(store)
+	ctx := context.Background()
+
+	templatePath := filepath.Join(tmpDir, "kitty.conf.tmpl")
+	targetPath := filepath.Join(tmpDir, "config", "kitty.conf")
+	mergePath := targetPath + configuration.MergeFileSuffix
+	config := configservice.ConfigurationFile{
+		SourceTemplate: templatePath,
+		TargetPath:     targetPath,
+		Permissions:    0644,
+		BackupBefore:   true,
+	}
+	vars := templates.TemplateVars{"size": "11"}
+	deployTemplate := func(t *testing.T, template string) *configservice.DeploymentResult {
+		t.Helper()
+		require.NoError(t, os.WriteFile(templatePath, []byte(template), 0644))
+		result, err := deployer.DeployWithBackup(ctx, config, vars)
+		require.NoError(t, err)
+		return result
+	}
+	readTarget := func(t *testing.T) string {
+		t.Helper()
+		content, err := os.ReadFile(targetPath)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	deployTemplate(t, "font_size {{size}}\ncursor_shape block\nscrollback_lines 2000\nopacity 1.0\n")
+
+	t.Run("merges the template's changes with the user's edits", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(targetPath, []byte("font_size 11\ncursor_shape block\nscrollback_lines 2000\nopacity 0.9\n"), 0644))
+
+		result := deployTemplate(t, "font_size {{size}}\ncursor_shape beam\nscrollback_lines 2000\nopacity 1.0\n")
+		assert.Equal(t, configservice.ActionMerged, result.Action)
+		assert.NotEmpty(t, result.BackupID)
+		assert.Equal(t, "font_size 11\ncursor_shape beam\nscrollback_lines 2000\nopacity 0.9\n", readTarget(t))
+	})
+
+	t.Run("leaves overlapping changes to resolve", func(t *testing.T) {
+		edited := "font_size 11\ncursor_shape underline\nscrollback_lines 2000\nopacity 0.9\n"
+		require.NoError(t, os.WriteFile(targetPath, []byte(edited), 0644))
+		require.NoError(t, os.WriteFile(templatePath, []byte("font_size {{size}}\ncursor_shape block\nscrollback_lines 2000\nopacity 1.0\n"), 0644))
+
+		action, diff, err := deployer.PreviewDiff(ctx, config, vars)
+		require.NoError(t, err)
+		assert.Equal(t, configservice.ActionConflict, action)
+		assert.Contains(t, diff, "+"+configuration.ConflictStart)
+		assert.NoFileExists(t, mergePath, "a preview writes nothing")
+
+		result := deployTemplate(t, "font_size {{size}}\ncursor_shape block\nscrollback_lines 2000\nopacity 1.0\n")
+		assert.Equal(t, configservice.ActionConflict, result.Action)
+		assert.Equal(t, 1, result.Conflicts)
+		assert.Equal(t, mergePath, result.MergePath)
+		assert.Equal(t, edited, readTarget(t), "the user's file is left alone")
+
+		merge, err := os.ReadFile(mergePath)
+		require.NoError(t, err)
+		assert.True(t, configuration.HasConflictMarkers(string(merge)))
+	})
+
+	t.Run("applies the merge once resolved", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(mergePath, []byte("font_size 11\ncursor_shape block\nscrollback_lines 2000\nopacity 0.9\n"), 0644))
+
+		result := deployTemplate(t, "font_size {{size}}\ncursor_shape block\nscrollback_lines 2000\nopacity 1.0\n")
+		assert.Equal(t, configservice.ActionMerged, result.Action)
+		assert.Equal(t, "font_size 11\ncursor_shape block\nscrollback_lines 2000\nopacity 0.9\n", readTarget(t))
+		assert.NoFileExists(t, mergePath)
+	})
+
+	t.Run("replaces files nobody edited", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(targetPath, []byte("font_size 11\ncursor_shape block\nscrollback_lines 2000\nopacity 1.0\n"), 0644))
+		deployTemplate(t, "font_size {{size}}\ncursor_shape block\nscrollback_lines 2000\nopacity 1.0\n")
+
+		result := deployTemplate(t, "font_size {{size}}\ncursor_shape beam\nscrollback_lines 2000\nopacity 1.0\n")
+		assert.Equal(t, configservice.ActionUpdated, result.Action)
+		assert.Equal(t, "font_size 11\ncursor_shape beam\nscrollback_lines 2000\nopacity 1.0\n", readTarget(t))
+	})
+}
+
 func setupDeployer(t *testing.T, backupDir string) *configservice.ConfigDeployer {
 	t.Helper()
 
@@ -0,0 +1,155 @@
+package configservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+)
+
+// DeployRecordsFileName is the deployed template records' file in the
+// gohan data directory
+const DeployRecordsFileName = "deployed-templates.json"
+
+// DeployRecorder keeps a record of each file rendered from a template
+type DeployRecorder interface {
+	Record(record configuration.DeployedTemplate) error
+	// Find returns the latest record for a target, reporting false when
+	// it was never deployed from a template
+	Find(targetPath string) (configuration.DeployedTemplate, bool, error)
+}
+
+// DeployRecordStore keeps the latest deployed template record of each
+// target file in a JSON file
+type DeployRecordStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// deployRecordDTO is a serializable version of DeployedTemplate
+type deployRecordDTO struct {
+	TargetPath     string            `json:"target_path"`
+	SourceTemplate string            `json:"source_template"`
+	TemplateHash   string            `json:"template_hash"`
+	Rendered       string            `json:"rendered"`
+	Vars           map[string]string `json:"vars,omitempty"`
+	DeployedAt     time.Time         `json:"deployed_at"`
+}
+
+// NewDeployRecordStore creates a store backed by the file at path
+func NewDeployRecordStore(path string) *DeployRecordStore {
+	return &DeployRecordStore{path: path}
+}
+
+// Path returns the records file
+func (s *DeployRecordStore) Path() string {
+	return s.path
+}
+
+// Record saves a record, replacing the previous one for its target
+func (s *DeployRecordStore) Record(record configuration.DeployedTemplate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return err
+	}
+	records[record.TargetPath()] = deployRecordDTO{
+		TargetPath:     record.TargetPath(),
+		SourceTemplate: record.SourceTemplate(),
+		TemplateHash:   record.TemplateHash(),
+		Rendered:       record.Rendered(),
+		Vars:           record.Vars(),
+		DeployedAt:     record.DeployedAt(),
+	}
+
+	list := make([]deployRecordDTO, 0, len(records))
+	for _, r := range records {
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].TargetPath < list[j].TargetPath })
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode deployed templates: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write deployed templates: %w", err)
+	}
+	return nil
+}
+
+// List returns the records ordered by target path; none before the first
+// deployment
+func (s *DeployRecordStore) List() ([]configuration.DeployedTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]configuration.DeployedTemplate, 0, len(records))
+	for _, r := range records {
+		record, err := configuration.NewDeployedTemplate(r.TargetPath, r.SourceTemplate, r.TemplateHash, r.Rendered, r.Vars, r.DeployedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct deployed template: %w", err)
+		}
+		list = append(list, record)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].TargetPath() < list[j].TargetPath() })
+	return list, nil
+}
+
+// Find returns the latest record for a target, reporting false when it
+// was never deployed from a template
+func (s *DeployRecordStore) Find(targetPath string) (configuration.DeployedTemplate, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return configuration.DeployedTemplate{}, false, err
+	}
+	r, ok := records[targetPath]
+	if !ok {
+		return configuration.DeployedTemplate{}, false, nil
+	}
+	record, err := configuration.NewDeployedTemplate(r.TargetPath, r.SourceTemplate, r.TemplateHash, r.Rendered, r.Vars, r.DeployedAt)
+	if err != nil {
+		return configuration.DeployedTemplate{}, false, fmt.Errorf("failed to reconstruct deployed template: %w", err)
+	}
+	return record, true, nil
+}
+
+func (s *DeployRecordStore) load() (map[string]deployRecordDTO, error) {
+	records := make(map[string]deployRecordDTO)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployed templates: %w", err)
+	}
+
+	var list []deployRecordDTO
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode deployed templates %s: %w", s.path, err)
+	}
+	for _, r := range list {
+		records[r.TargetPath] = r
+	}
+	return records, nil
+}
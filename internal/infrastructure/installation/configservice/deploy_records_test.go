@@ -0,0 +1,81 @@
+package configservice_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployRecordStore(t *testing.T) {
+	newRecord := func(t *testing.T, target, rendered string) configuration.DeployedTemplate {
+		t.Helper()
+		record, err := configuration.NewDeployedTemplate(
+			target,
+			"/templates/test.tmpl",
+			configuration.HashTemplate([]byte("template")),
+			rendered,
+			map[string]string{"username": "alice"},
+			time.Now().UTC().Truncate(time.Second),
+		)
+		require.NoError(t, err)
+		return record
+	}
+
+	t.Run("lists nothing before the first record", func(t *testing.T) {
+		store := configservice.NewDeployRecordStore(filepath.Join(t.TempDir(), configservice.DeployRecordsFileName))
+
+		records, err := store.List()
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("keeps the latest record of each target", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "data", configservice.DeployRecordsFileName)
+		store := configservice.NewDeployRecordStore(path)
+
+		require.NoError(t, store.Record(newRecord(t, "/home/alice/b.conf", "first")))
+		require.NoError(t, store.Record(newRecord(t, "/home/alice/a.conf", "a")))
+		require.NoError(t, store.Record(newRecord(t, "/home/alice/b.conf", "second")))
+
+		records, err := configservice.NewDeployRecordStore(path).List()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "/home/alice/a.conf", records[0].TargetPath())
+		assert.Equal(t, "/home/alice/b.conf", records[1].TargetPath())
+		assert.Equal(t, "second", records[1].Rendered())
+		assert.Equal(t, "alice", records[1].Vars()["username"])
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("finds a target's latest record", func(t *testing.T) {
+		store := configservice.NewDeployRecordStore(filepath.Join(t.TempDir(), configservice.DeployRecordsFileName))
+		require.NoError(t, store.Record(newRecord(t, "/home/alice/a.conf", "first")))
+		require.NoError(t, store.Record(newRecord(t, "/home/alice/a.conf", "second")))
+
+		record, found, err := store.Find("/home/alice/a.conf")
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, "second", record.Rendered())
+
+		_, found, err = store.Find("/home/alice/b.conf")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("fails on a corrupt file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), configservice.DeployRecordsFileName)
+		require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+		_, err := configservice.NewDeployRecordStore(path).List()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,373 @@
+package configservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+)
+
+// deployTransaction deploys a set of configuration files all or nothing.
+// Every file is rendered into a staging directory before any target is
+// touched, the targets about to be replaced are backed up as one set, and
+// the staged files are then moved into place, followed by the copies kept
+// in the render directory. When a move fails, the targets and copies
+// already written are put back as they were and the directories created
+// for them removed.
+type deployTransaction struct {
+	deployer    *ConfigDeployer
+	vars        templates.TemplateVars
+	stagingDir  string
+	files       []*stagedFile
+	copies      []*renderedCopy // Written under the render directory, in order
+	createdDirs []string        // Created for targets and copies, outermost first
+}
+
+// renderedCopy is a copy of a rendered file kept under the render
+// directory, with what it replaced
+type renderedCopy struct {
+	path     string
+	existed  bool
+	previous []byte
+}
+
+// stagedFile is a configuration file rendered and waiting to be moved
+// into place
+type stagedFile struct {
+	config    ConfigurationFile
+	rendered  string
+	content   string
+	action    FileAction
+	conflicts int
+	staged    string // Staged content, for files that are written
+	target    string // Where the content goes, the target or what it links to
+
+	// What the target held before, to roll back to
+	existed      bool
+	previous     []byte
+	previousMode os.FileMode
+
+	moved     bool
+	mergePath string // Merge left to resolve, for conflicts
+	result    *DeploymentResult
+}
+
+// newDeployTransaction creates a transaction with an empty staging
+// directory; close removes it
+func (cd *ConfigDeployer) newDeployTransaction(vars templates.TemplateVars) (*deployTransaction, error) {
+	dir, err := os.MkdirTemp("", "gohan-deploy-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	return &deployTransaction{deployer: cd, vars: vars, stagingDir: dir}, nil
+}
+
+// close removes the staging directory
+func (tx *deployTransaction) close() {
+	_ = os.RemoveAll(tx.stagingDir)
+}
+
+// stage renders a configuration file, plans what deploying it does and
+// writes the content to the staging directory. The target is only read.
+func (tx *deployTransaction) stage(config ConfigurationFile) (*stagedFile, error) {
+	file := &stagedFile{
+		config: config,
+		target: config.TargetPath,
+		result: &DeploymentResult{
+			FilePath:       config.TargetPath,
+			SourceTemplate: config.SourceTemplate,
+			Action:         ActionFailed,
+		},
+	}
+	fail := func(err error) (*stagedFile, error) {
+		file.result.Error = err
+		return file, err
+	}
+
+	rendered, err := tx.deployer.render(config, tx.vars)
+	if err != nil {
+		return fail(fmt.Errorf("failed to process template: %w", err))
+	}
+	file.rendered = rendered
+	file.action, file.content, file.conflicts = tx.deployer.plan(config, rendered)
+
+	// Writing a linked target writes the file it links to, as in place
+	if resolved, err := filepath.EvalSymlinks(config.TargetPath); err == nil {
+		file.target = resolved
+	}
+	info, err := os.Stat(file.target)
+	switch {
+	case err == nil:
+		previous, err := os.ReadFile(file.target)
+		if err != nil {
+			return fail(fmt.Errorf("failed to read %s: %w", config.TargetPath, err))
+		}
+		file.existed, file.previous, file.previousMode = true, previous, info.Mode().Perm()
+	case !errors.Is(err, fs.ErrNotExist):
+		return fail(fmt.Errorf("failed to read %s: %w", config.TargetPath, err))
+	}
+
+	if file.action == ActionCreated || file.action == ActionUpdated || file.action == ActionMerged {
+		file.staged = filepath.Join(tx.stagingDir, fmt.Sprintf("%d-%s", len(tx.files), filepath.Base(config.TargetPath)))
+		if err := os.WriteFile(file.staged, []byte(file.content), 0o600); err != nil {
+			return fail(fmt.Errorf("failed to stage %s: %w", config.TargetPath, err))
+		}
+	}
+
+	tx.files = append(tx.files, file)
+	return file, nil
+}
+
+// backup backs up, as one set, the staged files' targets that are about
+// to be replaced and want a backup. On failure it returns the first of
+// them with the error.
+func (tx *deployTransaction) backup(ctx context.Context) (*stagedFile, error) {
+	var paths []string
+	var files []*stagedFile
+	for _, file := range tx.files {
+		if file.staged != "" && file.existed && file.config.BackupBefore {
+			paths = append(paths, file.config.TargetPath)
+			files = append(files, file)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	metadata, err := tx.deployer.backupService.CreateBackup(
+		ctx,
+		paths,
+		fmt.Sprintf("Backup before deploying %d configuration files", len(paths)),
+	)
+	if err != nil {
+		err = fmt.Errorf("failed to backup existing files: %w", err)
+		files[0].result.Error = err
+		return files[0], err
+	}
+	for _, file := range files {
+		file.result.BackupID = metadata.ID
+		file.result.BackupPath = metadata.Path
+	}
+	return nil, nil
+}
+
+// commit moves the staged files into place in order, and leaves the merge
+// of each conflict next to its target, then keeps the rendered copies. It
+// stops at the first file that cannot be moved or kept, or when ctx is
+// cancelled, and returns that file and the error; the caller rolls back.
+func (tx *deployTransaction) commit(ctx context.Context) (*stagedFile, error) {
+	for _, file := range tx.files {
+		if err := ctx.Err(); err != nil {
+			return file, fmt.Errorf("context cancelled: %w", err)
+		}
+		if file.action == ActionConflict {
+			mergePath, err := leaveMerge(file.config.TargetPath, file.content)
+			if err != nil {
+				file.result.Error = err
+				return file, err
+			}
+			file.mergePath = mergePath
+			continue
+		}
+		if file.staged == "" {
+			continue
+		}
+
+		created, err := tx.deployer.policy.prepareDir(file.config)
+		tx.createdDirs = append(tx.createdDirs, created...)
+		if err != nil {
+			file.result.Error = err
+			return file, err
+		}
+		if err := moveIntoPlace(file.staged, file.target); err != nil {
+			file.result.Error = err
+			return file, err
+		}
+		file.moved = true
+		if err := tx.deployer.policy.apply(file.config); err != nil {
+			file.result.Error = err
+			return file, err
+		}
+	}
+
+	for _, file := range tx.files {
+		if err := tx.keepRendered(file); err != nil {
+			file.result.Error = err
+			return file, err
+		}
+	}
+	return nil, nil
+}
+
+// keepRendered writes a file's content under the deployer's render
+// directory, if any, remembering what it replaces for rollback
+func (tx *deployTransaction) keepRendered(file *stagedFile) error {
+	if tx.deployer.renderDir == "" {
+		return nil
+	}
+	fail := func(err error) error {
+		return fmt.Errorf("failed to keep rendered output: %w", err)
+	}
+
+	kept := &renderedCopy{path: pathUnder(tx.deployer.renderDir, file.config.TargetPath)}
+	created, err := mkdirAll(filepath.Dir(kept.path), 0o700)
+	tx.createdDirs = append(tx.createdDirs, created...)
+	if err != nil {
+		return fail(err)
+	}
+	previous, err := os.ReadFile(kept.path)
+	switch {
+	case err == nil:
+		kept.existed, kept.previous = true, previous
+	case !errors.Is(err, fs.ErrNotExist):
+		return fail(err)
+	}
+
+	tx.copies = append(tx.copies, kept)
+	if err := os.WriteFile(kept.path, []byte(file.content), 0o600); err != nil {
+		return fail(err)
+	}
+	return nil
+}
+
+// finish records the deployed files and reports what was done to each,
+// once every file is in place. Merges resolved by hand are removed now
+// they are applied.
+func (tx *deployTransaction) finish() {
+	for _, file := range tx.files {
+		result := file.result
+		switch file.action {
+		case ActionConflict:
+			result.Conflicts = file.conflicts
+			result.MergePath = file.mergePath
+		case ActionUnchanged:
+			// Permissions are still enforced; best effort, as in deploy
+			_ = tx.deployer.policy.apply(file.config)
+			tx.deployer.record(file.config, tx.vars, file.rendered)
+		default:
+			tx.deployer.record(file.config, tx.vars, file.rendered)
+			if file.action == ActionMerged {
+				_ = os.Remove(file.config.TargetPath + configuration.MergeFileSuffix)
+			}
+			result.BytesWritten = int64(len(file.content))
+		}
+		result.Action = file.action
+		result.Success = true
+	}
+}
+
+// rollback puts the targets already moved back as they were, last moved
+// first: replaced files get their previous content and mode back and
+// created files are removed, as are the merges left for conflicts. The
+// rendered copies are put back likewise, and the directories created for
+// either removed.
+func (tx *deployTransaction) rollback() error {
+	var errs []error
+	for i := len(tx.files) - 1; i >= 0; i-- {
+		file := tx.files[i]
+		if file.mergePath != "" {
+			_ = os.Remove(file.mergePath)
+		}
+		if !file.moved {
+			continue
+		}
+		if !file.existed {
+			if err := os.Remove(file.target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("failed to remove %s: %w", file.config.TargetPath, err))
+			}
+			continue
+		}
+		if err := writeFileAtomically(file.target, file.previous, file.previousMode); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", file.config.TargetPath, err))
+		}
+	}
+
+	for i := len(tx.copies) - 1; i >= 0; i-- {
+		kept := tx.copies[i]
+		if !kept.existed {
+			if err := os.Remove(kept.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("failed to remove %s: %w", kept.path, err))
+			}
+			continue
+		}
+		if err := writeFileAtomically(kept.path, kept.previous, 0o600); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", kept.path, err))
+		}
+	}
+
+	// Directories something else has written to since are not empty and
+	// stay
+	for i := len(tx.createdDirs) - 1; i >= 0; i-- {
+		_ = os.Remove(tx.createdDirs[i])
+	}
+	return errors.Join(errs...)
+}
+
+// mkdirAll creates dir and its missing parents with mode, and returns
+// those it created, outermost first, even when it fails
+func mkdirAll(dir string, mode os.FileMode) ([]string, error) {
+	var missing []string
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		missing = append(missing, d)
+		if parent := filepath.Dir(d); parent == d {
+			break
+		}
+	}
+
+	var created []string
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := os.Mkdir(missing[i], mode); err == nil {
+			created = append(created, missing[i])
+		} else if !os.IsExist(err) {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// moveIntoPlace replaces target with the staged file by renaming it. When
+// the staging directory is on another filesystem, the content is copied
+// next to the target first, so the replacement is still a single rename.
+func moveIntoPlace(staged, target string) error {
+	if err := os.Rename(staged, target); err == nil {
+		return nil
+	}
+	content, err := os.ReadFile(staged)
+	if err != nil {
+		return fmt.Errorf("failed to read staged %s: %w", target, err)
+	}
+	return writeFileAtomically(target, content, 0o600)
+}
+
+// writeFileAtomically writes content to a temporary file next to path and
+// renames it over path, so path holds either its old or its new content
+func writeFileAtomically(path string, content []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".gohan-")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to set mode of %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package configservice
+
+import (
+	"context"
+
+	"github.com/rebelopsio/gohan/internal/domain/configuration"
+	"github.com/rebelopsio/gohan/internal/domain/maintenance"
+)
+
+// UnitInstaller is the interface for writing and enabling systemd units
+type UnitInstaller interface {
+	WriteUnit(scope maintenance.Scope, name, content string) error
+	Reload(ctx context.Context, scope maintenance.Scope) error
+	EnableUnit(ctx context.Context, scope maintenance.Scope, name string) error
+}
+
+// LoginHook applies deployments deferred to the next login with a systemd
+// user service
+type LoginHook struct {
+	installer UnitInstaller
+	unit      configuration.PendingApplyUnit
+}
+
+// NewLoginHook creates a hook installing unit with installer
+func NewLoginHook(installer UnitInstaller, unit configuration.PendingApplyUnit) *LoginHook {
+	return &LoginHook{installer: installer, unit: unit}
+}
+
+// Install writes the service and enables it without starting it, so the
+// running session is left alone until the next login
+func (h *LoginHook) Install(ctx context.Context) error {
+	if err := h.installer.WriteUnit(maintenance.ScopeUser, configuration.PendingApplyServiceName, h.unit.RenderService()); err != nil {
+		return err
+	}
+	if err := h.installer.Reload(ctx, maintenance.ScopeUser); err != nil {
+		return err
+	}
+	return h.installer.EnableUnit(ctx, maintenance.ScopeUser, configuration.PendingApplyServiceName)
+}
@@ -0,0 +1,293 @@
+package configservice
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+)
+
+// PendingDirName is the directory in the gohan data directory holding a
+// deployment deferred to the next login
+const PendingDirName = "pending"
+
+// pendingManifestName lists the staged files of a pending deployment
+const pendingManifestName = "manifest.json"
+
+// pendingManifest describes a deployment staged to be moved into place
+// later, with what is needed to record it as deployed then
+type pendingManifest struct {
+	DeferredAt time.Time              `json:"deferred_at"`
+	Vars       templates.TemplateVars `json:"vars,omitempty"`
+	Files      []pendingEntry         `json:"files"`
+}
+
+// pendingEntry is one staged file of a pending deployment
+type pendingEntry struct {
+	SourceTemplate string      `json:"source_template"`
+	TargetPath     string      `json:"target_path"`
+	Permissions    os.FileMode `json:"permissions"`
+	BackupBefore   bool        `json:"backup_before,omitempty"`
+	Sensitive      bool        `json:"sensitive,omitempty"`
+	ManagedBlock   bool        `json:"managed_block,omitempty"`
+	Action         FileAction  `json:"action"`
+	Rendered       string      `json:"rendered"`
+	Staged         string      `json:"staged"`                // File name in the pending directory
+	TargetHash     string      `json:"target_hash,omitempty"` // SHA-256 of the target when deferred; empty when it did not exist
+}
+
+// PendingDeployment is a deployment waiting in the pending directory
+type PendingDeployment struct {
+	DeferredAt time.Time
+	Targets    []string
+}
+
+// DeferConfigurations renders configuration files and stages what
+// deploying them would write in dir, to be moved into place by
+// ApplyPending, e.g. at the next login. Targets are not written; a
+// deployment already pending in dir is replaced. A conflict leaves its
+// merge next to the target, as a deployment does, and is not staged.
+func (cd *ConfigDeployer) DeferConfigurations(
+	ctx context.Context,
+	configs []ConfigurationFile,
+	vars templates.TemplateVars,
+	dir string) ([]*DeploymentResult, error) {
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create pending directory: %w", err)
+	}
+	// Staged next to dir, so the finished set replaces it with a rename
+	stagingDir, err := os.MkdirTemp(filepath.Dir(dir), "."+filepath.Base(dir)+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pending directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+	tx := &deployTransaction{deployer: cd, vars: vars, stagingDir: stagingDir}
+
+	results := make([]*DeploymentResult, 0, len(configs))
+	manifest := pendingManifest{DeferredAt: time.Now(), Vars: vars}
+	for _, config := range configs {
+		if err := ctx.Err(); err != nil {
+			return results, fmt.Errorf("context cancelled: %w", err)
+		}
+
+		file, err := tx.stage(config)
+		results = append(results, file.result)
+		if err != nil {
+			return results, fmt.Errorf("failed to stage %s: %w", config.TargetPath, err)
+		}
+
+		file.result.Action = file.action
+		file.result.Success = true
+		switch {
+		case file.action == ActionConflict:
+			mergePath, err := leaveMerge(config.TargetPath, file.content)
+			if err != nil {
+				file.result.Error = err
+				file.result.Action = ActionFailed
+				file.result.Success = false
+				return results, err
+			}
+			file.result.Conflicts = file.conflicts
+			file.result.MergePath = mergePath
+		case file.staged != "":
+			entry := pendingEntry{
+				SourceTemplate: config.SourceTemplate,
+				TargetPath:     config.TargetPath,
+				Permissions:    config.Permissions,
+				BackupBefore:   config.BackupBefore,
+				Sensitive:      config.Sensitive,
+				ManagedBlock:   config.ManagedBlock,
+				Action:         file.action,
+				Rendered:       file.rendered,
+				Staged:         filepath.Base(file.staged),
+			}
+			if file.existed {
+				sum := sha256.Sum256(file.previous)
+				entry.TargetHash = hex.EncodeToString(sum[:])
+			}
+			manifest.Files = append(manifest.Files, entry)
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return results, fmt.Errorf("failed to encode pending deployment: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(stagingDir, pendingManifestName), data, 0o600); err != nil {
+		return results, fmt.Errorf("failed to write pending deployment: %w", err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return results, fmt.Errorf("failed to replace pending deployment: %w", err)
+	}
+	if err := os.Rename(stagingDir, dir); err != nil {
+		return results, fmt.Errorf("failed to write pending deployment: %w", err)
+	}
+	return results, nil
+}
+
+// Pending returns the deployment waiting in dir, reporting false when
+// nothing is pending
+func (cd *ConfigDeployer) Pending(dir string) (PendingDeployment, bool, error) {
+	manifest, found, err := readPendingManifest(dir)
+	if err != nil || !found {
+		return PendingDeployment{}, false, err
+	}
+	pending := PendingDeployment{DeferredAt: manifest.DeferredAt}
+	for _, entry := range manifest.Files {
+		pending.Targets = append(pending.Targets, entry.TargetPath)
+	}
+	sort.Strings(pending.Targets)
+	return pending, true, nil
+}
+
+// ApplyPending moves the deployment waiting in dir into place, all or
+// nothing as DeployConfigurations does, and removes it. A target changed
+// since the deployment was deferred is left alone and reported failed, so
+// the change is not lost; the others are applied. When a file cannot be
+// written, the files written before it are put back and the deployment is
+// kept to retry. It returns nothing when nothing is pending.
+func (cd *ConfigDeployer) ApplyPending(ctx context.Context, dir string) ([]*DeploymentResult, error) {
+	manifest, found, err := readPendingManifest(dir)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	// Moving the files into place consumes them; copies are moved so the
+	// pending deployment survives a failure
+	tx, err := cd.newDeployTransaction(manifest.Vars)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.close()
+
+	results := make([]*DeploymentResult, 0, len(manifest.Files))
+	for i, entry := range manifest.Files {
+		file, err := tx.stagePending(entry, filepath.Join(dir, entry.Staged), i)
+		results = append(results, file.result)
+		if err != nil {
+			return results, err
+		}
+		if file.action != ActionSkipped {
+			tx.files = append(tx.files, file)
+		}
+	}
+
+	if _, err := tx.backup(ctx); err != nil {
+		return results, err
+	}
+	if _, err := tx.commit(ctx); err != nil {
+		if rollbackErr := tx.rollback(); rollbackErr != nil {
+			return results, fmt.Errorf("%w; rolling back failed: %v", err, rollbackErr)
+		}
+		return results, fmt.Errorf("%w; rolled back the files written before it", err)
+	}
+	tx.finish()
+
+	if err := os.RemoveAll(dir); err != nil {
+		return results, fmt.Errorf("failed to remove applied pending deployment: %w", err)
+	}
+	return results, nil
+}
+
+// stagePending copies a pending file to the staging directory. A target
+// that changed since the deployment was deferred is skipped with an error
+// in its result.
+func (tx *deployTransaction) stagePending(entry pendingEntry, pendingPath string, index int) (*stagedFile, error) {
+	config := ConfigurationFile{
+		SourceTemplate: entry.SourceTemplate,
+		TargetPath:     entry.TargetPath,
+		Permissions:    entry.Permissions,
+		BackupBefore:   entry.BackupBefore,
+		Sensitive:      entry.Sensitive,
+		ManagedBlock:   entry.ManagedBlock,
+	}
+	file := &stagedFile{
+		config:   config,
+		rendered: entry.Rendered,
+		action:   entry.Action,
+		target:   entry.TargetPath,
+		result: &DeploymentResult{
+			FilePath:       entry.TargetPath,
+			SourceTemplate: entry.SourceTemplate,
+			Action:         ActionFailed,
+		},
+	}
+
+	content, err := os.ReadFile(pendingPath)
+	if err != nil {
+		err = fmt.Errorf("failed to read pending %s: %w", entry.TargetPath, err)
+		file.result.Error = err
+		return file, err
+	}
+	file.content = string(content)
+
+	if resolved, err := filepath.EvalSymlinks(entry.TargetPath); err == nil {
+		file.target = resolved
+	}
+	current, err := os.ReadFile(file.target)
+	switch {
+	case err == nil:
+		info, err := os.Stat(file.target)
+		if err != nil {
+			file.result.Error = err
+			return file, err
+		}
+		file.existed, file.previous, file.previousMode = true, current, info.Mode().Perm()
+	case !errors.Is(err, fs.ErrNotExist):
+		err = fmt.Errorf("failed to read %s: %w", entry.TargetPath, err)
+		file.result.Error = err
+		return file, err
+	}
+
+	hash := ""
+	if file.existed {
+		sum := sha256.Sum256(current)
+		hash = hex.EncodeToString(sum[:])
+	}
+	if hash != entry.TargetHash {
+		file.action = ActionSkipped
+		file.result.Action = ActionSkipped
+		file.result.Error = fmt.Errorf("%s changed since the deployment was deferred; deploy it again", entry.TargetPath)
+		return file, nil
+	}
+	if file.existed && bytes.Equal(current, content) {
+		file.action = ActionUnchanged
+		return file, nil
+	}
+
+	file.staged = filepath.Join(tx.stagingDir, fmt.Sprintf("%d-%s", index, filepath.Base(entry.TargetPath)))
+	if err := os.WriteFile(file.staged, content, 0o600); err != nil {
+		err = fmt.Errorf("failed to stage %s: %w", entry.TargetPath, err)
+		file.result.Error = err
+		return file, err
+	}
+	return file, nil
+}
+
+// readPendingManifest reads the manifest of the deployment pending in dir,
+// reporting false when there is none
+func readPendingManifest(dir string) (pendingManifest, bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, pendingManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return pendingManifest{}, false, nil
+	}
+	if err != nil {
+		return pendingManifest{}, false, fmt.Errorf("failed to read pending deployment: %w", err)
+	}
+	var manifest pendingManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return pendingManifest{}, false, fmt.Errorf("failed to parse pending deployment: %w", err)
+	}
+	return manifest, true, nil
+}
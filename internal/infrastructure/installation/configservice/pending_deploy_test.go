@@ -0,0 +1,99 @@
+package configservice_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigDeployer_DeferConfigurations(t *testing.T) {
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+	records := configservice.NewDeployRecordStore(filepath.Join(tmpDir, configservice.DeployRecordsFileName))
+	deployer := setupDeployer(t, filepath.Join(tmpDir, "backups")).WithRecords(records)
+	pendingDir := filepath.Join(tmpDir, "data", configservice.PendingDirName)
+
+	hyprTemplate := filepath.Join(tmpDir, "hyprland.conf.tmpl")
+	kittyTemplate := filepath.Join(tmpDir, "kitty.conf.tmpl")
+	require.NoError(t, os.WriteFile(hyprTemplate, []byte("gaps_in = {{gaps}}\n"), 0644))
+	require.NoError(t, os.WriteFile(kittyTemplate, []byte("font_size 11\n"), 0644))
+
+	hyprPath := filepath.Join(tmpDir, "config", "hypr", "hyprland.conf")
+	kittyPath := filepath.Join(tmpDir, "config", "kitty", "kitty.conf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(hyprPath), 0755))
+	require.NoError(t, os.WriteFile(hyprPath, []byte("gaps_in = 2\n"), 0644))
+	configs := []configservice.ConfigurationFile{
+		{SourceTemplate: hyprTemplate, TargetPath: hyprPath, Permissions: 0644, BackupBefore: true},
+		{SourceTemplate: kittyTemplate, TargetPath: kittyPath, Permissions: 0644, BackupBefore: true},
+	}
+	vars := templates.TemplateVars{"gaps": "5"}
+
+	t.Run("stages the files without touching the targets", func(t *testing.T) {
+		results, err := deployer.DeferConfigurations(ctx, configs, vars, pendingDir)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, configservice.ActionUpdated, results[0].Action)
+		assert.Equal(t, configservice.ActionCreated, results[1].Action)
+
+		content, err := os.ReadFile(hyprPath)
+		require.NoError(t, err)
+		assert.Equal(t, "gaps_in = 2\n", string(content))
+		assert.NoFileExists(t, kittyPath)
+
+		pending, found, err := deployer.Pending(pendingDir)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.ElementsMatch(t, []string{hyprPath, kittyPath}, pending.Targets)
+	})
+
+	t.Run("applies the pending deployment and removes it", func(t *testing.T) {
+		results, err := deployer.ApplyPending(ctx, pendingDir)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, configservice.ActionUpdated, results[0].Action)
+		assert.NotEmpty(t, results[0].BackupID, "the replaced file is backed up")
+		assert.Equal(t, configservice.ActionCreated, results[1].Action)
+
+		content, err := os.ReadFile(hyprPath)
+		require.NoError(t, err)
+		assert.Equal(t, "gaps_in = 5\n", string(content))
+		assert.FileExists(t, kittyPath)
+
+		record, found, err := records.Find(hyprPath)
+		require.NoError(t, err)
+		require.True(t, found, "applied files are recorded as deployed")
+		assert.Equal(t, hyprTemplate, record.SourceTemplate())
+
+		_, found, err = deployer.Pending(pendingDir)
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.NoDirExists(t, pendingDir)
+
+		results, err = deployer.ApplyPending(ctx, pendingDir)
+		require.NoError(t, err)
+		assert.Empty(t, results, "nothing is pending")
+	})
+
+	t.Run("leaves targets changed since alone", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(hyprTemplate, []byte("gaps_in = {{gaps}}\ngaps_out = 10\n"), 0644))
+		_, err := deployer.DeferConfigurations(ctx, configs[:1], vars, pendingDir)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(hyprPath, []byte("gaps_in = 8\n"), 0644))
+		results, err := deployer.ApplyPending(ctx, pendingDir)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, configservice.ActionSkipped, results[0].Action)
+		assert.ErrorContains(t, results[0].Error, "changed since the deployment was deferred")
+
+		content, err := os.ReadFile(hyprPath)
+		require.NoError(t, err)
+		assert.Equal(t, "gaps_in = 8\n", string(content))
+	})
+}
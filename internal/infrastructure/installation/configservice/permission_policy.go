@@ -0,0 +1,188 @@
+package configservice
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultDirMode is the mode for created directories before the umask
+	defaultDirMode os.FileMode = 0755
+	// defaultUmask is assumed when the process umask cannot be read
+	defaultUmask os.FileMode = 0022
+	// strictFileMode and strictDirMode restrict sensitive files under a strict policy
+	strictFileMode os.FileMode = 0600
+	strictDirMode  os.FileMode = 0700
+)
+
+// FileOwner is the user and group that deployed files should belong to
+type FileOwner struct {
+	UID int
+	GID int
+}
+
+// PermissionPolicy decides the modes and ownership of deployed files and of
+// the directories created for them
+type PermissionPolicy struct {
+	// Umask bits are cleared from every mode
+	Umask os.FileMode
+
+	// DirMode is the mode for created directories before the umask
+	DirMode os.FileMode
+
+	// StrictSensitive writes sensitive files (lock screen settings) as 0600
+	// and restricts the directory holding them to 0700
+	StrictSensitive bool
+
+	// Owner receives written files and created directories; nil keeps the
+	// process owner. Set when running privileged on behalf of a user.
+	Owner *FileOwner
+}
+
+// DefaultPermissionPolicy respects the process umask and, under sudo, hands
+// deployed files to the invoking user
+func DefaultPermissionPolicy() PermissionPolicy {
+	return PermissionPolicy{
+		Umask:   CurrentUmask(),
+		DirMode: defaultDirMode,
+		Owner:   SudoOwner(),
+	}
+}
+
+// FileMode returns the mode a configuration file is written with
+func (p PermissionPolicy) FileMode(config ConfigurationFile) os.FileMode {
+	mode := config.Permissions
+	if mode == 0 {
+		mode = 0644
+	}
+	if p.StrictSensitive && config.Sensitive {
+		mode = strictFileMode
+	}
+	return mode.Perm() &^ p.Umask
+}
+
+// DirModeFor returns the mode of the directory holding a configuration file
+func (p PermissionPolicy) DirModeFor(config ConfigurationFile) os.FileMode {
+	mode := p.DirMode
+	if mode == 0 {
+		mode = defaultDirMode
+	}
+	if p.StrictSensitive && config.Sensitive {
+		mode = strictDirMode
+	}
+	return mode.Perm() &^ p.Umask
+}
+
+// prepareDir creates the missing directories above a configuration file
+// with the policy's mode and owner, and returns those it created, outermost
+// first, even when it fails. Existing directories are left alone, except
+// that a strict policy restricts the one holding a sensitive file.
+func (p PermissionPolicy) prepareDir(config ConfigurationFile) ([]string, error) {
+	dir := filepath.Dir(config.TargetPath)
+	mode := p.DirModeFor(config)
+	parentMode := p.DirModeFor(ConfigurationFile{})
+
+	var missing []string
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		missing = append(missing, d)
+		if parent := filepath.Dir(d); parent == d {
+			break
+		}
+	}
+
+	var created []string
+	for i := len(missing) - 1; i >= 0; i-- {
+		dirMode := parentMode
+		if missing[i] == dir {
+			dirMode = mode
+		}
+		if err := os.Mkdir(missing[i], dirMode); err == nil {
+			created = append(created, missing[i])
+		} else if !os.IsExist(err) {
+			return created, fmt.Errorf("failed to create directory %s: %w", missing[i], err)
+		}
+		// Mkdir applies the process umask; set the policy's mode exactly
+		if err := os.Chmod(missing[i], dirMode); err != nil {
+			return created, fmt.Errorf("failed to set mode of %s: %w", missing[i], err)
+		}
+		if err := p.chown(missing[i]); err != nil {
+			return created, err
+		}
+	}
+
+	if p.StrictSensitive && config.Sensitive {
+		if err := os.Chmod(dir, mode); err != nil {
+			return created, fmt.Errorf("failed to restrict %s: %w", dir, err)
+		}
+	}
+
+	return created, nil
+}
+
+// apply sets a written file's mode and owner
+func (p PermissionPolicy) apply(config ConfigurationFile) error {
+	if err := os.Chmod(config.TargetPath, p.FileMode(config)); err != nil {
+		return fmt.Errorf("failed to set mode of %s: %w", config.TargetPath, err)
+	}
+	return p.chown(config.TargetPath)
+}
+
+// chown hands path to the policy owner, if any
+func (p PermissionPolicy) chown(path string) error {
+	if p.Owner == nil {
+		return nil
+	}
+	if err := os.Lchown(path, p.Owner.UID, p.Owner.GID); err != nil {
+		return fmt.Errorf("failed to set owner of %s: %w", path, err)
+	}
+	return nil
+}
+
+// CurrentUmask reads the process umask from /proc without changing it,
+// falling back to 022
+func CurrentUmask() os.FileMode {
+	f, err := os.Open("/proc/self/status")
+	if err != nil {
+		return defaultUmask
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(scanner.Text(), "Umask:")
+		if !ok {
+			continue
+		}
+		umask, err := strconv.ParseUint(strings.TrimSpace(value), 8, 32)
+		if err != nil {
+			return defaultUmask
+		}
+		return os.FileMode(umask).Perm()
+	}
+	return defaultUmask
+}
+
+// SudoOwner returns the invoking user when running as root under sudo, or
+// nil otherwise
+func SudoOwner() *FileOwner {
+	if os.Geteuid() != 0 {
+		return nil
+	}
+
+	uid, err := strconv.Atoi(os.Getenv("SUDO_UID"))
+	if err != nil {
+		return nil
+	}
+	gid, err := strconv.Atoi(os.Getenv("SUDO_GID"))
+	if err != nil {
+		return nil
+	}
+	return &FileOwner{UID: uid, GID: gid}
+}
@@ -0,0 +1,113 @@
+package configservice_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionPolicy_Modes(t *testing.T) {
+	config := configservice.ConfigurationFile{Permissions: 0664}
+	sensitive := configservice.ConfigurationFile{Permissions: 0644, Sensitive: true}
+
+	t.Run("clears umask bits", func(t *testing.T) {
+		policy := configservice.PermissionPolicy{Umask: 0027, DirMode: 0755}
+
+		assert.Equal(t, os.FileMode(0640), policy.FileMode(config))
+		assert.Equal(t, os.FileMode(0750), policy.DirModeFor(config))
+	})
+
+	t.Run("restricts sensitive files only when strict", func(t *testing.T) {
+		relaxed := configservice.PermissionPolicy{Umask: 0022, DirMode: 0755}
+		strict := relaxed
+		strict.StrictSensitive = true
+
+		assert.Equal(t, os.FileMode(0644), relaxed.FileMode(sensitive))
+		assert.Equal(t, os.FileMode(0600), strict.FileMode(sensitive))
+		assert.Equal(t, os.FileMode(0700), strict.DirModeFor(sensitive))
+		assert.Equal(t, os.FileMode(0644), strict.FileMode(config))
+		assert.Equal(t, os.FileMode(0755), strict.DirModeFor(config))
+	})
+
+	t.Run("defaults unset modes", func(t *testing.T) {
+		policy := configservice.PermissionPolicy{}
+
+		assert.Equal(t, os.FileMode(0644), policy.FileMode(configservice.ConfigurationFile{}))
+		assert.Equal(t, os.FileMode(0755), policy.DirModeFor(config))
+	})
+}
+
+func TestConfigDeployer_AppliesPermissionPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	templatePath := filepath.Join(tmpDir, "templates", "hyprlock.conf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(templatePath), 0755))
+	require.NoError(t, os.WriteFile(templatePath, []byte("general {}"), 0644))
+
+	policy := configservice.PermissionPolicy{Umask: 0022, DirMode: 0755, StrictSensitive: true}
+	deployer := setupDeployer(t, filepath.Join(tmpDir, "backups")).WithPermissionPolicy(policy)
+	ctx := context.Background()
+
+	t.Run("creates sensitive files and their directory owner-only", func(t *testing.T) {
+		target := filepath.Join(tmpDir, "config", "hypr", "hyprlock.conf")
+		err := deployer.DeployConfiguration(ctx, configservice.ConfigurationFile{
+			SourceTemplate: templatePath,
+			TargetPath:     target,
+			Permissions:    0644,
+			Sensitive:      true,
+		}, templates.TemplateVars{})
+		require.NoError(t, err)
+
+		info, err := os.Stat(target)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		dirInfo, err := os.Stat(filepath.Dir(target))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0700), dirInfo.Mode().Perm())
+
+		// Only the directory holding the file is restricted
+		parentInfo, err := os.Stat(filepath.Join(tmpDir, "config"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), parentInfo.Mode().Perm())
+	})
+
+	t.Run("tightens an unchanged file left too open", func(t *testing.T) {
+		target := filepath.Join(tmpDir, "config", "hypr", "hyprlock.conf")
+		require.NoError(t, os.Chmod(target, 0666))
+
+		err := deployer.DeployConfiguration(ctx, configservice.ConfigurationFile{
+			SourceTemplate: templatePath,
+			TargetPath:     target,
+			Permissions:    0644,
+			Sensitive:      true,
+		}, templates.TemplateVars{})
+		require.NoError(t, err)
+
+		info, err := os.Stat(target)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("hands files to the policy owner", func(t *testing.T) {
+		owner := &configservice.FileOwner{UID: os.Getuid(), GID: os.Getgid()}
+		owned := deployer.WithPermissionPolicy(configservice.PermissionPolicy{Umask: 0022, Owner: owner})
+
+		target := filepath.Join(tmpDir, "owned", "app.conf")
+		err := owned.DeployConfiguration(ctx, configservice.ConfigurationFile{
+			SourceTemplate: templatePath,
+			TargetPath:     target,
+			Permissions:    0644,
+		}, templates.TemplateVars{})
+		require.NoError(t, err)
+
+		info, err := os.Stat(target)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+	})
+}
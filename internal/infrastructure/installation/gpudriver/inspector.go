@@ -0,0 +1,220 @@
+package gpudriver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// DefaultInitramfsTimeout bounds update-initramfs, which rebuilds the
+// image of every installed kernel
+const DefaultInitramfsTimeout = 5 * time.Minute
+
+// modprobeDirs are where modprobe reads its configuration, relative to the
+// root; files in earlier directories override those of the same name in
+// later ones
+var modprobeDirs = []string{"etc/modprobe.d", "run/modprobe.d", "lib/modprobe.d", "usr/lib/modprobe.d"}
+
+// driverPackages are the packages whose installation shows a driver is
+// installed
+var driverPackages = map[installation.ComponentName]string{
+	installation.ComponentNVIDIADriver: "nvidia-driver",
+	installation.ComponentAMDDriver:    "firmware-amd-graphics",
+}
+
+// Inspector reads the kernel setup of the GPU drivers from modprobe's
+// configuration, the kernel command line and the loaded modules, and
+// rebuilds the initramfs after the drivers are installed
+type Inspector struct {
+	root string
+}
+
+// NewInspector creates an inspector for the running system
+func NewInspector() *Inspector {
+	return NewInspectorForRoot("/")
+}
+
+// NewInspectorForRoot creates an inspector reading /etc, /proc and /sys
+// under root
+func NewInspectorForRoot(root string) *Inspector {
+	return &Inspector{root: root}
+}
+
+// Inspect returns the kernel setup found for driver
+func (i *Inspector) Inspect(driver installation.ComponentName) (installation.GPUDriverState, error) {
+	options, err := i.modprobeOptions()
+	if err != nil {
+		return installation.GPUDriverState{}, err
+	}
+	cmdline, err := i.read("proc/cmdline")
+	if err != nil {
+		return installation.GPUDriverState{}, err
+	}
+	loaded, err := i.loadedModules()
+	if err != nil {
+		return installation.GPUDriverState{}, err
+	}
+
+	blacklisted := options.blacklisted[installation.ModuleNouveau] ||
+		hasKernelParameter(cmdline, "modprobe.blacklist", installation.ModuleNouveau) ||
+		hasKernelParameter(cmdline, "nouveau.modeset", "0")
+	modeset := options.nvidiaModeset || hasKernelParameter(cmdline, "nvidia-drm.modeset", "1")
+	if current, err := i.read("sys/module/nvidia_drm/parameters/modeset"); err == nil && strings.TrimSpace(current) == "Y" {
+		modeset = true
+	}
+
+	return installation.NewGPUDriverState(driver, blacklisted, modeset, loaded)
+}
+
+// InstalledDrivers returns the GPU drivers that need kernel setup and
+// whose packages are installed
+func (i *Inspector) InstalledDrivers(ctx context.Context) []installation.ComponentName {
+	var drivers []installation.ComponentName
+	for _, driver := range []installation.ComponentName{installation.ComponentNVIDIADriver, installation.ComponentAMDDriver} {
+		output, err := exec.CommandContext(ctx, "dpkg-query", "-W", "-f=${Status}", driverPackages[driver]).Output()
+		if err == nil && strings.Contains(string(output), "install ok installed") {
+			drivers = append(drivers, driver)
+		}
+	}
+	return drivers
+}
+
+// UpdateInitramfs rebuilds the initramfs so the drivers and the modprobe
+// configuration in it are current at the next boot
+func (i *Inspector) UpdateInitramfs(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultInitramfsTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "update-initramfs", "-u").CombinedOutput()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("update-initramfs did not finish: %w", ctxErr)
+	}
+	if err != nil {
+		return fmt.Errorf("update-initramfs failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// modprobeOptions is what modprobe's configuration says about the modules
+// GPU drivers care about
+type modprobeOptions struct {
+	blacklisted   map[string]bool
+	nvidiaModeset bool
+}
+
+// modprobeOptions reads the .conf files modprobe would use
+func (i *Inspector) modprobeOptions() (modprobeOptions, error) {
+	options := modprobeOptions{blacklisted: make(map[string]bool)}
+	seen := make(map[string]bool)
+	for _, dir := range modprobeDirs {
+		entries, err := os.ReadDir(filepath.Join(i.root, dir))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return modprobeOptions{}, fmt.Errorf("failed to list %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || filepath.Ext(name) != ".conf" || seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			content, err := os.ReadFile(filepath.Join(i.root, dir, name))
+			if err != nil {
+				return modprobeOptions{}, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			options.parse(string(content))
+		}
+	}
+	return options, nil
+}
+
+// parse adds the blacklist and option lines of a modprobe.d file
+func (o *modprobeOptions) parse(content string) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		module := strings.ReplaceAll(fields[1], "-", "_")
+		switch fields[0] {
+		case "blacklist":
+			o.blacklisted[module] = true
+		case "install":
+			// "install nouveau /bin/false" keeps it from loading too
+			if len(fields) > 2 && (fields[2] == "/bin/false" || fields[2] == "/bin/true") {
+				o.blacklisted[module] = true
+			}
+		case "options":
+			for _, option := range fields[2:] {
+				if module == installation.ModuleNVIDIADRM && option == "modeset=1" {
+					o.nvidiaModeset = true
+				}
+				if module == installation.ModuleNouveau && option == "modeset=0" {
+					o.blacklisted[module] = true
+				}
+			}
+		}
+	}
+}
+
+// loadedModules returns the modules listed in /proc/modules
+func (i *Inspector) loadedModules() ([]string, error) {
+	content, err := i.read("proc/modules")
+	if err != nil {
+		return nil, err
+	}
+
+	var modules []string
+	for _, line := range strings.Split(content, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			modules = append(modules, fields[0])
+		}
+	}
+	return modules, nil
+}
+
+// read returns a file under the root; a missing file reads as empty
+func (i *Inspector) read(path string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(i.root, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read /%s: %w", path, err)
+	}
+	return string(content), nil
+}
+
+// hasKernelParameter returns true if the kernel command line sets key to
+// value, or lists value among key's comma-separated values. Module
+// parameters accept dashes and underscores alike.
+func hasKernelParameter(cmdline, key, value string) bool {
+	normalize := func(s string) string { return strings.ReplaceAll(s, "-", "_") }
+	for _, parameter := range strings.Fields(cmdline) {
+		k, v, ok := strings.Cut(parameter, "=")
+		if !ok || normalize(k) != normalize(key) {
+			continue
+		}
+		for _, item := range strings.Split(v, ",") {
+			if item == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package gpudriver_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/gpudriver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+	require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+}
+
+func TestInspector_Inspect(t *testing.T) {
+	t.Run("reads the blacklist and modeset from modprobe.d", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "etc/modprobe.d/blacklist-nouveau.conf", "# keep nouveau away\nblacklist nouveau\n")
+		writeFile(t, root, "lib/modprobe.d/nvidia.conf", "options nvidia-drm modeset=1\n")
+		writeFile(t, root, "proc/cmdline", "BOOT_IMAGE=/vmlinuz root=/dev/sda1 quiet\n")
+		writeFile(t, root, "proc/modules", "nvidia_drm 90112 4 - Live 0x0\nnvidia 56487936 2 nvidia_drm, Live 0x0\n")
+
+		state, err := gpudriver.NewInspectorForRoot(root).Inspect(installation.ComponentNVIDIADriver)
+		require.NoError(t, err)
+		assert.True(t, state.NouveauBlacklisted())
+		assert.True(t, state.ModesetEnabled())
+		assert.Equal(t, []string{"nvidia", "nvidia_drm"}, state.LoadedModules())
+		assert.False(t, state.RebootRequired())
+	})
+
+	t.Run("reads the kernel command line", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "proc/cmdline", "quiet modprobe.blacklist=pcspkr,nouveau nvidia_drm.modeset=1\n")
+
+		state, err := gpudriver.NewInspectorForRoot(root).Inspect(installation.ComponentNVIDIADriver)
+		require.NoError(t, err)
+		assert.True(t, state.NouveauBlacklisted())
+		assert.True(t, state.ModesetEnabled())
+	})
+
+	t.Run("files in /etc override those of the same name", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "etc/modprobe.d/nvidia.conf", "# disabled\n")
+		writeFile(t, root, "usr/lib/modprobe.d/nvidia.conf", "blacklist nouveau\noptions nvidia-drm modeset=1\n")
+
+		state, err := gpudriver.NewInspectorForRoot(root).Inspect(installation.ComponentNVIDIADriver)
+		require.NoError(t, err)
+		assert.False(t, state.NouveauBlacklisted())
+		assert.False(t, state.ModesetEnabled())
+	})
+
+	t.Run("reports nouveau still loaded before a reboot", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "etc/modprobe.d/nvidia.conf", "blacklist nouveau\noptions nvidia-drm modeset=1\n")
+		writeFile(t, root, "proc/modules", "nouveau 2998272 3 - Live 0x0\n")
+
+		state, err := gpudriver.NewInspectorForRoot(root).Inspect(installation.ComponentNVIDIADriver)
+		require.NoError(t, err)
+		assert.Empty(t, state.ConfigurationProblems())
+		assert.True(t, state.RebootRequired())
+	})
+
+	t.Run("rejects drivers without kernel setup", func(t *testing.T) {
+		_, err := gpudriver.NewInspectorForRoot(t.TempDir()).Inspect(installation.ComponentIntelDriver)
+		assert.ErrorIs(t, err, installation.ErrInvalidGPUSupport)
+	})
+}
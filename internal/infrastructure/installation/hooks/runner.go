@@ -0,0 +1,112 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// ErrHookFailed is returned when a hook exits with an error or runs past
+// its timeout
+var ErrHookFailed = errors.New("hook failed")
+
+// deployLogName is the log of hooks run by deployments outside an
+// installation session
+const deployLogName = "config-deploy"
+
+// Runner runs the shell commands the user configured for each hook phase.
+// Their output is appended to a log per installation session, so it can be
+// read after the run whether or not they succeeded.
+type Runner struct {
+	commands map[installation.HookPhase][]string
+	logDir   string
+	timeout  time.Duration
+	now      func() time.Time
+}
+
+// NewRunner creates a runner for the commands of each phase, logging to
+// <logDir>/<session-id>.log
+func NewRunner(commands map[installation.HookPhase][]string, logDir string) (*Runner, error) {
+	copied := make(map[installation.HookPhase][]string, len(commands))
+	for phase, list := range commands {
+		if !phase.IsValid() {
+			return nil, fmt.Errorf("%w: %q", installation.ErrInvalidHookPhase, phase)
+		}
+		copied[phase] = append([]string(nil), list...)
+	}
+	return &Runner{commands: copied, logDir: logDir, now: time.Now}, nil
+}
+
+// WithTimeout returns a copy of the runner stopping each command after
+// timeout; 0 lets commands run as long as they take
+func (r *Runner) WithTimeout(timeout time.Duration) *Runner {
+	copied := *r
+	copied.timeout = timeout
+	return &copied
+}
+
+// LogPath returns the log the hooks of a session are written to
+func (r *Runner) LogPath(sessionID string) string {
+	if sessionID == "" {
+		sessionID = deployLogName
+	}
+	return filepath.Join(r.logDir, sessionID+".log")
+}
+
+// Run runs the commands of the invocation's phase one after another with
+// sh -c, stopping at the first that fails. Each gets gohan's environment
+// plus the invocation's GOHAN_* variables.
+func (r *Runner) Run(ctx context.Context, invocation installation.HookInvocation) error {
+	commands := r.commands[invocation.Phase()]
+	if len(commands) == 0 {
+		return nil
+	}
+
+	logPath := r.LogPath(invocation.SessionID())
+	if err := os.MkdirAll(r.logDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create hook log directory: %w", err)
+	}
+	log, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open hook log: %w", err)
+	}
+	defer log.Close()
+
+	env := append(os.Environ(), invocation.Environment()...)
+	for _, command := range commands {
+		fmt.Fprintf(log, "== %s %s: %s ==\n", r.now().Format(time.RFC3339), invocation.Phase(), command)
+
+		runErr := r.run(ctx, command, env, log)
+		if runErr != nil {
+			fmt.Fprintf(log, "== failed: %v ==\n\n", runErr)
+			return fmt.Errorf("%w: %s hook %q: %v (output in %s)", ErrHookFailed, invocation.Phase(), command, runErr, logPath)
+		}
+		fmt.Fprintf(log, "== done ==\n\n")
+	}
+	return nil
+}
+
+// run runs one command with its standard output and error going to log
+func (r *Runner) run(ctx context.Context, command string, env []string, log *os.File) error {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = env
+	cmd.Stdout = log
+	cmd.Stderr = log
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", r.timeout)
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+package hooks_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/hooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_Run(t *testing.T) {
+	ctx := context.Background()
+	invocation, err := installation.NewHookInvocation(installation.HookPostDeploy, "3f2a9c", []string{"hyprland"})
+	require.NoError(t, err)
+
+	t.Run("logs the output of each command with the session variables", func(t *testing.T) {
+		logDir := t.TempDir()
+		runner, err := hooks.NewRunner(map[installation.HookPhase][]string{
+			installation.HookPostDeploy: {"echo deployed $GOHAN_COMPONENTS", "echo for $GOHAN_SESSION_ID >&2"},
+		}, logDir)
+		require.NoError(t, err)
+
+		require.NoError(t, runner.Run(ctx, invocation))
+
+		log, err := os.ReadFile(runner.LogPath("3f2a9c"))
+		require.NoError(t, err)
+		assert.Contains(t, string(log), "post_deploy: echo deployed $GOHAN_COMPONENTS ==\ndeployed hyprland\n== done ==")
+		assert.Contains(t, string(log), "for 3f2a9c\n")
+	})
+
+	t.Run("stops at the first failing command", func(t *testing.T) {
+		logDir := t.TempDir()
+		marker := logDir + "/ran"
+		runner, err := hooks.NewRunner(map[installation.HookPhase][]string{
+			installation.HookPostDeploy: {"echo broken; exit 3", "touch " + marker},
+		}, logDir)
+		require.NoError(t, err)
+
+		err = runner.Run(ctx, invocation)
+
+		assert.ErrorIs(t, err, hooks.ErrHookFailed)
+		assert.ErrorContains(t, err, runner.LogPath("3f2a9c"))
+		assert.NoFileExists(t, marker)
+	})
+
+	t.Run("stops a command past the timeout", func(t *testing.T) {
+		runner, err := hooks.NewRunner(map[installation.HookPhase][]string{
+			installation.HookPostDeploy: {"sleep 5"},
+		}, t.TempDir())
+		require.NoError(t, err)
+
+		err = runner.WithTimeout(50*time.Millisecond).Run(ctx, invocation)
+
+		assert.ErrorIs(t, err, hooks.ErrHookFailed)
+		assert.ErrorContains(t, err, "timed out")
+	})
+
+	t.Run("does nothing for phases without hooks", func(t *testing.T) {
+		logDir := t.TempDir()
+		runner, err := hooks.NewRunner(nil, logDir)
+		require.NoError(t, err)
+
+		require.NoError(t, runner.Run(ctx, invocation))
+		assert.NoFileExists(t, runner.LogPath("3f2a9c"))
+	})
+
+	t.Run("rejects unknown phases", func(t *testing.T) {
+		_, err := hooks.NewRunner(map[installation.HookPhase][]string{"post_reboot": {"true"}}, t.TempDir())
+		assert.ErrorIs(t, err, installation.ErrInvalidHookPhase)
+	})
+}
@@ -0,0 +1,108 @@
+package nightlight
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/weather"
+)
+
+// ErrNoCoordinates is returned when the tz database has no coordinates for
+// the system timezone
+var ErrNoCoordinates = errors.New("no coordinates for timezone")
+
+// zoneTables are the tz database tables listing each timezone's principal
+// location, the newer one first
+var zoneTables = []string{"zone1970.tab", "zone.tab"}
+
+// LocationResolver finds the location the night light computes sunrise and
+// sunset for. Configured coordinates are used as is; otherwise those of the
+// system timezone's principal city are read from the tz database, so
+// nothing is looked up over the network.
+type LocationResolver struct {
+	latitude  float64
+	longitude float64
+	timezone  func() (string, error)
+	zoneDir   string
+}
+
+// NewLocationResolver creates a resolver for the configured coordinates,
+// reading the running system's timezone when both are zero
+func NewLocationResolver(latitude, longitude float64) *LocationResolver {
+	return &LocationResolver{
+		latitude:  latitude,
+		longitude: longitude,
+		timezone:  weather.SystemTimezone,
+		zoneDir:   "/usr/share/zoneinfo",
+	}
+}
+
+// WithTimezone returns a copy of the resolver that uses the given timezone,
+// looked up in the tables in zoneDir, instead of the system's
+func (r *LocationResolver) WithTimezone(timezone, zoneDir string) *LocationResolver {
+	copied := *r
+	copied.timezone = func() (string, error) { return timezone, nil }
+	copied.zoneDir = zoneDir
+	return &copied
+}
+
+// Location returns the configured coordinates, or those of the system
+// timezone when none are configured
+func (r *LocationResolver) Location() (installation.NightLightLocation, error) {
+	if r.latitude != 0 || r.longitude != 0 {
+		return installation.NewNightLightLocation(r.latitude, r.longitude)
+	}
+
+	timezone, err := r.timezone()
+	if err != nil {
+		return installation.NightLightLocation{}, err
+	}
+
+	for _, table := range zoneTables {
+		coordinates, err := findCoordinates(filepath.Join(r.zoneDir, table), timezone)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return installation.NightLightLocation{}, err
+		}
+		if coordinates != "" {
+			return installation.ParseISO6709Coordinates(coordinates)
+		}
+	}
+	return installation.NightLightLocation{}, fmt.Errorf(
+		"%w %s; set night_light.latitude and night_light.longitude instead", ErrNoCoordinates, timezone)
+}
+
+// findCoordinates returns the coordinates column of timezone's row in a
+// zone table, or "" when it has none
+func findCoordinates(path, timezone string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		// country codes, coordinates, timezone, comments
+		fields := strings.Split(line, "\t")
+		if len(fields) >= 3 && fields[2] == timezone {
+			return fields[1], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return "", nil
+}
@@ -0,0 +1,49 @@
+package nightlight_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/nightlight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationResolver_Location(t *testing.T) {
+	zoneDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(zoneDir, "zone1970.tab"), []byte(
+		"# tz zone descriptions\n"+
+			"DE,DK,NO,SE,SJ\t+5230+01322\tEurope/Berlin\tmost of Germany\n"+
+			"AR\t-3436-05827\tAmerica/Argentina/Buenos_Aires\tBuenos Aires (BA, CF)\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(zoneDir, "zone.tab"), []byte(
+		"DK\t+5540+01235\tEurope/Copenhagen\n"), 0644))
+
+	t.Run("prefers the configured coordinates", func(t *testing.T) {
+		location, err := nightlight.NewLocationResolver(53.55, 9.99).WithTimezone("Europe/Berlin", zoneDir).Location()
+
+		require.NoError(t, err)
+		assert.Equal(t, 53.55, location.Latitude())
+	})
+
+	t.Run("reads the coordinates of the timezone", func(t *testing.T) {
+		location, err := nightlight.NewLocationResolver(0, 0).WithTimezone("America/Argentina/Buenos_Aires", zoneDir).Location()
+
+		require.NoError(t, err)
+		assert.InDelta(t, -34.6, location.Latitude(), 0.01)
+		assert.InDelta(t, -58.45, location.Longitude(), 0.01)
+	})
+
+	t.Run("falls back to the older table", func(t *testing.T) {
+		location, err := nightlight.NewLocationResolver(0, 0).WithTimezone("Europe/Copenhagen", zoneDir).Location()
+
+		require.NoError(t, err)
+		assert.InDelta(t, 55.67, location.Latitude(), 0.01)
+	})
+
+	t.Run("fails for a timezone without coordinates", func(t *testing.T) {
+		_, err := nightlight.NewLocationResolver(0, 0).WithTimezone("Etc/UTC", zoneDir).Location()
+
+		assert.ErrorIs(t, err, nightlight.ErrNoCoordinates)
+	})
+}
@@ -1,11 +1,17 @@
 package packagemanager
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 )
@@ -13,7 +19,28 @@ import (
 // APTManager implements package management operations using APT
 // Implements installation.ConflictResolver interface
 type APTManager struct {
-	dryRun bool
+	dryRun   bool
+	timeouts Timeouts
+	launcher launcher
+	options  Options
+	backend  Backend // Nil installs with apt-get
+}
+
+// Timeouts bounds how long each kind of apt and dpkg call may run, so a
+// stuck mirror or lock cannot hang an installation. Zero disables a bound.
+type Timeouts struct {
+	Install time.Duration // apt-get install, download and remove
+	Update  time.Duration // apt-get update
+	Query   time.Duration // dpkg-query and apt-cache lookups
+}
+
+// DefaultTimeouts returns the bounds used unless configured otherwise
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Install: 30 * time.Minute,
+		Update:  10 * time.Minute,
+		Query:   30 * time.Second,
+	}
 }
 
 // PackageInfo contains information about a package
@@ -27,31 +54,182 @@ type PackageInfo struct {
 // PackageProgress represents progress for a single package installation
 type PackageProgress struct {
 	PackageName    string
-	Status         string // "started", "installing", "completed", "failed"
+	Status         string // One of the Status constants
 	PercentComplete float64
 	Error          error
+
+	// Reported while apt-get runs; see StatusParser
+	Message        string        // apt's description of the step
+	BytesPerSecond float64       // Download speed; 0 when unknown
+	Remaining      time.Duration // Download time left by apt's estimate; 0 when unknown
 }
 
 // ProgressStatus constants for package installation
 const (
-	StatusStarted    = "started"
-	StatusInstalling = "installing"
-	StatusCompleted  = "completed"
-	StatusFailed     = "failed"
+	StatusStarted     = "started"
+	StatusDownloading = "downloading"
+	StatusInstalling  = "installing"
+	StatusUnpacking   = "unpacking"
+	StatusConfiguring = "configuring"
+	StatusCompleted   = "completed"
+	StatusFailed      = "failed"
 )
 
 // NewAPTManager creates a new APT package manager
 func NewAPTManager() *APTManager {
 	return &APTManager{
-		dryRun: false,
+		dryRun:   false,
+		timeouts: DefaultTimeouts(),
 	}
 }
 
 // NewAPTManagerDryRun creates a new APT manager in dry-run mode (for testing)
 func NewAPTManagerDryRun() *APTManager {
 	return &APTManager{
-		dryRun: true,
+		dryRun:   true,
+		timeouts: DefaultTimeouts(),
+	}
+}
+
+// WithTimeouts returns a copy of the manager using the given bounds
+func (a *APTManager) WithTimeouts(timeouts Timeouts) *APTManager {
+	copied := *a
+	copied.timeouts = timeouts
+	return &copied
+}
+
+// WithOptions returns a copy of the manager that passes the given apt
+// options to every apt-get and apt-cache call
+func (a *APTManager) WithOptions(options Options) *APTManager {
+	copied := *a
+	copied.options = options
+	return &copied
+}
+
+// WithBackend returns a copy of the manager that installs, downloads and
+// removes packages and updates the package lists with backend
+func (a *APTManager) WithBackend(backend Backend) *APTManager {
+	copied := *a
+	copied.backend = backend
+	return &copied
+}
+
+// WithPriority returns a copy of the manager that runs apt and dpkg with
+// the given CPU and IO priority
+func (a *APTManager) WithPriority(priority Priority) *APTManager {
+	copied := *a
+	copied.launcher = newLauncher(priority)
+	return &copied
+}
+
+// run executes an apt or dpkg command bounded by timeout and returns its
+// combined output. Cancellation and timeouts are reported as such instead
+// of as a killed process.
+func (a *APTManager) run(ctx context.Context, timeout time.Duration, name string, args ...string) ([]byte, error) {
+	return a.runReporting(ctx, timeout, nil, name, args...)
+}
+
+// runReporting runs an apt-get command like run, passing the progress it
+// reports to report as the command goes. A nil report runs it like run.
+func (a *APTManager) runReporting(ctx context.Context, timeout time.Duration, report func(PackageProgress), name string, args ...string) ([]byte, error) {
+	commandArgs := args
+	if takesAptOptions(name) && len(a.options) > 0 {
+		commandArgs = append(a.options.Args(), args...)
+	}
+	if report != nil {
+		commandArgs = append([]string{"-o", statusFdOption}, commandArgs...)
+	}
+	return a.runCommand(ctx, timeout, report, name, args[0], commandArgs)
+}
+
+// runBackend runs op on a package with the manager's backend, passing it
+// the apt options. Progress is passed to report only if the backend writes
+// apt's status lines.
+func (a *APTManager) runBackend(ctx context.Context, timeout time.Duration, report func(PackageProgress), op Operation, pkg string) ([]byte, error) {
+	backend := a.currentBackend()
+	if !backend.ReportsStatus() {
+		report = nil
+	}
+
+	options := a.options.Args()
+	if report != nil {
+		options = append([]string{"-o", statusFdOption}, options...)
+	}
+	return a.runCommand(ctx, timeout, report, backend.Program(), op.String(), backend.Args(op, pkg, options))
+}
+
+// runCommand runs a command with its final arguments, bounded by timeout.
+// subcommand names the call in timeout errors.
+func (a *APTManager) runCommand(ctx context.Context, timeout time.Duration, report func(PackageProgress), name, subcommand string, commandArgs []string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	command, commandArgs := a.launcher.command(name, commandArgs...)
+	cmd := exec.CommandContext(ctx, command, commandArgs...)
+	var output []byte
+	var err error
+	if report == nil {
+		output, err = cmd.CombinedOutput()
+	} else {
+		output, err = streamOutput(cmd, NewStatusParser(), report)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if errors.Is(ctxErr, context.DeadlineExceeded) {
+			return output, fmt.Errorf("%s %s timed out after %s: %w", name, subcommand, timeout, ctxErr)
+		}
+		return output, ctxErr
+	}
+	return output, err
+}
+
+// currentBackend returns the configured backend, apt-get unless set
+func (a *APTManager) currentBackend() Backend {
+	if a.backend == nil {
+		return aptGetBackend{}
+	}
+	return a.backend
+}
+
+// streamOutput runs cmd, passing each progress report parsed from its
+// combined output to report as the lines are written, and returns the
+// whole output
+func streamOutput(cmd *exec.Cmd, parser *StatusParser, report func(PackageProgress)) ([]byte, error) {
+	pipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var output bytes.Buffer
+	scanner := bufio.NewScanner(pipe)
+	for scanner.Scan() {
+		output.Write(scanner.Bytes())
+		output.WriteByte('\n')
+		if progress, ok := parser.Parse(scanner.Text()); ok {
+			report(progress)
+		}
 	}
+	// Drain whatever did not scan, such as an overlong line, so the
+	// command is not blocked writing
+	_, _ = io.Copy(&output, pipe)
+
+	return output.Bytes(), cmd.Wait()
+}
+
+// isContextError reports whether a command failed because it was cancelled
+// or timed out rather than because of its exit status
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
 
 // DetectConflicts implements installation.ConflictResolver
@@ -64,8 +242,10 @@ func (a *APTManager) DetectConflicts(ctx context.Context, components []installat
 		packageName := componentToPackageName(comp.Component())
 
 		// Check for conflicts using dpkg
-		cmd := exec.CommandContext(ctx, "dpkg", "-s", packageName)
-		output, err := cmd.CombinedOutput()
+		output, err := a.run(ctx, a.timeouts.Query, "dpkg", "-s", packageName)
+		if isContextError(err) {
+			return nil, err
+		}
 
 		if err == nil {
 			// Package exists, check for conflicts
@@ -102,12 +282,19 @@ func (a *APTManager) ResolveConflict(ctx context.Context, conflict installation.
 
 // InstallPackage installs a package using APT
 func (a *APTManager) InstallPackage(ctx context.Context, packageName, version string) error {
+	return a.InstallPackageWithProgress(ctx, packageName, version, nil)
+}
+
+// InstallPackageWithProgress installs a package using APT, passing the
+// download, unpack and configure progress of it and its dependencies to
+// report as apt-get goes
+func (a *APTManager) InstallPackageWithProgress(ctx context.Context, packageName, version string, report func(PackageProgress)) error {
 	if packageName == "" {
 		return errors.New("package name cannot be empty")
 	}
 
 	if a.dryRun {
-		return nil
+		return ctx.Err()
 	}
 
 	// If version is specified, append it to package name
@@ -116,8 +303,7 @@ func (a *APTManager) InstallPackage(ctx context.Context, packageName, version st
 		fullPackageName = fmt.Sprintf("%s=%s", packageName, version)
 	}
 
-	cmd := exec.CommandContext(ctx, "apt-get", "install", "-y", fullPackageName)
-	output, err := cmd.CombinedOutput()
+	output, err := a.runBackend(ctx, a.timeouts.Install, report, OperationInstall, fullPackageName)
 	if err != nil {
 		return fmt.Errorf("failed to install package %s: %w\nOutput: %s", fullPackageName, err, string(output))
 	}
@@ -125,6 +311,36 @@ func (a *APTManager) InstallPackage(ctx context.Context, packageName, version st
 	return nil
 }
 
+// DownloadPackage fetches a package and its dependencies into the APT cache
+// without installing them
+func (a *APTManager) DownloadPackage(ctx context.Context, packageName, version string) error {
+	return a.DownloadPackageWithProgress(ctx, packageName, version, nil)
+}
+
+// DownloadPackageWithProgress fetches a package and its dependencies like
+// DownloadPackage, passing the download progress to report as apt-get goes
+func (a *APTManager) DownloadPackageWithProgress(ctx context.Context, packageName, version string, report func(PackageProgress)) error {
+	if packageName == "" {
+		return errors.New("package name cannot be empty")
+	}
+
+	if a.dryRun {
+		return ctx.Err()
+	}
+
+	fullPackageName := packageName
+	if version != "" {
+		fullPackageName = fmt.Sprintf("%s=%s", packageName, version)
+	}
+
+	output, err := a.runBackend(ctx, a.timeouts.Install, report, OperationDownload, fullPackageName)
+	if err != nil {
+		return fmt.Errorf("failed to download package %s: %w\nOutput: %s", fullPackageName, err, string(output))
+	}
+
+	return nil
+}
+
 // RemovePackage removes a package using APT
 func (a *APTManager) RemovePackage(ctx context.Context, packageName string) error {
 	if packageName == "" {
@@ -132,11 +348,10 @@ func (a *APTManager) RemovePackage(ctx context.Context, packageName string) erro
 	}
 
 	if a.dryRun {
-		return nil
+		return ctx.Err()
 	}
 
-	cmd := exec.CommandContext(ctx, "apt-get", "remove", "-y", packageName)
-	output, err := cmd.CombinedOutput()
+	output, err := a.runBackend(ctx, a.timeouts.Install, nil, OperationRemove, packageName)
 	if err != nil {
 		return fmt.Errorf("failed to remove package %s: %w\nOutput: %s", packageName, err, string(output))
 	}
@@ -150,9 +365,10 @@ func (a *APTManager) IsPackageInstalled(ctx context.Context, packageName string)
 		return false, errors.New("package name cannot be empty")
 	}
 
-	cmd := exec.CommandContext(ctx, "dpkg-query", "-W", "-f=${Status}", packageName)
-	output, err := cmd.CombinedOutput()
-
+	output, err := a.run(ctx, a.timeouts.Query, "dpkg-query", "-W", "-f=${Status}", packageName)
+	if isContextError(err) {
+		return false, err
+	}
 	if err != nil {
 		// Package not found
 		return false, nil
@@ -162,14 +378,146 @@ func (a *APTManager) IsPackageInstalled(ctx context.Context, packageName string)
 	return strings.Contains(status, "install ok installed"), nil
 }
 
+// binaryDirs are where a package's executables must end up to be usable
+var binaryDirs = []string{"/usr/bin/", "/usr/sbin/", "/bin/", "/sbin/"}
+
+// VerifyPackage checks that a package is fully installed according to dpkg
+// and that every executable it ships exists and is executable. Each problem
+// found is returned as one line of the error.
+func (a *APTManager) VerifyPackage(ctx context.Context, packageName string) error {
+	if packageName == "" {
+		return errors.New("package name cannot be empty")
+	}
+
+	if a.dryRun {
+		return ctx.Err()
+	}
+
+	installed, err := a.IsPackageInstalled(ctx, packageName)
+	if err != nil {
+		return err
+	}
+	if !installed {
+		return fmt.Errorf("dpkg does not list %s as installed", packageName)
+	}
+
+	output, err := a.run(ctx, a.timeouts.Query, "dpkg", "-L", packageName)
+	if err != nil {
+		return fmt.Errorf("failed to list files of %s: %w", packageName, err)
+	}
+
+	var problems []string
+	for _, path := range strings.Split(string(output), "\n") {
+		path = strings.TrimSpace(path)
+		if !isBinaryPath(path) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("missing binary %s", path))
+			continue
+		}
+		if !info.IsDir() && info.Mode().Perm()&0111 == 0 {
+			problems = append(problems, fmt.Sprintf("%s is not executable", path))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+// isBinaryPath reports whether a dpkg file list entry is an executable
+// location rather than one of the directories themselves
+func isBinaryPath(path string) bool {
+	for _, dir := range binaryDirs {
+		if rest, ok := strings.CutPrefix(path, dir); ok && rest != "" && !strings.Contains(rest, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPackageAvailable checks if a package has an installation candidate in
+// the configured repositories
+func (a *APTManager) IsPackageAvailable(ctx context.Context, packageName string) (bool, error) {
+	if packageName == "" {
+		return false, errors.New("package name cannot be empty")
+	}
+
+	// Dry runs must not depend on the host's repositories
+	if a.dryRun {
+		return true, ctx.Err()
+	}
+
+	output, err := a.run(ctx, a.timeouts.Query, "apt-cache", "policy", packageName)
+	if err != nil {
+		return false, fmt.Errorf("failed to query package policy: %w", err)
+	}
+
+	return hasInstallCandidate(string(output)), nil
+}
+
+// hasInstallCandidate parses apt-cache policy output for a usable candidate
+func hasInstallCandidate(policyOutput string) bool {
+	for _, line := range strings.Split(policyOutput, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if candidate, ok := strings.CutPrefix(trimmed, "Candidate:"); ok {
+			candidate = strings.TrimSpace(candidate)
+			return candidate != "" && candidate != "(none)"
+		}
+	}
+	return false
+}
+
+// ResolvePackage returns the candidate version of a package and its
+// installed size, as apt-get install would install it now
+func (a *APTManager) ResolvePackage(ctx context.Context, packageName string) (installation.PackageInfo, error) {
+	if packageName == "" {
+		return installation.PackageInfo{}, errors.New("package name cannot be empty")
+	}
+
+	output, err := a.run(ctx, a.timeouts.Query, "apt-cache", "show", "--no-all-versions", packageName)
+	if isContextError(err) {
+		return installation.PackageInfo{}, err
+	}
+	if err != nil {
+		return installation.PackageInfo{}, fmt.Errorf("no installation candidate for %s", packageName)
+	}
+
+	return ParseCandidate(packageName, string(output))
+}
+
+// ParseCandidate reads the version and installed size from apt-cache show
+// output. Installed-Size is given in KiB.
+func ParseCandidate(packageName, output string) (installation.PackageInfo, error) {
+	var version string
+	var sizeBytes uint64
+	for _, line := range strings.Split(output, "\n") {
+		if value, ok := strings.CutPrefix(line, "Version:"); ok && version == "" {
+			version = strings.TrimSpace(value)
+		}
+		if value, ok := strings.CutPrefix(line, "Installed-Size:"); ok && sizeBytes == 0 {
+			if kib, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64); err == nil {
+				sizeBytes = kib * 1024
+			}
+		}
+	}
+
+	if version == "" {
+		return installation.PackageInfo{}, fmt.Errorf("no version in apt-cache output for %s", packageName)
+	}
+	return installation.NewPackageInfo(packageName, version, sizeBytes, nil)
+}
+
 // UpdatePackageCache updates the APT package cache
 func (a *APTManager) UpdatePackageCache(ctx context.Context) error {
 	if a.dryRun {
-		return nil
+		return ctx.Err()
 	}
 
-	cmd := exec.CommandContext(ctx, "apt-get", "update")
-	output, err := cmd.CombinedOutput()
+	output, err := a.runBackend(ctx, a.timeouts.Update, nil, OperationUpdate, "")
 	if err != nil {
 		return fmt.Errorf("failed to update package cache: %w\nOutput: %s", err, string(output))
 	}
@@ -183,9 +531,10 @@ func (a *APTManager) GetPackageInfo(ctx context.Context, packageName string) (*P
 		return nil, errors.New("package name cannot be empty")
 	}
 
-	cmd := exec.CommandContext(ctx, "dpkg-query", "-W", "-f=${Package}|${Version}|${Architecture}|${Description}", packageName)
-	output, err := cmd.CombinedOutput()
-
+	output, err := a.run(ctx, a.timeouts.Query, "dpkg-query", "-W", "-f=${Package}|${Version}|${Architecture}|${Description}", packageName)
+	if isContextError(err) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("package not found: %s", packageName)
 	}
@@ -308,51 +657,61 @@ func (a *APTManager) InstallPackages(ctx context.Context, packages []string, pro
 		percentComplete := float64(i) / float64(totalPackages) * 100
 
 		// Report started
-		if progressChan != nil {
-			progressChan <- PackageProgress{
-				PackageName:     pkg,
-				Status:          StatusStarted,
-				PercentComplete: percentComplete,
-			}
-		}
+		sendProgress(ctx, progressChan, PackageProgress{
+			PackageName:     pkg,
+			Status:          StatusStarted,
+			PercentComplete: percentComplete,
+		})
 
 		// Report installing
-		if progressChan != nil {
-			progressChan <- PackageProgress{
-				PackageName:     pkg,
-				Status:          StatusInstalling,
-				PercentComplete: percentComplete + (50.0 / float64(totalPackages)),
+		sendProgress(ctx, progressChan, PackageProgress{
+			PackageName:     pkg,
+			Status:          StatusInstalling,
+			PercentComplete: percentComplete + (50.0 / float64(totalPackages)),
+		})
+
+		// Install the package, passing on apt's progress within its share
+		err := a.InstallPackageWithProgress(ctx, pkg, "", func(progress PackageProgress) {
+			if progress.PackageName == "" {
+				progress.PackageName = pkg
 			}
-		}
-
-		// Install the package
-		err := a.InstallPackage(ctx, pkg, "")
+			progress.PercentComplete = percentComplete + progress.PercentComplete/float64(totalPackages)
+			sendProgress(ctx, progressChan, progress)
+		})
 		if err != nil {
 			// Report failure
-			if progressChan != nil {
-				progressChan <- PackageProgress{
-					PackageName:     pkg,
-					Status:          StatusFailed,
-					PercentComplete: percentComplete,
-					Error:           err,
-				}
-			}
+			sendProgress(ctx, progressChan, PackageProgress{
+				PackageName:     pkg,
+				Status:          StatusFailed,
+				PercentComplete: percentComplete,
+				Error:           err,
+			})
 			return fmt.Errorf("failed to install %s: %w", pkg, err)
 		}
 
 		// Report completed
-		if progressChan != nil {
-			progressChan <- PackageProgress{
-				PackageName:     pkg,
-				Status:          StatusCompleted,
-				PercentComplete: float64(i+1) / float64(totalPackages) * 100,
-			}
-		}
+		sendProgress(ctx, progressChan, PackageProgress{
+			PackageName:     pkg,
+			Status:          StatusCompleted,
+			PercentComplete: float64(i+1) / float64(totalPackages) * 100,
+		})
 	}
 
 	return nil
 }
 
+// sendProgress delivers a progress update unless the channel is nil or the
+// context is cancelled while the receiver is not reading
+func sendProgress(ctx context.Context, progressChan chan<- PackageProgress, progress PackageProgress) {
+	if progressChan == nil {
+		return
+	}
+	select {
+	case progressChan <- progress:
+	case <-ctx.Done():
+	}
+}
+
 // InstallProfile installs packages for a specific profile (minimal/recommended/full)
 func (a *APTManager) InstallProfile(ctx context.Context, profileName string, progressChan chan<- PackageProgress) error {
 	if profileName == "" {
@@ -3,6 +3,7 @@ package packagemanager_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
@@ -130,6 +131,24 @@ func TestAPTManager_InstallPackage(t *testing.T) {
 	})
 }
 
+func TestAPTManager_DownloadPackage(t *testing.T) {
+	t.Run("validates package name", func(t *testing.T) {
+		manager := packagemanager.NewAPTManager()
+
+		err := manager.DownloadPackage(context.Background(), "", "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("dry run does not download", func(t *testing.T) {
+		manager := packagemanager.NewAPTManagerDryRun()
+
+		err := manager.DownloadPackage(context.Background(), "hyprland", "0.35.0")
+
+		assert.NoError(t, err)
+	})
+}
+
 func TestAPTManager_RemovePackage(t *testing.T) {
 	t.Run("validates package name", func(t *testing.T) {
 		manager := packagemanager.NewAPTManager()
@@ -152,6 +171,17 @@ func TestAPTManager_RemovePackage(t *testing.T) {
 	})
 }
 
+func TestAPTManager_IsPackageAvailable(t *testing.T) {
+	t.Run("validates package name", func(t *testing.T) {
+		manager := packagemanager.NewAPTManager()
+		ctx := context.Background()
+
+		_, err := manager.IsPackageAvailable(ctx, "")
+
+		assert.Error(t, err)
+	})
+}
+
 func TestAPTManager_IsPackageInstalled(t *testing.T) {
 	t.Run("validates package name", func(t *testing.T) {
 		manager := packagemanager.NewAPTManager()
@@ -224,6 +254,91 @@ func TestAPTManager_GetPackageInfo(t *testing.T) {
 	})
 }
 
+func TestAPTManager_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	managers := map[string]*packagemanager.APTManager{
+		"real":    packagemanager.NewAPTManager(),
+		"dry run": packagemanager.NewAPTManagerDryRun(),
+	}
+
+	for name, manager := range managers {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, manager.InstallPackage(ctx, "coreutils", ""), context.Canceled)
+			assert.ErrorIs(t, manager.DownloadPackage(ctx, "coreutils", ""), context.Canceled)
+			assert.ErrorIs(t, manager.RemovePackage(ctx, "coreutils"), context.Canceled)
+			assert.ErrorIs(t, manager.UpdatePackageCache(ctx), context.Canceled)
+		})
+	}
+
+	t.Run("queries report cancellation instead of a missing package", func(t *testing.T) {
+		manager := packagemanager.NewAPTManager()
+
+		_, err := manager.IsPackageInstalled(ctx, "coreutils")
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = manager.GetPackageInfo(ctx, "coreutils")
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = manager.IsPackageAvailable(ctx, "coreutils")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestAPTManager_WithTimeouts(t *testing.T) {
+	t.Run("reports a query that exceeds its timeout", func(t *testing.T) {
+		manager := packagemanager.NewAPTManager().WithTimeouts(packagemanager.Timeouts{Query: time.Nanosecond})
+
+		_, err := manager.IsPackageInstalled(context.Background(), "coreutils")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "timed out")
+	})
+
+	t.Run("returns a copy", func(t *testing.T) {
+		manager := packagemanager.NewAPTManager()
+
+		_ = manager.WithTimeouts(packagemanager.Timeouts{Query: time.Nanosecond})
+
+		installed, err := manager.IsPackageInstalled(context.Background(), "coreutils")
+		require.NoError(t, err)
+		assert.True(t, installed)
+	})
+}
+
+func TestAPTManager_VerifyPackage(t *testing.T) {
+	t.Run("rejects an empty package name", func(t *testing.T) {
+		manager := packagemanager.NewAPTManager()
+
+		err := manager.VerifyPackage(context.Background(), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("skips checks in dry run", func(t *testing.T) {
+		manager := packagemanager.NewAPTManagerDryRun()
+
+		err := manager.VerifyPackage(context.Background(), "nonexistent-package-xyz123")
+		assert.NoError(t, err)
+	})
+
+	t.Run("passes an installed package with its binaries", func(t *testing.T) {
+		manager := packagemanager.NewAPTManager()
+
+		err := manager.VerifyPackage(context.Background(), "coreutils")
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails a package dpkg does not list", func(t *testing.T) {
+		manager := packagemanager.NewAPTManager()
+
+		err := manager.VerifyPackage(context.Background(), "nonexistent-package-xyz123")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not list")
+	})
+}
+
 // ========================================
 // Phase 3.1: Batch Installation Tests
 // ========================================
@@ -425,6 +540,19 @@ func TestAPTManager_InstallPackages_ProgressReporting(t *testing.T) {
 		assert.Error(t, err, "Should return error when context is cancelled")
 		assert.Contains(t, err.Error(), "context", "Error should mention context")
 	})
+
+	t.Run("does not block on a receiver that stopped reading", func(t *testing.T) {
+		manager := packagemanager.NewAPTManagerDryRun()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// Unbuffered and never read
+		progressChan := make(chan packagemanager.PackageProgress)
+
+		err := manager.InstallPackages(ctx, []string{"pkg1", "pkg2"}, progressChan)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
 }
 
 func TestAPTManager_InstallProfile(t *testing.T) {
@@ -483,3 +611,20 @@ func TestAPTManager_InstallProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCandidate(t *testing.T) {
+	output := `Package: hyprland
+Version: 0.41.2+ds-1
+Installed-Size: 7423
+Depends: libc6 (>= 2.38)
+Description: dynamic tiling Wayland compositor
+`
+
+	info, err := packagemanager.ParseCandidate("hyprland", output)
+	require.NoError(t, err)
+	assert.Equal(t, "0.41.2+ds-1", info.Version())
+	assert.Equal(t, uint64(7423*1024), info.SizeBytes())
+
+	_, err = packagemanager.ParseCandidate("missing", "")
+	assert.Error(t, err)
+}
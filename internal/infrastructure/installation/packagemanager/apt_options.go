@@ -0,0 +1,54 @@
+package packagemanager
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidAptOption is returned for an apt option apt would not accept
+// or that would spill into other arguments
+var ErrInvalidAptOption = errors.New("invalid apt option")
+
+// Options are apt configuration items, such as Acquire::http::Proxy, passed
+// with -o to every apt-get and apt-cache call. They apply on top of
+// /etc/apt/apt.conf and apt.conf.d, which apt reads as usual.
+type Options map[string]string
+
+// Validate reports whether every option can be passed as a single
+// "-o name=value" argument
+func (o Options) Validate() error {
+	for name, value := range o {
+		switch {
+		case name == "":
+			return fmt.Errorf("%w: empty name", ErrInvalidAptOption)
+		case strings.ContainsAny(name, "= \t\n"):
+			return fmt.Errorf("%w: name %q contains '=' or whitespace", ErrInvalidAptOption, name)
+		case strings.Contains(value, "\n"):
+			return fmt.Errorf("%w: value of %s spans several lines", ErrInvalidAptOption, name)
+		}
+	}
+	return nil
+}
+
+// Args returns the options as -o arguments, sorted by name so commands are
+// the same from run to run
+func (o Options) Args() []string {
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		args = append(args, "-o", name+"="+o[name])
+	}
+	return args
+}
+
+// takesAptOptions reports whether a command reads apt configuration
+func takesAptOptions(name string) bool {
+	return name == "apt-get" || name == "apt-cache"
+}
@@ -0,0 +1,52 @@
+package packagemanager_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_Args(t *testing.T) {
+	t.Run("sorts options by name", func(t *testing.T) {
+		options := packagemanager.Options{
+			"Acquire::https::Proxy": "DIRECT",
+			"Acquire::http::Proxy":  "http://apt-cache.internal:3142",
+		}
+
+		assert.Equal(t, []string{
+			"-o", "Acquire::http::Proxy=http://apt-cache.internal:3142",
+			"-o", "Acquire::https::Proxy=DIRECT",
+		}, options.Args())
+	})
+
+	t.Run("no options", func(t *testing.T) {
+		assert.Empty(t, packagemanager.Options(nil).Args())
+	})
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options packagemanager.Options
+		valid   bool
+	}{
+		{"proxy", packagemanager.Options{"Acquire::http::Proxy": "http://proxy:3128"}, true},
+		{"empty value", packagemanager.Options{"Acquire::http::Proxy": ""}, true},
+		{"empty name", packagemanager.Options{"": "x"}, false},
+		{"name with equals sign", packagemanager.Options{"Acquire::http::Proxy=http://proxy": ""}, false},
+		{"name with space", packagemanager.Options{"Acquire http": "x"}, false},
+		{"multi-line value", packagemanager.Options{"Acquire::http::Proxy": "a\nb"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, packagemanager.ErrInvalidAptOption)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package packagemanager
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statusFdOption makes apt-get write machine-readable progress to stdout,
+// alongside its usual output
+const statusFdOption = "APT::Status-Fd=1"
+
+var (
+	// remainingPattern finds apt's estimate in "Retrieving file 2 of 5 (1min 5s remaining)"
+	remainingPattern = regexp.MustCompile(`\(([0-9dhmins ]+) remaining\)`)
+	// fetchedPattern matches "Fetched 2,717 kB in 1s (3,126 kB/s)"
+	fetchedPattern = regexp.MustCompile(`^Fetched ([0-9.,]+ [kMGT]?B) in .* \(([0-9.,]+ [kMGT]?B)/s\)`)
+	// durationPart matches one unit of apt's durations, such as "1min" or "5s"
+	durationPart = regexp.MustCompile(`([0-9]+)(d|h|min|s)`)
+)
+
+// StatusParser turns the lines apt-get writes with APT::Status-Fd, mixed
+// with its usual output, into progress reports for the packages it
+// downloads and installs. Download speed is estimated from the sizes on
+// apt's Get: lines until the closing Fetched line gives the measured one.
+type StatusParser struct {
+	now          func() time.Time
+	started      time.Time
+	items        map[int]string // Package name of each download item
+	totalBytes   float64        // Sum of the sizes on the Get: lines so far
+	lastDownload string
+}
+
+// NewStatusParser creates a parser for one apt-get run
+func NewStatusParser() *StatusParser {
+	return &StatusParser{
+		now:   time.Now,
+		items: make(map[int]string),
+	}
+}
+
+// Parse reads one line of output, returning the progress it reports, if
+// any. Lines that only inform later reports, such as Get: lines, and lines
+// apt writes for people return false.
+func (p *StatusParser) Parse(line string) (PackageProgress, bool) {
+	line = strings.TrimRight(line, "\r\n")
+
+	switch {
+	case strings.HasPrefix(line, "Get:"):
+		p.parseGet(line)
+		return PackageProgress{}, false
+	case strings.HasPrefix(line, "Fetched "):
+		return p.parseFetched(line)
+	}
+
+	kind, rest, ok := strings.Cut(line, ":")
+	if !ok {
+		return PackageProgress{}, false
+	}
+	subject, percent, message, ok := splitStatus(rest)
+	if !ok {
+		return PackageProgress{}, false
+	}
+
+	switch kind {
+	case "dlstatus":
+		return p.parseDownload(subject, percent, message), true
+	case "pmstatus":
+		return parsePackageStatus(subject, percent, message), true
+	case "pmerror":
+		return PackageProgress{
+			PackageName:     packageName(subject),
+			Status:          StatusFailed,
+			PercentComplete: percent,
+			Message:         message,
+			Error:           errors.New(message),
+		}, true
+	default:
+		// pmconffile, media-change and anything newer
+		return PackageProgress{}, false
+	}
+}
+
+// splitStatus splits the fields of a status line after its kind: the item
+// or package, the percent and the message. Multi-arch packages are written
+// with their architecture, as in "libc6:amd64", so the percent is either
+// the second or the third field.
+func splitStatus(rest string) (string, float64, string, bool) {
+	fields := strings.SplitN(rest, ":", 4)
+	for i := 1; i < len(fields)-1 && i <= 2; i++ {
+		percent, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			continue
+		}
+		subject := strings.Join(fields[:i], ":")
+		message := strings.TrimSpace(strings.Join(fields[i+1:], ":"))
+		return subject, percent, message, true
+	}
+	return "", 0, "", false
+}
+
+// parseGet records the package and size of a download item, from lines
+// such as "Get:1 http://deb.debian.org/debian sid/main amd64 hyprland amd64 0.41.2 [1,234 kB]"
+func (p *StatusParser) parseGet(line string) {
+	head, rest, _ := strings.Cut(line, " ")
+	item, err := strconv.Atoi(strings.TrimPrefix(head, "Get:"))
+	if err != nil {
+		return
+	}
+
+	if open := strings.LastIndex(rest, "["); open >= 0 && strings.HasSuffix(rest, "]") {
+		if size, ok := parseSize(rest[open+1 : len(rest)-1]); ok {
+			p.totalBytes += size
+		}
+		rest = rest[:open]
+	}
+
+	// The description ends with the package, its architecture and version
+	if fields := strings.Fields(rest); len(fields) >= 3 {
+		p.items[item] = fields[len(fields)-3]
+	}
+}
+
+// parseDownload reads a dlstatus report: the item being retrieved, overall
+// percent and a message that may hold apt's estimate of the time left
+func (p *StatusParser) parseDownload(itemField string, percent float64, message string) PackageProgress {
+	now := p.now()
+	if p.started.IsZero() {
+		p.started = now
+	}
+
+	progress := PackageProgress{
+		Status:          StatusDownloading,
+		PercentComplete: percent,
+		Message:         message,
+	}
+	if item, err := strconv.Atoi(itemField); err == nil {
+		progress.PackageName = p.items[item]
+	}
+	if progress.PackageName == "" {
+		progress.PackageName = p.lastDownload
+	}
+	p.lastDownload = progress.PackageName
+
+	if match := remainingPattern.FindStringSubmatch(message); match != nil {
+		progress.Remaining = parseAptDuration(match[1])
+	}
+	if elapsed := now.Sub(p.started).Seconds(); elapsed > 0 && p.totalBytes > 0 {
+		progress.BytesPerSecond = p.totalBytes * percent / 100 / elapsed
+	}
+	return progress
+}
+
+// parseFetched reads the line closing the downloads, which has the
+// measured speed
+func (p *StatusParser) parseFetched(line string) (PackageProgress, bool) {
+	match := fetchedPattern.FindStringSubmatch(line)
+	if match == nil {
+		return PackageProgress{}, false
+	}
+	speed, ok := parseSize(match[2])
+	if !ok {
+		return PackageProgress{}, false
+	}
+	return PackageProgress{
+		PackageName:     p.lastDownload,
+		Status:          StatusDownloading,
+		PercentComplete: 100,
+		Message:         line,
+		BytesPerSecond:  speed,
+	}, true
+}
+
+// parsePackageStatus reads a pmstatus report of dpkg's work on a package.
+// apt describes each step as "Unpacking hyprland (amd64)", "Configuring
+// hyprland" and so on.
+func parsePackageStatus(pkg string, percent float64, message string) PackageProgress {
+	progress := PackageProgress{
+		PackageName:     packageName(pkg),
+		Status:          StatusInstalling,
+		PercentComplete: percent,
+		Message:         message,
+	}
+
+	switch {
+	case strings.HasPrefix(message, "Preparing to configure"),
+		strings.HasPrefix(message, "Configuring"),
+		strings.HasPrefix(message, "Running post-installation trigger"):
+		progress.Status = StatusConfiguring
+	case strings.HasPrefix(message, "Preparing"),
+		strings.HasPrefix(message, "Unpacking"):
+		progress.Status = StatusUnpacking
+	case strings.HasPrefix(message, "Installed"):
+		progress.Status = StatusCompleted
+	}
+
+	// dpkg-exec stands for dpkg as a whole rather than a package
+	if progress.PackageName == "dpkg-exec" {
+		progress.PackageName = ""
+	}
+	return progress
+}
+
+// packageName drops the architecture apt appends to multi-arch packages.
+// dpkg errors name the archive instead, as in
+// /var/cache/apt/archives/hyprland_0.41.2_amd64.deb.
+func packageName(pkg string) string {
+	if strings.HasSuffix(pkg, ".deb") {
+		name, _, _ := strings.Cut(path.Base(pkg), "_")
+		return name
+	}
+	name, _, _ := strings.Cut(pkg, ":")
+	return name
+}
+
+// parseSize reads a size apt printed, such as "1,234 kB" or "2.5 MB". apt
+// counts in powers of 1000.
+func parseSize(text string) (float64, bool) {
+	number, unit, ok := strings.Cut(strings.TrimSpace(text), " ")
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	multipliers := map[string]float64{"B": 1, "kB": 1e3, "MB": 1e6, "GB": 1e9, "TB": 1e12}
+	multiplier, ok := multipliers[unit]
+	if !ok {
+		return 0, false
+	}
+	return value * multiplier, true
+}
+
+// parseAptDuration reads a duration apt printed, such as "1h 2min 5s"
+func parseAptDuration(text string) time.Duration {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "h": time.Hour, "min": time.Minute, "s": time.Second}
+
+	var total time.Duration
+	for _, match := range durationPart.FindAllStringSubmatch(text, -1) {
+		count, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		total += time.Duration(count) * units[match[2]]
+	}
+	return total
+}
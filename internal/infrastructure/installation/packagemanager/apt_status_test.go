@@ -0,0 +1,85 @@
+package packagemanager_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusParser(t *testing.T) {
+	t.Run("reports downloads with the package, time left and speed", func(t *testing.T) {
+		parser := packagemanager.NewStatusParser()
+
+		_, ok := parser.Parse("Get:1 http://deb.debian.org/debian sid/main amd64 libhyprutils0 amd64 0.2.1-1 [60.0 kB]")
+		assert.False(t, ok)
+		_, ok = parser.Parse("Get:2 http://deb.debian.org/debian sid/main amd64 hyprland amd64 0.41.2+ds-1 [1,940 kB]")
+		assert.False(t, ok)
+
+		progress, ok := parser.Parse("dlstatus:2:42.5000:Retrieving file 2 of 2 (1min 5s remaining)")
+		require.True(t, ok)
+		assert.Equal(t, "hyprland", progress.PackageName)
+		assert.Equal(t, packagemanager.StatusDownloading, progress.Status)
+		assert.Equal(t, 42.5, progress.PercentComplete)
+		assert.Equal(t, 65*time.Second, progress.Remaining)
+
+		progress, ok = parser.Parse("Fetched 2,000 kB in 1s (1,500 kB/s)")
+		require.True(t, ok)
+		assert.Equal(t, "hyprland", progress.PackageName)
+		assert.Equal(t, 100.0, progress.PercentComplete)
+		assert.Equal(t, 1.5e6, progress.BytesPerSecond)
+	})
+
+	t.Run("reports unpacking, configuring and installed packages", func(t *testing.T) {
+		parser := packagemanager.NewStatusParser()
+
+		tests := []struct {
+			line    string
+			pkg     string
+			status  string
+			percent float64
+		}{
+			{"pmstatus:dpkg-exec:0.0000:Running dpkg", "", packagemanager.StatusInstalling, 0},
+			{"pmstatus:hyprland:16.6667:Preparing hyprland (amd64)", "hyprland", packagemanager.StatusUnpacking, 16.6667},
+			{"pmstatus:hyprland:33.3333:Unpacking hyprland (amd64)", "hyprland", packagemanager.StatusUnpacking, 33.3333},
+			{"pmstatus:hyprland:50.0000:Preparing to configure hyprland (amd64)", "hyprland", packagemanager.StatusConfiguring, 50},
+			{"pmstatus:libhyprutils0:amd64:66.6667:Configuring libhyprutils0:amd64 (amd64)", "libhyprutils0", packagemanager.StatusConfiguring, 66.6667},
+			{"pmstatus:hyprland:83.3333:Configuring hyprland (amd64)", "hyprland", packagemanager.StatusConfiguring, 83.3333},
+			{"pmstatus:hyprland:100.0000:Installed hyprland (amd64)", "hyprland", packagemanager.StatusCompleted, 100},
+		}
+		for _, tt := range tests {
+			progress, ok := parser.Parse(tt.line)
+			require.True(t, ok, tt.line)
+			assert.Equal(t, tt.pkg, progress.PackageName, tt.line)
+			assert.Equal(t, tt.status, progress.Status, tt.line)
+			assert.InDelta(t, tt.percent, progress.PercentComplete, 0.0001, tt.line)
+		}
+	})
+
+	t.Run("reports dpkg errors as failures", func(t *testing.T) {
+		parser := packagemanager.NewStatusParser()
+
+		progress, ok := parser.Parse("pmerror:/var/cache/apt/archives/hyprland_0.41.2_amd64.deb:40.0000:trying to overwrite '/usr/bin/Hyprland'")
+		require.True(t, ok)
+		assert.Equal(t, "hyprland", progress.PackageName)
+		assert.Equal(t, packagemanager.StatusFailed, progress.Status)
+		assert.EqualError(t, progress.Error, "trying to overwrite '/usr/bin/Hyprland'")
+	})
+
+	t.Run("ignores output meant for people", func(t *testing.T) {
+		parser := packagemanager.NewStatusParser()
+
+		for _, line := range []string{
+			"Reading package lists...",
+			"E: Unable to locate package hyprlandd",
+			"Hit:1 http://deb.debian.org/debian sid InRelease",
+			"pmconffile:/etc/xdg/hypr/hyprland.conf:50.0000:'/etc/xdg/hypr/hyprland.conf' '/etc/xdg/hypr/hyprland.conf.dpkg-new' 1 1",
+			"",
+		} {
+			_, ok := parser.Parse(line)
+			assert.False(t, ok, line)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package packagemanager
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrUnknownBackend is returned when selecting a backend that is not
+	// registered
+	ErrUnknownBackend = errors.New("unknown package manager backend")
+	// ErrBackendAlreadyRegistered is returned when registering a second
+	// backend with the same name
+	ErrBackendAlreadyRegistered = errors.New("package manager backend already registered")
+)
+
+// Operation is a change a backend makes to the installed packages or the
+// package cache
+type Operation int
+
+const (
+	OperationInstall  Operation = iota // Install a package and its dependencies
+	OperationDownload                  // Fetch a package into the APT cache without installing it
+	OperationRemove                    // Remove a package
+	OperationUpdate                    // Refresh the package lists
+)
+
+// String names the operation in errors
+func (o Operation) String() string {
+	switch o {
+	case OperationDownload:
+		return "download"
+	case OperationRemove:
+		return "remove"
+	case OperationUpdate:
+		return "update"
+	default:
+		return "install"
+	}
+}
+
+// Backend is the apt frontend an APTManager installs, downloads and
+// removes packages with. Queries always go through dpkg-query and
+// apt-cache, which every frontend is built on.
+type Backend interface {
+	// Name selects the backend in the package_manager setting
+	Name() string
+
+	// Program is the command the backend runs
+	Program() string
+
+	// Args returns the arguments that run op non-interactively, with
+	// options, apt's -o arguments, where the program reads them. pkg is
+	// empty for OperationUpdate.
+	Args(op Operation, pkg string, options []string) []string
+
+	// ReportsStatus tells whether the program writes apt's machine-readable
+	// progress with APT::Status-Fd
+	ReportsStatus() bool
+}
+
+// DefaultBackend is the backend used unless another is configured
+const DefaultBackend = "apt"
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Backend{
+		aptGetBackend{}.Name(): aptGetBackend{},
+		nalaBackend{}.Name():   nalaBackend{},
+	}
+)
+
+// RegisterBackend makes a backend selectable by its name
+func RegisterBackend(backend Backend) error {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	if _, exists := backends[backend.Name()]; exists {
+		return fmt.Errorf("%w: %s", ErrBackendAlreadyRegistered, backend.Name())
+	}
+	backends[backend.Name()] = backend
+	return nil
+}
+
+// LookupBackend returns the backend registered under name. An empty name
+// selects DefaultBackend.
+func LookupBackend(name string) (Backend, error) {
+	if name == "" {
+		name = DefaultBackend
+	}
+
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	backend, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (available: %v)", ErrUnknownBackend, name, backendNames())
+	}
+	return backend, nil
+}
+
+// BackendNames returns the names of the registered backends, sorted
+func BackendNames() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	return backendNames()
+}
+
+// backendNames lists the registered backends; the caller holds backendsMu
+func backendNames() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// aptGetBackend installs with apt-get
+type aptGetBackend struct{}
+
+func (aptGetBackend) Name() string        { return "apt" }
+func (aptGetBackend) Program() string     { return "apt-get" }
+func (aptGetBackend) ReportsStatus() bool { return true }
+
+func (aptGetBackend) Args(op Operation, pkg string, options []string) []string {
+	args := append([]string(nil), options...)
+	switch op {
+	case OperationDownload:
+		return append(args, "install", "-y", "--download-only", pkg)
+	case OperationRemove:
+		return append(args, "remove", "-y", pkg)
+	case OperationUpdate:
+		return append(args, "update")
+	default:
+		return append(args, "install", "-y", pkg)
+	}
+}
+
+// nalaBackend installs with nala, which downloads from several mirrors in
+// parallel and resumes interrupted downloads. It draws its own progress
+// instead of writing apt's status lines.
+type nalaBackend struct{}
+
+func (nalaBackend) Name() string        { return "nala" }
+func (nalaBackend) Program() string     { return "nala" }
+func (nalaBackend) ReportsStatus() bool { return false }
+
+// Args places options after the subcommand, where nala reads them
+func (nalaBackend) Args(op Operation, pkg string, options []string) []string {
+	var args []string
+	switch op {
+	case OperationDownload:
+		args = []string{"install", "--assume-yes", "--download-only"}
+	case OperationRemove:
+		args = []string{"remove", "--assume-yes"}
+	case OperationUpdate:
+		return append([]string{"update"}, options...)
+	default:
+		args = []string{"install", "--assume-yes"}
+	}
+	return append(append(args, options...), pkg)
+}
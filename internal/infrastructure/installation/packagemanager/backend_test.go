@@ -0,0 +1,74 @@
+package packagemanager_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupBackend(t *testing.T) {
+	t.Run("defaults to apt-get", func(t *testing.T) {
+		backend, err := packagemanager.LookupBackend("")
+
+		require.NoError(t, err)
+		assert.Equal(t, "apt", backend.Name())
+		assert.Equal(t, "apt-get", backend.Program())
+		assert.True(t, backend.ReportsStatus())
+	})
+
+	t.Run("selects nala", func(t *testing.T) {
+		backend, err := packagemanager.LookupBackend("nala")
+
+		require.NoError(t, err)
+		assert.Equal(t, "nala", backend.Program())
+		assert.False(t, backend.ReportsStatus(), "nala draws its own progress")
+	})
+
+	t.Run("rejects unknown backends", func(t *testing.T) {
+		_, err := packagemanager.LookupBackend("pacman")
+
+		assert.ErrorIs(t, err, packagemanager.ErrUnknownBackend)
+		assert.Contains(t, err.Error(), "nala")
+	})
+}
+
+func TestRegisterBackend(t *testing.T) {
+	backend, err := packagemanager.LookupBackend("apt")
+	require.NoError(t, err)
+
+	err = packagemanager.RegisterBackend(backend)
+
+	assert.ErrorIs(t, err, packagemanager.ErrBackendAlreadyRegistered)
+	assert.Contains(t, packagemanager.BackendNames(), "nala")
+}
+
+func TestBackend_Args(t *testing.T) {
+	options := []string{"-o", "Acquire::http::Proxy=http://proxy:3128"}
+
+	tests := []struct {
+		backend string
+		op      packagemanager.Operation
+		pkg     string
+		want    []string
+	}{
+		{"apt", packagemanager.OperationInstall, "hyprland", []string{"-o", "Acquire::http::Proxy=http://proxy:3128", "install", "-y", "hyprland"}},
+		{"apt", packagemanager.OperationDownload, "hyprland", []string{"-o", "Acquire::http::Proxy=http://proxy:3128", "install", "-y", "--download-only", "hyprland"}},
+		{"apt", packagemanager.OperationRemove, "hyprland", []string{"-o", "Acquire::http::Proxy=http://proxy:3128", "remove", "-y", "hyprland"}},
+		{"apt", packagemanager.OperationUpdate, "", []string{"-o", "Acquire::http::Proxy=http://proxy:3128", "update"}},
+		{"nala", packagemanager.OperationInstall, "hyprland", []string{"install", "--assume-yes", "-o", "Acquire::http::Proxy=http://proxy:3128", "hyprland"}},
+		{"nala", packagemanager.OperationDownload, "hyprland", []string{"install", "--assume-yes", "--download-only", "-o", "Acquire::http::Proxy=http://proxy:3128", "hyprland"}},
+		{"nala", packagemanager.OperationRemove, "hyprland", []string{"remove", "--assume-yes", "-o", "Acquire::http::Proxy=http://proxy:3128", "hyprland"}},
+		{"nala", packagemanager.OperationUpdate, "", []string{"update", "-o", "Acquire::http::Proxy=http://proxy:3128"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend+" "+tt.op.String(), func(t *testing.T) {
+			backend, err := packagemanager.LookupBackend(tt.backend)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, backend.Args(tt.op, tt.pkg, options))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package packagemanager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+)
+
+const (
+	defaultDpkgLogPath = "/var/log/dpkg.log"
+	dpkgLogTimeLayout  = "2006-01-02 15:04:05"
+)
+
+// DpkgInventory implements history.PackageInventory by reading the dpkg log
+// and querying dpkg for installed sizes
+type DpkgInventory struct {
+	logPath string
+}
+
+// NewDpkgInventory creates an inventory reading /var/log/dpkg.log
+func NewDpkgInventory() *DpkgInventory {
+	return NewDpkgInventoryWithLog(defaultDpkgLogPath)
+}
+
+// NewDpkgInventoryWithLog creates an inventory reading the given dpkg log
+func NewDpkgInventoryWithLog(logPath string) *DpkgInventory {
+	return &DpkgInventory{logPath: logPath}
+}
+
+// InstalledBetween returns every package dpkg finished installing between
+// from and to, including dependencies pulled in by apt. Packages removed
+// again within the window are left out.
+func (d *DpkgInventory) InstalledBetween(ctx context.Context, from, to time.Time) ([]history.InstalledPackage, error) {
+	file, err := os.Open(d.logPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dpkg log: %w", err)
+	}
+	defer file.Close()
+
+	// dpkg logs local time with second precision
+	from = from.Truncate(time.Second)
+
+	versions := make(map[string]string)
+	var order []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// 2024-05-01 12:00:00 status installed hyprland:amd64 0.45.0-1
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 6 || fields[2] != "status" {
+			continue
+		}
+
+		at, err := time.ParseInLocation(dpkgLogTimeLayout, fields[0]+" "+fields[1], time.Local)
+		if err != nil || at.Before(from) || at.After(to) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(fields[4], ":")
+		switch fields[3] {
+		case "installed":
+			if _, seen := versions[name]; !seen {
+				order = append(order, name)
+			}
+			versions[name] = fields[5]
+		case "not-installed", "config-files":
+			delete(versions, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dpkg log: %w", err)
+	}
+
+	var names []string
+	for _, name := range order {
+		if _, ok := versions[name]; ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	sizes := d.installedSizes(ctx, names)
+
+	packages := make([]history.InstalledPackage, 0, len(names))
+	for _, name := range names {
+		pkg, err := history.NewInstalledPackage(name, versions[name], sizes[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid dpkg log entry for %s: %w", name, err)
+		}
+		packages = append(packages, pkg)
+	}
+
+	return packages, nil
+}
+
+// installedSizes returns the installed size in bytes of each package.
+// Packages dpkg no longer knows about are left out.
+func (d *DpkgInventory) installedSizes(ctx context.Context, names []string) map[string]uint64 {
+	args := append([]string{"-W", "-f=${Package}\t${Installed-Size}\n"}, names...)
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeouts().Query)
+	defer cancel()
+
+	// dpkg-query exits non-zero if any package is unknown but still
+	// prints the ones it found
+	output, _ := exec.CommandContext(ctx, "dpkg-query", args...).Output()
+
+	sizes := make(map[string]uint64)
+	for _, line := range strings.Split(string(output), "\n") {
+		name, size, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		// Installed-Size is in KiB
+		kib, err := strconv.ParseUint(strings.TrimSpace(size), 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, seen := sizes[name]; !seen {
+			sizes[name] = kib * 1024
+		}
+	}
+	return sizes
+}
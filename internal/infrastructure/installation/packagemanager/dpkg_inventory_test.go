@@ -0,0 +1,59 @@
+package packagemanager_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDpkgLog = `2024-05-01 11:59:58 status installed gohan-test-before:amd64 1.0-1
+2024-05-01 12:00:01 startup packages configure
+2024-05-01 12:00:02 status unpacked gohan-test-hyprland:amd64 0.45.0-1
+2024-05-01 12:00:03 status half-configured gohan-test-hyprland:amd64 0.45.0-1
+2024-05-01 12:00:03 status installed gohan-test-hyprland:amd64 0.45.0-1
+2024-05-01 12:00:04 status installed gohan-test-libaquamarine:amd64 0.4.5-1
+2024-05-01 12:00:05 status installed gohan-test-temp:all 2.0
+2024-05-01 12:00:06 status not-installed gohan-test-temp:all <none>
+2024-05-01 12:00:07 status installed gohan-test-hyprland:amd64 0.45.2-1
+2024-05-01 12:05:00 status installed gohan-test-after:amd64 3.0-1
+`
+
+func TestDpkgInventory_InstalledBetween(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "dpkg.log")
+	require.NoError(t, os.WriteFile(logPath, []byte(testDpkgLog), 0644))
+
+	inventory := packagemanager.NewDpkgInventoryWithLog(logPath)
+	from := time.Date(2024, 5, 1, 12, 0, 0, 500, time.Local)
+	to := time.Date(2024, 5, 1, 12, 1, 0, 0, time.Local)
+
+	t.Run("returns packages installed during the window", func(t *testing.T) {
+		packages, err := inventory.InstalledBetween(context.Background(), from, to)
+
+		require.NoError(t, err)
+		require.Len(t, packages, 2)
+		assert.Equal(t, "gohan-test-hyprland", packages[0].Name())
+		assert.Equal(t, "0.45.2-1", packages[0].Version(), "should keep the last installed version")
+		assert.Equal(t, "gohan-test-libaquamarine", packages[1].Name())
+	})
+
+	t.Run("returns nothing for an empty window", func(t *testing.T) {
+		packages, err := inventory.InstalledBetween(context.Background(), to.Add(time.Hour), to.Add(2*time.Hour))
+
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+	})
+
+	t.Run("fails when the log cannot be read", func(t *testing.T) {
+		missing := packagemanager.NewDpkgInventoryWithLog(filepath.Join(t.TempDir(), "missing.log"))
+
+		_, err := missing.InstalledBetween(context.Background(), from, to)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,116 @@
+package packagemanager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// ErrInvalidPriority is returned for a priority outside the ranges nice,
+// ionice and systemd accept
+var ErrInvalidPriority = errors.New("invalid priority")
+
+// Priority is the CPU and IO priority apt and dpkg run with. The zero value
+// runs them like any other process.
+type Priority struct {
+	Nice      int // 0 to 19; higher yields more CPU to other processes
+	IOLevel   int // best-effort IO level, 0 to 7; higher yields more IO
+	CPUWeight int // systemd CPU weight, 1 to 10000; 0 leaves the default of 100
+	IOWeight  int // systemd IO weight, 1 to 10000; 0 leaves the default of 100
+}
+
+// BackgroundPriority returns a priority that keeps a desktop session
+// responsive while packages install
+func BackgroundPriority() Priority {
+	return Priority{
+		Nice:      10,
+		IOLevel:   7,
+		CPUWeight: 20,
+		IOWeight:  20,
+	}
+}
+
+// Validate reports whether the priority is within the accepted ranges
+func (p Priority) Validate() error {
+	switch {
+	case p.Nice < 0 || p.Nice > 19:
+		return fmt.Errorf("%w: nice %d is not between 0 and 19", ErrInvalidPriority, p.Nice)
+	case p.IOLevel < 0 || p.IOLevel > 7:
+		return fmt.Errorf("%w: IO level %d is not between 0 and 7", ErrInvalidPriority, p.IOLevel)
+	case p.CPUWeight < 0 || p.CPUWeight > 10000:
+		return fmt.Errorf("%w: CPU weight %d is not between 1 and 10000", ErrInvalidPriority, p.CPUWeight)
+	case p.IOWeight < 0 || p.IOWeight > 10000:
+		return fmt.Errorf("%w: IO weight %d is not between 1 and 10000", ErrInvalidPriority, p.IOWeight)
+	}
+	return nil
+}
+
+// IsNormal reports whether commands run without any adjustment
+func (p Priority) IsNormal() bool {
+	return p == Priority{}
+}
+
+// launcher wraps commands so they run with a priority, using what the
+// system provides
+type launcher struct {
+	priority   Priority
+	available  func(name string) bool
+	hasSystemd func() bool
+	isRoot     func() bool
+}
+
+// newLauncher creates a launcher for the running system
+func newLauncher(priority Priority) launcher {
+	return launcher{
+		priority: priority,
+		available: func(name string) bool {
+			_, err := exec.LookPath(name)
+			return err == nil
+		},
+		hasSystemd: func() bool {
+			_, err := os.Stat("/run/systemd/system")
+			return err == nil
+		},
+		isRoot: func() bool { return os.Geteuid() == 0 },
+	}
+}
+
+// command returns the command line to run name with. As root on a systemd
+// system the command runs in a transient scope with CPU and IO weights;
+// otherwise it runs under nice and ionice when they are installed.
+func (l launcher) command(name string, args ...string) (string, []string) {
+	p := l.priority
+	if p.IsNormal() {
+		return name, args
+	}
+
+	if (p.CPUWeight > 0 || p.IOWeight > 0) && l.isRoot() && l.hasSystemd() && l.available("systemd-run") {
+		wrapped := []string{"--scope", "--quiet", "--collect"}
+		if p.CPUWeight > 0 {
+			wrapped = append(wrapped, "--property=CPUWeight="+strconv.Itoa(p.CPUWeight))
+		}
+		if p.IOWeight > 0 {
+			wrapped = append(wrapped, "--property=IOWeight="+strconv.Itoa(p.IOWeight))
+		}
+		if p.Nice > 0 {
+			wrapped = append(wrapped, "--nice="+strconv.Itoa(p.Nice))
+		}
+		wrapped = append(wrapped, "--", name)
+		return "systemd-run", append(wrapped, args...)
+	}
+
+	var prefix []string
+	if p.Nice > 0 && l.available("nice") {
+		prefix = append(prefix, "nice", "-n", strconv.Itoa(p.Nice))
+	}
+	if p.IOLevel > 0 && l.available("ionice") {
+		prefix = append(prefix, "ionice", "-c", "2", "-n", strconv.Itoa(p.IOLevel))
+	}
+	if len(prefix) == 0 {
+		return name, args
+	}
+	prefix = append(prefix, name)
+	return prefix[0], append(prefix[1:], args...)
+}
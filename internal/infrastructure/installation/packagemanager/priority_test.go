@@ -0,0 +1,77 @@
+package packagemanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testLauncher(priority Priority, root, systemd bool, tools ...string) launcher {
+	return launcher{
+		priority: priority,
+		available: func(name string) bool {
+			for _, tool := range tools {
+				if tool == name {
+					return true
+				}
+			}
+			return false
+		},
+		hasSystemd: func() bool { return systemd },
+		isRoot:     func() bool { return root },
+	}
+}
+
+func TestLauncher_Command(t *testing.T) {
+	t.Run("runs commands unchanged at normal priority", func(t *testing.T) {
+		name, args := testLauncher(Priority{}, true, true, "systemd-run", "nice", "ionice").
+			command("apt-get", "install", "-y", "waybar")
+
+		assert.Equal(t, "apt-get", name)
+		assert.Equal(t, []string{"install", "-y", "waybar"}, args)
+	})
+
+	t.Run("uses a weighted systemd scope as root", func(t *testing.T) {
+		name, args := testLauncher(BackgroundPriority(), true, true, "systemd-run", "nice", "ionice").
+			command("apt-get", "install", "-y", "waybar")
+
+		assert.Equal(t, "systemd-run", name)
+		assert.Equal(t, []string{
+			"--scope", "--quiet", "--collect",
+			"--property=CPUWeight=20", "--property=IOWeight=20", "--nice=10",
+			"--", "apt-get", "install", "-y", "waybar",
+		}, args)
+	})
+
+	t.Run("falls back to nice and ionice without systemd", func(t *testing.T) {
+		name, args := testLauncher(BackgroundPriority(), true, false, "systemd-run", "nice", "ionice").
+			command("apt-get", "install", "-y", "waybar")
+
+		assert.Equal(t, "nice", name)
+		assert.Equal(t, []string{"-n", "10", "ionice", "-c", "2", "-n", "7", "apt-get", "install", "-y", "waybar"}, args)
+	})
+
+	t.Run("uses nice and ionice as a regular user", func(t *testing.T) {
+		name, args := testLauncher(BackgroundPriority(), false, true, "systemd-run", "ionice").
+			command("dpkg", "-s", "waybar")
+
+		assert.Equal(t, "ionice", name)
+		assert.Equal(t, []string{"-c", "2", "-n", "7", "dpkg", "-s", "waybar"}, args)
+	})
+
+	t.Run("runs commands unchanged when no tool is installed", func(t *testing.T) {
+		name, args := testLauncher(BackgroundPriority(), false, false).command("apt-get", "update")
+
+		assert.Equal(t, "apt-get", name)
+		assert.Equal(t, []string{"update"}, args)
+	})
+}
+
+func TestPriority_Validate(t *testing.T) {
+	assert.NoError(t, Priority{}.Validate())
+	assert.NoError(t, BackgroundPriority().Validate())
+	assert.ErrorIs(t, Priority{Nice: 20}.Validate(), ErrInvalidPriority)
+	assert.ErrorIs(t, Priority{IOLevel: 8}.Validate(), ErrInvalidPriority)
+	assert.ErrorIs(t, Priority{CPUWeight: -1}.Validate(), ErrInvalidPriority)
+	assert.ErrorIs(t, Priority{IOWeight: 10001}.Validate(), ErrInvalidPriority)
+}
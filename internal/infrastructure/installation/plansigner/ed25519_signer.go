@@ -0,0 +1,147 @@
+package plansigner
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+)
+
+// Algorithm identifies signatures made by this signer
+const Algorithm = "ed25519"
+
+// ErrInvalidSignature is returned when a plan does not match its signature
+var ErrInvalidSignature = errors.New("installation plan signature is invalid")
+
+// Ed25519Signer signs plans with a per-user ed25519 key, created on first use
+type Ed25519Signer struct {
+	keyPath string
+}
+
+// NewEd25519Signer creates a signer using ~/.gohan/plan_signing.key
+func NewEd25519Signer() *Ed25519Signer {
+	homeDir, _ := os.UserHomeDir()
+	return NewEd25519SignerWithKey(filepath.Join(homeDir, ".gohan", "plan_signing.key"))
+}
+
+// NewEd25519SignerWithKey creates a signer using the key at keyPath
+func NewEd25519SignerWithKey(keyPath string) *Ed25519Signer {
+	return &Ed25519Signer{keyPath: keyPath}
+}
+
+// Sign signs a plan payload, creating the local key if needed
+func (s *Ed25519Signer) Sign(payload []byte) (dto.PlanSignature, error) {
+	key, err := s.loadOrCreateKey()
+	if err != nil {
+		return dto.PlanSignature{}, err
+	}
+
+	public := key.Public().(ed25519.PublicKey)
+	return dto.PlanSignature{
+		Algorithm: Algorithm,
+		PublicKey: base64.StdEncoding.EncodeToString(public),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+		Signer:    fingerprint(public),
+	}, nil
+}
+
+// Verify checks that the signature was made over payload by the embedded
+// public key, and that the signer fingerprint belongs to that key
+func (s *Ed25519Signer) Verify(payload []byte, signature dto.PlanSignature) error {
+	if signature.Algorithm != Algorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, signature.Algorithm)
+	}
+
+	public, err := base64.StdEncoding.DecodeString(signature.PublicKey)
+	if err != nil || len(public) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: malformed public key", ErrInvalidSignature)
+	}
+	if fingerprint(public) != signature.Signer {
+		return fmt.Errorf("%w: signer does not match the public key", ErrInvalidSignature)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+	if !ed25519.Verify(public, payload, value) {
+		return fmt.Errorf("%w: the plan was modified after signing", ErrInvalidSignature)
+	}
+
+	return nil
+}
+
+// Fingerprint identifies the local key. It fails when no key exists yet.
+func (s *Ed25519Signer) Fingerprint() (string, error) {
+	key, err := s.loadKey()
+	if err != nil {
+		return "", err
+	}
+	return fingerprint(key.Public().(ed25519.PublicKey)), nil
+}
+
+func (s *Ed25519Signer) loadOrCreateKey() (ed25519.PrivateKey, error) {
+	key, err := s.loadKey()
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	_, key, err = ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signing key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.keyPath), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create key directory: %w", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(s.keyPath, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (s *Ed25519Signer) loadKey() (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(s.keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid signing key %s", s.keyPath)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key %s: %w", s.keyPath, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not an ed25519 key", s.keyPath)
+	}
+
+	return key, nil
+}
+
+// fingerprint is the first 16 bytes of the SHA-256 of the public key
+func fingerprint(public ed25519.PublicKey) string {
+	sum := sha256.Sum256(public)
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,54 @@
+package plansigner_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/plansigner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEd25519Signer_SignAndVerify(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "plan_signing.key")
+	signer := plansigner.NewEd25519SignerWithKey(keyPath)
+
+	_, err := signer.Fingerprint()
+	assert.Error(t, err, "no key before the first signature")
+
+	payload := []byte(`{"components":[{"name":"hyprland","version":"0.41.2-1"}]}`)
+	signature, err := signer.Sign(payload)
+	require.NoError(t, err)
+
+	info, err := os.Stat(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	local, err := signer.Fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, local, signature.Signer)
+
+	assert.NoError(t, signer.Verify(payload, signature))
+
+	t.Run("rejects a modified payload", func(t *testing.T) {
+		err := signer.Verify([]byte(`{"components":[{"name":"hyprland","version":"0.40.0-1"}]}`), signature)
+		assert.ErrorIs(t, err, plansigner.ErrInvalidSignature)
+	})
+
+	t.Run("rejects a signer that does not match the key", func(t *testing.T) {
+		forged := signature
+		forged.Signer = "00000000000000000000000000000000"
+		assert.ErrorIs(t, signer.Verify(payload, forged), plansigner.ErrInvalidSignature)
+	})
+}
+
+func TestEd25519Signer_VerifiesOtherKeys(t *testing.T) {
+	payload := []byte("plan")
+	signature, err := plansigner.NewEd25519SignerWithKey(filepath.Join(t.TempDir(), "a.key")).Sign(payload)
+	require.NoError(t, err)
+
+	// A machine without the signing key can still check the signature
+	other := plansigner.NewEd25519SignerWithKey(filepath.Join(t.TempDir(), "b.key"))
+	assert.NoError(t, other.Verify(payload, signature))
+}
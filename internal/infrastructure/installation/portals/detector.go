@@ -0,0 +1,63 @@
+package portals
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// DefaultPortalsDir is where backends install their .portal files
+const DefaultPortalsDir = "/usr/share/xdg-desktop-portal/portals"
+
+// Detector finds the installed portal backends from their .portal files
+type Detector struct {
+	dir string
+}
+
+// NewDetector creates a detector reading the system portals directory
+func NewDetector() *Detector {
+	return NewDetectorForDir(DefaultPortalsDir)
+}
+
+// NewDetectorForDir creates a detector reading .portal files from dir
+func NewDetectorForDir(dir string) *Detector {
+	return &Detector{dir: dir}
+}
+
+// Installed returns the installed backends. Without xdg-desktop-portal
+// there are none.
+func (d *Detector) Installed() ([]installation.PortalBackend, error) {
+	entries, err := os.ReadDir(d.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list portal backends: %w", err)
+	}
+
+	var backends []installation.PortalBackend
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".portal" {
+			continue
+		}
+		backends = append(backends, installation.PortalBackend(strings.TrimSuffix(name, ".portal")))
+	}
+	sort.Slice(backends, func(i, j int) bool { return backends[i] < backends[j] })
+	return backends, nil
+}
+
+// Selection returns the backend selection among the installed backends
+func (d *Detector) Selection() (installation.PortalSelection, error) {
+	installed, err := d.Installed()
+	if err != nil {
+		return installation.PortalSelection{}, err
+	}
+	return installation.NewPortalSelection(installed), nil
+}
@@ -0,0 +1,33 @@
+package portals_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/portals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Installed(t *testing.T) {
+	t.Run("lists backends from their portal files", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"hyprland.portal", "gtk.portal", "gnome.portal", "README"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("[portal]\n"), 0o644))
+		}
+
+		backends, err := portals.NewDetectorForDir(dir).Installed()
+		require.NoError(t, err)
+		assert.Equal(t, []installation.PortalBackend{
+			installation.PortalGNOME, installation.PortalGTK, installation.PortalHyprland,
+		}, backends)
+	})
+
+	t.Run("finds nothing without xdg-desktop-portal", func(t *testing.T) {
+		selection, err := portals.NewDetectorForDir(filepath.Join(t.TempDir(), "missing")).Selection()
+		require.NoError(t, err)
+		assert.Empty(t, selection.Installed())
+	})
+}
@@ -0,0 +1,172 @@
+package profiles
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"gopkg.in/yaml.v3"
+)
+
+// profileFile is the YAML layout of a custom profile
+//
+//	name: Work laptop
+//	description: Recommended without the file manager, plus alacritty
+//	extends: recommended
+//	rendering: lite
+//	packages: [alacritty]
+//	exclude: [nautilus]
+type profileFile struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Extends     string   `yaml:"extends"`
+	Rendering   string   `yaml:"rendering"`
+	Packages    []string `yaml:"packages"`
+	Exclude     []string `yaml:"exclude"`
+}
+
+// Loader finds installation profiles by name: the built-in profiles first,
+// then custom profiles in a directory, then a YAML file at the given path
+type Loader struct {
+	dir string
+}
+
+// NewLoader creates a loader reading custom profiles from dir
+func NewLoader(dir string) *Loader {
+	return &Loader{dir: dir}
+}
+
+// Dir returns the directory custom profiles are read from
+func (l *Loader) Dir() string {
+	return l.dir
+}
+
+// Load returns the profile with the given name
+func (l *Loader) Load(name string) (installation.InstallationProfile, error) {
+	if profile, err := installation.LookupProfile(name); err == nil {
+		return profile, nil
+	}
+
+	for _, path := range l.candidates(name) {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return installation.InstallationProfile{}, fmt.Errorf("failed to read profile %s: %w", path, err)
+		}
+		return parseProfile(name, path, data)
+	}
+
+	return installation.InstallationProfile{}, fmt.Errorf(
+		"%w: %q (built-in profiles are minimal, recommended, full and lite; custom profiles are read from %s)",
+		installation.ErrUnknownProfile, name, l.dir)
+}
+
+// Names returns the names of the custom profiles in the directory,
+// sorted. Files named after a built-in profile are left out, since Load
+// returns the built-in one for them.
+func (l *Loader) Names() ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if _, err := installation.LookupProfile(name); err == nil || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// candidates returns where a custom profile may be stored
+func (l *Loader) candidates(name string) []string {
+	if strings.ContainsRune(name, filepath.Separator) || filepath.Ext(name) == ".yaml" || filepath.Ext(name) == ".yml" {
+		return []string{name}
+	}
+	return []string{
+		filepath.Join(l.dir, name+".yaml"),
+		filepath.Join(l.dir, name+".yml"),
+	}
+}
+
+func parseProfile(name, path string, data []byte) (installation.InstallationProfile, error) {
+	var file profileFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return installation.InstallationProfile{}, fmt.Errorf("invalid profile %s: %w", path, err)
+	}
+
+	profile := installation.InstallationProfile{
+		Name:        file.Name,
+		Description: file.Description,
+	}
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	}
+
+	// Custom profiles extend built-in ones, which keeps them from
+	// extending each other in a loop
+	var packages, optIn []string
+	if file.Extends != "" {
+		base, err := installation.LookupProfile(file.Extends)
+		if err != nil {
+			return installation.InstallationProfile{}, fmt.Errorf("profile %s: %w", path, err)
+		}
+		packages = base.Packages
+		optIn = base.OptIn
+		profile.Rendering = base.Rendering
+	}
+
+	if file.Rendering != "" {
+		mode, err := installation.ParseRenderingMode(file.Rendering)
+		if err != nil {
+			return installation.InstallationProfile{}, fmt.Errorf("profile %s: %w", path, err)
+		}
+		profile.Rendering = mode
+	}
+
+	excluded := make(map[string]bool, len(file.Exclude))
+	for _, pkg := range file.Exclude {
+		excluded[pkg] = true
+	}
+	seen := make(map[string]bool)
+	for _, pkg := range append(append([]string(nil), packages...), file.Packages...) {
+		pkg = strings.TrimSpace(pkg)
+		if pkg == "" || excluded[pkg] || seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		profile.Packages = append(profile.Packages, pkg)
+	}
+
+	// Packages the base offers stay on offer unless installed or excluded
+	for _, pkg := range optIn {
+		if !excluded[pkg] && !seen[pkg] {
+			profile.OptIn = append(profile.OptIn, pkg)
+		}
+	}
+
+	if len(profile.Packages) == 0 {
+		return installation.InstallationProfile{}, fmt.Errorf("profile %s installs no packages", path)
+	}
+	return profile, nil
+}
@@ -0,0 +1,112 @@
+package profiles_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/profiles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProfile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLoader(t *testing.T) {
+	t.Run("loads built-in profiles", func(t *testing.T) {
+		profile, err := profiles.NewLoader(t.TempDir()).Load("minimal")
+		require.NoError(t, err)
+
+		assert.Equal(t, installation.GetMinimalProfile(), profile)
+	})
+
+	t.Run("loads custom profiles extending a built-in one", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProfile(t, filepath.Join(dir, "work.yaml"), `
+name: Work laptop
+description: Recommended without the file manager
+extends: recommended
+packages: [alacritty, cliphist]
+exclude: [nautilus]
+`)
+
+		profile, err := profiles.NewLoader(dir).Load("work")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Work laptop", profile.Name)
+		assert.Contains(t, profile.Packages, "alacritty")
+		assert.Contains(t, profile.Packages, "hyprland")
+		assert.NotContains(t, profile.Packages, "nautilus")
+		assert.Len(t, profile.Packages, len(installation.GetRecommendedProfile().Packages))
+	})
+
+	t.Run("loads profiles by path and names them after the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tiny.yml")
+		writeProfile(t, path, "rendering: lite\npackages: [hyprland, foot]\n")
+
+		profile, err := profiles.NewLoader(t.TempDir()).Load(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, "tiny", profile.Name)
+		assert.Equal(t, []string{"hyprland", "foot"}, profile.Packages)
+		assert.Equal(t, installation.RenderingLite, profile.Rendering)
+	})
+
+	t.Run("keeps the rendering mode of the extended profile", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProfile(t, filepath.Join(dir, "small.yaml"), "extends: lite\n")
+
+		profile, err := profiles.NewLoader(dir).Load("small")
+		require.NoError(t, err)
+
+		assert.Equal(t, installation.RenderingLite, profile.Rendering)
+	})
+
+	t.Run("rejects invalid profiles", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProfile(t, filepath.Join(dir, "base.yaml"), "extends: gaming\n")
+		writeProfile(t, filepath.Join(dir, "empty.yaml"), "name: Empty\n")
+		writeProfile(t, filepath.Join(dir, "mode.yaml"), "rendering: fancy\npackages: [hyprland]\n")
+		loader := profiles.NewLoader(dir)
+
+		_, err := loader.Load("base")
+		assert.ErrorIs(t, err, installation.ErrUnknownProfile)
+
+		_, err = loader.Load("empty")
+		assert.Error(t, err)
+
+		_, err = loader.Load("mode")
+		assert.ErrorIs(t, err, installation.ErrInvalidRenderingMode)
+	})
+
+	t.Run("reports unknown profiles", func(t *testing.T) {
+		_, err := profiles.NewLoader(t.TempDir()).Load("gaming")
+		assert.ErrorIs(t, err, installation.ErrUnknownProfile)
+	})
+}
+
+func TestLoader_Names(t *testing.T) {
+	t.Run("lists custom profiles", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProfile(t, filepath.Join(dir, "work.yaml"), "extends: recommended\n")
+		writeProfile(t, filepath.Join(dir, "tiny.yml"), "packages: [hyprland]\n")
+		writeProfile(t, filepath.Join(dir, "minimal.yaml"), "packages: [hyprland]\n")
+		writeProfile(t, filepath.Join(dir, "notes.txt"), "not a profile\n")
+
+		names, err := profiles.NewLoader(dir).Names()
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"tiny", "work"}, names)
+	})
+
+	t.Run("lists nothing without a profiles directory", func(t *testing.T) {
+		names, err := profiles.NewLoader(filepath.Join(t.TempDir(), "missing")).Names()
+		require.NoError(t, err)
+
+		assert.Empty(t, names)
+	})
+}
@@ -0,0 +1,85 @@
+package reboot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// Detector tells whether an installation needs a reboot from the packages
+// dpkg installed during it and the system's reboot-required flag
+type Detector struct {
+	inventory    history.PackageInventory
+	flagPath     string
+	packagesPath string
+}
+
+// NewDetector creates a detector reading the system flag files
+func NewDetector(inventory history.PackageInventory) *Detector {
+	return NewDetectorWithFlag(inventory, installation.RebootRequiredFlag, installation.RebootRequiredPackagesFile)
+}
+
+// NewDetectorWithFlag creates a detector reading the given flag and
+// package list files
+func NewDetectorWithFlag(inventory history.PackageInventory, flagPath, packagesPath string) *Detector {
+	return &Detector{
+		inventory:    inventory,
+		flagPath:     flagPath,
+		packagesPath: packagesPath,
+	}
+}
+
+// Detect returns why the installation of components, started at since,
+// needs a reboot. The flag is still read when the dpkg log is not, and the
+// error reports what could not be checked.
+func (d *Detector) Detect(ctx context.Context, components []installation.ComponentName, since time.Time) (installation.RebootRequirement, error) {
+	var names []string
+	packages, inventoryErr := d.inventory.InstalledBetween(ctx, since, time.Now())
+	for _, pkg := range packages {
+		names = append(names, pkg.Name())
+	}
+
+	flagged, flaggedPackages, flagErr := d.readFlag()
+	reboot := installation.DetectRebootRequirement(components, names, flagged, flaggedPackages)
+
+	if inventoryErr != nil {
+		return reboot, fmt.Errorf("installed packages unknown: %w", inventoryErr)
+	}
+	return reboot, flagErr
+}
+
+// readFlag returns whether the reboot-required flag is set and the
+// packages listed as needing it
+func (d *Detector) readFlag() (bool, []string, error) {
+	if _, err := os.Stat(d.flagPath); errors.Is(err, fs.ErrNotExist) {
+		return false, nil, nil
+	} else if err != nil {
+		return false, nil, fmt.Errorf("failed to check %s: %w", d.flagPath, err)
+	}
+
+	content, err := os.ReadFile(d.packagesPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil, nil
+	}
+	if err != nil {
+		return true, nil, fmt.Errorf("failed to read %s: %w", d.packagesPath, err)
+	}
+
+	var packages []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		name := strings.TrimSpace(line)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			packages = append(packages, name)
+		}
+	}
+	return true, packages, nil
+}
@@ -0,0 +1,80 @@
+package reboot_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/reboot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeInventory reports a fixed set of installed packages
+type fakeInventory struct {
+	names []string
+	err   error
+}
+
+func (f fakeInventory) InstalledBetween(ctx context.Context, from, to time.Time) ([]history.InstalledPackage, error) {
+	var packages []history.InstalledPackage
+	for _, name := range f.names {
+		pkg, err := history.NewInstalledPackage(name, "1.0", 0)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, f.err
+}
+
+func TestDetector_Detect(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("needs no reboot for user-space packages", func(t *testing.T) {
+		dir := t.TempDir()
+		detector := reboot.NewDetectorWithFlag(fakeInventory{names: []string{"hyprland", "waybar"}},
+			filepath.Join(dir, "reboot-required"), filepath.Join(dir, "reboot-required.pkgs"))
+
+		requirement, err := detector.Detect(ctx, []installation.ComponentName{installation.ComponentHyprland}, time.Now())
+		require.NoError(t, err)
+		assert.False(t, requirement.IsRequired())
+	})
+
+	t.Run("needs a reboot for a kernel pulled in as a dependency", func(t *testing.T) {
+		dir := t.TempDir()
+		detector := reboot.NewDetectorWithFlag(fakeInventory{names: []string{"hyprland", "linux-image-6.12.0-1-amd64"}},
+			filepath.Join(dir, "reboot-required"), filepath.Join(dir, "reboot-required.pkgs"))
+
+		requirement, err := detector.Detect(ctx, nil, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A new kernel was installed (linux-image-6.12.0-1-amd64)"}, requirement.Reasons())
+	})
+
+	t.Run("reads the system flag and the packages that set it", func(t *testing.T) {
+		dir := t.TempDir()
+		flag := filepath.Join(dir, "reboot-required")
+		pkgs := filepath.Join(dir, "reboot-required.pkgs")
+		require.NoError(t, os.WriteFile(flag, []byte("*** System restart required ***\n"), 0o644))
+		require.NoError(t, os.WriteFile(pkgs, []byte("libc6\ndbus\nlibc6\n"), 0o644))
+
+		requirement, err := reboot.NewDetectorWithFlag(fakeInventory{}, flag, pkgs).Detect(ctx, nil, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"The system reports a reboot is required for libc6, dbus"}, requirement.Reasons())
+	})
+
+	t.Run("still reads the flag when the dpkg log cannot be read", func(t *testing.T) {
+		dir := t.TempDir()
+		flag := filepath.Join(dir, "reboot-required")
+		require.NoError(t, os.WriteFile(flag, nil, 0o644))
+
+		requirement, err := reboot.NewDetectorWithFlag(fakeInventory{err: assert.AnError}, flag, filepath.Join(dir, "missing")).
+			Detect(ctx, []installation.ComponentName{installation.ComponentNVIDIADriver}, time.Now())
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Len(t, requirement.Reasons(), 2)
+	})
+}
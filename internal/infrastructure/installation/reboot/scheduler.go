@@ -0,0 +1,34 @@
+package reboot
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ScheduleMessage is broadcast to logged-in users when a reboot is
+// scheduled
+const ScheduleMessage = "gohan: rebooting to finish the installation"
+
+// Scheduler schedules reboots with shutdown(8), so they can still be
+// cancelled with shutdown -c
+type Scheduler struct{}
+
+// NewScheduler creates a scheduler for the running system
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Schedule reboots the system after delay, rounded up to whole minutes as
+// shutdown expects
+func (s *Scheduler) Schedule(ctx context.Context, delay time.Duration) error {
+	minutes := max(int((delay+time.Minute-1)/time.Minute), 0)
+
+	output, err := exec.CommandContext(ctx, "shutdown", "-r", fmt.Sprintf("+%d", minutes), ScheduleMessage).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to schedule a reboot: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
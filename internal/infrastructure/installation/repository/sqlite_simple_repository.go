@@ -78,6 +78,103 @@ type sessionStorageModel struct {
 	StartedAt           time.Time                  `json:"started_at"`
 	CompletedAt         time.Time                  `json:"completed_at"`
 	FailureReason       string                     `json:"failure_reason"`
+	Progress            *progressDTO               `json:"progress,omitempty"`
+	SystemContext       *systemContextDTO          `json:"system_context,omitempty"`
+	Warnings            []warningDTO               `json:"warnings,omitempty"`
+	PreflightChecks     []preflightCheckDTO        `json:"preflight_checks,omitempty"`
+	PreflightSessionID  string                     `json:"preflight_session_id,omitempty"`
+	DeployedConfigs     []deployedConfigDTO        `json:"deployed_configs,omitempty"`
+	Conflicts           []conflictResolutionDTO    `json:"conflicts,omitempty"`
+	ComponentStatuses   []componentStatusDTO       `json:"component_statuses,omitempty"`
+	Verifications       []componentVerificationDTO `json:"verifications,omitempty"`
+	Scope               string                     `json:"scope,omitempty"`
+	Invocation          *invocationDTO             `json:"invocation,omitempty"`
+	RebootReasons       []string                   `json:"reboot_reasons,omitempty"`
+	Timeline            []timelineEntryDTO         `json:"timeline,omitempty"`
+}
+
+// warningDTO is a serializable version of InstallationWarning
+type warningDTO struct {
+	Source   string    `json:"source"`
+	Message  string    `json:"message"`
+	RaisedAt time.Time `json:"raised_at"`
+}
+
+// preflightCheckDTO is a serializable version of PreflightCheck
+type preflightCheckDTO struct {
+	Requirement string `json:"requirement"`
+	Status      string `json:"status"`
+	Actual      string `json:"actual,omitempty"`
+	Expected    string `json:"expected,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// invocationDTO is a serializable version of Invocation
+type invocationDTO struct {
+	Args       []string          `json:"args"`
+	Flags      map[string]string `json:"flags,omitempty"`
+	ConfigHash string            `json:"config_hash,omitempty"`
+	Version    string            `json:"version,omitempty"`
+	Commit     string            `json:"commit,omitempty"`
+	User       string            `json:"user,omitempty"`
+}
+
+// deployedConfigDTO is a serializable version of DeployedConfig
+type deployedConfigDTO struct {
+	Path     string `json:"path"`
+	Hash     string `json:"hash"`
+	BackupID string `json:"backup_id,omitempty"`
+}
+
+// conflictResolutionDTO is a serializable version of ConflictResolution
+type conflictResolutionDTO struct {
+	PackageName        string `json:"package_name"`
+	ConflictingPackage string `json:"conflicting_package"`
+	Reason             string `json:"reason,omitempty"`
+	Action             string `json:"action"`
+	Applied            bool   `json:"applied"`
+}
+
+// componentStatusDTO is a serializable version of ComponentStatus
+type componentStatusDTO struct {
+	Component  string    `json:"component"`
+	State      string    `json:"state"`
+	Error      string    `json:"error,omitempty"`
+	SkipReason string    `json:"skip_reason,omitempty"`
+	SkipDetail string    `json:"skip_detail,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// componentVerificationDTO is a serializable version of ComponentVerification
+type componentVerificationDTO struct {
+	Component  string    `json:"component"`
+	Problems   []string  `json:"problems,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// timelineEntryDTO is a serializable version of TimelineEntry
+type timelineEntryDTO struct {
+	Kind   string    `json:"kind"`
+	Name   string    `json:"name"`
+	State  string    `json:"state,omitempty"`
+	Detail string    `json:"detail,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// progressDTO is a serializable version of InstallationProgress
+type progressDTO struct {
+	Phase         string    `json:"phase"`
+	Percent       int       `json:"percent"`
+	Message       string    `json:"message"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// systemContextDTO is a serializable version of SystemContext
+type systemContextDTO struct {
+	DiskWriteBytesPerSec float64   `json:"disk_write_bytes_per_sec"`
+	DownloadBytesPerSec  float64   `json:"download_bytes_per_sec"`
+	MeasuredAt           time.Time `json:"measured_at"`
 }
 
 // configurationDTO is a serializable version of InstallationConfiguration
@@ -89,6 +186,20 @@ type configurationDTO struct {
 	DiskAvailable      uint64                  `json:"disk_available"`
 	DiskRequired       uint64                  `json:"disk_required"`
 	MergeExistingConf  bool                    `json:"merge_existing_conf"`
+	Alternatives       []string                `json:"alternatives,omitempty"`
+	RenderingMode      string                  `json:"rendering_mode,omitempty"`
+	GPUs               []gpuDeviceDTO          `json:"gpus,omitempty"`
+	RenderGPU          string                  `json:"render_gpu,omitempty"`
+	Accessibility      []string                `json:"accessibility,omitempty"`
+	DryRun             bool                    `json:"dry_run,omitempty"`
+}
+
+// gpuDeviceDTO is a serializable version of GPUDevice
+type gpuDeviceDTO struct {
+	Vendor    string `json:"vendor"`
+	Model     string `json:"model,omitempty"`
+	PCISlot   string `json:"pci_slot"`
+	DRMDevice string `json:"drm_device,omitempty"`
 }
 
 // componentSelectionDTO is a serializable version of ComponentSelection
@@ -157,6 +268,22 @@ func toStorageModel(session *installation.InstallationSession) *sessionStorageMo
 		configDTO.GPUDriverComponent = string(config.GPUSupport().DriverComponent())
 	}
 
+	configDTO.Alternatives = config.Alternatives().Strings()
+	configDTO.RenderingMode = config.RenderingMode().String()
+	configDTO.Accessibility = config.Accessibility().Strings()
+	configDTO.DryRun = config.DryRun()
+	for _, gpu := range config.GPUs().Devices() {
+		configDTO.GPUs = append(configDTO.GPUs, gpuDeviceDTO{
+			Vendor:    gpu.Vendor(),
+			Model:     gpu.Model(),
+			PCISlot:   gpu.PCISlot(),
+			DRMDevice: gpu.DRMDevice(),
+		})
+	}
+	if render, ok := config.GPUs().RenderGPU(); ok {
+		configDTO.RenderGPU = render.PCISlot()
+	}
+
 	// Convert snapshot if present
 	var snapDTO *snapshotDTO
 	if snapshot := session.Snapshot(); snapshot != nil {
@@ -193,6 +320,115 @@ func toStorageModel(session *installation.InstallationSession) *sessionStorageMo
 		installedDTOs = append(installedDTOs, compDTO)
 	}
 
+	// Convert progress if reported
+	var progDTO *progressDTO
+	if progress := session.Progress(); !progress.IsZero() {
+		progDTO = &progressDTO{
+			Phase:         progress.Phase(),
+			Percent:       progress.PercentComplete(),
+			Message:       progress.Message(),
+			UpdatedAt:     progress.UpdatedAt(),
+		}
+	}
+
+	// Convert system context if measured
+	var contextDTO *systemContextDTO
+	if systemContext := session.SystemContext(); systemContext.IsMeasured() {
+		contextDTO = &systemContextDTO{
+			DiskWriteBytesPerSec: systemContext.DiskWriteBytesPerSec(),
+			DownloadBytesPerSec:  systemContext.DownloadBytesPerSec(),
+			MeasuredAt:           systemContext.MeasuredAt(),
+		}
+	}
+
+	// Convert warnings
+	var warningDTOs []warningDTO
+	for _, w := range session.Warnings() {
+		warningDTOs = append(warningDTOs, warningDTO{
+			Source:   string(w.Source()),
+			Message:  w.Message(),
+			RaisedAt: w.RaisedAt(),
+		})
+	}
+
+	// Convert preflight checks
+	var checkDTOs []preflightCheckDTO
+	for _, c := range session.PreflightChecks() {
+		checkDTOs = append(checkDTOs, preflightCheckDTO{
+			Requirement: c.Requirement(),
+			Status:      c.Status(),
+			Actual:      c.Actual(),
+			Expected:    c.Expected(),
+			Message:     c.Message(),
+		})
+	}
+
+	// Convert deployed configs
+	var deployedDTOs []deployedConfigDTO
+	for _, d := range session.DeployedConfigs() {
+		deployedDTOs = append(deployedDTOs, deployedConfigDTO{Path: d.Path(), Hash: d.Hash(), BackupID: d.BackupID()})
+	}
+
+	// Convert conflict resolutions
+	var conflictDTOs []conflictResolutionDTO
+	for _, r := range session.ConflictResolutions() {
+		conflictDTOs = append(conflictDTOs, conflictResolutionDTO{
+			PackageName:        r.Conflict().PackageName(),
+			ConflictingPackage: r.Conflict().ConflictingPackage(),
+			Reason:             r.Conflict().Reason(),
+			Action:             r.Action().String(),
+			Applied:            r.IsApplied(),
+		})
+	}
+
+	// Convert component statuses
+	var statusDTOs []componentStatusDTO
+	for _, c := range session.ComponentStatuses() {
+		statusDTOs = append(statusDTOs, componentStatusDTO{
+			Component:  string(c.Component()),
+			State:      c.State().String(),
+			Error:      c.ErrorMessage(),
+			SkipReason: c.SkipReason().String(),
+			SkipDetail: c.SkipDetail(),
+			UpdatedAt:  c.UpdatedAt(),
+		})
+	}
+
+	// Convert verification outcomes
+	var verificationDTOs []componentVerificationDTO
+	for _, v := range session.ComponentVerifications() {
+		verificationDTOs = append(verificationDTOs, componentVerificationDTO{
+			Component:  string(v.Component()),
+			Problems:   v.Problems(),
+			DurationMs: v.Duration().Milliseconds(),
+			CheckedAt:  v.CheckedAt(),
+		})
+	}
+
+	// Convert timeline
+	var timelineDTOs []timelineEntryDTO
+	for _, e := range session.Timeline() {
+		timelineDTOs = append(timelineDTOs, timelineEntryDTO{
+			Kind:   string(e.Kind()),
+			Name:   e.Name(),
+			State:  e.State().String(),
+			Detail: e.Detail(),
+			At:     e.At(),
+		})
+	}
+
+	var invocationModel *invocationDTO
+	if invocation := session.Invocation(); !invocation.IsZero() {
+		invocationModel = &invocationDTO{
+			Args:       invocation.Args(),
+			Flags:      invocation.Flags(),
+			ConfigHash: invocation.ConfigHash(),
+			Version:    invocation.Version(),
+			Commit:     invocation.Commit(),
+			User:       invocation.User(),
+		}
+	}
+
 	return &sessionStorageModel{
 		ID:                  session.ID(),
 		Configuration:       configDTO,
@@ -202,6 +438,19 @@ func toStorageModel(session *installation.InstallationSession) *sessionStorageMo
 		StartedAt:           session.StartedAt(),
 		CompletedAt:         session.CompletedAt(),
 		FailureReason:       session.FailureReason(),
+		Progress:            progDTO,
+		SystemContext:       contextDTO,
+		Warnings:            warningDTOs,
+		PreflightChecks:     checkDTOs,
+		DeployedConfigs:     deployedDTOs,
+		Conflicts:           conflictDTOs,
+		ComponentStatuses:   statusDTOs,
+		Verifications:       verificationDTOs,
+		Scope:               session.Scope(),
+		PreflightSessionID:  session.PreflightSessionID(),
+		Invocation:          invocationModel,
+		RebootReasons:       session.RebootRequirement().Reasons(),
+		Timeline:            timelineDTOs,
 	}
 }
 
@@ -307,6 +556,41 @@ func fromStorageModel(model *sessionStorageModel) (*installation.InstallationSes
 		return nil, fmt.Errorf("failed to create configuration: %w", err)
 	}
 
+	alternatives, err := installation.ParseAlternativeSelection(model.Configuration.Alternatives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore alternatives: %w", err)
+	}
+	config, err = config.WithAlternatives(alternatives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore alternatives: %w", err)
+	}
+
+	renderingMode, err := installation.ParseRenderingMode(model.Configuration.RenderingMode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore rendering mode: %w", err)
+	}
+	config = config.WithRenderingMode(renderingMode)
+
+	accessibility, err := installation.ParseAccessibilitySettings(model.Configuration.Accessibility)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore accessibility options: %w", err)
+	}
+	config = config.WithAccessibility(accessibility).WithDryRun(model.Configuration.DryRun)
+
+	gpuDevices := make([]installation.GPUDevice, 0, len(model.Configuration.GPUs))
+	for _, g := range model.Configuration.GPUs {
+		device, err := installation.NewGPUDevice(g.Vendor, g.Model, g.PCISlot, g.DRMDevice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore GPU %s: %w", g.PCISlot, err)
+		}
+		gpuDevices = append(gpuDevices, device)
+	}
+	gpus, err := installation.NewGPUSelection(gpuDevices, model.Configuration.RenderGPU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore GPUs: %w", err)
+	}
+	config = config.WithGPUs(gpus)
+
 	// Reconstruct snapshot if present
 	var snapshot *installation.SystemSnapshot
 	if model.Snapshot != nil {
@@ -382,6 +666,161 @@ func fromStorageModel(model *sessionStorageModel) (*installation.InstallationSes
 		return nil, fmt.Errorf("failed to reconstruct session: %w", err)
 	}
 
+	if model.Progress != nil {
+		session.UpdateProgress(installation.NewInstallationProgress(
+			model.Progress.Phase,
+			model.Progress.Percent,
+			model.Progress.Message,
+			model.Progress.UpdatedAt,
+		))
+	}
+
+	if model.SystemContext != nil {
+		systemContext, err := installation.NewSystemContext(
+			model.SystemContext.DiskWriteBytesPerSec,
+			model.SystemContext.DownloadBytesPerSec,
+			model.SystemContext.MeasuredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct system context: %w", err)
+		}
+		session.SetSystemContext(systemContext)
+	}
+
+	for _, w := range model.Warnings {
+		warning, err := installation.ReconstructInstallationWarning(
+			installation.WarningSource(w.Source),
+			w.Message,
+			w.RaisedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct warning: %w", err)
+		}
+		session.AddWarning(warning)
+	}
+
+	session.SetScope(model.Scope)
+	session.SetRebootRequirement(installation.NewRebootRequirement(model.RebootReasons...))
+	session.SetPreflightSessionID(model.PreflightSessionID)
+
+	if model.Invocation != nil {
+		invocation, err := installation.NewInvocation(
+			model.Invocation.Args,
+			model.Invocation.Flags,
+			model.Invocation.ConfigHash,
+			model.Invocation.Version,
+			model.Invocation.Commit,
+			model.Invocation.User,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct invocation: %w", err)
+		}
+		session.SetInvocation(invocation)
+	}
+
+	if len(model.PreflightChecks) > 0 {
+		checks := make([]installation.PreflightCheck, 0, len(model.PreflightChecks))
+		for _, c := range model.PreflightChecks {
+			check, err := installation.NewPreflightCheck(c.Requirement, c.Status, c.Actual, c.Expected, c.Message)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reconstruct preflight check: %w", err)
+			}
+			checks = append(checks, check)
+		}
+		session.RecordPreflightChecks(checks)
+	}
+
+	if len(model.DeployedConfigs) > 0 {
+		configs := make([]installation.DeployedConfig, 0, len(model.DeployedConfigs))
+		for _, d := range model.DeployedConfigs {
+			config, err := installation.NewDeployedConfig(d.Path, d.Hash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reconstruct deployed config: %w", err)
+			}
+			configs = append(configs, config.WithBackupID(d.BackupID))
+		}
+		session.RecordDeployedConfigs(configs)
+	}
+
+	if len(model.Conflicts) > 0 {
+		resolutions := make([]installation.ConflictResolution, 0, len(model.Conflicts))
+		for _, c := range model.Conflicts {
+			conflict, err := installation.NewPackageConflict(c.PackageName, c.ConflictingPackage, c.Reason)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reconstruct conflict: %w", err)
+			}
+			resolution := installation.NewConflictResolution(conflict, installation.ResolutionAction(c.Action))
+			if c.Applied {
+				resolution = resolution.MarkAsApplied()
+			}
+			resolutions = append(resolutions, resolution)
+		}
+		session.RestoreConflictResolutions(resolutions)
+	}
+
+	// Sessions saved before component tracking keep their pending defaults
+	if len(model.ComponentStatuses) > 0 {
+		statuses := make([]installation.ComponentStatus, 0, len(model.ComponentStatuses))
+		for _, c := range model.ComponentStatuses {
+			var status installation.ComponentStatus
+			var err error
+			if installation.ComponentState(c.State) == installation.ComponentStateSkipped {
+				status, err = installation.ReconstructSkippedComponentStatus(
+					installation.ComponentName(c.Component),
+					installation.SkipReason(c.SkipReason),
+					c.SkipDetail,
+					c.UpdatedAt,
+				)
+			} else {
+				status, err = installation.ReconstructComponentStatus(
+					installation.ComponentName(c.Component),
+					installation.ComponentState(c.State),
+					c.Error,
+					c.UpdatedAt,
+				)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to reconstruct component status: %w", err)
+			}
+			statuses = append(statuses, status)
+		}
+		session.RestoreComponentStatuses(statuses)
+	}
+
+	if len(model.Verifications) > 0 {
+		verifications := make([]installation.ComponentVerification, 0, len(model.Verifications))
+		for _, v := range model.Verifications {
+			verification, err := installation.ReconstructComponentVerification(
+				installation.ComponentName(v.Component),
+				v.Problems,
+				time.Duration(v.DurationMs)*time.Millisecond,
+				v.CheckedAt,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reconstruct component verification: %w", err)
+			}
+			verifications = append(verifications, verification)
+		}
+		session.RestoreComponentVerifications(verifications)
+	}
+
+	// Always restored, replacing the phase restoring the progress recorded
+	timeline := make([]installation.TimelineEntry, 0, len(model.Timeline))
+	for _, e := range model.Timeline {
+		entry, err := installation.ReconstructTimelineEntry(
+			installation.TimelineEntryKind(e.Kind),
+			e.Name,
+			installation.ComponentState(e.State),
+			e.Detail,
+			e.At,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct timeline entry: %w", err)
+		}
+		timeline = append(timeline, entry)
+	}
+	session.RestoreTimeline(timeline)
+
 	return session, nil
 }
 
@@ -399,7 +838,7 @@ func (r *SQLiteSimpleSessionRepository) FindByID(ctx context.Context, id string)
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("session not found: %s", id)
+			return nil, fmt.Errorf("session %s: %w", id, installation.ErrSessionNotFound)
 		}
 		return nil, fmt.Errorf("failed to query session: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/stretchr/testify/assert"
@@ -85,7 +86,7 @@ func TestSQLiteSimpleSessionRepository_FindByID(t *testing.T) {
 		found, err := repo.FindByID(ctx, "non-existent-id")
 
 		// Assert
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, installation.ErrSessionNotFound)
 		assert.Nil(t, found)
 	})
 
@@ -139,6 +140,404 @@ func TestSQLiteSimpleSessionRepository_FindByID(t *testing.T) {
 		assert.NotNil(t, found.Snapshot())
 		assert.Len(t, found.InstalledComponents(), 1)
 	})
+
+	t.Run("restores reported progress, warnings and preflight checks", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		session := createTestSession(t)
+		ctx := context.Background()
+
+		updatedAt := time.Now().UTC().Truncate(time.Second)
+		session.UpdateProgress(installation.NewInstallationProgress(
+			"Installing Components",
+			57,
+			"Installing waybar (2/3)",
+			updatedAt,
+		))
+
+		warning, err := installation.NewInstallationWarning(installation.WarningSourceSkipped, "Skipped hyprpaper")
+		require.NoError(t, err)
+		session.AddWarning(warning)
+
+		check, err := installation.NewPreflightCheck("disk_space", "warning", "12 GB available", "10 GB", "Disk space is low")
+		require.NoError(t, err)
+		session.RecordPreflightChecks([]installation.PreflightCheck{check})
+
+		deployed, err := installation.NewDeployedConfig("/home/user/.config/hypr/hyprland.conf", "abc123")
+		require.NoError(t, err)
+		deployed = deployed.WithBackupID("backup-20241030-120000")
+		session.RecordDeployedConfigs([]installation.DeployedConfig{deployed})
+
+		conflict, err := installation.NewPackageConflict("hyprland", "hyprland-git", "conflicting package versions")
+		require.NoError(t, err)
+		session.RecordConflict(conflict, installation.ActionRemove)
+		_, err = session.ResolveConflict(conflict)
+		require.NoError(t, err)
+
+		err = repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		progress := found.Progress()
+		assert.Equal(t, "Installing Components", progress.Phase())
+		assert.Equal(t, 57, progress.PercentComplete())
+		assert.Equal(t, "Installing waybar (2/3)", progress.Message())
+		assert.True(t, updatedAt.Equal(progress.UpdatedAt()))
+
+		require.Len(t, found.Warnings(), 1)
+		assert.Equal(t, installation.WarningSourceSkipped, found.Warnings()[0].Source())
+		assert.Equal(t, "Skipped hyprpaper", found.Warnings()[0].Message())
+
+		require.Len(t, found.PreflightChecks(), 1)
+		assert.Equal(t, check, found.PreflightChecks()[0])
+
+		assert.Equal(t, []installation.DeployedConfig{deployed}, found.DeployedConfigs())
+
+		require.Len(t, found.ConflictResolutions(), 1)
+		assert.Equal(t, session.ConflictResolutions()[0], found.ConflictResolutions()[0])
+	})
+
+	t.Run("restores measured system context", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		session := createTestSession(t)
+		ctx := context.Background()
+
+		measuredAt := time.Now().UTC().Truncate(time.Second)
+		systemContext, err := installation.NewSystemContext(18*float64(installation.MB), 512*1024, measuredAt)
+		require.NoError(t, err)
+		session.SetSystemContext(systemContext)
+
+		err = repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		restored := found.SystemContext()
+		assert.Equal(t, 18*float64(installation.MB), restored.DiskWriteBytesPerSec())
+		assert.Equal(t, float64(512*1024), restored.DownloadBytesPerSec())
+		assert.True(t, measuredAt.Equal(restored.MeasuredAt()))
+	})
+
+	t.Run("restores component states and failure causes", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		session := createTestSession(t)
+		ctx := context.Background()
+		require.NoError(t, session.FailComponent(installation.ComponentHyprland, "failed to install hyprland: exit status 100"))
+
+		err := repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		status, ok := found.ComponentStatus(installation.ComponentHyprland)
+		require.True(t, ok)
+		assert.Equal(t, installation.ComponentStateFailed, status.State())
+		assert.Equal(t, "failed to install hyprland: exit status 100", status.ErrorMessage())
+		assert.False(t, status.UpdatedAt().IsZero())
+	})
+
+	t.Run("restores skipped components and why", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		session := createTestSession(t)
+		ctx := context.Background()
+		require.NoError(t, session.SkipComponent(installation.ComponentHyprland, installation.SkipReasonUnavailable,
+			"package hyprland is not available from the configured repositories"))
+
+		err := repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		skipped := found.SkippedComponents()
+		require.Len(t, skipped, 1)
+		assert.Equal(t, installation.ComponentHyprland, skipped[0].Component())
+		assert.Equal(t, installation.SkipReasonUnavailable, skipped[0].SkipReason())
+		assert.Equal(t, "package hyprland is not available from the configured repositories", skipped[0].SkipDetail())
+	})
+
+	t.Run("restores component verifications and timing", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		session := createTestSession(t)
+		ctx := context.Background()
+		verification, err := installation.NewComponentVerification(
+			installation.ComponentHyprland,
+			[]string{"missing binary /usr/bin/Hyprland"},
+			250*time.Millisecond,
+		)
+		require.NoError(t, err)
+		require.NoError(t, session.RecordComponentVerification(verification))
+
+		err = repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		restored, ok := found.ComponentVerification(installation.ComponentHyprland)
+		require.True(t, ok)
+		assert.False(t, restored.Passed())
+		assert.Equal(t, []string{"missing binary /usr/bin/Hyprland"}, restored.Problems())
+		assert.Equal(t, 250*time.Millisecond, restored.Duration())
+		assert.False(t, restored.CheckedAt().IsZero())
+	})
+
+	t.Run("restores chosen alternatives", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		compSel, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.32.0", nil)
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(500000000, 100000000)
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{compSel}, nil, diskSpace, false)
+		require.NoError(t, err)
+
+		alternatives, err := installation.ParseAlternativeSelection([]string{"terminal=foot", "swaylock"})
+		require.NoError(t, err)
+		config, err = config.WithAlternatives(alternatives)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		ctx := context.Background()
+
+		err = repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, alternatives.Choices(), found.Configuration().Alternatives().Choices())
+	})
+
+	t.Run("restores chosen rendering mode, accessibility options and dry run", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		compSel, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.32.0", nil)
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(500000000, 100000000)
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{compSel}, nil, diskSpace, false)
+		require.NoError(t, err)
+		config = config.WithRenderingMode(installation.RenderingLite).
+			WithAccessibility(installation.NewAccessibilitySettings(true, false, true)).
+			WithDryRun(true)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		ctx := context.Background()
+
+		err = repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, installation.RenderingLite, found.Configuration().RenderingMode())
+		assert.Equal(t, []string{"reduced-motion", "high-contrast"}, found.Configuration().Accessibility().Strings())
+		assert.True(t, found.Configuration().DryRun())
+	})
+
+	t.Run("restores detected GPUs and render choice", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		compSel, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.32.0", nil)
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(500000000, 100000000)
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{compSel}, nil, diskSpace, false)
+		require.NoError(t, err)
+
+		intel, err := installation.NewGPUDevice("intel", "UHD Graphics 620", "0000:00:02.0", "/dev/dri/card0")
+		require.NoError(t, err)
+		nvidia, err := installation.NewGPUDevice("nvidia", "GeForce MX150", "0000:01:00.0", "/dev/dri/card1")
+		require.NoError(t, err)
+		gpus, err := installation.NewGPUSelection([]installation.GPUDevice{intel, nvidia}, "0000:01:00.0")
+		require.NoError(t, err)
+		config = config.WithGPUs(gpus)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		ctx := context.Background()
+
+		err = repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		restored := found.Configuration().GPUs()
+		assert.Equal(t, gpus.Devices(), restored.Devices())
+		render, ok := restored.RenderGPU()
+		require.True(t, ok)
+		assert.Equal(t, "0000:01:00.0", render.PCISlot())
+	})
+
+	t.Run("restores preflight session reference", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		compSel, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.32.0", nil)
+		require.NoError(t, err)
+		diskSpace, err := installation.NewDiskSpace(500000000, 100000000)
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{compSel}, nil, diskSpace, false)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		session.SetPreflightSessionID("preflight-123")
+		ctx := context.Background()
+
+		err = repo.Save(ctx, session)
+		require.NoError(t, err)
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "preflight-123", found.PreflightSessionID())
+	})
+
+	t.Run("restores the invocation", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		compSel, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.32.0", nil)
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{compSel}, nil, installation.DiskSpace{}, false)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		invocation, err := installation.NewInvocation(
+			[]string{"gohan", "install", "--dry-run"},
+			map[string]string{"dry-run": "true"},
+			"abc123", "1.2.0", "deadbeef", "alice",
+		)
+		require.NoError(t, err)
+		session.SetInvocation(invocation)
+		ctx := context.Background()
+
+		require.NoError(t, repo.Save(ctx, session))
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, invocation, found.Invocation())
+	})
+
+	t.Run("restores the reboot requirement", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		compSel, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.32.0", nil)
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{compSel}, nil, installation.DiskSpace{}, false)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		reboot := installation.NewRebootRequirement("A new kernel was installed (linux-image-6.12.0-1-amd64)")
+		session.SetRebootRequirement(reboot)
+		ctx := context.Background()
+
+		require.NoError(t, repo.Save(ctx, session))
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, found.RebootRequirement().IsRequired())
+		assert.Equal(t, reboot.Reasons(), found.RebootRequirement().Reasons())
+	})
+
+	t.Run("restores the timeline", func(t *testing.T) {
+		// Arrange
+		repo := setupTestDB(t)
+		defer repo.Close()
+
+		compSel, err := installation.NewComponentSelection(installation.ComponentHyprland, "0.32.0", nil)
+		require.NoError(t, err)
+		config, err := installation.NewInstallationConfiguration(
+			[]installation.ComponentSelection{compSel}, nil, installation.DiskSpace{}, false)
+		require.NoError(t, err)
+
+		session, err := installation.NewInstallationSession(config)
+		require.NoError(t, err)
+		session.UpdateProgress(installation.NewInstallationProgress("Preflight", 5, "Checking", time.Now()))
+		session.UpdateProgress(installation.NewInstallationProgress("Installing", 30, "Installing hyprland", time.Now()))
+		require.NoError(t, session.FailComponent(installation.ComponentHyprland, "apt-get exited with status 100"))
+		ctx := context.Background()
+
+		require.NoError(t, repo.Save(ctx, session))
+
+		// Act
+		found, err := repo.FindByID(ctx, session.ID())
+
+		// Assert
+		require.NoError(t, err)
+		timeline := found.Timeline()
+		require.Len(t, timeline, 3)
+		assert.Equal(t, "Preflight", timeline[0].Name())
+		assert.Equal(t, "Installing", timeline[1].Name())
+		assert.Equal(t, installation.TimelineEntryComponent, timeline[2].Kind())
+		assert.Equal(t, installation.ComponentStateFailed, timeline[2].State())
+		assert.Equal(t, "apt-get exited with status 100", timeline[2].Detail())
+	})
 }
 
 func TestSQLiteSimpleSessionRepository_List(t *testing.T) {
@@ -252,3 +651,26 @@ func TestSQLiteSimpleSessionRepository_List(t *testing.T) {
 		assert.Equal(t, 1, statuses[installation.StatusFailed])
 	})
 }
+
+func TestSQLiteSimpleSessionRepository_CanceledContext(t *testing.T) {
+	repo := setupTestDB(t)
+	defer repo.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	session := createTestSession(t)
+
+	assert.ErrorIs(t, repo.Save(ctx, session), context.Canceled)
+
+	_, err := repo.FindByID(ctx, session.ID())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.List(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.Count(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.ErrorIs(t, repo.Clear(ctx), context.Canceled)
+}
@@ -6,6 +6,20 @@ import (
 	"github.com/rebelopsio/gohan/internal/domain/installation"
 )
 
+// calibrationRate is how far each past installation moves the phase
+// weights towards the shares its phases took
+const calibrationRate = 0.3
+
+// plannedPhases are the phases an installation is timed in. Packages are
+// downloaded right before they are installed, so the downloading share is
+// planned in the installing phase.
+var plannedPhases = []installation.InstallationStatus{
+	installation.StatusPreparation,
+	installation.StatusInstalling,
+	installation.StatusConfiguring,
+	installation.StatusVerifying,
+}
+
 // ProgressEstimator implements installation.ProgressEstimator
 // Calculates installation progress and time estimates
 type ProgressEstimator struct {
@@ -118,3 +132,107 @@ func (p *ProgressEstimator) estimateFromPhaseWeights(
 
 	return time.Duration(remaining)
 }
+
+// EstimateRemainingTimeWithContext implements installation.ThroughputAwareEstimator
+// Early in an installation, elapsed time says little about what is left, so
+// the estimate starts from the time the measured disk and network speeds
+// need for the packages and shifts towards the observed rate as progress grows
+func (p *ProgressEstimator) EstimateRemainingTimeWithContext(
+	systemContext installation.SystemContext,
+	downloadBytes uint64,
+	currentPhase installation.InstallationStatus,
+	percentComplete int,
+	elapsedTime time.Duration,
+) time.Duration {
+	observed := p.EstimateRemainingTime(currentPhase, percentComplete, elapsedTime)
+	if !systemContext.IsMeasured() || percentComplete >= 100 || currentPhase == installation.StatusCompleted {
+		return observed
+	}
+
+	// Package time covers the downloading and installing phases only
+	expectedTotal := float64(systemContext.EstimatePackageTime(downloadBytes)) / p.packageWeight()
+
+	progressFraction := 0.0
+	if percentComplete > 0 {
+		progressFraction = float64(percentComplete) / 100.0
+	}
+	measured := expectedTotal * (1.0 - progressFraction)
+	if progressFraction == 0 {
+		return time.Duration(measured)
+	}
+
+	return time.Duration(measured*(1.0-progressFraction) + float64(observed)*progressFraction)
+}
+
+// PlanPhases implements installation.PhasePlanner
+// The measured time for the packages covers their share of the phase
+// weights; the other phases get the rest in proportion to their weights
+func (p *ProgressEstimator) PlanPhases(
+	systemContext installation.SystemContext,
+	downloadBytes uint64,
+) (map[installation.InstallationStatus]time.Duration, bool) {
+	if !systemContext.IsMeasured() {
+		return nil, false
+	}
+
+	packageWeight := p.packageWeight()
+	total := float64(systemContext.EstimatePackageTime(downloadBytes)) / packageWeight
+
+	plan := make(map[installation.InstallationStatus]time.Duration, len(plannedPhases))
+	for _, phase := range plannedPhases {
+		weight := p.getPhaseWeight(phase)
+		if phase == installation.StatusInstalling {
+			weight = packageWeight
+		}
+		plan[phase] = time.Duration(total * weight)
+	}
+	return plan, true
+}
+
+// Calibrate moves the phase weights towards the shares the phases took in
+// past installations on this machine, oldest first, so later estimates
+// converge on how this machine performs. Weights of phases that were not
+// timed are kept.
+func (p *ProgressEstimator) Calibrate(runs [][]installation.PhaseTiming) {
+	for _, timings := range runs {
+		actual := make(map[installation.InstallationStatus]time.Duration, len(timings))
+		var total time.Duration
+		for _, t := range timings {
+			actual[t.Phase()] += t.Actual()
+			total += t.Actual()
+		}
+		if total <= 0 || len(actual) != len(plannedPhases) {
+			continue
+		}
+
+		// Shares are scaled to the weight of the timed phases, so the
+		// untimed ones keep theirs
+		var mass float64
+		for _, phase := range plannedPhases {
+			if phase == installation.StatusInstalling {
+				mass += p.packageWeight()
+				continue
+			}
+			mass += p.getPhaseWeight(phase)
+		}
+
+		for _, phase := range plannedPhases {
+			share := float64(actual[phase]) / float64(total) * mass
+			if phase != installation.StatusInstalling {
+				p.phaseWeights[phase] += calibrationRate * (share - p.getPhaseWeight(phase))
+				continue
+			}
+
+			// Downloading and installing keep their ratio
+			packageWeight := p.packageWeight()
+			scale := (packageWeight + calibrationRate*(share-packageWeight)) / packageWeight
+			p.phaseWeights[installation.StatusDownloading] = p.getPhaseWeight(installation.StatusDownloading) * scale
+			p.phaseWeights[installation.StatusInstalling] = p.getPhaseWeight(installation.StatusInstalling) * scale
+		}
+	}
+}
+
+// packageWeight is the weight of downloading and installing the packages
+func (p *ProgressEstimator) packageWeight() float64 {
+	return p.getPhaseWeight(installation.StatusDownloading) + p.getPhaseWeight(installation.StatusInstalling)
+}
@@ -242,3 +242,105 @@ func TestProgressEstimator_ConsistentCalculations(t *testing.T) {
 		assert.Equal(t, result1, result2, "Progress calculation should be deterministic")
 	})
 }
+
+func TestProgressEstimator_EstimateRemainingTimeWithContext(t *testing.T) {
+	estimator := services.NewProgressEstimator()
+	var _ installation.ThroughputAwareEstimator = estimator
+
+	now := time.Now()
+	downloadBytes := uint64(700 * installation.MB)
+
+	t.Run("falls back to observed rate when nothing was measured", func(t *testing.T) {
+		observed := estimator.EstimateRemainingTime(installation.StatusInstalling, 50, 10*time.Minute)
+		remaining := estimator.EstimateRemainingTimeWithContext(
+			installation.SystemContext{}, downloadBytes, installation.StatusInstalling, 50, 10*time.Minute)
+
+		assert.Equal(t, observed, remaining)
+	})
+
+	t.Run("slow eMMC and network give a longer early estimate", func(t *testing.T) {
+		fast, err := installation.NewSystemContext(500*float64(installation.MB), 50*float64(installation.MB), now)
+		assert.NoError(t, err)
+		slow, err := installation.NewSystemContext(20*float64(installation.MB), 1*float64(installation.MB), now)
+		assert.NoError(t, err)
+
+		// Preflight finished quickly, so the observed rate alone is optimistic
+		observed := estimator.EstimateRemainingTime(installation.StatusPreparation, 15, 30*time.Second)
+		fastRemaining := estimator.EstimateRemainingTimeWithContext(fast, downloadBytes, installation.StatusPreparation, 15, 30*time.Second)
+		slowRemaining := estimator.EstimateRemainingTimeWithContext(slow, downloadBytes, installation.StatusPreparation, 15, 30*time.Second)
+
+		assert.Greater(t, slowRemaining, fastRemaining)
+		assert.Greater(t, slowRemaining, observed)
+		assert.Greater(t, slowRemaining, 10*time.Minute, "700MB at 1MB/s takes well over ten minutes")
+	})
+
+	t.Run("estimate at start comes from measured throughput", func(t *testing.T) {
+		ctx, err := installation.NewSystemContext(0, 1*float64(installation.MB), now)
+		assert.NoError(t, err)
+
+		remaining := estimator.EstimateRemainingTimeWithContext(ctx, 70*uint64(installation.MB), installation.StatusPending, 0, 0)
+
+		// 70 seconds of downloading is 70% of the total by phase weights
+		assert.InDelta(t, float64(100*time.Second), float64(remaining), float64(time.Second))
+	})
+
+	t.Run("completed installation has nothing remaining", func(t *testing.T) {
+		ctx, err := installation.NewSystemContext(20*float64(installation.MB), 0, now)
+		assert.NoError(t, err)
+
+		remaining := estimator.EstimateRemainingTimeWithContext(ctx, downloadBytes, installation.StatusCompleted, 100, time.Hour)
+		assert.Equal(t, time.Duration(0), remaining)
+	})
+}
+
+func TestProgressEstimator_PlanPhases(t *testing.T) {
+	var _ installation.PhasePlanner = services.NewProgressEstimator()
+	measured, err := installation.NewSystemContext(0, 1*float64(installation.MB), time.Now())
+	assert.NoError(t, err)
+	downloadBytes := 70 * uint64(installation.MB)
+
+	t.Run("needs a measured throughput", func(t *testing.T) {
+		_, ok := services.NewProgressEstimator().PlanPhases(installation.SystemContext{}, downloadBytes)
+		assert.False(t, ok)
+	})
+
+	t.Run("splits the expected total by phase weights", func(t *testing.T) {
+		plan, ok := services.NewProgressEstimator().PlanPhases(measured, downloadBytes)
+		assert.True(t, ok)
+
+		// 70 seconds of packages is 70% of a 100 second installation
+		assert.InDelta(t, float64(70*time.Second), float64(plan[installation.StatusInstalling]), float64(time.Second))
+		assert.InDelta(t, float64(15*time.Second), float64(plan[installation.StatusConfiguring]), float64(time.Second))
+		assert.NotContains(t, plan, installation.StatusDownloading, "downloads are planned in the installing phase")
+	})
+
+	t.Run("calibration converges on the phases' actual shares", func(t *testing.T) {
+		estimator := services.NewProgressEstimator()
+		run := func(prep, install, configure, verify time.Duration) []installation.PhaseTiming {
+			var timings []installation.PhaseTiming
+			for phase, actual := range map[installation.InstallationStatus]time.Duration{
+				installation.StatusPreparation: prep,
+				installation.StatusInstalling:  install,
+				installation.StatusConfiguring: configure,
+				installation.StatusVerifying:   verify,
+			} {
+				timing, err := installation.NewPhaseTiming(phase, 0, actual)
+				assert.NoError(t, err)
+				timings = append(timings, timing)
+			}
+			return timings
+		}
+
+		// Configuring is slow on this machine
+		var runs [][]installation.PhaseTiming
+		for range 20 {
+			runs = append(runs, run(5*time.Second, 70*time.Second, 70*time.Second, 9*time.Second))
+		}
+		estimator.Calibrate(runs)
+
+		plan, ok := estimator.PlanPhases(measured, downloadBytes)
+		assert.True(t, ok)
+		assert.InDelta(t, float64(70*time.Second), float64(plan[installation.StatusConfiguring]), float64(2*time.Second))
+		assert.InDelta(t, float64(70*time.Second), float64(plan[installation.StatusInstalling]), float64(time.Second))
+	})
+}
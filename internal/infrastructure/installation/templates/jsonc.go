@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ValidateJSONC reports whether rendered content, such as a Waybar config,
+// is valid JSON once its // and /* */ comments are removed
+func ValidateJSONC(content string) error {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(stripJSONComments(content)), &decoded); err != nil {
+		return fmt.Errorf("rendered JSON is invalid: %w", err)
+	}
+	return nil
+}
+
+// stripJSONComments blanks out comments outside of strings, keeping line
+// breaks so offsets in errors stay meaningful
+func stripJSONComments(content string) string {
+	var b strings.Builder
+	b.Grow(len(content))
+
+	inString, escaped := false, false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case inString:
+			b.WriteByte(c)
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			b.WriteByte(c)
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				b.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			i += 2
+			for i < len(content) && !(content[i] == '*' && i+1 < len(content) && content[i+1] == '/') {
+				if content[i] == '\n' {
+					b.WriteByte('\n')
+				}
+				i++
+			}
+			i++ // Skip the closing slash
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// ValidateJSONCFile renders a JSONC template and validates the result
+func (e *TemplateEngine) ValidateJSONCFile(srcPath string, vars TemplateVars) error {
+	rendered, err := e.RenderFile(srcPath, vars)
+	if err != nil {
+		return err
+	}
+	if err := ValidateJSONC(rendered); err != nil {
+		return fmt.Errorf("%s: %w", srcPath, err)
+	}
+	return nil
+}
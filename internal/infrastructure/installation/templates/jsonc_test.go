@@ -0,0 +1,49 @@
+package templates_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJSONC(t *testing.T) {
+	t.Run("accepts comments outside strings", func(t *testing.T) {
+		content := `{
+  // line comment
+  "url": "https://wttr.in/Berlin", /* block
+  comment */ "n": 1
+}`
+		assert.NoError(t, templates.ValidateJSONC(content))
+	})
+
+	t.Run("rejects invalid JSON", func(t *testing.T) {
+		assert.Error(t, templates.ValidateJSONC(`{"modules-right": ["tray" "clock"]}`))
+	})
+}
+
+func TestWaybarConfigTemplates(t *testing.T) {
+	location, err := installation.NewWeatherLocation("São Paulo", installation.WeatherLocationConfigured)
+	require.NoError(t, err)
+
+	engine := templates.NewTemplateEngine()
+	for _, name := range []string{"config.jsonc", "config-lite.jsonc"} {
+		path := filepath.Join("..", "..", "..", "..", "templates", "waybar", name)
+
+		for _, weather := range []installation.WeatherLocation{{}, location} {
+			vars, err := templates.CollectSystemVars()
+			require.NoError(t, err)
+			for k, v := range installation.PowerTemplateVars("tlp") {
+				vars[k] = v
+			}
+			for k, v := range weather.TemplateVars() {
+				vars[k] = v
+			}
+
+			assert.NoError(t, engine.ValidateJSONCFile(path, vars), "%s with weather %s", name, weather)
+		}
+	}
+}
@@ -5,7 +5,12 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/migration"
 )
 
 // TemplateEngine handles template variable substitution
@@ -37,18 +42,53 @@ func (e *TemplateEngine) ProcessTemplate(content string, vars TemplateVars) (str
 
 // ProcessFile reads a template file, processes it, and writes the result
 func (e *TemplateEngine) ProcessFile(srcPath, dstPath string, vars TemplateVars) error {
+	processed, err := e.RenderFile(srcPath, vars)
+	if err != nil {
+		return err
+	}
+
+	return e.WriteOutput(dstPath, processed)
+}
+
+// RenderFile reads a template file and returns the processed content
+// without writing it anywhere
+func (e *TemplateEngine) RenderFile(srcPath string, vars TemplateVars) (string, error) {
 	// Read source template
 	content, err := os.ReadFile(srcPath)
 	if err != nil {
-		return fmt.Errorf("failed to read template file %s: %w", srcPath, err)
+		return "", fmt.Errorf("failed to read template file %s: %w", srcPath, err)
 	}
 
 	// Process template
 	processed, err := e.ProcessTemplate(string(content), vars)
 	if err != nil {
-		return fmt.Errorf("failed to process template: %w", err)
+		return "", fmt.Errorf("failed to process template: %w", err)
+	}
+
+	return processed, nil
+}
+
+// placeholderPattern matches a variable placeholder as ProcessTemplate
+// replaces it
+var placeholderPattern = regexp.MustCompile(`\{\{([A-Za-z0-9_]+)\}\}`)
+
+// UnresolvedPlaceholders returns the names of the placeholders left in
+// rendered content, sorted, such as a misspelled variable in a template
+func UnresolvedPlaceholders(rendered string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(rendered, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
 	}
+	sort.Strings(names)
+	return names
+}
 
+// WriteOutput writes processed content to dstPath, creating its directory
+func (e *TemplateEngine) WriteOutput(dstPath, processed string) error {
 	// Ensure destination directory exists
 	dstDir := filepath.Dir(dstPath)
 	if err := os.MkdirAll(dstDir, 0755); err != nil {
@@ -91,6 +131,68 @@ func CollectSystemVars() (TemplateVars, error) {
 	vars["display"] = detectPrimaryDisplay()
 	vars["resolution"] = detectPrimaryResolution()
 
+	// Default terminal, locker, idle and wallpaper commands; installations
+	// override these with the alternatives the user chose
+	var alternatives installation.AlternativeSelection
+	for k, v := range alternatives.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Power profile bindings and Waybar module stay empty until installed
+	for k, v := range installation.PowerTemplateVars("") {
+		vars[k] = v
+	}
+
+	// Night light autostart and Waybar toggle stay empty until installed
+	for k, v := range installation.NightLightTemplateVars("", installation.NightLightLocation{}) {
+		vars[k] = v
+	}
+
+	// Effects default to on; lite mode turns them off
+	for k, v := range installation.RenderingStandard.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Default font and cursor sizes; installations apply the chosen
+	// accessibility options
+	var accessibility installation.AccessibilitySettings
+	for k, v := range accessibility.TemplateVars() {
+		vars[k] = v
+	}
+
+	// No weather module until a location is configured or resolved
+	var weather installation.WeatherLocation
+	for k, v := range weather.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Blurred screenshot behind a 24-hour clock until a lock screen is
+	// configured
+	var lockScreen installation.LockScreenSettings
+	for k, v := range lockScreen.TemplateVars() {
+		vars[k] = v
+	}
+
+	// No AQ_DRM_DEVICES until installations supply the detected GPUs
+	var gpus installation.GPUSelection
+	for k, v := range gpus.TemplateVars() {
+		vars[k] = v
+	}
+
+	// Hyprland's portal backend with GTK's file picker until installations
+	// detect the installed backends
+	var portals installation.PortalSelection
+	for k, v := range portals.TemplateVars() {
+		vars[k] = v
+	}
+
+	// us keyboard layout and auto-detected monitors until settings are
+	// imported with gohan migrate
+	var migrated migration.Settings
+	for k, v := range migrated.TemplateVars() {
+		vars[k] = v
+	}
+
 	return vars, nil
 }
 
@@ -220,6 +220,16 @@ func TestTemplateVars_CollectFromSystem(t *testing.T) {
 		expected := filepath.Join(vars["home"], ".config")
 		assert.Equal(t, expected, vars["config_dir"])
 	})
+
+	t.Run("defaults keyboard and monitors until settings are imported", func(t *testing.T) {
+		vars, err := templates.CollectSystemVars()
+
+		require.NoError(t, err)
+		assert.Equal(t, "us", vars["kb_layout"])
+		assert.Contains(t, vars, "kb_variant")
+		assert.Contains(t, vars, "kb_options")
+		assert.Contains(t, vars, "monitor_config")
+	})
 }
 
 func TestTemplateEngine_RealWorldUsage(t *testing.T) {
@@ -335,3 +345,10 @@ func TestTemplateEngine_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestUnresolvedPlaceholders(t *testing.T) {
+	rendered := "col.active_border = rgb({{theme_mauv}})\nfont_size {{font_size}} {{theme_mauv}}\nformat = \"{icon}\"\n"
+
+	assert.Equal(t, []string{"font_size", "theme_mauv"}, templates.UnresolvedPlaceholders(rendered))
+	assert.Empty(t, templates.UnresolvedPlaceholders("gaps_in = 5\n"))
+}
@@ -0,0 +1,74 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// ErrNoTimezone is returned when the system timezone cannot be read
+var ErrNoTimezone = errors.New("system timezone not found")
+
+// LocationResolver finds the location the Waybar weather module reports
+// on. A configured city is used as is; otherwise the city is taken from the
+// system timezone, so nothing is looked up over the network.
+type LocationResolver struct {
+	city     string
+	timezone func() (string, error)
+}
+
+// NewLocationResolver creates a resolver for the configured city, reading
+// the running system's timezone when it is empty
+func NewLocationResolver(city string) *LocationResolver {
+	return &LocationResolver{city: city, timezone: SystemTimezone}
+}
+
+// NewLocationResolverWithTimezone creates a resolver that uses the given
+// timezone instead of the system's
+func NewLocationResolverWithTimezone(city, timezone string) *LocationResolver {
+	return &LocationResolver{city: city, timezone: func() (string, error) { return timezone, nil }}
+}
+
+// Location returns the configured city, or the city of the system timezone
+// when none is configured
+func (r *LocationResolver) Location() (installation.WeatherLocation, error) {
+	if strings.TrimSpace(r.city) != "" {
+		return installation.NewWeatherLocation(r.city, installation.WeatherLocationConfigured)
+	}
+
+	timezone, err := r.timezone()
+	if err != nil {
+		return installation.WeatherLocation{}, err
+	}
+	location, err := installation.WeatherLocationFromTimezone(timezone)
+	if err != nil {
+		return installation.WeatherLocation{}, fmt.Errorf("%w; set weather.city instead", err)
+	}
+	return location, nil
+}
+
+// SystemTimezone reads the timezone from TZ, /etc/timezone or the target
+// of the /etc/localtime link
+func SystemTimezone() (string, error) {
+	if tz := strings.TrimPrefix(os.Getenv("TZ"), ":"); tz != "" && !filepath.IsAbs(tz) {
+		return tz, nil
+	}
+
+	if data, err := os.ReadFile("/etc/timezone"); err == nil {
+		if tz := strings.TrimSpace(string(data)); tz != "" {
+			return tz, nil
+		}
+	}
+
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if _, tz, found := strings.Cut(target, "zoneinfo/"); found {
+			return tz, nil
+		}
+	}
+
+	return "", ErrNoTimezone
+}
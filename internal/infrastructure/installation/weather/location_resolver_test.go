@@ -0,0 +1,34 @@
+package weather_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/weather"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationResolver_Location(t *testing.T) {
+	t.Run("prefers the configured city", func(t *testing.T) {
+		location, err := weather.NewLocationResolverWithTimezone("Hamburg", "Europe/Berlin").Location()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hamburg", location.City())
+		assert.Equal(t, installation.WeatherLocationConfigured, location.Source())
+	})
+
+	t.Run("derives the city from the timezone", func(t *testing.T) {
+		location, err := weather.NewLocationResolverWithTimezone("", "America/Argentina/Buenos_Aires").Location()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Buenos Aires", location.City())
+		assert.Equal(t, installation.WeatherLocationTimezone, location.Source())
+	})
+
+	t.Run("fails for a timezone without a city", func(t *testing.T) {
+		_, err := weather.NewLocationResolverWithTimezone("", "Etc/UTC").Location()
+
+		assert.ErrorIs(t, err, installation.ErrInvalidWeatherLocation)
+	})
+}
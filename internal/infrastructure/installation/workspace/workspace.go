@@ -0,0 +1,180 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when no session has a working directory
+	ErrNotFound = errors.New("no artifacts found for session")
+
+	// ErrAmbiguous is returned when a session ID prefix matches more than
+	// one working directory
+	ErrAmbiguous = errors.New("session ID prefix matches more than one session")
+)
+
+// Subdirectories created in every session's working directory
+const (
+	// RenderedDir holds a copy of each configuration file as rendered
+	RenderedDir = "rendered"
+
+	// DownloadsDir holds sources downloaded for the session
+	DownloadsDir = "downloads"
+
+	// BuildDir holds build trees
+	BuildDir = "build"
+
+	// TempDir holds temporary files
+	TempDir = "tmp"
+)
+
+// Workspace describes one session's working directory
+type Workspace struct {
+	SessionID  string
+	Path       string
+	Bytes      uint64
+	Files      int
+	ModifiedAt time.Time
+}
+
+// Store keeps a working directory per installation session under a root
+// directory. Directories are removed when the session succeeds and kept
+// when it fails, so what it rendered and downloaded can be inspected.
+type Store struct {
+	root string
+}
+
+// NewStore creates a store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{root: dir}
+}
+
+// Root returns the directory session workspaces are created in
+func (s *Store) Root() string {
+	return s.root
+}
+
+// Path returns where the session's working directory is kept, whether or
+// not it exists
+func (s *Store) Path(sessionID string) string {
+	return filepath.Join(s.root, sessionID)
+}
+
+// Create makes the session's working directory and its subdirectories and
+// returns its path. An existing directory, such as one left by a cancelled
+// run being resumed, is reused.
+func (s *Store) Create(sessionID string) (string, error) {
+	if err := validateSessionID(sessionID); err != nil {
+		return "", err
+	}
+
+	path := s.Path(sessionID)
+	for _, dir := range []string{RenderedDir, DownloadsDir, BuildDir, TempDir} {
+		if err := os.MkdirAll(filepath.Join(path, dir), 0o700); err != nil {
+			return "", fmt.Errorf("failed to create session workspace: %w", err)
+		}
+	}
+	return path, nil
+}
+
+// Remove deletes the session's working directory; a missing directory is
+// not an error
+func (s *Store) Remove(sessionID string) error {
+	if err := validateSessionID(sessionID); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(s.Path(sessionID)); err != nil {
+		return fmt.Errorf("failed to remove session workspace: %w", err)
+	}
+	return nil
+}
+
+// Find looks up a session's working directory by its ID or a unique prefix
+// of it
+func (s *Store) Find(sessionID string) (*Workspace, error) {
+	if err := validateSessionID(sessionID); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(s.root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, sessionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.root, err)
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if entry.Name() == sessionID {
+			matches = []string{entry.Name()}
+			break
+		}
+		if strings.HasPrefix(entry.Name(), sessionID) {
+			matches = append(matches, entry.Name())
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, sessionID)
+	case 1:
+		return s.describe(matches[0])
+	default:
+		sort.Strings(matches)
+		return nil, fmt.Errorf("%w: %s", ErrAmbiguous, strings.Join(matches, ", "))
+	}
+}
+
+// describe measures a session's working directory
+func (s *Store) describe(sessionID string) (*Workspace, error) {
+	path := s.Path(sessionID)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session workspace: %w", err)
+	}
+
+	workspace := &Workspace{
+		SessionID:  sessionID,
+		Path:       path,
+		ModifiedAt: info.ModTime(),
+	}
+	err = filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		workspace.Bytes += uint64(info.Size())
+		workspace.Files++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure session workspace: %w", err)
+	}
+	return workspace, nil
+}
+
+// validateSessionID rejects IDs that would escape the store's root
+func validateSessionID(sessionID string) error {
+	if sessionID == "" || sessionID == "." || sessionID == ".." ||
+		strings.ContainsAny(sessionID, `/\`) {
+		return fmt.Errorf("invalid session ID %q", sessionID)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package workspace_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("creates a working directory with its subdirectories", func(t *testing.T) {
+		store := workspace.NewStore(t.TempDir())
+
+		path, err := store.Create("3f2a9c1e")
+		require.NoError(t, err)
+		assert.Equal(t, store.Path("3f2a9c1e"), path)
+		for _, dir := range []string{workspace.RenderedDir, workspace.DownloadsDir, workspace.BuildDir, workspace.TempDir} {
+			assert.DirExists(t, filepath.Join(path, dir))
+		}
+
+		// A resumed session reuses its directory
+		require.NoError(t, os.WriteFile(filepath.Join(path, workspace.TempDir, "partial"), []byte("x"), 0o600))
+		_, err = store.Create("3f2a9c1e")
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(path, workspace.TempDir, "partial"))
+	})
+
+	t.Run("removes a working directory", func(t *testing.T) {
+		store := workspace.NewStore(t.TempDir())
+		path, err := store.Create("3f2a9c1e")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Remove("3f2a9c1e"))
+		assert.NoDirExists(t, path)
+		assert.NoError(t, store.Remove("3f2a9c1e"))
+	})
+
+	t.Run("finds a working directory by a unique prefix", func(t *testing.T) {
+		store := workspace.NewStore(t.TempDir())
+		path, err := store.Create("3f2a9c1e")
+		require.NoError(t, err)
+		_, err = store.Create("3f8b0d2a")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(path, workspace.RenderedDir, "kitty.conf"), []byte("font_size 11"), 0o600))
+
+		found, err := store.Find("3f2a")
+		require.NoError(t, err)
+		assert.Equal(t, "3f2a9c1e", found.SessionID)
+		assert.Equal(t, path, found.Path)
+		assert.Equal(t, uint64(12), found.Bytes)
+		assert.Equal(t, 1, found.Files)
+
+		_, err = store.Find("3f")
+		assert.ErrorIs(t, err, workspace.ErrAmbiguous)
+
+		_, err = store.Find("9999")
+		assert.ErrorIs(t, err, workspace.ErrNotFound)
+	})
+
+	t.Run("reports no artifacts before any session ran", func(t *testing.T) {
+		store := workspace.NewStore(filepath.Join(t.TempDir(), "missing"))
+
+		_, err := store.Find("3f2a9c1e")
+		assert.ErrorIs(t, err, workspace.ErrNotFound)
+	})
+
+	t.Run("rejects session IDs outside its root", func(t *testing.T) {
+		store := workspace.NewStore(t.TempDir())
+
+		for _, id := range []string{"", "..", "../etc", "a/b"} {
+			_, err := store.Create(id)
+			assert.Error(t, err, id)
+			assert.Error(t, store.Remove(id), id)
+		}
+	})
+}
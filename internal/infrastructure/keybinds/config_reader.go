@@ -0,0 +1,95 @@
+package keybinds
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxSourceDepth stops source lines that include each other
+const maxSourceDepth = 8
+
+// ConfigReader reads a Hyprland configuration with the files its source
+// lines include, the way Hyprland itself sees it
+type ConfigReader struct {
+	path string
+	home string
+}
+
+// NewConfigReader creates a reader starting at the main configuration
+// file, usually ~/.config/hypr/hyprland.conf
+func NewConfigReader(path string) *ConfigReader {
+	home, _ := os.UserHomeDir()
+	return &ConfigReader{path: path, home: home}
+}
+
+// Path returns the main configuration file
+func (r *ConfigReader) Path() string {
+	return r.path
+}
+
+// Read returns the configuration with every sourced file inlined in place.
+// Sourced files that do not exist are skipped, as Hyprland does.
+func (r *ConfigReader) Read() (string, error) {
+	var b strings.Builder
+	if err := r.read(&b, r.path, 0); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (r *ConfigReader) read(b *strings.Builder, path string, depth int) error {
+	if depth > maxSourceDepth {
+		return fmt.Errorf("%s: source lines nested more than %d deep", path, maxSourceDepth)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read Hyprland configuration: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		keyword, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(keyword) != "source" {
+			b.WriteString(line)
+			b.WriteByte('\n')
+			continue
+		}
+
+		// A sourced file starts its own sections and comments
+		b.WriteByte('\n')
+		matches, err := filepath.Glob(r.resolve(strings.TrimSpace(value), filepath.Dir(path)))
+		if err != nil {
+			return fmt.Errorf("%s: invalid source pattern: %w", path, err)
+		}
+		for _, match := range matches {
+			if err := r.read(b, match, depth+1); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+		b.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// resolve expands ~ and makes relative paths relative to the including
+// file
+func (r *ConfigReader) resolve(path, dir string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		path = filepath.Join(r.home, path[1:])
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path
+}
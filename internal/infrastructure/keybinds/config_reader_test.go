@@ -0,0 +1,51 @@
+package keybinds_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/keybinds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestConfigReader(t *testing.T) {
+	t.Run("inlines sourced files", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		hypr := filepath.Join(home, ".config", "hypr")
+		writeFile(t, filepath.Join(hypr, "hyprland.conf"),
+			"source = ~/.config/hypr/bindings.conf\nsource = extra/*.conf\nsource = missing.conf\n")
+		writeFile(t, filepath.Join(hypr, "bindings.conf"), "bind = SUPER, Return, exec, kitty\n")
+		writeFile(t, filepath.Join(hypr, "extra", "mine.conf"), "bind = SUPER, B, exec, firefox\n")
+
+		reader := keybinds.NewConfigReader(filepath.Join(hypr, "hyprland.conf"))
+		content, err := reader.Read()
+		require.NoError(t, err)
+
+		assert.Contains(t, content, "bind = SUPER, Return, exec, kitty")
+		assert.Contains(t, content, "bind = SUPER, B, exec, firefox")
+		assert.NotContains(t, content, "source =")
+	})
+
+	t.Run("stops files sourcing each other", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "a.conf"), "source = b.conf\n")
+		writeFile(t, filepath.Join(dir, "b.conf"), "source = a.conf\n")
+
+		_, err := keybinds.NewConfigReader(filepath.Join(dir, "a.conf")).Read()
+		assert.Error(t, err)
+	})
+
+	t.Run("fails without the main file", func(t *testing.T) {
+		_, err := keybinds.NewConfigReader(filepath.Join(t.TempDir(), "hyprland.conf")).Read()
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
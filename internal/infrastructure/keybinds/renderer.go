@@ -0,0 +1,127 @@
+package keybinds
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rebelopsio/gohan/internal/domain/keybinds"
+)
+
+// Renderer writes cheat sheets as text, markdown or HTML
+type Renderer struct{}
+
+// NewRenderer creates a new renderer
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+// Render returns the cheat sheet in the given format
+func (r *Renderer) Render(sheet *keybinds.CheatSheet, format keybinds.Format) (string, error) {
+	switch format {
+	case keybinds.FormatText:
+		return renderText(sheet)
+	case keybinds.FormatMarkdown:
+		return renderMarkdown(sheet), nil
+	case keybinds.FormatHTML:
+		return renderHTML(sheet)
+	}
+	return "", fmt.Errorf("%w: %q", keybinds.ErrUnknownFormat, format)
+}
+
+// action is what a row says the keys do: the comment describing them, or
+// the command when there is none
+func action(entry keybinds.Entry) string {
+	if entry.Description() != "" {
+		return entry.Description()
+	}
+	return entry.Command()
+}
+
+func renderText(sheet *keybinds.CheatSheet) (string, error) {
+	var b strings.Builder
+	for i, section := range sheet.Sections() {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(section.Title() + "\n")
+
+		w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		for _, entry := range section.Entries() {
+			command := entry.Command()
+			if entry.Description() == "" {
+				command = ""
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", entry.Keys(), action(entry), command)
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+func renderMarkdown(sheet *keybinds.CheatSheet) string {
+	escape := strings.NewReplacer("|", `\|`)
+
+	var b strings.Builder
+	b.WriteString("# Key bindings\n")
+	for _, section := range sheet.Sections() {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.Title())
+		b.WriteString("| Keys | Action | Command |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, entry := range section.Entries() {
+			fmt.Fprintf(&b, "| %s | %s | `%s` |\n",
+				escape.Replace(entry.Keys()), escape.Replace(action(entry)), escape.Replace(entry.Command()))
+		}
+	}
+	return b.String()
+}
+
+var htmlTemplate = template.Must(template.New("cheatsheet").Funcs(template.FuncMap{
+	"keys":   func(keys string) []string { return strings.Split(keys, "+") },
+	"action": action,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Key bindings</title>
+<style>
+  body { font-family: sans-serif; background: #1e1e2e; color: #cdd6f4; margin: 2rem; }
+  h1 { font-weight: 400; }
+  main { columns: 28rem; column-gap: 2rem; }
+  section { break-inside: avoid; margin-bottom: 1.5rem; }
+  h2 { font-size: 1rem; color: #89b4fa; border-bottom: 1px solid #45475a; padding-bottom: .25rem; }
+  table { border-collapse: collapse; width: 100%; }
+  td { padding: .2rem .4rem; vertical-align: top; }
+  td:first-child { white-space: nowrap; }
+  kbd { background: #313244; border: 1px solid #45475a; border-radius: 4px; padding: 0 .35rem; font-size: .85rem; }
+  code { color: #a6adc8; font-size: .8rem; }
+</style>
+</head>
+<body>
+<h1>Key bindings</h1>
+<main>
+{{- range .Sections}}
+<section>
+<h2>{{.Title}}</h2>
+<table>
+{{- range .Entries}}
+<tr><td>{{range $i, $k := keys .Keys}}{{if $i}}+{{end}}<kbd>{{$k}}</kbd>{{end}}</td><td>{{action .}}</td><td><code>{{.Command}}</code></td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+func renderHTML(sheet *keybinds.CheatSheet) (string, error) {
+	var b strings.Builder
+	if err := htmlTemplate.Execute(&b, sheet); err != nil {
+		return "", fmt.Errorf("failed to render cheat sheet: %w", err)
+	}
+	return b.String(), nil
+}
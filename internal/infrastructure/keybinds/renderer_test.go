@@ -0,0 +1,72 @@
+package keybinds_test
+
+import (
+	"os"
+	"testing"
+
+	domain "github.com/rebelopsio/gohan/internal/domain/keybinds"
+	"github.com/rebelopsio/gohan/internal/infrastructure/keybinds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bindings = `# ============================================================================
+# UTILITIES
+# ============================================================================
+
+# Clipboard manager
+bind = SUPER, V, exec, cliphist list | fuzzel --dmenu
+
+bind = SUPER, X, exec, <script>
+`
+
+func TestRenderer(t *testing.T) {
+	sheet, err := domain.Parse(bindings)
+	require.NoError(t, err)
+	renderer := keybinds.NewRenderer()
+
+	t.Run("text aligns keys and actions under headings", func(t *testing.T) {
+		out, err := renderer.Render(sheet, domain.FormatText)
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "Utilities\n")
+		assert.Contains(t, out, "  SUPER+V  Clipboard manager")
+		assert.Contains(t, out, "  SUPER+X  exec <script>")
+	})
+
+	t.Run("markdown escapes pipes in commands", func(t *testing.T) {
+		out, err := renderer.Render(sheet, domain.FormatMarkdown)
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "## Utilities")
+		assert.Contains(t, out, "| SUPER+V | Clipboard manager | `exec cliphist list \\| fuzzel --dmenu` |")
+	})
+
+	t.Run("html shows keys and escapes commands", func(t *testing.T) {
+		out, err := renderer.Render(sheet, domain.FormatHTML)
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "<kbd>SUPER</kbd>+<kbd>V</kbd>")
+		assert.Contains(t, out, "exec &lt;script&gt;")
+		assert.NotContains(t, out, "<script>")
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		_, err := renderer.Render(sheet, domain.Format("png"))
+		assert.ErrorIs(t, err, domain.ErrUnknownFormat)
+	})
+}
+
+// TestRenderer_DefaultBindings renders the bindings gohan deploys
+func TestRenderer_DefaultBindings(t *testing.T) {
+	content, err := os.ReadFile("../../../templates/hyprland/bindings.conf")
+	require.NoError(t, err)
+
+	sheet, err := domain.Parse(string(content))
+	require.NoError(t, err)
+
+	out, err := keybinds.NewRenderer().Render(sheet, domain.FormatText)
+	require.NoError(t, err)
+	assert.Contains(t, out, "SUPER+F1")
+	assert.Contains(t, out, "SUPER+1…0")
+}
@@ -0,0 +1,120 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/maintenance"
+)
+
+// SystemUnitDir is where units for the system instance are installed
+const SystemUnitDir = "/etc/systemd/system"
+
+// defaultSystemctlTimeout bounds each systemctl call
+const defaultSystemctlTimeout = time.Minute
+
+// SystemdTimerInstaller writes unit files and enables timers with systemctl,
+// using the user instance (systemctl --user) for the user scope
+type SystemdTimerInstaller struct {
+	userDir   string
+	systemDir string
+	timeout   time.Duration
+}
+
+// NewSystemdTimerInstaller creates an installer for the standard unit
+// directories: ~/.config/systemd/user and /etc/systemd/system
+func NewSystemdTimerInstaller() *SystemdTimerInstaller {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		homeDir, _ := os.UserHomeDir()
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return NewSystemdTimerInstallerWithDirs(filepath.Join(configDir, "systemd", "user"), SystemUnitDir)
+}
+
+// NewSystemdTimerInstallerWithDirs creates an installer writing units to the
+// given directories
+func NewSystemdTimerInstallerWithDirs(userDir, systemDir string) *SystemdTimerInstaller {
+	return &SystemdTimerInstaller{
+		userDir:   userDir,
+		systemDir: systemDir,
+		timeout:   defaultSystemctlTimeout,
+	}
+}
+
+// UnitDir returns where units of the scope are written
+func (i *SystemdTimerInstaller) UnitDir(scope maintenance.Scope) string {
+	if scope.IsSystem() {
+		return i.systemDir
+	}
+	return i.userDir
+}
+
+// WriteUnit writes a unit file, replacing any previous version
+func (i *SystemdTimerInstaller) WriteUnit(scope maintenance.Scope, name, content string) error {
+	dir := i.UnitDir(scope)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create unit directory %s: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write unit %s: %w", path, err)
+	}
+	return nil
+}
+
+// Reload makes systemd pick up new or changed unit files
+func (i *SystemdTimerInstaller) Reload(ctx context.Context, scope maintenance.Scope) error {
+	if output, err := i.systemctl(ctx, scope, "daemon-reload"); err != nil {
+		return fmt.Errorf("failed to reload systemd: %w, output: %s", err, output)
+	}
+	return nil
+}
+
+// EnableTimer enables a timer and starts it right away
+func (i *SystemdTimerInstaller) EnableTimer(ctx context.Context, scope maintenance.Scope, name string) error {
+	if output, err := i.systemctl(ctx, scope, "enable", "--now", name); err != nil {
+		return fmt.Errorf("failed to enable timer %s: %w, output: %s", name, err, output)
+	}
+	return nil
+}
+
+// EnableUnit enables a unit without starting it, so it first runs when its
+// target is next reached, e.g. at the next login for a user unit
+func (i *SystemdTimerInstaller) EnableUnit(ctx context.Context, scope maintenance.Scope, name string) error {
+	if output, err := i.systemctl(ctx, scope, "enable", name); err != nil {
+		return fmt.Errorf("failed to enable %s: %w, output: %s", name, err, output)
+	}
+	return nil
+}
+
+// DisableTimer stops a timer and disables it
+func (i *SystemdTimerInstaller) DisableTimer(ctx context.Context, scope maintenance.Scope, name string) error {
+	if output, err := i.systemctl(ctx, scope, "disable", "--now", name); err != nil {
+		return fmt.Errorf("failed to disable timer %s: %w, output: %s", name, err, output)
+	}
+	return nil
+}
+
+// systemctl runs a systemctl call against the scope's instance
+func (i *SystemdTimerInstaller) systemctl(ctx context.Context, scope maintenance.Scope, args ...string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+
+	if !scope.IsSystem() {
+		args = append([]string{"--user"}, args...)
+	}
+	output, err := exec.CommandContext(ctx, "systemctl", args...).CombinedOutput()
+	return string(output), err
+}
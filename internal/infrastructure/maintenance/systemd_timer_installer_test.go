@@ -0,0 +1,31 @@
+package maintenance_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainMaintenance "github.com/rebelopsio/gohan/internal/domain/maintenance"
+	"github.com/rebelopsio/gohan/internal/infrastructure/maintenance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemdTimerInstaller_UnitDir(t *testing.T) {
+	installer := maintenance.NewSystemdTimerInstallerWithDirs("/home/user/.config/systemd/user", "/etc/systemd/system")
+
+	assert.Equal(t, "/home/user/.config/systemd/user", installer.UnitDir(domainMaintenance.ScopeUser))
+	assert.Equal(t, "/etc/systemd/system", installer.UnitDir(domainMaintenance.ScopeSystem))
+}
+
+func TestSystemdTimerInstaller_WriteUnit(t *testing.T) {
+	userDir := filepath.Join(t.TempDir(), "systemd", "user")
+	installer := maintenance.NewSystemdTimerInstallerWithDirs(userDir, t.TempDir())
+
+	require.NoError(t, installer.WriteUnit(domainMaintenance.ScopeUser, "gohan-health-check.timer", "old"))
+	require.NoError(t, installer.WriteUnit(domainMaintenance.ScopeUser, "gohan-health-check.timer", "new"))
+
+	data, err := os.ReadFile(filepath.Join(userDir, "gohan-health-check.timer"))
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+}
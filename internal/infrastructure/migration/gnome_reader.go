@@ -0,0 +1,139 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+)
+
+var (
+	// xkbSourcePattern matches the xkb entries of GNOME's input sources,
+	// such as ('xkb', 'de+nodeadkeys'); input methods such as ibus are
+	// left out
+	xkbSourcePattern = regexp.MustCompile(`\(\s*'xkb'\s*,\s*'([^']+)'\s*\)`)
+
+	// quotedPattern matches the strings of a GVariant array
+	quotedPattern = regexp.MustCompile(`'([^']*)'`)
+)
+
+// GNOMEReader reads settings from gsettings and ~/.config/monitors.xml
+type GNOMEReader struct {
+	homeDir string
+	get     func(ctx context.Context, schema, key string) (string, error)
+}
+
+// NewGNOMEReader creates a reader for the user's GNOME settings
+func NewGNOMEReader(homeDir string) *GNOMEReader {
+	return &GNOMEReader{homeDir: homeDir, get: gsettingsGet}
+}
+
+// Read returns the settings that were found; the rest are noted
+func (r *GNOMEReader) Read(ctx context.Context) (migration.Settings, error) {
+	settings := migration.NewSettings(migration.DesktopGNOME)
+
+	if uri, err := r.get(ctx, "org.gnome.desktop.background", "picture-uri"); err != nil {
+		settings = settings.WithNote(fmt.Sprintf("Wallpaper not imported: %v", err))
+	} else if path := fileURIPath(unquote(uri)); path != "" {
+		settings = settings.WithWallpaper(path)
+	} else {
+		settings = settings.WithNote("Wallpaper not imported: GNOME uses a color rather than an image")
+	}
+
+	settings = r.readKeyboard(ctx, settings)
+
+	if command, err := r.get(ctx, "org.gnome.desktop.default-applications.terminal", "exec"); err != nil {
+		settings = settings.WithNote(fmt.Sprintf("Default terminal not imported: %v", err))
+	} else {
+		settings = settings.WithTerminal(unquote(command))
+	}
+
+	path := filepath.Join(r.homeDir, ".config", "monitors.xml")
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		settings = settings.WithNote("Monitor layout not imported: GNOME never saved one, so monitors stay auto-detected")
+	case err != nil:
+		return migration.Settings{}, fmt.Errorf("failed to read %s: %w", path, err)
+	default:
+		monitors, err := ParseMonitorsXML(data)
+		if err != nil {
+			settings = settings.WithNote(fmt.Sprintf("Monitor layout not imported: %v", err))
+		} else {
+			settings = settings.WithMonitors(monitors)
+		}
+	}
+
+	return settings, nil
+}
+
+func (r *GNOMEReader) readKeyboard(ctx context.Context, settings migration.Settings) migration.Settings {
+	sources, err := r.get(ctx, "org.gnome.desktop.input-sources", "sources")
+	if err != nil {
+		return settings.WithNote(fmt.Sprintf("Keyboard layout not imported: %v", err))
+	}
+
+	var layouts []migration.KeyboardLayout
+	for _, match := range xkbSourcePattern.FindAllStringSubmatch(sources, -1) {
+		layout, err := migration.ParseKeyboardLayout(match[1])
+		if err != nil {
+			continue
+		}
+		layouts = append(layouts, layout)
+	}
+	if strings.Contains(sources, "'ibus'") {
+		settings = settings.WithNote("Input methods (ibus) not imported: only keyboard layouts carry over")
+	}
+
+	var options []string
+	if xkbOptions, err := r.get(ctx, "org.gnome.desktop.input-sources", "xkb-options"); err == nil {
+		for _, match := range quotedPattern.FindAllStringSubmatch(xkbOptions, -1) {
+			options = append(options, match[1])
+		}
+	}
+
+	if len(layouts) == 0 && len(options) == 0 {
+		return settings
+	}
+	return settings.WithKeyboard(migration.NewKeyboard(layouts, options))
+}
+
+// gsettingsGet returns a setting as gsettings prints it, a GVariant such
+// as 'value' or ['a', 'b']
+func gsettingsGet(ctx context.Context, schema, key string) (string, error) {
+	output, err := exec.CommandContext(ctx, "gsettings", "get", schema, key).Output()
+	if err != nil {
+		return "", fmt.Errorf("gsettings get %s %s: %w", schema, key, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// unquote returns a GVariant string without its quotes
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// fileURIPath returns the local path of a file:// URI, or the value itself
+// when it is already a path
+func fileURIPath(uri string) string {
+	if strings.HasPrefix(uri, "/") {
+		return uri
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return ""
+	}
+	return parsed.Path
+}
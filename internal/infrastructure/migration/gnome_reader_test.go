@@ -0,0 +1,95 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubGSettings(values map[string]string) func(ctx context.Context, schema, key string) (string, error) {
+	return func(ctx context.Context, schema, key string) (string, error) {
+		value, ok := values[schema+" "+key]
+		if !ok {
+			return "", fmt.Errorf("No such schema %q", schema)
+		}
+		return value, nil
+	}
+}
+
+func TestGNOMEReader_Read(t *testing.T) {
+	t.Run("reads wallpaper, keyboard, terminal and monitors", func(t *testing.T) {
+		home := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".config"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(home, ".config", "monitors.xml"), []byte(`<monitors version="2">
+  <configuration>
+    <logicalmonitor>
+      <x>0</x><y>0</y><scale>2</scale>
+      <monitor>
+        <monitorspec><connector>eDP-1</connector></monitorspec>
+        <mode><width>2880</width><height>1800</height><rate>90.000</rate></mode>
+      </monitor>
+    </logicalmonitor>
+  </configuration>
+</monitors>`), 0o644))
+
+		reader := NewGNOMEReader(home)
+		reader.get = stubGSettings(map[string]string{
+			"org.gnome.desktop.background picture-uri":             "'file:///home/ada/Pictures/My%20Mountains.jpg'",
+			"org.gnome.desktop.input-sources sources":              "[('xkb', 'us'), ('xkb', 'de+nodeadkeys'), ('ibus', 'anthy')]",
+			"org.gnome.desktop.input-sources xkb-options":          "['caps:escape']",
+			"org.gnome.desktop.default-applications.terminal exec": "'alacritty'",
+		})
+
+		settings, err := reader.Read(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "/home/ada/Pictures/My Mountains.jpg", settings.Wallpaper())
+		assert.Equal(t, "alacritty", settings.Terminal())
+		assert.Equal(t, map[string]string{
+			"kb_layout":      "us,de",
+			"kb_variant":     ",nodeadkeys",
+			"kb_options":     "caps:escape",
+			"monitor_config": "monitor = eDP-1, 2880x1800@90, 0x0, 2",
+		}, settings.ImportedVars())
+		assert.Len(t, settings.Notes(), 1, "ibus input method is noted")
+	})
+
+	t.Run("notes what gsettings and monitors.xml lack", func(t *testing.T) {
+		reader := NewGNOMEReader(t.TempDir())
+		reader.get = stubGSettings(map[string]string{
+			"org.gnome.desktop.background picture-uri": "''",
+			"org.gnome.desktop.input-sources sources":  "@a(ss) []",
+		})
+
+		settings, err := reader.Read(context.Background())
+		require.NoError(t, err)
+
+		assert.Empty(t, settings.ImportedVars())
+		assert.Empty(t, settings.Wallpaper())
+		assert.Len(t, settings.Notes(), 3) // wallpaper, terminal and monitors
+	})
+}
+
+func TestServiceChecker_CanDisable(t *testing.T) {
+	services := map[string]string{
+		"packagekit.service":         "static",
+		"tracker-miner-fs-3.service": "masked",
+	}
+	checker := &ServiceChecker{isEnabled: func(ctx context.Context, user bool, unit string) string {
+		return services[unit]
+	}}
+
+	var can []string
+	for _, service := range migration.ServicesSafeToDisable(migration.DesktopGNOME) {
+		if checker.CanDisable(context.Background(), service) {
+			can = append(can, service.Name())
+		}
+	}
+	assert.Equal(t, []string{"packagekit.service"}, can)
+}
@@ -0,0 +1,255 @@
+package migration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+)
+
+// KDEReader reads settings from Plasma's configuration files: kxkbrc,
+// kdeglobals, the desktop's applet configuration and the KScreen layouts
+type KDEReader struct {
+	homeDir string
+}
+
+// NewKDEReader creates a reader for the user's Plasma settings
+func NewKDEReader(homeDir string) *KDEReader {
+	return &KDEReader{homeDir: homeDir}
+}
+
+// Read returns the settings that were found; the rest are noted
+func (r *KDEReader) Read(ctx context.Context) (migration.Settings, error) {
+	settings := migration.NewSettings(migration.DesktopKDE)
+	configDir := filepath.Join(r.homeDir, ".config")
+
+	appletsrc, err := readINI(filepath.Join(configDir, "plasma-org.kde.plasma.desktop-appletsrc"))
+	if err != nil {
+		return migration.Settings{}, err
+	}
+	if image := firstValue(appletsrc, "Image"); image != "" {
+		settings = settings.WithWallpaper(fileURIPath(image))
+	} else {
+		settings = settings.WithNote("Wallpaper not imported: Plasma uses its default wallpaper")
+	}
+
+	kxkbrc, err := readINI(filepath.Join(configDir, "kxkbrc"))
+	if err != nil {
+		return migration.Settings{}, err
+	}
+	if keyboard, ok := kdeKeyboard(kxkbrc["Layout"]); ok {
+		settings = settings.WithKeyboard(keyboard)
+	} else {
+		settings = settings.WithNote("Keyboard layout not imported: Plasma uses the system layout")
+	}
+
+	kdeglobals, err := readINI(filepath.Join(configDir, "kdeglobals"))
+	if err != nil {
+		return migration.Settings{}, err
+	}
+	terminal := kdeglobals["General"]["TerminalApplication"]
+	if terminal == "" {
+		terminal = "konsole"
+	}
+	settings = settings.WithTerminal(terminal)
+
+	monitors, err := r.readKScreen()
+	if err != nil {
+		settings = settings.WithNote(fmt.Sprintf("Monitor layout not imported: %v", err))
+	} else {
+		settings = settings.WithMonitors(monitors)
+	}
+
+	return settings, nil
+}
+
+func kdeKeyboard(layout map[string]string) (migration.Keyboard, bool) {
+	names := splitList(layout["LayoutList"])
+	variants := splitList(layout["VariantList"])
+	var layouts []migration.KeyboardLayout
+	for i, name := range names {
+		variant := ""
+		if i < len(variants) {
+			variant = variants[i]
+		}
+		kl, err := migration.NewKeyboardLayout(name, variant)
+		if err != nil {
+			continue
+		}
+		layouts = append(layouts, kl)
+	}
+	options := splitList(layout["Options"])
+	if len(layouts) == 0 && len(options) == 0 {
+		return migration.Keyboard{}, false
+	}
+	return migration.NewKeyboard(layouts, options), true
+}
+
+// kscreenOutput is one output of a KScreen layout
+type kscreenOutput struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Mode    struct {
+		Refresh float64 `json:"refresh"`
+		Size    struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"size"`
+	} `json:"mode"`
+	Pos struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	} `json:"pos"`
+	Scale    float64 `json:"scale"`
+	Rotation int     `json:"rotation"`
+}
+
+// readKScreen returns the layout KScreen saved last. KScreen keeps one
+// file per combination of monitors in ~/.local/share/kscreen.
+func (r *KDEReader) readKScreen() ([]migration.Monitor, error) {
+	dir := filepath.Join(r.homeDir, ".local", "share", "kscreen")
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("KScreen never saved one, so monitors stay auto-detected")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var latest string
+	var latestInfo fs.FileInfo
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if latestInfo == nil || info.ModTime().After(latestInfo.ModTime()) {
+			latest, latestInfo = entry.Name(), info
+		}
+	}
+	if latest == "" {
+		return nil, fmt.Errorf("KScreen never saved one, so monitors stay auto-detected")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, latest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KScreen layout: %w", err)
+	}
+	return ParseKScreen(data)
+}
+
+// ParseKScreen returns the monitor layout of a KScreen layout file
+func ParseKScreen(data []byte) ([]migration.Monitor, error) {
+	var outputs []kscreenOutput
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil, fmt.Errorf("invalid KScreen layout: %w", err)
+	}
+
+	var monitors []migration.Monitor
+	for _, output := range outputs {
+		if !output.Enabled {
+			monitor, err := migration.NewDisabledMonitor(output.Name)
+			if err != nil {
+				return nil, err
+			}
+			monitors = append(monitors, monitor)
+			continue
+		}
+		monitor, err := migration.NewMonitor(output.Name, output.Mode.Size.Width, output.Mode.Size.Height,
+			roundRate(output.Mode.Refresh), output.Pos.X, output.Pos.Y, output.Scale)
+		if err != nil {
+			return nil, err
+		}
+		monitors = append(monitors, monitor.WithTransform(kscreenTransform(output.Rotation)))
+	}
+	if len(monitors) == 0 {
+		return nil, fmt.Errorf("KScreen layout has no outputs")
+	}
+	return monitors, nil
+}
+
+// kscreenTransform maps KScreen's rotation flags to Hyprland's transforms
+func kscreenTransform(rotation int) migration.Transform {
+	switch rotation {
+	case 2: // Left
+		return migration.Transform90
+	case 4: // Inverted
+		return migration.Transform180
+	case 8: // Right
+		return migration.Transform270
+	}
+	return migration.TransformNormal
+}
+
+// readINI reads a KDE configuration file into its groups. Nested groups
+// such as [Containments][1][Wallpaper] are keyed by their full header. A
+// missing file has no groups.
+func readINI(path string) (map[string]map[string]string, error) {
+	groups := make(map[string]map[string]string)
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return groups, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	group := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "["):
+			group = strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				continue
+			}
+			if groups[group] == nil {
+				groups[group] = make(map[string]string)
+			}
+			groups[group][strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return groups, nil
+}
+
+// firstValue returns the value of key in the group with the lowest name
+// that sets it, so the result does not depend on map order
+func firstValue(groups map[string]map[string]string, key string) string {
+	var found string
+	var foundGroup string
+	for group, values := range groups {
+		if value := values[key]; value != "" && (found == "" || group < foundGroup) {
+			found, foundGroup = value, group
+		}
+	}
+	return found
+}
+
+func splitList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
@@ -0,0 +1,111 @@
+package migration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestKDEReader_Read(t *testing.T) {
+	t.Run("reads Plasma's configuration files", func(t *testing.T) {
+		home := t.TempDir()
+		writeFile(t, filepath.Join(home, ".config", "kxkbrc"), `[Layout]
+LayoutList=us,de
+Options=caps:escape
+ResetOldOptions=true
+Use=true
+VariantList=,nodeadkeys
+`)
+		writeFile(t, filepath.Join(home, ".config", "kdeglobals"), `[General]
+TerminalApplication=kitty
+`)
+		writeFile(t, filepath.Join(home, ".config", "plasma-org.kde.plasma.desktop-appletsrc"), `[Containments][1][Wallpaper][org.kde.image][General]
+Image=file:///home/ada/Pictures/lake.png
+SlidePaths=/usr/share/wallpapers/
+`)
+		writeFile(t, filepath.Join(home, ".local", "share", "kscreen", "3f1e0ab2"), `[
+  {"name": "DP-1", "enabled": true, "scale": 1,
+   "mode": {"refresh": 60, "size": {"width": 2560, "height": 1440}},
+   "pos": {"x": 0, "y": 0}}
+]`)
+
+		settings, err := migration.NewKDEReader(home).Read(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "/home/ada/Pictures/lake.png", settings.Wallpaper())
+		assert.Equal(t, "kitty", settings.Terminal())
+		assert.Equal(t, map[string]string{
+			"kb_layout":      "us,de",
+			"kb_variant":     ",nodeadkeys",
+			"kb_options":     "caps:escape",
+			"monitor_config": "monitor = DP-1, 2560x1440@60, 0x0, 1",
+		}, settings.ImportedVars())
+		assert.Empty(t, settings.Notes())
+	})
+
+	t.Run("falls back to konsole and notes the rest", func(t *testing.T) {
+		settings, err := migration.NewKDEReader(t.TempDir()).Read(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "konsole", settings.Terminal())
+		assert.Empty(t, settings.ImportedVars())
+		assert.Len(t, settings.Notes(), 3) // wallpaper, keyboard and monitors
+	})
+}
+
+func TestVarStore(t *testing.T) {
+	t.Run("loads nothing before an import", func(t *testing.T) {
+		store := migration.NewVarStore(filepath.Join(t.TempDir(), migration.VarsFileName))
+
+		vars, err := store.Load()
+		require.NoError(t, err)
+		assert.Empty(t, vars)
+	})
+
+	t.Run("saving replaces imported variables and keeps the others", func(t *testing.T) {
+		store := migration.NewVarStore(filepath.Join(t.TempDir(), ".gohan", migration.VarsFileName))
+
+		require.NoError(t, store.Save(map[string]string{"kb_layout": "de", "monitor_config": "monitor = DP-1, disable"}))
+		require.NoError(t, store.Save(map[string]string{"kb_layout": "fr"}))
+
+		vars, err := store.Load()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"kb_layout": "fr", "monitor_config": "monitor = DP-1, disable"}, vars)
+	})
+
+	t.Run("rejects a file that is not a map", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), migration.VarsFileName)
+		writeFile(t, path, "- kb_layout\n")
+
+		_, err := migration.NewVarStore(path).Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestWallpaperStore(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "lake.png")
+	writeFile(t, source, "image")
+	store := migration.NewWallpaperStore(filepath.Join(dir, ".config", "gohan", "wallpaper.jpg"))
+
+	assert.False(t, store.Exists())
+	require.NoError(t, store.Import(source))
+	assert.True(t, store.Exists())
+
+	data, err := os.ReadFile(store.Path())
+	require.NoError(t, err)
+	assert.Equal(t, "image", string(data))
+
+	assert.Error(t, store.Import(filepath.Join(dir, "missing.png")))
+}
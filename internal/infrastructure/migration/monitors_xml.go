@@ -0,0 +1,107 @@
+package migration
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+)
+
+// monitorsXML is the layout of ~/.config/monitors.xml, where GNOME keeps
+// a configuration for every combination of monitors it has seen
+type monitorsXML struct {
+	Configurations []struct {
+		LogicalMonitors []struct {
+			X         int    `xml:"x"`
+			Y         int    `xml:"y"`
+			Scale     string `xml:"scale"`
+			Transform struct {
+				Rotation string `xml:"rotation"`
+				Flipped  string `xml:"flipped"`
+			} `xml:"transform"`
+			Monitors []struct {
+				Connector string `xml:"monitorspec>connector"`
+				Width     int    `xml:"mode>width"`
+				Height    int    `xml:"mode>height"`
+				Rate      string `xml:"mode>rate"`
+			} `xml:"monitor"`
+		} `xml:"logicalmonitor"`
+		Disabled []struct {
+			Connector string `xml:"monitorspec>connector"`
+		} `xml:"disabled"`
+	} `xml:"configuration"`
+}
+
+// ParseMonitorsXML returns the monitor layout of GNOME's monitors.xml. Of
+// the configurations saved, the one with the most monitors is used, which
+// is the docked layout on a laptop; mirrored monitors share a position.
+func ParseMonitorsXML(data []byte) ([]migration.Monitor, error) {
+	var file monitorsXML
+	if err := xml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid monitors.xml: %w", err)
+	}
+
+	best, most := -1, 0
+	for i, configuration := range file.Configurations {
+		count := 0
+		for _, logical := range configuration.LogicalMonitors {
+			count += len(logical.Monitors)
+		}
+		if count > most {
+			best, most = i, count
+		}
+	}
+	if best < 0 {
+		return nil, fmt.Errorf("monitors.xml has no monitor configuration")
+	}
+
+	configuration := file.Configurations[best]
+	var monitors []migration.Monitor
+	for _, logical := range configuration.LogicalMonitors {
+		scale, _ := strconv.ParseFloat(strings.TrimSpace(logical.Scale), 64)
+		transform := gnomeTransform(logical.Transform.Rotation, logical.Transform.Flipped)
+		for _, m := range logical.Monitors {
+			rate, _ := strconv.ParseFloat(strings.TrimSpace(m.Rate), 64)
+			monitor, err := migration.NewMonitor(m.Connector, m.Width, m.Height, roundRate(rate), logical.X, logical.Y, scale)
+			if err != nil {
+				return nil, err
+			}
+			monitors = append(monitors, monitor.WithTransform(transform))
+		}
+	}
+	for _, disabled := range configuration.Disabled {
+		monitor, err := migration.NewDisabledMonitor(disabled.Connector)
+		if err != nil {
+			return nil, err
+		}
+		monitors = append(monitors, monitor)
+	}
+	return monitors, nil
+}
+
+// gnomeTransform maps mutter's rotations, counterclockwise, to Hyprland's
+// transforms
+func gnomeTransform(rotation, flipped string) migration.Transform {
+	var transform migration.Transform
+	switch strings.TrimSpace(rotation) {
+	case "left":
+		transform = migration.Transform90
+	case "upside_down":
+		transform = migration.Transform180
+	case "right":
+		transform = migration.Transform270
+	}
+	if strings.TrimSpace(flipped) == "yes" {
+		transform += migration.TransformFlipped
+	}
+	return transform
+}
+
+// roundRate keeps refresh rates like 59.951 to the two decimals Hyprland
+// matches modes by
+func roundRate(rate float64) float64 {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(rate, 'f', 2, 64), 64)
+	return rounded
+}
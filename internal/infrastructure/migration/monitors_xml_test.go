@@ -0,0 +1,120 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const monitorsXML = `<monitors version="2">
+  <configuration>
+    <logicalmonitor>
+      <x>0</x>
+      <y>0</y>
+      <scale>1.5</scale>
+      <primary>yes</primary>
+      <monitor>
+        <monitorspec>
+          <connector>eDP-1</connector>
+          <vendor>BOE</vendor>
+          <product>0x0bca</product>
+          <serial>0x00000000</serial>
+        </monitorspec>
+        <mode>
+          <width>2256</width>
+          <height>1504</height>
+          <rate>59.999</rate>
+        </mode>
+      </monitor>
+    </logicalmonitor>
+  </configuration>
+  <configuration>
+    <logicalmonitor>
+      <x>0</x>
+      <y>0</y>
+      <scale>1</scale>
+      <primary>yes</primary>
+      <monitor>
+        <monitorspec>
+          <connector>DP-1</connector>
+        </monitorspec>
+        <mode>
+          <width>2560</width>
+          <height>1440</height>
+          <rate>143.912</rate>
+        </mode>
+      </monitor>
+    </logicalmonitor>
+    <logicalmonitor>
+      <x>2560</x>
+      <y>0</y>
+      <scale>1</scale>
+      <transform>
+        <rotation>left</rotation>
+        <flipped>no</flipped>
+      </transform>
+      <monitor>
+        <monitorspec>
+          <connector>HDMI-1</connector>
+        </monitorspec>
+        <mode>
+          <width>1920</width>
+          <height>1080</height>
+          <rate>60.000</rate>
+        </mode>
+      </monitor>
+    </logicalmonitor>
+    <disabled>
+      <monitorspec>
+        <connector>eDP-1</connector>
+      </monitorspec>
+    </disabled>
+  </configuration>
+</monitors>`
+
+func TestParseMonitorsXML(t *testing.T) {
+	t.Run("uses the configuration with the most monitors", func(t *testing.T) {
+		monitors, err := migration.ParseMonitorsXML([]byte(monitorsXML))
+		require.NoError(t, err)
+
+		lines := make([]string, 0, len(monitors))
+		for _, monitor := range monitors {
+			lines = append(lines, monitor.HyprlandLine())
+		}
+		assert.Equal(t, []string{
+			"monitor = DP-1, 2560x1440@143.91, 0x0, 1",
+			"monitor = HDMI-1, 1920x1080@60, 2560x0, 1, transform, 1",
+			"monitor = eDP-1, disable",
+		}, lines)
+	})
+
+	t.Run("rejects a file without configurations", func(t *testing.T) {
+		_, err := migration.ParseMonitorsXML([]byte(`<monitors version="2"></monitors>`))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects invalid XML", func(t *testing.T) {
+		_, err := migration.ParseMonitorsXML([]byte(`<monitors`))
+		assert.Error(t, err)
+	})
+}
+
+func TestParseKScreen(t *testing.T) {
+	data := `[
+	  {"name": "DP-2", "enabled": true, "scale": 1.25, "rotation": 8,
+	   "mode": {"refresh": 59.95, "size": {"width": 3840, "height": 2160}},
+	   "pos": {"x": 1920, "y": 0}},
+	  {"name": "eDP-1", "enabled": false}
+	]`
+
+	monitors, err := migration.ParseKScreen([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, monitors, 2)
+	assert.Equal(t, "monitor = DP-2, 3840x2160@59.95, 1920x0, 1.25, transform, 3", monitors[0].HyprlandLine())
+	assert.Equal(t, "monitor = eDP-1, disable", monitors[1].HyprlandLine())
+
+	_, err = migration.ParseKScreen([]byte(`[]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package migration
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/migration"
+)
+
+// ServiceChecker asks systemd whether a service is installed and not yet
+// masked
+type ServiceChecker struct {
+	isEnabled func(ctx context.Context, user bool, unit string) string
+}
+
+// NewServiceChecker creates a checker using systemctl
+func NewServiceChecker() *ServiceChecker {
+	return &ServiceChecker{isEnabled: systemctlIsEnabled}
+}
+
+// CanDisable returns true if the service is installed and still able to
+// start. Services started on demand report static or indirect, which
+// counts as able to start.
+func (c *ServiceChecker) CanDisable(ctx context.Context, service migration.Service) bool {
+	switch c.isEnabled(ctx, service.IsUserService(), service.Name()) {
+	case "enabled", "enabled-runtime", "static", "indirect", "alias", "linked", "linked-runtime", "generated":
+		return true
+	}
+	// disabled, masked, not-found or systemctl unavailable
+	return false
+}
+
+// systemctlIsEnabled returns the unit file state systemctl reports, empty
+// when it reports none
+func systemctlIsEnabled(ctx context.Context, user bool, unit string) string {
+	args := []string{"is-enabled", unit}
+	if user {
+		args = append([]string{"--user"}, args...)
+	}
+	// is-enabled exits non-zero for disabled units, so the output is
+	// what counts
+	output, _ := exec.CommandContext(ctx, "systemctl", args...).Output()
+	return strings.TrimSpace(string(output))
+}
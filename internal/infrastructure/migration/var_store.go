@@ -0,0 +1,72 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VarsFileName is the file in gohan's data directory imported variables
+// are kept in
+const VarsFileName = "template-vars.yaml"
+
+// VarStore keeps imported template variables in a YAML file of names and
+// values. Installations and gohan config deploy render templates with
+// them, over the defaults.
+type VarStore struct {
+	path string
+}
+
+// NewVarStore creates a store backed by the file at path
+func NewVarStore(path string) *VarStore {
+	return &VarStore{path: path}
+}
+
+// Path returns the file the variables are kept in
+func (s *VarStore) Path() string {
+	return s.path
+}
+
+// Load returns the stored variables, none if nothing was imported yet
+func (s *VarStore) Load() (map[string]string, error) {
+	vars := make(map[string]string)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return vars, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template variables: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &vars); err != nil {
+		return nil, fmt.Errorf("invalid template variables in %s: %w", s.path, err)
+	}
+	return vars, nil
+}
+
+// Save stores the variables, replacing stored ones of the same name and
+// keeping the others
+func (s *VarStore) Save(vars map[string]string) error {
+	stored, err := s.Load()
+	if err != nil {
+		return err
+	}
+	for k, v := range vars {
+		stored[k] = v
+	}
+
+	data, err := yaml.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode template variables: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write template variables: %w", err)
+	}
+	return nil
+}
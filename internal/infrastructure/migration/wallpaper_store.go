@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WallpaperStore copies a wallpaper image to the path the deployed
+// configuration reads it from, ~/.config/gohan/wallpaper.jpg. swaybg and
+// hyprlock detect the image format from its contents, so PNG images work
+// despite the name.
+type WallpaperStore struct {
+	path string
+}
+
+// NewWallpaperStore creates a store writing the wallpaper to path
+func NewWallpaperStore(path string) *WallpaperStore {
+	return &WallpaperStore{path: path}
+}
+
+// Path returns where the wallpaper is copied to
+func (s *WallpaperStore) Path() string {
+	return s.path
+}
+
+// Exists returns true if a wallpaper is already in place
+func (s *WallpaperStore) Exists() bool {
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+// Import copies the image at source into place
+func (s *WallpaperStore) Import(source string) error {
+	in, err := os.Open(source)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("wallpaper %s no longer exists", source)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open wallpaper: %w", err)
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	out, err := os.Create(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to write wallpaper: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to copy wallpaper: %w", err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,109 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+// DefaultQueueSize is how many events can wait for delivery unless
+// configured otherwise
+const DefaultQueueSize = 16
+
+var (
+	// ErrQueueFull is returned when an event arrives while the delivery
+	// queue is full; the event is dropped
+	ErrQueueFull = errors.New("notification queue is full")
+
+	// ErrNotifierClosed is returned for events arriving after Close
+	ErrNotifierClosed = errors.New("notifier is closed")
+)
+
+// Notifier delivers installation lifecycle events
+type Notifier interface {
+	Notify(ctx context.Context, event installation.DomainEvent) error
+}
+
+// AsyncNotifier queues events and delivers them through a notifier on a
+// background goroutine, so slow endpoints and their retries do not hold up
+// installations. Events arriving while the queue is full are dropped.
+type AsyncNotifier struct {
+	notifier Notifier
+	onError  func(event installation.DomainEvent, err error)
+	queue    chan installation.DomainEvent
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewAsyncNotifier starts delivering events through notifier, queueing up
+// to size of them. onError, if not nil, is called with each event that
+// could not be delivered.
+func NewAsyncNotifier(notifier Notifier, size int, onError func(event installation.DomainEvent, err error)) *AsyncNotifier {
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &AsyncNotifier{
+		notifier: notifier,
+		onError:  onError,
+		queue:    make(chan installation.DomainEvent, max(size, 1)),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+// Notify queues event without waiting for it to be delivered. Delivery
+// outlives ctx; Close bounds it.
+func (n *AsyncNotifier) Notify(_ context.Context, event installation.DomainEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return ErrNotifierClosed
+	}
+
+	select {
+	case n.queue <- event:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s dropped", ErrQueueFull, event.EventType())
+	}
+}
+
+// Close stops accepting events and waits for the queued ones to be
+// delivered until ctx ends, when deliveries still in progress are
+// abandoned. It may be called more than once.
+func (n *AsyncNotifier) Close(ctx context.Context) error {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+
+	select {
+	case <-n.done:
+		return nil
+	case <-ctx.Done():
+		n.cancel()
+		<-n.done
+		return ctx.Err()
+	}
+}
+
+// run delivers the queued events in order until the queue is closed
+func (n *AsyncNotifier) run() {
+	defer close(n.done)
+	defer n.cancel()
+	for event := range n.queue {
+		if err := n.notifier.Notify(n.ctx, event); err != nil && n.onError != nil {
+			n.onError(event, err)
+		}
+	}
+}
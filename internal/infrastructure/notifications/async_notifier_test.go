@@ -0,0 +1,82 @@
+package notifications_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingNotifier records events, holding each delivery until released
+// or cancelled
+type blockingNotifier struct {
+	mu        sync.Mutex
+	release   chan struct{}
+	delivered []string
+}
+
+func (n *blockingNotifier) Notify(ctx context.Context, event installation.DomainEvent) error {
+	select {
+	case <-n.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.delivered = append(n.delivered, event.EventType())
+	return nil
+}
+
+func TestAsyncNotifier(t *testing.T) {
+	t.Run("queues events without waiting and delivers them in order on close", func(t *testing.T) {
+		target := &blockingNotifier{release: make(chan struct{})}
+		notifier := notifications.NewAsyncNotifier(target, notifications.DefaultQueueSize, nil)
+
+		require.NoError(t, notifier.Notify(context.Background(), installation.NewInstallationStartedEvent("session-1")))
+		require.NoError(t, notifier.Notify(context.Background(), installation.NewInstallationCompletedEvent("session-1", time.Minute, 2)))
+		close(target.release)
+
+		require.NoError(t, notifier.Close(context.Background()))
+		assert.Equal(t, []string{"installation.started", "installation.completed"}, target.delivered)
+	})
+
+	t.Run("drops events while the queue is full", func(t *testing.T) {
+		target := &blockingNotifier{release: make(chan struct{})}
+		notifier := notifications.NewAsyncNotifier(target, 1, nil)
+		defer notifier.Close(context.Background())
+		defer close(target.release)
+
+		event := installation.NewInstallationStartedEvent("session-2")
+		require.Eventually(t, func() bool {
+			return notifier.Notify(context.Background(), event) != nil
+		}, time.Second, time.Millisecond)
+		assert.ErrorIs(t, notifier.Notify(context.Background(), event), notifications.ErrQueueFull)
+	})
+
+	t.Run("abandons deliveries once the close deadline passes", func(t *testing.T) {
+		target := &blockingNotifier{release: make(chan struct{})}
+		var mu sync.Mutex
+		var failed []error
+		notifier := notifications.NewAsyncNotifier(target, notifications.DefaultQueueSize, func(_ installation.DomainEvent, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, err)
+		})
+		require.NoError(t, notifier.Notify(context.Background(), installation.NewInstallationStartedEvent("session-3")))
+		require.NoError(t, notifier.Notify(context.Background(), installation.NewInstallationStartedEvent("session-4")))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, notifier.Close(ctx), context.DeadlineExceeded)
+		assert.Len(t, failed, 2)
+		assert.Empty(t, target.delivered)
+
+		err := notifier.Notify(context.Background(), installation.NewInstallationStartedEvent("session-5"))
+		assert.ErrorIs(t, err, notifications.ErrNotifierClosed)
+	})
+}
@@ -0,0 +1,222 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+)
+
+const (
+	// WebhookEventHeader carries the event type, such as
+	// installation.completed
+	WebhookEventHeader = "X-Gohan-Event"
+
+	// WebhookSignatureHeader carries "sha256=" followed by the hex
+	// HMAC-SHA256 of the request body, keyed with the webhook secret
+	WebhookSignatureHeader = "X-Gohan-Signature"
+
+	// defaultWebhookTimeout bounds each delivery attempt
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+var (
+	// ErrInvalidWebhookURL is returned for a webhook URL that is not an
+	// absolute http or https URL
+	ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+
+	// ErrWebhookDelivery is returned when an endpoint did not accept an
+	// event within the allowed attempts
+	ErrWebhookDelivery = errors.New("webhook delivery failed")
+)
+
+// RetryPolicy controls how often a delivery is attempted. The delay before
+// the first retry is Backoff and doubles after each further failure.
+type RetryPolicy struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used unless one is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{Attempts: 3, Backoff: 2 * time.Second}
+}
+
+// WebhookPayload is the JSON body posted for each event. Fields that do not
+// apply to an event are left out.
+type WebhookPayload struct {
+	Event               string    `json:"event"`
+	SessionID           string    `json:"session_id"`
+	OccurredAt          time.Time `json:"occurred_at"`
+	DurationSeconds     float64   `json:"duration_seconds,omitempty"`
+	ComponentsInstalled int       `json:"components_installed,omitempty"`
+	Phase               string    `json:"phase,omitempty"`
+	Reason              string    `json:"reason,omitempty"`
+	Recoverable         bool      `json:"recoverable,omitempty"`
+}
+
+// WebhookNotifier posts installation lifecycle events to webhook URLs,
+// retrying failed deliveries with exponential backoff
+type WebhookNotifier struct {
+	urls   []string
+	secret []byte
+	retry  RetryPolicy
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting to urls. Bodies are signed
+// when secret is not empty.
+func NewWebhookNotifier(urls []string, secret string) (*WebhookNotifier, error) {
+	for _, raw := range urls {
+		parsed, err := url.Parse(raw)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWebhookURL, raw)
+		}
+	}
+
+	copied := make([]string, len(urls))
+	copy(copied, urls)
+	return &WebhookNotifier{
+		urls:   copied,
+		secret: []byte(secret),
+		retry:  DefaultRetryPolicy(),
+		client: &http.Client{Timeout: defaultWebhookTimeout},
+	}, nil
+}
+
+// WithRetry returns a copy of the notifier using the given retry policy.
+// Fewer than one attempt counts as one.
+func (n *WebhookNotifier) WithRetry(policy RetryPolicy) *WebhookNotifier {
+	copied := *n
+	copied.retry = policy
+	return &copied
+}
+
+// WithClient returns a copy of the notifier sending with client
+func (n *WebhookNotifier) WithClient(client *http.Client) *WebhookNotifier {
+	copied := *n
+	copied.client = client
+	return &copied
+}
+
+// Notify posts event to every URL. Events other than installation.started,
+// installation.completed and installation.failed are not sent.
+func (n *WebhookNotifier) Notify(ctx context.Context, event installation.DomainEvent) error {
+	payload, ok := newWebhookPayload(event)
+	if !ok {
+		return nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", payload.Event, err)
+	}
+
+	var errs []error
+	for _, target := range n.urls {
+		if err := n.deliver(ctx, target, payload.Event, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Sign returns the signature header value for body keyed with secret, for
+// receivers checking deliveries
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver posts body to target until it is accepted, the attempts run out
+// or the endpoint rejects it outright
+func (n *WebhookNotifier) deliver(ctx context.Context, target, eventType string, body []byte) error {
+	attempts := max(n.retry.Attempts, 1)
+	delay := n.retry.Backoff
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w: %s: %w", ErrWebhookDelivery, target, ctx.Err())
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+
+		retryable, err := n.post(ctx, target, eventType, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return fmt.Errorf("%w: %s: %w", ErrWebhookDelivery, target, lastErr)
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying: network errors, rate limiting and server errors are, other
+// rejections are not
+func (n *WebhookNotifier) post(ctx context.Context, target, eventType string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gohan-webhook")
+	req.Header.Set(WebhookEventHeader, eventType)
+	if len(n.secret) > 0 {
+		req.Header.Set(WebhookSignatureHeader, Sign(n.secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("unexpected status %s", resp.Status)
+}
+
+// newWebhookPayload describes a lifecycle event, reporting false for
+// events that are not sent
+func newWebhookPayload(event installation.DomainEvent) (WebhookPayload, bool) {
+	payload := WebhookPayload{
+		Event:      event.EventType(),
+		OccurredAt: event.OccurredAt().UTC(),
+	}
+	switch e := event.(type) {
+	case installation.InstallationStartedEvent:
+		payload.SessionID = e.SessionID()
+	case installation.InstallationCompletedEvent:
+		payload.SessionID = e.SessionID()
+		payload.DurationSeconds = e.Duration().Seconds()
+		payload.ComponentsInstalled = e.ComponentsInstalled()
+	case installation.InstallationFailedEvent:
+		payload.SessionID = e.SessionID()
+		payload.Phase = e.Phase().String()
+		payload.Reason = e.Reason()
+		payload.Recoverable = e.IsRecoverable()
+	default:
+		return WebhookPayload{}, false
+	}
+	return payload, true
+}
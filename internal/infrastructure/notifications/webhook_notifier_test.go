@@ -0,0 +1,147 @@
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/infrastructure/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver records deliveries and answers them with the given
+// statuses in turn, then with 200
+type webhookReceiver struct {
+	mu         sync.Mutex
+	statuses   []int
+	bodies     [][]byte
+	events     []string
+	signatures []string
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies = append(r.bodies, body)
+	r.events = append(r.events, req.Header.Get(notifications.WebhookEventHeader))
+	r.signatures = append(r.signatures, req.Header.Get(notifications.WebhookSignatureHeader))
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+var fastRetry = notifications.RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Run("posts completed events signed with the secret", func(t *testing.T) {
+		receiver := &webhookReceiver{}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		notifier, err := notifications.NewWebhookNotifier([]string{server.URL}, "s3cret")
+		require.NoError(t, err)
+
+		event := installation.NewInstallationCompletedEvent("session-1", 90*time.Second, 4)
+		require.NoError(t, notifier.Notify(context.Background(), event))
+
+		require.Len(t, receiver.bodies, 1)
+		var payload notifications.WebhookPayload
+		require.NoError(t, json.Unmarshal(receiver.bodies[0], &payload))
+		assert.Equal(t, "installation.completed", payload.Event)
+		assert.Equal(t, "session-1", payload.SessionID)
+		assert.Equal(t, 90.0, payload.DurationSeconds)
+		assert.Equal(t, 4, payload.ComponentsInstalled)
+		assert.Equal(t, "installation.completed", receiver.events[0])
+		assert.Equal(t, notifications.Sign([]byte("s3cret"), receiver.bodies[0]), receiver.signatures[0])
+	})
+
+	t.Run("describes failures and leaves bodies unsigned without a secret", func(t *testing.T) {
+		receiver := &webhookReceiver{}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		notifier, err := notifications.NewWebhookNotifier([]string{server.URL}, "")
+		require.NoError(t, err)
+
+		event := installation.NewInstallationFailedEvent("session-2", installation.StatusInstalling, "apt-get failed", false)
+		require.NoError(t, notifier.Notify(context.Background(), event))
+
+		var payload notifications.WebhookPayload
+		require.NoError(t, json.Unmarshal(receiver.bodies[0], &payload))
+		assert.Equal(t, "installation.failed", payload.Event)
+		assert.Equal(t, "installing", payload.Phase)
+		assert.Equal(t, "apt-get failed", payload.Reason)
+		assert.Empty(t, receiver.signatures[0])
+	})
+
+	t.Run("retries server errors until accepted", func(t *testing.T) {
+		receiver := &webhookReceiver{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		notifier, err := notifications.NewWebhookNotifier([]string{server.URL}, "")
+		require.NoError(t, err)
+
+		err = notifier.WithRetry(fastRetry).Notify(context.Background(), installation.NewInstallationStartedEvent("session-3"))
+		require.NoError(t, err)
+		assert.Len(t, receiver.bodies, 3)
+	})
+
+	t.Run("gives up after the allowed attempts", func(t *testing.T) {
+		receiver := &webhookReceiver{statuses: []int{500, 500, 500, 500}}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		notifier, err := notifications.NewWebhookNotifier([]string{server.URL}, "")
+		require.NoError(t, err)
+
+		err = notifier.WithRetry(fastRetry).Notify(context.Background(), installation.NewInstallationStartedEvent("session-4"))
+		assert.ErrorIs(t, err, notifications.ErrWebhookDelivery)
+		assert.Len(t, receiver.bodies, 3)
+	})
+
+	t.Run("does not retry rejected events", func(t *testing.T) {
+		receiver := &webhookReceiver{statuses: []int{http.StatusUnauthorized}}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		notifier, err := notifications.NewWebhookNotifier([]string{server.URL}, "")
+		require.NoError(t, err)
+
+		err = notifier.WithRetry(fastRetry).Notify(context.Background(), installation.NewInstallationStartedEvent("session-5"))
+		assert.ErrorIs(t, err, notifications.ErrWebhookDelivery)
+		assert.Len(t, receiver.bodies, 1)
+	})
+
+	t.Run("ignores other events", func(t *testing.T) {
+		receiver := &webhookReceiver{}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		notifier, err := notifications.NewWebhookNotifier([]string{server.URL}, "")
+		require.NoError(t, err)
+
+		event := installation.NewRollbackStartedEvent("session-6", "snapshot-1", "failed")
+		require.NoError(t, notifier.Notify(context.Background(), event))
+		assert.Empty(t, receiver.bodies)
+	})
+
+	t.Run("rejects URLs that are not http or https", func(t *testing.T) {
+		for _, raw := range []string{"ftp://example.com/hook", "example.com/hook", "http://"} {
+			_, err := notifications.NewWebhookNotifier([]string{raw}, "")
+			assert.ErrorIs(t, err, notifications.ErrInvalidWebhookURL, raw)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package onboarding
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/onboarding"
+)
+
+// ScriptName is the tour script Hyprland's autostart runs when it exists
+const ScriptName = "onboarding-tour.sh"
+
+// doneName marks a tour that ran or was disabled
+const doneName = "onboarding-tour.done"
+
+// Seconds each notification stays up, and the pause before the next
+const (
+	notificationSeconds = 12
+	stepSeconds         = 8
+)
+
+// ScriptStore keeps the first-login tour as a script in gohan's data
+// directory. The script shows the tour as notifications, then removes
+// itself and leaves a marker so it runs only once.
+type ScriptStore struct {
+	dir string
+}
+
+// NewScriptStore creates a store keeping the script in dir
+func NewScriptStore(dir string) *ScriptStore {
+	return &ScriptStore{dir: dir}
+}
+
+// Path returns where the tour script is written
+func (s *ScriptStore) Path() string {
+	return filepath.Join(s.dir, ScriptName)
+}
+
+func (s *ScriptStore) donePath() string {
+	return filepath.Join(s.dir, doneName)
+}
+
+// Enable writes the tour script so it runs on the next login
+func (s *ScriptStore) Enable(tour onboarding.Tour) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+	if err := os.WriteFile(s.Path(), []byte(s.script(tour)), 0o755); err != nil {
+		return fmt.Errorf("failed to write onboarding tour: %w", err)
+	}
+	if err := os.Remove(s.donePath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to reset onboarding tour: %w", err)
+	}
+	return nil
+}
+
+// Disable removes the tour script and marks the tour done, so installing
+// again does not bring it back
+func (s *ScriptStore) Disable() error {
+	if err := os.Remove(s.Path()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove onboarding tour: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+	if err := os.WriteFile(s.donePath(), nil, 0o644); err != nil {
+		return fmt.Errorf("failed to mark onboarding tour done: %w", err)
+	}
+	return nil
+}
+
+// State reports whether the tour runs on the next login
+func (s *ScriptStore) State() (onboarding.State, error) {
+	for _, check := range []struct {
+		path  string
+		state onboarding.State
+	}{
+		{s.Path(), onboarding.StatePending},
+		{s.donePath(), onboarding.StateDone},
+	} {
+		_, err := os.Stat(check.path)
+		if err == nil {
+			return check.state, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to read onboarding tour state: %w", err)
+		}
+	}
+	return onboarding.StateNotSetUp, nil
+}
+
+// script renders the tour as a shell script sending one notification per
+// step. It removes itself first, so an interrupted session does not show
+// the tour again.
+func (s *ScriptStore) script(tour onboarding.Tour) string {
+	steps := tour.Steps()
+
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString("# First-login tour of the Hyprland key bindings, generated by gohan.\n")
+	b.WriteString("# Runs once; show it again with: gohan onboarding enable\n")
+	fmt.Fprintf(&b, "rm -f -- %s\n", shellQuote(s.Path()))
+	fmt.Fprintf(&b, ": > %s\n", shellQuote(s.donePath()))
+	b.WriteString("command -v notify-send >/dev/null 2>&1 || exit 0\n")
+	b.WriteString("\n# Give Waybar and the notification daemon time to start\nsleep 5\n\n")
+
+	notify := func(title, body string) {
+		fmt.Fprintf(&b, "notify-send --app-name=gohan --expire-time=%d %s %s\n",
+			notificationSeconds*1000, shellQuote(title), shellQuote(body))
+	}
+	notify("Welcome to Hyprland", fmt.Sprintf("Here are %d key bindings to get started. SUPER is the Windows key.", len(steps)))
+	for i, step := range steps {
+		fmt.Fprintf(&b, "sleep %d\n", stepSeconds)
+		notify(fmt.Sprintf("%d/%d  %s", i+1, len(steps), step.Keys()), step.Action())
+	}
+	fmt.Fprintf(&b, "sleep %d\n", stepSeconds)
+	notify("That's it", "Press SUPER+F1 for a cheat sheet of every binding")
+	return b.String()
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
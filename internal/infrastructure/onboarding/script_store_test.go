@@ -0,0 +1,91 @@
+package onboarding_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	domain "github.com/rebelopsio/gohan/internal/domain/onboarding"
+	"github.com/rebelopsio/gohan/internal/infrastructure/onboarding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptStore(t *testing.T) {
+	t.Run("is not set up at first", func(t *testing.T) {
+		store := onboarding.NewScriptStore(filepath.Join(t.TempDir(), ".gohan"))
+
+		state, err := store.State()
+		require.NoError(t, err)
+		assert.Equal(t, domain.StateNotSetUp, state)
+	})
+
+	t.Run("enable writes an executable script", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), ".gohan")
+		store := onboarding.NewScriptStore(dir)
+
+		require.NoError(t, store.Enable(domain.DefaultTour()))
+
+		assert.Equal(t, filepath.Join(dir, onboarding.ScriptName), store.Path())
+		info, err := os.Stat(store.Path())
+		require.NoError(t, err)
+		assert.NotZero(t, info.Mode().Perm()&0o100, "script should be executable")
+
+		state, err := store.State()
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatePending, state)
+	})
+
+	t.Run("script notifies each step and removes itself", func(t *testing.T) {
+		store := onboarding.NewScriptStore(t.TempDir())
+		step, err := domain.NewStep("SUPER+Return", "Open a terminal")
+		require.NoError(t, err)
+		tour, err := domain.NewTour(step)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Enable(tour))
+
+		script, err := os.ReadFile(store.Path())
+		require.NoError(t, err)
+		assert.Contains(t, string(script), "#!/bin/sh\n")
+		assert.Contains(t, string(script), "rm -f -- '"+store.Path()+"'")
+		assert.Contains(t, string(script), "'1/1  SUPER+Return' 'Open a terminal'")
+	})
+
+	t.Run("script quotes text for the shell", func(t *testing.T) {
+		store := onboarding.NewScriptStore(t.TempDir())
+		step, err := domain.NewStep("SUPER+Q", "Don't panic; $(reboot)")
+		require.NoError(t, err)
+		tour, err := domain.NewTour(step)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Enable(tour))
+
+		script, err := os.ReadFile(store.Path())
+		require.NoError(t, err)
+		assert.Contains(t, string(script), `'Don'\''t panic; $(reboot)'`)
+	})
+
+	t.Run("disable removes the script and stays done", func(t *testing.T) {
+		store := onboarding.NewScriptStore(t.TempDir())
+		require.NoError(t, store.Enable(domain.DefaultTour()))
+
+		require.NoError(t, store.Disable())
+
+		assert.NoFileExists(t, store.Path())
+		state, err := store.State()
+		require.NoError(t, err)
+		assert.Equal(t, domain.StateDone, state)
+	})
+
+	t.Run("enable after disable runs the tour again", func(t *testing.T) {
+		store := onboarding.NewScriptStore(t.TempDir())
+		require.NoError(t, store.Disable())
+
+		require.NoError(t, store.Enable(domain.DefaultTour()))
+
+		state, err := store.State()
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatePending, state)
+	})
+}
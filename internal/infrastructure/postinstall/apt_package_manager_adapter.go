@@ -18,6 +18,13 @@ func NewAPTPackageManagerAdapter() *APTPackageManagerAdapter {
 	}
 }
 
+// NewAPTPackageManagerAdapterWithManager adapts an already configured APTManager
+func NewAPTPackageManagerAdapterWithManager(aptManager *packagemanager.APTManager) *APTPackageManagerAdapter {
+	return &APTPackageManagerAdapter{
+		aptManager: aptManager,
+	}
+}
+
 // Install installs one or more packages
 func (a *APTPackageManagerAdapter) Install(ctx context.Context, packages ...string) error {
 	// Use InstallPackages without progress channel
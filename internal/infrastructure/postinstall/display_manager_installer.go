@@ -33,6 +33,7 @@ type ServiceManager interface {
 	Stop(ctx context.Context, service string) error
 	IsEnabled(ctx context.Context, service string) (bool, error)
 	IsActive(ctx context.Context, service string) (bool, error)
+	DaemonReload(ctx context.Context) error
 }
 
 // NewDisplayManagerInstaller creates a new display manager installer
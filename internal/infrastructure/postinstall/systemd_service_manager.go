@@ -5,19 +5,45 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 )
 
+// defaultServiceCommandTimeout bounds each systemctl call
+const defaultServiceCommandTimeout = time.Minute
+
 // SystemdServiceManager implements ServiceManager using systemd
-type SystemdServiceManager struct{}
+type SystemdServiceManager struct {
+	timeout time.Duration
+}
 
 // NewSystemdServiceManager creates a new systemd service manager
 func NewSystemdServiceManager() *SystemdServiceManager {
-	return &SystemdServiceManager{}
+	return NewSystemdServiceManagerWithTimeout(defaultServiceCommandTimeout)
+}
+
+// NewSystemdServiceManagerWithTimeout creates a systemd service manager
+// whose systemctl calls are cancelled after timeout (0 means no bound)
+func NewSystemdServiceManagerWithTimeout(timeout time.Duration) *SystemdServiceManager {
+	return &SystemdServiceManager{timeout: timeout}
+}
+
+// command builds a systemctl call bounded by the manager's timeout. The
+// returned cancel func must be called once the command has finished.
+func (s *SystemdServiceManager) command(ctx context.Context, name string, args ...string) (*exec.Cmd, context.CancelFunc) {
+	if s.timeout <= 0 {
+		return exec.CommandContext(ctx, name, args...), func() {}
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	return exec.CommandContext(ctx, name, args...), cancel
 }
 
 // Enable enables a systemd service
 func (s *SystemdServiceManager) Enable(ctx context.Context, service string) error {
-	cmd := exec.CommandContext(ctx, "sudo", "systemctl", "enable", service)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cmd, cancel := s.command(ctx, "sudo", "systemctl", "enable", service)
+	defer cancel()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to enable service %s: %w, output: %s", service, err, string(output))
@@ -27,7 +53,11 @@ func (s *SystemdServiceManager) Enable(ctx context.Context, service string) erro
 
 // Disable disables a systemd service
 func (s *SystemdServiceManager) Disable(ctx context.Context, service string) error {
-	cmd := exec.CommandContext(ctx, "sudo", "systemctl", "disable", service)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cmd, cancel := s.command(ctx, "sudo", "systemctl", "disable", service)
+	defer cancel()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to disable service %s: %w, output: %s", service, err, string(output))
@@ -37,7 +67,11 @@ func (s *SystemdServiceManager) Disable(ctx context.Context, service string) err
 
 // Start starts a systemd service
 func (s *SystemdServiceManager) Start(ctx context.Context, service string) error {
-	cmd := exec.CommandContext(ctx, "sudo", "systemctl", "start", service)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cmd, cancel := s.command(ctx, "sudo", "systemctl", "start", service)
+	defer cancel()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to start service %s: %w, output: %s", service, err, string(output))
@@ -47,7 +81,11 @@ func (s *SystemdServiceManager) Start(ctx context.Context, service string) error
 
 // Stop stops a systemd service
 func (s *SystemdServiceManager) Stop(ctx context.Context, service string) error {
-	cmd := exec.CommandContext(ctx, "sudo", "systemctl", "stop", service)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cmd, cancel := s.command(ctx, "sudo", "systemctl", "stop", service)
+	defer cancel()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to stop service %s: %w, output: %s", service, err, string(output))
@@ -57,7 +95,11 @@ func (s *SystemdServiceManager) Stop(ctx context.Context, service string) error
 
 // IsEnabled checks if a service is enabled
 func (s *SystemdServiceManager) IsEnabled(ctx context.Context, service string) (bool, error) {
-	cmd := exec.CommandContext(ctx, "systemctl", "is-enabled", service)
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	cmd, cancel := s.command(ctx, "systemctl", "is-enabled", service)
+	defer cancel()
 	output, err := cmd.Output()
 	if err != nil {
 		// If exit code is not 0, service is not enabled
@@ -68,7 +110,11 @@ func (s *SystemdServiceManager) IsEnabled(ctx context.Context, service string) (
 
 // IsActive checks if a service is active (running)
 func (s *SystemdServiceManager) IsActive(ctx context.Context, service string) (bool, error) {
-	cmd := exec.CommandContext(ctx, "systemctl", "is-active", service)
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	cmd, cancel := s.command(ctx, "systemctl", "is-active", service)
+	defer cancel()
 	output, err := cmd.Output()
 	if err != nil {
 		// If exit code is not 0, service is not active
@@ -76,3 +122,17 @@ func (s *SystemdServiceManager) IsActive(ctx context.Context, service string) (b
 	}
 	return strings.TrimSpace(string(output)) == "active", nil
 }
+
+// DaemonReload reloads unit files so generators such as zram-generator rerun
+func (s *SystemdServiceManager) DaemonReload(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cmd, cancel := s.command(ctx, "sudo", "systemctl", "daemon-reload")
+	defer cancel()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to reload systemd: %w, output: %s", err, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package postinstall_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/infrastructure/postinstall"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemdServiceManager_CanceledContext(t *testing.T) {
+	manager := postinstall.NewSystemdServiceManager()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, manager.Enable(ctx, "NetworkManager"), context.Canceled)
+	assert.ErrorIs(t, manager.Disable(ctx, "NetworkManager"), context.Canceled)
+	assert.ErrorIs(t, manager.Start(ctx, "NetworkManager"), context.Canceled)
+	assert.ErrorIs(t, manager.Stop(ctx, "NetworkManager"), context.Canceled)
+	assert.ErrorIs(t, manager.DaemonReload(ctx), context.Canceled)
+
+	// A canceled query must not be mistaken for a disabled or stopped unit
+	_, err := manager.IsEnabled(ctx, "NetworkManager")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = manager.IsActive(ctx, "NetworkManager")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,178 @@
+package postinstall
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rebelopsio/gohan/internal/domain/postinstall"
+	"github.com/rebelopsio/gohan/internal/infrastructure/preflight/detectors"
+)
+
+// zramSetupService is the unit systemd-zram-generator creates for zram0
+const zramSetupService = "systemd-zram-setup@" + postinstall.ZramDevice + ".service"
+
+// ZramInstaller handles compressed swap setup (systemd-zram-generator)
+type ZramInstaller struct {
+	packageMgr     PackageManager
+	serviceMgr     ServiceManager
+	configPath     string
+	swapsPath      string
+	previousConfig []byte
+}
+
+// NewZramInstaller creates a new zram installer
+func NewZramInstaller(packageMgr PackageManager, serviceMgr ServiceManager) *ZramInstaller {
+	return &ZramInstaller{
+		packageMgr: packageMgr,
+		serviceMgr: serviceMgr,
+		configPath: postinstall.ZramConfigPath,
+		swapsPath:  "/proc/swaps",
+	}
+}
+
+// Name returns the installer name
+func (i *ZramInstaller) Name() string {
+	return "Compressed Swap (zram)"
+}
+
+// Component returns the component type
+func (i *ZramInstaller) Component() postinstall.ComponentType {
+	return postinstall.ComponentZram
+}
+
+// Install performs the installation
+func (i *ZramInstaller) Install(ctx context.Context) (postinstall.ComponentResult, error) {
+	result := postinstall.NewComponentResult(
+		postinstall.ComponentZram,
+		postinstall.StatusInProgress,
+		"Configuring zram swap",
+	)
+
+	details := []string{}
+
+	installed, err := i.packageMgr.IsInstalled(ctx, "systemd-zram-generator")
+	if err != nil {
+		return postinstall.NewComponentResultWithError(
+			postinstall.ComponentZram,
+			"Failed to check package installation",
+			err,
+		), err
+	}
+
+	if !installed {
+		if err := i.packageMgr.Install(ctx, "systemd-zram-generator"); err != nil {
+			return postinstall.NewComponentResultWithError(
+				postinstall.ComponentZram,
+				"Failed to install systemd-zram-generator",
+				err,
+			), err
+		}
+		details = append(details, "systemd-zram-generator installed")
+	} else {
+		details = append(details, "systemd-zram-generator already installed")
+	}
+
+	// Size the device from detected memory, falling back to the defaults
+	config := postinstall.DefaultZramConfig()
+	if resources, err := detectors.NewSystemResourceDetector().DetectResources(ctx); err == nil {
+		config = postinstall.ZramConfigForMemory(resources.TotalMemory())
+	}
+
+	// Keep the existing configuration for rollback
+	if previous, err := os.ReadFile(i.configPath); err == nil {
+		i.previousConfig = previous
+	}
+
+	if err := os.WriteFile(i.configPath, []byte(config.Render()), 0644); err != nil {
+		return postinstall.NewComponentResultWithError(
+			postinstall.ComponentZram,
+			"Failed to write zram-generator.conf",
+			err,
+		), err
+	}
+	details = append(details, fmt.Sprintf("%s written (size %s, %s)",
+		i.configPath, config.SizeExpression(), config.CompressionAlgorithm()))
+
+	// The generator only runs on daemon-reload, then the setup unit creates the swap
+	if err := i.serviceMgr.DaemonReload(ctx); err != nil {
+		return postinstall.NewComponentResultWithError(
+			postinstall.ComponentZram,
+			"Failed to reload systemd",
+			err,
+		), err
+	}
+
+	if err := i.serviceMgr.Start(ctx, zramSetupService); err != nil {
+		return postinstall.NewComponentResultWithError(
+			postinstall.ComponentZram,
+			"Failed to start zram setup service",
+			err,
+		), err
+	}
+
+	// Verify the swap device actually came up
+	device, err := i.activeZram()
+	if err != nil {
+		return postinstall.NewComponentResultWithError(
+			postinstall.ComponentZram,
+			"Failed to read swap status",
+			err,
+		), err
+	}
+	if device == nil {
+		err := fmt.Errorf("%s is not listed in %s", postinstall.ZramDevice, i.swapsPath)
+		return postinstall.NewComponentResultWithError(
+			postinstall.ComponentZram,
+			"zram swap did not activate",
+			err,
+		), err
+	}
+	details = append(details, fmt.Sprintf("%s active: %d MB swap (priority %d)",
+		device.Name, device.SizeKB/1024, device.Priority))
+
+	return result.
+		WithDetails(details...).
+		Complete(postinstall.StatusCompleted), nil
+}
+
+// Verify checks if zram swap is active
+func (i *ZramInstaller) Verify(ctx context.Context) (bool, error) {
+	device, err := i.activeZram()
+	if err != nil {
+		return false, err
+	}
+	return device != nil, nil
+}
+
+// Rollback reverts the installation
+func (i *ZramInstaller) Rollback(ctx context.Context) error {
+	// Stop the swap device and restore the previous configuration, but
+	// keep the package installed
+	if err := i.serviceMgr.Stop(ctx, zramSetupService); err != nil {
+		return err
+	}
+
+	if i.previousConfig != nil {
+		return os.WriteFile(i.configPath, i.previousConfig, 0644)
+	}
+	if err := os.Remove(i.configPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// activeZram returns the active zram swap device, or nil if there is none
+func (i *ZramInstaller) activeZram() (*postinstall.SwapDevice, error) {
+	content, err := os.ReadFile(i.swapsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, device := range postinstall.ParseSwaps(string(content)) {
+		if device.IsZram() {
+			return &device, nil
+		}
+	}
+	return nil, nil
+}
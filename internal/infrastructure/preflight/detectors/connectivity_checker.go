@@ -42,6 +42,11 @@ func (c *SystemConnectivityChecker) CheckInternetConnectivity(ctx context.Contex
 		}
 	}
 
+	// A cancelled check says nothing about the network
+	if err := ctx.Err(); err != nil {
+		return preflight.InternetConnectivity{}, err
+	}
+
 	return preflight.NewInternetConnectivity(hasConnection, tests), nil
 }
 
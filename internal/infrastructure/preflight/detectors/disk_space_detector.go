@@ -1,18 +1,34 @@
 package detectors
 
 import (
+	"bufio"
 	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
 )
 
+const defaultMountInfoPath = "/proc/self/mountinfo"
+
 // SystemDiskSpaceDetector implements preflight.DiskSpaceDetector using syscall.Statfs
-type SystemDiskSpaceDetector struct{}
+// and preflight.MountPointDetector using the kernel mount table
+type SystemDiskSpaceDetector struct {
+	mountInfoPath string
+}
 
 // NewSystemDiskSpaceDetector creates a new disk space detector
 func NewSystemDiskSpaceDetector() *SystemDiskSpaceDetector {
-	return &SystemDiskSpaceDetector{}
+	return NewSystemDiskSpaceDetectorWithMountInfo(defaultMountInfoPath)
+}
+
+// NewSystemDiskSpaceDetectorWithMountInfo creates a detector reading the
+// mount table from the given mountinfo file
+func NewSystemDiskSpaceDetectorWithMountInfo(mountInfoPath string) *SystemDiskSpaceDetector {
+	return &SystemDiskSpaceDetector{mountInfoPath: mountInfoPath}
 }
 
 // DetectAvailableSpace checks disk space at path
@@ -36,3 +52,90 @@ func (d *SystemDiskSpaceDetector) DetectAvailableSpace(ctx context.Context, path
 
 	return preflight.NewDiskSpace(available, total, path)
 }
+
+// DetectMountPoint returns the mount point holding path. Paths that do not
+// exist yet resolve through their nearest existing parent.
+func (d *SystemDiskSpaceDetector) DetectMountPoint(ctx context.Context, path string) (string, error) {
+	mountPoints, err := d.readMountPoints()
+	if err != nil {
+		return "", err
+	}
+
+	resolved := resolveExistingPath(path)
+	best := "/"
+	for _, mountPoint := range mountPoints {
+		if len(mountPoint) > len(best) && isUnderPath(resolved, mountPoint) {
+			best = mountPoint
+		}
+	}
+	return best, nil
+}
+
+// readMountPoints returns the mount points listed in mountinfo
+func (d *SystemDiskSpaceDetector) readMountPoints() ([]string, error) {
+	f, err := os.Open(d.mountInfoPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mountPoints []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// id parent major:minor root mount-point options ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mountPoints = append(mountPoints, unescapeMountPath(fields[4]))
+	}
+	return mountPoints, scanner.Err()
+}
+
+// resolveExistingPath cleans path, walks up to the nearest existing
+// directory and resolves symlinks, e.g. /home -> /var/home on ostree systems
+func resolveExistingPath(path string) string {
+	path = filepath.Clean("/" + path)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			if resolved, err := filepath.EvalSymlinks(path); err == nil {
+				return resolved
+			}
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
+
+// isUnderPath reports whether path is dir or inside it
+func isUnderPath(path, dir string) bool {
+	if dir == "/" || path == dir {
+		return true
+	}
+	return strings.HasPrefix(path, dir+"/")
+}
+
+// unescapeMountPath decodes the octal escapes mountinfo uses for spaces,
+// tabs, newlines and backslashes
+func unescapeMountPath(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package detectors
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// SystemFilesystemWritabilityDetector implements
+// preflight.FilesystemWritabilityDetector using the kernel mount table
+type SystemFilesystemWritabilityDetector struct {
+	mountInfoPath string
+}
+
+// mountEntry is one mount from mountinfo
+type mountEntry struct {
+	mountPoint string
+	fsType     string
+	readOnly   bool
+}
+
+// NewSystemFilesystemWritabilityDetector creates a new writability detector
+func NewSystemFilesystemWritabilityDetector() *SystemFilesystemWritabilityDetector {
+	return NewSystemFilesystemWritabilityDetectorWithMountInfo(defaultMountInfoPath)
+}
+
+// NewSystemFilesystemWritabilityDetectorWithMountInfo creates a detector
+// reading the mount table from the given mountinfo file
+func NewSystemFilesystemWritabilityDetectorWithMountInfo(mountInfoPath string) *SystemFilesystemWritabilityDetector {
+	return &SystemFilesystemWritabilityDetector{mountInfoPath: mountInfoPath}
+}
+
+// DetectWritability reports which of paths live on read-only mounts. Paths
+// that do not exist yet are checked through their nearest existing parent.
+func (d *SystemFilesystemWritabilityDetector) DetectWritability(ctx context.Context, paths []string) (preflight.FilesystemWritability, error) {
+	mounts, err := d.readMounts()
+	if err != nil {
+		return preflight.FilesystemWritability{}, err
+	}
+
+	var readOnly []preflight.ReadOnlyMount
+	for _, path := range paths {
+		resolved := resolveExistingPath(path)
+
+		// Later entries are mounted over earlier ones on the same point
+		var holder *mountEntry
+		for i := range mounts {
+			if isUnderPath(resolved, mounts[i].mountPoint) &&
+				(holder == nil || len(mounts[i].mountPoint) >= len(holder.mountPoint)) {
+				holder = &mounts[i]
+			}
+		}
+		if holder != nil && holder.readOnly {
+			readOnly = append(readOnly, preflight.ReadOnlyMount{
+				Path:       path,
+				MountPoint: holder.mountPoint,
+				FSType:     holder.fsType,
+			})
+		}
+	}
+	return preflight.NewFilesystemWritability(readOnly), nil
+}
+
+// readMounts returns the mounts listed in mountinfo with their filesystem
+// type and whether the mount or its superblock is read-only
+func (d *SystemFilesystemWritabilityDetector) readMounts() ([]mountEntry, error) {
+	f, err := os.Open(d.mountInfoPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mounts []mountEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// id parent major:minor root mount-point options [optional...] - fstype source super-options
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		entry := mountEntry{
+			mountPoint: unescapeMountPath(fields[4]),
+			readOnly:   hasMountOption(fields[5], "ro"),
+		}
+		for i := 6; i < len(fields); i++ {
+			if fields[i] != "-" {
+				continue
+			}
+			if i+1 < len(fields) {
+				entry.fsType = fields[i+1]
+			}
+			if i+3 < len(fields) && hasMountOption(fields[i+3], "ro") {
+				entry.readOnly = true
+			}
+			break
+		}
+		mounts = append(mounts, entry)
+	}
+	return mounts, scanner.Err()
+}
+
+// hasMountOption reports whether a comma-separated option list contains option
+func hasMountOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -2,25 +2,44 @@ package detectors
 
 import (
 	"context"
-	"os/exec"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
 )
 
-// SystemGPUDetector implements preflight.GPUDetector using lspci
-type SystemGPUDetector struct{}
+// nvidiaVersionRegex matches the module version in /proc/driver/nvidia/version
+// Example: NVRM version: NVIDIA UNIX x86_64 Kernel Module  550.54.14  Thu Feb 22 01:44:30 UTC 2024
+var nvidiaVersionRegex = regexp.MustCompile(`Kernel Module\s+([0-9][0-9.]*)`)
+
+// SystemGPUDetector implements preflight.GPUDetector using lspci, with the
+// bound driver and VRAM read from sysfs
+type SystemGPUDetector struct {
+	sysfsRoot string
+	procRoot  string
+}
 
 // NewSystemGPUDetector creates a new GPU detector
 func NewSystemGPUDetector() *SystemGPUDetector {
-	return &SystemGPUDetector{}
+	return NewSystemGPUDetectorWithRoots("/sys", "/proc")
+}
+
+// NewSystemGPUDetectorWithRoots creates a GPU detector that reads driver
+// information from the given sysfs and procfs roots
+func NewSystemGPUDetectorWithRoots(sysfsRoot, procRoot string) *SystemGPUDetector {
+	return &SystemGPUDetector{
+		sysfsRoot: sysfsRoot,
+		procRoot:  procRoot,
+	}
 }
 
 // DetectGPUs returns all detected GPUs
 func (d *SystemGPUDetector) DetectGPUs(ctx context.Context) ([]preflight.GPUType, error) {
-	cmd := exec.CommandContext(ctx, "lspci", "-nn")
-	output, err := cmd.Output()
+	// -D prints the PCI domain so the slot matches /sys/bus/pci/devices
+	output, err := probeOutput(ctx, "lspci", "-D", "-nn")
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +59,7 @@ func (d *SystemGPUDetector) DetectGPUs(ctx context.Context) ([]preflight.GPUType
 
 		gpu, err := d.parseGPULine(line, pciIDRegex)
 		if err == nil {
-			gpus = append(gpus, gpu)
+			gpus = append(gpus, d.withDriverInfo(ctx, gpu, pciSlot(line)))
 		}
 	}
 
@@ -123,3 +142,100 @@ func (d *SystemGPUDetector) extractModel(line string, vendor preflight.GPUVendor
 
 	return strings.TrimSpace(desc)
 }
+
+// pciSlot returns the PCI address at the start of an lspci line
+func pciSlot(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// withDriverInfo adds the bound kernel driver, its version and the VRAM size.
+// Each lookup is best effort; missing information is left unset.
+func (d *SystemGPUDetector) withDriverInfo(ctx context.Context, gpu preflight.GPUType, slot string) preflight.GPUType {
+	if slot == "" {
+		return gpu
+	}
+	deviceDir := filepath.Join(d.sysfsRoot, "bus", "pci", "devices", slot)
+
+	driver := preflight.GPUDriverNone
+	if target, err := os.Readlink(filepath.Join(deviceDir, "driver")); err == nil {
+		driver = preflight.GPUKernelDriver(filepath.Base(target))
+	}
+
+	gpu = gpu.WithKernelDriver(driver, d.driverVersion(driver))
+	gpu = gpu.WithDevice(slot, d.drmDevice(deviceDir))
+	return gpu.WithVRAM(d.detectVRAM(ctx, deviceDir, driver, slot))
+}
+
+// drmDevice returns the /dev/dri card node the driver created for the GPU
+func (d *SystemGPUDetector) drmDevice(deviceDir string) string {
+	entries, err := os.ReadDir(filepath.Join(deviceDir, "drm"))
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "card") {
+			return filepath.Join("/dev/dri", entry.Name())
+		}
+	}
+	return ""
+}
+
+// driverVersion reads the module version. In-tree drivers such as nouveau
+// and amdgpu carry no version of their own and ship with the running kernel.
+func (d *SystemGPUDetector) driverVersion(driver preflight.GPUKernelDriver) string {
+	if driver == preflight.GPUDriverNone {
+		return ""
+	}
+
+	if data, err := os.ReadFile(filepath.Join(d.sysfsRoot, "module", string(driver), "version")); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+
+	if driver == preflight.GPUDriverNVIDIA {
+		if data, err := os.ReadFile(filepath.Join(d.procRoot, "driver", "nvidia", "version")); err == nil {
+			if matches := nvidiaVersionRegex.FindStringSubmatch(string(data)); len(matches) == 2 {
+				return matches[1]
+			}
+		}
+		return ""
+	}
+
+	if data, err := os.ReadFile(filepath.Join(d.procRoot, "sys", "kernel", "osrelease")); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	return ""
+}
+
+// detectVRAM returns dedicated video memory in bytes. amdgpu exposes it in
+// sysfs; the proprietary NVIDIA driver only reports it through nvidia-smi.
+func (d *SystemGPUDetector) detectVRAM(ctx context.Context, deviceDir string, driver preflight.GPUKernelDriver, slot string) uint64 {
+	switch driver {
+	case preflight.GPUDriverAMDGPU:
+		data, err := os.ReadFile(filepath.Join(deviceDir, "mem_info_vram_total"))
+		if err != nil {
+			return 0
+		}
+		bytes, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return 0
+		}
+		return bytes
+	case preflight.GPUDriverNVIDIA:
+		output, err := probeOutput(ctx, "nvidia-smi",
+			"--query-gpu=memory.total", "--format=csv,noheader,nounits", "--id="+slot)
+		if err != nil {
+			return 0
+		}
+		mib, err := strconv.ParseUint(strings.TrimSpace(string(output)), 10, 64)
+		if err != nil {
+			return 0
+		}
+		return mib * preflight.MB
+	default:
+		return 0
+	}
+}
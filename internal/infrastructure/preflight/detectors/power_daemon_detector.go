@@ -0,0 +1,37 @@
+package detectors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// SystemPowerDaemonDetector implements preflight.PowerDaemonDetector using systemctl
+type SystemPowerDaemonDetector struct{}
+
+// NewSystemPowerDaemonDetector creates a new power daemon detector
+func NewSystemPowerDaemonDetector() *SystemPowerDaemonDetector {
+	return &SystemPowerDaemonDetector{}
+}
+
+// DetectPowerDaemons reports which known power daemons are active
+func (d *SystemPowerDaemonDetector) DetectPowerDaemons(ctx context.Context) (preflight.PowerDaemonStatus, error) {
+	var active []string
+
+	for _, daemon := range preflight.KnownPowerDaemons {
+		// is-active exits non-zero for inactive and unknown units
+		output, err := probeOutput(ctx, "systemctl", "is-active", daemon)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return preflight.PowerDaemonStatus{}, ctxErr
+		}
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(string(output)) == "active" {
+			active = append(active, daemon)
+		}
+	}
+
+	return preflight.NewPowerDaemonStatus(active), nil
+}
@@ -0,0 +1,19 @@
+package detectors
+
+import (
+	"context"
+	"os/exec"
+	"time"
+)
+
+// probeCommandTimeout bounds each external command a detector runs, so a
+// hung lspci, nvidia-smi or systemctl cannot stall preflight checks
+const probeCommandTimeout = 5 * time.Second
+
+// probeOutput runs a detector command bounded by probeCommandTimeout and
+// returns its standard output
+func probeOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeCommandTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, name, args...).Output()
+}
@@ -0,0 +1,116 @@
+package detectors
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// SystemResourceDetector implements preflight.ResourceDetector using /proc and /sys
+type SystemResourceDetector struct {
+	meminfoPath string
+	mountsPath  string
+	sysBlockDir string
+}
+
+// NewSystemResourceDetector creates a new resource detector
+func NewSystemResourceDetector() *SystemResourceDetector {
+	return &SystemResourceDetector{
+		meminfoPath: "/proc/meminfo",
+		mountsPath:  "/proc/mounts",
+		sysBlockDir: "/sys/class/block",
+	}
+}
+
+// DetectResources reports total memory and whether root storage is slow
+func (d *SystemResourceDetector) DetectResources(ctx context.Context) (preflight.SystemResources, error) {
+	totalMemory, err := d.totalMemory()
+	if err != nil {
+		return preflight.SystemResources{}, err
+	}
+
+	// Storage detection is best effort; unknown storage is treated as fast
+	device := d.rootDevice()
+	slow := device != "" && d.isSlowDevice(device)
+
+	return preflight.NewSystemResources(totalMemory, device, slow)
+}
+
+// totalMemory reads MemTotal from /proc/meminfo
+func (d *SystemResourceDetector) totalMemory() (uint64, error) {
+	f, err := os.Open(d.meminfoPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid MemTotal value %q: %w", fields[1], err)
+			}
+			return kb * 1024, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, fmt.Errorf("MemTotal not found in %s", d.meminfoPath)
+}
+
+// rootDevice returns the kernel name of the block device mounted at /
+func (d *SystemResourceDetector) rootDevice() string {
+	content, err := os.ReadFile(d.mountsPath)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[1] != "/" || !strings.HasPrefix(fields[0], "/dev/") {
+			continue
+		}
+
+		// Resolve /dev/mapper and /dev/disk/by-* symlinks to the kernel name
+		source := fields[0]
+		if resolved, err := filepath.EvalSymlinks(source); err == nil {
+			source = resolved
+		}
+		return filepath.Base(source)
+	}
+
+	return ""
+}
+
+// isSlowDevice reports rotational disks and SD/eMMC media as slow
+func (d *SystemResourceDetector) isSlowDevice(device string) bool {
+	if strings.HasPrefix(device, "mmcblk") {
+		return true
+	}
+
+	// Partitions share the queue of their parent disk
+	devicePath, err := filepath.EvalSymlinks(filepath.Join(d.sysBlockDir, device))
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(devicePath, "partition")); err == nil {
+		devicePath = filepath.Dir(devicePath)
+	}
+
+	rotational, err := os.ReadFile(filepath.Join(devicePath, "queue", "rotational"))
+	if err != nil {
+		return false
+	}
+
+	return strings.TrimSpace(string(rotational)) == "1"
+}
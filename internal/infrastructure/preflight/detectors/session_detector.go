@@ -0,0 +1,69 @@
+package detectors
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// SystemSessionDetector implements preflight.SessionDetector using the
+// environment, PATH lookups and pgrep
+type SystemSessionDetector struct{}
+
+// NewSystemSessionDetector creates a new session detector
+func NewSystemSessionDetector() *SystemSessionDetector {
+	return &SystemSessionDetector{}
+}
+
+// DetectSession reports installed display managers and any active Wayland session
+func (d *SystemSessionDetector) DetectSession(ctx context.Context) (preflight.SessionEnvironment, error) {
+	var managers []string
+	for _, manager := range preflight.KnownDisplayManagers {
+		if _, err := exec.LookPath(manager); err == nil {
+			managers = append(managers, manager)
+			continue
+		}
+		// Display manager binaries usually live in sbin, which may not be on PATH
+		if _, err := os.Stat("/usr/sbin/" + manager); err == nil {
+			managers = append(managers, manager)
+		}
+	}
+
+	compositor := d.detectCompositor(ctx)
+	if err := ctx.Err(); err != nil {
+		return preflight.SessionEnvironment{}, err
+	}
+
+	return preflight.NewSessionEnvironment(managers, compositor), nil
+}
+
+func (d *SystemSessionDetector) detectCompositor(ctx context.Context) string {
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" {
+		return "Hyprland"
+	}
+	if os.Getenv("SWAYSOCK") != "" {
+		return "sway"
+	}
+
+	// Running from a TTY or over SSH still breaks a session left open on
+	// another VT, so look for the current user's compositor processes too
+	uid := strconv.Itoa(os.Getuid())
+	for _, compositor := range preflight.KnownWaylandCompositors {
+		if _, err := probeOutput(ctx, "pgrep", "-x", "-u", uid, compositor); err == nil {
+			return compositor
+		}
+	}
+
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if desktop := os.Getenv("XDG_CURRENT_DESKTOP"); desktop != "" {
+			return strings.Split(desktop, ":")[0]
+		}
+		return "Wayland"
+	}
+
+	return ""
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/rebelopsio/gohan/internal/infrastructure/repository"
 )
 
 // SystemSourceRepositoryChecker implements preflight.SourceRepositoryChecker
@@ -33,13 +34,13 @@ func (c *SystemSourceRepositoryChecker) CheckSourceRepositories(ctx context.Cont
 		sources = append(sources, mainSources...)
 	}
 
-	// Read sources.list.d/*.list files
+	// Read sources.list.d/*.list and *.sources files
 	dirSources, err := c.readSourcesDir(c.sourcesListDir)
 	if err == nil {
 		sources = append(sources, dirSources...)
 	}
 
-	// Check if any deb-src lines exist
+	// Check if any deb-src lines exist (disabled deb822 entries are not returned)
 	hasDebSrc := false
 	for _, source := range sources {
 		trimmed := strings.TrimSpace(source)
@@ -91,13 +92,17 @@ func (c *SystemSourceRepositoryChecker) readSourcesDir(dirPath string) ([]string
 			continue
 		}
 
-		// Only process .list files
-		if !strings.HasSuffix(entry.Name(), ".list") {
+		filePath := filepath.Join(dirPath, entry.Name())
+
+		var fileSources []string
+		switch {
+		case strings.HasSuffix(entry.Name(), ".list"):
+			fileSources, err = c.readSourcesFile(filePath)
+		case strings.HasSuffix(entry.Name(), ".sources"):
+			fileSources, err = c.readDeb822File(filePath)
+		default:
 			continue
 		}
-
-		filePath := filepath.Join(dirPath, entry.Name())
-		fileSources, err := c.readSourcesFile(filePath)
 		if err == nil {
 			sources = append(sources, fileSources...)
 		}
@@ -105,3 +110,27 @@ func (c *SystemSourceRepositoryChecker) readSourcesDir(dirPath string) ([]string
 
 	return sources, nil
 }
+
+// readDeb822File reads a deb822 .sources file and returns its enabled
+// entries in one-line form so they can be reported alongside sources.list
+func (c *SystemSourceRepositoryChecker) readDeb822File(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := repository.ParseDeb822(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Disabled {
+			continue
+		}
+		sources = append(sources, entry.String())
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,54 @@
+package detectors
+
+import (
+	"context"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/repository"
+)
+
+// SystemSourcesSanityChecker implements preflight.SourcesSanityChecker
+type SystemSourcesSanityChecker struct {
+	sourcesListPath string
+	sourcesListDir  string
+	manager         *repository.FileSourcesManager
+}
+
+// NewSystemSourcesSanityChecker creates a new sources sanity checker
+func NewSystemSourcesSanityChecker() *SystemSourcesSanityChecker {
+	return &SystemSourcesSanityChecker{
+		sourcesListPath: "/etc/apt/sources.list",
+		sourcesListDir:  "/etc/apt/sources.list.d",
+		manager:         repository.NewFileSourcesManager(),
+	}
+}
+
+// CheckSourcesSanity lints every sources file and reports problems with
+// their file and line
+func (c *SystemSourcesSanityChecker) CheckSourcesSanity(ctx context.Context) (preflight.SourcesSanity, error) {
+	paths, err := c.manager.ListSourcesFiles(c.sourcesListPath, c.sourcesListDir)
+	if err != nil {
+		return preflight.SourcesSanity{}, err
+	}
+
+	var entries []domainRepo.LocatedEntry
+	var issues []string
+	for _, path := range paths {
+		fileEntries, fileIssues, err := c.manager.ReadLocatedEntries(path)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, fileEntries...)
+		for _, issue := range fileIssues {
+			issues = append(issues, issue.String())
+		}
+	}
+
+	report := domainRepo.LintSources(entries)
+	for _, issue := range report.Issues {
+		issues = append(issues, issue.String())
+	}
+
+	return preflight.NewSourcesSanity(issues, report.FixableIssues()), nil
+}
@@ -0,0 +1,108 @@
+package detectors
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/repository"
+)
+
+const (
+	// defaultDiskSampleBytes is how much data is written for the disk sample
+	defaultDiskSampleBytes = 32 * preflight.MB
+	// defaultDownloadSampleBytes caps the download sample
+	defaultDownloadSampleBytes = 2 * preflight.MB
+	// defaultProbeTimeout bounds the download sample
+	defaultProbeTimeout = 10 * time.Second
+)
+
+// SystemThroughputProbe implements preflight.ThroughputProbe by writing a
+// short sample file and downloading part of a Debian Release file
+type SystemThroughputProbe struct {
+	sampleDirs  []string
+	sampleBytes int
+	mirrorURI   string
+	suite       string
+	benchmarker *repository.HTTPMirrorBenchmarker
+}
+
+// NewSystemThroughputProbe creates a new throughput probe
+func NewSystemThroughputProbe() *SystemThroughputProbe {
+	return &SystemThroughputProbe{
+		// apt unpacks under /var; /tmp is often tmpfs and would measure RAM
+		sampleDirs:  []string{"/var/cache/apt/archives/partial", "/var/tmp", os.TempDir()},
+		sampleBytes: defaultDiskSampleBytes,
+		mirrorURI:   domainRepo.DefaultDebianMirrors[0],
+		suite:       "sid",
+		benchmarker: repository.NewHTTPMirrorBenchmarkerWithClient(
+			&http.Client{Timeout: defaultProbeTimeout},
+			defaultDownloadSampleBytes,
+		),
+	}
+}
+
+// ProbeThroughput measures disk write and apt download throughput.
+// Each sample is best effort; a failed sample is reported as not measured.
+func (p *SystemThroughputProbe) ProbeThroughput(ctx context.Context) (preflight.Throughput, error) {
+	return preflight.NewThroughput(p.sampleDiskWrite(), p.sampleDownload(ctx))
+}
+
+// sampleDiskWrite writes and syncs a sample file in the first writable
+// directory and returns bytes per second, or 0 if no sample was taken
+func (p *SystemThroughputProbe) sampleDiskWrite() float64 {
+	buf := make([]byte, preflight.MB)
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+
+	for _, dir := range p.sampleDirs {
+		f, err := os.CreateTemp(dir, ".gohan-throughput-*")
+		if err != nil {
+			continue
+		}
+
+		start := time.Now()
+		written := 0
+		for written < p.sampleBytes {
+			n, err := f.Write(buf)
+			if err != nil {
+				break
+			}
+			written += n
+		}
+		syncErr := f.Sync()
+		elapsed := time.Since(start)
+
+		f.Close()
+		os.Remove(f.Name())
+
+		if syncErr != nil || written < p.sampleBytes {
+			continue
+		}
+		if elapsed <= 0 {
+			elapsed = time.Microsecond
+		}
+		return float64(written) / elapsed.Seconds()
+	}
+
+	return 0
+}
+
+// sampleDownload downloads part of the mirror's Release file and returns
+// bytes per second, or 0 if the mirror could not be reached
+func (p *SystemThroughputProbe) sampleDownload(ctx context.Context) float64 {
+	mirror, err := domainRepo.NewMirror(p.mirrorURI)
+	if err != nil {
+		return 0
+	}
+
+	result := p.benchmarker.Benchmark(ctx, mirror, p.suite)
+	if result.Err != nil {
+		return 0
+	}
+	return result.Throughput
+}
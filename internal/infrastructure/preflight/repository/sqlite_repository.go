@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// SQLiteRepository is a SQLite implementation of preflight.ValidationSessionRepository
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRepository creates a new SQLite validation session repository
+func NewSQLiteRepository(dbPath string) (*SQLiteRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	repo := &SQLiteRepository{db: db}
+	if err := repo.initialize(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	return repo, nil
+}
+
+// initialize creates the necessary tables
+func (r *SQLiteRepository) initialize() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS validation_sessions (
+		id TEXT PRIMARY KEY,
+		started_at DATETIME NOT NULL,
+		outcome TEXT NOT NULL,
+		data TEXT NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_validation_sessions_started_at ON validation_sessions(started_at);
+	`
+
+	_, err := r.db.Exec(schema)
+	return err
+}
+
+// sessionStorageModel is a serializable representation of a validation session
+type sessionStorageModel struct {
+	ID          string            `json:"id"`
+	StartedAt   time.Time         `json:"started_at"`
+	CompletedAt time.Time         `json:"completed_at"`
+	Policy      map[string]string `json:"policy,omitempty"`
+	Results     []resultDTO       `json:"results"`
+}
+
+// resultDTO is a serializable version of ValidationResult. Detected and
+// expected values are kept as their display strings.
+type resultDTO struct {
+	ID          string    `json:"id"`
+	Requirement string    `json:"requirement"`
+	Status      string    `json:"status"`
+	Severity    string    `json:"severity"`
+	Actual      string    `json:"actual,omitempty"`
+	Expected    string    `json:"expected,omitempty"`
+	Guidance    guidance  `json:"guidance"`
+	DetectedAt  time.Time `json:"detected_at"`
+	Override    string    `json:"override,omitempty"`
+	Measurement *float64  `json:"measurement,omitempty"`
+}
+
+// guidance is a serializable version of UserGuidance
+type guidance struct {
+	Message          string   `json:"message,omitempty"`
+	Reason           string   `json:"reason,omitempty"`
+	Steps            []string `json:"steps,omitempty"`
+	DocumentationURL string   `json:"documentation_url,omitempty"`
+}
+
+func toStorageModel(session *preflight.ValidationSession) sessionStorageModel {
+	model := sessionStorageModel{
+		ID:          session.ID(),
+		StartedAt:   session.StartedAt(),
+		CompletedAt: session.CompletedAt(),
+	}
+
+	if policy := session.SeverityPolicy(); !policy.IsEmpty() {
+		model.Policy = make(map[string]string)
+		for requirement, override := range policy.Overrides() {
+			model.Policy[string(requirement)] = string(override)
+		}
+	}
+
+	for _, result := range session.Results() {
+		dto := resultDTO{
+			ID:          result.ID(),
+			Requirement: string(result.RequirementName()),
+			Status:      string(result.Status()),
+			Severity:    string(result.Severity()),
+			Actual:      formatValue(result.ActualValue()),
+			Expected:    formatValue(result.ExpectedValue()),
+			Guidance: guidance{
+				Message:          result.Guidance().Message(),
+				Reason:           result.Guidance().Reason(),
+				Steps:            result.Guidance().ActionableSteps(),
+				DocumentationURL: result.Guidance().DocumentationURL(),
+			},
+			DetectedAt: result.DetectedAt(),
+			Override:   string(result.Override()),
+		}
+		if measurement, ok := result.Measurement(); ok {
+			dto.Measurement = &measurement
+		}
+		model.Results = append(model.Results, dto)
+	}
+
+	return model
+}
+
+func fromStorageModel(model sessionStorageModel) (*preflight.ValidationSession, error) {
+	policy, err := preflight.NewSeverityPolicy(model.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct severity policy: %w", err)
+	}
+
+	results := make([]preflight.ValidationResult, 0, len(model.Results))
+	for _, dto := range model.Results {
+		result, err := preflight.ReconstructValidationResult(
+			dto.ID,
+			preflight.RequirementName(dto.Requirement),
+			preflight.ValidationStatus(dto.Status),
+			preflight.Severity(dto.Severity),
+			parseValue(dto.Actual),
+			parseValue(dto.Expected),
+			preflight.NewUserGuidance(dto.Guidance.Message, dto.Guidance.Reason, dto.Guidance.Steps, dto.Guidance.DocumentationURL),
+			dto.DetectedAt,
+			preflight.SeverityOverride(dto.Override),
+			dto.Measurement,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct validation result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return preflight.ReconstructValidationSession(model.ID, model.StartedAt, model.CompletedAt, policy, results)
+}
+
+// formatValue renders a detected or expected value, or "" if unset
+func formatValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// parseValue restores a stored value, keeping unset values unset
+func parseValue(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// Save persists a validation session, replacing an earlier save of it
+func (r *SQLiteRepository) Save(ctx context.Context, session *preflight.ValidationSession) error {
+	data, err := json.Marshal(toStorageModel(session))
+	if err != nil {
+		return fmt.Errorf("failed to serialize validation session: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT OR REPLACE INTO validation_sessions (id, started_at, outcome, data) VALUES (?, ?, ?, ?)`,
+		session.ID(),
+		session.StartedAt().UTC(),
+		string(session.OverallResult()),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save validation session: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves a session by ID
+func (r *SQLiteRepository) FindByID(ctx context.Context, id string) (*preflight.ValidationSession, error) {
+	return r.findOne(ctx, `SELECT data FROM validation_sessions WHERE id = ?`, id)
+}
+
+// FindLatest retrieves the most recently started session
+func (r *SQLiteRepository) FindLatest(ctx context.Context) (*preflight.ValidationSession, error) {
+	return r.findOne(ctx, `SELECT data FROM validation_sessions ORDER BY started_at DESC LIMIT 1`)
+}
+
+func (r *SQLiteRepository) findOne(ctx context.Context, query string, args ...interface{}) (*preflight.ValidationSession, error) {
+	var data string
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, preflight.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validation session: %w", err)
+	}
+
+	return decode(data)
+}
+
+// List retrieves all sessions, oldest first
+func (r *SQLiteRepository) List(ctx context.Context) ([]*preflight.ValidationSession, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT data FROM validation_sessions ORDER BY started_at ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validation sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*preflight.ValidationSession, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan validation session: %w", err)
+		}
+
+		session, err := decode(data)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+func decode(data string) (*preflight.ValidationSession, error) {
+	var model sessionStorageModel
+	if err := json.Unmarshal([]byte(data), &model); err != nil {
+		return nil, fmt.Errorf("failed to deserialize validation session: %w", err)
+	}
+
+	session, err := fromStorageModel(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct validation session: %w", err)
+	}
+	return session, nil
+}
+
+// Close closes the database connection
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/rebelopsio/gohan/internal/infrastructure/preflight/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteRepository(t *testing.T) {
+	ctx := context.Background()
+
+	repo, err := repository.NewSQLiteRepository(filepath.Join(t.TempDir(), "preflight.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	policy, err := preflight.NewSeverityPolicy(map[string]string{"gpu_support": "ignore"})
+	require.NoError(t, err)
+
+	space, err := preflight.NewDiskSpace(25*preflight.GB, 500*preflight.GB, "/")
+	require.NoError(t, err)
+
+	base := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	earlier, err := preflight.ReconstructValidationSession("earlier", base, base.Add(time.Second), preflight.SeverityPolicy{}, nil)
+	require.NoError(t, err)
+
+	later := preflight.NewValidationSessionWithPolicy(policy)
+	later.AddResult(preflight.NewValidationResult(
+		preflight.RequirementDiskSpace,
+		preflight.StatusWarning,
+		preflight.SeverityMedium,
+		[]preflight.MountSpace{preflight.NewMountSpace(space, nil)},
+		"10 GB",
+		preflight.NewUserGuidance("Low disk space", "", []string{"Free up space"}, ""),
+	))
+	later.Complete()
+
+	t.Run("saves and finds a session", func(t *testing.T) {
+		require.NoError(t, repo.Save(ctx, later))
+
+		found, err := repo.FindByID(ctx, later.ID())
+		require.NoError(t, err)
+
+		assert.Equal(t, later.ID(), found.ID())
+		assert.True(t, found.StartedAt().Equal(later.StartedAt()))
+		assert.Equal(t, later.OverallResult(), found.OverallResult())
+		assert.Equal(t, policy.Overrides(), found.SeverityPolicy().Overrides())
+
+		require.Len(t, found.Results(), 1)
+		result := found.Results()[0]
+		assert.Equal(t, preflight.RequirementDiskSpace, result.RequirementName())
+		assert.True(t, result.IsWarning())
+		assert.Equal(t, "10 GB", result.ExpectedValue())
+		assert.Equal(t, []string{"Free up space"}, result.Guidance().ActionableSteps())
+
+		measurement, ok := result.Measurement()
+		require.True(t, ok)
+		assert.Equal(t, float64(25*preflight.GB), measurement)
+	})
+
+	t.Run("lists sessions oldest first", func(t *testing.T) {
+		require.NoError(t, repo.Save(ctx, earlier))
+
+		sessions, err := repo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "earlier", sessions[0].ID())
+		assert.Equal(t, later.ID(), sessions[1].ID())
+
+		latest, err := repo.FindLatest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, later.ID(), latest.ID())
+	})
+
+	t.Run("unknown session is not found", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, "missing")
+		assert.ErrorIs(t, err, preflight.ErrSessionNotFound)
+	})
+}
@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+)
+
+// DefaultDebianSourcesPath is where the Debian archive sources live in deb822 format
+const DefaultDebianSourcesPath = "/etc/apt/sources.list.d/debian.sources"
+
+// deb822OptionFields maps one-line option names to their deb822 field names
+var deb822OptionFields = []struct {
+	option string
+	field  string
+}{
+	{"arch", "Architectures"},
+	{"lang", "Languages"},
+	{"target", "Targets"},
+	{"trusted", "Trusted"},
+	{"pdiffs", "PDiffs"},
+	{"by-hash", "By-Hash"},
+	{"check-valid-until", "Check-Valid-Until"},
+}
+
+// deb822Stanza is a single paragraph of a .sources file
+type deb822Stanza struct {
+	types      []string
+	uri        string
+	suites     []string
+	components []string
+	signedBy   string
+	options    []string
+	disabled   bool
+}
+
+// ParseDeb822 parses the content of a deb822 .sources file.
+// Each stanza is expanded into one entry per type/URI/suite combination.
+func ParseDeb822(content string) ([]ParsedEntry, error) {
+	var entries []ParsedEntry
+
+	paragraphs, _, err := splitDeb822Paragraphs(content)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, fields := range paragraphs {
+		stanzaEntries, err := parseDeb822Stanza(fields)
+		if err != nil {
+			return nil, fmt.Errorf("stanza %d: %w", i+1, err)
+		}
+		entries = append(entries, stanzaEntries...)
+	}
+
+	return entries, nil
+}
+
+// deb822LineError is a syntax error at a specific line of a .sources file
+type deb822LineError struct {
+	line int
+	err  error
+}
+
+func (e *deb822LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.line, e.err)
+}
+
+func (e *deb822LineError) Unwrap() error {
+	return e.err
+}
+
+// splitDeb822Paragraphs splits content into paragraphs of field name/value pairs
+// and returns the line each paragraph starts on.
+// Field names are lower-cased; continuation lines are joined with newlines.
+func splitDeb822Paragraphs(content string) ([]map[string]string, []int, error) {
+	var paragraphs []map[string]string
+	var startLines []int
+	current := make(map[string]string)
+	currentStart := 0
+	lastField := ""
+
+	flush := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, current)
+			startLines = append(startLines, currentStart)
+		}
+		current = make(map[string]string)
+		lastField = ""
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Continuation line
+		if line[0] == ' ' || line[0] == '\t' {
+			if lastField == "" {
+				return nil, nil, &deb822LineError{lineNum, fmt.Errorf("%w: continuation without field", ErrInvalidLine)}
+			}
+			value := strings.TrimSpace(line)
+			if value == "." {
+				value = ""
+			}
+			current[lastField] += "\n" + value
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, nil, &deb822LineError{lineNum, fmt.Errorf("%w: expected 'Field: value'", ErrInvalidLine)}
+		}
+		if len(current) == 0 {
+			currentStart = lineNum
+		}
+		lastField = strings.ToLower(strings.TrimSpace(name))
+		current[lastField] = strings.TrimSpace(value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	flush()
+
+	return paragraphs, startLines, nil
+}
+
+// parseDeb822Stanza expands a single paragraph into source entries
+func parseDeb822Stanza(fields map[string]string) ([]ParsedEntry, error) {
+	types := strings.Fields(fields["types"])
+	uris := strings.Fields(fields["uris"])
+	suites := strings.Fields(fields["suites"])
+	components := strings.Fields(fields["components"])
+
+	if len(types) == 0 {
+		return nil, fmt.Errorf("%w: missing Types", ErrInvalidLine)
+	}
+	if len(uris) == 0 {
+		return nil, fmt.Errorf("%w: missing URIs", ErrInvalidLine)
+	}
+	if len(suites) == 0 {
+		return nil, fmt.Errorf("%w: missing Suites", ErrInvalidLine)
+	}
+
+	disabled := strings.EqualFold(strings.TrimSpace(fields["enabled"]), "no")
+
+	var options []string
+	for _, mapping := range deb822OptionFields {
+		if value, ok := fields[strings.ToLower(mapping.field)]; ok {
+			options = append(options, mapping.option+"="+strings.Join(strings.Fields(value), ","))
+		}
+	}
+
+	var entries []ParsedEntry
+	for _, entryType := range types {
+		if entryType != "deb" && entryType != "deb-src" {
+			return nil, fmt.Errorf("%w: invalid type '%s'", ErrInvalidLine, entryType)
+		}
+		for _, uri := range uris {
+			for _, suite := range suites {
+				entries = append(entries, ParsedEntry{
+					Type:       entryType,
+					URI:        uri,
+					Suite:      suite,
+					Components: append([]string(nil), components...),
+					SignedBy:   fields["signed-by"],
+					Options:    append([]string(nil), options...),
+					Disabled:   disabled,
+				})
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// FormatDeb822 formats source entries as deb822 stanzas.
+// Entries are grouped so that each stanza describes exactly the same set of
+// type/suite combinations as the input.
+func FormatDeb822(entries []domainRepo.SourceEntry) string {
+	// First pass: collect the types used for each URI/suite/attributes tuple
+	type suiteKey struct {
+		uri, suite, attrs string
+	}
+	var suiteOrder []suiteKey
+	suiteTypes := make(map[suiteKey][]string)
+	suiteEntries := make(map[suiteKey]domainRepo.SourceEntry)
+	for _, entry := range entries {
+		key := suiteKey{entry.URI, entry.Suite, entryAttributes(entry)}
+		if _, ok := suiteTypes[key]; !ok {
+			suiteOrder = append(suiteOrder, key)
+			suiteEntries[key] = entry
+		}
+		suiteTypes[key] = appendUnique(suiteTypes[key], entry.Type)
+	}
+
+	// Second pass: merge suites that share URI, types and attributes
+	type stanzaKey struct {
+		uri, types, attrs string
+	}
+	var stanzaOrder []stanzaKey
+	stanzas := make(map[stanzaKey]*deb822Stanza)
+	for _, key := range suiteOrder {
+		types := suiteTypes[key]
+		sk := stanzaKey{key.uri, strings.Join(types, " "), key.attrs}
+		stanza, ok := stanzas[sk]
+		if !ok {
+			entry := suiteEntries[key]
+			stanza = &deb822Stanza{
+				types:      types,
+				uri:        key.uri,
+				components: entry.Components,
+				signedBy:   entry.SignedBy,
+				options:    entry.Options,
+				disabled:   entry.Disabled,
+			}
+			stanzas[sk] = stanza
+			stanzaOrder = append(stanzaOrder, sk)
+		}
+		stanza.suites = appendUnique(stanza.suites, key.suite)
+	}
+
+	paragraphs := make([]string, 0, len(stanzaOrder))
+	for _, sk := range stanzaOrder {
+		paragraphs = append(paragraphs, stanzas[sk].String())
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// entryAttributes returns a key describing everything about an entry except
+// its type, URI and suite
+func entryAttributes(entry domainRepo.SourceEntry) string {
+	return fmt.Sprintf("%s|%s|%s|%t",
+		strings.Join(entry.Components, " "),
+		entry.SignedBy,
+		strings.Join(entry.Options, " "),
+		entry.Disabled,
+	)
+}
+
+// String formats the stanza as deb822 fields
+func (s *deb822Stanza) String() string {
+	var lines []string
+	if s.disabled {
+		lines = append(lines, "Enabled: no")
+	}
+	lines = append(lines,
+		"Types: "+strings.Join(s.types, " "),
+		"URIs: "+s.uri,
+		"Suites: "+strings.Join(s.suites, " "),
+	)
+	if len(s.components) > 0 {
+		lines = append(lines, "Components: "+strings.Join(s.components, " "))
+	}
+
+	for _, option := range s.options {
+		name, value, _ := strings.Cut(option, "=")
+		for _, mapping := range deb822OptionFields {
+			if mapping.option == name {
+				lines = append(lines, mapping.field+": "+strings.ReplaceAll(value, ",", " "))
+				break
+			}
+		}
+	}
+
+	if s.signedBy != "" {
+		lines = append(lines, "Signed-By: "+formatDeb822Multiline(s.signedBy))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatDeb822Multiline formats a value that may span several lines,
+// such as an inline armored key, using deb822 continuation syntax
+func formatDeb822Multiline(value string) string {
+	lines := strings.Split(value, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == "" {
+			lines[i] = " ."
+		} else {
+			lines[i] = " " + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WriteDeb822Config writes a RepositoryConfig to a deb822 .sources file
+func (m *FileSourcesManager) WriteDeb822Config(path string, config *domainRepo.RepositoryConfig) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	content := "# Managed by gohan\n" + FormatDeb822(config.Entries()) + "\n"
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// DisableLegacySources comments out every entry in a one-line sources.list
+// so that apt does not see duplicates after migrating to deb822
+func (m *FileSourcesManager) DisableLegacySources(path, note string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	out := make([]string, 0, len(lines)+1)
+	if note != "" {
+		out = append(out, "# "+note)
+	}
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "deb ") || strings.HasPrefix(trimmed, "deb-src ") {
+			line = "# " + line
+		}
+		out = append(out, line)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(out, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,222 @@
+package repository_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeb822(t *testing.T) {
+	t.Run("expands stanza into entries", func(t *testing.T) {
+		content := `Types: deb deb-src
+URIs: http://deb.debian.org/debian
+Suites: trixie trixie-updates
+Components: main contrib non-free-firmware
+Signed-By: /usr/share/keyrings/debian-archive-keyring.gpg`
+
+		entries, err := repository.ParseDeb822(content)
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+
+		assert.Equal(t, "deb", entries[0].Type)
+		assert.Equal(t, "trixie", entries[0].Suite)
+		assert.Equal(t, "deb", entries[1].Type)
+		assert.Equal(t, "trixie-updates", entries[1].Suite)
+		assert.Equal(t, "deb-src", entries[2].Type)
+		assert.Equal(t, []string{"main", "contrib", "non-free-firmware"}, entries[0].Components)
+		assert.Equal(t, "/usr/share/keyrings/debian-archive-keyring.gpg", entries[0].SignedBy)
+		assert.False(t, entries[0].Disabled)
+	})
+
+	t.Run("parses multiple stanzas, comments and Enabled", func(t *testing.T) {
+		content := `# Debian archive
+Types: deb
+URIs: http://deb.debian.org/debian
+Suites: sid
+Components: main
+
+Enabled: no
+Types: deb-src
+URIs: http://deb.debian.org/debian
+Suites: sid
+Components: main
+Architectures: amd64 i386`
+
+		entries, err := repository.ParseDeb822(content)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.False(t, entries[0].Disabled)
+		assert.True(t, entries[1].Disabled)
+		assert.Equal(t, []string{"arch=amd64,i386"}, entries[1].Options)
+	})
+
+	t.Run("keeps inline keys as multi-line values", func(t *testing.T) {
+		content := `Types: deb
+URIs: https://repo.example.com/apt
+Suites: stable
+Components: main
+Signed-By:
+ -----BEGIN PGP PUBLIC KEY BLOCK-----
+ .
+ abc
+ -----END PGP PUBLIC KEY BLOCK-----`
+
+		entries, err := repository.ParseDeb822(content)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "\n-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nabc\n-----END PGP PUBLIC KEY BLOCK-----", entries[0].SignedBy)
+		assert.Contains(t, repository.FormatDeb822(entries), "Signed-By: \n -----BEGIN PGP PUBLIC KEY BLOCK-----\n .\n abc\n")
+	})
+
+	t.Run("rejects stanza without URIs", func(t *testing.T) {
+		_, err := repository.ParseDeb822("Types: deb\nSuites: sid\nComponents: main")
+		assert.ErrorIs(t, err, repository.ErrInvalidLine)
+	})
+
+	t.Run("accepts flat repository without components", func(t *testing.T) {
+		entries, err := repository.ParseDeb822("Types: deb\nURIs: https://repo.example.com/apt\nSuites: ./")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.NoError(t, entries[0].Validate())
+	})
+}
+
+func TestFormatDeb822(t *testing.T) {
+	t.Run("merges deb and deb-src for the same suite", func(t *testing.T) {
+		entries := []domainRepo.SourceEntry{
+			{Type: "deb", URI: "http://deb.debian.org/debian", Suite: "sid", Components: []string{"main", "contrib"}},
+			{Type: "deb-src", URI: "http://deb.debian.org/debian", Suite: "sid", Components: []string{"main", "contrib"}},
+		}
+
+		expected := `Types: deb deb-src
+URIs: http://deb.debian.org/debian
+Suites: sid
+Components: main contrib`
+
+		assert.Equal(t, expected, repository.FormatDeb822(entries))
+	})
+
+	t.Run("merges suites sharing types and components", func(t *testing.T) {
+		entries := []domainRepo.SourceEntry{
+			{Type: "deb", URI: "http://deb.debian.org/debian", Suite: "trixie", Components: []string{"main"}},
+			{Type: "deb", URI: "http://deb.debian.org/debian", Suite: "trixie-updates", Components: []string{"main"}},
+			{Type: "deb", URI: "http://security.debian.org/debian-security", Suite: "trixie-security", Components: []string{"main"}},
+		}
+
+		expected := `Types: deb
+URIs: http://deb.debian.org/debian
+Suites: trixie trixie-updates
+Components: main
+
+Types: deb
+URIs: http://security.debian.org/debian-security
+Suites: trixie-security
+Components: main`
+
+		assert.Equal(t, expected, repository.FormatDeb822(entries))
+	})
+
+	t.Run("writes Enabled, Signed-By and option fields", func(t *testing.T) {
+		entries := []domainRepo.SourceEntry{
+			{
+				Type:       "deb",
+				URI:        "https://repo.example.com/apt",
+				Suite:      "stable",
+				Components: []string{"main"},
+				SignedBy:   "/usr/share/keyrings/example.gpg",
+				Options:    []string{"arch=amd64"},
+				Disabled:   true,
+			},
+		}
+
+		expected := `Enabled: no
+Types: deb
+URIs: https://repo.example.com/apt
+Suites: stable
+Components: main
+Architectures: amd64
+Signed-By: /usr/share/keyrings/example.gpg`
+
+		assert.Equal(t, expected, repository.FormatDeb822(entries))
+	})
+}
+
+func TestFileSourcesManager_Deb822RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "debian.sources")
+	content := `Types: deb
+URIs: http://deb.debian.org/debian
+Suites: sid
+Components: main
+Signed-By: /usr/share/keyrings/debian-archive-keyring.gpg
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	manager := repository.NewFileSourcesManager()
+	config, err := manager.ReadConfig(path)
+	require.NoError(t, err)
+	assert.False(t, config.HasComponent("non-free"))
+
+	config.AddComponent("non-free")
+	require.NoError(t, manager.WriteConfig(path, config))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "Components: main non-free\n")
+	assert.Contains(t, string(written), "Signed-By: /usr/share/keyrings/debian-archive-keyring.gpg\n")
+
+	listPath := filepath.Join(dir, "sources.list")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.list"), []byte(""), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte(""), 0644))
+	files, err := manager.ListSourcesFiles(listPath, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path, filepath.Join(dir, "other.list")}, files)
+}
+
+func TestFileSourcesManager_DisableLegacySources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sources.list")
+	require.NoError(t, os.WriteFile(path, []byte("# comment\ndeb http://deb.debian.org/debian sid main\n"), 0644))
+
+	manager := repository.NewFileSourcesManager()
+	require.NoError(t, manager.DisableLegacySources(path, "moved"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# moved\n# comment\n# deb http://deb.debian.org/debian sid main\n", string(content))
+}
+
+func TestFileSourcesSnapshotter(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "sources.list")
+	created := filepath.Join(dir, "sources.list.d", "debian.sources")
+	snapshotDir := filepath.Join(dir, "snapshots")
+
+	require.NoError(t, os.WriteFile(existing, []byte("original"), 0644))
+
+	snapshotter := repository.NewFileSourcesSnapshotter()
+	_, err := snapshotter.Snapshot([]string{existing, created}, snapshotDir, "test")
+	require.NoError(t, err)
+
+	// Simulate a rewrite
+	require.NoError(t, os.WriteFile(existing, []byte("modified"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Dir(created), 0755))
+	require.NoError(t, os.WriteFile(created, []byte("new"), 0644))
+
+	latest, err := snapshotter.Latest(snapshotDir)
+	require.NoError(t, err)
+	assert.Equal(t, "test", latest.Reason)
+	require.NoError(t, snapshotter.Restore(latest))
+
+	content, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+	assert.NoFileExists(t, created)
+
+	_, err = snapshotter.Latest(snapshotDir)
+	assert.ErrorIs(t, err, repository.ErrNoSnapshot)
+}
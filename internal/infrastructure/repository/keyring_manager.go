@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+)
+
+const (
+	// DefaultKeyRegistryPath records the keys installed by gohan
+	DefaultKeyRegistryPath = "/var/lib/gohan/keyrings.json"
+	// DefaultRepoAuditLogPath receives one JSON line per key operation
+	DefaultRepoAuditLogPath = "/var/log/gohan/repo-audit.log"
+
+	// maxKeySize bounds key downloads; real repository keys are a few KB
+	maxKeySize = 1 << 20
+)
+
+// managedKeyDTO is the on-disk representation of a managed key
+type managedKeyDTO struct {
+	Name        string    `json:"name"`
+	Fingerprint string    `json:"fingerprint"`
+	KeyringPath string    `json:"keyring_path"`
+	SourceURL   string    `json:"source_url"`
+	SourcesPath string    `json:"sources_path,omitempty"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+// auditEntry is a single line of the repository audit log
+type auditEntry struct {
+	Time        time.Time `json:"time"`
+	Action      string    `json:"action"`
+	Name        string    `json:"name"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	SourceURL   string    `json:"source_url,omitempty"`
+	User        string    `json:"user,omitempty"`
+	Result      string    `json:"result"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// FileKeyringManager installs repository keyrings and tracks them in a
+// JSON registry, writing every change to an append-only audit log
+type FileKeyringManager struct {
+	keyringDir   string
+	registryPath string
+	auditLogPath string
+	client       *http.Client
+}
+
+// NewFileKeyringManager creates a keyring manager using the system paths
+func NewFileKeyringManager() *FileKeyringManager {
+	return NewFileKeyringManagerWithPaths(
+		domainRepo.KeyringDir,
+		DefaultKeyRegistryPath,
+		DefaultRepoAuditLogPath,
+		&http.Client{Timeout: 30 * time.Second},
+	)
+}
+
+// NewFileKeyringManagerWithPaths creates a keyring manager with custom paths
+func NewFileKeyringManagerWithPaths(keyringDir, registryPath, auditLogPath string, client *http.Client) *FileKeyringManager {
+	return &FileKeyringManager{
+		keyringDir:   keyringDir,
+		registryPath: registryPath,
+		auditLogPath: auditLogPath,
+		client:       client,
+	}
+}
+
+// Fetch downloads key data from a URL
+func (m *FileKeyringManager) Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download key: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download key: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxKeySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	if len(data) > maxKeySize {
+		return nil, fmt.Errorf("key exceeds %d bytes", maxKeySize)
+	}
+
+	return data, nil
+}
+
+// Inspect dearmors key data and returns the binary key with the primary
+// keys it holds
+func (m *FileKeyringManager) Inspect(data []byte) ([]byte, []domainRepo.PublicKey, error) {
+	binaryKey, err := Dearmor(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys, err := PublicKeys(binaryKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return binaryKey, keys, nil
+}
+
+// KeyringPath returns where the keyring for a key name is installed
+func (m *FileKeyringManager) KeyringPath(name string) string {
+	return filepath.Join(m.keyringDir, domainRepo.KeyringFileName(name))
+}
+
+// Install writes a binary keyring for a key name, refusing to replace a
+// file that already exists
+func (m *FileKeyringManager) Install(name string, binaryKey []byte) (string, error) {
+	if err := os.MkdirAll(m.keyringDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create keyring directory %s: %w", m.keyringDir, err)
+	}
+
+	path := m.KeyringPath(name)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("%w: %s", domainRepo.ErrFileExists, path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create keyring %s: %w", path, err)
+	}
+
+	_, err = file.Write(binaryKey)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("failed to write keyring %s: %w", path, err)
+	}
+
+	return path, nil
+}
+
+// Replace atomically overwrites the keyring of a managed key
+func (m *FileKeyringManager) Replace(path string, binaryKey []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".keyring-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary keyring: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(binaryKey)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write keyring %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// RemoveFile deletes a keyring or sources file, ignoring missing files
+func (m *FileKeyringManager) RemoveFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadKeyring returns the installed keyring contents
+func (m *FileKeyringManager) ReadKeyring(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// LoadKeys reads the registry of managed keys
+func (m *FileKeyringManager) LoadKeys() ([]domainRepo.ManagedKey, error) {
+	data, err := os.ReadFile(m.registryPath)
+	if os.IsNotExist(err) {
+		return []domainRepo.ManagedKey{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key registry: %w", err)
+	}
+
+	var dtos []managedKeyDTO
+	if err := json.Unmarshal(data, &dtos); err != nil {
+		return nil, fmt.Errorf("failed to decode key registry: %w", err)
+	}
+
+	keys := make([]domainRepo.ManagedKey, 0, len(dtos))
+	for _, dto := range dtos {
+		fingerprint, err := domainRepo.NewKeyFingerprint(dto.Fingerprint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fingerprint for key %s: %w", dto.Name, err)
+		}
+		keys = append(keys, domainRepo.ManagedKey{
+			Name:        dto.Name,
+			Fingerprint: fingerprint,
+			KeyringPath: dto.KeyringPath,
+			SourceURL:   dto.SourceURL,
+			SourcesPath: dto.SourcesPath,
+			AddedAt:     dto.AddedAt,
+		})
+	}
+
+	return keys, nil
+}
+
+// SaveKeys writes the registry of managed keys
+func (m *FileKeyringManager) SaveKeys(keys []domainRepo.ManagedKey) error {
+	dtos := make([]managedKeyDTO, 0, len(keys))
+	for _, key := range keys {
+		dtos = append(dtos, managedKeyDTO{
+			Name:        key.Name,
+			Fingerprint: key.Fingerprint.String(),
+			KeyringPath: key.KeyringPath,
+			SourceURL:   key.SourceURL,
+			SourcesPath: key.SourcesPath,
+			AddedAt:     key.AddedAt,
+		})
+	}
+
+	data, err := json.MarshalIndent(dtos, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode key registry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.registryPath), 0755); err != nil {
+		return fmt.Errorf("failed to create registry directory: %w", err)
+	}
+	if err := os.WriteFile(m.registryPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write key registry: %w", err)
+	}
+
+	return nil
+}
+
+// Audit appends a key operation to the audit log
+func (m *FileKeyringManager) Audit(action string, key domainRepo.ManagedKey, opErr error) error {
+	entry := auditEntry{
+		Time:        time.Now().UTC(),
+		Action:      action,
+		Name:        key.Name,
+		Fingerprint: key.Fingerprint.String(),
+		SourceURL:   key.SourceURL,
+		User:        invokingUser(),
+		Result:      "ok",
+	}
+	if opErr != nil {
+		entry.Result = "error"
+		entry.Error = opErr.Error()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.auditLogPath), 0755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(m.auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// invokingUser returns the user who ran gohan, looking through sudo
+func invokingUser() string {
+	if user := os.Getenv("SUDO_USER"); user != "" {
+		return user
+	}
+	return os.Getenv("USER")
+}
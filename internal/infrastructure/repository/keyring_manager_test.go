@@ -0,0 +1,182 @@
+package repository_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testArmoredKey is an ed25519 public key with fingerprint testKeyFingerprint
+const testArmoredKey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mDMEatJ1MxYJKwYBBAHaRw8BAQdAgiFxJH0nL9UYd2lykx2C98u7GfHXVhc7mSJm
+bi1iTxS0HWdvaGFuIHRlc3QgPHRlc3RAZXhhbXBsZS5jb20+iJAEExYIADgWIQQE
+XYjZ7jAAmav3bHtFpdFKvWtD6gUCatJ1MwIbAwULCQgHAgYVCgkICwIEFgIDAQIe
+AQIXgAAKCRBFpdFKvWtD6p8lAP0ZcsHzNi4cyobVcMQrEtPF1Maq6n6BjTJ3hZYX
+UMQpRAD/dQ2/CaHFZYkgmWXsLDuXSscozsLSn8kXbC8CHIL5Ews=
+=gjtr
+-----END PGP PUBLIC KEY BLOCK-----
+`
+
+const testKeyFingerprint = "045D88D9EE300099ABF76C7B45A5D14ABD6B43EA"
+
+// attackerKey is a v4 primary key packet, enough to be fingerprinted
+var attackerKey = []byte{0xc6, 0x06, 0x04, 0x63, 0x00, 0x00, 0x00, 0x16}
+
+// v6AttackerKey is a v6 primary key packet, which cannot be fingerprinted as v4
+var v6AttackerKey = []byte{0xc6, 0x06, 0x06, 0x63, 0x00, 0x00, 0x00, 0x1b}
+
+func TestPublicKeys(t *testing.T) {
+	t.Run("computes fingerprint of armored key", func(t *testing.T) {
+		binaryKey, err := repository.Dearmor([]byte(testArmoredKey))
+		require.NoError(t, err)
+
+		keys, err := repository.PublicKeys(binaryKey)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, testKeyFingerprint, keys[0].Fingerprint.String())
+		assert.Empty(t, keys[0].Subkeys)
+	})
+
+	t.Run("binary input passes through dearmor unchanged", func(t *testing.T) {
+		binaryKey, err := repository.Dearmor([]byte(testArmoredKey))
+		require.NoError(t, err)
+
+		again, err := repository.Dearmor(binaryKey)
+		require.NoError(t, err)
+		assert.Equal(t, binaryKey, again)
+	})
+
+	t.Run("rejects non-key data", func(t *testing.T) {
+		_, err := repository.PublicKeys([]byte("<html>not a key</html>"))
+		assert.ErrorIs(t, err, repository.ErrInvalidKeyData)
+	})
+
+	t.Run("lists every primary key of a bundle, which fails verification", func(t *testing.T) {
+		binaryKey, err := repository.Dearmor([]byte(testArmoredKey))
+		require.NoError(t, err)
+		bundle := append(append([]byte{}, binaryKey...), attackerKey...)
+
+		keys, err := repository.PublicKeys(bundle)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Equal(t, testKeyFingerprint, keys[0].Fingerprint.String())
+
+		pinned, err := domainRepo.NewKeyFingerprint(testKeyFingerprint)
+		require.NoError(t, err)
+		assert.ErrorIs(t, domainRepo.VerifyFingerprints(pinned, keys), domainRepo.ErrUnexpectedKeys)
+	})
+
+	t.Run("rejects a bundle hiding a non-v4 primary key", func(t *testing.T) {
+		binaryKey, err := repository.Dearmor([]byte(testArmoredKey))
+		require.NoError(t, err)
+		bundle := append(append([]byte{}, binaryKey...), v6AttackerKey...)
+
+		_, err = repository.PublicKeys(bundle)
+		assert.ErrorIs(t, err, repository.ErrInvalidKeyData)
+	})
+}
+
+func TestFileKeyringManager(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testArmoredKey))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	auditLog := filepath.Join(dir, "audit.log")
+	manager := repository.NewFileKeyringManagerWithPaths(
+		filepath.Join(dir, "keyrings"),
+		filepath.Join(dir, "registry.json"),
+		auditLog,
+		server.Client(),
+	)
+
+	data, err := manager.Fetch(context.Background(), server.URL+"/key.asc")
+	require.NoError(t, err)
+
+	binaryKey, publicKeys, err := manager.Inspect(data)
+	require.NoError(t, err)
+	require.Len(t, publicKeys, 1)
+
+	path, err := manager.Install("example", binaryKey)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "keyrings", "example-archive-keyring.gpg"), path)
+
+	key := domainRepo.ManagedKey{
+		Name:        "example",
+		Fingerprint: publicKeys[0].Fingerprint,
+		KeyringPath: path,
+		SourceURL:   server.URL + "/key.asc",
+		AddedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+	require.NoError(t, manager.SaveKeys([]domainRepo.ManagedKey{key}))
+
+	keys, err := manager.LoadKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key, keys[0])
+
+	require.NoError(t, manager.Audit("key-add", key, nil))
+	logContent, err := os.ReadFile(auditLog)
+	require.NoError(t, err)
+	assert.Contains(t, string(logContent), `"action":"key-add"`)
+	assert.Contains(t, string(logContent), testKeyFingerprint)
+	assert.Contains(t, string(logContent), `"result":"ok"`)
+}
+
+func TestFileKeyringManager_Install_RefusesExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	manager := repository.NewFileKeyringManagerWithPaths(
+		dir,
+		filepath.Join(dir, "registry.json"),
+		filepath.Join(dir, "audit.log"),
+		http.DefaultClient,
+	)
+
+	path := manager.KeyringPath("debian")
+	require.NoError(t, os.WriteFile(path, []byte("system keyring"), 0644))
+
+	_, err := manager.Install("debian", []byte("replacement"))
+	assert.ErrorIs(t, err, domainRepo.ErrFileExists)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "system keyring", string(content))
+
+	require.NoError(t, manager.Replace(path, []byte("refreshed")))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed", string(content))
+}
+
+func TestFileKeyringManager_Fetch_CanceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testArmoredKey))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	manager := repository.NewFileKeyringManagerWithPaths(
+		filepath.Join(dir, "keyrings"),
+		filepath.Join(dir, "registry.json"),
+		filepath.Join(dir, "audit.log"),
+		server.Client(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := manager.Fetch(ctx, server.URL+"/key.asc")
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
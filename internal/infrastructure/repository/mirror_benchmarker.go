@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+)
+
+const (
+	// defaultSampleBytes limits how much of the Release file is downloaded
+	// when measuring throughput
+	defaultSampleBytes = 512 * 1024
+	// defaultMirrorTimeout bounds the time spent on a single mirror
+	defaultMirrorTimeout = 10 * time.Second
+)
+
+// HTTPMirrorBenchmarker measures mirror latency and throughput over HTTP
+type HTTPMirrorBenchmarker struct {
+	client      *http.Client
+	sampleBytes int64
+}
+
+// NewHTTPMirrorBenchmarker creates a new HTTP-based mirror benchmarker
+func NewHTTPMirrorBenchmarker() *HTTPMirrorBenchmarker {
+	return &HTTPMirrorBenchmarker{
+		client:      &http.Client{Timeout: defaultMirrorTimeout},
+		sampleBytes: defaultSampleBytes,
+	}
+}
+
+// NewHTTPMirrorBenchmarkerWithClient creates a benchmarker using a custom client
+func NewHTTPMirrorBenchmarkerWithClient(client *http.Client, sampleBytes int64) *HTTPMirrorBenchmarker {
+	if sampleBytes <= 0 {
+		sampleBytes = defaultSampleBytes
+	}
+	return &HTTPMirrorBenchmarker{
+		client:      client,
+		sampleBytes: sampleBytes,
+	}
+}
+
+// Benchmark downloads a sample of the suite's Release file from the mirror.
+// Latency is the time until response headers arrive; throughput is measured
+// over the sampled body.
+func (b *HTTPMirrorBenchmarker) Benchmark(ctx context.Context, mirror domainRepo.Mirror, suite string) domainRepo.MirrorBenchmark {
+	result := domainRepo.MirrorBenchmark{Mirror: mirror}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mirror.ReleaseURL(suite), nil)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create request: %w", err)
+		return result
+	}
+
+	start := time.Now()
+	resp, err := b.client.Do(req)
+	if err != nil {
+		result.Err = fmt.Errorf("request failed: %w", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.Latency = time.Since(start)
+
+	if resp.StatusCode != http.StatusOK {
+		result.Err = fmt.Errorf("unexpected status: %s", resp.Status)
+		return result
+	}
+
+	bodyStart := time.Now()
+	n, err := io.Copy(io.Discard, io.LimitReader(resp.Body, b.sampleBytes))
+	if err != nil {
+		result.Err = fmt.Errorf("failed to download sample: %w", err)
+		return result
+	}
+
+	elapsed := time.Since(bodyStart)
+	if elapsed <= 0 {
+		elapsed = time.Microsecond
+	}
+	result.Throughput = float64(n) / elapsed.Seconds()
+
+	return result
+}
@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMirrorBenchmarker_Benchmark(t *testing.T) {
+	t.Run("measures reachable mirror", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/debian/dists/sid/Release", r.URL.Path)
+			w.Write([]byte(strings.Repeat("x", 4096)))
+		}))
+		defer server.Close()
+
+		mirror, err := domainRepo.NewMirror(server.URL + "/debian")
+		require.NoError(t, err)
+
+		benchmarker := repository.NewHTTPMirrorBenchmarkerWithClient(server.Client(), 0)
+		result := benchmarker.Benchmark(context.Background(), mirror, "sid")
+
+		require.NoError(t, result.Err)
+		assert.True(t, result.Reachable())
+		assert.Greater(t, result.Throughput, 0.0)
+	})
+
+	t.Run("reports error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		mirror, err := domainRepo.NewMirror(server.URL + "/debian")
+		require.NoError(t, err)
+
+		benchmarker := repository.NewHTTPMirrorBenchmarkerWithClient(server.Client(), 0)
+		result := benchmarker.Benchmark(context.Background(), mirror, "sid")
+
+		assert.Error(t, result.Err)
+		assert.False(t, result.Reachable())
+	})
+
+	t.Run("gives up on a stalled mirror when the context ends", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		mirror, err := domainRepo.NewMirror(server.URL + "/debian")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		benchmarker := repository.NewHTTPMirrorBenchmarkerWithClient(server.Client(), 0)
+		result := benchmarker.Benchmark(ctx, mirror, "sid")
+
+		assert.ErrorIs(t, result.Err, context.DeadlineExceeded)
+		assert.False(t, result.Reachable())
+	})
+}
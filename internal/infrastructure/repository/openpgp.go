@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+)
+
+const (
+	armorHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	armorFooter = "-----END PGP PUBLIC KEY BLOCK-----"
+
+	packetTagPublicKey    = 6
+	packetTagPublicSubkey = 14
+)
+
+var (
+	// ErrInvalidKeyData is returned when key data is not a valid OpenPGP public key
+	ErrInvalidKeyData = errors.New("invalid OpenPGP public key data")
+)
+
+// Dearmor converts an ASCII-armored public key block to its binary form.
+// Binary input is returned unchanged, matching `gpg --dearmor` output.
+func Dearmor(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte(armorHeader)) {
+		return data, nil
+	}
+
+	var body strings.Builder
+	inBlock := false
+	inHeaders := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == armorHeader:
+			inBlock = true
+			inHeaders = true
+		case !inBlock:
+			continue
+		case line == armorFooter:
+			decoded, err := base64.StdEncoding.DecodeString(body.String())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidKeyData, err)
+			}
+			return decoded, nil
+		case inHeaders:
+			// Armor headers ("Version: ...") end at the first blank line;
+			// some producers omit them entirely
+			if line == "" {
+				inHeaders = false
+			} else if !strings.Contains(line, ": ") {
+				inHeaders = false
+				body.WriteString(line)
+			}
+		case strings.HasPrefix(line, "="):
+			// CRC24 checksum line
+			continue
+		default:
+			body.WriteString(line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read armored key: %w", err)
+	}
+
+	return nil, fmt.Errorf("%w: missing armor footer", ErrInvalidKeyData)
+}
+
+// PublicKeys returns the primary keys in binary OpenPGP key data, each
+// with the fingerprints of the subkeys following it. Key data holding any
+// key other than v4 is rejected, so every primary key counts toward
+// fingerprint verification.
+func PublicKeys(data []byte) ([]domainRepo.PublicKey, error) {
+	var keys []domainRepo.PublicKey
+
+	for len(data) > 0 {
+		tag, body, rest, err := readPacket(data)
+		if err != nil {
+			return nil, err
+		}
+		data = rest
+
+		if tag != packetTagPublicKey && tag != packetTagPublicSubkey {
+			continue
+		}
+		if len(body) == 0 {
+			return nil, fmt.Errorf("%w: empty key packet", ErrInvalidKeyData)
+		}
+		if body[0] != 4 {
+			return nil, fmt.Errorf("%w: version %d key found, only v4 keys are supported", ErrInvalidKeyData, body[0])
+		}
+
+		// v4 fingerprint: SHA-1 over 0x99, two-octet body length, body
+		h := sha1.New()
+		h.Write([]byte{0x99, byte(len(body) >> 8), byte(len(body))})
+		h.Write(body)
+
+		fingerprint, err := domainRepo.NewKeyFingerprint(hex.EncodeToString(h.Sum(nil)))
+		if err != nil {
+			return nil, err
+		}
+		if tag == packetTagPublicKey {
+			keys = append(keys, domainRepo.PublicKey{Fingerprint: fingerprint})
+			continue
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("%w: subkey without a primary key", ErrInvalidKeyData)
+		}
+		keys[len(keys)-1].Subkeys = append(keys[len(keys)-1].Subkeys, fingerprint)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no public key packets found", ErrInvalidKeyData)
+	}
+
+	return keys, nil
+}
+
+// readPacket splits the next OpenPGP packet off data
+func readPacket(data []byte) (tag int, body []byte, rest []byte, err error) {
+	header := data[0]
+	if header&0x80 == 0 {
+		return 0, nil, nil, fmt.Errorf("%w: bad packet header", ErrInvalidKeyData)
+	}
+
+	var length, offset int
+	if header&0x40 != 0 {
+		// New format packet
+		tag = int(header & 0x3f)
+		if len(data) < 2 {
+			return 0, nil, nil, fmt.Errorf("%w: truncated packet", ErrInvalidKeyData)
+		}
+		switch first := int(data[1]); {
+		case first < 192:
+			length, offset = first, 2
+		case first < 224:
+			if len(data) < 3 {
+				return 0, nil, nil, fmt.Errorf("%w: truncated packet", ErrInvalidKeyData)
+			}
+			length, offset = ((first-192)<<8)+int(data[2])+192, 3
+		case first == 255:
+			if len(data) < 6 {
+				return 0, nil, nil, fmt.Errorf("%w: truncated packet", ErrInvalidKeyData)
+			}
+			length, offset = int(binary.BigEndian.Uint32(data[2:6])), 6
+		default:
+			return 0, nil, nil, fmt.Errorf("%w: partial body lengths are not supported", ErrInvalidKeyData)
+		}
+	} else {
+		// Old format packet
+		tag = int(header>>2) & 0x0f
+		switch header & 0x03 {
+		case 0:
+			if len(data) < 2 {
+				return 0, nil, nil, fmt.Errorf("%w: truncated packet", ErrInvalidKeyData)
+			}
+			length, offset = int(data[1]), 2
+		case 1:
+			if len(data) < 3 {
+				return 0, nil, nil, fmt.Errorf("%w: truncated packet", ErrInvalidKeyData)
+			}
+			length, offset = int(binary.BigEndian.Uint16(data[1:3])), 3
+		case 2:
+			if len(data) < 5 {
+				return 0, nil, nil, fmt.Errorf("%w: truncated packet", ErrInvalidKeyData)
+			}
+			length, offset = int(binary.BigEndian.Uint32(data[1:5])), 5
+		default:
+			length, offset = len(data)-1, 1
+		}
+	}
+
+	if length < 0 || offset+length > len(data) {
+		return 0, nil, nil, fmt.Errorf("%w: truncated packet", ErrInvalidKeyData)
+	}
+
+	return tag, data[offset : offset+length], data[offset+length:], nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// SystemRepositoryLister lists the enabled entries of the apt sources
+type SystemRepositoryLister struct {
+	manager         *FileSourcesManager
+	sourcesListPath string
+	sourcesListDir  string
+}
+
+// NewSystemRepositoryLister creates a lister for /etc/apt/sources.list and
+// /etc/apt/sources.list.d
+func NewSystemRepositoryLister() *SystemRepositoryLister {
+	return NewRepositoryListerWithPaths("/etc/apt/sources.list", "/etc/apt/sources.list.d")
+}
+
+// NewRepositoryListerWithPaths creates a lister for the given sources
+func NewRepositoryListerWithPaths(sourcesListPath, sourcesListDir string) *SystemRepositoryLister {
+	return &SystemRepositoryLister{
+		manager:         NewFileSourcesManager(),
+		sourcesListPath: sourcesListPath,
+		sourcesListDir:  sourcesListDir,
+	}
+}
+
+// ListRepositories returns every enabled entry as a sources.list line, in
+// file order
+func (l *SystemRepositoryLister) ListRepositories(ctx context.Context) ([]string, error) {
+	paths, err := l.manager.ListSourcesFiles(l.sourcesListPath, l.sourcesListDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []string
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		entries, err := ParseSourcesContent(path, string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		for _, entry := range entries {
+			if entry.Disabled {
+				continue
+			}
+			repositories = append(repositories, entry.String())
+		}
+	}
+
+	return repositories, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+)
+
+// ReadLocatedEntries parses a sources file and records the line each entry
+// is defined on. Lines or stanzas apt cannot parse are returned as issues
+// instead of failing the whole file.
+func (m *FileSourcesManager) ReadLocatedEntries(path string) ([]domainRepo.LocatedEntry, []domainRepo.SourceIssue, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	if domainRepo.SourcesFormatForPath(path) == domainRepo.SourcesFormatDeb822 {
+		entries, issues := parseLocatedDeb822(path, string(content))
+		return entries, issues, nil
+	}
+	entries, issues := parseLocatedOneLine(path, string(content))
+	return entries, issues, nil
+}
+
+func parseLocatedOneLine(path, content string) ([]domainRepo.LocatedEntry, []domainRepo.SourceIssue) {
+	var entries []domainRepo.LocatedEntry
+	var issues []domainRepo.SourceIssue
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		entry, err := ParseLine(scanner.Text())
+		if err != nil {
+			issues = append(issues, invalidEntryIssue(path, lineNum, err))
+			continue
+		}
+		if entry != nil {
+			entries = append(entries, domainRepo.LocatedEntry{SourceEntry: *entry, Path: path, Line: lineNum})
+		}
+	}
+
+	return entries, issues
+}
+
+func parseLocatedDeb822(path, content string) ([]domainRepo.LocatedEntry, []domainRepo.SourceIssue) {
+	paragraphs, startLines, err := splitDeb822Paragraphs(content)
+	if err != nil {
+		line := 1
+		var lineErr *deb822LineError
+		if errors.As(err, &lineErr) {
+			line, err = lineErr.line, lineErr.err
+		}
+		return nil, []domainRepo.SourceIssue{invalidEntryIssue(path, line, err)}
+	}
+
+	var entries []domainRepo.LocatedEntry
+	var issues []domainRepo.SourceIssue
+	for i, fields := range paragraphs {
+		stanzaEntries, err := parseDeb822Stanza(fields)
+		if err != nil {
+			issues = append(issues, invalidEntryIssue(path, startLines[i], err))
+			continue
+		}
+		for _, entry := range stanzaEntries {
+			entries = append(entries, domainRepo.LocatedEntry{SourceEntry: entry, Path: path, Line: startLines[i]})
+		}
+	}
+
+	return entries, issues
+}
+
+func invalidEntryIssue(path string, line int, err error) domainRepo.SourceIssue {
+	return domainRepo.SourceIssue{
+		Kind:    domainRepo.IssueInvalidEntry,
+		Path:    path,
+		Line:    line,
+		Message: err.Error(),
+	}
+}
+
+// ApplySourceFixes repairs entries in a sources file in place. Removed
+// one-line entries are commented out and removed deb822 stanzas get
+// "Enabled: no", so line numbers and comments are preserved.
+func (m *FileSourcesManager) ApplySourceFixes(path string, fixes []domainRepo.SourceFix) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	deb822 := domainRepo.SourcesFormatForPath(path) == domainRepo.SourcesFormatDeb822
+
+	// Stanza insertions shift later lines, so collect them and apply last
+	inserts := make(map[int]string)
+	for _, fix := range fixes {
+		if fix.Path != path {
+			continue
+		}
+		idx := fix.Line - 1
+		if idx < 0 || idx >= len(lines) {
+			return fmt.Errorf("%s: line %d out of range", path, fix.Line)
+		}
+
+		switch {
+		case deb822 && fix.Remove:
+			if !disableDeb822Stanza(lines, idx) {
+				inserts[idx] = "Enabled: no"
+			}
+		case deb822:
+			return fmt.Errorf("%s:%d: deb822 stanzas can only be disabled", path, fix.Line)
+		case fix.Remove:
+			lines[idx] = "# " + lines[idx] + " # duplicate, disabled by gohan"
+		default:
+			lines[idx] = fix.Replacement.String()
+		}
+	}
+
+	out := make([]string, 0, len(lines)+len(inserts))
+	for i, line := range lines {
+		if insert, ok := inserts[i]; ok {
+			out = append(out, "# Duplicate stanza, disabled by gohan", insert)
+		}
+		out = append(out, line)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(out, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// disableDeb822Stanza rewrites an existing Enabled field in the stanza that
+// starts at idx. It returns false when the stanza has no Enabled field.
+func disableDeb822Stanza(lines []string, idx int) bool {
+	for i := idx; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+		name, _, ok := strings.Cut(lines[i], ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "enabled") {
+			lines[i] = "Enabled: no"
+			return true
+		}
+	}
+	return false
+}
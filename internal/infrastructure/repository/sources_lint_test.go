@@ -0,0 +1,106 @@
+package repository_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSourcesManager_ReadLocatedEntries(t *testing.T) {
+	manager := repository.NewFileSourcesManager()
+	dir := t.TempDir()
+
+	t.Run("one-line file records lines and invalid entries", func(t *testing.T) {
+		path := filepath.Join(dir, "sources.list")
+		content := "# comment\ndeb http://deb.debian.org/debian sid main\n\ndeb http://broken\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		entries, issues, err := manager.ReadLocatedEntries(path)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].Line)
+		require.Len(t, issues, 1)
+		assert.Equal(t, domainRepo.IssueInvalidEntry, issues[0].Kind)
+		assert.Equal(t, 4, issues[0].Line)
+	})
+
+	t.Run("deb822 entries point at their stanza", func(t *testing.T) {
+		path := filepath.Join(dir, "debian.sources")
+		content := `# Debian
+Types: deb
+URIs: http://deb.debian.org/debian
+Suites: sid
+Components: main
+
+Types: deb
+URIs: http://deb.debian.org/debian
+Components: main
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		entries, issues, err := manager.ReadLocatedEntries(path)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].Line)
+		require.Len(t, issues, 1)
+		assert.Equal(t, 7, issues[0].Line)
+		assert.Contains(t, issues[0].Message, "missing Suites")
+	})
+}
+
+func TestFileSourcesManager_ApplySourceFixes(t *testing.T) {
+	manager := repository.NewFileSourcesManager()
+	dir := t.TempDir()
+
+	t.Run("one-line fixes keep line numbers", func(t *testing.T) {
+		path := filepath.Join(dir, "sources.list")
+		content := "deb http://deb.debian.org/debian sid main\ndeb http://deb.debian.org/debian sid main\ndeb http://deb.debian.org/debian sid main contrib\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		err := manager.ApplySourceFixes(path, []domainRepo.SourceFix{
+			{Path: path, Line: 2, Remove: true},
+			{Path: path, Line: 3, Replacement: domainRepo.SourceEntry{
+				Type: "deb", URI: "http://deb.debian.org/debian", Suite: "sid", Components: []string{"contrib"},
+			}},
+		})
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "deb http://deb.debian.org/debian sid main\n"+
+			"# deb http://deb.debian.org/debian sid main # duplicate, disabled by gohan\n"+
+			"deb http://deb.debian.org/debian sid contrib\n", string(data))
+
+		entries, issues, err := manager.ReadLocatedEntries(path)
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+		assert.Empty(t, domainRepo.LintSources(entries).Issues)
+	})
+
+	t.Run("deb822 stanza is disabled", func(t *testing.T) {
+		path := filepath.Join(dir, "extra.sources")
+		content := "Types: deb\nURIs: http://deb.debian.org/debian\nSuites: sid\nComponents: main\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		require.NoError(t, manager.ApplySourceFixes(path, []domainRepo.SourceFix{{Path: path, Line: 1, Remove: true}}))
+
+		entries, issues, err := manager.ReadLocatedEntries(path)
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+		require.Len(t, entries, 1)
+		assert.True(t, entries[0].Disabled)
+	})
+
+	t.Run("out of range line fails", func(t *testing.T) {
+		path := filepath.Join(dir, "short.list")
+		require.NoError(t, os.WriteFile(path, []byte("deb http://deb.debian.org/debian sid main\n"), 0644))
+
+		err := manager.ApplySourceFixes(path, []domainRepo.SourceFix{{Path: path, Line: 10, Remove: true}})
+		assert.Error(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	domainRepo "github.com/rebelopsio/gohan/internal/domain/repository"
@@ -17,7 +18,16 @@ func NewFileSourcesManager() *FileSourcesManager {
 	return &FileSourcesManager{}
 }
 
-// ReadConfig reads and parses a sources.list file into a RepositoryConfig
+// ParseSourcesContent parses sources file content using the format implied
+// by the file name (.sources files are deb822, everything else one-line)
+func ParseSourcesContent(path, content string) ([]ParsedEntry, error) {
+	if domainRepo.SourcesFormatForPath(path) == domainRepo.SourcesFormatDeb822 {
+		return ParseDeb822(content)
+	}
+	return ParseSourcesFile(content)
+}
+
+// ReadConfig reads and parses a sources.list or deb822 .sources file into a RepositoryConfig
 func (m *FileSourcesManager) ReadConfig(path string) (*domainRepo.RepositoryConfig, error) {
 	// Read file content
 	content, err := os.ReadFile(path)
@@ -26,9 +36,9 @@ func (m *FileSourcesManager) ReadConfig(path string) (*domainRepo.RepositoryConf
 	}
 
 	// Parse entries
-	entries, err := ParseSourcesFile(string(content))
+	entries, err := ParseSourcesContent(path, string(content))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse sources.list: %w", err)
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
 	}
 
 	// Create config
@@ -48,8 +58,11 @@ func (m *FileSourcesManager) WriteConfig(path string, config *domainRepo.Reposit
 		return fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
 
-	// Convert config to string
+	// Convert config to string in the file's format
 	content := config.String() + "\n"
+	if domainRepo.SourcesFormatForPath(path) == domainRepo.SourcesFormatDeb822 {
+		content = FormatDeb822(config.Entries()) + "\n"
+	}
 
 	// Write file with appropriate permissions (root-owned files)
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
@@ -85,6 +98,42 @@ func (m *FileSourcesManager) Backup(path string, backupDir string) (string, erro
 	return backupPath, nil
 }
 
+// ListSourcesFiles returns the main sources.list (if present) followed by
+// every .list and .sources file in the sources.list.d directory
+func (m *FileSourcesManager) ListSourcesFiles(listPath, dir string) ([]string, error) {
+	var paths []string
+
+	if exists, err := m.Exists(listPath); err != nil {
+		return nil, err
+	} else if exists {
+		paths = append(paths, listPath)
+	}
+
+	if dir == "" {
+		return paths, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return paths, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if strings.HasSuffix(name, ".list") || strings.HasSuffix(name, ".sources") {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+
+	return paths, nil
+}
+
 // Exists checks if a file exists
 func (m *FileSourcesManager) Exists(path string) (bool, error) {
 	_, err := os.Stat(path)
@@ -62,6 +62,18 @@ func ParseLine(line string) (*ParsedEntry, error) {
 		return nil, nil
 	}
 
+	// Extract bracketed options, e.g. "deb [arch=amd64 signed-by=/path] uri ..."
+	var options []string
+	if idx := strings.IndexAny(line, " \t"); idx > 0 && strings.HasPrefix(strings.TrimSpace(line[idx:]), "[") {
+		typ, rest := line[:idx], strings.TrimSpace(line[idx:])
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated options", ErrInvalidLine)
+		}
+		options = strings.Fields(rest[1:end])
+		line = typ + " " + rest[end+1:]
+	}
+
 	// Split line into fields
 	fields := strings.Fields(line)
 
@@ -80,10 +92,20 @@ func ParseLine(line string) (*ParsedEntry, error) {
 		return nil, fmt.Errorf("%w: invalid type '%s'", ErrInvalidLine, entryType)
 	}
 
-	return &ParsedEntry{
+	entry := &ParsedEntry{
 		Type:       entryType,
 		URI:        uri,
 		Suite:      suite,
 		Components: components,
-	}, nil
+	}
+
+	for _, option := range options {
+		if value, ok := strings.CutPrefix(option, "signed-by="); ok {
+			entry.SignedBy = value
+			continue
+		}
+		entry.Options = append(entry.Options, option)
+	}
+
+	return entry, nil
 }
@@ -143,6 +143,25 @@ func TestParseLine(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "options with signed-by",
+			line: "deb [arch=amd64 signed-by=/usr/share/keyrings/example.gpg] https://repo.example.com/apt stable main",
+			want: &repository.ParsedEntry{
+				Type:       "deb",
+				URI:        "https://repo.example.com/apt",
+				Suite:      "stable",
+				Components: []string{"main"},
+				SignedBy:   "/usr/share/keyrings/example.gpg",
+				Options:    []string{"arch=amd64"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid line - unterminated options",
+			line:    "deb [arch=amd64 https://repo.example.com/apt stable main",
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "invalid line - no components",
 			line:    "deb http://deb.debian.org/debian sid",
@@ -166,6 +185,8 @@ func TestParseLine(t *testing.T) {
 					assert.Equal(t, tt.want.URI, got.URI)
 					assert.Equal(t, tt.want.Suite, got.Suite)
 					assert.Equal(t, tt.want.Components, got.Components)
+					assert.Equal(t, tt.want.SignedBy, got.SignedBy)
+					assert.Equal(t, tt.want.Options, got.Options)
 				}
 			}
 		})
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSourcesSnapshotDir is where gohan keeps snapshots of apt sources
+	DefaultSourcesSnapshotDir = "/var/backups/gohan/sources"
+
+	// DefaultSourcesLintSnapshotDir keeps snapshots taken before lint fixes
+	// apart from mirror switches, so a mirror revert never undoes them
+	DefaultSourcesLintSnapshotDir = "/var/backups/gohan/sources/lint"
+
+	snapshotManifestName = "manifest.json"
+	snapshotDirPrefix    = "sources-"
+)
+
+var (
+	// ErrNoSnapshot is returned when there is no snapshot to restore
+	ErrNoSnapshot = errors.New("no sources snapshot found")
+)
+
+// SnapshotFile records the state of a single file at snapshot time
+type SnapshotFile struct {
+	Path    string `json:"path"`
+	Backup  string `json:"backup,omitempty"`
+	Existed bool   `json:"existed"`
+}
+
+// SourcesSnapshot describes a point-in-time copy of apt sources files
+type SourcesSnapshot struct {
+	Dir       string         `json:"-"`
+	CreatedAt time.Time      `json:"created_at"`
+	Reason    string         `json:"reason"`
+	Files     []SnapshotFile `json:"files"`
+}
+
+// FileSourcesSnapshotter snapshots and restores sources files on disk
+type FileSourcesSnapshotter struct{}
+
+// NewFileSourcesSnapshotter creates a new snapshotter
+func NewFileSourcesSnapshotter() *FileSourcesSnapshotter {
+	return &FileSourcesSnapshotter{}
+}
+
+// Snapshot copies the given files into a new timestamped directory under
+// snapshotDir. Files that do not exist are recorded so that a restore
+// removes them again.
+func (s *FileSourcesSnapshotter) Snapshot(paths []string, snapshotDir, reason string) (*SourcesSnapshot, error) {
+	createdAt := time.Now()
+	dir := filepath.Join(snapshotDir, snapshotDirPrefix+createdAt.Format("20060102-150405.000000000"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory %s: %w", dir, err)
+	}
+
+	snapshot := &SourcesSnapshot{
+		Dir:       dir,
+		CreatedAt: createdAt,
+		Reason:    reason,
+		Files:     make([]SnapshotFile, 0, len(paths)),
+	}
+
+	for i, path := range paths {
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			snapshot.Files = append(snapshot.Files, SnapshotFile{Path: path})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+
+		backupPath := filepath.Join(dir, fmt.Sprintf("%d-%s", i, filepath.Base(path)))
+		if err := os.WriteFile(backupPath, content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write backup file %s: %w", backupPath, err)
+		}
+
+		snapshot.Files = append(snapshot.Files, SnapshotFile{
+			Path:    path,
+			Backup:  backupPath,
+			Existed: true,
+		})
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, snapshotManifestName), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// Latest returns the most recent snapshot in snapshotDir
+func (s *FileSourcesSnapshotter) Latest(snapshotDir string) (*SourcesSnapshot, error) {
+	entries, err := os.ReadDir(snapshotDir)
+	if os.IsNotExist(err) {
+		return nil, ErrNoSnapshot
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory %s: %w", snapshotDir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), snapshotDirPrefix) {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil, ErrNoSnapshot
+	}
+	sort.Strings(names)
+
+	dir := filepath.Join(snapshotDir, names[len(names)-1])
+	data, err := os.ReadFile(filepath.Join(dir, snapshotManifestName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+
+	var snapshot SourcesSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot manifest: %w", err)
+	}
+	snapshot.Dir = dir
+
+	return &snapshot, nil
+}
+
+// Restore puts every file back to its snapshotted state and removes the
+// snapshot directory afterwards
+func (s *FileSourcesSnapshotter) Restore(snapshot *SourcesSnapshot) error {
+	for _, file := range snapshot.Files {
+		if !file.Existed {
+			if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", file.Path, err)
+			}
+			continue
+		}
+
+		content, err := os.ReadFile(file.Backup)
+		if err != nil {
+			return fmt.Errorf("failed to read backup %s: %w", file.Backup, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+		}
+		if err := os.WriteFile(file.Path, content, 0644); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", file.Path, err)
+		}
+	}
+
+	if err := os.RemoveAll(snapshot.Dir); err != nil {
+		return fmt.Errorf("failed to remove snapshot %s: %w", snapshot.Dir, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebelopsio/gohan/internal/domain/stats"
+)
+
+// SQLiteRepository is a SQLite implementation of stats.Repository
+// The database never leaves the machine; nothing here talks to the network.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRepository creates a new SQLite stats repository
+func NewSQLiteRepository(dbPath string) (*SQLiteRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	repo := &SQLiteRepository{db: db}
+	if err := repo.initialize(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	return repo, nil
+}
+
+// initialize creates the necessary tables
+func (r *SQLiteRepository) initialize() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS install_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		recorded_at DATETIME NOT NULL,
+		outcome TEXT NOT NULL,
+		duration_ms INTEGER NOT NULL,
+		failure_phase TEXT NOT NULL DEFAULT '',
+		component_count INTEGER NOT NULL DEFAULT 0
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_install_runs_recorded_at ON install_runs(recorded_at);
+	`
+
+	_, err := r.db.Exec(schema)
+	return err
+}
+
+// Record persists an install run
+func (r *SQLiteRepository) Record(ctx context.Context, run stats.InstallRun) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO install_runs (recorded_at, outcome, duration_ms, failure_phase, component_count)
+		 VALUES (?, ?, ?, ?, ?)`,
+		run.RecordedAt().UTC(),
+		run.Outcome().String(),
+		run.Duration().Milliseconds(),
+		run.FailurePhase(),
+		run.ComponentCount(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record install run: %w", err)
+	}
+	return nil
+}
+
+// FindAll returns all recorded runs, oldest first
+func (r *SQLiteRepository) FindAll(ctx context.Context) ([]stats.InstallRun, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT recorded_at, outcome, duration_ms, failure_phase, component_count
+		 FROM install_runs ORDER BY recorded_at ASC, id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query install runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []stats.InstallRun
+	for rows.Next() {
+		var (
+			recordedAt     time.Time
+			outcome        string
+			durationMs     int64
+			failurePhase   string
+			componentCount int
+		)
+		if err := rows.Scan(&recordedAt, &outcome, &durationMs, &failurePhase, &componentCount); err != nil {
+			return nil, fmt.Errorf("failed to scan install run: %w", err)
+		}
+
+		run, err := stats.NewInstallRun(
+			recordedAt,
+			stats.Outcome(outcome),
+			time.Duration(durationMs)*time.Millisecond,
+			failurePhase,
+			componentCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct install run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// Clear removes all recorded runs
+func (r *SQLiteRepository) Clear(ctx context.Context) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM install_runs`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear install runs: %w", err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count removed runs: %w", err)
+	}
+	return int(removed), nil
+}
+
+// Close closes the database connection
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
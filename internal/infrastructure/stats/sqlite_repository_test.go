@@ -0,0 +1,74 @@
+package stats_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/stats"
+	statsInfra "github.com/rebelopsio/gohan/internal/infrastructure/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteRepository(t *testing.T) {
+	ctx := context.Background()
+
+	repo, err := statsInfra.NewSQLiteRepository(filepath.Join(t.TempDir(), "stats.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	base := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	later, err := stats.NewInstallRun(base.Add(time.Hour), stats.OutcomeFailed, 90*time.Second, "Installing Components", 4)
+	require.NoError(t, err)
+	earlier, err := stats.NewInstallRun(base, stats.OutcomeSucceeded, 3*time.Minute, "", 2)
+	require.NoError(t, err)
+
+	t.Run("records and returns runs oldest first", func(t *testing.T) {
+		require.NoError(t, repo.Record(ctx, later))
+		require.NoError(t, repo.Record(ctx, earlier))
+
+		runs, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+
+		assert.True(t, runs[0].RecordedAt().Equal(base))
+		assert.True(t, runs[0].Succeeded())
+		assert.Equal(t, 3*time.Minute, runs[0].Duration())
+
+		assert.Equal(t, stats.OutcomeFailed, runs[1].Outcome())
+		assert.Equal(t, "Installing Components", runs[1].FailurePhase())
+		assert.Equal(t, 4, runs[1].ComponentCount())
+	})
+
+	t.Run("clears all runs", func(t *testing.T) {
+		removed, err := repo.Clear(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, removed)
+
+		runs, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+}
+
+func TestSQLiteRepository_CanceledContext(t *testing.T) {
+	repo, err := statsInfra.NewSQLiteRepository(filepath.Join(t.TempDir(), "stats.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	run, err := stats.NewInstallRun(time.Now(), stats.OutcomeSucceeded, time.Minute, "", 1)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, repo.Record(ctx, run), context.Canceled)
+
+	_, err = repo.FindAll(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.Clear(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -23,14 +23,14 @@ type ComponentConfiguration struct {
 type ThemeApplierImpl struct {
 	configDeployer    *configservice.ConfigDeployer
 	componentReloader *ComponentReloader
+	extraVars         templates.TemplateVars
 }
 
-// NewThemeApplier creates a new theme applier
+// NewThemeApplier creates a new theme applier that only deploys
+// configuration; running components are reloaded by a ReloadService
 func NewThemeApplier(configDeployer *configservice.ConfigDeployer) *ThemeApplierImpl {
-	executor := NewSystemCommandExecutor()
 	return &ThemeApplierImpl{
-		configDeployer:    configDeployer,
-		componentReloader: NewComponentReloader(executor),
+		configDeployer: configDeployer,
 	}
 }
 
@@ -42,6 +42,15 @@ func NewThemeApplierWithReloader(configDeployer *configservice.ConfigDeployer, r
 	}
 }
 
+// WithTemplateVars returns a copy of the applier that also renders with the
+// given variables, such as the user's accessibility options, so changing the
+// theme keeps them
+func (ta *ThemeApplierImpl) WithTemplateVars(vars templates.TemplateVars) *ThemeApplierImpl {
+	applier := *ta
+	applier.extraVars = vars
+	return &applier
+}
+
 // ApplyTheme applies a theme to the system by updating configuration files
 func (ta *ThemeApplierImpl) ApplyTheme(ctx context.Context, th *theme.Theme) error {
 	// Convert theme to template variables
@@ -57,45 +66,46 @@ func (ta *ThemeApplierImpl) ApplyTheme(ctx context.Context, th *theme.Theme) err
 	for k, v := range systemVars {
 		vars[k] = v
 	}
+	for k, v := range ta.extraVars {
+		vars[k] = v
+	}
 
-	// Get component configurations
-	componentConfigs := GetComponentConfigurations()
-
-	// Convert to ConfigurationFile format
-	configFiles := make([]configservice.ConfigurationFile, 0, len(componentConfigs))
-	for _, compCfg := range componentConfigs {
+	// Components the theme recolors are deployed with their own
+	// variables; the rest share one deployment
+	var shared []configservice.ConfigurationFile
+	for _, compCfg := range GetComponentConfigurations() {
 		// Check if template file exists
 		if _, err := os.Stat(compCfg.TemplatePath); err != nil {
 			// Skip if template doesn't exist (optional component)
 			continue
 		}
 
-		configFiles = append(configFiles, configservice.ConfigurationFile{
+		file := configservice.ConfigurationFile{
 			SourceTemplate: compCfg.TemplatePath,
 			TargetPath:     compCfg.TargetPath,
 			Permissions:    0644,
 			BackupBefore:   compCfg.BackupBefore,
-		})
-	}
-
-	// Deploy configurations without progress channel (synchronous)
-	if len(configFiles) > 0 {
-		progressChan := make(chan configservice.DeploymentProgress, len(configFiles)*3)
-		done := make(chan error, 1)
-
-		go func() {
-			done <- ta.configDeployer.DeployConfigurations(ctx, configFiles, vars, progressChan)
-			close(progressChan)
-		}()
-
-		// Consume progress updates
-		for range progressChan {
-			// Silently consume for now
+			ManagedBlock:   true,
+		}
+		colors := th.ComponentColors(compCfg.Component)
+		if len(colors) == 0 {
+			shared = append(shared, file)
+			continue
 		}
 
-		if err := <-done; err != nil {
-			return fmt.Errorf("failed to deploy configurations: %w", err)
+		componentVars := make(templates.TemplateVars, len(vars)+len(colors))
+		for k, v := range vars {
+			componentVars[k] = v
 		}
+		for role, color := range colors {
+			componentVars["theme_"+role] = color.String()
+		}
+		if err := ta.deploy(ctx, []configservice.ConfigurationFile{file}, componentVars); err != nil {
+			return err
+		}
+	}
+	if err := ta.deploy(ctx, shared, vars); err != nil {
+		return err
 	}
 
 	// Reload components to apply changes
@@ -109,6 +119,32 @@ func (ta *ThemeApplierImpl) ApplyTheme(ctx context.Context, th *theme.Theme) err
 	return nil
 }
 
+// deploy renders configuration files with vars, waiting for the deployment
+// to finish
+func (ta *ThemeApplierImpl) deploy(ctx context.Context, configFiles []configservice.ConfigurationFile, vars templates.TemplateVars) error {
+	if len(configFiles) == 0 {
+		return nil
+	}
+
+	progressChan := make(chan configservice.DeploymentProgress, len(configFiles)*3)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- ta.configDeployer.DeployConfigurations(ctx, configFiles, vars, progressChan)
+		close(progressChan)
+	}()
+
+	// Consume progress updates
+	for range progressChan {
+		// Silently consume for now
+	}
+
+	if err := <-done; err != nil {
+		return fmt.Errorf("failed to deploy configurations: %w", err)
+	}
+	return nil
+}
+
 // ThemeToTemplateVars converts a theme to template variables
 func ThemeToTemplateVars(th *theme.Theme) templates.TemplateVars {
 	cs := th.ColorScheme()
@@ -214,3 +250,13 @@ func GetComponentConfigurations() []ComponentConfiguration {
 		},
 	}
 }
+
+// ComponentTargetPath returns where a component's configuration is deployed
+func ComponentTargetPath(component string) string {
+	for _, compCfg := range GetComponentConfigurations() {
+		if compCfg.Component == component {
+			return compCfg.TargetPath
+		}
+	}
+	return ""
+}
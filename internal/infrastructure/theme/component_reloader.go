@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"time"
 )
 
+// reloadCommandTimeout bounds each reload command so an unresponsive
+// compositor cannot hang a theme switch
+const reloadCommandTimeout = 30 * time.Second
+
 // CommandExecutor defines the interface for executing system commands
 type CommandExecutor interface {
 	Execute(ctx context.Context, command string, args ...string) error
@@ -40,6 +45,10 @@ func (r *ComponentReloader) ReloadHyprland(ctx context.Context) error {
 		return nil
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if err := r.executor.Execute(ctx, "hyprctl", "reload"); err != nil {
 		return fmt.Errorf("failed to reload Hyprland: %w", err)
 	}
@@ -54,6 +63,10 @@ func (r *ComponentReloader) ReloadWaybar(ctx context.Context) error {
 		return nil
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Kill existing waybar process (ignore error if not running)
 	_ = r.executor.Execute(ctx, "killall", "waybar")
 
@@ -75,11 +88,17 @@ func (r *ComponentReloader) ReloadAll(ctx context.Context) error {
 
 	// Reload Hyprland first
 	if err := r.ReloadHyprland(ctx); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		fmt.Printf("Warning: %v\n", err)
 	}
 
 	// Then reload Waybar
 	if err := r.ReloadWaybar(ctx); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		fmt.Printf("Warning: %v\n", err)
 	}
 
@@ -94,8 +113,15 @@ func NewSystemCommandExecutor() *SystemCommandExecutor {
 	return &SystemCommandExecutor{}
 }
 
-// Execute runs a system command
+// Execute runs a system command, killing it after reloadCommandTimeout
 func (e *SystemCommandExecutor) Execute(ctx context.Context, command string, args ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reloadCommandTimeout)
+	defer cancel()
+
 	cmd := exec.CommandContext(ctx, command, args...)
 	return cmd.Run()
 }
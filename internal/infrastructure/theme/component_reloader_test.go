@@ -138,6 +138,29 @@ func TestComponentReloader_DryRun(t *testing.T) {
 	})
 }
 
+func TestComponentReloader_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("ReloadAll stops without running commands", func(t *testing.T) {
+		executor := &MockCommandExecutor{
+			commands: []string{},
+		}
+		reloader := themeInfra.NewComponentReloader(executor)
+
+		err := reloader.ReloadAll(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, executor.commands)
+	})
+
+	t.Run("system executor does not start the command", func(t *testing.T) {
+		err := themeInfra.NewSystemCommandExecutor().Execute(ctx, "true")
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
 // MockCommandExecutor is a mock implementation of command execution
 type MockCommandExecutor struct {
 	commands      []string
@@ -0,0 +1,81 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/rebelopsio/gohan/internal/domain/theme"
+)
+
+// ErrUnsupportedPaletteFormat is returned for palette files that are
+// neither .toml nor .json
+var ErrUnsupportedPaletteFormat = errors.New("unsupported palette file format")
+
+// paletteFile is the layout of a palette file, in TOML:
+//
+//	name = "solarized"
+//	display_name = "Solarized Dark"
+//	variant = "dark"
+//
+//	[colors]
+//	base = "#002b36"
+//	text = "#839496"
+//	accent = "#268bd2"
+//
+// and the same keys in JSON
+type paletteFile struct {
+	Name        string            `toml:"name" json:"name"`
+	DisplayName string            `toml:"display_name" json:"display_name,omitempty"`
+	Author      string            `toml:"author" json:"author,omitempty"`
+	Description string            `toml:"description" json:"description,omitempty"`
+	Variant     string            `toml:"variant" json:"variant"`
+	Colors      map[string]string `toml:"colors" json:"colors"`
+}
+
+// LoadPaletteFile reads a custom theme from a .toml or .json palette file.
+// Keys outside the layout are rejected, so a misspelled key is not silently
+// ignored. A file without a name is named after the file.
+func LoadPaletteFile(path string) (theme.CustomThemeDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return theme.CustomThemeDefinition{}, fmt.Errorf("failed to read palette file: %w", err)
+	}
+
+	var file paletteFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		meta, err := toml.Decode(string(data), &file)
+		if err != nil {
+			return theme.CustomThemeDefinition{}, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			keys := make([]string, len(undecoded))
+			for i, key := range undecoded {
+				keys[i] = key.String()
+			}
+			sort.Strings(keys)
+			return theme.CustomThemeDefinition{}, fmt.Errorf("%w: unknown keys in %s: %s",
+				theme.ErrInvalidPalette, path, strings.Join(keys, ", "))
+		}
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&file); err != nil {
+			return theme.CustomThemeDefinition{}, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	default:
+		return theme.CustomThemeDefinition{}, fmt.Errorf("%w: %q; use .toml or .json", ErrUnsupportedPaletteFormat, ext)
+	}
+
+	if file.Name == "" {
+		file.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return theme.CustomThemeDefinition(file), nil
+}
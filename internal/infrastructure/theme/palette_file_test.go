@@ -0,0 +1,80 @@
+package theme_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/theme"
+	themeInfra "github.com/rebelopsio/gohan/internal/infrastructure/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePaletteFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadPaletteFile(t *testing.T) {
+	want := theme.CustomThemeDefinition{
+		Name:        "solarized",
+		DisplayName: "Solarized Dark",
+		Variant:     "dark",
+		Colors:      map[string]string{"base": "#002b36", "text": "#839496", "accent": "#268bd2"},
+	}
+
+	t.Run("reads TOML", func(t *testing.T) {
+		path := writePaletteFile(t, "solarized.toml", `# Solarized
+name = "solarized"
+display_name = "Solarized Dark"
+variant = "dark"
+
+[colors]
+base = "#002b36"
+text = "#839496"
+accent = "#268bd2"
+`)
+
+		def, err := themeInfra.LoadPaletteFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, want, def)
+	})
+
+	t.Run("reads JSON", func(t *testing.T) {
+		path := writePaletteFile(t, "solarized.json", `{
+  "name": "solarized",
+  "display_name": "Solarized Dark",
+  "variant": "dark",
+  "colors": {"base": "#002b36", "text": "#839496", "accent": "#268bd2"}
+}`)
+
+		def, err := themeInfra.LoadPaletteFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, want, def)
+	})
+
+	t.Run("names the theme after the file", func(t *testing.T) {
+		path := writePaletteFile(t, "amber.toml", "variant = \"light\"\n")
+
+		def, err := themeInfra.LoadPaletteFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "amber", def.Name)
+	})
+
+	t.Run("rejects unknown keys", func(t *testing.T) {
+		_, err := themeInfra.LoadPaletteFile(writePaletteFile(t, "typo.toml", "varient = \"dark\"\n"))
+		assert.ErrorIs(t, err, theme.ErrInvalidPalette)
+		assert.ErrorContains(t, err, "varient")
+
+		_, err = themeInfra.LoadPaletteFile(writePaletteFile(t, "typo.json", `{"varient": "dark"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects other formats", func(t *testing.T) {
+		_, err := themeInfra.LoadPaletteFile(writePaletteFile(t, "solarized.yaml", "name: solarized\n"))
+		assert.ErrorIs(t, err, themeInfra.ErrUnsupportedPaletteFormat)
+	})
+}
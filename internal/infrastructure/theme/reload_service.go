@@ -0,0 +1,110 @@
+package theme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// kittySocketPattern matches the sockets kitty listens on with the bundled
+// kitty.conf's listen_on unix:/tmp/kitty, to which kitty appends its PID
+const kittySocketPattern = "/tmp/kitty-*"
+
+// ReloadService signals running components to pick up their redeployed
+// configuration, so a new theme shows without logging out. Components that
+// are not running are skipped.
+type ReloadService struct {
+	executor     CommandExecutor
+	kittyConfig  string
+	kittySockets func() []string
+}
+
+// NewReloadService creates a reload service; kittyConfig is the deployed
+// kitty.conf whose colors running kitty windows are set to
+func NewReloadService(executor CommandExecutor, kittyConfig string) *ReloadService {
+	return &ReloadService{
+		executor:     executor,
+		kittyConfig:  kittyConfig,
+		kittySockets: globKittySockets,
+	}
+}
+
+// WithKittySockets returns a copy of the service that finds the remote
+// control sockets of running kitty instances with sockets
+func (s *ReloadService) WithKittySockets(sockets func() []string) *ReloadService {
+	copied := *s
+	copied.kittySockets = sockets
+	return &copied
+}
+
+// Reload signals every running component and returns the ones reloaded.
+// A component that fails to reload does not stop the others.
+func (s *ReloadService) Reload(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		reloaded []string
+		errs     []error
+	)
+	record := func(component string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", component, err))
+			return
+		}
+		reloaded = append(reloaded, component)
+	}
+
+	if s.running(ctx, "Hyprland") {
+		record("hyprland", s.executor.Execute(ctx, "hyprctl", "reload"))
+	}
+	// Waybar rereads its config and style on SIGUSR2
+	if s.running(ctx, "waybar") {
+		record("waybar", s.executor.Execute(ctx, "pkill", "-SIGUSR2", "-x", "waybar"))
+	}
+	if s.running(ctx, "mako") {
+		record("mako", s.executor.Execute(ctx, "makoctl", "reload"))
+	}
+	if sockets := s.kittySockets(); len(sockets) > 0 {
+		record("kitty", s.reloadKitty(ctx, sockets))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return reloaded, err
+	}
+	return reloaded, errors.Join(errs...)
+}
+
+// reloadKitty sets the colors of every window of every kitty instance to
+// the deployed kitty.conf's through remote control. Stale sockets of exited
+// instances fail, so kitty counts as reloaded when any instance was.
+func (s *ReloadService) reloadKitty(ctx context.Context, sockets []string) error {
+	var lastErr error
+	reloaded := false
+	for _, socket := range sockets {
+		err := s.executor.Execute(ctx, "kitty", "@", "--to", "unix:"+socket,
+			"set-colors", "--all", "--configured", s.kittyConfig)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		reloaded = true
+	}
+	if reloaded {
+		return nil
+	}
+	return lastErr
+}
+
+// running reports whether a process named name is running
+func (s *ReloadService) running(ctx context.Context, name string) bool {
+	return s.executor.Execute(ctx, "pgrep", "-x", name) == nil
+}
+
+// globKittySockets lists kitty's remote control sockets
+func globKittySockets() []string {
+	sockets, _ := filepath.Glob(kittySocketPattern)
+	return sockets
+}
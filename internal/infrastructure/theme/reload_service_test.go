@@ -0,0 +1,93 @@
+package theme_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	themeInfra "github.com/rebelopsio/gohan/internal/infrastructure/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// processExecutor runs commands against a set of running processes: pgrep
+// finds them, and commands listed in failing fail
+type processExecutor struct {
+	running  map[string]bool
+	failing  map[string]bool
+	commands []string
+}
+
+func (e *processExecutor) Execute(ctx context.Context, command string, args ...string) error {
+	full := strings.Join(append([]string{command}, args...), " ")
+	if command == "pgrep" {
+		if !e.running[args[len(args)-1]] {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}
+	e.commands = append(e.commands, full)
+	if e.failing[full] {
+		return errors.New("exit status 1")
+	}
+	return nil
+}
+
+func TestReloadService_Reload(t *testing.T) {
+	ctx := context.Background()
+	noKitty := func() []string { return nil }
+
+	t.Run("signals the running components", func(t *testing.T) {
+		executor := &processExecutor{running: map[string]bool{"Hyprland": true, "waybar": true}}
+		service := themeInfra.NewReloadService(executor, "/home/me/.config/kitty/kitty.conf").
+			WithKittySockets(func() []string { return []string{"/tmp/kitty-100"} })
+
+		reloaded, err := service.Reload(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"hyprland", "waybar", "kitty"}, reloaded)
+		assert.Equal(t, []string{
+			"hyprctl reload",
+			"pkill -SIGUSR2 -x waybar",
+			"kitty @ --to unix:/tmp/kitty-100 set-colors --all --configured /home/me/.config/kitty/kitty.conf",
+		}, executor.commands)
+	})
+
+	t.Run("skips components that are not running", func(t *testing.T) {
+		executor := &processExecutor{}
+		service := themeInfra.NewReloadService(executor, "kitty.conf").WithKittySockets(noKitty)
+
+		reloaded, err := service.Reload(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, reloaded)
+		assert.Empty(t, executor.commands)
+	})
+
+	t.Run("reloads the others when one fails", func(t *testing.T) {
+		executor := &processExecutor{
+			running: map[string]bool{"Hyprland": true, "mako": true},
+			failing: map[string]bool{"hyprctl reload": true},
+		}
+		service := themeInfra.NewReloadService(executor, "kitty.conf").WithKittySockets(noKitty)
+
+		reloaded, err := service.Reload(ctx)
+
+		assert.ErrorContains(t, err, "hyprland")
+		assert.Equal(t, []string{"mako"}, reloaded)
+	})
+
+	t.Run("ignores stale kitty sockets", func(t *testing.T) {
+		executor := &processExecutor{failing: map[string]bool{
+			"kitty @ --to unix:/tmp/kitty-1 set-colors --all --configured kitty.conf": true,
+		}}
+		service := themeInfra.NewReloadService(executor, "kitty.conf").
+			WithKittySockets(func() []string { return []string{"/tmp/kitty-1", "/tmp/kitty-2"} })
+
+		reloaded, err := service.Reload(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"kitty"}, reloaded)
+	})
+}
@@ -0,0 +1,261 @@
+package theme
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebelopsio/gohan/internal/domain/theme"
+)
+
+// SQLiteRepository persists the active theme, the theme history and the
+// user's custom themes in SQLite, implementing both ThemeStateStore and
+// ThemeHistoryStore
+type SQLiteRepository struct {
+	db         *sql.DB
+	maxEntries int
+}
+
+// NewSQLiteRepository creates a new SQLite theme repository
+func NewSQLiteRepository(dbPath string) (*SQLiteRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	repo := &SQLiteRepository{db: db, maxEntries: 10}
+	if err := repo.initialize(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	return repo, nil
+}
+
+// initialize creates the necessary tables
+func (r *SQLiteRepository) initialize() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS theme_state (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		theme_name TEXT NOT NULL,
+		theme_variant TEXT NOT NULL,
+		set_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS theme_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		theme_name TEXT NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS custom_themes (
+		name TEXT PRIMARY KEY,
+		definition TEXT NOT NULL,
+		imported_at DATETIME NOT NULL
+	);
+	`
+
+	_, err := r.db.Exec(schema)
+	return err
+}
+
+// Save replaces the active theme state
+func (r *SQLiteRepository) Save(ctx context.Context, state *ThemeState) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO theme_state (id, theme_name, theme_variant, set_at) VALUES (1, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET theme_name = excluded.theme_name,
+		 theme_variant = excluded.theme_variant, set_at = excluded.set_at`,
+		string(state.ThemeName), string(state.ThemeVariant), state.SetAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save theme state: %w", err)
+	}
+	return nil
+}
+
+// Load returns the active theme state, wrapping fs.ErrNotExist when no
+// theme has been set
+func (r *SQLiteRepository) Load(ctx context.Context) (*ThemeState, error) {
+	var (
+		name, variant string
+		setAt         time.Time
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT theme_name, theme_variant, set_at FROM theme_state WHERE id = 1`,
+	).Scan(&name, &variant, &setAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("no theme state saved: %w", fs.ErrNotExist)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load theme state: %w", err)
+	}
+
+	return &ThemeState{
+		ThemeName:    theme.ThemeName(name),
+		ThemeVariant: theme.ThemeVariant(variant),
+		SetAt:        setAt,
+	}, nil
+}
+
+// Exists checks whether a theme state has been saved
+func (r *SQLiteRepository) Exists(ctx context.Context) (bool, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM theme_state`).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check theme state: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Add records a theme change in history, keeping the newest entries
+func (r *SQLiteRepository) Add(ctx context.Context, themeName theme.ThemeName) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO theme_history (theme_name) VALUES (?)`, string(themeName)); err != nil {
+		return fmt.Errorf("failed to add theme history: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM theme_history WHERE id NOT IN (
+			SELECT id FROM theme_history ORDER BY id DESC LIMIT ?)`,
+		r.maxEntries,
+	); err != nil {
+		return fmt.Errorf("failed to trim theme history: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetPrevious returns the previous theme (second in history)
+func (r *SQLiteRepository) GetPrevious(ctx context.Context) (theme.ThemeName, error) {
+	history, err := r.GetHistory(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Need at least 2 entries (current and previous)
+	if len(history) < 2 {
+		return "", ErrNoThemeHistory
+	}
+	return history[1], nil
+}
+
+// GetHistory returns all themes in history (newest first)
+func (r *SQLiteRepository) GetHistory(ctx context.Context) ([]theme.ThemeName, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT theme_name FROM theme_history ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query theme history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []theme.ThemeName{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan theme history: %w", err)
+		}
+		history = append(history, theme.ThemeName(name))
+	}
+
+	return history, rows.Err()
+}
+
+// RemoveLast removes the most recent theme from history
+func (r *SQLiteRepository) RemoveLast(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM theme_history WHERE id = (SELECT MAX(id) FROM theme_history)`)
+	if err != nil {
+		return fmt.Errorf("failed to remove theme history: %w", err)
+	}
+	return nil
+}
+
+// Clear removes all history
+func (r *SQLiteRepository) Clear(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM theme_history`); err != nil {
+		return fmt.Errorf("failed to clear theme history: %w", err)
+	}
+	return nil
+}
+
+// SaveCustomTheme stores a custom theme, replacing one of the same name
+func (r *SQLiteRepository) SaveCustomTheme(ctx context.Context, def theme.CustomThemeDefinition) error {
+	definition, err := json.Marshal(paletteFile(def))
+	if err != nil {
+		return fmt.Errorf("failed to encode custom theme: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO custom_themes (name, definition, imported_at) VALUES (?, ?, ?)
+		 ON CONFLICT(name) DO UPDATE SET definition = excluded.definition,
+		 imported_at = excluded.imported_at`,
+		def.Name, string(definition), time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save custom theme: %w", err)
+	}
+	return nil
+}
+
+// ListCustomThemes returns the stored custom themes by name
+func (r *SQLiteRepository) ListCustomThemes(ctx context.Context) ([]theme.CustomThemeDefinition, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT definition FROM custom_themes ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query custom themes: %w", err)
+	}
+	defer rows.Close()
+
+	var definitions []theme.CustomThemeDefinition
+	for rows.Next() {
+		var definition string
+		if err := rows.Scan(&definition); err != nil {
+			return nil, fmt.Errorf("failed to scan custom theme: %w", err)
+		}
+		var file paletteFile
+		if err := json.Unmarshal([]byte(definition), &file); err != nil {
+			return nil, fmt.Errorf("failed to decode custom theme: %w", err)
+		}
+		definitions = append(definitions, theme.CustomThemeDefinition(file))
+	}
+
+	return definitions, rows.Err()
+}
+
+// ImportLegacy copies the state and history kept by the file stores of
+// earlier versions, once, into a repository that has no theme state yet
+func (r *SQLiteRepository) ImportLegacy(ctx context.Context, stateStore ThemeStateStore, historyStore ThemeHistoryStore) error {
+	if exists, err := r.Exists(ctx); err != nil || exists {
+		return err
+	}
+	if exists, err := stateStore.Exists(ctx); err != nil || !exists {
+		return err
+	}
+
+	state, err := stateStore.Load(ctx)
+	if err != nil {
+		return err
+	}
+	history, err := historyStore.GetHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	// History is stored oldest first
+	for i := len(history) - 1; i >= 0; i-- {
+		if err := r.Add(ctx, history[i]); err != nil {
+			return err
+		}
+	}
+	return r.Save(ctx, state)
+}
+
+// Close closes the database connection
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
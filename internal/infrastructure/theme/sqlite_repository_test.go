@@ -0,0 +1,135 @@
+package theme_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/theme"
+	themeInfra "github.com/rebelopsio/gohan/internal/infrastructure/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSQLiteRepository(t *testing.T) *themeInfra.SQLiteRepository {
+	t.Helper()
+	repo, err := themeInfra.NewSQLiteRepository(filepath.Join(t.TempDir(), "themes.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestSQLiteRepository_State(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepository(t)
+
+	exists, err := repo.Exists(ctx)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, err = repo.Load(ctx)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	setAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Save(ctx, &themeInfra.ThemeState{ThemeName: theme.ThemeNord, ThemeVariant: theme.ThemeVariantDark, SetAt: setAt}))
+	require.NoError(t, repo.Save(ctx, &themeInfra.ThemeState{ThemeName: theme.ThemeGruvbox, ThemeVariant: theme.ThemeVariantDark, SetAt: setAt}))
+
+	state, err := repo.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, theme.ThemeGruvbox, state.ThemeName, "saving replaces the state")
+	assert.True(t, state.SetAt.Equal(setAt))
+}
+
+func TestSQLiteRepository_History(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns themes newest first", func(t *testing.T) {
+		repo := newSQLiteRepository(t)
+		_, err := repo.GetPrevious(ctx)
+		assert.ErrorIs(t, err, themeInfra.ErrNoThemeHistory)
+
+		require.NoError(t, repo.Add(ctx, theme.ThemeMocha))
+		require.NoError(t, repo.Add(ctx, theme.ThemeNord))
+
+		history, err := repo.GetHistory(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []theme.ThemeName{theme.ThemeNord, theme.ThemeMocha}, history)
+		previous, err := repo.GetPrevious(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, theme.ThemeMocha, previous)
+
+		require.NoError(t, repo.RemoveLast(ctx))
+		history, err = repo.GetHistory(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []theme.ThemeName{theme.ThemeMocha}, history)
+	})
+
+	t.Run("keeps the ten newest entries", func(t *testing.T) {
+		repo := newSQLiteRepository(t)
+		for i := 0; i < 12; i++ {
+			require.NoError(t, repo.Add(ctx, theme.ThemeName(string(rune('a'+i)))))
+		}
+
+		history, err := repo.GetHistory(ctx)
+		require.NoError(t, err)
+		require.Len(t, history, 10)
+		assert.Equal(t, theme.ThemeName("l"), history[0])
+
+		require.NoError(t, repo.Clear(ctx))
+		history, err = repo.GetHistory(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+}
+
+func TestSQLiteRepository_CustomThemes(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepository(t)
+
+	solarized := theme.CustomThemeDefinition{
+		Name:    "solarized",
+		Variant: "dark",
+		Colors:  map[string]string{"base": "#002b36", "text": "#839496", "accent": "#268bd2"},
+	}
+	require.NoError(t, repo.SaveCustomTheme(ctx, solarized))
+	require.NoError(t, repo.SaveCustomTheme(ctx, theme.CustomThemeDefinition{Name: "amber", Variant: "light"}))
+
+	solarized.DisplayName = "Solarized Dark"
+	require.NoError(t, repo.SaveCustomTheme(ctx, solarized))
+
+	definitions, err := repo.ListCustomThemes(ctx)
+	require.NoError(t, err)
+	require.Len(t, definitions, 2)
+	assert.Equal(t, "amber", definitions[0].Name)
+	assert.Equal(t, solarized, definitions[1], "saving again replaces the theme")
+}
+
+func TestSQLiteRepository_ImportLegacy(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	stateStore := themeInfra.NewFileThemeStateStore(filepath.Join(dir, "theme-state.json"))
+	historyStore := themeInfra.NewFileThemeHistoryStore(filepath.Join(dir, "theme-history.json"))
+	require.NoError(t, stateStore.Save(ctx, &themeInfra.ThemeState{ThemeName: theme.ThemeLatte, ThemeVariant: theme.ThemeVariantLight, SetAt: time.Now()}))
+	require.NoError(t, historyStore.Add(ctx, theme.ThemeMocha))
+	require.NoError(t, historyStore.Add(ctx, theme.ThemeLatte))
+
+	repo := newSQLiteRepository(t)
+	require.NoError(t, repo.ImportLegacy(ctx, stateStore, historyStore))
+
+	state, err := repo.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, theme.ThemeLatte, state.ThemeName)
+	history, err := repo.GetHistory(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []theme.ThemeName{theme.ThemeLatte, theme.ThemeMocha}, history)
+
+	t.Run("imports once", func(t *testing.T) {
+		require.NoError(t, historyStore.Add(ctx, theme.ThemeNord))
+		require.NoError(t, repo.ImportLegacy(ctx, stateStore, historyStore))
+
+		history, err := repo.GetHistory(ctx)
+		require.NoError(t, err)
+		assert.Len(t, history, 2)
+	})
+}
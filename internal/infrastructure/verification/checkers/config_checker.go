@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/rebelopsio/gohan/internal/domain/verification"
 )
 
+// maxHyprlandSourceDepth stops source lines that include each other
+const maxHyprlandSourceDepth = 8
+
 // ConfigChecker verifies configuration files
 type ConfigChecker struct {
 	configDir string
@@ -18,6 +22,12 @@ type ConfigChecker struct {
 func NewConfigChecker() *ConfigChecker {
 	homeDir, _ := os.UserHomeDir()
 	configDir := filepath.Join(homeDir, ".config")
+	return NewConfigCheckerWithDir(configDir)
+}
+
+// NewConfigCheckerWithDir creates a config checker for configuration under
+// configDir, usually ~/.config
+func NewConfigCheckerWithDir(configDir string) *ConfigChecker {
 	return &ConfigChecker{configDir: configDir}
 }
 
@@ -31,7 +41,8 @@ func (c *ConfigChecker) Component() verification.ComponentName {
 	return verification.ComponentConfiguration
 }
 
-// Check verifies essential configuration files exist
+// Check verifies essential configuration files exist and that Hyprland can
+// parse its configuration
 func (c *ConfigChecker) Check(ctx context.Context) verification.CheckResult {
 	essentialFiles := []string{
 		filepath.Join(c.configDir, "hypr/hyprland.conf"),
@@ -63,6 +74,33 @@ func (c *ConfigChecker) Check(ctx context.Context) verification.CheckResult {
 		)
 	}
 
+	files := c.hyprlandFiles(essentialFiles[0])
+	var problems []string
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", c.displayPath(file), err))
+			continue
+		}
+		for _, problem := range verification.CheckHyprlandSyntax(string(content)) {
+			problems = append(problems, fmt.Sprintf("%s %s", c.displayPath(file), problem))
+		}
+	}
+
+	if len(problems) > 0 {
+		return verification.NewCheckResult(
+			verification.ComponentConfiguration,
+			verification.StatusFail,
+			verification.SeverityHigh,
+			"Hyprland configuration has syntax errors",
+			append([]string{"Problems:"}, problems...),
+			[]string{
+				"Fix the lines listed, then reload: hyprctl reload",
+				"Or redeploy gohan's configuration, backing up yours: gohan config deploy --components hyprland",
+			},
+		)
+	}
+
 	return verification.NewCheckResult(
 		verification.ComponentConfiguration,
 		verification.StatusPass,
@@ -71,7 +109,65 @@ func (c *ConfigChecker) Check(ctx context.Context) verification.CheckResult {
 		[]string{
 			fmt.Sprintf("Checked %d essential files", len(essentialFiles)),
 			fmt.Sprintf("All %d files are readable", readableFiles),
+			fmt.Sprintf("Hyprland configuration parses (%d files)", len(files)),
 		},
 		nil,
 	)
 }
+
+// hyprlandFiles returns the main Hyprland configuration followed by the
+// files its source lines include, each once. Sourced files that do not
+// exist are left out, as Hyprland skips them.
+func (c *ConfigChecker) hyprlandFiles(main string) []string {
+	files := []string{main}
+	seen := map[string]bool{main: true}
+
+	var walk func(path string, depth int)
+	walk = func(path string, depth int) {
+		if depth > maxHyprlandSourceDepth {
+			return
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			keyword, value, ok := strings.Cut(line, "=")
+			if !ok || strings.TrimSpace(keyword) != "source" {
+				continue
+			}
+			matches, _ := filepath.Glob(c.resolveSource(strings.TrimSpace(value), filepath.Dir(path)))
+			for _, match := range matches {
+				if seen[match] {
+					continue
+				}
+				seen[match] = true
+				files = append(files, match)
+				walk(match, depth+1)
+			}
+		}
+	}
+	walk(main, 0)
+	return files
+}
+
+// resolveSource expands ~ and makes a sourced path relative to the
+// including file
+func (c *ConfigChecker) resolveSource(path, dir string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		path = filepath.Join(filepath.Dir(c.configDir), path[1:])
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path
+}
+
+// displayPath shortens paths under the configuration directory, as in
+// hypr/hyprland.conf
+func (c *ConfigChecker) displayPath(path string) string {
+	if rel, err := filepath.Rel(c.configDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
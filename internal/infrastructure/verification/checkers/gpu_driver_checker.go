@@ -0,0 +1,117 @@
+package checkers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/gpudriver"
+)
+
+// GPUDriverChecker verifies that installed NVIDIA and AMD drivers are
+// loaded. Until the machine reboots after installing them, the kernel
+// keeps using the previous driver, and without nouveau blacklisted or
+// NVIDIA kernel mode setting Hyprland cannot use the GPU.
+type GPUDriverChecker struct {
+	inspector *gpudriver.Inspector
+}
+
+// NewGPUDriverChecker creates a checker for the running system
+func NewGPUDriverChecker() *GPUDriverChecker {
+	return &GPUDriverChecker{inspector: gpudriver.NewInspector()}
+}
+
+// Name returns the checker name
+func (c *GPUDriverChecker) Name() string {
+	return "GPU Driver"
+}
+
+// Component returns the component being checked
+func (c *GPUDriverChecker) Component() verification.ComponentName {
+	return verification.ComponentGPU
+}
+
+// Check validates the kernel setup and module load state of each installed
+// driver
+func (c *GPUDriverChecker) Check(ctx context.Context) verification.CheckResult {
+	drivers := c.inspector.InstalledDrivers(ctx)
+	if len(drivers) == 0 {
+		return verification.NewCheckResult(
+			verification.ComponentGPU,
+			verification.StatusPass,
+			verification.SeverityLow,
+			"No NVIDIA or AMD driver installed",
+			[]string{"Nothing to check for the kernel's built-in drivers"},
+			nil,
+		)
+	}
+
+	var details, suggestions []string
+	reboot := false
+	for _, driver := range drivers {
+		state, err := c.inspector.Inspect(driver)
+		if err != nil {
+			return verification.NewCheckResult(
+				verification.ComponentGPU,
+				verification.StatusWarning,
+				verification.SeverityMedium,
+				"Cannot check the GPU driver",
+				[]string{fmt.Sprintf("Error: %v", err)},
+				nil,
+			)
+		}
+
+		details = append(details, fmt.Sprintf("%s: loaded modules %s", driver, loadedGPUModules(state)))
+		for _, problem := range state.ConfigurationProblems() {
+			details = append(details, "Problem: "+problem.Message)
+			suggestions = append(suggestions, problem.Fix)
+		}
+		for _, problem := range state.LoadProblems() {
+			details = append(details, "Problem: "+problem.Message)
+			reboot = reboot || problem.Reboot
+		}
+	}
+
+	if len(suggestions) > 0 || reboot {
+		message := "The GPU driver needs a reboot to load"
+		if len(suggestions) > 0 {
+			message = "The GPU driver's kernel setup is incomplete"
+		}
+		if reboot {
+			suggestions = append(suggestions, "Reboot to load the driver: sudo reboot")
+		}
+		return verification.NewCheckResult(
+			verification.ComponentGPU,
+			verification.StatusWarning,
+			verification.SeverityHigh,
+			message,
+			details,
+			suggestions,
+		)
+	}
+
+	return verification.NewCheckResult(
+		verification.ComponentGPU,
+		verification.StatusPass,
+		verification.SeverityLow,
+		"The GPU driver is loaded",
+		details,
+		nil,
+	)
+}
+
+// loadedGPUModules lists the GPU modules among the loaded ones
+func loadedGPUModules(state installation.GPUDriverState) string {
+	var modules []string
+	for _, module := range []string{installation.ModuleNVIDIA, installation.ModuleNVIDIADRM, installation.ModuleNouveau, installation.ModuleAMDGPU} {
+		if state.IsLoaded(module) {
+			modules = append(modules, module)
+		}
+	}
+	if len(modules) == 0 {
+		return "none"
+	}
+	return strings.Join(modules, ", ")
+}
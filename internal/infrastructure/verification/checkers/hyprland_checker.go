@@ -6,10 +6,14 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/rebelopsio/gohan/internal/domain/verification"
 )
 
+// versionCommandTimeout bounds the Hyprland --version probe
+const versionCommandTimeout = 10 * time.Second
+
 // HyprlandChecker verifies Hyprland installation
 type HyprlandChecker struct{}
 
@@ -86,7 +90,9 @@ func (c *HyprlandChecker) Check(ctx context.Context) verification.CheckResult {
 	}
 
 	// Try to get version
-	cmd := exec.CommandContext(ctx, "Hyprland", "--version")
+	versionCtx, cancel := context.WithTimeout(ctx, versionCommandTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(versionCtx, "Hyprland", "--version")
 	output, err := cmd.Output()
 	version := "unknown"
 	if err == nil {
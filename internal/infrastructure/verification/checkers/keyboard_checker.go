@@ -0,0 +1,184 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+)
+
+// defaultXKBRulesPath lists the layouts and variants xkb can load
+const defaultXKBRulesPath = "/usr/share/X11/xkb/rules/evdev.lst"
+
+// KeyboardChecker confirms the configured keyboard layout exists in xkb and
+// is the one Hyprland uses. A layout xkb cannot load, or a different layout
+// than expected, means the password typed at the lock screen is not the one
+// the user thinks they typed.
+type KeyboardChecker struct {
+	configDir string
+	rulesPath string
+	getOption func(ctx context.Context, option string) (string, error)
+}
+
+// NewKeyboardChecker creates a checker for ~/.config/hypr
+func NewKeyboardChecker() *KeyboardChecker {
+	homeDir, _ := os.UserHomeDir()
+	return &KeyboardChecker{
+		configDir: filepath.Join(homeDir, ".config", "hypr"),
+		rulesPath: defaultXKBRulesPath,
+		getOption: hyprctlOption,
+	}
+}
+
+// Name returns the checker name
+func (c *KeyboardChecker) Name() string {
+	return "Keyboard Layout"
+}
+
+// Component returns the component being checked
+func (c *KeyboardChecker) Component() verification.ComponentName {
+	return verification.ComponentKeyboard
+}
+
+// Check validates the configured layout against xkb and the running
+// compositor
+func (c *KeyboardChecker) Check(ctx context.Context) verification.CheckResult {
+	configured, source := c.configuredLayout()
+	if configured.IsEmpty() {
+		return verification.NewCheckResult(
+			verification.ComponentKeyboard,
+			verification.StatusPass,
+			verification.SeverityLow,
+			"No keyboard layout configured, Hyprland uses us",
+			nil,
+			nil,
+		)
+	}
+
+	details := []string{fmt.Sprintf("Configured: %s (%s)", configured, source)}
+
+	content, err := os.ReadFile(c.rulesPath)
+	if err != nil {
+		return verification.NewCheckResult(
+			verification.ComponentKeyboard,
+			verification.StatusWarning,
+			verification.SeverityMedium,
+			"Cannot verify the keyboard layout against xkb",
+			append(details, fmt.Sprintf("Error: %v", err)),
+			[]string{"Install the xkb data: sudo apt install xkb-data"},
+		)
+	}
+
+	if missing := configured.MissingFrom(verification.ParseXKBRegistry(string(content))); len(missing) > 0 {
+		for _, entry := range missing {
+			details = append(details, fmt.Sprintf("Unknown to xkb: %s", entry))
+		}
+		return verification.NewCheckResult(
+			verification.ComponentKeyboard,
+			verification.StatusFail,
+			verification.SeverityCritical,
+			"The configured keyboard layout does not exist",
+			details,
+			[]string{
+				"Do not lock the screen or log out until this is fixed; your password may not be typeable",
+				fmt.Sprintf("Fix kb_layout and kb_variant in %s", source),
+				fmt.Sprintf("List the available layouts: grep -A200 '! layout' %s", c.rulesPath),
+			},
+		)
+	}
+
+	active, err := c.activeLayout(ctx)
+	if err != nil {
+		return verification.NewCheckResult(
+			verification.ComponentKeyboard,
+			verification.StatusPass,
+			verification.SeverityLow,
+			fmt.Sprintf("Keyboard layout %s is available", configured),
+			append(details, fmt.Sprintf("Not compared with Hyprland: %v", err)),
+			nil,
+		)
+	}
+
+	details = append(details, fmt.Sprintf("Hyprland: %s", active))
+	if !active.Equal(configured) {
+		return verification.NewCheckResult(
+			verification.ComponentKeyboard,
+			verification.StatusFail,
+			verification.SeverityHigh,
+			"Hyprland is using a different keyboard layout than configured",
+			details,
+			[]string{
+				"Reload the configuration: hyprctl reload",
+				"Type your password in a terminal first to confirm the layout before locking the screen",
+			},
+		)
+	}
+
+	return verification.NewCheckResult(
+		verification.ComponentKeyboard,
+		verification.StatusPass,
+		verification.SeverityLow,
+		fmt.Sprintf("Hyprland is using keyboard layout %s", configured),
+		details,
+		nil,
+	)
+}
+
+// configuredLayout reads the layout from input.conf, which hyprland.conf
+// sources after its own settings, falling back to hyprland.conf
+func (c *KeyboardChecker) configuredLayout() (verification.KeyboardLayout, string) {
+	var layout, variant, source string
+	for _, name := range []string{"hyprland.conf", "input.conf"} {
+		path := filepath.Join(c.configDir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if l, v, found := verification.ParseInputKeyboard(string(content)); found {
+			layout, variant, source = l, v, path
+		}
+	}
+	return verification.NewKeyboardLayout(layout, variant), source
+}
+
+// activeLayout asks the running compositor for its layout
+func (c *KeyboardChecker) activeLayout(ctx context.Context) (verification.KeyboardLayout, error) {
+	layout, err := c.getOption(ctx, "input:kb_layout")
+	if err != nil {
+		return verification.KeyboardLayout{}, err
+	}
+	variant, err := c.getOption(ctx, "input:kb_variant")
+	if err != nil {
+		return verification.KeyboardLayout{}, err
+	}
+	return verification.NewKeyboardLayout(layout, variant), nil
+}
+
+// hyprctlOption reads a string option from the running compositor
+func hyprctlOption(ctx context.Context, option string) (string, error) {
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") == "" {
+		return "", fmt.Errorf("Hyprland is not running")
+	}
+
+	output, err := exec.CommandContext(ctx, "hyprctl", "-j", "getoption", option).Output()
+	if err != nil {
+		return "", fmt.Errorf("hyprctl getoption %s: %w", option, err)
+	}
+
+	var value struct {
+		Str string `json:"str"`
+	}
+	if err := json.Unmarshal(output, &value); err != nil {
+		return "", fmt.Errorf("hyprctl getoption %s: %w", option, err)
+	}
+	// Unset string options are reported as "[[EMPTY]]"
+	if value.Str == "[[EMPTY]]" {
+		return "", nil
+	}
+	return strings.TrimSpace(value.Str), nil
+}
@@ -0,0 +1,141 @@
+package checkers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+)
+
+// historySearchLimit bounds how many recent records are searched for the
+// last successful installation
+const historySearchLimit = 50
+
+// InstalledPackageSource lists the packages gohan installed
+type InstalledPackageSource interface {
+	InstalledPackages(ctx context.Context) ([]string, error)
+}
+
+// PackageVerifier checks an installed package's dpkg status and binaries
+type PackageVerifier interface {
+	VerifyPackage(ctx context.Context, packageName string) error
+}
+
+// PackagesChecker verifies that the packages gohan installed are still
+// installed and intact
+type PackagesChecker struct {
+	source   InstalledPackageSource
+	verifier PackageVerifier
+}
+
+// NewPackagesChecker creates a checker verifying the packages source lists
+func NewPackagesChecker(source InstalledPackageSource, verifier PackageVerifier) *PackagesChecker {
+	return &PackagesChecker{source: source, verifier: verifier}
+}
+
+// Name returns the checker name
+func (c *PackagesChecker) Name() string {
+	return "Installed Packages"
+}
+
+// Component returns the component being checked
+func (c *PackagesChecker) Component() verification.ComponentName {
+	return verification.ComponentDependencies
+}
+
+// Check verifies each package of the last successful installation
+func (c *PackagesChecker) Check(ctx context.Context) verification.CheckResult {
+	packages, err := c.source.InstalledPackages(ctx)
+	if err != nil {
+		return verification.NewCheckResult(
+			verification.ComponentDependencies,
+			verification.StatusWarning,
+			verification.SeverityMedium,
+			"Cannot read which packages gohan installed",
+			[]string{fmt.Sprintf("Error: %v", err)},
+			nil,
+		)
+	}
+	if len(packages) == 0 {
+		return verification.NewCheckResult(
+			verification.ComponentDependencies,
+			verification.StatusPass,
+			verification.SeverityLow,
+			"No installation recorded",
+			[]string{"Nothing to check until gohan installs packages"},
+			nil,
+		)
+	}
+
+	var broken, details []string
+	for _, name := range packages {
+		if err := c.verifier.VerifyPackage(ctx, name); err != nil {
+			broken = append(broken, name)
+			details = append(details, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+
+	if len(broken) > 0 {
+		return verification.NewCheckResult(
+			verification.ComponentDependencies,
+			verification.StatusFail,
+			verification.SeverityHigh,
+			fmt.Sprintf("%d of %d installed packages are missing or damaged", len(broken), len(packages)),
+			details,
+			[]string{"Reinstall them: sudo apt install --reinstall " + strings.Join(broken, " ")},
+		)
+	}
+
+	return verification.NewCheckResult(
+		verification.ComponentDependencies,
+		verification.StatusPass,
+		verification.SeverityLow,
+		"Installed packages are intact",
+		[]string{fmt.Sprintf("Verified %d packages", len(packages))},
+		nil,
+	)
+}
+
+// HistoryPackageSource lists the packages of the most recent successful
+// installation recorded in any of the given histories
+type HistoryPackageSource struct {
+	repos []history.Repository
+}
+
+// NewHistoryPackageSource creates a source searching repos, such as the
+// user and system-wide histories
+func NewHistoryPackageSource(repos ...history.Repository) *HistoryPackageSource {
+	return &HistoryPackageSource{repos: repos}
+}
+
+// InstalledPackages returns the package names of the last successful
+// installation, or none if no installation succeeded yet
+func (s *HistoryPackageSource) InstalledPackages(ctx context.Context) ([]string, error) {
+	var latest *history.InstallationRecord
+	for _, repo := range s.repos {
+		records, err := repo.FindRecent(ctx, historySearchLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range records {
+			if !records[i].WasSuccessful() {
+				continue
+			}
+			if latest == nil || records[i].RecordedAt().After(latest.RecordedAt()) {
+				latest = &records[i]
+			}
+			break
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	var names []string
+	for _, pkg := range latest.Metadata().InstalledPackages() {
+		names = append(names, pkg.Name())
+	}
+	return names, nil
+}
@@ -0,0 +1,136 @@
+package checkers
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+)
+
+// PermissionsChecker verifies that deployed configuration belongs to the
+// user and cannot be modified by anyone else
+type PermissionsChecker struct {
+	configDir       string
+	managedDirs     []string
+	sensitiveFiles  []string
+	strictSensitive bool
+}
+
+// NewPermissionsChecker creates a new permissions checker. With
+// strictSensitive, sensitive files such as hyprlock.conf must also not be
+// readable by other users.
+func NewPermissionsChecker(strictSensitive bool) *PermissionsChecker {
+	homeDir, _ := os.UserHomeDir()
+	return &PermissionsChecker{
+		configDir:       filepath.Join(homeDir, ".config"),
+		managedDirs:     []string{"hypr", "waybar", "kitty", "alacritty", "fuzzel"},
+		sensitiveFiles:  []string{"hypr/hyprlock.conf"},
+		strictSensitive: strictSensitive,
+	}
+}
+
+// Name returns the checker name
+func (c *PermissionsChecker) Name() string {
+	return "Configuration Permissions"
+}
+
+// Component returns the component being checked
+func (c *PermissionsChecker) Component() verification.ComponentName {
+	return verification.ComponentPermissions
+}
+
+// Check walks the managed configuration directories for entries owned by
+// another user or writable by group or others
+func (c *PermissionsChecker) Check(ctx context.Context) verification.CheckResult {
+	uid := expectedOwner()
+	problems := []string{}
+	checked := 0
+
+	for _, dir := range c.managedDirs {
+		root := filepath.Join(c.configDir, dir)
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return nil
+				}
+				problems = append(problems, fmt.Sprintf("%s: %v", path, err))
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if d.Type()&fs.ModeSymlink != 0 {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			checked++
+
+			if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != uid {
+				problems = append(problems, fmt.Sprintf("%s is owned by uid %d", path, stat.Uid))
+			}
+			if info.Mode().Perm()&0022 != 0 {
+				problems = append(problems, fmt.Sprintf("%s is writable by others (%04o)", path, info.Mode().Perm()))
+			}
+			return nil
+		})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", root, err))
+		}
+	}
+
+	if c.strictSensitive {
+		for _, file := range c.sensitiveFiles {
+			path := filepath.Join(c.configDir, file)
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if info.Mode().Perm()&0077 != 0 {
+				problems = append(problems, fmt.Sprintf("%s is accessible to other users (%04o)", path, info.Mode().Perm()))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return verification.NewCheckResult(
+			verification.ComponentPermissions,
+			verification.StatusWarning,
+			verification.SeverityMedium,
+			"Configuration files have unsafe ownership or permissions",
+			problems,
+			[]string{
+				"Redeploy configuration as your user: gohan config deploy",
+				fmt.Sprintf("Or fix ownership: chown -R %d %s", uid, c.configDir),
+			},
+		)
+	}
+
+	return verification.NewCheckResult(
+		verification.ComponentPermissions,
+		verification.StatusPass,
+		verification.SeverityLow,
+		"Configuration ownership and permissions are correct",
+		[]string{fmt.Sprintf("Checked %d files and directories", checked)},
+		nil,
+	)
+}
+
+// expectedOwner is the uid configuration should belong to: the invoking
+// user under sudo, otherwise the current user
+func expectedOwner() int {
+	if os.Geteuid() == 0 {
+		if uid, err := strconv.Atoi(os.Getenv("SUDO_UID")); err == nil {
+			return uid
+		}
+	}
+	return os.Getuid()
+}
@@ -0,0 +1,195 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/portals"
+)
+
+// PortalChecker verifies that the Hyprland session's portals are served by
+// the right backends. When GNOME's or KDE's backend takes over screen
+// sharing, or no backend offers file pickers, sharing a screen and
+// choosing files in applications break.
+type PortalChecker struct {
+	configPath string
+	detector   *portals.Detector
+	busctl     func(ctx context.Context, args ...string) (string, error)
+}
+
+// NewPortalChecker creates a checker for ~/.config/xdg-desktop-portal
+func NewPortalChecker() *PortalChecker {
+	homeDir, _ := os.UserHomeDir()
+	return &PortalChecker{
+		configPath: filepath.Join(homeDir, ".config", "xdg-desktop-portal", installation.PortalsConfigName),
+		detector:   portals.NewDetector(),
+		busctl:     sessionBusctl,
+	}
+}
+
+// Name returns the checker name
+func (c *PortalChecker) Name() string {
+	return "Desktop Portals"
+}
+
+// Component returns the component being checked
+func (c *PortalChecker) Component() verification.ComponentName {
+	return verification.ComponentPortal
+}
+
+// Check validates the installed backends, the portal configuration and,
+// in a running Hyprland session, the backends D-Bus reports
+func (c *PortalChecker) Check(ctx context.Context) verification.CheckResult {
+	selection, err := c.detector.Selection()
+	if err != nil {
+		return verification.NewCheckResult(
+			verification.ComponentPortal,
+			verification.StatusWarning,
+			verification.SeverityMedium,
+			"Cannot list the installed portal backends",
+			[]string{fmt.Sprintf("Error: %v", err)},
+			nil,
+		)
+	}
+
+	if !selection.IsInstalled(installation.PortalHyprland) {
+		return verification.NewCheckResult(
+			verification.ComponentPortal,
+			verification.StatusFail,
+			verification.SeverityHigh,
+			"The Hyprland portal backend is not installed",
+			[]string{"Screen sharing and screenshots from applications will not work"},
+			[]string{"Install it: sudo apt install " + installation.PortalHyprland.Package()},
+		)
+	}
+
+	installed := make([]string, 0, len(selection.Installed()))
+	for _, backend := range selection.Installed() {
+		installed = append(installed, backend.String())
+	}
+	details := []string{"Installed backends: " + strings.Join(installed, ", ")}
+	var problems, suggestions []string
+
+	if _, ok := selection.FileChooser(); !ok {
+		problems = append(problems, "No backend can show file pickers")
+		suggestions = append(suggestions, "Install one: sudo apt install "+installation.PortalGTK.Package())
+	}
+	for _, conflict := range selection.Conflicts() {
+		details = append(details, fmt.Sprintf("%s %s", conflict.Backend.Package(), conflict.Reason))
+		if conflict.Backend == installation.PortalWLR {
+			problems = append(problems, conflict.Backend.Package()+" is installed")
+			suggestions = append(suggestions, "Remove it: sudo apt remove "+conflict.Backend.Package())
+		}
+	}
+
+	content, err := os.ReadFile(c.configPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return verification.NewCheckResult(
+			verification.ComponentPortal,
+			verification.StatusFail,
+			verification.SeverityMedium,
+			"No portal configuration for the Hyprland session",
+			append(details, fmt.Sprintf("Missing: %s", c.configPath),
+				"xdg-desktop-portal may pick GNOME's or KDE's backend, which breaks screen sharing"),
+			append(suggestions, "Deploy it: gohan config deploy --components portals"),
+		)
+	}
+	if err != nil {
+		return verification.NewCheckResult(
+			verification.ComponentPortal,
+			verification.StatusWarning,
+			verification.SeverityMedium,
+			"Cannot read the portal configuration",
+			append(details, fmt.Sprintf("Error: %v", err)),
+			suggestions,
+		)
+	}
+
+	config, err := installation.ParsePortalsConfig(string(content))
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("%s is invalid: %v", c.configPath, err))
+		suggestions = append(suggestions, "Deploy it again: gohan config deploy --components portals")
+	} else if mismatches := config.Mismatches(selection); len(mismatches) > 0 {
+		problems = append(problems, mismatches...)
+		suggestions = append(suggestions, "Deploy it again: gohan config deploy --components portals")
+	}
+
+	if inHyprlandSession() {
+		problems = append(problems, c.checkBus(ctx, selection)...)
+	} else {
+		details = append(details, "Not checked over D-Bus: not running in a Hyprland session")
+	}
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			details = append(details, "Problem: "+problem)
+		}
+		return verification.NewCheckResult(
+			verification.ComponentPortal,
+			verification.StatusWarning,
+			verification.SeverityMedium,
+			"Portal backends are not set up as expected",
+			details,
+			append(suggestions, "Restart the portal after changes: systemctl --user restart xdg-desktop-portal"),
+		)
+	}
+
+	return verification.NewCheckResult(
+		verification.ComponentPortal,
+		verification.StatusPass,
+		verification.SeverityLow,
+		"Portals are served by the Hyprland and file picker backends",
+		details,
+		nil,
+	)
+}
+
+// checkBus asks the running portal for each interface and whether the
+// chosen backends are running. The portal starts the backends it selected,
+// so a chosen backend that is not running means another one took over.
+func (c *PortalChecker) checkBus(ctx context.Context, selection installation.PortalSelection) []string {
+	var problems []string
+	checked := make(map[installation.PortalBackend]bool)
+	for _, preference := range selection.Preferences() {
+		if _, err := c.busctl(ctx, "get-property", "org.freedesktop.portal.Desktop",
+			"/org/freedesktop/portal/desktop", preference.Interface.FrontendInterface(), "version"); err != nil {
+			problems = append(problems, fmt.Sprintf("The %s portal is not available: %v", preference.Interface.ShortName(), err))
+			continue
+		}
+
+		if checked[preference.Backend] {
+			continue
+		}
+		checked[preference.Backend] = true
+		owned, err := c.busctl(ctx, "call", "org.freedesktop.DBus", "/org/freedesktop/DBus",
+			"org.freedesktop.DBus", "NameHasOwner", "s", "org.freedesktop.impl.portal.desktop."+preference.Backend.String())
+		if err == nil && strings.TrimSpace(owned) != "b true" {
+			problems = append(problems, fmt.Sprintf("The %s backend is not running; another backend serves %s",
+				preference.Backend, preference.Interface.ShortName()))
+		}
+	}
+	return problems
+}
+
+// inHyprlandSession returns true inside a Hyprland session with a session
+// bus
+func inHyprlandSession() bool {
+	return os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" && os.Getenv("DBUS_SESSION_BUS_ADDRESS") != ""
+}
+
+// sessionBusctl runs busctl on the user's session bus
+func sessionBusctl(ctx context.Context, args ...string) (string, error) {
+	output, err := exec.CommandContext(ctx, "busctl", append([]string{"--user"}, args...)...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
@@ -0,0 +1,147 @@
+package checkers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+)
+
+// defaultWaylandSessionsDir holds the sessions display managers offer
+const defaultWaylandSessionsDir = "/usr/share/wayland-sessions"
+
+// SessionFileChecker verifies that Hyprland can be started: a display
+// manager needs a session file for it, and without one the TTY launcher
+// gohan post-install sets up must exist
+type SessionFileChecker struct {
+	sessionsDir string
+	homeDir     string
+}
+
+// NewSessionFileChecker creates a checker for the system session directory
+func NewSessionFileChecker() *SessionFileChecker {
+	homeDir, _ := os.UserHomeDir()
+	return NewSessionFileCheckerWithDirs(defaultWaylandSessionsDir, homeDir)
+}
+
+// NewSessionFileCheckerWithDirs creates a checker looking for session files
+// in sessionsDir and the TTY launcher under homeDir
+func NewSessionFileCheckerWithDirs(sessionsDir, homeDir string) *SessionFileChecker {
+	return &SessionFileChecker{sessionsDir: sessionsDir, homeDir: homeDir}
+}
+
+// Name returns the checker name
+func (c *SessionFileChecker) Name() string {
+	return "Display Manager Session"
+}
+
+// Component returns the component being checked
+func (c *SessionFileChecker) Component() verification.ComponentName {
+	return verification.ComponentDisplayManager
+}
+
+// Check looks for a Hyprland session file whose command exists
+func (c *SessionFileChecker) Check(ctx context.Context) verification.CheckResult {
+	sessionFile, command := c.findSession()
+	if sessionFile == "" {
+		launcher := filepath.Join(c.homeDir, ".local", "bin", "start-hyprland")
+		if _, err := os.Stat(launcher); err == nil {
+			return verification.NewCheckResult(
+				verification.ComponentDisplayManager,
+				verification.StatusPass,
+				verification.SeverityLow,
+				"Hyprland starts from the TTY",
+				[]string{"Launcher: " + launcher},
+				nil,
+			)
+		}
+		return verification.NewCheckResult(
+			verification.ComponentDisplayManager,
+			verification.StatusFail,
+			verification.SeverityHigh,
+			"No Hyprland session for display managers",
+			[]string{fmt.Sprintf("No session in %s starts Hyprland", c.sessionsDir)},
+			[]string{
+				"Set up a display manager: gohan post-install --display-manager sddm",
+				"Or reinstall Hyprland, which ships a session file: sudo apt install --reinstall hyprland",
+			},
+		)
+	}
+
+	if !commandExists(command) {
+		return verification.NewCheckResult(
+			verification.ComponentDisplayManager,
+			verification.StatusFail,
+			verification.SeverityHigh,
+			"The Hyprland session starts a missing command",
+			[]string{
+				"Session: " + sessionFile,
+				"Command: " + command,
+			},
+			[]string{"Reinstall Hyprland: sudo apt install --reinstall hyprland"},
+		)
+	}
+
+	return verification.NewCheckResult(
+		verification.ComponentDisplayManager,
+		verification.StatusPass,
+		verification.SeverityLow,
+		"Hyprland session is available",
+		[]string{
+			"Session: " + sessionFile,
+			"Command: " + command,
+		},
+		nil,
+	)
+}
+
+// findSession returns the first session file starting Hyprland and the
+// command it runs
+func (c *SessionFileChecker) findSession() (string, string) {
+	files, _ := filepath.Glob(filepath.Join(c.sessionsDir, "*.desktop"))
+	for _, file := range files {
+		command := desktopExec(file)
+		// Covers Hyprland itself and wrappers such as start-hyprland
+		if strings.Contains(strings.ToLower(filepath.Base(command)), "hyprland") {
+			return file, command
+		}
+	}
+	return "", ""
+}
+
+// desktopExec returns the command of a desktop entry's Exec key, without
+// its arguments
+func desktopExec(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok || strings.TrimSpace(key) != "Exec" {
+			continue
+		}
+		if fields := strings.Fields(value); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// commandExists reports whether command is an executable path or on PATH
+func commandExists(command string) bool {
+	if filepath.IsAbs(command) {
+		info, err := os.Stat(command)
+		return err == nil && info.Mode()&0111 != 0
+	}
+	_, err := exec.LookPath(command)
+	return err == nil
+}
@@ -0,0 +1,196 @@
+package checkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+)
+
+// defaultSmokeTestDuration is how long the compositor must stay up
+const defaultSmokeTestDuration = 5 * time.Second
+
+// smokeTestOutputLines bounds the compositor output shown on failure
+const smokeTestOutputLines = 10
+
+// SessionSmokeChecker starts Hyprland on the headless backend with the
+// user's configuration, confirming the compositor comes up before the user
+// leaves their current session
+type SessionSmokeChecker struct {
+	configPath string
+	duration   time.Duration
+}
+
+// NewSessionSmokeChecker creates a checker for ~/.config/hypr/hyprland.conf
+func NewSessionSmokeChecker() *SessionSmokeChecker {
+	homeDir, _ := os.UserHomeDir()
+	return &SessionSmokeChecker{
+		configPath: filepath.Join(homeDir, ".config", "hypr", "hyprland.conf"),
+		duration:   defaultSmokeTestDuration,
+	}
+}
+
+// Name returns the checker name
+func (c *SessionSmokeChecker) Name() string {
+	return "Compositor Startup"
+}
+
+// Component returns the component being checked
+func (c *SessionSmokeChecker) Component() verification.ComponentName {
+	return verification.ComponentCompositor
+}
+
+// Check launches a headless Hyprland and reports whether it stays up
+func (c *SessionSmokeChecker) Check(ctx context.Context) verification.CheckResult {
+	path, err := exec.LookPath("Hyprland")
+	if err != nil {
+		return c.skipped("Hyprland is not installed", "Install Hyprland: gohan install")
+	}
+	if os.Geteuid() == 0 {
+		return c.skipped("Hyprland refuses to run as root", "Run the smoke test as your user: gohan doctor --smoke-test")
+	}
+	if _, err := os.Stat(c.configPath); err != nil {
+		return c.skipped(fmt.Sprintf("No configuration at %s", c.configPath), "Deploy configuration: gohan config deploy")
+	}
+
+	workDir, err := os.MkdirTemp("", "gohan-smoke-*")
+	if err != nil {
+		return c.skipped(fmt.Sprintf("Cannot create a working directory: %v", err), "")
+	}
+	defer os.RemoveAll(workDir)
+
+	// Load the user's configuration, then give the headless output a mode
+	wrapper := filepath.Join(workDir, "hyprland.conf")
+	content := fmt.Sprintf("# Generated by gohan doctor --smoke-test\nsource = %s\nmonitor = , 1920x1080@60, auto, 1\n", c.configPath)
+	if err := os.WriteFile(wrapper, []byte(content), 0600); err != nil {
+		return c.skipped(fmt.Sprintf("Cannot write the test configuration: %v", err), "")
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, c.duration)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(runCtx, path, "--config", wrapper)
+	cmd.Env = smokeTestEnv(workDir)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	// exec-once programs run in the compositor's process group; stop them too
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+	cmd.WaitDelay = 2 * time.Second
+
+	err = cmd.Run()
+
+	switch {
+	case ctx.Err() != nil:
+		return c.skipped("Smoke test was interrupted", "")
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		// Still running when the time was up: the compositor started
+		if configErrors := matchingLines(output.String(), "Config error"); len(configErrors) > 0 {
+			return verification.NewCheckResult(
+				verification.ComponentCompositor,
+				verification.StatusWarning,
+				verification.SeverityMedium,
+				"Hyprland starts, but the configuration has errors",
+				configErrors,
+				[]string{
+					fmt.Sprintf("Fix the reported lines in %s", c.configPath),
+					"Restore the previous configuration: gohan backup list, then gohan backup restore <backup-id>",
+				},
+			)
+		}
+		return verification.NewCheckResult(
+			verification.ComponentCompositor,
+			verification.StatusPass,
+			verification.SeverityLow,
+			fmt.Sprintf("Hyprland started with your configuration and ran for %s", c.duration),
+			[]string{fmt.Sprintf("Configuration: %s", c.configPath)},
+			nil,
+		)
+	default:
+		details := []string{fmt.Sprintf("Exit: %v", err)}
+		details = append(details, lastLines(output.String(), smokeTestOutputLines)...)
+		return verification.NewCheckResult(
+			verification.ComponentCompositor,
+			verification.StatusFail,
+			verification.SeverityHigh,
+			"Hyprland exited during startup",
+			details,
+			[]string{
+				"Do not log out until this is resolved; your current session still works",
+				"Check the GPU driver: gohan preflight check",
+				fmt.Sprintf("Review %s, or restore the previous configuration: gohan backup restore <backup-id>", c.configPath),
+			},
+		)
+	}
+}
+
+// skipped reports a smoke test that could not run
+func (c *SessionSmokeChecker) skipped(reason, suggestion string) verification.CheckResult {
+	var suggestions []string
+	if suggestion != "" {
+		suggestions = []string{suggestion}
+	}
+	return verification.NewCheckResult(
+		verification.ComponentCompositor,
+		verification.StatusWarning,
+		verification.SeverityLow,
+		"Compositor smoke test skipped",
+		[]string{reason},
+		suggestions,
+	)
+}
+
+// smokeTestEnv runs Hyprland on the headless backend, detached from any
+// running session
+func smokeTestEnv(workDir string) []string {
+	env := make([]string, 0, len(os.Environ())+3)
+	for _, entry := range os.Environ() {
+		name, _, _ := strings.Cut(entry, "=")
+		switch name {
+		case "WAYLAND_DISPLAY", "DISPLAY", "HYPRLAND_INSTANCE_SIGNATURE", "WLR_BACKENDS":
+			continue
+		}
+		env = append(env, entry)
+	}
+
+	env = append(env, "WLR_BACKENDS=headless", "WLR_LIBINPUT_NO_DEVICES=1")
+	if os.Getenv("XDG_RUNTIME_DIR") == "" {
+		env = append(env, "XDG_RUNTIME_DIR="+workDir)
+	}
+	return env
+}
+
+// matchingLines returns the output lines containing substr
+func matchingLines(output, substr string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, substr) {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines
+}
+
+// lastLines returns up to n trailing non-empty output lines
+func lastLines(output string, n int) []string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
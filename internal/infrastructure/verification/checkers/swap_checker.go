@@ -0,0 +1,87 @@
+package checkers
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rebelopsio/gohan/internal/domain/postinstall"
+	"github.com/rebelopsio/gohan/internal/domain/verification"
+)
+
+// SwapChecker reports swap and zram status
+type SwapChecker struct {
+	swapsPath string
+}
+
+// NewSwapChecker creates a new swap checker
+func NewSwapChecker() *SwapChecker {
+	return &SwapChecker{swapsPath: "/proc/swaps"}
+}
+
+// Name returns the checker name
+func (c *SwapChecker) Name() string {
+	return "Swap (zram)"
+}
+
+// Component returns the component being checked
+func (c *SwapChecker) Component() verification.ComponentName {
+	return verification.ComponentSwap
+}
+
+// Check reports active swap devices and whether zram is in use
+func (c *SwapChecker) Check(ctx context.Context) verification.CheckResult {
+	content, err := os.ReadFile(c.swapsPath)
+	if err != nil {
+		return verification.NewCheckResult(
+			verification.ComponentSwap,
+			verification.StatusWarning,
+			verification.SeverityLow,
+			"Cannot read swap status",
+			[]string{fmt.Sprintf("Error: %v", err)},
+			nil,
+		)
+	}
+
+	devices := postinstall.ParseSwaps(string(content))
+	if len(devices) == 0 {
+		return verification.NewCheckResult(
+			verification.ComponentSwap,
+			verification.StatusWarning,
+			verification.SeverityMedium,
+			"No swap is active",
+			[]string{"Memory pressure will trigger the OOM killer instead of swapping"},
+			[]string{"Enable compressed swap: gohan post-install --zram"},
+		)
+	}
+
+	details := make([]string, 0, len(devices))
+	hasZram := false
+	for _, device := range devices {
+		if device.IsZram() {
+			hasZram = true
+		}
+		details = append(details, fmt.Sprintf("%s: %d/%d MB used (priority %d)",
+			device.Name, device.UsedKB/1024, device.SizeKB/1024, device.Priority))
+	}
+
+	if !hasZram {
+		return verification.NewCheckResult(
+			verification.ComponentSwap,
+			verification.StatusWarning,
+			verification.SeverityLow,
+			"Swap is active but zram is not configured",
+			details,
+			[]string{"Enable compressed swap: gohan post-install --zram"},
+		)
+	}
+
+	return verification.NewCheckResult(
+		verification.ComponentSwap,
+		verification.StatusPass,
+		verification.SeverityLow,
+		"zram swap is active",
+		details,
+		nil,
+	)
+}
@@ -0,0 +1,101 @@
+package wallpaper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+)
+
+// CommandExecutor defines the interface for executing system commands
+type CommandExecutor interface {
+	Execute(ctx context.Context, command string, args ...string) error
+}
+
+// HyprApplier renders the wallpaper daemon's configuration into
+// ~/.config/hypr and restarts the daemon in the running Hyprland session
+type HyprApplier struct {
+	hyprDir   string
+	executor  CommandExecutor
+	inSession func() bool
+}
+
+// NewHyprApplier creates an applier writing to hyprDir, ~/.config/hypr
+func NewHyprApplier(hyprDir string, executor CommandExecutor) *HyprApplier {
+	return &HyprApplier{
+		hyprDir:   hyprDir,
+		executor:  executor,
+		inSession: inWaylandSession,
+	}
+}
+
+// WithSession returns a copy of the applier that asks inSession whether a
+// Hyprland session is running to reload the daemon in
+func (a *HyprApplier) WithSession(inSession func() bool) *HyprApplier {
+	copied := *a
+	copied.inSession = inSession
+	return &copied
+}
+
+// Apply writes hyprpaper.conf for hyprpaper, points autostart.conf's
+// wallpaper line at the tool, and restarts the daemon through Hyprland so
+// it outlives gohan. Outside a session the layout applies at next login.
+func (a *HyprApplier) Apply(ctx context.Context, tool wallpaper.Tool, layout wallpaper.Layout) (bool, error) {
+	if err := os.MkdirAll(a.hyprDir, 0o755); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", a.hyprDir, err)
+	}
+
+	if tool == wallpaper.ToolHyprpaper {
+		path := filepath.Join(a.hyprDir, "hyprpaper.conf")
+		if err := os.WriteFile(path, []byte(layout.RenderHyprpaper()), 0o644); err != nil {
+			return false, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	command := layout.Command(tool)
+	if err := a.updateAutostart(command); err != nil {
+		return false, err
+	}
+
+	if !a.inSession() {
+		return false, nil
+	}
+	// Both daemons are stopped so switching tools leaves one running; one
+	// that is not running fails pkill harmlessly
+	for _, running := range wallpaper.Tools {
+		_ = a.executor.Execute(ctx, "pkill", "-x", string(running))
+	}
+	if err := a.executor.Execute(ctx, "hyprctl", "dispatch", "exec", command); err != nil {
+		return false, fmt.Errorf("failed to start %s: %w", tool, err)
+	}
+	return true, nil
+}
+
+// updateAutostart rewrites autostart.conf's wallpaper line when it differs
+func (a *HyprApplier) updateAutostart(command string) error {
+	path := filepath.Join(a.hyprDir, "autostart.conf")
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated := wallpaper.SetAutostart(string(content), command)
+	if updated == string(content) {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// inWaylandSession reports whether gohan runs inside a Hyprland session.
+// The rotation service sees WAYLAND_DISPLAY once autostart.conf has
+// exported it to the systemd user instance.
+func inWaylandSession() bool {
+	return os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+}
@@ -0,0 +1,71 @@
+package wallpaper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+	wallpaperInfra "github.com/rebelopsio/gohan/internal/infrastructure/wallpaper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingExecutor struct {
+	commands []string
+}
+
+func (r *recordingExecutor) Execute(ctx context.Context, command string, args ...string) error {
+	r.commands = append(r.commands, strings.Join(append([]string{command}, args...), " "))
+	return nil
+}
+
+func TestHyprApplier_Apply(t *testing.T) {
+	layout, err := wallpaper.NewLayout(
+		wallpaper.Assignment{Monitor: wallpaper.AllMonitors, Image: "/home/me/.config/gohan/wallpaper.jpg"},
+		wallpaper.Assignment{Monitor: "DP-1", Image: "/home/me/.config/gohan/wallpapers/DP-1.jpg"},
+	)
+	require.NoError(t, err)
+
+	t.Run("renders hyprpaper.conf and restarts hyprpaper in the session", func(t *testing.T) {
+		hyprDir := t.TempDir()
+		autostart := "exec-once = waybar\n\n# Wallpaper\nexec-once = swaybg -i ~/.config/gohan/wallpaper.jpg -m fill\n"
+		require.NoError(t, os.WriteFile(filepath.Join(hyprDir, "autostart.conf"), []byte(autostart), 0644))
+		executor := &recordingExecutor{}
+		applier := wallpaperInfra.NewHyprApplier(hyprDir, executor).WithSession(func() bool { return true })
+
+		reloaded, err := applier.Apply(context.Background(), wallpaper.ToolHyprpaper, layout)
+		require.NoError(t, err)
+
+		assert.True(t, reloaded)
+		conf, err := os.ReadFile(filepath.Join(hyprDir, "hyprpaper.conf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(conf), "wallpaper = DP-1,/home/me/.config/gohan/wallpapers/DP-1.jpg\n")
+		updated, err := os.ReadFile(filepath.Join(hyprDir, "autostart.conf"))
+		require.NoError(t, err)
+		assert.Equal(t, "exec-once = waybar\n\n# Wallpaper\nexec-once = hyprpaper\n", string(updated))
+		assert.Equal(t, []string{
+			"pkill -x hyprpaper",
+			"pkill -x swaybg",
+			"hyprctl dispatch exec hyprpaper",
+		}, executor.commands)
+	})
+
+	t.Run("writes swaybg's line and waits for the next login outside a session", func(t *testing.T) {
+		hyprDir := t.TempDir()
+		executor := &recordingExecutor{}
+		applier := wallpaperInfra.NewHyprApplier(hyprDir, executor).WithSession(func() bool { return false })
+
+		reloaded, err := applier.Apply(context.Background(), wallpaper.ToolSwaybg, layout)
+		require.NoError(t, err)
+
+		assert.False(t, reloaded)
+		assert.NoFileExists(t, filepath.Join(hyprDir, "hyprpaper.conf"))
+		updated, err := os.ReadFile(filepath.Join(hyprDir, "autostart.conf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(updated), "exec-once = swaybg -o '*' -i /home/me/.config/gohan/wallpaper.jpg -m fill -o DP-1 -i /home/me/.config/gohan/wallpapers/DP-1.jpg -m fill\n")
+		assert.Empty(t, executor.commands)
+	})
+}
@@ -0,0 +1,65 @@
+package wallpaper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/rebelopsio/gohan/internal/domain/maintenance"
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+)
+
+// UnitInstaller is the interface for writing and enabling systemd units
+type UnitInstaller interface {
+	UnitDir(scope maintenance.Scope) string
+	WriteUnit(scope maintenance.Scope, name, content string) error
+	Reload(ctx context.Context, scope maintenance.Scope) error
+	EnableTimer(ctx context.Context, scope maintenance.Scope, name string) error
+	DisableTimer(ctx context.Context, scope maintenance.Scope, name string) error
+}
+
+// TimerScheduler runs the rotation as a systemd user timer
+type TimerScheduler struct {
+	installer UnitInstaller
+}
+
+// NewTimerScheduler creates a scheduler installing units with installer
+func NewTimerScheduler(installer UnitInstaller) *TimerScheduler {
+	return &TimerScheduler{installer: installer}
+}
+
+// Schedule writes the rotation's service and timer and enables the timer,
+// replacing the interval of an earlier rotation
+func (s *TimerScheduler) Schedule(ctx context.Context, rotation wallpaper.Rotation) error {
+	if err := s.installer.WriteUnit(maintenance.ScopeUser, wallpaper.RotationServiceName, rotation.RenderService()); err != nil {
+		return err
+	}
+	if err := s.installer.WriteUnit(maintenance.ScopeUser, wallpaper.RotationTimerName, rotation.RenderTimer()); err != nil {
+		return err
+	}
+	if err := s.installer.Reload(ctx, maintenance.ScopeUser); err != nil {
+		return err
+	}
+	return s.installer.EnableTimer(ctx, maintenance.ScopeUser, wallpaper.RotationTimerName)
+}
+
+// Unschedule disables the timer and removes the units
+func (s *TimerScheduler) Unschedule(ctx context.Context) error {
+	dir := s.installer.UnitDir(maintenance.ScopeUser)
+	if _, err := os.Stat(filepath.Join(dir, wallpaper.RotationTimerName)); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err := s.installer.DisableTimer(ctx, maintenance.ScopeUser, wallpaper.RotationTimerName); err != nil {
+		return err
+	}
+	for _, name := range []string{wallpaper.RotationTimerName, wallpaper.RotationServiceName} {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", name, err)
+		}
+	}
+	return s.installer.Reload(ctx, maintenance.ScopeUser)
+}
@@ -0,0 +1,185 @@
+package wallpaper
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rebelopsio/gohan/internal/domain/wallpaper"
+)
+
+// managedExt names the managed copies; hyprpaper and swaybg detect the
+// format from the contents, so PNG and WebP images work despite it
+const managedExt = ".jpg"
+
+// rotationLink is the link to the directory being rotated through
+const rotationLink = "rotation"
+
+// FileStore keeps the default wallpaper at ~/.config/gohan/wallpaper.jpg,
+// where hyprlock and the default swaybg line read it, and the wallpaper of
+// each monitor with its own as ~/.config/gohan/wallpapers/<monitor>.jpg
+type FileStore struct {
+	path string
+	dir  string
+}
+
+// NewFileStore creates a store under gohanConfigDir, ~/.config/gohan
+func NewFileStore(gohanConfigDir string) *FileStore {
+	return &FileStore{
+		path: filepath.Join(gohanConfigDir, "wallpaper"+managedExt),
+		dir:  filepath.Join(gohanConfigDir, "wallpapers"),
+	}
+}
+
+// Path returns where the wallpaper of monitor is kept
+func (s *FileStore) Path(monitor string) string {
+	if monitor == wallpaper.AllMonitors {
+		return s.path
+	}
+	return filepath.Join(s.dir, monitor+managedExt)
+}
+
+// Layout returns the wallpapers in place
+func (s *FileStore) Layout() (wallpaper.Layout, error) {
+	var assignments []wallpaper.Assignment
+	if _, err := os.Stat(s.path); err == nil {
+		assignments = append(assignments, wallpaper.Assignment{Monitor: wallpaper.AllMonitors, Image: s.path})
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return wallpaper.Layout{}, fmt.Errorf("failed to read %s: %w", s.dir, err)
+	}
+	for _, entry := range entries {
+		monitor, ok := strings.CutSuffix(entry.Name(), managedExt)
+		if !ok || monitor == "" {
+			continue
+		}
+		assignments = append(assignments, wallpaper.Assignment{Monitor: monitor, Image: filepath.Join(s.dir, entry.Name())})
+	}
+	return wallpaper.NewLayout(assignments...)
+}
+
+// Import replaces the monitor's wallpaper with a copy of image, or a link
+// to it. The new file is moved into place so a daemon reading the old one
+// never sees it half written.
+func (s *FileStore) Import(monitor, image string, link bool) error {
+	target := s.Path(monitor)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+	}
+
+	temp := target + ".tmp"
+	_ = os.Remove(temp)
+	if link {
+		absolute, err := filepath.Abs(image)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", image, err)
+		}
+		if err := os.Symlink(absolute, temp); err != nil {
+			return fmt.Errorf("failed to link wallpaper: %w", err)
+		}
+	} else if err := copyFile(image, temp); err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+
+	if err := os.Rename(temp, target); err != nil {
+		_ = os.Remove(temp)
+		return fmt.Errorf("failed to put wallpaper in place: %w", err)
+	}
+	return nil
+}
+
+// Source returns the image the monitor's wallpaper links to
+func (s *FileStore) Source(monitor string) string {
+	source, err := os.Readlink(s.Path(monitor))
+	if err != nil {
+		return ""
+	}
+	return source
+}
+
+// Images lists the images directly in dir, sorted by name
+func (s *FileStore) Images(dir string) ([]string, error) {
+	absolute, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	entries, err := os.ReadDir(absolute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var images []string
+	for _, entry := range entries {
+		path := filepath.Join(absolute, entry.Name())
+		if !wallpaper.IsImage(path) {
+			continue
+		}
+		// Follows links, so linked images count and linked directories do not
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		images = append(images, path)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// RotationDir returns the directory rotated through
+func (s *FileStore) RotationDir() string {
+	dir, err := os.Readlink(filepath.Join(s.dir, rotationLink))
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// SetRotationDir links dir as the directory to rotate through, or removes
+// the link when dir is empty
+func (s *FileStore) SetRotationDir(dir string) error {
+	link := filepath.Join(s.dir, rotationLink)
+	if err := os.Remove(link); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove %s: %w", link, err)
+	}
+	if dir == "" {
+		return nil
+	}
+
+	absolute, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+	if err := os.Symlink(absolute, link); err != nil {
+		return fmt.Errorf("failed to link rotation directory: %w", err)
+	}
+	return nil
+}
+
+// copyFile copies the file at source to target
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("failed to open wallpaper: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("failed to write wallpaper: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to copy wallpaper: %w", err)
+	}
+	return out.Close()
+}
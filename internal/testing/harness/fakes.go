@@ -0,0 +1,175 @@
+package harness
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+)
+
+// System is a fake of the system preflight inspects: the Debian release,
+// GPUs, disk space, network and apt sources. The zero value is an offline
+// system without GPUs or disk space; NewSystem returns one ready to
+// install on.
+type System struct {
+	Debian         preflight.DebianVersion
+	GPUs           []preflight.GPUType
+	AvailableBytes uint64
+	TotalBytes     uint64
+	Online         bool
+	SourceRepos    bool
+}
+
+// NewSystem returns a Debian sid system with an Intel GPU, 50 GB free and
+// network access, which passes every preflight check
+func NewSystem() *System {
+	gpu, _ := preflight.NewGPUType(preflight.GPUVendorIntel, "UHD Graphics 620", "8086:5917")
+	return &System{
+		Debian:         preflight.DebianSid,
+		GPUs:           []preflight.GPUType{gpu},
+		AvailableBytes: 50 * uint64(installation.GB),
+		TotalBytes:     100 * uint64(installation.GB),
+		Online:         true,
+		SourceRepos:    true,
+	}
+}
+
+// DetectVersion implements preflight.DebianDetector
+func (s *System) DetectVersion(ctx context.Context) (preflight.DebianVersion, error) {
+	return s.Debian, ctx.Err()
+}
+
+// IsDebianBased implements preflight.DebianDetector
+func (s *System) IsDebianBased(ctx context.Context) bool {
+	return s.Debian.Codename() != ""
+}
+
+// DetectGPUs implements preflight.GPUDetector
+func (s *System) DetectGPUs(ctx context.Context) ([]preflight.GPUType, error) {
+	return s.GPUs, ctx.Err()
+}
+
+// PrimaryGPU implements preflight.GPUDetector
+func (s *System) PrimaryGPU(ctx context.Context) (preflight.GPUType, error) {
+	if len(s.GPUs) == 0 {
+		return preflight.GPUType{}, errors.New("no GPU found")
+	}
+	return s.GPUs[0], ctx.Err()
+}
+
+// DetectAvailableSpace implements preflight.DiskSpaceDetector. Every path
+// is on the same partition.
+func (s *System) DetectAvailableSpace(ctx context.Context, path string) (preflight.DiskSpace, error) {
+	space, err := preflight.NewDiskSpace(s.AvailableBytes, s.TotalBytes, path)
+	if err != nil {
+		return preflight.DiskSpace{}, err
+	}
+	return space, ctx.Err()
+}
+
+// CheckInternetConnectivity implements preflight.ConnectivityChecker
+func (s *System) CheckInternetConnectivity(ctx context.Context) (preflight.InternetConnectivity, error) {
+	return preflight.NewInternetConnectivity(s.Online, []preflight.ConnectivityTest{
+		{Endpoint: "deb.debian.org", Success: s.Online, Latency: 20 * time.Millisecond},
+	}), ctx.Err()
+}
+
+// CheckDebianRepositories implements preflight.ConnectivityChecker
+func (s *System) CheckDebianRepositories(ctx context.Context) (bool, error) {
+	return s.Online, ctx.Err()
+}
+
+// CheckSourceRepositories implements preflight.SourceRepositoryChecker
+func (s *System) CheckSourceRepositories(ctx context.Context) (preflight.SourceRepositoryStatus, error) {
+	var sources []string
+	if s.SourceRepos {
+		sources = []string{"deb-src http://deb.debian.org/debian sid main"}
+	}
+	return preflight.NewSourceRepositoryStatus(s.SourceRepos, sources), ctx.Err()
+}
+
+// Conflicts is a fake conflict resolver reporting the conflicts added to
+// it, so installations do not depend on the packages of the machine running
+// the tests. It records the conflicts it resolved.
+type Conflicts struct {
+	mu        sync.Mutex
+	conflicts []installation.PackageConflict
+	resolved  []installation.PackageConflict
+}
+
+// NewConflicts creates a resolver that detects no conflicts
+func NewConflicts() *Conflicts {
+	return &Conflicts{}
+}
+
+// Add makes the resolver detect conflicts in later installations
+func (c *Conflicts) Add(conflicts ...installation.PackageConflict) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conflicts = append(c.conflicts, conflicts...)
+}
+
+// DetectConflicts implements installation.ConflictResolver
+func (c *Conflicts) DetectConflicts(ctx context.Context, components []installation.ComponentSelection) ([]installation.PackageConflict, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]installation.PackageConflict(nil), c.conflicts...), ctx.Err()
+}
+
+// ResolveConflict implements installation.ConflictResolver
+func (c *Conflicts) ResolveConflict(ctx context.Context, conflict installation.PackageConflict, strategy installation.ResolutionAction) error {
+	if strategy == installation.ActionAbort {
+		return errors.New("installation aborted due to package conflict: " + conflict.String())
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resolved = append(c.resolved, conflict)
+	return ctx.Err()
+}
+
+// Resolved returns the conflicts resolved so far
+func (c *Conflicts) Resolved() []installation.PackageConflict {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]installation.PackageConflict(nil), c.resolved...)
+}
+
+// GPUDrivers is a fake GPU driver setup. Drivers are found loaded with
+// nouveau blacklisted and kernel mode setting on; it counts initramfs
+// rebuilds.
+type GPUDrivers struct {
+	mu               sync.Mutex
+	initramfsUpdates int
+}
+
+// UpdateInitramfs implements usecases.GPUDriverSetup
+func (g *GPUDrivers) UpdateInitramfs(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.initramfsUpdates++
+	return ctx.Err()
+}
+
+// Inspect implements usecases.GPUDriverSetup
+func (g *GPUDrivers) Inspect(driver installation.ComponentName) (installation.GPUDriverState, error) {
+	return installation.NewGPUDriverState(driver, true, true, []string{driver.KernelModule(), installation.ModuleNVIDIADRM})
+}
+
+// InitramfsUpdates returns how often the initramfs was rebuilt
+func (g *GPUDrivers) InitramfsUpdates() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.initramfsUpdates
+}
+
+// NoReboot is a fake reboot detector for installations that never need a
+// reboot
+type NoReboot struct{}
+
+// Detect implements usecases.RebootDetector
+func (NoReboot) Detect(ctx context.Context, components []installation.ComponentName, since time.Time) (installation.RebootRequirement, error) {
+	return installation.RebootRequirement{}, ctx.Err()
+}
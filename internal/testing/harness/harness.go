@@ -0,0 +1,227 @@
+// Package harness assembles the installation pipeline around a simulated
+// system, so acceptance tests can run whole installations without root or
+// network access. Sessions live in memory, packages go through apt in
+// dry-run mode, preflight inspects a fake System, and configuration files
+// are deployed into a temporary home directory.
+package harness
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	historyServices "github.com/rebelopsio/gohan/internal/application/history/services"
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	historyRepo "github.com/rebelopsio/gohan/internal/infrastructure/history/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/services"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/workspace"
+	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
+)
+
+// progressBuffer holds every progress report of an installation, so
+// none is missed
+const progressBuffer = 4096
+
+// Disk space requested by ProfileRequest
+const (
+	defaultAvailableSpace = 50 * uint64(installation.GB)
+	defaultRequiredSpace  = 5 * uint64(installation.GB)
+)
+
+// Harness is an installation pipeline wired to a simulated system. Its
+// fakes can be adjusted until the first installation starts.
+type Harness struct {
+	// Root is the temporary directory everything is written under
+	Root string
+	// HomeDir is where configuration files are deployed, under Root
+	HomeDir string
+
+	// Simulated system
+	System     *System
+	Conflicts  *Conflicts
+	GPUDrivers *GPUDrivers
+	Packages   *packagemanager.APTManager // In dry-run mode
+
+	// Stores
+	Sessions *repository.MemorySessionRepository
+	History  *historyRepo.SQLiteRepository
+
+	// Use cases
+	Start   *usecases.StartInstallationUseCase
+	Execute *usecases.ExecuteInstallationUseCase
+	Status  *usecases.GetInstallationStatusUseCase
+
+	progress *usecases.ProgressBroadcaster
+}
+
+// New builds a harness under a temporary directory removed when the test
+// ends
+func New(t testing.TB) *Harness {
+	t.Helper()
+
+	root := t.TempDir()
+	h := &Harness{
+		Root:       root,
+		HomeDir:    filepath.Join(root, "home"),
+		System:     NewSystem(),
+		Conflicts:  NewConflicts(),
+		GPUDrivers: &GPUDrivers{},
+		Packages:   packagemanager.NewAPTManagerDryRun(),
+		Sessions:   repository.NewMemorySessionRepository(),
+		progress:   usecases.NewProgressBroadcaster(),
+	}
+	if err := os.MkdirAll(h.HomeDir, 0755); err != nil {
+		t.Fatalf("failed to create home directory: %v", err)
+	}
+
+	history, err := historyRepo.NewSQLiteRepository(filepath.Join(root, "history.db"))
+	if err != nil {
+		t.Fatalf("failed to create history repository: %v", err)
+	}
+	t.Cleanup(func() { _ = history.Close() })
+	h.History = history
+
+	deployer := configservice.NewConfigDeployer(
+		templates.NewTemplateEngine(),
+		backup.NewBackupService(filepath.Join(root, "backups")),
+	).WithRecords(configservice.NewDeployRecordStore(filepath.Join(root, configservice.DeployRecordsFileName)))
+
+	// Detectors are looked up on each run, so tests can change the System
+	// after New
+	newPreflight := func(config installation.InstallationConfiguration) usecases.PreflightValidator {
+		return preflightTUI.NewValidationRunnerWithUseCase(preflightApp.NewRunPreflightUseCase(h.detectors())).
+			WithInstallation(config)
+	}
+
+	estimator := services.NewProgressEstimator()
+	h.Start = usecases.NewStartInstallationUseCase(h.Sessions)
+	h.Execute = usecases.NewExecuteInstallationUseCase(
+		h.Sessions,
+		h.Conflicts,
+		estimator,
+		services.NewConfigurationMerger(),
+		h.Packages,
+		historyServices.NewHistoryRecordingService(history),
+		newPreflight,
+		deployer,
+	).WithWorkspaces(workspace.NewStore(filepath.Join(root, "sessions"))).
+		WithGPUDriverSetup(h.GPUDrivers).
+		WithRebootDetection(NoReboot{}).
+		WithHomeDir(h.HomeDir).
+		WithDryRun(h.Packages, h.Conflicts).
+		WithProgressBroadcaster(h.progress)
+	h.Status = usecases.NewGetInstallationStatusUseCaseWithEstimator(h.Sessions, estimator)
+
+	return h
+}
+
+// detectors returns the preflight detectors, all backed by the System
+func (h *Harness) detectors() preflightApp.Detectors {
+	return preflightApp.Detectors{
+		DebianDetector:          h.System,
+		GPUDetector:             h.System,
+		DiskSpaceDetector:       h.System,
+		ConnectivityChecker:     h.System,
+		SourceRepositoryChecker: h.System,
+	}
+}
+
+// Progress is one progress report of an installation
+type Progress struct {
+	Phase               string
+	Percent             int
+	Message             string
+	ComponentsInstalled int
+	ComponentsTotal     int
+}
+
+// Result is the outcome of an installation run through Install
+type Result struct {
+	SessionID string
+	Response  *dto.InstallationProgressResponse
+	Progress  []Progress
+	Session   *installation.InstallationSession
+}
+
+// Install starts and executes an installation as gohan install does,
+// without prompts, collecting its progress reports
+func (h *Harness) Install(ctx context.Context, request dto.InstallationRequest) (*Result, error) {
+	started, err := h.Start.Execute(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start installation: %w", err)
+	}
+
+	result := &Result{SessionID: started.SessionID}
+	unsubscribe := h.progress.SubscribeBuffered(func(update usecases.ProgressUpdate) {
+		if update.SessionID == started.SessionID {
+			result.Progress = append(result.Progress, Progress{
+				update.Phase, update.Percent, update.Message, update.ComponentsInstalled, update.ComponentsTotal,
+			})
+		}
+	}, progressBuffer)
+	result.Response, err = h.Execute.Execute(ctx, started.SessionID)
+	if dropped := unsubscribe(); dropped > 0 {
+		return result, fmt.Errorf("missed %d progress reports", dropped)
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to execute installation: %w", err)
+	}
+
+	result.Session, err = h.Sessions.FindByID(ctx, started.SessionID)
+	if err != nil {
+		return result, fmt.Errorf("failed to load session: %w", err)
+	}
+	return result, nil
+}
+
+// ConfigPath returns where a configuration file is deployed in the
+// harness's home directory, such as ConfigPath("hypr", "hyprland.conf")
+func (h *Harness) ConfigPath(elem ...string) string {
+	return filepath.Join(append([]string{h.HomeDir, ".config"}, elem...)...)
+}
+
+// ProfileRequest returns the request gohan install makes for a built-in
+// profile: its components, alternatives and rendering mode
+func ProfileRequest(name string) (dto.InstallationRequest, error) {
+	profile, err := installation.LookupProfile(name)
+	if err != nil {
+		return dto.InstallationRequest{}, err
+	}
+
+	components := make([]dto.ComponentRequest, 0, len(profile.Components()))
+	for _, component := range profile.Components() {
+		components = append(components, dto.ComponentRequest{Name: string(component), Version: "latest"})
+	}
+
+	return dto.InstallationRequest{
+		Components:     components,
+		Alternatives:   profile.Alternatives().Strings(),
+		RenderingMode:  profile.RenderingMode().String(),
+		AvailableSpace: defaultAvailableSpace,
+		RequiredSpace:  defaultRequiredSpace,
+	}, nil
+}
+
+// WithGPU sets a request's GPU vendor, as gohan install --gpu does, and
+// adds the vendor's driver to its components
+func WithGPU(request dto.InstallationRequest, vendor string) dto.InstallationRequest {
+	driver := vendor + "_driver"
+	request.GPU = &dto.GPURequest{
+		Vendor:         vendor,
+		RequiresDriver: true,
+		DriverName:     driver,
+	}
+	request.Components = append(append([]dto.ComponentRequest(nil), request.Components...),
+		dto.ComponentRequest{Name: driver, Version: "latest"})
+	return request
+}
@@ -0,0 +1,102 @@
+package harness_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rebelopsio/gohan/internal/domain/history"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	"github.com/rebelopsio/gohan/internal/testing/harness"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarness_Install(t *testing.T) {
+	t.Run("installs the minimal profile", func(t *testing.T) {
+		h := harness.New(t)
+
+		request, err := harness.ProfileRequest("minimal")
+		require.NoError(t, err)
+
+		result, err := h.Install(context.Background(), request)
+		require.NoError(t, err)
+
+		assert.Equal(t, "completed", result.Response.Status)
+		assert.Len(t, result.Session.InstalledComponents(), len(request.Components))
+		assert.NotEmpty(t, result.Session.PreflightSessionID(), "preflight ran against the fake system")
+		var phases []string
+		for _, progress := range result.Progress {
+			phases = append(phases, progress.Phase)
+		}
+		assert.Contains(t, phases, "Installing Components")
+		assert.Contains(t, phases, "Verifying")
+
+		count, err := h.History.Count(context.Background(), history.RecordFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("installs and sets up the driver of the selected GPU", func(t *testing.T) {
+		h := harness.New(t)
+		gpu, err := preflight.NewGPUType(preflight.GPUVendorNVIDIA, "GeForce RTX 3060", "10de:2503")
+		require.NoError(t, err)
+		h.System.GPUs = []preflight.GPUType{gpu}
+
+		request, err := harness.ProfileRequest("recommended")
+		require.NoError(t, err)
+
+		result, err := h.Install(context.Background(), harness.WithGPU(request, "nvidia"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "completed", result.Response.Status)
+		assert.True(t, isInstalled(result.Session, installation.ComponentNVIDIADriver))
+		assert.Equal(t, 1, h.GPUDrivers.InitramfsUpdates())
+	})
+
+	t.Run("resolves conflicts without prompting when unattended", func(t *testing.T) {
+		conflict, err := installation.NewPackageConflict("hyprland", "sway", "both provide a Wayland session")
+		require.NoError(t, err)
+
+		h := harness.New(t)
+		h.Conflicts.Add(conflict)
+
+		request, err := harness.ProfileRequest("recommended")
+		require.NoError(t, err)
+
+		result, err := h.Install(context.Background(), request)
+		require.NoError(t, err)
+
+		assert.Equal(t, "completed", result.Response.Status)
+		assert.Len(t, h.Conflicts.Resolved(), 1)
+		require.Len(t, result.Response.Conflicts, 1)
+		assert.True(t, result.Response.Conflicts[0].Applied)
+	})
+
+	t.Run("stops before installing when preflight finds a blocker", func(t *testing.T) {
+		h := harness.New(t)
+		h.System.Online = false
+
+		request, err := harness.ProfileRequest("minimal")
+		require.NoError(t, err)
+
+		result, err := h.Install(context.Background(), request)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), string(preflight.RequirementInternet))
+
+		session, err := h.Sessions.FindByID(context.Background(), result.SessionID)
+		require.NoError(t, err)
+		assert.Equal(t, installation.StatusFailed, session.Status())
+		assert.Empty(t, session.InstalledComponents())
+	})
+}
+
+// isInstalled reports whether the session installed component
+func isInstalled(session *installation.InstallationSession, component installation.ComponentName) bool {
+	for _, installed := range session.InstalledComponents() {
+		if installed.Component() == component {
+			return true
+		}
+	}
+	return false
+}
@@ -258,6 +258,29 @@ func (b *Browser) renderDetail() string {
 		s.WriteString(detailSectionStyle.Render(packagesInfo))
 	}
 
+	// Warnings if present
+	if record.HasWarnings() {
+		warningsInfo := b.renderWarnings(record)
+		s.WriteString(detailSectionStyle.Render(warningsInfo))
+	}
+
+	// Preflight results if present
+	if record.HasPreflightChecks() {
+		preflightInfo := b.renderPreflightChecks(record)
+		s.WriteString(detailSectionStyle.Render(preflightInfo))
+	}
+
+	// Components left out if any
+	if skipped := record.SkippedComponents(); len(skipped) > 0 {
+		s.WriteString(detailSectionStyle.Render(b.renderSkippedComponents(skipped)))
+	}
+
+	// Resolved package conflicts if present
+	if conflicts := record.Conflicts(); len(conflicts) > 0 {
+		conflictsInfo := b.renderConflicts(conflicts)
+		s.WriteString(detailSectionStyle.Render(conflictsInfo))
+	}
+
 	// Failure details if present
 	if record.HasFailureDetails() {
 		failureInfo := b.renderFailureDetails(record)
@@ -329,6 +352,24 @@ func (b *Browser) renderSystemContext(record history.InstallationRecord) string
 	if sysCtx.Hostname() != "" {
 		s.WriteString(detailLabelStyle.Render("Hostname:"))
 		s.WriteString(detailValueStyle.Render(sysCtx.Hostname()))
+		s.WriteString("\n")
+	}
+
+	if sysCtx.Architecture() != "" {
+		s.WriteString(detailLabelStyle.Render("Arch:"))
+		s.WriteString(detailValueStyle.Render(sysCtx.Architecture()))
+		s.WriteString("\n")
+	}
+
+	if sysCtx.GPUVendor() != "" {
+		s.WriteString(detailLabelStyle.Render("GPU:"))
+		s.WriteString(detailValueStyle.Render(sysCtx.GPUVendor()))
+		s.WriteString("\n")
+	}
+
+	if sysCtx.RenderGPU() != "" {
+		s.WriteString(detailLabelStyle.Render("Render GPU:"))
+		s.WriteString(detailValueStyle.Render(sysCtx.RenderGPU()))
 	}
 
 	return s.String()
@@ -354,6 +395,74 @@ func (b *Browser) renderInstalledPackages(record history.InstallationRecord) str
 	return strings.TrimRight(s.String(), "\n")
 }
 
+// renderWarnings renders warnings raised during the installation
+func (b *Browser) renderWarnings(record history.InstallationRecord) string {
+	var s strings.Builder
+	warnings := record.Warnings()
+
+	s.WriteString(lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Warnings (%d)", len(warnings))))
+	s.WriteString("\n\n")
+
+	for _, w := range warnings {
+		s.WriteString(fmt.Sprintf("• %s\n", w))
+	}
+
+	return strings.TrimRight(s.String(), "\n")
+}
+
+// renderPreflightChecks renders the preflight results embedded in the record
+func (b *Browser) renderPreflightChecks(record history.InstallationRecord) string {
+	var s strings.Builder
+	checks := record.PreflightChecks()
+
+	s.WriteString(lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Preflight Checks (%d)", len(checks))))
+	s.WriteString("\n\n")
+
+	for _, c := range checks {
+		s.WriteString(fmt.Sprintf("• %s\n", c))
+		if !c.Passed() && c.Message() != "" {
+			s.WriteString(fmt.Sprintf("  %s\n", c.Message()))
+		}
+	}
+
+	return strings.TrimRight(s.String(), "\n")
+}
+
+// renderConflicts renders the package conflicts and how they were resolved
+func (b *Browser) renderConflicts(conflicts []history.ConflictResolution) string {
+	var s strings.Builder
+
+	s.WriteString(lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Conflicts (%d)", len(conflicts))))
+	s.WriteString("\n\n")
+
+	for _, c := range conflicts {
+		s.WriteString(fmt.Sprintf("• %s\n", c))
+		if c.Reason() != "" {
+			s.WriteString(fmt.Sprintf("  %s\n", c.Reason()))
+		}
+	}
+
+	return strings.TrimRight(s.String(), "\n")
+}
+
+// renderSkippedComponents renders the components left out and why
+func (b *Browser) renderSkippedComponents(skipped []history.SkippedComponent) string {
+	var s strings.Builder
+
+	s.WriteString(lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Skipped Components (%d)", len(skipped))))
+	s.WriteString("\n\n")
+
+	for _, c := range skipped {
+		s.WriteString(fmt.Sprintf("• %s\n", c))
+	}
+
+	return strings.TrimRight(s.String(), "\n")
+}
+
 // renderFailureDetails renders failure details
 func (b *Browser) renderFailureDetails(record history.InstallationRecord) string {
 	var s strings.Builder
@@ -0,0 +1,145 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AccessibilityOption is an accessibility option the user can toggle
+type AccessibilityOption struct {
+	Name        string // e.g. "reduced-motion"
+	Description string
+	Enabled     bool
+}
+
+// accessibilityPickerKeys defines keyboard shortcuts for the accessibility
+// picker
+type accessibilityPickerKeys struct {
+	Up      key.Binding
+	Down    key.Binding
+	Toggle  key.Binding
+	Confirm key.Binding
+	Quit    key.Binding
+}
+
+// AccessibilityPicker lets the user toggle accessibility options before
+// the configuration is rendered
+type AccessibilityPicker struct {
+	options   []AccessibilityOption
+	cursor    int
+	confirmed bool
+	quitting  bool
+	keys      accessibilityPickerKeys
+}
+
+// NewAccessibilityPicker creates a picker with the given options, enabled
+// ones preselected
+func NewAccessibilityPicker(options []AccessibilityOption) AccessibilityPicker {
+	return AccessibilityPicker{
+		options: options,
+		keys: accessibilityPickerKeys{
+			Up:      key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "move up")),
+			Down:    key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "move down")),
+			Toggle:  key.NewBinding(key.WithKeys(" ", "x"), key.WithHelp("space", "toggle")),
+			Confirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "continue")),
+			Quit:    key.NewBinding(key.WithKeys("q", "esc", "ctrl+c"), key.WithHelp("q/esc", "cancel")),
+		},
+	}
+}
+
+// Init initializes the model
+func (m AccessibilityPicker) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles key presses
+func (m AccessibilityPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Quit):
+		m.quitting = true
+		return m, tea.Quit
+
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(m.options)-1 {
+			m.cursor++
+		}
+
+	case key.Matches(keyMsg, m.keys.Toggle):
+		if len(m.options) > 0 {
+			m.options[m.cursor].Enabled = !m.options[m.cursor].Enabled
+		}
+
+	case key.Matches(keyMsg, m.keys.Confirm):
+		m.confirmed = true
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// View renders the option list
+func (m AccessibilityPicker) View() string {
+	if m.quitting {
+		if m.confirmed {
+			if enabled := m.Enabled(); len(enabled) > 0 {
+				return successStyle.Render(fmt.Sprintf("✓ Accessibility: %s", strings.Join(enabled, ", "))) + "\n"
+			}
+			return successStyle.Render("✓ Accessibility: none") + "\n"
+		}
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("♿ Accessibility"))
+	b.WriteString("\n")
+	b.WriteString(logDimStyle.Render("  Applied to the Hyprland, Waybar and terminal configuration"))
+	b.WriteString("\n\n")
+
+	for i, option := range m.options {
+		check := "[ ]"
+		if option.Enabled {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("  %s %s", check, option.Description)
+		if i == m.cursor {
+			b.WriteString(packageNameStyle.Render("▶" + line))
+		} else {
+			b.WriteString(logInfoStyle.Render(" " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓ move • space toggle • enter continue • q cancel"))
+	return b.String()
+}
+
+// Enabled returns the names of the enabled options
+func (m AccessibilityPicker) Enabled() []string {
+	var names []string
+	for _, option := range m.options {
+		if option.Enabled {
+			names = append(names, option.Name)
+		}
+	}
+	return names
+}
+
+// Confirmed returns true if the user continued rather than cancelled
+func (m AccessibilityPicker) Confirmed() bool {
+	return m.confirmed
+}
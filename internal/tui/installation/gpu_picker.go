@@ -0,0 +1,121 @@
+package installation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// GPUOption is a GPU the user can choose to render on
+type GPUOption struct {
+	PCISlot     string
+	Description string // e.g. "nvidia GeForce RTX 3070 (nvidia 550.54.14, 8.00 GB VRAM)"
+}
+
+// gpuPickerKeys defines keyboard shortcuts for the GPU picker
+type gpuPickerKeys struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Quit   key.Binding
+}
+
+// GPUPicker lets the user pick the GPU Hyprland renders on
+type GPUPicker struct {
+	options  []GPUOption
+	cursor   int
+	selected *GPUOption
+	quitting bool
+	keys     gpuPickerKeys
+}
+
+// NewGPUPicker creates a GPU picker with the first option highlighted
+func NewGPUPicker(options []GPUOption) GPUPicker {
+	return GPUPicker{
+		options: options,
+		keys: gpuPickerKeys{
+			Up:     key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "move up")),
+			Down:   key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "move down")),
+			Select: key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "select")),
+			Quit:   key.NewBinding(key.WithKeys("q", "esc", "ctrl+c"), key.WithHelp("q/esc", "cancel")),
+		},
+	}
+}
+
+// Init initializes the model
+func (m GPUPicker) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles key presses
+func (m GPUPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Quit):
+		m.quitting = true
+		return m, tea.Quit
+
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(m.options)-1 {
+			m.cursor++
+		}
+
+	case key.Matches(keyMsg, m.keys.Select):
+		if len(m.options) > 0 {
+			m.selected = &m.options[m.cursor]
+		}
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// View renders the GPU list
+func (m GPUPicker) View() string {
+	if m.quitting {
+		if m.selected != nil {
+			return successStyle.Render(fmt.Sprintf("✓ Rendering on %s", m.selected.Description)) + "\n"
+		}
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("🖥  Multiple GPUs detected"))
+	b.WriteString("\n")
+	b.WriteString(logDimStyle.Render("  Choose the GPU Hyprland renders on; the others still drive their own outputs"))
+	b.WriteString("\n\n")
+
+	for i, option := range m.options {
+		line := fmt.Sprintf("  %s  %s", option.PCISlot, option.Description)
+		if i == m.cursor {
+			b.WriteString(packageNameStyle.Render("▶" + line))
+		} else {
+			b.WriteString(logInfoStyle.Render(" " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓ move • enter select • q cancel"))
+	return b.String()
+}
+
+// Selected returns the chosen GPU, or false if the user cancelled
+func (m GPUPicker) Selected() (GPUOption, bool) {
+	if m.selected == nil {
+		return GPUOption{}, false
+	}
+	return *m.selected, true
+}
@@ -21,6 +21,15 @@ type ProgressUpdate struct {
 	IsComplete         bool
 	IsError            bool
 	ErrorMessage       string
+	Components         []ComponentStatus // Per-component outcome, sent with the final update
+}
+
+// ComponentStatus is where one component ended up
+type ComponentStatus struct {
+	Name       string
+	State      string // pending, downloading, installing, configured, verified, failed, skipped
+	Error      string
+	SkipReason string // Why a skipped component was left out
 }
 
 // LogEntry represents a log entry
@@ -172,6 +181,12 @@ func (m *ProgressViewer) View() string {
 	b.WriteString(m.renderProgress())
 	b.WriteString("\n\n")
 
+	// Component outcome once the installation has ended
+	if m.done && len(m.currentUpdate.Components) > 0 {
+		b.WriteString(m.renderComponents())
+		b.WriteString("\n\n")
+	}
+
 	// Logs section
 	b.WriteString(m.renderLogs())
 	b.WriteString("\n")
@@ -203,9 +218,14 @@ func (m *ProgressViewer) renderProgress() string {
 	}
 
 	if !m.done {
-		b.WriteString(fmt.Sprintf("%s %s\n\n",
+		b.WriteString(fmt.Sprintf("%s %s\n",
 			m.spinner.View(),
 			phaseStyle.Render(phase)))
+		// The current step, with the estimate for the component being installed
+		if m.currentUpdate.Message != "" {
+			b.WriteString(fmt.Sprintf("  %s\n", logInfoStyle.Render(m.currentUpdate.Message)))
+		}
+		b.WriteString("\n")
 	} else {
 		if m.currentUpdate.IsError {
 			b.WriteString(fmt.Sprintf("✗ %s\n\n", errorStyle.Render("Failed")))
@@ -285,6 +305,39 @@ func (m *ProgressViewer) renderLogs() string {
 	return boxStyle.Render(b.String())
 }
 
+func (m *ProgressViewer) renderComponents() string {
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().
+		Foreground(dimColor).
+		Bold(true).
+		Render("Components"))
+	b.WriteString("\n\n")
+
+	for _, c := range m.currentUpdate.Components {
+		switch c.State {
+		case "verified", "configured":
+			b.WriteString("  ✓ ")
+			b.WriteString(logSuccessStyle.Render(fmt.Sprintf("%s (%s)", c.Name, c.State)))
+		case "failed":
+			b.WriteString("  ✗ ")
+			b.WriteString(logErrorStyle.Render(fmt.Sprintf("%s: %s", c.Name, c.Error)))
+		case "skipped":
+			b.WriteString("  - ")
+			b.WriteString(logDimStyle.Render(fmt.Sprintf("%s (skipped: %s)", c.Name, c.SkipReason)))
+		case "pending":
+			b.WriteString("  · ")
+			b.WriteString(logDimStyle.Render(fmt.Sprintf("%s (untouched)", c.Name)))
+		default:
+			b.WriteString("  → ")
+			b.WriteString(logInfoStyle.Render(fmt.Sprintf("%s (%s)", c.Name, c.State)))
+		}
+		b.WriteString("\n")
+	}
+
+	return boxStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
+
 func (m *ProgressViewer) renderFooter() string {
 	if m.currentUpdate.IsError {
 		msg := "Installation failed"
@@ -3,20 +3,23 @@ package preflight
 import (
 	"context"
 	"fmt"
+	"os"
+	"sync/atomic"
 
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
 	"github.com/rebelopsio/gohan/internal/infrastructure/preflight/detectors"
 )
 
-// ValidationRunner orchestrates the validation flow
+// ValidationRunner runs the preflight use case in the background and
+// publishes its progress for the TUI and installation to subscribe to
 type ValidationRunner struct {
-	debianDetector       *detectors.DebianVersionDetector
-	gpuDetector          *detectors.SystemGPUDetector
-	diskSpaceDetector    *detectors.SystemDiskSpaceDetector
-	connectivityChecker  *detectors.SystemConnectivityChecker
-	sourceRepoChecker    *detectors.SystemSourceRepositoryChecker
-	session              *preflight.ValidationSession
-	progressChan         chan ProgressUpdate
+	useCase        *preflightApp.RunPreflightUseCase
+	scope          *preflightApp.InstallationScope
+	session        *preflight.ValidationSession
+	progressChan   chan ProgressUpdate
+	droppedUpdates atomic.Int64
 }
 
 // ProgressUpdate represents a validation progress event
@@ -27,380 +30,156 @@ type ProgressUpdate struct {
 	Result          *preflight.ValidationResult
 }
 
-// NewValidationRunner creates a new validation runner
-func NewValidationRunner() *ValidationRunner {
-	return &ValidationRunner{
-		debianDetector:      detectors.NewDebianVersionDetector(),
-		gpuDetector:         detectors.NewSystemGPUDetector(),
-		diskSpaceDetector:   detectors.NewSystemDiskSpaceDetector(),
-		connectivityChecker: detectors.NewSystemConnectivityChecker(),
-		sourceRepoChecker:   detectors.NewSystemSourceRepositoryChecker(),
-		session:             preflight.NewValidationSession(),
-		progressChan:        make(chan ProgressUpdate, 10),
+// SystemDetectors returns the detectors that inspect the running system
+func SystemDetectors() preflightApp.Detectors {
+	return preflightApp.Detectors{
+		DebianDetector:          detectors.NewDebianVersionDetector(),
+		GPUDetector:             detectors.NewSystemGPUDetector(),
+		DiskSpaceDetector:       detectors.NewSystemDiskSpaceDetector(),
+		ConnectivityChecker:     detectors.NewSystemConnectivityChecker(),
+		SourceRepositoryChecker: detectors.NewSystemSourceRepositoryChecker(),
+		ResourceDetector:        detectors.NewSystemResourceDetector(),
+		PowerDaemonDetector:     detectors.NewSystemPowerDaemonDetector(),
+		SessionDetector:         detectors.NewSystemSessionDetector(),
+		SourcesSanityChecker:    detectors.NewSystemSourcesSanityChecker(),
+		ThroughputProbe:         detectors.NewSystemThroughputProbe(),
+		WritabilityDetector:     detectors.NewSystemFilesystemWritabilityDetector(),
 	}
 }
 
-// Run executes all validation checks
-func (r *ValidationRunner) Run(ctx context.Context) error {
-	defer close(r.progressChan)
-
-	// Run each validation in sequence
-	validations := []func(context.Context) error{
-		r.validateDebianVersion,
-		r.validateGPU,
-		r.validateDiskSpace,
-		r.validateConnectivity,
-		r.validateSourceRepositories,
-	}
+// NewValidationRunner creates a validation runner that checks the running
+// system
+func NewValidationRunner() *ValidationRunner {
+	return NewValidationRunnerWithUseCase(preflightApp.NewRunPreflightUseCase(SystemDetectors()))
+}
 
-	for _, validate := range validations {
-		if err := validate(ctx); err != nil {
-			// Continue even on error - we want to complete all validations
-			// Individual validation errors are captured in results
-			continue
-		}
+// NewValidationRunnerWithUseCase creates a validation runner around a
+// configured preflight use case
+func NewValidationRunnerWithUseCase(useCase *preflightApp.RunPreflightUseCase) *ValidationRunner {
+	r := &ValidationRunner{
+		useCase: useCase,
+		session: preflight.NewValidationSession(),
 	}
-
-	// Mark session as complete
-	r.session.Complete()
-
-	return nil
+	r.sizeProgress()
+	return r
 }
 
-// Session returns the validation session
-func (r *ValidationRunner) Session() *preflight.ValidationSession {
-	return r.session
+// WithSeverityPolicy makes the runner adjust results with per-requirement
+// severity overrides. Call it before Run.
+func (r *ValidationRunner) WithSeverityPolicy(policy preflight.SeverityPolicy) *ValidationRunner {
+	r.useCase = r.useCase.WithSeverityPolicy(policy)
+	r.session = preflight.NewValidationSessionWithPolicy(policy)
+	return r
 }
 
-// Progress returns the progress update channel
-func (r *ValidationRunner) Progress() <-chan ProgressUpdate {
-	return r.progressChan
+// WithSpaceReclaimer makes the runner free space, such as by cleaning
+// caches, when a partition is close to its requirement. Call it before Run.
+func (r *ValidationRunner) WithSpaceReclaimer(reclaimer preflightApp.SpaceReclaimer) *ValidationRunner {
+	r.useCase = r.useCase.WithSpaceReclaimer(reclaimer)
+	return r
 }
 
-func (r *ValidationRunner) validateDebianVersion(ctx context.Context) error {
-	r.sendProgress(preflight.RequirementDebianVersion, "running", "Detecting Debian version...")
-
-	version, err := r.debianDetector.DetectVersion(ctx)
-	if err != nil {
-		result := preflight.NewValidationResult(
-			preflight.RequirementDebianVersion,
-			preflight.StatusFail,
-			preflight.SeverityCritical,
-			nil,
-			"Debian Sid or Trixie",
-			preflight.NewUserGuidance(
-				"Unable to detect Debian version",
-				"Failed to read or parse /etc/os-release",
-				[]string{
-					"Ensure /etc/os-release exists and contains VERSION_CODENAME",
-					"Verify you are running Debian Sid or Trixie",
-					"Check that the system is properly configured",
-				},
-				"",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementDebianVersion, preflight.StatusFail, "Failed to detect Debian version", &result)
-		return err
-	}
-
-	if !version.IsSupported() {
-		result := preflight.NewValidationResult(
-			preflight.RequirementDebianVersion,
-			preflight.StatusFail,
-			preflight.SeverityCritical,
-			version.Codename(),
-			"Debian Sid or Trixie",
-			preflight.NewUserGuidance(
-				fmt.Sprintf("Debian %s is not supported. Gohan requires Debian Sid or Trixie.", version.Codename()),
-				"This Debian version does not meet Hyprland's requirements",
-				[]string{
-					"Upgrade to Debian Sid (unstable) or Trixie (testing)",
-					"Visit https://wiki.debian.org/DebianUnstable for upgrade instructions",
-					"Backup your system before upgrading",
-				},
-				"https://wiki.debian.org/DebianUnstable",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementDebianVersion, preflight.StatusFail, fmt.Sprintf("Unsupported version: %s", version), &result)
-		return nil
-	}
-
-	result := preflight.NewValidationResult(
-		preflight.RequirementDebianVersion,
-		preflight.StatusPass,
-		preflight.SeverityLow,
-		version.Codename(),
-		"Debian Sid or Trixie",
-		preflight.UserGuidance{},
-	)
-	r.session.AddResult(result)
-	r.sendProgressWithResult(preflight.RequirementDebianVersion, preflight.StatusPass, fmt.Sprintf("Detected: %s", version), &result)
-	return nil
+// WithInstallation adapts the checks to the components an installation
+// selected. Call it before Run and Progress.
+func (r *ValidationRunner) WithInstallation(config installation.InstallationConfiguration) *ValidationRunner {
+	scope := preflightApp.ScopeForInstallation(config)
+	r.scope = &scope
+	r.sizeProgress()
+	return r
 }
 
-func (r *ValidationRunner) validateGPU(ctx context.Context) error {
-	r.sendProgress(preflight.RequirementGPUSupport, "running", "Detecting GPU...")
-
-	gpus, err := r.gpuDetector.DetectGPUs(ctx)
-	if err != nil {
-		result := preflight.NewValidationResult(
-			preflight.RequirementGPUSupport,
-			preflight.StatusWarning,
-			preflight.SeverityMedium,
-			nil,
-			"AMD or NVIDIA GPU",
-			preflight.NewUserGuidance(
-				"No GPU detected. Hyprland may run with reduced performance.",
-				"lspci did not detect any VGA or 3D controllers",
-				[]string{
-					"Hyprland can run on integrated graphics but performance will be limited",
-					"Consider installing a dedicated AMD or NVIDIA GPU for best experience",
-					"Check if GPU is properly seated in PCIe slot",
-				},
-				"",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementGPUSupport, preflight.StatusWarning, "No GPU detected", &result)
-		return nil
-	}
-
-	primaryGPU := gpus[0]
-	result := preflight.NewValidationResult(
-		preflight.RequirementGPUSupport,
-		preflight.StatusPass,
-		preflight.SeverityLow,
-		primaryGPU.Vendor(),
-		"AMD or NVIDIA GPU",
-		preflight.UserGuidance{},
-	)
-	r.session.AddResult(result)
-	r.sendProgressWithResult(preflight.RequirementGPUSupport, preflight.StatusPass, fmt.Sprintf("Detected: %s", primaryGPU), &result)
-	return nil
+// TotalChecks returns how many checks Run performs
+func (r *ValidationRunner) TotalChecks() int {
+	return r.useCase.CheckCount(r.request())
 }
 
-func (r *ValidationRunner) validateDiskSpace(ctx context.Context) error {
-	r.sendProgress(preflight.RequirementDiskSpace, "running", "Checking disk space...")
-
-	diskSpace, err := r.diskSpaceDetector.DetectAvailableSpace(ctx, "/")
-	if err != nil {
-		result := preflight.NewValidationResult(
-			preflight.RequirementDiskSpace,
-			preflight.StatusFail,
-			preflight.SeverityHigh,
-			nil,
-			"10 GB available",
-			preflight.NewUserGuidance(
-				"Unable to check disk space",
-				"Failed to query filesystem statistics",
-				[]string{
-					"Verify filesystem is mounted correctly",
-					"Check disk health with 'smartctl -a /dev/sda'",
-					"Ensure at least 10 GB of free space on root partition",
-				},
-				"",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementDiskSpace, preflight.StatusFail, "Failed to check disk space", &result)
-		return err
-	}
-
-	const minSpaceGB = 10.0
-	if !diskSpace.MeetsMinimum(minSpaceGB) {
-		result := preflight.NewValidationResult(
-			preflight.RequirementDiskSpace,
-			preflight.StatusFail,
-			preflight.SeverityHigh,
-			fmt.Sprintf("%.2f GB", diskSpace.AvailableGB()),
-			fmt.Sprintf("%.2f GB", minSpaceGB),
-			preflight.NewUserGuidance(
-				fmt.Sprintf("Insufficient disk space. Found %.2f GB, need %.2f GB", diskSpace.AvailableGB(), minSpaceGB),
-				"Hyprland and dependencies require significant disk space",
-				[]string{
-					"Free up disk space by removing unnecessary files",
-					"Use 'apt clean' to remove cached packages",
-					"Use 'du -sh /*' to find large directories",
-					"Consider resizing partitions or adding storage",
-				},
-				"",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementDiskSpace, preflight.StatusFail, fmt.Sprintf("Only %.2f GB available", diskSpace.AvailableGB()), &result)
-		return nil
-	}
-
-	result := preflight.NewValidationResult(
-		preflight.RequirementDiskSpace,
-		preflight.StatusPass,
-		preflight.SeverityLow,
-		fmt.Sprintf("%.2f GB", diskSpace.AvailableGB()),
-		fmt.Sprintf("%.2f GB", minSpaceGB),
-		preflight.UserGuidance{},
-	)
-	r.session.AddResult(result)
-	r.sendProgressWithResult(preflight.RequirementDiskSpace, preflight.StatusPass, fmt.Sprintf("%.2f GB available", diskSpace.AvailableGB()), &result)
-	return nil
+// request describes the run to the use case
+func (r *ValidationRunner) request() preflightApp.RunPreflightRequest {
+	// Configs are deployed to $HOME, which may be its own partition
+	homeDir, _ := os.UserHomeDir()
+	return preflightApp.RunPreflightRequest{HomeDir: homeDir, Scope: r.scope}
 }
 
-func (r *ValidationRunner) validateConnectivity(ctx context.Context) error {
-	r.sendProgress(preflight.RequirementInternet, "running", "Testing internet connectivity...")
+// Run executes all validation checks. The progress channel is closed when
+// it returns.
+func (r *ValidationRunner) Run(ctx context.Context) error {
+	defer close(r.progressChan)
 
-	connectivity, err := r.connectivityChecker.CheckInternetConnectivity(ctx)
+	session, err := r.useCase.RunSession(ctx, r.request(),
+		func(name string, requirement preflight.RequirementName) {
+			r.publish(ProgressUpdate{
+				RequirementName: requirement,
+				Message:         fmt.Sprintf("Checking %s...", name),
+			})
+		},
+		func(name string, result preflight.ValidationResult) {
+			r.publish(ProgressUpdate{
+				RequirementName: result.RequirementName(),
+				Status:          result.Status(),
+				Message:         progressMessage(result),
+				Result:          &result,
+			})
+		},
+	)
 	if err != nil {
-		result := preflight.NewValidationResult(
-			preflight.RequirementInternet,
-			preflight.StatusFail,
-			preflight.SeverityHigh,
-			nil,
-			"Internet access",
-			preflight.NewUserGuidance(
-				"Unable to test internet connectivity",
-				"Network connectivity test failed",
-				[]string{
-					"Check network configuration with 'ip addr' and 'ip route'",
-					"Verify DNS resolution with 'ping -c 3 debian.org'",
-					"Check firewall settings",
-					"Ensure network cable is connected or WiFi is enabled",
-				},
-				"",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementInternet, preflight.StatusFail, "Failed to check connectivity", &result)
 		return err
 	}
 
-	if !connectivity.IsConnected() {
-		result := preflight.NewValidationResult(
-			preflight.RequirementInternet,
-			preflight.StatusFail,
-			preflight.SeverityHigh,
-			"No connection",
-			"Internet access",
-			preflight.NewUserGuidance(
-				"No internet connection detected. Cannot reach Debian repositories.",
-				"All connectivity tests failed to reach Debian servers",
-				[]string{
-					"Check network cable or WiFi connection",
-					"Verify network configuration: 'ip addr' and 'ip route'",
-					"Test DNS: 'ping -c 3 debian.org'",
-					"Check if proxy settings are required",
-					"Temporarily disable firewall: 'systemctl stop ufw'",
-				},
-				"",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementInternet, preflight.StatusFail, "No internet connection", &result)
-		return nil
-	}
-
-	result := preflight.NewValidationResult(
-		preflight.RequirementInternet,
-		preflight.StatusPass,
-		preflight.SeverityLow,
-		"Connected",
-		"Internet access",
-		preflight.UserGuidance{},
-	)
-	r.session.AddResult(result)
-	r.sendProgressWithResult(preflight.RequirementInternet, preflight.StatusPass, fmt.Sprintf("Connected (avg latency: %v)", connectivity.AverageLatency()), &result)
+	r.session = session
 	return nil
 }
 
-func (r *ValidationRunner) validateSourceRepositories(ctx context.Context) error {
-	r.sendProgress(preflight.RequirementSourceRepos, "running", "Checking source repositories...")
-
-	status, err := r.sourceRepoChecker.CheckSourceRepositories(ctx)
-	if err != nil {
-		result := preflight.NewValidationResult(
-			preflight.RequirementSourceRepos,
-			preflight.StatusWarning,
-			preflight.SeverityLow,
-			nil,
-			"deb-src configured",
-			preflight.NewUserGuidance(
-				"Unable to check source repositories",
-				"Could not read /etc/apt/sources.list or sources.list.d/",
-				[]string{
-					"This is optional but recommended for building packages from source",
-					"Manually verify /etc/apt/sources.list contains deb-src lines",
-				},
-				"",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementSourceRepos, preflight.StatusWarning, "Could not check source repos", &result)
-		return nil
+// progressMessage summarizes a finished check in one line
+func progressMessage(result preflight.ValidationResult) string {
+	if result.IsPassing() {
+		if actual := result.ActualValue(); actual != nil {
+			return fmt.Sprintf("Detected: %v", actual)
+		}
+		return "Passed"
 	}
+	return result.Guidance().Message()
+}
 
-	if !status.HasDebSrc() {
-		result := preflight.NewValidationResult(
-			preflight.RequirementSourceRepos,
-			preflight.StatusWarning,
-			preflight.SeverityLow,
-			"Not configured",
-			"deb-src configured",
-			preflight.NewUserGuidance(
-				"Source repositories (deb-src) are not configured. Recommended for building packages.",
-				"No deb-src lines found in apt configuration",
-				[]string{
-					"Edit /etc/apt/sources.list",
-					"Uncomment lines starting with 'deb-src' or add them if missing",
-					"Run 'apt update' after making changes",
-					"This is optional but helpful for building custom packages",
-				},
-				"",
-			),
-		)
-		r.session.AddResult(result)
-		r.sendProgressWithResult(preflight.RequirementSourceRepos, preflight.StatusWarning, "deb-src not configured", &result)
-		return nil
-	}
+// Session returns the validation session
+func (r *ValidationRunner) Session() *preflight.ValidationSession {
+	return r.session
+}
 
-	result := preflight.NewValidationResult(
-		preflight.RequirementSourceRepos,
-		preflight.StatusPass,
-		preflight.SeverityLow,
-		"Configured",
-		"deb-src configured",
-		preflight.UserGuidance{},
-	)
-	r.session.AddResult(result)
-	r.sendProgressWithResult(preflight.RequirementSourceRepos, preflight.StatusPass, "deb-src configured", &result)
-	return nil
+// Progress returns the progress update channel. Publishing never blocks
+// validation: when the consumer falls behind and the buffer is full, the
+// oldest pending update is dropped in favour of the newest.
+func (r *ValidationRunner) Progress() <-chan ProgressUpdate {
+	return r.progressChan
 }
 
-func (r *ValidationRunner) sendProgress(req preflight.RequirementName, status, message string) {
-	// Convert string status to ValidationStatus
-	var validationStatus preflight.ValidationStatus
-	switch status {
-	case "running":
-		validationStatus = "" // No status yet
-	case "pass":
-		validationStatus = preflight.StatusPass
-	case "fail":
-		validationStatus = preflight.StatusFail
-	case "warning":
-		validationStatus = preflight.StatusWarning
-	}
+// sizeProgress makes the progress channel hold two updates per check, so
+// a consumer that only reads after Run returns still sees every update
+func (r *ValidationRunner) sizeProgress() {
+	r.progressChan = make(chan ProgressUpdate, 2*r.TotalChecks())
+}
 
-	r.progressChan <- ProgressUpdate{
-		RequirementName: req,
-		Status:          validationStatus,
-		Message:         message,
-	}
+// DroppedUpdates returns how many progress updates were discarded because
+// the consumer was too slow or absent
+func (r *ValidationRunner) DroppedUpdates() int64 {
+	return r.droppedUpdates.Load()
 }
 
-func (r *ValidationRunner) sendProgressWithResult(req preflight.RequirementName, status preflight.ValidationStatus, message string, result *preflight.ValidationResult) {
-	r.progressChan <- ProgressUpdate{
-		RequirementName: req,
-		Status:          status,
-		Message:         message,
-		Result:          result,
+// publish queues an update without blocking, dropping the oldest pending
+// update when the buffer is full. Results are kept on the session, so a
+// dropped update only loses intermediate progress.
+func (r *ValidationRunner) publish(update ProgressUpdate) {
+	for {
+		select {
+		case r.progressChan <- update:
+			return
+		default:
+		}
+
+		// The consumer may have drained the buffer in the meantime
+		select {
+		case <-r.progressChan:
+			r.droppedUpdates.Add(1)
+		default:
+		}
 	}
 }
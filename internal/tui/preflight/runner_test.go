@@ -2,9 +2,11 @@ package preflight
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	preflightApp "github.com/rebelopsio/gohan/internal/application/preflight"
 	"github.com/rebelopsio/gohan/internal/domain/preflight"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,14 +17,29 @@ func TestNewValidationRunner(t *testing.T) {
 
 	assert.NotNil(t, runner)
 	assert.NotNil(t, runner.session)
-	assert.NotNil(t, runner.debianDetector)
-	assert.NotNil(t, runner.gpuDetector)
-	assert.NotNil(t, runner.diskSpaceDetector)
-	assert.NotNil(t, runner.connectivityChecker)
-	assert.NotNil(t, runner.sourceRepoChecker)
+	assert.NotNil(t, runner.useCase)
 	assert.NotNil(t, runner.progressChan)
 }
 
+func TestValidationRunner_ProgressBuffer(t *testing.T) {
+	detector := failingDetector{}
+	runner := NewValidationRunnerWithUseCase(preflightApp.NewRunPreflightUseCase(preflightApp.Detectors{
+		DebianDetector:          detector,
+		GPUDetector:             detector,
+		DiskSpaceDetector:       detector,
+		ConnectivityChecker:     detector,
+		SourceRepositoryChecker: detector,
+	}))
+
+	// Two updates per check, so none are dropped without a consumer
+	assert.Equal(t, 2*5, cap(runner.progressChan))
+	require.NoError(t, runner.Run(context.Background()))
+	assert.Zero(t, runner.DroppedUpdates())
+
+	system := NewValidationRunner()
+	assert.Equal(t, 2*system.TotalChecks(), cap(system.progressChan))
+}
+
 func TestValidationRunner_Session(t *testing.T) {
 	runner := NewValidationRunner()
 
@@ -52,9 +69,9 @@ func TestValidationRunner_Run_CompletesAllValidations(t *testing.T) {
 	assert.False(t, session.CompletedAt().IsZero(), "Session should be marked complete")
 	assert.NotEmpty(t, session.Results(), "Session should have results")
 
-	// Should have exactly 5 validation results (one for each check)
+	// Should have exactly 11 validation results (one for each check)
 	results := session.Results()
-	assert.Len(t, results, 5, "Should have 5 validation results")
+	assert.Len(t, results, 11, "Should have 11 validation results")
 }
 
 func TestValidationRunner_Run_ProgressUpdates(t *testing.T) {
@@ -83,8 +100,8 @@ func TestValidationRunner_Run_ProgressUpdates(t *testing.T) {
 	// Verify we received progress updates
 	assert.NotEmpty(t, updates, "Should receive progress updates")
 
-	// Should have at least 5 updates (one for each validation)
-	assert.GreaterOrEqual(t, len(updates), 5, "Should have at least 5 progress updates")
+	// Should have at least 11 updates (one for each validation)
+	assert.GreaterOrEqual(t, len(updates), 11, "Should have at least 11 progress updates")
 
 	// Verify all requirements were checked
 	requirements := make(map[preflight.RequirementName]bool)
@@ -126,7 +143,7 @@ func TestValidationRunner_Run_HandlesDetectorErrors(t *testing.T) {
 	results := session.Results()
 
 	assert.NotEmpty(t, results, "Should have results even if some checks failed")
-	assert.Len(t, results, 5, "Should attempt all 5 validations")
+	assert.Len(t, results, 11, "Should attempt all 11 validations")
 }
 
 func TestValidationRunner_ValidationResults_HaveGuidance(t *testing.T) {
@@ -237,3 +254,128 @@ func TestProgressUpdate_Structure(t *testing.T) {
 	assert.Equal(t, "Test message", update.Message)
 	assert.Nil(t, update.Result)
 }
+
+func TestValidationRunner_Publish_DropsOldestWhenFull(t *testing.T) {
+	runner := NewValidationRunner()
+	runner.progressChan = make(chan ProgressUpdate, 2)
+
+	// No consumer: publishing must not block
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, message := range []string{"1", "2", "3", "4", "5"} {
+			runner.publish(ProgressUpdate{Message: message})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a full channel")
+	}
+
+	assert.Equal(t, int64(3), runner.DroppedUpdates())
+	assert.Equal(t, "4", (<-runner.Progress()).Message)
+	assert.Equal(t, "5", (<-runner.Progress()).Message)
+}
+
+func TestValidationRunner_Run_SlowConsumer(t *testing.T) {
+	runner := NewValidationRunner()
+	runner.progressChan = make(chan ProgressUpdate, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Consumer that falls behind, as a busy UI would
+	var last ProgressUpdate
+	received := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range runner.Progress() {
+			time.Sleep(10 * time.Millisecond)
+			last = update
+			received++
+		}
+	}()
+
+	err := runner.Run(ctx)
+	require.NoError(t, err)
+	<-done
+
+	// Validation was not held back and every check still ran
+	assert.Len(t, runner.Session().Results(), 11)
+	assert.Equal(t, int64(2*11), int64(received)+runner.DroppedUpdates())
+	assert.Equal(t, preflight.RequirementWritableRoot, last.RequirementName, "Newest update is never dropped")
+	assert.NotNil(t, last.Result)
+}
+
+// failingDetector fails every detection it is asked for
+type failingDetector struct{}
+
+func (failingDetector) DetectVersion(ctx context.Context) (preflight.DebianVersion, error) {
+	return preflight.DebianVersion{}, errors.New("no os-release")
+}
+
+func (failingDetector) IsDebianBased(ctx context.Context) bool { return false }
+
+func (failingDetector) DetectGPUs(ctx context.Context) ([]preflight.GPUType, error) {
+	return nil, errors.New("no lspci")
+}
+
+func (failingDetector) PrimaryGPU(ctx context.Context) (preflight.GPUType, error) {
+	return preflight.GPUType{}, errors.New("no lspci")
+}
+
+func (failingDetector) DetectAvailableSpace(ctx context.Context, path string) (preflight.DiskSpace, error) {
+	return preflight.DiskSpace{}, errors.New("statfs failed")
+}
+
+func (failingDetector) CheckInternetConnectivity(ctx context.Context) (preflight.InternetConnectivity, error) {
+	return preflight.InternetConnectivity{}, errors.New("no route")
+}
+
+func (failingDetector) CheckDebianRepositories(ctx context.Context) (bool, error) {
+	return false, errors.New("no route")
+}
+
+func (failingDetector) CheckSourceRepositories(ctx context.Context) (preflight.SourceRepositoryStatus, error) {
+	return preflight.SourceRepositoryStatus{}, errors.New("unreadable")
+}
+
+func TestValidationRunner_Run_UsesPreflightUseCase(t *testing.T) {
+	detector := failingDetector{}
+	runner := NewValidationRunnerWithUseCase(preflightApp.NewRunPreflightUseCase(preflightApp.Detectors{
+		DebianDetector:          detector,
+		GPUDetector:             detector,
+		DiskSpaceDetector:       detector,
+		ConnectivityChecker:     detector,
+		SourceRepositoryChecker: detector,
+	}))
+
+	var updates []ProgressUpdate
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range runner.Progress() {
+			updates = append(updates, update)
+		}
+	}()
+
+	require.NoError(t, runner.Run(context.Background()))
+	<-done
+
+	// Detection failures are recorded rather than skipped
+	session := runner.Session()
+	require.Len(t, session.Results(), 5)
+	assert.True(t, session.HasBlockers())
+	assert.False(t, session.CompletedAt().IsZero())
+
+	// Each check reports that it started, then its result
+	require.Len(t, updates, 10)
+	assert.Equal(t, preflight.RequirementDebianVersion, updates[0].RequirementName)
+	assert.Empty(t, updates[0].Status)
+	assert.Nil(t, updates[0].Result)
+	assert.Equal(t, preflight.StatusFail, updates[1].Status)
+	require.NotNil(t, updates[1].Result)
+	assert.Equal(t, "Unable to detect Debian version", updates[1].Message)
+}
@@ -182,6 +182,12 @@ func (w *Wizard) viewRunning() string {
 		preflight.RequirementDiskSpace,
 		preflight.RequirementInternet,
 		preflight.RequirementSourceRepos,
+		preflight.RequirementSystemResources,
+		preflight.RequirementPowerDaemons,
+		preflight.RequirementSession,
+		preflight.RequirementSourcesSanity,
+		preflight.RequirementThroughput,
+		preflight.RequirementWritableRoot,
 	}
 
 	for _, req := range requirements {
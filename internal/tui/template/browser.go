@@ -0,0 +1,283 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	configApp "github.com/rebelopsio/gohan/internal/application/configuration"
+)
+
+// Searcher searches saved configuration templates
+type Searcher interface {
+	Execute(ctx context.Context, req configApp.SearchTemplatesRequest) (*configApp.ListTemplatesResponse, error)
+}
+
+// Browser is the Bubble Tea model for browsing configuration templates.
+// Typing refines the search: #tag requires a tag, @category selects a
+// category and any other word matches template names.
+type Browser struct {
+	searcher      Searcher
+	input         string
+	templates     []configApp.TemplateDTO
+	selectedIndex int
+	detail        bool
+	searchErr     error
+	width         int
+	height        int
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// Message types
+type resultsMsg struct {
+	input     string
+	templates []configApp.TemplateDTO
+	err       error
+}
+
+// NewBrowser creates a new template browser
+func NewBrowser(searcher Searcher) *Browser {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Browser{
+		searcher: searcher,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Init loads every template
+func (b *Browser) Init() tea.Cmd {
+	return b.search()
+}
+
+// Update handles messages
+func (b *Browser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return b.handleKey(msg)
+
+	case tea.WindowSizeMsg:
+		b.width = msg.Width
+		b.height = msg.Height
+		return b, nil
+
+	case resultsMsg:
+		// Ignore results of searches the input has moved past
+		if msg.input != b.input {
+			return b, nil
+		}
+		b.templates = msg.templates
+		b.searchErr = msg.err
+		if b.selectedIndex >= len(b.templates) {
+			b.selectedIndex = 0
+		}
+		return b, nil
+	}
+
+	return b, nil
+}
+
+// handleKey handles keyboard input; printable keys edit the search
+func (b *Browser) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		b.cancel()
+		return b, tea.Quit
+
+	case tea.KeyEsc:
+		switch {
+		case b.detail:
+			b.detail = false
+		case b.input != "":
+			b.input = ""
+			return b, b.search()
+		default:
+			b.cancel()
+			return b, tea.Quit
+		}
+
+	case tea.KeyUp:
+		if !b.detail && b.selectedIndex > 0 {
+			b.selectedIndex--
+		}
+
+	case tea.KeyDown:
+		if !b.detail && b.selectedIndex < len(b.templates)-1 {
+			b.selectedIndex++
+		}
+
+	case tea.KeyEnter:
+		if !b.detail && len(b.templates) > 0 {
+			b.detail = true
+		}
+
+	case tea.KeyBackspace:
+		if !b.detail && b.input != "" {
+			runes := []rune(b.input)
+			b.input = string(runes[:len(runes)-1])
+			return b, b.search()
+		}
+
+	case tea.KeyRunes, tea.KeySpace:
+		if !b.detail {
+			b.input += string(msg.Runes)
+			b.selectedIndex = 0
+			return b, b.search()
+		}
+	}
+
+	return b, nil
+}
+
+// search runs the search for the current input
+func (b *Browser) search() tea.Cmd {
+	input := b.input
+	return func() tea.Msg {
+		response, err := b.searcher.Execute(b.ctx, parseSearch(input))
+		if err != nil {
+			return resultsMsg{input: input, err: err}
+		}
+		return resultsMsg{input: input, templates: response.Templates}
+	}
+}
+
+// parseSearch splits the search input into name, #tag and @category terms
+func parseSearch(input string) configApp.SearchTemplatesRequest {
+	var req configApp.SearchTemplatesRequest
+	var words []string
+
+	for _, field := range strings.Fields(input) {
+		switch {
+		case strings.HasPrefix(field, "#") && len(field) > 1:
+			req.Tags = append(req.Tags, field[1:])
+		case strings.HasPrefix(field, "@") && len(field) > 1:
+			req.Category = field[1:]
+		default:
+			words = append(words, field)
+		}
+	}
+	req.Query = strings.Join(words, " ")
+
+	return req
+}
+
+// View renders the current view
+func (b *Browser) View() string {
+	if b.detail && b.selectedIndex < len(b.templates) {
+		return b.renderDetail(b.templates[b.selectedIndex])
+	}
+	return b.renderList()
+}
+
+// renderList renders the search input and the matching templates
+func (b *Browser) renderList() string {
+	var s strings.Builder
+
+	s.WriteString(titleStyle.Render("Configuration Templates"))
+	s.WriteString("\n\n")
+	s.WriteString(promptStyle.Render("Search: "))
+	s.WriteString(b.input + "█")
+	s.WriteString("\n\n")
+
+	if b.searchErr != nil {
+		s.WriteString(errorStyle.Render(b.searchErr.Error()))
+		s.WriteString("\n")
+	} else if len(b.templates) == 0 {
+		s.WriteString("No templates match.\n")
+	} else {
+		header := fmt.Sprintf("%-24s %-12s %-10s %s", "NAME", "CATEGORY", "COMPONENTS", "TAGS")
+		s.WriteString(listHeaderStyle.Render(header))
+		s.WriteString("\n\n")
+
+		// Show a window around the selection
+		visibleHeight := b.height - 12
+		if visibleHeight < 5 {
+			visibleHeight = 5
+		}
+		startIdx := 0
+		if b.selectedIndex >= visibleHeight {
+			startIdx = b.selectedIndex - visibleHeight + 1
+		}
+		endIdx := startIdx + visibleHeight
+		if endIdx > len(b.templates) {
+			endIdx = len(b.templates)
+		}
+
+		for i := startIdx; i < endIdx; i++ {
+			template := b.templates[i]
+			line := fmt.Sprintf("%-24s %-12s %-10d %s",
+				truncateString(template.Name, 24),
+				template.Category,
+				len(template.Components),
+				strings.Join(template.Tags, ", "))
+
+			if i == b.selectedIndex {
+				s.WriteString(selectedItemStyle.Render("→ " + line))
+			} else {
+				s.WriteString(listItemStyle.Render("  " + line))
+			}
+			s.WriteString("\n")
+		}
+	}
+
+	s.WriteString(helpStyle.Render(
+		"type to search (#tag, @category) • ↑/↓: move • enter: details • esc: clear/quit"))
+
+	return s.String()
+}
+
+// renderDetail renders one template
+func (b *Browser) renderDetail(template configApp.TemplateDTO) string {
+	var s strings.Builder
+
+	s.WriteString(titleStyle.Render(template.Name))
+	s.WriteString("\n\n")
+
+	var info strings.Builder
+	info.WriteString(lipgloss.NewStyle().Bold(true).Render("Template"))
+	info.WriteString("\n\n")
+	fields := [][2]string{
+		{"ID:", template.ID},
+		{"Category:", template.Category},
+		{"Description:", template.Description},
+		{"Author:", template.Author},
+		{"Tags:", strings.Join(template.Tags, ", ")},
+		{"Created:", template.CreatedAt},
+		{"Version:", fmt.Sprintf("%d", template.Version)},
+	}
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+		info.WriteString(detailLabelStyle.Render(field[0]))
+		info.WriteString(detailValueStyle.Render(field[1]))
+		info.WriteString("\n")
+	}
+	s.WriteString(detailSectionStyle.Render(strings.TrimRight(info.String(), "\n")))
+	s.WriteString("\n")
+
+	var components strings.Builder
+	components.WriteString(lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Components (%d)", len(template.Components))))
+	components.WriteString("\n\n")
+	for _, comp := range template.Components {
+		components.WriteString(fmt.Sprintf("• %s %s\n", comp.Name, comp.Version))
+	}
+	s.WriteString(detailSectionStyle.Render(strings.TrimRight(components.String(), "\n")))
+
+	s.WriteString("\n")
+	s.WriteString(helpStyle.Render("esc: back to list • ctrl+c: quit"))
+
+	return s.String()
+}
+
+func truncateString(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length-3] + "..."
+}
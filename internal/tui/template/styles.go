@@ -0,0 +1,62 @@
+package template
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	// Color palette
+	primaryColor   = lipgloss.Color("#7C3AED") // Purple
+	errorColor     = lipgloss.Color("#EF4444") // Red
+	mutedColor     = lipgloss.Color("#6B7280") // Gray
+	highlightColor = lipgloss.Color("#F59E0B") // Amber
+
+	// Title styles
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(primaryColor).
+			MarginBottom(1)
+
+	// Search input styles
+	promptStyle = lipgloss.NewStyle().
+			Foreground(primaryColor).
+			Bold(true)
+
+	// List styles
+	listHeaderStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(primaryColor).
+			Padding(0, 1)
+
+	listItemStyle = lipgloss.NewStyle().
+			Padding(0, 2)
+
+	selectedItemStyle = lipgloss.NewStyle().
+				Padding(0, 2).
+				Foreground(highlightColor).
+				Bold(true)
+
+	// Detail view styles
+	detailLabelStyle = lipgloss.NewStyle().
+				Foreground(mutedColor).
+				Width(16)
+
+	detailValueStyle = lipgloss.NewStyle().
+				Bold(true)
+
+	detailSectionStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(primaryColor).
+				Padding(1, 2).
+				MarginBottom(1)
+
+	// Help text styles
+	helpStyle = lipgloss.NewStyle().
+			Foreground(mutedColor).
+			Italic(true).
+			MarginTop(1)
+
+	// Error styles
+	errorStyle = lipgloss.NewStyle().
+			Foreground(errorColor).
+			Bold(true)
+)
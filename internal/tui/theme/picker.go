@@ -125,7 +125,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) View() string {
 	if m.quitting {
 		if m.selected != nil {
-			return successStyle.Render(fmt.Sprintf("✓ Selected theme: %s", m.selected.DisplayName))
+			return successStyle.Render(fmt.Sprintf("✓ Selected theme: %s", m.selected.DisplayName()))
 		}
 		return ""
 	}
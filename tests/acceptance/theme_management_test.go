@@ -17,15 +17,16 @@ func TestThemeManagement(t *testing.T) {
 
 	t.Run("List available themes", func(t *testing.T) {
 		// Given the theme system is initialized
-		// And the following themes are available: mocha, latte, frappe, macchiato, gohan
+		// And the following themes are available: mocha, latte, frappe, macchiato, gohan,
+		// high-contrast, high-contrast-light
 		themeService := setupThemeService(t)
 
 		// When I view available themes
 		themes, err := themeService.ListThemes(ctx)
 
-		// Then I should see 5 themes
+		// Then I should see 7 themes
 		require.NoError(t, err)
-		assert.Len(t, themes, 5)
+		assert.Len(t, themes, 7)
 
 		// And each theme should have a name
 		for _, theme := range themes {
@@ -102,8 +103,8 @@ func TestThemeManagement(t *testing.T) {
 		darkThemes, err := themeService.ListThemesByVariant(ctx, "dark")
 		require.NoError(t, err)
 
-		// Then I should see 4 themes
-		assert.Len(t, darkThemes, 4)
+		// Then I should see 5 themes
+		assert.Len(t, darkThemes, 5)
 		// And all themes should be suitable for low-light environments
 		for _, theme := range darkThemes {
 			assert.Equal(t, "dark", theme.Variant)
@@ -118,10 +119,11 @@ func TestThemeManagement(t *testing.T) {
 		lightThemes, err := themeService.ListThemesByVariant(ctx, "light")
 		require.NoError(t, err)
 
-		// Then I should see 1 theme
-		require.Len(t, lightThemes, 1)
-		// And the theme should be "latte"
-		assert.Equal(t, "latte", lightThemes[0].Name)
+		// Then I should see 2 themes
+		require.Len(t, lightThemes, 2)
+		// And they should be "latte" and "high-contrast-light"
+		assert.ElementsMatch(t, []string{"latte", "high-contrast-light"},
+			[]string{lightThemes[0].Name, lightThemes[1].Name})
 	})
 
 	t.Run("Get active theme information", func(t *testing.T) {
@@ -0,0 +1,242 @@
+//go:build integration
+// +build integration
+
+package cancellation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rebelopsio/gohan/internal/application/installation/dto"
+	"github.com/rebelopsio/gohan/internal/application/installation/usecases"
+	"github.com/rebelopsio/gohan/internal/domain/installation"
+	"github.com/rebelopsio/gohan/internal/domain/preflight"
+	httpinfra "github.com/rebelopsio/gohan/internal/infrastructure/http"
+	"github.com/rebelopsio/gohan/internal/infrastructure/http/handlers"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/packagemanager"
+	"github.com/rebelopsio/gohan/internal/infrastructure/installation/repository"
+	installServices "github.com/rebelopsio/gohan/internal/infrastructure/installation/services"
+	preflightTUI "github.com/rebelopsio/gohan/internal/tui/preflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedAPTManager is the dry-run APT manager with a gate on the first
+// package install, so a test can cancel while it is in progress
+type gatedAPTManager struct {
+	*packagemanager.APTManager
+	installing chan struct{}
+	release    chan struct{}
+	installs   atomic.Int32
+}
+
+func newGatedAPTManager() *gatedAPTManager {
+	return &gatedAPTManager{
+		APTManager: packagemanager.NewAPTManagerDryRun(),
+		installing: make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+}
+
+func (m *gatedAPTManager) InstallPackage(ctx context.Context, packageName, version string) error {
+	if err := m.wait(ctx); err != nil {
+		return err
+	}
+	return m.APTManager.InstallPackage(ctx, packageName, version)
+}
+
+func (m *gatedAPTManager) InstallPackageWithProgress(ctx context.Context, packageName, version string, report func(packagemanager.PackageProgress)) error {
+	if err := m.wait(ctx); err != nil {
+		return err
+	}
+	return m.APTManager.InstallPackageWithProgress(ctx, packageName, version, report)
+}
+
+// wait holds the first install until the gate is released
+func (m *gatedAPTManager) wait(ctx context.Context) error {
+	if m.installs.Add(1) == 1 {
+		close(m.installing)
+		select {
+		case <-m.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// passingPreflight reports every check as passed
+type passingPreflight struct{}
+
+func (passingPreflight) Run(ctx context.Context) error { return nil }
+
+func (passingPreflight) Session() *preflight.ValidationSession {
+	session := preflight.NewValidationSession()
+	session.Complete()
+	return session
+}
+
+func (passingPreflight) Progress() <-chan preflightTUI.ProgressUpdate {
+	progress := make(chan preflightTUI.ProgressUpdate)
+	close(progress)
+	return progress
+}
+
+func (passingPreflight) TotalChecks() int { return 0 }
+
+// newPassingPreflight creates a passing validator for every installation
+func newPassingPreflight(installation.InstallationConfiguration) usecases.PreflightValidator {
+	return passingPreflight{}
+}
+
+// apiFixture serves the installation API over a session database
+type apiFixture struct {
+	server  *httptest.Server
+	running *usecases.RunningInstallations
+}
+
+func newAPIFixture(t *testing.T, dbPath string, packageManager usecases.PackageManager) *apiFixture {
+	sessionRepo, err := repository.NewSQLiteSimpleSessionRepository(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { sessionRepo.Close() })
+
+	running := usecases.NewRunningInstallations()
+	aptManager := packagemanager.NewAPTManagerDryRun()
+	handler := handlers.NewInstallationHandler(
+		usecases.NewStartInstallationUseCase(sessionRepo),
+		usecases.NewExecuteInstallationUseCase(
+			sessionRepo,
+			aptManager,
+			installServices.NewProgressEstimator(),
+			installServices.NewConfigurationMerger(),
+			packageManager,
+			nil,
+			newPassingPreflight,
+			nil,
+		).WithRunningInstallations(running),
+		usecases.NewGetInstallationStatusUseCase(sessionRepo),
+		usecases.NewListInstallationsUseCase(sessionRepo),
+		usecases.NewCancelInstallationUseCase(sessionRepo).WithRunningInstallations(running),
+	)
+
+	server := httptest.NewServer(httpinfra.NewServer(httpinfra.Config{}, handler, false).Router())
+	t.Cleanup(server.Close)
+	return &apiFixture{server: server, running: running}
+}
+
+func (f *apiFixture) post(t *testing.T, path string, body any) (int, dto.InstallationProgressResponse) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	resp, err := http.Post(f.server.URL+path, "application/json", bytes.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var progress dto.InstallationProgressResponse
+	_ = json.NewDecoder(resp.Body).Decode(&progress)
+	return resp.StatusCode, progress
+}
+
+func (f *apiFixture) start(t *testing.T) string {
+	payload, err := json.Marshal(dto.InstallationRequest{
+		Components: []dto.ComponentRequest{
+			{Name: "hyprland", Version: "0.35.0"},
+			{Name: "waybar", Version: "0.9.24"},
+		},
+		AvailableSpace: 100 * 1024 * 1024 * 1024,
+		RequiredSpace:  10 * 1024 * 1024 * 1024,
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Post(f.server.URL+"/api/installation/start", "application/json", bytes.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var started dto.InstallationResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&started))
+	return started.SessionID
+}
+
+// executeAsync runs the installation in the background and returns its
+// final response
+func (f *apiFixture) executeAsync(t *testing.T, sessionID string) <-chan dto.InstallationProgressResponse {
+	results := make(chan dto.InstallationProgressResponse, 1)
+	go func() {
+		_, response := f.post(t, "/api/installation/"+sessionID+"/execute", nil)
+		results <- response
+	}()
+	return results
+}
+
+func TestCancelInstallation_DuringPackagePhase(t *testing.T) {
+	t.Run("graceful cancel finishes the current package and resumes later", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "installations.db")
+		packageManager := newGatedAPTManager()
+		api := newAPIFixture(t, dbPath, packageManager)
+
+		sessionID := api.start(t)
+		executed := api.executeAsync(t, sessionID)
+		<-packageManager.installing
+
+		cancelled := make(chan dto.InstallationProgressResponse, 1)
+		go func() {
+			status, response := api.post(t, "/api/installation/"+sessionID+"/cancel", nil)
+			assert.Equal(t, http.StatusOK, status)
+			cancelled <- response
+		}()
+		require.Eventually(t, func() bool { return api.running.IsCancelling(sessionID) }, 5*time.Second, time.Millisecond)
+		close(packageManager.release)
+
+		response := <-cancelled
+		assert.Equal(t, "cancelled", response.Status)
+		assert.Equal(t, 1, response.ComponentsInstalled)
+		assert.Equal(t, "cancelled", (<-executed).Status)
+		assert.EqualValues(t, 1, packageManager.installs.Load())
+
+		// A fresh process over the same database resumes where it stopped
+		resumedManager := newGatedAPTManager()
+		close(resumedManager.release)
+		resumed := newAPIFixture(t, dbPath, resumedManager)
+
+		status, final := resumed.post(t, "/api/installation/"+sessionID+"/execute", nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "completed", final.Status)
+		assert.Equal(t, 2, final.ComponentsInstalled)
+		assert.EqualValues(t, 1, resumedManager.installs.Load(), "only the remaining package is installed")
+	})
+
+	t.Run("force cancel interrupts the current package", func(t *testing.T) {
+		packageManager := newGatedAPTManager()
+		api := newAPIFixture(t, filepath.Join(t.TempDir(), "installations.db"), packageManager)
+
+		sessionID := api.start(t)
+		executed := api.executeAsync(t, sessionID)
+		<-packageManager.installing
+
+		status, response := api.post(t, "/api/installation/"+sessionID+"/cancel?force=true", nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "cancelled", response.Status)
+		assert.Equal(t, 0, response.ComponentsInstalled)
+		for _, component := range response.Components {
+			assert.Equal(t, "pending", component.State)
+		}
+		assert.Equal(t, "cancelled", (<-executed).Status)
+
+		// Nothing is running any more; a second cancel is a no-op
+		status, response = api.post(t, "/api/installation/"+sessionID+"/cancel", nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "cancelled", response.Status)
+	})
+}
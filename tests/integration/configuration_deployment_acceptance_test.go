@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/rebelopsio/gohan/internal/application/configuration"
+	domainConfig "github.com/rebelopsio/gohan/internal/domain/configuration"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/backup"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/configservice"
 	"github.com/rebelopsio/gohan/internal/infrastructure/installation/templates"
@@ -497,8 +498,56 @@ func TestConfigurationDeployment_VerifyDeployment(t *testing.T) {
 	t.Skip("Covered by other tests - file existence, permissions, and template substitution")
 }
 
+// TestConfigurationDeployment_PreserveCustomizations corresponds to:
+// Scenario: Preserve user customizations around gohan's managed block
 func TestConfigurationDeployment_PreserveCustomizations(t *testing.T) {
-	t.Skip("TODO: Implement custom file preservation test when feature is added")
+	setupTemplateFiles(t)
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+
+	templateEngine := templates.NewTemplateEngine()
+	backupService := backup.NewBackupService(filepath.Join(tmpDir, "backups"))
+	deployer := configservice.NewConfigDeployer(templateEngine, backupService)
+	useCase := configuration.NewConfigDeployUseCase(deployer, templateEngine)
+
+	request := configuration.DeployConfigRequest{
+		Components: []string{"hyprland"},
+		CustomVars: map[string]string{
+			"username": "testuser",
+			"home":     tmpDir,
+			"home_dir": tmpDir,
+		},
+	}
+
+	// Given I have deployed the Hyprland configuration
+	_, err := useCase.Execute(ctx, request)
+	require.NoError(t, err)
+	hyprlandConf := filepath.Join(tmpDir, ".config", "hypr", "hyprland.conf")
+	deployed, err := os.ReadFile(hyprlandConf)
+	require.NoError(t, err)
+	assert.Contains(t, string(deployed), domainConfig.ManagedBlockBegin)
+	assert.Contains(t, string(deployed), domainConfig.ManagedBlockEnd)
+
+	// And I added my own settings around gohan's managed block
+	customized := "# my monitors\nmonitor = DP-1, 2560x1440@144, 0x0, 1\n" +
+		string(deployed) +
+		"bind = SUPER, B, exec, firefox\n"
+	require.NoError(t, os.WriteFile(hyprlandConf, []byte(customized), 0644))
+
+	// When the configuration is deployed again with other settings
+	request.CustomVars["username"] = "otheruser"
+	resp, err := useCase.Execute(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.SuccessfulFiles)
+
+	// Then my settings should still be there
+	content, err := os.ReadFile(hyprlandConf)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "# my monitors\nmonitor = DP-1, 2560x1440@144, 0x0, 1\n"))
+	assert.True(t, strings.HasSuffix(string(content), "bind = SUPER, B, exec, firefox\n"))
+
+	// And the managed block should appear once
+	assert.Equal(t, 1, strings.Count(string(content), domainConfig.ManagedBlockBegin))
 }
 
 func TestConfigurationDeployment_RollbackOnFailure(t *testing.T) {
@@ -52,7 +52,7 @@ func TestPackageInstallation_MinimalProfile(t *testing.T) {
 	// Execute installation asynchronously
 	done := make(chan error, 1)
 	go func() {
-		_, err := services.executeUseCase.Execute(ctx, sessionID, nil)
+		_, err := services.executeUseCase.Execute(ctx, sessionID)
 		done <- err
 	}()
 
@@ -5,6 +5,8 @@ package preflight_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -95,6 +97,69 @@ func TestSystemDiskSpaceDetector_Integration(t *testing.T) {
 		assert.Equal(t, "/", diskSpace.Path(), "Empty path should default to /")
 		assert.Greater(t, diskSpace.Total(), uint64(0), "Total space should be greater than 0")
 	})
+
+	t.Run("DetectMountPoint for root", func(t *testing.T) {
+		mountPoint, err := detector.DetectMountPoint(ctx, "/")
+		require.NoError(t, err)
+		assert.Equal(t, "/", mountPoint)
+	})
+
+	t.Run("DetectMountPoint with separate partitions", func(t *testing.T) {
+		mountInfo := filepath.Join(t.TempDir(), "mountinfo")
+		content := "22 1 8:2 / / rw,relatime shared:1 - ext4 /dev/sda2 rw\n" +
+			"23 22 8:3 / /var rw,relatime shared:2 - ext4 /dev/sda3 rw\n" +
+			"24 22 8:1 / /boot rw,relatime shared:3 - ext4 /dev/sda1 rw\n"
+		require.NoError(t, os.WriteFile(mountInfo, []byte(content), 0644))
+
+		fixture := detectors.NewSystemDiskSpaceDetectorWithMountInfo(mountInfo)
+
+		mountPoint, err := fixture.DetectMountPoint(ctx, "/var/cache/apt/archives")
+		require.NoError(t, err)
+		assert.Equal(t, "/var", mountPoint)
+
+		mountPoint, err = fixture.DetectMountPoint(ctx, "/variable")
+		require.NoError(t, err)
+		assert.Equal(t, "/", mountPoint, "/variable is not under /var")
+	})
+}
+
+func TestSystemFilesystemWritabilityDetector_Integration(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("DetectWritability on this system", func(t *testing.T) {
+		writability, err := detectors.NewSystemFilesystemWritabilityDetector().DetectWritability(ctx, []string{"/usr", "/var/lib/dpkg"})
+		require.NoError(t, err)
+		t.Logf("Package directories: %s", writability)
+	})
+
+	t.Run("DetectWritability with read-only root", func(t *testing.T) {
+		mountInfo := filepath.Join(t.TempDir(), "mountinfo")
+		content := "22 1 8:2 / / ro,relatime shared:1 - squashfs /dev/sda2 ro\n" +
+			"23 22 8:3 / /var rw,relatime shared:2 - ext4 /dev/sda3 rw\n" +
+			"24 22 0:5 / /tmp rw,nosuid shared:3 - tmpfs tmpfs rw\n"
+		require.NoError(t, os.WriteFile(mountInfo, []byte(content), 0644))
+
+		fixture := detectors.NewSystemFilesystemWritabilityDetectorWithMountInfo(mountInfo)
+
+		writability, err := fixture.DetectWritability(ctx, []string{"/usr", "/var/lib/dpkg"})
+		require.NoError(t, err)
+		require.Len(t, writability.ReadOnlyMounts(), 1)
+		assert.Equal(t, "/usr", writability.ReadOnlyMounts()[0].Path)
+		assert.Equal(t, "squashfs", writability.ReadOnlyMounts()[0].FSType)
+		assert.Equal(t, []string{"/"}, writability.ReadOnlyMountPoints())
+	})
+
+	t.Run("DetectWritability with read-only superblock", func(t *testing.T) {
+		mountInfo := filepath.Join(t.TempDir(), "mountinfo")
+		content := "22 1 8:2 / / rw,relatime shared:1 - ext4 /dev/sda2 ro,errors=remount-ro\n"
+		require.NoError(t, os.WriteFile(mountInfo, []byte(content), 0644))
+
+		fixture := detectors.NewSystemFilesystemWritabilityDetectorWithMountInfo(mountInfo)
+
+		writability, err := fixture.DetectWritability(ctx, []string{"/etc/apt"})
+		require.NoError(t, err)
+		assert.False(t, writability.CanInstallPackages())
+	})
 }
 
 func TestSystemConnectivityChecker_Integration(t *testing.T) {
@@ -170,6 +235,31 @@ func TestSystemSourceRepositoryChecker_Integration(t *testing.T) {
 	})
 }
 
+func TestDetectors_CanceledContext_Integration(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("GPU detector", func(t *testing.T) {
+		_, err := detectors.NewSystemGPUDetector().DetectGPUs(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("power daemon detector", func(t *testing.T) {
+		_, err := detectors.NewSystemPowerDaemonDetector().DetectPowerDaemons(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("session detector", func(t *testing.T) {
+		_, err := detectors.NewSystemSessionDetector().DetectSession(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("connectivity checker", func(t *testing.T) {
+		_, err := detectors.NewSystemConnectivityChecker().CheckInternetConnectivity(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
 func TestAllDetectors_RealWorldScenario_Integration(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()